@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"blocowallet/internal/compliance"
+)
+
+// runApprove answers a compliance approval request with a decision signed by
+// the approver's key, or creates that key. It only reads its arguments, so it
+// runs before the configuration and the database are opened, on the
+// approver's own machine if need be.
+func runApprove(args []string) int {
+	if len(args) > 0 && args[0] == "keygen" {
+		return runApproveKeygen(args[1:])
+	}
+
+	fs := flag.NewFlagSet("approve", flag.ContinueOnError)
+	keyFile := fs.String("key", "", "the approver key created with approve keygen")
+	deny := fs.Bool("deny", false, "deny the request instead of approving it")
+	reason := fs.String("reason", "", "reason recorded with the decision")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bloco-wallet approve --key <file> [options] <id>.request.json")
+		fmt.Fprintln(fs.Output(), "       bloco-wallet approve keygen --name <approver> --out <file>")
+		fmt.Fprintln(fs.Output(), "Writes the signed <id>.approval.json next to the request.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 || *keyFile == "" {
+		fs.Usage()
+		return exitUsage
+	}
+
+	key, err := compliance.LoadApproverKey(*keyFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "approve: %v\n", err)
+		return exitUsage
+	}
+	requestPath := fs.Arg(0)
+	data, err := os.ReadFile(requestPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "approve: %v\n", err)
+		return exitUsage
+	}
+	var req compliance.Request
+	if err := json.Unmarshal(data, &req); err != nil || req.ID == "" {
+		fmt.Fprintf(os.Stderr, "approve: %s is not an approval request\n", requestPath)
+		return exitUsage
+	}
+
+	// What is signed is what the approver sees here
+	fmt.Printf("Request %s\n  action:       %s\n  subject:      %s\n  requested by: %s at %s\n",
+		req.ID, req.Action, req.Subject, req.RequestedBy, req.RequestedAt.Format("2006-01-02 15:04:05 MST"))
+	decision := key.Sign(req, !*deny, *reason)
+	out, err := json.MarshalIndent(decision, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "approve: %v\n", err)
+		return exitTaskFailed
+	}
+	approvalPath := filepath.Join(filepath.Dir(requestPath), strings.TrimSuffix(filepath.Base(requestPath), ".request.json")+".approval.json")
+	if err := os.WriteFile(approvalPath, append(out, '\n'), 0640); err != nil {
+		fmt.Fprintf(os.Stderr, "approve: %v\n", err)
+		return exitTaskFailed
+	}
+	verb := "Approved"
+	if *deny {
+		verb = "Denied"
+	}
+	fmt.Printf("%s as %s: %s\n", verb, key.Approver, approvalPath)
+	return exitOK
+}
+
+// runApproveKeygen creates an approver key and prints the line to add under
+// [compliance.approver_keys]
+func runApproveKeygen(args []string) int {
+	fs := flag.NewFlagSet("approve keygen", flag.ContinueOnError)
+	name := fs.String("name", "", "the approver name, as recorded in the audit log")
+	out := fs.String("out", "", "file to write the private key to; it is never overwritten")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *name == "" || *out == "" || fs.NArg() != 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	key, err := compliance.GenerateApproverKey(*name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "approve keygen: %v\n", err)
+		return exitUsage
+	}
+	if err := key.Save(*out); err != nil {
+		fmt.Fprintf(os.Stderr, "approve keygen: %v\n", err)
+		return exitTaskFailed
+	}
+	fmt.Printf("Private key written to %s; keep it to yourself.\n", *out)
+	fmt.Println("Add this line under [compliance.approver_keys] in the config.toml of the installations you approve for:")
+	fmt.Printf("%s = %q\n", strings.ToLower(key.Approver), key.PublicKey())
+	return exitOK
+}
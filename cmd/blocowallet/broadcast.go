@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+)
+
+// runBroadcast sends a signed raw transaction, given as hex, a file or "-"
+// for stdin, and reports its hash and status. The transaction is added to
+// the tracker, so the interactive manager follows it until final.
+func runBroadcast(cfg *config.Config, ws *wallet.WalletService, args []string) int {
+	fs := flag.NewFlagSet("broadcast", flag.ContinueOnError)
+	networkFlag := fs.String("network", "", "configured network to send to, by key or name (default: the active network of the transaction's chain)")
+	rpc := fs.String("rpc", "", "send through this RPC endpoint instead of a configured network")
+	wait := fs.Duration("wait", 0, "wait up to this long for the transaction to be mined, e.g. 2m")
+	dryRun := fs.Bool("dry-run", false, "decode and print the transaction without sending it")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bloco-wallet broadcast [options] <hex|file|->")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	input, err := readRawTransaction(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "broadcast: reading the transaction: %v\n", err)
+		return exitUsage
+	}
+	raw, err := wallet.DecodeRawTransaction(input)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "broadcast: %v\n", err)
+		return exitUsage
+	}
+	printRawTransaction(raw)
+	if call := describeCall(cfg, raw.Tx.Data()); call != "" {
+		fmt.Printf("Call:          %s\n", call)
+	}
+	if *dryRun {
+		return exitOK
+	}
+
+	network, err := broadcastNetwork(cfg, *networkFlag, *rpc, raw)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "broadcast: %v\n", err)
+		return exitUsage
+	}
+	// An explicit --rpc wins over the sender's own endpoint
+	if *rpc == "" {
+		network = ws.NetworkFor(raw.From.Hex(), network)
+	}
+	client, err := blockchain.NewEthereum(network.RPCEndpoint, blockchain.DefaultTimeout, network.Symbol, 18, network.Name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "broadcast: %v\n", err)
+		return exitTaskFailed
+	}
+	defer client.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := client.Broadcast(ctx, raw.Tx); err != nil {
+		fmt.Fprintf(os.Stderr, "broadcast: %v\n", err)
+		return exitTaskFailed
+	}
+	fmt.Printf("Sent to %s: %s\n", network.Name, raw.Tx.Hash().Hex())
+	if _, err := ws.TrackTransaction(raw, network.Name, "", ""); err != nil {
+		fmt.Fprintf(os.Stderr, "broadcast: the transaction will not be tracked: %v\n", err)
+	}
+
+	var status blockchain.TxStatus
+	if *wait > 0 {
+		status, err = client.WaitForInclusion(ctx, raw.Tx.Hash(), *wait, 2*time.Second)
+	} else {
+		status, err = client.TransactionStatus(ctx, raw.Tx.Hash())
+	}
+	if err != nil {
+		// The transaction was accepted; only the status is unknown
+		fmt.Fprintf(os.Stderr, "broadcast: checking the status: %v\n", err)
+		return exitOK
+	}
+	switch status.State {
+	case blockchain.TxIncluded:
+		fmt.Printf("Status: included in block %d (%d confirmations)\n", status.BlockNumber, status.Confirmations)
+	case blockchain.TxReverted:
+		fmt.Printf("Status: reverted in block %d\n", status.BlockNumber)
+		return exitTaskFailed
+	default:
+		fmt.Printf("Status: %s\n", status.State)
+	}
+	return exitOK
+}
+
+// readRawTransaction returns arg itself when it is hex, or the content of the
+// file it names, "-" being stdin
+func readRawTransaction(arg string) (string, error) {
+	if arg == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		return string(data), err
+	}
+	if _, err := os.Stat(arg); err == nil {
+		data, err := os.ReadFile(arg)
+		return string(data), err
+	}
+	return arg, nil
+}
+
+func printRawTransaction(raw *wallet.RawTransaction) {
+	tx := raw.Tx
+	to := "(contract creation)"
+	if tx.To() != nil {
+		to = tx.To().Hex()
+	}
+	fmt.Printf("From:          %s\n", raw.From.Hex())
+	fmt.Printf("To:            %s\n", to)
+	fmt.Printf("Value:         %s\n", wallet.FormatUnits(tx.Value(), 18))
+	fmt.Printf("Nonce:         %d\n", tx.Nonce())
+	fmt.Printf("Chain ID:      %s\n", tx.ChainId())
+	fmt.Printf("Gas limit:     %d\n", tx.Gas())
+	fmt.Printf("Max fee:       %s gwei\n", wallet.FormatUnits(tx.GasFeeCap(), 9))
+	fmt.Printf("Priority fee:  %s gwei\n", wallet.FormatUnits(tx.GasTipCap(), 9))
+	fmt.Printf("Max cost:      %s\n", wallet.FormatUnits(tx.Cost(), 18))
+	fmt.Printf("Hash:          %s\n", tx.Hash().Hex())
+}
+
+// describeCall renders calldata as a function call with the bundled and
+// learned signatures, asking the 4byte directory when online lookups are on
+func describeCall(cfg *config.Config, data []byte) string {
+	selector, ok := blockchain.SelectorOf(data)
+	if !ok {
+		return ""
+	}
+	path := filepath.Join(cfg.AppDir, blockchain.SignaturesFileName)
+	db := blockchain.NewSignatureDB()
+	_ = db.LoadFile(path)
+	if call, ok := db.Decode(data); ok {
+		return call.String()
+	}
+	if cfg.Decoding.OnlineLookup {
+		ctx, cancel := context.WithTimeout(context.Background(), blockchain.DefaultTimeout)
+		defer cancel()
+		if sigs, err := blockchain.NewSignatureDirectory().Lookup(ctx, selector); err == nil {
+			_ = blockchain.SaveSignatures(path, db.Add(sigs...))
+			if call, ok := db.Decode(data); ok {
+				return call.String()
+			}
+		}
+	}
+	return selector.String() + " (unknown function)"
+}
+
+// broadcastNetwork picks where to send: the --rpc endpoint, the --network
+// named, or the only active network of the transaction's chain
+func broadcastNetwork(cfg *config.Config, name, rpc string, raw *wallet.RawTransaction) (config.Network, error) {
+	if rpc != "" {
+		return config.Network{Name: rpc, RPCEndpoint: rpc}, nil
+	}
+	keys := make([]string, 0, len(cfg.Networks))
+	for key := range cfg.Networks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if name != "" {
+		for _, key := range keys {
+			network := cfg.Networks[key]
+			if strings.EqualFold(key, name) || strings.EqualFold(network.Name, name) {
+				if network.RPCEndpoint == "" {
+					return network, fmt.Errorf("network %q has no RPC endpoint", name)
+				}
+				if network.Quarantine != "" {
+					return network, fmt.Errorf("network %q is quarantined: %s", name, network.Quarantine)
+				}
+				return network, nil
+			}
+		}
+		return config.Network{}, fmt.Errorf("network %q is not configured", name)
+	}
+
+	var matches []config.Network
+	for _, key := range keys {
+		network := cfg.Networks[key]
+		if network.Usable() && network.RPCEndpoint != "" && raw.Tx.ChainId().IsInt64() && network.ChainID == raw.Tx.ChainId().Int64() {
+			matches = append(matches, network)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return config.Network{}, fmt.Errorf("no active network has chain ID %s; use --network or --rpc", raw.Tx.ChainId())
+	case 1:
+		return matches[0], nil
+	default:
+		return config.Network{}, fmt.Errorf("several active networks have chain ID %s; choose one with --network", raw.Tx.ChainId())
+	}
+}
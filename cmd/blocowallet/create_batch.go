@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"blocowallet/internal/wallet"
+)
+
+// runCreateBatch creates a batch of new wallets and writes their names and
+// addresses to a CSV file, or to stdout without --csv
+func runCreateBatch(ws *wallet.WalletService, args []string) int {
+	fs := flag.NewFlagSet("create-batch", flag.ContinueOnError)
+	count := fs.Int("count", 0, fmt.Sprintf("number of wallets to create (1-%d)", wallet.MaxBatchCreateCount))
+	pattern := fs.String("name", "wallet-{:03d}", "name pattern; {} or {:03d} is replaced by the wallet number")
+	first := fs.Int("first", 1, "number of the first wallet")
+	passwordFile := fs.String("password-file", "", `file holding the password shared by all wallets, "-" for stdin`)
+	perWallet := fs.Bool("per-wallet-passwords", false, "generate a random password for each wallet and add it to the CSV")
+	csvPath := fs.String("csv", "", "write the name/address CSV to this file instead of stdout")
+	quiet := fs.Bool("quiet", false, "do not print progress on stderr")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bloco-wallet create-batch --count <n> [options]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	opts := wallet.BatchCreateOptions{Count: *count, NamePattern: *pattern, First: *first, Policy: wallet.PasswordShared}
+	switch {
+	case *perWallet && *passwordFile != "":
+		fmt.Fprintln(os.Stderr, "create-batch: use either --password-file or --per-wallet-passwords")
+		return exitUsage
+	case *perWallet:
+		opts.Policy = wallet.PasswordPerWallet
+	case *passwordFile != "":
+		password, err := readPasswordFile(*passwordFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "create-batch: reading password: %v\n", err)
+			return exitUsage
+		}
+		opts.Password = password
+	default:
+		fmt.Fprintln(os.Stderr, "create-batch: --password-file or --per-wallet-passwords is required")
+		return exitUsage
+	}
+	if err := opts.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "create-batch: %v\n", err)
+		return exitUsage
+	}
+
+	progress := func(done, total int) {
+		if !*quiet {
+			fmt.Fprintf(os.Stderr, "\rcreated %d/%d", done, total)
+		}
+	}
+	created, err := ws.CreateWallets(opts, progress)
+	if !*quiet && len(created) > 0 {
+		fmt.Fprintln(os.Stderr)
+	}
+
+	// The report is written even after a failure: it lists the wallets that exist
+	var writeErr error
+	if *csvPath != "" {
+		writeErr = wallet.WriteBatchCSVFile(*csvPath, created, opts.Policy)
+	} else {
+		writeErr = wallet.WriteBatchCSV(os.Stdout, created, opts.Policy)
+	}
+	if writeErr != nil {
+		fmt.Fprintf(os.Stderr, "create-batch: writing the CSV: %v\n", writeErr)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "create-batch: %v (%d of %d wallets created)\n", err, len(created), opts.Count)
+		return exitTaskFailed
+	}
+	if writeErr != nil {
+		return exitTaskFailed
+	}
+	return exitOK
+}
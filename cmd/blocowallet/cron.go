@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"blocowallet/internal/cron"
+	"blocowallet/internal/notify"
+	"blocowallet/internal/systemd"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+)
+
+// Exit codes of the headless commands
+const (
+	exitOK         = 0
+	exitTaskFailed = 1
+	exitUsage      = 2
+)
+
+func printUsage() {
+	fmt.Println(`Usage:
+  bloco-wallet                start the interactive wallet manager
+  bloco-wallet init [options] create the configuration, database and keystore directory and exit
+  bloco-wallet cron [options] run the scheduled tasks configured in [cron] and exit
+  bloco-wallet list [options] list the wallets, with --json as JSON
+  bloco-wallet create --name <name> --password-file <file> [options]
+                              create a wallet from a new mnemonic
+  bloco-wallet import --name <name> --keystore <file> --password-file <file> [options]
+                              import a keystore, a mnemonic or a private key
+  bloco-wallet export --address <address> --password-file <file> [options]
+                              export a wallet as a KeyStore V3 file
+  bloco-wallet create-batch --count <n> [options]
+                              create n wallets named after a pattern and print a name/address CSV
+  bloco-wallet broadcast [options] <hex|file|->
+                              send a signed raw transaction and report its status
+  bloco-wallet snapshot --sign-with <address> --password-file <file> [options]
+                              write the nonce and balances of every wallet at a block as a signed JSON report
+  bloco-wallet snapshot verify <file>
+                              check the signature of a snapshot report
+  bloco-wallet diagnose [options] <file>
+                              explain why a keystore file cannot be imported
+  bloco-wallet verify [--json] [--repair]
+                              cross-check the database with the keystore directory and repair what disagrees
+  bloco-wallet diff [options] <a> <b>
+                              compare the wallets of two databases or sync documents
+  bloco-wallet approve --key <file> [--deny] <id>.request.json
+                              sign a decision on a compliance approval request
+  bloco-wallet approve keygen --name <approver> --out <file>
+                              create the key an approver signs decisions with
+  bloco-wallet schema [options]
+                              print the configuration, directories and database schema as markdown
+  bloco-wallet migrate [status|up|down] [--to <version>]
+                              show the schema migrations of the database, apply or undo them
+  bloco-wallet locales [--json] [--missing]
+                              report how complete the language packs in <data dir>/config/locales are
+  bloco-wallet --version      print version information
+
+Global options:
+  --data-dir <dir>            use <dir> for configuration, keystores and the database
+  --profile <name>            use the profile <name>, a separate configuration, database and keystores
+                              under the data directory ("default" is the data directory itself)
+  --accessible                plain ASCII output without colours or banners, printed line by line,
+                              for screen readers, serial consoles and CI logs
+
+Secrets can be read from files by adding _FILE to their variable, e.g.
+BLOCO_WALLET_SYNC_PASSPHRASE_FILE=/run/secrets/sync_passphrase.
+
+Run "bloco-wallet <command> -h" for the command options.`)
+}
+
+// runCron executes the scheduled tasks and returns the process exit code
+func runCron(cfg *config.Config, ws *wallet.WalletService, db cron.Database, notifier *notify.Notifier, args []string) int {
+	fs := flag.NewFlagSet("cron", flag.ContinueOnError)
+	tasks := fs.String("tasks", "", "comma-separated tasks to run instead of the configured ones ("+
+		strings.Join([]string{cron.TaskBackup, cron.TaskBalanceSnapshot, cron.TaskIntegrityCheck}, ", ")+")")
+	jsonOut := fs.Bool("json", false, "print the report as JSON on stdout")
+	noReport := fs.Bool("no-report-file", false, "do not write the report to the report directory")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	var names []string
+	for _, name := range strings.Split(*tasks, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Under systemd (Type=notify) report readiness and keep the watchdog fed
+	// while long tasks such as backups run
+	if err := systemd.StartWatchdog(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "cron: %v\n", err)
+	}
+	status := "running configured tasks"
+	if len(names) > 0 {
+		status = "running " + strings.Join(names, ", ")
+	}
+	_, _ = systemd.Notify(systemd.Ready, systemd.Status(status))
+	defer systemd.Notify(systemd.Stopping)
+
+	runner := cron.NewRunner(cfg, ws, db, notifier)
+	report, err := runner.Run(ctx, names)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cron: %v\n", err)
+		return exitUsage
+	}
+
+	if !*noReport {
+		path, err := runner.WriteReport(report)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "cron: failed to write report: %v\n", err)
+			return exitTaskFailed
+		}
+		if !*jsonOut {
+			fmt.Printf("Report written to %s\n", path)
+		}
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintf(os.Stderr, "cron: %v\n", err)
+			return exitTaskFailed
+		}
+	} else {
+		for _, task := range report.Tasks {
+			line := fmt.Sprintf("%-18s %-10s %6dms", task.Name, task.Status, task.DurationMS)
+			if task.Error != "" {
+				line += "  " + task.Error
+			}
+			fmt.Println(line)
+		}
+	}
+
+	if !report.Succeeded {
+		_, _ = systemd.Notify(systemd.Status("finished with failed tasks"))
+		return exitTaskFailed
+	}
+	_, _ = systemd.Notify(systemd.Status("finished"))
+	return exitOK
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"blocowallet/internal/storage"
+	"blocowallet/pkg/config"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// databaseKey returns the key of an encrypted wallet database, nil when it is
+// not encrypted. With the master password it is read from the environment
+// like unlockHeadless does, or asked on the terminal before the TUI starts;
+// the password is returned too, so it can unlock the sealed fields as well.
+func databaseKey(cfg *config.Config) (*storage.DatabaseKey, string, error) {
+	existing := storage.EncryptedDatabaseExists(cfg)
+	switch cfg.Database.Encryption {
+	case "":
+		return nil, "", nil
+	case config.DatabaseEncryptionKeyFile:
+		key, err := storage.LoadKeyFile(storage.KeyFilePath(cfg), !existing)
+		return key, "", err
+	case config.DatabaseEncryptionMasterPassword:
+	default:
+		// Reported by the repository with the accepted values
+		return nil, "", nil
+	}
+
+	password, err := masterPasswordFromEnv()
+	if err != nil {
+		return nil, "", err
+	}
+	if password == "" {
+		if password, err = promptDatabasePassword(!existing); err != nil {
+			return nil, "", err
+		}
+	}
+	return storage.PasswordKey(password), password, nil
+}
+
+// promptDatabasePassword asks for the master password on the terminal, twice
+// when it will encrypt a new database
+func promptDatabasePassword(confirm bool) (string, error) {
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return "", fmt.Errorf("%w; set BLOCO_WALLET_MASTER_PASSWORD or BLOCO_WALLET_MASTER_PASSWORD_FILE", storage.ErrDatabaseKeyRequired)
+	}
+	read := func(prompt string) (string, error) {
+		fmt.Fprint(os.Stderr, prompt)
+		password, err := term.ReadPassword(os.Stdin.Fd())
+		fmt.Fprintln(os.Stderr)
+		return string(password), err
+	}
+	password, err := read("Master password for the wallet database: ")
+	if err != nil {
+		return "", err
+	}
+	if password == "" {
+		return "", errors.New("the master password is empty")
+	}
+	if confirm {
+		again, err := read("Repeat it to encrypt the new database: ")
+		if err != nil {
+			return "", err
+		}
+		if again != password {
+			return "", errors.New("the passwords do not match")
+		}
+	}
+	return password, nil
+}
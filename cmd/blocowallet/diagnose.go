@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"blocowallet/internal/wallet"
+)
+
+// runDiagnose explains why a keystore file cannot be imported. It only reads
+// the file, so it runs before the configuration and the database are opened.
+func runDiagnose(args []string) int {
+	fs := flag.NewFlagSet("diagnose", flag.ContinueOnError)
+	passwordFile := fs.String("password-file", "", `file holding the keystore password, "-" for stdin; without it the MAC is not checked`)
+	jsonOut := fs.Bool("json", false, "print the report as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bloco-wallet diagnose [options] <keystore.json>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	password := ""
+	if *passwordFile != "" {
+		var err error
+		if password, err = readPasswordFile(*passwordFile); err != nil {
+			fmt.Fprintf(os.Stderr, "diagnose: reading password: %v\n", err)
+			return exitUsage
+		}
+	}
+
+	report, err := wallet.NewEnhancedKeyStoreService().DiagnoseKeystore(fs.Arg(0), password)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "diagnose: %v\n", err)
+		return exitUsage
+	}
+	if *jsonOut {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "diagnose: %v\n", err)
+			return exitTaskFailed
+		}
+		fmt.Println(string(data))
+	} else {
+		fmt.Print(report.String())
+	}
+
+	switch report.Verdict {
+	case wallet.VerdictCompatible, wallet.VerdictNeedsPassword:
+		return exitOK
+	default:
+		return exitTaskFailed
+	}
+}
+
+// readPasswordFile reads a password from a file, or from stdin for "-",
+// without its trailing newline
+func readPasswordFile(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"blocowallet/internal/metasync"
+	"blocowallet/internal/storage"
+)
+
+// sqliteHeader starts every SQLite database file
+var sqliteHeader = []byte("SQLite format 3\x00")
+
+// runDiff compares the wallets of two installations, each given as a wallet
+// database or a sync document. Like diagnose it only reads its arguments, so
+// it runs before the configuration and the database are opened.
+func runDiff(args []string) int {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	passphraseFile := fs.String("passphrase-file", "", `file holding the sync passphrase of encrypted documents, "-" for stdin; defaults to BLOCO_WALLET_SYNC_PASSPHRASE`)
+	jsonOut := fs.Bool("json", false, "print the comparison as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bloco-wallet diff [options] <a> <b>")
+		fmt.Fprintln(fs.Output(), "Each side is a wallet database (bloco.db) or a sync document, encrypted or plain.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	passphrase := os.Getenv("BLOCO_WALLET_SYNC_PASSPHRASE")
+	if *passphraseFile != "" {
+		var err error
+		if passphrase, err = readPasswordFile(*passphraseFile); err != nil {
+			fmt.Fprintf(os.Stderr, "diff: reading passphrase: %v\n", err)
+			return exitUsage
+		}
+	}
+
+	a, err := readInventory(fs.Arg(0), passphrase)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "diff: %v\n", err)
+		return exitUsage
+	}
+	b, err := readInventory(fs.Arg(1), passphrase)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "diff: %v\n", err)
+		return exitUsage
+	}
+
+	comparison := metasync.Compare(a, b)
+	if *jsonOut {
+		data, err := json.MarshalIndent(comparison, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "diff: %v\n", err)
+			return exitTaskFailed
+		}
+		fmt.Println(string(data))
+	} else {
+		comparison.WriteText(os.Stdout, fs.Arg(0), fs.Arg(1))
+	}
+	if comparison.Equal() {
+		return exitOK
+	}
+	return exitTaskFailed
+}
+
+// readInventory reads the wallets of a database, with the keystores found
+// next to it, or the inventory of a sync document
+func readInventory(path, passphrase string) (metasync.Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return metasync.Snapshot{}, err
+	}
+	header := make([]byte, len(sqliteHeader))
+	_, err = io.ReadFull(f, header)
+	f.Close()
+	if err != nil || !bytes.Equal(header, sqliteHeader) {
+		return metasync.ReadSnapshot(path, passphrase)
+	}
+
+	wallets, err := storage.ReadWallets(path)
+	if err != nil {
+		return metasync.Snapshot{}, err
+	}
+	// The default layout keeps the keystores under the directory of the database
+	dir := filepath.Dir(path)
+	return metasync.SnapshotOfWallets(wallets, filepath.Join(dir, "keystore", "keystore"), filepath.Join(dir, "keystore")), nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"blocowallet/internal/sandbox"
+	"blocowallet/internal/storage"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/logger"
+)
+
+// harden moves the process into the sandbox when hardening is enabled. The
+// process is started again inside it, so this must run before anything
+// worth keeping is opened. Systems without a sandbox only get a warning.
+func harden(cfg *config.Config, lgr logger.Logger) {
+	if !cfg.Hardening.Enabled {
+		return
+	}
+	err := sandbox.Enter(hardeningPolicy(cfg))
+	switch {
+	case errors.Is(err, sandbox.ErrUnsupported):
+		lgr.Warn("Process hardening unavailable", logger.Error(err))
+	case err != nil:
+		log.Printf("Failed to harden the process: %v", err)
+		os.Exit(1)
+	default:
+		lgr.Info("Process hardening active", logger.Any("offline", cfg.Hardening.Offline))
+	}
+}
+
+// hardeningPolicy keeps the directories holding the wallets writable, the
+// metrics port open and, unless offline, the ports of the proxy and the
+// network endpoints reachable
+func hardeningPolicy(cfg *config.Config) sandbox.Policy {
+	policy := sandbox.Policy{
+		Dirs:    []string{cfg.AppDir, cfg.WalletsDir, filepath.Dir(cfg.DatabasePath)},
+		Offline: cfg.Hardening.Offline,
+	}
+	if cfg.LocaleDir != "" {
+		policy.ReadOnly = append(policy.ReadOnly, cfg.LocaleDir)
+	}
+	// The database is opened after entering the sandbox
+	if cfg.Database.Encryption == config.DatabaseEncryptionKeyFile {
+		policy.ReadOnly = append(policy.ReadOnly, storage.KeyFilePath(cfg))
+	}
+	// The metrics endpoint only listens on loopback, so it stays up offline
+	if cfg.Metrics.Enabled {
+		if _, port, err := net.SplitHostPort(cfg.Metrics.Listen); err == nil {
+			if n, err := strconv.Atoi(port); err == nil && n > 0 {
+				policy.Listen = append(policy.Listen, n)
+			}
+		}
+	}
+	if policy.Offline {
+		return policy
+	}
+	// The database server, when the wallets are not kept in SQLite; it is
+	// not reached through the proxy
+	if port := storage.ServerPort(cfg.Database); port > 0 {
+		policy.Ports = append(policy.Ports, port)
+	}
+
+	proxy := cfg.Proxy
+	if proxy.URL != "" {
+		policy.Ports = append(policy.Ports, endpointPort(proxy.URL))
+		if proxy.RPC && proxy.ChainList && proxy.Signatures && proxy.WalletConnect {
+			return policy
+		}
+	}
+	// chainlist.org and the 4byte directory, the WalletConnect relay, then
+	// each network's endpoint
+	policy.Ports = append(policy.Ports, 443, 80)
+	if port := endpointPort(cfg.WalletConnect.RelayURL); port > 0 {
+		policy.Ports = append(policy.Ports, port)
+	}
+	for _, network := range cfg.Networks {
+		if port := endpointPort(network.RPCEndpoint); port > 0 {
+			policy.Ports = append(policy.Ports, port)
+		}
+	}
+	slices.Sort(policy.Ports)
+	policy.Ports = slices.Compact(policy.Ports)
+	return policy
+}
+
+// endpointPort is the TCP port an endpoint URL connects to; 0 when unknown
+func endpointPort(raw string) int {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return 0
+	}
+	if port, err := strconv.Atoi(u.Port()); err == nil {
+		return port
+	}
+	switch u.Scheme {
+	case "https", "wss":
+		return 443
+	case "http", "ws":
+		return 80
+	case "socks5", "socks5h":
+		return 1080
+	}
+	return 0
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"blocowallet/internal/platform"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+)
+
+// extractDataDir removes the global --data-dir option from args. The directory
+// replaces the application directory for configuration, keystores and the
+// database, which is what a container volume usually holds.
+func extractDataDir(args []string) (rest []string, dataDir string, err error) {
+	return extractGlobalOption(args, "data-dir", "a directory")
+}
+
+// extractProfile removes the global --profile option from args. The profile
+// is a separate application directory under the one in use.
+func extractProfile(args []string) (rest []string, profile string, err error) {
+	return extractGlobalOption(args, "profile", "a name")
+}
+
+// extractAccessible removes the global --accessible flag from args. It turns
+// on the accessible mode of the TUI for one run.
+func extractAccessible(args []string) (rest []string, accessible bool) {
+	for _, arg := range args {
+		if arg == "--accessible" || arg == "-accessible" {
+			accessible = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, accessible
+}
+
+// extractGlobalOption removes --<name> <value> or --<name>=<value> from args,
+// wherever it appears, and returns its value
+func extractGlobalOption(args []string, name, what string) (rest []string, value string, err error) {
+	missing := fmt.Errorf("--%s requires %s", name, what)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--"+name || arg == "-"+name:
+			if i+1 >= len(args) || args[i+1] == "" {
+				return nil, "", missing
+			}
+			value = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--"+name+"=") || strings.HasPrefix(arg, "-"+name+"="):
+			value = arg[strings.Index(arg, "=")+1:]
+			if value == "" {
+				return nil, "", missing
+			}
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return rest, value, nil
+}
+
+// runInit prepares the data directory without the TUI, so images and
+// provisioning scripts can create it before the first "cron" run. By the time
+// it runs, the configuration, database and keystore directory already exist.
+func runInit(configManager *config.ConfigurationManager, cfg *config.Config, keystoreDir string, args []string) int {
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	language := fs.String("language", "", "interface language to store in the configuration (en, pt, es or a language pack)")
+	quiet := fs.Bool("quiet", false, "do not print the created paths")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	if *language != "" && *language != cfg.Language {
+		if !slices.Contains(localization.GetAvailableLanguages(cfg.LocaleDir), *language) {
+			fmt.Fprintf(os.Stderr, "init: unsupported language %q\n", *language)
+			return exitUsage
+		}
+		cfg.Language = *language
+		if err := configManager.SaveConfiguration(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "init: %v\n", err)
+			return exitTaskFailed
+		}
+	}
+
+	// Keystores and the database must only be readable by the wallet user,
+	// whatever the umask of the volume
+	var problems []string
+	for _, path := range []string{keystoreDir, cfg.DatabasePath} {
+		if err := platform.MakePrivate(path); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if !*quiet {
+		fmt.Printf("Configuration: %s\n", configManager.GetConfigPath())
+		fmt.Printf("Database:      %s\n", cfg.DatabasePath)
+		fmt.Printf("Keystores:     %s\n", keystoreDir)
+	}
+	for _, problem := range problems {
+		fmt.Fprintf(os.Stderr, "init: %s\n", problem)
+	}
+	if len(problems) > 0 {
+		return exitTaskFailed
+	}
+	return exitOK
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"blocowallet/internal/integrity"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/logger"
+)
+
+// checkIntegrity verifies the running binary and, when enabled, the data
+// compiled into it, returning what was found modified since the build
+func checkIntegrity(cfg *config.Config, lgr logger.Logger) []integrity.Issue {
+	var issues []integrity.Issue
+	if cfg.Integrity.CheckBinary {
+		status, found, err := integrity.CheckExecutable()
+		switch {
+		case err != nil:
+			lgr.Warn("Binary integrity could not be checked", logger.Error(err))
+		case status == integrity.Unstamped:
+			lgr.Info("Binary is not stamped; integrity not checked")
+		case status == integrity.Verified:
+			lgr.Info("Binary integrity verified")
+		}
+		issues = append(issues, found...)
+	}
+	if cfg.Integrity.CheckAssets {
+		found, err := integrity.CheckAssets()
+		if err != nil {
+			lgr.Warn("Asset integrity could not be checked", logger.Error(err))
+		}
+		issues = append(issues, found...)
+	}
+	for _, issue := range issues {
+		lgr.Error("Integrity check failed", logger.String("subject", issue.Subject), logger.String("reason", issue.Reason))
+	}
+	return issues
+}
+
+// warnIntegrity tells headless commands what was modified since the build
+func warnIntegrity(issues []integrity.Issue) {
+	for _, issue := range issues {
+		fmt.Fprintf(os.Stderr, "warning: %s was modified since the build: %s\n", issue.Subject, issue.Reason)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+)
+
+// runLocales reports how complete the language packs of the locales directory
+// are. Keys a pack leaves out are shown in English, so a partial pack works;
+// the report tells translators what is left.
+func runLocales(cfg *config.Config, args []string) int {
+	fs := flag.NewFlagSet("locales", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print the report as JSON")
+	missing := fs.Bool("missing", false, "list the keys each pack does not translate")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bloco-wallet locales [options]")
+		fmt.Fprintln(fs.Output(), "Exits with status 1 when a language pack cannot be loaded.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	reports := localization.LanguagePackReports()
+	code := exitOK
+	for _, r := range reports {
+		if r.Err != nil {
+			fmt.Fprintf(os.Stderr, "locales: %s: %v\n", r.Path, r.Err)
+			code = exitTaskFailed
+		}
+	}
+	if *jsonOut {
+		loaded := make([]localization.PackReport, 0, len(reports))
+		for _, r := range reports {
+			if r.Err == nil {
+				loaded = append(loaded, r)
+			}
+		}
+		if c := printJSON("locales", loaded); c != exitOK {
+			return c
+		}
+		return code
+	}
+
+	dir := localization.LanguagePacksDir(cfg.AppDir)
+	if len(reports) == 0 {
+		fmt.Printf("No language packs in %s\n", dir)
+		return code
+	}
+	fmt.Printf("Language packs in %s\n\n", dir)
+	for _, r := range reports {
+		if r.Err != nil {
+			continue
+		}
+		fmt.Printf("%-8s %-12s %3d%%  %d of %d messages\n", r.Language, r.Name, r.Percent(), r.Translated, r.Total)
+		if len(r.Unknown) > 0 {
+			fmt.Printf("         unknown keys: %s\n", strings.Join(r.Unknown, ", "))
+		}
+		if *missing {
+			for _, key := range r.Missing {
+				fmt.Printf("         missing: %s\n", key)
+			}
+		}
+	}
+	return code
+}
@@ -7,6 +7,14 @@ import (
 	"os"
 	"path/filepath"
 
+	"blocowallet/internal/audit"
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/buildinfo"
+	"blocowallet/internal/changelog"
+	"blocowallet/internal/compliance"
+	"blocowallet/internal/metasync"
+	"blocowallet/internal/notify"
+	"blocowallet/internal/platform"
 	"blocowallet/internal/storage"
 	"blocowallet/internal/ui"
 	"blocowallet/internal/wallet"
@@ -26,13 +34,73 @@ var (
 )
 
 func main() {
+	args, dataDir, err := extractDataDir(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitUsage)
+	}
+	if dataDir != "" {
+		// Same effect as BLOCO_WALLET_APP_APP_DIR, which the configuration already honours
+		if err := os.Setenv("BLOCO_WALLET_APP_APP_DIR", dataDir); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(exitUsage)
+		}
+	}
+	args, profile, err := extractProfile(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitUsage)
+	}
+	if profile != "" {
+		if err := config.ValidateProfileName(profile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(exitUsage)
+		}
+		// The configuration reads the profile from the environment, as the data directory
+		if err := os.Setenv(config.ProfileEnv, profile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(exitUsage)
+		}
+	}
+	args, accessible := extractAccessible(args)
+	if accessible {
+		// Read by the configuration as app.accessible
+		if err := os.Setenv("BLOCO_WALLET_APP_ACCESSIBLE", "true"); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(exitUsage)
+		}
+	}
+	command := ""
+	if len(args) > 0 {
+		command = args[0]
+	}
+
 	// Print version information if requested
-	if len(os.Args) > 1 && (os.Args[1] == "--version" || os.Args[1] == "-v") {
-		fmt.Printf("bloco-wallet-manager version %s\n", version)
-		fmt.Printf("Git commit: %s\n", commit)
-		fmt.Printf("Build date: %s\n", date)
+	if command == "--version" || command == "-v" {
+		info := buildinfo.New(version, commit, date)
+		fmt.Printf("bloco-wallet-manager version %s\n", info.Version)
+		fmt.Printf("Git commit: %s\n", info.Commit)
+		fmt.Printf("Build date: %s\n", info.Date)
+		fmt.Printf("Go: %s %s/%s\n", info.GoVersion, info.OS, info.Arch)
+		return
+	}
+	if command == "--help" || command == "-h" {
+		printUsage()
 		return
 	}
+	if command == "diagnose" {
+		os.Exit(runDiagnose(args[1:]))
+	}
+	if command == "diff" {
+		os.Exit(runDiff(args[1:]))
+	}
+	if command == "approve" {
+		os.Exit(runApprove(args[1:]))
+	}
+	if command == "snapshot" && len(args) > 1 && args[1] == "verify" {
+		// Checking a report needs neither the configuration nor the wallets
+		os.Exit(runSnapshotVerify(args[2:]))
+	}
 
 	// Disable standard logger output to avoid terminal logs
 	log.SetOutput(io.Discard)
@@ -44,26 +112,18 @@ func main() {
 		log.Printf("Failed to load configuration: %v", err)
 		os.Exit(1)
 	}
+	if command == "schema" {
+		os.Exit(runSchema(cfg, args[1:]))
+	}
 
 	// Initialize file-based logger (no terminal output)
-	logDir := filepath.Join(cfg.AppDir, "logs")
-	lgr, err := logger.NewFileLogger(logger.LoggingConfig{
-		LogDir:      logDir,
-		LogLevel:    "info",
-		MaxFileSize: 25,
-		MaxBackups:  3,
-		MaxAge:      14,
-	})
-	if err != nil {
-		// Fall back silently; continue without crashing per requirements
-		lgr = nil
-	}
+	lgr := openFileLogger(cfg)
 	// Provide UI package with file-based logger for debug-only input logs
 	ui.SetLogger(lgr)
 	wallet.SetLogger(lgr)
 	defer func() {
 		if lgr != nil {
-			_ = lgr.Sync()
+			_ = lgr.Close()
 		}
 	}()
 
@@ -72,15 +132,49 @@ func main() {
 		log.Printf("Failed to initialize localization: %v", err)
 		os.Exit(1)
 	}
+	for _, r := range localization.LanguagePackReports() {
+		if r.Err != nil {
+			lgr.Warn("Language pack not loaded", logger.String("path", r.Path), logger.Error(r.Err))
+			continue
+		}
+		lgr.Info("Language pack loaded", logger.String("language", r.Language),
+			logger.Int("translated", r.Translated), logger.Int("missing", len(r.Missing)))
+	}
+	if command == "locales" {
+		os.Exit(runLocales(cfg, args[1:]))
+	}
+
+	// Route chainlist and RPC traffic through the SOCKS5 proxy, if any; an
+	// unusable setting must not silently fall back to direct connections
+	if err := blockchain.SetProxy(cfg.Proxy); err != nil {
+		log.Printf("Failed to configure the proxy: %v", err)
+		os.Exit(1)
+	}
+
+	// Optional Linux sandbox: the process starts again inside it, before the
+	// database and keystores are opened
+	harden(cfg, lgr)
+
+	// Warn when the binary or the data compiled into it changed since the build
+	integrityIssues := checkIntegrity(cfg, lgr)
 
 	// Initialize crypto service
 	wallet.InitCryptoService(cfg)
 	lgr.Info("Crypto service initialized")
 
-	// Create wallet repository
-	repo, err := storage.NewWalletRepository(cfg)
+	// Create wallet repository, with the key of an encrypted database
+	dbKey, dbPassword, err := databaseKey(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open the wallet database: %v\n", err)
+		os.Exit(1)
+	}
+	if command == "migrate" {
+		os.Exit(runMigrate(cfg, dbKey, args[1:]))
+	}
+	repo, err := storage.NewEncryptedWalletRepository(cfg, dbKey)
 	if err != nil {
 		log.Printf("Failed to create wallet repository: %v", err)
+		fmt.Fprintf(os.Stderr, "Failed to open the wallet database: %v\n", err)
 		os.Exit(1)
 	}
 	defer func() {
@@ -91,7 +185,7 @@ func main() {
 
 	// Create keystore
 	keystoreDir := filepath.Join(cfg.WalletsDir, "keystore")
-	if err := os.MkdirAll(keystoreDir, 0755); err != nil {
+	if err := os.MkdirAll(keystoreDir, 0700); err != nil {
 		log.Printf("Failed to create keystore directory: %v", err)
 		os.Exit(1)
 	}
@@ -100,15 +194,182 @@ func main() {
 
 	// Initialize wallet service
 	walletService := wallet.NewWalletService(repo, ks)
+	walletService.ApplyConfig(cfg)
+	walletService.KeystoreDir = keystoreDir
 	lgr.Info("Wallet service initialized")
 
-	// Initialize and start the TUI application
+	// The password that opened the database also unlocks the sealed fields
+	// when it is the same master password
+	if dbPassword != "" {
+		if state, err := walletService.MasterPasswordState(); err == nil && state == wallet.MasterPasswordLocked {
+			_ = walletService.UnlockMasterPassword(dbPassword)
+		}
+	}
+
+	// Webhook notifications for finished imports, backups and failed health checks
+	notifier, err := notify.New(cfg.Notifications)
+	if err != nil {
+		lgr.Warn("Webhook notifications disabled", logger.Error(err))
+	}
+
+	// Strict RPC mode re-verifies the network endpoints before anything connects to them
+	var quarantined []blockchain.EndpointCheck
+	if command != "init" {
+		quarantined = verifyNetworks(configManager, cfg, lgr)
+	}
+
+	// In compliance mode secret exports and deletions need a second approver
+	var gate *compliance.Gate
+	var auditLog *audit.Logger
+	if cfg.Compliance.Enabled {
+		auditLog, err = audit.Open(filepath.Join(cfg.AppDir, audit.FileName))
+		if err != nil {
+			log.Printf("Failed to open audit log: %v", err)
+			os.Exit(1)
+		}
+		gate, err = compliance.NewGate(cfg, auditLog)
+		if err != nil {
+			log.Printf("Failed to enable compliance mode: %v", err)
+			os.Exit(1)
+		}
+		lgr.Info("Compliance mode enabled", logger.String("audit_log", auditLog.Path()))
+	}
+
+	// Headless commands run instead of the TUI; those reading wallets need
+	// the master password first, when one is set
+	switch command {
+	case "create-batch", "list", "import", "create", "export", "broadcast", "snapshot", "cron":
+		if code := unlockHeadless(command, walletService); code != exitOK {
+			_ = repo.Close()
+			os.Exit(code)
+		}
+	}
+	if command == "init" {
+		warnIntegrity(integrityIssues)
+		code := runInit(configManager, cfg, keystoreDir, args[1:])
+		_ = repo.Close()
+		os.Exit(code)
+	}
+	if command == "create-batch" {
+		warnIntegrity(integrityIssues)
+		code := runCreateBatch(walletService, args[1:])
+		_ = repo.Close()
+		os.Exit(code)
+	}
+	if command == "list" || command == "import" || command == "create" || command == "export" {
+		warnIntegrity(integrityIssues)
+		var code int
+		switch command {
+		case "list":
+			code = runList(walletService, args[1:])
+		case "import":
+			code = runImport(walletService, args[1:])
+		case "create":
+			code = runCreate(walletService, args[1:])
+		default:
+			code = runExport(walletService, gate, args[1:])
+		}
+		_ = repo.Close()
+		os.Exit(code)
+	}
+	if command == "verify" {
+		// Only --repair needs the master password; runVerify asks for it
+		code := runVerify(walletService, gate, args[1:])
+		_ = repo.Close()
+		os.Exit(code)
+	}
+	if command == "broadcast" {
+		warnQuarantined(quarantined)
+		warnIntegrity(integrityIssues)
+		code := runBroadcast(cfg, walletService, args[1:])
+		_ = repo.Close()
+		os.Exit(code)
+	}
+	if command == "snapshot" {
+		warnQuarantined(quarantined)
+		warnIntegrity(integrityIssues)
+		code := runSnapshot(cfg, walletService, repo, args[1:])
+		_ = repo.Close()
+		os.Exit(code)
+	}
+	// Prometheus metrics for the modes that keep running, when enabled
+	stopMetrics := startMetrics(cfg, lgr)
+	if command == "cron" {
+		warnQuarantined(quarantined)
+		warnIntegrity(integrityIssues)
+		code := runCron(cfg, walletService, repo, notifier, args[1:])
+		stopMetrics()
+		_ = repo.Close()
+		os.Exit(code)
+	}
+
+	// Initialize and start the TUI application; the accessible mode must be
+	// set before the model builds its styles
+	ui.SetAccessible(cfg.Accessible)
 	app := ui.NewCLIModel(walletService)
+	info := buildinfo.New(version, commit, date)
+	app.SetBuildInfo(info)
+	// Show what changed when this version runs for the first time
+	releaseNotes, err := changelog.Upgrade(cfg.AppDir, info.Version)
+	if err != nil {
+		lgr.Warn("Failed to check the last run version", logger.Error(err))
+	}
+	app.SetReleaseNotes(releaseNotes)
+	// Keystores and the database must only be readable by the current user
+	app.SetProtectedPaths(keystoreDir, cfg.DatabasePath, cfg.DatabasePath+"-wal", cfg.DatabasePath+"-shm")
+
+	// Optional encrypted sync of wallet names and networks
+	syncer, err := metasync.New(cfg)
+	if err != nil {
+		lgr.Warn("Metadata sync disabled", logger.Error(err))
+	}
+	app.SetSyncer(syncer)
+
+	app.SetNotifier(notifier)
+	app.SetQuarantinedNetworks(quarantined)
+	app.SetIntegrityIssues(integrityIssues)
+
+	app.SetComplianceGate(gate)
+	// Other profiles open without restarting; the deferred Close then
+	// releases the repository and the logs of the last one
+	switcher := &profileSwitcher{repo: &repo, lgr: &lgr, auditLog: auditLog, cfg: cfg}
+	app.SetProfileOpener(switcher.open)
 	p := tea.NewProgram(app, tea.WithAltScreen())
+	if cfg.Accessible {
+		// Screens are printed line by line to the normal buffer, where screen
+		// readers and logs can follow them
+		p = tea.NewProgram(ui.NewPlainModel(app))
+	}
+
+	// Enable ANSI sequences and UTF-8 output on Windows consoles
+	restoreConsole, err := platform.PrepareConsole()
+	if err != nil {
+		lgr.Warn("Failed to prepare console", logger.Error(err))
+	}
 
 	lgr.Info("Starting application")
-	if _, err := p.Run(); err != nil {
+	_, err = p.Run()
+	restoreConsole()
+	stopMetrics()
+	if err != nil {
 		log.Printf("Application error: %v", err)
 		os.Exit(1)
 	}
 }
+
+// openFileLogger opens the rotating logs in the logs directory of cfg's
+// application directory; nil when they cannot be opened
+func openFileLogger(cfg *config.Config) logger.Logger {
+	lgr, err := logger.NewFileLogger(logger.LoggingConfig{
+		LogDir:      filepath.Join(cfg.AppDir, "logs"),
+		LogLevel:    cfg.Logging.Level,
+		MaxFileSize: cfg.Logging.MaxSizeMB,
+		MaxBackups:  cfg.Logging.MaxBackups,
+		MaxAge:      cfg.Logging.MaxAgeDays,
+	})
+	if err != nil {
+		// Fall back silently; continue without crashing per requirements
+		return nil
+	}
+	return lgr
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"blocowallet/internal/wallet"
+)
+
+// unlockHeadless enters the master password for the headless commands, which
+// cannot show the unlock screen. It is read from BLOCO_WALLET_MASTER_PASSWORD
+// or the file named by BLOCO_WALLET_MASTER_PASSWORD_FILE.
+func unlockHeadless(command string, ws *wallet.WalletService) int {
+	state, err := ws.MasterPasswordState()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", command, err)
+		return exitTaskFailed
+	}
+	if state != wallet.MasterPasswordLocked {
+		return exitOK
+	}
+
+	password, err := masterPasswordFromEnv()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", command, err)
+		return exitUsage
+	}
+	if password == "" {
+		fmt.Fprintf(os.Stderr, "%s: %v; set BLOCO_WALLET_MASTER_PASSWORD or BLOCO_WALLET_MASTER_PASSWORD_FILE\n", command, wallet.ErrMasterPasswordLocked)
+		return exitUsage
+	}
+	if err := ws.UnlockMasterPassword(password); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", command, err)
+		if errors.Is(err, wallet.ErrMasterPassword) {
+			return exitUsage
+		}
+		return exitTaskFailed
+	}
+	return exitOK
+}
+
+// masterPasswordFromEnv reads the master password from
+// BLOCO_WALLET_MASTER_PASSWORD or the file named by
+// BLOCO_WALLET_MASTER_PASSWORD_FILE; "" when neither is set
+func masterPasswordFromEnv() (string, error) {
+	password := os.Getenv("BLOCO_WALLET_MASTER_PASSWORD")
+	path := os.Getenv("BLOCO_WALLET_MASTER_PASSWORD_FILE")
+	if path == "" {
+		return password, nil
+	}
+	if password != "" {
+		return "", errors.New("both BLOCO_WALLET_MASTER_PASSWORD and BLOCO_WALLET_MASTER_PASSWORD_FILE are set")
+	}
+	password, err := readPasswordFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading BLOCO_WALLET_MASTER_PASSWORD_FILE: %w", err)
+	}
+	return password, nil
+}
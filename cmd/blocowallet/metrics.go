@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"blocowallet/internal/metrics"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/logger"
+)
+
+// startMetrics serves the metrics while the TUI or cron runs, when enabled.
+// The returned function stops the endpoint. A failure only disables it: the
+// wallets stay usable without monitoring.
+func startMetrics(cfg *config.Config, lgr logger.Logger) func() {
+	if !cfg.Metrics.Enabled {
+		return func() {}
+	}
+	server, err := metrics.Serve(cfg.Metrics.Listen)
+	if err != nil {
+		lgr.Warn("Metrics endpoint disabled", logger.Error(err))
+		return func() {}
+	}
+	lgr.Info("Serving metrics", logger.String("url", "http://"+cfg.Metrics.Listen+"/metrics"))
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = server.Shutdown(ctx)
+	}
+}
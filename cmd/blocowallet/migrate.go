@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"blocowallet/internal/storage"
+	"blocowallet/pkg/config"
+)
+
+// runMigrate shows or changes the schema version of the wallet database. The
+// database is opened without the automatic migration of a normal start, so
+// pending migrations are listed as such. Going down undoes the migrations of
+// this release, letting an older one open the database again; the next start
+// of this release migrates it back up.
+func runMigrate(cfg *config.Config, key *storage.DatabaseKey, args []string) int {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	to := fs.Int("to", -1, "schema version to migrate to (default: the latest for up, the previous one for down)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bloco-wallet migrate [status|up|down] [options]")
+		fs.PrintDefaults()
+	}
+	action := "status"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		action, args = args[0], args[1:]
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 0 || (action != "status" && action != "up" && action != "down") {
+		fs.Usage()
+		return exitUsage
+	}
+
+	repo, err := storage.OpenWalletRepository(cfg, key)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "migrate: %v\n", err)
+		return exitTaskFailed
+	}
+	defer repo.Close()
+	current, err := repo.SchemaVersion()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "migrate: %v\n", err)
+		return exitTaskFailed
+	}
+
+	target := *to
+	switch action {
+	case "up":
+		if target < 0 {
+			target = storage.LatestSchemaVersion()
+		}
+		if target < current {
+			fmt.Fprintf(os.Stderr, "migrate: version %d is below the current %d; use down\n", target, current)
+			return exitUsage
+		}
+	case "down":
+		if target < 0 {
+			target = current - 1
+		}
+		if target > current || target < 0 {
+			fmt.Fprintf(os.Stderr, "migrate: version %d is not below the current %d\n", target, current)
+			return exitUsage
+		}
+	}
+	if action != "status" {
+		if err := repo.MigrateTo(target); err != nil {
+			fmt.Fprintf(os.Stderr, "migrate: %v\n", err)
+			return exitTaskFailed
+		}
+		fmt.Printf("Schema version %d -> %d\n", current, target)
+		current = target
+	}
+
+	status, err := repo.MigrationStatus()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "migrate: %v\n", err)
+		return exitTaskFailed
+	}
+	fmt.Printf("Database schema: version %d, this release knows up to %d\n", current, storage.LatestSchemaVersion())
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tNAME\tAPPLIED\tREVERSIBLE")
+	for _, s := range status {
+		applied := "pending"
+		if s.AppliedAt != nil {
+			applied = s.AppliedAt.Local().Format(time.DateTime)
+		}
+		reversible := "no"
+		if s.Reversible {
+			reversible = "yes"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", s.Version, s.Name, applied, reversible)
+	}
+	_ = tw.Flush()
+	return exitOK
+}
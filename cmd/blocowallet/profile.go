@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"blocowallet/internal/audit"
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/compliance"
+	"blocowallet/internal/metasync"
+	"blocowallet/internal/notify"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/ui"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/logger"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+// profileSwitcher opens other profiles for the TUI. It owns the wallet
+// repository and the logs of the profile in use, which main closes on exit.
+type profileSwitcher struct {
+	repo     **storage.GORMRepository
+	lgr      *logger.Logger
+	auditLog *audit.Logger
+	// cfg is the configuration of the profile in use, whose Argon2 settings
+	// the crypto service is set back to when another profile fails to open
+	cfg *config.Config
+}
+
+// open builds the services of the profile name, as main does on startup, and
+// closes the audit log, the repository and the logs of the previous profile
+// once they are ready. The database key cannot be asked for on the terminal
+// while the TUI runs, so a database encrypted with the master password needs
+// it in the environment.
+func (s *profileSwitcher) open(name string) (*ui.ProfileSession, error) {
+	if err := config.ValidateProfileName(name); err != nil {
+		return nil, err
+	}
+	previous, hadPrevious := os.LookupEnv(config.ProfileEnv)
+	if err := os.Setenv(config.ProfileEnv, name); err != nil {
+		return nil, err
+	}
+	session, repo, auditLog, err := s.openProfile(name)
+	if err != nil {
+		if hadPrevious {
+			_ = os.Setenv(config.ProfileEnv, previous)
+		} else {
+			_ = os.Unsetenv(config.ProfileEnv)
+		}
+		// The wallet service of the failed profile may have set its Argon2
+		// settings already
+		wallet.InitCryptoService(s.cfg)
+		return nil, err
+	}
+
+	if s.auditLog != nil {
+		_ = s.auditLog.Close()
+	}
+	s.auditLog = auditLog
+	if err := (*s.repo).Close(); err != nil {
+		log.Printf("Error closing repository: %v", err)
+	}
+	*s.repo = repo
+	s.cfg = session.Config
+	wallet.InitCryptoService(session.Config)
+
+	// Later entries go to the logs of the new profile
+	lgr := openFileLogger(session.Config)
+	ui.SetLogger(lgr)
+	wallet.SetLogger(lgr)
+	if *s.lgr != nil {
+		_ = (*s.lgr).Close()
+	}
+	*s.lgr = lgr
+	if lgr != nil {
+		lgr.Info("Profile opened", logger.String("profile", name), logger.String("app_dir", session.Config.AppDir))
+	}
+	return session, nil
+}
+
+func (s *profileSwitcher) openProfile(name string) (*ui.ProfileSession, *storage.GORMRepository, *audit.Logger, error) {
+	configManager := config.NewConfigurationManager()
+	cfg, err := configManager.LoadConfiguration()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if cfg.Database.Encryption == config.DatabaseEncryptionMasterPassword {
+		password, err := masterPasswordFromEnv()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if password == "" {
+			return nil, nil, nil, fmt.Errorf("the database of profile %q is encrypted with the master password; start with --profile %s", name, name)
+		}
+	}
+	dbKey, dbPassword, err := databaseKey(cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	repo, err := storage.NewEncryptedWalletRepository(cfg, dbKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	keystoreDir := filepath.Join(cfg.WalletsDir, "keystore")
+	if err := os.MkdirAll(keystoreDir, 0700); err != nil {
+		_ = repo.Close()
+		return nil, nil, nil, fmt.Errorf("failed to create keystore directory: %w", err)
+	}
+	ks := keystore.NewKeyStore(keystoreDir, keystore.StandardScryptN, keystore.StandardScryptP)
+	walletService := wallet.NewWalletService(repo, ks)
+	walletService.ApplyConfig(cfg)
+	walletService.KeystoreDir = keystoreDir
+	if dbPassword != "" {
+		if state, err := walletService.MasterPasswordState(); err == nil && state == wallet.MasterPasswordLocked {
+			_ = walletService.UnlockMasterPassword(dbPassword)
+		}
+	}
+
+	var gate *compliance.Gate
+	var auditLog *audit.Logger
+	if cfg.Compliance.Enabled {
+		auditLog, err = audit.Open(filepath.Join(cfg.AppDir, audit.FileName))
+		if err == nil {
+			gate, err = compliance.NewGate(cfg, auditLog)
+		}
+		if err != nil {
+			_ = repo.Close()
+			return nil, nil, nil, fmt.Errorf("failed to enable compliance mode: %w", err)
+		}
+	}
+	notifier, err := notify.New(cfg.Notifications)
+	if err != nil {
+		(*s.lgr).Warn("Webhook notifications disabled", logger.Error(err))
+	}
+	syncer, err := metasync.New(cfg)
+	if err != nil {
+		(*s.lgr).Warn("Metadata sync disabled", logger.Error(err))
+	}
+	// The proxy is process wide: set last, so a profile that fails to open
+	// leaves the one in use as it was
+	if err := blockchain.SetProxy(cfg.Proxy); err != nil {
+		_ = repo.Close()
+		return nil, nil, nil, fmt.Errorf("failed to configure the proxy: %w", err)
+	}
+
+	return &ui.ProfileSession{
+		Name:           name,
+		Config:         cfg,
+		Service:        walletService,
+		Syncer:         syncer,
+		Notifier:       notifier,
+		Gate:           gate,
+		ProtectedPaths: []string{keystoreDir, cfg.DatabasePath, cfg.DatabasePath + "-wal", cfg.DatabasePath + "-shm"},
+		Quarantined:    verifyNetworks(configManager, cfg, *s.lgr),
+	}, repo, auditLog, nil
+}
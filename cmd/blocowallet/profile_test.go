@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/audit"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/ui"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readLog returns the app.log of a profile directory
+func readLog(t *testing.T, appDir string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(appDir, "logs", logger.AppLogFile))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestProfileSwitcher_LogsToEachProfile(t *testing.T) {
+	baseDir := t.TempDir()
+	t.Setenv("BLOCO_WALLET_APP_APP_DIR", baseDir)
+	t.Setenv(config.ProfileEnv, "")
+	require.NoError(t, config.CreateProfile(baseDir, "work"))
+	require.NoError(t, config.CreateProfile(baseDir, "home"))
+
+	// The work profile runs in compliance mode, with its own audit log
+	t.Setenv(config.ProfileEnv, "work")
+	cm := config.NewConfigurationManager()
+	workCfg, err := cm.LoadConfiguration()
+	require.NoError(t, err)
+	workCfg.Compliance.Enabled = true
+	workCfg.Compliance.ApprovalEndpoint = "http://127.0.0.1:1"
+	require.NoError(t, cm.SaveConfiguration(workCfg))
+	t.Setenv(config.ProfileEnv, "")
+
+	cfg, err := config.NewConfigurationManager().LoadConfiguration()
+	require.NoError(t, err)
+	wallet.InitCryptoService(cfg)
+	repo, err := storage.NewWalletRepository(cfg)
+	require.NoError(t, err)
+	lgr := openFileLogger(cfg)
+	require.NotNil(t, lgr)
+	s := &profileSwitcher{repo: &repo, lgr: &lgr, cfg: cfg}
+	t.Cleanup(func() {
+		_ = repo.Close()
+		_ = lgr.Close()
+		ui.SetLogger(nil)
+		wallet.SetLogger(nil)
+	})
+
+	work, err := s.open("work")
+	require.NoError(t, err)
+	require.NotNil(t, s.auditLog)
+	workAudit := s.auditLog
+	lgr.Info("work event")
+
+	home, err := s.open("home")
+	require.NoError(t, err)
+	lgr.Info("home event")
+	require.NoError(t, lgr.Sync())
+
+	// Each event is in the logs of the profile in use when it happened
+	workLog, homeLog := readLog(t, work.Config.AppDir), readLog(t, home.Config.AppDir)
+	assert.Contains(t, workLog, `"profile":"work"`)
+	assert.Contains(t, workLog, "work event")
+	assert.NotContains(t, workLog, "home event")
+	assert.Contains(t, homeLog, `"profile":"home"`)
+	assert.Contains(t, homeLog, "home event")
+	assert.NotContains(t, homeLog, "work event")
+	assert.NotContains(t, readLog(t, cfg.AppDir), "event")
+
+	// The audit log of the work profile was closed with it
+	assert.Nil(t, s.auditLog)
+	assert.ErrorIs(t, workAudit.Record(audit.Entry{Action: "export_secret", Decision: audit.DecisionExecuted}), audit.ErrClosed)
+	assert.Same(t, home.Config, s.cfg)
+}
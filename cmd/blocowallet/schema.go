@@ -0,0 +1,177 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"blocowallet/internal/audit"
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/buildinfo"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+)
+
+// runSchema prints the effective configuration, the directory layout and the
+// database schema as markdown. Everything is read from the structs and the
+// loaded configuration, so the output always matches the running version.
+func runSchema(cfg *config.Config, args []string) int {
+	fs := flag.NewFlagSet("schema", flag.ContinueOnError)
+	output := fs.String("output", "", "write the markdown to this file instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bloco-wallet schema [options]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	tables, err := storage.DescribeSchema()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "schema: %v\n", err)
+		return exitTaskFailed
+	}
+	var b strings.Builder
+	info := buildinfo.New(version, commit, date)
+	fmt.Fprintf(&b, "# bloco-wallet %s\n\n", info.Version)
+	fmt.Fprintln(&b, "Generated by `bloco-wallet schema`. Secrets are redacted.")
+	writeConfigSchema(&b, cfg)
+	writeDirectorySchema(&b, cfg)
+	writeDatabaseSchema(&b, cfg, tables)
+
+	if *output == "" {
+		fmt.Print(b.String())
+		return exitOK
+	}
+	if err := os.WriteFile(*output, []byte(b.String()), 0600); err != nil {
+		fmt.Fprintf(os.Stderr, "schema: %v\n", err)
+		return exitTaskFailed
+	}
+	return exitOK
+}
+
+// writeConfigSchema writes one table per config.toml section
+func writeConfigSchema(w io.Writer, cfg *config.Config) {
+	fmt.Fprint(w, "\n## Configuration\n")
+	section := ""
+	for _, s := range config.Settings(cfg) {
+		i := strings.LastIndex(s.Key, ".")
+		if s.Key[:i] != section {
+			section = s.Key[:i]
+			fmt.Fprintf(w, "\n### [%s]\n\n| Key | Value |\n| --- | --- |\n", section)
+		}
+		fmt.Fprintf(w, "| `%s` | `%s` |\n", s.Key[i+1:], markdownCell(s.Value))
+	}
+}
+
+// writeDirectorySchema writes where the files of the installation are
+func writeDirectorySchema(w io.Writer, cfg *config.Config) {
+	keystoreDir := filepath.Join(cfg.WalletsDir, "keystore")
+	reportDir := cfg.Cron.ReportDir
+	if reportDir == "" {
+		reportDir = filepath.Join(cfg.AppDir, "reports")
+	}
+	backupDir := cfg.Cron.BackupDir
+	if backupDir == "" {
+		backupDir = filepath.Join(cfg.AppDir, "backups")
+	}
+	// A DSN can hold credentials, so only the plain database path is shown
+	database := cfg.DatabasePath
+	if cfg.Database.DSN != "" {
+		database = config.Redacted
+	}
+	approvalDir := cfg.Compliance.ApprovalDir
+	if approvalDir == "" {
+		approvalDir = filepath.Join(cfg.AppDir, "approvals")
+	}
+	paths := [][2]string{
+		{"App directory", cfg.AppDir},
+		{"Config file", filepath.Join(cfg.AppDir, "config.toml")},
+		{"Database", database},
+		{"Keystores", keystoreDir},
+		{"Quarantined keystores", filepath.Join(filepath.Dir(keystoreDir), wallet.QuarantineDirName)},
+		{"Logs", filepath.Join(cfg.AppDir, "logs")},
+		{"Cron reports", reportDir},
+		{"Backups", backupDir},
+		{"Approvals", approvalDir},
+		{"Audit log", filepath.Join(cfg.AppDir, audit.FileName)},
+		{"Learned signatures", filepath.Join(cfg.AppDir, blockchain.SignaturesFileName)},
+		{"Spam list", filepath.Join(cfg.AppDir, blockchain.SpamListFileName)},
+	}
+	fmt.Fprint(w, "\n## Directories\n\n| Item | Path |\n| --- | --- |\n")
+	for _, p := range paths {
+		fmt.Fprintf(w, "| %s | `%s` |\n", p[0], markdownCell(p[1]))
+	}
+}
+
+// writeDatabaseSchema writes the tables this version creates and how the
+// current database differs from them
+func writeDatabaseSchema(w io.Writer, cfg *config.Config, tables []storage.Table) {
+	fmt.Fprint(w, "\n## Database\n\n")
+	dbPath := databasePath(cfg)
+	switch {
+	case cfg.Database.Type != "" && cfg.Database.Type != "sqlite":
+		fmt.Fprintf(w, "Current database: n/a (%s)\n", cfg.Database.Type)
+	case cfg.Database.Encryption != "":
+		fmt.Fprintln(w, "Current database: n/a (encrypted)")
+	default:
+		version, err := storage.ReadSchemaVersion(dbPath)
+		if errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintln(w, "Current database: not created yet")
+			break
+		}
+		if err != nil {
+			fmt.Fprintln(w, "Current database: unreadable")
+			break
+		}
+		fmt.Fprintf(w, "Current database: %s\n", version)
+		missing, err := storage.MissingColumns(dbPath, tables)
+		if err == nil && len(missing) > 0 {
+			fmt.Fprintf(w, "\nAdded on the next start: %s\n", strings.Join(missing, ", "))
+		}
+	}
+	for _, t := range tables {
+		fmt.Fprintf(w, "\n### %s\n\n| Column | Type | Constraints |\n| --- | --- | --- |\n", t.Name)
+		for _, c := range t.Columns {
+			var constraints []string
+			if c.PrimaryKey && !strings.Contains(c.Type, "PRIMARY KEY") {
+				constraints = append(constraints, "primary key")
+			}
+			if c.NotNull {
+				constraints = append(constraints, "not null")
+			}
+			if c.Unique {
+				constraints = append(constraints, "unique")
+			}
+			if c.Default != "" {
+				constraints = append(constraints, "default "+c.Default)
+			}
+			fmt.Fprintf(w, "| `%s` | %s | %s |\n", c.Name, c.Type, strings.Join(constraints, ", "))
+		}
+		if len(t.Indexes) > 0 {
+			fmt.Fprintf(w, "\nIndexes: %s\n", strings.Join(t.Indexes, "; "))
+		}
+	}
+}
+
+// databasePath is the SQLite file the repository opens
+func databasePath(cfg *config.Config) string {
+	if cfg.Database.DSN != "" {
+		return cfg.Database.DSN
+	}
+	return cfg.DatabasePath
+}
+
+// markdownCell keeps a value from breaking out of its table cell
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "`", "'").Replace(s)
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/snapshot"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+)
+
+// runSnapshot writes the nonce, native balance and token balances of every
+// wallet at a block of each network as a JSON report signed by a wallet
+func runSnapshot(cfg *config.Config, ws *wallet.WalletService, tokens blockchain.TokenStore, args []string) int {
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	signWith := fs.String("sign-with", "", "address of the wallet signing the report (required)")
+	passwordFile := fs.String("password-file", "", `file holding the password of the signing wallet, "-" for stdin (required)`)
+	networks := fs.String("network", "", "comma-separated networks to read, by key or name (default: every active network)")
+	block := fs.String("block", "", "block number to read; needs a single --network (default: the latest block of each network)")
+	tokenFilter := fs.String("tokens", "", "comma-separated followed tokens to read, by symbol or address (default: every followed token not hidden as spam)")
+	output := fs.String("output", "", "write the report to this file instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bloco-wallet snapshot --sign-with <address> --password-file <file> [options]")
+		fmt.Fprintln(fs.Output(), "       bloco-wallet snapshot verify <file>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 0 || *signWith == "" || *passwordFile == "" {
+		fs.Usage()
+		return exitUsage
+	}
+
+	selected, err := snapshotNetworks(cfg, *networks)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot: %v\n", err)
+		return exitUsage
+	}
+	var number *big.Int
+	if *block != "" {
+		var ok bool
+		if number, ok = new(big.Int).SetString(*block, 10); !ok || number.Sign() < 0 {
+			fmt.Fprintf(os.Stderr, "snapshot: invalid block number %q\n", *block)
+			return exitUsage
+		}
+		if len(selected) != 1 {
+			fmt.Fprintln(os.Stderr, "snapshot: block numbers differ between chains; choose one network with --network")
+			return exitUsage
+		}
+	}
+
+	// The signing wallet is unlocked before any network is read
+	password, err := readPasswordFile(*passwordFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot: reading password: %v\n", err)
+		return exitUsage
+	}
+	signer, err := ws.FindWalletByAddress(*signWith)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot: %s: %v\n", *signWith, err)
+		return exitTaskFailed
+	}
+	details, err := ws.LoadWallet(signer, password)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot: %v\n", err)
+		return exitTaskFailed
+	}
+	if details.Signer == nil {
+		fmt.Fprintf(os.Stderr, "snapshot: wallet %s cannot sign\n", signer.Address)
+		return exitTaskFailed
+	}
+
+	wallets, err := ws.GetAllWallets()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot: %v\n", err)
+		return exitTaskFailed
+	}
+	followed, err := snapshotTokens(blockchain.NewTokenService(tokens), *tokenFilter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot: %v\n", err)
+		return exitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	report, err := snapshot.Take(ctx, snapshot.Options{
+		Networks:   selected,
+		Wallets:    wallets,
+		Tokens:     followed,
+		Block:      number,
+		Dial:       snapshot.DialEthereum,
+		NetworkFor: ws.NetworkFor,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot: %v\n", err)
+		return exitTaskFailed
+	}
+	if err := report.Sign(details.Signer); err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot: %v\n", err)
+		return exitTaskFailed
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot: %v\n", err)
+		return exitTaskFailed
+	}
+	if *output == "" {
+		fmt.Println(string(data))
+	} else if err := os.WriteFile(*output, append(data, '\n'), 0600); err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot: %v\n", err)
+		return exitTaskFailed
+	}
+	if failed := report.Failed(); failed > 0 {
+		fmt.Fprintf(os.Stderr, "snapshot: %d networks or accounts could not be read; see their error in the report\n", failed)
+		return exitTaskFailed
+	}
+	return exitOK
+}
+
+// runSnapshotVerify checks that a report was signed by the address it names
+func runSnapshotVerify(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: bloco-wallet snapshot verify <file>")
+		return exitUsage
+	}
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot: %v\n", err)
+		return exitUsage
+	}
+	report, err := snapshot.Verify(data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot: %s: %v\n", args[0], err)
+		return exitTaskFailed
+	}
+	fmt.Printf("Signed by %s on %s\n", report.Signer, report.CreatedAt.Format("2006-01-02 15:04:05 MST"))
+	for _, n := range report.Networks {
+		if n.Error != "" {
+			fmt.Printf("  %s: not read (%s)\n", n.Name, n.Error)
+			continue
+		}
+		fmt.Printf("  %s: block %d, %d accounts\n", n.Name, n.Block, len(n.Accounts))
+	}
+	return exitOK
+}
+
+// snapshotNetworks returns the networks named in the comma-separated list,
+// or every active network with an RPC endpoint when it is empty
+func snapshotNetworks(cfg *config.Config, list string) (map[string]config.Network, error) {
+	selected := make(map[string]config.Network)
+	if strings.TrimSpace(list) == "" {
+		for key, network := range cfg.Networks {
+			if network.Usable() && network.RPCEndpoint != "" {
+				selected[key] = network
+			}
+		}
+		if len(selected) == 0 {
+			return nil, fmt.Errorf("no active networks configured")
+		}
+		return selected, nil
+	}
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for key, network := range cfg.Networks {
+			if strings.EqualFold(key, name) || strings.EqualFold(network.Name, name) {
+				if network.RPCEndpoint == "" {
+					return nil, fmt.Errorf("network %q has no RPC endpoint", name)
+				}
+				if network.Quarantine != "" {
+					return nil, fmt.Errorf("network %q is quarantined: %s", name, network.Quarantine)
+				}
+				selected[key] = network
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("network %q is not configured", name)
+		}
+	}
+	return selected, nil
+}
+
+// snapshotTokens groups by chain ID the followed tokens matching the
+// comma-separated symbols or addresses, or all visible ones when it is empty
+func snapshotTokens(service *blockchain.TokenService, list string) (map[int64][]blockchain.Token, error) {
+	all, err := service.Tokens(0)
+	if err != nil {
+		return nil, err
+	}
+	var wanted []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			wanted = append(wanted, item)
+		}
+	}
+	matched := make(map[string]bool)
+	byChain := make(map[int64][]blockchain.Token)
+	for _, token := range all {
+		if len(wanted) == 0 {
+			if service.Visible(token) {
+				byChain[token.ChainID] = append(byChain[token.ChainID], token)
+			}
+			continue
+		}
+		for _, item := range wanted {
+			if strings.EqualFold(item, token.Symbol) || strings.EqualFold(item, token.Address) {
+				byChain[token.ChainID] = append(byChain[token.ChainID], token)
+				matched[item] = true
+				break
+			}
+		}
+	}
+	for _, item := range wanted {
+		if !matched[item] {
+			return nil, fmt.Errorf("token %q is not followed on any network", item)
+		}
+	}
+	return byChain, nil
+}
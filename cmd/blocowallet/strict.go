@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/logger"
+)
+
+// verifyNetworks runs the strict RPC checks when enabled, saving quarantines
+// and releases to the configuration. It returns the networks that are
+// quarantined after the checks.
+func verifyNetworks(configManager *config.ConfigurationManager, cfg *config.Config, lgr logger.Logger) []blockchain.EndpointCheck {
+	if !cfg.Security.StrictRPC {
+		return nil
+	}
+	checks := blockchain.VerifyEndpoints(context.Background(), cfg.Networks)
+	changed := blockchain.ApplyEndpointChecks(cfg.Networks, checks)
+	for _, check := range changed {
+		if check.Network.Quarantine != "" {
+			lgr.Error("Network quarantined", logger.String("network", check.Network.Name), logger.String("reason", check.Network.Quarantine))
+		} else {
+			lgr.Info("Network quarantine lifted", logger.String("network", check.Network.Name))
+		}
+	}
+	for _, check := range checks {
+		if check.Err != nil && !check.Quarantine() {
+			lgr.Warn("Network endpoint could not be verified", logger.String("network", check.Network.Name), logger.Error(check.Err))
+		}
+	}
+	if len(changed) > 0 {
+		if err := configManager.SaveConfiguration(cfg); err != nil {
+			lgr.Error("Failed to save network quarantine", logger.Error(err))
+		}
+	}
+
+	var quarantined []blockchain.EndpointCheck
+	for _, check := range checks {
+		if network := cfg.Networks[check.Key]; network.Quarantine != "" {
+			check.Network = network
+			quarantined = append(quarantined, check)
+		}
+	}
+	return quarantined
+}
+
+// warnQuarantined tells headless commands which networks are out of use
+func warnQuarantined(quarantined []blockchain.EndpointCheck) {
+	for _, check := range quarantined {
+		fmt.Fprintf(os.Stderr, "warning: network %s is quarantined: %s\n", check.Network.Name, check.Network.Quarantine)
+	}
+}
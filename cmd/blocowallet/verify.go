@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"blocowallet/internal/compliance"
+	"blocowallet/internal/wallet"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// runVerify cross-checks the wallet database with the keystore directory and
+// reports what disagrees. With --repair it walks through the issues on the
+// terminal, offering the fixes of each one; removals wait for approval in
+// compliance mode, as in the TUI.
+func runVerify(ws *wallet.WalletService, gate *compliance.Gate, args []string) int {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print the report as JSON")
+	repair := fs.Bool("repair", false, "offer the fixes of each issue on the terminal")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bloco-wallet verify [options]")
+		fmt.Fprintln(fs.Output(), "Exits with status 1 while the database and the keystore directory disagree.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 0 || (*repair && *jsonOut) {
+		fs.Usage()
+		return exitUsage
+	}
+	if *repair {
+		if !term.IsTerminal(os.Stdin.Fd()) {
+			fmt.Fprintln(os.Stderr, "verify: --repair asks on the terminal; run it interactively")
+			return exitUsage
+		}
+		// Re-imported wallets are sealed with the master password
+		if code := unlockHeadless("verify", ws); code != exitOK {
+			return code
+		}
+	}
+
+	report, err := ws.VerifyStore()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "verify: %v\n", err)
+		return exitTaskFailed
+	}
+	if *jsonOut {
+		if code := printJSON("verify", report); code != exitOK {
+			return code
+		}
+	} else {
+		printStoreReport(report)
+	}
+	if *repair && !report.OK() {
+		repairStore(ws, gate, report)
+		if report, err = ws.VerifyStore(); err != nil {
+			fmt.Fprintf(os.Stderr, "verify: %v\n", err)
+			return exitTaskFailed
+		}
+		fmt.Printf("\n%d issues left\n", len(report.Issues))
+	}
+	if report.OK() {
+		return exitOK
+	}
+	return exitTaskFailed
+}
+
+// printStoreReport lists the issues, one per line with its details below
+func printStoreReport(report *wallet.StoreReport) {
+	fmt.Printf("%d wallets, %d keystores in %s\n", report.Wallets, report.Keystores, report.KeystoreDir)
+	if report.OK() {
+		fmt.Println("The database and the keystore directory agree.")
+		return
+	}
+	for i, issue := range report.Issues {
+		fmt.Printf("\n%d. %s\n", i+1, describeStoreIssue(issue))
+		fmt.Printf("   %s\n", issue.Detail)
+		if issue.Candidate != "" {
+			fmt.Printf("   keystore found with the same address: %s\n", issue.Candidate)
+		}
+	}
+}
+
+// describeStoreIssue names the issue and what it concerns
+func describeStoreIssue(issue wallet.StoreIssue) string {
+	switch issue.Kind {
+	case wallet.StoreIssueMissingKeystore:
+		return fmt.Sprintf("missing keystore: wallet %q (%s) points at %s", issue.Wallet.Name, issue.Wallet.Address, issue.Path)
+	case wallet.StoreIssueAddressMismatch:
+		return fmt.Sprintf("address mismatch: wallet %q (%s), %s", issue.Wallet.Name, issue.Wallet.Address, issue.Path)
+	case wallet.StoreIssueUnreadableKeystore:
+		return fmt.Sprintf("unreadable keystore: wallet %q (%s), %s", issue.Wallet.Name, issue.Wallet.Address, issue.Path)
+	default:
+		return fmt.Sprintf("orphan keystore: %s (%s)", issue.Path, issue.Address)
+	}
+}
+
+// repairStore asks, issue by issue, which fix to apply; enter skips
+func repairStore(ws *wallet.WalletService, gate *compliance.Gate, report *wallet.StoreReport) {
+	in := bufio.NewReader(os.Stdin)
+	ask := func(prompt string) string {
+		fmt.Print(prompt)
+		line, _ := in.ReadString('\n')
+		return strings.TrimSpace(line)
+	}
+	for i, issue := range report.Issues {
+		repairs := issue.Repairs()
+		if len(repairs) == 0 {
+			continue
+		}
+		fmt.Printf("\n%d. %s\n", i+1, describeStoreIssue(issue))
+		options := make([]string, len(repairs))
+		for j, r := range repairs {
+			options[j] = string(r)
+		}
+		choice := ask(fmt.Sprintf("   fix with %s, or enter to skip: ", strings.Join(options, "/")))
+		if choice == "" {
+			continue
+		}
+		repair := wallet.StoreRepair(choice)
+
+		var name, password string
+		if repair == wallet.RepairReimport {
+			name = ask("   wallet name: ")
+			if name == "" {
+				name = "Recovered " + wallet.ChecksumAddress(issue.Address)
+			}
+			fmt.Print("   keystore password: ")
+			secret, err := term.ReadPassword(os.Stdin.Fd())
+			fmt.Println()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "verify: %v\n", err)
+				continue
+			}
+			password = string(secret)
+		}
+
+		apply := func() error { return ws.RepairStoreIssue(issue, repair, name, password) }
+		var err error
+		if gate.Enabled() && (repair == wallet.RepairRemoveRow || repair == wallet.RepairQuarantine) {
+			req := gate.NewRequest(compliance.ActionDeleteWallet, issue.Wallet.Address)
+			fmt.Fprintf(os.Stderr, "verify: waiting for approval of request %s: %s\n", req.ID, gate.Describe(req))
+			if err = gate.Authorize(context.Background(), req); err == nil {
+				err = apply()
+				if auditErr := gate.RecordOutcome(req, err); auditErr != nil && err == nil {
+					err = auditErr
+				}
+			}
+		} else {
+			err = apply()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "verify: %v\n", err)
+			continue
+		}
+		fmt.Printf("   done: %s\n", repair)
+	}
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"blocowallet/internal/compliance"
+	"blocowallet/internal/wallet"
+)
+
+// walletJSON is how the headless commands print a wallet with --json. It
+// never holds key material.
+type walletJSON struct {
+	ID             int       `json:"id"`
+	Name           string    `json:"name"`
+	Address        string    `json:"address"`
+	ImportMethod   string    `json:"import_method"`
+	WatchOnly      bool      `json:"watch_only"`
+	KeystorePath   string    `json:"keystore_path,omitempty"`
+	DerivationPath string    `json:"derivation_path,omitempty"`
+	Notes          string    `json:"notes,omitempty"`
+	References     []string  `json:"references,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func newWalletJSON(w *wallet.Wallet) walletJSON {
+	return walletJSON{
+		ID:             w.ID,
+		Name:           w.Name,
+		Address:        wallet.ChecksumAddress(w.Address),
+		ImportMethod:   w.ImportMethod,
+		WatchOnly:      w.WatchOnly(),
+		KeystorePath:   w.KeyStorePath,
+		DerivationPath: w.DerivationPath,
+		Notes:          w.Notes,
+		References:     w.References,
+		CreatedAt:      w.CreatedAt.UTC(),
+	}
+}
+
+// printJSON writes v to stdout as indented JSON
+func printJSON(command string, v any) int {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", command, err)
+		return exitTaskFailed
+	}
+	fmt.Println(string(data))
+	return exitOK
+}
+
+// runList prints the stored wallets
+func runList(ws *wallet.WalletService, args []string) int {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print the wallets as JSON")
+	query := fs.String("filter", "", "only list wallets whose name, address, notes or references contain this text")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bloco-wallet list [options]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	wallets, err := ws.GetAllWallets()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "list: %v\n", err)
+		return exitTaskFailed
+	}
+	out := make([]walletJSON, 0, len(wallets))
+	for i := range wallets {
+		if *query == "" || wallets[i].Matches(*query) {
+			out = append(out, newWalletJSON(&wallets[i]))
+		}
+	}
+	if *jsonOut {
+		return printJSON("list", out)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tADDRESS\tMETHOD\tCREATED")
+	for _, w := range out {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", w.ID, w.Name, wallet.ChecksumAddress(w.Address), w.ImportMethod, w.CreatedAt.Format("2006-01-02"))
+	}
+	if err := tw.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "list: %v\n", err)
+		return exitTaskFailed
+	}
+	return exitOK
+}
+
+// runImport imports one wallet from a keystore file, a mnemonic or a private
+// key. Secrets are read from files, or stdin for "-", never from arguments,
+// so they do not end up in the shell history or the process list.
+func runImport(ws *wallet.WalletService, args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	name := fs.String("name", "", "name of the wallet (required)")
+	keystorePath := fs.String("keystore", "", "KeyStore V3 file to import")
+	mnemonicFile := fs.String("mnemonic-file", "", `file holding the mnemonic to import, "-" for stdin`)
+	keyFile := fs.String("private-key-file", "", `file holding the hex private key to import, "-" for stdin`)
+	passwordFile := fs.String("password-file", "", `file holding the password, "-" for stdin; for a keystore, the password it is encrypted with`)
+	jsonOut := fs.Bool("json", false, "print the imported wallet as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bloco-wallet import --name <name> (--keystore <file> | --mnemonic-file <file> | --private-key-file <file>) --password-file <file> [options]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	sources := 0
+	for _, s := range []string{*keystorePath, *mnemonicFile, *keyFile} {
+		if s != "" {
+			sources++
+		}
+	}
+	if fs.NArg() != 0 || sources != 1 || strings.TrimSpace(*name) == "" || *passwordFile == "" {
+		fs.Usage()
+		return exitUsage
+	}
+	if *passwordFile == "-" && (*mnemonicFile == "-" || *keyFile == "-") {
+		fmt.Fprintln(os.Stderr, "import: only one of the files can be read from stdin")
+		return exitUsage
+	}
+
+	password, err := readPasswordFile(*passwordFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "import: reading password: %v\n", err)
+		return exitUsage
+	}
+	var details *wallet.WalletDetails
+	switch {
+	case *keystorePath != "":
+		details, err = ws.ImportWalletFromKeystoreV3(*name, *keystorePath, password)
+	case *mnemonicFile != "":
+		var mnemonic string
+		if mnemonic, err = readPasswordFile(*mnemonicFile); err != nil {
+			fmt.Fprintf(os.Stderr, "import: reading mnemonic: %v\n", err)
+			return exitUsage
+		}
+		details, err = ws.ImportWallet(*name, strings.Join(strings.Fields(mnemonic), " "), password)
+	default:
+		var key string
+		if key, err = readPasswordFile(*keyFile); err != nil {
+			fmt.Fprintf(os.Stderr, "import: reading private key: %v\n", err)
+			return exitUsage
+		}
+		details, err = ws.ImportWalletFromPrivateKey(*name, strings.TrimSpace(key), password)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "import: %v\n", err)
+		return exitTaskFailed
+	}
+	return printWallet("import", details.Wallet, *jsonOut)
+}
+
+// runCreate creates a wallet from a new mnemonic. The mnemonic is stored
+// encrypted with the password and can be revealed later in the TUI.
+func runCreate(ws *wallet.WalletService, args []string) int {
+	fs := flag.NewFlagSet("create", flag.ContinueOnError)
+	name := fs.String("name", "", "name of the wallet (required)")
+	passwordFile := fs.String("password-file", "", `file holding the password, "-" for stdin (required)`)
+	jsonOut := fs.Bool("json", false, "print the new wallet as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bloco-wallet create --name <name> --password-file <file> [options]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 0 || strings.TrimSpace(*name) == "" || *passwordFile == "" {
+		fs.Usage()
+		return exitUsage
+	}
+
+	password, err := readPasswordFile(*passwordFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "create: reading password: %v\n", err)
+		return exitUsage
+	}
+	if validationErr, ok := wallet.ValidatePassword(password); !ok {
+		fmt.Fprintf(os.Stderr, "create: %s\n", validationErr.GetErrorMessage())
+		return exitUsage
+	}
+	details, err := ws.CreateWallet(*name, password)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "create: %v\n", err)
+		return exitTaskFailed
+	}
+	return printWallet("create", details.Wallet, *jsonOut)
+}
+
+// runExport writes the key of a stored wallet as a KeyStore V3 file. In
+// compliance mode the export waits for a second person's approval, as in the TUI.
+func runExport(ws *wallet.WalletService, gate *compliance.Gate, args []string) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	address := fs.String("address", "", "address of the wallet to export (required)")
+	passwordFile := fs.String("password-file", "", `file holding the wallet password, "-" for stdin (required)`)
+	newPasswordFile := fs.String("new-password-file", "", "file holding the password for the exported file; defaults to the wallet password")
+	output := fs.String("output", "", "path of the exported file; defaults to UTC--<time>--<address> in the current directory")
+	jsonOut := fs.Bool("json", false, "print the address and path as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bloco-wallet export --address <address> --password-file <file> [options]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 0 || *address == "" || *passwordFile == "" {
+		fs.Usage()
+		return exitUsage
+	}
+	if *passwordFile == "-" && *newPasswordFile == "-" {
+		fmt.Fprintln(os.Stderr, "export: only one of the password files can be read from stdin")
+		return exitUsage
+	}
+
+	password, err := readPasswordFile(*passwordFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "export: reading password: %v\n", err)
+		return exitUsage
+	}
+	exportPassword := password
+	if *newPasswordFile != "" {
+		if exportPassword, err = readPasswordFile(*newPasswordFile); err != nil {
+			fmt.Fprintf(os.Stderr, "export: reading new password: %v\n", err)
+			return exitUsage
+		}
+	}
+
+	w, err := ws.FindWalletByAddress(*address)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "export: %s: %v\n", *address, err)
+		return exitTaskFailed
+	}
+	details, err := ws.LoadWallet(w, password)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "export: %v\n", err)
+		return exitTaskFailed
+	}
+	path := *output
+	if path == "" {
+		path = wallet.DefaultKeystoreFileName(w, time.Now())
+	}
+
+	export := func() error { return ws.ExportKeystore(details, exportPassword, path) }
+	if gate.Enabled() {
+		req := gate.NewRequest(compliance.ActionExportSecret, fmt.Sprintf("%s %s", w.Address, wallet.ExportSecretKeystoreV3))
+		fmt.Fprintf(os.Stderr, "export: waiting for approval of request %s: %s\n", req.ID, gate.Describe(req))
+		if err = gate.Authorize(context.Background(), req); err == nil {
+			err = export()
+			if auditErr := gate.RecordOutcome(req, err); auditErr != nil && err == nil {
+				err = auditErr
+			}
+		}
+	} else {
+		err = export()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "export: %v\n", err)
+		return exitTaskFailed
+	}
+
+	if *jsonOut {
+		return printJSON("export", struct {
+			Address string `json:"address"`
+			Path    string `json:"path"`
+		}{w.Address, path})
+	}
+	fmt.Println(path)
+	return exitOK
+}
+
+// printWallet reports a created or imported wallet
+func printWallet(command string, w *wallet.Wallet, jsonOut bool) int {
+	if jsonOut {
+		return printJSON(command, newWalletJSON(w))
+	}
+	fmt.Printf("%s\t%s\n", wallet.ChecksumAddress(w.Address), w.Name)
+	return exitOK
+}
@@ -0,0 +1,31 @@
+// Command integrity-stamp writes into built bloco-wallet binaries the SHA-256
+// they check on startup. Run it as the last step of a build:
+//
+//	go run ./cmd/integrity-stamp build/bloco-wallet
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"blocowallet/internal/integrity"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "Usage: integrity-stamp <binary>...")
+		os.Exit(2)
+	}
+	failed := false
+	for _, path := range os.Args[1:] {
+		if err := integrity.Stamp(path); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			failed = true
+			continue
+		}
+		fmt.Printf("stamped %s\n", path)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,169 @@
+// Package audit keeps an append-only log of security sensitive decisions.
+// Each entry carries the hash of the previous one, so edits or deletions in
+// the middle of the file are detected by Verify.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileName is the audit log file name inside the application directory
+const FileName = "audit.log"
+
+// Decision values recorded in the log
+const (
+	DecisionRequested = "requested"
+	DecisionApproved  = "approved"
+	DecisionDenied    = "denied"
+	DecisionError     = "error"
+	DecisionExecuted  = "executed"
+	DecisionFailed    = "failed"
+)
+
+// ErrTampered is returned by Verify when the hash chain is broken
+var ErrTampered = errors.New("audit log has been modified")
+
+// ErrClosed is returned by Record once the logger is closed
+var ErrClosed = errors.New("audit log is closed")
+
+// Entry is a single audit record
+type Entry struct {
+	Time      time.Time `json:"time"`
+	RequestID string    `json:"request_id,omitempty"`
+	Action    string    `json:"action"`
+	Subject   string    `json:"subject,omitempty"`
+	Actor     string    `json:"actor,omitempty"`
+	Approver  string    `json:"approver,omitempty"`
+	Decision  string    `json:"decision"`
+	Detail    string    `json:"detail,omitempty"`
+	PrevHash  string    `json:"prev_hash"`
+	Hash      string    `json:"hash"`
+}
+
+// Logger appends entries to an audit log file
+type Logger struct {
+	mu       sync.Mutex
+	path     string
+	lastHash string
+	closed   bool
+}
+
+// Open opens (or creates on first write) the audit log at path
+func Open(path string) (*Logger, error) {
+	entries, err := readEntries(path)
+	if err != nil {
+		return nil, err
+	}
+	l := &Logger{path: path}
+	if len(entries) > 0 {
+		l.lastHash = entries[len(entries)-1].Hash
+	}
+	return l, nil
+}
+
+// Path returns the location of the log file
+func (l *Logger) Path() string {
+	return l.path
+}
+
+// Record appends an entry, filling in its time and hash chain
+func (l *Logger) Record(entry Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return ErrClosed
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	entry.PrevHash = l.lastHash
+	entry.Hash = ""
+	entry.Hash = hashEntry(entry)
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	l.lastHash = entry.Hash
+	return nil
+}
+
+// Close stops the logger from writing, so that a gate still held by the
+// profile it belonged to cannot append to its log after a switch. Entries
+// are written as they are recorded, so there is nothing to flush.
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closed = true
+	return nil
+}
+
+// Verify checks the hash chain of the log at path
+func Verify(path string) error {
+	entries, err := readEntries(path)
+	if err != nil {
+		return err
+	}
+	prev := ""
+	for i, entry := range entries {
+		expected := entry.Hash
+		entry.Hash = ""
+		if entry.PrevHash != prev || hashEntry(entry) != expected {
+			return fmt.Errorf("%w at entry %d", ErrTampered, i+1)
+		}
+		prev = expected
+	}
+	return nil
+}
+
+func hashEntry(entry Entry) string {
+	data, _ := json.Marshal(entry)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func readEntries(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("%w: invalid entry on line %d", ErrTampered, line)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
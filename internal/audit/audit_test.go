@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndVerify(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	l, err := Open(path)
+	require.NoError(t, err)
+
+	require.NoError(t, l.Record(Entry{Action: "delete_wallet", Subject: "0xABC", Actor: "alice", Decision: DecisionRequested}))
+	require.NoError(t, l.Record(Entry{Action: "delete_wallet", Subject: "0xABC", Approver: "bob", Decision: DecisionApproved}))
+	require.NoError(t, Verify(path))
+
+	// Reopening continues the chain
+	l, err = Open(path)
+	require.NoError(t, err)
+	require.NoError(t, l.Record(Entry{Action: "delete_wallet", Subject: "0xABC", Decision: DecisionExecuted}))
+	require.NoError(t, Verify(path))
+
+	// A closed logger writes nothing more
+	require.NoError(t, l.Close())
+	assert.ErrorIs(t, l.Record(Entry{Action: "delete_wallet", Decision: DecisionExecuted}), ErrClosed)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(data), "\n"))
+	require.NoError(t, Verify(path))
+}
+
+func TestVerify_DetectsTampering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	l, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, l.Record(Entry{Action: "export_secret", Approver: "bob", Decision: DecisionApproved}))
+	require.NoError(t, l.Record(Entry{Action: "export_secret", Decision: DecisionExecuted}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(string(data), `"bob"`, `"eve"`, 1)), 0600))
+
+	assert.ErrorIs(t, Verify(path), ErrTampered)
+}
+
+func TestVerify_DetectsDeletedEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	l, err := Open(path)
+	require.NoError(t, err)
+	for _, d := range []string{DecisionRequested, DecisionDenied, DecisionRequested} {
+		require.NoError(t, l.Record(Entry{Action: "delete_wallet", Decision: d}))
+	}
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.SplitAfter(string(data), "\n")
+	require.NoError(t, os.WriteFile(path, []byte(lines[0]+lines[2]), 0600))
+
+	assert.ErrorIs(t, Verify(path), ErrTampered)
+}
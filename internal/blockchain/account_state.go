@@ -0,0 +1,70 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// stateBackend is the part of ethclient.Client used to read accounts at a block
+type stateBackend interface {
+	previewBackend
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+// AccountState is what an account held at a block
+type AccountState struct {
+	Nonce   uint64
+	Balance *big.Int
+	// Tokens holds the balance of each token asked for, in the same order;
+	// an entry is nil when TokenErrors has the reason it could not be read
+	Tokens      []*big.Int
+	TokenErrors []error
+}
+
+// HeaderAt returns the header of block number, or of the latest block when
+// number is nil, so several reads can be pinned to the same block
+func (e *Ethereum) HeaderAt(ctx context.Context, number *big.Int) (*types.Header, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+	header, err := e.client.HeaderByNumber(ctx, number)
+	if err != nil {
+		if number == nil {
+			return nil, fmt.Errorf("failed to get the latest block: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get block %s: %w", number, err)
+	}
+	return header, nil
+}
+
+// AccountAt reads the nonce, the native balance and the balance of each
+// token of account at block number. Archive data is needed for old blocks.
+func (e *Ethereum) AccountAt(ctx context.Context, account common.Address, tokens []common.Address, number *big.Int) (AccountState, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+	return accountAt(ctx, e.client, account, tokens, number)
+}
+
+func accountAt(ctx context.Context, backend stateBackend, account common.Address, tokens []common.Address, number *big.Int) (AccountState, error) {
+	nonce, err := backend.NonceAt(ctx, account, number)
+	if err != nil {
+		return AccountState{}, fmt.Errorf("failed to get the nonce of %s: %w", account.Hex(), err)
+	}
+	balance, err := backend.BalanceAt(ctx, account, number)
+	if err != nil {
+		return AccountState{}, fmt.Errorf("failed to get the balance of %s: %w", account.Hex(), err)
+	}
+	state := AccountState{
+		Nonce:       nonce,
+		Balance:     balance,
+		Tokens:      make([]*big.Int, len(tokens)),
+		TokenErrors: make([]error, len(tokens)),
+	}
+	for i, token := range tokens {
+		state.Tokens[i], state.TokenErrors[i] = tokenBalanceAt(ctx, backend, token, account, number)
+	}
+	return state, nil
+}
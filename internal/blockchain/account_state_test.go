@@ -0,0 +1,59 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStateBackend answers for one token and records the blocks it was asked for
+type fakeStateBackend struct {
+	fakeTokenBackend
+	nonces map[common.Address]uint64
+	blocks []*big.Int
+}
+
+func (f *fakeStateBackend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	f.blocks = append(f.blocks, blockNumber)
+	return f.nonces[account], nil
+}
+
+func (f *fakeStateBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	f.blocks = append(f.blocks, blockNumber)
+	if *msg.To != previewToken {
+		return nil, errors.New("execution reverted")
+	}
+	return f.fakeTokenBackend.CallContract(ctx, msg, blockNumber)
+}
+
+func TestAccountAt(t *testing.T) {
+	backend := &fakeStateBackend{
+		fakeTokenBackend: fakeTokenBackend{
+			native:   map[common.Address]*big.Int{previewSender: big.NewInt(7e17)},
+			balances: map[common.Address]*big.Int{previewSender: big.NewInt(2500000)},
+		},
+		nonces: map[common.Address]uint64{previewSender: 12},
+	}
+	block := big.NewInt(19000000)
+	other := common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+	state, err := accountAt(context.Background(), backend, previewSender, []common.Address{previewToken, other}, block)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(12), state.Nonce)
+	assert.Equal(t, big.NewInt(7e17), state.Balance)
+	require.Len(t, state.Tokens, 2)
+	assert.Equal(t, big.NewInt(2500000), state.Tokens[0])
+	assert.NoError(t, state.TokenErrors[0])
+	assert.Nil(t, state.Tokens[1], "a contract that reverts has no balance")
+	assert.Error(t, state.TokenErrors[1])
+
+	for _, asked := range backend.blocks {
+		assert.Equal(t, block, asked, "every read is pinned to the block")
+	}
+}
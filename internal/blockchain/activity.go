@@ -0,0 +1,99 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"blocowallet/pkg/config"
+)
+
+// activityClient is the part of Ethereum used to tell whether an address was used
+type activityClient interface {
+	GetBalance(ctx context.Context, address string) (*big.Int, error)
+	GetTransactionCount(ctx context.Context, address string) (uint64, error)
+	Close()
+}
+
+type activityNetwork struct {
+	name   string
+	client activityClient
+}
+
+// ActivityChecker tells whether an address was used on any of a set of
+// networks. An address counts as used when it holds a balance or has sent a
+// transaction; addresses that only ever received tokens are not detected.
+type ActivityChecker struct {
+	networks []activityNetwork
+}
+
+// NewActivityChecker connects to the active networks that have an RPC endpoint
+func NewActivityChecker(networks map[string]config.Network) (*ActivityChecker, error) {
+	keys := make([]string, 0, len(networks))
+	for key, network := range networks {
+		if network.Usable() && network.RPCEndpoint != "" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no active network with an RPC endpoint")
+	}
+	sort.Strings(keys)
+
+	checker := &ActivityChecker{}
+	for _, key := range keys {
+		network := networks[key]
+		client, err := NewEthereum(network.RPCEndpoint, DefaultTimeout, network.Symbol, 18, network.Name)
+		if err != nil {
+			checker.Close()
+			return nil, fmt.Errorf("%s: %w", network.Name, err)
+		}
+		checker.networks = append(checker.networks, activityNetwork{name: network.Name, client: client})
+	}
+	return checker, nil
+}
+
+// HasHistory reports whether address was used on at least one network. Errors
+// are only returned when no network could answer, so a single unreachable
+// endpoint does not stop a scan.
+func (c *ActivityChecker) HasHistory(ctx context.Context, address string) (bool, error) {
+	var errs []error
+	for _, n := range c.networks {
+		used, err := addressUsed(ctx, n.client, address)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", n.name, err))
+			continue
+		}
+		if used {
+			return true, nil
+		}
+	}
+	if len(errs) == len(c.networks) {
+		return false, errors.Join(errs...)
+	}
+	return false, nil
+}
+
+func addressUsed(ctx context.Context, client activityClient, address string) (bool, error) {
+	nonce, err := client.GetTransactionCount(ctx, address)
+	if err != nil {
+		return false, err
+	}
+	if nonce > 0 {
+		return true, nil
+	}
+	balance, err := client.GetBalance(ctx, address)
+	if err != nil {
+		return false, err
+	}
+	return balance.Sign() > 0, nil
+}
+
+// Close closes the network connections
+func (c *ActivityChecker) Close() {
+	for _, n := range c.networks {
+		n.client.Close()
+	}
+}
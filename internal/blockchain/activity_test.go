@@ -0,0 +1,69 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"blocowallet/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeActivityClient struct {
+	nonce   uint64
+	balance int64
+	err     error
+}
+
+func (f *fakeActivityClient) GetBalance(ctx context.Context, address string) (*big.Int, error) {
+	return big.NewInt(f.balance), f.err
+}
+
+func (f *fakeActivityClient) GetTransactionCount(ctx context.Context, address string) (uint64, error) {
+	return f.nonce, f.err
+}
+
+func (f *fakeActivityClient) Close() {}
+
+func TestActivityChecker_HasHistory(t *testing.T) {
+	ctx := context.Background()
+	checker := func(clients ...*fakeActivityClient) *ActivityChecker {
+		c := &ActivityChecker{}
+		for _, client := range clients {
+			c.networks = append(c.networks, activityNetwork{name: "net", client: client})
+		}
+		return c
+	}
+
+	used, err := checker(&fakeActivityClient{}, &fakeActivityClient{nonce: 3}).HasHistory(ctx, "0x1")
+	require.NoError(t, err)
+	assert.True(t, used)
+
+	used, err = checker(&fakeActivityClient{balance: 1}).HasHistory(ctx, "0x1")
+	require.NoError(t, err)
+	assert.True(t, used)
+
+	used, err = checker(&fakeActivityClient{}, &fakeActivityClient{}).HasHistory(ctx, "0x1")
+	require.NoError(t, err)
+	assert.False(t, used)
+
+	// One unreachable network does not fail the check
+	down := &fakeActivityClient{err: errors.New("connection refused")}
+	used, err = checker(down, &fakeActivityClient{}).HasHistory(ctx, "0x1")
+	require.NoError(t, err)
+	assert.False(t, used)
+
+	_, err = checker(down, down).HasHistory(ctx, "0x1")
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestNewActivityChecker_NoActiveNetwork(t *testing.T) {
+	_, err := NewActivityChecker(map[string]config.Network{
+		"off":    {Name: "Off", RPCEndpoint: "http://127.0.0.1:1", IsActive: false},
+		"no_rpc": {Name: "No RPC", IsActive: true},
+	})
+	assert.Error(t, err)
+}
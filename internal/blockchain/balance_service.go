@@ -0,0 +1,154 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"blocowallet/internal/metrics"
+	"blocowallet/pkg/config"
+)
+
+// DefaultBalanceTTL is how long a fetched balance is reused before the
+// network is asked again
+const DefaultBalanceTTL = 30 * time.Second
+
+// balanceTimeout bounds each eth_getBalance, so one slow network does not
+// hold the others back
+const balanceTimeout = 10 * time.Second
+
+// BalanceService fetches the native balance of an address on many networks
+// at once and caches the answers for a TTL
+type BalanceService struct {
+	ttl  time.Duration
+	dial func(network config.Network) (BalanceProvider, error)
+	now  func() time.Time
+
+	mu    sync.Mutex
+	cache map[balanceKey]NetworkBalance
+}
+
+// balanceKey identifies a balance; the endpoint is part of it so changing a
+// network's RPC, or a wallet override, is not answered from the cache
+type balanceKey struct {
+	address  string
+	chainID  int64
+	endpoint string
+}
+
+// NewBalanceService returns a service that caches balances for ttl
+func NewBalanceService(ttl time.Duration) *BalanceService {
+	return &BalanceService{
+		ttl: ttl,
+		dial: func(n config.Network) (BalanceProvider, error) {
+			return NewEthereum(n.RPCEndpoint, balanceTimeout, n.Symbol, 18, n.Name)
+		},
+		now:   time.Now,
+		cache: make(map[balanceKey]NetworkBalance),
+	}
+}
+
+// Fetch queries the balance of address on every usable network with an RPC
+// endpoint, concurrently. Each result is sent on the returned channel as soon
+// as it arrives, fresh cached ones first; the channel is closed once all
+// networks answered. Failed queries are sent with Error set and not cached.
+func (s *BalanceService) Fetch(ctx context.Context, address string, networks map[string]config.Network) <-chan NetworkBalance {
+	results := make(chan NetworkBalance, len(networks))
+	var wg sync.WaitGroup
+	for key, network := range networks {
+		if !network.Usable() || network.RPCEndpoint == "" {
+			continue
+		}
+		if cached, ok := s.cached(address, network); ok {
+			cached.NetworkKey = key
+			results <- cached
+			continue
+		}
+		wg.Add(1)
+		go func(key string, network config.Network) {
+			defer wg.Done()
+			balance := s.query(ctx, address, key, network)
+			metrics.BalanceRefreshes.Inc(metrics.Result(balance.Error))
+			results <- balance
+		}(key, network)
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+// Invalidate forgets the cached balances of address, so the next Fetch asks
+// every network again
+func (s *BalanceService) Invalidate(address string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key := range s.cache {
+		if key.address == strings.ToLower(address) {
+			delete(s.cache, key)
+		}
+	}
+}
+
+// FundedAddresses lists the addresses with a non-zero balance cached on the
+// chain, stale or not, sorted
+func (s *BalanceService) FundedAddresses(chainID int64) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seen := make(map[string]bool)
+	var addresses []string
+	for key, b := range s.cache {
+		if key.chainID == chainID && b.Amount != nil && b.Amount.Sign() > 0 && !seen[key.address] {
+			seen[key.address] = true
+			addresses = append(addresses, key.address)
+		}
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+func (s *BalanceService) cached(address string, network config.Network) (NetworkBalance, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.cache[keyOf(address, network)]
+	if !ok || s.now().Sub(b.FetchedAt) >= s.ttl {
+		return NetworkBalance{}, false
+	}
+	return b, true
+}
+
+func (s *BalanceService) query(ctx context.Context, address, key string, network config.Network) NetworkBalance {
+	balance := NetworkBalance{NetworkKey: key, NetworkName: network.Name, Symbol: network.Symbol, Decimals: 18}
+	provider, err := s.dial(network)
+	if err != nil {
+		balance.Error = fmt.Errorf("failed to connect to %s: %w", network.Name, err)
+		return balance
+	}
+	if closer, ok := provider.(interface{ Close() }); ok {
+		defer closer.Close()
+	}
+	balance.Decimals = provider.GetNetworkDecimals()
+
+	ctx, cancel := context.WithTimeout(ctx, balanceTimeout)
+	defer cancel()
+	amount, err := provider.GetBalance(ctx, address)
+	if err != nil {
+		balance.Error = fmt.Errorf("failed to get balance on %s: %w", network.Name, err)
+		return balance
+	}
+	balance.Amount = amount
+	balance.FetchedAt = s.now()
+
+	s.mu.Lock()
+	s.cache[keyOf(address, network)] = balance
+	s.mu.Unlock()
+	return balance
+}
+
+func keyOf(address string, network config.Network) balanceKey {
+	return balanceKey{address: strings.ToLower(address), chainID: network.ChainID, endpoint: network.RPCEndpoint}
+}
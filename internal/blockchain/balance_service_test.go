@@ -0,0 +1,103 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"blocowallet/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBalanceProvider answers with a fixed balance or error
+type fakeBalanceProvider struct {
+	amount *big.Int
+	err    error
+}
+
+func (f fakeBalanceProvider) GetBalance(ctx context.Context, address string) (*big.Int, error) {
+	return f.amount, f.err
+}
+
+func (f fakeBalanceProvider) GetNetworkSymbol() string { return "ETH" }
+func (f fakeBalanceProvider) GetNetworkDecimals() int  { return 18 }
+
+func collectBalances(ch <-chan NetworkBalance) map[string]NetworkBalance {
+	out := map[string]NetworkBalance{}
+	for b := range ch {
+		out[b.NetworkKey] = b
+	}
+	return out
+}
+
+func TestBalanceService_FetchAndCache(t *testing.T) {
+	var queries atomic.Int32
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewBalanceService(time.Minute)
+	s.now = func() time.Time { return now }
+	s.dial = func(n config.Network) (BalanceProvider, error) {
+		queries.Add(1)
+		if n.ChainID == 137 {
+			return fakeBalanceProvider{err: errors.New("rate limited")}, nil
+		}
+		return fakeBalanceProvider{amount: big.NewInt(n.ChainID)}, nil
+	}
+	networks := map[string]config.Network{
+		"mainnet":  {Name: "Ethereum", ChainID: 1, RPCEndpoint: "http://a", IsActive: true},
+		"sepolia":  {Name: "Sepolia", ChainID: 11155111, RPCEndpoint: "http://b", IsActive: true},
+		"polygon":  {Name: "Polygon", ChainID: 137, RPCEndpoint: "http://c", IsActive: true},
+		"inactive": {Name: "Off", ChainID: 10, RPCEndpoint: "http://d"},
+		"no-rpc":   {Name: "None", ChainID: 8453, IsActive: true},
+	}
+
+	got := collectBalances(s.Fetch(context.Background(), "0xAbC", networks))
+	require.Len(t, got, 3)
+	assert.Equal(t, big.NewInt(11155111), got["sepolia"].Amount)
+	assert.Equal(t, now, got["sepolia"].FetchedAt)
+	assert.Error(t, got["polygon"].Error)
+	assert.Equal(t, int32(3), queries.Load())
+
+	// Within the TTL only the failed network is asked again
+	got = collectBalances(s.Fetch(context.Background(), "0xabc", networks))
+	require.Len(t, got, 3)
+	assert.Equal(t, big.NewInt(1), got["mainnet"].Amount)
+	assert.Equal(t, int32(4), queries.Load())
+
+	// A new endpoint is not answered from the cache
+	moved := networks["mainnet"]
+	moved.RPCEndpoint = "http://own-node"
+	collectBalances(s.Fetch(context.Background(), "0xabc", map[string]config.Network{"mainnet": moved}))
+	assert.Equal(t, int32(5), queries.Load())
+
+	now = now.Add(time.Minute)
+	collectBalances(s.Fetch(context.Background(), "0xabc", networks))
+	assert.Equal(t, int32(8), queries.Load(), "expired")
+
+	s.Invalidate("0xABC")
+	collectBalances(s.Fetch(context.Background(), "0xabc", networks))
+	assert.Equal(t, int32(11), queries.Load())
+}
+
+func TestBalanceService_FundedAddresses(t *testing.T) {
+	s := NewBalanceService(time.Minute)
+	s.dial = func(n config.Network) (BalanceProvider, error) {
+		if n.RPCEndpoint == "http://empty" {
+			return fakeBalanceProvider{amount: big.NewInt(0)}, nil
+		}
+		return fakeBalanceProvider{amount: big.NewInt(1)}, nil
+	}
+	mainnet := config.Network{Name: "Ethereum", ChainID: 1, RPCEndpoint: "http://a", IsActive: true}
+	mirror := config.Network{Name: "Ethereum mirror", ChainID: 1, RPCEndpoint: "http://b", IsActive: true}
+	empty := config.Network{Name: "Ethereum empty", ChainID: 1, RPCEndpoint: "http://empty", IsActive: true}
+	collectBalances(s.Fetch(context.Background(), "0xBBB", map[string]config.Network{"mainnet": mainnet, "mirror": mirror}))
+	collectBalances(s.Fetch(context.Background(), "0xAAA", map[string]config.Network{"mainnet": mainnet}))
+	collectBalances(s.Fetch(context.Background(), "0xCCC", map[string]config.Network{"empty": empty}))
+
+	assert.Equal(t, []string{"0xaaa", "0xbbb"}, s.FundedAddresses(1), "zero balances are left out")
+	assert.Empty(t, s.FundedAddresses(137))
+}
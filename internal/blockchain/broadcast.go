@@ -0,0 +1,127 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxState is where a broadcast transaction stands
+type TxState string
+
+const (
+	TxPending  TxState = "pending"   // known to the node, not in a block yet
+	TxIncluded TxState = "included"  // mined and executed successfully
+	TxReverted TxState = "reverted"  // mined, but execution failed
+	TxNotFound TxState = "not_found" // unknown to the node, e.g. dropped
+)
+
+// TxStatus reports the state of a transaction on one network
+type TxStatus struct {
+	Hash          common.Hash
+	State         TxState
+	BlockNumber   uint64 // set once included
+	Confirmations uint64 // blocks on top of the including block, plus one
+}
+
+// ErrChainMismatch is returned when a transaction is sent to a network of
+// another chain, where it would be rejected or, without EIP-155, replayed
+var ErrChainMismatch = errors.New("transaction chain ID does not match the network")
+
+// txBackend is the part of ethclient.Client used to send and follow transactions
+type txBackend interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// Broadcast sends a signed transaction after checking it is for this network's chain
+func (e *Ethereum) Broadcast(ctx context.Context, tx *types.Transaction) error {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+	return broadcast(ctx, e.client, tx, e.chainName)
+}
+
+// TransactionStatus looks up a transaction by hash
+func (e *Ethereum) TransactionStatus(ctx context.Context, hash common.Hash) (TxStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+	return transactionStatus(ctx, e.client, hash)
+}
+
+// WaitForInclusion polls the transaction status until it is in a block or
+// wait has passed, returning the last status seen
+func (e *Ethereum) WaitForInclusion(ctx context.Context, hash common.Hash, wait, interval time.Duration) (TxStatus, error) {
+	return waitForInclusion(ctx, e.client, hash, wait, interval)
+}
+
+func broadcast(ctx context.Context, backend txBackend, tx *types.Transaction, networkName string) error {
+	chainID, err := backend.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read chain ID of %s: %w", networkName, err)
+	}
+	// Pre-EIP-155 legacy transactions carry no chain ID and are accepted anywhere
+	if tx.Protected() && tx.ChainId().Cmp(chainID) != 0 {
+		return fmt.Errorf("%w: the transaction is for chain %s, %s is chain %s", ErrChainMismatch, tx.ChainId(), networkName, chainID)
+	}
+	if err := backend.SendTransaction(ctx, tx); err != nil {
+		return fmt.Errorf("failed to broadcast on %s: %w", networkName, err)
+	}
+	return nil
+}
+
+func transactionStatus(ctx context.Context, backend txBackend, hash common.Hash) (TxStatus, error) {
+	status := TxStatus{Hash: hash, State: TxNotFound}
+	receipt, err := backend.TransactionReceipt(ctx, hash)
+	switch {
+	case err == nil:
+		status.BlockNumber = receipt.BlockNumber.Uint64()
+		status.State = TxIncluded
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			status.State = TxReverted
+		}
+		head, err := backend.BlockNumber(ctx)
+		if err != nil {
+			return status, err
+		}
+		if head >= status.BlockNumber {
+			status.Confirmations = head - status.BlockNumber + 1
+		}
+		return status, nil
+	case !errors.Is(err, ethereum.NotFound):
+		return status, err
+	}
+
+	// No receipt yet: the node may still hold it in the mempool
+	if _, _, err := backend.TransactionByHash(ctx, hash); err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return status, nil
+		}
+		return status, err
+	}
+	status.State = TxPending
+	return status, nil
+}
+
+func waitForInclusion(ctx context.Context, backend txBackend, hash common.Hash, wait, interval time.Duration) (TxStatus, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		status, err := transactionStatus(ctx, backend, hash)
+		if err != nil || status.State == TxIncluded || status.State == TxReverted || !time.Now().Add(interval).Before(deadline) {
+			return status, err
+		}
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
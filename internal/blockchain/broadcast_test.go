@@ -0,0 +1,116 @@
+package blockchain
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTxBackend holds sent transactions in a mempool until mine is called
+type fakeTxBackend struct {
+	chainID  int64
+	head     uint64
+	mempool  map[common.Hash]*types.Transaction
+	receipts map[common.Hash]*types.Receipt
+}
+
+func newFakeTxBackend(chainID int64) *fakeTxBackend {
+	return &fakeTxBackend{chainID: chainID, head: 100, mempool: map[common.Hash]*types.Transaction{}, receipts: map[common.Hash]*types.Receipt{}}
+}
+
+func (f *fakeTxBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(f.chainID), nil
+}
+
+func (f *fakeTxBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	f.mempool[tx.Hash()] = tx
+	return nil
+}
+
+func (f *fakeTxBackend) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	if tx, ok := f.mempool[hash]; ok {
+		return tx, true, nil
+	}
+	return nil, false, ethereum.NotFound
+}
+
+func (f *fakeTxBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	if r, ok := f.receipts[hash]; ok {
+		return r, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func (f *fakeTxBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	return f.head, nil
+}
+
+func (f *fakeTxBackend) mine(hash common.Hash, status uint64) {
+	f.head++
+	delete(f.mempool, hash)
+	f.receipts[hash] = &types.Receipt{Status: status, BlockNumber: new(big.Int).SetUint64(f.head)}
+}
+
+func signedTestTx(t *testing.T, chainID int64) *types.Transaction {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(chainID)), &types.DynamicFeeTx{
+		ChainID: big.NewInt(chainID), GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2), Gas: 21000, To: &to, Value: big.NewInt(1),
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func TestBroadcast(t *testing.T) {
+	ctx := context.Background()
+	backend := newFakeTxBackend(11155111)
+	tx := signedTestTx(t, 11155111)
+
+	status, err := transactionStatus(ctx, backend, tx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, TxNotFound, status.State)
+
+	require.NoError(t, broadcast(ctx, backend, tx, "Sepolia"))
+	status, err = transactionStatus(ctx, backend, tx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, TxPending, status.State)
+
+	backend.mine(tx.Hash(), types.ReceiptStatusSuccessful)
+	backend.head += 2
+	status, err = transactionStatus(ctx, backend, tx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, TxIncluded, status.State)
+	assert.Equal(t, uint64(101), status.BlockNumber)
+	assert.Equal(t, uint64(3), status.Confirmations)
+
+	// A transaction for another chain is not sent
+	other := signedTestTx(t, 1)
+	err = broadcast(ctx, backend, other, "Sepolia")
+	assert.ErrorIs(t, err, ErrChainMismatch)
+	assert.Empty(t, backend.mempool)
+}
+
+func TestWaitForInclusion(t *testing.T) {
+	ctx := context.Background()
+	backend := newFakeTxBackend(1)
+	tx := signedTestTx(t, 1)
+	require.NoError(t, broadcast(ctx, backend, tx, "Ethereum"))
+
+	status, err := waitForInclusion(ctx, backend, tx.Hash(), 30*time.Millisecond, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, TxPending, status.State, "gives up after the wait")
+
+	backend.mine(tx.Hash(), types.ReceiptStatusFailed)
+	status, err = waitForInclusion(ctx, backend, tx.Hash(), time.Minute, time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, TxReverted, status.State)
+}
@@ -12,6 +12,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"blocowallet/pkg/config"
 )
 
 // ErrChainlistUnavailable is returned when ChainList API cannot be reached or responds with an error
@@ -38,11 +40,20 @@ type ChainInfo struct {
 		Name string `json:"name"`
 		URL  string `json:"url"`
 	} `json:"explorers"`
+	Faucets   []string `json:"faucets,omitempty"`
+	IsTestnet bool     `json:"isTestnet,omitempty"`
+}
+
+// Testnet reports whether the chain is a test network: flagged so by
+// chainlist, listing a faucet, or known by its chain ID or name
+func (c ChainInfo) Testnet() bool {
+	return c.IsTestnet || len(c.Faucets) > 0 || config.LooksLikeTestnet(int64(c.ChainID), c.Name)
 }
 
 // ChainListService handles interaction with ChainList API
 type ChainListService struct {
-	client      *http.Client
+	client      *http.Client // chainlist.org requests
+	rpcClient   *http.Client // requests to the RPC endpoints being checked
 	baseURL     string
 	chains      []ChainInfo
 	cacheMu     sync.RWMutex
@@ -68,16 +79,27 @@ type RPCConnectionResult struct {
 // NewChainListService creates a new ChainList service
 func NewChainListService() *ChainListService {
 	return &ChainListService{
-		client: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		baseURL: "https://chainlist.org",
-		chains:  make([]ChainInfo, 0),
+		client:    NewHTTPClient(ProxyChainList, 10*time.Second),
+		rpcClient: NewHTTPClient(ProxyRPC, 10*time.Second),
+		baseURL:   "https://chainlist.org",
+		chains:    make([]ChainInfo, 0),
 	}
 }
 
-// GetChainInfo fetches chain information by chain ID
+// GetChainInfo fetches chain information by chain ID, falling back to the
+// bundled snapshot when chainlist.org cannot be reached
 func (s *ChainListService) GetChainInfo(chainID int) (*ChainInfo, error) {
+	chain, err := s.fetchChainInfo(chainID)
+	if errors.Is(err, ErrChainlistUnavailable) {
+		if bundled, ok := snapshotChain(chainID); ok {
+			return bundled, nil
+		}
+	}
+	return chain, err
+}
+
+// fetchChainInfo fetches chain information by chain ID from chainlist.org
+func (s *ChainListService) fetchChainInfo(chainID int) (*ChainInfo, error) {
 	url := fmt.Sprintf("%s/rpcs.json", s.baseURL)
 
 	resp, err := s.client.Get(url)
@@ -124,7 +146,7 @@ func (s *ChainListService) ValidateRPCEndpoint(rpcURL string) error {
 	// Create a simple JSON-RPC request to check if the endpoint is alive
 	reqBody := `{"jsonrpc":"2.0","method":"eth_chainId","params":[],"id":1}`
 
-	resp, err := s.client.Post(rpcURL, "application/json",
+	resp, err := s.rpcClient.Post(rpcURL, "application/json",
 		strings.NewReader(reqBody))
 	if err != nil {
 		return NewNetworkOperationError("validate", "RPC endpoint is not accessible", err)
@@ -144,7 +166,7 @@ func (s *ChainListService) ValidateRPCEndpoint(rpcURL string) error {
 func (s *ChainListService) GetChainIDFromRPC(rpcURL string) (int, error) {
 	reqBody := `{"jsonrpc":"2.0","method":"eth_chainId","params":[],"id":1}`
 
-	resp, err := s.client.Post(rpcURL, "application/json",
+	resp, err := s.rpcClient.Post(rpcURL, "application/json",
 		strings.NewReader(reqBody))
 	if err != nil {
 		return 0, NewNetworkOperationError("validate", "failed to call RPC", err)
@@ -182,7 +204,8 @@ func (s *ChainListService) GetChainIDFromRPC(rpcURL string) (int, error) {
 	return int(chainID), nil
 }
 
-// loadChains loads and caches chain data from ChainList API with simple retry/backoff
+// loadChains loads and caches chain data from ChainList API. When it cannot
+// be reached the bundled snapshot stands in until snapshotRetry has passed.
 func (s *ChainListService) loadChains() error {
 	s.cacheMu.Lock()
 	defer s.cacheMu.Unlock()
@@ -192,6 +215,22 @@ func (s *ChainListService) loadChains() error {
 		return nil
 	}
 
+	chains, err := s.fetchChains()
+	if err != nil {
+		if !errors.Is(err, ErrChainlistUnavailable) {
+			return err
+		}
+		s.chains = bundledChainList().Chains
+		s.cacheExpiry = time.Now().Add(snapshotRetry)
+		return nil
+	}
+	s.chains = chains
+	s.cacheExpiry = time.Now().Add(24 * time.Hour)
+	return nil
+}
+
+// fetchChains downloads the chain list with simple retry/backoff
+func (s *ChainListService) fetchChains() ([]ChainInfo, error) {
 	url := fmt.Sprintf("%s/rpcs.json", s.baseURL)
 
 	var lastErr error
@@ -203,7 +242,7 @@ func (s *ChainListService) loadChains() error {
 				time.Sleep(time.Duration(300*(1<<attempt)) * time.Millisecond)
 				continue
 			}
-			return NewNetworkOperationError("search", "failed to fetch chain list", fmt.Errorf("%w: %v", ErrChainlistUnavailable, err))
+			return nil, NewNetworkOperationError("search", "failed to fetch chain list", fmt.Errorf("%w: %v", ErrChainlistUnavailable, err))
 		}
 
 		// Ensure body is closed each iteration
@@ -215,7 +254,7 @@ func (s *ChainListService) loadChains() error {
 				time.Sleep(time.Duration(300*(1<<attempt)) * time.Millisecond)
 				continue
 			}
-			return NewNetworkOperationError("search", "failed to read ChainList response", fmt.Errorf("%w: %v", ErrChainlistUnavailable, readErr))
+			return nil, NewNetworkOperationError("search", "failed to read ChainList response", fmt.Errorf("%w: %v", ErrChainlistUnavailable, readErr))
 		}
 
 		if resp.StatusCode != http.StatusOK {
@@ -225,22 +264,19 @@ func (s *ChainListService) loadChains() error {
 				time.Sleep(time.Duration(300*(1<<attempt)) * time.Millisecond)
 				continue
 			}
-			return NewNetworkOperationError("search", "ChainList API error", fmt.Errorf("%w: %v", ErrChainlistUnavailable, lastErr))
+			return nil, NewNetworkOperationError("search", "ChainList API error", fmt.Errorf("%w: %v", ErrChainlistUnavailable, lastErr))
 		}
 
 		var chains []ChainInfo
 		if err := json.Unmarshal(bodyBytes, &chains); err != nil {
-			return NewNetworkOperationError("search", "failed to parse ChainList response", fmt.Errorf("%w: %v", ErrChainlistUnavailable, err))
+			return nil, NewNetworkOperationError("search", "failed to parse ChainList response", fmt.Errorf("%w: %v", ErrChainlistUnavailable, err))
 		}
 
-		// Success: cache and return
-		s.chains = chains
-		s.cacheExpiry = time.Now().Add(24 * time.Hour)
-		return nil
+		return chains, nil
 	}
 
 	// Exhausted attempts
-	return NewNetworkOperationError("search", "unable to fetch network data from ChainList", lastErr)
+	return nil, NewNetworkOperationError("search", "unable to fetch network data from ChainList", fmt.Errorf("%w: %v", ErrChainlistUnavailable, lastErr))
 }
 
 // isTransientNetworkError determines whether an error is likely transient
@@ -432,7 +468,7 @@ func (s *ChainListService) testRPCEndpoint(rpcURL string, expectedChainID int) (
 	}
 
 	// Create a client with shorter timeout for testing
-	client := &http.Client{Timeout: 5 * time.Second}
+	client := NewHTTPClient(ProxyRPC, 5*time.Second)
 
 	reqBody := `{"jsonrpc":"2.0","method":"eth_chainId","params":[],"id":1}`
 
@@ -0,0 +1,46 @@
+package blockchain
+
+import (
+	_ "embed"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+//go:embed chainlist_snapshot.json
+var bundledChainListJSON []byte
+
+// snapshotRetry is how long the bundled snapshot stands in for chainlist.org
+// before another download is tried
+const snapshotRetry = 5 * time.Minute
+
+// ChainListSnapshot is the copy of the chainlist.org data bundled with the
+// binary, so well-known networks can be added and searched offline
+type ChainListSnapshot struct {
+	Version string      `json:"version"`
+	Source  string      `json:"source"`
+	Chains  []ChainInfo `json:"chains"`
+}
+
+var bundledChainList = sync.OnceValue(func() ChainListSnapshot {
+	var snapshot ChainListSnapshot
+	if err := json.Unmarshal(bundledChainListJSON, &snapshot); err != nil {
+		panic("bundled chainlist snapshot: " + err.Error())
+	}
+	return snapshot
+})
+
+// BundledChainList returns the chainlist snapshot bundled with the binary
+func BundledChainList() ChainListSnapshot {
+	return bundledChainList()
+}
+
+// snapshotChain returns the bundled entry for chainID
+func snapshotChain(chainID int) (*ChainInfo, bool) {
+	for _, chain := range bundledChainList().Chains {
+		if chain.ChainID == chainID {
+			return &chain, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,82 @@
+package blockchain
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundledChainList(t *testing.T) {
+	snapshot := BundledChainList()
+	assert.NotEmpty(t, snapshot.Version)
+	seen := make(map[int]bool)
+	for _, chain := range snapshot.Chains {
+		assert.False(t, seen[chain.ChainID], "chain %d listed twice", chain.ChainID)
+		seen[chain.ChainID] = true
+		assert.NotEmpty(t, chain.Name)
+		assert.NotEmpty(t, chain.NativeCurrency.Symbol)
+		assert.NotEmpty(t, chain.RPC, chain.Name)
+	}
+	assert.True(t, seen[1])
+	assert.True(t, seen[11155111])
+}
+
+func TestChainListService_FallsBackToSnapshot(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	s := NewChainListService()
+	s.baseURL = server.URL
+
+	chain, err := s.GetChainInfo(8453)
+	require.NoError(t, err)
+	assert.Equal(t, "Base", chain.Name)
+
+	_, err = s.GetChainInfo(987654321)
+	assert.ErrorIs(t, err, ErrChainlistUnavailable)
+
+	suggestions, err := s.SearchNetworksByName("sepolia")
+	require.NoError(t, err)
+	assert.NotEmpty(t, suggestions)
+	assert.WithinDuration(t, time.Now().Add(snapshotRetry), s.cacheExpiry, time.Minute)
+}
+
+func TestChainListService_PrefersLiveList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"chainId":8453,"name":"Base Live","rpc":[{"url":"https://live.example"}]}]`))
+	}))
+	defer server.Close()
+	s := NewChainListService()
+	s.baseURL = server.URL
+
+	chain, err := s.GetChainInfo(8453)
+	require.NoError(t, err)
+	assert.Equal(t, "Base Live", chain.Name)
+
+	_, err = s.GetChainInfo(1)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrChainlistUnavailable)
+}
+
+func TestBundledSignatures(t *testing.T) {
+	version, count := BundledSignatures()
+	assert.NotEmpty(t, version)
+	assert.Greater(t, count, 50)
+}
+
+func TestChainInfo_Testnet(t *testing.T) {
+	for _, chain := range BundledChainList().Chains {
+		assert.Equal(t, chain.IsTestnet, chain.Testnet(), chain.Name)
+	}
+	sepolia, ok := snapshotChain(11155111)
+	require.True(t, ok)
+	assert.True(t, sepolia.Testnet())
+
+	// A faucet or the name marks the chains chainlist does not flag
+	assert.True(t, ChainInfo{ChainID: 999999, Name: "Acme", Faucets: []string{"https://faucet.acme.example"}}.Testnet())
+	assert.True(t, ChainInfo{ChainID: 999999, Name: "Acme Devnet"}.Testnet())
+	assert.False(t, ChainInfo{ChainID: 999999, Name: "Acme"}.Testnet())
+}
@@ -0,0 +1,72 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrNoChains is returned when a chains file holds no chain
+var ErrNoChains = errors.New("the file lists no chains")
+
+// UnmarshalJSON accepts an endpoint given as a plain URL, as in the
+// chainid.network chains.json, as well as the object used by chainlist.org
+func (e *RPCEndpoint) UnmarshalJSON(data []byte) error {
+	var plain string
+	if err := json.Unmarshal(data, &plain); err == nil {
+		*e = RPCEndpoint{URL: plain}
+		return nil
+	}
+	type endpoint RPCEndpoint
+	var object endpoint
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	*e = RPCEndpoint(object)
+	return nil
+}
+
+// ParseChainsFile reads a chainlist-style JSON export: an array of chains with
+// their name, chainId, rpc and nativeCurrency. A document with the array under
+// "chains", like the bundled snapshot, is read too. The entries are not
+// checked here, so each one can be reported on its own.
+func ParseChainsFile(data []byte) ([]ChainInfo, error) {
+	var chains []ChainInfo
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var snapshot ChainListSnapshot
+		if err := json.Unmarshal(trimmed, &snapshot); err != nil {
+			return nil, fmt.Errorf("invalid chains file: %w", err)
+		}
+		chains = snapshot.Chains
+	} else if err := json.Unmarshal(data, &chains); err != nil {
+		return nil, fmt.Errorf("invalid chains file: %w", err)
+	}
+	if len(chains) == 0 {
+		return nil, ErrNoChains
+	}
+	return chains, nil
+}
+
+// ImportableRPCs returns the HTTP endpoints of the chain in their listed
+// order, leaving out websockets and templates such as ${INFURA_API_KEY}
+// that need a key filled in first
+func (c ChainInfo) ImportableRPCs() []string {
+	var urls []string
+	seen := make(map[string]bool)
+	for _, rpc := range c.RPC {
+		raw := strings.TrimSpace(rpc.URL)
+		if raw == "" || strings.Contains(raw, "${") || seen[raw] {
+			continue
+		}
+		parsed, err := url.Parse(raw)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			continue
+		}
+		seen[raw] = true
+		urls = append(urls, raw)
+	}
+	return urls
+}
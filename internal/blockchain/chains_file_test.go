@@ -0,0 +1,41 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChainsFile(t *testing.T) {
+	chains, err := ParseChainsFile([]byte(`[
+		{"name": "Devnet", "chainId": 1337, "nativeCurrency": {"name": "Dev", "symbol": "DEV", "decimals": 18},
+		 "rpc": ["https://mainnet.infura.io/v3/${INFURA_API_KEY}", "wss://devnet.example/ws", "https://devnet.example", "https://devnet.example"]},
+		{"name": "Testnet", "chainId": 4242, "nativeCurrency": {"symbol": "TST"},
+		 "rpc": [{"url": "http://127.0.0.1:9545", "tracking": "none"}]}
+	]`))
+	require.NoError(t, err)
+	require.Len(t, chains, 2)
+	assert.Equal(t, 1337, chains[0].ChainID)
+	assert.Equal(t, "DEV", chains[0].NativeCurrency.Symbol)
+	assert.Equal(t, []string{"https://devnet.example"}, chains[0].ImportableRPCs())
+	assert.Equal(t, []string{"http://127.0.0.1:9545"}, chains[1].ImportableRPCs())
+	assert.Equal(t, "none", chains[1].RPC[0].Tracking)
+}
+
+func TestParseChainsFile_SnapshotDocument(t *testing.T) {
+	chains, err := ParseChainsFile(bundledChainListJSON)
+	require.NoError(t, err)
+	assert.Len(t, chains, len(BundledChainList().Chains))
+}
+
+func TestParseChainsFile_Invalid(t *testing.T) {
+	_, err := ParseChainsFile([]byte(`[]`))
+	assert.ErrorIs(t, err, ErrNoChains)
+
+	_, err = ParseChainsFile([]byte(`{"name": "Devnet"}`))
+	assert.ErrorIs(t, err, ErrNoChains)
+
+	_, err = ParseChainsFile([]byte(`[{"chainId": "one"}]`))
+	assert.ErrorContains(t, err, "invalid chains file")
+}
@@ -0,0 +1,191 @@
+package blockchain
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"blocowallet/pkg/config"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ErrEndpointChanged marks verification failures that quarantine a network:
+// its endpoint no longer proves it serves the configured chain. An endpoint
+// that cannot be reached is not reported with it.
+var ErrEndpointChanged = errors.New("RPC endpoint failed verification")
+
+// EndpointCheck is the verification outcome of one network
+type EndpointCheck struct {
+	Key     string
+	Network config.Network
+	Err     error // nil when the endpoint was verified
+}
+
+// Quarantine reports whether the network failed verification, as opposed to
+// passing it or being unreachable
+func (c EndpointCheck) Quarantine() bool {
+	return errors.Is(c.Err, ErrEndpointChanged)
+}
+
+// chainBackend is the part of ethclient.Client used to identify a chain
+type chainBackend interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// VerifyEndpoint checks that a network's RPC endpoint is served over TLS with
+// a valid certificate, reports the configured chain ID and, when the network
+// pins one, has the expected genesis block. Endpoints on the local machine
+// may use plain HTTP.
+func VerifyEndpoint(ctx context.Context, network config.Network) error {
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+	if err := verifyTransport(ctx, network.RPCEndpoint, nil); err != nil {
+		return err
+	}
+	rpcClient, err := dialRPC(ctx, network.RPCEndpoint)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", network.Name, err)
+	}
+	client := ethclient.NewClient(rpcClient)
+	defer client.Close()
+	return verifyChain(ctx, client, network)
+}
+
+// VerifyEndpoints checks, in parallel, the active networks and those already
+// quarantined, so that a quarantine can be lifted. Results are sorted by key.
+func VerifyEndpoints(ctx context.Context, networks map[string]config.Network) []EndpointCheck {
+	var checks []EndpointCheck
+	for key, network := range networks {
+		if network.RPCEndpoint != "" && (network.IsActive || network.Quarantine != "") {
+			checks = append(checks, EndpointCheck{Key: key, Network: network})
+		}
+	}
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Key < checks[j].Key })
+
+	var wg sync.WaitGroup
+	for i := range checks {
+		wg.Add(1)
+		go func(check *EndpointCheck) {
+			defer wg.Done()
+			check.Err = VerifyEndpoint(ctx, check.Network)
+		}(&checks[i])
+	}
+	wg.Wait()
+	return checks
+}
+
+// ApplyEndpointChecks quarantines the networks that failed verification and
+// releases those that passed, returning the checks that changed a network
+func ApplyEndpointChecks(networks map[string]config.Network, checks []EndpointCheck) []EndpointCheck {
+	var changed []EndpointCheck
+	for _, check := range checks {
+		network, ok := networks[check.Key]
+		if !ok {
+			continue
+		}
+		switch {
+		case check.Quarantine():
+			if network.Quarantine == check.Err.Error() {
+				continue
+			}
+			network.Quarantine = check.Err.Error()
+		case check.Err == nil && network.Quarantine != "":
+			network.Quarantine = ""
+		default:
+			continue
+		}
+		networks[check.Key] = network
+		check.Network = network
+		changed = append(changed, check)
+	}
+	return changed
+}
+
+// verifyTransport requires TLS with a certificate valid for the host, trusted
+// by roots or, when nil, by the system. The handshake goes through the proxy
+// when RPC traffic is proxied.
+func verifyTransport(ctx context.Context, endpoint string, roots *x509.CertPool) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		// IPC paths never leave the machine
+		if err == nil && u.Scheme == "" {
+			return nil
+		}
+		return fmt.Errorf("%w: invalid endpoint URL %q", ErrEndpointChanged, endpoint)
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "https", "wss":
+	case "http", "ws":
+		if isLocalHost(u.Hostname()) {
+			return nil
+		}
+		return fmt.Errorf("%w: %s is not served over TLS", ErrEndpointChanged, u.Host)
+	default:
+		return fmt.Errorf("%w: unsupported scheme %q", ErrEndpointChanged, u.Scheme)
+	}
+
+	// Any HTTP answer proves the handshake succeeded
+	probe := *u
+	probe.Scheme = "https"
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, probe.String(), nil)
+	if err != nil {
+		return fmt.Errorf("%w: invalid endpoint URL %q", ErrEndpointChanged, endpoint)
+	}
+	client := NewHTTPClient(ProxyRPC, 0)
+	transport := client.Transport.(*http.Transport)
+	transport.TLSClientConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+	defer transport.CloseIdleConnections()
+	resp, err := client.Do(req)
+	if err != nil {
+		var verifyErr *tls.CertificateVerificationError
+		if errors.As(err, &verifyErr) {
+			return fmt.Errorf("%w: certificate of %s: %v", ErrEndpointChanged, u.Hostname(), verifyErr.Err)
+		}
+		return fmt.Errorf("failed to reach %s: %w", u.Host, err)
+	}
+	return resp.Body.Close()
+}
+
+// verifyChain compares the chain ID and, when pinned, the genesis hash
+func verifyChain(ctx context.Context, backend chainBackend, network config.Network) error {
+	chainID, err := backend.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read chain ID of %s: %w", network.Name, err)
+	}
+	if !chainID.IsInt64() || chainID.Int64() != network.ChainID {
+		return fmt.Errorf("%w: chain ID is %s, expected %d", ErrEndpointChanged, chainID, network.ChainID)
+	}
+	if network.GenesisHash == "" {
+		return nil
+	}
+	genesis, err := backend.HeaderByNumber(ctx, big.NewInt(0))
+	if err != nil {
+		return fmt.Errorf("failed to read genesis block of %s: %w", network.Name, err)
+	}
+	if genesis.Hash() != common.HexToHash(network.GenesisHash) {
+		return fmt.Errorf("%w: genesis hash is %s, expected %s", ErrEndpointChanged, genesis.Hash().Hex(), network.GenesisHash)
+	}
+	return nil
+}
+
+func isLocalHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
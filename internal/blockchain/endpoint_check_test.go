@@ -0,0 +1,109 @@
+package blockchain
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"blocowallet/pkg/config"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChain answers with a fixed chain ID and genesis header
+type fakeChain struct {
+	chainID int64
+	genesis *types.Header
+}
+
+func (f fakeChain) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(f.chainID), nil
+}
+
+func (f fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return f.genesis, nil
+}
+
+// chainIDServer answers eth_chainId over plain HTTP
+func chainIDServer(t *testing.T, chainID string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(req.ID) + `,"result":"` + chainID + `"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestVerifyTransport(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, verifyTransport(ctx, "http://127.0.0.1:8545", nil), "local nodes may use plain HTTP")
+	assert.NoError(t, verifyTransport(ctx, "ws://localhost:8546", nil))
+	assert.NoError(t, verifyTransport(ctx, "/var/run/geth.ipc", nil))
+	assert.ErrorIs(t, verifyTransport(ctx, "http://rpc.example.com", nil), ErrEndpointChanged)
+	assert.ErrorIs(t, verifyTransport(ctx, "ftp://rpc.example.com", nil), ErrEndpointChanged)
+
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	err := verifyTransport(ctx, server.URL, nil)
+	assert.ErrorIs(t, err, ErrEndpointChanged, "a certificate the system does not trust")
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	assert.NoError(t, verifyTransport(ctx, server.URL, roots))
+
+	// Nothing listening is not a verification failure
+	unreachable := httptest.NewTLSServer(http.NotFoundHandler())
+	unreachable.Close()
+	err = verifyTransport(ctx, unreachable.URL, roots)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrEndpointChanged)
+}
+
+func TestVerifyChain(t *testing.T) {
+	ctx := context.Background()
+	genesis := &types.Header{Number: big.NewInt(0), Difficulty: big.NewInt(1)}
+	backend := fakeChain{chainID: 1, genesis: genesis}
+
+	assert.NoError(t, verifyChain(ctx, backend, config.Network{ChainID: 1}))
+	assert.ErrorIs(t, verifyChain(ctx, backend, config.Network{ChainID: 10}), ErrEndpointChanged)
+	assert.NoError(t, verifyChain(ctx, backend, config.Network{ChainID: 1, GenesisHash: genesis.Hash().Hex()}))
+	err := verifyChain(ctx, backend, config.Network{ChainID: 1, GenesisHash: "0x01"})
+	assert.ErrorIs(t, err, ErrEndpointChanged)
+	assert.Contains(t, err.Error(), "genesis hash")
+}
+
+func TestVerifyEndpointsAndApply(t *testing.T) {
+	mainnet := chainIDServer(t, "0x1")
+	networks := map[string]config.Network{
+		"good":     {Name: "Good", RPCEndpoint: mainnet.URL, ChainID: 1, IsActive: true},
+		"hijacked": {Name: "Hijacked", RPCEndpoint: mainnet.URL, ChainID: 10, IsActive: true},
+		"released": {Name: "Released", RPCEndpoint: mainnet.URL, ChainID: 1, Quarantine: "old failure"},
+		"inactive": {Name: "Inactive", RPCEndpoint: "http://rpc.example.com", ChainID: 1},
+	}
+
+	checks := VerifyEndpoints(context.Background(), networks)
+	require.Len(t, checks, 3, "inactive networks are not checked")
+	assert.Equal(t, "good", checks[0].Key)
+	assert.NoError(t, checks[0].Err)
+	assert.True(t, checks[1].Quarantine())
+
+	changed := ApplyEndpointChecks(networks, checks)
+	require.Len(t, changed, 2)
+	assert.Contains(t, networks["hijacked"].Quarantine, "chain ID is 1, expected 10")
+	assert.False(t, networks["hijacked"].Usable())
+	assert.Empty(t, networks["released"].Quarantine)
+	assert.True(t, networks["good"].Usable())
+
+	// The same failure on the next start changes nothing
+	assert.Empty(t, ApplyEndpointChecks(networks, VerifyEndpoints(context.Background(), networks)))
+}
@@ -21,13 +21,13 @@ type Ethereum struct {
 
 // NewEthereum creates a new Ethereum balance provider
 func NewEthereum(rpcURL string, timeout time.Duration, symbol string, decimals int, chainName string) (*Ethereum, error) {
-	client, err := ethclient.Dial(rpcURL)
+	rpcClient, err := dialRPC(context.Background(), rpcURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
 	}
 
 	return &Ethereum{
-		client:    client,
+		client:    ethclient.NewClient(rpcClient),
 		timeout:   timeout,
 		symbol:    symbol,
 		decimals:  decimals,
@@ -54,6 +54,69 @@ func (e *Ethereum) GetBalance(ctx context.Context, address string) (*big.Int, er
 	return balance, nil
 }
 
+// GetTransactionCount gets the number of transactions sent from an address
+func (e *Ethereum) GetTransactionCount(ctx context.Context, address string) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	if !common.IsHexAddress(address) {
+		return 0, fmt.Errorf("invalid Ethereum address: %s", address)
+	}
+
+	nonce, err := e.client.NonceAt(ctx, common.HexToAddress(address), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get transaction count for address %s: %w", address, err)
+	}
+
+	return nonce, nil
+}
+
+// GetPendingNonce gets the nonce for the next transaction of an address,
+// counting transactions still in the mempool
+func (e *Ethereum) GetPendingNonce(ctx context.Context, address string) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	if !common.IsHexAddress(address) {
+		return 0, fmt.Errorf("invalid Ethereum address: %s", address)
+	}
+
+	nonce, err := e.client.PendingNonceAt(ctx, common.HexToAddress(address))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pending nonce for address %s: %w", address, err)
+	}
+
+	return nonce, nil
+}
+
+// SuggestFees suggests EIP-1559 fees: the node's priority fee, and a fee cap
+// of twice the latest base fee plus that priority fee
+func (e *Ethereum) SuggestFees(ctx context.Context) (maxFeePerGas, maxPriorityFeePerGas *big.Int, err error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	tip, err := e.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get priority fee: %w", err)
+	}
+	head, err := e.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	if head.BaseFee == nil {
+		return nil, nil, fmt.Errorf("%s does not support EIP-1559 fees", e.chainName)
+	}
+
+	maxFee := new(big.Int).Mul(head.BaseFee, big.NewInt(2))
+	return maxFee.Add(maxFee, tip), tip, nil
+}
+
+// Client returns the RPC client, e.g. as the backend of a
+// wallet.TransactionService
+func (e *Ethereum) Client() *ethclient.Client {
+	return e.client
+}
+
 // Close closes the Ethereum client connection
 func (e *Ethereum) Close() {
 	e.client.Close()
@@ -0,0 +1,379 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"blocowallet/pkg/config"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	// ErrAnvilNotFound is returned when Foundry's anvil is not installed
+	ErrAnvilNotFound = errors.New("anvil not found: install Foundry or set anvil_path under [fork]")
+	// ErrForkProxied is returned when the network is reached through the SOCKS5
+	// proxy, which anvil cannot use; forking would reveal the user's address
+	ErrForkProxied = errors.New("the RPC endpoint is reached through the proxy, which anvil cannot use")
+)
+
+// transferTopic is the topic of the ERC-20 Transfer(address,address,uint256) event
+var transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// ForkRunner starts local anvil forks of a network, so a transaction can be
+// executed against the real state before it is signed
+type ForkRunner struct {
+	path    string
+	timeout time.Duration
+}
+
+// NewForkRunner returns a runner using the anvil binary of cfg
+func NewForkRunner(cfg config.ForkConfig) *ForkRunner {
+	timeout := time.Duration(cfg.StartupTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = config.DefaultForkStartupTimeout * time.Second
+	}
+	return &ForkRunner{path: cfg.AnvilPath, timeout: timeout}
+}
+
+// Available reports whether the anvil binary can be found
+func (r *ForkRunner) Available() bool {
+	_, err := r.lookPath()
+	return err == nil
+}
+
+func (r *ForkRunner) lookPath() (string, error) {
+	name := r.path
+	if name == "" {
+		name = "anvil"
+	}
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return "", fmt.Errorf("%w (%v)", ErrAnvilNotFound, err)
+	}
+	return path, nil
+}
+
+// Fork is a running anvil fork listening on the local machine
+type Fork struct {
+	URL string
+
+	cmd     *exec.Cmd
+	output  *bytes.Buffer // anvil's stderr, read once it exited
+	exited  chan struct{}
+	rpc     *rpc.Client
+	client  *ethclient.Client
+	waitErr error
+}
+
+// Start forks network at its latest block on a free local port and waits
+// until the fork answers. The fork must be closed.
+func (r *ForkRunner) Start(ctx context.Context, network config.Network) (*Fork, error) {
+	path, err := r.lookPath()
+	if err != nil {
+		return nil, err
+	}
+	if network.RPCEndpoint == "" {
+		return nil, fmt.Errorf("%s has no RPC endpoint", network.Name)
+	}
+	if u, err := url.Parse(network.RPCEndpoint); err == nil && proxyFor(ProxyRPC, u.Hostname()) != nil {
+		return nil, ErrForkProxied
+	}
+	port, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+
+	// Not bound to ctx: the fork lives until Close
+	cmd := exec.Command(path, "--fork-url", network.RPCEndpoint, "--host", "127.0.0.1", "--port", strconv.Itoa(port))
+	// The banner lists the private keys of anvil's test accounts; none is used
+	cmd.Stdout = io.Discard
+	output := &bytes.Buffer{}
+	cmd.Stderr = output
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start anvil: %w", err)
+	}
+	fork := &Fork{URL: "http://127.0.0.1:" + strconv.Itoa(port), cmd: cmd, output: output, exited: make(chan struct{})}
+	go func() {
+		fork.waitErr = cmd.Wait()
+		close(fork.exited)
+	}()
+	if err := fork.waitReady(ctx, r.timeout); err != nil {
+		fork.Close()
+		return nil, err
+	}
+	return fork, nil
+}
+
+// waitReady polls the fork until it answers eth_chainId, anvil exits or
+// timeout passes
+func (f *Fork) waitReady(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	client, err := rpc.DialContext(ctx, f.URL)
+	if err != nil {
+		return err
+	}
+	f.rpc, f.client = client, ethclient.NewClient(client)
+
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		callCtx, callCancel := context.WithTimeout(ctx, time.Second)
+		_, err := f.client.ChainID(callCtx)
+		callCancel()
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-f.exited:
+			return fmt.Errorf("anvil exited: %s", lastLine(f.output.String(), f.waitErr))
+		case <-ctx.Done():
+			return fmt.Errorf("anvil did not answer within %s", timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Close stops anvil; the fork and its state are gone
+func (f *Fork) Close() {
+	if f.rpc != nil {
+		f.rpc.Close()
+	}
+	select {
+	case <-f.exited:
+	default:
+		_ = f.cmd.Process.Kill()
+		<-f.exited
+	}
+}
+
+// ForkResult is what a transaction did when executed on a fork
+type ForkResult struct {
+	Hash     common.Hash
+	Reverted bool
+	// RevertReason is set when the transaction reverted and the node said why
+	RevertReason string
+	GasUsed      uint64
+	Fee          *big.Int // Gas used at the effective gas price
+	// Changes are the native balances of the sender and recipient and every
+	// token balance moved by a Transfer event, read before and after the
+	// block of the transaction
+	Changes []BalanceChange
+}
+
+// forkBackend is the part of a running fork used to execute a transaction
+type forkBackend interface {
+	previewBackend
+	TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
+	impersonate(ctx context.Context, account common.Address) error
+	sendAs(ctx context.Context, from common.Address, tx *types.Transaction) (common.Hash, error)
+}
+
+// Execute sends tx on the fork as from, without its key, and reads what it
+// changed. symbol names the native currency.
+func (f *Fork) Execute(ctx context.Context, tx *types.Transaction, from common.Address, symbol string) (ForkResult, error) {
+	return executeOnFork(ctx, f, tx, from, symbol)
+}
+
+func (f *Fork) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return f.client.BalanceAt(ctx, account, blockNumber)
+}
+
+func (f *Fork) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return f.client.CallContract(ctx, msg, blockNumber)
+}
+
+func (f *Fork) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	return f.client.TransactionReceipt(ctx, hash)
+}
+
+func (f *Fork) impersonate(ctx context.Context, account common.Address) error {
+	return f.rpc.CallContext(ctx, nil, "anvil_impersonateAccount", account)
+}
+
+// sendAs sends tx unsigned from the impersonated account; anvil picks the
+// nonce, as the fork does not see transactions still pending on the network
+func (f *Fork) sendAs(ctx context.Context, from common.Address, tx *types.Transaction) (common.Hash, error) {
+	args := map[string]interface{}{
+		"from":  from,
+		"gas":   hexutil.Uint64(tx.Gas()),
+		"value": (*hexutil.Big)(tx.Value()),
+		"input": hexutil.Bytes(tx.Data()),
+	}
+	if tx.To() != nil {
+		args["to"] = *tx.To()
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		args["maxFeePerGas"] = (*hexutil.Big)(tx.GasFeeCap())
+		args["maxPriorityFeePerGas"] = (*hexutil.Big)(tx.GasTipCap())
+	} else {
+		args["gasPrice"] = (*hexutil.Big)(tx.GasPrice())
+	}
+	var hash common.Hash
+	err := f.rpc.CallContext(ctx, &hash, "eth_sendTransaction", args)
+	return hash, err
+}
+
+func executeOnFork(ctx context.Context, backend forkBackend, tx *types.Transaction, from common.Address, symbol string) (ForkResult, error) {
+	if err := backend.impersonate(ctx, from); err != nil {
+		return ForkResult{}, fmt.Errorf("failed to impersonate %s on the fork: %w", from.Hex(), err)
+	}
+	hash, err := backend.sendAs(ctx, from, tx)
+	if err != nil {
+		return ForkResult{}, fmt.Errorf("the fork refused the transaction: %w", err)
+	}
+	receipt, err := forkReceipt(ctx, backend, hash)
+	if err != nil {
+		return ForkResult{}, err
+	}
+
+	result := ForkResult{Hash: hash, GasUsed: receipt.GasUsed, Reverted: receipt.Status == types.ReceiptStatusFailed}
+	result.Fee = new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receiptGasPrice(receipt, tx))
+	block := receipt.BlockNumber
+	parent := new(big.Int).Sub(block, big.NewInt(1))
+	if result.Reverted {
+		if _, err := backend.CallContract(ctx, ethereum.CallMsg{From: from, To: tx.To(), Gas: tx.Gas(), Value: tx.Value(), Data: tx.Data()}, parent); err != nil {
+			result.RevertReason = err.Error()
+		}
+	}
+
+	// Native currency: the sender always, the recipient when it changed
+	accounts := []common.Address{from}
+	if tx.To() != nil && *tx.To() != from {
+		accounts = append(accounts, *tx.To())
+	}
+	for i, account := range accounts {
+		before, err := backend.BalanceAt(ctx, account, parent)
+		if err != nil {
+			return result, err
+		}
+		after, err := backend.BalanceAt(ctx, account, block)
+		if err != nil {
+			return result, err
+		}
+		if i == 0 || before.Cmp(after) != 0 {
+			result.Changes = append(result.Changes, BalanceChange{Account: account, Symbol: symbol, Decimals: 18, Before: before, After: after})
+		}
+	}
+
+	// Tokens: every holder moved by a Transfer event, once; mints and burns
+	// come from or go to the zero address, which is left out
+	type holding struct{ token, account common.Address }
+	seen := make(map[holding]bool)
+	for _, l := range receipt.Logs {
+		if len(l.Topics) != 3 || l.Topics[0] != transferTopic {
+			continue
+		}
+		for _, topic := range l.Topics[1:] {
+			h := holding{token: l.Address, account: common.BytesToAddress(topic.Bytes())}
+			if h.account == (common.Address{}) || seen[h] {
+				continue
+			}
+			seen[h] = true
+			before, errBefore := tokenBalanceAt(ctx, backend, h.token, h.account, parent)
+			after, errAfter := tokenBalanceAt(ctx, backend, h.token, h.account, block)
+			if errBefore != nil || errAfter != nil {
+				// Not an ERC-20 token, such as an ERC-721 with the same event
+				continue
+			}
+			token := h.token
+			result.Changes = append(result.Changes, BalanceChange{
+				Account:  h.account,
+				Token:    &token,
+				Symbol:   tokenSymbol(ctx, backend, token),
+				Decimals: tokenDecimals(ctx, backend, token),
+				Before:   before,
+				After:    after,
+			})
+		}
+	}
+	return result, nil
+}
+
+// forkReceipt waits for anvil to mine hash; it mines every transaction at
+// once, so this is only a short wait
+func forkReceipt(ctx context.Context, backend forkBackend, hash common.Hash) (*types.Receipt, error) {
+	for {
+		receipt, err := backend.TransactionReceipt(ctx, hash)
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("failed to read the receipt from the fork: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("the fork did not mine the transaction: %w", ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// receiptGasPrice is the price paid per gas; the transaction's own price
+// when the node did not report it
+func receiptGasPrice(receipt *types.Receipt, tx *types.Transaction) *big.Int {
+	if receipt.EffectiveGasPrice != nil {
+		return receipt.EffectiveGasPrice
+	}
+	return tx.GasPrice()
+}
+
+// tokenBalanceAt reads the token balance of account at blockNumber
+func tokenBalanceAt(ctx context.Context, backend previewBackend, token, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	input, err := parsedERC20ABI.Pack("balanceOf", account)
+	if err != nil {
+		return nil, err
+	}
+	output, err := backend.CallContract(ctx, ethereum.CallMsg{To: &token, Data: input}, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	values, err := parsedERC20ABI.Unpack("balanceOf", output)
+	if err != nil || len(values) != 1 {
+		return nil, fmt.Errorf("%w: unexpected balanceOf result from %s", ErrNotAToken, token.Hex())
+	}
+	balance, ok := values[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected balanceOf result from %s", ErrNotAToken, token.Hex())
+	}
+	return balance, nil
+}
+
+// freePort asks the system for a local TCP port nobody listens on
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free port for anvil: %w", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// lastLine returns the last line anvil wrote, or how it exited
+func lastLine(output string, waitErr error) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return last
+	}
+	if waitErr != nil {
+		return waitErr.Error()
+	}
+	return "no output"
+}
@@ -0,0 +1,153 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"blocowallet/pkg/config"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFork is a fork holding the state before (block 9) and after (block 10)
+// the executed transaction
+type fakeFork struct {
+	before, after *fakeTokenBackend
+	receipt       *types.Receipt
+	refuse        error
+
+	impersonated common.Address
+}
+
+func (f *fakeFork) at(blockNumber *big.Int) *fakeTokenBackend {
+	if blockNumber != nil && blockNumber.Int64() < 10 {
+		return f.before
+	}
+	return f.after
+}
+
+func (f *fakeFork) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return f.at(blockNumber).BalanceAt(ctx, account, blockNumber)
+}
+
+func (f *fakeFork) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return f.at(blockNumber).CallContract(ctx, msg, blockNumber)
+}
+
+func (f *fakeFork) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	return f.receipt, nil
+}
+
+func (f *fakeFork) impersonate(ctx context.Context, account common.Address) error {
+	f.impersonated = account
+	return nil
+}
+
+func (f *fakeFork) sendAs(ctx context.Context, from common.Address, tx *types.Transaction) (common.Hash, error) {
+	return common.HexToHash("0x01"), f.refuse
+}
+
+func transferLog(token, from, to common.Address) *types.Log {
+	return &types.Log{Address: token, Topics: []common.Hash{transferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())}}
+}
+
+func TestExecuteOnFork_TokenTransfer(t *testing.T) {
+	fork := &fakeFork{
+		before: &fakeTokenBackend{
+			native:   map[common.Address]*big.Int{previewSender: big.NewInt(1_000_000)},
+			balances: map[common.Address]*big.Int{previewSender: big.NewInt(10_000_000), previewPayee: big.NewInt(1)},
+		},
+		after: &fakeTokenBackend{
+			native:   map[common.Address]*big.Int{previewSender: big.NewInt(958_000)},
+			balances: map[common.Address]*big.Int{previewSender: big.NewInt(7_500_000), previewPayee: big.NewInt(2_500_001)},
+		},
+		receipt: &types.Receipt{
+			Status:            types.ReceiptStatusSuccessful,
+			GasUsed:           42_000,
+			EffectiveGasPrice: big.NewInt(1),
+			BlockNumber:       big.NewInt(10),
+			Logs: []*types.Log{
+				transferLog(previewToken, previewSender, previewPayee),
+				// A mint: the zero address is not a holder
+				transferLog(previewToken, common.Address{}, previewPayee),
+			},
+		},
+	}
+
+	result, err := executeOnFork(context.Background(), fork, tokenTx(t, "transfer", previewPayee, big.NewInt(2_500_000)), previewSender, "ETH")
+	require.NoError(t, err)
+	assert.Equal(t, previewSender, fork.impersonated)
+	assert.False(t, result.Reverted)
+	assert.Equal(t, uint64(42_000), result.GasUsed)
+	assert.Equal(t, big.NewInt(42_000), result.Fee)
+
+	// Sender's native balance, then each token holder once; the token
+	// contract received no value so it is not listed
+	require.Len(t, result.Changes, 3)
+	native := result.Changes[0]
+	assert.Nil(t, native.Token)
+	assert.Equal(t, big.NewInt(1_000_000), native.Before)
+	assert.Equal(t, big.NewInt(958_000), native.After)
+
+	out, in := result.Changes[1], result.Changes[2]
+	assert.Equal(t, previewSender, out.Account)
+	assert.Equal(t, "USDC", out.Symbol)
+	assert.Equal(t, 6, out.Decimals)
+	assert.Equal(t, big.NewInt(7_500_000), out.After)
+	assert.Equal(t, previewPayee, in.Account)
+	assert.Equal(t, big.NewInt(1), in.Before)
+	assert.Equal(t, big.NewInt(2_500_001), in.After)
+}
+
+func TestExecuteOnFork_Reverted(t *testing.T) {
+	state := &fakeTokenBackend{revert: errors.New("execution reverted: transfer amount exceeds balance")}
+	fork := &fakeFork{
+		before:  state,
+		after:   state,
+		receipt: &types.Receipt{Status: types.ReceiptStatusFailed, GasUsed: 30_000, BlockNumber: big.NewInt(10)},
+	}
+	result, err := executeOnFork(context.Background(), fork, tokenTx(t, "approve", previewSpender, big.NewInt(1)), previewSender, "ETH")
+	require.NoError(t, err)
+	assert.True(t, result.Reverted)
+	assert.Equal(t, "execution reverted: transfer amount exceeds balance", result.RevertReason)
+	require.Len(t, result.Changes, 1, "only the sender, who paid the gas")
+}
+
+func TestExecuteOnFork_Refused(t *testing.T) {
+	fork := &fakeFork{refuse: errors.New("insufficient funds for gas * price + value")}
+	_, err := executeOnFork(context.Background(), fork, tokenTx(t, "transfer", previewPayee, big.NewInt(1)), previewSender, "ETH")
+	assert.ErrorContains(t, err, "insufficient funds")
+}
+
+func TestForkRunner_Start(t *testing.T) {
+	network := config.Network{Name: "Ethereum", ChainID: 1, RPCEndpoint: "https://rpc.example.com"}
+
+	missing := NewForkRunner(config.ForkConfig{AnvilPath: filepath.Join(t.TempDir(), "anvil")})
+	assert.False(t, missing.Available())
+	_, err := missing.Start(context.Background(), network)
+	assert.ErrorIs(t, err, ErrAnvilNotFound)
+
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script stand-in for anvil")
+	}
+	anvil := filepath.Join(t.TempDir(), "anvil")
+	require.NoError(t, os.WriteFile(anvil, []byte("#!/bin/sh\necho 'Error: failed to get fork block number' >&2\nexit 1\n"), 0700))
+	runner := NewForkRunner(config.ForkConfig{AnvilPath: anvil, StartupTimeout: 5})
+	assert.True(t, runner.Available())
+	_, err = runner.Start(context.Background(), network)
+	assert.ErrorContains(t, err, "failed to get fork block number")
+
+	// anvil cannot use the SOCKS5 proxy, so forking through it is refused
+	useProxy(t, config.ProxyConfig{URL: "socks5h://127.0.0.1:9050", RPC: true})
+	_, err = runner.Start(context.Background(), network)
+	assert.ErrorIs(t, err, ErrForkProxied)
+}
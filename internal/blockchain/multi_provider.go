@@ -73,6 +73,7 @@ type NetworkBalance struct {
 	Decimals    int
 	Amount      *big.Int
 	Error       error
+	FetchedAt   time.Time // when Amount was read; zero on error
 }
 
 // GetAllBalances gets the balance for a wallet address on all active networks
@@ -84,7 +85,7 @@ func (mp *MultiProvider) GetAllBalances(ctx context.Context, address string) []N
 
 	// For each provider, get the balance
 	for key, provider := range mp.providers {
-		if !provider.network.IsActive {
+		if !provider.network.Usable() {
 			continue
 		}
 
@@ -120,8 +121,8 @@ func (mp *MultiProvider) RefreshProviders(cfg *config.Config) {
 	for key, network := range cfg.Networks {
 		stillNeeded[key] = true
 
-		// Skip inactive networks
-		if !network.IsActive {
+		// Skip inactive and quarantined networks
+		if !network.Usable() {
 			continue
 		}
 
@@ -0,0 +1,276 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrNotAToken is returned when a contract does not answer like an ERC-20 token
+var ErrNotAToken = errors.New("contract is not an ERC-20 token")
+
+// Token calls recognised in transaction data
+const (
+	TokenTransfer     = "transfer"
+	TokenApprove      = "approve"
+	TokenTransferFrom = "transferFrom"
+)
+
+// erc20ABI is the part of the ERC-20 interface used to preview token calls
+const erc20ABI = `[
+	{"name":"transfer","type":"function","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"name":"approve","type":"function","inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"name":"transferFrom","type":"function","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"name":"balanceOf","type":"function","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"name":"allowance","type":"function","stateMutability":"view","inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"name":"symbol","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+	{"name":"decimals","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]}
+]`
+
+var parsedERC20ABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(erc20ABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// TokenCall is an ERC-20 transfer, approve or transferFrom decoded from
+// transaction data. From is only set for transferFrom.
+type TokenCall struct {
+	Method  string
+	From    common.Address
+	To      common.Address // recipient of transfer and transferFrom
+	Spender common.Address // approved by approve
+	Amount  *big.Int
+}
+
+// Unlimited reports whether an approval lets the spender take any amount;
+// wallets and dapps use the maximum uint256, or close to it
+func (c TokenCall) Unlimited() bool {
+	return c.Method == TokenApprove && c.Amount.BitLen() >= 255
+}
+
+// DecodeTokenCall recognises ERC-20 calls in transaction data, without
+// asking the network
+func DecodeTokenCall(data []byte) (*TokenCall, bool) {
+	if len(data) < 4 {
+		return nil, false
+	}
+	method, err := parsedERC20ABI.MethodById(data[:4])
+	if err != nil {
+		return nil, false
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, false
+	}
+	switch method.Name {
+	case TokenTransfer:
+		return &TokenCall{Method: TokenTransfer, To: args[0].(common.Address), Amount: args[1].(*big.Int)}, true
+	case TokenApprove:
+		return &TokenCall{Method: TokenApprove, Spender: args[0].(common.Address), Amount: args[1].(*big.Int)}, true
+	case TokenTransferFrom:
+		return &TokenCall{Method: TokenTransferFrom, From: args[0].(common.Address), To: args[1].(common.Address), Amount: args[2].(*big.Int)}, true
+	}
+	return nil, false
+}
+
+// BalanceChange is the expected effect of a transaction on one balance or,
+// when Spender is set, on one allowance
+type BalanceChange struct {
+	Account  common.Address
+	Token    *common.Address // nil for the native currency
+	Symbol   string
+	Decimals int
+	Spender  *common.Address
+	Before   *big.Int
+	After    *big.Int
+	// Insufficient marks a debit larger than the balance or allowance
+	Insufficient bool
+}
+
+// TxPreview is what a transaction is expected to do before it is sent
+type TxPreview struct {
+	Token   *TokenCall // nil when the data is not a recognised token call
+	Changes []BalanceChange
+	// RevertReason is set when simulating the transaction failed
+	RevertReason string
+}
+
+// previewBackend is the part of ethclient.Client used to preview transactions
+type previewBackend interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// PreviewTransaction simulates tx as sent by from against the latest block
+// and works out the balance and allowance changes of the sender and the
+// counterparties. The native debit assumes the whole gas limit at the maximum
+// fee, so it is the most the sender can pay.
+func (e *Ethereum) PreviewTransaction(ctx context.Context, tx *types.Transaction, from common.Address) (TxPreview, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+	return previewTransaction(ctx, e.client, tx, from, e.symbol)
+}
+
+func previewTransaction(ctx context.Context, backend previewBackend, tx *types.Transaction, from common.Address, symbol string) (TxPreview, error) {
+	var preview TxPreview
+	if _, err := backend.CallContract(ctx, ethereum.CallMsg{From: from, To: tx.To(), Gas: tx.Gas(), Value: tx.Value(), Data: tx.Data()}, nil); err != nil {
+		preview.RevertReason = err.Error()
+	}
+
+	// Native currency: the value and the most the fee can cost
+	balance, err := backend.BalanceAt(ctx, from, nil)
+	if err != nil {
+		return preview, err
+	}
+	preview.Changes = append(preview.Changes, debit(BalanceChange{Account: from, Symbol: symbol, Decimals: 18, Before: balance}, tx.Cost()))
+	if tx.To() != nil && tx.Value().Sign() > 0 && *tx.To() != from {
+		balance, err := backend.BalanceAt(ctx, *tx.To(), nil)
+		if err != nil {
+			return preview, err
+		}
+		preview.Changes = append(preview.Changes, BalanceChange{Account: *tx.To(), Symbol: symbol, Decimals: 18, Before: balance, After: new(big.Int).Add(balance, tx.Value())})
+	}
+
+	if tx.To() == nil {
+		return preview, nil
+	}
+	call, ok := DecodeTokenCall(tx.Data())
+	if !ok {
+		return preview, nil
+	}
+	preview.Token = call
+	token := *tx.To()
+	asset := BalanceChange{Token: &token, Symbol: tokenSymbol(ctx, backend, token), Decimals: tokenDecimals(ctx, backend, token)}
+
+	switch call.Method {
+	case TokenTransfer, TokenTransferFrom:
+		source := from
+		if call.Method == TokenTransferFrom {
+			source = call.From
+		}
+		changes, err := tokenTransfer(ctx, backend, asset, source, call.To, call.Amount)
+		if err != nil {
+			return preview, err
+		}
+		preview.Changes = append(preview.Changes, changes...)
+		if call.Method == TokenTransferFrom {
+			allowance, err := callUint(ctx, backend, token, "allowance", call.From, from)
+			if err != nil {
+				return preview, err
+			}
+			change := asset
+			change.Account, change.Spender, change.Before = call.From, &from, allowance
+			// Unlimited allowances are not spent by most tokens
+			if allowance.Cmp(math.MaxBig256) == 0 {
+				change.After = allowance
+			} else {
+				change = debit(change, call.Amount)
+			}
+			preview.Changes = append(preview.Changes, change)
+		}
+	case TokenApprove:
+		allowance, err := callUint(ctx, backend, token, "allowance", from, call.Spender)
+		if err != nil {
+			return preview, err
+		}
+		change := asset
+		change.Account, change.Spender, change.Before, change.After = from, &call.Spender, allowance, call.Amount
+		preview.Changes = append(preview.Changes, change)
+	}
+	return preview, nil
+}
+
+// tokenTransfer works out the token balances of both sides of a transfer
+func tokenTransfer(ctx context.Context, backend previewBackend, asset BalanceChange, source, recipient common.Address, amount *big.Int) ([]BalanceChange, error) {
+	sourceBalance, err := callUint(ctx, backend, *asset.Token, "balanceOf", source)
+	if err != nil {
+		return nil, err
+	}
+	out := asset
+	out.Account, out.Before = source, sourceBalance
+	changes := []BalanceChange{debit(out, amount)}
+	if recipient == source {
+		changes[0].After = sourceBalance
+		return changes, nil
+	}
+	recipientBalance, err := callUint(ctx, backend, *asset.Token, "balanceOf", recipient)
+	if err != nil {
+		return nil, err
+	}
+	in := asset
+	in.Account, in.Before, in.After = recipient, recipientBalance, new(big.Int).Add(recipientBalance, amount)
+	return append(changes, in), nil
+}
+
+// debit takes amount from change.Before, flooring at zero
+func debit(change BalanceChange, amount *big.Int) BalanceChange {
+	change.After = new(big.Int).Sub(change.Before, amount)
+	if change.After.Sign() < 0 {
+		change.After.SetInt64(0)
+		change.Insufficient = true
+	}
+	return change
+}
+
+// callUint calls a view method of token that returns a uint256
+func callUint(ctx context.Context, backend previewBackend, token common.Address, method string, args ...interface{}) (*big.Int, error) {
+	values, err := callERC20(ctx, backend, token, method, args...)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := values[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected %s result from %s", ErrNotAToken, method, token.Hex())
+	}
+	return value, nil
+}
+
+// tokenSymbol reads the symbol of token, or abbreviates its address for
+// tokens without one or with a bytes32 symbol
+func tokenSymbol(ctx context.Context, backend previewBackend, token common.Address) string {
+	if values, err := callERC20(ctx, backend, token, "symbol"); err == nil {
+		if symbol, ok := values[0].(string); ok && symbol != "" {
+			return symbol
+		}
+	}
+	hex := token.Hex()
+	return hex[:6] + "…" + hex[len(hex)-4:]
+}
+
+// tokenDecimals reads the decimals of token; 18 when it has none
+func tokenDecimals(ctx context.Context, backend previewBackend, token common.Address) int {
+	if values, err := callERC20(ctx, backend, token, "decimals"); err == nil {
+		if decimals, ok := values[0].(uint8); ok {
+			return int(decimals)
+		}
+	}
+	return 18
+}
+
+// callERC20 calls a view method of token and returns its single result
+func callERC20(ctx context.Context, backend previewBackend, token common.Address, method string, args ...interface{}) ([]interface{}, error) {
+	input, err := parsedERC20ABI.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	output, err := backend.CallContract(ctx, ethereum.CallMsg{To: &token, Data: input}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s on %s: %w", method, token.Hex(), err)
+	}
+	values, err := parsedERC20ABI.Unpack(method, output)
+	if err != nil || len(values) != 1 {
+		return nil, fmt.Errorf("%w: unexpected %s result from %s", ErrNotAToken, method, token.Hex())
+	}
+	return values, nil
+}
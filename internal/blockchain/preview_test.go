@@ -0,0 +1,173 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTokenBackend holds native balances and one ERC-20 token
+type fakeTokenBackend struct {
+	native     map[common.Address]*big.Int
+	balances   map[common.Address]*big.Int
+	allowances map[[2]common.Address]*big.Int
+	revert     error
+}
+
+func (f *fakeTokenBackend) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if b, ok := f.native[account]; ok {
+		return b, nil
+	}
+	return new(big.Int), nil
+}
+
+func (f *fakeTokenBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if len(msg.Data) == 0 {
+		return nil, nil
+	}
+	method, err := parsedERC20ABI.MethodById(msg.Data)
+	if err != nil {
+		return nil, errors.New("execution reverted")
+	}
+	args, err := method.Inputs.Unpack(msg.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	amount := func(v *big.Int) *big.Int {
+		if v == nil {
+			return new(big.Int)
+		}
+		return v
+	}
+	switch method.Name {
+	case "balanceOf":
+		return method.Outputs.Pack(amount(f.balances[args[0].(common.Address)]))
+	case "allowance":
+		return method.Outputs.Pack(amount(f.allowances[[2]common.Address{args[0].(common.Address), args[1].(common.Address)}]))
+	case "symbol":
+		return method.Outputs.Pack("USDC")
+	case "decimals":
+		return method.Outputs.Pack(uint8(6))
+	default:
+		if f.revert != nil {
+			return nil, f.revert
+		}
+		return method.Outputs.Pack(true)
+	}
+}
+
+var (
+	previewToken   = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	previewSender  = common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")
+	previewPayee   = common.HexToAddress("0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0")
+	previewSpender = common.HexToAddress("0x000000000022D473030F116dDEE9F6B43aC78BA3")
+)
+
+func tokenTx(t *testing.T, method string, args ...interface{}) *types.Transaction {
+	data, err := parsedERC20ABI.Pack(method, args...)
+	require.NoError(t, err)
+	return types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), To: &previewToken, Gas: 50000, GasFeeCap: big.NewInt(10), Data: data})
+}
+
+func TestDecodeTokenCall(t *testing.T) {
+	call, ok := DecodeTokenCall(tokenTx(t, "approve", previewSpender, math.MaxBig256).Data())
+	require.True(t, ok)
+	assert.Equal(t, TokenApprove, call.Method)
+	assert.Equal(t, previewSpender, call.Spender)
+	assert.True(t, call.Unlimited())
+
+	call, ok = DecodeTokenCall(tokenTx(t, "transferFrom", previewSender, previewPayee, big.NewInt(5)).Data())
+	require.True(t, ok)
+	assert.Equal(t, TokenCall{Method: TokenTransferFrom, From: previewSender, To: previewPayee, Amount: big.NewInt(5)}, *call)
+	assert.False(t, call.Unlimited())
+
+	_, ok = DecodeTokenCall([]byte{0xde, 0xad, 0xbe, 0xef})
+	assert.False(t, ok, "unknown selector")
+	_, ok = DecodeTokenCall(nil)
+	assert.False(t, ok, "plain transfer")
+}
+
+func TestPreviewTransaction_TokenTransfer(t *testing.T) {
+	backend := &fakeTokenBackend{
+		native:   map[common.Address]*big.Int{previewSender: big.NewInt(1_000_000)},
+		balances: map[common.Address]*big.Int{previewSender: big.NewInt(10_000_000), previewPayee: big.NewInt(1)},
+	}
+	preview, err := previewTransaction(context.Background(), backend, tokenTx(t, "transfer", previewPayee, big.NewInt(2_500_000)), previewSender, "ETH")
+	require.NoError(t, err)
+	assert.Empty(t, preview.RevertReason)
+	require.NotNil(t, preview.Token)
+	require.Len(t, preview.Changes, 3)
+
+	fee := preview.Changes[0]
+	assert.Nil(t, fee.Token)
+	assert.Equal(t, "ETH", fee.Symbol)
+	assert.Equal(t, big.NewInt(500_000), fee.After, "the most the gas can cost")
+
+	out, in := preview.Changes[1], preview.Changes[2]
+	assert.Equal(t, previewSender, out.Account)
+	assert.Equal(t, "USDC", out.Symbol)
+	assert.Equal(t, 6, out.Decimals)
+	assert.Equal(t, big.NewInt(7_500_000), out.After)
+	assert.Equal(t, previewPayee, in.Account)
+	assert.Equal(t, big.NewInt(2_500_001), in.After)
+}
+
+func TestPreviewTransaction_Insufficient(t *testing.T) {
+	backend := &fakeTokenBackend{
+		balances: map[common.Address]*big.Int{previewSender: big.NewInt(1)},
+		revert:   errors.New("execution reverted: ERC20: transfer amount exceeds balance"),
+	}
+	preview, err := previewTransaction(context.Background(), backend, tokenTx(t, "transfer", previewPayee, big.NewInt(2)), previewSender, "ETH")
+	require.NoError(t, err)
+	assert.Contains(t, preview.RevertReason, "exceeds balance")
+	assert.True(t, preview.Changes[0].Insufficient, "no native balance for the fee")
+	assert.True(t, preview.Changes[1].Insufficient)
+	assert.Equal(t, int64(0), preview.Changes[1].After.Int64())
+}
+
+func TestPreviewTransaction_Approve(t *testing.T) {
+	backend := &fakeTokenBackend{
+		allowances: map[[2]common.Address]*big.Int{{previewSender, previewSpender}: big.NewInt(7)},
+	}
+	preview, err := previewTransaction(context.Background(), backend, tokenTx(t, "approve", previewSpender, math.MaxBig256), previewSender, "ETH")
+	require.NoError(t, err)
+	require.Len(t, preview.Changes, 2)
+	allowance := preview.Changes[1]
+	require.NotNil(t, allowance.Spender)
+	assert.Equal(t, previewSpender, *allowance.Spender)
+	assert.Equal(t, big.NewInt(7), allowance.Before)
+	assert.Equal(t, math.MaxBig256, allowance.After)
+}
+
+func TestPreviewTransaction_TransferFrom(t *testing.T) {
+	backend := &fakeTokenBackend{
+		balances:   map[common.Address]*big.Int{previewPayee: big.NewInt(100)},
+		allowances: map[[2]common.Address]*big.Int{{previewPayee, previewSender}: big.NewInt(60)},
+	}
+	preview, err := previewTransaction(context.Background(), backend, tokenTx(t, "transferFrom", previewPayee, previewSender, big.NewInt(40)), previewSender, "ETH")
+	require.NoError(t, err)
+	require.Len(t, preview.Changes, 4)
+	assert.Equal(t, big.NewInt(60), preview.Changes[1].After, "the owner's tokens")
+	assert.Equal(t, big.NewInt(40), preview.Changes[2].After, "the sender receives them")
+	assert.Equal(t, big.NewInt(20), preview.Changes[3].After, "the allowance left")
+}
+
+func TestPreviewTransaction_NativeTransfer(t *testing.T) {
+	backend := &fakeTokenBackend{native: map[common.Address]*big.Int{previewSender: big.NewInt(1_000_000)}}
+	tx := types.NewTx(&types.LegacyTx{To: &previewPayee, Gas: 21000, GasPrice: big.NewInt(1), Value: big.NewInt(100)})
+	preview, err := previewTransaction(context.Background(), backend, tx, previewSender, "ETH")
+	require.NoError(t, err)
+	assert.Nil(t, preview.Token)
+	assert.Empty(t, preview.RevertReason)
+	require.Len(t, preview.Changes, 2)
+	assert.Equal(t, big.NewInt(1_000_000-21000-100), preview.Changes[0].After)
+	assert.Equal(t, big.NewInt(100), preview.Changes[1].After)
+}
@@ -0,0 +1,184 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"blocowallet/internal/metrics"
+	"blocowallet/pkg/config"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
+)
+
+// Services that can be routed through the SOCKS5 proxy
+const (
+	ProxyChainList     = "chainlist"     // chainlist.org lookups
+	ProxyRPC           = "rpc"           // JSON-RPC endpoints of the networks
+	ProxySignatures    = "signatures"    // 4byte directory lookups
+	ProxyWalletConnect = "walletconnect" // WalletConnect relay connection
+)
+
+var (
+	proxyMu     sync.RWMutex
+	proxyURL    *url.URL
+	proxyConfig config.ProxyConfig
+)
+
+// SetProxy routes the enabled services through the SOCKS5 proxy of cfg; an
+// empty URL sends everything directly. Clients created before the call
+// follow the new setting too.
+func SetProxy(cfg config.ProxyConfig) error {
+	var u *url.URL
+	if cfg.URL != "" {
+		parsed, err := url.Parse(cfg.URL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if parsed.Scheme != "socks5" && parsed.Scheme != "socks5h" || parsed.Host == "" {
+			return fmt.Errorf("invalid proxy URL %q: expected socks5://host:port or socks5h://host:port", cfg.URL)
+		}
+		u = parsed
+	}
+	proxyMu.Lock()
+	defer proxyMu.Unlock()
+	proxyURL, proxyConfig = u, cfg
+	return nil
+}
+
+// ProxyAddress returns the host:port of the configured proxy, or "" when
+// there is none
+func ProxyAddress() string {
+	proxyMu.RLock()
+	defer proxyMu.RUnlock()
+	if proxyURL == nil {
+		return ""
+	}
+	return proxyURL.Host
+}
+
+// proxyFor returns the proxy a request of service to host goes through, or
+// nil to connect directly. The local machine is always reached directly.
+func proxyFor(service, host string) *url.URL {
+	proxyMu.RLock()
+	defer proxyMu.RUnlock()
+	if proxyURL == nil || isLocalHost(host) {
+		return nil
+	}
+	switch service {
+	case ProxyChainList:
+		if !proxyConfig.ChainList {
+			return nil
+		}
+	case ProxyRPC:
+		if !proxyConfig.RPC {
+			return nil
+		}
+	case ProxySignatures:
+		if !proxyConfig.Signatures {
+			return nil
+		}
+	case ProxyWalletConnect:
+		if !proxyConfig.WalletConnect {
+			return nil
+		}
+	}
+	return proxyURL
+}
+
+// NewHTTPClient returns a client for service that honours the proxy settings;
+// requests that are not proxied keep the environment's HTTP proxy
+func NewHTTPClient(service string, timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		if u := proxyFor(service, req.URL.Hostname()); u != nil {
+			return u, nil
+		}
+		return http.ProxyFromEnvironment(req)
+	}
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// dialRPC connects to a JSON-RPC endpoint, through the proxy when enabled
+func dialRPC(ctx context.Context, endpoint string) (*rpc.Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return rpc.DialContext(ctx, endpoint)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		client := NewHTTPClient(ProxyRPC, 0)
+		client.Transport = metrics.InstrumentRPC(client.Transport)
+		return rpc.DialOptions(ctx, endpoint, rpc.WithHTTPClient(client))
+	case "ws", "wss":
+		if proxyFor(ProxyRPC, u.Hostname()) == nil {
+			break
+		}
+		return rpc.DialOptions(ctx, endpoint, rpc.WithWebsocketDialer(NewWebsocketDialer(ProxyRPC, u.Hostname())))
+	}
+	return rpc.DialContext(ctx, endpoint)
+}
+
+// NewWebsocketDialer returns a dialer for a websocket of service to host,
+// going through the proxy when enabled
+func NewWebsocketDialer(service, host string) websocket.Dialer {
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: DefaultTimeout,
+		ReadBufferSize:   1024,
+		WriteBufferSize:  1024,
+	}
+	if proxy := proxyFor(service, host); proxy != nil {
+		// The websocket dialer knows the scheme as socks5; names are still
+		// resolved by the proxy
+		wsProxy := *proxy
+		wsProxy.Scheme = "socks5"
+		dialer.Proxy = http.ProxyURL(&wsProxy)
+	}
+	return dialer
+}
+
+// CheckProxy connects to the configured proxy and completes the SOCKS5
+// greeting, reporting whether it can carry traffic
+func CheckProxy(ctx context.Context) error {
+	proxyMu.RLock()
+	u := proxyURL
+	proxyMu.RUnlock()
+	if u == nil {
+		return errors.New("no proxy configured")
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", u.Host)
+	if err != nil {
+		return fmt.Errorf("proxy %s is unreachable: %w", u.Host, err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	// Version 5, offering "no authentication" and, with credentials, user/password
+	methods := []byte{0x00}
+	if u.User != nil {
+		methods = append(methods, 0x02)
+	}
+	if _, err := conn.Write(append([]byte{0x05, byte(len(methods))}, methods...)); err != nil {
+		return fmt.Errorf("proxy %s: %w", u.Host, err)
+	}
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return fmt.Errorf("proxy %s did not answer the SOCKS5 greeting: %w", u.Host, err)
+	}
+	if reply[0] != 0x05 || reply[1] == 0xff {
+		return fmt.Errorf("proxy %s is not a usable SOCKS5 proxy", u.Host)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"blocowallet/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// socksServer is a minimal SOCKS5 proxy without authentication that records
+// the destinations it was asked to connect to
+type socksServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	targets  []string
+	// dial replaces the destination, since tests cannot reach real hosts
+	dial func(target string) (net.Conn, error)
+}
+
+func newSocksServer(t *testing.T, dial func(target string) (net.Conn, error)) *socksServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &socksServer{listener: listener, dial: dial}
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *socksServer) serve(conn net.Conn) {
+	defer conn.Close()
+	greeting := make([]byte, 2)
+	if _, err := io.ReadFull(conn, greeting); err != nil {
+		return
+	}
+	if _, err := io.ReadFull(conn, make([]byte, greeting[1])); err != nil {
+		return
+	}
+	if _, err := conn.Write([]byte{0x05, 0x00}); err != nil {
+		return
+	}
+
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return
+	}
+	var host string
+	switch header[3] {
+	case 0x01:
+		ip := make([]byte, 4)
+		if _, err := io.ReadFull(conn, ip); err != nil {
+			return
+		}
+		host = net.IP(ip).String()
+	case 0x03:
+		size := make([]byte, 1)
+		if _, err := io.ReadFull(conn, size); err != nil {
+			return
+		}
+		name := make([]byte, size[0])
+		if _, err := io.ReadFull(conn, name); err != nil {
+			return
+		}
+		host = string(name)
+	default:
+		return
+	}
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return
+	}
+	target := net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port))))
+	s.mu.Lock()
+	s.targets = append(s.targets, target)
+	s.mu.Unlock()
+
+	upstream, err := s.dial(target)
+	if err != nil {
+		_, _ = conn.Write([]byte{0x05, 0x05, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+		return
+	}
+	defer upstream.Close()
+	if _, err := conn.Write([]byte{0x05, 0x00, 0x00, 0x01, 0, 0, 0, 0, 0, 0}); err != nil {
+		return
+	}
+	go func() { _, _ = io.Copy(upstream, conn) }()
+	_, _ = io.Copy(conn, upstream)
+}
+
+func (s *socksServer) seen() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.targets...)
+}
+
+func useProxy(t *testing.T, cfg config.ProxyConfig) {
+	require.NoError(t, SetProxy(cfg))
+	t.Cleanup(func() { _ = SetProxy(config.ProxyConfig{}) })
+}
+
+func TestSetProxy(t *testing.T) {
+	t.Cleanup(func() { _ = SetProxy(config.ProxyConfig{}) })
+	assert.Error(t, SetProxy(config.ProxyConfig{URL: "http://127.0.0.1:8080"}))
+	assert.Error(t, SetProxy(config.ProxyConfig{URL: "socks5://"}))
+	assert.NoError(t, SetProxy(config.ProxyConfig{URL: "socks5h://127.0.0.1:9050", RPC: true}))
+	assert.Equal(t, "127.0.0.1:9050", ProxyAddress())
+
+	assert.NotNil(t, proxyFor(ProxyRPC, "rpc.example.com"))
+	assert.Nil(t, proxyFor(ProxyChainList, "chainlist.org"), "turned off per service")
+	assert.Nil(t, proxyFor(ProxyRPC, "localhost"), "local nodes are reached directly")
+	assert.Nil(t, proxyFor(ProxyWalletConnect, "relay.walletconnect.org"))
+
+	require.NoError(t, SetProxy(config.ProxyConfig{}))
+	assert.Empty(t, ProxyAddress())
+	assert.Nil(t, proxyFor(ProxyRPC, "rpc.example.com"))
+}
+
+func TestNewHTTPClient_RoutesThroughProxy(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer backend.Close()
+	socks := newSocksServer(t, func(string) (net.Conn, error) {
+		return net.Dial("tcp", backend.Listener.Addr().String())
+	})
+	useProxy(t, config.ProxyConfig{URL: "socks5h://" + socks.listener.Addr().String(), ChainList: true, RPC: true})
+
+	resp, err := NewHTTPClient(ProxyChainList, 5*time.Second).Get("http://chainlist.example:8080/rpcs.json")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, "ok", string(body))
+	assert.Equal(t, []string{"chainlist.example:8080"}, socks.seen(), "the proxy resolves the name")
+}
+
+func TestNewWebsocketDialer(t *testing.T) {
+	useProxy(t, config.ProxyConfig{URL: "socks5h://127.0.0.1:9050", WalletConnect: true})
+	req, _ := http.NewRequest(http.MethodGet, "https://relay.walletconnect.org", nil)
+
+	proxy, err := NewWebsocketDialer(ProxyWalletConnect, "relay.walletconnect.org").Proxy(req)
+	require.NoError(t, err)
+	require.NotNil(t, proxy)
+	assert.Equal(t, "socks5://127.0.0.1:9050", proxy.String())
+
+	proxy, err = NewWebsocketDialer(ProxyRPC, "rpc.example.com").Proxy(req)
+	require.NoError(t, err)
+	if proxy != nil {
+		assert.NotEqual(t, "socks5", proxy.Scheme, "rpc is not proxied, only the environment's proxy applies")
+	}
+}
+
+func TestCheckProxy(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	socks := newSocksServer(t, func(string) (net.Conn, error) { return nil, io.EOF })
+	useProxy(t, config.ProxyConfig{URL: "socks5://" + socks.listener.Addr().String(), RPC: true})
+	assert.NoError(t, CheckProxy(ctx))
+
+	// A listener that is not a SOCKS5 proxy
+	other, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		conn, err := other.Accept()
+		if err == nil {
+			_, _ = conn.Write([]byte("HTTP/1.1 400 Bad Request\r\n\r\n"))
+			_ = conn.Close()
+		}
+	}()
+	useProxy(t, config.ProxyConfig{URL: "socks5://" + other.Addr().String()})
+	assert.Error(t, CheckProxy(ctx))
+
+	_ = other.Close()
+	assert.ErrorContains(t, CheckProxy(ctx), "unreachable")
+}
@@ -0,0 +1,96 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrNotASafe is returned for addresses that do not answer like a Safe
+// multisig: no contract code, or no owners list
+var ErrNotASafe = errors.New("address is not a Safe contract")
+
+// safeABI is the part of the Safe contract interface that lists its signers,
+// unchanged since Gnosis Safe 1.0
+const safeABI = `[
+	{"name":"getOwners","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address[]"}]},
+	{"name":"getThreshold","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]}
+]`
+
+var parsedSafeABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(safeABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// SafeInfo lists the owners of a Safe and how many of them must confirm a
+// transaction
+type SafeInfo struct {
+	Address   common.Address
+	Owners    []common.Address
+	Threshold uint64
+}
+
+// safeBackend is the part of ethclient.Client used to read a Safe
+type safeBackend interface {
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// SafeOwners reads the owners and the threshold of the Safe at address
+func (e *Ethereum) SafeOwners(ctx context.Context, address string) (SafeInfo, error) {
+	if !common.IsHexAddress(address) {
+		return SafeInfo{}, fmt.Errorf("invalid Ethereum address: %s", address)
+	}
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+	return readSafe(ctx, e.client, common.HexToAddress(address))
+}
+
+func readSafe(ctx context.Context, backend safeBackend, safe common.Address) (SafeInfo, error) {
+	code, err := backend.CodeAt(ctx, safe, nil)
+	if err != nil {
+		return SafeInfo{}, fmt.Errorf("failed to read the code of %s: %w", safe.Hex(), err)
+	}
+	if len(code) == 0 {
+		return SafeInfo{}, fmt.Errorf("%w: %s has no contract code on this network", ErrNotASafe, safe.Hex())
+	}
+
+	var owners []common.Address
+	if err := callSafe(ctx, backend, safe, "getOwners", &owners); err != nil {
+		return SafeInfo{}, err
+	}
+	var threshold *big.Int
+	if err := callSafe(ctx, backend, safe, "getThreshold", &threshold); err != nil {
+		return SafeInfo{}, err
+	}
+	if len(owners) == 0 || threshold == nil || !threshold.IsUint64() {
+		return SafeInfo{}, fmt.Errorf("%w: %s", ErrNotASafe, safe.Hex())
+	}
+	return SafeInfo{Address: safe, Owners: owners, Threshold: threshold.Uint64()}, nil
+}
+
+// callSafe calls a view method of the Safe and decodes its single result into out
+func callSafe(ctx context.Context, backend safeBackend, safe common.Address, method string, out interface{}) error {
+	input, err := parsedSafeABI.Pack(method)
+	if err != nil {
+		return err
+	}
+	output, err := backend.CallContract(ctx, ethereum.CallMsg{To: &safe, Data: input}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to call %s on %s: %w", method, safe.Hex(), err)
+	}
+	values, err := parsedSafeABI.Unpack(method, output)
+	if err != nil || len(values) != 1 {
+		return fmt.Errorf("%w: unexpected %s result from %s", ErrNotASafe, method, safe.Hex())
+	}
+	return parsedSafeABI.Methods[method].Outputs.Copy(out, values)
+}
@@ -0,0 +1,57 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSafeBackend answers getOwners and getThreshold for the contracts it holds
+type fakeSafeBackend struct {
+	code      map[common.Address][]byte
+	owners    []common.Address
+	threshold int64
+}
+
+func (f *fakeSafeBackend) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return f.code[account], nil
+}
+
+func (f *fakeSafeBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	method, err := parsedSafeABI.MethodById(msg.Data)
+	if err != nil {
+		return nil, errors.New("execution reverted")
+	}
+	switch method.Name {
+	case "getOwners":
+		return method.Outputs.Pack(f.owners)
+	default:
+		return method.Outputs.Pack(big.NewInt(f.threshold))
+	}
+}
+
+func TestReadSafe(t *testing.T) {
+	safe := common.HexToAddress("0x5aFE3855358E112B5647B952709E6165e1c1eEEe")
+	owners := []common.Address{
+		common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94"),
+		common.HexToAddress("0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0"),
+	}
+	backend := &fakeSafeBackend{code: map[common.Address][]byte{safe: {0x60}}, owners: owners, threshold: 2}
+
+	info, err := readSafe(context.Background(), backend, safe)
+	require.NoError(t, err)
+	assert.Equal(t, SafeInfo{Address: safe, Owners: owners, Threshold: 2}, info)
+
+	_, err = readSafe(context.Background(), backend, common.HexToAddress("0x000000000000000000000000000000000000dEaD"))
+	assert.ErrorIs(t, err, ErrNotASafe, "an account without code")
+
+	backend.owners = nil
+	_, err = readSafe(context.Background(), backend, safe)
+	assert.ErrorIs(t, err, ErrNotASafe, "a contract without owners")
+}
@@ -0,0 +1,384 @@
+package blockchain
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+//go:embed signatures.txt
+var bundledSignatures string
+
+// SignaturesFileName is the file in the app directory holding the
+// signatures learned from the 4byte directory
+const SignaturesFileName = "signatures.txt"
+
+// SignatureDirectoryURL is the public 4byte directory used for online lookups
+const SignatureDirectoryURL = "https://www.4byte.directory"
+
+// Selector is the first 4 bytes of calldata, naming the function called
+type Selector [4]byte
+
+// SelectorOf returns the selector of data, false when it is too short to hold one
+func SelectorOf(data []byte) (Selector, bool) {
+	var s Selector
+	if len(data) < len(s) {
+		return s, false
+	}
+	copy(s[:], data)
+	return s, true
+}
+
+func (s Selector) String() string {
+	return hexutil.Encode(s[:])
+}
+
+// SignatureDB maps selectors to the text signatures known for them: the
+// bundled list plus any learned from the 4byte directory
+type SignatureDB struct {
+	mu   sync.RWMutex
+	sigs map[Selector][]string
+}
+
+// NewSignatureDB returns a database holding the bundled signatures
+func NewSignatureDB() *SignatureDB {
+	db := &SignatureDB{sigs: make(map[Selector][]string)}
+	db.addLines(bundledSignatures)
+	return db
+}
+
+// BundledSignatures returns the version stamp of the bundled signature list
+// and the number of signatures it holds
+func BundledSignatures() (string, int) {
+	var version string
+	count := 0
+	for _, line := range strings.Split(bundledSignatures, "\n") {
+		line = strings.TrimSpace(line)
+		if v, ok := strings.CutPrefix(line, "# version:"); ok {
+			version = strings.TrimSpace(v)
+		} else if line != "" && !strings.HasPrefix(line, "#") {
+			count++
+		}
+	}
+	return version, count
+}
+
+// LoadFile adds the signatures saved at path by SaveSignatures; a missing
+// file is not an error
+func (db *SignatureDB) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	db.addLines(string(data))
+	return nil
+}
+
+// addLines reads "<selector> <signature>" lines, or bare signatures
+func (db *SignatureDB) addLines(text string) {
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		db.Add(fields[len(fields)-1])
+	}
+}
+
+// Add stores signatures under their selectors and returns the ones that were
+// new. Malformed signatures are skipped.
+func (db *SignatureDB) Add(signatures ...string) []string {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	var added []string
+	for _, sig := range signatures {
+		sig = strings.TrimSpace(sig)
+		if _, _, err := parseSignature(sig); err != nil {
+			continue
+		}
+		var selector Selector
+		copy(selector[:], crypto.Keccak256([]byte(sig)))
+		if slices.Contains(db.sigs[selector], sig) {
+			continue
+		}
+		db.sigs[selector] = append(db.sigs[selector], sig)
+		added = append(added, sig)
+	}
+	return added
+}
+
+// Lookup returns the signatures known for selector, in the order they were added
+func (db *SignatureDB) Lookup(selector Selector) []string {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return append([]string(nil), db.sigs[selector]...)
+}
+
+// Decode decodes data with the first known signature that fits it exactly
+func (db *SignatureDB) Decode(data []byte) (*DecodedCall, bool) {
+	selector, ok := SelectorOf(data)
+	if !ok {
+		return nil, false
+	}
+	return DecodeCalldata(data, db.Lookup(selector))
+}
+
+// SaveSignatures appends signatures to the file at path, for LoadFile
+func SaveSignatures(path string, signatures []string) error {
+	if len(signatures) == 0 {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	for _, sig := range signatures {
+		if _, err := fmt.Fprintln(f, sig); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// SignatureDirectory looks selectors up in the 4byte directory
+type SignatureDirectory struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewSignatureDirectory returns a client of the public 4byte directory that
+// honours the proxy settings
+func NewSignatureDirectory() *SignatureDirectory {
+	return &SignatureDirectory{client: NewHTTPClient(ProxySignatures, 10*time.Second), baseURL: SignatureDirectoryURL}
+}
+
+// Lookup returns the signatures registered for selector, oldest first, as the
+// first registration is usually the genuine one
+func (d *SignatureDirectory) Lookup(ctx context.Context, selector Selector) ([]string, error) {
+	query := url.Values{"hex_signature": {selector.String()}, "ordering": {"created_at"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.baseURL+"/api/v1/signatures/?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("signature lookup failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("signature lookup failed: %s", resp.Status)
+	}
+	var body struct {
+		Results []struct {
+			TextSignature string `json:"text_signature"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("signature lookup failed: %w", err)
+	}
+	sigs := make([]string, 0, len(body.Results))
+	for _, r := range body.Results {
+		sigs = append(sigs, r.TextSignature)
+	}
+	return sigs, nil
+}
+
+// DecodedParam is one argument of a decoded call
+type DecodedParam struct {
+	Type  string
+	Value string
+}
+
+// DecodedCall is calldata read as a function name and its arguments
+type DecodedCall struct {
+	Signature string
+	Name      string
+	Params    []DecodedParam
+}
+
+func (c DecodedCall) String() string {
+	params := make([]string, len(c.Params))
+	for i, p := range c.Params {
+		params[i] = p.Type + " " + p.Value
+	}
+	return c.Name + "(" + strings.Join(params, ", ") + ")"
+}
+
+// DecodeCalldata tries each signature in turn and returns the first whose
+// selector matches and whose arguments re-encode to exactly the data, so a
+// colliding signature with other types is not taken for the real one
+func DecodeCalldata(data []byte, signatures []string) (*DecodedCall, bool) {
+	selector, ok := SelectorOf(data)
+	if !ok {
+		return nil, false
+	}
+	for _, sig := range signatures {
+		if !bytes.Equal(crypto.Keccak256([]byte(sig))[:4], selector[:]) {
+			continue
+		}
+		name, args, err := parseSignature(sig)
+		if err != nil {
+			continue
+		}
+		values, err := args.Unpack(data[4:])
+		if err != nil {
+			continue
+		}
+		if packed, err := args.Pack(values...); err != nil || !bytes.Equal(packed, data[4:]) {
+			continue
+		}
+		call := &DecodedCall{Signature: sig, Name: name, Params: make([]DecodedParam, len(args))}
+		for i, arg := range args {
+			call.Params[i] = DecodedParam{Type: arg.Type.String(), Value: formatABIValue(values[i])}
+		}
+		return call, true
+	}
+	return nil, false
+}
+
+// parseSignature reads "name(type,...)" into the function name and its inputs
+func parseSignature(sig string) (string, abi.Arguments, error) {
+	open := strings.IndexByte(sig, '(')
+	if open <= 0 || !strings.HasSuffix(sig, ")") {
+		return "", nil, fmt.Errorf("invalid signature %q", sig)
+	}
+	types, err := splitTypes(sig[open+1 : len(sig)-1])
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid signature %q: %w", sig, err)
+	}
+	args := make(abi.Arguments, 0, len(types))
+	for i, t := range types {
+		marshaling, err := typeMarshaling(t, fmt.Sprintf("arg%d", i))
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid signature %q: %w", sig, err)
+		}
+		typ, err := abi.NewType(marshaling.Type, "", marshaling.Components)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid signature %q: %w", sig, err)
+		}
+		args = append(args, abi.Argument{Name: marshaling.Name, Type: typ})
+	}
+	return sig[:open], args, nil
+}
+
+// typeMarshaling describes a type such as "uint256" or "(address,bytes)[]"
+// for abi.NewType
+func typeMarshaling(t, name string) (abi.ArgumentMarshaling, error) {
+	if !strings.HasPrefix(t, "(") {
+		return abi.ArgumentMarshaling{Name: name, Type: t}, nil
+	}
+	closing := strings.LastIndexByte(t, ')')
+	if closing < 0 {
+		return abi.ArgumentMarshaling{}, fmt.Errorf("unbalanced tuple %q", t)
+	}
+	fields, err := splitTypes(t[1:closing])
+	if err != nil {
+		return abi.ArgumentMarshaling{}, err
+	}
+	components := make([]abi.ArgumentMarshaling, 0, len(fields))
+	for i, f := range fields {
+		component, err := typeMarshaling(f, fmt.Sprintf("f%d", i))
+		if err != nil {
+			return abi.ArgumentMarshaling{}, err
+		}
+		components = append(components, component)
+	}
+	return abi.ArgumentMarshaling{Name: name, Type: "tuple" + t[closing+1:], Components: components}, nil
+}
+
+// splitTypes splits a type list at the commas outside tuples
+func splitTypes(list string) ([]string, error) {
+	if list == "" {
+		return nil, nil
+	}
+	var types []string
+	depth, start := 0, 0
+	for i, c := range list {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, errors.New("unbalanced parentheses")
+			}
+		case ',':
+			if depth == 0 {
+				types = append(types, list[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, errors.New("unbalanced parentheses")
+	}
+	types = append(types, list[start:])
+	for _, t := range types {
+		if t == "" {
+			return nil, errors.New("empty type")
+		}
+	}
+	return types, nil
+}
+
+// formatABIValue renders a decoded argument: addresses checksummed, bytes as
+// hex, numbers in decimal, arrays in brackets and tuples in parentheses
+func formatABIValue(v interface{}) string {
+	switch value := v.(type) {
+	case common.Address:
+		return value.Hex()
+	case *big.Int:
+		return value.String()
+	case []byte:
+		return hexutil.Encode(value)
+	case string:
+		return fmt.Sprintf("%q", value)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hexutil.Encode(b)
+		}
+		fallthrough
+	case reflect.Slice:
+		items := make([]string, rv.Len())
+		for i := range items {
+			items[i] = formatABIValue(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Struct:
+		fields := make([]string, rv.NumField())
+		for i := range fields {
+			fields[i] = formatABIValue(rv.Field(i).Interface())
+		}
+		return "(" + strings.Join(fields, ", ") + ")"
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,137 @@
+package blockchain
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// packCall encodes a call of sig with args, as a contract would receive it
+func packCall(t *testing.T, sig string, args ...interface{}) []byte {
+	_, inputs, err := parseSignature(sig)
+	require.NoError(t, err)
+	packed, err := inputs.Pack(args...)
+	require.NoError(t, err)
+	return append(crypto.Keccak256([]byte(sig))[:4], packed...)
+}
+
+func TestSignatureDB_Bundled(t *testing.T) {
+	db := NewSignatureDB()
+	to := common.HexToAddress("0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0")
+
+	call, ok := db.Decode(packCall(t, "transfer(address,uint256)", to, big.NewInt(1500)))
+	require.True(t, ok)
+	assert.Equal(t, "transfer", call.Name)
+	assert.Equal(t, "transfer(address "+to.Hex()+", uint256 1500)", call.String())
+
+	call, ok = db.Decode(packCall(t, "approve(address,uint256)", to, math.MaxBig256))
+	require.True(t, ok)
+	assert.Equal(t, math.MaxBig256.String(), call.Params[1].Value)
+
+	_, ok = db.Decode([]byte{0x12, 0x34, 0x56, 0x78})
+	assert.False(t, ok, "unknown selector")
+	_, ok = db.Decode(nil)
+	assert.False(t, ok)
+
+	// Every bundled line is a signature that parses and hashes to its selector
+	for _, line := range strings.Split(bundledSignatures, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		selector, err := hexutil.Decode(fields[0])
+		require.NoError(t, err, line)
+		assert.Contains(t, db.Lookup(Selector(selector)), fields[1], line)
+	}
+}
+
+func TestDecodeCalldata_TuplesAndArrays(t *testing.T) {
+	sig := "aggregate3((address,bool,bytes)[])"
+	_, inputs, err := parseSignature(sig)
+	require.NoError(t, err)
+	calls := reflectSliceOf(t, inputs[0].Type, []interface{}{
+		common.HexToAddress("0x000000000000000000000000000000000000dEaD"), true, []byte{0xca, 0xfe},
+	})
+	data := packCall(t, sig, calls)
+
+	call, ok := DecodeCalldata(data, []string{sig})
+	require.True(t, ok)
+	assert.Equal(t, "(address,bool,bytes)[]", call.Params[0].Type)
+	assert.Equal(t, "[(0x000000000000000000000000000000000000dEaD, true, 0xcafe)]", call.Params[0].Value)
+}
+
+func TestDecodeCalldata_RejectsMismatchedSignature(t *testing.T) {
+	data := packCall(t, "transfer(address,uint256)", common.Address{}, big.NewInt(1))
+	_, ok := DecodeCalldata(data, []string{"approve(address,uint256)"})
+	assert.False(t, ok, "another selector")
+
+	// Trailing bytes mean the arguments are not what the signature says
+	_, ok = DecodeCalldata(append(data, 0x01), []string{"transfer(address,uint256)"})
+	assert.False(t, ok)
+}
+
+func TestSignatureDB_LearnedSignatures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signatures.txt")
+	db := NewSignatureDB()
+	added := db.Add("transfer(address,uint256)", "foo(uint8", "registerName(string)")
+	assert.Equal(t, []string{"registerName(string)"}, added, "known and malformed ones are skipped")
+	require.NoError(t, SaveSignatures(path, added))
+
+	other := NewSignatureDB()
+	require.NoError(t, other.LoadFile(path))
+	call, ok := other.Decode(packCall(t, "registerName(string)", "alice"))
+	require.True(t, ok)
+	assert.Equal(t, `registerName(string "alice")`, call.String())
+
+	require.NoError(t, other.LoadFile(filepath.Join(t.TempDir(), "missing.txt")))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestSignatureDirectory_Lookup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/signatures/", r.URL.Path)
+		assert.Equal(t, "0xa9059cbb", r.URL.Query().Get("hex_signature"))
+		_, _ = w.Write([]byte(`{"count":2,"results":[{"id":1,"text_signature":"transfer(address,uint256)"},{"id":2,"text_signature":"many_msg_babbage(bytes1)"}]}`))
+	}))
+	defer server.Close()
+
+	d := &SignatureDirectory{client: server.Client(), baseURL: server.URL}
+	sigs, err := d.Lookup(context.Background(), Selector{0xa9, 0x05, 0x9c, 0xbb})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"transfer(address,uint256)", "many_msg_babbage(bytes1)"}, sigs)
+
+	d.baseURL = server.URL + "/missing"
+	server.Config.Handler = http.NotFoundHandler()
+	_, err = d.Lookup(context.Background(), Selector{})
+	assert.Error(t, err)
+}
+
+// reflectSliceOf builds a slice of the Go struct type abi uses for the
+// elements of typ, for packing tuple arrays
+func reflectSliceOf(t *testing.T, typ abi.Type, fields ...[]interface{}) interface{} {
+	t.Helper()
+	slice := reflect.MakeSlice(typ.GetType(), len(fields), len(fields))
+	for i, values := range fields {
+		elem := slice.Index(i)
+		for j, v := range values {
+			elem.Field(j).Set(reflect.ValueOf(v))
+		}
+	}
+	return slice.Interface()
+}
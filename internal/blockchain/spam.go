@@ -0,0 +1,140 @@
+package blockchain
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:embed spam_tokens.txt
+var bundledSpamTokens string
+
+// SpamListFileName is the file in the app directory extending the bundled
+// list of spam tokens
+const SpamListFileName = "spam_tokens.txt"
+
+// SpamReason tells why a token was flagged as spam; empty when it was not
+type SpamReason string
+
+const (
+	SpamListed    SpamReason = "listed"    // In the bundled or the local spam list
+	SpamURL       SpamReason = "url"       // The symbol advertises a site
+	SpamLure      SpamReason = "lure"      // The symbol invites to claim an airdrop
+	SpamLookalike SpamReason = "lookalike" // The symbol mimics another with look-alike letters
+)
+
+// spamLures are words airdropped scam tokens use in their symbols to lure
+// holders to a phishing site
+var spamLures = []string{"claim", "visit", "reward", "airdrop", "voucher", "bonus", "gift"}
+
+// spamDomains are top-level domains that give away a site in a symbol
+var spamDomains = []string{".com", ".io", ".org", ".net", ".xyz", ".app", ".finance", ".site", ".top", ".live"}
+
+// SpamList holds the contracts known to be spam: the bundled list plus the
+// ones added locally
+type SpamList struct {
+	mu        sync.RWMutex
+	contracts map[string]bool // "<chain id>:<address>", chain 0 for every chain
+}
+
+// NewSpamList returns a list holding the bundled spam contracts
+func NewSpamList() *SpamList {
+	l := &SpamList{contracts: make(map[string]bool)}
+	l.addLines(bundledSpamTokens)
+	return l
+}
+
+// LoadFile adds the contracts listed at path; a missing file is not an error
+func (l *SpamList) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	l.addLines(string(data))
+	return nil
+}
+
+// addLines reads "<chain id> <address>" lines, or bare addresses
+func (l *SpamList) addLines(text string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		address := fields[len(fields)-1]
+		if !common.IsHexAddress(address) {
+			continue
+		}
+		var chainID int64
+		if len(fields) > 1 {
+			id, err := strconv.ParseInt(fields[0], 10, 64)
+			if err != nil {
+				continue
+			}
+			chainID = id
+		}
+		l.contracts[spamKey(chainID, address)] = true
+	}
+}
+
+// Contains reports whether the contract at address on chainID is listed
+func (l *SpamList) Contains(chainID int64, address string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.contracts[spamKey(chainID, address)] || l.contracts[spamKey(0, address)]
+}
+
+func spamKey(chainID int64, address string) string {
+	return strconv.FormatInt(chainID, 10) + ":" + common.HexToAddress(address).Hex()
+}
+
+// Check tells why token is spam, listed or by the look of its symbol; empty
+// when nothing gives it away
+func (l *SpamList) Check(token Token) SpamReason {
+	if l.Contains(token.ChainID, token.Address) {
+		return SpamListed
+	}
+	return SpamHeuristic(token.Symbol)
+}
+
+// SpamHeuristic flags the symbols unsolicited airdrops use: a site to visit,
+// an invitation to claim, or letters from other scripts passing for Latin ones
+func SpamHeuristic(symbol string) SpamReason {
+	lower := strings.ToLower(symbol)
+	if strings.Contains(lower, "http") || strings.Contains(lower, "www.") || strings.Contains(lower, "t.me/") {
+		return SpamURL
+	}
+	for _, domain := range spamDomains {
+		if i := strings.Index(lower, domain); i > 0 {
+			end := i + len(domain)
+			if end == len(lower) || !unicode.IsLetter(rune(lower[end])) {
+				return SpamURL
+			}
+		}
+	}
+	for _, lure := range spamLures {
+		if strings.Contains(lower, lure) {
+			return SpamLure
+		}
+	}
+	for _, r := range symbol {
+		if r > unicode.MaxASCII && unicode.IsLetter(r) {
+			return SpamLookalike
+		}
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package blockchain
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpamHeuristic(t *testing.T) {
+	cases := map[string]SpamReason{
+		"USDC":                    "",
+		"WETH":                    "",
+		"stETH":                   "",
+		"0xA0b8…eB48":             "",
+		"Visit uni-claim.xyz":     SpamURL,
+		"https://t.me/free":       SpamURL,
+		"usdt-bonus.com":          SpamURL,
+		"$ claim rewards":         SpamLure,
+		"AIRDROP":                 SpamLure,
+		"USDС":                    SpamLookalike, // Cyrillic С
+		"ΕTH":                     SpamLookalike, // Greek Ε
+		"Comp.ound":               "",
+		"1INCH.io token":          SpamURL,
+		"WBTC.e":                  "",
+		"Reward-bearing sUSD.com": SpamURL,
+	}
+	for symbol, want := range cases {
+		assert.Equal(t, want, SpamHeuristic(symbol), symbol)
+	}
+}
+
+func TestSpamList_LoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SpamListFileName)
+	require.NoError(t, os.WriteFile(path, []byte("# local\n1 0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48\n0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0\nnot an address\n"), 0600))
+
+	list := NewSpamList()
+	require.NoError(t, list.LoadFile(path))
+	require.NoError(t, list.LoadFile(filepath.Join(t.TempDir(), "missing.txt")))
+
+	assert.True(t, list.Contains(1, previewToken.Hex()))
+	assert.False(t, list.Contains(137, previewToken.Hex()), "listed on mainnet only")
+	assert.True(t, list.Contains(137, "0x6fac4d18c912343bf86fa7049364dd4e424ab9c0"), "listed on every chain")
+	assert.Equal(t, SpamListed, list.Check(Token{ChainID: 1, Address: previewToken.Hex(), Symbol: "USDC"}))
+}
+
+func TestTokenService_SpamReview(t *testing.T) {
+	s, store := newTestTokenService(&fakeTokenBackend{})
+	mainnet := config.Network{Name: "Ethereum", ChainID: 1, RPCEndpoint: "http://a", IsActive: true}
+	usdc, err := s.AddToken(context.Background(), mainnet, previewToken.Hex())
+	require.NoError(t, err)
+	store.tokens = append(store.tokens, Token{ChainID: 1, Address: previewPayee.Hex(), Symbol: "claim-usdc.com", Decimals: 0})
+
+	// The airdropped token is hidden until allowed
+	visible, err := s.VisibleTokens(1)
+	require.NoError(t, err)
+	assert.Equal(t, []Token{usdc}, visible)
+	require.NoError(t, s.SetStatus(1, previewPayee.Hex(), TokenStatusAllowed))
+	visible, err = s.VisibleTokens(1)
+	require.NoError(t, err)
+	assert.Len(t, visible, 2)
+
+	// A blocked token stays hidden and cannot be followed again
+	require.NoError(t, s.SetStatus(1, previewToken.Hex(), TokenStatusBlocked))
+	visible, err = s.VisibleTokens(1)
+	require.NoError(t, err)
+	require.Len(t, visible, 1)
+	assert.Equal(t, "claim-usdc.com", visible[0].Symbol)
+	_, err = s.AddToken(context.Background(), mainnet, previewToken.Hex())
+	assert.ErrorIs(t, err, ErrTokenBlocked)
+	all, err := s.Tokens(1)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
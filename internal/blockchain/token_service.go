@@ -0,0 +1,312 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"blocowallet/pkg/config"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrInsufficientTokenBalance is returned when a token transfer is larger
+// than the sender's token balance
+var ErrInsufficientTokenBalance = errors.New("insufficient token balance")
+
+// ErrTokenBlocked is returned when following a token the user blocked
+var ErrTokenBlocked = errors.New("the token contract is blocked")
+
+// Review states of a token; an empty status leaves it to the spam detection
+const (
+	TokenStatusAllowed = "allowed" // Shown even when flagged as spam
+	TokenStatusBlocked = "blocked" // Never shown nor followed again
+)
+
+// Token is an ERC-20 contract the user follows on a network. Symbol and
+// decimals are read from the contract when it is added.
+type Token struct {
+	ID        int       `gorm:"primaryKey"`
+	ChainID   int64     `gorm:"not null;uniqueIndex:idx_token"`
+	Address   string    `gorm:"not null;uniqueIndex:idx_token"` // checksummed
+	Symbol    string    `gorm:"not null"`
+	Decimals  int       `gorm:"not null"`
+	Status    string    `gorm:"not null;default:''"` // TokenStatusAllowed, TokenStatusBlocked or empty
+	CreatedAt time.Time `gorm:"not null;autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados
+func (Token) TableName() string {
+	return "tokens"
+}
+
+// TokenStore is implemented by repositories that keep the followed tokens
+type TokenStore interface {
+	AddToken(token *Token) error
+	DeleteToken(chainID int64, address string) error
+	// SetTokenStatus records the review of a token, keeping it when blocked
+	SetTokenStatus(chainID int64, address, status string) error
+	// Tokens lists the tokens of chainID, or of every chain when it is 0
+	Tokens(chainID int64) ([]Token, error)
+}
+
+// TokenBalance is the balance of one token on one network, or why it could
+// not be read
+type TokenBalance struct {
+	NetworkKey string
+	Token      Token
+	Amount     *big.Int
+	Error      error
+}
+
+// TokenService adds and removes followed tokens, reads their balances and
+// builds transfers of them. Tokens flagged as spam are left out until the
+// user allows them.
+type TokenService struct {
+	store TokenStore
+	spam  *SpamList
+	dial  func(network config.Network) (previewBackend, func(), error)
+}
+
+// NewTokenService returns a service keeping its tokens in store
+func NewTokenService(store TokenStore) *TokenService {
+	return &TokenService{
+		store: store,
+		spam:  NewSpamList(),
+		dial: func(n config.Network) (previewBackend, func(), error) {
+			client, err := NewEthereum(n.RPCEndpoint, DefaultTimeout, n.Symbol, 18, n.Name)
+			if err != nil {
+				return nil, nil, err
+			}
+			return client.client, client.Close, nil
+		},
+	}
+}
+
+// AddToken follows the ERC-20 contract at address on network, reading its
+// symbol and decimals. A contract that does not answer decimals() is refused
+// with ErrNotAToken.
+func (s *TokenService) AddToken(ctx context.Context, network config.Network, address string) (Token, error) {
+	if !common.IsHexAddress(address) {
+		return Token{}, fmt.Errorf("invalid token address %q", address)
+	}
+	contract := common.HexToAddress(address)
+	if s.blocked(network.ChainID, contract.Hex()) {
+		return Token{}, fmt.Errorf("%w: %s", ErrTokenBlocked, contract.Hex())
+	}
+	backend, closeBackend, err := s.dial(network)
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to connect to %s: %w", network.Name, err)
+	}
+	defer closeBackend()
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+	values, err := callERC20(ctx, backend, contract, "decimals")
+	if err != nil {
+		return Token{}, err
+	}
+	decimals, ok := values[0].(uint8)
+	if !ok {
+		return Token{}, fmt.Errorf("%w: unexpected decimals result from %s", ErrNotAToken, contract.Hex())
+	}
+	token := Token{
+		ChainID:  network.ChainID,
+		Address:  contract.Hex(),
+		Symbol:   tokenSymbol(ctx, backend, contract),
+		Decimals: int(decimals),
+	}
+	if err := s.store.AddToken(&token); err != nil {
+		return Token{}, err
+	}
+	return token, nil
+}
+
+// RemoveToken stops following the token at address on chainID
+func (s *TokenService) RemoveToken(chainID int64, address string) error {
+	return s.store.DeleteToken(chainID, common.HexToAddress(address).Hex())
+}
+
+// Tokens lists the tokens followed on chainID, or on every chain when it is
+// 0, including the ones hidden as spam
+func (s *TokenService) Tokens(chainID int64) ([]Token, error) {
+	return s.store.Tokens(chainID)
+}
+
+// VisibleTokens lists the tokens of chainID that are not hidden as spam
+func (s *TokenService) VisibleTokens(chainID int64) ([]Token, error) {
+	tokens, err := s.store.Tokens(chainID)
+	if err != nil {
+		return nil, err
+	}
+	visible := tokens[:0]
+	for _, t := range tokens {
+		if s.Visible(t) {
+			visible = append(visible, t)
+		}
+	}
+	return visible, nil
+}
+
+// LoadSpamList adds the contracts listed in the file at path to the spam list
+func (s *TokenService) LoadSpamList(path string) error {
+	return s.spam.LoadFile(path)
+}
+
+// SpamReason tells why token is flagged as spam; empty when it is not
+func (s *TokenService) SpamReason(token Token) SpamReason {
+	return s.spam.Check(token)
+}
+
+// Visible reports whether token is shown: allowed by the user, or neither
+// blocked nor flagged as spam
+func (s *TokenService) Visible(token Token) bool {
+	switch token.Status {
+	case TokenStatusAllowed:
+		return true
+	case TokenStatusBlocked:
+		return false
+	}
+	return s.SpamReason(token) == ""
+}
+
+// SetStatus records the review of the token at address on chainID: allowed,
+// blocked, or back to the spam detection when status is empty
+func (s *TokenService) SetStatus(chainID int64, address, status string) error {
+	return s.store.SetTokenStatus(chainID, common.HexToAddress(address).Hex(), status)
+}
+
+// blocked reports whether the user blocked the contract at address on chainID
+func (s *TokenService) blocked(chainID int64, address string) bool {
+	tokens, err := s.store.Tokens(chainID)
+	if err != nil {
+		return false
+	}
+	for _, t := range tokens {
+		if t.Address == address {
+			return t.Status == TokenStatusBlocked
+		}
+	}
+	return false
+}
+
+// Balances reads the balance of owner in every followed token of each usable
+// network, the networks concurrently. Each result is sent on the returned
+// channel as it arrives; the channel is closed once all networks answered.
+func (s *TokenService) Balances(ctx context.Context, owner string, networks map[string]config.Network) <-chan TokenBalance {
+	all, err := s.VisibleTokens(0)
+	byChain := make(map[int64][]Token)
+	for _, t := range all {
+		byChain[t.ChainID] = append(byChain[t.ChainID], t)
+	}
+	total := 0
+	for _, network := range networks {
+		total += len(byChain[network.ChainID])
+	}
+	results := make(chan TokenBalance, total)
+	if err != nil || total == 0 {
+		close(results)
+		return results
+	}
+
+	account := common.HexToAddress(owner)
+	var wg sync.WaitGroup
+	for key, network := range networks {
+		tokens := byChain[network.ChainID]
+		if len(tokens) == 0 || !network.Usable() || network.RPCEndpoint == "" {
+			continue
+		}
+		wg.Add(1)
+		go func(key string, network config.Network, tokens []Token) {
+			defer wg.Done()
+			backend, closeBackend, err := s.dial(network)
+			if err != nil {
+				for _, t := range tokens {
+					results <- TokenBalance{NetworkKey: key, Token: t, Error: fmt.Errorf("failed to connect to %s: %w", network.Name, err)}
+				}
+				return
+			}
+			defer closeBackend()
+			for _, t := range tokens {
+				ctx, cancel := context.WithTimeout(ctx, balanceTimeout)
+				amount, err := callUint(ctx, backend, common.HexToAddress(t.Address), "balanceOf", account)
+				cancel()
+				results <- TokenBalance{NetworkKey: key, Token: t, Amount: amount, Error: err}
+			}
+		}(key, network, tokens)
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+// TransferData encodes an ERC-20 transfer of amount to recipient, the data
+// of a transaction sent to the token contract with no value
+func TransferData(recipient common.Address, amount *big.Int) []byte {
+	data, err := parsedERC20ABI.Pack(TokenTransfer, recipient, amount)
+	if err != nil {
+		// Only reachable with a nil or negative amount
+		panic(err)
+	}
+	return data
+}
+
+// CheckTransfer makes sure from holds at least amount of token on network,
+// so a transfer is refused before any gas is estimated
+func (s *TokenService) CheckTransfer(ctx context.Context, network config.Network, token Token, from common.Address, amount *big.Int) error {
+	backend, closeBackend, err := s.dial(network)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", network.Name, err)
+	}
+	defer closeBackend()
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+	balance, err := callUint(ctx, backend, common.HexToAddress(token.Address), "balanceOf", from)
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(amount) < 0 {
+		return fmt.Errorf("%w: %s %s available", ErrInsufficientTokenBalance, formatTokenUnits(balance, token.Decimals), token.Symbol)
+	}
+	return nil
+}
+
+// HolderBalances reads the balance of each owner in each token on network,
+// indexed by token then owner, such as for a sweep of many wallets
+func (s *TokenService) HolderBalances(ctx context.Context, network config.Network, tokens []Token, owners []common.Address) ([][]*big.Int, error) {
+	backend, closeBackend, err := s.dial(network)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", network.Name, err)
+	}
+	defer closeBackend()
+	balances := make([][]*big.Int, len(tokens))
+	for i, token := range tokens {
+		balances[i] = make([]*big.Int, len(owners))
+		for j, owner := range owners {
+			ctx, cancel := context.WithTimeout(ctx, balanceTimeout)
+			balances[i][j], err = callUint(ctx, backend, common.HexToAddress(token.Address), "balanceOf", owner)
+			cancel()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the %s balance of %s: %w", token.Symbol, owner.Hex(), err)
+			}
+		}
+	}
+	return balances, nil
+}
+
+// formatTokenUnits shows an amount in whole token units, without trailing zeros
+func formatTokenUnits(amount *big.Int, decimals int) string {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	whole, frac := new(big.Int).QuoRem(amount, unit, new(big.Int))
+	if frac.Sign() == 0 {
+		return whole.String()
+	}
+	digits := fmt.Sprintf("%0*s", decimals, frac.String())
+	return whole.String() + "." + strings.TrimRight(digits, "0")
+}
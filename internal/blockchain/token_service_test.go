@@ -0,0 +1,156 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"blocowallet/pkg/config"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryTokenStore keeps tokens in a slice, like the repository does in a table
+type memoryTokenStore struct {
+	tokens []Token
+}
+
+func (s *memoryTokenStore) AddToken(token *Token) error {
+	for i, t := range s.tokens {
+		if t.ChainID == token.ChainID && t.Address == token.Address {
+			token.Status = t.Status
+			s.tokens[i] = *token
+			return nil
+		}
+	}
+	s.tokens = append(s.tokens, *token)
+	return nil
+}
+
+func (s *memoryTokenStore) DeleteToken(chainID int64, address string) error {
+	kept := s.tokens[:0]
+	for _, t := range s.tokens {
+		if t.ChainID != chainID || t.Address != address {
+			kept = append(kept, t)
+		}
+	}
+	s.tokens = kept
+	return nil
+}
+
+func (s *memoryTokenStore) SetTokenStatus(chainID int64, address, status string) error {
+	for i, t := range s.tokens {
+		if t.ChainID == chainID && t.Address == address {
+			s.tokens[i].Status = status
+			return nil
+		}
+	}
+	return errors.New("token not found")
+}
+
+func (s *memoryTokenStore) Tokens(chainID int64) ([]Token, error) {
+	var out []Token
+	for _, t := range s.tokens {
+		if chainID == 0 || t.ChainID == chainID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// noContractBackend answers every call like an account without code
+type noContractBackend struct{ *fakeTokenBackend }
+
+func (noContractBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return nil, nil
+}
+
+func newTestTokenService(backend previewBackend) (*TokenService, *memoryTokenStore) {
+	store := &memoryTokenStore{}
+	s := NewTokenService(store)
+	s.dial = func(n config.Network) (previewBackend, func(), error) {
+		if n.RPCEndpoint == "" {
+			return nil, nil, errors.New("no endpoint")
+		}
+		return backend, func() {}, nil
+	}
+	return s, store
+}
+
+func TestTokenService_AddAndRemove(t *testing.T) {
+	s, store := newTestTokenService(&fakeTokenBackend{})
+	mainnet := config.Network{Name: "Ethereum", ChainID: 1, RPCEndpoint: "http://a", IsActive: true}
+
+	token, err := s.AddToken(context.Background(), mainnet, "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	require.NoError(t, err)
+	assert.Equal(t, Token{ChainID: 1, Address: previewToken.Hex(), Symbol: "USDC", Decimals: 6}, token)
+	assert.Len(t, store.tokens, 1)
+
+	_, err = s.AddToken(context.Background(), mainnet, "not an address")
+	assert.Error(t, err)
+
+	notToken, _ := newTestTokenService(noContractBackend{&fakeTokenBackend{}})
+	_, err = notToken.AddToken(context.Background(), mainnet, previewPayee.Hex())
+	assert.ErrorIs(t, err, ErrNotAToken)
+
+	require.NoError(t, s.RemoveToken(1, "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"))
+	assert.Empty(t, store.tokens)
+}
+
+func TestTokenService_BalancesAndTransfer(t *testing.T) {
+	backend := &fakeTokenBackend{balances: map[common.Address]*big.Int{previewSender: big.NewInt(2_500_000)}}
+	s, store := newTestTokenService(backend)
+	usdc := Token{ChainID: 1, Address: previewToken.Hex(), Symbol: "USDC", Decimals: 6}
+	store.tokens = []Token{usdc, {ChainID: 137, Address: previewToken.Hex(), Symbol: "USDC", Decimals: 6}, {ChainID: 10, Address: previewToken.Hex(), Symbol: "OP", Decimals: 18}}
+	networks := map[string]config.Network{
+		"mainnet": {Name: "Ethereum", ChainID: 1, RPCEndpoint: "http://a", IsActive: true},
+		"polygon": {Name: "Polygon", ChainID: 137, IsActive: true},
+		"base":    {Name: "Base", ChainID: 8453, RPCEndpoint: "http://b", IsActive: true},
+	}
+
+	var balances []TokenBalance
+	for b := range s.Balances(context.Background(), previewSender.Hex(), networks) {
+		balances = append(balances, b)
+	}
+	require.Len(t, balances, 1, "networks without endpoint or tokens are skipped")
+	assert.Equal(t, "mainnet", balances[0].NetworkKey)
+	assert.Equal(t, big.NewInt(2_500_000), balances[0].Amount)
+
+	assert.NoError(t, s.CheckTransfer(context.Background(), networks["mainnet"], usdc, previewSender, big.NewInt(2_500_000)))
+	err := s.CheckTransfer(context.Background(), networks["mainnet"], usdc, previewSender, big.NewInt(2_500_001))
+	assert.ErrorIs(t, err, ErrInsufficientTokenBalance)
+	assert.Contains(t, err.Error(), "2.5 USDC available")
+
+	call, ok := DecodeTokenCall(TransferData(previewPayee, big.NewInt(1_000_000)))
+	require.True(t, ok)
+	assert.Equal(t, TokenTransfer, call.Method)
+	assert.Equal(t, previewPayee, call.To)
+	assert.Equal(t, big.NewInt(1_000_000), call.Amount)
+}
+
+func TestTokenService_HolderBalances(t *testing.T) {
+	backend := &fakeTokenBackend{balances: map[common.Address]*big.Int{previewSender: big.NewInt(2_500_000)}}
+	s, _ := newTestTokenService(backend)
+	usdc := Token{ChainID: 1, Address: previewToken.Hex(), Symbol: "USDC", Decimals: 6}
+
+	balances, err := s.HolderBalances(context.Background(), config.Network{Name: "Ethereum", ChainID: 1, RPCEndpoint: "http://a"}, []Token{usdc}, []common.Address{previewSender, previewPayee})
+	require.NoError(t, err)
+	require.Len(t, balances, 1)
+	require.Len(t, balances[0], 2)
+	assert.Equal(t, "2500000", balances[0][0].String())
+	assert.Zero(t, balances[0][1].Sign())
+
+	_, err = s.HolderBalances(context.Background(), config.Network{Name: "Polygon", ChainID: 137}, []Token{usdc}, []common.Address{previewSender})
+	assert.ErrorContains(t, err, "failed to connect to Polygon")
+}
+
+func TestFormatTokenUnits(t *testing.T) {
+	assert.Equal(t, "0", formatTokenUnits(big.NewInt(0), 6))
+	assert.Equal(t, "0.000001", formatTokenUnits(big.NewInt(1), 6))
+	assert.Equal(t, "12", formatTokenUnits(big.NewInt(12_000_000), 6))
+	assert.Equal(t, "7", formatTokenUnits(big.NewInt(7), 0))
+}
@@ -0,0 +1,81 @@
+// Package buildinfo describes the running binary for the About screen and
+// bug reports.
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
+
+// Info identifies a build. Version, Commit and Date come from the linker
+// flags of the release build; a plain "go build" falls back to the module
+// version and the VCS stamp Go records in the binary.
+type Info struct {
+	Version   string
+	Commit    string
+	Date      string
+	GoVersion string
+	OS        string
+	Arch      string
+	Tags      []string // Build tags, sorted
+}
+
+// New returns the Info of the running binary, preferring the injected values
+// unless they are the "dev"/"unknown" placeholders
+func New(version, commit, date string) Info {
+	info := Info{
+		Version:   version,
+		Commit:    commit,
+		Date:      date,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if isPlaceholder(info.Version) && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		info.Version = bi.Main.Version
+	}
+	settings := make(map[string]string, len(bi.Settings))
+	for _, s := range bi.Settings {
+		settings[s.Key] = s.Value
+	}
+	if isPlaceholder(info.Commit) && settings["vcs.revision"] != "" {
+		info.Commit = settings["vcs.revision"]
+		if settings["vcs.modified"] == "true" {
+			info.Commit += "-dirty"
+		}
+	}
+	if isPlaceholder(info.Date) && settings["vcs.time"] != "" {
+		info.Date = settings["vcs.time"]
+	}
+	info.Tags = parseTags(settings["-tags"])
+	return info
+}
+
+// HasTag reports whether the binary was built with tag
+func (i Info) HasTag(tag string) bool {
+	for _, t := range i.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func isPlaceholder(s string) bool {
+	return s == "" || s == "dev" || s == "unknown"
+}
+
+func parseTags(s string) []string {
+	tags := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' })
+	if len(tags) == 0 {
+		return nil
+	}
+	sort.Strings(tags)
+	return tags
+}
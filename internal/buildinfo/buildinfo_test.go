@@ -0,0 +1,23 @@
+package buildinfo
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_KeepsInjectedValues(t *testing.T) {
+	info := New("v1.2.3", "abc1234", "2025-01-01T00:00:00Z")
+	assert.Equal(t, "v1.2.3", info.Version)
+	assert.Equal(t, "abc1234", info.Commit)
+	assert.Equal(t, "2025-01-01T00:00:00Z", info.Date)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+	assert.Equal(t, runtime.GOARCH, info.Arch)
+}
+
+func TestParseTags(t *testing.T) {
+	assert.Equal(t, []string{"netgo", "production"}, parseTags("production,netgo"))
+	assert.Nil(t, parseTags(""))
+	assert.True(t, Info{Tags: parseTags("netgo,production")}.HasTag("production"))
+}
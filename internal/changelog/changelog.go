@@ -0,0 +1,166 @@
+// Package changelog holds the release notes embedded at build time and tells
+// which of them the user has not seen since the last run.
+package changelog
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// StateFileName is the file inside the application directory that keeps the
+// version of the last run
+const StateFileName = "last_version"
+
+// ReleasesURL is where the full notes of every release are published
+const ReleasesURL = "https://github.com/italoag/bloco-wallet-manager/releases"
+
+//go:embed changelog.toml
+var embedded []byte
+
+// Notes are the highlights of a release in one language
+type Notes struct {
+	Features []string `toml:"features"`
+	Breaking []string `toml:"breaking"`
+}
+
+// Release is the changelog entry of one version
+type Release struct {
+	Version string           `toml:"version"`
+	Notes   map[string]Notes `toml:"notes"`
+}
+
+// Localized returns the notes in lang, or the English ones when the release
+// was not translated
+func (r Release) Localized(lang string) Notes {
+	if notes, ok := r.Notes[lang]; ok {
+		return notes
+	}
+	return r.Notes["en"]
+}
+
+// URL links to the full release notes of the version
+func (r Release) URL() string {
+	return ReleasesURL + "/tag/v" + strings.TrimPrefix(r.Version, "v")
+}
+
+// Releases returns the embedded releases, newest first
+func Releases() ([]Release, error) {
+	return parse(embedded)
+}
+
+func parse(data []byte) ([]Release, error) {
+	var doc struct {
+		Release []Release `toml:"release"`
+	}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse changelog: %w", err)
+	}
+	for _, r := range doc.Release {
+		if _, ok := parseVersion(r.Version); !ok {
+			return nil, fmt.Errorf("invalid changelog version %q", r.Version)
+		}
+		if _, ok := r.Notes["en"]; !ok {
+			return nil, fmt.Errorf("changelog of %s has no English notes", r.Version)
+		}
+	}
+	sort.SliceStable(doc.Release, func(i, j int) bool {
+		return Compare(doc.Release[i].Version, doc.Release[j].Version) > 0
+	})
+	return doc.Release, nil
+}
+
+// Compare orders two versions like "v1.2.3", returning -1, 0 or 1. Anything
+// after a "-" (pre-release, git describe suffix) is ignored, and versions that
+// cannot be parsed sort before every release.
+func Compare(a, b string) int {
+	va, okA := parseVersion(a)
+	vb, okB := parseVersion(b)
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okA:
+		return -1
+	case !okB:
+		return 1
+	}
+	for i := range va {
+		if va[i] != vb[i] {
+			if va[i] < vb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// parseVersion reads major.minor.patch; "dev" and other placeholders are not
+// versions
+func parseVersion(s string) ([3]int, bool) {
+	var v [3]int
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+// Since returns the releases newer than previous up to current, newest first
+func Since(releases []Release, previous, current string) []Release {
+	var out []Release
+	for _, r := range releases {
+		if Compare(r.Version, previous) > 0 && Compare(r.Version, current) <= 0 {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Upgrade records current as the version of this run and returns the
+// releases the user has not seen since the previous one. A first run, a
+// downgrade or a development build shows nothing; development builds leave
+// the recorded version alone.
+func Upgrade(appDir, current string) ([]Release, error) {
+	if _, ok := parseVersion(current); !ok {
+		return nil, nil
+	}
+	path := filepath.Join(appDir, StateFileName)
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read last version: %w", err)
+	}
+	previous := strings.TrimSpace(string(data))
+	if previous == current {
+		return nil, nil
+	}
+	if err := os.WriteFile(path, []byte(current+"\n"), 0600); err != nil {
+		return nil, fmt.Errorf("failed to record version: %w", err)
+	}
+	if _, ok := parseVersion(previous); !ok || Compare(current, previous) <= 0 {
+		return nil, nil
+	}
+	releases, err := Releases()
+	if err != nil {
+		return nil, err
+	}
+	return Since(releases, previous, current), nil
+}
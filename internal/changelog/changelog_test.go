@@ -0,0 +1,93 @@
+package changelog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	assert.Equal(t, 0, Compare("v0.3.0", "0.3.0"))
+	assert.Equal(t, 0, Compare("v0.3.0-4-g1a2b3c-dirty", "0.3"))
+	assert.Equal(t, -1, Compare("0.2.9", "0.3.0"))
+	assert.Equal(t, 1, Compare("1.0.0", "0.10.0"))
+	assert.Equal(t, -1, Compare("dev", "0.1.0"))
+	assert.Equal(t, 0, Compare("dev", "unknown"))
+}
+
+func TestReleases_Embedded(t *testing.T) {
+	releases, err := Releases()
+	require.NoError(t, err)
+	require.NotEmpty(t, releases)
+	for i, r := range releases {
+		assert.NotEmpty(t, r.Localized("en").Features, r.Version)
+		if i > 0 {
+			assert.Equal(t, 1, Compare(releases[i-1].Version, r.Version), "newest first")
+		}
+	}
+	assert.Equal(t, ReleasesURL+"/tag/v0.3.0", Release{Version: "0.3.0"}.URL())
+}
+
+func TestParse_RequiresEnglish(t *testing.T) {
+	_, err := parse([]byte("[[release]]\nversion = \"1.0.0\"\n[release.notes.pt]\nfeatures = [\"x\"]\n"))
+	assert.Error(t, err)
+	_, err = parse([]byte("[[release]]\nversion = \"next\"\n[release.notes.en]\nfeatures = [\"x\"]\n"))
+	assert.Error(t, err)
+}
+
+func TestRelease_Localized(t *testing.T) {
+	r := Release{Version: "1.0.0", Notes: map[string]Notes{
+		"en": {Features: []string{"Faster"}},
+		"pt": {Features: []string{"Mais rápido"}},
+	}}
+	assert.Equal(t, "Mais rápido", r.Localized("pt").Features[0])
+	assert.Equal(t, "Faster", r.Localized("es").Features[0])
+}
+
+func TestSince(t *testing.T) {
+	releases := []Release{{Version: "0.4.0"}, {Version: "0.3.1"}, {Version: "0.3.0"}, {Version: "0.2.0"}}
+	got := Since(releases, "0.2.0", "v0.3.1")
+	require.Len(t, got, 2)
+	assert.Equal(t, "0.3.1", got[0].Version)
+	assert.Equal(t, "0.3.0", got[1].Version)
+}
+
+func TestUpgrade(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, StateFileName)
+
+	// First run only records the version
+	notes, err := Upgrade(dir, "v0.2.0")
+	require.NoError(t, err)
+	assert.Empty(t, notes)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "v0.2.0\n", string(data))
+
+	// Development builds neither show nor record anything
+	notes, err = Upgrade(dir, "dev")
+	require.NoError(t, err)
+	assert.Empty(t, notes)
+	data, _ = os.ReadFile(path)
+	assert.Equal(t, "v0.2.0\n", string(data))
+
+	notes, err = Upgrade(dir, "v0.3.0")
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "0.3.0", notes[0].Version)
+
+	// Shown once
+	notes, err = Upgrade(dir, "v0.3.0")
+	require.NoError(t, err)
+	assert.Empty(t, notes)
+
+	// A downgrade is recorded silently
+	notes, err = Upgrade(dir, "v0.2.0")
+	require.NoError(t, err)
+	assert.Empty(t, notes)
+	data, _ = os.ReadFile(path)
+	assert.Equal(t, "v0.2.0\n", string(data))
+}
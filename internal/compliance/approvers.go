@@ -0,0 +1,179 @@
+package compliance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const defaultPollInterval = 2 * time.Second
+
+// FileApprover co-signs through a shared directory: the request is written as
+// "<id>.request.json" and the approver answers with "<id>.approval.json".
+// Anyone able to write to the directory could answer, so only decisions
+// signed with one of Keys are accepted.
+type FileApprover struct {
+	Dir          string
+	Keys         ApproverKeys
+	PollInterval time.Duration
+}
+
+// NewFileApprover creates a file based approver for dir that accepts the
+// decisions signed with keys
+func NewFileApprover(dir string, keys ApproverKeys) *FileApprover {
+	return &FileApprover{Dir: dir, Keys: keys, PollInterval: defaultPollInterval}
+}
+
+// Describe tells the user which file the approver must create
+func (a *FileApprover) Describe(req Request) string {
+	return filepath.Join(a.Dir, req.ID+".approval.json")
+}
+
+// Approve writes the request and waits for the approval file
+func (a *FileApprover) Approve(ctx context.Context, req Request) (Decision, error) {
+	if err := os.MkdirAll(a.Dir, 0750); err != nil {
+		return Decision{}, fmt.Errorf("failed to create approval directory: %w", err)
+	}
+	data, err := json.MarshalIndent(req, "", "  ")
+	if err != nil {
+		return Decision{}, err
+	}
+	if err := os.WriteFile(filepath.Join(a.Dir, req.ID+".request.json"), data, 0640); err != nil {
+		return Decision{}, fmt.Errorf("failed to write approval request: %w", err)
+	}
+
+	approvalPath := a.Describe(req)
+	ticker := time.NewTicker(a.PollInterval)
+	defer ticker.Stop()
+	for {
+		decision, err := readDecision(approvalPath, req, a.Keys)
+		if err == nil {
+			return decision, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return Decision{}, err
+		}
+		select {
+		case <-ctx.Done():
+			return Decision{}, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func readDecision(path string, req Request, keys ApproverKeys) (Decision, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Decision{}, err
+	}
+	if keys == nil {
+		// The file approver always checks the signature
+		keys = ApproverKeys{}
+	}
+	return parseDecision(data, req, keys)
+}
+
+// parseDecision reads the decision on req; with keys, it must be signed by
+// one of them
+func parseDecision(data []byte, req Request, keys ApproverKeys) (Decision, error) {
+	var decision Decision
+	if err := json.Unmarshal(data, &decision); err != nil {
+		return Decision{}, fmt.Errorf("invalid approval: %w", err)
+	}
+	if decision.RequestID != req.ID {
+		return Decision{}, fmt.Errorf("approval is for request %q, expected %q", decision.RequestID, req.ID)
+	}
+	if keys != nil {
+		if err := keys.Verify(req, decision); err != nil {
+			return Decision{}, fmt.Errorf("approval rejected: %w", err)
+		}
+	}
+	return decision, nil
+}
+
+// EndpointApprover co-signs through a remote service. The request is POSTed as
+// JSON; the service answers 200 with a decision, or 202 while the decision is
+// pending, in which case "<endpoint>/<id>" is polled until it answers 200.
+// When Keys is set, decisions must be signed with one of them as well.
+type EndpointApprover struct {
+	URL          string
+	Keys         ApproverKeys
+	Client       *http.Client
+	PollInterval time.Duration
+}
+
+// NewEndpointApprover creates an approver for the given endpoint URL
+func NewEndpointApprover(url string) *EndpointApprover {
+	return &EndpointApprover{
+		URL:          strings.TrimRight(url, "/"),
+		Client:       &http.Client{Timeout: 30 * time.Second},
+		PollInterval: defaultPollInterval,
+	}
+}
+
+// Describe tells the user where the request is pending
+func (a *EndpointApprover) Describe(req Request) string {
+	return a.URL + "/" + req.ID
+}
+
+// Approve submits the request and waits for the decision
+func (a *EndpointApprover) Approve(ctx context.Context, req Request) (Decision, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return Decision{}, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, a.URL, bytes.NewReader(body))
+	if err != nil {
+		return Decision{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	decision, pending, err := a.do(httpReq, req)
+	for err == nil && pending {
+		select {
+		case <-ctx.Done():
+			return Decision{}, ctx.Err()
+		case <-time.After(a.PollInterval):
+		}
+		pollReq, reqErr := http.NewRequestWithContext(ctx, http.MethodGet, a.Describe(req), nil)
+		if reqErr != nil {
+			return Decision{}, reqErr
+		}
+		decision, pending, err = a.do(pollReq, req)
+	}
+	return decision, err
+}
+
+func (a *EndpointApprover) do(httpReq *http.Request, req Request) (Decision, bool, error) {
+	resp, err := a.Client.Do(httpReq)
+	if err != nil {
+		return Decision{}, false, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return Decision{}, false, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var keys ApproverKeys
+		if len(a.Keys) > 0 {
+			keys = a.Keys
+		}
+		decision, err := parseDecision(data, req, keys)
+		return decision, false, err
+	case http.StatusAccepted:
+		return Decision{}, true, nil
+	default:
+		return Decision{}, false, fmt.Errorf("approval endpoint returned %s", resp.Status)
+	}
+}
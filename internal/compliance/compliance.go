@@ -0,0 +1,196 @@
+// Package compliance implements the enterprise approval workflow: secret
+// exports and wallet deletions must be co-signed by a second person, either
+// through a shared approval directory or a remote approval endpoint, and every
+// request and decision is written to the audit log.
+package compliance
+
+import (
+	"blocowallet/internal/audit"
+	"blocowallet/pkg/config"
+	"context"
+	"errors"
+	"fmt"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Action identifies an operation that needs approval
+type Action string
+
+const (
+	ActionExportSecret Action = "export_secret"
+	ActionDeleteWallet Action = "delete_wallet"
+)
+
+var (
+	ErrApprovalDenied = errors.New("the action was denied by the approver")
+	ErrSelfApproval   = errors.New("the action must be approved by someone other than the requester")
+)
+
+// Request describes an action waiting for approval
+type Request struct {
+	ID          string    `json:"id"`
+	Action      Action    `json:"action"`
+	Subject     string    `json:"subject"`
+	RequestedBy string    `json:"requested_by"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// Decision is the approver's answer to a request
+type Decision struct {
+	RequestID string    `json:"request_id"`
+	Approved  bool      `json:"approved"`
+	Approver  string    `json:"approver"`
+	Reason    string    `json:"reason,omitempty"`
+	DecidedAt time.Time `json:"decided_at,omitempty"`
+	// Signature is the approver's Ed25519 signature of the request and the
+	// decision, base64 encoded; see SignDecision
+	Signature string `json:"signature,omitempty"`
+}
+
+// Approver obtains a decision for a request, blocking until one is available or ctx ends
+type Approver interface {
+	Approve(ctx context.Context, req Request) (Decision, error)
+	// Describe tells the user where the approver has to act
+	Describe(req Request) string
+}
+
+// Gate enforces approvals for sensitive actions. A nil Gate, or one created
+// with compliance disabled, allows everything without recording anything.
+type Gate struct {
+	approver Approver
+	auditLog *audit.Logger
+	operator string
+	timeout  time.Duration
+}
+
+// NewGate builds the gate from configuration. It returns nil when compliance mode is disabled.
+func NewGate(cfg *config.Config, auditLog *audit.Logger) (*Gate, error) {
+	if !cfg.Compliance.Enabled {
+		return nil, nil
+	}
+	if auditLog == nil {
+		return nil, errors.New("compliance mode requires an audit log")
+	}
+
+	keys, err := ParseApproverKeys(cfg.Compliance.ApproverKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	var approver Approver
+	if endpoint := strings.TrimSpace(cfg.Compliance.ApprovalEndpoint); endpoint != "" {
+		endpointApprover := NewEndpointApprover(endpoint)
+		endpointApprover.Keys = keys
+		approver = endpointApprover
+	} else {
+		if len(keys) == 0 {
+			return nil, errors.New("approvals through approval_dir must be signed: configure the approvers under [compliance.approver_keys]")
+		}
+		dir := strings.TrimSpace(cfg.Compliance.ApprovalDir)
+		if dir == "" {
+			dir = filepath.Join(cfg.AppDir, "approvals")
+		}
+		approver = NewFileApprover(dir, keys)
+	}
+
+	return NewGateWithApprover(approver, auditLog, cfg.Compliance.Operator, time.Duration(cfg.Compliance.TimeoutSeconds)*time.Second), nil
+}
+
+// NewGateWithApprover builds a gate with an explicit approver
+func NewGateWithApprover(approver Approver, auditLog *audit.Logger, operator string, timeout time.Duration) *Gate {
+	operator = strings.TrimSpace(operator)
+	if operator == "" {
+		operator = currentUserName()
+	}
+	return &Gate{approver: approver, auditLog: auditLog, operator: operator, timeout: timeout}
+}
+
+// Enabled reports whether actions need approval
+func (g *Gate) Enabled() bool {
+	return g != nil && g.approver != nil
+}
+
+// NewRequest prepares an approval request for action on subject
+func (g *Gate) NewRequest(action Action, subject string) Request {
+	return Request{
+		ID:          uuid.NewString(),
+		Action:      action,
+		Subject:     subject,
+		RequestedBy: g.operator,
+		RequestedAt: time.Now().UTC(),
+	}
+}
+
+// Describe tells the user where the request has to be approved
+func (g *Gate) Describe(req Request) string {
+	return g.approver.Describe(req)
+}
+
+// Authorize waits for the approver's decision and records it. It returns nil
+// only when someone other than the requester approved the request and the
+// approval was written to the audit log; audit failures deny the action.
+func (g *Gate) Authorize(ctx context.Context, req Request) error {
+	if err := g.record(req, audit.DecisionRequested, "", ""); err != nil {
+		return err
+	}
+
+	if g.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.timeout)
+		defer cancel()
+	}
+
+	decision, err := g.approver.Approve(ctx, req)
+	if err != nil {
+		_ = g.record(req, audit.DecisionError, "", err.Error())
+		return fmt.Errorf("approval failed: %w", err)
+	}
+	if decision.Approver == "" || strings.EqualFold(decision.Approver, req.RequestedBy) {
+		_ = g.record(req, audit.DecisionDenied, decision.Approver, ErrSelfApproval.Error())
+		return ErrSelfApproval
+	}
+	if !decision.Approved {
+		_ = g.record(req, audit.DecisionDenied, decision.Approver, decision.Reason)
+		if decision.Reason != "" {
+			return fmt.Errorf("%w: %s", ErrApprovalDenied, decision.Reason)
+		}
+		return ErrApprovalDenied
+	}
+	return g.record(req, audit.DecisionApproved, decision.Approver, decision.Reason)
+}
+
+// RecordOutcome writes whether an approved action was carried out
+func (g *Gate) RecordOutcome(req Request, err error) error {
+	if err != nil {
+		return g.record(req, audit.DecisionFailed, "", err.Error())
+	}
+	return g.record(req, audit.DecisionExecuted, "", "")
+}
+
+func (g *Gate) record(req Request, decision, approver, detail string) error {
+	err := g.auditLog.Record(audit.Entry{
+		RequestID: req.ID,
+		Action:    string(req.Action),
+		Subject:   req.Subject,
+		Actor:     req.RequestedBy,
+		Approver:  approver,
+		Decision:  decision,
+		Detail:    detail,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+func currentUserName() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return "unknown"
+}
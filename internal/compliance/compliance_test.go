@@ -0,0 +1,232 @@
+package compliance
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"blocowallet/internal/audit"
+	"blocowallet/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticApprover returns a fixed decision
+type staticApprover struct {
+	decision Decision
+	err      error
+}
+
+func (a staticApprover) Approve(_ context.Context, req Request) (Decision, error) {
+	d := a.decision
+	d.RequestID = req.ID
+	return d, a.err
+}
+
+func (a staticApprover) Describe(Request) string { return "test" }
+
+func newTestGate(t *testing.T, approver Approver) (*Gate, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), audit.FileName)
+	log, err := audit.Open(path)
+	require.NoError(t, err)
+	return NewGateWithApprover(approver, log, "alice", time.Minute), path
+}
+
+func decisions(t *testing.T, path string) []string {
+	t.Helper()
+	require.NoError(t, audit.Verify(path))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var out []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var e audit.Entry
+		require.NoError(t, json.Unmarshal([]byte(line), &e))
+		out = append(out, e.Decision)
+	}
+	return out
+}
+
+func TestNewGate_DisabledByDefault(t *testing.T) {
+	gate, err := NewGate(&config.Config{}, nil)
+	require.NoError(t, err)
+	assert.False(t, gate.Enabled())
+}
+
+func TestAuthorize_Approved(t *testing.T) {
+	gate, path := newTestGate(t, staticApprover{decision: Decision{Approved: true, Approver: "bob"}})
+	req := gate.NewRequest(ActionDeleteWallet, "0xABC")
+
+	require.NoError(t, gate.Authorize(context.Background(), req))
+	require.NoError(t, gate.RecordOutcome(req, nil))
+	assert.Equal(t, []string{audit.DecisionRequested, audit.DecisionApproved, audit.DecisionExecuted}, decisions(t, path))
+}
+
+func TestAuthorize_RejectsSelfApproval(t *testing.T) {
+	gate, path := newTestGate(t, staticApprover{decision: Decision{Approved: true, Approver: "ALICE"}})
+
+	err := gate.Authorize(context.Background(), gate.NewRequest(ActionExportSecret, "0xABC"))
+	assert.ErrorIs(t, err, ErrSelfApproval)
+	assert.Equal(t, []string{audit.DecisionRequested, audit.DecisionDenied}, decisions(t, path))
+}
+
+func TestAuthorize_Denied(t *testing.T) {
+	gate, _ := newTestGate(t, staticApprover{decision: Decision{Approved: false, Approver: "bob", Reason: "not during audit"}})
+
+	err := gate.Authorize(context.Background(), gate.NewRequest(ActionExportSecret, "0xABC"))
+	assert.ErrorIs(t, err, ErrApprovalDenied)
+	assert.Contains(t, err.Error(), "not during audit")
+}
+
+// testApproverKey creates bob's signing key and the keys that trust it
+func testApproverKey(t *testing.T) (*ApproverKey, ApproverKeys) {
+	t.Helper()
+	key, err := GenerateApproverKey("Bob")
+	require.NoError(t, err)
+	keys, err := ParseApproverKeys(map[string]string{"bob": key.PublicKey()})
+	require.NoError(t, err)
+	return key, keys
+}
+
+// answerFileRequest writes the decision once the request file shows up
+func answerFileRequest(approver *FileApprover, req Request, decision Decision) {
+	requestPath := filepath.Join(approver.Dir, req.ID+".request.json")
+	for i := 0; i < 100; i++ {
+		if _, err := os.Stat(requestPath); err == nil {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	data, _ := json.Marshal(decision)
+	_ = os.WriteFile(approver.Describe(req), data, 0600)
+}
+
+func TestFileApprover(t *testing.T) {
+	key, keys := testApproverKey(t)
+	approver := NewFileApprover(t.TempDir(), keys)
+	approver.PollInterval = 10 * time.Millisecond
+	gate, _ := newTestGate(t, approver)
+	req := gate.NewRequest(ActionDeleteWallet, "0xABC")
+
+	// The approver reads the request and answers next to it
+	go answerFileRequest(approver, req, key.Sign(req, true, ""))
+
+	require.NoError(t, gate.Authorize(context.Background(), req))
+}
+
+func TestFileApprover_RejectsUnsignedDecisions(t *testing.T) {
+	key, keys := testApproverKey(t)
+	other, err := GenerateApproverKey("bob")
+	require.NoError(t, err)
+
+	cases := map[string]func(req Request) Decision{
+		"unsigned": func(req Request) Decision {
+			return Decision{RequestID: req.ID, Approved: true, Approver: "bob"}
+		},
+		"unknown approver": func(req Request) Decision {
+			mallory, err := GenerateApproverKey("mallory")
+			require.NoError(t, err)
+			return mallory.Sign(req, true, "")
+		},
+		"another key": func(req Request) Decision {
+			return other.Sign(req, true, "")
+		},
+		"tampered": func(req Request) Decision {
+			denied := key.Sign(req, false, "")
+			denied.Approved = true
+			return denied
+		},
+		"another request": func(req Request) Decision {
+			moved := req
+			moved.Subject = "0xDEF"
+			return key.Sign(moved, true, "")
+		},
+	}
+	for name, decide := range cases {
+		t.Run(name, func(t *testing.T) {
+			approver := NewFileApprover(t.TempDir(), keys)
+			approver.PollInterval = 10 * time.Millisecond
+			gate, path := newTestGate(t, approver)
+			req := gate.NewRequest(ActionDeleteWallet, "0xABC")
+			go answerFileRequest(approver, req, decide(req))
+
+			assert.ErrorContains(t, gate.Authorize(context.Background(), req), "approval rejected")
+			assert.Equal(t, []string{audit.DecisionRequested, audit.DecisionError}, decisions(t, path))
+		})
+	}
+}
+
+func TestNewGate_FileApprovalsNeedKeys(t *testing.T) {
+	log, err := audit.Open(filepath.Join(t.TempDir(), audit.FileName))
+	require.NoError(t, err)
+	cfg := &config.Config{AppDir: t.TempDir(), Compliance: config.ComplianceConfig{Enabled: true}}
+
+	_, err = NewGate(cfg, log)
+	assert.ErrorContains(t, err, "approver_keys")
+
+	cfg.Compliance.ApproverKeys = map[string]string{"bob": "not a key"}
+	_, err = NewGate(cfg, log)
+	assert.ErrorContains(t, err, "Ed25519")
+
+	key, _ := testApproverKey(t)
+	cfg.Compliance.ApproverKeys = map[string]string{"bob": key.PublicKey()}
+	gate, err := NewGate(cfg, log)
+	require.NoError(t, err)
+	assert.True(t, gate.Enabled())
+}
+
+func TestApproverKey_SaveAndLoad(t *testing.T) {
+	key, keys := testApproverKey(t)
+	path := filepath.Join(t.TempDir(), "bob.key")
+	require.NoError(t, key.Save(path))
+	assert.Error(t, key.Save(path), "an existing key is never replaced")
+
+	loaded, err := LoadApproverKey(path)
+	require.NoError(t, err)
+	req := Request{ID: "r1", Action: ActionExportSecret, Subject: "0xABC", RequestedBy: "alice"}
+	assert.NoError(t, keys.Verify(req, loaded.Sign(req, true, "ok")))
+}
+
+func TestFileApprover_Timeout(t *testing.T) {
+	_, keys := testApproverKey(t)
+	approver := NewFileApprover(t.TempDir(), keys)
+	approver.PollInterval = 5 * time.Millisecond
+	gate, path := newTestGate(t, approver)
+	gate.timeout = 20 * time.Millisecond
+
+	err := gate.Authorize(context.Background(), gate.NewRequest(ActionDeleteWallet, "0xABC"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []string{audit.DecisionRequested, audit.DecisionError}, decisions(t, path))
+}
+
+func TestEndpointApprover_PollsPendingDecision(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		if polls.Add(1) < 2 {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		id := strings.TrimPrefix(r.URL.Path, "/approvals/")
+		_ = json.NewEncoder(w).Encode(Decision{RequestID: id, Approved: true, Approver: "bob"})
+	}))
+	defer server.Close()
+
+	approver := NewEndpointApprover(server.URL + "/approvals")
+	approver.PollInterval = 5 * time.Millisecond
+	gate, _ := newTestGate(t, approver)
+
+	require.NoError(t, gate.Authorize(context.Background(), gate.NewRequest(ActionExportSecret, "0xABC")))
+	assert.Equal(t, int32(2), polls.Load())
+}
@@ -0,0 +1,146 @@
+package compliance
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnsignedDecision is returned for a decision without a signature
+	ErrUnsignedDecision = errors.New("the decision is not signed")
+	// ErrUnknownApprover is returned when no key is configured for the approver
+	ErrUnknownApprover = errors.New("no key is configured for the approver")
+	// ErrDecisionSignature is returned when the signature does not match the
+	// approver's key, the request or the decision
+	ErrDecisionSignature = errors.New("the decision signature is invalid")
+)
+
+// ApproverKeys maps each approver name, in lower case, to the Ed25519 public
+// key that signs their decisions
+type ApproverKeys map[string]ed25519.PublicKey
+
+// ParseApproverKeys reads the [compliance.approver_keys] table: approver names
+// to base64 Ed25519 public keys
+func ParseApproverKeys(encoded map[string]string) (ApproverKeys, error) {
+	keys := make(ApproverKeys, len(encoded))
+	for name, value := range encoded {
+		name = strings.ToLower(strings.TrimSpace(name))
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("approver key of %q is not a base64 Ed25519 public key", name)
+		}
+		keys[name] = ed25519.PublicKey(key)
+	}
+	return keys, nil
+}
+
+// Verify checks that decision carries a signature of its approver over req
+func (keys ApproverKeys) Verify(req Request, decision Decision) error {
+	if decision.Signature == "" {
+		return ErrUnsignedDecision
+	}
+	key, ok := keys[strings.ToLower(decision.Approver)]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownApprover, decision.Approver)
+	}
+	signature, err := base64.StdEncoding.DecodeString(decision.Signature)
+	if err != nil || !ed25519.Verify(key, decisionMessage(req, decision), signature) {
+		return ErrDecisionSignature
+	}
+	return nil
+}
+
+// decisionMessage is what an approver signs: the request as it was shown to
+// them and their answer, so a signature cannot be moved to another request
+func decisionMessage(req Request, decision Decision) []byte {
+	var b strings.Builder
+	b.WriteString("bloco-wallet approval v1\n")
+	for _, field := range []string{
+		req.ID, string(req.Action), req.Subject, req.RequestedBy,
+		strconv.FormatBool(decision.Approved), strings.ToLower(decision.Approver), decision.Reason,
+	} {
+		b.WriteString(strconv.Quote(field))
+		b.WriteString("\n")
+	}
+	return []byte(b.String())
+}
+
+// SignDecision signs decision on req with the approver's private key
+func SignDecision(key ed25519.PrivateKey, req Request, decision Decision) Decision {
+	decision.RequestID = req.ID
+	decision.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, decisionMessage(req, decision)))
+	return decision
+}
+
+// ApproverKey is the private signing key of an approver, kept in a file only
+// they can read
+type ApproverKey struct {
+	Approver   string `json:"approver"`
+	PrivateKey []byte `json:"private_key"`
+}
+
+// GenerateApproverKey creates a signing key for approver
+func GenerateApproverKey(approver string) (*ApproverKey, error) {
+	approver = strings.TrimSpace(approver)
+	if approver == "" {
+		return nil, errors.New("the approver name is required")
+	}
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &ApproverKey{Approver: approver, PrivateKey: private}, nil
+}
+
+// PublicKey is the base64 public key to configure under approver_keys
+func (k *ApproverKey) PublicKey() string {
+	return base64.StdEncoding.EncodeToString(ed25519.PrivateKey(k.PrivateKey).Public().(ed25519.PublicKey))
+}
+
+// Sign answers req as this approver
+func (k *ApproverKey) Sign(req Request, approved bool, reason string) Decision {
+	decision := Decision{Approved: approved, Approver: k.Approver, Reason: reason, DecidedAt: time.Now().UTC()}
+	return SignDecision(ed25519.PrivateKey(k.PrivateKey), req, decision)
+}
+
+// Save writes the key to path, readable only by its owner; an existing file
+// is never replaced
+func (k *ApproverKey) Save(path string) error {
+	data, err := json.MarshalIndent(k, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadApproverKey reads a key written by Save
+func LoadApproverKey(path string) (*ApproverKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var key ApproverKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("invalid approver key: %w", err)
+	}
+	if key.Approver == "" || len(key.PrivateKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("invalid approver key")
+	}
+	return &key, nil
+}
@@ -24,6 +24,40 @@ const (
 	LanguageSelectionView     = "language_selection"
 	NetworkListView           = "network_list"
 	AddNetworkView            = "add_network"
+	JobsView                  = "jobs"
+	SecurityWarningView       = "security_warning"
+	SecureExportView          = "secure_export"
+	InheritanceView           = "inheritance"
+	SyncView                  = "sync"
+	WalletIntegrityView       = "wallet_integrity"
+	WalletNotesView           = "wallet_notes"
+	BatchCreateView           = "batch_create"
+	ChildWalletsView          = "child_wallets"
+	ChildDiscoveryView        = "child_discovery"
+	SignTransactionView       = "sign_transaction"
+	SendTransactionView       = "send_transaction"
+	BroadcastView             = "broadcast_tx"
+	PendingTransactionsView   = "pending_transactions"
+	WalletRPCView             = "wallet_rpc"
+	SafeImportView            = "safe_import"
+	AboutView                 = "about"
+	TokensView                = "tokens"
+	WhatsNewView              = "whats_new"
+	BatchSendView             = "batch_send"
+	BatchExportView           = "batch_export"
+	WalletConnectView         = "walletconnect"
+	KeystoreOverwriteView     = "keystore_overwrite"
+	SessionSummaryView        = "session_summary"
+	BackupView                = "backup"
+	MasterPasswordView        = "master_password"
+	WalletBatchView           = "wallet_batch"
+	NetworkImportView         = "network_import"
+	MetamaskImportView        = "metamask_import"
+	LogViewerView             = "log_viewer"
+	StoreCheckView            = "store_check"
+	ConfigEditorView          = "config_editor"
+	ProfilesView              = "profiles"
+	ThemeView                 = "theme"
 	StyleWidth                = 40
 	StyleMargin               = 1
 	SplashDuration            = 2 * time.Second
@@ -0,0 +1,186 @@
+// Package cron runs the recurring maintenance tasks behind "bloco-wallet cron":
+// encrypted backups, balance snapshots and keystore integrity checks. Each run
+// executes the configured tasks through the job manager, writes a JSON report
+// and exits, leaving the scheduling to systemd timers or cron.
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"blocowallet/internal/jobs"
+	"blocowallet/internal/notify"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+)
+
+// Task names accepted in configuration and on the command line
+const (
+	TaskBackup          = "backup"
+	TaskBalanceSnapshot = "balance_snapshot"
+	TaskIntegrityCheck  = "integrity_check"
+)
+
+// ErrUnknownTask is returned for task names that do not exist
+var ErrUnknownTask = errors.New("unknown task")
+
+// Database is the part of the wallet repository the tasks need
+type Database interface {
+	IntegrityCheck() error
+}
+
+// BalanceFetcher returns the balance of an address on a network
+type BalanceFetcher func(ctx context.Context, network config.Network, address string) (string, error)
+
+// Runner executes scheduled tasks
+type Runner struct {
+	cfg      *config.Config
+	ws       *wallet.WalletService
+	db       Database
+	jobs     *jobs.Manager
+	notifier *notify.Notifier
+	balances BalanceFetcher
+	now      func() time.Time
+}
+
+// NewRunner creates a runner. notifier may be nil.
+func NewRunner(cfg *config.Config, ws *wallet.WalletService, db Database, notifier *notify.Notifier) *Runner {
+	return &Runner{
+		cfg:      cfg,
+		ws:       ws,
+		db:       db,
+		jobs:     jobs.NewManager(),
+		notifier: notifier,
+		balances: fetchBalance,
+		now:      time.Now,
+	}
+}
+
+// Report is the machine-readable outcome of a run
+type Report struct {
+	StartedAt  time.Time    `json:"started_at"`
+	FinishedAt time.Time    `json:"finished_at"`
+	Host       string       `json:"host,omitempty"`
+	Succeeded  bool         `json:"succeeded"`
+	Tasks      []TaskResult `json:"tasks"`
+}
+
+// TaskResult is the outcome of a single task
+type TaskResult struct {
+	Name       string      `json:"name"`
+	Status     jobs.Status `json:"status"`
+	StartedAt  time.Time   `json:"started_at"`
+	DurationMS int64       `json:"duration_ms"`
+	Error      string      `json:"error,omitempty"`
+	Details    any         `json:"details,omitempty"`
+}
+
+type taskFunc func(ctx context.Context, report jobs.ProgressFunc) (any, error)
+
+// Run executes the tasks one after the other. When names is empty the tasks
+// from the configuration are used.
+func (r *Runner) Run(ctx context.Context, names []string) (*Report, error) {
+	if len(names) == 0 {
+		names = r.cfg.Cron.Tasks
+	}
+	if len(names) == 0 {
+		return nil, errors.New("no tasks configured (set tasks in the [cron] section)")
+	}
+	for _, name := range names {
+		if _, _, err := r.task(name); err != nil {
+			return nil, err
+		}
+	}
+
+	host, _ := os.Hostname()
+	report := &Report{StartedAt: r.now().UTC(), Host: host, Succeeded: true}
+	for _, name := range names {
+		result := r.runTask(ctx, name)
+		if result.Status != jobs.StatusSucceeded {
+			report.Succeeded = false
+		}
+		report.Tasks = append(report.Tasks, result)
+	}
+	report.FinishedAt = r.now().UTC()
+	return report, nil
+}
+
+func (r *Runner) task(name string) (jobs.Kind, taskFunc, error) {
+	switch name {
+	case TaskBackup:
+		return jobs.KindBackup, r.backup, nil
+	case TaskBalanceSnapshot:
+		return jobs.KindBalanceSnapshot, r.balanceSnapshot, nil
+	case TaskIntegrityCheck:
+		return jobs.KindIntegrityCheck, r.integrityCheck, nil
+	default:
+		return "", nil, fmt.Errorf("%w: %q", ErrUnknownTask, name)
+	}
+}
+
+// runTask runs one task through the job manager and waits for it
+func (r *Runner) runTask(ctx context.Context, name string) TaskResult {
+	kind, fn, _ := r.task(name)
+	var details any
+	id, err := r.jobs.Submit(kind, name, func(jobCtx context.Context, report jobs.ProgressFunc) error {
+		// Stop the job when the caller gives up too
+		jobCtx, cancel := context.WithCancel(jobCtx)
+		defer cancel()
+		stop := context.AfterFunc(ctx, cancel)
+		defer stop()
+
+		var err error
+		details, err = fn(jobCtx, report)
+		return err
+	})
+	if err != nil {
+		return TaskResult{Name: name, Status: jobs.StatusFailed, StartedAt: r.now().UTC(), Error: err.Error()}
+	}
+
+	job, _ := r.jobs.Wait(id)
+	result := TaskResult{
+		Name:       name,
+		Status:     job.Status,
+		StartedAt:  job.StartedAt.UTC(),
+		DurationMS: job.FinishedAt.Sub(job.StartedAt).Milliseconds(),
+		Details:    details,
+	}
+	if job.Err != nil {
+		result.Error = job.Err.Error()
+	}
+	return result
+}
+
+// ReportDir returns where run reports are written
+func (r *Runner) ReportDir() string {
+	if r.cfg.Cron.ReportDir != "" {
+		return r.cfg.Cron.ReportDir
+	}
+	return filepath.Join(r.cfg.AppDir, "reports")
+}
+
+// WriteReport saves the report as "cron-<time>.json" and refreshes
+// "cron-latest.json" for monitoring tools, returning the saved path
+func (r *Runner) WriteReport(report *Report) (string, error) {
+	dir := r.ReportDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "cron-"+report.StartedAt.Format("20060102T150405Z")+".json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "cron-latest.json"), data, 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,227 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+	"time"
+
+	"blocowallet/internal/jobs"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixture struct {
+	cfg    *config.Config
+	ws     *wallet.WalletService
+	runner *Runner
+	ksDir  string
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	dir := t.TempDir()
+	cfg := &config.Config{
+		AppDir:       dir,
+		WalletsDir:   dir,
+		DatabasePath: filepath.Join(dir, "wallets.db"),
+		Database:     config.DatabaseConfig{Type: "sqlite"},
+	}
+	repo, err := storage.NewWalletRepository(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ws := &wallet.WalletService{Repo: repo, KeystoreDir: filepath.Join(dir, "keystore")}
+	r := NewRunner(cfg, ws, repo, nil)
+	r.now = func() time.Time { return time.Date(2025, 3, 1, 4, 0, 0, 0, time.UTC) }
+	return &fixture{cfg: cfg, ws: ws, runner: r, ksDir: filepath.Join(dir, "keystore")}
+}
+
+func (f *fixture) addWallet(t *testing.T, address, keystoreAddress string) wallet.Wallet {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(f.ksDir, 0700))
+	path := filepath.Join(f.ksDir, address+".json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"address":"`+keystoreAddress+`","crypto":{"cipher":"aes-128-ctr"},"version":3}`), 0600))
+	w := wallet.Wallet{Name: "w-" + address, Address: "0x" + address, KeyStorePath: path, ImportMethod: "keystore", SourceHash: address}
+	require.NoError(t, f.ws.Repo.AddWallet(&w))
+	return w
+}
+
+func TestRun_UnknownTask(t *testing.T) {
+	f := newFixture(t)
+	_, err := f.runner.Run(context.Background(), []string{"integrity_check", "defrag"})
+	assert.ErrorIs(t, err, ErrUnknownTask)
+
+	_, err = f.runner.Run(context.Background(), nil)
+	assert.Error(t, err, "no tasks configured")
+}
+
+func TestIntegrityCheck(t *testing.T) {
+	f := newFixture(t)
+	f.addWallet(t, "aa", "aa")
+	f.addWallet(t, "bb", "cc")
+	missing := f.addWallet(t, "dd", "dd")
+	require.NoError(t, os.Remove(missing.KeyStorePath))
+
+	report, err := f.runner.Run(context.Background(), []string{TaskIntegrityCheck})
+	require.NoError(t, err)
+
+	assert.False(t, report.Succeeded)
+	task := report.Tasks[0]
+	assert.Equal(t, jobs.StatusFailed, task.Status)
+	assert.Contains(t, task.Error, "2 integrity issues")
+	details := task.Details.(*IntegrityReport)
+	assert.Equal(t, 3, details.Keystores)
+	var problems []string
+	for _, issue := range details.Issues {
+		problems = append(problems, issue.Address+": "+issue.Problem)
+	}
+	sort.Strings(problems)
+	assert.Equal(t, []string{
+		"0xbb: keystore belongs to 0xcc, not to the wallet address",
+		"0xdd: keystore file is missing",
+	}, problems)
+}
+
+func TestIntegrityCheck_DetectsExposedKeystore(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("mode bits are not used on Windows")
+	}
+	f := newFixture(t)
+	w := f.addWallet(t, "aa", "aa")
+	require.NoError(t, os.Chmod(w.KeyStorePath, 0644))
+
+	report, err := f.runner.Run(context.Background(), []string{TaskIntegrityCheck})
+	require.NoError(t, err)
+	assert.False(t, report.Succeeded)
+}
+
+func TestIntegrityCheck_Healthy(t *testing.T) {
+	f := newFixture(t)
+	f.addWallet(t, "aa", "0xAA")
+	// Watch-only wallets have no keystore to check
+	_, err := f.ws.AddWatchOnlyWallet("watched", "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0", "", nil)
+	require.NoError(t, err)
+
+	report, err := f.runner.Run(context.Background(), []string{TaskIntegrityCheck})
+	require.NoError(t, err)
+	assert.True(t, report.Succeeded, report.Tasks[0].Error)
+	assert.Equal(t, 1, report.Tasks[0].Details.(*IntegrityReport).Keystores)
+}
+
+func TestBackup(t *testing.T) {
+	f := newFixture(t)
+	f.addWallet(t, "aa", "aa")
+	require.NoError(t, os.WriteFile(filepath.Join(f.cfg.AppDir, "config.toml"), []byte("language = \"en\"\n"), 0600))
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	f.cfg.Cron.BackupRecipient = identity.Recipient().String()
+
+	report, err := f.runner.Run(context.Background(), []string{TaskBackup})
+	require.NoError(t, err)
+	require.True(t, report.Succeeded, report.Tasks[0].Error)
+
+	details := report.Tasks[0].Details.(BackupDetails)
+	assert.Equal(t, filepath.Join(f.cfg.AppDir, "backups", "bloco-wallet-20250301T040000Z.backup"), details.Path)
+	assert.Equal(t, 3, details.Files)
+	info, err := os.Stat(details.Path)
+	require.NoError(t, err)
+	if runtime.GOOS != "windows" {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	// The scheduled backup restores like one written from the TUI
+	dir := t.TempDir()
+	cfg := &config.Config{AppDir: dir, WalletsDir: dir, DatabasePath: filepath.Join(dir, "wallets.db"), Database: config.DatabaseConfig{Type: "sqlite"}}
+	repo, err := storage.NewWalletRepository(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = repo.Close() })
+	restore := &wallet.WalletService{Repo: repo, KeystoreDir: filepath.Join(dir, "keystore")}
+
+	_, err = restore.ImportBackup(details.Path, "Backup-Pass1")
+	assert.ErrorIs(t, err, wallet.ErrBackupKeyRequired)
+	other, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	otherFile := filepath.Join(dir, "other.txt")
+	require.NoError(t, os.WriteFile(otherFile, []byte(other.String()+"\n"), 0600))
+	_, err = restore.ImportBackupWithKey(details.Path, wallet.BackupKey{IdentityFile: otherFile})
+	assert.ErrorIs(t, err, wallet.ErrBackupKey)
+
+	identityFile := filepath.Join(dir, "identity.txt")
+	require.NoError(t, os.WriteFile(identityFile, []byte(identity.String()+"\n"), 0600))
+	manifest, err := restore.ImportBackupWithKey(details.Path, wallet.BackupKey{IdentityFile: identityFile})
+	require.NoError(t, err)
+	var names []string
+	for _, file := range manifest.Files {
+		names = append(names, file.Name)
+	}
+	assert.Equal(t, []string{"wallets.db", "keystore/aa.json", "extra/config.toml"}, names)
+	assert.Equal(t, 1, manifest.Wallets)
+
+	wallets, err := restore.GetAllWallets()
+	require.NoError(t, err)
+	require.Len(t, wallets, 1)
+	assert.Equal(t, "0xaa", wallets[0].Address)
+	assert.Equal(t, filepath.Join(dir, "keystore", "aa.json"), wallets[0].KeyStorePath)
+	assert.FileExists(t, wallets[0].KeyStorePath)
+
+	// Without a recipient the task fails instead of writing a plaintext backup
+	f.cfg.Cron.BackupRecipient = ""
+	report, err = f.runner.Run(context.Background(), []string{TaskBackup})
+	require.NoError(t, err)
+	assert.False(t, report.Succeeded)
+}
+
+func TestBalanceSnapshot(t *testing.T) {
+	f := newFixture(t)
+	f.addWallet(t, "aa", "aa")
+	f.cfg.Networks = map[string]config.Network{
+		"eth":      {Name: "Ethereum", Symbol: "ETH", RPCEndpoint: "https://eth", IsActive: true},
+		"polygon":  {Name: "Polygon", Symbol: "POL", RPCEndpoint: "https://polygon", IsActive: true},
+		"inactive": {Name: "Old", RPCEndpoint: "https://old"},
+	}
+	f.runner.balances = func(_ context.Context, n config.Network, address string) (string, error) {
+		if n.Symbol == "POL" {
+			return "", errors.New("rpc unavailable")
+		}
+		return "1000", nil
+	}
+
+	report, err := f.runner.Run(context.Background(), []string{TaskBalanceSnapshot})
+	require.NoError(t, err)
+	require.True(t, report.Succeeded)
+
+	snap := report.Tasks[0].Details.(*BalanceSnapshot)
+	require.Len(t, snap.Balances, 2)
+	assert.Equal(t, BalanceEntry{Address: "0xaa", Wallet: "w-aa", Network: "eth", Symbol: "ETH", BalanceWei: "1000"}, snap.Balances[0])
+	assert.Equal(t, "rpc unavailable", snap.Balances[1].Error)
+	assert.Equal(t, 1, snap.Failed)
+}
+
+func TestWriteReport(t *testing.T) {
+	f := newFixture(t)
+	f.addWallet(t, "aa", "aa")
+	report, err := f.runner.Run(context.Background(), []string{TaskIntegrityCheck})
+	require.NoError(t, err)
+
+	path, err := f.runner.WriteReport(report)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(f.cfg.AppDir, "reports", "cron-20250301T040000Z.json"), path)
+
+	data, err := os.ReadFile(filepath.Join(f.cfg.AppDir, "reports", "cron-latest.json"))
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, true, decoded["succeeded"])
+	assert.Equal(t, "integrity_check", decoded["tasks"].([]any)[0].(map[string]any)["name"])
+}
@@ -0,0 +1,239 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"blocowallet/internal/audit"
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/jobs"
+	"blocowallet/internal/notify"
+	"blocowallet/internal/platform"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+)
+
+// BackupDetails describes a finished backup
+type BackupDetails struct {
+	Path      string `json:"path"`
+	Recipient string `json:"recipient"`
+	Files     int    `json:"files"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+// backup writes the database, the keystores and the configuration in the
+// backup format the TUI restores, encrypted to the configured recipient
+func (r *Runner) backup(ctx context.Context, progress jobs.ProgressFunc) (any, error) {
+	if strings.TrimSpace(r.cfg.Cron.BackupRecipient) == "" {
+		return nil, errors.New("backup_recipient is not set in the [cron] section")
+	}
+	recipient, err := wallet.ParseExportRecipient(r.cfg.Cron.BackupRecipient)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := r.cfg.Cron.BackupDir
+	if dir == "" {
+		dir = filepath.Join(r.cfg.AppDir, "backups")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	var extras []string
+	for _, name := range []string{"config.toml", "inheritance_plan.json"} {
+		path := filepath.Join(r.cfg.AppDir, name)
+		if _, err := os.Stat(path); err == nil {
+			extras = append(extras, path)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	outputPath := filepath.Join(dir, wallet.DefaultBackupFileName(r.now()))
+	manifest, err := r.ws.ExportBackupTo(outputPath, recipient, extras)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(outputPath)
+	if err != nil {
+		return nil, err
+	}
+	progress(1, "backup encrypted")
+
+	details := BackupDetails{Path: outputPath, Recipient: recipient.Description, Files: len(manifest.Files), SizeBytes: info.Size()}
+	_ = r.notifier.Send(ctx, notify.EventBackupCompleted, "Scheduled backup completed",
+		map[string]any{"kind": "scheduled backup", "location": outputPath, "files": len(manifest.Files)})
+	return details, nil
+}
+
+// BalanceEntry is one wallet balance on one network
+type BalanceEntry struct {
+	Address    string `json:"address"`
+	Wallet     string `json:"wallet"`
+	Network    string `json:"network"`
+	Symbol     string `json:"symbol"`
+	BalanceWei string `json:"balance_wei,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// BalanceSnapshot lists the balances of every wallet on every active network
+type BalanceSnapshot struct {
+	Balances []BalanceEntry `json:"balances"`
+	Failed   int            `json:"failed"`
+}
+
+func (r *Runner) balanceSnapshot(ctx context.Context, progress jobs.ProgressFunc) (any, error) {
+	wallets, err := r.ws.GetAllWallets()
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for key, n := range r.cfg.Networks {
+		if n.Usable() && n.RPCEndpoint != "" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no active networks configured")
+	}
+	sort.Strings(keys)
+
+	snapshot := &BalanceSnapshot{}
+	total := len(keys) * len(wallets)
+	for _, key := range keys {
+		network := r.cfg.Networks[key]
+		for _, w := range wallets {
+			if err := ctx.Err(); err != nil {
+				return snapshot, err
+			}
+			entry := BalanceEntry{Address: w.Address, Wallet: w.Name, Network: key, Symbol: network.Symbol}
+			balance, err := r.balances(ctx, r.ws.NetworkFor(w.Address, network), w.Address)
+			if err != nil {
+				entry.Error = err.Error()
+				snapshot.Failed++
+			} else {
+				entry.BalanceWei = balance
+			}
+			snapshot.Balances = append(snapshot.Balances, entry)
+			progress(float64(len(snapshot.Balances))/float64(total), key+" "+w.Address)
+		}
+	}
+	if total > 0 && snapshot.Failed == total {
+		return snapshot, errors.New("every balance query failed")
+	}
+	return snapshot, nil
+}
+
+func fetchBalance(ctx context.Context, network config.Network, address string) (string, error) {
+	client, err := blockchain.NewEthereum(network.RPCEndpoint, blockchain.DefaultTimeout, network.Symbol, 18, network.Name)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+	balance, err := client.GetBalance(ctx, address)
+	if err != nil {
+		return "", err
+	}
+	return balance.String(), nil
+}
+
+// IntegrityIssue is a problem found by the integrity check
+type IntegrityIssue struct {
+	Path    string `json:"path,omitempty"`
+	Address string `json:"address,omitempty"`
+	Problem string `json:"problem"`
+}
+
+// IntegrityReport lists what the integrity check looked at and what it found
+type IntegrityReport struct {
+	Keystores int              `json:"keystores"`
+	Issues    []IntegrityIssue `json:"issues,omitempty"`
+}
+
+// integrityCheck verifies keystores, their permissions, the database and the audit log
+func (r *Runner) integrityCheck(ctx context.Context, progress jobs.ProgressFunc) (any, error) {
+	wallets, err := r.ws.GetAllWallets()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &IntegrityReport{}
+	for i, w := range wallets {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		// Watch-only entries have no keystore
+		if w.WatchOnly() {
+			continue
+		}
+		result.Keystores++
+		if problem := checkKeystore(w); problem != "" {
+			result.Issues = append(result.Issues, IntegrityIssue{Path: w.KeyStorePath, Address: w.Address, Problem: problem})
+		}
+		progress(float64(i+1)/float64(len(wallets)+2), w.Address)
+	}
+
+	if err := r.db.IntegrityCheck(); err != nil {
+		result.Issues = append(result.Issues, IntegrityIssue{Path: r.cfg.DatabasePath, Problem: err.Error()})
+	}
+	auditPath := filepath.Join(r.cfg.AppDir, audit.FileName)
+	if _, err := os.Stat(auditPath); err == nil {
+		if err := audit.Verify(auditPath); err != nil {
+			result.Issues = append(result.Issues, IntegrityIssue{Path: auditPath, Problem: err.Error()})
+		}
+	}
+	progress(1, "done")
+
+	if len(result.Issues) == 0 {
+		return result, nil
+	}
+	paths := make([]string, len(result.Issues))
+	for i, issue := range result.Issues {
+		paths[i] = issue.Path
+	}
+	_ = r.notifier.Send(ctx, notify.EventHealthCheckFailed,
+		fmt.Sprintf("Health check failed: %d integrity issues", len(result.Issues)),
+		map[string]any{"check": "integrity", "paths": paths})
+	return result, fmt.Errorf("%d integrity issues found", len(result.Issues))
+}
+
+// checkKeystore returns a description of what is wrong with the wallet's keystore, if anything
+func checkKeystore(w wallet.Wallet) string {
+	data, err := os.ReadFile(w.KeyStorePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "keystore file is missing"
+		}
+		return err.Error()
+	}
+	var ks struct {
+		Address     string          `json:"address"`
+		Crypto      json.RawMessage `json:"crypto"`
+		CryptoUpper json.RawMessage `json:"Crypto"`
+	}
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return "keystore is not valid JSON"
+	}
+	if len(ks.Crypto) == 0 && len(ks.CryptoUpper) == 0 {
+		return "keystore has no crypto section"
+	}
+	if ks.Address != "" && !strings.EqualFold(strings.TrimPrefix(ks.Address, "0x"), strings.TrimPrefix(w.Address, "0x")) {
+		return fmt.Sprintf("keystore belongs to 0x%s, not to the wallet address", strings.TrimPrefix(ks.Address, "0x"))
+	}
+	issue, err := platform.CheckPrivate(w.KeyStorePath)
+	if err != nil {
+		return err.Error()
+	}
+	if issue != nil {
+		return issue.Reason
+	}
+	return ""
+}
@@ -0,0 +1,176 @@
+package inheritance
+
+import (
+	"blocowallet/internal/wallet"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	instructionsFileName = "INSTRUCTIONS.md"
+	sharesFileName       = "SHARES.md"
+	bundleBaseName       = "wallets.json"
+)
+
+// bundleWallet is a wallet as stored inside the encrypted bundle
+type bundleWallet struct {
+	Name         string          `json:"name"`
+	Address      string          `json:"address"`
+	ImportMethod string          `json:"import_method"`
+	CreatedAt    time.Time       `json:"created_at"`
+	Keystore     json.RawMessage `json:"keystore"`
+}
+
+type bundle struct {
+	GeneratedAt time.Time      `json:"generated_at"`
+	Wallets     []bundleWallet `json:"wallets"`
+}
+
+// PackageOptions configures the generation of an inheritance package
+type PackageOptions struct {
+	OutputDir string
+	Wallets   []wallet.Wallet
+	Recipient *wallet.ExportRecipient
+	Now       time.Time
+}
+
+// GeneratePackage writes an inheritance package into a new directory under
+// OutputDir: human readable instructions, the SLIP-39 share distribution list
+// and a bundle of the wallet keystores encrypted to the recipient. Keystores
+// are read into memory and encrypted directly into the bundle file. On success
+// the plan records the package location.
+func GeneratePackage(plan *Plan, opts PackageOptions) (string, error) {
+	if opts.Recipient == nil {
+		return "", errors.New("a recipient public key is required to encrypt the bundle")
+	}
+	if len(opts.Wallets) == 0 {
+		return "", errors.New("there are no wallets to include in the package")
+	}
+
+	b := bundle{GeneratedAt: opts.Now.UTC()}
+	for _, w := range opts.Wallets {
+		// Watch-only entries hold no key to pass on
+		if w.WatchOnly() {
+			continue
+		}
+		keystoreJSON, err := os.ReadFile(w.KeyStorePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read keystore for %s: %w", w.Address, err)
+		}
+		b.Wallets = append(b.Wallets, bundleWallet{
+			Name:         w.Name,
+			Address:      w.Address,
+			ImportMethod: w.ImportMethod,
+			CreatedAt:    w.CreatedAt,
+			Keystore:     json.RawMessage(keystoreJSON),
+		})
+	}
+	if len(b.Wallets) == 0 {
+		return "", errors.New("only watch-only wallets were selected: they hold no key to pass on")
+	}
+	plaintext, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(opts.OutputDir, "inheritance-"+opts.Now.Format("20060102-150405"))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create package directory: %w", err)
+	}
+
+	bundleName := bundleBaseName + opts.Recipient.FileExtension()
+	var encrypted bytes.Buffer
+	if err := opts.Recipient.Encrypt(&encrypted, plaintext); err != nil {
+		return "", fmt.Errorf("failed to encrypt bundle: %w", err)
+	}
+	for i := range plaintext {
+		plaintext[i] = 0
+	}
+
+	files := map[string][]byte{
+		bundleName:           encrypted.Bytes(),
+		instructionsFileName: []byte(renderInstructions(plan, opts, bundleName, b.Wallets)),
+		sharesFileName:       []byte(renderShares(plan)),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0600); err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	now := opts.Now
+	plan.PackageGeneratedAt = &now
+	plan.PackagePath = dir
+	return dir, nil
+}
+
+// renderInstructions explains how to recover the bundled wallets, those
+// left out as watch-only not counted
+func renderInstructions(plan *Plan, opts PackageOptions, bundleName string, bundled []bundleWallet) string {
+	var b strings.Builder
+	b.WriteString("# Wallet Inheritance Instructions\n\n")
+	fmt.Fprintf(&b, "Prepared on %s with BlocoWallet.\n\n", opts.Now.Format("2006-01-02"))
+
+	b.WriteString("## What this package contains\n\n")
+	fmt.Fprintf(&b, "- `%s`: the encrypted keystore files of %d wallet(s), encrypted to %s key `%s`.\n",
+		bundleName, len(bundled), strings.ToUpper(string(opts.Recipient.Type)), opts.Recipient.Description)
+	fmt.Fprintf(&b, "- `%s`: who holds the owner's SLIP-39 shares, if any were recorded.\n", sharesFileName)
+	b.WriteString("- This file.\n\n")
+
+	b.WriteString("## Wallets\n\n")
+	b.WriteString("| Name | Address |\n|------|---------|\n")
+	for _, w := range bundled {
+		fmt.Fprintf(&b, "| %s | `%s` |\n", w.Name, w.Address)
+	}
+	b.WriteString("\n")
+	if left := len(opts.Wallets) - len(bundled); left > 0 {
+		fmt.Fprintf(&b, "%d watch-only wallet(s) were left out: they hold no key.\n\n", left)
+	}
+
+	b.WriteString("## How to recover the funds\n\n")
+	if opts.Recipient.Type == wallet.RecipientGPG {
+		fmt.Fprintf(&b, "1. Decrypt the bundle with the matching private key: `gpg --decrypt %s > wallets.json`\n", bundleName)
+	} else {
+		fmt.Fprintf(&b, "1. Decrypt the bundle with the matching identity file: `age --decrypt -i key.txt %s > wallets.json`\n", bundleName)
+	}
+	b.WriteString("2. Each entry of `wallets.json` holds a standard Ethereum keystore (JSON). Save each `keystore` value to its own file.\n")
+	b.WriteString("3. Obtain the password of each keystore from the owner's instructions or password manager. The keystores cannot be opened without them.\n")
+	b.WriteString("4. Import each keystore into any Ethereum wallet (BlocoWallet, MetaMask, geth) with its password and move the funds.\n\n")
+
+	if plan.Threshold > 0 && len(plan.Holders) > 0 {
+		b.WriteString("## SLIP-39 shares\n\n")
+		fmt.Fprintf(&b, "The owner recorded %d holders of SLIP-39 shares in `%s`; at least %d of them are needed to combine the shares with a SLIP-39 compatible tool. ",
+			len(plan.Holders), sharesFileName, plan.Threshold)
+		b.WriteString("The shares were created and handed out by the owner, outside BlocoWallet, and this package neither contains them nor depends on them: what they recover is whatever the owner split, as described in their instructions.\n\n")
+	}
+
+	b.WriteString("## Safety notes\n\n")
+	b.WriteString("- Never share the decrypted files or recovered secret with anyone offering to help.\n")
+	b.WriteString("- Work on an offline computer when handling the decrypted keystores.\n")
+	return b.String()
+}
+
+func renderShares(plan *Plan) string {
+	var b strings.Builder
+	b.WriteString("# SLIP-39 Share Distribution\n\n")
+	if len(plan.Holders) == 0 {
+		b.WriteString("No share holders have been recorded.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Recovery requires %d of %d shares. Share contents are never stored in this package.\n\n", plan.Threshold, len(plan.Holders))
+	b.WriteString("| Share | Holder | Contact | Delivered |\n|-------|--------|---------|-----------|\n")
+	for _, h := range plan.Holders {
+		delivered := "no"
+		if h.Delivered && h.DeliveredAt != nil {
+			delivered = h.DeliveredAt.Format("2006-01-02")
+		}
+		fmt.Fprintf(&b, "| %d | %s | %s | %s |\n", h.ShareIndex, h.Name, h.Contact, delivered)
+	}
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package inheritance
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"blocowallet/internal/wallet"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePackage(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	recipient, err := wallet.ParseExportRecipient(identity.Recipient().String())
+	require.NoError(t, err)
+
+	keystorePath := filepath.Join(t.TempDir(), "key.json")
+	require.NoError(t, os.WriteFile(keystorePath, []byte(`{"address":"abc","crypto":{}}`), 0600))
+
+	plan := &Plan{}
+	require.NoError(t, plan.AddHolder("Alice", "alice@example.com"))
+	require.NoError(t, plan.AddHolder("Bob", ""))
+	require.NoError(t, plan.SetThreshold(2))
+
+	now := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	dir, err := GeneratePackage(plan, PackageOptions{
+		OutputDir: t.TempDir(),
+		Wallets: []wallet.Wallet{
+			{Name: "Savings", Address: "0xABC", KeyStorePath: keystorePath},
+			{Name: "Cold storage", Address: "0xDEF", ImportMethod: string(wallet.ImportMethodWatchOnly)},
+		},
+		Recipient: recipient,
+		Now:       now,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, dir, plan.PackagePath)
+	assert.True(t, plan.IsDone(StepPackageGenerated, now, 0))
+
+	instructions, err := os.ReadFile(filepath.Join(dir, instructionsFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(instructions), "0xABC")
+	assert.Contains(t, string(instructions), "keystore files of 1 wallet(s)")
+	assert.NotContains(t, string(instructions), "0xDEF", "watch-only wallets are not bundled")
+	assert.Contains(t, string(instructions), "1 watch-only wallet(s) were left out")
+	// The keystore passwords are always needed, shares or not
+	assert.Contains(t, string(instructions), "password of each keystore")
+	assert.Contains(t, string(instructions), "at least 2 of them")
+	assert.NotContains(t, string(instructions), "unlocks the keystores")
+
+	shares, err := os.ReadFile(filepath.Join(dir, sharesFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(shares), "alice@example.com")
+
+	ciphertext, err := os.ReadFile(filepath.Join(dir, "wallets.json.age"))
+	require.NoError(t, err)
+	r, err := age.Decrypt(armor.NewReader(bytes.NewReader(ciphertext)), identity)
+	require.NoError(t, err)
+	plaintext, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	var decoded bundle
+	require.NoError(t, json.Unmarshal(plaintext, &decoded))
+	require.Len(t, decoded.Wallets, 1)
+	assert.JSONEq(t, `{"address":"abc","crypto":{}}`, string(decoded.Wallets[0].Keystore))
+}
+
+func TestGeneratePackage_RequiresWallets(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	recipient, err := wallet.ParseExportRecipient(identity.Recipient().String())
+	require.NoError(t, err)
+
+	_, err = GeneratePackage(&Plan{}, PackageOptions{OutputDir: t.TempDir(), Recipient: recipient, Now: time.Now()})
+	assert.Error(t, err)
+}
+
+func TestGeneratePackage_WatchOnlyOnly(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	recipient, err := wallet.ParseExportRecipient(identity.Recipient().String())
+	require.NoError(t, err)
+
+	_, err = GeneratePackage(&Plan{}, PackageOptions{
+		OutputDir: t.TempDir(),
+		Wallets:   []wallet.Wallet{{Name: "Cold storage", Address: "0xDEF", ImportMethod: string(wallet.ImportMethodWatchOnly)}},
+		Recipient: recipient,
+		Now:       time.Now(),
+	})
+	assert.ErrorContains(t, err, "watch-only")
+}
@@ -0,0 +1,208 @@
+// Package inheritance tracks the user's backup verification reminders and
+// inheritance ("dead man's switch") plan: who holds the SLIP-39 shares, whether
+// they were delivered, and when the inheritance package was last generated.
+package inheritance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PlanFileName is the name of the plan state file inside the application directory
+const PlanFileName = "inheritance_plan.json"
+
+// Step identifies an item of the inheritance checklist
+type Step string
+
+const (
+	StepBackupsVerified    Step = "backups_verified"
+	StepSharesDistributed  Step = "shares_distributed"
+	StepPackageGenerated   Step = "package_generated"
+	StepInstructionsStored Step = "instructions_stored"
+)
+
+// Steps lists the checklist in the order it should be completed
+var Steps = []Step{StepBackupsVerified, StepSharesDistributed, StepPackageGenerated, StepInstructionsStored}
+
+var (
+	ErrInvalidThreshold = errors.New("threshold must be between 1 and the number of share holders")
+	ErrHolderNotFound   = errors.New("share holder not found")
+)
+
+// ShareHolder is a person or place that keeps one SLIP-39 share
+type ShareHolder struct {
+	Name        string     `json:"name"`
+	Contact     string     `json:"contact,omitempty"`
+	ShareIndex  int        `json:"share_index"`
+	Delivered   bool       `json:"delivered"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+}
+
+// Plan is the persisted state of the inheritance plan
+type Plan struct {
+	LastVerifiedAt     *time.Time    `json:"last_verified_at,omitempty"`
+	Threshold          int           `json:"threshold"`
+	Holders            []ShareHolder `json:"holders"`
+	PackageGeneratedAt *time.Time    `json:"package_generated_at,omitempty"`
+	PackagePath        string        `json:"package_path,omitempty"`
+	InstructionsStored bool          `json:"instructions_stored"`
+}
+
+// Store loads and saves the plan as JSON readable only by the owner
+type Store struct {
+	path string
+}
+
+// NewStore creates a store for the plan file inside appDir
+func NewStore(appDir string) *Store {
+	return &Store{path: filepath.Join(appDir, PlanFileName)}
+}
+
+// Load reads the plan, returning an empty plan when none was saved yet
+func (s *Store) Load() (*Plan, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Plan{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inheritance plan: %w", err)
+	}
+	var plan Plan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse inheritance plan: %w", err)
+	}
+	return &plan, nil
+}
+
+// Save writes the plan atomically
+func (s *Store) Save(plan *Plan) error {
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write inheritance plan: %w", err)
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// MarkBackupsVerified records that the user checked their backups at now
+func (p *Plan) MarkBackupsVerified(now time.Time) {
+	p.LastVerifiedAt = &now
+}
+
+// NextReminder returns when the next backup verification is due.
+// The zero time means a verification is due immediately.
+func (p *Plan) NextReminder(interval time.Duration) time.Time {
+	if p.LastVerifiedAt == nil {
+		return time.Time{}
+	}
+	return p.LastVerifiedAt.Add(interval)
+}
+
+// ReminderDue reports whether the backups should be verified again.
+// A non-positive interval disables reminders.
+func (p *Plan) ReminderDue(now time.Time, interval time.Duration) bool {
+	if interval <= 0 {
+		return false
+	}
+	return !now.Before(p.NextReminder(interval))
+}
+
+// AddHolder appends a share holder with the next share index
+func (p *Plan) AddHolder(name, contact string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("share holder name is required")
+	}
+	p.Holders = append(p.Holders, ShareHolder{
+		Name:       name,
+		Contact:    strings.TrimSpace(contact),
+		ShareIndex: len(p.Holders) + 1,
+	})
+	if p.Threshold == 0 {
+		p.Threshold = 1
+	}
+	return nil
+}
+
+// RemoveHolder removes the holder at index and renumbers the remaining shares
+func (p *Plan) RemoveHolder(index int) error {
+	if index < 0 || index >= len(p.Holders) {
+		return ErrHolderNotFound
+	}
+	p.Holders = append(p.Holders[:index], p.Holders[index+1:]...)
+	for i := range p.Holders {
+		p.Holders[i].ShareIndex = i + 1
+	}
+	if p.Threshold > len(p.Holders) {
+		p.Threshold = len(p.Holders)
+	}
+	return nil
+}
+
+// ToggleDelivered flips the delivery status of the holder at index
+func (p *Plan) ToggleDelivered(index int, now time.Time) error {
+	if index < 0 || index >= len(p.Holders) {
+		return ErrHolderNotFound
+	}
+	holder := &p.Holders[index]
+	holder.Delivered = !holder.Delivered
+	if holder.Delivered {
+		holder.DeliveredAt = &now
+	} else {
+		holder.DeliveredAt = nil
+	}
+	return nil
+}
+
+// SetThreshold sets how many shares are needed to recover the secret
+func (p *Plan) SetThreshold(threshold int) error {
+	if threshold < 1 || threshold > len(p.Holders) {
+		return ErrInvalidThreshold
+	}
+	p.Threshold = threshold
+	return nil
+}
+
+// IsDone reports whether a checklist step is complete
+func (p *Plan) IsDone(step Step, now time.Time, interval time.Duration) bool {
+	switch step {
+	case StepBackupsVerified:
+		return p.LastVerifiedAt != nil && !p.ReminderDue(now, interval)
+	case StepSharesDistributed:
+		if len(p.Holders) == 0 || p.Threshold == 0 {
+			return false
+		}
+		for _, h := range p.Holders {
+			if !h.Delivered {
+				return false
+			}
+		}
+		return true
+	case StepPackageGenerated:
+		return p.PackageGeneratedAt != nil
+	case StepInstructionsStored:
+		return p.InstructionsStored
+	}
+	return false
+}
+
+// Completion returns how many checklist steps are complete
+func (p *Plan) Completion(now time.Time, interval time.Duration) (done, total int) {
+	for _, step := range Steps {
+		if p.IsDone(step, now, interval) {
+			done++
+		}
+	}
+	return done, len(Steps)
+}
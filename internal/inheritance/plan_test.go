@@ -0,0 +1,75 @@
+package inheritance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ninetyDays = 90 * 24 * time.Hour
+
+func TestReminderDue(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	plan := &Plan{}
+
+	assert.True(t, plan.ReminderDue(now, ninetyDays), "never verified")
+	assert.False(t, plan.ReminderDue(now, 0), "reminders disabled")
+
+	plan.MarkBackupsVerified(now)
+	assert.False(t, plan.ReminderDue(now.Add(89*24*time.Hour), ninetyDays))
+	assert.True(t, plan.ReminderDue(now.Add(ninetyDays), ninetyDays))
+}
+
+func TestHoldersAndThreshold(t *testing.T) {
+	plan := &Plan{}
+	require.NoError(t, plan.AddHolder("Alice", "alice@example.com"))
+	require.NoError(t, plan.AddHolder("Bob", ""))
+	require.NoError(t, plan.AddHolder("Carol", ""))
+	assert.Error(t, plan.AddHolder("  ", ""))
+
+	require.NoError(t, plan.SetThreshold(3))
+	assert.ErrorIs(t, plan.SetThreshold(4), ErrInvalidThreshold)
+
+	require.NoError(t, plan.RemoveHolder(0))
+	assert.Equal(t, 2, plan.Threshold, "threshold is clamped to the holder count")
+	assert.Equal(t, []int{1, 2}, []int{plan.Holders[0].ShareIndex, plan.Holders[1].ShareIndex})
+	assert.ErrorIs(t, plan.RemoveHolder(5), ErrHolderNotFound)
+}
+
+func TestCompletion(t *testing.T) {
+	now := time.Now()
+	plan := &Plan{}
+	done, total := plan.Completion(now, ninetyDays)
+	assert.Equal(t, 0, done)
+	assert.Equal(t, len(Steps), total)
+
+	plan.MarkBackupsVerified(now)
+	require.NoError(t, plan.AddHolder("Alice", ""))
+	require.NoError(t, plan.ToggleDelivered(0, now))
+	plan.InstructionsStored = true
+	done, _ = plan.Completion(now, ninetyDays)
+	assert.Equal(t, 3, done)
+	assert.False(t, plan.IsDone(StepPackageGenerated, now, ninetyDays))
+
+	require.NoError(t, plan.ToggleDelivered(0, now))
+	assert.False(t, plan.IsDone(StepSharesDistributed, now, ninetyDays))
+	assert.Nil(t, plan.Holders[0].DeliveredAt)
+}
+
+func TestStore_RoundTrip(t *testing.T) {
+	store := NewStore(t.TempDir())
+	plan, err := store.Load()
+	require.NoError(t, err)
+	assert.Empty(t, plan.Holders)
+
+	require.NoError(t, plan.AddHolder("Alice", "safe deposit box"))
+	plan.MarkBackupsVerified(time.Now())
+	require.NoError(t, store.Save(plan))
+
+	loaded, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "safe deposit box", loaded.Holders[0].Contact)
+	assert.NotNil(t, loaded.LastVerifiedAt)
+}
@@ -0,0 +1,119 @@
+package integrity
+
+import (
+	"bufio"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/digitallyserviced/tdfgo/tdf"
+	"github.com/tyler-smith/go-bip39/wordlists"
+)
+
+//go:generate go run gen_manifest.go
+
+//go:embed assets.sha256
+var assetManifest string
+
+// Assets hashes the data compiled in from dependencies that a tampered
+// module could change unnoticed: the BIP-39 wordlists and the built-in fonts
+func Assets() (map[string]string, error) {
+	lists := map[string][]string{
+		"chinese_simplified":  wordlists.ChineseSimplified,
+		"chinese_traditional": wordlists.ChineseTraditional,
+		"czech":               wordlists.Czech,
+		"english":             wordlists.English,
+		"french":              wordlists.French,
+		"italian":             wordlists.Italian,
+		"japanese":            wordlists.Japanese,
+		"korean":              wordlists.Korean,
+		"spanish":             wordlists.Spanish,
+	}
+	hashes := make(map[string]string, len(lists))
+	for name, words := range lists {
+		hashes["wordlist/"+name] = hashOf([]byte(strings.Join(words, "\n")))
+	}
+	err := fs.WalkDir(tdf.BuiltinFontsFiles, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := tdf.BuiltinFontsFiles.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		hashes["font/"+d.Name()] = hashOf(data)
+		return nil
+	})
+	return hashes, err
+}
+
+func hashOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Manifest formats hashes as "<sha256>  <name>" lines sorted by name, the
+// format of assets.sha256
+func Manifest(hashes map[string]string) string {
+	names := make([]string, 0, len(hashes))
+	for name := range hashes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s  %s\n", hashes[name], name)
+	}
+	return b.String()
+}
+
+// CheckAssets compares the compiled-in assets with the manifest recorded when
+// the dependencies were last reviewed
+func CheckAssets() ([]Issue, error) {
+	actual, err := Assets()
+	if err != nil {
+		return nil, err
+	}
+	expected := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(assetManifest))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 {
+			expected[fields[1]] = fields[0]
+		}
+	}
+
+	var issues []Issue
+	for _, name := range sortedKeys(expected, actual) {
+		want, known := expected[name]
+		got, present := actual[name]
+		switch {
+		case !present:
+			issues = append(issues, Issue{Subject: name, Reason: "missing from the binary"})
+		case !known:
+			issues = append(issues, Issue{Subject: name, Reason: "not in the manifest"})
+		case got != want:
+			issues = append(issues, Issue{Subject: name, Reason: fmt.Sprintf("SHA-256 is %s, expected %s", got, want)})
+		}
+	}
+	return issues, nil
+}
+
+func sortedKeys(maps ...map[string]string) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range maps {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,23 @@
+//go:build ignore
+
+// gen_manifest writes assets.sha256 from the assets compiled in today; run it
+// with go generate after reviewing a dependency update
+package main
+
+import (
+	"log"
+	"os"
+
+	"blocowallet/internal/integrity"
+)
+
+func main() {
+	hashes, err := integrity.Assets()
+	if err != nil {
+		log.Fatal(err)
+	}
+	header := "# SHA-256 of the wordlists and fonts compiled into the binary, checked by\n# check_assets under [integrity]. Regenerate with go generate ./internal/integrity.\n"
+	if err := os.WriteFile("assets.sha256", []byte(header+integrity.Manifest(hashes)), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,130 @@
+// Package integrity checks that the running binary and the data compiled into
+// it are the ones that were built: the release build stamps the binary with
+// its own SHA-256, and the wordlists and fonts are compared with a manifest of
+// their hashes.
+package integrity
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// stamp is replaced in the built binary by the SHA-256 of the binary with the
+// digest zeroed. It must stay a single literal: Stamp and CheckBinary find it
+// by its prefix, which appears nowhere else in the binary.
+var stamp = "BLOCO-WALLET-INTEGRITY-V1:0000000000000000000000000000000000000000000000000000000000000000"
+
+// markerLen is the length of the prefix before the hex digest
+const markerLen = len("BLOCO-WALLET-INTEGRITY-V1:")
+
+// ErrNoStamp is returned when the binary holds no stamp or more than one
+var ErrNoStamp = errors.New("no integrity stamp found in the binary")
+
+// Status is the outcome of checking the binary
+type Status int
+
+const (
+	// Verified means the binary matches its stamp
+	Verified Status = iota
+	// Unstamped means the binary was not stamped, as a development build
+	Unstamped
+	// Tampered means the binary changed after it was stamped
+	Tampered
+)
+
+// Issue is something found modified since the build
+type Issue struct {
+	Subject string // What was checked: the binary path or an asset name
+	Reason  string
+}
+
+// marker returns the prefix the stamp is found by
+func marker() []byte {
+	return []byte(stamp[:markerLen])
+}
+
+// locate returns where the hex digest of the stamp starts in data
+func locate(data []byte) (int, error) {
+	m := marker()
+	i := bytes.Index(data, m)
+	if i < 0 || bytes.Contains(data[i+len(m):], m) {
+		return 0, ErrNoStamp
+	}
+	start := i + len(m)
+	if start+sha256.Size*2 > len(data) {
+		return 0, ErrNoStamp
+	}
+	return start, nil
+}
+
+// digest hashes data with the stamped digest at start zeroed
+func digest(data []byte, start int) [sha256.Size]byte {
+	h := sha256.New()
+	h.Write(data[:start])
+	h.Write(bytes.Repeat([]byte{'0'}, sha256.Size*2))
+	h.Write(data[start+sha256.Size*2:])
+	var sum [sha256.Size]byte
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+// Stamp writes the digest of the binary at path into its stamp. Stamping must
+// be the last change to the binary: stripping or signing it afterwards breaks
+// the check.
+func Stamp(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	start, err := locate(data)
+	if err != nil {
+		return err
+	}
+	sum := digest(data, start)
+	copy(data[start:], hex.EncodeToString(sum[:]))
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, info.Mode().Perm())
+}
+
+// CheckBinary compares the binary at path with its stamp
+func CheckBinary(path string) (Status, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Unstamped, err
+	}
+	start, err := locate(data)
+	if err != nil {
+		return Tampered, err
+	}
+	stamped := string(data[start : start+sha256.Size*2])
+	if stamped == string(bytes.Repeat([]byte{'0'}, sha256.Size*2)) {
+		return Unstamped, nil
+	}
+	sum := digest(data, start)
+	if actual := hex.EncodeToString(sum[:]); actual != stamped {
+		return Tampered, fmt.Errorf("SHA-256 is %s, stamped %s", actual, stamped)
+	}
+	return Verified, nil
+}
+
+// CheckExecutable checks the running binary, returning an issue when it was
+// modified after being stamped
+func CheckExecutable() (Status, []Issue, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return Unstamped, nil, err
+	}
+	status, err := CheckBinary(path)
+	if status == Tampered {
+		return status, []Issue{{Subject: path, Reason: err.Error()}}, nil
+	}
+	return status, nil, err
+}
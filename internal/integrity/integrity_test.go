@@ -0,0 +1,74 @@
+package integrity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBinary writes a file holding the unstamped stamp between other bytes
+func fakeBinary(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "bloco-wallet")
+	require.NoError(t, os.WriteFile(path, []byte("\x7fELF code "+stamp+" more code"), 0755))
+	return path
+}
+
+func TestStampAndCheckBinary(t *testing.T) {
+	path := fakeBinary(t)
+	status, err := CheckBinary(path)
+	require.NoError(t, err)
+	assert.Equal(t, Unstamped, status)
+
+	require.NoError(t, Stamp(path))
+	status, err = CheckBinary(path)
+	require.NoError(t, err)
+	assert.Equal(t, Verified, status)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	// Changing any byte outside the stamp is caught
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	data[1] = 'X'
+	require.NoError(t, os.WriteFile(path, data, 0755))
+	status, err = CheckBinary(path)
+	assert.Equal(t, Tampered, status)
+	assert.ErrorContains(t, err, "stamped")
+}
+
+func TestCheckBinary_NoStamp(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "other")
+	require.NoError(t, os.WriteFile(path, []byte("no stamp here"), 0755))
+	status, err := CheckBinary(path)
+	assert.Equal(t, Tampered, status)
+	assert.ErrorIs(t, err, ErrNoStamp)
+	assert.ErrorIs(t, Stamp(path), ErrNoStamp)
+
+	// Two stamps cannot tell which one is real
+	require.NoError(t, os.WriteFile(path, []byte(stamp+stamp), 0755))
+	assert.ErrorIs(t, Stamp(path), ErrNoStamp)
+}
+
+func TestCheckAssets(t *testing.T) {
+	issues, err := CheckAssets()
+	require.NoError(t, err)
+	assert.Empty(t, issues, "assets.sha256 is stale: run go generate ./internal/integrity")
+
+	saved := assetManifest
+	defer func() { assetManifest = saved }()
+	assetManifest = "0000  wordlist/english\n1111  font/removed.tdf\n"
+	issues, err = CheckAssets()
+	require.NoError(t, err)
+	reasons := make(map[string]string)
+	for _, issue := range issues {
+		reasons[issue.Subject] = issue.Reason
+	}
+	assert.Contains(t, reasons["wordlist/english"], "expected 0000")
+	assert.Equal(t, "missing from the binary", reasons["font/removed.tdf"])
+	assert.Equal(t, "not in the manifest", reasons["wordlist/czech"])
+}
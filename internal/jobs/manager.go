@@ -0,0 +1,270 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Kind identifies the type of a long-running job
+type Kind string
+
+const (
+	KindBatchImport     Kind = "batch_import"
+	KindBalanceRefresh  Kind = "balance_refresh"
+	KindBackup          Kind = "backup"
+	KindHistorySync     Kind = "history_sync"
+	KindBalanceSnapshot Kind = "balance_snapshot"
+	KindIntegrityCheck  Kind = "integrity_check"
+	KindBatchExport     Kind = "batch_export"
+	KindWalletBatch     Kind = "wallet_batch"
+	KindNetworkImport   Kind = "network_import"
+)
+
+// Status represents the lifecycle state of a job
+type Status string
+
+const (
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// IsFinal reports whether the status is terminal
+func (s Status) IsFinal() bool {
+	return s == StatusSucceeded || s == StatusFailed || s == StatusCancelled
+}
+
+// ErrConflict is returned when a job cannot start because a conflicting job is running
+var ErrConflict = errors.New("a conflicting job is already running")
+
+// ErrJobNotFound is returned when a job id is unknown
+var ErrJobNotFound = errors.New("job not found")
+
+// conflicts lists, for each kind, the kinds that must not run at the same time.
+// A kind always conflicts with itself.
+var conflicts = map[Kind][]Kind{
+	KindBatchImport: {KindBackup},
+	KindBackup:      {KindBatchImport},
+}
+
+// ProgressFunc reports job progress (0.0 to 1.0) and a short status message
+type ProgressFunc func(progress float64, message string)
+
+// Func is the work executed by a job. It must return when ctx is cancelled.
+type Func func(ctx context.Context, report ProgressFunc) error
+
+// Job is a snapshot of a job's state
+type Job struct {
+	ID         string
+	Kind       Kind
+	Name       string
+	Status     Status
+	Progress   float64
+	Message    string
+	Err        error
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+type jobEntry struct {
+	Job
+	seq    int
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Manager runs long-running jobs off the UI thread and tracks their state
+type Manager struct {
+	mu     sync.Mutex
+	jobs   map[string]*jobEntry
+	nextID int
+	now    func() time.Time
+}
+
+// NewManager creates an empty job manager
+func NewManager() *Manager {
+	return &Manager{
+		jobs: make(map[string]*jobEntry),
+		now:  time.Now,
+	}
+}
+
+// Submit starts fn in a new goroutine unless a conflicting job is running
+func (m *Manager) Submit(kind Kind, name string, fn Func) (string, error) {
+	m.mu.Lock()
+	if running := m.conflictingJobLocked(kind); running != nil {
+		m.mu.Unlock()
+		return "", fmt.Errorf("%w: %s (%s)", ErrConflict, running.Name, running.ID)
+	}
+
+	m.nextID++
+	ctx, cancel := context.WithCancel(context.Background())
+	entry := &jobEntry{
+		Job: Job{
+			ID:        fmt.Sprintf("job-%d", m.nextID),
+			Kind:      kind,
+			Name:      name,
+			Status:    StatusRunning,
+			StartedAt: m.now(),
+		},
+		seq:    m.nextID,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	m.jobs[entry.ID] = entry
+	m.mu.Unlock()
+
+	go m.run(ctx, entry, fn)
+	return entry.ID, nil
+}
+
+func (m *Manager) run(ctx context.Context, entry *jobEntry, fn Func) {
+	defer close(entry.done)
+	defer entry.cancel()
+
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("job panicked: %v", r)
+			}
+		}()
+		err = fn(ctx, func(progress float64, message string) {
+			m.Report(entry.ID, progress, message)
+		})
+	}()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry.FinishedAt = m.now()
+	switch {
+	case ctx.Err() != nil && (err == nil || errors.Is(err, context.Canceled)):
+		entry.Status = StatusCancelled
+	case err != nil:
+		entry.Status = StatusFailed
+		entry.Err = err
+	default:
+		entry.Status = StatusSucceeded
+		entry.Progress = 1
+	}
+}
+
+// Report updates the progress of a running job
+func (m *Manager) Report(id string, progress float64, message string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.jobs[id]
+	if !ok || entry.Status.IsFinal() {
+		return
+	}
+	if progress < 0 {
+		progress = 0
+	} else if progress > 1 {
+		progress = 1
+	}
+	entry.Progress = progress
+	entry.Message = message
+}
+
+// Cancel requests cancellation of a running job
+func (m *Manager) Cancel(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.jobs[id]
+	if !ok {
+		return ErrJobNotFound
+	}
+	if !entry.Status.IsFinal() {
+		entry.cancel()
+	}
+	return nil
+}
+
+// Wait blocks until the job finishes and returns its final snapshot
+func (m *Manager) Wait(id string) (Job, error) {
+	m.mu.Lock()
+	entry, ok := m.jobs[id]
+	m.mu.Unlock()
+	if !ok {
+		return Job{}, ErrJobNotFound
+	}
+	<-entry.done
+	return m.Get(id)
+}
+
+// Get returns a snapshot of a job
+func (m *Manager) Get(id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.jobs[id]
+	if !ok {
+		return Job{}, ErrJobNotFound
+	}
+	return entry.Job, nil
+}
+
+// List returns snapshots of all jobs, newest first
+func (m *Manager) List() []Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries := make([]*jobEntry, 0, len(m.jobs))
+	for _, entry := range m.jobs {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].seq > entries[j].seq })
+
+	list := make([]Job, len(entries))
+	for i, entry := range entries {
+		list[i] = entry.Job
+	}
+	return list
+}
+
+// HasRunning reports whether any job is still running
+func (m *Manager) HasRunning() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, entry := range m.jobs {
+		if !entry.Status.IsFinal() {
+			return true
+		}
+	}
+	return false
+}
+
+// ClearFinished removes finished jobs from the list
+func (m *Manager) ClearFinished() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, entry := range m.jobs {
+		if entry.Status.IsFinal() {
+			delete(m.jobs, id)
+		}
+	}
+}
+
+func (m *Manager) conflictingJobLocked(kind Kind) *jobEntry {
+	blocked := append([]Kind{kind}, conflicts[kind]...)
+	for _, entry := range m.jobs {
+		if entry.Status.IsFinal() {
+			continue
+		}
+		for _, k := range blocked {
+			if entry.Kind == k {
+				return entry
+			}
+		}
+	}
+	return nil
+}
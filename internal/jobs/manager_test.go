@@ -0,0 +1,150 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_SubmitRunsJobToCompletion(t *testing.T) {
+	m := NewManager()
+
+	id, err := m.Submit(KindBalanceRefresh, "refresh", func(ctx context.Context, report ProgressFunc) error {
+		report(0.5, "halfway")
+		return nil
+	})
+	require.NoError(t, err)
+
+	job, err := m.Wait(id)
+	require.NoError(t, err)
+	assert.Equal(t, StatusSucceeded, job.Status)
+	assert.Equal(t, 1.0, job.Progress)
+	assert.False(t, job.FinishedAt.IsZero())
+	assert.False(t, m.HasRunning())
+}
+
+func TestManager_FailedJobKeepsError(t *testing.T) {
+	m := NewManager()
+	boom := errors.New("boom")
+
+	id, err := m.Submit(KindBackup, "backup", func(ctx context.Context, report ProgressFunc) error {
+		return boom
+	})
+	require.NoError(t, err)
+
+	job, err := m.Wait(id)
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, job.Status)
+	assert.ErrorIs(t, job.Err, boom)
+}
+
+func TestManager_PanicMarksJobFailed(t *testing.T) {
+	m := NewManager()
+
+	id, err := m.Submit(KindHistorySync, "sync", func(ctx context.Context, report ProgressFunc) error {
+		panic("unexpected")
+	})
+	require.NoError(t, err)
+
+	job, err := m.Wait(id)
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, job.Status)
+	assert.Contains(t, job.Err.Error(), "unexpected")
+}
+
+func TestManager_CancelStopsJob(t *testing.T) {
+	m := NewManager()
+	started := make(chan struct{})
+
+	id, err := m.Submit(KindBatchImport, "import", func(ctx context.Context, report ProgressFunc) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.NoError(t, err)
+	<-started
+
+	require.NoError(t, m.Cancel(id))
+	job, err := m.Wait(id)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCancelled, job.Status)
+	assert.ErrorIs(t, m.Cancel("missing"), ErrJobNotFound)
+}
+
+func TestManager_RejectsConflictingJobs(t *testing.T) {
+	m := NewManager()
+	release := make(chan struct{})
+
+	id, err := m.Submit(KindBatchImport, "first import", func(ctx context.Context, report ProgressFunc) error {
+		<-release
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, err = m.Submit(KindBatchImport, "second import", func(ctx context.Context, report ProgressFunc) error { return nil })
+	assert.ErrorIs(t, err, ErrConflict)
+
+	_, err = m.Submit(KindBackup, "backup", func(ctx context.Context, report ProgressFunc) error { return nil })
+	assert.ErrorIs(t, err, ErrConflict)
+
+	// Unrelated kinds may run alongside
+	other, err := m.Submit(KindBalanceRefresh, "refresh", func(ctx context.Context, report ProgressFunc) error { return nil })
+	require.NoError(t, err)
+	_, err = m.Wait(other)
+	require.NoError(t, err)
+
+	close(release)
+	_, err = m.Wait(id)
+	require.NoError(t, err)
+
+	// Once finished, the same kind can be submitted again
+	next, err := m.Submit(KindBatchImport, "third import", func(ctx context.Context, report ProgressFunc) error { return nil })
+	require.NoError(t, err)
+	_, err = m.Wait(next)
+	require.NoError(t, err)
+}
+
+func TestManager_ListAndClearFinished(t *testing.T) {
+	m := NewManager()
+
+	first, err := m.Submit(KindBalanceRefresh, "first", func(ctx context.Context, report ProgressFunc) error { return nil })
+	require.NoError(t, err)
+	_, _ = m.Wait(first)
+	second, err := m.Submit(KindBalanceRefresh, "second", func(ctx context.Context, report ProgressFunc) error { return nil })
+	require.NoError(t, err)
+	_, _ = m.Wait(second)
+
+	list := m.List()
+	require.Len(t, list, 2)
+	assert.Equal(t, second, list[0].ID)
+	assert.Equal(t, first, list[1].ID)
+
+	m.ClearFinished()
+	assert.Empty(t, m.List())
+}
+
+func TestManager_ReportClampsProgress(t *testing.T) {
+	m := NewManager()
+	release := make(chan struct{})
+
+	id, err := m.Submit(KindHistorySync, "sync", func(ctx context.Context, report ProgressFunc) error {
+		<-release
+		return nil
+	})
+	require.NoError(t, err)
+
+	m.Report(id, 2.5, "over")
+	job, _ := m.Get(id)
+	assert.Equal(t, 1.0, job.Progress)
+	assert.Equal(t, "over", job.Message)
+
+	m.Report(id, -1, "under")
+	job, _ = m.Get(id)
+	assert.Equal(t, 0.0, job.Progress)
+
+	close(release)
+	_, _ = m.Wait(id)
+}
@@ -0,0 +1,357 @@
+package metasync
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"blocowallet/pkg/config"
+)
+
+// DocumentName is the file name of the synced document on every backend
+const DocumentName = "bloco-inventory.age"
+
+// ErrNotFound is returned by a backend that holds no document yet
+var ErrNotFound = errors.New("sync document not found")
+
+// Backend stores the encrypted sync document
+type Backend interface {
+	Fetch(ctx context.Context) ([]byte, error)
+	Store(ctx context.Context, data []byte) error
+	Describe() string
+}
+
+// NewBackend builds the backend selected in the configuration. workDir holds
+// local state such as the git checkout.
+func NewBackend(cfg config.SyncConfig, workDir string) (Backend, error) {
+	if strings.TrimSpace(cfg.URL) == "" {
+		return nil, fmt.Errorf("sync backend %q requires a url", cfg.Backend)
+	}
+	switch cfg.Backend {
+	case "dir":
+		return &DirBackend{Path: filepath.Join(cfg.URL, DocumentName)}, nil
+	case "webdav":
+		return &WebDAVBackend{URL: documentURL(cfg.URL), Username: cfg.Username, Password: cfg.Password}, nil
+	case "s3":
+		region := cfg.Region
+		if region == "" {
+			region = "us-east-1"
+		}
+		return &S3Backend{URL: documentURL(cfg.URL), Region: region, AccessKeyID: cfg.Username, SecretAccessKey: cfg.Password}, nil
+	case "git":
+		branch := cfg.Branch
+		if branch == "" {
+			branch = "main"
+		}
+		return &GitBackend{Remote: cfg.URL, Branch: branch, Dir: filepath.Join(workDir, "git")}, nil
+	default:
+		return nil, fmt.Errorf("unknown sync backend %q", cfg.Backend)
+	}
+}
+
+// documentURL appends the document name to URLs that point at a folder
+func documentURL(raw string) string {
+	if strings.HasSuffix(raw, "/") {
+		return raw + DocumentName
+	}
+	return raw
+}
+
+// DirBackend keeps the document in a directory, e.g. a network share or a
+// folder synchronized by another tool
+type DirBackend struct {
+	Path string
+}
+
+func (b *DirBackend) Fetch(context.Context) ([]byte, error) {
+	data, err := os.ReadFile(b.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+func (b *DirBackend) Store(_ context.Context, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(b.Path), 0700); err != nil {
+		return err
+	}
+	return writeFileAtomic(b.Path, data)
+}
+
+func (b *DirBackend) Describe() string {
+	return b.Path
+}
+
+// WebDAVBackend reads and writes the document with plain GET and PUT requests
+type WebDAVBackend struct {
+	URL      string
+	Username string
+	Password string
+	Client   *http.Client
+}
+
+func (b *WebDAVBackend) Fetch(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	b.authorize(req)
+	return doFetch(httpClient(b.Client), req)
+}
+
+func (b *WebDAVBackend) Store(ctx context.Context, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, b.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	b.authorize(req)
+	return doStore(httpClient(b.Client), req)
+}
+
+func (b *WebDAVBackend) Describe() string {
+	return redactURL(b.URL)
+}
+
+func (b *WebDAVBackend) authorize(req *http.Request) {
+	if b.Username != "" || b.Password != "" {
+		req.SetBasicAuth(b.Username, b.Password)
+	}
+}
+
+// S3Backend stores the document as an object in an S3-compatible bucket. URL is
+// the object URL, in virtual-hosted or path style.
+type S3Backend struct {
+	URL             string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	Client          *http.Client
+	now             func() time.Time
+}
+
+func (b *S3Backend) Fetch(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	b.sign(req, nil)
+	return doFetch(httpClient(b.Client), req)
+}
+
+func (b *S3Backend) Store(ctx context.Context, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, b.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	b.sign(req, data)
+	return doStore(httpClient(b.Client), req)
+}
+
+func (b *S3Backend) Describe() string {
+	return redactURL(b.URL)
+}
+
+// sign adds an AWS Signature Version 4 authorization header
+func (b *S3Backend) sign(req *http.Request, body []byte) {
+	if b.AccessKeyID == "" {
+		return // public or pre-signed URL
+	}
+	now := time.Now
+	if b.now != nil {
+		now = b.now
+	}
+	t := now().UTC()
+	amzDate := t.Format("20060102T150405Z")
+	day := t.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	canonicalURI := req.URL.EscapedPath()
+	if canonicalURI == "" {
+		canonicalURI = "/"
+	}
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method, canonicalURI, req.URL.Query().Encode(), canonicalHeaders, signedHeaders, payloadHash,
+	}, "\n")
+
+	scope := day + "/" + b.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+b.SecretAccessKey), day)
+	key = hmacSHA256(key, b.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		b.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func httpClient(c *http.Client) *http.Client {
+	if c != nil {
+		return c
+	}
+	return &http.Client{Timeout: 30 * time.Second}
+}
+
+func doFetch(client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching sync document: unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func doStore(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("storing sync document: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// redactURL hides credentials embedded in a URL
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	return u.Redacted()
+}
+
+// GitBackend commits the document to a branch of a git repository using the
+// git command line, so existing SSH keys and credential helpers apply
+type GitBackend struct {
+	Remote string
+	Branch string
+	Dir    string // Local checkout owned by the sync
+}
+
+func (b *GitBackend) Fetch(ctx context.Context) ([]byte, error) {
+	if err := b.prepare(ctx); err != nil {
+		return nil, err
+	}
+	if err := b.git(ctx, "fetch", "origin"); err != nil {
+		return nil, err
+	}
+	// An empty repository or a missing branch means nothing was synced yet
+	if err := b.git(ctx, "rev-parse", "--verify", "--quiet", "refs/remotes/origin/"+b.Branch); err != nil {
+		return nil, ErrNotFound
+	}
+	if err := b.git(ctx, "checkout", "-B", b.Branch, "origin/"+b.Branch); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(b.Dir, DocumentName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+func (b *GitBackend) Store(ctx context.Context, data []byte) error {
+	if err := b.prepare(ctx); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(b.Dir, DocumentName), data); err != nil {
+		return err
+	}
+	if err := b.git(ctx, "add", DocumentName); err != nil {
+		return err
+	}
+	host, _ := os.Hostname()
+	if err := b.git(ctx, "-c", "user.name=bloco-wallet", "-c", "user.email=bloco-wallet@localhost",
+		"commit", "--quiet", "--allow-empty", "-m", "Update wallet inventory from "+host); err != nil {
+		return err
+	}
+	return b.git(ctx, "push", "--quiet", "origin", "HEAD:refs/heads/"+b.Branch)
+}
+
+func (b *GitBackend) Describe() string {
+	return redactURL(b.Remote) + " (" + b.Branch + ")"
+}
+
+// prepare creates the local checkout on first use
+func (b *GitBackend) prepare(ctx context.Context) error {
+	if _, err := os.Stat(filepath.Join(b.Dir, ".git")); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(b.Dir, 0700); err != nil {
+		return err
+	}
+	if err := b.git(ctx, "init", "--quiet"); err != nil {
+		return err
+	}
+	if err := b.git(ctx, "checkout", "--quiet", "-b", b.Branch); err != nil {
+		return err
+	}
+	return b.git(ctx, "remote", "add", "origin", b.Remote)
+}
+
+func (b *GitBackend) git(ctx context.Context, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", b.Dir}, args...)...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// writeFileAtomic replaces path with data without leaving a partial file behind
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".sync-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,119 @@
+package metasync
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"blocowallet/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// objectServer is a minimal WebDAV/S3 stand-in keeping a single object
+type objectServer struct {
+	mu     sync.Mutex
+	data   []byte
+	header http.Header
+}
+
+func (s *objectServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.header = r.Header.Clone()
+	switch r.Method {
+	case http.MethodGet:
+		if s.data == nil {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(s.data)
+	case http.MethodPut:
+		s.data, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+func roundTrip(t *testing.T, b Backend) {
+	t.Helper()
+	ctx := context.Background()
+	_, err := b.Fetch(ctx)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, b.Store(ctx, []byte("first")))
+	require.NoError(t, b.Store(ctx, []byte("second")))
+	data, err := b.Fetch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+}
+
+func TestDirBackend(t *testing.T) {
+	b, err := NewBackend(config.SyncConfig{Backend: "dir", URL: t.TempDir()}, t.TempDir())
+	require.NoError(t, err)
+	roundTrip(t, b)
+}
+
+func TestWebDAVBackend(t *testing.T) {
+	srv := &objectServer{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	b, err := NewBackend(config.SyncConfig{Backend: "webdav", URL: ts.URL + "/dav/", Username: "u", Password: "p"}, "")
+	require.NoError(t, err)
+	roundTrip(t, b)
+	assert.Equal(t, ts.URL+"/dav/"+DocumentName, b.(*WebDAVBackend).URL)
+
+	user, pass, ok := (&http.Request{Header: srv.header}).BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "u", user)
+	assert.Equal(t, "p", pass)
+}
+
+func TestS3Backend_SignsRequests(t *testing.T) {
+	srv := &objectServer{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	b, err := NewBackend(config.SyncConfig{Backend: "s3", URL: ts.URL + "/bucket/inventory.age", Username: "AKID", Password: "secret", Region: "eu-west-1"}, "")
+	require.NoError(t, err)
+	roundTrip(t, b)
+
+	auth := srv.header.Get("Authorization")
+	assert.True(t, strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/"), auth)
+	assert.Contains(t, auth, "/eu-west-1/s3/aws4_request")
+	assert.Contains(t, auth, "SignedHeaders=host;x-amz-content-sha256;x-amz-date")
+	assert.NotEmpty(t, srv.header.Get("X-Amz-Date"))
+}
+
+func TestGitBackend(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	require.NoError(t, exec.Command("git", "init", "--quiet", "--bare", remote).Run())
+
+	b, err := NewBackend(config.SyncConfig{Backend: "git", URL: remote}, t.TempDir())
+	require.NoError(t, err)
+	roundTrip(t, b)
+
+	// A second checkout sees what the first one pushed
+	other, err := NewBackend(config.SyncConfig{Backend: "git", URL: remote}, t.TempDir())
+	require.NoError(t, err)
+	data, err := other.Fetch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+}
+
+func TestNewBackend_Errors(t *testing.T) {
+	_, err := NewBackend(config.SyncConfig{Backend: "ftp", URL: "ftp://x"}, "")
+	assert.Error(t, err)
+	_, err = NewBackend(config.SyncConfig{Backend: "dir"}, "")
+	assert.Error(t, err)
+}
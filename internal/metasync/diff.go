@@ -0,0 +1,247 @@
+package metasync
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"blocowallet/internal/wallet"
+)
+
+// FieldChange is a wallet field holding different values on the two sides
+type FieldChange struct {
+	Field string `json:"field"`
+	A     string `json:"a"`
+	B     string `json:"b"`
+}
+
+// WalletDiff is a wallet present on both sides whose metadata or keystore differ
+type WalletDiff struct {
+	Address string        `json:"address"`
+	NameA   string        `json:"name_a"`
+	NameB   string        `json:"name_b"`
+	Changes []FieldChange `json:"changes,omitempty"`
+	// KeystoreMismatch is set when both sides carry a keystore for the wallet
+	// and the files are not the same
+	KeystoreMismatch bool `json:"keystore_mismatch,omitempty"`
+}
+
+// Comparison is the result of comparing two inventories, A and B
+type Comparison struct {
+	OnlyInA   []WalletEntry `json:"only_in_a"`
+	OnlyInB   []WalletEntry `json:"only_in_b"`
+	Differing []WalletDiff  `json:"differing"`
+	Identical int           `json:"identical"`
+	// Networks are only compared when both sides list them; wallet databases do not
+	NetworksCompared  bool     `json:"networks_compared"`
+	NetworksOnlyInA   []string `json:"networks_only_in_a,omitempty"`
+	NetworksOnlyInB   []string `json:"networks_only_in_b,omitempty"`
+	NetworksDiffering []string `json:"networks_differing,omitempty"`
+}
+
+// Equal reports whether both sides hold the same wallets and networks
+func (c Comparison) Equal() bool {
+	return len(c.OnlyInA) == 0 && len(c.OnlyInB) == 0 && len(c.Differing) == 0 &&
+		len(c.NetworksOnlyInA) == 0 && len(c.NetworksOnlyInB) == 0 && len(c.NetworksDiffering) == 0
+}
+
+// Compare lists the wallets found on only one side and those whose metadata
+// or keystore differ. Wallets are matched by source hash and then by address,
+// so a wallet imported on each side in a different way is reported as
+// differing rather than missing twice.
+func Compare(a, b Snapshot) Comparison {
+	var c Comparison
+	aWallets, bWallets := walletsByKey(a.Wallets), walletsByKey(b.Wallets)
+	var onlyA, onlyB []WalletEntry
+	for _, key := range unionKeys(aWallets, bWallets) {
+		wa, inA := aWallets[key]
+		wb, inB := bWallets[key]
+		switch {
+		case !inB:
+			onlyA = append(onlyA, wa)
+		case !inA:
+			onlyB = append(onlyB, wb)
+		default:
+			c.add(wa, wb)
+		}
+	}
+
+	byAddress := make(map[string]int, len(onlyB))
+	for i, w := range onlyB {
+		byAddress[strings.ToLower(w.Address)] = i
+	}
+	paired := make(map[int]bool)
+	for _, wa := range onlyA {
+		i, ok := byAddress[strings.ToLower(wa.Address)]
+		if !ok || paired[i] {
+			c.OnlyInA = append(c.OnlyInA, wa.withoutKeystore())
+			continue
+		}
+		paired[i] = true
+		c.add(wa, onlyB[i])
+	}
+	for i, wb := range onlyB {
+		if !paired[i] {
+			c.OnlyInB = append(c.OnlyInB, wb.withoutKeystore())
+		}
+	}
+	sort.Slice(c.Differing, func(i, j int) bool { return c.Differing[i].Address < c.Differing[j].Address })
+
+	if len(a.Networks) > 0 && len(b.Networks) > 0 {
+		c.NetworksCompared = true
+		for _, key := range unionKeys(a.Networks, b.Networks) {
+			na, inA := a.Networks[key]
+			nb, inB := b.Networks[key]
+			switch {
+			case !inB:
+				c.NetworksOnlyInA = append(c.NetworksOnlyInA, key)
+			case !inA:
+				c.NetworksOnlyInB = append(c.NetworksOnlyInB, key)
+			case na != nb:
+				c.NetworksDiffering = append(c.NetworksDiffering, key)
+			}
+		}
+	}
+	return c
+}
+
+// add records a wallet known on both sides, as identical or differing
+func (c *Comparison) add(a, b WalletEntry) {
+	diff := WalletDiff{Address: a.Address, NameA: a.Name, NameB: b.Name}
+	field := func(name, va, vb string) {
+		if va != vb {
+			diff.Changes = append(diff.Changes, FieldChange{Field: name, A: va, B: vb})
+		}
+	}
+	field("source_hash", a.SourceHash, b.SourceHash)
+	field("name", a.Name, b.Name)
+	field("import_method", a.ImportMethod, b.ImportMethod)
+	field("notes", a.Notes, b.Notes)
+	field("references", strings.Join(a.References, ", "), strings.Join(b.References, ", "))
+	if len(a.Keystore) > 0 && len(b.Keystore) > 0 {
+		diff.KeystoreMismatch = KeystoreHash(a.Keystore) != KeystoreHash(b.Keystore)
+	}
+
+	if len(diff.Changes) == 0 && !diff.KeystoreMismatch {
+		c.Identical++
+		return
+	}
+	c.Differing = append(c.Differing, diff)
+}
+
+func (w WalletEntry) withoutKeystore() WalletEntry {
+	w.Keystore = nil
+	return w
+}
+
+// KeystoreHash is the SHA-256 of the keystore JSON with insignificant
+// whitespace removed, so a keystore file and its synced copy hash the same
+func KeystoreHash(keystore []byte) string {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, keystore); err == nil {
+		keystore = compact.Bytes()
+	}
+	sum := sha256.Sum256(keystore)
+	return hex.EncodeToString(sum[:])
+}
+
+// WriteText writes the comparison for people, naming the sides labelA and labelB
+func (c Comparison) WriteText(w io.Writer, labelA, labelB string) {
+	entries := func(title string, list []WalletEntry) {
+		if len(list) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "%s (%d):\n", title, len(list))
+		for _, e := range list {
+			fmt.Fprintf(w, "  %s  %s (%s)\n", e.Address, e.Name, e.ImportMethod)
+		}
+		fmt.Fprintln(w)
+	}
+	entries("Only in "+labelA, c.OnlyInA)
+	entries("Only in "+labelB, c.OnlyInB)
+
+	if len(c.Differing) > 0 {
+		fmt.Fprintf(w, "Different (%d):\n", len(c.Differing))
+		for _, d := range c.Differing {
+			fmt.Fprintf(w, "  %s  %s\n", d.Address, d.NameA)
+			if d.KeystoreMismatch {
+				fmt.Fprintln(w, "    keystore: the files differ")
+			}
+			for _, ch := range d.Changes {
+				fmt.Fprintf(w, "    %s: %q in %s, %q in %s\n", ch.Field, ch.A, labelA, ch.B, labelB)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	networks := func(title string, keys []string) {
+		if len(keys) > 0 {
+			fmt.Fprintf(w, "%s: %s\n", title, strings.Join(keys, ", "))
+		}
+	}
+	networks("Networks only in "+labelA, c.NetworksOnlyInA)
+	networks("Networks only in "+labelB, c.NetworksOnlyInB)
+	networks("Networks configured differently", c.NetworksDiffering)
+
+	fmt.Fprintf(w, "%d wallets identical, %d only in %s, %d only in %s, %d different\n",
+		c.Identical, len(c.OnlyInA), labelA, len(c.OnlyInB), labelB, len(c.Differing))
+}
+
+// ReadSnapshot reads an inventory written by sync: an encrypted sync
+// document, opened with passphrase, or a plain one such as the sync state
+func ReadSnapshot(path, passphrase string) (Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("-----BEGIN AGE ENCRYPTED FILE-----")) {
+		if passphrase == "" {
+			return Snapshot{}, fmt.Errorf("%s is encrypted: %w", path, ErrNoPassphrase)
+		}
+		return (&Syncer{passphrase: passphrase}).decrypt(data)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return Snapshot{}, fmt.Errorf("%s is not a wallet inventory: %w", path, err)
+	}
+	return snap, nil
+}
+
+// SnapshotOfWallets builds the inventory of wallets read from a database.
+// Keystores are read to compare them; a keystore that cannot be found is left
+// out rather than failing, as databases copied from another machine point to
+// its paths. keystoreDirs are searched for the file name in that case.
+func SnapshotOfWallets(wallets []wallet.Wallet, keystoreDirs ...string) Snapshot {
+	snap := Snapshot{Version: SnapshotVersion}
+	for _, w := range wallets {
+		entry := entryOf(w)
+		if !w.WatchOnly() {
+			if data, err := readKeystore(w.KeyStorePath, keystoreDirs); err == nil {
+				entry.Keystore = data
+			}
+		}
+		snap.Wallets = append(snap.Wallets, entry)
+	}
+	return snap
+}
+
+func readKeystore(path string, dirs []string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err == nil || !errors.Is(err, os.ErrNotExist) {
+		return data, err
+	}
+	for _, dir := range dirs {
+		if data, err := os.ReadFile(filepath.Join(dir, filepath.Base(path))); err == nil {
+			return data, nil
+		}
+	}
+	return nil, err
+}
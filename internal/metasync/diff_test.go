@@ -0,0 +1,101 @@
+package metasync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/wallet"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	keystore := []byte(`{"address": "aa", "crypto": {}}`)
+	a := Snapshot{
+		Wallets: []WalletEntry{
+			{SourceHash: "same", Address: "0x01", Name: "Same", Keystore: keystore},
+			{SourceHash: "renamed", Address: "0x02", Name: "Old name"},
+			{SourceHash: "only-a", Address: "0x03", Name: "Laptop"},
+			{SourceHash: "pk", Address: "0x04", Name: "Imported", ImportMethod: "private_key"},
+			{SourceHash: "tampered", Address: "0x05", Name: "Tampered", Keystore: keystore},
+		},
+		Networks: map[string]NetworkEntry{"sepolia": {ChainID: 11155111}, "base": {ChainID: 8453}},
+	}
+	b := Snapshot{
+		Wallets: []WalletEntry{
+			// Synced copies are compacted; they still match the file
+			{SourceHash: "same", Address: "0x01", Name: "Same", Keystore: []byte(`{"address":"aa","crypto":{}}`)},
+			{SourceHash: "renamed", Address: "0x02", Name: "New name", Notes: "moved"},
+			{SourceHash: "only-b", Address: "0x06", Name: "Desktop"},
+			{SourceHash: "ks", Address: "0x04", Name: "Imported", ImportMethod: "keystore"},
+			{SourceHash: "tampered", Address: "0x05", Name: "Tampered", Keystore: []byte(`{"address":"bb"}`)},
+		},
+		Networks: map[string]NetworkEntry{"sepolia": {ChainID: 11155111, Confirmations: 3}, "polygon": {ChainID: 137}},
+	}
+
+	c := Compare(a, b)
+	assert.False(t, c.Equal())
+	assert.Equal(t, 1, c.Identical)
+	require.Len(t, c.OnlyInA, 1)
+	assert.Equal(t, "Laptop", c.OnlyInA[0].Name)
+	require.Len(t, c.OnlyInB, 1)
+	assert.Equal(t, "Desktop", c.OnlyInB[0].Name)
+
+	require.Len(t, c.Differing, 3)
+	renamed, imported, tampered := c.Differing[0], c.Differing[1], c.Differing[2]
+	assert.Equal(t, []FieldChange{{Field: "name", A: "Old name", B: "New name"}, {Field: "notes", A: "", B: "moved"}}, renamed.Changes)
+	assert.False(t, renamed.KeystoreMismatch, "no keystores to compare")
+	assert.Equal(t, "0x04", imported.Address, "matched by address")
+	assert.Len(t, imported.Changes, 2)
+	assert.True(t, tampered.KeystoreMismatch)
+	assert.Empty(t, tampered.Changes)
+
+	assert.True(t, c.NetworksCompared)
+	assert.Equal(t, []string{"base"}, c.NetworksOnlyInA)
+	assert.Equal(t, []string{"polygon"}, c.NetworksOnlyInB)
+	assert.Equal(t, []string{"sepolia"}, c.NetworksDiffering)
+
+	assert.True(t, Compare(a, a).Equal())
+	assert.False(t, Compare(a, Snapshot{Wallets: a.Wallets}).NetworksCompared)
+}
+
+func TestReadSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	snap := Snapshot{Version: SnapshotVersion, Wallets: []WalletEntry{{SourceHash: "a", Address: "0x01", Name: "A"}}}
+
+	s := &Syncer{passphrase: "correct horse", workFactor: 10}
+	encrypted, err := s.encrypt(snap)
+	require.NoError(t, err)
+	path := filepath.Join(dir, DocumentName)
+	require.NoError(t, os.WriteFile(path, encrypted, 0600))
+
+	_, err = ReadSnapshot(path, "")
+	assert.ErrorIs(t, err, ErrNoPassphrase)
+	_, err = ReadSnapshot(path, "wrong")
+	assert.Error(t, err)
+	read, err := ReadSnapshot(path, "correct horse")
+	require.NoError(t, err)
+	assert.Equal(t, "A", read.Wallets[0].Name)
+
+	plain := filepath.Join(dir, "state.json")
+	require.NoError(t, os.WriteFile(plain, []byte(`{"version":1,"wallets":[{"source_hash":"a","name":"B"}]}`), 0600))
+	read, err = ReadSnapshot(plain, "")
+	require.NoError(t, err)
+	assert.Equal(t, "B", read.Wallets[0].Name)
+}
+
+func TestSnapshotOfWallets_FindsMovedKeystores(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "UTC--a.json"), []byte(`{"address":"aa"}`), 0600))
+	wallets := []wallet.Wallet{
+		{SourceHash: "a", Address: "0x01", KeyStorePath: "/home/old/.bloco/keystore/keystore/UTC--a.json"},
+		{SourceHash: "b", Address: "0x02", KeyStorePath: "/home/old/.bloco/keystore/keystore/UTC--b.json"},
+	}
+
+	snap := SnapshotOfWallets(wallets, dir)
+	require.Len(t, snap.Wallets, 2)
+	assert.JSONEq(t, `{"address":"aa"}`, string(snap.Wallets[0].Keystore))
+	assert.Empty(t, snap.Wallets[1].Keystore, "not found anywhere")
+}
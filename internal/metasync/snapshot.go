@@ -0,0 +1,227 @@
+// Package metasync keeps the non-secret wallet inventory (wallet names, notes
+// and networks) consistent between installations. The inventory is encrypted with
+// a passphrase and stored on a user-provided backend; keystores are only
+// included when explicitly enabled.
+package metasync
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"blocowallet/pkg/config"
+)
+
+// SnapshotVersion is the version of the synced document format
+const SnapshotVersion = 1
+
+// Snapshot is the synced inventory document
+type Snapshot struct {
+	Version   int                     `json:"version"`
+	Device    string                  `json:"device,omitempty"`
+	UpdatedAt time.Time               `json:"updated_at"`
+	Wallets   []WalletEntry           `json:"wallets"`
+	Networks  map[string]NetworkEntry `json:"networks"`
+}
+
+// WalletEntry describes a wallet without any of its secrets
+type WalletEntry struct {
+	SourceHash   string    `json:"source_hash"` // Identifies the wallet across installations
+	Address      string    `json:"address"`
+	Name         string    `json:"name"`
+	ImportMethod string    `json:"import_method"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Notes        string    `json:"notes,omitempty"`
+	References   []string  `json:"references,omitempty"`
+	// Keystore is the password-encrypted keystore JSON, present only when keystore sync is enabled
+	Keystore json.RawMessage `json:"keystore,omitempty"`
+}
+
+// NetworkEntry is a configured network
+type NetworkEntry struct {
+	Name          string `json:"name"`
+	RPCEndpoint   string `json:"rpc_endpoint"`
+	ChainID       int64  `json:"chain_id"`
+	Symbol        string `json:"symbol"`
+	Explorer      string `json:"explorer,omitempty"`
+	IsActive      bool   `json:"is_active"`
+	Confirmations int    `json:"confirmations,omitempty"`
+	GenesisHash   string `json:"genesis_hash,omitempty"`
+	Testnet       bool   `json:"testnet,omitempty"`
+}
+
+func networkEntry(n config.Network) NetworkEntry {
+	return NetworkEntry{
+		Name:          n.Name,
+		RPCEndpoint:   n.RPCEndpoint,
+		ChainID:       n.ChainID,
+		Symbol:        n.Symbol,
+		Explorer:      n.Explorer,
+		IsActive:      n.IsActive,
+		Confirmations: n.Confirmations,
+		GenesisHash:   n.GenesisHash,
+		Testnet:       n.Testnet,
+	}
+}
+
+// Network converts the entry back into configuration form
+func (n NetworkEntry) Network() config.Network {
+	return config.Network{
+		Name:          n.Name,
+		RPCEndpoint:   n.RPCEndpoint,
+		ChainID:       n.ChainID,
+		Symbol:        n.Symbol,
+		Explorer:      n.Explorer,
+		IsActive:      n.IsActive,
+		Confirmations: n.Confirmations,
+		GenesisHash:   n.GenesisHash,
+		Testnet:       n.Testnet,
+	}
+}
+
+// Conflict is an item changed differently on both sides since the last sync.
+// Networks keep the local version; wallets keep the most recent rename.
+type Conflict struct {
+	Kind string `json:"kind"` // "wallet" or "network"
+	Key  string `json:"key"`
+}
+
+// annotations joins the notes and references, which are edited together
+func (w WalletEntry) annotations() string {
+	return w.Notes + "\x00" + strings.Join(w.References, "\n")
+}
+
+// pickRemote merges one field of a wallet known on both sides. The remote
+// value wins when only the remote side changed it since base; when both did,
+// the most recent change wins and the conflict is reported.
+func pickRemote(local, remote, base string, inBase, remoteNewer bool) (takeRemote, conflict bool) {
+	switch {
+	case local == remote:
+		return false, false
+	case inBase && base == local:
+		return true, false
+	case inBase && base == remote:
+		return false, false
+	default:
+		return remoteNewer, true
+	}
+}
+
+func (w WalletEntry) changedAt() time.Time {
+	if w.UpdatedAt.IsZero() {
+		return w.CreatedAt
+	}
+	return w.UpdatedAt
+}
+
+// Merge combines the local and remote snapshots using base (the result of the
+// previous sync, possibly empty) to tell which side changed each item.
+//
+// Wallets are merged as a union: a wallet removed on one installation is never
+// removed from another, since that one may hold the only copy of its keystore.
+// A name, or notes, changed on both sides go to the most recent change. Networks follow
+// regular three-way rules, including removals.
+func Merge(base, local, remote Snapshot) (Snapshot, []Conflict) {
+	var conflicts []Conflict
+	merged := Snapshot{Version: SnapshotVersion, Networks: map[string]NetworkEntry{}}
+
+	baseWallets := walletsByKey(base.Wallets)
+	localWallets := walletsByKey(local.Wallets)
+	remoteWallets := walletsByKey(remote.Wallets)
+	for _, key := range unionKeys(localWallets, remoteWallets) {
+		l, inLocal := localWallets[key]
+		r, inRemote := remoteWallets[key]
+		switch {
+		case !inRemote:
+			merged.Wallets = append(merged.Wallets, l)
+			continue
+		case !inLocal:
+			merged.Wallets = append(merged.Wallets, r)
+			continue
+		}
+
+		result := l
+		b, inBase := baseWallets[key]
+		remoteNewer := r.changedAt().After(l.changedAt())
+		takeName, nameConflict := pickRemote(l.Name, r.Name, b.Name, inBase, remoteNewer)
+		takeNotes, notesConflict := pickRemote(l.annotations(), r.annotations(), b.annotations(), inBase, remoteNewer)
+		if takeName {
+			result.Name = r.Name
+		}
+		if takeNotes {
+			result.Notes, result.References = r.Notes, r.References
+		}
+		if (takeName || takeNotes) && r.UpdatedAt.After(result.UpdatedAt) {
+			result.UpdatedAt = r.UpdatedAt
+		}
+		if nameConflict || notesConflict {
+			conflicts = append(conflicts, Conflict{Kind: "wallet", Key: l.Address})
+		}
+		if len(result.Keystore) == 0 {
+			result.Keystore = r.Keystore
+		}
+		merged.Wallets = append(merged.Wallets, result)
+	}
+
+	for _, key := range unionKeys(local.Networks, remote.Networks, base.Networks) {
+		b, inBase := base.Networks[key]
+		l, inLocal := local.Networks[key]
+		r, inRemote := remote.Networks[key]
+
+		var keep bool
+		var result NetworkEntry
+		switch {
+		case inLocal == inRemote && l == r:
+			result, keep = l, inLocal
+		case inLocal == inBase && l == b:
+			result, keep = r, inRemote
+		case inRemote == inBase && r == b:
+			result, keep = l, inLocal
+		default:
+			result, keep = l, inLocal
+			conflicts = append(conflicts, Conflict{Kind: "network", Key: key})
+		}
+		if keep {
+			merged.Networks[key] = result
+		}
+	}
+
+	return merged, conflicts
+}
+
+func walletsByKey(entries []WalletEntry) map[string]WalletEntry {
+	out := make(map[string]WalletEntry, len(entries))
+	for _, e := range entries {
+		out[e.SourceHash] = e
+	}
+	return out
+}
+
+// unionKeys returns the sorted keys present in any of the maps
+func unionKeys[V any](maps ...map[string]V) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, m := range maps {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// withoutKeystores returns a copy of the snapshot that only carries metadata
+func (s Snapshot) withoutKeystores() Snapshot {
+	out := s
+	out.Wallets = make([]WalletEntry, len(s.Wallets))
+	for i, w := range s.Wallets {
+		w.Keystore = nil
+		out.Wallets[i] = w
+	}
+	return out
+}
@@ -0,0 +1,120 @@
+package metasync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func walletEntry(hash, name string, updated time.Time) WalletEntry {
+	return WalletEntry{SourceHash: hash, Address: "0x" + hash, Name: name, UpdatedAt: updated}
+}
+
+func TestMerge_WalletsAreUnited(t *testing.T) {
+	local := Snapshot{Wallets: []WalletEntry{walletEntry("a", "A", time.Time{})}}
+	remote := Snapshot{Wallets: []WalletEntry{walletEntry("b", "B", time.Time{})}}
+
+	merged, conflicts := Merge(Snapshot{}, local, remote)
+
+	assert.Empty(t, conflicts)
+	require.Len(t, merged.Wallets, 2)
+	assert.Equal(t, "A", merged.Wallets[0].Name)
+	assert.Equal(t, "B", merged.Wallets[1].Name)
+}
+
+func TestMerge_WalletRemovedElsewhereIsKept(t *testing.T) {
+	base := Snapshot{Wallets: []WalletEntry{walletEntry("a", "A", time.Time{})}}
+	local := base
+
+	merged, _ := Merge(base, local, Snapshot{})
+
+	require.Len(t, merged.Wallets, 1)
+}
+
+func TestMerge_WalletRenames(t *testing.T) {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	base := Snapshot{Wallets: []WalletEntry{walletEntry("a", "Old", time.Time{})}}
+
+	// Renamed only remotely: the remote name wins even if older
+	merged, conflicts := Merge(base,
+		Snapshot{Wallets: []WalletEntry{walletEntry("a", "Old", t2)}},
+		Snapshot{Wallets: []WalletEntry{walletEntry("a", "Remote", t1)}})
+	assert.Empty(t, conflicts)
+	assert.Equal(t, "Remote", merged.Wallets[0].Name)
+
+	// Renamed only locally
+	merged, conflicts = Merge(base,
+		Snapshot{Wallets: []WalletEntry{walletEntry("a", "Local", t1)}},
+		Snapshot{Wallets: []WalletEntry{walletEntry("a", "Old", t2)}})
+	assert.Empty(t, conflicts)
+	assert.Equal(t, "Local", merged.Wallets[0].Name)
+
+	// Renamed on both sides: the latest rename wins and the conflict is reported
+	merged, conflicts = Merge(base,
+		Snapshot{Wallets: []WalletEntry{walletEntry("a", "Local", t1)}},
+		Snapshot{Wallets: []WalletEntry{walletEntry("a", "Remote", t2)}})
+	assert.Equal(t, "Remote", merged.Wallets[0].Name)
+	assert.Equal(t, []Conflict{{Kind: "wallet", Key: "0xa"}}, conflicts)
+}
+
+func TestMerge_Networks(t *testing.T) {
+	eth := NetworkEntry{Name: "Ethereum", ChainID: 1, RPCEndpoint: "https://eth"}
+	ethNew := NetworkEntry{Name: "Ethereum", ChainID: 1, RPCEndpoint: "https://eth2"}
+	poly := NetworkEntry{Name: "Polygon", ChainID: 137}
+	base := Snapshot{Networks: map[string]NetworkEntry{"eth": eth, "polygon": poly}}
+
+	t.Run("remote edit and local removal", func(t *testing.T) {
+		local := Snapshot{Networks: map[string]NetworkEntry{"eth": eth}}
+		remote := Snapshot{Networks: map[string]NetworkEntry{"eth": ethNew, "polygon": poly}}
+
+		merged, conflicts := Merge(base, local, remote)
+
+		assert.Empty(t, conflicts)
+		assert.Equal(t, map[string]NetworkEntry{"eth": ethNew}, merged.Networks)
+	})
+
+	t.Run("both edited keeps local", func(t *testing.T) {
+		local := Snapshot{Networks: map[string]NetworkEntry{"eth": ethNew, "polygon": poly}}
+		remote := Snapshot{Networks: map[string]NetworkEntry{"eth": {Name: "Mainnet", ChainID: 1}, "polygon": poly}}
+
+		merged, conflicts := Merge(base, local, remote)
+
+		assert.Equal(t, ethNew, merged.Networks["eth"])
+		assert.Equal(t, []Conflict{{Kind: "network", Key: "eth"}}, conflicts)
+	})
+
+	t.Run("first sync unites", func(t *testing.T) {
+		merged, conflicts := Merge(Snapshot{},
+			Snapshot{Networks: map[string]NetworkEntry{"eth": eth}},
+			Snapshot{Networks: map[string]NetworkEntry{"polygon": poly}})
+
+		assert.Empty(t, conflicts)
+		assert.Len(t, merged.Networks, 2)
+	})
+}
+
+func TestMerge_WalletNotes(t *testing.T) {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	base := Snapshot{Wallets: []WalletEntry{walletEntry("a", "A", time.Time{})}}
+
+	// Notes written remotely and a rename done locally are both kept
+	local := walletEntry("a", "Local", t1)
+	remote := walletEntry("a", "A", t2)
+	remote.Notes = "Treasury hot wallet"
+	remote.References = []string{"OPS-42"}
+	merged, conflicts := Merge(base, Snapshot{Wallets: []WalletEntry{local}}, Snapshot{Wallets: []WalletEntry{remote}})
+	assert.Empty(t, conflicts)
+	assert.Equal(t, "Local", merged.Wallets[0].Name)
+	assert.Equal(t, "Treasury hot wallet", merged.Wallets[0].Notes)
+	assert.Equal(t, []string{"OPS-42"}, merged.Wallets[0].References)
+
+	// Notes edited on both sides: the latest edit wins
+	local.Notes = "Mine"
+	merged, conflicts = Merge(base, Snapshot{Wallets: []WalletEntry{local}}, Snapshot{Wallets: []WalletEntry{remote}})
+	assert.Equal(t, "Treasury hot wallet", merged.Wallets[0].Notes)
+	assert.Equal(t, []Conflict{{Kind: "wallet", Key: "0xa"}}, conflicts)
+}
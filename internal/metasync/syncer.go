@@ -0,0 +1,370 @@
+package metasync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"time"
+
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrNoPassphrase is returned when sync is configured without a passphrase
+var ErrNoPassphrase = errors.New("sync passphrase is not set (BLOCO_WALLET_SYNC_PASSPHRASE)")
+
+// ErrInvalidKeystoreEntry is returned for a synced keystore that has no valid
+// address or belongs to another address than its wallet
+var ErrInvalidKeystoreEntry = errors.New("synced keystore does not match its wallet")
+
+// Syncer exchanges the wallet inventory with the configured backend
+type Syncer struct {
+	backend          Backend
+	passphrase       string
+	includeKeystores bool
+	keystoreDir      string // Where synced keystores are written
+	statePath        string // Result of the last sync, used as merge base
+	device           string
+	workFactor       int // scrypt work factor; 0 uses the age default
+}
+
+// Report summarizes a sync run
+type Report struct {
+	SyncedAt          time.Time
+	Wallets           int // Wallets in the merged inventory
+	RemoteOnly        int // Wallets known only from other installations
+	Renamed           int
+	NotesUpdated      int
+	KeystoresImported int
+	Networks          map[string]config.Network // Merged networks
+	NetworksChanged   bool                      // Networks must be saved to the configuration
+	Conflicts         []Conflict
+}
+
+// New builds a syncer from configuration. It returns nil when sync is not configured.
+func New(cfg *config.Config) (*Syncer, error) {
+	if cfg.Sync.Backend == "" {
+		return nil, nil
+	}
+	if cfg.Sync.Passphrase == "" {
+		return nil, ErrNoPassphrase
+	}
+	workDir := filepath.Join(cfg.AppDir, "sync")
+	backend, err := NewBackend(cfg.Sync, workDir)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithBackend(backend, cfg.Sync.Passphrase, cfg.Sync.IncludeKeystores,
+		filepath.Join(cfg.WalletsDir, "keystore"), workDir), nil
+}
+
+// NewWithBackend builds a syncer around an explicit backend
+func NewWithBackend(backend Backend, passphrase string, includeKeystores bool, keystoreDir, workDir string) *Syncer {
+	device, _ := os.Hostname()
+	return &Syncer{
+		backend:          backend,
+		passphrase:       passphrase,
+		includeKeystores: includeKeystores,
+		keystoreDir:      keystoreDir,
+		statePath:        filepath.Join(workDir, "state.json"),
+		device:           device,
+	}
+}
+
+// Describe tells where the inventory is synced to
+func (s *Syncer) Describe() string {
+	return s.backend.Describe()
+}
+
+// IncludesKeystores reports whether keystores are synced too
+func (s *Syncer) IncludesKeystores() bool {
+	return s.includeKeystores
+}
+
+// LastSync returns when this installation last synced, or the zero time
+func (s *Syncer) LastSync() time.Time {
+	base, err := s.loadBase()
+	if err != nil {
+		return time.Time{}
+	}
+	return base.UpdatedAt
+}
+
+// Sync merges the local inventory with the remote one, applies remote changes
+// to the wallet database and uploads the result. Merged networks are returned
+// in the report for the caller to save.
+func (s *Syncer) Sync(ctx context.Context, ws *wallet.WalletService, networks map[string]config.Network) (*Report, error) {
+	wallets, err := ws.GetAllWallets()
+	if err != nil {
+		return nil, err
+	}
+	local, err := s.localSnapshot(wallets, networks)
+	if err != nil {
+		return nil, err
+	}
+
+	remote := Snapshot{}
+	data, err := s.backend.Fetch(ctx)
+	switch {
+	case errors.Is(err, ErrNotFound):
+	case err != nil:
+		return nil, err
+	default:
+		if remote, err = s.decrypt(data); err != nil {
+			return nil, err
+		}
+	}
+
+	base, err := s.loadBase()
+	if err != nil {
+		return nil, err
+	}
+
+	merged, conflicts := Merge(base, local, remote)
+	merged.Device = s.device
+	merged.UpdatedAt = time.Now().UTC()
+
+	report, err := s.apply(ws, wallets, merged)
+	if err != nil {
+		return nil, err
+	}
+	report.Conflicts = conflicts
+	report.SyncedAt = merged.UpdatedAt
+	report.NetworksChanged = !reflect.DeepEqual(local.Networks, merged.Networks)
+
+	encrypted, err := s.encrypt(merged)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.backend.Store(ctx, encrypted); err != nil {
+		return nil, err
+	}
+	if err := s.saveBase(merged.withoutKeystores()); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *Syncer) localSnapshot(wallets []wallet.Wallet, networks map[string]config.Network) (Snapshot, error) {
+	snap := Snapshot{Version: SnapshotVersion, Networks: map[string]NetworkEntry{}}
+	for _, w := range wallets {
+		entry := entryOf(w)
+		if s.includeKeystores && !w.WatchOnly() {
+			data, err := os.ReadFile(w.KeyStorePath)
+			if err != nil {
+				return Snapshot{}, fmt.Errorf("reading keystore of %s: %w", w.Address, err)
+			}
+			entry.Keystore = data
+		}
+		snap.Wallets = append(snap.Wallets, entry)
+	}
+	for key, n := range networks {
+		snap.Networks[key] = networkEntry(n)
+	}
+	return snap, nil
+}
+
+// entryOf describes a stored wallet without its keystore
+func entryOf(w wallet.Wallet) WalletEntry {
+	return WalletEntry{
+		SourceHash:   w.SourceHash,
+		Address:      w.Address,
+		Name:         w.Name,
+		ImportMethod: w.ImportMethod,
+		CreatedAt:    w.CreatedAt.UTC(),
+		UpdatedAt:    w.UpdatedAt.UTC(),
+		Notes:        w.Notes,
+		References:   w.References,
+	}
+}
+
+// apply brings the local wallet database in line with the merged inventory
+func (s *Syncer) apply(ws *wallet.WalletService, wallets []wallet.Wallet, merged Snapshot) (*Report, error) {
+	report := &Report{Wallets: len(merged.Wallets), Networks: map[string]config.Network{}}
+	for key, n := range merged.Networks {
+		report.Networks[key] = n.Network()
+	}
+
+	bySource := make(map[string]*wallet.Wallet, len(wallets))
+	for i := range wallets {
+		bySource[wallets[i].SourceHash] = &wallets[i]
+	}
+
+	for _, entry := range merged.Wallets {
+		if w, ok := bySource[entry.SourceHash]; ok {
+			at := entry.UpdatedAt
+			if at.IsZero() {
+				at = time.Now().UTC()
+			}
+			if w.Name != entry.Name {
+				if err := ws.RenameWallet(w, entry.Name, at); err != nil {
+					return nil, err
+				}
+				report.Renamed++
+			}
+			if w.Notes != entry.Notes || !slices.Equal([]string(w.References), entry.References) {
+				if err := ws.UpdateWalletNotes(w, entry.Notes, entry.References, at); err != nil {
+					return nil, err
+				}
+				report.NotesUpdated++
+			}
+			continue
+		}
+		if !s.includeKeystores || len(entry.Keystore) == 0 {
+			report.RemoteOnly++
+			continue
+		}
+		if err := s.importKeystore(ws, entry); err != nil {
+			return nil, fmt.Errorf("importing keystore of %s: %w", entry.Address, err)
+		}
+		report.KeystoresImported++
+	}
+	return report, nil
+}
+
+// importKeystore adds a wallet synced from another installation. The keystore
+// stays encrypted with its original password. Its file is named after the
+// address inside the keystore, which must be the address of the entry.
+func (s *Syncer) importKeystore(ws *wallet.WalletService, entry WalletEntry) error {
+	if !common.IsHexAddress(entry.Address) {
+		return fmt.Errorf("%w: %q is not an address", ErrInvalidKeystoreEntry, entry.Address)
+	}
+	var keystore struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(entry.Keystore, &keystore); err != nil || !common.IsHexAddress(keystore.Address) {
+		return fmt.Errorf("%w: the keystore has no address", ErrInvalidKeystoreEntry)
+	}
+	address := common.HexToAddress(keystore.Address)
+	if address != common.HexToAddress(entry.Address) {
+		return fmt.Errorf("%w: the keystore belongs to %s", ErrInvalidKeystoreEntry, address.Hex())
+	}
+
+	if err := os.MkdirAll(s.keystoreDir, 0700); err != nil {
+		return err
+	}
+	path := filepath.Join(s.keystoreDir, address.Hex()+".json")
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	for n := 2; errors.Is(err, os.ErrExist); n++ {
+		path = filepath.Join(s.keystoreDir, fmt.Sprintf("%s-%d.json", address.Hex(), n))
+		file, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(entry.Keystore); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	w := &wallet.Wallet{
+		Name:         entry.Name,
+		Address:      entry.Address,
+		KeyStorePath: path,
+		ImportMethod: string(wallet.ImportMethodKeystore),
+		SourceHash:   entry.SourceHash,
+		UpdatedAt:    entry.UpdatedAt,
+		Notes:        entry.Notes,
+		References:   entry.References,
+	}
+	if err := ws.Repo.AddWallet(w); err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+func (s *Syncer) encrypt(snap Snapshot) ([]byte, error) {
+	plaintext, err := json.Marshal(snap)
+	if err != nil {
+		return nil, err
+	}
+	recipient, err := age.NewScryptRecipient(s.passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if s.workFactor > 0 {
+		recipient.SetWorkFactor(s.workFactor)
+	}
+
+	var buf bytes.Buffer
+	armorWriter := armor.NewWriter(&buf)
+	w, err := age.Encrypt(armorWriter, recipient)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	if err := armorWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *Syncer) decrypt(data []byte) (Snapshot, error) {
+	identity, err := age.NewScryptIdentity(s.passphrase)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	r, err := age.Decrypt(armor.NewReader(bytes.NewReader(data)), identity)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("decrypting sync document (wrong passphrase?): %w", err)
+	}
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(plaintext, &snap); err != nil {
+		return Snapshot{}, fmt.Errorf("invalid sync document: %w", err)
+	}
+	if snap.Version > SnapshotVersion {
+		return Snapshot{}, fmt.Errorf("sync document version %d is newer than supported (%d); update this installation", snap.Version, SnapshotVersion)
+	}
+	return snap, nil
+}
+
+func (s *Syncer) loadBase() (Snapshot, error) {
+	data, err := os.ReadFile(s.statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return Snapshot{}, nil
+	}
+	if err != nil {
+		return Snapshot{}, err
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return Snapshot{}, fmt.Errorf("invalid sync state %s: %w", s.statePath, err)
+	}
+	return snap, nil
+}
+
+func (s *Syncer) saveBase(snap Snapshot) error {
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.statePath), 0700); err != nil {
+		return err
+	}
+	return writeFileAtomic(s.statePath, data)
+}
@@ -0,0 +1,210 @@
+package metasync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// installation is one machine sharing the inventory
+type installation struct {
+	ws       *wallet.WalletService
+	syncer   *Syncer
+	ksDir    string
+	networks map[string]config.Network
+}
+
+func newInstallation(t *testing.T, shared string, includeKeystores bool) *installation {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := storage.NewWalletRepository(&config.Config{
+		AppDir:   dir,
+		Database: config.DatabaseConfig{Type: "sqlite", DSN: ":memory:"},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ksDir := filepath.Join(dir, "keystore")
+	s := NewWithBackend(&DirBackend{Path: filepath.Join(shared, DocumentName)}, "correct horse", includeKeystores, ksDir, filepath.Join(dir, "sync"))
+	s.workFactor = 10 // keep scrypt fast in tests
+	return &installation{ws: &wallet.WalletService{Repo: repo}, syncer: s, ksDir: ksDir, networks: map[string]config.Network{}}
+}
+
+func (i *installation) addWallet(t *testing.T, name, hash string) *wallet.Wallet {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(i.ksDir, 0700))
+	path := filepath.Join(i.ksDir, hash+".json")
+	address := strings.Repeat("0", 40-len(hash)) + hash
+	require.NoError(t, os.WriteFile(path, []byte(`{"address":"`+address+`","crypto":{}}`), 0600))
+	w := &wallet.Wallet{Name: name, Address: "0x" + address, KeyStorePath: path, ImportMethod: string(wallet.ImportMethodKeystore), SourceHash: hash}
+	require.NoError(t, i.ws.Repo.AddWallet(w))
+	return w
+}
+
+func (i *installation) sync(t *testing.T) *Report {
+	t.Helper()
+	report, err := i.syncer.Sync(context.Background(), i.ws, i.networks)
+	require.NoError(t, err)
+	if report.NetworksChanged {
+		i.networks = report.Networks
+	}
+	return report
+}
+
+func (i *installation) names(t *testing.T) map[string]string {
+	t.Helper()
+	wallets, err := i.ws.GetAllWallets()
+	require.NoError(t, err)
+	out := map[string]string{}
+	for _, w := range wallets {
+		out[w.SourceHash] = w.Name
+	}
+	return out
+}
+
+func TestSync_MetadataBetweenInstallations(t *testing.T) {
+	shared := t.TempDir()
+	a := newInstallation(t, shared, false)
+	b := newInstallation(t, shared, false)
+
+	wa := a.addWallet(t, "Savings", "aaa")
+	a.networks["eth"] = config.Network{Name: "Ethereum", ChainID: 1, RPCEndpoint: "https://eth"}
+	b.addWallet(t, "Trading", "bbb")
+
+	report := a.sync(t)
+	assert.Equal(t, 0, report.RemoteOnly)
+
+	report = b.sync(t)
+	assert.Equal(t, 2, report.Wallets)
+	assert.Equal(t, 1, report.RemoteOnly, "the keystore of Savings stays on A")
+	assert.True(t, report.NetworksChanged)
+	assert.Contains(t, b.networks, "eth")
+
+	// A rename on A reaches B
+	require.NoError(t, a.ws.RenameWallet(wa, "Cold storage", time.Now()))
+	a.sync(t)
+	b.sync(t)
+	a.sync(t)
+
+	shared2, err := os.ReadFile(filepath.Join(shared, DocumentName))
+	require.NoError(t, err)
+	assert.NotContains(t, string(shared2), "Cold storage", "the document is encrypted")
+	assert.Equal(t, map[string]string{"bbb": "Trading"}, b.names(t))
+	assert.Equal(t, map[string]string{"aaa": "Cold storage"}, a.names(t))
+
+	// B only has its own wallet but knows A's name through the synced document
+	base, err := b.syncer.loadBase()
+	require.NoError(t, err)
+	var names []string
+	for _, w := range base.Wallets {
+		names = append(names, w.Name)
+	}
+	assert.ElementsMatch(t, []string{"Cold storage", "Trading"}, names)
+}
+
+func TestSync_IncludeKeystores(t *testing.T) {
+	shared := t.TempDir()
+	a := newInstallation(t, shared, true)
+	b := newInstallation(t, shared, true)
+	a.addWallet(t, "Savings", "aaa")
+
+	a.sync(t)
+	report := b.sync(t)
+
+	assert.Equal(t, 1, report.KeystoresImported)
+	wallets, err := b.ws.GetAllWallets()
+	require.NoError(t, err)
+	require.Len(t, wallets, 1)
+	assert.Equal(t, "Savings", wallets[0].Name)
+	assert.Equal(t, filepath.Join(b.ksDir, wallet.ChecksumAddress(wallets[0].Address)+".json"), wallets[0].KeyStorePath)
+	data, err := os.ReadFile(wallets[0].KeyStorePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"address":"0000000000000000000000000000000000000aaa"`)
+
+	// Nothing is imported twice
+	report = b.sync(t)
+	assert.Zero(t, report.KeystoresImported)
+
+	// A rename on B reaches A, which holds the same wallet
+	require.NoError(t, b.ws.RenameWallet(&wallets[0], "Cold storage", time.Now().Add(time.Second)))
+	b.sync(t)
+	report = a.sync(t)
+	assert.Equal(t, 1, report.Renamed)
+	assert.Equal(t, map[string]string{"aaa": "Cold storage"}, a.names(t))
+}
+
+func TestImportKeystore_RejectsForeignEntries(t *testing.T) {
+	b := newInstallation(t, t.TempDir(), true)
+	keystore := []byte(`{"address":"0000000000000000000000000000000000000aaa","crypto":{}}`)
+
+	for _, entry := range []WalletEntry{
+		{SourceHash: "path", Address: "../../outside", Keystore: keystore},
+		{SourceHash: "other", Address: "0x0000000000000000000000000000000000000bbb", Keystore: keystore},
+		{SourceHash: "none", Address: "0x0000000000000000000000000000000000000aaa", Keystore: []byte(`{"address":"../x","crypto":{}}`)},
+	} {
+		err := b.syncer.importKeystore(b.ws, entry)
+		assert.ErrorIs(t, err, ErrInvalidKeystoreEntry, entry.SourceHash)
+	}
+	_, err := os.Stat(b.ksDir)
+	assert.True(t, os.IsNotExist(err), "nothing is written")
+	wallets, err := b.ws.GetAllWallets()
+	require.NoError(t, err)
+	assert.Empty(t, wallets)
+
+	// The same address twice gets a second file instead of replacing the first
+	entry := WalletEntry{SourceHash: "a1", Address: "0x0000000000000000000000000000000000000AAA", Keystore: keystore}
+	require.NoError(t, b.syncer.importKeystore(b.ws, entry))
+	entry.SourceHash = "a2"
+	require.NoError(t, b.syncer.importKeystore(b.ws, entry))
+	assert.FileExists(t, filepath.Join(b.ksDir, wallet.ChecksumAddress(entry.Address)+"-2.json"))
+}
+
+func TestSync_WrongPassphrase(t *testing.T) {
+	shared := t.TempDir()
+	a := newInstallation(t, shared, false)
+	b := newInstallation(t, shared, false)
+	b.syncer.passphrase = "something else"
+
+	a.sync(t)
+	_, err := b.syncer.Sync(context.Background(), b.ws, b.networks)
+	assert.ErrorContains(t, err, "wrong passphrase")
+}
+
+func TestNew_Disabled(t *testing.T) {
+	s, err := New(&config.Config{})
+	assert.NoError(t, err)
+	assert.Nil(t, s)
+
+	_, err = New(&config.Config{Sync: config.SyncConfig{Backend: "dir", URL: t.TempDir()}})
+	assert.ErrorIs(t, err, ErrNoPassphrase)
+}
+
+func TestSync_WalletNotes(t *testing.T) {
+	shared := t.TempDir()
+	a := newInstallation(t, shared, false)
+	b := newInstallation(t, shared, false)
+
+	wa := a.addWallet(t, "Payroll", "ccc")
+	b.addWallet(t, "Payroll", "ccc")
+
+	require.NoError(t, a.ws.UpdateWalletNotes(wa, "Monthly salaries", []string{"FIN-7"}, time.Now()))
+	a.sync(t)
+	report := b.sync(t)
+	assert.Equal(t, 1, report.NotesUpdated)
+
+	wallets, err := b.ws.GetAllWallets()
+	require.NoError(t, err)
+	require.Len(t, wallets, 1)
+	assert.Equal(t, "Monthly salaries", wallets[0].Notes)
+	assert.Equal(t, wallet.WalletReferences{"FIN-7"}, wallets[0].References)
+}
@@ -0,0 +1,257 @@
+// Package metrics counts wallet imports, RPC calls, key derivations and
+// balance refreshes, and serves them in the Prometheus text format on a
+// loopback address so long-running processes can be monitored.
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metrics of the application. They are always counted; they are only
+// exposed while the endpoint is served.
+var (
+	Imports          = NewCounterVec("blocowallet_imports_total", "Wallet imports by import method and result.", "method", "result")
+	RPCRequests      = NewCounterVec("blocowallet_rpc_requests_total", "HTTP requests to the JSON-RPC endpoints of the networks by result.", "result")
+	KDFDuration      = NewHistogramVec("blocowallet_kdf_duration_seconds", "Time spent deriving keystore keys by KDF.", []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}, "kdf")
+	BalanceRefreshes = NewCounterVec("blocowallet_balance_refreshes_total", "Balance queries to the networks by result.", "result")
+)
+
+// registry lists the metrics in the order they are written
+var registry = []collector{Imports, RPCRequests, KDFDuration, BalanceRefreshes}
+
+// ErrNotLoopback is returned by Serve for addresses other hosts could reach
+var ErrNotLoopback = errors.New("the metrics endpoint only listens on a loopback address")
+
+type collector interface {
+	write(w io.Writer)
+}
+
+// Result is the result label of an operation that returned err
+func Result(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
+
+// CounterVec is a counter for each combination of label values
+type CounterVec struct {
+	name, help string
+	labels     []string
+
+	mu     sync.Mutex
+	values map[string]float64
+	series map[string][]string
+}
+
+// NewCounterVec returns a counter with the given label names
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{name: name, help: help, labels: labels, values: map[string]float64{}, series: map[string][]string{}}
+}
+
+// Inc adds one to the counter of the label values, given in the order of
+// the label names
+func (c *CounterVec) Inc(values ...string) {
+	key := seriesKey(values)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key]++
+	c.series[key] = values
+}
+
+// Value returns the counter of the label values
+func (c *CounterVec) Value(values ...string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[seriesKey(values)]
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, formatLabels(c.labels, c.series[key]), formatValue(c.values[key]))
+	}
+}
+
+// HistogramVec is a histogram for each combination of label values
+type HistogramVec struct {
+	name, help string
+	labels     []string
+	buckets    []float64 // Upper bounds, ascending
+
+	mu     sync.Mutex
+	data   map[string]*histogram
+	series map[string][]string
+}
+
+type histogram struct {
+	counts []uint64 // Observations at or below each bucket, not cumulated
+	count  uint64
+	sum    float64
+}
+
+// NewHistogramVec returns a histogram with the given bucket upper bounds and
+// label names
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	return &HistogramVec{name: name, help: help, labels: labels, buckets: buckets, data: map[string]*histogram{}, series: map[string][]string{}}
+}
+
+// Observe records a value for the label values
+func (h *HistogramVec) Observe(value float64, values ...string) {
+	key := seriesKey(values)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	data, ok := h.data[key]
+	if !ok {
+		data = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.data[key] = data
+		h.series[key] = values
+	}
+	if i := sort.SearchFloat64s(h.buckets, value); i < len(h.buckets) {
+		data.counts[i]++
+	}
+	data.count++
+	data.sum += value
+}
+
+// ObserveDuration records the time since start in seconds
+func (h *HistogramVec) ObserveDuration(start time.Time, values ...string) {
+	h.Observe(time.Since(start).Seconds(), values...)
+}
+
+// Count returns how many values were observed for the label values
+func (h *HistogramVec) Count(values ...string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if data, ok := h.data[seriesKey(values)]; ok {
+		return data.count
+	}
+	return 0
+}
+
+func (h *HistogramVec) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name)
+	labels := append(append([]string(nil), h.labels...), "le")
+	for _, key := range sortedKeys(h.data) {
+		data := h.data[key]
+		var cumulated uint64
+		for i, bound := range h.buckets {
+			cumulated += data.counts[i]
+			values := append(append([]string(nil), h.series[key]...), formatValue(bound))
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(labels, values), cumulated)
+		}
+		values := append(append([]string(nil), h.series[key]...), "+Inf")
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(labels, values), data.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, formatLabels(h.labels, h.series[key]), formatValue(data.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, formatLabels(h.labels, h.series[key]), data.count)
+	}
+}
+
+// seriesKey identifies a combination of label values
+func seriesKey(values []string) string {
+	return strings.Join(values, "\xff")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatLabels writes {name="value",...}, escaping the values as the text
+// format requires
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+		pairs[i] = name + `="` + escaper.Replace(value) + `"`
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// Write writes every metric in the Prometheus text format
+func Write(w io.Writer) {
+	for _, c := range registry {
+		c.write(w)
+	}
+}
+
+// Handler serves the metrics on GET /metrics
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		Write(w)
+	})
+	return mux
+}
+
+// Serve listens on addr and serves the metrics in the background until the
+// returned server is closed. addr must be a loopback address, such as
+// 127.0.0.1:9479 or localhost:9479.
+func Serve(addr string) (*http.Server, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metrics address %q: %w", addr, err)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return nil, fmt.Errorf("%w: %s", ErrNotLoopback, addr)
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	server := &http.Server{Handler: Handler(), ReadHeaderTimeout: 5 * time.Second}
+	go func() { _ = server.Serve(listener) }()
+	return server, nil
+}
+
+// InstrumentRPC counts the requests sent through next; HTTP errors count as
+// failed requests. A nil next uses http.DefaultTransport.
+func InstrumentRPC(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := next.RoundTrip(req)
+		if err == nil && resp.StatusCode >= http.StatusBadRequest {
+			RPCRequests.Inc("error")
+		} else {
+			RPCRequests.Inc(Result(err))
+		}
+		return resp, err
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
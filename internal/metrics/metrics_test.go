@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounterVec_Write(t *testing.T) {
+	c := NewCounterVec("test_total", "Test counter.", "method", "result")
+	c.Inc("mnemonic", "ok")
+	c.Inc("mnemonic", "ok")
+	c.Inc(`key"store`, Result(errors.New("boom")))
+
+	var b strings.Builder
+	c.write(&b)
+	assert.Equal(t, "# HELP test_total Test counter.\n# TYPE test_total counter\n"+
+		`test_total{method="key\"store",result="error"} 1`+"\n"+
+		`test_total{method="mnemonic",result="ok"} 2`+"\n", b.String())
+	assert.Equal(t, float64(2), c.Value("mnemonic", "ok"))
+}
+
+func TestHistogramVec_Write(t *testing.T) {
+	h := NewHistogramVec("test_seconds", "Test histogram.", []float64{0.1, 1}, "kdf")
+	h.Observe(0.05, "scrypt")
+	h.Observe(0.1, "scrypt")
+	h.Observe(3, "scrypt")
+
+	var b strings.Builder
+	h.write(&b)
+	out := b.String()
+	assert.Contains(t, out, "# TYPE test_seconds histogram\n")
+	assert.Contains(t, out, `test_seconds_bucket{kdf="scrypt",le="0.1"} 2`)
+	assert.Contains(t, out, `test_seconds_bucket{kdf="scrypt",le="1"} 2`)
+	assert.Contains(t, out, `test_seconds_bucket{kdf="scrypt",le="+Inf"} 3`)
+	assert.Contains(t, out, `test_seconds_sum{kdf="scrypt"} 3.15`)
+	assert.Contains(t, out, `test_seconds_count{kdf="scrypt"} 3`)
+	assert.Equal(t, uint64(3), h.Count("scrypt"))
+}
+
+func TestInstrumentRPC(t *testing.T) {
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer rpc.Close()
+
+	ok, failed := RPCRequests.Value("ok"), RPCRequests.Value("error")
+	client := &http.Client{Transport: InstrumentRPC(nil)}
+	for _, path := range []string{"/", "/down"} {
+		resp, err := client.Get(rpc.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.Equal(t, ok+1, RPCRequests.Value("ok"))
+	assert.Equal(t, failed+1, RPCRequests.Value("error"))
+}
+
+func TestServe(t *testing.T) {
+	_, err := Serve("0.0.0.0:0")
+	assert.ErrorIs(t, err, ErrNotLoopback)
+	_, err = Serve("192.168.1.10:9479")
+	assert.ErrorIs(t, err, ErrNotLoopback)
+
+	server, err := Serve("127.0.0.1:0")
+	require.NoError(t, err)
+	defer server.Close()
+
+	BalanceRefreshes.Inc("ok")
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	body, _ := io.ReadAll(rec.Body)
+	assert.Contains(t, string(body), "# TYPE blocowallet_imports_total counter")
+	assert.Contains(t, string(body), `blocowallet_balance_refreshes_total{result="ok"}`)
+
+	rec = httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
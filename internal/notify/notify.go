@@ -0,0 +1,204 @@
+// Package notify posts JSON notifications about finished operations to a
+// webhook, so operators of unattended installations get alerted in tools such
+// as Slack or Discord.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"blocowallet/pkg/config"
+)
+
+// Event identifies what happened
+type Event string
+
+const (
+	EventImportCompleted   Event = "import.completed"
+	EventBackupCompleted   Event = "backup.completed"
+	EventHealthCheckFailed Event = "health_check.failed"
+	EventTxFinal           Event = "transaction.final"
+	EventTxFailed          Event = "transaction.failed"
+)
+
+// Payload formats
+const (
+	FormatAuto    = "auto"
+	FormatJSON    = "json"
+	FormatSlack   = "slack"
+	FormatDiscord = "discord"
+)
+
+// Payload is the body posted in the generic JSON format
+type Payload struct {
+	Event   Event          `json:"event"`
+	Time    time.Time      `json:"time"`
+	Host    string         `json:"host,omitempty"`
+	Summary string         `json:"summary"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// Notifier delivers events to the configured webhook
+type Notifier struct {
+	url     string
+	format  string
+	events  map[Event]bool // nil means every event
+	client  *http.Client
+	retries int
+	backoff time.Duration
+	host    string
+}
+
+// New builds a notifier from configuration. It returns nil when no webhook is configured.
+func New(cfg config.NotificationsConfig) (*Notifier, error) {
+	webhook := strings.TrimSpace(cfg.WebhookURL)
+	if webhook == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(webhook)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid webhook url")
+	}
+
+	format := strings.ToLower(strings.TrimSpace(cfg.Format))
+	switch format {
+	case "", FormatAuto:
+		format = detectFormat(u)
+	case FormatJSON, FormatSlack, FormatDiscord:
+	default:
+		return nil, fmt.Errorf("unknown webhook format %q", cfg.Format)
+	}
+
+	var events map[Event]bool
+	for _, e := range cfg.Events {
+		if events == nil {
+			events = map[Event]bool{}
+		}
+		events[Event(strings.TrimSpace(e))] = true
+	}
+
+	timeout := time.Duration(cfg.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	host, _ := os.Hostname()
+	return &Notifier{
+		url:     webhook,
+		format:  format,
+		events:  events,
+		client:  &http.Client{Timeout: timeout},
+		retries: 3,
+		backoff: 2 * time.Second,
+		host:    host,
+	}, nil
+}
+
+// detectFormat picks the chat format from well-known webhook hosts
+func detectFormat(u *url.URL) string {
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "hooks.slack.com":
+		return FormatSlack
+	case (host == "discord.com" || host == "discordapp.com") && strings.HasPrefix(u.Path, "/api/webhooks/"):
+		return FormatDiscord
+	default:
+		return FormatJSON
+	}
+}
+
+// Wants reports whether the event is delivered. It is false on a nil Notifier.
+func (n *Notifier) Wants(event Event) bool {
+	return n != nil && (n.events == nil || n.events[event])
+}
+
+// Send posts the event, retrying transient failures. Events that are not
+// wanted are silently dropped.
+func (n *Notifier) Send(ctx context.Context, event Event, summary string, details map[string]any) error {
+	if !n.Wants(event) {
+		return nil
+	}
+	body, err := n.body(Payload{Event: event, Time: time.Now().UTC(), Host: n.host, Summary: summary, Details: details})
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < n.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(n.backoff * time.Duration(attempt)):
+			}
+		}
+		retry, err := n.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+	return fmt.Errorf("webhook delivery of %s failed: %w", event, lastErr)
+}
+
+// post sends one request and reports whether a failure is worth retrying
+func (n *Notifier) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "bloco-wallet")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("unexpected status %s", resp.Status)
+	default:
+		return false, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+}
+
+func (n *Notifier) body(p Payload) ([]byte, error) {
+	switch n.format {
+	case FormatSlack:
+		return json.Marshal(map[string]string{"text": chatText(p)})
+	case FormatDiscord:
+		return json.Marshal(map[string]string{"username": "bloco-wallet", "content": chatText(p)})
+	default:
+		return json.Marshal(p)
+	}
+}
+
+// chatText renders the payload as a short message for chat webhooks
+func chatText(p Payload) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[bloco-wallet] %s", p.Summary)
+	if p.Host != "" {
+		fmt.Fprintf(&b, " (%s)", p.Host)
+	}
+	keys := make([]string, 0, len(p.Details))
+	for k := range p.Details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "\n• %s: %v", k, p.Details[k])
+	}
+	return b.String()
+}
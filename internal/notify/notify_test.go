@@ -0,0 +1,131 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"blocowallet/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNotifier(t *testing.T, cfg config.NotificationsConfig) *Notifier {
+	t.Helper()
+	n, err := New(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, n)
+	n.backoff = time.Millisecond
+	return n
+}
+
+func TestNew_Disabled(t *testing.T) {
+	n, err := New(config.NotificationsConfig{})
+	assert.NoError(t, err)
+	assert.Nil(t, n)
+	assert.False(t, n.Wants(EventImportCompleted))
+	assert.NoError(t, n.Send(context.Background(), EventImportCompleted, "ignored", nil))
+}
+
+func TestNew_Invalid(t *testing.T) {
+	_, err := New(config.NotificationsConfig{WebhookURL: "ftp://example.com"})
+	assert.Error(t, err)
+	_, err = New(config.NotificationsConfig{WebhookURL: "https://example.com", Format: "teams"})
+	assert.Error(t, err)
+}
+
+func TestDetectFormat(t *testing.T) {
+	for raw, want := range map[string]string{
+		"https://hooks.slack.com/services/T/B/X":      FormatSlack,
+		"https://discord.com/api/webhooks/1/abc":      FormatDiscord,
+		"https://ops.example.com/hooks/bloco":         FormatJSON,
+		"https://discord.com/channels/not-a-webhook/": FormatJSON,
+	} {
+		u, err := url.Parse(raw)
+		require.NoError(t, err)
+		assert.Equal(t, want, detectFormat(u), raw)
+	}
+}
+
+func TestSend_JSONPayload(t *testing.T) {
+	var got Payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer srv.Close()
+
+	n := newTestNotifier(t, config.NotificationsConfig{WebhookURL: srv.URL})
+	err := n.Send(context.Background(), EventImportCompleted, "Batch import finished", map[string]any{"imported": 3})
+
+	require.NoError(t, err)
+	assert.Equal(t, EventImportCompleted, got.Event)
+	assert.Equal(t, "Batch import finished", got.Summary)
+	assert.EqualValues(t, 3, got.Details["imported"])
+}
+
+func TestSend_ChatFormats(t *testing.T) {
+	var body map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+	}))
+	defer srv.Close()
+
+	n := newTestNotifier(t, config.NotificationsConfig{WebhookURL: srv.URL, Format: "slack"})
+	require.NoError(t, n.Send(context.Background(), EventBackupCompleted, "Backup completed", map[string]any{"kind": "mnemonic"}))
+	assert.Contains(t, body["text"], "Backup completed")
+	assert.Contains(t, body["text"], "kind: mnemonic")
+
+	n = newTestNotifier(t, config.NotificationsConfig{WebhookURL: srv.URL, Format: "discord"})
+	require.NoError(t, n.Send(context.Background(), EventBackupCompleted, "Backup completed", nil))
+	assert.Contains(t, body["content"], "Backup completed")
+}
+
+func TestSend_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	n := newTestNotifier(t, config.NotificationsConfig{WebhookURL: srv.URL})
+	require.NoError(t, n.Send(context.Background(), EventHealthCheckFailed, "check failed", nil))
+	assert.EqualValues(t, 3, calls.Load())
+}
+
+func TestSend_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	n := newTestNotifier(t, config.NotificationsConfig{WebhookURL: srv.URL})
+	assert.Error(t, n.Send(context.Background(), EventHealthCheckFailed, "check failed", nil))
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestSend_FiltersEvents(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	n := newTestNotifier(t, config.NotificationsConfig{WebhookURL: srv.URL, Events: []string{"health_check.failed"}})
+	assert.False(t, n.Wants(EventImportCompleted))
+	require.NoError(t, n.Send(context.Background(), EventImportCompleted, "ignored", nil))
+	require.NoError(t, n.Send(context.Background(), EventHealthCheckFailed, "sent", nil))
+	assert.EqualValues(t, 1, calls.Load())
+}
@@ -0,0 +1,47 @@
+package platform
+
+import (
+	"github.com/atotto/clipboard"
+	"github.com/muesli/termenv"
+)
+
+// ClipboardMethod tells how CopyToClipboard handed the text over
+type ClipboardMethod int
+
+const (
+	// ClipboardSystem used the system clipboard (pbcopy, xclip, wl-copy, Windows API)
+	ClipboardSystem ClipboardMethod = iota
+	// ClipboardTerminal sent an OSC 52 sequence; the terminal decides whether to honour it
+	ClipboardTerminal
+)
+
+// clipboardWrite and clipboardRead allow tests to replace the system clipboard
+var (
+	clipboardWrite = clipboard.WriteAll
+	clipboardRead  = clipboard.ReadAll
+)
+
+// CopyToClipboard puts text on the system clipboard. Without one, as over SSH
+// or on a Linux console without xclip, it falls back to asking the terminal
+// through OSC 52.
+func CopyToClipboard(text string) ClipboardMethod {
+	if err := clipboardWrite(text); err == nil {
+		return ClipboardSystem
+	}
+	termenv.Copy(text)
+	return ClipboardTerminal
+}
+
+// ClearClipboard empties the clipboard that CopyToClipboard filled with text.
+// The system clipboard is only cleared while it still holds text, so whatever
+// the user copied since is kept; the terminal's cannot be read back and is
+// overwritten with an empty string.
+func ClearClipboard(text string, method ClipboardMethod) {
+	if method == ClipboardTerminal {
+		termenv.Copy("")
+		return
+	}
+	if current, err := clipboardRead(); err == nil && current == text {
+		_ = clipboardWrite("")
+	}
+}
@@ -0,0 +1,32 @@
+package platform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// withClipboard replaces the system clipboard with an in-memory one
+func withClipboard(t *testing.T, contents *string) {
+	t.Helper()
+	write, read := clipboardWrite, clipboardRead
+	clipboardWrite = func(text string) error { *contents = text; return nil }
+	clipboardRead = func() (string, error) { return *contents, nil }
+	t.Cleanup(func() { clipboardWrite, clipboardRead = write, read })
+}
+
+func TestClearClipboard(t *testing.T) {
+	var contents string
+	withClipboard(t, &contents)
+
+	assert.Equal(t, ClipboardSystem, CopyToClipboard("0xsecret"))
+	assert.Equal(t, "0xsecret", contents)
+	ClearClipboard("0xsecret", ClipboardSystem)
+	assert.Empty(t, contents)
+
+	// Something copied since is left alone
+	CopyToClipboard("0xsecret")
+	contents = "copied elsewhere"
+	ClearClipboard("0xsecret", ClipboardSystem)
+	assert.Equal(t, "copied elsewhere", contents)
+}
@@ -0,0 +1,68 @@
+// Package platform isolates operating-system specific behavior (console setup,
+// glyph support, path conventions and file permission checks) so the rest of
+// the application can stay portable across Linux, macOS and Windows.
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Capabilities describes what the current terminal can render
+type Capabilities struct {
+	// UnicodeGlyphs is true when symbols such as ✓, ✗ and █ render correctly
+	UnicodeGlyphs bool
+	// Emoji is true when pictographs such as 📁 render with the expected width
+	Emoji bool
+}
+
+// goos allows tests to simulate another operating system
+var goos = runtime.GOOS
+
+// DetectCapabilities inspects the environment to decide which glyphs are safe.
+// The legacy Windows console (conhost) lacks fonts for most symbols, while
+// Windows Terminal, VS Code and ConEmu handle them fine.
+func DetectCapabilities() Capabilities {
+	if os.Getenv("BLOCO_WALLET_ASCII") != "" {
+		return Capabilities{}
+	}
+	if goos != "windows" {
+		return Capabilities{UnicodeGlyphs: true, Emoji: true}
+	}
+
+	modern := os.Getenv("WT_SESSION") != "" ||
+		os.Getenv("TERM_PROGRAM") == "vscode" ||
+		os.Getenv("ConEmuANSI") == "ON" ||
+		strings.Contains(os.Getenv("TERM"), "xterm")
+	return Capabilities{UnicodeGlyphs: modern, Emoji: modern}
+}
+
+// IsWindows reports whether the application runs on Windows
+func IsWindows() bool {
+	return goos == "windows"
+}
+
+// IsExplicitPath reports whether p is absolute or explicitly relative
+// ("./x", ".\x"), as opposed to a bare file name prefix
+func IsExplicitPath(p string) bool {
+	if filepath.IsAbs(p) || strings.HasPrefix(p, "./") || strings.HasPrefix(p, "/") {
+		return true
+	}
+	if goos == "windows" {
+		return strings.HasPrefix(p, `.\`) || strings.HasPrefix(p, `\`) || filepath.VolumeName(p) != ""
+	}
+	return false
+}
+
+// IsRootDir reports whether dir is a filesystem root ("/", "C:\")
+func IsRootDir(dir string) bool {
+	clean := filepath.Clean(dir)
+	return filepath.Dir(clean) == clean && clean != "."
+}
+
+// DirSuffix is appended to directory suggestions so the user can keep typing
+func DirSuffix() string {
+	return string(filepath.Separator)
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package platform
+
+// PrepareConsole is a no-op outside Windows: Unix terminals already handle
+// ANSI sequences and UTF-8.
+func PrepareConsole() (func(), error) {
+	return func() {}, nil
+}
@@ -0,0 +1,49 @@
+package platform
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func withGOOS(t *testing.T, value string) {
+	t.Helper()
+	original := goos
+	goos = value
+	t.Cleanup(func() { goos = original })
+}
+
+func TestDetectCapabilities_ASCIIOverride(t *testing.T) {
+	t.Setenv("BLOCO_WALLET_ASCII", "1")
+	assert.Equal(t, Capabilities{}, DetectCapabilities())
+}
+
+func TestDetectCapabilities_LegacyWindowsConsole(t *testing.T) {
+	withGOOS(t, "windows")
+	t.Setenv("BLOCO_WALLET_ASCII", "")
+	t.Setenv("WT_SESSION", "")
+	t.Setenv("TERM_PROGRAM", "")
+	t.Setenv("ConEmuANSI", "")
+	t.Setenv("TERM", "")
+	assert.False(t, DetectCapabilities().UnicodeGlyphs)
+
+	t.Setenv("WT_SESSION", "b6c1a0e2")
+	assert.True(t, DetectCapabilities().UnicodeGlyphs)
+}
+
+func TestIsExplicitPath(t *testing.T) {
+	assert.True(t, IsExplicitPath("./keystore"))
+	assert.True(t, IsExplicitPath("/tmp/keystore"))
+	assert.False(t, IsExplicitPath("keystore"))
+
+	withGOOS(t, "windows")
+	assert.True(t, IsExplicitPath(`.\keystore`))
+	assert.True(t, IsExplicitPath(`\Users\keystore`))
+}
+
+func TestIsRootDir(t *testing.T) {
+	assert.True(t, IsRootDir(string(filepath.Separator)))
+	assert.False(t, IsRootDir("."))
+	assert.False(t, IsRootDir(t.TempDir()))
+}
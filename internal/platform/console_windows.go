@@ -0,0 +1,37 @@
+//go:build windows
+
+package platform
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// cpUTF8 is the Windows code page identifier for UTF-8
+const cpUTF8 = 65001
+
+// PrepareConsole enables ANSI escape processing and UTF-8 output on the
+// Windows console. The returned function restores the original modes.
+func PrepareConsole() (func(), error) {
+	handle := windows.Handle(os.Stdout.Fd())
+
+	var originalMode uint32
+	if err := windows.GetConsoleMode(handle, &originalMode); err != nil {
+		// Not a console (redirected output, mintty pipes): nothing to configure
+		return func() {}, nil
+	}
+
+	originalCP, _ := windows.GetConsoleOutputCP()
+	if err := windows.SetConsoleMode(handle, originalMode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING); err != nil {
+		return func() {}, err
+	}
+	_ = windows.SetConsoleOutputCP(cpUTF8)
+
+	return func() {
+		_ = windows.SetConsoleMode(handle, originalMode)
+		if originalCP != 0 {
+			_ = windows.SetConsoleOutputCP(originalCP)
+		}
+	}, nil
+}
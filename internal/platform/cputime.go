@@ -0,0 +1,10 @@
+package platform
+
+import "time"
+
+// ProcessCPUTime returns the CPU time, user plus system, used by this process
+// so far. While a key derivation runs it keeps growing; when it stops growing
+// the process is waiting on something rather than computing.
+func ProcessCPUTime() (time.Duration, error) {
+	return processCPUTime()
+}
@@ -0,0 +1,16 @@
+//go:build !windows
+
+package platform
+
+import (
+	"syscall"
+	"time"
+)
+
+func processCPUTime() (time.Duration, error) {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0, err
+	}
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano()), nil
+}
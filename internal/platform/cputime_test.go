@@ -0,0 +1,23 @@
+package platform
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessCPUTime(t *testing.T) {
+	before, err := ProcessCPUTime()
+	require.NoError(t, err)
+
+	sum := sha256.Sum256(nil)
+	for i := 0; i < 200000; i++ {
+		sum = sha256.Sum256(sum[:])
+	}
+
+	after, err := ProcessCPUTime()
+	require.NoError(t, err)
+	assert.Greater(t, after, before)
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package platform
+
+import (
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+func processCPUTime() (time.Duration, error) {
+	var creation, exit, kernel, user windows.Filetime
+	if err := windows.GetProcessTimes(windows.CurrentProcess(), &creation, &exit, &kernel, &user); err != nil {
+		return 0, err
+	}
+	// Filetime durations are counted in 100-nanosecond intervals
+	ticks := func(ft windows.Filetime) int64 { return int64(ft.HighDateTime)<<32 | int64(ft.LowDateTime) }
+	return time.Duration(ticks(kernel)+ticks(user)) * 100, nil
+}
@@ -0,0 +1,8 @@
+package platform
+
+// TotalMemory returns the physical memory of the machine in bytes. It is
+// available on Linux and Windows; elsewhere it returns an error and callers
+// fall back to a fixed budget.
+func TotalMemory() (uint64, error) {
+	return totalMemory()
+}
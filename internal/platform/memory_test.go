@@ -0,0 +1,19 @@
+package platform
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTotalMemory(t *testing.T) {
+	total, err := TotalMemory()
+	if runtime.GOOS != "linux" && runtime.GOOS != "windows" {
+		assert.Error(t, err)
+		return
+	}
+	require.NoError(t, err)
+	assert.Greater(t, total, uint64(64<<20))
+}
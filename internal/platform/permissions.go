@@ -0,0 +1,83 @@
+package platform
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// PermissionIssue describes why a file is accessible to other users
+type PermissionIssue struct {
+	Path   string
+	Reason string
+}
+
+// CheckPrivate reports whether path is readable only by its owner.
+// On Unix this inspects the group/other mode bits; on Windows it inspects the
+// DACL for entries granting access to broad groups (Everyone, Users,
+// Authenticated Users), the equivalent of reviewing `icacls` output.
+func CheckPrivate(path string) (*PermissionIssue, error) {
+	return checkPrivate(path)
+}
+
+// MakePrivate restricts path to its owner: 0600 for files and 0700 for
+// directories on Unix, an owner-only protected DACL on Windows
+func MakePrivate(path string) error {
+	return makePrivate(path)
+}
+
+// EnforceReport summarizes a permission enforcement pass
+type EnforceReport struct {
+	Fixed  []string          // Paths whose permissions were tightened
+	Issues []PermissionIssue // Paths still accessible to other users
+}
+
+// EnforcePrivate checks every path, recursing into directories, tightens the
+// permissions of anything accessible to other users and reports what could
+// not be fixed. Paths that don't exist are skipped.
+func EnforcePrivate(paths ...string) EnforceReport {
+	var report EnforceReport
+	for _, root := range paths {
+		if _, err := os.Lstat(root); err != nil {
+			continue
+		}
+		_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				report.Issues = append(report.Issues, PermissionIssue{Path: path, Reason: err.Error()})
+				return nil
+			}
+			if d.Type()&fs.ModeSymlink != 0 {
+				return nil
+			}
+			enforceOne(path, &report)
+			return nil
+		})
+	}
+	return report
+}
+
+func enforceOne(path string, report *EnforceReport) {
+	issue, err := CheckPrivate(path)
+	if err != nil {
+		report.Issues = append(report.Issues, PermissionIssue{Path: path, Reason: err.Error()})
+		return
+	}
+	if issue == nil {
+		return
+	}
+
+	if err := MakePrivate(path); err != nil {
+		report.Issues = append(report.Issues, PermissionIssue{
+			Path:   path,
+			Reason: fmt.Sprintf("%s (fix failed: %v)", issue.Reason, err),
+		})
+		return
+	}
+	// Re-check: ACL inheritance or filesystem limitations (e.g. FAT, network shares) may keep the file exposed
+	if remaining, err := CheckPrivate(path); err != nil || remaining != nil {
+		report.Issues = append(report.Issues, *issue)
+		return
+	}
+	report.Fixed = append(report.Fixed, path)
+}
@@ -0,0 +1,34 @@
+//go:build !windows
+
+package platform
+
+import (
+	"fmt"
+	"os"
+)
+
+func checkPrivate(path string) (*PermissionIssue, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		return &PermissionIssue{
+			Path:   path,
+			Reason: fmt.Sprintf("mode %04o grants group/other access", perm),
+		}, nil
+	}
+	return nil, nil
+}
+
+func makePrivate(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	mode := os.FileMode(0o600)
+	if info.IsDir() {
+		mode = 0o700
+	}
+	return os.Chmod(path, mode)
+}
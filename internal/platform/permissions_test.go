@@ -0,0 +1,57 @@
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPrivate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("mode bits are not meaningful on Windows")
+	}
+	dir := t.TempDir()
+
+	private := filepath.Join(dir, "private.json")
+	require.NoError(t, os.WriteFile(private, []byte("{}"), 0600))
+	issue, err := CheckPrivate(private)
+	require.NoError(t, err)
+	assert.Nil(t, issue)
+
+	shared := filepath.Join(dir, "shared.json")
+	require.NoError(t, os.WriteFile(shared, []byte("{}"), 0600))
+	require.NoError(t, os.Chmod(shared, 0644))
+	issue, err = CheckPrivate(shared)
+	require.NoError(t, err)
+	require.NotNil(t, issue)
+	assert.Equal(t, shared, issue.Path)
+}
+
+func TestEnforcePrivate_FixesTree(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("mode bits are not meaningful on Windows")
+	}
+	root := t.TempDir()
+	keystoreDir := filepath.Join(root, "keystore")
+	require.NoError(t, os.Mkdir(keystoreDir, 0700))
+	require.NoError(t, os.Chmod(keystoreDir, 0755))
+	keyFile := filepath.Join(keystoreDir, "key.json")
+	require.NoError(t, os.WriteFile(keyFile, []byte("{}"), 0600))
+	require.NoError(t, os.Chmod(keyFile, 0644))
+
+	report := EnforcePrivate(keystoreDir, filepath.Join(root, "missing.db"))
+
+	assert.Empty(t, report.Issues)
+	assert.ElementsMatch(t, []string{keystoreDir, keyFile}, report.Fixed)
+
+	info, err := os.Stat(keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	info, err = os.Stat(keystoreDir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+}
@@ -0,0 +1,83 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// broadSIDs are well-known groups that must not have access to secrets
+var broadSIDs = []windows.WELL_KNOWN_SID_TYPE{
+	windows.WinWorldSid,             // Everyone
+	windows.WinAuthenticatedUserSid, // Authenticated Users
+	windows.WinBuiltinUsersSid,      // BUILTIN\Users
+	windows.WinAnonymousSid,         // ANONYMOUS LOGON
+	windows.WinBuiltinGuestsSid,     // BUILTIN\Guests
+}
+
+func checkPrivate(path string) (*PermissionIssue, error) {
+	sd, err := windows.GetNamedSecurityInfo(path, windows.SE_FILE_OBJECT, windows.DACL_SECURITY_INFORMATION)
+	if err != nil {
+		return nil, err
+	}
+	dacl, _, err := sd.DACL()
+	if err != nil {
+		return nil, err
+	}
+	if dacl == nil {
+		// A NULL DACL grants full access to everyone
+		return &PermissionIssue{Path: path, Reason: "no access control list (everyone has access)"}, nil
+	}
+
+	for i := uint32(0); i < uint32(dacl.AceCount); i++ {
+		var ace *windows.ACCESS_ALLOWED_ACE
+		if err := windows.GetAce(dacl, i, &ace); err != nil {
+			return nil, err
+		}
+		if ace.Header.AceType != windows.ACCESS_ALLOWED_ACE_TYPE {
+			continue
+		}
+		sid := (*windows.SID)(unsafe.Pointer(&ace.SidStart))
+		for _, wk := range broadSIDs {
+			if sid.IsWellKnown(wk) {
+				account, _, _, _ := sid.LookupAccount("")
+				if account == "" {
+					account = sid.String()
+				}
+				return &PermissionIssue{
+					Path:   path,
+					Reason: fmt.Sprintf("access granted to %s", account),
+				}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// makePrivate replaces the DACL with a protected one granting full control to
+// the current user only, the equivalent of `icacls path /inheritance:r /grant:r %USERNAME%:F`
+func makePrivate(path string) error {
+	user, err := windows.GetCurrentProcessToken().GetTokenUser()
+	if err != nil {
+		return err
+	}
+	acl, err := windows.ACLFromEntries([]windows.EXPLICIT_ACCESS{{
+		AccessPermissions: windows.GENERIC_ALL,
+		AccessMode:        windows.GRANT_ACCESS,
+		Inheritance:       windows.SUB_CONTAINERS_AND_OBJECTS_INHERIT,
+		Trustee: windows.TRUSTEE{
+			TrusteeForm:  windows.TRUSTEE_IS_SID,
+			TrusteeType:  windows.TRUSTEE_IS_USER,
+			TrusteeValue: windows.TrusteeValueFromSID(user.User.Sid),
+		},
+	}}, nil)
+	if err != nil {
+		return err
+	}
+	return windows.SetNamedSecurityInfo(path, windows.SE_FILE_OBJECT,
+		windows.DACL_SECURITY_INFORMATION|windows.PROTECTED_DACL_SECURITY_INFORMATION,
+		nil, nil, acl, nil)
+}
@@ -0,0 +1,193 @@
+package qr
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReedSolomon(t *testing.T) {
+	// The "HELLO WORLD" 1-M example of the standard
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	assert.Equal(t, want, reedSolomonRemainder(data, reedSolomonDivisor(10)))
+}
+
+func TestFormatAndVersionBits(t *testing.T) {
+	// Values from the tables of the standard
+	assert.Equal(t, 0b111011111000100, formatBits(Low, 0))
+	assert.Equal(t, 0b101010000010010, formatBits(Medium, 0))
+	assert.Equal(t, 0b110100101110110, formatBits(Low, 7))
+	assert.Equal(t, 0b000111110010010100, versionBits(7))
+	assert.Equal(t, 0b101000110001101001, versionBits(40))
+}
+
+func TestCapacity(t *testing.T) {
+	// Byte mode capacities at level L
+	for version, capacity := range map[int]int{1: 17, 10: 271, 40: 2953} {
+		assert.Equal(t, capacity, (dataCodewords(version, Low)*8-4-countBits(version))/8, "version %d", version)
+	}
+	assert.Equal(t, []int{6, 30, 58, 86, 114, 142, 170}, newCode(40).alignmentPositions())
+	assert.Equal(t, []int{6, 34, 60, 86, 112, 138}, newCode(32).alignmentPositions())
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	for _, text := range []string{
+		"hi",
+		"0x02f86c0180843b9aca00850ba43b7400825208945aeda56215b167893e80b4fe645ba6d5bab767de8806f05b59d3b2000080c001a0",
+		strings.Repeat("bloco-wallet ", 80),
+	} {
+		for _, level := range []Level{Low, Medium} {
+			c, err := Encode(text, level)
+			require.NoError(t, err)
+			assert.Equal(t, c.Version*4+17, c.Size)
+			assert.Equal(t, text, decode(t, c, level))
+		}
+	}
+
+	_, err := Encode(strings.Repeat("x", 3000), Low)
+	assert.ErrorIs(t, err, ErrTooLong)
+}
+
+func TestTerminalAndPNG(t *testing.T) {
+	c, err := Encode("0xabc", Low)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(c.Terminal(), "\n"), "\n")
+	assert.Len(t, lines, (c.Size+2*quietZone+1)/2)
+	assert.Equal(t, c.Width(), len([]rune(lines[0])))
+
+	var buf bytes.Buffer
+	require.NoError(t, c.WritePNG(&buf, 4))
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, (c.Size+8)*4, img.Bounds().Dx())
+}
+
+// decode reads a code back: it checks the format bits, removes the mask,
+// verifies the error correction of every block and returns the text
+func decode(t *testing.T, c *Code, level Level) string {
+	t.Helper()
+
+	// Format bits around the top left finder
+	format := 0
+	bits := []bool{}
+	for i := 0; i <= 5; i++ {
+		bits = append(bits, c.Modules[i][8])
+	}
+	bits = append(bits, c.Modules[7][8], c.Modules[8][8], c.Modules[8][7])
+	for i := 9; i < 15; i++ {
+		bits = append(bits, c.Modules[8][14-i])
+	}
+	for i, bit := range bits {
+		if bit {
+			format |= 1 << i
+		}
+	}
+	mask := -1
+	for m := 0; m < 8; m++ {
+		if formatBits(level, m) == format {
+			mask = m
+		}
+	}
+	require.NotEqual(t, -1, mask, "format bits do not match the level")
+
+	// A fresh symbol of the same version tells which modules hold data
+	layout := newCode(c.Version)
+	layout.drawFunctionPatterns(level)
+	for y := range c.Modules {
+		for x := range c.Modules[y] {
+			if layout.function[y][x] {
+				require.Equal(t, layout.Modules[y][x] || isFormatArea(c, x, y), c.Modules[y][x] || isFormatArea(c, x, y), "function module %d,%d", x, y)
+			}
+		}
+	}
+
+	var raw []byte
+	var cur byte
+	n := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if layout.function[y][x] {
+					continue
+				}
+				bit := c.Modules[y][x] != maskBit(mask, x, y)
+				cur <<= 1
+				if bit {
+					cur |= 1
+				}
+				if n++; n%8 == 0 {
+					raw = append(raw, cur)
+					cur = 0
+				}
+			}
+		}
+	}
+	raw = raw[:rawDataModules(c.Version)/8]
+
+	// Undo the interleaving and check each block's error correction
+	numBlocks := eccBlocks[level][c.Version]
+	eccLen := eccCodewordsPerBlock[level][c.Version]
+	numShort := numBlocks - len(raw)%numBlocks
+	shortLen := len(raw) / numBlocks
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := 0; i < shortLen+1; i++ {
+		for j := 0; j < numBlocks; j++ {
+			// Short blocks have no codeword where long blocks have their last data codeword
+			if i == shortLen-eccLen && j < numShort {
+				continue
+			}
+			blocks[j] = append(blocks[j], raw[k])
+			k++
+		}
+	}
+	var data []byte
+	divisor := reedSolomonDivisor(eccLen)
+	for _, block := range blocks {
+		payload := block[:len(block)-eccLen]
+		require.Equal(t, block[len(block)-eccLen:], reedSolomonRemainder(payload, divisor))
+		data = append(data, payload...)
+	}
+
+	// Byte mode header, then the text
+	require.Equal(t, byte(0x4), data[0]>>4)
+	var stream bitBuffer
+	for _, b := range data {
+		stream.append(int(b), 8)
+	}
+	read := func(pos, length int) int {
+		v := 0
+		for i := 0; i < length; i++ {
+			v <<= 1
+			if stream[pos+i] {
+				v |= 1
+			}
+		}
+		return v
+	}
+	length := read(4, countBits(c.Version))
+	out := make([]byte, length)
+	for i := range out {
+		out[i] = byte(read(4+countBits(c.Version)+8*i, 8))
+	}
+	return string(out)
+}
+
+// isFormatArea reports whether a module belongs to the format information,
+// which differs from the reserved placeholder once the mask is chosen
+func isFormatArea(c *Code, x, y int) bool {
+	return (y == 8 && (x <= 8 || x >= c.Size-8)) || (x == 8 && (y <= 8 || y >= c.Size-8))
+}
@@ -0,0 +1,31 @@
+// Package sandbox restricts what the running process may do once it has
+// started, so that a compromised dependency cannot read other files, run
+// programs or reach arbitrary hosts. Only Linux is supported, through
+// landlock and seccomp.
+package sandbox
+
+import "errors"
+
+// ErrUnsupported is returned where the system offers no sandbox
+var ErrUnsupported = errors.New("process sandboxing is not supported on this system")
+
+// reexecEnv marks the process started again inside the sandbox
+const reexecEnv = "BLOCO_WALLET_SANDBOXED"
+
+// Policy describes what the sandboxed process may still do. System files
+// needed to run (libraries, certificates, time zones, devices) stay readable.
+type Policy struct {
+	Dirs     []string // Read and written, but nothing in them can be run
+	ReadOnly []string // Only read
+	Ports    []int    // TCP ports connections may go to; any when empty
+	Listen   []int    // TCP ports that may be listened on, even offline
+	Offline  bool     // No TCP connection at all
+}
+
+// active is set once the process runs inside the sandbox
+var active bool
+
+// Active reports whether the process runs inside the sandbox
+func Active() bool {
+	return active
+}
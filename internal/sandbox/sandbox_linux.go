@@ -0,0 +1,240 @@
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Landlock rule for a TCP port (ABI 4), not yet in x/sys
+const landlockRuleNetPort = 2
+
+type landlockNetPortAttr struct {
+	allowedAccess uint64
+	port          uint64
+}
+
+// System paths the process keeps reading: configuration, certificates and
+// time zones, kernel information, and the terminal and random devices
+var (
+	systemReadOnly = []string{"/etc", "/usr/share", "/usr/local/share", "/proc", "/sys"}
+	systemDevices  = []string{"/dev"}
+	// Shared libraries, and the dynamic loader that starts the process again
+	systemLibraries = []string{"/lib", "/lib64", "/usr/lib", "/usr/lib64", "/usr/local/lib"}
+)
+
+// Enter moves the process into the sandbox described by p. Landlock only
+// restricts the thread that asks for it, so the restriction is applied on one
+// thread which then runs the executable again with the same arguments: the
+// new process, every thread included, starts inside the sandbox and Enter
+// returns nil there, after adding a seccomp filter that refuses to run
+// programs. Enter does not return when the process is started again.
+func Enter(p Policy) error {
+	if os.Getenv(reexecEnv) != "" {
+		_ = os.Unsetenv(reexecEnv)
+		if err := forbidExec(); err != nil {
+			return fmt.Errorf("failed to install the seccomp filter: %w", err)
+		}
+		active = true
+		return nil
+	}
+
+	abi, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, 0, 0, unix.LANDLOCK_CREATE_RULESET_VERSION)
+	if errno != 0 {
+		return fmt.Errorf("%w: landlock is not available: %v", ErrUnsupported, errno)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	// The thread is restricted for good, so it must never run other goroutines
+	runtime.LockOSThread()
+	if err := restrictThread(int(abi), p, exe); err != nil {
+		return err
+	}
+	if err := os.Setenv(reexecEnv, "1"); err != nil {
+		return err
+	}
+	return syscall.Exec(exe, os.Args, os.Environ())
+}
+
+// fsAccess lists the file system rights known to landlock ABI abi
+func fsAccess(abi int) uint64 {
+	access := uint64(unix.LANDLOCK_ACCESS_FS_MAKE_SYM<<1 - 1)
+	if abi >= 2 {
+		access |= unix.LANDLOCK_ACCESS_FS_REFER
+	}
+	if abi >= 3 {
+		access |= unix.LANDLOCK_ACCESS_FS_TRUNCATE
+	}
+	if abi >= 5 {
+		access |= unix.LANDLOCK_ACCESS_FS_IOCTL_DEV
+	}
+	return access
+}
+
+// restrictThread applies the landlock ruleset of p to the calling thread
+func restrictThread(abi int, p Policy, exe string) error {
+	handled := fsAccess(abi)
+	attr := unix.LandlockRulesetAttr{Access_fs: handled}
+	limitNet := abi >= 4 && (p.Offline || len(p.Ports) > 0)
+	if limitNet {
+		attr.Access_net = unix.LANDLOCK_ACCESS_NET_CONNECT_TCP | unix.LANDLOCK_ACCESS_NET_BIND_TCP
+	}
+	fd, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, uintptr(unsafe.Pointer(&attr)), unsafe.Sizeof(attr), 0)
+	if errno != 0 {
+		return fmt.Errorf("failed to create the landlock ruleset: %w", errno)
+	}
+	ruleset := int(fd)
+	defer unix.Close(ruleset)
+
+	read := uint64(unix.LANDLOCK_ACCESS_FS_READ_FILE | unix.LANDLOCK_ACCESS_FS_READ_DIR)
+	write := handled &^ (unix.LANDLOCK_ACCESS_FS_EXECUTE | unix.LANDLOCK_ACCESS_FS_MAKE_CHAR | unix.LANDLOCK_ACCESS_FS_MAKE_BLOCK)
+	device := read | unix.LANDLOCK_ACCESS_FS_WRITE_FILE | unix.LANDLOCK_ACCESS_FS_IOCTL_DEV
+	run := read | unix.LANDLOCK_ACCESS_FS_EXECUTE
+
+	rules := []struct {
+		paths  []string
+		access uint64
+	}{
+		{systemReadOnly, read},
+		{systemDevices, device},
+		{systemLibraries, run},
+		{[]string{exe}, run},
+		{p.ReadOnly, read},
+		{p.Dirs, write},
+	}
+	for _, rule := range rules {
+		for _, path := range rule.paths {
+			if err := allowPath(ruleset, path, rule.access&handled); err != nil {
+				return fmt.Errorf("failed to allow %s: %w", path, err)
+			}
+		}
+	}
+	var ports []landlockNetPortAttr
+	if limitNet && !p.Offline {
+		for _, port := range p.Ports {
+			ports = append(ports, landlockNetPortAttr{allowedAccess: unix.LANDLOCK_ACCESS_NET_CONNECT_TCP, port: uint64(port)})
+		}
+	}
+	if limitNet {
+		for _, port := range p.Listen {
+			ports = append(ports, landlockNetPortAttr{allowedAccess: unix.LANDLOCK_ACCESS_NET_BIND_TCP, port: uint64(port)})
+		}
+	}
+	for _, attr := range ports {
+		if _, _, errno := unix.Syscall6(unix.SYS_LANDLOCK_ADD_RULE, uintptr(ruleset), landlockRuleNetPort, uintptr(unsafe.Pointer(&attr)), 0, 0, 0); errno != 0 {
+			return fmt.Errorf("failed to allow port %d: %w", attr.port, errno)
+		}
+	}
+
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return fmt.Errorf("failed to set no_new_privs: %w", err)
+	}
+	if _, _, errno := unix.Syscall(unix.SYS_LANDLOCK_RESTRICT_SELF, uintptr(ruleset), 0, 0); errno != 0 {
+		return fmt.Errorf("failed to enter the landlock ruleset: %w", errno)
+	}
+	return nil
+}
+
+// allowPath grants access beneath path; a missing path is skipped, and a file
+// only gets the rights that apply to files
+func allowPath(ruleset int, path string, access uint64) error {
+	if path == "" {
+		return nil
+	}
+	fd, err := unix.Open(path, unix.O_PATH|unix.O_CLOEXEC, 0)
+	if errors.Is(err, unix.ENOENT) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+	var st unix.Stat_t
+	if err := unix.Fstat(fd, &st); err != nil {
+		return err
+	}
+	if st.Mode&unix.S_IFMT != unix.S_IFDIR {
+		access &= unix.LANDLOCK_ACCESS_FS_EXECUTE | unix.LANDLOCK_ACCESS_FS_WRITE_FILE | unix.LANDLOCK_ACCESS_FS_READ_FILE |
+			unix.LANDLOCK_ACCESS_FS_TRUNCATE | unix.LANDLOCK_ACCESS_FS_IOCTL_DEV
+	}
+	attr := unix.LandlockPathBeneathAttr{Allowed_access: access, Parent_fd: int32(fd)}
+	if _, _, errno := unix.Syscall6(unix.SYS_LANDLOCK_ADD_RULE, uintptr(ruleset), unix.LANDLOCK_RULE_PATH_BENEATH, uintptr(unsafe.Pointer(&attr)), 0, 0, 0); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// execFilter is the seccomp program refusing execve and execveat, and every
+// call made through another architecture's interface (such as 32-bit calls
+// on x86-64), with EPERM
+func execFilter() []unix.SockFilter {
+	const (
+		load  = unix.BPF_LD | unix.BPF_W | unix.BPF_ABS
+		jeq   = unix.BPF_JMP | unix.BPF_JEQ | unix.BPF_K
+		jge   = unix.BPF_JMP | unix.BPF_JGE | unix.BPF_K
+		ret   = unix.BPF_RET | unix.BPF_K
+		deny  = unix.SECCOMP_RET_ERRNO | uint32(unix.EPERM)
+		allow = unix.SECCOMP_RET_ALLOW
+	)
+	// Offsets of struct seccomp_data
+	const nrOffset, archOffset = 0, 4
+
+	const archCheck = 1
+	filter := []unix.SockFilter{
+		{Code: load, K: archOffset},
+		{Code: jeq, K: auditArch}, // Another architecture jumps to deny
+		{Code: load, K: nrOffset},
+	}
+	if x32Bit != 0 {
+		filter = append(filter, unix.SockFilter{Code: jge, K: x32Bit})
+	}
+	filter = append(filter,
+		unix.SockFilter{Code: jeq, K: sysExecve},
+		unix.SockFilter{Code: jeq, K: sysExecvat},
+		unix.SockFilter{Code: ret, K: allow},
+		unix.SockFilter{Code: ret, K: deny},
+	)
+	denyAt := len(filter) - 1
+	for i := range filter {
+		if filter[i].Code != jeq && filter[i].Code != jge {
+			continue
+		}
+		skip := uint8(denyAt - i - 1)
+		if i == archCheck {
+			filter[i].Jf = skip
+		} else {
+			filter[i].Jt = skip
+		}
+	}
+	return filter
+}
+
+// forbidExec installs execFilter on every thread of the process
+func forbidExec() error {
+	if auditArch == 0 {
+		return nil
+	}
+	filter := execFilter()
+	prog := unix.SockFprog{Len: uint16(len(filter)), Filter: &filter[0]}
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return err
+	}
+	// With TSYNC a thread that could not be synchronised is returned instead
+	tid, _, errno := unix.Syscall(unix.SYS_SECCOMP, unix.SECCOMP_SET_MODE_FILTER, unix.SECCOMP_FILTER_FLAG_TSYNC, uintptr(unsafe.Pointer(&prog)))
+	runtime.KeepAlive(filter)
+	if errno != 0 {
+		return errno
+	}
+	if tid != 0 {
+		return fmt.Errorf("thread %d could not be filtered", tid)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package sandbox
+
+import (
+	"errors"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+// helperEnv runs TestHelperSandboxed as the process to sandbox
+const helperEnv = "BLOCO_WALLET_SANDBOX_HELPER"
+
+func TestExecFilter(t *testing.T) {
+	filter := execFilter()
+	require.NotEmpty(t, filter)
+	deny := len(filter) - 1
+	assert.Equal(t, uint32(unix.SECCOMP_RET_ERRNO|uint32(unix.EPERM)), filter[deny].K)
+	assert.Equal(t, uint32(unix.SECCOMP_RET_ALLOW), filter[deny-1].K)
+	// Every jump lands on the deny instruction
+	for i, f := range filter {
+		if jump := int(f.Jt) + int(f.Jf); jump > 0 {
+			assert.Equal(t, deny, i+1+jump, i)
+		}
+	}
+}
+
+func TestEnter(t *testing.T) {
+	if _, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, 0, 0, unix.LANDLOCK_CREATE_RULESET_VERSION); errno != 0 {
+		t.Skipf("landlock is not available: %v", errno)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	free, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	listen := strconv.Itoa(free.Addr().(*net.TCPAddr).Port)
+	require.NoError(t, free.Close())
+	dir, outside := t.TempDir(), t.TempDir()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestHelperSandboxed$", "-test.v")
+	cmd.Env = append(os.Environ(), helperEnv+"="+dir, helperEnv+"_OUTSIDE="+outside, helperEnv+"_ADDR="+listener.Addr().String(), helperEnv+"_LISTEN="+listen)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	assert.Contains(t, string(out), "PASS")
+	assert.FileExists(t, filepath.Join(dir, "inside"))
+	assert.NoFileExists(t, filepath.Join(outside, "outside"))
+}
+
+// TestHelperSandboxed is the sandboxed process of TestEnter
+func TestHelperSandboxed(t *testing.T) {
+	dir := os.Getenv(helperEnv)
+	if dir == "" {
+		t.Skip("run by TestEnter")
+	}
+	outside := os.Getenv(helperEnv + "_OUTSIDE")
+	listen, err := strconv.Atoi(os.Getenv(helperEnv + "_LISTEN"))
+	require.NoError(t, err)
+	require.NoError(t, Enter(Policy{Dirs: []string{dir}, Listen: []int{listen}, Offline: true}))
+	require.True(t, Active())
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "inside"), []byte("ok"), 0600))
+	err = os.WriteFile(filepath.Join(outside, "outside"), []byte("no"), 0600)
+	assert.True(t, errors.Is(err, os.ErrPermission), err)
+	_, err = exec.Command("/bin/true").Output()
+	assert.Error(t, err)
+
+	// Offline refuses TCP connections where landlock can limit them (ABI 4)
+	if abi, _, _ := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, 0, 0, unix.LANDLOCK_CREATE_RULESET_VERSION); abi >= 4 {
+		_, err = net.Dial("tcp", os.Getenv(helperEnv+"_ADDR"))
+		assert.ErrorIs(t, err, unix.EACCES)
+
+		// Listen ports stay open, such as the metrics endpoint
+		l, err := net.Listen("tcp", "127.0.0.1:"+strconv.Itoa(listen))
+		require.NoError(t, err)
+		assert.NoError(t, l.Close())
+	}
+}
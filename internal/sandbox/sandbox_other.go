@@ -0,0 +1,8 @@
+//go:build !linux
+
+package sandbox
+
+// Enter is not supported outside Linux
+func Enter(p Policy) error {
+	return ErrUnsupported
+}
@@ -0,0 +1,12 @@
+package sandbox
+
+import "golang.org/x/sys/unix"
+
+// The architecture checked by the seccomp filter, and its calls running programs
+const (
+	auditArch  = unix.AUDIT_ARCH_X86_64
+	sysExecve  = unix.SYS_EXECVE
+	sysExecvat = unix.SYS_EXECVEAT
+	// x32 calls share the architecture with this bit set; they are all refused
+	x32Bit = 0x40000000
+)
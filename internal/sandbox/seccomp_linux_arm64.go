@@ -0,0 +1,11 @@
+package sandbox
+
+import "golang.org/x/sys/unix"
+
+// The architecture checked by the seccomp filter, and its calls running programs
+const (
+	auditArch  = unix.AUDIT_ARCH_AARCH64
+	sysExecve  = unix.SYS_EXECVE
+	sysExecvat = unix.SYS_EXECVEAT
+	x32Bit     = 0
+)
@@ -0,0 +1,12 @@
+//go:build linux && !amd64 && !arm64
+
+package sandbox
+
+// No seccomp filter is built for other architectures; landlock still keeps
+// programs from being run
+const (
+	auditArch  = 0
+	sysExecve  = 0
+	sysExecvat = 0
+	x32Bit     = 0
+)
@@ -0,0 +1,276 @@
+// Package snapshot records the nonce, native balance and token balances of
+// every wallet at one block per network. Reports are signed by a wallet, so
+// audit snapshots taken over time can be checked and compared.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Version is the format version written in new reports
+const Version = 1
+
+// ErrBadSignature is returned by Verify when the report was changed after it
+// was signed, or was not signed by the address it names
+var ErrBadSignature = errors.New("snapshot signature does not match its content")
+
+// Report is the state of every wallet on each network at one block
+type Report struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Networks  []Network `json:"networks"`
+	Signer    string    `json:"signer,omitempty"`
+	// Signature is the EIP-191 signature by Signer of the report as JSON,
+	// with this field empty
+	Signature string `json:"signature,omitempty"`
+}
+
+// Network is the state of the wallets on one network, read at Block
+type Network struct {
+	Key       string    `json:"key"`
+	Name      string    `json:"name"`
+	ChainID   int64     `json:"chain_id"`
+	Symbol    string    `json:"symbol"`
+	Block     uint64    `json:"block,omitempty"`
+	BlockHash string    `json:"block_hash,omitempty"`
+	BlockTime time.Time `json:"block_time,omitzero"`
+	Accounts  []Account `json:"accounts,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Account is what a wallet held at the block of its network
+type Account struct {
+	Address    string  `json:"address"`
+	Wallet     string  `json:"wallet"`
+	Nonce      uint64  `json:"nonce"`
+	BalanceWei string  `json:"balance_wei,omitempty"`
+	Tokens     []Token `json:"tokens,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// Token is the balance of one ERC-20 token, in its smallest unit
+type Token struct {
+	Address  string `json:"address"`
+	Symbol   string `json:"symbol"`
+	Decimals int    `json:"decimals"`
+	Balance  string `json:"balance,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Reader reads accounts pinned to a block; *blockchain.Ethereum implements it
+type Reader interface {
+	HeaderAt(ctx context.Context, number *big.Int) (*types.Header, error)
+	AccountAt(ctx context.Context, account common.Address, tokens []common.Address, number *big.Int) (blockchain.AccountState, error)
+}
+
+// Options selects what Take reads
+type Options struct {
+	Networks map[string]config.Network
+	Wallets  []wallet.Wallet
+	// Tokens lists the tokens to read on each chain ID
+	Tokens map[int64][]blockchain.Token
+	// Block is read on every network; nil reads the latest block of each
+	Block *big.Int
+	// Dial connects to a network; the returned function closes the connection
+	Dial func(network config.Network) (Reader, func(), error)
+	// NetworkFor returns the network as used by one wallet, e.g. with its own
+	// RPC endpoint; nil reads every wallet through the network's endpoint
+	NetworkFor func(address string, network config.Network) config.Network
+	Now        func() time.Time
+}
+
+// DialEthereum connects to network over JSON-RPC
+func DialEthereum(network config.Network) (Reader, func(), error) {
+	client, err := blockchain.NewEthereum(network.RPCEndpoint, blockchain.DefaultTimeout, network.Symbol, 18, network.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, client.Close, nil
+}
+
+// Take reads every wallet on every network of opts. The block of each
+// network is fixed first, so all its accounts are read at the same height.
+// Networks and accounts that cannot be read are reported with their error.
+func Take(ctx context.Context, opts Options) (*Report, error) {
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	keys := make([]string, 0, len(opts.Networks))
+	for key := range opts.Networks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	wallets := append([]wallet.Wallet(nil), opts.Wallets...)
+	sort.Slice(wallets, func(i, j int) bool {
+		return strings.ToLower(wallets[i].Address) < strings.ToLower(wallets[j].Address)
+	})
+
+	report := &Report{Version: Version, CreatedAt: now().UTC()}
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		report.Networks = append(report.Networks, takeNetwork(ctx, opts, key, wallets))
+	}
+	return report, nil
+}
+
+// takeNetwork reads the wallets on the network named key
+func takeNetwork(ctx context.Context, opts Options, key string, wallets []wallet.Wallet) Network {
+	network := opts.Networks[key]
+	result := Network{Key: key, Name: network.Name, ChainID: network.ChainID, Symbol: network.Symbol}
+
+	// Wallets with their own endpoint share one connection per endpoint
+	readers := make(map[string]Reader)
+	var closers []func()
+	defer func() {
+		for _, closeReader := range closers {
+			closeReader()
+		}
+	}()
+	reader := func(n config.Network) (Reader, error) {
+		if r, ok := readers[n.RPCEndpoint]; ok {
+			return r, nil
+		}
+		r, closeReader, err := opts.Dial(n)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to %s: %w", n.Name, err)
+		}
+		readers[n.RPCEndpoint] = r
+		closers = append(closers, closeReader)
+		return r, nil
+	}
+
+	main, err := reader(network)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	header, err := main.HeaderAt(ctx, opts.Block)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Block = header.Number.Uint64()
+	result.BlockHash = header.Hash().Hex()
+	result.BlockTime = time.Unix(int64(header.Time), 0).UTC()
+
+	tokens := opts.Tokens[network.ChainID]
+	contracts := make([]common.Address, len(tokens))
+	for i, t := range tokens {
+		contracts[i] = common.HexToAddress(t.Address)
+	}
+	for _, w := range wallets {
+		account := Account{Address: common.HexToAddress(w.Address).Hex(), Wallet: w.Name}
+		r := main
+		if opts.NetworkFor != nil {
+			if r, err = reader(opts.NetworkFor(w.Address, network)); err != nil {
+				account.Error = err.Error()
+				result.Accounts = append(result.Accounts, account)
+				continue
+			}
+		}
+		state, err := r.AccountAt(ctx, common.HexToAddress(w.Address), contracts, header.Number)
+		if err != nil {
+			account.Error = err.Error()
+			result.Accounts = append(result.Accounts, account)
+			continue
+		}
+		account.Nonce = state.Nonce
+		account.BalanceWei = state.Balance.String()
+		for i, t := range tokens {
+			token := Token{Address: contracts[i].Hex(), Symbol: t.Symbol, Decimals: t.Decimals}
+			if state.TokenErrors[i] != nil {
+				token.Error = state.TokenErrors[i].Error()
+			} else {
+				token.Balance = state.Tokens[i].String()
+			}
+			account.Tokens = append(account.Tokens, token)
+		}
+		result.Accounts = append(result.Accounts, account)
+	}
+	return result
+}
+
+// Failed counts the networks and accounts that could not be read
+func (r *Report) Failed() int {
+	failed := 0
+	for _, n := range r.Networks {
+		if n.Error != "" {
+			failed++
+		}
+		for _, a := range n.Accounts {
+			if a.Error != "" {
+				failed++
+			}
+		}
+	}
+	return failed
+}
+
+// payload is the content covered by the signature
+func (r *Report) payload() ([]byte, error) {
+	unsigned := *r
+	unsigned.Signature = ""
+	return json.Marshal(unsigned)
+}
+
+// Sign signs the report with signer, replacing any previous signature
+func (r *Report) Sign(signer wallet.Signer) error {
+	r.Signer = signer.Address().Hex()
+	payload, err := r.payload()
+	if err != nil {
+		return err
+	}
+	sig, err := signer.SignText(payload)
+	if err != nil {
+		return fmt.Errorf("signing the snapshot: %w", err)
+	}
+	r.Signature = hexutil.Encode(sig)
+	return nil
+}
+
+// Verify parses a report and checks it was signed by the address it names
+func Verify(data []byte) (*Report, error) {
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %w", err)
+	}
+	if report.Signature == "" || !common.IsHexAddress(report.Signer) {
+		return &report, fmt.Errorf("%w: the snapshot is not signed", ErrBadSignature)
+	}
+	sig, err := hexutil.Decode(report.Signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return &report, fmt.Errorf("%w: malformed signature", ErrBadSignature)
+	}
+	payload, err := report.payload()
+	if err != nil {
+		return &report, err
+	}
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	public, err := crypto.SigToPub(accounts.TextHash(payload), sig)
+	if err != nil || crypto.PubkeyToAddress(*public) != common.HexToAddress(report.Signer) {
+		return &report, ErrBadSignature
+	}
+	return &report, nil
+}
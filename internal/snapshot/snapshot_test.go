@@ -0,0 +1,144 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const usdc = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+
+// fakeReader serves balances at a head block and records what was asked
+type fakeReader struct {
+	endpoint string
+	head     uint64
+	asked    []*big.Int
+}
+
+func (f *fakeReader) HeaderAt(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		number = new(big.Int).SetUint64(f.head)
+	}
+	return &types.Header{Number: number, Time: 1700000000}, nil
+}
+
+func (f *fakeReader) AccountAt(ctx context.Context, account common.Address, tokens []common.Address, number *big.Int) (blockchain.AccountState, error) {
+	f.asked = append(f.asked, number)
+	if account == common.HexToAddress("0xBBB") {
+		return blockchain.AccountState{}, errors.New("missing trie node")
+	}
+	state := blockchain.AccountState{Nonce: 3, Balance: big.NewInt(1e18), Tokens: make([]*big.Int, len(tokens)), TokenErrors: make([]error, len(tokens))}
+	for i := range tokens {
+		state.Tokens[i] = big.NewInt(5000000)
+	}
+	return state, nil
+}
+
+func TestTake(t *testing.T) {
+	readers := map[string]*fakeReader{}
+	closed := 0
+	opts := Options{
+		Networks: map[string]config.Network{
+			"mainnet": {Name: "Ethereum", ChainID: 1, Symbol: "ETH", RPCEndpoint: "https://eth.example"},
+			"down":    {Name: "Down", ChainID: 10, Symbol: "ETH", RPCEndpoint: "https://down.example"},
+		},
+		Wallets: []wallet.Wallet{{Name: "Payroll", Address: "0xBBB"}, {Name: "Treasury", Address: "0xaaa"}},
+		Tokens:  map[int64][]blockchain.Token{1: {{ChainID: 1, Address: usdc, Symbol: "USDC", Decimals: 6}}},
+		Dial: func(n config.Network) (Reader, func(), error) {
+			if n.Name == "Down" {
+				return nil, nil, errors.New("connection refused")
+			}
+			r := &fakeReader{endpoint: n.RPCEndpoint, head: 19000000}
+			readers[n.RPCEndpoint] = r
+			return r, func() { closed++ }, nil
+		},
+		NetworkFor: func(address string, n config.Network) config.Network {
+			if address == "0xaaa" {
+				n.RPCEndpoint = "https://private.example"
+			}
+			return n
+		},
+		Now: func() time.Time { return time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC) },
+	}
+
+	report, err := Take(context.Background(), opts)
+	require.NoError(t, err)
+	require.Len(t, report.Networks, 2)
+
+	down := report.Networks[0]
+	assert.Equal(t, "down", down.Key)
+	assert.Contains(t, down.Error, "connection refused")
+
+	mainnet := report.Networks[1]
+	assert.Equal(t, uint64(19000000), mainnet.Block)
+	require.Len(t, mainnet.Accounts, 2)
+	treasury := mainnet.Accounts[0]
+	assert.Equal(t, "Treasury", treasury.Wallet)
+	assert.Equal(t, common.HexToAddress("0xaaa").Hex(), treasury.Address)
+	assert.Equal(t, uint64(3), treasury.Nonce)
+	assert.Equal(t, "1000000000000000000", treasury.BalanceWei)
+	assert.Equal(t, []Token{{Address: usdc, Symbol: "USDC", Decimals: 6, Balance: "5000000"}}, treasury.Tokens)
+	assert.Contains(t, mainnet.Accounts[1].Error, "missing trie node")
+	assert.Equal(t, 2, report.Failed())
+
+	// The wallet with its own endpoint was read there, at the block fixed on the network
+	require.Contains(t, readers, "https://private.example")
+	assert.Equal(t, []*big.Int{big.NewInt(19000000)}, readers["https://private.example"].asked)
+	assert.Equal(t, 2, closed)
+
+	// An explicit block is read instead of the latest one
+	opts.Block = big.NewInt(18000000)
+	delete(opts.Networks, "down")
+	report, err = Take(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(18000000), report.Networks[0].Block)
+}
+
+func TestSignAndVerify(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	report := &Report{
+		Version:   Version,
+		CreatedAt: time.Date(2026, 10, 15, 12, 0, 0, 123, time.UTC),
+		Networks: []Network{{Key: "mainnet", Name: "Ethereum", ChainID: 1, Block: 19000000, BlockTime: time.Unix(1700000000, 0).UTC(),
+			Accounts: []Account{{Address: "0xAAA", Wallet: "Treasury", Nonce: 3, BalanceWei: "1000"}}}},
+	}
+	require.NoError(t, report.Sign(wallet.NewKeySigner(key)))
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey).Hex(), report.Signer)
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	require.NoError(t, err)
+	verified, err := Verify(data)
+	require.NoError(t, err, "indentation does not change what was signed")
+	assert.Equal(t, report, verified)
+
+	tampered := strings.Replace(string(data), `"balance_wei": "1000"`, `"balance_wei": "9000"`, 1)
+	require.NotEqual(t, string(data), tampered)
+	_, err = Verify([]byte(tampered))
+	assert.ErrorIs(t, err, ErrBadSignature)
+
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	forged := strings.Replace(string(data), report.Signer, crypto.PubkeyToAddress(other.PublicKey).Hex(), 1)
+	_, err = Verify([]byte(forged))
+	assert.ErrorIs(t, err, ErrBadSignature)
+
+	report.Signature = ""
+	unsigned, _ := json.Marshal(report)
+	_, err = Verify(unsigned)
+	assert.ErrorIs(t, err, ErrBadSignature)
+}
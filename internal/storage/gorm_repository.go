@@ -1,11 +1,17 @@
 package storage
 
 import (
+	"blocowallet/internal/blockchain"
 	"blocowallet/internal/wallet"
 	"blocowallet/pkg/config"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
@@ -13,43 +19,134 @@ import (
 
 // GORMRepository implementa a interface WalletRepository usando GORM
 type GORMRepository struct {
-	db *gorm.DB
+	db          *gorm.DB
+	dbType      string // DatabaseSQLite, DatabasePostgres ou DatabaseMySQL
+	journalMode string // journal_mode configurado, restaurado após o PRAGMA rekey
+	// key é a chave do SQLCipher; nil quando o banco não é criptografado
+	key atomic.Pointer[DatabaseKey]
+	// fields abre e sela os campos protegidos pela senha mestra; nil enquanto
+	// ela não foi digitada ou quando não há senha mestra
+	fields atomic.Pointer[wallet.FieldCipher]
 }
 
 // Garantimos que GORMRepository implementa a interface WalletRepository
 var _ wallet.WalletRepository = &GORMRepository{}
+var _ wallet.BackupDatabase = &GORMRepository{}
+var _ wallet.KeystorePathUpdater = &GORMRepository{}
 
-// NewWalletRepository cria uma nova instância de GORMRepository com base na configuração
+// NewWalletRepository cria uma nova instância de GORMRepository com base na
+// configuração: o arquivo do SQLite ou, com database.type postgres ou mysql,
+// o servidor do DSN. As migrações pendentes são aplicadas na abertura.
 func NewWalletRepository(cfg *config.Config) (*GORMRepository, error) {
-	// Usar apenas SQLite para testes e desenvolvimento
-	dbPath := cfg.DatabasePath
-	if cfg.Database.DSN != "" {
-		dbPath = cfg.Database.DSN
-	}
+	return NewEncryptedWalletRepository(cfg, nil)
+}
 
-	// Garantir que o diretório existe
-	dir := filepath.Dir(dbPath)
-	if err := ensureDir(dir); err != nil {
-		return nil, fmt.Errorf("falha ao criar diretório para o banco de dados: %w", err)
+// NewEncryptedWalletRepository cria o repositório como NewWalletRepository,
+// abrindo com key o arquivo do SQLite quando database.encryption está ativo
+func NewEncryptedWalletRepository(cfg *config.Config, key *DatabaseKey) (*GORMRepository, error) {
+	repo, err := OpenWalletRepository(cfg, key)
+	if err != nil {
+		return nil, err
 	}
+	if err := repo.MigrateTo(LatestSchemaVersion()); err != nil {
+		_ = repo.Close()
+		return nil, err
+	}
+	return repo, nil
+}
 
-	// Usar o driver SQLite apropriado para o ambiente
-	dialector := createSQLiteDialector(dbPath)
+// OpenWalletRepository abre o banco de dados sem aplicar as migrações, para
+// consultá-las ou desfazê-las com MigrateTo
+func OpenWalletRepository(cfg *config.Config, key *DatabaseKey) (*GORMRepository, error) {
+	dbType, err := DatabaseType(cfg.Database)
+	if err != nil {
+		return nil, err
+	}
+	repo := &GORMRepository{dbType: dbType, journalMode: cfg.Database.JournalMode}
+	var dialector gorm.Dialector
+	switch {
+	case dbType != DatabaseSQLite:
+		if cfg.Database.Encryption != "" {
+			return nil, fmt.Errorf("database.encryption só se aplica ao SQLite, não ao banco de dados %s", dbType)
+		}
+		dialector, err = serverDialector(dbType, cfg.Database.DSN)
+	case cfg.Database.Encryption == "":
+		dialector, err = sqliteDialector(cfg)
+	case cfg.Database.Encryption != config.DatabaseEncryptionMasterPassword && cfg.Database.Encryption != config.DatabaseEncryptionKeyFile:
+		return nil, fmt.Errorf("database.encryption inválido %q (use %s ou %s)", cfg.Database.Encryption, config.DatabaseEncryptionMasterPassword, config.DatabaseEncryptionKeyFile)
+	case key == nil:
+		return nil, ErrDatabaseKeyRequired
+	default:
+		repo.key.Store(key)
+		dialector, err = encryptedSQLiteDialector(cfg, &repo.key)
+	}
+	if err != nil {
+		return nil, err
+	}
 
 	db, err := gorm.Open(dialector, &gorm.Config{
 		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
 	})
 	if err != nil {
+		if errors.Is(err, ErrDatabaseKey) {
+			return nil, ErrDatabaseKey
+		}
 		return nil, fmt.Errorf("falha ao conectar ao banco de dados: %w", err)
 	}
 
-	// Auto Migrate cria a tabela se não existir
-	err = db.AutoMigrate(&wallet.Wallet{})
+	repo.db = db
+	return repo, nil
+}
+
+// ReadWallets lê as carteiras de outro banco de dados, por exemplo de um backup
+// ou de outra máquina, sem alterá-lo: o arquivo é aberto somente para leitura e
+// não é migrado. Colunas que ainda não existiam na versão que o criou ficam vazias.
+func ReadWallets(dbPath string) ([]wallet.Wallet, error) {
+	db, closeDB, err := openReadOnly(dbPath)
 	if err != nil {
-		return nil, fmt.Errorf("falha ao migrar tabela de carteiras: %w", err)
+		return nil, err
+	}
+	defer closeDB()
+
+	if !db.Migrator().HasTable(&wallet.Wallet{}) {
+		return nil, fmt.Errorf("%s não é um banco de dados de carteiras", dbPath)
+	}
+	columns, err := db.Migrator().ColumnTypes(&wallet.Wallet{})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(columns))
+	for _, c := range columns {
+		names = append(names, c.Name())
 	}
+	var wallets []wallet.Wallet
+	if err := db.Select(names).Find(&wallets).Error; err != nil {
+		return nil, err
+	}
+	return wallets, nil
+}
 
-	return &GORMRepository{db: db}, nil
+// models são as structs das tabelas criadas pelo baseline das migrações
+func models() []any {
+	return []any{&wallet.Wallet{}, &wallet.ChildAllocation{}, &wallet.TrackedTransaction{}, &wallet.RPCOverride{}, &blockchain.Token{}, &wallet.ImportFileState{}, &wallet.MasterPassword{}}
+}
+
+// openReadOnly abre um banco de dados existente somente para leitura
+func openReadOnly(dbPath string) (*gorm.DB, func(), error) {
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, nil, err
+	}
+	db, err := gorm.Open(createSQLiteDialector("file:"+filepath.ToSlash(dbPath)+"?mode=ro"), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("falha ao abrir o banco de dados %s: %w", dbPath, err)
+	}
+	return db, func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	}, nil
 }
 
 // ensureDir garante que o diretório existe
@@ -57,21 +154,56 @@ func ensureDir(dir string) error {
 	return os.MkdirAll(dir, os.ModePerm)
 }
 
-// AddWallet adiciona uma nova carteira ao banco de dados
-func (repo *GORMRepository) AddWallet(wallet *wallet.Wallet) error {
-	return repo.db.Create(wallet).Error
+// AddWallet adiciona uma nova carteira ao banco de dados, com os campos
+// protegidos selados pela senha mestra
+func (repo *GORMRepository) AddWallet(w *wallet.Wallet) error {
+	if w.Version == 0 {
+		w.Version = 1
+	}
+	fields, err := repo.writeCipher()
+	if err != nil {
+		return err
+	}
+	mnemonic, notes, references := w.Mnemonic, w.Notes, w.References
+	if err := sealWallet(fields, w); err != nil {
+		return err
+	}
+	err = repo.db.Create(w).Error
+	// O chamador continua com os valores em claro
+	w.Mnemonic, w.Notes, w.References = mnemonic, notes, references
+	return err
 }
 
 // GetAllWallets retorna todas as carteiras salvas
 func (repo *GORMRepository) GetAllWallets() ([]wallet.Wallet, error) {
 	var wallets []wallet.Wallet
-	result := repo.db.Find(&wallets)
-	return wallets, result.Error
+	if err := repo.db.Find(&wallets).Error; err != nil {
+		return nil, err
+	}
+	return wallets, repo.openWallets(wallets)
 }
 
-// DeleteWallet remove uma carteira pelo ID
-func (repo *GORMRepository) DeleteWallet(walletID int) error {
-	return repo.db.Delete(&wallet.Wallet{}, walletID).Error
+// DeleteWallet remove uma carteira pelo ID, desde que ela ainda esteja na
+// versão carregada pelo chamador. Uma carteira já removida não é um erro.
+func (repo *GORMRepository) DeleteWallet(walletID, version int) error {
+	result := repo.db.Where("id = ? AND version = ?", walletID, version).Delete(&wallet.Wallet{})
+	if result.Error != nil || result.RowsAffected > 0 {
+		return result.Error
+	}
+	exists, err := repo.walletExists(walletID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return wallet.ErrStaleWallet
+	}
+	return nil
+}
+
+func (repo *GORMRepository) walletExists(walletID int) (bool, error) {
+	var count int64
+	err := repo.db.Model(&wallet.Wallet{}).Where("id = ?", walletID).Count(&count).Error
+	return count > 0, err
 }
 
 // FindBySourceHash finds a wallet by its source hash
@@ -84,21 +216,361 @@ func (repo *GORMRepository) FindBySourceHash(sourceHash string) (*wallet.Wallet,
 		}
 		return nil, result.Error
 	}
+	if err := openWallet(repo.fields.Load(), &w); err != nil {
+		return nil, err
+	}
 	return &w, nil
 }
 
 // FindByAddress returns all wallets that match the given address (may be multiple)
 func (repo *GORMRepository) FindByAddress(address string) ([]wallet.Wallet, error) {
 	var wallets []wallet.Wallet
-	result := repo.db.Where("address = ?", address).Find(&wallets)
-	return wallets, result.Error
+	if err := repo.db.Where("address = ?", address).Find(&wallets).Error; err != nil {
+		return nil, err
+	}
+	return wallets, repo.openWallets(wallets)
 }
 
 // FindByAddressAndMethod returns wallets filtered by address and import method
 func (repo *GORMRepository) FindByAddressAndMethod(address, importMethod string) ([]wallet.Wallet, error) {
 	var wallets []wallet.Wallet
-	result := repo.db.Where("address = ? AND import_method = ?", address, importMethod).Find(&wallets)
-	return wallets, result.Error
+	if err := repo.db.Where("address = ? AND import_method = ?", address, importMethod).Find(&wallets).Error; err != nil {
+		return nil, err
+	}
+	return wallets, repo.openWallets(wallets)
+}
+
+// UpdateWalletName renomeia uma carteira preservando o horário informado da
+// alteração. Falha com ErrStaleWallet se outra sessão alterou ou removeu a
+// carteira depois que ela foi carregada.
+func (repo *GORMRepository) UpdateWalletName(walletID, version int, name string, updatedAt time.Time) error {
+	result := repo.db.Model(&wallet.Wallet{}).Where("id = ? AND version = ?", walletID, version).
+		UpdateColumns(map[string]interface{}{
+			"name":       name,
+			"updated_at": updatedAt,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return wallet.ErrStaleWallet
+	}
+	return nil
+}
+
+// UpdateWalletNotes substitui as notas e referências externas de uma carteira,
+// com a mesma verificação de versão de UpdateWalletName
+func (repo *GORMRepository) UpdateWalletNotes(walletID, version int, notes string, references wallet.WalletReferences, updatedAt time.Time) error {
+	fields, err := repo.writeCipher()
+	if err != nil {
+		return err
+	}
+	sealed := wallet.Wallet{Notes: notes, References: references}
+	if err := sealWallet(fields, &sealed); err != nil {
+		return err
+	}
+	notes, references = sealed.Notes, sealed.References
+	result := repo.db.Model(&wallet.Wallet{}).Where("id = ? AND version = ?", walletID, version).
+		UpdateColumns(map[string]interface{}{
+			"notes":               notes,
+			"external_references": references,
+			"updated_at":          updatedAt,
+			"version":             gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return wallet.ErrStaleWallet
+	}
+	return nil
+}
+
+// UpdateKeystorePath aponta a carteira para outro arquivo keystore, com a
+// mesma verificação de versão de UpdateWalletName
+func (repo *GORMRepository) UpdateKeystorePath(walletID, version int, path string) error {
+	result := repo.db.Model(&wallet.Wallet{}).Where("id = ? AND version = ?", walletID, version).
+		UpdateColumns(map[string]interface{}{
+			"key_store_path": path,
+			"version":        gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return wallet.ErrStaleWallet
+	}
+	return nil
+}
+
+// AddChildAllocation reserva um índice de derivação de uma mnemônica mestre.
+// Falha com ErrChildIndexAllocated se o índice já foi entregue.
+func (repo *GORMRepository) AddChildAllocation(allocation *wallet.ChildAllocation) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&wallet.ChildAllocation{}).
+			Where("master_source_hash = ? AND derivation_index = ?", allocation.MasterSourceHash, allocation.Index).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return wallet.ErrChildIndexAllocated
+		}
+		return tx.Create(allocation).Error
+	})
+}
+
+// ChildAllocations lista os índices entregues a partir de uma mnemônica mestre
+func (repo *GORMRepository) ChildAllocations(masterSourceHash string) ([]wallet.ChildAllocation, error) {
+	var allocations []wallet.ChildAllocation
+	result := repo.db.Where("master_source_hash = ?", masterSourceHash).Order("derivation_index").Find(&allocations)
+	return allocations, result.Error
+}
+
+// AddTrackedTransaction passa a acompanhar uma transação transmitida
+func (repo *GORMRepository) AddTrackedTransaction(tx *wallet.TrackedTransaction) error {
+	return repo.db.Create(tx).Error
+}
+
+// UpdateTrackedTransaction grava o estado atual de uma transação acompanhada
+func (repo *GORMRepository) UpdateTrackedTransaction(tx *wallet.TrackedTransaction) error {
+	return repo.db.Save(tx).Error
+}
+
+// TrackedTransactions lista as transações acompanhadas, das mais recentes para
+// as mais antigas. Com openOnly, apenas as pendentes ou aguardando confirmações.
+func (repo *GORMRepository) TrackedTransactions(openOnly bool) ([]wallet.TrackedTransaction, error) {
+	var txs []wallet.TrackedTransaction
+	query := repo.db.Order("submitted_at DESC, id DESC")
+	if openOnly {
+		query = query.Where("state IN ?", []string{string(wallet.TrackedPending), string(wallet.TrackedIncluded)})
+	}
+	result := query.Find(&txs)
+	return txs, result.Error
+}
+
+// SetRPCOverride grava o endpoint RPC de uma carteira em uma rede,
+// substituindo o anterior
+func (repo *GORMRepository) SetRPCOverride(override *wallet.RPCOverride) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var existing wallet.RPCOverride
+		err := tx.Where("address = ? AND chain_id = ?", override.Address, override.ChainID).First(&existing).Error
+		switch {
+		case err == nil:
+			override.ID = existing.ID
+			return tx.Save(override).Error
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return tx.Create(override).Error
+		default:
+			return err
+		}
+	})
+}
+
+// DeleteRPCOverride remove o endpoint RPC de uma carteira em uma rede
+func (repo *GORMRepository) DeleteRPCOverride(address string, chainID int64) error {
+	return repo.db.Where("address = ? AND chain_id = ?", address, chainID).Delete(&wallet.RPCOverride{}).Error
+}
+
+// RPCOverrides lista os endpoints RPC próprios de uma carteira, por chain ID
+func (repo *GORMRepository) RPCOverrides(address string) ([]wallet.RPCOverride, error) {
+	var overrides []wallet.RPCOverride
+	result := repo.db.Where("address = ?", address).Order("chain_id").Find(&overrides)
+	return overrides, result.Error
+}
+
+// AddToken passa a acompanhar um token ERC-20 numa rede; se ele já é
+// acompanhado, atualiza o símbolo e as casas decimais, mantendo a revisão
+func (repo *GORMRepository) AddToken(token *blockchain.Token) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var existing blockchain.Token
+		err := tx.Where("chain_id = ? AND address = ?", token.ChainID, token.Address).First(&existing).Error
+		switch {
+		case err == nil:
+			token.ID, token.CreatedAt, token.Status = existing.ID, existing.CreatedAt, existing.Status
+			return tx.Save(token).Error
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return tx.Create(token).Error
+		default:
+			return err
+		}
+	})
+}
+
+// DeleteToken deixa de acompanhar um token numa rede
+func (repo *GORMRepository) DeleteToken(chainID int64, address string) error {
+	return repo.db.Where("chain_id = ? AND address = ?", chainID, address).Delete(&blockchain.Token{}).Error
+}
+
+// SetTokenStatus grava a revisão de um token acompanhado numa rede
+func (repo *GORMRepository) SetTokenStatus(chainID int64, address, status string) error {
+	result := repo.db.Model(&blockchain.Token{}).Where("chain_id = ? AND address = ?", chainID, address).Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Tokens lista os tokens acompanhados numa rede, ou em todas quando chainID é 0
+func (repo *GORMRepository) Tokens(chainID int64) ([]blockchain.Token, error) {
+	var tokens []blockchain.Token
+	query := repo.db.Order("chain_id, symbol")
+	if chainID != 0 {
+		query = query.Where("chain_id = ?", chainID)
+	}
+	result := query.Find(&tokens)
+	return tokens, result.Error
+}
+
+// StartImportBatch grava os arquivos de uma importação em lote que começa,
+// substituindo o estado da importação anterior
+func (repo *GORMRepository) StartImportBatch(files []wallet.ImportFileState) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&wallet.ImportFileState{}).Error; err != nil {
+			return err
+		}
+		if len(files) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(files, 200).Error
+	})
+}
+
+// UpdateImportFile grava o resultado de um arquivo da importação em lote.
+// Não faz nada se outra importação já substituiu a do arquivo.
+func (repo *GORMRepository) UpdateImportFile(batchID string, position int, status wallet.ImportFileStatus, address, message string) error {
+	return repo.db.Model(&wallet.ImportFileState{}).
+		Where("batch_id = ? AND position = ?", batchID, position).
+		Updates(map[string]any{"status": status, "address": address, "error": message, "updated_at": time.Now()}).Error
+}
+
+// ImportBatchFiles lista os arquivos da última importação em lote, na ordem
+// em que foram importados
+func (repo *GORMRepository) ImportBatchFiles() ([]wallet.ImportFileState, error) {
+	var files []wallet.ImportFileState
+	result := repo.db.Order("position").Find(&files)
+	return files, result.Error
+}
+
+// ClearImportBatch esquece a última importação em lote
+func (repo *GORMRepository) ClearImportBatch() error {
+	return repo.db.Where("1 = 1").Delete(&wallet.ImportFileState{}).Error
+}
+
+// BackupTo grava uma cópia consistente do banco de dados em path (VACUUM INTO)
+func (repo *GORMRepository) BackupTo(path string) error {
+	if repo.dbType != DatabaseSQLite {
+		return fmt.Errorf("backup do banco de dados %s: %w", repo.dbType, ErrSQLiteOnly)
+	}
+	return repo.db.Exec("VACUUM INTO ?", path).Error
+}
+
+// RestoreFrom substitui o conteúdo do banco de dados pelo do arquivo em path,
+// por exemplo de um backup. Cada tabela é copiada com as colunas presentes nos
+// dois bancos, numa única transação; keystorePath ajusta o caminho das
+// keystores das carteiras restauradas.
+func (repo *GORMRepository) RestoreFrom(path string, keystorePath func(stored string) string) error {
+	if repo.dbType != DatabaseSQLite {
+		return fmt.Errorf("restauração do banco de dados %s: %w", repo.dbType, ErrSQLiteOnly)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	// ATTACH vale apenas para a conexão em que foi executado
+	return repo.db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("ATTACH DATABASE ? AS restored", path).Error; err != nil {
+			return fmt.Errorf("falha ao abrir o banco de dados %s: %w", path, err)
+		}
+		defer conn.Exec("DETACH DATABASE restored")
+
+		var tables []string
+		if err := conn.Raw("SELECT name FROM restored.sqlite_master WHERE type = 'table'").Scan(&tables).Error; err != nil {
+			return err
+		}
+		if !slices.Contains(tables, wallet.Wallet{}.TableName()) {
+			return fmt.Errorf("%s não é um banco de dados de carteiras", path)
+		}
+
+		return conn.Transaction(func(tx *gorm.DB) error {
+			for _, model := range models() {
+				stmt := &gorm.Statement{DB: tx}
+				if err := stmt.Parse(model); err != nil {
+					return err
+				}
+				if err := restoreTable(tx, stmt.Schema.Table); err != nil {
+					return fmt.Errorf("falha ao restaurar a tabela %s: %w", stmt.Schema.Table, err)
+				}
+			}
+
+			var rows []wallet.Wallet
+			if err := tx.Select("id", "key_store_path").Find(&rows).Error; err != nil {
+				return err
+			}
+			for _, row := range rows {
+				if restored := keystorePath(row.KeyStorePath); restored != row.KeyStorePath {
+					if err := tx.Model(&wallet.Wallet{}).Where("id = ?", row.ID).UpdateColumn("key_store_path", restored).Error; err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// restoreTable troca as linhas de table pelas do banco anexado como restored;
+// colunas que não existiam na versão que criou o backup ficam com o padrão
+func restoreTable(tx *gorm.DB, table string) error {
+	var current, backup []string
+	if err := tx.Raw("SELECT name FROM pragma_table_info(?, 'main')", table).Scan(&current).Error; err != nil {
+		return err
+	}
+	if err := tx.Raw("SELECT name FROM pragma_table_info(?, 'restored')", table).Scan(&backup).Error; err != nil {
+		return err
+	}
+	var columns []string
+	for _, c := range current {
+		if slices.Contains(backup, c) {
+			columns = append(columns, `"`+c+`"`)
+		}
+	}
+
+	if err := tx.Exec(`DELETE FROM main."` + table + `"`).Error; err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+	list := strings.Join(columns, ", ")
+	return tx.Exec(`INSERT INTO main."` + table + `" (` + list + `) SELECT ` + list + ` FROM restored."` + table + `"`).Error
+}
+
+// IntegrityCheck executa o PRAGMA integrity_check do SQLite. Nos servidores a
+// integridade é responsabilidade deles, e só a conexão é verificada.
+func (repo *GORMRepository) IntegrityCheck() error {
+	if repo.dbType != DatabaseSQLite {
+		sqlDB, err := repo.db.DB()
+		if err != nil {
+			return err
+		}
+		if err := sqlDB.Ping(); err != nil {
+			return fmt.Errorf("banco de dados %s inacessível: %w", repo.dbType, err)
+		}
+		return nil
+	}
+	var rows []string
+	if err := repo.db.Raw("PRAGMA integrity_check").Scan(&rows).Error; err != nil {
+		return err
+	}
+	if len(rows) == 1 && rows[0] == "ok" {
+		return nil
+	}
+	return fmt.Errorf("integridade do banco de dados comprometida: %s", strings.Join(rows, "; "))
 }
 
 // Close fecha a conexão com o banco de dados
@@ -1,10 +1,13 @@
 package storage
 
 import (
+	"blocowallet/internal/blockchain"
 	"blocowallet/internal/wallet"
 	"blocowallet/pkg/config"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -157,7 +160,7 @@ func TestGORMRepository_DeleteWallet(t *testing.T) {
 	require.NotZero(t, testWallet.ID)
 
 	// Deletando a carteira
-	err = repo.DeleteWallet(testWallet.ID)
+	err = repo.DeleteWallet(testWallet.ID, testWallet.Version)
 	assert.NoError(t, err)
 
 	// Verificando se a carteira foi removida
@@ -166,6 +169,195 @@ func TestGORMRepository_DeleteWallet(t *testing.T) {
 	assert.Empty(t, wallets)
 }
 
+func TestGORMRepository_UpdateWalletName(t *testing.T) {
+	cfg := setupTestConfig(t)
+
+	repo, err := NewWalletRepository(cfg)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	testWallet := &wallet.Wallet{
+		Name:         "Old",
+		Address:      "0x123456",
+		KeyStorePath: "/path/to/keystore",
+		ImportMethod: string(wallet.ImportMethodPrivateKey),
+		SourceHash:   "rename-hash",
+	}
+	require.NoError(t, repo.AddWallet(testWallet))
+
+	// O horário informado é mantido (não é substituído pelo autoUpdateTime)
+	renamedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.UpdateWalletName(testWallet.ID, testWallet.Version, "New", renamedAt))
+
+	found, err := repo.FindBySourceHash("rename-hash")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "New", found.Name)
+	assert.True(t, renamedAt.Equal(found.UpdatedAt), "got %v", found.UpdatedAt)
+	assert.Equal(t, testWallet.Version+1, found.Version)
+}
+
+func TestGORMRepository_UpdateKeystorePath(t *testing.T) {
+	repo, err := NewWalletRepository(setupTestConfig(t))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	testWallet := &wallet.Wallet{
+		Name:         "Moved",
+		Address:      "0x654321",
+		KeyStorePath: "/old/keystore",
+		ImportMethod: string(wallet.ImportMethodKeystore),
+		SourceHash:   "relink-hash",
+	}
+	require.NoError(t, repo.AddWallet(testWallet))
+	require.NoError(t, repo.UpdateKeystorePath(testWallet.ID, testWallet.Version, "/new/keystore"))
+
+	found, err := repo.FindBySourceHash("relink-hash")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "/new/keystore", found.KeyStorePath)
+	assert.Equal(t, testWallet.Version+1, found.Version)
+
+	// Uma versão antiga é recusada
+	assert.ErrorIs(t, repo.UpdateKeystorePath(testWallet.ID, testWallet.Version, "/other"), wallet.ErrStaleWallet)
+}
+
+func TestGORMRepository_OptimisticLocking(t *testing.T) {
+	cfg := setupTestConfig(t)
+
+	repo, err := NewWalletRepository(cfg)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	testWallet := &wallet.Wallet{
+		Name:         "Shared",
+		Address:      "0x123456",
+		KeyStorePath: "/path/to/keystore",
+		ImportMethod: string(wallet.ImportMethodPrivateKey),
+		SourceHash:   "locking-hash",
+	}
+	require.NoError(t, repo.AddWallet(testWallet))
+	assert.Equal(t, 1, testWallet.Version)
+
+	// Outra sessão renomeia a carteira primeiro
+	require.NoError(t, repo.UpdateWalletName(testWallet.ID, 1, "Theirs", time.Now()))
+
+	// As alterações baseadas na versão antiga são recusadas
+	assert.ErrorIs(t, repo.UpdateWalletName(testWallet.ID, 1, "Mine", time.Now()), wallet.ErrStaleWallet)
+	assert.ErrorIs(t, repo.DeleteWallet(testWallet.ID, 1), wallet.ErrStaleWallet)
+
+	found, err := repo.FindBySourceHash("locking-hash")
+	require.NoError(t, err)
+	assert.Equal(t, "Theirs", found.Name)
+
+	// Com a versão atual a exclusão funciona, e repeti-la não é um erro
+	require.NoError(t, repo.DeleteWallet(testWallet.ID, found.Version))
+	assert.NoError(t, repo.DeleteWallet(testWallet.ID, found.Version))
+	assert.ErrorIs(t, repo.UpdateWalletName(testWallet.ID, found.Version, "Gone", time.Now()), wallet.ErrStaleWallet)
+}
+
+func TestGORMRepository_BackupAndIntegrityCheck(t *testing.T) {
+	cfg := setupTestConfig(t)
+	cfg.Database.DSN = ""
+
+	repo, err := NewWalletRepository(cfg)
+	require.NoError(t, err)
+	defer repo.Close()
+	require.NoError(t, repo.AddWallet(&wallet.Wallet{
+		Name: "Backup", Address: "0x1", KeyStorePath: "/k", ImportMethod: "keystore", SourceHash: "backup-hash",
+	}))
+
+	require.NoError(t, repo.IntegrityCheck())
+
+	backupPath := filepath.Join(t.TempDir(), "copy.db")
+	require.NoError(t, repo.BackupTo(backupPath))
+
+	copyRepo, err := NewWalletRepository(&config.Config{DatabasePath: backupPath})
+	require.NoError(t, err)
+	defer copyRepo.Close()
+	wallets, err := copyRepo.GetAllWallets()
+	require.NoError(t, err)
+	require.Len(t, wallets, 1)
+	assert.Equal(t, "Backup", wallets[0].Name)
+}
+
+func TestGORMRepository_RestoreFrom(t *testing.T) {
+	source := setupTestConfig(t)
+	source.Database.DSN = ""
+	old, err := NewWalletRepository(source)
+	require.NoError(t, err)
+	require.NoError(t, old.AddWallet(&wallet.Wallet{
+		Name: "Laptop", Address: "0x1", KeyStorePath: `C:\Users\me\keystore\UTC--1`, ImportMethod: "keystore", SourceHash: "laptop-hash", Notes: "old",
+	}))
+	require.NoError(t, old.AddToken(&blockchain.Token{ChainID: 1, Address: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Symbol: "USDC", Decimals: 6}))
+	// Um backup de uma versão anterior, sem as colunas de notas
+	require.NoError(t, old.db.Migrator().DropColumn(&wallet.Wallet{}, "Notes"))
+	backupPath := filepath.Join(t.TempDir(), "backup.db")
+	require.NoError(t, old.BackupTo(backupPath))
+	require.NoError(t, old.Close())
+
+	target := setupTestConfig(t)
+	target.Database.DSN = ""
+	repo, err := NewWalletRepository(target)
+	require.NoError(t, err)
+	defer repo.Close()
+	require.NoError(t, repo.AddWallet(&wallet.Wallet{
+		Name: "Replaced", Address: "0x2", KeyStorePath: "/k", ImportMethod: "keystore", SourceHash: "replaced-hash",
+	}))
+
+	require.NoError(t, repo.RestoreFrom(backupPath, func(stored string) string {
+		return "/home/me/keystore/UTC--1"
+	}))
+	wallets, err := repo.GetAllWallets()
+	require.NoError(t, err)
+	require.Len(t, wallets, 1)
+	assert.Equal(t, "Laptop", wallets[0].Name)
+	assert.Equal(t, "/home/me/keystore/UTC--1", wallets[0].KeyStorePath)
+	assert.Empty(t, wallets[0].Notes)
+	tokens, err := repo.Tokens(1)
+	require.NoError(t, err)
+	assert.Len(t, tokens, 1)
+
+	// Um arquivo que não é um banco de carteiras não altera nada
+	notWallets := filepath.Join(t.TempDir(), "other.db")
+	other, err := NewWalletRepository(&config.Config{DatabasePath: notWallets})
+	require.NoError(t, err)
+	require.NoError(t, other.db.Migrator().DropTable(&wallet.Wallet{}))
+	require.NoError(t, other.Close())
+	assert.Error(t, repo.RestoreFrom(notWallets, func(stored string) string { return stored }))
+	wallets, err = repo.GetAllWallets()
+	require.NoError(t, err)
+	assert.Len(t, wallets, 1)
+}
+
+func TestReadWallets_ReadOnlyAndOldSchema(t *testing.T) {
+	cfg := setupTestConfig(t)
+	cfg.Database.DSN = ""
+	repo, err := NewWalletRepository(cfg)
+	require.NoError(t, err)
+	require.NoError(t, repo.AddWallet(&wallet.Wallet{
+		Name: "Laptop", Address: "0x1", KeyStorePath: "/k", ImportMethod: "keystore", SourceHash: "laptop-hash", Notes: "old",
+	}))
+	// Um banco de uma versão anterior, sem as colunas de notas
+	require.NoError(t, repo.db.Migrator().DropColumn(&wallet.Wallet{}, "Notes"))
+	require.NoError(t, repo.Close())
+	before, err := os.ReadFile(cfg.DatabasePath)
+	require.NoError(t, err)
+
+	wallets, err := ReadWallets(cfg.DatabasePath)
+	require.NoError(t, err)
+	require.Len(t, wallets, 1)
+	assert.Equal(t, "Laptop", wallets[0].Name)
+	assert.Empty(t, wallets[0].Notes)
+
+	after, err := os.ReadFile(cfg.DatabasePath)
+	require.NoError(t, err)
+	assert.Equal(t, before, after, "the database is not migrated")
+
+	_, err = ReadWallets(filepath.Join(t.TempDir(), "missing.db"))
+	assert.Error(t, err)
+}
+
 // Teste para verificar o comportamento com diferentes configurações SQLite
 func TestGORMRepository_SQLiteConfigurations(t *testing.T) {
 	testCases := []struct {
@@ -222,6 +414,52 @@ func TestGORMRepository_SQLiteConfigurations(t *testing.T) {
 	}
 }
 
+func TestGORMRepository_SQLitePragmas(t *testing.T) {
+	tempDir := t.TempDir()
+	pragmas := func(t *testing.T, database config.DatabaseConfig) (string, int, int) {
+		cfg := &config.Config{AppDir: tempDir, DatabasePath: filepath.Join(tempDir, t.Name()+".db"), Database: database}
+		repo, err := NewWalletRepository(cfg)
+		require.NoError(t, err)
+		defer repo.Close()
+
+		var journalMode string
+		var busyTimeout, synchronous int
+		require.NoError(t, repo.db.Raw("PRAGMA journal_mode").Scan(&journalMode).Error)
+		require.NoError(t, repo.db.Raw("PRAGMA busy_timeout").Scan(&busyTimeout).Error)
+		require.NoError(t, repo.db.Raw("PRAGMA synchronous").Scan(&synchronous).Error)
+		return journalMode, busyTimeout, synchronous
+	}
+
+	t.Run("padrões", func(t *testing.T) {
+		journalMode, busyTimeout, synchronous := pragmas(t, config.DatabaseConfig{Type: "sqlite"})
+		assert.Equal(t, "wal", journalMode)
+		assert.Equal(t, DefaultBusyTimeoutMS, busyTimeout)
+		assert.Equal(t, 1, synchronous) // NORMAL
+	})
+
+	t.Run("configurados", func(t *testing.T) {
+		journalMode, busyTimeout, synchronous := pragmas(t, config.DatabaseConfig{
+			Type: "sqlite", JournalMode: "delete", BusyTimeoutMS: 250, Synchronous: "FULL",
+		})
+		assert.Equal(t, "delete", journalMode)
+		assert.Equal(t, 250, busyTimeout)
+		assert.Equal(t, 2, synchronous) // FULL
+	})
+
+	t.Run("inválidos", func(t *testing.T) {
+		cfg := &config.Config{AppDir: tempDir, DatabasePath: filepath.Join(tempDir, "invalid.db"),
+			Database: config.DatabaseConfig{Type: "sqlite", JournalMode: "FAST"}}
+		_, err := NewWalletRepository(cfg)
+		assert.ErrorContains(t, err, "journal_mode")
+	})
+}
+
+func TestSQLiteDSN_KeepsUserParameters(t *testing.T) {
+	dsn, err := sqliteDSN("file:wallets.db?_busy_timeout=100", config.DatabaseConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, "file:wallets.db?_busy_timeout=100&_journal_mode=WAL&_synchronous=NORMAL", dsn)
+}
+
 func TestGORMRepository_FindBySourceHash_And_AddressQueries(t *testing.T) {
 	cfg := setupTestConfig(t)
 
@@ -269,3 +507,229 @@ func TestGORMRepository_FindBySourceHash_And_AddressQueries(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, listPriv, 1)
 }
+
+func TestGORMRepository_UpdateWalletNotes(t *testing.T) {
+	cfg := setupTestConfig(t)
+
+	repo, err := NewWalletRepository(cfg)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	testWallet := &wallet.Wallet{
+		Name:         "Annotated",
+		Address:      "0x123456",
+		KeyStorePath: "/path/to/keystore",
+		ImportMethod: string(wallet.ImportMethodPrivateKey),
+		SourceHash:   "notes-hash",
+	}
+	require.NoError(t, repo.AddWallet(testWallet))
+
+	refs := wallet.WalletReferences{"OPS-1", "https://wiki/treasury"}
+	require.NoError(t, repo.UpdateWalletNotes(testWallet.ID, testWallet.Version, "Cold storage", refs, time.Now()))
+
+	found, err := repo.FindBySourceHash("notes-hash")
+	require.NoError(t, err)
+	assert.Equal(t, "Cold storage", found.Notes)
+	assert.Equal(t, refs, found.References)
+	assert.Equal(t, testWallet.Version+1, found.Version)
+
+	// Notas baseadas na versão antiga são recusadas
+	assert.ErrorIs(t, repo.UpdateWalletNotes(testWallet.ID, testWallet.Version, "Stale", nil, time.Now()), wallet.ErrStaleWallet)
+}
+
+func TestGORMRepository_ChildAllocations(t *testing.T) {
+	cfg := setupTestConfig(t)
+
+	repo, err := NewWalletRepository(cfg)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	require.NoError(t, repo.AddChildAllocation(&wallet.ChildAllocation{MasterSourceHash: "master", Index: 2, Label: "bob", Address: "0x2"}))
+	require.NoError(t, repo.AddChildAllocation(&wallet.ChildAllocation{MasterSourceHash: "master", Index: 1, Label: "alice", Address: "0x1"}))
+	require.NoError(t, repo.AddChildAllocation(&wallet.ChildAllocation{MasterSourceHash: "other", Index: 1, Label: "carol", Address: "0x3"}))
+
+	// Um índice entregue não pode ser reservado de novo
+	err = repo.AddChildAllocation(&wallet.ChildAllocation{MasterSourceHash: "master", Index: 1, Label: "mallory", Address: "0x1"})
+	assert.ErrorIs(t, err, wallet.ErrChildIndexAllocated)
+
+	allocations, err := repo.ChildAllocations("master")
+	require.NoError(t, err)
+	require.Len(t, allocations, 2)
+	assert.Equal(t, "alice", allocations[0].Label)
+	assert.Equal(t, uint32(2), allocations[1].Index)
+}
+
+func TestGORMRepository_TrackedTransactions(t *testing.T) {
+	cfg := setupTestConfig(t)
+
+	repo, err := NewWalletRepository(cfg)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	first := &wallet.TrackedTransaction{Hash: "0x1", ChainID: 1, Network: "Ethereum", From: "0xa", RawTx: "0x", State: string(wallet.TrackedPending), SubmittedAt: base}
+	second := &wallet.TrackedTransaction{Hash: "0x2", ChainID: 1, Network: "Ethereum", From: "0xa", Nonce: 1, RawTx: "0x", State: string(wallet.TrackedPending), SubmittedAt: base.Add(time.Minute)}
+	require.NoError(t, repo.AddTrackedTransaction(first))
+	require.NoError(t, repo.AddTrackedTransaction(second))
+	assert.Error(t, repo.AddTrackedTransaction(&wallet.TrackedTransaction{Hash: "0x1", From: "0xa", RawTx: "0x", State: "pending", SubmittedAt: base}), "o hash é único")
+
+	first.State = string(wallet.TrackedFinal)
+	first.Confirmations = 12
+	require.NoError(t, repo.UpdateTrackedTransaction(first))
+
+	all, err := repo.TrackedTransactions(false)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "0x2", all[0].Hash, "as mais recentes primeiro")
+	assert.Equal(t, uint64(12), all[1].Confirmations)
+
+	open, err := repo.TrackedTransactions(true)
+	require.NoError(t, err)
+	require.Len(t, open, 1)
+	assert.Equal(t, "0x2", open[0].Hash)
+}
+
+func TestGORMRepository_RPCOverrides(t *testing.T) {
+	cfg := setupTestConfig(t)
+
+	repo, err := NewWalletRepository(cfg)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	require.NoError(t, repo.SetRPCOverride(&wallet.RPCOverride{Address: "0xA", ChainID: 137, RPCEndpoint: "https://polygon.example"}))
+	require.NoError(t, repo.SetRPCOverride(&wallet.RPCOverride{Address: "0xA", ChainID: 1, RPCEndpoint: "https://node.example"}))
+	require.NoError(t, repo.SetRPCOverride(&wallet.RPCOverride{Address: "0xA", ChainID: 1, RPCEndpoint: "https://private.example"}))
+	require.NoError(t, repo.SetRPCOverride(&wallet.RPCOverride{Address: "0xB", ChainID: 1, RPCEndpoint: "https://other.example"}))
+
+	overrides, err := repo.RPCOverrides("0xA")
+	require.NoError(t, err)
+	require.Len(t, overrides, 2, "a mesma rede é substituída")
+	assert.Equal(t, int64(1), overrides[0].ChainID)
+	assert.Equal(t, "https://private.example", overrides[0].RPCEndpoint)
+
+	require.NoError(t, repo.DeleteRPCOverride("0xA", 1))
+	overrides, err = repo.RPCOverrides("0xA")
+	require.NoError(t, err)
+	require.Len(t, overrides, 1)
+	assert.Equal(t, int64(137), overrides[0].ChainID)
+}
+
+func TestGORMRepository_Tokens(t *testing.T) {
+	cfg := setupTestConfig(t)
+
+	repo, err := NewWalletRepository(cfg)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	require.NoError(t, repo.AddToken(&blockchain.Token{ChainID: 1, Address: "0xA", Symbol: "USDC", Decimals: 6}))
+	require.NoError(t, repo.AddToken(&blockchain.Token{ChainID: 137, Address: "0xA", Symbol: "USDC", Decimals: 6}))
+	require.NoError(t, repo.AddToken(&blockchain.Token{ChainID: 1, Address: "0xB", Symbol: "DAI", Decimals: 18}))
+	require.NoError(t, repo.AddToken(&blockchain.Token{ChainID: 1, Address: "0xA", Symbol: "USDC.e", Decimals: 6}))
+
+	tokens, err := repo.Tokens(1)
+	require.NoError(t, err)
+	require.Len(t, tokens, 2, "o mesmo token é atualizado")
+	assert.Equal(t, "DAI", tokens[0].Symbol)
+	assert.Equal(t, "USDC.e", tokens[1].Symbol)
+
+	all, err := repo.Tokens(0)
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+
+	require.NoError(t, repo.DeleteToken(1, "0xA"))
+	tokens, err = repo.Tokens(1)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, "0xB", tokens[0].Address)
+}
+
+func TestGORMRepository_ImportBatch(t *testing.T) {
+	cfg := setupTestConfig(t)
+
+	repo, err := NewWalletRepository(cfg)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	require.NoError(t, repo.StartImportBatch([]wallet.ImportFileState{
+		{BatchID: "a", Position: 0, KeystorePath: "old.json", Status: wallet.ImportFilePending},
+	}))
+	require.NoError(t, repo.StartImportBatch([]wallet.ImportFileState{
+		{BatchID: "b", Position: 1, KeystorePath: "two.json", Status: wallet.ImportFilePending},
+		{BatchID: "b", Position: 0, KeystorePath: "one.json", Status: wallet.ImportFilePending},
+	}))
+	require.NoError(t, repo.UpdateImportFile("b", 0, wallet.ImportFileSuccess, "0xA", ""))
+	require.NoError(t, repo.UpdateImportFile("a", 1, wallet.ImportFileFailed, "", "lote substituído"))
+
+	files, err := repo.ImportBatchFiles()
+	require.NoError(t, err)
+	require.Len(t, files, 2, "uma nova importação substitui a anterior")
+	assert.Equal(t, "one.json", files[0].KeystorePath)
+	assert.Equal(t, wallet.ImportFileSuccess, files[0].Status)
+	assert.Equal(t, "0xA", files[0].Address)
+	assert.Equal(t, wallet.ImportFilePending, files[1].Status, "o resultado de outro lote é ignorado")
+
+	require.NoError(t, repo.ClearImportBatch())
+	files, err = repo.ImportBatchFiles()
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestReadSchemaVersion(t *testing.T) {
+	cfg := setupTestConfig(t)
+	cfg.Database.DSN = ""
+	repo, err := NewWalletRepository(cfg)
+	require.NoError(t, err)
+	require.NoError(t, repo.Close())
+
+	v, err := ReadSchemaVersion(cfg.DatabasePath)
+	require.NoError(t, err)
+	assert.Equal(t, LatestSchemaVersion(), v.UserVersion)
+	assert.Equal(t, 8, v.Tables)
+	assert.Len(t, v.Fingerprint, 12)
+
+	again, err := ReadSchemaVersion(cfg.DatabasePath)
+	require.NoError(t, err)
+	assert.Equal(t, v, again, "reading does not change the schema")
+
+	// Um banco de uma versão anterior tem outra impressão digital
+	repo, err = NewWalletRepository(cfg)
+	require.NoError(t, err)
+	require.NoError(t, repo.db.Migrator().DropColumn(&wallet.Wallet{}, "Notes"))
+	require.NoError(t, repo.Close())
+	older, err := ReadSchemaVersion(cfg.DatabasePath)
+	require.NoError(t, err)
+	assert.NotEqual(t, v.Fingerprint, older.Fingerprint)
+}
+
+func TestDescribeSchema(t *testing.T) {
+	tables, err := DescribeSchema()
+	require.NoError(t, err)
+	require.Len(t, tables, 8)
+
+	var wallets *Table
+	for i := range tables {
+		if tables[i].Name == "wallets" {
+			wallets = &tables[i]
+		}
+	}
+	require.NotNil(t, wallets)
+	columns := make(map[string]Column)
+	for _, c := range wallets.Columns {
+		columns[c.Name] = c
+	}
+	assert.True(t, columns["id"].PrimaryKey)
+	assert.Contains(t, columns["id"].Type, "integer")
+	assert.Equal(t, "text", columns["address"].Type)
+	assert.Contains(t, columns, "notes")
+
+	cfg := setupTestConfig(t)
+	cfg.Database.DSN = ""
+	repo, err := NewWalletRepository(cfg)
+	require.NoError(t, err)
+	require.NoError(t, repo.db.Migrator().DropColumn(&wallet.Wallet{}, "Notes"))
+	require.NoError(t, repo.Close())
+
+	missing, err := MissingColumns(cfg.DatabasePath, tables)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"wallets.notes"}, missing)
+}
@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"blocowallet/internal/wallet"
+	"encoding/json"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var _ wallet.MasterPasswordDatabase = &GORMRepository{}
+var _ wallet.DatabaseRekeyer = &GORMRepository{}
+
+// MasterPassword retorna o registro da senha mestra, nil se nunca foi configurada
+func (repo *GORMRepository) MasterPassword() (*wallet.MasterPassword, error) {
+	var record wallet.MasterPassword
+	err := repo.db.First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// SetFieldCipher define a chave usada para abrir e selar os campos protegidos
+func (repo *GORMRepository) SetFieldCipher(fields *wallet.FieldCipher) {
+	repo.fields.Store(fields)
+}
+
+// SaveMasterPassword grava o registro e sela de novo, na mesma transação, a
+// mnemônica, as notas e as referências de todas as carteiras com next
+func (repo *GORMRepository) SaveMasterPassword(record *wallet.MasterPassword, next *wallet.FieldCipher) error {
+	current := repo.fields.Load()
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		var rows []wallet.Wallet
+		if err := tx.Select("id", "mnemonic", "notes", "external_references").Find(&rows).Error; err != nil {
+			return err
+		}
+		for i := range rows {
+			row := &rows[i]
+			if err := openWallet(current, row); err != nil {
+				return err
+			}
+			if err := sealWallet(next, row); err != nil {
+				return err
+			}
+			// A versão não muda: o conteúdo das carteiras é o mesmo
+			err := tx.Model(&wallet.Wallet{}).Where("id = ?", row.ID).UpdateColumns(map[string]interface{}{
+				"mnemonic":            row.Mnemonic,
+				"notes":               row.Notes,
+				"external_references": row.References,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Save(record).Error
+	})
+	if err != nil {
+		return err
+	}
+	repo.fields.Store(next)
+	return nil
+}
+
+// writeCipher retorna a chave para gravar campos protegidos; sem ela, grava
+// em claro apenas se nenhuma senha mestra estiver ativa
+func (repo *GORMRepository) writeCipher() (*wallet.FieldCipher, error) {
+	if fields := repo.fields.Load(); fields != nil {
+		return fields, nil
+	}
+	record, err := repo.MasterPassword()
+	if err != nil {
+		return nil, err
+	}
+	if record != nil && record.Enabled {
+		return nil, wallet.ErrMasterPasswordLocked
+	}
+	return nil, nil
+}
+
+// openWallets abre os campos protegidos das carteiras lidas do banco
+func (repo *GORMRepository) openWallets(wallets []wallet.Wallet) error {
+	fields := repo.fields.Load()
+	for i := range wallets {
+		if err := openWallet(fields, &wallets[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sealWallet sela os campos protegidos de w; com fields nil eles ficam em
+// claro, escapados quando começam como um valor selado
+func sealWallet(fields *wallet.FieldCipher, w *wallet.Wallet) error {
+	if w.Mnemonic != nil {
+		sealed, err := fields.Seal(*w.Mnemonic)
+		if err != nil {
+			return err
+		}
+		w.Mnemonic = &sealed
+	}
+	notes, err := fields.Seal(w.Notes)
+	if err != nil {
+		return err
+	}
+	w.Notes = notes
+	if fields == nil {
+		if len(w.References) > 0 {
+			references := make(wallet.WalletReferences, len(w.References))
+			for i, reference := range w.References {
+				if references[i], err = fields.Seal(reference); err != nil {
+					return err
+				}
+			}
+			w.References = references
+		}
+		return nil
+	}
+	if len(w.References) > 0 {
+		// As referências são guardadas como uma lista JSON com um único item selado
+		data, err := json.Marshal([]string(w.References))
+		if err != nil {
+			return err
+		}
+		sealed, err := fields.Seal(string(data))
+		if err != nil {
+			return err
+		}
+		w.References = wallet.WalletReferences{sealed}
+	}
+	return nil
+}
+
+// openWallet abre os campos protegidos de w; valores em claro ficam como estão
+func openWallet(fields *wallet.FieldCipher, w *wallet.Wallet) error {
+	if w.Mnemonic != nil {
+		mnemonic, err := fields.Open(*w.Mnemonic)
+		if err != nil {
+			return err
+		}
+		w.Mnemonic = &mnemonic
+	}
+	notes, err := fields.Open(w.Notes)
+	if err != nil {
+		return err
+	}
+	w.Notes = notes
+	if len(w.References) == 1 && wallet.IsSealed(w.References[0]) {
+		data, err := fields.Open(w.References[0])
+		if err != nil {
+			return err
+		}
+		var references []string
+		if err := json.Unmarshal([]byte(data), &references); err != nil {
+			return err
+		}
+		w.References = references
+		return nil
+	}
+	for i, reference := range w.References {
+		if w.References[i], err = fields.Open(reference); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGORMRepository_MasterPassword(t *testing.T) {
+	repo, err := NewWalletRepository(setupTestConfig(t))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	mnemonic := "encrypted-with-the-wallet-password"
+	plain := &wallet.Wallet{Name: "Antes", Address: "0x01", KeyStorePath: "a.json", ImportMethod: "mnemonic", SourceHash: "h1",
+		Mnemonic: &mnemonic, Notes: "cliente 42", References: wallet.WalletReferences{"TICKET-1"}}
+	require.NoError(t, repo.AddWallet(plain))
+
+	ws := &wallet.WalletService{Repo: repo}
+	state, err := ws.MasterPasswordState()
+	require.NoError(t, err)
+	assert.Equal(t, wallet.MasterPasswordUnset, state)
+
+	// Definir a senha mestra sela as carteiras já gravadas
+	require.NoError(t, ws.SetMasterPassword("", "Master-Pass1"))
+	var raw wallet.Wallet
+	require.NoError(t, repo.db.First(&raw, plain.ID).Error)
+	assert.True(t, wallet.IsSealed(*raw.Mnemonic))
+	assert.True(t, wallet.IsSealed(raw.Notes))
+	require.Len(t, raw.References, 1)
+	assert.True(t, wallet.IsSealed(raw.References[0]))
+	assert.Equal(t, "Antes", raw.Name, "nomes e endereços continuam em claro")
+
+	// Novas carteiras e notas são seladas e lidas em claro
+	added := &wallet.Wallet{Name: "Depois", Address: "0x02", KeyStorePath: "b.json", ImportMethod: "mnemonic", SourceHash: "h2", Mnemonic: &mnemonic}
+	require.NoError(t, repo.AddWallet(added))
+	assert.Equal(t, mnemonic, *added.Mnemonic)
+	require.NoError(t, repo.UpdateWalletNotes(added.ID, added.Version, "nota", wallet.WalletReferences{"a", "b"}, time.Now()))
+	wallets, err := repo.GetAllWallets()
+	require.NoError(t, err)
+	require.Len(t, wallets, 2)
+	assert.Equal(t, mnemonic, *wallets[0].Mnemonic)
+	assert.Equal(t, "cliente 42", wallets[0].Notes)
+	assert.Equal(t, wallet.WalletReferences{"TICKET-1"}, wallets[0].References)
+	assert.Equal(t, "nota", wallets[1].Notes)
+	assert.Equal(t, wallet.WalletReferences{"a", "b"}, wallets[1].References)
+
+	// Sem a senha mestra nada é lido nem gravado
+	locked := &wallet.WalletService{Repo: repo}
+	repo.SetFieldCipher(nil)
+	state, err = locked.MasterPasswordState()
+	require.NoError(t, err)
+	assert.Equal(t, wallet.MasterPasswordLocked, state)
+	_, err = repo.GetAllWallets()
+	assert.ErrorIs(t, err, wallet.ErrMasterPasswordLocked)
+	assert.ErrorIs(t, repo.AddWallet(&wallet.Wallet{Name: "X", Address: "0x03", KeyStorePath: "c.json", ImportMethod: "keystore", SourceHash: "h3"}), wallet.ErrMasterPasswordLocked)
+	assert.ErrorIs(t, locked.UnlockMasterPassword("Wrong-Pass1"), wallet.ErrMasterPassword)
+	require.NoError(t, locked.UnlockMasterPassword("Master-Pass1"))
+
+	// A troca exige a senha atual e sela tudo de novo com a nova
+	assert.ErrorIs(t, locked.SetMasterPassword("Wrong-Pass1", "Master-Pass2"), wallet.ErrMasterPassword)
+	require.NoError(t, locked.SetMasterPassword("Master-Pass1", "Master-Pass2"))
+	repo.SetFieldCipher(nil)
+	assert.ErrorIs(t, locked.UnlockMasterPassword("Master-Pass1"), wallet.ErrMasterPassword)
+	require.NoError(t, locked.UnlockMasterPassword("Master-Pass2"))
+	found, err := repo.FindBySourceHash("h1")
+	require.NoError(t, err)
+	assert.Equal(t, mnemonic, *found.Mnemonic)
+	assert.Equal(t, "cliente 42", found.Notes)
+}
+
+func TestGORMRepository_DeclineMasterPassword(t *testing.T) {
+	repo, err := NewWalletRepository(setupTestConfig(t))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	ws := &wallet.WalletService{Repo: repo}
+	require.NoError(t, ws.DeclineMasterPassword())
+	state, err := ws.MasterPasswordState()
+	require.NoError(t, err)
+	assert.Equal(t, wallet.MasterPasswordDisabled, state)
+
+	// Pode ser definida depois, pelo menu de configuração
+	require.NoError(t, ws.SetMasterPassword("", "Master-Pass1"))
+	state, err = ws.MasterPasswordState()
+	require.NoError(t, err)
+	assert.Equal(t, wallet.MasterPasswordUnlocked, state)
+}
+
+// failingRekeyRepository falha ao trocar a chave do banco de dados
+type failingRekeyRepository struct {
+	*GORMRepository
+}
+
+func (failingRekeyRepository) RekeyWithPassword(string) (func() error, error) {
+	return nil, errors.New("disk full")
+}
+
+func TestGORMRepository_SetMasterPassword_RekeyFailure(t *testing.T) {
+	repo, err := NewWalletRepository(setupTestConfig(t))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	mnemonic := "encrypted-with-the-wallet-password"
+	require.NoError(t, repo.AddWallet(&wallet.Wallet{Name: "A", Address: "0x01", KeyStorePath: "a.json", ImportMethod: "mnemonic", SourceHash: "h1",
+		Mnemonic: &mnemonic, Notes: "nota"}))
+	ws := &wallet.WalletService{Repo: repo}
+	require.NoError(t, ws.SetMasterPassword("", "Master-Pass1"))
+
+	// A troca falha antes de selar os campos com a nova chave
+	failing := &wallet.WalletService{Repo: failingRekeyRepository{repo}}
+	require.NoError(t, failing.UnlockMasterPassword("Master-Pass1"))
+	assert.ErrorContains(t, failing.SetMasterPassword("Master-Pass1", "Master-Pass2"), "disk full")
+	state, err := failing.MasterPasswordState()
+	require.NoError(t, err)
+	assert.Equal(t, wallet.MasterPasswordUnlocked, state)
+
+	// A senha atual continua abrindo tudo
+	repo.SetFieldCipher(nil)
+	assert.ErrorIs(t, ws.UnlockMasterPassword("Master-Pass2"), wallet.ErrMasterPassword)
+	require.NoError(t, ws.UnlockMasterPassword("Master-Pass1"))
+	found, err := repo.FindBySourceHash("h1")
+	require.NoError(t, err)
+	assert.Equal(t, mnemonic, *found.Mnemonic)
+	assert.Equal(t, "nota", found.Notes)
+}
+
+// failingSaveRepository troca a chave do banco, mas falha ao selar os campos
+type failingSaveRepository struct {
+	*GORMRepository
+}
+
+func (failingSaveRepository) SaveMasterPassword(*wallet.MasterPassword, *wallet.FieldCipher) error {
+	return errors.New("disk full")
+}
+
+// keyRecordingRepository guarda a chave do banco em key, sem o SQLCipher
+type keyRecordingRepository struct {
+	failingSaveRepository
+	key *string
+}
+
+func (r keyRecordingRepository) RekeyWithPassword(password string) (func() error, error) {
+	previous := *r.key
+	*r.key = password
+	return func() error {
+		*r.key = previous
+		return nil
+	}, nil
+}
+
+func TestSetMasterPassword_RestoresKeyInEffect(t *testing.T) {
+	repo, err := NewWalletRepository(setupTestConfig(t))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	key := "Startup-Pass1"
+	ws := &wallet.WalletService{Repo: keyRecordingRepository{failingSaveRepository{repo}, &key}}
+	assert.ErrorContains(t, ws.SetMasterPassword("", "Master-Pass1"), "disk full")
+	assert.Equal(t, "Startup-Pass1", key)
+}
+
+func TestGORMRepository_SetMasterPassword_RestoresDatabaseKey(t *testing.T) {
+	version, err := SQLCipherVersion()
+	require.NoError(t, err)
+	if version == "" {
+		t.Skip("o SQLite deste binário não é o SQLCipher")
+	}
+	cfg := setupTestConfig(t)
+	cfg.Database.DSN = ""
+	cfg.Database.Encryption = config.DatabaseEncryptionMasterPassword
+	repo, err := NewEncryptedWalletRepository(cfg, PasswordKey("Startup-Pass1"))
+	require.NoError(t, err)
+	require.NoError(t, repo.AddWallet(&wallet.Wallet{Name: "A", Address: "0x01", KeyStorePath: "a.json", ImportMethod: "keystore", SourceHash: "h1"}))
+
+	// A troca da chave dá certo, selar os campos não: o banco volta à chave
+	// com que foi aberto, não à senha mestra anterior, que nem existia
+	ws := &wallet.WalletService{Repo: failingSaveRepository{repo}}
+	assert.ErrorContains(t, ws.SetMasterPassword("", "Master-Pass1"), "disk full")
+	require.NoError(t, repo.Close())
+
+	_, err = NewEncryptedWalletRepository(cfg, PasswordKey("Master-Pass1"))
+	assert.ErrorIs(t, err, ErrDatabaseKey)
+	repo, err = NewEncryptedWalletRepository(cfg, PasswordKey("Startup-Pass1"))
+	require.NoError(t, err)
+	defer repo.Close()
+	found, err := repo.FindBySourceHash("h1")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+}
+
+func TestGORMRepository_PlainValuesLikeSealed(t *testing.T) {
+	repo, err := NewWalletRepository(setupTestConfig(t))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	// Sem senha mestra, textos que começam como um valor selado continuam em claro
+	mnemonic := "mk1:not-sealed"
+	added := &wallet.Wallet{Name: "A", Address: "0x01", KeyStorePath: "a.json", ImportMethod: "mnemonic", SourceHash: "h1",
+		Mnemonic: &mnemonic, Notes: "mk1:nota", References: wallet.WalletReferences{"mk1:ticket"}}
+	require.NoError(t, repo.AddWallet(added))
+	assert.Equal(t, "mk1:nota", added.Notes)
+	require.NoError(t, repo.AddWallet(&wallet.Wallet{Name: "B", Address: "0x02", KeyStorePath: "b.json", ImportMethod: "keystore", SourceHash: "h2",
+		Notes: "mk0:nota", References: wallet.WalletReferences{"mk0:a", "b"}}))
+
+	check := func() {
+		t.Helper()
+		wallets, err := repo.GetAllWallets()
+		require.NoError(t, err)
+		require.Len(t, wallets, 2)
+		assert.Equal(t, mnemonic, *wallets[0].Mnemonic)
+		assert.Equal(t, "mk1:nota", wallets[0].Notes)
+		assert.Equal(t, wallet.WalletReferences{"mk1:ticket"}, wallets[0].References)
+		assert.Equal(t, "mk0:nota", wallets[1].Notes)
+		assert.Equal(t, wallet.WalletReferences{"mk0:a", "b"}, wallets[1].References)
+	}
+	check()
+	require.NoError(t, repo.UpdateWalletNotes(added.ID, added.Version, "mk1:nota", wallet.WalletReferences{"mk1:ticket"}, time.Now()))
+	check()
+
+	// Selados depois pela senha mestra, voltam iguais
+	ws := &wallet.WalletService{Repo: repo}
+	require.NoError(t, ws.SetMasterPassword("", "Master-Pass1"))
+	check()
+}
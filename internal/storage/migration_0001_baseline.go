@@ -0,0 +1,22 @@
+package storage
+
+import "gorm.io/gorm"
+
+// O baseline cria as tabelas com o AutoMigrate, como o aplicativo fazia antes
+// das migrações. Num banco criado antes delas só acrescenta o que faltar, e o
+// registro em schema_version passa a valer a partir daqui.
+//
+// Ele não fixa um esquema: cria as tabelas das structs da versão que o roda,
+// por isso as migrações seguintes toleram encontrar o seu resultado pronto.
+// É irreversível: desfazê-lo apagaria as carteiras, e um esquema que depende
+// da versão não teria como ser desfeito com exatidão. Sem Down, migrateTo
+// recusa qualquer volta abaixo da versão 1 sem desfazer nada.
+func init() {
+	registerMigration(Migration{
+		Version: 1,
+		Name:    "baseline",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(models()...)
+		},
+	})
+}
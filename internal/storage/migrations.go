@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration é uma alteração versionada do esquema. Cada uma fica no seu
+// arquivo migration_NNNN_nome.go e se registra com registerMigration; as
+// versões começam em 1 e não têm lacunas.
+//
+// O baseline cria as tabelas com as structs da versão em que o banco é
+// criado, por isso Up precisa tolerar um banco novo que já tenha o resultado
+// dela (Migrator().HasColumn, HasTable). Down desfaz Up, para que o banco
+// volte a abrir numa versão anterior do aplicativo; nil quando não é possível.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// SchemaMigration é uma linha da tabela schema_version: uma migração aplicada
+type SchemaMigration struct {
+	Version   int    `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"not null"`
+	AppliedAt time.Time
+}
+
+// TableName define o nome da tabela das migrações aplicadas
+func (SchemaMigration) TableName() string {
+	return "schema_version"
+}
+
+// MigrationStatus é uma migração conhecida e quando foi aplicada; AppliedAt é
+// nil enquanto ela está pendente
+type MigrationStatus struct {
+	Version    int
+	Name       string
+	AppliedAt  *time.Time
+	Reversible bool
+}
+
+var (
+	// ErrSchemaTooNew indica um banco migrado por uma versão mais nova do
+	// aplicativo, com migrações que esta não conhece
+	ErrSchemaTooNew = errors.New("o esquema do banco de dados é mais novo que esta versão do aplicativo")
+	// ErrIrreversibleMigration indica uma migração sem Down no caminho da volta
+	ErrIrreversibleMigration = errors.New("migração irreversível")
+)
+
+// migrations são as migrações registradas pelos arquivos migration_*.go
+var migrations []Migration
+
+// registerMigration inclui m em migrations; chamada no init de cada arquivo
+func registerMigration(m Migration) {
+	migrations = append(migrations, m)
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+}
+
+// LatestSchemaVersion é a versão do esquema desta versão do aplicativo
+func LatestSchemaVersion() int {
+	return latestVersion(migrations)
+}
+
+func latestVersion(list []Migration) int {
+	if len(list) == 0 {
+		return 0
+	}
+	return list[len(list)-1].Version
+}
+
+// appliedVersion é a última migração registrada em schema_version; zero num
+// banco novo ou criado antes das migrações
+func appliedVersion(db *gorm.DB) (int, error) {
+	var version int
+	err := db.Model(&SchemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	return version, err
+}
+
+// migrateTo leva o esquema de db até a versão target, aplicando Up das
+// migrações pendentes em ordem ou Down das aplicadas além de target, da mais
+// nova para a mais antiga. Cada migração roda numa transação com a sua linha
+// de schema_version; no MySQL, cujas instruções DDL não são transacionais,
+// uma falha no meio pode deixar parte da migração aplicada.
+func migrateTo(db *gorm.DB, list []Migration, target int) error {
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return fmt.Errorf("falha ao criar a tabela schema_version: %w", err)
+	}
+	current, err := appliedVersion(db)
+	if err != nil {
+		return err
+	}
+	latest := latestVersion(list)
+	if current > latest {
+		return fmt.Errorf("%w: versão %d, esta conhece até a %d", ErrSchemaTooNew, current, latest)
+	}
+	if target < 0 || target > latest {
+		return fmt.Errorf("versão do esquema inválida %d (use de 0 a %d)", target, latest)
+	}
+	// A volta é recusada antes de desfazer qualquer migração quando passa por
+	// uma sem Down, como o baseline, para não deixar o banco no meio do caminho
+	for _, m := range list {
+		if m.Version > target && m.Version <= current && m.Down == nil {
+			return fmt.Errorf("%w: %d (%s); o esquema continua na versão %d", ErrIrreversibleMigration, m.Version, m.Name, current)
+		}
+	}
+
+	for _, m := range list {
+		if m.Version <= current || m.Version > target {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("falha ao aplicar a migração %d (%s): %w", m.Version, m.Name, err)
+		}
+	}
+	for i := len(list) - 1; i >= 0; i-- {
+		m := list[i]
+		if m.Version > current || m.Version <= target {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&SchemaMigration{}, m.Version).Error
+		})
+		if err != nil {
+			return fmt.Errorf("falha ao desfazer a migração %d (%s): %w", m.Version, m.Name, err)
+		}
+	}
+
+	// No SQLite a versão também fica no cabeçalho do arquivo, lida por
+	// ReadSchemaVersion sem consultar as tabelas
+	if db.Dialector.Name() == "sqlite" {
+		return db.Exec(fmt.Sprintf("PRAGMA user_version = %d", target)).Error
+	}
+	return nil
+}
+
+// MigrateTo leva o esquema do banco de dados até a versão target; abaixo da
+// atual desfaz as migrações, para que uma versão anterior do aplicativo volte
+// a abri-lo
+func (repo *GORMRepository) MigrateTo(target int) error {
+	return migrateTo(repo.db, migrations, target)
+}
+
+// SchemaVersion é a última migração aplicada ao banco de dados
+func (repo *GORMRepository) SchemaVersion() (int, error) {
+	if !repo.db.Migrator().HasTable(&SchemaMigration{}) {
+		return 0, nil
+	}
+	return appliedVersion(repo.db)
+}
+
+// MigrationStatus lista as migrações desta versão e quando cada uma foi
+// aplicada ao banco de dados
+func (repo *GORMRepository) MigrationStatus() ([]MigrationStatus, error) {
+	var applied []SchemaMigration
+	if repo.db.Migrator().HasTable(&SchemaMigration{}) {
+		if err := repo.db.Order("version").Find(&applied).Error; err != nil {
+			return nil, err
+		}
+	}
+	status := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		s := MigrationStatus{Version: m.Version, Name: m.Name, Reversible: m.Down != nil}
+		for _, a := range applied {
+			if a.Version == m.Version {
+				appliedAt := a.AppliedAt
+				s.AppliedAt = &appliedAt
+			}
+		}
+		status = append(status, s)
+	}
+	return status, nil
+}
@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// tagRow é uma tabela de teste, criada e apagada pelas migrações de testMigrations
+type tagRow struct {
+	ID   uint
+	Name string
+}
+
+func testMigrations(failUp bool) []Migration {
+	return []Migration{
+		migrations[0],
+		{
+			Version: 2, Name: "tags",
+			Up:   func(tx *gorm.DB) error { return tx.Migrator().CreateTable(&tagRow{}) },
+			Down: func(tx *gorm.DB) error { return tx.Migrator().DropTable(&tagRow{}) },
+		},
+		{
+			Version: 3, Name: "tag color",
+			Up: func(tx *gorm.DB) error {
+				if failUp {
+					return errors.New("falha de teste")
+				}
+				return tx.Exec("ALTER TABLE tag_rows ADD COLUMN color TEXT").Error
+			},
+			Down: func(tx *gorm.DB) error { return tx.Exec("ALTER TABLE tag_rows DROP COLUMN color").Error },
+		},
+	}
+}
+
+func TestMigrations_Registered(t *testing.T) {
+	require.NotEmpty(t, migrations)
+	for i, m := range migrations {
+		assert.Equal(t, i+1, m.Version, "as versões começam em 1 e não têm lacunas")
+		assert.NotEmpty(t, m.Name)
+		assert.NotNil(t, m.Up)
+	}
+	assert.Equal(t, migrations[len(migrations)-1].Version, LatestSchemaVersion())
+}
+
+func TestNewWalletRepository_AppliesMigrations(t *testing.T) {
+	repo, err := NewWalletRepository(setupTestConfig(t))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	version, err := repo.SchemaVersion()
+	require.NoError(t, err)
+	assert.Equal(t, LatestSchemaVersion(), version)
+	status, err := repo.MigrationStatus()
+	require.NoError(t, err)
+	require.Len(t, status, LatestSchemaVersion())
+	assert.Equal(t, "baseline", status[0].Name)
+	assert.NotNil(t, status[0].AppliedAt)
+	assert.False(t, status[0].Reversible)
+}
+
+func TestMigrateTo_UpAndDown(t *testing.T) {
+	repo, err := NewWalletRepository(setupTestConfig(t))
+	require.NoError(t, err)
+	defer repo.Close()
+	require.NoError(t, repo.AddWallet(&wallet.Wallet{Name: "Kept", Address: "0xA1", KeyStorePath: "/k/a", ImportMethod: "keystore", SourceHash: "kept"}))
+
+	list := testMigrations(false)
+	require.NoError(t, migrateTo(repo.db, list, 3))
+	assert.True(t, repo.db.Migrator().HasColumn(&tagRow{}, "color"))
+	version, err := appliedVersion(repo.db)
+	require.NoError(t, err)
+	assert.Equal(t, 3, version)
+	var userVersion int
+	require.NoError(t, repo.db.Raw("PRAGMA user_version").Scan(&userVersion).Error)
+	assert.Equal(t, 3, userVersion)
+
+	// Down desfaz da mais nova para a mais antiga
+	require.NoError(t, migrateTo(repo.db, list, 1))
+	assert.False(t, repo.db.Migrator().HasTable(&tagRow{}))
+	version, err = appliedVersion(repo.db)
+	require.NoError(t, err)
+	assert.Equal(t, 1, version)
+
+	// O baseline não pode ser desfeito, e as carteiras continuam lá
+	assert.ErrorIs(t, migrateTo(repo.db, list, 0), ErrIrreversibleMigration)
+	wallets, err := repo.GetAllWallets()
+	require.NoError(t, err)
+	assert.Len(t, wallets, 1)
+
+	// Uma volta que passa pelo baseline é recusada antes de desfazer as
+	// migrações reversíveis acima dele
+	require.NoError(t, migrateTo(repo.db, list, 3))
+	err = migrateTo(repo.db, list, 0)
+	assert.ErrorIs(t, err, ErrIrreversibleMigration)
+	assert.ErrorContains(t, err, "baseline")
+	version, err = appliedVersion(repo.db)
+	require.NoError(t, err)
+	assert.Equal(t, 3, version)
+	assert.True(t, repo.db.Migrator().HasColumn(&tagRow{}, "color"))
+	require.NoError(t, repo.db.Raw("PRAGMA user_version").Scan(&userVersion).Error)
+	assert.Equal(t, 3, userVersion)
+
+	assert.Error(t, migrateTo(repo.db, list, 4), "versão desconhecida")
+}
+
+func TestMigrateTo_FailedMigrationIsRolledBack(t *testing.T) {
+	repo, err := NewWalletRepository(setupTestConfig(t))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	err = migrateTo(repo.db, testMigrations(true), 3)
+	assert.ErrorContains(t, err, "migração 3 (tag color)")
+	version, err := appliedVersion(repo.db)
+	require.NoError(t, err)
+	assert.Equal(t, 2, version, "as migrações anteriores ficam aplicadas")
+	assert.False(t, repo.db.Migrator().HasColumn(&tagRow{}, "color"))
+}
+
+func TestNewWalletRepository_SchemaTooNew(t *testing.T) {
+	cfg := setupTestConfig(t)
+	cfg.Database.DSN = ""
+	repo, err := NewWalletRepository(cfg)
+	require.NoError(t, err)
+	require.NoError(t, migrateTo(repo.db, testMigrations(false), 3))
+	require.NoError(t, repo.Close())
+
+	// Uma versão anterior do aplicativo conhece só o baseline
+	_, err = NewWalletRepository(cfg)
+	assert.ErrorIs(t, err, ErrSchemaTooNew)
+
+	// A mais nova desfaz as suas migrações antes
+	repo, err = OpenWalletRepository(cfg, nil)
+	require.NoError(t, err)
+	require.NoError(t, migrateTo(repo.db, testMigrations(false), 1))
+	require.NoError(t, repo.Close())
+	repo, err = NewWalletRepository(cfg)
+	require.NoError(t, err)
+	require.NoError(t, repo.Close())
+}
+
+func TestNewWalletRepository_BaselinesOlderDatabase(t *testing.T) {
+	cfg := setupTestConfig(t)
+	cfg.Database.DSN = ""
+
+	// Um banco criado antes das migrações, só com o AutoMigrate
+	db, err := gorm.Open(createSQLiteDialector(cfg.DatabasePath), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(models()...))
+	require.NoError(t, db.Create(&wallet.Wallet{Name: "Old", Address: "0xB1", KeyStorePath: "/k/b", ImportMethod: "keystore", SourceHash: "old", Version: 1}).Error)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+
+	repo, err := NewWalletRepository(&config.Config{DatabasePath: cfg.DatabasePath})
+	require.NoError(t, err)
+	defer repo.Close()
+	version, err := repo.SchemaVersion()
+	require.NoError(t, err)
+	assert.Equal(t, LatestSchemaVersion(), version)
+	found, err := repo.FindBySourceHash("old")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+}
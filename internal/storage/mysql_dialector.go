@@ -0,0 +1,34 @@
+//go:build mysql
+
+package storage
+
+import (
+	"strings"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// O driver do MySQL entra no binário apenas com -tags mysql
+func init() {
+	serverDialectors[DatabaseMySQL] = func(dsn string) gorm.Dialector {
+		return mysql.Open(mysqlDSN(dsn))
+	}
+}
+
+// mysqlDSN pede ao driver que leia as colunas de data como time.Time, o que
+// as carteiras precisam, e que grave em UTF-8 completo
+func mysqlDSN(dsn string) string {
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+	if !strings.Contains(dsn, "parseTime=") {
+		dsn += separator + "parseTime=true"
+		separator = "&"
+	}
+	if !strings.Contains(dsn, "charset=") {
+		dsn += separator + "charset=utf8mb4"
+	}
+	return dsn
+}
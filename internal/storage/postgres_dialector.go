@@ -0,0 +1,10 @@
+//go:build postgres
+
+package storage
+
+import "gorm.io/driver/postgres"
+
+// O driver do PostgreSQL entra no binário apenas com -tags postgres
+func init() {
+	serverDialectors[DatabasePostgres] = postgres.Open
+}
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm/schema"
+)
+
+// Column descreve uma coluna como o AutoMigrate a cria no SQLite
+type Column struct {
+	Name       string
+	Type       string
+	PrimaryKey bool
+	NotNull    bool
+	Unique     bool
+	Default    string
+}
+
+// Table descreve uma tabela e os seus índices
+type Table struct {
+	Name    string
+	Columns []Column
+	Indexes []string // "nome (colunas)", com UNIQUE quando for o caso
+}
+
+// DescribeSchema descreve as tabelas desta versão a partir das structs dos
+// modelos, sem abrir nenhum banco de dados
+func DescribeSchema() ([]Table, error) {
+	dialector := createSQLiteDialector("")
+	cache := &sync.Map{}
+	var tables []Table
+	for _, model := range append(models(), &SchemaMigration{}) {
+		s, err := schema.Parse(model, cache, schema.NamingStrategy{})
+		if err != nil {
+			return nil, fmt.Errorf("falha ao analisar o modelo %T: %w", model, err)
+		}
+		t := Table{Name: s.Table}
+		for _, f := range s.Fields {
+			if f.DBName == "" {
+				continue
+			}
+			t.Columns = append(t.Columns, Column{
+				Name:       f.DBName,
+				Type:       dialector.DataTypeOf(f),
+				PrimaryKey: f.PrimaryKey,
+				NotNull:    f.NotNull,
+				Unique:     f.Unique,
+				Default:    f.DefaultValue,
+			})
+		}
+		for _, idx := range s.ParseIndexes() {
+			var cols []string
+			for _, opt := range idx.Fields {
+				cols = append(cols, opt.DBName)
+			}
+			name := fmt.Sprintf("%s (%s)", idx.Name, strings.Join(cols, ", "))
+			if idx.Class == "UNIQUE" {
+				name = "UNIQUE " + name
+			}
+			t.Indexes = append(t.Indexes, name)
+		}
+		sort.Strings(t.Indexes)
+		tables = append(tables, t)
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Name < tables[j].Name })
+	return tables, nil
+}
+
+// MissingColumns lista, como "tabela.coluna", o que as structs definem e o
+// banco de dados em dbPath ainda não tem; o AutoMigrate acrescenta essas
+// colunas na próxima vez que o aplicativo abrir o banco. O arquivo não é alterado.
+func MissingColumns(dbPath string, tables []Table) ([]string, error) {
+	db, closeDB, err := openReadOnly(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	defer closeDB()
+
+	var missing []string
+	for _, t := range tables {
+		if !db.Migrator().HasTable(t.Name) {
+			missing = append(missing, t.Name)
+			continue
+		}
+		for _, c := range t.Columns {
+			if !db.Migrator().HasColumn(t.Name, c.Name) {
+				missing = append(missing, t.Name+"."+c.Name)
+			}
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// SchemaVersion identifica o esquema de um banco de dados de carteiras.
+// UserVersion é o PRAGMA user_version, a última migração aplicada (zero nos
+// bancos criados antes das migrações); a impressão digital das instruções
+// CREATE distingue ainda os esquemas que o baseline criou em versões diferentes.
+type SchemaVersion struct {
+	UserVersion int
+	Tables      int
+	Fingerprint string // Primeiros 12 dígitos hexadecimais do SHA-256
+}
+
+// String descreve o esquema numa linha, para relatórios
+func (v SchemaVersion) String() string {
+	return fmt.Sprintf("user_version %d, %d tables, fingerprint %s", v.UserVersion, v.Tables, v.Fingerprint)
+}
+
+// ReadSchemaVersion lê o esquema de um banco de dados sem alterá-lo
+func ReadSchemaVersion(dbPath string) (SchemaVersion, error) {
+	db, closeDB, err := openReadOnly(dbPath)
+	if err != nil {
+		return SchemaVersion{}, err
+	}
+	defer closeDB()
+
+	var v SchemaVersion
+	if err := db.Raw("PRAGMA user_version").Scan(&v.UserVersion).Error; err != nil {
+		return SchemaVersion{}, err
+	}
+	var rows []struct {
+		Type string
+		SQL  string
+	}
+	err = db.Raw("SELECT type, sql FROM sqlite_master WHERE sql IS NOT NULL AND name NOT LIKE 'sqlite_%' ORDER BY type, name").Scan(&rows).Error
+	if err != nil {
+		return SchemaVersion{}, err
+	}
+	h := sha256.New()
+	for _, r := range rows {
+		if r.Type == "table" {
+			v.Tables++
+		}
+		// Espaços não mudam o esquema
+		h.Write([]byte(strings.Join(strings.Fields(r.SQL), " ")))
+		h.Write([]byte{'\n'})
+	}
+	v.Fingerprint = hex.EncodeToString(h.Sum(nil))[:12]
+	return v, nil
+}
@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"blocowallet/pkg/config"
+
+	"gorm.io/gorm"
+)
+
+// Tipos de banco de dados aceitos em database.type
+const (
+	DatabaseSQLite   = "sqlite"
+	DatabasePostgres = "postgres"
+	DatabaseMySQL    = "mysql"
+)
+
+// Portas padrão dos servidores, quando o DSN não informa outra
+const (
+	defaultPostgresPort = 5432
+	defaultMySQLPort    = 3306
+)
+
+// ErrDriverNotCompiled indica que o driver do banco de dados configurado não
+// foi incluído no binário
+var ErrDriverNotCompiled = errors.New("driver do banco de dados não incluído nesta compilação")
+
+// ErrSQLiteOnly é retornado pelas operações que dependem do arquivo do SQLite,
+// como o backup com VACUUM INTO; nos servidores elas ficam com as ferramentas
+// do próprio servidor (pg_dump, mysqldump)
+var ErrSQLiteOnly = errors.New("operação disponível apenas com o SQLite")
+
+// serverDialectors abrem os bancos de dados acessados pela rede. Cada driver é
+// registrado pelo seu arquivo, compilado apenas com a tag de mesmo nome
+// (-tags postgres, -tags mysql), para que a compilação padrão continue só com
+// o SQLite.
+var serverDialectors = map[string]func(dsn string) gorm.Dialector{}
+
+// DatabaseType normaliza database.type; vazio é SQLite
+func DatabaseType(cfg config.DatabaseConfig) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Type)) {
+	case "", "sqlite", "sqlite3":
+		return DatabaseSQLite, nil
+	case "postgres", "postgresql":
+		return DatabasePostgres, nil
+	case "mysql", "mariadb":
+		return DatabaseMySQL, nil
+	}
+	return "", fmt.Errorf("tipo de banco de dados desconhecido %q (use sqlite, postgres ou mysql)", cfg.Type)
+}
+
+// serverDialector cria o dialector do servidor dbType com o DSN configurado
+func serverDialector(dbType, dsn string) (gorm.Dialector, error) {
+	if strings.TrimSpace(dsn) == "" {
+		return nil, fmt.Errorf("database.dsn é obrigatório para o banco de dados %s", dbType)
+	}
+	open, ok := serverDialectors[dbType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s (compile com -tags %s)", ErrDriverNotCompiled, dbType, dbType)
+	}
+	return open(dsn), nil
+}
+
+// mysqlAddress encontra o endereço em DSNs como user:pass@tcp(host:3306)/db
+var mysqlAddress = regexp.MustCompile(`@tcp\(([^)]*)\)`)
+
+// ServerPort é a porta TCP do servidor de banco de dados configurado; zero
+// para o SQLite, sockets Unix e DSNs que não puderam ser lidos
+func ServerPort(cfg config.DatabaseConfig) int {
+	dbType, err := DatabaseType(cfg)
+	if err != nil {
+		return 0
+	}
+	switch dbType {
+	case DatabasePostgres:
+		return postgresPort(cfg.DSN)
+	case DatabaseMySQL:
+		match := mysqlAddress.FindStringSubmatch(cfg.DSN)
+		if match == nil {
+			// Sem endereço o driver conecta em 127.0.0.1:3306
+			if strings.Contains(cfg.DSN, "@/") {
+				return defaultMySQLPort
+			}
+			return 0
+		}
+		return hostPort(match[1], defaultMySQLPort)
+	}
+	return 0
+}
+
+// postgresPort lê a porta de DSNs em forma de URL (postgres://host:5432/db) ou
+// de pares chave=valor (host=... port=5432)
+func postgresPort(dsn string) int {
+	if u, err := url.Parse(dsn); err == nil && (u.Scheme == "postgres" || u.Scheme == "postgresql") {
+		if strings.HasPrefix(u.Hostname(), "/") {
+			return 0
+		}
+		return hostPort(u.Host, defaultPostgresPort)
+	}
+	port := defaultPostgresPort
+	for _, field := range strings.Fields(dsn) {
+		key, value, _ := strings.Cut(field, "=")
+		switch key {
+		case "host":
+			if strings.HasPrefix(value, "/") {
+				return 0
+			}
+		case "port":
+			if n, err := strconv.Atoi(value); err == nil {
+				port = n
+			}
+		}
+	}
+	return port
+}
+
+// hostPort é a porta de host:porta, ou fallback quando ela não é informada
+func hostPort(address string, fallback int) int {
+	if _, port, err := net.SplitHostPort(address); err == nil {
+		if n, err := strconv.Atoi(port); err == nil {
+			return n
+		}
+	}
+	return fallback
+}
+
+// postgresPassword encontra a senha em DSNs de pares chave=valor
+var postgresPassword = regexp.MustCompile(`(password=)(\S+)`)
+
+// RedactDSN esconde a senha do DSN, para exibi-lo em relatórios
+func RedactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.User != nil {
+		return u.Redacted()
+	}
+	if at := strings.Index(dsn, "@tcp("); at >= 0 {
+		if user, _, ok := strings.Cut(dsn[:at], ":"); ok {
+			return user + ":xxxxx" + dsn[at:]
+		}
+	}
+	return postgresPassword.ReplaceAllString(dsn, "${1}xxxxx")
+}
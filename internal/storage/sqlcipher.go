@@ -0,0 +1,282 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// KeyFileName é o arquivo padrão da chave da criptografia "keyfile"
+const KeyFileName = "database.key"
+
+var (
+	// ErrSQLCipherUnavailable indica que o SQLite do binário não é o SQLCipher,
+	// e que o banco ficaria em claro
+	ErrSQLCipherUnavailable = errors.New("o SQLite deste binário não é o SQLCipher (compile com -tags libsqlite3 contra a libsqlcipher)")
+	// ErrDatabaseKey indica que a chave não abre o banco de dados
+	ErrDatabaseKey = errors.New("chave do banco de dados incorreta")
+	// ErrDatabaseKeyRequired indica que o banco é criptografado e nenhuma chave foi informada
+	ErrDatabaseKeyRequired = errors.New("o banco de dados é criptografado e requer uma chave")
+)
+
+// sqliteHeader inicia todo arquivo do SQLite em claro
+var sqliteHeader = []byte("SQLite format 3\x00")
+
+// DatabaseKey é a chave do SQLCipher: uma senha, que o SQLCipher deriva com o
+// salt guardado no próprio arquivo, ou uma chave bruta de 32 bytes
+type DatabaseKey struct {
+	pragma   string // Valor do PRAGMA key
+	password bool
+}
+
+// PasswordKey usa a senha mestra como chave do banco de dados
+func PasswordKey(password string) *DatabaseKey {
+	return &DatabaseKey{pragma: quoteSQL(wallet.NormalizeSecret(password)), password: true}
+}
+
+// rawKey usa 32 bytes como chave, sem derivação
+func rawKey(key []byte) *DatabaseKey {
+	return &DatabaseKey{pragma: `"x'` + hex.EncodeToString(key) + `'"`}
+}
+
+// KeyFilePath é o arquivo da chave da criptografia "keyfile"
+func KeyFilePath(cfg *config.Config) string {
+	if cfg.Database.KeyFile != "" {
+		return cfg.Database.KeyFile
+	}
+	return filepath.Join(cfg.AppDir, KeyFileName)
+}
+
+// LoadKeyFile lê a chave em path: 64 dígitos hexadecimais, como os gravados
+// aqui, ou qualquer arquivo de pelo menos 32 bytes, cujo SHA-256 é a chave.
+// Com create, um arquivo ausente é criado com uma chave aleatória.
+func LoadKeyFile(path string, create bool) (*DatabaseKey, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && create {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return nil, err
+		}
+		// O_EXCL: uma chave existente nunca é substituída
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.WriteString(hex.EncodeToString(key) + "\n"); err != nil {
+			f.Close()
+			return nil, err
+		}
+		if err := f.Close(); err != nil {
+			return nil, err
+		}
+		return rawKey(key), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler a chave do banco de dados: %w", err)
+	}
+
+	if key, err := hex.DecodeString(strings.TrimSpace(string(data))); err == nil && len(key) == 32 {
+		return rawKey(key), nil
+	}
+	if len(data) < 32 {
+		return nil, fmt.Errorf("a chave do banco de dados em %s tem menos de 32 bytes", path)
+	}
+	sum := sha256.Sum256(data)
+	return rawKey(sum[:]), nil
+}
+
+// quoteSQL escreve s como literal de texto do SQL
+func quoteSQL(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// SQLCipherVersion é a versão do SQLCipher do binário; vazia quando o SQLite
+// não é o SQLCipher
+func SQLCipherVersion() (string, error) {
+	db, err := sql.Open(sqlite.DriverName, ":memory:")
+	if err != nil {
+		return "", err
+	}
+	defer db.Close()
+	var version string
+	err = db.QueryRow("PRAGMA cipher_version").Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return version, err
+}
+
+// sqliteFile é o arquivo do banco de dados SQLite configurado, sem os
+// parâmetros do DSN
+func sqliteFile(cfg *config.Config) string {
+	dbPath := cfg.DatabasePath
+	if cfg.Database.DSN != "" {
+		dbPath = cfg.Database.DSN
+	}
+	file, _, _ := strings.Cut(strings.TrimPrefix(dbPath, "file:"), "?")
+	return file
+}
+
+// EncryptedDatabaseExists indica se o arquivo do SQLite já existe
+// criptografado. Enquanto não existe, ou está em claro, a chave ainda pode
+// ser escolhida.
+func EncryptedDatabaseExists(cfg *config.Config) bool {
+	info, err := os.Stat(sqliteFile(cfg))
+	return err == nil && info.Size() > 0 && !isPlaintextSQLite(sqliteFile(cfg))
+}
+
+// isPlaintextSQLite indica se path é um banco do SQLite ainda em claro
+func isPlaintextSQLite(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	header := make([]byte, len(sqliteHeader))
+	n, _ := f.Read(header)
+	return n == len(header) && bytes.Equal(header, sqliteHeader)
+}
+
+// encryptPlaintext converte o banco em claro em path para o SQLCipher com
+// sqlcipher_export. A cópia criptografada substitui o arquivo só quando
+// completa, e o WAL em claro é apagado com ele.
+func encryptPlaintext(path string, key *DatabaseKey) error {
+	tmp := path + ".encrypting"
+	_ = os.Remove(tmp)
+
+	db, err := sql.Open(sqlite.DriverName, path)
+	if err != nil {
+		return err
+	}
+	// ATTACH vale apenas para a conexão em que foi executado
+	db.SetMaxOpenConns(1)
+	err = func() error {
+		if _, err := db.Exec("ATTACH DATABASE ? AS encrypted KEY "+key.pragma, tmp); err != nil {
+			return err
+		}
+		if _, err := db.Exec("SELECT sqlcipher_export('encrypted')"); err != nil {
+			return err
+		}
+		_, err := db.Exec("DETACH DATABASE encrypted")
+		return err
+	}()
+	if closeErr := db.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("falha ao criptografar o banco de dados: %w", err)
+	}
+
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(path + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return os.Rename(tmp, path)
+}
+
+// encryptedSQLiteDialector abre o arquivo do SQLite com o SQLCipher,
+// convertendo antes um banco que ainda esteja em claro
+func encryptedSQLiteDialector(cfg *config.Config, key *atomic.Pointer[DatabaseKey]) (gorm.Dialector, error) {
+	version, err := SQLCipherVersion()
+	if err != nil {
+		return nil, err
+	}
+	if version == "" {
+		return nil, ErrSQLCipherUnavailable
+	}
+
+	dbPath := cfg.DatabasePath
+	if cfg.Database.DSN != "" {
+		dbPath = cfg.Database.DSN
+	}
+	if err := ensureDir(filepath.Dir(dbPath)); err != nil {
+		return nil, fmt.Errorf("falha ao criar diretório para o banco de dados: %w", err)
+	}
+	if file := sqliteFile(cfg); isPlaintextSQLite(file) {
+		if err := encryptPlaintext(file, key.Load()); err != nil {
+			return nil, err
+		}
+	}
+
+	// Os pragmas são validados por sqliteDSN e aplicados após a chave
+	if _, err := sqliteDSN(dbPath, cfg.Database); err != nil {
+		return nil, err
+	}
+	busyTimeout := cfg.Database.BusyTimeoutMS
+	if busyTimeout <= 0 {
+		busyTimeout = DefaultBusyTimeoutMS
+	}
+	pragmas := []string{
+		fmt.Sprintf("busy_timeout = %d", busyTimeout),
+		"journal_mode = " + pragmaOrDefault(cfg.Database.JournalMode, DefaultJournalMode),
+		"synchronous = " + pragmaOrDefault(cfg.Database.Synchronous, DefaultSynchronous),
+	}
+	return cipherDialector(dbPath, key, pragmas)
+}
+
+func pragmaOrDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return strings.ToUpper(value)
+}
+
+// RekeyWithPassword criptografa de novo o banco com a nova senha mestra,
+// quando ele é criptografado com ela; nos demais casos não faz nada. A função
+// devolvida volta à chave em uso antes da troca, que na primeira definição da
+// senha mestra é a senha informada ao abrir o banco.
+func (repo *GORMRepository) RekeyWithPassword(password string) (func() error, error) {
+	current := repo.key.Load()
+	if current == nil || !current.password {
+		return func() error { return nil }, nil
+	}
+	if err := repo.rekey(PasswordKey(password)); err != nil {
+		return nil, err
+	}
+	return func() error { return repo.rekey(current) }, nil
+}
+
+// rekey troca a chave do banco por next
+func (repo *GORMRepository) rekey(next *DatabaseKey) error {
+	journalMode := pragmaOrDefault(repo.journalMode, DefaultJournalMode)
+	err := repo.db.Connection(func(conn *gorm.DB) error {
+		// O SQLCipher troca a chave apenas fora do modo WAL
+		if err := conn.Exec("PRAGMA journal_mode = DELETE").Error; err != nil {
+			return err
+		}
+		if err := conn.Exec("PRAGMA rekey = " + next.pragma).Error; err != nil {
+			return err
+		}
+		return conn.Exec("PRAGMA journal_mode = " + journalMode).Error
+	})
+	if err != nil {
+		return fmt.Errorf("falha ao trocar a chave do banco de dados: %w", err)
+	}
+	repo.key.Store(next)
+
+	// As outras conexões ainda usam a chave antiga
+	if sqlDB, err := repo.db.DB(); err == nil {
+		sqlDB.SetMaxIdleConns(0)
+		sqlDB.SetMaxIdleConns(2)
+	}
+	return nil
+}
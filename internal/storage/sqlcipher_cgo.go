@@ -0,0 +1,42 @@
+//go:build cgo
+
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// cipherDrivers numera os drivers registrados por cipherDialector
+var cipherDrivers atomic.Int64
+
+// cipherDialector registra um driver do go-sqlite3 que entrega a chave a cada
+// nova conexão antes de qualquer leitura do arquivo, e só então aplica os
+// pragmas; por isso eles não vão no DSN, que o driver aplica antes do gancho.
+// A chave é lida de key a cada conexão, para acompanhar o PRAGMA rekey.
+func cipherDialector(dsn string, key *atomic.Pointer[DatabaseKey], pragmas []string) (gorm.Dialector, error) {
+	name := fmt.Sprintf("sqlite3_cipher_%d", cipherDrivers.Add(1))
+	sql.Register(name, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			if _, err := conn.Exec("PRAGMA key = "+key.Load().pragma, nil); err != nil {
+				return err
+			}
+			for _, pragma := range pragmas {
+				if _, err := conn.Exec("PRAGMA "+pragma, nil); err != nil {
+					if strings.Contains(err.Error(), "file is not a database") {
+						return ErrDatabaseKey
+					}
+					return err
+				}
+			}
+			return nil
+		},
+	})
+	return sqlite.New(sqlite.Config{DriverName: name, DSN: dsn}), nil
+}
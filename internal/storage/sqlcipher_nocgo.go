@@ -0,0 +1,15 @@
+//go:build !cgo
+
+package storage
+
+import (
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+// cipherDialector depende do gancho de conexão do go-sqlite3, que só existe
+// com o cgo; sem ele também não há SQLite
+func cipherDialector(string, *atomic.Pointer[DatabaseKey], []string) (gorm.Dialector, error) {
+	return nil, ErrSQLCipherUnavailable
+}
@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadKeyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", KeyFileName)
+
+	_, err := LoadKeyFile(path, false)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	created, err := LoadKeyFile(path, true)
+	require.NoError(t, err)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	if os.PathSeparator == '/' {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `"x'`+strings.TrimSpace(string(data))+`'"`, created.pragma)
+
+	// Uma chave existente é lida, nunca substituída
+	again, err := LoadKeyFile(path, true)
+	require.NoError(t, err)
+	assert.Equal(t, created, again)
+
+	// Outros arquivos valem pelo SHA-256 do conteúdo
+	other := filepath.Join(t.TempDir(), "other.key")
+	content := []byte(strings.Repeat("k", 40))
+	require.NoError(t, os.WriteFile(other, content, 0600))
+	key, err := LoadKeyFile(other, false)
+	require.NoError(t, err)
+	sum := sha256.Sum256(content)
+	assert.Equal(t, `"x'`+hex.EncodeToString(sum[:])+`'"`, key.pragma)
+
+	require.NoError(t, os.WriteFile(other, []byte("short"), 0600))
+	_, err = LoadKeyFile(other, false)
+	assert.ErrorContains(t, err, "32 bytes")
+}
+
+func TestPasswordKey_QuotesThePassword(t *testing.T) {
+	key := PasswordKey("it's")
+	assert.Equal(t, `'it''s'`, key.pragma)
+	assert.True(t, key.password)
+}
+
+func TestNewEncryptedWalletRepository_Configuration(t *testing.T) {
+	cfg := setupTestConfig(t)
+	cfg.Database.DSN = ""
+
+	cfg.Database.Encryption = config.DatabaseEncryptionKeyFile
+	_, err := NewWalletRepository(cfg)
+	assert.ErrorIs(t, err, ErrDatabaseKeyRequired)
+
+	cfg.Database.Encryption = "rot13"
+	_, err = NewEncryptedWalletRepository(cfg, PasswordKey("secret"))
+	assert.ErrorContains(t, err, "database.encryption")
+
+	cfg.Database = config.DatabaseConfig{Type: DatabasePostgres, DSN: "postgres://db/bloco", Encryption: config.DatabaseEncryptionMasterPassword}
+	_, err = NewEncryptedWalletRepository(cfg, PasswordKey("secret"))
+	assert.ErrorContains(t, err, "só se aplica ao SQLite")
+}
+
+func TestNewEncryptedWalletRepository(t *testing.T) {
+	cfg := setupTestConfig(t)
+	cfg.Database.DSN = ""
+	cfg.Database.Encryption = config.DatabaseEncryptionMasterPassword
+
+	// Um banco em claro existente
+	plain, err := NewWalletRepository(&config.Config{DatabasePath: cfg.DatabasePath})
+	require.NoError(t, err)
+	require.NoError(t, plain.AddWallet(&wallet.Wallet{
+		Name: "Secret name", Address: "0xE1", KeyStorePath: "/k/e", ImportMethod: "keystore", SourceHash: "encrypted-hash",
+	}))
+	require.NoError(t, plain.Close())
+	assert.False(t, EncryptedDatabaseExists(cfg), "o banco ainda está em claro")
+
+	version, err := SQLCipherVersion()
+	require.NoError(t, err)
+	if version == "" {
+		// Sem o SQLCipher o banco não é aberto, para não continuar em claro
+		_, err := NewEncryptedWalletRepository(cfg, PasswordKey("secret"))
+		assert.ErrorIs(t, err, ErrSQLCipherUnavailable)
+		assert.True(t, isPlaintextSQLite(cfg.DatabasePath))
+		return
+	}
+
+	// O banco em claro é convertido na primeira abertura
+	repo, err := NewEncryptedWalletRepository(cfg, PasswordKey("secret"))
+	require.NoError(t, err)
+	found, err := repo.FindBySourceHash("encrypted-hash")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "Secret name", found.Name)
+	_, err = repo.RekeyWithPassword("changed")
+	require.NoError(t, err)
+	require.NoError(t, repo.Close())
+
+	assert.True(t, EncryptedDatabaseExists(cfg))
+	data, err := os.ReadFile(cfg.DatabasePath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "Secret name")
+
+	_, err = NewEncryptedWalletRepository(cfg, PasswordKey("secret"))
+	assert.ErrorIs(t, err, ErrDatabaseKey)
+	repo, err = NewEncryptedWalletRepository(cfg, PasswordKey("changed"))
+	require.NoError(t, err)
+	require.NoError(t, repo.Close())
+}
+
+func TestRekeyWithPassword_PlainDatabase(t *testing.T) {
+	repo, err := NewWalletRepository(setupTestConfig(t))
+	require.NoError(t, err)
+	defer repo.Close()
+	restore, err := repo.RekeyWithPassword("ignored")
+	assert.NoError(t, err)
+	assert.NoError(t, restore())
+}
@@ -1,11 +1,111 @@
 package storage
 
 import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"blocowallet/pkg/config"
+
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
+// Valores padrão dos pragmas do SQLite: WAL permite que a interface leia enquanto
+// tarefas em segundo plano gravam, e o busy timeout evita "database is locked"
+// durante importações em lote
+const (
+	DefaultJournalMode   = "WAL"
+	DefaultBusyTimeoutMS = 5000
+	DefaultSynchronous   = "NORMAL"
+)
+
+var (
+	journalModes      = []string{"DELETE", "TRUNCATE", "PERSIST", "MEMORY", "WAL", "OFF"}
+	synchronousLevels = []string{"OFF", "NORMAL", "FULL", "EXTRA"}
+)
+
+// sqliteDialector abre o arquivo de database_path, ou o DSN quando informado,
+// com os pragmas configurados
+func sqliteDialector(cfg *config.Config) (gorm.Dialector, error) {
+	dbPath := cfg.DatabasePath
+	if cfg.Database.DSN != "" {
+		dbPath = cfg.Database.DSN
+	}
+
+	// Garantir que o diretório existe
+	dir := filepath.Dir(dbPath)
+	if err := ensureDir(dir); err != nil {
+		return nil, fmt.Errorf("falha ao criar diretório para o banco de dados: %w", err)
+	}
+
+	dsn, err := sqliteDSN(dbPath, cfg.Database)
+	if err != nil {
+		return nil, err
+	}
+	return createSQLiteDialector(dsn), nil
+}
+
 // createSQLiteDialector cria o dialector SQLite apropriado para o ambiente
-func createSQLiteDialector(dbPath string) gorm.Dialector {
-	return sqlite.Open(dbPath)
+func createSQLiteDialector(dsn string) gorm.Dialector {
+	return sqlite.Open(dsn)
+}
+
+// sqliteDSN acrescenta os pragmas configurados ao DSN. Eles são passados como
+// parâmetros do driver para valer em todas as conexões do pool, e não apenas
+// na primeira.
+func sqliteDSN(dbPath string, cfg config.DatabaseConfig) (string, error) {
+	journalMode := strings.ToUpper(cfg.JournalMode)
+	if journalMode == "" {
+		journalMode = DefaultJournalMode
+	}
+	if !contains(journalModes, journalMode) {
+		return "", fmt.Errorf("journal_mode inválido %q (use %s)", cfg.JournalMode, strings.Join(journalModes, ", "))
+	}
+	synchronous := strings.ToUpper(cfg.Synchronous)
+	if synchronous == "" {
+		synchronous = DefaultSynchronous
+	}
+	if !contains(synchronousLevels, synchronous) {
+		return "", fmt.Errorf("synchronous inválido %q (use %s)", cfg.Synchronous, strings.Join(synchronousLevels, ", "))
+	}
+	busyTimeout := cfg.BusyTimeoutMS
+	if busyTimeout <= 0 {
+		busyTimeout = DefaultBusyTimeoutMS
+	}
+
+	// Parâmetros já presentes no DSN informado pelo usuário têm precedência
+	params := url.Values{}
+	existing := ""
+	if i := strings.IndexRune(dbPath, '?'); i >= 0 {
+		existing = dbPath[i+1:]
+	}
+	set := func(key, value string) {
+		if !strings.Contains(existing, key+"=") {
+			params.Set(key, value)
+		}
+	}
+	set("_journal_mode", journalMode)
+	set("_busy_timeout", strconv.Itoa(busyTimeout))
+	set("_synchronous", synchronous)
+	if len(params) == 0 {
+		return dbPath, nil
+	}
+
+	separator := "?"
+	if existing != "" || strings.HasSuffix(dbPath, "?") {
+		separator = "&"
+	}
+	return dbPath + separator + params.Encode(), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
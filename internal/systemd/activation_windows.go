@@ -0,0 +1,8 @@
+package systemd
+
+import "net"
+
+// Listeners always returns nil on Windows, which has no socket activation
+func Listeners() ([]net.Listener, error) {
+	return nil, nil
+}
@@ -0,0 +1,89 @@
+// Package systemd implements the parts of the systemd service protocol the
+// wallet needs without linking libsystemd: readiness and status notifications,
+// watchdog keep-alives and socket activation. Every function is a no-op when
+// the process was not started by systemd.
+package systemd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Notification states understood by systemd
+const (
+	Ready    = "READY=1"
+	Stopping = "STOPPING=1"
+	Watchdog = "WATCHDOG=1"
+)
+
+// Status returns the notification that sets the status line shown by
+// "systemctl status"
+func Status(text string) string {
+	return "STATUS=" + strings.ReplaceAll(text, "\n", " ")
+}
+
+// Notify sends the states to the service manager. It reports false without an
+// error when NOTIFY_SOCKET is not set.
+func Notify(states ...string) (bool, error) {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return false, nil
+	}
+	// Names starting with "@" live in the abstract namespace
+	if strings.HasPrefix(socket, "@") {
+		socket = "\x00" + socket[1:]
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(strings.Join(states, "\n"))); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// WatchdogInterval returns how often systemd expects a keep-alive, or zero
+// when the watchdog is disabled or meant for another process
+func WatchdogInterval() (time.Duration, error) {
+	raw := os.Getenv("WATCHDOG_USEC")
+	if raw == "" {
+		return 0, nil
+	}
+	usec, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || usec <= 0 {
+		return 0, errors.New("invalid WATCHDOG_USEC: " + raw)
+	}
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0, nil
+	}
+	return time.Duration(usec) * time.Microsecond, nil
+}
+
+// StartWatchdog sends keep-alives at half the watchdog interval until ctx is
+// done. It does nothing when the watchdog is not enabled.
+func StartWatchdog(ctx context.Context) error {
+	interval, err := WatchdogInterval()
+	if err != nil || interval == 0 {
+		return err
+	}
+	go func() {
+		ticker := time.NewTicker(interval / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_, _ = Notify(Watchdog)
+			}
+		}
+	}()
+	return nil
+}
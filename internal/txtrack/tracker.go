@@ -0,0 +1,253 @@
+// Package txtrack follows broadcast transactions until they are final,
+// noticing when they are mined, reverted or replaced by another transaction
+// with the same nonce.
+package txtrack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrNotReplaceable is returned for transactions that are no longer pending
+var ErrNotReplaceable = errors.New("only pending transactions can be sped up or cancelled")
+
+// Source reads the state of transactions from one network
+type Source interface {
+	TransactionStatus(ctx context.Context, hash common.Hash) (blockchain.TxStatus, error)
+	GetTransactionCount(ctx context.Context, address string) (uint64, error)
+}
+
+// Client is a network connection able to send replacements;
+// *blockchain.Ethereum satisfies it
+type Client interface {
+	Source
+	SuggestFees(ctx context.Context) (maxFee, tip *big.Int, err error)
+	Broadcast(ctx context.Context, tx *types.Transaction) error
+}
+
+// Refresh updates tx from the network and reports whether its state or
+// confirmations changed. required is the number of confirmations that makes
+// it final.
+func Refresh(ctx context.Context, tx *wallet.TrackedTransaction, source Source, required uint64, now time.Time) (bool, error) {
+	status, err := source.TransactionStatus(ctx, common.HexToHash(tx.Hash))
+	if err != nil {
+		return false, err
+	}
+	before := *tx
+	tx.CheckedAt = &now
+
+	switch status.State {
+	case blockchain.TxIncluded:
+		tx.BlockNumber = status.BlockNumber
+		tx.Confirmations = status.Confirmations
+		tx.State = string(wallet.TrackedIncluded)
+		if status.Confirmations >= required {
+			tx.State = string(wallet.TrackedFinal)
+		}
+	case blockchain.TxReverted:
+		tx.BlockNumber = status.BlockNumber
+		tx.Confirmations = status.Confirmations
+		tx.State = string(wallet.TrackedReverted)
+	default:
+		// Not mined, or no longer: a reorg can send an included transaction back
+		tx.BlockNumber = 0
+		tx.Confirmations = 0
+		tx.State = string(wallet.TrackedPending)
+		if status.State == blockchain.TxNotFound {
+			mined, err := source.GetTransactionCount(ctx, tx.From)
+			if err != nil {
+				return false, err
+			}
+			// Another transaction of the sender took the nonce
+			if mined > tx.Nonce {
+				tx.State = string(wallet.TrackedReplaced)
+			}
+		}
+	}
+	changed := tx.State != before.State || tx.Confirmations != before.Confirmations || tx.BlockNumber != before.BlockNumber
+	return changed, nil
+}
+
+// Tracker polls the open transactions of a wallet service on the configured
+// networks
+type Tracker struct {
+	Service  *wallet.WalletService
+	Networks map[string]config.Network
+	// Connect opens a network; nil uses blockchain.NewEthereum
+	Connect func(network config.Network) (Client, func(), error)
+}
+
+// Poll refreshes every open transaction and saves the changes. It returns the
+// transactions that were open, with their new state, and the first error seen;
+// a network that cannot be reached does not stop the others.
+func (t *Tracker) Poll(ctx context.Context) ([]wallet.TrackedTransaction, error) {
+	txs, err := t.Service.TrackedTransactions(true)
+	if err != nil {
+		return nil, err
+	}
+	sources := map[string]Client{}
+	var closers []func()
+	defer func() {
+		for _, c := range closers {
+			c()
+		}
+	}()
+
+	var firstErr error
+	keep := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	now := time.Now()
+	for i := range txs {
+		tx := &txs[i]
+		network, ok := t.network(tx)
+		if !ok {
+			keep(fmt.Errorf("%s: no network with an RPC endpoint for chain %d", tx.Hash, tx.ChainID))
+			continue
+		}
+		// Senders with their own endpoint are polled through it
+		source, ok := sources[network.RPCEndpoint]
+		if !ok {
+			var closer func()
+			source, closer, err = t.connect(network)
+			if err != nil {
+				keep(fmt.Errorf("%s: %w", network.Name, err))
+				continue
+			}
+			sources[network.RPCEndpoint] = source
+			closers = append(closers, closer)
+		}
+		changed, err := Refresh(ctx, tx, source, network.RequiredConfirmations(), now)
+		if err != nil {
+			keep(fmt.Errorf("%s: %w", tx.Hash, err))
+			continue
+		}
+		if changed {
+			markCancelled(tx, txs)
+		}
+		if err := t.Service.UpdateTrackedTransaction(tx); err != nil {
+			keep(err)
+		}
+	}
+	return txs, firstErr
+}
+
+// markCancelled labels a transaction replaced by its own cancel transaction as
+// cancelled rather than replaced
+func markCancelled(tx *wallet.TrackedTransaction, txs []wallet.TrackedTransaction) {
+	if wallet.TrackedTxState(tx.State) != wallet.TrackedReplaced {
+		return
+	}
+	for _, other := range txs {
+		if other.Replaces == tx.Hash && other.Kind == wallet.TrackedKindCancel && wallet.TrackedTxState(other.State) != wallet.TrackedReplaced {
+			tx.State = string(wallet.TrackedCancelled)
+			return
+		}
+	}
+}
+
+// Required returns the confirmations that make tx final on its network
+func (t *Tracker) Required(tx wallet.TrackedTransaction) uint64 {
+	network, ok := t.network(&tx)
+	if !ok {
+		return config.DefaultConfirmations
+	}
+	return network.RequiredConfirmations()
+}
+
+// network finds where a transaction was sent: the network of the same name,
+// or else an active network of its chain, with the RPC override of the sender
+func (t *Tracker) network(tx *wallet.TrackedTransaction) (config.Network, bool) {
+	keys := make([]string, 0, len(t.Networks))
+	for key := range t.Networks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var fallback *config.Network
+	for _, key := range keys {
+		network := t.Networks[key]
+		if network.RPCEndpoint == "" || network.Quarantine != "" || network.ChainID != tx.ChainID {
+			continue
+		}
+		if strings.EqualFold(network.Name, tx.Network) {
+			return t.Service.NetworkFor(tx.From, network), true
+		}
+		if network.Usable() && fallback == nil {
+			fallback = &network
+		}
+	}
+	if fallback == nil {
+		return config.Network{}, false
+	}
+	return t.Service.NetworkFor(tx.From, *fallback), true
+}
+
+func (t *Tracker) connect(network config.Network) (Client, func(), error) {
+	if t.Connect != nil {
+		return t.Connect(network)
+	}
+	client, err := blockchain.NewEthereum(network.RPCEndpoint, blockchain.DefaultTimeout, network.Symbol, 18, network.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, client.Close, nil
+}
+
+// Replace signs a transaction that takes the nonce of tx with higher fees,
+// broadcasts it and tracks it. A speed-up repeats tx; a cancel sends nothing
+// to the sender. signer must belong to the sender of tx.
+func (t *Tracker) Replace(ctx context.Context, tx wallet.TrackedTransaction, signer wallet.Signer, cancel bool) (*wallet.TrackedTransaction, error) {
+	if wallet.TrackedTxState(tx.State) != wallet.TrackedPending {
+		return nil, ErrNotReplaceable
+	}
+	original, err := tx.Transaction()
+	if err != nil {
+		return nil, err
+	}
+	network, ok := t.network(&tx)
+	if !ok {
+		return nil, fmt.Errorf("no network with an RPC endpoint for chain %d", tx.ChainID)
+	}
+	client, closer, err := t.connect(network)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", network.Name, err)
+	}
+	defer closer()
+
+	// Without fee suggestions, e.g. on chains before EIP-1559, the bump alone applies
+	maxFee, tip, err := client.SuggestFees(ctx)
+	if err != nil {
+		maxFee, tip = nil, nil
+	}
+	from := common.HexToAddress(tx.From)
+	req, err := wallet.ReplacementRequest(original, from, cancel, maxFee, tip)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := wallet.SignTransfer(signer, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Broadcast(ctx, signed); err != nil {
+		return nil, err
+	}
+	kind := wallet.TrackedKindSpeedUp
+	if cancel {
+		kind = wallet.TrackedKindCancel
+	}
+	return t.Service.TrackTransaction(&wallet.RawTransaction{Tx: signed, From: from}, network.Name, kind, tx.Hash)
+}
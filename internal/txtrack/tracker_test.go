@@ -0,0 +1,240 @@
+package txtrack
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient answers from a map of statuses and a mined nonce per sender
+type fakeClient struct {
+	statuses map[common.Hash]blockchain.TxStatus
+	mined    map[string]uint64
+	sent     []*types.Transaction
+	closed   int
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{statuses: map[common.Hash]blockchain.TxStatus{}, mined: map[string]uint64{}}
+}
+
+func (f *fakeClient) TransactionStatus(ctx context.Context, hash common.Hash) (blockchain.TxStatus, error) {
+	if status, ok := f.statuses[hash]; ok {
+		return status, nil
+	}
+	return blockchain.TxStatus{Hash: hash, State: blockchain.TxNotFound}, nil
+}
+
+func (f *fakeClient) GetTransactionCount(ctx context.Context, address string) (uint64, error) {
+	return f.mined[common.HexToAddress(address).Hex()], nil
+}
+
+func (f *fakeClient) SuggestFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	return nil, nil, errors.New("no base fee")
+}
+
+func (f *fakeClient) Broadcast(ctx context.Context, tx *types.Transaction) error {
+	f.sent = append(f.sent, tx)
+	f.statuses[tx.Hash()] = blockchain.TxStatus{Hash: tx.Hash(), State: blockchain.TxPending}
+	return nil
+}
+
+func newTestTracker(t *testing.T) (*Tracker, *fakeClient) {
+	dir := t.TempDir()
+	repo, err := storage.NewWalletRepository(&config.Config{AppDir: dir, DatabasePath: filepath.Join(dir, "wallets.db")})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = repo.Close() })
+
+	client := newFakeClient()
+	tracker := &Tracker{
+		Service: &wallet.WalletService{Repo: repo},
+		Networks: map[string]config.Network{
+			"sepolia": {Name: "Sepolia", ChainID: 11155111, RPCEndpoint: "http://sepolia", IsActive: true},
+		},
+		Connect: func(config.Network) (Client, func(), error) {
+			return client, func() { client.closed++ }, nil
+		},
+	}
+	return tracker, client
+}
+
+func sendTracked(t *testing.T, tracker *Tracker, key *ecdsa.PrivateKey, nonce uint64) *wallet.TrackedTransaction {
+	tx, err := wallet.SignTransfer(wallet.NewKeySigner(key), wallet.TransferRequest{
+		ChainID:              big.NewInt(11155111),
+		To:                   common.HexToAddress("0x000000000000000000000000000000000000dEaD"),
+		Value:                big.NewInt(1),
+		Nonce:                nonce,
+		GasLimit:             wallet.TxGas,
+		MaxFeePerGas:         big.NewInt(2e9),
+		MaxPriorityFeePerGas: big.NewInt(1e9),
+	})
+	require.NoError(t, err)
+	tracked, err := tracker.Service.TrackTransaction(&wallet.RawTransaction{Tx: tx, From: crypto.PubkeyToAddress(key.PublicKey)}, "Sepolia", "", "")
+	require.NoError(t, err)
+	return tracked
+}
+
+func TestRefresh(t *testing.T) {
+	client := newFakeClient()
+	from := "0x00000000000000000000000000000000000000Aa"
+	tx := &wallet.TrackedTransaction{Hash: common.HexToHash("0x01").Hex(), From: from, Nonce: 3, State: string(wallet.TrackedPending)}
+	now := time.Now()
+
+	client.statuses[common.HexToHash(tx.Hash)] = blockchain.TxStatus{State: blockchain.TxPending}
+	changed, err := Refresh(context.Background(), tx, client, 3, now)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, &now, tx.CheckedAt)
+
+	client.statuses[common.HexToHash(tx.Hash)] = blockchain.TxStatus{State: blockchain.TxIncluded, BlockNumber: 10, Confirmations: 1}
+	changed, _ = Refresh(context.Background(), tx, client, 3, now)
+	assert.True(t, changed)
+	assert.Equal(t, string(wallet.TrackedIncluded), tx.State)
+
+	client.statuses[common.HexToHash(tx.Hash)] = blockchain.TxStatus{State: blockchain.TxIncluded, BlockNumber: 10, Confirmations: 3}
+	_, _ = Refresh(context.Background(), tx, client, 3, now)
+	assert.Equal(t, string(wallet.TrackedFinal), tx.State)
+
+	// Unknown to the node while the nonce is still free: keep waiting
+	delete(client.statuses, common.HexToHash(tx.Hash))
+	_, _ = Refresh(context.Background(), tx, client, 3, now)
+	assert.Equal(t, string(wallet.TrackedPending), tx.State)
+	assert.Zero(t, tx.BlockNumber)
+
+	// Unknown and the nonce was used by something else
+	client.mined[common.HexToAddress(from).Hex()] = 4
+	_, _ = Refresh(context.Background(), tx, client, 3, now)
+	assert.Equal(t, string(wallet.TrackedReplaced), tx.State)
+
+	client.statuses[common.HexToHash(tx.Hash)] = blockchain.TxStatus{State: blockchain.TxReverted, BlockNumber: 11, Confirmations: 1}
+	_, _ = Refresh(context.Background(), tx, client, 3, now)
+	assert.Equal(t, string(wallet.TrackedReverted), tx.State)
+}
+
+func TestTracker_PollAndCancel(t *testing.T) {
+	tracker, client := newTestTracker(t)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	mined := sendTracked(t, tracker, key, 0)
+	stuck := sendTracked(t, tracker, key, 1)
+	client.statuses[common.HexToHash(mined.Hash)] = blockchain.TxStatus{State: blockchain.TxIncluded, BlockNumber: 5, Confirmations: config.DefaultConfirmations}
+	client.statuses[common.HexToHash(stuck.Hash)] = blockchain.TxStatus{State: blockchain.TxPending}
+
+	txs, err := tracker.Poll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	open, err := tracker.Service.TrackedTransactions(true)
+	require.NoError(t, err)
+	require.Len(t, open, 1, "the final transaction is no longer polled")
+	assert.Equal(t, stuck.Hash, open[0].Hash)
+	assert.Equal(t, 1, client.closed, "one connection per network and poll")
+
+	// Only pending transactions can be replaced
+	final := *mined
+	final.State = string(wallet.TrackedFinal)
+	_, err = tracker.Replace(context.Background(), final, wallet.NewKeySigner(key), true)
+	assert.ErrorIs(t, err, ErrNotReplaceable)
+
+	cancel, err := tracker.Replace(context.Background(), open[0], wallet.NewKeySigner(key), true)
+	require.NoError(t, err)
+	require.Len(t, client.sent, 1)
+	sent := client.sent[0]
+	assert.Equal(t, uint64(1), sent.Nonce())
+	assert.Equal(t, from, *sent.To())
+	assert.Equal(t, wallet.TrackedKindCancel, cancel.Kind)
+	assert.Equal(t, stuck.Hash, cancel.Replaces)
+
+	// The cancel is mined: the original is reported as cancelled
+	delete(client.statuses, common.HexToHash(stuck.Hash))
+	client.statuses[sent.Hash()] = blockchain.TxStatus{State: blockchain.TxIncluded, BlockNumber: 6, Confirmations: 1}
+	client.mined[from.Hex()] = 2
+	_, err = tracker.Poll(context.Background())
+	require.NoError(t, err)
+	all, err := tracker.Service.TrackedTransactions(false)
+	require.NoError(t, err)
+	states := map[string]string{}
+	for _, tx := range all {
+		states[tx.Hash] = tx.State
+	}
+	assert.Equal(t, string(wallet.TrackedCancelled), states[stuck.Hash])
+	assert.Equal(t, string(wallet.TrackedIncluded), states[cancel.Hash])
+}
+
+func TestTracker_PollReportsMissingNetwork(t *testing.T) {
+	tracker, _ := newTestTracker(t)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sendTracked(t, tracker, key, 0)
+	tracker.Networks = nil
+
+	txs, err := tracker.Poll(context.Background())
+	assert.ErrorContains(t, err, "no network")
+	assert.Len(t, txs, 1)
+}
+
+func TestTracker_PerNetworkConfirmations(t *testing.T) {
+	tracker, client := newTestTracker(t)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx := sendTracked(t, tracker, key, 0)
+	assert.Equal(t, uint64(config.DefaultConfirmations), tracker.Required(*tx))
+
+	client.statuses[common.HexToHash(tx.Hash)] = blockchain.TxStatus{State: blockchain.TxIncluded, BlockNumber: 5, Confirmations: 2}
+	polled, err := tracker.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, string(wallet.TrackedIncluded), polled[0].State)
+
+	network := tracker.Networks["sepolia"]
+	network.Confirmations = 2
+	tracker.Networks["sepolia"] = network
+	assert.Equal(t, uint64(2), tracker.Required(*tx))
+	polled, err = tracker.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, string(wallet.TrackedFinal), polled[0].State)
+}
+
+func TestTracker_UsesSenderRPCOverride(t *testing.T) {
+	tracker, client := newTestTracker(t)
+	var endpoints []string
+	tracker.Connect = func(network config.Network) (Client, func(), error) {
+		endpoints = append(endpoints, network.RPCEndpoint)
+		return client, func() {}, nil
+	}
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sendTracked(t, tracker, key, 0)
+	sendTracked(t, tracker, other, 0)
+
+	from := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	require.NoError(t, tracker.Service.SetRPCOverride(from, 11155111, "https://private.example"))
+	assert.Error(t, tracker.Service.SetRPCOverride(from, 11155111, "ftp://private.example"))
+
+	_, err = tracker.Poll(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"https://private.example", "http://sepolia"}, endpoints)
+
+	require.NoError(t, tracker.Service.SetRPCOverride(from, 11155111, ""))
+	endpoints = nil
+	_, err = tracker.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://sepolia"}, endpoints)
+}
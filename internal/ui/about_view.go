@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/buildinfo"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/platform"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func init() {
+	registerKeyMap(constants.AboutView, func(m *CLIModel) []key.Binding {
+		return []key.Binding{bindingIf(m.aboutReport != "", newBinding("c/enter", "key_copy_report", "c", "enter"))}
+	})
+}
+
+// copyToClipboard allows tests to keep the report off the real clipboard
+var copyToClipboard = platform.CopyToClipboard
+
+// SetBuildInfo sets the version and build details shown on the About screen
+func (m *CLIModel) SetBuildInfo(info buildinfo.Info) {
+	m.buildInfo = info
+}
+
+// initAbout opens the About screen, reading the schema of the database once
+func (m *CLIModel) initAbout() {
+	if m.currentConfig == nil {
+		if err := m.ensureConfigAndNetworksLoaded(); err != nil {
+			m.aboutReport = ""
+			m.setAboutResult(err.Error(), true)
+			m.currentView = constants.AboutView
+			return
+		}
+	}
+	m.aboutReport = environmentReport(m.buildInfo, m.currentConfig, readSchema(m.currentConfig))
+	m.setAboutResult("", false)
+	m.currentView = constants.AboutView
+}
+
+func (m *CLIModel) setAboutResult(status string, failed bool) {
+	m.aboutStatus = status
+	m.aboutFailed = failed
+}
+
+func (m *CLIModel) updateAbout(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && (msg.String() == "c" || msg.String() == "enter") && m.aboutReport != "" {
+		if copyToClipboard(m.aboutReport) == platform.ClipboardTerminal {
+			m.setAboutResult(localization.Labels["about_copied_terminal"], false)
+		} else {
+			m.setAboutResult(localization.Labels["about_copied"], false)
+		}
+	}
+	return m, nil
+}
+
+func (m *CLIModel) viewAbout() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["about_title"]))
+	b.WriteString("\n\n")
+	b.WriteString(m.styles.MenuDesc.Render(localization.Labels["about_desc"]))
+	b.WriteString("\n\n")
+	b.WriteString(m.aboutReport)
+	b.WriteString("\n")
+
+	if m.aboutStatus != "" {
+		if m.aboutFailed {
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.aboutStatus))
+		} else {
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.aboutStatus))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// readSchema describes the schema of the wallet database, or why it could not
+// be read
+func readSchema(cfg *config.Config) string {
+	dbPath := cfg.DatabasePath
+	if cfg.Database.DSN != "" {
+		dbPath = cfg.Database.DSN
+	}
+	if cfg.Database.Type != "" && cfg.Database.Type != "sqlite" {
+		return "n/a (" + cfg.Database.Type + ")"
+	}
+	if cfg.Database.Encryption != "" {
+		return "n/a (encrypted)"
+	}
+	schema, err := storage.ReadSchemaVersion(dbPath)
+	if err != nil {
+		return "unreadable: " + err.Error()
+	}
+	return schema.String()
+}
+
+// environmentReport lists the build and the installation for bug reports. It
+// is in English whatever the interface language, always in the same order,
+// and paths under the home directory are written with "~" so the user name is
+// not disclosed.
+func environmentReport(info buildinfo.Info, cfg *config.Config, schema string) string {
+	tags := strings.Join(info.Tags, ",")
+	if tags == "" {
+		tags = "none"
+	}
+	crypto := "development (light scrypt parameters in tests)"
+	if wallet.ProductionBuild {
+		crypto = "production"
+	}
+	database := cfg.DatabasePath
+	if cfg.Database.DSN != "" {
+		// Server DSNs can hold a password
+		database = storage.RedactDSN(cfg.Database.DSN)
+	}
+	dbType := cfg.Database.Type
+	if dbType == "" {
+		dbType = "sqlite"
+	}
+	chains := blockchain.BundledChainList()
+	sigVersion, sigCount := blockchain.BundledSignatures()
+	embedded := fmt.Sprintf("bip39 %s, chains %s (%d), signatures %s (%d)",
+		wallet.WordListVersion, chains.Version, len(chains.Chains), sigVersion, sigCount)
+
+	fields := [][2]string{
+		{"Version", info.Version},
+		{"Commit", info.Commit},
+		{"Build date", info.Date},
+		{"Go", info.GoVersion},
+		{"OS/Arch", info.OS + "/" + info.Arch},
+		{"Build tags", tags},
+		{"Crypto", crypto},
+		{"Language", cfg.Language},
+		{"App directory", homeRelative(cfg.AppDir)},
+		{"Config file", homeRelative(filepath.Join(cfg.AppDir, "config.toml"))},
+		{"Database", dbType + " " + homeRelative(database)},
+		{"Keystores", homeRelative(filepath.Join(cfg.WalletsDir, "keystore"))},
+		{"Logs", homeRelative(filepath.Join(cfg.AppDir, "logs"))},
+		{"DB schema", schema},
+		{"Embedded data", embedded},
+	}
+	var b strings.Builder
+	for _, f := range fields {
+		fmt.Fprintf(&b, "%-14s %s\n", f[0]+":", f[1])
+	}
+	return b.String()
+}
+
+// homeRelative writes a path under the home directory as "~/..."
+func homeRelative(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	if rel, err := filepath.Rel(home, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.Join("~", rel)
+	}
+	return path
+}
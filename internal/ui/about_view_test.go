@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/buildinfo"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/platform"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbout_ReportAndCopy(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddAboutMessages()
+
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+	appDir := filepath.Join(home, ".bloco")
+	m := &CLIModel{styles: createStyles(), menuItems: NewMenu(), currentView: constants.DefaultView}
+	m.currentConfig = &config.Config{
+		AppDir:       appDir,
+		Language:     "en",
+		WalletsDir:   filepath.Join(appDir, "keystore"),
+		DatabasePath: filepath.Join(t.TempDir(), "missing.db"),
+	}
+	m.SetBuildInfo(buildinfo.Info{Version: "v1.2.3", Commit: "abc1234", Date: "2025-01-01", GoVersion: "go1.24.6", OS: "linux", Arch: "arm64", Tags: []string{"netgo", "production"}})
+
+	for i, item := range m.menuItems {
+		if item.title == "About" {
+			m.selectedMenu = i
+		}
+	}
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, constants.AboutView, m.currentView)
+
+	report := m.aboutReport
+	assert.Contains(t, report, "v1.2.3")
+	assert.Contains(t, report, "linux/arm64")
+	assert.Contains(t, report, "netgo,production")
+	assert.Contains(t, report, filepath.Join("~", ".bloco", "config.toml"))
+	assert.NotContains(t, report, home+string(filepath.Separator))
+	assert.Contains(t, report, "DB schema:     unreadable")
+	assert.Contains(t, report, "Embedded data: bip39 "+wallet.WordListVersion)
+	assert.Equal(t, report, environmentReport(m.buildInfo, m.currentConfig, readSchema(m.currentConfig)), "deterministic")
+
+	var copied string
+	copyToClipboard = func(text string) platform.ClipboardMethod {
+		copied = text
+		return platform.ClipboardTerminal
+	}
+	defer func() { copyToClipboard = platform.CopyToClipboard }()
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	assert.Equal(t, report, copied)
+	assert.Contains(t, m.viewAbout(), "OSC 52")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.DefaultView, m.currentView)
+}
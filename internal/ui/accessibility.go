@@ -0,0 +1,197 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// accessible is set for screen readers and limited terminals such as serial
+// consoles and CI logs: no colours, banners or symbols outside ASCII
+var accessible bool
+
+// Colour profile and glyphs restored when accessible mode ends
+var (
+	previousProfile termenv.Profile
+	previousGlyphs  glyphSet
+)
+
+// SetAccessible turns the accessible mode on or off. Call it before creating
+// the model, as the styles are built from it.
+func SetAccessible(enabled bool) {
+	if enabled == accessible {
+		return
+	}
+	accessible = enabled
+	if enabled {
+		previousGlyphs = glyphs
+		glyphs = asciiGlyphs
+		previousProfile = lipgloss.ColorProfile()
+		lipgloss.SetColorProfile(termenv.Ascii)
+		return
+	}
+	glyphs = previousGlyphs
+	lipgloss.SetColorProfile(previousProfile)
+}
+
+// plainSymbols spells out the symbols of the views and messages that screen
+// readers announce badly or limited terminals cannot show
+var plainSymbols = strings.NewReplacer(
+	"↑/↓", "up/down",
+	"←/→", "left/right",
+	"↑", "up",
+	"↓", "down",
+	"←", "left",
+	"→", "->",
+	" • ", ", ",
+	"•", "-",
+	"…", "...",
+	"–", "-",
+	"—", "-",
+	"✓", "[ok]",
+	"✅", "[ok]",
+	"✗", "[x]",
+	"❌", "[x]",
+	"⚠", "[!]",
+	"💡", "Tip",
+	"🔹", "-",
+	"●", "*",
+	"■", "#",
+)
+
+// plainText makes s ASCII apart from letters and digits of the language in
+// use: symbols are spelled out, box drawing becomes "-", "|" and "+", and
+// emoji are dropped
+func plainText(s string) string {
+	s = plainSymbols.Replace(s)
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r < 0x80:
+			b.WriteRune(r)
+		case r >= 0x2500 && r <= 0x257F:
+			b.WriteRune(boxRune(r))
+		case r >= 0x2580 && r <= 0x259F:
+			if r == '░' {
+				b.WriteRune('-')
+			} else {
+				b.WriteRune('#')
+			}
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune(' ')
+		}
+	}
+	return b.String()
+}
+
+// boxRune draws a box drawing character with ASCII
+func boxRune(r rune) rune {
+	switch {
+	case strings.ContainsRune("─━═╌╍┄┅┈┉╴╶╸╺", r):
+		return '-'
+	case strings.ContainsRune("│┃║╎╏┆┇┊┋╵╷╹╻", r):
+		return '|'
+	default:
+		return '+'
+	}
+}
+
+// plainLines splits a screen into plain lines without the padding of the
+// layout, keeping a single blank line between blocks
+func plainLines(view string) []string {
+	lines := trimBlankLines(view)
+	indent := -1
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		if n := len(line) - len(strings.TrimLeft(line, " ")); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = line[indent:]
+		}
+	}
+	return lines
+}
+
+// trimBlankLines makes view plain and drops trailing spaces and repeated
+// blank lines
+func trimBlankLines(view string) []string {
+	var lines []string
+	blank := true
+	for _, line := range strings.Split(plainText(view), "\n") {
+		line = strings.TrimRight(line, " ")
+		if strings.TrimSpace(line) == "" {
+			if !blank {
+				lines = append(lines, "")
+			}
+			blank = true
+			continue
+		}
+		lines = append(lines, line)
+		blank = false
+	}
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// plainModel runs the TUI in accessible mode. Nothing is redrawn in place:
+// each screen is printed once as plain lines when it opens, and afterwards
+// only the lines that changed, so screen readers and logs follow along.
+type plainModel struct {
+	m     *CLIModel
+	view  string   // View of the screen printed last
+	lines []string // Lines printed for it
+}
+
+// NewPlainModel wraps the model for accessible mode; run it without the
+// alternate screen
+func NewPlainModel(m *CLIModel) tea.Model {
+	return &plainModel{m: m}
+}
+
+func (p *plainModel) Init() tea.Cmd {
+	return p.m.Init()
+}
+
+func (p *plainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	_, cmd := p.m.Update(msg)
+	if out := p.changes(); out != "" {
+		cmd = tea.Batch(cmd, tea.Println(out))
+	}
+	return p, cmd
+}
+
+// View is empty: the output is printed by Update
+func (p *plainModel) View() string {
+	return ""
+}
+
+// changes returns what to print for the current screen: all of it when the
+// view changed, otherwise the lines that differ from the last print
+func (p *plainModel) changes() string {
+	lines := plainLines(p.m.View())
+	var out []string
+	if p.m.currentView != p.view {
+		// A blank line separates the screens
+		out = append([]string{""}, lines...)
+	} else {
+		for i, line := range lines {
+			if line != "" && (i >= len(p.lines) || p.lines[i] != line) {
+				out = append(out, line)
+			}
+		}
+	}
+	p.view, p.lines = p.m.currentView, lines
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/pkg/localization"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlainText(t *testing.T) {
+	assert.Equal(t, "up/down: Move, enter: Use", plainText("↑/↓: Move • enter: Use"))
+	assert.Equal(t, "[ok] Saved", plainText("✓ Saved"))
+	assert.Equal(t, "[!] Tip: back up", plainText("⚠ 💡: back up"))
+	assert.Equal(t, "+---+\n| a |\n+---+", plainText("╭───╮\n│ a │\n╰───╯"))
+	assert.Equal(t, "##-", plainText("█▓░"))
+	assert.Equal(t, "Configurações", plainText("Configurações"), "letters of the language stay")
+	assert.Equal(t, " Wallet", plainText("🔑 Wallet"))
+}
+
+func TestPlainLines(t *testing.T) {
+	lines := plainLines("\n  Title   \n\n\n    item ✓  \n   \n")
+	assert.Equal(t, []string{"Title", "", "  item [ok]"}, lines)
+}
+
+func TestSetAccessible(t *testing.T) {
+	before := glyphs
+	SetAccessible(true)
+	t.Cleanup(func() { SetAccessible(false) })
+	assert.Equal(t, asciiGlyphs, glyphs)
+
+	m := &CLIModel{
+		styles:      createStyles(),
+		currentView: constants.DefaultView,
+		menuItems:   []menuItem{{title: "Wallets", description: "List wallets"}, {title: "Exit", description: "Quit"}},
+	}
+	header := m.renderHeader()
+	assert.Contains(t, header, "BLOCO Wallet")
+	assert.NotContains(t, header, "Date:", "the clock would change the screen every second")
+	assert.Contains(t, header, "> Wallets: List wallets\n  Exit: Quit")
+	assert.NotContains(t, m.renderStatusBar(), "\x1b[", "no colour codes")
+
+	SetAccessible(false)
+	assert.Equal(t, before, glyphs)
+}
+
+func TestPlainModel_PrintsScreensAndChanges(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	SetAccessible(true)
+	t.Cleanup(func() { SetAccessible(false) })
+
+	m := &CLIModel{
+		styles:      createStyles(),
+		currentView: constants.DefaultView,
+		width:       80,
+		height:      24,
+		menuItems:   []menuItem{{title: "Wallets", description: "List wallets"}, {title: "Exit", description: "Quit"}},
+	}
+	p := &plainModel{m: m}
+	assert.Empty(t, p.View())
+
+	// The first screen is printed whole
+	out := p.changes()
+	assert.Equal(t, "BLOCO Wallet", strings.TrimSpace(strings.Split(out, "\n")[1]))
+	assert.Contains(t, out, "> Wallets: List wallets")
+	assert.Contains(t, out, "Wallets: 0 | View: ")
+	for _, r := range out {
+		require.Less(t, r, rune(128), "output %q is not ASCII", out)
+	}
+
+	// Moving the cursor prints the two menu lines that changed
+	m.selectedMenu = 1
+	changed := strings.Split(p.changes(), "\n")
+	require.Len(t, changed, 2)
+	assert.Equal(t, "Wallets: List wallets", strings.TrimSpace(changed[0]))
+	assert.Equal(t, "> Exit: Quit", strings.TrimSpace(changed[1]))
+	assert.Empty(t, p.changes(), "nothing is left to print")
+
+	// Another screen is printed whole again
+	m.currentView = constants.ConfigurationView
+	assert.Contains(t, p.changes(), "BLOCO Wallet")
+}
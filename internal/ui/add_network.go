@@ -3,13 +3,12 @@ package ui
 import (
 	"errors"
 	"fmt"
-	"runtime"
 	"strconv"
 	"strings"
 	"time"
-	"unicode"
 
 	"blocowallet/internal/blockchain"
+	"blocowallet/pkg/config"
 	"blocowallet/pkg/localization"
 	"blocowallet/pkg/logger"
 
@@ -19,23 +18,19 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Architecture and logging helpers for UI input handling
-var (
-	// archDetector allows tests to mock architecture; defaults to runtime.GOARCH
-	archDetector = func() string { return runtime.GOARCH }
-	// uiLogger is an optional file-based logger injected from main
-	uiLogger logger.Logger
-)
+// uiLogger is an optional file-based logger injected from main
+var uiLogger logger.Logger
 
 // SetLogger allows the main application to inject a file-based logger for UI debug events
 func SetLogger(l logger.Logger) { uiLogger = l }
 
 type AddNetworkComponent struct {
-	id     string
-	width  int
-	height int
-	err    error
-	adding bool
+	id      string
+	width   int
+	height  int
+	err     error
+	adding  bool
+	editing bool // Saves over a configured network instead of adding one
 
 	// Text input fields
 	searchInput      textinput.Model
@@ -201,6 +196,18 @@ func (c *AddNetworkComponent) Reset() {
 	c.initInputs()
 }
 
+// EditNetwork fills the form with a configured network and focuses its name,
+// so saving replaces it instead of adding another
+func (c *AddNetworkComponent) EditNetwork(network config.Network) {
+	c.nameInput.SetValue(network.Name)
+	c.chainIDInput.SetValue(strconv.FormatInt(network.ChainID, 10))
+	c.symbolInput.SetValue(network.Symbol)
+	c.rpcEndpointInput.SetValue(network.RPCEndpoint)
+	c.editing = true
+	c.focusIndex = 1
+	c.updateFocus()
+}
+
 // searchNetworks searches for networks based on the query
 func (c *AddNetworkComponent) searchNetworks(query string) tea.Cmd {
 	return func() tea.Msg {
@@ -481,62 +488,17 @@ func (c *AddNetworkComponent) Update(msg tea.Msg) (*AddNetworkComponent, tea.Cmd
 			switch c.focusIndex {
 			case 0: // Search input
 				oldValue := c.searchInput.Value()
-				// ARM64-specific handling: manually insert printable runes when Bubble Tea fails to echo runes
-				if archDetector() == "arm64" {
-					// Handle backspace manually as a fallback
-					if msg.String() == "backspace" && len(oldValue) > 0 {
-						// remove last rune safely
-						newVal := removeLastRune(oldValue)
-						c.searchInput.SetValue(newVal)
-						if uiLogger != nil {
-							uiLogger.Debug("input_key_arm64_backspace",
-								logger.String("value", newVal),
-							)
-						}
-						c.loadingSuggestions = true
-						c.selectedSuggestion = -1
-						cmds = append(cmds, c.searchNetworks(newVal))
-						break
-					}
-					if msg.Type == tea.KeyRunes && len(msg.Runes) > 0 {
-						var b strings.Builder
-						for _, r := range msg.Runes {
-							if r == '\n' || r == '\r' || r == '\t' {
-								continue
-							}
-							if unicode.IsPrint(r) || unicode.IsSpace(r) {
-								b.WriteRune(r)
-							}
-						}
-						if b.Len() > 0 {
-							newVal := oldValue + b.String()
-							if len([]rune(newVal)) > 120 {
-								newVal = truncateRunes(newVal, 120)
-							}
-							c.searchInput.SetValue(newVal)
-							if uiLogger != nil {
-								uiLogger.Debug("input_key_arm64_runes",
-									logger.String("key", msg.String()),
-									logger.Int("runes", len(msg.Runes)),
-									logger.String("value", newVal),
-								)
-							}
-							c.loadingSuggestions = true
-							c.selectedSuggestion = -1
-							cmds = append(cmds, c.searchNetworks(newVal))
-							break
-						}
-					}
+				c.searchInput, cmd = updateTextInput(c.searchInput, msg)
+				newValue := truncateRunes(c.searchInput.Value(), 120)
+				if newValue != c.searchInput.Value() {
+					c.searchInput.SetValue(newValue)
 				}
-				// Default handling
-				c.searchInput, cmd = c.searchInput.Update(msg)
-				newValue := c.searchInput.Value()
 				cmds = append(cmds, cmd)
 
 				// Trigger search if value changed
 				if oldValue != newValue {
 					if uiLogger != nil {
-						uiLogger.Debug("input_key_default", logger.String("key", msg.String()), logger.String("value", newValue))
+						uiLogger.Debug("input_key_search", logger.String("key", msg.String()), logger.String("value", newValue))
 					}
 					// Auto-search after a short delay
 					c.loadingSuggestions = true
@@ -545,19 +507,19 @@ func (c *AddNetworkComponent) Update(msg tea.Msg) (*AddNetworkComponent, tea.Cmd
 				}
 
 			case 1: // Name input
-				c.nameInput, cmd = c.nameInput.Update(msg)
+				c.nameInput, cmd = updateTextInput(c.nameInput, msg)
 				cmds = append(cmds, cmd)
 
 			case 2: // Chain ID input
-				c.chainIDInput, cmd = c.chainIDInput.Update(msg)
+				c.chainIDInput, cmd = updateTextInput(c.chainIDInput, msg)
 				cmds = append(cmds, cmd)
 
 			case 3: // Symbol input
-				c.symbolInput, cmd = c.symbolInput.Update(msg)
+				c.symbolInput, cmd = updateTextInput(c.symbolInput, msg)
 				cmds = append(cmds, cmd)
 
 			case 4: // RPC endpoint input
-				c.rpcEndpointInput, cmd = c.rpcEndpointInput.Update(msg)
+				c.rpcEndpointInput, cmd = updateTextInput(c.rpcEndpointInput, msg)
 				cmds = append(cmds, cmd)
 			}
 		}
@@ -567,15 +529,15 @@ func (c *AddNetworkComponent) Update(msg tea.Msg) (*AddNetworkComponent, tea.Cmd
 			var cmd tea.Cmd
 			switch c.focusIndex {
 			case 1:
-				c.nameInput, cmd = c.nameInput.Update(msg)
+				c.nameInput, cmd = updateTextInput(c.nameInput, msg)
 			case 2:
-				c.chainIDInput, cmd = c.chainIDInput.Update(msg)
+				c.chainIDInput, cmd = updateTextInput(c.chainIDInput, msg)
 			case 3:
-				c.symbolInput, cmd = c.symbolInput.Update(msg)
+				c.symbolInput, cmd = updateTextInput(c.symbolInput, msg)
 			case 4:
-				c.rpcEndpointInput, cmd = c.rpcEndpointInput.Update(msg)
+				c.rpcEndpointInput, cmd = updateTextInput(c.rpcEndpointInput, msg)
 			default:
-				c.searchInput, cmd = c.searchInput.Update(msg)
+				c.searchInput, cmd = updateTextInput(c.searchInput, msg)
 			}
 			cmds = append(cmds, cmd)
 		}
@@ -714,26 +676,30 @@ func (c *AddNetworkComponent) View() string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FFF")).
-		Background(lipgloss.Color("#874BFD")).
+		Foreground(palette.SelectionFg).
+		Background(palette.Accent).
 		MarginLeft(2).
 		MarginBottom(1)
-	b.WriteString(headerStyle.Render("🌐 " + localization.Labels["add_network"]))
+	title, status, footer := localization.Labels["add_network"], localization.Labels["adding_network"], localization.Labels["add_network_footer"]
+	if c.editing {
+		title, status, footer = localization.Labels["edit_network"], localization.Labels["saving_network"], localization.Labels["edit_network_footer"]
+	}
+	b.WriteString(headerStyle.Render("🌐 " + title))
 	b.WriteString("\n\n")
 
 	// Styles
 	fieldStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("250")).
+		Foreground(palette.Text).
 		MarginLeft(2).
 		MarginBottom(1)
 
 	labelStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#874BFD"))
+		Foreground(palette.Accent)
 
 	searchLabelStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("13"))
+		Foreground(palette.Accent)
 
 	// Search field
 	b.WriteString(searchLabelStyle.Render("🔍 " + localization.Labels["search_networks"] + ":"))
@@ -742,31 +708,31 @@ func (c *AddNetworkComponent) View() string {
 	if c.focusIndex == 0 {
 		searchFieldStyle = fieldStyle.
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
+			BorderForeground(palette.Accent).
 			PaddingLeft(1).PaddingRight(1)
 	}
 	b.WriteString(searchFieldStyle.Render(c.searchInput.View()))
 
 	// Styles for messages
 	infoStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#666666")).
+		Foreground(palette.Muted).
 		MarginLeft(2)
 
 	loadingStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#874BFD")).
+		Foreground(palette.Accent).
 		MarginLeft(2)
 
 	errorStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FF0000")).
+		Foreground(palette.Error).
 		MarginLeft(2)
 
 	warningStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FFA500")).
+		Foreground(palette.Warning).
 		Bold(true).
 		MarginLeft(2)
 
 	footerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#874BFD")).
+		Foreground(palette.Accent).
 		MarginTop(1)
 
 	// Interactive suggestions
@@ -781,8 +747,8 @@ func (c *AddNetworkComponent) View() string {
 	b.WriteString("\n\n")
 	detailHeaderStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FFF")).
-		Background(lipgloss.Color("#874BFD")).
+		Foreground(palette.SelectionFg).
+		Background(palette.Accent).
 		MarginLeft(2).
 		MarginBottom(1)
 	b.WriteString(detailHeaderStyle.Render(localization.Labels["network_details"] + ":"))
@@ -795,7 +761,7 @@ func (c *AddNetworkComponent) View() string {
 	if c.focusIndex == 1 {
 		nameFieldStyle = fieldStyle.
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
+			BorderForeground(palette.Accent).
 			PaddingLeft(1).PaddingRight(1)
 	}
 	b.WriteString(nameFieldStyle.Render(c.nameInput.View()))
@@ -808,7 +774,7 @@ func (c *AddNetworkComponent) View() string {
 	if c.focusIndex == 2 {
 		chainFieldStyle = fieldStyle.
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
+			BorderForeground(palette.Accent).
 			PaddingLeft(1).PaddingRight(1)
 	}
 	b.WriteString(chainFieldStyle.Render(c.chainIDInput.View()))
@@ -821,7 +787,7 @@ func (c *AddNetworkComponent) View() string {
 	if c.focusIndex == 3 {
 		symbolFieldStyle = fieldStyle.
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
+			BorderForeground(palette.Accent).
 			PaddingLeft(1).PaddingRight(1)
 	}
 	b.WriteString(symbolFieldStyle.Render(c.symbolInput.View()))
@@ -834,7 +800,7 @@ func (c *AddNetworkComponent) View() string {
 	if c.focusIndex == 4 {
 		rpcFieldStyle = fieldStyle.
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
+			BorderForeground(palette.Accent).
 			PaddingLeft(1).PaddingRight(1)
 	}
 	b.WriteString(rpcFieldStyle.Render(c.rpcEndpointInput.View()))
@@ -843,7 +809,7 @@ func (c *AddNetworkComponent) View() string {
 	// Status messages
 	if c.adding {
 		b.WriteString("\n")
-		b.WriteString(loadingStyle.Render("⏳ " + localization.Labels["adding_network"] + "..."))
+		b.WriteString(loadingStyle.Render("⏳ " + status + "..."))
 	} else if c.err != nil {
 		b.WriteString("\n")
 		b.WriteString(errorStyle.Render("❌ " + localization.Labels["error_title"] + ": " + c.err.Error()))
@@ -861,7 +827,7 @@ func (c *AddNetworkComponent) View() string {
 	b.WriteString("\n\n")
 
 	// Footer
-	b.WriteString(footerStyle.Render(localization.Labels["add_network_footer"]))
+	b.WriteString(footerStyle.Render(footer))
 
 	return b.String()
 }
@@ -879,23 +845,3 @@ type networkSuggestionsMsg []blockchain.NetworkSuggestion
 
 // errorMsg is sent when an error occurs
 type errorMsg string
-
-// --- helpers: rune-safe operations for ARM64 fallback ---
-func removeLastRune(s string) string {
-	r := []rune(s)
-	if len(r) == 0 {
-		return s
-	}
-	return string(r[:len(r)-1])
-}
-
-func truncateRunes(s string, n int) string {
-	r := []rune(s)
-	if n < 0 {
-		return ""
-	}
-	if len(r) <= n {
-		return s
-	}
-	return string(r[:n])
-}
@@ -0,0 +1,32 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// readAddress parses an address typed in a form, returning invalid as the
+// error when it is not one. A mixed-case address that fails its EIP-55
+// checksum is refused once with a warning naming the expected form; entering
+// the same text again accepts it.
+func (m *CLIModel) readAddress(input, invalid string) (common.Address, error) {
+	input = strings.TrimSpace(input)
+	address, err := wallet.ParseAddress(input)
+	switch {
+	case errors.Is(err, wallet.ErrAddressChecksum):
+		if m.addressChecksumWarned == input {
+			return address, nil
+		}
+		m.addressChecksumWarned = input
+		return common.Address{}, fmt.Errorf(localization.Labels["address_checksum_mismatch"], address.Hex())
+	case err != nil:
+		return common.Address{}, errors.New(invalid)
+	}
+	return address, nil
+}
@@ -0,0 +1,316 @@
+package ui
+
+import (
+	"blocowallet/internal/compliance"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	backupFocusMode = iota
+	backupFocusPath
+	backupFocusPassword
+	backupFocusConfirm
+	backupFocusKey
+)
+
+// backupDoneMsg carries the outcome of a restore run in the background
+type backupDoneMsg struct {
+	manifest *wallet.BackupManifest
+	err      error
+}
+
+// initBackupView opens the encrypted backup screen from the configuration menu
+func (m *CLIModel) initBackupView() {
+	m.backupRestore = false
+	m.backupPathInput = textinput.New()
+	m.backupPathInput.Placeholder = localization.Labels["backup_file_placeholder"]
+	m.backupPathInput.CharLimit = 512
+	m.backupPathInput.Width = 70
+	m.backupPasswordInput = newExportPasswordInput(localization.Labels["backup_password_placeholder"])
+	m.backupConfirmInput = newExportPasswordInput(localization.Labels["backup_confirm_placeholder"])
+	m.backupKeyInput = textinput.New()
+	m.backupKeyInput.Placeholder = localization.Labels["backup_key_placeholder"]
+	m.backupKeyInput.CharLimit = 512
+	m.backupKeyInput.Width = 70
+	m.setBackupResult("", false)
+	m.setBackupFocus(backupFocusPath)
+	m.currentView = constants.BackupView
+}
+
+func (m *CLIModel) setBackupFocus(focus int) {
+	m.backupFocus = focus
+	m.backupPathInput.Blur()
+	m.backupPasswordInput.Blur()
+	m.backupConfirmInput.Blur()
+	m.backupKeyInput.Blur()
+	switch focus {
+	case backupFocusPath:
+		m.backupPathInput.Focus()
+	case backupFocusPassword:
+		m.backupPasswordInput.Focus()
+	case backupFocusConfirm:
+		m.backupConfirmInput.Focus()
+	case backupFocusKey:
+		m.backupKeyInput.Focus()
+	}
+}
+
+// stepBackupFocus moves the focus to the next or previous field; a restore
+// has no password confirmation but takes the key of scheduled backups
+func (m *CLIModel) stepBackupFocus(step int) {
+	fields := []int{backupFocusMode, backupFocusPath, backupFocusPassword, backupFocusConfirm}
+	if m.backupRestore {
+		fields = []int{backupFocusMode, backupFocusPath, backupFocusKey, backupFocusPassword}
+	}
+	for i, field := range fields {
+		if field == m.backupFocus {
+			m.setBackupFocus(fields[(i+step+len(fields))%len(fields)])
+			return
+		}
+	}
+	m.setBackupFocus(backupFocusMode)
+}
+
+func (m *CLIModel) setBackupResult(status string, failed bool) {
+	m.backupStatus = status
+	m.backupFailed = failed
+}
+
+// updateBackup handles input on the encrypted backup screen
+func (m *CLIModel) updateBackup(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.backupRunning {
+		return m, nil
+	}
+
+	if text, ok := pastedText(msg); ok && m.backupFocus == backupFocusPath {
+		m.backupPathInput.SetValue(cleanPastedPath(text))
+		m.backupPathInput.CursorEnd()
+		return m, nil
+	}
+	if text, ok := pastedText(msg); ok && m.backupFocus == backupFocusKey {
+		m.backupKeyInput.SetValue(cleanPastedPath(text))
+		m.backupKeyInput.CursorEnd()
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "tab", "down":
+		m.stepBackupFocus(1)
+		return m, nil
+	case "shift+tab", "up":
+		m.stepBackupFocus(-1)
+		return m, nil
+	case "enter":
+		if m.backupRestore {
+			return m, m.runBackupRestore()
+		}
+		return m, m.runBackupExport()
+	}
+
+	var cmd tea.Cmd
+	switch m.backupFocus {
+	case backupFocusMode:
+		switch keyMsg.String() {
+		case "left", "h", "right", " ", "l":
+			m.backupRestore = !m.backupRestore
+			m.backupConfirmInput.SetValue("")
+			m.setBackupResult("", false)
+		}
+	case backupFocusPath:
+		m.backupPathInput, cmd = updateTextInput(m.backupPathInput, msg)
+	case backupFocusPassword:
+		m.backupPasswordInput, cmd = updateTextInput(m.backupPasswordInput, msg)
+	case backupFocusConfirm:
+		m.backupConfirmInput, cmd = updateTextInput(m.backupConfirmInput, msg)
+	case backupFocusKey:
+		m.backupKeyInput, cmd = updateTextInput(m.backupKeyInput, msg)
+	}
+	return m, cmd
+}
+
+// runBackupExport writes the backup; exporting every keystore needs the same
+// approval as exporting one secret in compliance mode
+func (m *CLIModel) runBackupExport() tea.Cmd {
+	password := m.backupPasswordInput.Value()
+	if validationErr, ok := wallet.ValidatePassword(password); !ok {
+		m.setBackupResult(validationErr.GetErrorMessage(), true)
+		m.setBackupFocus(backupFocusPassword)
+		return nil
+	}
+	if m.backupConfirmInput.Value() != password {
+		m.setBackupResult(localization.Labels["backup_password_mismatch"], true)
+		m.backupConfirmInput.SetValue("")
+		m.setBackupFocus(backupFocusConfirm)
+		return nil
+	}
+
+	outputPath := strings.TrimSpace(m.backupPathInput.Value())
+	if outputPath == "" {
+		outputPath = wallet.DefaultBackupFileName(time.Now())
+	}
+	if abs, err := filepath.Abs(outputPath); err == nil {
+		outputPath = abs
+	}
+
+	var manifest *wallet.BackupManifest
+	m.setBackupResult("", false)
+	return m.withApproval(approvedAction{
+		action:  compliance.ActionExportSecret,
+		subject: "all wallets backup",
+		run: func() error {
+			var err error
+			manifest, err = m.Service.ExportBackup(outputPath, password)
+			return err
+		},
+		done: func(err error) tea.Cmd {
+			if err != nil {
+				m.setBackupResult(err.Error(), true)
+				return nil
+			}
+			m.backupPasswordInput.SetValue("")
+			m.backupConfirmInput.SetValue("")
+			m.setBackupResult(fmt.Sprintf(localization.Labels["backup_export_success"], manifest.Wallets, manifest.Keystores(), outputPath), false)
+			return m.notifyBackupCompleted("encrypted wallet backup", outputPath)
+		},
+	})
+}
+
+// runBackupRestore checks and restores the backup in the background. With a
+// key file the password is the passphrase of the key and may be empty.
+func (m *CLIModel) runBackupRestore() tea.Cmd {
+	path := strings.TrimSpace(m.backupPathInput.Value())
+	if path == "" {
+		m.setBackupResult(localization.Labels["backup_file_required"], true)
+		m.setBackupFocus(backupFocusPath)
+		return nil
+	}
+	key := wallet.BackupKey{Password: m.backupPasswordInput.Value(), IdentityFile: strings.TrimSpace(m.backupKeyInput.Value())}
+	if key.Password == "" && key.IdentityFile == "" {
+		m.setBackupResult(localization.Labels["backup_password_required"], true)
+		m.setBackupFocus(backupFocusPassword)
+		return nil
+	}
+
+	m.backupRunning = true
+	m.setBackupResult(localization.Labels["backup_restoring"], false)
+	service := m.Service
+	return func() tea.Msg {
+		manifest, err := service.ImportBackupWithKey(path, key)
+		return backupDoneMsg{manifest: manifest, err: err}
+	}
+}
+
+// handleBackupDone shows the outcome of a restore and reloads the wallets
+func (m *CLIModel) handleBackupDone(msg backupDoneMsg) tea.Cmd {
+	m.backupRunning = false
+	switch {
+	case errors.Is(msg.err, wallet.ErrBackupPassword):
+		m.setBackupResult(localization.Labels["backup_wrong_password"], true)
+		m.setBackupFocus(backupFocusPassword)
+		return nil
+	case errors.Is(msg.err, wallet.ErrBackupKeyRequired):
+		m.setBackupResult(localization.Labels["backup_key_required"], true)
+		m.setBackupFocus(backupFocusKey)
+		return nil
+	case errors.Is(msg.err, wallet.ErrBackupKey):
+		m.setBackupResult(localization.Labels["backup_wrong_key"], true)
+		m.setBackupFocus(backupFocusKey)
+		return nil
+	case errors.Is(msg.err, wallet.ErrBackupCorrupted):
+		m.setBackupResult(fmt.Sprintf(localization.Labels["backup_damaged"], msg.err), true)
+		return nil
+	case errors.Is(msg.err, wallet.ErrBackupVersion):
+		m.setBackupResult(localization.Labels["backup_newer"], true)
+		return nil
+	case errors.Is(msg.err, wallet.ErrNotBackup):
+		m.setBackupResult(localization.Labels["backup_not_backup"], true)
+		return nil
+	case msg.err != nil:
+		m.setBackupResult(msg.err.Error(), true)
+		return nil
+	}
+
+	m.backupPasswordInput.SetValue("")
+	created := msg.manifest.CreatedAt.Local().Format("2006-01-02 15:04")
+	m.setBackupResult(fmt.Sprintf(localization.Labels["backup_restore_success"], msg.manifest.Wallets, msg.manifest.Keystores(), created), false)
+	if state, err := m.Service.MasterPasswordState(); err == nil && state == wallet.MasterPasswordLocked {
+		// The restored database keeps the master password it was backed up with
+		m.initMasterPasswordView(masterModeUnlock)
+		m.masterRestored = true
+		return nil
+	}
+	return m.refreshWalletsTable()
+}
+
+// viewBackup renders the encrypted backup screen
+func (m *CLIModel) viewBackup() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["backup_title"]))
+	b.WriteString("\n\n")
+	if m.backupRestore {
+		b.WriteString(localization.Labels["backup_restore_desc"])
+	} else {
+		b.WriteString(localization.Labels["backup_export_desc"])
+	}
+	b.WriteString("\n\n")
+
+	mode := localization.Labels["backup_mode_export"]
+	if m.backupRestore {
+		mode = localization.Labels["backup_mode_restore"]
+	}
+	modeLine := fmt.Sprintf("%s < %s >", localization.Labels["backup_mode"], mode)
+	if m.backupFocus == backupFocusMode {
+		modeLine = m.styles.SelectedTitle.Render(modeLine)
+	}
+	b.WriteString(modeLine)
+	b.WriteString("\n\n")
+
+	b.WriteString(localization.Labels["backup_file"])
+	b.WriteString("\n")
+	b.WriteString(m.backupPathInput.View())
+	b.WriteString("\n\n")
+	if m.backupRestore {
+		b.WriteString(localization.Labels["backup_key"])
+		b.WriteString("\n")
+		b.WriteString(m.backupKeyInput.View())
+		b.WriteString("\n\n")
+	}
+	b.WriteString(localization.Labels["backup_password"])
+	b.WriteString("\n")
+	b.WriteString(m.backupPasswordInput.View())
+	b.WriteString("\n")
+	if !m.backupRestore {
+		b.WriteString(m.backupConfirmInput.View())
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if notice := m.renderApprovalNotice(); notice != "" {
+		b.WriteString(notice)
+		b.WriteString("\n\n")
+	}
+	if m.backupStatus != "" {
+		switch {
+		case m.backupRunning:
+			b.WriteString(m.styles.MenuDesc.Render(m.backupStatus))
+		case m.backupFailed:
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.backupStatus))
+		default:
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.backupStatus))
+		}
+		b.WriteString("\n\n")
+	}
+	b.WriteString(m.styles.MenuDesc.Render(localization.Labels["backup_help"]))
+	return b.String()
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBackupModel(t *testing.T) *CLIModel {
+	t.Helper()
+	localization.SetCurrentLanguage("en")
+	localization.AddBackupMessages()
+
+	m := &CLIModel{
+		Service:     &wallet.WalletService{},
+		styles:      createStyles(),
+		currentView: constants.ConfigurationView,
+	}
+	m.initBackupView()
+	require.Equal(t, constants.BackupView, m.currentView)
+	return m
+}
+
+func TestBackupView_Export(t *testing.T) {
+	m := newBackupModel(t)
+	assert.True(t, m.capturesTextInput(), "q is typed into the file name")
+
+	m.backupPathInput.SetValue(filepath.Join(t.TempDir(), "wallets.backup"))
+	m.backupPasswordInput.SetValue("Backup-Pass1")
+	m.backupConfirmInput.SetValue("Backup-Pass2")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, m.backupFailed)
+	assert.Equal(t, localization.Labels["backup_password_mismatch"], m.backupStatus)
+	assert.Equal(t, backupFocusConfirm, m.backupFocus)
+
+	// A repository without backup support reports it instead of writing a file
+	m.backupConfirmInput.SetValue("Backup-Pass1")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, m.backupFailed)
+	assert.Equal(t, wallet.ErrBackupUnsupported.Error(), m.backupStatus)
+}
+
+func TestBackupView_Restore(t *testing.T) {
+	m := newBackupModel(t)
+	m.setBackupFocus(backupFocusMode)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	require.True(t, m.backupRestore)
+	assert.Contains(t, m.viewBackup(), localization.Labels["backup_mode_restore"])
+
+	// Restoring has no confirmation field
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	assert.Equal(t, backupFocusPassword, m.backupFocus)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.Equal(t, localization.Labels["backup_file_required"], m.backupStatus)
+
+	m.backupPathInput.SetValue("wallets.backup")
+	m.backupPasswordInput.SetValue("Backup-Pass1")
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.True(t, m.backupRunning)
+
+	m.handleBackupDone(backupDoneMsg{err: wallet.ErrBackupPassword})
+	assert.False(t, m.backupRunning)
+	assert.Equal(t, localization.Labels["backup_wrong_password"], m.backupStatus)
+	// A scheduled backup is encrypted to a key and asks for its file
+	m.handleBackupDone(backupDoneMsg{err: wallet.ErrBackupKeyRequired})
+	assert.Equal(t, localization.Labels["backup_key_required"], m.backupStatus)
+	assert.Equal(t, backupFocusKey, m.backupFocus)
+	assert.Contains(t, m.viewBackup(), localization.Labels["backup_key"])
+	m.handleBackupDone(backupDoneMsg{err: fmt.Errorf("%w: wallets.db does not match the manifest", wallet.ErrBackupCorrupted)})
+	assert.Contains(t, m.backupStatus, "does not match the manifest")
+
+	m.handleBackupDone(backupDoneMsg{manifest: &wallet.BackupManifest{
+		Wallets: 2,
+		Files:   []wallet.BackupFile{{Name: "wallets.db"}, {Name: "keystore/UTC--1"}},
+	}})
+	assert.False(t, m.backupFailed)
+	assert.Contains(t, m.backupStatus, "Restored 2 wallets and 1 keystores")
+	assert.Empty(t, m.backupPasswordInput.Value())
+}
@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"blocowallet/internal/blockchain"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// balanceUpdateMsg carries the balance of the displayed wallet on one network
+type balanceUpdateMsg struct {
+	seq     int
+	balance blockchain.NetworkBalance
+	updates <-chan blockchain.NetworkBalance
+}
+
+// balanceService returns the shared balance cache, creating it on first use
+func (m *CLIModel) balanceService() *blockchain.BalanceService {
+	if m.balanceCache == nil {
+		m.balanceCache = blockchain.NewBalanceService(blockchain.DefaultBalanceTTL)
+	}
+	return m.balanceCache
+}
+
+// fetchBalances starts reading the balances of the displayed wallet on every
+// usable network, through its RPC overrides, native and in the followed
+// tokens. With refresh the cached native ones are dropped first.
+func (m *CLIModel) fetchBalances(refresh bool) tea.Cmd {
+	m.balanceSeq++
+	m.balances = make(map[string]blockchain.NetworkBalance)
+	m.balanceNetworks = make(map[string]config.Network)
+	m.tokenBalances = make(map[string][]blockchain.TokenBalance)
+	if m.walletDetails == nil {
+		return nil
+	}
+	if m.currentConfig == nil {
+		if err := m.ensureConfigAndNetworksLoaded(); err != nil {
+			log.Printf("Warning: failed to load networks/config: %v", err)
+			return nil
+		}
+	}
+
+	address := m.walletDetails.Wallet.Address
+	for key, network := range m.currentConfig.Networks {
+		if network.Usable() && network.RPCEndpoint != "" && !m.hidesNetwork(network) {
+			m.balanceNetworks[key] = m.Service.NetworkFor(address, network)
+		}
+	}
+	if len(m.balanceNetworks) == 0 {
+		return nil
+	}
+	if refresh {
+		m.balanceService().Invalidate(address)
+	}
+	updates := m.balanceService().Fetch(context.Background(), address, m.balanceNetworks)
+	return tea.Batch(waitForBalance(m.balanceSeq, updates), m.fetchTokenBalances())
+}
+
+// hidesNetwork reports whether hide_testnets leaves the network out of the
+// balances
+func (m *CLIModel) hidesNetwork(network config.Network) bool {
+	return network.Testnet && m.currentConfig != nil && m.currentConfig.Balances.HideTestnets
+}
+
+// hiddenTestnets counts the usable test networks left out of the balances
+func (m *CLIModel) hiddenTestnets() int {
+	if m.currentConfig == nil {
+		return 0
+	}
+	hidden := 0
+	for _, network := range m.currentConfig.Networks {
+		if network.Usable() && network.RPCEndpoint != "" && m.hidesNetwork(network) {
+			hidden++
+		}
+	}
+	return hidden
+}
+
+// waitForBalance delivers the next balance to arrive, one message at a time,
+// until every network answered
+func waitForBalance(seq int, updates <-chan blockchain.NetworkBalance) tea.Cmd {
+	return func() tea.Msg {
+		balance, ok := <-updates
+		if !ok {
+			return nil
+		}
+		return balanceUpdateMsg{seq: seq, balance: balance, updates: updates}
+	}
+}
+
+// handleBalanceUpdate shows one balance and waits for the next
+func (m *CLIModel) handleBalanceUpdate(msg balanceUpdateMsg) tea.Cmd {
+	if msg.seq != m.balanceSeq {
+		// Balances of a wallet no longer displayed
+		return nil
+	}
+	m.balances[msg.balance.NetworkKey] = msg.balance
+	return waitForBalance(msg.seq, msg.updates)
+}
+
+// renderWalletBalances lists the balance of the displayed wallet on each
+// network, as the answers arrive
+func (m *CLIModel) renderWalletBalances() string {
+	if m.walletDetails == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Balance Information:\n"))
+	hiddenTestnets := m.hiddenTestnets()
+	if len(m.balanceNetworks) == 0 {
+		b.WriteString(localization.Labels["balance_no_networks"] + "\n")
+		if hiddenTestnets > 0 {
+			b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf(localization.Labels["balance_testnets_hidden"], hiddenTestnets)))
+			b.WriteString("\n")
+		}
+		return b.String()
+	}
+
+	// Test networks are listed after the others under their own heading, so
+	// Sepolia ETH is not read as mainnet ETH
+	keys := make([]string, 0, len(m.balanceNetworks))
+	for key := range m.balanceNetworks {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := m.balanceNetworks[keys[i]], m.balanceNetworks[keys[j]]
+		if a.Testnet != b.Testnet {
+			return !a.Testnet
+		}
+		return a.Name < b.Name
+	})
+
+	hideDust := m.currentConfig.Balances.HideDust && !m.showDust
+	hidden := 0
+	testnetHeading := false
+	for _, key := range keys {
+		network := m.balanceNetworks[key]
+		if network.Testnet && !testnetHeading {
+			testnetHeading = true
+			b.WriteString(lipgloss.NewStyle().Bold(true).Render("🧪 " + localization.Labels["balance_testnets"]))
+			b.WriteString("\n")
+		}
+		name := network.Name
+		if configured, ok := m.currentConfig.Networks[key]; ok && configured.RPCEndpoint != network.RPCEndpoint {
+			name += " (" + localization.Labels["wallet_details_rpc_override"] + ")"
+		}
+		balance, ok := m.balances[key]
+		switch {
+		case !ok:
+			b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf("… %s: %s", name, localization.Labels["balance_loading"])))
+			b.WriteString("\n")
+		case balance.Error != nil:
+			b.WriteString(fmt.Sprintf("❌ %s: %s\n", name, balance.Error))
+		case hideDust && isDust(balance.Amount, balance.Decimals, network.Symbol, m.currentConfig.Balances.NativeDust, m.currentConfig.Balances):
+			hidden++
+		default:
+			b.WriteString(fmt.Sprintf("🔹 %s: %s %s\n", name, formatBalance(balance.Amount, balance.Decimals), network.Symbol))
+		}
+		tokens := m.tokenBalances[key]
+		sort.Slice(tokens, func(i, j int) bool { return tokens[i].Token.Symbol < tokens[j].Token.Symbol })
+		for _, t := range tokens {
+			switch {
+			case t.Error != nil:
+				b.WriteString(fmt.Sprintf("   ❌ %s: %s\n", t.Token.Symbol, t.Error))
+			case hideDust && isDust(t.Amount, t.Token.Decimals, t.Token.Symbol, m.currentConfig.Balances.TokenDust, m.currentConfig.Balances):
+				hidden++
+			default:
+				b.WriteString(fmt.Sprintf("   └ %s %s\n", formatBalance(t.Amount, t.Token.Decimals), t.Token.Symbol))
+			}
+		}
+	}
+	switch {
+	case hidden > 0:
+		b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf(localization.Labels["balance_dust_hidden"], hidden)))
+		b.WriteString("\n")
+	case m.showDust && m.currentConfig.Balances.HideDust:
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["balance_dust_shown"]))
+		b.WriteString("\n")
+	}
+	if hiddenTestnets > 0 {
+		b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf(localization.Labels["balance_testnets_hidden"], hiddenTestnets)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// isDust reports whether an amount with decimals digits is too small to show:
+// worth less than fiat_dust when symbol has a price, below threshold whole
+// units otherwise. Zero is dust only with hide_zero
+func isDust(amount *big.Int, decimals int, symbol, threshold string, cfg config.BalancesConfig) bool {
+	if amount == nil || amount.Sign() == 0 {
+		return cfg.HideZero
+	}
+	value := new(big.Rat).SetFrac(amount, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	if price, ok := cfg.Price(symbol); ok {
+		if unit, ok := new(big.Rat).SetString(price); ok {
+			value.Mul(value, unit)
+			threshold = cfg.FiatDust
+		}
+	}
+	limit, ok := new(big.Rat).SetString(threshold)
+	if !ok {
+		return false
+	}
+	return value.Cmp(limit) < 0
+}
+
+// formatBalance shows an amount in whole units with six decimals
+func formatBalance(amount *big.Int, decimals int) string {
+	value := new(big.Float).SetInt(amount)
+	value.Quo(value, new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	return value.Text('f', 6)
+}
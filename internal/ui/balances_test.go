@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drainBalances runs the balance commands until every network answered
+func drainBalances(m *CLIModel, cmd tea.Cmd) {
+	for cmd != nil {
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for _, c := range batch {
+				drainBalances(m, c)
+			}
+			return
+		}
+		if msg == nil {
+			return
+		}
+		_, cmd = m.Update(msg)
+	}
+}
+
+func TestWalletBalances_StreamAndRefresh(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddBalanceMessages()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1bc16d674ec80000"}`))
+	}))
+	defer server.Close()
+
+	m := &CLIModel{styles: createStyles(), currentView: constants.WalletDetailsView}
+	m.currentConfig = &config.Config{Networks: map[string]config.Network{
+		"sepolia": {Name: "Sepolia", ChainID: 11155111, Symbol: "SEP", RPCEndpoint: server.URL, IsActive: true},
+		"down":    {Name: "Down", ChainID: 17000, Symbol: "ETH", RPCEndpoint: "http://127.0.0.1:1", IsActive: true},
+		"off":     {Name: "Off", ChainID: 10, Symbol: "ETH", RPCEndpoint: server.URL},
+	}}
+	m.walletDetails = &wallet.WalletDetails{Wallet: &wallet.Wallet{Name: "Hot", Address: "0x00000000000000000000000000000000000000aa"}}
+
+	cmd := m.fetchBalances(false)
+	require.NotNil(t, cmd)
+	assert.Contains(t, m.renderWalletBalances(), "Sepolia: loading…")
+	assert.NotContains(t, m.renderWalletBalances(), "Off")
+
+	drainBalances(m, cmd)
+	view := m.renderWalletBalances()
+	assert.Contains(t, view, "Sepolia: 2.000000 SEP")
+	assert.Contains(t, view, "❌ Down")
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Reopening the details answers from the cache; 'r' asks again
+	drainBalances(m, m.fetchBalances(false))
+	assert.Equal(t, int32(1), calls.Load())
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	drainBalances(m, cmd)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// Answers for a wallet no longer displayed are dropped
+	stale := m.fetchBalances(false)
+	m.fetchBalances(false)
+	_, next := m.Update(stale())
+	assert.Nil(t, next)
+}
+
+func TestWalletBalances_HideDust(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddBalanceMessages()
+
+	m := &CLIModel{styles: createStyles(), currentView: constants.WalletDetailsView}
+	m.currentConfig = &config.Config{
+		Networks: map[string]config.Network{
+			"main": {Name: "Mainnet", ChainID: 1, Symbol: "ETH", RPCEndpoint: "http://main", IsActive: true},
+			"test": {Name: "Testnet", ChainID: 5, Symbol: "GOR", RPCEndpoint: "http://test", IsActive: true},
+		},
+		Balances: config.BalancesConfig{HideDust: true, NativeDust: "0.0001", TokenDust: "0.01"},
+	}
+	m.walletDetails = &wallet.WalletDetails{Wallet: &wallet.Wallet{Name: "Hot", Address: "0x00000000000000000000000000000000000000aa"}}
+	m.balanceNetworks = m.currentConfig.Networks
+	m.balances = map[string]blockchain.NetworkBalance{
+		"main": {NetworkKey: "main", Decimals: 18, Amount: big.NewInt(2e18)},
+		"test": {NetworkKey: "test", Decimals: 18, Amount: big.NewInt(5e13)}, // 0.00005
+	}
+	m.tokenBalances = map[string][]blockchain.TokenBalance{"main": {
+		{Token: blockchain.Token{Symbol: "USDC", Decimals: 6}, Amount: big.NewInt(2500000)},
+		{Token: blockchain.Token{Symbol: "SPAM", Decimals: 18}, Amount: big.NewInt(1)},
+		{Token: blockchain.Token{Symbol: "NIL", Decimals: 0}, Amount: new(big.Int)},
+	}}
+
+	view := m.renderWalletBalances()
+	assert.Contains(t, view, "Mainnet: 2.000000 ETH")
+	assert.Contains(t, view, "2.500000 USDC")
+	assert.NotContains(t, view, "Testnet")
+	assert.NotContains(t, view, "SPAM")
+	assert.Contains(t, view, "0.000000 NIL", "a followed token with nothing in it stays listed")
+	assert.Contains(t, view, "2 small balances hidden")
+
+	// 'h' shows every balance, and again hides the small ones
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	view = m.renderWalletBalances()
+	assert.Contains(t, view, "Testnet: 0.000050 GOR")
+	assert.Contains(t, view, "SPAM")
+	assert.Contains(t, view, "Showing every balance")
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	assert.NotContains(t, m.renderWalletBalances(), "SPAM")
+
+	// Turned off in the config, nothing is hidden nor mentioned
+	m.currentConfig.Balances.HideDust = false
+	view = m.renderWalletBalances()
+	assert.Contains(t, view, "SPAM")
+	assert.NotContains(t, view, "'h'")
+}
+
+func TestIsDust(t *testing.T) {
+	cfg := config.BalancesConfig{FiatDust: "1", Prices: map[string]string{"eth": "2500", "bad": "n/a"}}
+	wei := func(s string) *big.Int {
+		v, _ := new(big.Int).SetString(s, 10)
+		return v
+	}
+
+	// Priced: 0.0001 ETH is worth 0.25, below the fiat threshold even though
+	// it meets the unit one; 0.001 ETH is worth 2.5
+	assert.True(t, isDust(wei("100000000000000"), 18, "ETH", "0.0001", cfg))
+	assert.False(t, isDust(wei("1000000000000000"), 18, "eth", "0.0001", cfg))
+
+	// Without a usable price the unit threshold applies
+	assert.True(t, isDust(big.NewInt(5000), 6, "USDC", "0.01", cfg))
+	assert.False(t, isDust(big.NewInt(50000), 6, "USDC", "0.01", cfg))
+	assert.True(t, isDust(big.NewInt(5000), 6, "BAD", "0.01", cfg))
+
+	// Zero balances are kept unless hide_zero asks otherwise
+	assert.False(t, isDust(new(big.Int), 18, "ETH", "0.0001", cfg))
+	assert.False(t, isDust(nil, 6, "USDC", "0.01", cfg))
+	cfg.HideZero = true
+	assert.True(t, isDust(new(big.Int), 18, "ETH", "0.0001", cfg))
+	assert.True(t, isDust(nil, 6, "USDC", "0.01", cfg))
+}
+
+func TestWalletBalances_GroupsTestnets(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddBalanceMessages()
+
+	m := &CLIModel{styles: createStyles(), currentView: constants.WalletDetailsView}
+	m.currentConfig = &config.Config{Networks: map[string]config.Network{
+		"sepolia": {Name: "Sepolia", ChainID: 11155111, Symbol: "ETH", RPCEndpoint: "http://sepolia", IsActive: true, Testnet: true},
+		"main":    {Name: "Zeta Mainnet", ChainID: 1, Symbol: "ETH", RPCEndpoint: "http://main", IsActive: true},
+	}}
+	m.walletDetails = &wallet.WalletDetails{Wallet: &wallet.Wallet{Name: "Hot", Address: "0x00000000000000000000000000000000000000aa"}}
+	m.balanceNetworks = m.currentConfig.Networks
+	m.balances = map[string]blockchain.NetworkBalance{
+		"main":    {NetworkKey: "main", Decimals: 18, Amount: big.NewInt(1e18)},
+		"sepolia": {NetworkKey: "sepolia", Decimals: 18, Amount: big.NewInt(3e18)},
+	}
+
+	// Mainnets come first, whatever their name, and test networks under their heading
+	view := m.renderWalletBalances()
+	mainnet := strings.Index(view, "Zeta Mainnet: 1.000000 ETH")
+	heading := strings.Index(view, "Test networks · coins without value:")
+	testnet := strings.Index(view, "Sepolia: 3.000000 ETH")
+	require.True(t, mainnet >= 0 && heading >= 0 && testnet >= 0, view)
+	assert.Less(t, mainnet, heading)
+	assert.Less(t, heading, testnet)
+
+	// hide_testnets leaves them out of the fetch and says how many
+	m.currentConfig.Balances.HideTestnets = true
+	m.fetchBalances(false)
+	assert.Equal(t, []string{"main"}, keysOf(m.balanceNetworks))
+	view = m.renderWalletBalances()
+	assert.NotContains(t, view, "Sepolia")
+	assert.Contains(t, view, "1 test networks hidden")
+}
+
+func keysOf(networks map[string]config.Network) []string {
+	keys := make([]string, 0, len(networks))
+	for key := range networks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,305 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	batchFocusCount = iota
+	batchFocusPattern
+	batchFocusPolicy
+	batchFocusPassword
+	batchFocusOutput
+	batchFocusTotal
+)
+
+// batchCreateStepMsg carries the outcome of creating one wallet of the batch
+type batchCreateStepMsg struct {
+	created wallet.BatchCreatedWallet
+	err     error
+}
+
+// initBatchCreate opens the form to create several wallets at once
+func (m *CLIModel) initBatchCreate() {
+	m.batchCountInput = textinput.New()
+	m.batchCountInput.Placeholder = "10"
+	m.batchCountInput.CharLimit = 4
+	m.batchCountInput.Width = 10
+
+	m.batchPatternInput = textinput.New()
+	m.batchPatternInput.SetValue("wallet-{:03d}")
+	m.batchPatternInput.CharLimit = 50
+	m.batchPatternInput.Width = 50
+
+	m.batchPasswordInput = textinput.New()
+	m.batchPasswordInput.Placeholder = localization.Labels["enter_password"]
+	m.batchPasswordInput.CharLimit = constants.PasswordCharLimit
+	m.batchPasswordInput.Width = constants.PasswordWidth
+	m.batchPasswordInput.EchoMode = textinput.EchoPassword
+	m.batchPasswordInput.EchoCharacter = '•'
+
+	m.batchOutputInput = textinput.New()
+	m.batchOutputInput.Placeholder = localization.Labels["batch_create_output_placeholder"]
+	m.batchOutputInput.CharLimit = 512
+	m.batchOutputInput.Width = 70
+
+	m.batchPolicy = wallet.PasswordShared
+	m.batchCreated = nil
+	m.batchRunning = false
+	m.batchCancelled = false
+	m.batchStatus = ""
+	m.batchFailed = false
+	m.setBatchFocus(batchFocusCount)
+	m.currentView = constants.BatchCreateView
+}
+
+func (m *CLIModel) setBatchFocus(focus int) {
+	m.batchFocus = focus
+	m.batchCountInput.Blur()
+	m.batchPatternInput.Blur()
+	m.batchPasswordInput.Blur()
+	m.batchOutputInput.Blur()
+	switch focus {
+	case batchFocusCount:
+		m.batchCountInput.Focus()
+	case batchFocusPattern:
+		m.batchPatternInput.Focus()
+	case batchFocusPassword:
+		m.batchPasswordInput.Focus()
+	case batchFocusOutput:
+		m.batchOutputInput.Focus()
+	}
+}
+
+// moveBatchFocus steps through the fields, skipping the password when each
+// wallet gets its own
+func (m *CLIModel) moveBatchFocus(step int) {
+	focus := (m.batchFocus + step + batchFocusTotal) % batchFocusTotal
+	if focus == batchFocusPassword && m.batchPolicy == wallet.PasswordPerWallet {
+		focus = (focus + step + batchFocusTotal) % batchFocusTotal
+	}
+	m.setBatchFocus(focus)
+}
+
+// updateBatchCreate handles input on the batch creation screen
+func (m *CLIModel) updateBatchCreate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.batchRunning {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "tab", "down":
+		m.moveBatchFocus(1)
+		return m, nil
+	case "shift+tab", "up":
+		m.moveBatchFocus(-1)
+		return m, nil
+	case "enter":
+		return m, m.startBatchCreate()
+	}
+
+	var cmd tea.Cmd
+	switch m.batchFocus {
+	case batchFocusCount:
+		m.batchCountInput, cmd = updateTextInput(m.batchCountInput, msg)
+	case batchFocusPattern:
+		m.batchPatternInput, cmd = updateTextInput(m.batchPatternInput, msg)
+	case batchFocusPolicy:
+		switch keyMsg.String() {
+		case "left", "right", " ", "h", "l":
+			if m.batchPolicy == wallet.PasswordShared {
+				m.batchPolicy = wallet.PasswordPerWallet
+			} else {
+				m.batchPolicy = wallet.PasswordShared
+			}
+		}
+	case batchFocusPassword:
+		m.batchPasswordInput, cmd = updateTextInput(m.batchPasswordInput, msg)
+	case batchFocusOutput:
+		m.batchOutputInput, cmd = updateTextInput(m.batchOutputInput, msg)
+	}
+	return m, cmd
+}
+
+// startBatchCreate validates the form and creates the first wallet
+func (m *CLIModel) startBatchCreate() tea.Cmd {
+	count, err := strconv.Atoi(strings.TrimSpace(m.batchCountInput.Value()))
+	if err != nil {
+		m.setBatchResult(fmt.Sprintf(localization.Labels["batch_create_invalid_count"], wallet.MaxBatchCreateCount), true)
+		m.setBatchFocus(batchFocusCount)
+		return nil
+	}
+	opts := wallet.BatchCreateOptions{
+		Count:       count,
+		NamePattern: strings.TrimSpace(m.batchPatternInput.Value()),
+		First:       1,
+		Policy:      m.batchPolicy,
+	}
+	if opts.Policy == wallet.PasswordShared {
+		opts.Password = strings.TrimSpace(m.batchPasswordInput.Value())
+	}
+	if err := opts.Validate(); err != nil {
+		m.setBatchResult(err.Error(), true)
+		return nil
+	}
+
+	outputPath := strings.TrimSpace(m.batchOutputInput.Value())
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("wallets-%s.csv", time.Now().Format("20060102-150405"))
+	}
+	if abs, err := filepath.Abs(outputPath); err == nil {
+		outputPath = abs
+	}
+
+	m.batchOptions = opts
+	m.batchOutputPath = outputPath
+	m.batchCreated = nil
+	m.batchRunning = true
+	m.batchCancelled = false
+	m.setBatchResult(fmt.Sprintf(localization.Labels["batch_create_progress"], 0, opts.Count), false)
+	return batchCreateStepCmd(m.Service, opts, 0)
+}
+
+// batchCreateStepCmd creates one wallet in the background. Creating them one
+// command at a time keeps the progress on screen and lets esc stop the batch.
+func batchCreateStepCmd(ws *wallet.WalletService, opts wallet.BatchCreateOptions, index int) tea.Cmd {
+	return func() tea.Msg {
+		created, err := ws.CreateBatchWallet(opts, index)
+		return batchCreateStepMsg{created: created, err: err}
+	}
+}
+
+// handleBatchCreateStep records a created wallet and starts the next one, or
+// writes the CSV once the batch is over
+func (m *CLIModel) handleBatchCreateStep(msg batchCreateStepMsg) tea.Cmd {
+	if !m.batchRunning {
+		return nil
+	}
+	if msg.err == nil {
+		m.batchCreated = append(m.batchCreated, msg.created)
+		m.session.created++
+	} else {
+		m.session.errors++
+	}
+	done := len(m.batchCreated)
+	if msg.err == nil && !m.batchCancelled && done < m.batchOptions.Count {
+		m.setBatchResult(fmt.Sprintf(localization.Labels["batch_create_progress"], done, m.batchOptions.Count), false)
+		return batchCreateStepCmd(m.Service, m.batchOptions, done)
+	}
+
+	m.batchRunning = false
+	// The CSV lists whatever exists, also after a failure or a cancellation
+	writeErr := wallet.WriteBatchCSVFile(m.batchOutputPath, m.batchCreated, m.batchOptions.Policy)
+	switch {
+	case msg.err != nil:
+		m.setBatchResult(fmt.Sprintf(localization.Labels["batch_create_failed"], msg.err, done, m.batchOptions.Count), true)
+	case writeErr != nil:
+		m.setBatchResult(fmt.Sprintf(localization.Labels["batch_create_csv_failed"], writeErr), true)
+	case m.batchCancelled:
+		m.setBatchResult(fmt.Sprintf(localization.Labels["batch_create_cancelled"], done, m.batchOptions.Count, m.batchOutputPath), true)
+	default:
+		m.setBatchResult(fmt.Sprintf(localization.Labels["batch_create_success"], done, m.batchOutputPath), false)
+	}
+	if done == 0 {
+		return nil
+	}
+	return m.refreshWalletsTable()
+}
+
+// cancelBatchCreate stops the batch after the wallet being created
+func (m *CLIModel) cancelBatchCreate() {
+	m.batchCancelled = true
+	m.setBatchResult(localization.Labels["batch_create_stopping"], false)
+}
+
+func (m *CLIModel) setBatchResult(status string, failed bool) {
+	m.batchStatus = status
+	m.batchFailed = failed
+}
+
+// batchNamePreview shows the first and last names the pattern produces
+func (m *CLIModel) batchNamePreview() string {
+	pattern := strings.TrimSpace(m.batchPatternInput.Value())
+	first, err := wallet.FormatWalletName(pattern, 1)
+	if err != nil {
+		return err.Error()
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(m.batchCountInput.Value()))
+	if err != nil || count <= 1 {
+		return first
+	}
+	last, _ := wallet.FormatWalletName(pattern, count)
+	return first + " … " + last
+}
+
+// viewBatchCreate renders the batch creation form
+func (m *CLIModel) viewBatchCreate() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["batch_create_title"]))
+	b.WriteString("\n\n")
+	b.WriteString(localization.Labels["batch_create_desc"])
+	b.WriteString("\n\n")
+
+	b.WriteString(localization.Labels["batch_create_count"])
+	b.WriteString("\n")
+	b.WriteString(m.batchCountInput.View())
+	b.WriteString("\n\n")
+	b.WriteString(localization.Labels["batch_create_pattern"])
+	b.WriteString("\n")
+	b.WriteString(m.batchPatternInput.View())
+	b.WriteString("\n")
+	b.WriteString(m.styles.MenuDesc.Render(m.batchNamePreview()))
+	b.WriteString("\n\n")
+
+	policyLabel := localization.Labels["batch_create_policy_shared"]
+	if m.batchPolicy == wallet.PasswordPerWallet {
+		policyLabel = localization.Labels["batch_create_policy_per_wallet"]
+	}
+	policyLine := fmt.Sprintf("%s < %s >", localization.Labels["batch_create_policy"], policyLabel)
+	if m.batchFocus == batchFocusPolicy {
+		policyLine = m.styles.SelectedTitle.Render(policyLine)
+	}
+	b.WriteString(policyLine)
+	b.WriteString("\n\n")
+	if m.batchPolicy == wallet.PasswordShared {
+		b.WriteString(localization.Labels["batch_create_password"])
+		b.WriteString("\n")
+		b.WriteString(m.batchPasswordInput.View())
+	} else {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["batch_create_per_wallet_warning"]))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(localization.Labels["batch_create_output"])
+	b.WriteString("\n")
+	b.WriteString(m.batchOutputInput.View())
+	b.WriteString("\n\n")
+
+	if m.batchStatus != "" {
+		switch {
+		case m.batchRunning:
+			b.WriteString(m.styles.MenuDesc.Render(m.batchStatus))
+		case m.batchFailed:
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.batchStatus))
+		default:
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.batchStatus))
+		}
+		b.WriteString("\n\n")
+	}
+	help := localization.Labels["batch_create_help"]
+	if m.batchRunning {
+		help = localization.Labels["batch_create_help_running"]
+	}
+	b.WriteString(m.styles.MenuDesc.Render(help))
+	return b.String()
+}
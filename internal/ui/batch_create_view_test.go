@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchCreate_CreatesWalletsAndCSV(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddBatchCreateMessages()
+
+	dir := t.TempDir()
+	cfg := &config.Config{
+		AppDir:       dir,
+		DatabasePath: filepath.Join(dir, "wallets.db"),
+		Security:     config.SecurityConfig{Argon2Time: 1, Argon2Memory: 8 * 1024, Argon2Threads: 1, Argon2KeyLen: 32, SaltLength: 16},
+	}
+	wallet.InitCryptoService(cfg)
+	repo, err := storage.NewWalletRepository(cfg)
+	require.NoError(t, err)
+	defer repo.Close()
+	n, p := wallet.GetTestKeystoreParams()
+	ks := keystore.NewKeyStore(filepath.Join(dir, "keystore"), n, p)
+
+	m := &CLIModel{Service: &wallet.WalletService{Repo: repo, KeyStore: ks}, styles: createStyles()}
+	m.initBatchCreate()
+	assert.Equal(t, constants.BatchCreateView, m.currentView)
+
+	csvPath := filepath.Join(dir, "airdrop.csv")
+	m.batchCountInput.SetValue("3")
+	m.batchPatternInput.SetValue("airdrop-{:03d}")
+	m.batchOutputInput.SetValue(csvPath)
+	assert.Contains(t, m.viewBatchCreate(), "airdrop-001 … airdrop-003")
+
+	// Per-wallet passwords: the password field is skipped
+	m.setBatchFocus(batchFocusPolicy)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	assert.Equal(t, wallet.PasswordPerWallet, m.batchPolicy)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	assert.Equal(t, batchFocusOutput, m.batchFocus)
+
+	// Run the batch, one step per command
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, m.batchRunning)
+	for m.batchRunning {
+		require.NotNil(t, cmd)
+		_, cmd = m.Update(cmd())
+	}
+	assert.False(t, m.batchFailed, m.batchStatus)
+	assert.Contains(t, m.batchStatus, "3 wallets created")
+
+	wallets, err := repo.GetAllWallets()
+	require.NoError(t, err)
+	require.Len(t, wallets, 3)
+	assert.Equal(t, "airdrop-002", wallets[1].Name)
+
+	data, err := os.ReadFile(csvPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "name,address,password\nairdrop-001,"+wallets[0].Address+",")
+}
+
+func TestBatchCreate_EscStopsTheBatch(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddBatchCreateMessages()
+
+	m := &CLIModel{styles: createStyles()}
+	m.initBatchCreate()
+	m.batchOptions = wallet.BatchCreateOptions{Count: 5, NamePattern: "w{}", Policy: wallet.PasswordShared}
+	m.batchOutputPath = filepath.Join(t.TempDir(), "out.csv")
+	m.batchRunning = true
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.BatchCreateView, m.currentView)
+	assert.True(t, m.batchCancelled)
+
+	_, cmd := m.Update(batchCreateStepMsg{created: wallet.BatchCreatedWallet{Name: "w1", Address: "0x1"}})
+	assert.False(t, m.batchRunning)
+	assert.Contains(t, m.batchStatus, "Stopped: 1 of 5")
+	assert.NotNil(t, cmd, "the wallet list is refreshed")
+}
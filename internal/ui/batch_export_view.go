@@ -0,0 +1,528 @@
+package ui
+
+import (
+	"blocowallet/internal/compliance"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/jobs"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Fields of the export form
+const (
+	batchExportFocusDir = iota
+	batchExportFocusPasswords
+)
+
+// Stages of a batch export: the form, asking the password of each wallet for
+// its .pwd file, writing the files, the summary
+const (
+	batchExportStageForm = iota
+	batchExportStageUnlock
+	batchExportStageRunning
+	batchExportStageDone
+)
+
+// batchExportProgressMsg carries one progress update of the running export
+type batchExportProgressMsg struct {
+	seq      int
+	progress wallet.ImportProgress
+	updates  <-chan wallet.ImportProgress
+	results  <-chan []wallet.ExportResult
+}
+
+// batchExportDoneMsg carries the results of the export once it finished
+type batchExportDoneMsg struct {
+	seq     int
+	results []wallet.ExportResult
+}
+
+// batchExportUnlockMsg tells whether the password typed for a wallet opens it
+type batchExportUnlockMsg struct {
+	seq      int
+	index    int
+	password string
+	err      error
+}
+
+// errBatchExportNoPassword marks the wallets whose password was not given,
+// left out as their .pwd file could not be written
+var errBatchExportNoPassword = errors.New("no password given for the .pwd file")
+
+// toggleBatchExportMark marks or unmarks the wallet under the cursor of the
+// list for the next batch export
+func (m *CLIModel) toggleBatchExportMark() {
+	selectedRow := m.walletTable.SelectedRow()
+	if len(selectedRow) <= 4 {
+		return
+	}
+	if m.batchExportMarked == nil {
+		m.batchExportMarked = make(map[string]bool)
+	}
+	address := selectedRow[4]
+	// The marks are kept by the stored address, the row shows its EIP-55 form
+	for _, w := range m.wallets {
+		if wallet.SameAddress(w.Address, address) {
+			address = w.Address
+			break
+		}
+	}
+	if m.batchExportMarked[address] {
+		delete(m.batchExportMarked, address)
+	} else {
+		m.batchExportMarked[address] = true
+	}
+	m.applyWalletFilter()
+}
+
+// initBatchExport opens the export of the wallets marked in the list, or of
+// every wallet when none is
+func (m *CLIModel) initBatchExport() {
+	var selected []wallet.Wallet
+	for _, w := range m.wallets {
+		if m.batchExportMarked[w.Address] {
+			selected = append(selected, w)
+		}
+	}
+	if len(selected) == 0 {
+		selected = append(selected, m.wallets...)
+	}
+
+	m.batchExporter = wallet.NewBatchExportService()
+	m.batchExportJobs = m.batchExporter.CreateExportJobs(selected)
+	m.batchExportDirInput = textinput.New()
+	m.batchExportDirInput.Placeholder = defaultBatchExportDir(time.Now())
+	m.batchExportDirInput.CharLimit = 512
+	m.batchExportDirInput.Width = 60
+	m.batchExportDirInput.Focus()
+	m.batchExportFocus = batchExportFocusDir
+	m.batchExportStage = batchExportStageForm
+	m.batchExportResults = nil
+	m.batchExportStatus = ""
+	m.batchExportFailed = false
+	m.currentView = constants.BatchExportView
+}
+
+// defaultBatchExportDir is the directory used when none is typed
+func defaultBatchExportDir(at time.Time) string {
+	return "bloco-wallet-export-" + at.Format("2006-01-02")
+}
+
+// updateBatchExport handles input on the batch export screen
+func (m *CLIModel) updateBatchExport(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch m.batchExportStage {
+	case batchExportStageUnlock:
+		return m, m.updateBatchExportUnlock(msg)
+	case batchExportStageRunning:
+		return m, nil
+	case batchExportStageDone:
+		if keyMsg.String() == "enter" {
+			m.currentView = constants.ListWalletsView
+			return m, m.refreshWalletsTable()
+		}
+		return m, nil
+	}
+
+	if text, ok := pastedText(msg); ok && m.batchExportFocus == batchExportFocusDir {
+		m.batchExportDirInput.SetValue(cleanPastedPath(text))
+		m.batchExportDirInput.CursorEnd()
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "tab", "shift+tab", "up", "down":
+		if m.batchExportFocus == batchExportFocusDir {
+			m.batchExportFocus = batchExportFocusPasswords
+			m.batchExportDirInput.Blur()
+		} else {
+			m.batchExportFocus = batchExportFocusDir
+			m.batchExportDirInput.Focus()
+		}
+		return m, nil
+	case "enter":
+		return m, m.startBatchExport()
+	}
+
+	var cmd tea.Cmd
+	if m.batchExportFocus == batchExportFocusDir {
+		m.batchExportDirInput, cmd = updateTextInput(m.batchExportDirInput, msg)
+	} else {
+		switch keyMsg.String() {
+		case "left", "right", " ", "h", "l":
+			m.batchExportPasswords = !m.batchExportPasswords
+		}
+	}
+	return m, cmd
+}
+
+// batchExportCapturesText reports whether keys go to a text field
+func (m *CLIModel) batchExportCapturesText() bool {
+	switch m.batchExportStage {
+	case batchExportStageForm:
+		return m.batchExportFocus == batchExportFocusDir
+	case batchExportStageUnlock:
+		return true
+	}
+	return false
+}
+
+// startBatchExport asks the password of each wallet when .pwd files are
+// written, then writes the files
+func (m *CLIModel) startBatchExport() tea.Cmd {
+	if len(m.batchExportJobs) == 0 {
+		m.setBatchExportStatus(localization.Labels["batch_export_no_wallets"], true)
+		return nil
+	}
+	dir := strings.TrimSpace(m.batchExportDirInput.Value())
+	if dir == "" {
+		dir = m.batchExportDirInput.Placeholder
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	m.batchExportDir = dir
+	m.batchExportLeftOut = make(map[int]error)
+	for i := range m.batchExportJobs {
+		m.batchExportJobs[i].Password = ""
+	}
+	m.setBatchExportStatus("", false)
+
+	if !m.batchExportPasswords {
+		return m.runBatchExport()
+	}
+	m.batchExportStage = batchExportStageUnlock
+	m.batchExportUnlocking = -1
+	return m.nextBatchExportUnlock()
+}
+
+// nextBatchExportUnlock opens the password popup of the next wallet with a
+// keystore, or starts writing once every wallet answered
+func (m *CLIModel) nextBatchExportUnlock() tea.Cmd {
+	m.batchExportPopup = nil
+	for m.batchExportUnlocking+1 < len(m.batchExportJobs) {
+		m.batchExportUnlocking++
+		w := m.batchExportJobs[m.batchExportUnlocking].Wallet
+		if w.WatchOnly() {
+			continue
+		}
+		label := w.Name
+		if w.KeyStorePath != "" {
+			label += " · " + filepath.Base(w.KeyStorePath)
+		}
+		popup := NewPasswordPopupModel(label, 3)
+		m.batchExportPopup = &popup
+		return nil
+	}
+	return m.runBatchExport()
+}
+
+// updateBatchExportUnlock types into the password popup; enter checks the
+// password in the background and ctrl+s leaves the wallet out
+func (m *CLIModel) updateBatchExportUnlock(msg tea.Msg) tea.Cmd {
+	if m.batchExportPopup == nil || m.batchExportUnlockBusy {
+		return nil
+	}
+	popup, cmd := m.batchExportPopup.Update(msg)
+	m.batchExportPopup = &popup
+	if !popup.IsCompleted() {
+		return cmd
+	}
+	// The popup quits once answered: only its result is used
+	result := popup.GetResult()
+	if result.Skip {
+		m.batchExportLeftOut[m.batchExportUnlocking] = errBatchExportNoPassword
+		return m.nextBatchExportUnlock()
+	}
+	m.batchExportUnlockBusy = true
+	return batchExportUnlockCmd(m.Service, m.batchExportSeq, m.batchExportUnlocking, m.batchExportJobs[m.batchExportUnlocking].Wallet, result.Password)
+}
+
+// batchExportUnlockCmd opens the keystore off the UI, as the key derivation
+// takes a while
+func batchExportUnlockCmd(ws *wallet.WalletService, seq, index int, w wallet.Wallet, password string) tea.Cmd {
+	return func() tea.Msg {
+		_, err := ws.LoadWallet(&w, password)
+		return batchExportUnlockMsg{seq: seq, index: index, password: password, err: err}
+	}
+}
+
+// handleBatchExportUnlock keeps the password that opened the wallet, or asks
+// again until the attempts run out and the wallet is left out
+func (m *CLIModel) handleBatchExportUnlock(msg batchExportUnlockMsg) tea.Cmd {
+	if msg.seq != m.batchExportSeq || m.batchExportStage != batchExportStageUnlock || msg.index != m.batchExportUnlocking || m.batchExportPopup == nil {
+		return nil
+	}
+	m.batchExportUnlockBusy = false
+	if msg.err == nil {
+		m.batchExportJobs[msg.index].Password = msg.password
+		return m.nextBatchExportUnlock()
+	}
+	m.batchExportPopup.SetError(msg.err.Error())
+	if m.batchExportPopup.HasExceededMaxRetries() {
+		m.batchExportLeftOut[msg.index] = msg.err
+		return m.nextBatchExportUnlock()
+	}
+	return nil
+}
+
+// runBatchExport writes the files through the job manager, so the export
+// shows in the Jobs view and can be cancelled from there too. The keystores
+// and .pwd files are secrets: the job waits for the approval when compliance
+// mode is on.
+func (m *CLIModel) runBatchExport() tea.Cmd {
+	var run []wallet.ExportJob
+	for i, job := range m.batchExportJobs {
+		if m.batchExportLeftOut[i] == nil {
+			run = append(run, job)
+		}
+	}
+
+	m.batchExportSeq++
+	m.batchExportStage = batchExportStageRunning
+	m.batchExportPopup = nil
+	m.batchExportCancelling = false
+	m.batchExportProgress = wallet.ImportProgress{TotalFiles: len(run)}
+	m.batchExportBar = progress.New(progress.WithDefaultGradient(), progress.WithWidth(50), progress.WithoutPercentage())
+
+	updates := make(chan wallet.ImportProgress, 16)
+	results := make(chan []wallet.ExportResult, 1)
+	seq, exporter, dir := m.batchExportSeq, m.batchExporter, m.batchExportDir
+	name := fmt.Sprintf("%s (%d)", localization.Labels["job_kind_batch_export"], len(run))
+	var id string
+	return m.withApproval(approvedAction{
+		action:  compliance.ActionExportSecret,
+		subject: fmt.Sprintf("%d wallets to %s", len(run), dir),
+		run: func() error {
+			var err error
+			id, err = m.jobManager.Submit(jobs.KindBatchExport, name, func(ctx context.Context, report jobs.ProgressFunc) error {
+				results <- exporter.ExportBatch(ctx, run, dir, updates)
+				return ctx.Err()
+			})
+			if errors.Is(err, jobs.ErrConflict) {
+				err = errors.New(localization.Labels["job_conflict"])
+			}
+			return err
+		},
+		done: func(err error) tea.Cmd {
+			if err != nil {
+				m.batchExportStage = batchExportStageForm
+				m.setBatchExportStatus(err.Error(), true)
+				return nil
+			}
+			m.batchExportJobID = id
+			return waitForBatchExport(seq, updates, results)
+		},
+	})
+}
+
+// waitForBatchExport delivers the next progress update, or the results once
+// the export closed its updates
+func waitForBatchExport(seq int, updates <-chan wallet.ImportProgress, results <-chan []wallet.ExportResult) tea.Cmd {
+	return func() tea.Msg {
+		p, ok := <-updates
+		if !ok {
+			return batchExportDoneMsg{seq: seq, results: <-results}
+		}
+		return batchExportProgressMsg{seq: seq, progress: p, updates: updates, results: results}
+	}
+}
+
+// handleBatchExportProgress shows one progress update and waits for the next
+func (m *CLIModel) handleBatchExportProgress(msg batchExportProgressMsg) tea.Cmd {
+	if msg.seq == m.batchExportSeq {
+		m.batchExportProgress = msg.progress
+		m.jobManager.Report(m.batchExportJobID, msg.progress.Percentage/100, msg.progress.CurrentFile)
+	}
+	// Drained either way so the export never waits on the screen
+	return waitForBatchExport(msg.seq, msg.updates, msg.results)
+}
+
+// handleBatchExportDone puts the wallets left out before writing back among
+// the results, in the order of the export, and shows the summary
+func (m *CLIModel) handleBatchExportDone(msg batchExportDoneMsg) {
+	if msg.seq != m.batchExportSeq {
+		return
+	}
+	written := msg.results
+	m.batchExportResults = make([]wallet.ExportResult, 0, len(m.batchExportJobs))
+	for i, job := range m.batchExportJobs {
+		if err := m.batchExportLeftOut[i]; err != nil {
+			m.batchExportResults = append(m.batchExportResults, wallet.ExportResult{Job: job, Error: err, Skipped: true})
+			continue
+		}
+		if len(written) > 0 {
+			m.batchExportResults = append(m.batchExportResults, written[0])
+			written = written[1:]
+		}
+	}
+	for _, result := range m.batchExportResults {
+		if result.Success {
+			delete(m.batchExportMarked, result.Job.Wallet.Address)
+		}
+	}
+	m.batchExportStage = batchExportStageDone
+	m.batchExportJobID = ""
+}
+
+// closeBatchExport handles esc: it stops asking passwords, cancels the running
+// export after the current wallet, or goes back to the list
+func (m *CLIModel) closeBatchExport() tea.Cmd {
+	switch m.batchExportStage {
+	case batchExportStageUnlock:
+		m.batchExportSeq++
+		m.batchExportPopup = nil
+		m.batchExportUnlockBusy = false
+		m.batchExportStage = batchExportStageForm
+		return nil
+	case batchExportStageRunning:
+		if m.batchExportJobID != "" && !m.batchExportCancelling {
+			m.batchExportCancelling = true
+			_ = m.jobManager.Cancel(m.batchExportJobID)
+		}
+		return nil
+	}
+	m.currentView = constants.ListWalletsView
+	return m.refreshWalletsTable()
+}
+
+func (m *CLIModel) setBatchExportStatus(status string, failed bool) {
+	m.batchExportStatus = status
+	m.batchExportFailed = failed
+}
+
+// viewBatchExport renders the batch export form, its progress and summary
+func (m *CLIModel) viewBatchExport() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["batch_export_title"]))
+	b.WriteString("\n\n")
+
+	help := "batch_export_help"
+	switch m.batchExportStage {
+	case batchExportStageForm:
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["batch_export_desc"]))
+		b.WriteString("\n\n")
+		b.WriteString(fmt.Sprintf(localization.Labels["batch_export_wallets"], len(m.batchExportJobs)))
+		b.WriteString("\n")
+		for _, job := range m.batchExportJobs {
+			line := fmt.Sprintf("  %-24s → %s", truncateRunes(job.Wallet.Name, 24), job.FileName)
+			if job.Wallet.WatchOnly() {
+				line += " (" + localization.Labels["batch_export_watch_only"] + ")"
+			}
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(localization.Labels["batch_export_dir"])
+		b.WriteString("\n")
+		b.WriteString(m.batchExportDirInput.View())
+		b.WriteString("\n\n")
+		value := localization.Labels["batch_export_passwords_no"]
+		if m.batchExportPasswords {
+			value = localization.Labels["batch_export_passwords_yes"]
+		}
+		line := fmt.Sprintf("%s < %s >", localization.Labels["batch_export_passwords"], value)
+		if m.batchExportFocus == batchExportFocusPasswords {
+			line = m.styles.SelectedTitle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n\n")
+		if m.batchExportPasswords {
+			b.WriteString(m.styles.MenuDesc.Render(localization.Labels["batch_export_passwords_warning"]))
+			b.WriteString("\n\n")
+		}
+	case batchExportStageUnlock:
+		help = "batch_export_unlock_help"
+		b.WriteString(localization.Labels["batch_export_unlock"])
+		b.WriteString("\n\n")
+		if m.batchExportPopup != nil {
+			b.WriteString(m.batchExportPopup.View())
+			b.WriteString("\n\n")
+		}
+		if m.batchExportUnlockBusy {
+			b.WriteString(m.styles.MenuDesc.Render(localization.Labels["batch_send_unlocking"]))
+			b.WriteString("\n\n")
+		}
+	case batchExportStageRunning:
+		help = "batch_export_running_help"
+		p := m.batchExportProgress
+		b.WriteString(fmt.Sprintf(localization.Labels["batch_export_running"], m.batchExportDir))
+		b.WriteString("\n\n")
+		b.WriteString(m.batchExportBar.ViewAs(p.Percentage / 100))
+		b.WriteString(fmt.Sprintf("  %d/%d\n", p.ProcessedFiles, p.TotalFiles))
+		if p.CurrentFile != "" {
+			b.WriteString(m.styles.MenuDesc.Render(p.CurrentFile))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		if m.batchExportCancelling {
+			b.WriteString(m.styles.MenuDesc.Render(localization.Labels["batch_export_cancelling"]))
+			b.WriteString("\n\n")
+		}
+		if notice := m.renderApprovalNotice(); notice != "" {
+			b.WriteString(notice)
+			b.WriteString("\n\n")
+		}
+	case batchExportStageDone:
+		help = "batch_export_done_help"
+		b.WriteString(m.viewBatchExportSummary())
+	}
+
+	if m.batchExportStatus != "" {
+		if m.batchExportFailed {
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.batchExportStatus))
+		} else {
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.batchExportStatus))
+		}
+		b.WriteString("\n\n")
+	}
+	b.WriteString(m.styles.MenuDesc.Render(localization.Labels[help]))
+	return b.String()
+}
+
+// viewBatchExportSummary lists how each wallet went, as the import completion
+// screen does
+func (m *CLIModel) viewBatchExportSummary() string {
+	var b strings.Builder
+	summary := m.batchExporter.GetExportSummary(m.batchExportResults)
+	title := m.styles.SuccessStyle.Render(glyphs.Check + " " + localization.Labels["batch_export_done"])
+	if summary.FailedExports > 0 {
+		title = m.styles.ErrorStyle.Render(glyphs.Cross + " " + localization.Labels["batch_export_done_errors"])
+	}
+	b.WriteString(title)
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["batch_export_summary"], summary.ExportedWallets, summary.FailedExports, summary.SkippedExports, m.batchExportDir))
+	b.WriteString("\n\n")
+	for _, result := range m.batchExportResults {
+		name := truncateRunes(result.Job.Wallet.Name, 24)
+		switch {
+		case result.Success:
+			line := fmt.Sprintf("%s %-24s %s", glyphs.Check, name, filepath.Base(result.KeystorePath))
+			if result.PasswordPath != "" {
+				line += " + " + filepath.Base(result.PasswordPath)
+			}
+			b.WriteString(m.styles.SuccessStyle.Render(line))
+		case result.Skipped:
+			b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf("– %-24s %s", name, result.Error)))
+		default:
+			b.WriteString(m.styles.ErrorStyle.Render(fmt.Sprintf("%s %-24s %s", glyphs.Cross, name, result.Error)))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"blocowallet/internal/compliance"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/jobs"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchExportWallet stores a light keystore under password
+func batchExportWallet(t *testing.T, name, password string) wallet.Wallet {
+	t.Helper()
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key := &keystore.Key{Id: uuid.New(), Address: crypto.PubkeyToAddress(privateKey.PublicKey), PrivateKey: privateKey}
+	data, err := keystore.EncryptKey(key, password, keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), key.Address.Hex()+".json")
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return wallet.Wallet{Name: name, Address: key.Address.Hex(), KeyStorePath: path, ImportMethod: string(wallet.ImportMethodPrivateKey)}
+}
+
+func newBatchExportTestModel(t *testing.T, wallets ...wallet.Wallet) *CLIModel {
+	localization.SetCurrentLanguage("en")
+	localization.AddNotesMessages()
+	localization.AddBatchExportMessages()
+
+	m := &CLIModel{
+		Service:     &wallet.WalletService{},
+		jobManager:  jobs.NewManager(),
+		styles:      createStyles(),
+		currentView: constants.ListWalletsView,
+		width:       160,
+		wallets:     wallets,
+	}
+	m.rebuildWalletsTable()
+	return m
+}
+
+// runBatchExportCmds feeds the commands of the export back until none is left
+func runBatchExportCmds(m *CLIModel, cmd tea.Cmd) {
+	for cmd != nil {
+		msg := cmd()
+		if msg == nil {
+			return
+		}
+		_, cmd = m.Update(msg)
+	}
+}
+
+func TestBatchExport_MarkedWallets(t *testing.T) {
+	hot := batchExportWallet(t, "Hot", "hot-pass")
+	cold := batchExportWallet(t, "Cold", "cold-pass")
+	m := newBatchExportTestModel(t, hot, cold)
+
+	// Space marks the wallet under the cursor; only marked wallets are exported
+	batchKey(m, " ")
+	assert.True(t, m.batchExportMarked[hot.Address])
+	assert.Equal(t, "● Hot", m.walletTable.Rows()[0][1])
+	batchKey(m, "x")
+	require.Equal(t, constants.BatchExportView, m.currentView)
+	require.Len(t, m.batchExportJobs, 1)
+	assert.Equal(t, "Hot.json", m.batchExportJobs[0].FileName)
+
+	dir := filepath.Join(t.TempDir(), "out")
+	m.batchExportDirInput.SetValue(dir)
+	runBatchExportCmds(m, batchKey(m, "enter"))
+
+	require.Equal(t, batchExportStageDone, m.batchExportStage)
+	assert.FileExists(t, filepath.Join(dir, "Hot.json"))
+	assert.NoFileExists(t, filepath.Join(dir, "Hot.pwd"))
+	assert.NoFileExists(t, filepath.Join(dir, "Cold.json"))
+	assert.Contains(t, m.viewBatchExport(), "Exported: 1 · Failed: 0 · Skipped: 0")
+	assert.Empty(t, m.batchExportMarked)
+
+	job, err := m.jobManager.Get(m.jobManager.List()[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, jobs.KindBatchExport, job.Kind)
+
+	batchKey(m, "esc")
+	assert.Equal(t, constants.ListWalletsView, m.currentView)
+}
+
+func TestBatchExport_PasswordFiles(t *testing.T) {
+	hot := batchExportWallet(t, "Hot", "hot-pass")
+	cold := batchExportWallet(t, "Cold", "cold-pass")
+	watch := wallet.Wallet{Name: "Watch", Address: "0x0000000000000000000000000000000000000009", ImportMethod: string(wallet.ImportMethodWatchOnly)}
+	m := newBatchExportTestModel(t, hot, cold, watch)
+
+	// With no wallet marked, all of them are exported
+	batchKey(m, "x")
+	require.Len(t, m.batchExportJobs, 3)
+	dir := t.TempDir()
+	m.batchExportDirInput.SetValue(dir)
+	batchKey(m, "tab")
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	require.True(t, m.batchExportPasswords)
+
+	batchKey(m, "enter")
+	require.Equal(t, batchExportStageUnlock, m.batchExportStage)
+	require.NotNil(t, m.batchExportPopup)
+
+	// A wrong password asks again; the right one is kept for the .pwd file
+	m.batchExportPopup.SetValue("wrong")
+	runBatchExportCmds(m, batchKey(m, "enter"))
+	require.Equal(t, 0, m.batchExportUnlocking)
+	assert.Contains(t, m.viewBatchExport(), "incorrect password")
+	m.batchExportPopup.SetValue("hot-pass")
+	runBatchExportCmds(m, batchKey(m, "enter"))
+
+	// ctrl+s leaves the second wallet out, and the export runs
+	require.Equal(t, 1, m.batchExportUnlocking)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	runBatchExportCmds(m, cmd)
+
+	require.Equal(t, batchExportStageDone, m.batchExportStage)
+	require.Len(t, m.batchExportResults, 3)
+	assert.True(t, m.batchExportResults[0].Success)
+	password, err := os.ReadFile(filepath.Join(dir, "Hot.pwd"))
+	require.NoError(t, err)
+	assert.Equal(t, "hot-pass", string(password))
+	assert.True(t, m.batchExportResults[1].Skipped)
+	assert.NoFileExists(t, filepath.Join(dir, "Cold.json"))
+	assert.ErrorIs(t, m.batchExportResults[2].Error, wallet.ErrWatchOnlyExport)
+
+	view := m.viewBatchExport()
+	assert.Contains(t, view, "Hot.json + Hot.pwd")
+	assert.Contains(t, view, "Exported: 1 · Failed: 0 · Skipped: 2")
+}
+
+func TestBatchExport_EscDuringUnlock(t *testing.T) {
+	m := newBatchExportTestModel(t, batchExportWallet(t, "Hot", "hot-pass"))
+	batchKey(m, "x")
+	m.batchExportPasswords = true
+	m.batchExportDirInput.SetValue(t.TempDir())
+	batchKey(m, "enter")
+	require.Equal(t, batchExportStageUnlock, m.batchExportStage)
+
+	// Typing q goes to the password, esc returns to the form
+	batchKey(m, "q")
+	assert.Equal(t, "q", m.batchExportPopup.Value())
+	batchKey(m, "esc")
+	assert.Equal(t, constants.BatchExportView, m.currentView)
+	assert.Equal(t, batchExportStageForm, m.batchExportStage)
+	assert.True(t, strings.HasPrefix(m.batchExportDirInput.Placeholder, "bloco-wallet-export-"))
+}
+
+func TestBatchExport_WaitsForApproval(t *testing.T) {
+	hot := batchExportWallet(t, "Hot", "hot-pass")
+	m := newBatchExportTestModel(t, hot)
+	withTestGate(t, m, compliance.Decision{Approved: false, Approver: "bob", Reason: "not today"})
+
+	batchKey(m, "x")
+	dir := filepath.Join(t.TempDir(), "out")
+	m.batchExportDirInput.SetValue(dir)
+	cmd := batchKey(m, "enter")
+	require.NotNil(t, cmd)
+	assert.Contains(t, m.viewBatchExport(), "test approver")
+	assert.Empty(t, m.jobManager.List(), "nothing is written before the approval arrives")
+
+	// A denied export goes back to the form without writing anything
+	runBatchExportCmds(m, cmd)
+	assert.Equal(t, batchExportStageForm, m.batchExportStage)
+	assert.True(t, m.batchExportFailed)
+	assert.Contains(t, m.batchExportStatus, "not today")
+	assert.NoFileExists(t, filepath.Join(dir, "Hot.json"))
+
+	withTestGate(t, m, compliance.Decision{Approved: true, Approver: "bob"})
+	runBatchExportCmds(m, batchKey(m, "enter"))
+	require.Equal(t, batchExportStageDone, m.batchExportStage)
+	assert.FileExists(t, filepath.Join(dir, "Hot.json"))
+}
@@ -0,0 +1,988 @@
+package ui
+
+import (
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/platform"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Fields of the form; a batch asks for an amount, a sweep for the assets
+const (
+	batchSendFocusWallets = iota
+	batchSendFocusNetworks
+	batchSendFocusAssets
+	batchSendFocusTo
+	batchSendFocusAmount
+)
+
+// Stages of a batch send: the form, the plan under review, unlocking the
+// source wallets, sending, the report
+const (
+	batchSendStageForm = iota
+	batchSendStagePlan
+	batchSendStageUnlock
+	batchSendStageRunning
+	batchSendStageDone
+)
+
+// batchSendNativeAsset is the key of the native coin among the assets to sweep
+const batchSendNativeAsset = "native"
+
+// batchSendNetwork is the plan of the batch on one network and how each of
+// its transfers went
+type batchSendNetwork struct {
+	network  config.Network
+	plan     wallet.BatchPlan
+	err      error // The network could not be read
+	pending  bool  // Still estimating
+	outcomes []batchSendOutcome
+}
+
+// batchSendOutcome is what happened to one transfer of the plan
+type batchSendOutcome struct {
+	raw     *wallet.RawTransaction // Sent, or only signed in a dry run
+	err     error
+	skipped bool
+}
+
+// batchSendAsset is an asset offered to sweep
+type batchSendAsset struct {
+	key   string
+	label string
+}
+
+// batchPlanMsg carries the plan of the batch on one network
+type batchPlanMsg struct {
+	seq   int
+	index int
+	plan  wallet.BatchPlan
+	err   error
+}
+
+// batchUnlockMsg carries the signer of a source wallet, or why its password
+// did not open it
+type batchUnlockMsg struct {
+	from   common.Address
+	signer wallet.Signer
+	err    error
+}
+
+// batchSendStepMsg carries the outcome of sending one transfer of the batch
+type batchSendStepMsg struct {
+	network int
+	item    int
+	raw     *wallet.RawTransaction
+	tracked bool
+	err     error
+}
+
+// errBatchSkipped marks the transfers of a wallet the user chose not to unlock
+var errBatchSkipped = errors.New("wallet skipped")
+
+// initBatchSend opens the form to send an amount from several wallets at once
+func (m *CLIModel) initBatchSend() {
+	m.openBatchSend(false)
+}
+
+// initSweep opens the form to move everything out of several wallets to one
+// address
+func (m *CLIModel) initSweep() {
+	m.openBatchSend(true)
+}
+
+func (m *CLIModel) openBatchSend(sweep bool) {
+	m.setBatchSendResult("", false)
+	if m.currentConfig == nil {
+		if err := m.ensureConfigAndNetworksLoaded(); err != nil {
+			m.setBatchSendResult(err.Error(), true)
+		}
+	}
+	m.batchSweep = sweep
+	m.batchSendWallets = nil
+	if m.Service != nil {
+		all, err := m.Service.GetAllWallets()
+		if err != nil {
+			m.setBatchSendResult(err.Error(), true)
+		}
+		for _, w := range all {
+			// Watch-only wallets have no key to sign with
+			if !w.WatchOnly() {
+				m.batchSendWallets = append(m.batchSendWallets, w)
+			}
+		}
+	}
+	m.batchSendNetworks = m.usableNetworks()
+	m.batchSendTokens = nil
+	if sweep {
+		if service, err := m.tokenService(); err == nil {
+			m.batchSendTokens, _ = service.Tokens(0)
+		}
+	}
+	m.batchSendPicked = make(map[string]bool)
+	m.batchSendNetPicked = make(map[int64]bool)
+	m.batchSendAssetPicked = map[string]bool{batchSendNativeAsset: true}
+	m.batchSendCursor, m.batchSendNetCursor, m.batchSendAssetCursor = 0, 0, 0
+
+	m.batchSendTo = textinput.New()
+	m.batchSendTo.Placeholder = "0x…"
+	m.batchSendTo.CharLimit = 42
+	m.batchSendTo.Width = 44
+	m.batchSendAmount = textinput.New()
+	m.batchSendAmount.Placeholder = "0.0"
+	m.batchSendAmount.CharLimit = 32
+	m.batchSendAmount.Width = 24
+
+	m.batchSendStage = batchSendStageForm
+	m.batchSendPlans = nil
+	m.batchSendReport = ""
+	m.setBatchSendFocus(batchSendFocusWallets)
+	m.currentView = constants.BatchSendView
+}
+
+func (m *CLIModel) setBatchSendResult(status string, failed bool) {
+	m.batchSendStatus = status
+	m.batchSendFailed = failed
+}
+
+// batchSendFields lists the fields of the form in tab order
+func (m *CLIModel) batchSendFields() []int {
+	if m.batchSweep {
+		return []int{batchSendFocusWallets, batchSendFocusNetworks, batchSendFocusAssets, batchSendFocusTo}
+	}
+	return []int{batchSendFocusWallets, batchSendFocusNetworks, batchSendFocusTo, batchSendFocusAmount}
+}
+
+func (m *CLIModel) setBatchSendFocus(focus int) {
+	m.batchSendFocus = focus
+	m.batchSendTo.Blur()
+	m.batchSendAmount.Blur()
+	switch focus {
+	case batchSendFocusTo:
+		m.batchSendTo.Focus()
+	case batchSendFocusAmount:
+		m.batchSendAmount.Focus()
+	}
+}
+
+// moveBatchSendFocus moves to the next field of the form, or the previous one
+func (m *CLIModel) moveBatchSendFocus(step int) {
+	fields := m.batchSendFields()
+	current := 0
+	for i, f := range fields {
+		if f == m.batchSendFocus {
+			current = i
+		}
+	}
+	m.setBatchSendFocus(fields[(current+step+len(fields))%len(fields)])
+}
+
+// batchSendCapturesText reports whether keys go to a text field
+func (m *CLIModel) batchSendCapturesText() bool {
+	switch m.batchSendStage {
+	case batchSendStageForm:
+		return m.batchSendFocus == batchSendFocusTo || m.batchSendFocus == batchSendFocusAmount
+	case batchSendStageUnlock:
+		return true
+	}
+	return false
+}
+
+// updateBatchSend handles the form, the plan review, the password popups and
+// the report
+func (m *CLIModel) updateBatchSend(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch m.batchSendStage {
+	case batchSendStageRunning:
+		return m, nil
+	case batchSendStageUnlock:
+		return m, m.updateBatchUnlock(msg)
+	case batchSendStageDone:
+		switch keyMsg.String() {
+		case "enter":
+			m.openBatchSend(m.batchSweep)
+		case "c":
+			if copyToClipboard(m.batchSendReport) == platform.ClipboardTerminal {
+				m.setBatchSendResult(localization.Labels["batch_send_report_copied_terminal"], false)
+			} else {
+				m.setBatchSendResult(localization.Labels["batch_send_report_copied"], false)
+			}
+		case "backspace":
+			if m.batchSendDryRun {
+				// Nothing was sent: the plan still holds
+				m.batchSendStage = batchSendStagePlan
+				for i := range m.batchSendPlans {
+					m.batchSendPlans[i].outcomes = make([]batchSendOutcome, len(m.batchSendPlans[i].plan.Items))
+				}
+				m.setBatchSendResult("", false)
+			}
+		}
+		return m, nil
+	case batchSendStagePlan:
+		switch keyMsg.String() {
+		case "enter":
+			return m, m.startBatchSend(false)
+		case "d":
+			return m, m.startBatchSend(true)
+		case "backspace":
+			// Back to the form to change the batch
+			m.batchSendSeq++
+			m.batchSendStage = batchSendStageForm
+			m.setBatchSendResult("", false)
+		}
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "tab":
+		m.moveBatchSendFocus(1)
+		return m, nil
+	case "shift+tab":
+		m.moveBatchSendFocus(-1)
+		return m, nil
+	case "enter":
+		return m, m.planBatchSend()
+	}
+
+	switch m.batchSendFocus {
+	case batchSendFocusWallets:
+		m.batchSendCursor = moveChecklist(keyMsg.String(), m.batchSendCursor, len(m.batchSendWallets))
+		switch keyMsg.String() {
+		case " ":
+			if m.batchSendCursor < len(m.batchSendWallets) {
+				address := m.batchSendWallets[m.batchSendCursor].Address
+				m.batchSendPicked[address] = !m.batchSendPicked[address]
+			}
+		case "a":
+			all := len(m.batchSendSources()) < len(m.batchSendWallets)
+			for _, w := range m.batchSendWallets {
+				m.batchSendPicked[w.Address] = all
+			}
+		}
+	case batchSendFocusNetworks:
+		m.batchSendNetCursor = moveChecklist(keyMsg.String(), m.batchSendNetCursor, len(m.batchSendNetworks))
+		if keyMsg.String() == " " && m.batchSendNetCursor < len(m.batchSendNetworks) {
+			chainID := m.batchSendNetworks[m.batchSendNetCursor].ChainID
+			m.batchSendNetPicked[chainID] = !m.batchSendNetPicked[chainID]
+			m.batchSendAssetCursor = 0
+		}
+	case batchSendFocusAssets:
+		assets := m.batchSendAssets()
+		m.batchSendAssetCursor = moveChecklist(keyMsg.String(), m.batchSendAssetCursor, len(assets))
+		if keyMsg.String() == " " && m.batchSendAssetCursor < len(assets) {
+			key := assets[m.batchSendAssetCursor].key
+			m.batchSendAssetPicked[key] = !m.batchSendAssetPicked[key]
+		}
+	case batchSendFocusTo:
+		var cmd tea.Cmd
+		m.batchSendTo, cmd = updateTextInput(m.batchSendTo, msg)
+		return m, cmd
+	case batchSendFocusAmount:
+		var cmd tea.Cmd
+		m.batchSendAmount, cmd = updateTextInput(m.batchSendAmount, msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// moveChecklist moves the cursor of a list with up/down or k/j
+func moveChecklist(key string, cursor, size int) int {
+	switch key {
+	case "up", "k":
+		if cursor > 0 {
+			return cursor - 1
+		}
+	case "down", "j":
+		if cursor < size-1 {
+			return cursor + 1
+		}
+	}
+	return cursor
+}
+
+// batchSendSources lists the picked wallets in the order of the list
+func (m *CLIModel) batchSendSources() []wallet.Wallet {
+	var picked []wallet.Wallet
+	for _, w := range m.batchSendWallets {
+		if m.batchSendPicked[w.Address] {
+			picked = append(picked, w)
+		}
+	}
+	return picked
+}
+
+// batchSendAssets lists what a sweep can move: the native coin, then the
+// tokens followed on the picked networks
+func (m *CLIModel) batchSendAssets() []batchSendAsset {
+	assets := []batchSendAsset{{key: batchSendNativeAsset, label: localization.Labels["sweep_native"]}}
+	for _, n := range m.batchSendNetworks {
+		if !m.batchSendNetPicked[n.ChainID] {
+			continue
+		}
+		for _, t := range m.batchSendTokens {
+			if t.ChainID == n.ChainID {
+				assets = append(assets, batchSendAsset{key: batchSendTokenKey(t), label: fmt.Sprintf("%s · %s", t.Symbol, n.Name)})
+			}
+		}
+	}
+	return assets
+}
+
+func batchSendTokenKey(t blockchain.Token) string {
+	return fmt.Sprintf("%d:%s", t.ChainID, t.Address)
+}
+
+// planBatchSend validates the form and estimates the batch on each picked
+// network at once
+func (m *CLIModel) planBatchSend() tea.Cmd {
+	sources := m.batchSendSources()
+	if len(sources) == 0 {
+		m.setBatchSendResult(localization.Labels["batch_send_no_wallets"], true)
+		m.setBatchSendFocus(batchSendFocusWallets)
+		return nil
+	}
+	var networks []config.Network
+	for _, n := range m.batchSendNetworks {
+		if m.batchSendNetPicked[n.ChainID] {
+			networks = append(networks, n)
+		}
+	}
+	if len(networks) == 0 {
+		m.setBatchSendResult(localization.Labels["batch_send_no_networks"], true)
+		m.setBatchSendFocus(batchSendFocusNetworks)
+		return nil
+	}
+	native := !m.batchSweep || m.batchSendAssetPicked[batchSendNativeAsset]
+	tokens := make(map[int64][]blockchain.Token)
+	for _, t := range m.batchSendTokens {
+		if m.batchSendNetPicked[t.ChainID] && m.batchSendAssetPicked[batchSendTokenKey(t)] {
+			tokens[t.ChainID] = append(tokens[t.ChainID], t)
+		}
+	}
+	if !native && len(tokens) == 0 {
+		m.setBatchSendResult(localization.Labels["sweep_no_assets"], true)
+		m.setBatchSendFocus(batchSendFocusAssets)
+		return nil
+	}
+	to, err := m.readAddress(m.batchSendTo.Value(), localization.Labels["sign_tx_invalid_to"])
+	if err != nil {
+		m.setBatchSendResult(err.Error(), true)
+		m.setBatchSendFocus(batchSendFocusTo)
+		return nil
+	}
+	var amount *big.Int
+	if !m.batchSweep {
+		if amount, err = wallet.ParseUnits(m.batchSendAmount.Value(), 18); err != nil {
+			m.setBatchSendResult(err.Error(), true)
+			m.setBatchSendFocus(batchSendFocusAmount)
+			return nil
+		}
+	}
+
+	m.batchSendSeq++
+	m.batchSendStage = batchSendStagePlan
+	m.setBatchSendResult("", false)
+	m.batchSendPlans = make([]batchSendNetwork, len(networks))
+	cmds := make([]tea.Cmd, 0, len(networks))
+	for i, network := range networks {
+		m.batchSendPlans[i] = batchSendNetwork{network: network, pending: true}
+		if m.batchSweep {
+			service, _ := m.tokenService()
+			cmds = append(cmds, sweepPlanCmd(m.batchSendSeq, i, network, service, tokens[network.ChainID], native, sources, to))
+			continue
+		}
+		transfers := make([]wallet.BatchTransfer, 0, len(sources))
+		for _, w := range sources {
+			transfers = append(transfers, wallet.BatchTransfer{Label: w.Name, From: common.HexToAddress(w.Address), To: to, Value: amount})
+		}
+		cmds = append(cmds, batchPlanCmd(m.batchSendSeq, i, network, transfers))
+	}
+	return tea.Batch(cmds...)
+}
+
+// batchPlanCmd estimates the batch on one network in the background
+func batchPlanCmd(seq, index int, network config.Network, transfers []wallet.BatchTransfer) tea.Cmd {
+	return func() tea.Msg {
+		client, err := blockchain.NewEthereum(network.RPCEndpoint, blockchain.DefaultTimeout, network.Symbol, 18, network.Name)
+		if err != nil {
+			return batchPlanMsg{seq: seq, index: index, err: err}
+		}
+		defer client.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), blockchain.DefaultTimeout)
+		defer cancel()
+		plan, err := wallet.NewTransactionService(client.Client()).PlanBatch(ctx, transfers)
+		return batchPlanMsg{seq: seq, index: index, plan: plan, err: err}
+	}
+}
+
+// sweepPlanCmd reads the token balances of the sources on one network and
+// plans moving them, then the native coin, to the destination
+func sweepPlanCmd(seq, index int, network config.Network, tokenSvc *blockchain.TokenService, tokens []blockchain.Token, native bool, sources []wallet.Wallet, to common.Address) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), blockchain.DefaultTimeout)
+		defer cancel()
+		owners := make([]common.Address, len(sources))
+		for j, w := range sources {
+			owners[j] = common.HexToAddress(w.Address)
+		}
+		var balances [][]*big.Int
+		if len(tokens) > 0 {
+			var err error
+			if balances, err = tokenSvc.HolderBalances(ctx, network, tokens, owners); err != nil {
+				return batchPlanMsg{seq: seq, index: index, err: err}
+			}
+		}
+		sweeps := make([]wallet.Sweep, len(sources))
+		for j, w := range sources {
+			sweeps[j] = wallet.Sweep{Label: w.Name, From: owners[j], To: to, Native: native}
+			for i, token := range tokens {
+				if amount := balances[i][j]; amount.Sign() > 0 {
+					sweeps[j].Tokens = append(sweeps[j].Tokens, wallet.BatchTransfer{
+						To:    common.HexToAddress(token.Address),
+						Value: new(big.Int),
+						Data:  blockchain.TransferData(to, amount),
+						Asset: wallet.FormatUnits(amount, token.Decimals) + " " + token.Symbol,
+					})
+				}
+			}
+		}
+
+		client, err := blockchain.NewEthereum(network.RPCEndpoint, blockchain.DefaultTimeout, network.Symbol, 18, network.Name)
+		if err != nil {
+			return batchPlanMsg{seq: seq, index: index, err: err}
+		}
+		defer client.Close()
+		plan, err := wallet.NewTransactionService(client.Client()).PlanSweep(ctx, sweeps)
+		return batchPlanMsg{seq: seq, index: index, plan: plan, err: err}
+	}
+}
+
+// handleBatchPlan shows the plan of one network; plans of a batch since
+// changed are dropped
+func (m *CLIModel) handleBatchPlan(msg batchPlanMsg) {
+	if msg.seq != m.batchSendSeq || m.batchSendStage != batchSendStagePlan || msg.index >= len(m.batchSendPlans) {
+		return
+	}
+	p := &m.batchSendPlans[msg.index]
+	p.pending = false
+	p.plan, p.err = msg.plan, msg.err
+	p.outcomes = make([]batchSendOutcome, len(msg.plan.Items))
+}
+
+// batchSendPlanning reports whether some network is still estimating
+func (m *CLIModel) batchSendPlanning() bool {
+	for _, p := range m.batchSendPlans {
+		if p.pending {
+			return true
+		}
+	}
+	return false
+}
+
+// batchSendReady counts the transfers of the plan that can be sent
+func (m *CLIModel) batchSendReady() int {
+	ready := 0
+	for _, p := range m.batchSendPlans {
+		if p.err == nil {
+			ready += p.plan.Ready()
+		}
+	}
+	return ready
+}
+
+// startBatchSend asks for the password of each source wallet with a ready
+// transfer, then sends the transfers one at a time; a dry run only signs them
+func (m *CLIModel) startBatchSend(dryRun bool) tea.Cmd {
+	if m.batchSendPlanning() {
+		return nil
+	}
+	if m.batchSendReady() == 0 {
+		m.setBatchSendResult(localization.Labels["batch_send_nothing_ready"], true)
+		return nil
+	}
+	m.batchSendDryRun = dryRun
+	m.batchSendCancelled = false
+	m.batchSendSigners = make(map[common.Address]wallet.Signer)
+	m.batchSendLocked = make(map[common.Address]error)
+	m.batchSendPosition = [2]int{0, -1}
+
+	m.batchSendUnlockQueue = nil
+	queued := make(map[common.Address]bool)
+	for _, p := range m.batchSendPlans {
+		for _, item := range p.plan.Items {
+			if from := item.Transfer.From; p.err == nil && item.Err == nil && !queued[from] {
+				queued[from] = true
+				m.batchSendUnlockQueue = append(m.batchSendUnlockQueue, from)
+			}
+		}
+	}
+	m.batchSendStage = batchSendStageUnlock
+	m.setBatchSendResult("", false)
+	return m.nextBatchUnlock()
+}
+
+// nextBatchUnlock opens the password popup of the next source wallet, or
+// starts sending once every wallet was unlocked or skipped
+func (m *CLIModel) nextBatchUnlock() tea.Cmd {
+	m.batchSendPopup = nil
+	for len(m.batchSendUnlockQueue) > 0 {
+		from := m.batchSendUnlockQueue[0]
+		m.batchSendUnlockQueue = m.batchSendUnlockQueue[1:]
+		source, ok := m.batchSendWallet(from)
+		if !ok {
+			m.batchSendLocked[from] = errors.New(localization.Labels["batch_send_wallet_missing"])
+			continue
+		}
+		label := source.Name
+		if source.KeyStorePath != "" {
+			label += " · " + filepath.Base(source.KeyStorePath)
+		}
+		popup := NewPasswordPopupModel(label, 3)
+		m.batchSendPopup = &popup
+		m.batchSendUnlocking = source
+		return nil
+	}
+	m.batchSendStage = batchSendStageRunning
+	return m.nextBatchSend()
+}
+
+// updateBatchUnlock types into the password popup; enter tries the password
+// in the background and ctrl+s skips the wallet
+func (m *CLIModel) updateBatchUnlock(msg tea.Msg) tea.Cmd {
+	if m.batchSendPopup == nil || m.batchSendUnlockBusy {
+		return nil
+	}
+	popup, cmd := m.batchSendPopup.Update(msg)
+	m.batchSendPopup = &popup
+	if !popup.IsCompleted() {
+		return cmd
+	}
+	// The popup quits once answered, as in the batch import: only its result
+	// is used
+	result := popup.GetResult()
+	from := common.HexToAddress(m.batchSendUnlocking.Address)
+	if result.Skip {
+		m.batchSendLocked[from] = errBatchSkipped
+		return m.nextBatchUnlock()
+	}
+	m.batchSendUnlockBusy = true
+	return batchUnlockCmd(m.Service, m.batchSendUnlocking, result.Password)
+}
+
+// batchUnlockCmd decrypts the key of a source wallet off the UI, as the key
+// derivation takes a while
+func batchUnlockCmd(ws *wallet.WalletService, source wallet.Wallet, password string) tea.Cmd {
+	return func() tea.Msg {
+		from := common.HexToAddress(source.Address)
+		details, err := ws.LoadWallet(&source, password)
+		if err != nil {
+			return batchUnlockMsg{from: from, err: err}
+		}
+		return batchUnlockMsg{from: from, signer: details.Signer}
+	}
+}
+
+// handleBatchUnlock keeps the signer of the unlocked wallet, or asks again
+// until the attempts run out and the wallet is skipped
+func (m *CLIModel) handleBatchUnlock(msg batchUnlockMsg) tea.Cmd {
+	if m.batchSendStage != batchSendStageUnlock || m.batchSendPopup == nil || common.HexToAddress(m.batchSendUnlocking.Address) != msg.from {
+		return nil
+	}
+	m.batchSendUnlockBusy = false
+	if msg.err == nil {
+		m.batchSendSigners[msg.from] = msg.signer
+		return m.nextBatchUnlock()
+	}
+	m.batchSendPopup.SetError(msg.err.Error())
+	if m.batchSendPopup.HasExceededMaxRetries() {
+		m.batchSendLocked[msg.from] = msg.err
+		return m.nextBatchUnlock()
+	}
+	return nil
+}
+
+// nextBatchSend sends the next planned transfer, skipping those of a wallet
+// not unlocked or that failed on the same network, or finishes the batch
+func (m *CLIModel) nextBatchSend() tea.Cmd {
+	for !m.batchSendCancelled {
+		n, i := m.batchSendPosition[0], m.batchSendPosition[1]+1
+		for n < len(m.batchSendPlans) && (m.batchSendPlans[n].err != nil || i >= len(m.batchSendPlans[n].plan.Items)) {
+			n, i = n+1, 0
+		}
+		if n >= len(m.batchSendPlans) {
+			break
+		}
+		m.batchSendPosition = [2]int{n, i}
+		p := &m.batchSendPlans[n]
+		item := p.plan.Items[i]
+		if item.Err != nil {
+			continue
+		}
+		from := item.Transfer.From
+		signer := m.batchSendSigners[from]
+		if err := m.batchSendLocked[from]; err != nil || signer == nil || m.batchSendSourceFailed(n, i) {
+			p.outcomes[i] = batchSendOutcome{skipped: true, err: err}
+			continue
+		}
+		m.setBatchSendResult(fmt.Sprintf(localization.Labels["batch_send_progress"], m.batchSendDone()+1, m.batchSendReady(), item.Transfer.Label, p.network.Name), false)
+		return batchSendStepCmd(m.Service, p.network, n, i, item, signer, m.batchSendDryRun)
+	}
+	return m.finishBatchSend()
+}
+
+// batchSendSourceFailed reports whether an earlier transfer of the same
+// source on the same network failed; later nonces could not be mined
+func (m *CLIModel) batchSendSourceFailed(n, i int) bool {
+	p := m.batchSendPlans[n]
+	from := p.plan.Items[i].Transfer.From
+	for j := 0; j < i; j++ {
+		if p.plan.Items[j].Transfer.From == from && p.plan.Items[j].Err == nil && p.outcomes[j].raw == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// batchSendWallet finds the picked wallet at address
+func (m *CLIModel) batchSendWallet(address common.Address) (wallet.Wallet, bool) {
+	for _, w := range m.batchSendWallets {
+		if common.HexToAddress(w.Address) == address {
+			return w, true
+		}
+	}
+	return wallet.Wallet{}, false
+}
+
+// batchSendStepCmd sends one transfer and follows it until final, or in a dry
+// run only signs it. One command per transfer keeps the progress on screen
+// and lets esc stop the batch.
+func batchSendStepCmd(ws *wallet.WalletService, network config.Network, n, i int, item wallet.BatchItem, signer wallet.Signer, dryRun bool) tea.Cmd {
+	return func() tea.Msg {
+		msg := batchSendStepMsg{network: n, item: i}
+		if dryRun {
+			signed, err := wallet.SignTransfer(signer, item.Request)
+			if err != nil {
+				msg.err = err
+				return msg
+			}
+			msg.raw = &wallet.RawTransaction{Tx: signed, From: signer.Address()}
+			return msg
+		}
+		client, err := blockchain.NewEthereum(network.RPCEndpoint, blockchain.DefaultTimeout, network.Symbol, 18, network.Name)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		defer client.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), blockchain.DefaultTimeout)
+		defer cancel()
+		msg.raw, msg.err = wallet.NewTransactionService(client.Client()).SendTransfer(ctx, signer, item.Request)
+		if msg.err == nil {
+			_, err := ws.TrackTransaction(msg.raw, network.Name, "", "")
+			msg.tracked = err == nil
+		}
+		return msg
+	}
+}
+
+// handleBatchSendStep records one transfer and sends the next
+func (m *CLIModel) handleBatchSendStep(msg batchSendStepMsg) tea.Cmd {
+	if m.batchSendStage != batchSendStageRunning || msg.network >= len(m.batchSendPlans) {
+		return nil
+	}
+	m.batchSendPlans[msg.network].outcomes[msg.item] = batchSendOutcome{raw: msg.raw, err: msg.err}
+	if msg.tracked {
+		m.batchSendTracked = true
+	}
+	return m.nextBatchSend()
+}
+
+// batchSendDone counts the transfers sent, or signed in a dry run, so far
+func (m *CLIModel) batchSendDone() int {
+	done := 0
+	for _, p := range m.batchSendPlans {
+		for _, o := range p.outcomes {
+			if o.raw != nil {
+				done++
+			}
+		}
+	}
+	return done
+}
+
+// cancelBatchSend stops the batch: at once while unlocking, after the
+// transfer being sent otherwise
+func (m *CLIModel) cancelBatchSend() tea.Cmd {
+	m.batchSendCancelled = true
+	if m.batchSendStage == batchSendStageUnlock {
+		m.batchSendUnlockBusy = false
+		return m.finishBatchSend()
+	}
+	m.setBatchSendResult(localization.Labels["batch_send_stopping"], false)
+	return nil
+}
+
+// finishBatchSend forgets the unlocked keys and reports the batch
+func (m *CLIModel) finishBatchSend() tea.Cmd {
+	m.batchSendStage = batchSendStageDone
+	m.batchSendSigners = nil
+	m.batchSendPopup = nil
+	sent, failed, skipped := 0, 0, 0
+	for _, p := range m.batchSendPlans {
+		for i, o := range p.outcomes {
+			switch {
+			case o.raw != nil:
+				sent++
+			case o.skipped:
+				skipped++
+			case o.err != nil:
+				failed++
+			case p.plan.Items[i].Err == nil:
+				// Not reached before the batch was stopped
+				skipped++
+			}
+		}
+	}
+	format := localization.Labels["batch_send_summary"]
+	if m.batchSendDryRun {
+		format = localization.Labels["batch_send_dry_run_summary"]
+	}
+	summary := fmt.Sprintf(format, sent, m.batchSendReady(), failed, skipped)
+	if m.batchSendCancelled {
+		summary = localization.Labels["batch_send_cancelled"] + " " + summary
+	}
+	m.setBatchSendResult(summary, failed > 0 || skipped > 0)
+	m.batchSendReport = m.batchSendReportText(summary)
+	if !m.batchSendTracked {
+		return nil
+	}
+	m.batchSendTracked = false
+	return m.refreshPendingNow()
+}
+
+// batchSendReportText lists every transfer of the batch with its outcome, to
+// keep with the records of the sweep
+func (m *CLIModel) batchSendReportText(summary string) string {
+	var b strings.Builder
+	title := localization.Labels["batch_send_title"]
+	if m.batchSweep {
+		title = localization.Labels["sweep_title"]
+	}
+	b.WriteString(fmt.Sprintf("%s · %s · %s\n", title, time.Now().Format("2006-01-02 15:04:05"), strings.TrimSpace(m.batchSendTo.Value())))
+	for _, p := range m.batchSendPlans {
+		b.WriteString(fmt.Sprintf("\n%s (%d)\n", p.network.Name, p.network.ChainID))
+		if p.err != nil {
+			b.WriteString(fmt.Sprintf("  %v\n", p.err))
+			continue
+		}
+		for i, item := range p.plan.Items {
+			b.WriteString(fmt.Sprintf("  %-20s %-24s %s\n", item.Transfer.Label, batchSendAmountText(item, p.network), m.batchSendOutcomeText(item, p.outcomes[i])))
+		}
+	}
+	b.WriteString("\n" + summary + "\n")
+	return b.String()
+}
+
+// batchSendAmountText shows what a transfer moves
+func batchSendAmountText(item wallet.BatchItem, network config.Network) string {
+	if item.Transfer.Asset != "" {
+		return item.Transfer.Asset
+	}
+	symbol := network.Symbol
+	if symbol == "" {
+		symbol = "ETH"
+	}
+	return wallet.FormatUnits(item.Transfer.Value, 18) + " " + symbol
+}
+
+// batchSendOutcomeText tells what happened to a transfer, or its nonce and
+// gas while it waits
+func (m *CLIModel) batchSendOutcomeText(item wallet.BatchItem, outcome batchSendOutcome) string {
+	switch {
+	case item.Err != nil:
+		return item.Err.Error()
+	case outcome.raw != nil && m.batchSendDryRun:
+		return fmt.Sprintf(localization.Labels["batch_send_signed"], outcome.raw.Tx.Hash().Hex())
+	case outcome.raw != nil:
+		return fmt.Sprintf(localization.Labels["batch_send_sent"], outcome.raw.Tx.Hash().Hex())
+	case outcome.skipped && outcome.err != nil:
+		return localization.Labels["batch_send_skipped"] + ": " + outcome.err.Error()
+	case outcome.err != nil:
+		return outcome.err.Error()
+	case m.batchSendStage == batchSendStageDone:
+		return localization.Labels["batch_send_skipped"]
+	}
+	return fmt.Sprintf(localization.Labels["batch_send_item"], item.Request.Nonce, item.Request.GasLimit)
+}
+
+// viewBatchSend renders the form, the plan with its totals per network, or
+// the status of each transfer
+func (m *CLIModel) viewBatchSend() string {
+	var b strings.Builder
+	title, desc := localization.Labels["batch_send_title"], localization.Labels["batch_send_desc"]
+	if m.batchSweep {
+		title, desc = localization.Labels["sweep_title"], localization.Labels["sweep_desc"]
+	}
+	b.WriteString(m.styles.MenuTitle.Render(title))
+	b.WriteString("\n\n")
+
+	if m.batchSendStage == batchSendStageForm {
+		b.WriteString(m.styles.MenuDesc.Render(desc))
+		b.WriteString("\n\n")
+		b.WriteString(m.batchSendChecklistTitle(localization.Labels["batch_send_wallets"], batchSendFocusWallets))
+		if len(m.batchSendWallets) == 0 {
+			b.WriteString(m.styles.MenuDesc.Render("  " + localization.Labels["batch_send_no_wallets"]))
+			b.WriteString("\n")
+		}
+		for i, w := range m.batchSendWallets {
+			b.WriteString(m.batchSendCheckLine(i == m.batchSendCursor && m.batchSendFocus == batchSendFocusWallets, m.batchSendPicked[w.Address], fmt.Sprintf("%-20s %s", w.Name, wallet.ChecksumAddress(w.Address))))
+		}
+		b.WriteString("\n")
+		b.WriteString(m.batchSendChecklistTitle(localization.Labels["batch_send_networks"], batchSendFocusNetworks))
+		if len(m.batchSendNetworks) == 0 {
+			b.WriteString(m.styles.MenuDesc.Render("  " + localization.Labels["send_no_networks"]))
+			b.WriteString("\n")
+		}
+		for i, n := range m.batchSendNetworks {
+			b.WriteString(m.batchSendCheckLine(i == m.batchSendNetCursor && m.batchSendFocus == batchSendFocusNetworks, m.batchSendNetPicked[n.ChainID], fmt.Sprintf("%s (%d)", n.Name, n.ChainID)))
+		}
+		b.WriteString("\n")
+		if m.batchSweep {
+			b.WriteString(m.batchSendChecklistTitle(localization.Labels["sweep_assets"], batchSendFocusAssets))
+			for i, asset := range m.batchSendAssets() {
+				b.WriteString(m.batchSendCheckLine(i == m.batchSendAssetCursor && m.batchSendFocus == batchSendFocusAssets, m.batchSendAssetPicked[asset.key], asset.label))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(fmt.Sprintf("%-24s %s\n", localization.Labels["sign_tx_to"], m.batchSendTo.View()))
+		if !m.batchSweep {
+			b.WriteString(fmt.Sprintf("%-24s %s\n", localization.Labels["batch_send_amount"], m.batchSendAmount.View()))
+		}
+		b.WriteString("\n")
+	} else {
+		for i := range m.batchSendPlans {
+			b.WriteString(m.viewBatchSendNetwork(&m.batchSendPlans[i]))
+		}
+	}
+
+	if m.batchSendStage == batchSendStageUnlock && m.batchSendPopup != nil {
+		b.WriteString(m.batchSendPopup.View())
+		b.WriteString("\n\n")
+		if m.batchSendUnlockBusy {
+			b.WriteString(m.styles.MenuDesc.Render(localization.Labels["batch_send_unlocking"]))
+			b.WriteString("\n\n")
+		}
+	}
+
+	if m.batchSendStatus != "" {
+		switch {
+		case m.batchSendStage == batchSendStageRunning:
+			b.WriteString(m.styles.MenuDesc.Render(m.batchSendStatus))
+		case m.batchSendFailed:
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.batchSendStatus))
+		default:
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.batchSendStatus))
+		}
+		b.WriteString("\n\n")
+	}
+
+	help := localization.Labels["batch_send_help"]
+	switch m.batchSendStage {
+	case batchSendStagePlan:
+		help = localization.Labels["batch_send_help_plan"]
+	case batchSendStageUnlock:
+		help = localization.Labels["batch_send_help_unlock"]
+	case batchSendStageRunning:
+		help = localization.Labels["batch_send_help_running"]
+	case batchSendStageDone:
+		help = localization.Labels["batch_send_help_done"]
+		if m.batchSendDryRun {
+			help = localization.Labels["batch_send_help_dry_run"]
+		}
+	}
+	b.WriteString(m.styles.MenuDesc.Render(help))
+	return b.String()
+}
+
+func (m *CLIModel) batchSendChecklistTitle(title string, focus int) string {
+	if m.batchSendFocus == focus {
+		return m.styles.SelectedTitle.Render(title) + "\n"
+	}
+	return title + "\n"
+}
+
+func (m *CLIModel) batchSendCheckLine(current, checked bool, text string) string {
+	cursor, box := "  ", "[ ]"
+	if current {
+		cursor = "> "
+	}
+	if checked {
+		box = "[x]"
+	}
+	return fmt.Sprintf("%s%s %s\n", cursor, box, text)
+}
+
+// viewBatchSendNetwork renders the totals of one network and each transfer
+func (m *CLIModel) viewBatchSendNetwork(p *batchSendNetwork) string {
+	var b strings.Builder
+	symbol := p.network.Symbol
+	if symbol == "" {
+		symbol = "ETH"
+	}
+	switch {
+	case p.pending:
+		b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf(localization.Labels["batch_send_estimating"], p.network.Name)))
+		b.WriteString("\n\n")
+		return b.String()
+	case p.err != nil:
+		b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + fmt.Sprintf(localization.Labels["batch_send_network_failed"], p.network.Name, p.err)))
+		b.WriteString("\n\n")
+		return b.String()
+	}
+
+	plan := p.plan
+	total := new(big.Int).Add(plan.Value, plan.MaxFees)
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf(localization.Labels["batch_send_network_total"], p.network.Name, plan.Ready(), len(plan.Items),
+		plan.GasLimit, wallet.FormatUnits(plan.MaxFees, 18), symbol, wallet.FormatUnits(total, 18), symbol)))
+	b.WriteString("\n")
+	for i, item := range plan.Items {
+		outcome := p.outcomes[i]
+		line := fmt.Sprintf("%-20s %s  %s", item.Transfer.Label, batchSendAmountText(item, p.network), m.batchSendOutcomeText(item, outcome))
+		switch {
+		case item.Err != nil || outcome.err != nil || outcome.skipped:
+			b.WriteString(m.styles.ErrorStyle.Render("  " + glyphs.Cross + " " + line))
+		case outcome.raw != nil:
+			b.WriteString(m.styles.SuccessStyle.Render("  " + glyphs.Check + " " + line))
+		default:
+			b.WriteString("  " + glyphs.Bullet + " " + line)
+		}
+		b.WriteString("\n")
+	}
+	for _, source := range plan.Sources {
+		if source.Balance != nil && source.Insufficient() {
+			b.WriteString(m.styles.ErrorStyle.Render(fmt.Sprintf("  "+localization.Labels["batch_send_insufficient"], source.Label,
+				wallet.FormatUnits(source.Balance, 18), symbol, wallet.FormatUnits(source.Needed, 18), symbol)))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,279 @@
+package ui
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/platform"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	batchAlice    = common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")
+	batchBob      = common.HexToAddress("0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0")
+	batchTreasury = common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+)
+
+func newBatchSendTestModel(t *testing.T) *CLIModel {
+	localization.SetCurrentLanguage("en")
+	localization.AddSignTxMessages()
+	localization.AddSendMessages()
+	localization.AddBatchSendMessages()
+	localization.AddImportCompletionMessages()
+
+	m := &CLIModel{styles: createStyles(), currentView: constants.DefaultView}
+	m.currentConfig = &config.Config{
+		Networks: map[string]config.Network{
+			"sepolia": {Name: "Sepolia", ChainID: 11155111, Symbol: "SEP", RPCEndpoint: "http://127.0.0.1:1", IsActive: true},
+		},
+	}
+	m.initBatchSend()
+	m.batchSendWallets = []wallet.Wallet{
+		{Name: "Alice", Address: batchAlice.Hex()},
+		{Name: "Bob", Address: batchBob.Hex()},
+	}
+	require.Equal(t, constants.BatchSendView, m.currentView)
+	return m
+}
+
+func batchKey(m *CLIModel, key string) tea.Cmd {
+	var msg tea.KeyMsg
+	switch key {
+	case "tab":
+		msg = tea.KeyMsg{Type: tea.KeyTab}
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	case " ":
+		msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	default:
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+	_, cmd := m.Update(msg)
+	return cmd
+}
+
+// batchPlan is a plan where Alice sends twice and Bob cannot pay for his
+// transfer
+func batchPlan() wallet.BatchPlan {
+	request := func(nonce uint64) wallet.TransferRequest {
+		return wallet.TransferRequest{ChainID: big.NewInt(11155111), To: batchTreasury, Value: big.NewInt(1e17), Nonce: nonce,
+			GasLimit: wallet.TxGas, MaxFeePerGas: big.NewInt(1e9), MaxPriorityFeePerGas: big.NewInt(1e8)}
+	}
+	return wallet.BatchPlan{
+		ChainID: big.NewInt(11155111),
+		Items: []wallet.BatchItem{
+			{Transfer: wallet.BatchTransfer{Label: "Alice", From: batchAlice, To: batchTreasury, Value: big.NewInt(1e17)}, Request: request(4)},
+			{Transfer: wallet.BatchTransfer{Label: "Alice", From: batchAlice, To: batchTreasury, Value: big.NewInt(1e17)}, Request: request(5)},
+			{Transfer: wallet.BatchTransfer{Label: "Bob", From: batchBob, To: batchTreasury, Value: big.NewInt(1e17)}, Err: wallet.ErrInsufficientFunds},
+		},
+		Sources: []wallet.BatchSource{
+			{Label: "Alice", From: batchAlice, Balance: big.NewInt(1e18), Needed: big.NewInt(2e17)},
+			{Label: "Bob", From: batchBob, Balance: big.NewInt(1), Needed: big.NewInt(1e17)},
+		},
+		GasLimit: 2 * wallet.TxGas,
+		MaxFees:  big.NewInt(42_000e9),
+		Value:    big.NewInt(2e17),
+	}
+}
+
+func TestBatchSend_FormValidation(t *testing.T) {
+	m := newBatchSendTestModel(t)
+
+	assert.Nil(t, batchKey(m, "enter"))
+	assert.Equal(t, localization.Labels["batch_send_no_wallets"], m.batchSendStatus)
+
+	batchKey(m, "a")
+	assert.Len(t, m.batchSendSources(), 2)
+	batchKey(m, " ")
+	assert.Len(t, m.batchSendSources(), 1, "space unselects the wallet under the cursor")
+
+	assert.Nil(t, batchKey(m, "enter"))
+	assert.Equal(t, localization.Labels["batch_send_no_networks"], m.batchSendStatus)
+	assert.Equal(t, batchSendFocusNetworks, m.batchSendFocus)
+	batchKey(m, " ")
+
+	assert.Nil(t, batchKey(m, "enter"))
+	assert.Equal(t, localization.Labels["sign_tx_invalid_to"], m.batchSendStatus)
+	assert.True(t, m.batchSendCapturesText(), "typing the destination")
+	m.batchSendTo.SetValue(batchTreasury.Hex())
+	m.batchSendAmount.SetValue("0.1")
+
+	require.NotNil(t, batchKey(m, "enter"))
+	assert.Equal(t, batchSendStagePlan, m.batchSendStage)
+	require.Len(t, m.batchSendPlans, 1)
+	assert.True(t, m.batchSendPlanning())
+	assert.Contains(t, m.viewBatchSend(), "Sepolia: estimating")
+}
+
+func TestBatchSend_SweepForm(t *testing.T) {
+	m := newBatchSendTestModel(t)
+	m.initSweep()
+	m.batchSendWallets = []wallet.Wallet{{Name: "Alice", Address: batchAlice.Hex()}}
+	m.batchSendTokens = []blockchain.Token{{ChainID: 11155111, Address: batchTreasury.Hex(), Symbol: "USDC", Decimals: 6}}
+
+	assert.Equal(t, []int{batchSendFocusWallets, batchSendFocusNetworks, batchSendFocusAssets, batchSendFocusTo}, m.batchSendFields())
+	require.Len(t, m.batchSendAssets(), 1, "tokens of networks not picked are not offered")
+	batchKey(m, " ")
+	batchKey(m, "tab")
+	batchKey(m, " ")
+	require.Len(t, m.batchSendAssets(), 2)
+	assert.Contains(t, m.viewBatchSend(), "USDC · Sepolia")
+	assert.NotContains(t, m.viewBatchSend(), "Amount from each wallet")
+
+	// Leaving out the native coin with no token picked sweeps nothing
+	batchKey(m, "tab")
+	batchKey(m, " ")
+	assert.Nil(t, batchKey(m, "enter"))
+	assert.Equal(t, localization.Labels["sweep_no_assets"], m.batchSendStatus)
+	batchKey(m, "down")
+	batchKey(m, " ")
+	m.batchSendTo.SetValue(batchTreasury.Hex())
+	require.NotNil(t, batchKey(m, "enter"))
+	assert.Equal(t, batchSendStagePlan, m.batchSendStage)
+}
+
+// planned puts the model in review of batchPlan on Sepolia
+func planned(t *testing.T, m *CLIModel) {
+	m.batchSendStage = batchSendStagePlan
+	m.batchSendPlans = []batchSendNetwork{{network: m.usableNetworks()[0], pending: true}}
+	m.batchSendTo.SetValue(batchTreasury.Hex())
+
+	// Plans of an earlier batch are dropped
+	m.handleBatchPlan(batchPlanMsg{seq: m.batchSendSeq - 1, plan: batchPlan()})
+	assert.True(t, m.batchSendPlanning())
+	m.handleBatchPlan(batchPlanMsg{seq: m.batchSendSeq, plan: batchPlan()})
+	require.False(t, m.batchSendPlanning())
+}
+
+func batchSigner(t *testing.T) wallet.Signer {
+	key, err := crypto.HexToECDSA("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	require.NoError(t, err)
+	return wallet.NewKeySigner(key)
+}
+
+func TestBatchSend_PlanAndRun(t *testing.T) {
+	m := newBatchSendTestModel(t)
+	planned(t, m)
+	assert.Equal(t, 2, m.batchSendReady())
+
+	view := m.viewBatchSend()
+	assert.Contains(t, view, "2 of 3 ready")
+	assert.Contains(t, view, "gas 42000")
+	assert.Contains(t, view, "nonce 5")
+	assert.Contains(t, view, "Bob holds")
+
+	// Only Alice has transfers to send, so only her password is asked
+	assert.Nil(t, batchKey(m, "enter"))
+	assert.Equal(t, batchSendStageUnlock, m.batchSendStage)
+	require.NotNil(t, m.batchSendPopup)
+	assert.Equal(t, "Alice", m.batchSendUnlocking.Name)
+	assert.True(t, m.batchSendCapturesText())
+	assert.Contains(t, m.viewBatchSend(), "Password Required")
+
+	batchKey(m, "pw")
+	require.NotNil(t, batchKey(m, "enter"))
+	assert.True(t, m.batchSendUnlockBusy)
+	assert.Nil(t, m.handleBatchUnlock(batchUnlockMsg{from: batchAlice, err: errors.New("incorrect password")}))
+	assert.Equal(t, batchSendStageUnlock, m.batchSendStage, "asked again")
+	assert.Contains(t, m.viewBatchSend(), "incorrect password")
+
+	batchKey(m, "secret")
+	batchKey(m, "enter")
+	require.NotNil(t, m.handleBatchUnlock(batchUnlockMsg{from: batchAlice, signer: batchSigner(t)}))
+	assert.Equal(t, batchSendStageRunning, m.batchSendStage)
+	assert.Equal(t, [2]int{0, 0}, m.batchSendPosition, "Bob's transfer is never sent")
+
+	// The first transfer fails: Alice's next nonce could never be mined
+	assert.Nil(t, m.handleBatchSendStep(batchSendStepMsg{item: 0, err: errors.New("nonce too low")}))
+	assert.Equal(t, batchSendStageDone, m.batchSendStage)
+	assert.True(t, m.batchSendPlans[0].outcomes[1].skipped)
+	assert.Nil(t, m.batchSendSigners, "the unlocked keys are forgotten")
+	assert.Equal(t, "Sent 0 of 2, 1 failed, 1 skipped", m.batchSendStatus)
+	assert.True(t, m.batchSendFailed)
+	assert.Contains(t, m.batchSendReport, "nonce too low")
+	assert.Contains(t, m.batchSendReport, "Sepolia (11155111)")
+}
+
+func TestBatchSend_SkipWallet(t *testing.T) {
+	m := newBatchSendTestModel(t)
+	planned(t, m)
+	batchKey(m, "enter")
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	assert.Nil(t, cmd, "nothing is left to send")
+	assert.Equal(t, batchSendStageDone, m.batchSendStage)
+	assert.Equal(t, "Sent 0 of 2, 0 failed, 2 skipped", m.batchSendStatus)
+	assert.Contains(t, m.batchSendReport, "skipped: wallet skipped")
+}
+
+func TestBatchSend_DryRun(t *testing.T) {
+	m := newBatchSendTestModel(t)
+	planned(t, m)
+
+	batchKey(m, "d")
+	require.True(t, m.batchSendDryRun)
+	cmd := m.handleBatchUnlock(batchUnlockMsg{from: batchAlice, signer: batchSigner(t)})
+	// Both transfers are signed locally, without a network
+	for cmd != nil {
+		cmd = m.handleBatchSendStep(cmd().(batchSendStepMsg))
+	}
+	assert.Equal(t, batchSendStageDone, m.batchSendStage)
+	assert.Contains(t, m.batchSendStatus, "nothing was sent")
+	assert.Contains(t, m.batchSendReport, "signed, not sent 0x")
+	assert.False(t, m.batchSendTracked)
+
+	var copied string
+	copyToClipboard = func(text string) platform.ClipboardMethod {
+		copied = text
+		return platform.ClipboardSystem
+	}
+	defer func() { copyToClipboard = platform.CopyToClipboard }()
+	batchKey(m, "c")
+	assert.Equal(t, m.batchSendReport, copied)
+	assert.Equal(t, localization.Labels["batch_send_report_copied"], m.batchSendStatus)
+
+	// Nothing was sent, so the same plan can be sent for real
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.Equal(t, batchSendStagePlan, m.batchSendStage)
+	assert.Nil(t, m.batchSendPlans[0].outcomes[0].raw)
+}
+
+func TestBatchSend_StopWithEsc(t *testing.T) {
+	m := newBatchSendTestModel(t)
+	planned(t, m)
+	batchKey(m, "enter")
+
+	// While unlocking nothing was sent yet: esc stops at once
+	batchKey(m, "esc")
+	assert.Equal(t, constants.BatchSendView, m.currentView, "esc stops the batch without leaving it")
+	assert.Equal(t, batchSendStageDone, m.batchSendStage)
+	assert.Contains(t, m.batchSendStatus, "Stopped.")
+
+	planned(t, m)
+	batchKey(m, "enter")
+	require.NotNil(t, m.handleBatchUnlock(batchUnlockMsg{from: batchAlice, signer: batchSigner(t)}))
+	batchKey(m, "esc")
+	assert.True(t, m.batchSendCancelled)
+	assert.Equal(t, batchSendStageRunning, m.batchSendStage)
+
+	// The transfer in flight still lands and is kept
+	tx := types.NewTx(&types.DynamicFeeTx{Nonce: 4})
+	m.handleBatchSendStep(batchSendStepMsg{item: 0, raw: &wallet.RawTransaction{Tx: tx, From: batchAlice}})
+	assert.Equal(t, batchSendStageDone, m.batchSendStage)
+	assert.Contains(t, m.batchSendStatus, "Sent 1 of 2, 0 failed, 1 skipped")
+	assert.Contains(t, m.viewBatchSend(), tx.Hash().Hex()[:10])
+}
@@ -0,0 +1,377 @@
+package ui
+
+import (
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// broadcastResultMsg carries the outcome of sending, or re-checking, a transaction
+type broadcastResultMsg struct {
+	status  blockchain.TxStatus
+	tracked bool // added to the transaction tracker
+	err     error
+}
+
+// initBroadcast opens the screen that sends an already signed transaction
+func (m *CLIModel) initBroadcast() {
+	m.broadcastInput = textinput.New()
+	m.broadcastInput.Placeholder = localization.Labels["broadcast_input_placeholder"]
+	// Contract deployments can be large; the limit only guards against runaway pastes
+	m.broadcastInput.CharLimit = 256 * 1024
+	m.broadcastInput.Width = 66
+	m.broadcastInput.Focus()
+	m.resetBroadcast()
+	m.currentView = constants.BroadcastView
+}
+
+// resetBroadcast forgets the decoded transaction and any result
+func (m *CLIModel) resetBroadcast() {
+	m.broadcastTx = nil
+	m.broadcastSigner = ""
+	m.broadcastNetwork = 0
+	m.broadcastSending = false
+	m.broadcastSent = nil
+	m.broadcastTracked = false
+	// Results of a simulation still running no longer apply
+	m.broadcastPreviewSeq++
+	m.broadcastPreview = nil
+	m.broadcastPreviewing = false
+	m.broadcastPreviewErr = nil
+	m.setBroadcastResult("", false)
+}
+
+// usableNetworks lists the active networks that can be queried and sent to,
+// by name
+func (m *CLIModel) usableNetworks() []config.Network {
+	if m.currentConfig == nil {
+		return nil
+	}
+	networks := make([]config.Network, 0, len(m.currentConfig.Networks))
+	for _, n := range m.currentConfig.Networks {
+		if n.Usable() && n.RPCEndpoint != "" {
+			networks = append(networks, n)
+		}
+	}
+	sort.Slice(networks, func(i, j int) bool { return networks[i].Name < networks[j].Name })
+	return networks
+}
+
+// selectedBroadcastNetwork returns the network the transaction will be sent
+// to, through the sender's RPC override when it has one
+func (m *CLIModel) selectedBroadcastNetwork() (config.Network, bool) {
+	networks := m.usableNetworks()
+	if m.broadcastNetwork < 0 || m.broadcastNetwork >= len(networks) {
+		return config.Network{}, false
+	}
+	network := networks[m.broadcastNetwork]
+	if m.broadcastTx != nil {
+		network = m.Service.NetworkFor(m.broadcastTx.From.Hex(), network)
+	}
+	return network, true
+}
+
+// updateBroadcast handles input on the broadcast screen: paste and decode,
+// then pick the network and send
+func (m *CLIModel) updateBroadcast(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.broadcastSending {
+		return m, nil
+	}
+
+	if m.broadcastSent != nil {
+		switch keyMsg.String() {
+		case "r":
+			return m, m.refreshBroadcastStatus()
+		case "enter":
+			m.broadcastInput.SetValue("")
+			m.resetBroadcast()
+		}
+		return m, nil
+	}
+
+	if m.broadcastTx != nil {
+		switch keyMsg.String() {
+		case "left", "right":
+			if networks := m.usableNetworks(); len(networks) > 0 {
+				step := 1
+				if keyMsg.String() == "left" {
+					step = -1
+				}
+				m.broadcastNetwork = (m.broadcastNetwork + step + len(networks)) % len(networks)
+				return m, m.previewBroadcast()
+			}
+		case "enter":
+			return m, m.sendBroadcast()
+		case "backspace":
+			// Back to the input to fix the transaction
+			m.resetBroadcast()
+		}
+		return m, nil
+	}
+
+	if keyMsg.String() == "enter" {
+		m.decodeBroadcastInput()
+		if m.broadcastTx == nil {
+			return m, nil
+		}
+		return m, tea.Batch(m.previewBroadcast(), m.lookupSignature(m.broadcastTx.Tx.Data()))
+	}
+	var cmd tea.Cmd
+	m.broadcastInput, cmd = updateTextInput(m.broadcastInput, msg)
+	return m, cmd
+}
+
+// decodeBroadcastInput decodes the hex typed in, or read from the file it
+// names, and preselects the network of its chain
+func (m *CLIModel) decodeBroadcastInput() {
+	input := strings.TrimSpace(m.broadcastInput.Value())
+	if input == "" {
+		m.setBroadcastResult(localization.Labels["broadcast_empty"], true)
+		return
+	}
+	if data, err := os.ReadFile(input); err == nil {
+		input = string(data)
+	}
+	raw, err := wallet.DecodeRawTransaction(input)
+	if err != nil {
+		m.setBroadcastResult(fmt.Sprintf(localization.Labels["broadcast_invalid"], err), true)
+		return
+	}
+
+	m.broadcastTx = raw
+	m.broadcastSigner = m.localWalletName(raw.From)
+	m.broadcastNetwork = 0
+	matched := false
+	for i, n := range m.usableNetworks() {
+		if raw.Tx.ChainId().IsInt64() && n.ChainID == raw.Tx.ChainId().Int64() {
+			m.broadcastNetwork = i
+			matched = true
+			break
+		}
+	}
+	switch {
+	case len(m.usableNetworks()) == 0:
+		m.setBroadcastResult(localization.Labels["broadcast_no_networks"], true)
+	case !matched && raw.Tx.Protected():
+		m.setBroadcastResult(fmt.Sprintf(localization.Labels["broadcast_no_matching_network"], raw.Tx.ChainId()), true)
+	default:
+		m.setBroadcastResult("", false)
+	}
+}
+
+// sendBroadcast sends the decoded transaction on the selected network and
+// reads its status right after
+func (m *CLIModel) sendBroadcast() tea.Cmd {
+	network, ok := m.selectedBroadcastNetwork()
+	if !ok {
+		m.setBroadcastResult(localization.Labels["broadcast_no_networks"], true)
+		return nil
+	}
+	m.broadcastSending = true
+	m.setBroadcastResult(fmt.Sprintf(localization.Labels["broadcast_sending"], network.Name), false)
+	raw, ws := m.broadcastTx, m.Service
+	tx := raw.Tx
+	return func() tea.Msg {
+		client, err := blockchain.NewEthereum(network.RPCEndpoint, blockchain.DefaultTimeout, network.Symbol, 18, network.Name)
+		if err != nil {
+			return broadcastResultMsg{err: err}
+		}
+		defer client.Close()
+		ctx := context.Background()
+		if err := client.Broadcast(ctx, tx); err != nil {
+			return broadcastResultMsg{err: err}
+		}
+		// Follow it until final; a failure here does not undo the broadcast
+		tracked := false
+		if ws != nil {
+			_, err := ws.TrackTransaction(raw, network.Name, "", "")
+			tracked = err == nil
+		}
+		status, err := client.TransactionStatus(ctx, tx.Hash())
+		if err != nil {
+			// The transaction is out; only the first status check failed
+			status = blockchain.TxStatus{Hash: tx.Hash(), State: blockchain.TxPending}
+		}
+		return broadcastResultMsg{status: status, tracked: tracked}
+	}
+}
+
+// refreshBroadcastStatus checks again whether the sent transaction was mined
+func (m *CLIModel) refreshBroadcastStatus() tea.Cmd {
+	network, ok := m.selectedBroadcastNetwork()
+	if !ok {
+		return nil
+	}
+	m.broadcastSending = true
+	m.setBroadcastResult(fmt.Sprintf(localization.Labels["broadcast_checking"], network.Name), false)
+	hash := m.broadcastSent.Hash
+	return func() tea.Msg {
+		client, err := blockchain.NewEthereum(network.RPCEndpoint, blockchain.DefaultTimeout, network.Symbol, 18, network.Name)
+		if err != nil {
+			return broadcastResultMsg{status: blockchain.TxStatus{Hash: hash}, err: err}
+		}
+		defer client.Close()
+		status, err := client.TransactionStatus(context.Background(), hash)
+		return broadcastResultMsg{status: status, err: err}
+	}
+}
+
+// handleBroadcastResult shows the hash and state of the sent transaction
+func (m *CLIModel) handleBroadcastResult(msg broadcastResultMsg) tea.Cmd {
+	if !m.broadcastSending {
+		return nil
+	}
+	m.broadcastSending = false
+	if msg.err != nil {
+		if m.broadcastSent != nil {
+			m.setBroadcastResult(fmt.Sprintf(localization.Labels["broadcast_check_failed"], msg.err), true)
+		} else {
+			m.setBroadcastResult(fmt.Sprintf(localization.Labels["broadcast_failed"], msg.err), true)
+		}
+		return nil
+	}
+	status := msg.status
+	m.broadcastSent = &status
+	m.setBroadcastResult(broadcastStateLabel(status), status.State == blockchain.TxReverted || status.State == blockchain.TxNotFound)
+	if !msg.tracked {
+		return nil
+	}
+	m.broadcastTracked = true
+	return m.refreshPendingNow()
+}
+
+// broadcastStateLabel describes a transaction status in the current language
+func broadcastStateLabel(status blockchain.TxStatus) string {
+	switch status.State {
+	case blockchain.TxIncluded:
+		return fmt.Sprintf(localization.Labels["broadcast_state_included"], status.BlockNumber, status.Confirmations)
+	case blockchain.TxReverted:
+		return fmt.Sprintf(localization.Labels["broadcast_state_reverted"], status.BlockNumber)
+	case blockchain.TxNotFound:
+		return localization.Labels["broadcast_state_not_found"]
+	default:
+		return localization.Labels["broadcast_state_pending"]
+	}
+}
+
+func (m *CLIModel) setBroadcastResult(status string, failed bool) {
+	m.broadcastStatus = status
+	m.broadcastFailed = failed
+}
+
+// viewBroadcast renders the input, or the decoded transaction and its status
+func (m *CLIModel) viewBroadcast() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["broadcast_title"]))
+	b.WriteString("\n\n")
+
+	if m.broadcastTx == nil {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["broadcast_desc"]))
+		b.WriteString("\n\n")
+		b.WriteString(m.broadcastInput.View())
+		b.WriteString("\n\n")
+	} else {
+		b.WriteString(m.viewBroadcastSummary())
+	}
+
+	if m.broadcastStatus != "" {
+		switch {
+		case m.broadcastSending:
+			b.WriteString(m.styles.MenuDesc.Render(m.broadcastStatus))
+		case m.broadcastFailed:
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.broadcastStatus))
+		default:
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.broadcastStatus))
+		}
+		b.WriteString("\n\n")
+	}
+
+	if m.broadcastTracked {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["broadcast_tracked"]))
+		b.WriteString("\n\n")
+	}
+
+	help := localization.Labels["broadcast_help"]
+	switch {
+	case m.broadcastSent != nil:
+		help = localization.Labels["broadcast_help_sent"]
+	case m.broadcastTx != nil:
+		help = localization.Labels["broadcast_help_review"]
+	}
+	b.WriteString(m.styles.MenuDesc.Render(help))
+	return b.String()
+}
+
+// viewBroadcastSummary shows what the signed transaction will do and where it goes
+func (m *CLIModel) viewBroadcastSummary() string {
+	var b strings.Builder
+	tx := m.broadcastTx.Tx
+	network, hasNetwork := m.selectedBroadcastNetwork()
+	symbol := "ETH"
+	if hasNetwork && network.Symbol != "" {
+		symbol = network.Symbol
+	}
+	to := localization.Labels["broadcast_contract_creation"]
+	if tx.To() != nil {
+		to = tx.To().Hex()
+	}
+
+	b.WriteString(fmt.Sprintf(localization.Labels["broadcast_summary"],
+		m.broadcastTx.From.Hex(), to, wallet.FormatUnits(tx.Value(), 18), symbol, tx.Nonce(), tx.ChainId(),
+		tx.Gas(), wallet.FormatUnits(tx.GasFeeCap(), 9), wallet.FormatUnits(tx.GasTipCap(), 9),
+		wallet.FormatUnits(tx.Cost(), 18), symbol))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["sign_tx_hash"], tx.Hash().Hex()))
+	b.WriteString("\n")
+	if call := m.describeCalldata(tx.Data()); call != "" {
+		b.WriteString(fmt.Sprintf(localization.Labels["calldata_call"], call))
+		b.WriteString("\n")
+	}
+	if m.broadcastSigner != "" {
+		b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf(localization.Labels["broadcast_known_wallet"], m.broadcastSigner)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if hasNetwork {
+		line := fmt.Sprintf("%s < %s >", localization.Labels["broadcast_network"], network.Name)
+		if m.broadcastSent == nil {
+			line = m.styles.SelectedTitle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n\n")
+		if m.broadcastSent == nil {
+			b.WriteString(m.viewBroadcastPreview(network.Name))
+		}
+	}
+	return b.String()
+}
+
+// localWalletName returns the name of the stored wallet with address, if any
+func (m *CLIModel) localWalletName(address common.Address) string {
+	if m.Service == nil {
+		return ""
+	}
+	wallets, err := m.Service.GetAllWallets()
+	if err != nil {
+		return ""
+	}
+	for _, w := range wallets {
+		if common.HexToAddress(w.Address) == address {
+			return w.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signedRawForTest(t *testing.T, chainID int64) (string, common.Address) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx, err := wallet.SignTransfer(wallet.NewKeySigner(key), wallet.TransferRequest{
+		ChainID:              big.NewInt(chainID),
+		To:                   common.HexToAddress("0x000000000000000000000000000000000000dEaD"),
+		Value:                big.NewInt(5e17),
+		Nonce:                7,
+		GasLimit:             wallet.TxGas,
+		MaxFeePerGas:         big.NewInt(3e9),
+		MaxPriorityFeePerGas: big.NewInt(1e9),
+	})
+	require.NoError(t, err)
+	raw, err := wallet.EncodeRawTransaction(tx)
+	require.NoError(t, err)
+	return raw, crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func TestBroadcast_DecodeAndReview(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddBroadcastMessages()
+	localization.AddSignTxMessages()
+
+	m := &CLIModel{styles: createStyles(), currentView: constants.DefaultView}
+	m.currentConfig = &config.Config{Networks: map[string]config.Network{
+		"mainnet": {Name: "Ethereum", ChainID: 1, Symbol: "ETH", RPCEndpoint: "http://127.0.0.1:1", IsActive: true},
+		"sepolia": {Name: "Sepolia", ChainID: 11155111, Symbol: "SEP", RPCEndpoint: "http://127.0.0.1:1", IsActive: true},
+		"offline": {Name: "Amoy", ChainID: 80002, Symbol: "POL", IsActive: true},
+	}}
+	m.initBroadcast()
+	require.Equal(t, constants.BroadcastView, m.currentView)
+	assert.True(t, m.capturesTextInput())
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, m.broadcastFailed, "nothing to decode")
+
+	m.broadcastInput.SetValue("0xdeadbeef")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, m.broadcastFailed)
+	assert.Nil(t, m.broadcastTx)
+
+	// A file holding the hex is read, and the network of its chain preselected
+	raw, from := signedRawForTest(t, 11155111)
+	path := filepath.Join(t.TempDir(), "signed.txt")
+	require.NoError(t, os.WriteFile(path, []byte(raw+"\n"), 0600))
+	m.broadcastInput.SetValue(path)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, m.broadcastTx, m.broadcastStatus)
+	assert.False(t, m.broadcastFailed)
+	assert.Equal(t, from, m.broadcastTx.From)
+	network, ok := m.selectedBroadcastNetwork()
+	require.True(t, ok)
+	assert.Equal(t, "Sepolia", network.Name)
+
+	view := m.viewBroadcast()
+	assert.Contains(t, view, from.Hex())
+	assert.Contains(t, view, "nonce 7")
+	assert.Contains(t, view, "0.5 SEP")
+	assert.Contains(t, view, m.broadcastTx.Tx.Hash().Hex())
+
+	// Networks without an RPC endpoint are not offered
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	network, _ = m.selectedBroadcastNetwork()
+	assert.Equal(t, "Ethereum", network.Name)
+
+	// backspace returns to the input, keeping what was pasted
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.Nil(t, m.broadcastTx)
+	assert.Equal(t, path, m.broadcastInput.Value())
+
+	// A transaction for a chain that is not configured is flagged
+	other, _ := signedRawForTest(t, 10)
+	m.broadcastInput.SetValue(other)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, m.broadcastTx)
+	assert.True(t, m.broadcastFailed)
+	assert.Contains(t, m.broadcastStatus, "chain ID 10")
+}
+
+func TestBroadcast_Result(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddBroadcastMessages()
+
+	m := &CLIModel{styles: createStyles()}
+	m.currentConfig = &config.Config{Networks: map[string]config.Network{
+		"sepolia": {Name: "Sepolia", ChainID: 11155111, RPCEndpoint: "http://127.0.0.1:1", IsActive: true},
+	}}
+	m.initBroadcast()
+	raw, _ := signedRawForTest(t, 11155111)
+	m.broadcastInput.SetValue(raw)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, m.broadcastTx)
+	hash := m.broadcastTx.Tx.Hash()
+
+	// A rejected broadcast keeps the transaction for another try
+	m.broadcastSending = true
+	_, _ = m.Update(broadcastResultMsg{err: assert.AnError})
+	assert.True(t, m.broadcastFailed)
+	assert.Nil(t, m.broadcastSent)
+	assert.NotNil(t, m.broadcastTx)
+
+	m.broadcastSending = true
+	_, _ = m.Update(broadcastResultMsg{status: blockchain.TxStatus{Hash: hash, State: blockchain.TxPending}})
+	require.NotNil(t, m.broadcastSent)
+	assert.False(t, m.broadcastFailed)
+	assert.Contains(t, m.viewBroadcast(), "pending")
+
+	m.broadcastSending = true
+	_, _ = m.Update(broadcastResultMsg{status: blockchain.TxStatus{Hash: hash, State: blockchain.TxIncluded, BlockNumber: 42, Confirmations: 2}})
+	assert.Contains(t, m.viewBroadcast(), "block 42 (2 confirmations)")
+
+	// enter starts over with an empty input
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, m.broadcastTx)
+	assert.Empty(t, m.broadcastInput.Value())
+}
+
+func TestBroadcast_Preview(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddBroadcastMessages()
+	localization.AddTxPreviewMessages()
+
+	m := &CLIModel{styles: createStyles()}
+	m.currentConfig = &config.Config{Networks: map[string]config.Network{
+		"mainnet": {Name: "Ethereum", ChainID: 1, Symbol: "ETH", RPCEndpoint: "http://127.0.0.1:1", IsActive: true},
+		"sepolia": {Name: "Sepolia", ChainID: 11155111, Symbol: "SEP", RPCEndpoint: "http://127.0.0.1:1", IsActive: true},
+	}}
+	m.initBroadcast()
+	raw, from := signedRawForTest(t, 11155111)
+	m.broadcastInput.SetValue(raw)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, m.broadcastTx)
+	assert.NotNil(t, cmd, "the decoded transaction is simulated")
+	assert.True(t, m.broadcastPreviewing)
+	assert.Contains(t, m.viewBroadcast(), "Simulating on Sepolia")
+
+	// Another network starts over; the older result is dropped
+	stale := m.broadcastPreviewSeq
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	_, _ = m.Update(txPreviewMsg{seq: stale, err: assert.AnError})
+	assert.True(t, m.broadcastPreviewing)
+	assert.Nil(t, m.broadcastPreviewErr)
+
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	spender := common.HexToAddress("0x000000000022D473030F116dDEE9F6B43aC78BA3")
+	unlimited := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	_, _ = m.Update(txPreviewMsg{seq: m.broadcastPreviewSeq, preview: blockchain.TxPreview{
+		Token: &blockchain.TokenCall{Method: blockchain.TokenApprove, Spender: spender, Amount: unlimited},
+		Changes: []blockchain.BalanceChange{
+			{Account: from, Symbol: "ETH", Decimals: 18, Before: big.NewInt(1e18), After: big.NewInt(4e17)},
+			{Account: from, Token: &token, Symbol: "USDC", Decimals: 6, Spender: &spender, Before: big.NewInt(0), After: unlimited},
+		},
+		RevertReason: "execution reverted: paused",
+	}})
+	assert.False(t, m.broadcastPreviewing)
+	view := m.viewBroadcast()
+	assert.Contains(t, view, "Expected changes on Ethereum")
+	assert.Contains(t, view, "You  -0.6 ETH  (1 → 0.4)")
+	assert.Contains(t, view, "You lets "+spender.Hex()+" spend 0 → unlimited USDC")
+	assert.Contains(t, view, "Unlimited approval")
+	assert.Contains(t, view, "execution reverted: paused")
+
+	// Going back to the input forgets the preview
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.Nil(t, m.broadcastPreview)
+}
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"blocowallet/internal/blockchain"
+	"blocowallet/pkg/localization"
+	"context"
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// signaturesFetchedMsg carries the signatures found online for a selector
+type signaturesFetchedMsg struct {
+	selector   blockchain.Selector
+	signatures []string
+	err        error
+}
+
+// signatureDB returns the known function signatures, loading the bundled
+// ones and those learned before on first use
+func (m *CLIModel) signatureDB() *blockchain.SignatureDB {
+	if m.signatures == nil {
+		m.signatures = blockchain.NewSignatureDB()
+		if path := m.signaturesPath(); path != "" {
+			_ = m.signatures.LoadFile(path)
+		}
+	}
+	return m.signatures
+}
+
+func (m *CLIModel) signaturesPath() string {
+	if m.currentConfig == nil || m.currentConfig.AppDir == "" {
+		return ""
+	}
+	return filepath.Join(m.currentConfig.AppDir, blockchain.SignaturesFileName)
+}
+
+// describeCalldata renders data as a function call, or its selector when the
+// function is unknown; empty data is a plain transfer and gives ""
+func (m *CLIModel) describeCalldata(data []byte) string {
+	selector, ok := blockchain.SelectorOf(data)
+	if !ok {
+		return ""
+	}
+	if call, ok := m.signatureDB().Decode(data); ok {
+		return call.String()
+	}
+	return fmt.Sprintf(localization.Labels["calldata_unknown"], selector)
+}
+
+// lookupSignature asks the 4byte directory for the function of data when it
+// is unknown locally and online lookups are enabled, once per selector
+func (m *CLIModel) lookupSignature(data []byte) tea.Cmd {
+	if m.currentConfig == nil || !m.currentConfig.Decoding.OnlineLookup {
+		return nil
+	}
+	selector, ok := blockchain.SelectorOf(data)
+	if !ok || m.signatureLookups[selector] {
+		return nil
+	}
+	if _, ok := m.signatureDB().Decode(data); ok {
+		return nil
+	}
+	if m.signatureLookups == nil {
+		m.signatureLookups = make(map[blockchain.Selector]bool)
+	}
+	m.signatureLookups[selector] = true
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), blockchain.DefaultTimeout)
+		defer cancel()
+		sigs, err := blockchain.NewSignatureDirectory().Lookup(ctx, selector)
+		return signaturesFetchedMsg{selector: selector, signatures: sigs, err: err}
+	}
+}
+
+// handleSignaturesFetched keeps the signatures found, in memory and on disk,
+// so the next decoding does not need the network
+func (m *CLIModel) handleSignaturesFetched(msg signaturesFetchedMsg) {
+	if msg.err != nil {
+		// Allow another try the next time the selector is shown
+		delete(m.signatureLookups, msg.selector)
+		return
+	}
+	added := m.signatureDB().Add(msg.signatures...)
+	if path := m.signaturesPath(); path != "" {
+		_ = blockchain.SaveSignatures(path, added)
+	}
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribeCalldata(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddCalldataMessages()
+
+	dir := t.TempDir()
+	m := &CLIModel{styles: createStyles(), currentConfig: &config.Config{AppDir: dir}}
+	assert.Empty(t, m.describeCalldata(nil), "a plain transfer has no call")
+
+	to := common.HexToAddress("0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0")
+	transfer := append(crypto.Keccak256([]byte("transfer(address,uint256)"))[:4], append(common.LeftPadBytes(to.Bytes(), 32), common.LeftPadBytes(big.NewInt(42).Bytes(), 32)...)...)
+	assert.Equal(t, "transfer(address "+to.Hex()+", uint256 42)", m.describeCalldata(transfer))
+
+	unknown := append(crypto.Keccak256([]byte("setGreeting(uint256)"))[:4], common.LeftPadBytes(big.NewInt(7).Bytes(), 32)...)
+	assert.Contains(t, m.describeCalldata(unknown), "unknown function")
+
+	// Online lookups are off unless enabled
+	assert.Nil(t, m.lookupSignature(unknown))
+	m.currentConfig.Decoding.OnlineLookup = true
+	assert.Nil(t, m.lookupSignature(transfer), "known locally")
+	assert.NotNil(t, m.lookupSignature(unknown))
+	assert.Nil(t, m.lookupSignature(unknown), "asked once per selector")
+
+	// What the directory returns is decoded from then on, and remembered
+	selector, _ := blockchain.SelectorOf(unknown)
+	m.handleSignaturesFetched(signaturesFetchedMsg{selector: selector, signatures: []string{"setGreeting(uint256)"}})
+	assert.Equal(t, "setGreeting(uint256 7)", m.describeCalldata(unknown))
+	saved, err := os.ReadFile(filepath.Join(dir, blockchain.SignaturesFileName))
+	require.NoError(t, err)
+	assert.Equal(t, "setGreeting(uint256)\n", string(saved))
+
+	fresh := &CLIModel{currentConfig: &config.Config{AppDir: dir}}
+	assert.Equal(t, "setGreeting(uint256 7)", fresh.describeCalldata(unknown))
+
+	// A failed lookup may be tried again
+	m.handleSignaturesFetched(signaturesFetchedMsg{selector: blockchain.Selector{1, 2, 3, 4}, err: assert.AnError})
+	assert.False(t, m.signatureLookups[blockchain.Selector{1, 2, 3, 4}])
+}
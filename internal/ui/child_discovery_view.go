@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// childScanStepMsg carries the outcome of checking one derivation index
+type childScanStepMsg struct {
+	child wallet.DiscoveredChild
+	used  bool
+	err   error
+}
+
+// startChildDiscovery scans the child addresses of a freshly imported
+// mnemonic on the active networks. Nothing happens when the scan is disabled
+// or no network can be queried.
+func (m *CLIModel) startChildDiscovery(password string) tea.Cmd {
+	if m.walletDetails == nil || !m.walletDetails.HasMnemonic || m.currentConfig == nil || m.currentConfig.Discovery.GapLimit <= 0 {
+		return nil
+	}
+	checker, err := blockchain.NewActivityChecker(m.currentConfig.Networks)
+	if err != nil {
+		return nil
+	}
+	return m.beginChildDiscovery(checker, m.currentConfig.Discovery.GapLimit, password)
+}
+
+// beginChildDiscovery opens the discovery screen and checks the first index
+func (m *CLIModel) beginChildDiscovery(history wallet.AddressHistory, gapLimit int, password string) tea.Cmd {
+	m.discoveryHistory = history
+	m.discoveryGapLimit = gapLimit
+	m.discoveryPassword = password
+	m.discoveryNext = 1
+	m.discoveryGap = 0
+	m.discoveryFound = nil
+	m.discoveryRunning = true
+	m.discoveryCancelled = false
+	m.discoveryDone = false
+	m.setDiscoveryResult("", false)
+	m.currentView = constants.ChildDiscoveryView
+	return childScanStepCmd(history, *m.walletDetails.Mnemonic, m.discoveryNext)
+}
+
+// childScanStepCmd checks one index in the background, so the progress stays
+// on screen and esc can stop the scan between two requests
+func childScanStepCmd(history wallet.AddressHistory, mnemonic string, index uint32) tea.Cmd {
+	return func() tea.Msg {
+		child, used, err := wallet.CheckChildAddress(context.Background(), mnemonic, index, history)
+		return childScanStepMsg{child: child, used: used, err: err}
+	}
+}
+
+// handleChildScanStep records the checked index and moves to the next one
+// until gapLimit unused addresses were seen in a row
+func (m *CLIModel) handleChildScanStep(msg childScanStepMsg) tea.Cmd {
+	if !m.discoveryRunning || m.walletDetails == nil {
+		return nil
+	}
+	if msg.err != nil {
+		m.finishChildDiscovery()
+		m.setDiscoveryResult(fmt.Sprintf(localization.Labels["discovery_failed"], msg.err), true)
+		return nil
+	}
+	if msg.used {
+		m.discoveryFound = append(m.discoveryFound, msg.child)
+		m.discoveryGap = 0
+	} else {
+		m.discoveryGap++
+	}
+	m.discoveryNext++
+	if m.discoveryGap >= m.discoveryGapLimit || m.discoveryCancelled {
+		m.finishChildDiscovery()
+		return nil
+	}
+	return childScanStepCmd(m.discoveryHistory, *m.walletDetails.Mnemonic, m.discoveryNext)
+}
+
+// finishChildDiscovery stops the scan and releases the network connections
+func (m *CLIModel) finishChildDiscovery() {
+	m.discoveryRunning = false
+	if closer, ok := m.discoveryHistory.(interface{ Close() }); ok {
+		closer.Close()
+	}
+	m.discoveryHistory = nil
+}
+
+// skipChildDiscovery stops a running scan, or leaves for the imported wallet
+func (m *CLIModel) skipChildDiscovery() {
+	if m.discoveryRunning {
+		m.discoveryCancelled = true
+		m.setDiscoveryResult(localization.Labels["discovery_stopping"], false)
+		return
+	}
+	m.closeChildDiscovery()
+}
+
+// closeChildDiscovery forgets the import password and shows the imported wallet
+func (m *CLIModel) closeChildDiscovery() {
+	m.discoveryPassword = ""
+	m.discoveryFound = nil
+	m.currentView = constants.WalletDetailsView
+}
+
+// updateChildDiscovery registers the found addresses on enter
+func (m *CLIModel) updateChildDiscovery(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.discoveryRunning || keyMsg.String() != "enter" {
+		return m, nil
+	}
+	if m.discoveryDone || len(m.discoveryFound) == 0 {
+		m.closeChildDiscovery()
+		return m, nil
+	}
+	return m, m.registerDiscoveredChildren()
+}
+
+// registerDiscoveredChildren registers every found address as a child wallet
+// encrypted with the password of the import
+func (m *CLIModel) registerDiscoveredChildren() tea.Cmd {
+	registered, skipped, err := m.Service.RegisterDiscoveredChildren(m.walletDetails, m.discoveryFound, m.discoveryPassword)
+	m.discoveryDone = true
+	m.discoveryPassword = ""
+	if err != nil {
+		m.setDiscoveryResult(fmt.Sprintf(localization.Labels["discovery_register_failed"], err, registered), true)
+	} else {
+		m.setDiscoveryResult(fmt.Sprintf(localization.Labels["discovery_registered"], registered, skipped), false)
+	}
+	if registered == 0 {
+		return nil
+	}
+	return m.refreshWalletsTable()
+}
+
+func (m *CLIModel) setDiscoveryResult(status string, failed bool) {
+	m.discoveryStatus = status
+	m.discoveryFailed = failed
+}
+
+// viewChildDiscovery renders the scan progress and the addresses found
+func (m *CLIModel) viewChildDiscovery() string {
+	if m.walletDetails == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["discovery_title"]))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["child_master"], m.walletDetails.Wallet.Name, wallet.ChecksumAddress(m.walletDetails.Wallet.Address)))
+	b.WriteString("\n")
+	b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf(localization.Labels["discovery_desc"], m.discoveryGapLimit)))
+	b.WriteString("\n\n")
+
+	if m.discoveryRunning {
+		b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf(localization.Labels["discovery_progress"], wallet.ChildDerivationPath(m.discoveryNext), len(m.discoveryFound))))
+		b.WriteString("\n\n")
+	} else if len(m.discoveryFound) == 0 && !m.discoveryFailed {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["discovery_none"]))
+		b.WriteString("\n\n")
+	}
+	for _, child := range m.discoveryFound {
+		b.WriteString(fmt.Sprintf("%-22s %s  %s\n", wallet.ChildDerivationPath(child.Index), child.Address, wallet.ChildLabel(m.walletDetails.Wallet.Name, child.Index)))
+	}
+	if len(m.discoveryFound) > 0 {
+		b.WriteString("\n")
+	}
+
+	if m.discoveryStatus != "" {
+		if m.discoveryFailed {
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.discoveryStatus))
+		} else {
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.discoveryStatus))
+		}
+		b.WriteString("\n\n")
+	}
+
+	help := localization.Labels["discovery_help_done"]
+	switch {
+	case m.discoveryRunning:
+		help = localization.Labels["discovery_help_running"]
+	case !m.discoveryDone && len(m.discoveryFound) > 0:
+		help = localization.Labels["discovery_help_register"]
+	}
+	b.WriteString(m.styles.MenuDesc.Render(help))
+	return b.String()
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// usedAddresses reports the listed addresses as used
+type usedAddresses map[string]bool
+
+func (u usedAddresses) HasHistory(ctx context.Context, address string) (bool, error) {
+	return u[address], nil
+}
+
+func TestChildDiscovery_ScanAndRegister(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddChildWalletMessages()
+
+	dir := t.TempDir()
+	repo, err := storage.NewWalletRepository(&config.Config{AppDir: dir, DatabasePath: filepath.Join(dir, "wallets.db")})
+	require.NoError(t, err)
+	defer repo.Close()
+	n, p := wallet.GetTestKeystoreParams()
+	ws := &wallet.WalletService{Repo: repo, KeyStore: keystore.NewKeyStore(filepath.Join(dir, "keystore"), n, p)}
+
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	encrypted := "encrypted"
+	master := &wallet.Wallet{Name: "Team", Address: "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", KeyStorePath: "team.json",
+		ImportMethod: string(wallet.ImportMethodMnemonic), Mnemonic: &encrypted, SourceHash: "team"}
+	require.NoError(t, repo.AddWallet(master))
+	details := &wallet.WalletDetails{Wallet: master, Mnemonic: &mnemonic, HasMnemonic: true, ImportMethod: wallet.ImportMethodMnemonic}
+
+	// Index 3 was handed out before, so only index 1 is new
+	_, err = ws.CreateChildWallet(details, 3, "bob", "Str0ngPassword")
+	require.NoError(t, err)
+
+	used := usedAddresses{}
+	for _, index := range []uint32{1, 3} {
+		address, err := wallet.ChildAddress(mnemonic, index)
+		require.NoError(t, err)
+		used[address] = true
+	}
+
+	m := &CLIModel{Service: ws, styles: createStyles(), walletDetails: details}
+	cmd := m.beginChildDiscovery(used, 2, "Str0ngPassword")
+	require.Equal(t, constants.ChildDiscoveryView, m.currentView)
+	assert.Contains(t, m.viewChildDiscovery(), "m/44'/60'/0'/0/1")
+	for cmd != nil {
+		cmd = m.handleChildScanStep(cmd().(childScanStepMsg))
+	}
+	require.False(t, m.discoveryRunning)
+	require.Len(t, m.discoveryFound, 2)
+	assert.Equal(t, uint32(6), m.discoveryNext, "indices 4 and 5 close the gap")
+	assert.Contains(t, m.viewChildDiscovery(), "Team #1")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, m.discoveryFailed, m.discoveryStatus)
+	assert.Contains(t, m.discoveryStatus, "1 child wallets registered, 1 already known")
+	assert.Empty(t, m.discoveryPassword, "the import password is not kept")
+
+	wallets, err := repo.GetAllWallets()
+	require.NoError(t, err)
+	require.Len(t, wallets, 3)
+	assert.Equal(t, "Team #1", wallets[2].Name)
+	assert.Equal(t, "m/44'/60'/0'/0/1", wallets[2].DerivationPath)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, constants.WalletDetailsView, m.currentView)
+}
+
+func TestChildDiscovery_EscStopsThenSkips(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddChildWalletMessages()
+
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	m := &CLIModel{styles: createStyles()}
+	m.walletDetails = &wallet.WalletDetails{Wallet: &wallet.Wallet{Name: "Team"}, Mnemonic: &mnemonic, HasMnemonic: true}
+
+	cmd := m.beginChildDiscovery(usedAddresses{}, 20, "Str0ngPassword")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.True(t, m.discoveryRunning, "the current check finishes first")
+	assert.Nil(t, m.handleChildScanStep(cmd().(childScanStepMsg)))
+	assert.False(t, m.discoveryRunning)
+	assert.Contains(t, m.viewChildDiscovery(), "No used child addresses")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.WalletDetailsView, m.currentView)
+	assert.Empty(t, m.discoveryPassword)
+
+	// Nothing starts when the scan is disabled
+	m.currentConfig = &config.Config{Discovery: config.DiscoveryConfig{GapLimit: 0}}
+	assert.Nil(t, m.startChildDiscovery("Str0ngPassword"))
+}
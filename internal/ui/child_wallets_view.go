@@ -0,0 +1,197 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	childFocusLabel = iota
+	childFocusIndex
+	childFocusPassword
+	childFocusCount
+)
+
+// initChildWallets opens the child wallets of the master wallet being displayed
+func (m *CLIModel) initChildWallets() {
+	m.childLabelInput = textinput.New()
+	m.childLabelInput.Placeholder = localization.Labels["child_label_placeholder"]
+	m.childLabelInput.CharLimit = 50
+	m.childLabelInput.Width = 50
+
+	m.childIndexInput = textinput.New()
+	m.childIndexInput.CharLimit = 10
+	m.childIndexInput.Width = 12
+
+	m.childPasswordInput = textinput.New()
+	m.childPasswordInput.Placeholder = localization.Labels["enter_password"]
+	m.childPasswordInput.CharLimit = constants.PasswordCharLimit
+	m.childPasswordInput.Width = constants.PasswordWidth
+	m.childPasswordInput.EchoMode = textinput.EchoPassword
+	m.childPasswordInput.EchoCharacter = '•'
+
+	m.childStatus = ""
+	m.childFailed = false
+	m.loadChildAllocations()
+	m.setChildFocus(childFocusLabel)
+	m.currentView = constants.ChildWalletsView
+}
+
+// loadChildAllocations reads the allocated indices and suggests the next free one
+func (m *CLIModel) loadChildAllocations() {
+	allocations, err := m.Service.ChildAllocations(m.walletDetails.Wallet)
+	if err != nil {
+		m.setChildResult(err.Error(), true)
+		return
+	}
+	m.childAllocations = allocations
+	m.childIndexInput.SetValue(strconv.FormatUint(uint64(wallet.NextChildIndex(allocations)), 10))
+}
+
+func (m *CLIModel) setChildFocus(focus int) {
+	m.childFocus = focus
+	m.childLabelInput.Blur()
+	m.childIndexInput.Blur()
+	m.childPasswordInput.Blur()
+	switch focus {
+	case childFocusLabel:
+		m.childLabelInput.Focus()
+	case childFocusIndex:
+		m.childIndexInput.Focus()
+	case childFocusPassword:
+		m.childPasswordInput.Focus()
+	}
+}
+
+// updateChildWallets handles input on the child wallets screen
+func (m *CLIModel) updateChildWallets(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "tab", "down":
+		m.setChildFocus((m.childFocus + 1) % childFocusCount)
+		return m, nil
+	case "shift+tab", "up":
+		m.setChildFocus((m.childFocus + childFocusCount - 1) % childFocusCount)
+		return m, nil
+	case "enter":
+		return m, m.createChildWallet()
+	}
+
+	var cmd tea.Cmd
+	switch m.childFocus {
+	case childFocusLabel:
+		m.childLabelInput, cmd = updateTextInput(m.childLabelInput, msg)
+	case childFocusIndex:
+		m.childIndexInput, cmd = updateTextInput(m.childIndexInput, msg)
+	case childFocusPassword:
+		m.childPasswordInput, cmd = updateTextInput(m.childPasswordInput, msg)
+	}
+	return m, cmd
+}
+
+// createChildWallet derives and registers the child wallet described by the form
+func (m *CLIModel) createChildWallet() tea.Cmd {
+	index, err := strconv.ParseUint(strings.TrimSpace(m.childIndexInput.Value()), 10, 32)
+	if err != nil {
+		m.setChildResult(localization.Labels["child_invalid_index"], true)
+		m.setChildFocus(childFocusIndex)
+		return nil
+	}
+	password := strings.TrimSpace(m.childPasswordInput.Value())
+	if validationErr, ok := wallet.ValidatePassword(password); !ok {
+		m.setChildResult(validationErr.GetErrorMessage(), true)
+		m.setChildFocus(childFocusPassword)
+		return nil
+	}
+
+	child, err := m.Service.CreateChildWallet(m.walletDetails, uint32(index), m.childLabelInput.Value(), password)
+	switch {
+	case errors.Is(err, wallet.ErrChildIndexAllocated):
+		m.setChildResult(fmt.Sprintf(localization.Labels["child_index_taken"], index), true)
+		m.loadChildAllocations()
+		return nil
+	case err != nil:
+		m.setChildResult(err.Error(), true)
+		return nil
+	}
+
+	m.setChildResult(fmt.Sprintf(localization.Labels["child_created"], child.Wallet.Name, child.Wallet.DerivationPath, wallet.ChecksumAddress(child.Wallet.Address)), false)
+	m.childLabelInput.SetValue("")
+	m.childPasswordInput.SetValue("")
+	m.loadChildAllocations()
+	m.setChildFocus(childFocusLabel)
+	return m.refreshWalletsTable()
+}
+
+func (m *CLIModel) setChildResult(status string, failed bool) {
+	m.childStatus = status
+	m.childFailed = failed
+}
+
+// viewChildWallets renders the allocated children and the derivation form
+func (m *CLIModel) viewChildWallets() string {
+	if m.walletDetails == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["child_title"]))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["child_master"], m.walletDetails.Wallet.Name, wallet.ChecksumAddress(m.walletDetails.Wallet.Address)))
+	b.WriteString("\n")
+	b.WriteString(m.styles.MenuDesc.Render(localization.Labels["child_desc"]))
+	b.WriteString("\n\n")
+
+	if len(m.childAllocations) == 0 {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["child_none"]))
+		b.WriteString("\n")
+	}
+	registered := make(map[string]bool, len(m.wallets))
+	for _, w := range m.wallets {
+		registered[w.Address] = true
+	}
+	for _, a := range m.childAllocations {
+		line := fmt.Sprintf("%-22s %s  %s", wallet.ChildDerivationPath(a.Index), a.Address, a.Label)
+		if len(m.wallets) > 0 && !registered[a.Address] {
+			line += " " + m.styles.MenuDesc.Render(localization.Labels["child_deleted"])
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	b.WriteString(localization.Labels["child_label"])
+	b.WriteString("\n")
+	b.WriteString(m.childLabelInput.View())
+	b.WriteString("\n\n")
+	b.WriteString(localization.Labels["child_index"])
+	b.WriteString("\n")
+	b.WriteString(m.childIndexInput.View())
+	b.WriteString("\n\n")
+	b.WriteString(localization.Labels["child_password"])
+	b.WriteString("\n")
+	b.WriteString(m.childPasswordInput.View())
+	b.WriteString("\n\n")
+
+	if m.childStatus != "" {
+		if m.childFailed {
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.childStatus))
+		} else {
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.childStatus))
+		}
+		b.WriteString("\n\n")
+	}
+	b.WriteString(m.styles.MenuDesc.Render(localization.Labels["child_help"]))
+	return b.String()
+}
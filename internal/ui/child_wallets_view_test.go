@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChildWallets_DeriveAndTrackIndices(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddChildWalletMessages()
+
+	dir := t.TempDir()
+	repo, err := storage.NewWalletRepository(&config.Config{AppDir: dir, DatabasePath: filepath.Join(dir, "wallets.db")})
+	require.NoError(t, err)
+	defer repo.Close()
+	n, p := wallet.GetTestKeystoreParams()
+	ws := &wallet.WalletService{Repo: repo, KeyStore: keystore.NewKeyStore(filepath.Join(dir, "keystore"), n, p)}
+
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	encrypted := "encrypted"
+	master := &wallet.Wallet{Name: "Team", Address: "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", KeyStorePath: "team.json",
+		ImportMethod: string(wallet.ImportMethodMnemonic), Mnemonic: &encrypted, SourceHash: "team"}
+	require.NoError(t, repo.AddWallet(master))
+
+	m := &CLIModel{Service: ws, styles: createStyles(), currentView: constants.WalletDetailsView}
+	m.walletDetails = &wallet.WalletDetails{Wallet: master, Mnemonic: &mnemonic, HasMnemonic: true, ImportMethod: wallet.ImportMethodMnemonic}
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	require.Equal(t, constants.ChildWalletsView, m.currentView)
+	assert.Equal(t, "1", m.childIndexInput.Value())
+
+	m.childLabelInput.SetValue("alice")
+	m.childPasswordInput.SetValue("Str0ngPassword")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, m.childFailed, m.childStatus)
+	assert.Contains(t, m.childStatus, "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0")
+	assert.Equal(t, "2", m.childIndexInput.Value(), "the next free index is suggested")
+
+	// The child is a wallet of its own, without the mnemonic
+	wallets, err := repo.GetAllWallets()
+	require.NoError(t, err)
+	require.Len(t, wallets, 2)
+	child := wallets[1]
+	assert.Equal(t, "alice", child.Name)
+	assert.Equal(t, string(wallet.ImportMethodDerived), child.ImportMethod)
+	assert.Equal(t, "m/44'/60'/0'/0/1", child.DerivationPath)
+	assert.Nil(t, child.Mnemonic)
+
+	details, err := ws.LoadWallet(&child, "Str0ngPassword")
+	require.NoError(t, err)
+	assert.Equal(t, child.Address, details.Signer.Address().Hex())
+
+	// Deleting the child keeps its index allocated
+	require.NoError(t, ws.DeleteWallet(&child))
+	m.childIndexInput.SetValue("1")
+	m.childLabelInput.SetValue("mallory")
+	m.childPasswordInput.SetValue("Str0ngPassword")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, m.childFailed)
+	assert.Contains(t, m.childStatus, "Index 1 is already allocated")
+	assert.Contains(t, m.viewChildWallets(), "alice")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.WalletDetailsView, m.currentView)
+}
@@ -1,13 +1,32 @@
 package ui
 
 import (
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/buildinfo"
+	"blocowallet/internal/changelog"
+	"blocowallet/internal/compliance"
 	"blocowallet/internal/constants"
+	"blocowallet/internal/inheritance"
+	"blocowallet/internal/integrity"
+	"blocowallet/internal/jobs"
+	"blocowallet/internal/metasync"
+	"blocowallet/internal/notify"
+	"blocowallet/internal/platform"
+	"blocowallet/internal/qr"
 	"blocowallet/internal/wallet"
+	"blocowallet/internal/walletconnect"
 	"blocowallet/pkg/config"
+	"blocowallet/pkg/logger"
+	"time"
 
+	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/digitallyserviced/tdfgo/tdf"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 type CLIModel struct {
@@ -36,15 +55,461 @@ type CLIModel struct {
 	selectedFont      *tdf.TheDrawFont // Fonte selecionada aleatoriamente
 	fontInfo          *tdf.FontInfo    // Informação da fonte selecionada
 	dialogButtonIndex int              // 0 = Confirmar, 1 = Cancelar
-	currentConfig     *config.Config   // Configuração atual da aplicação
+	// Exclusão de carteiras sem mnemônica: frase de confirmação e cópia da keystore
+	deletePhraseRequired bool
+	deleteConfirmInput   textinput.Model // Nome da carteira ou os 4 últimos caracteres do endereço
+	deleteArchive        bool            // Copiar a keystore para a pasta de arquivo antes de excluir
+	deleteStatus         string          // Onde a keystore da última carteira excluída foi copiada
+	currentConfig        *config.Config  // Configuração atual da aplicação
 
 	// Network components
 	networkListComponent NetworkListComponent // Componente de lista de redes
 	addNetworkComponent  AddNetworkComponent  // Componente de adição de rede
 	editingNetworkKey    string               // Chave da rede sendo editada
+	networkDeleteKey     string               // Rede cuja remoção aguarda confirmação
+	networkDeleteUsage   networkUsage         // Saldos e transações pendentes na rede a remover
 
 	// Enhanced import state
 	enhancedImportState *EnhancedImportState
+
+	// Background jobs
+	jobManager  *jobs.Manager // Gerenciador de tarefas em segundo plano
+	selectedJob int           // Índice da tarefa selecionada na tela de Jobs
+	importJobID string        // Tarefa associada à importação em lote atual
+	session     sessionStats  // Resumo da sessão exibido ao sair
+
+	// Derivação de chave em andamento (desbloqueio ou importação)
+	kdfOp  *kdfOperation // Operação aguardada, nil quando nenhuma
+	kdfSeq int           // Identificador da última operação iniciada
+
+	// Aviso temporário sobre a última entrada (ex.: colagem rejeitada)
+	inputNotice string
+
+	// Cópia dos detalhes da carteira, apagada da área de transferência após o prazo
+	clipboard       *clipboardCopy // Cópia em contagem regressiva, nil quando nenhuma
+	clipboardSeq    int            // Identificador da última cópia
+	clipboardStatus string         // Resultado da última cópia
+
+	// Agendador que coalesce as recargas da lista de wallets
+	refreshScheduler *refreshScheduler
+
+	// Permissões de arquivos sensíveis (keystores e banco de dados)
+	protectedPaths      []string                   // Caminhos verificados na inicialização e após importações
+	permissionIssues    []platform.PermissionIssue // Arquivos que não puderam ser corrigidos
+	quarantinedNetworks []blockchain.EndpointCheck // Redes em quarentena pelo modo RPC estrito
+	integrityIssues     []integrity.Issue          // Binário ou dados embutidos alterados desde o build
+	proxyChecked        bool                       // Se o proxy SOCKS5 já foi verificado
+	proxyErr            error                      // Falha da última verificação do proxy
+	securityReturnView  string                     // Tela para onde voltar após o aviso
+
+	// Exportação criptografada de segredos para uma chave GPG/age
+	exportSecret         wallet.ExportSecret // Segredo selecionado (mnemônico, keystore ou KeyStore V3)
+	exportRecipientInput textinput.Model     // Chave pública age ou arquivo de chave GPG/age
+	exportPasswordInput  textinput.Model     // Nova senha do arquivo KeyStore V3
+	exportConfirmInput   textinput.Model     // Confirmação da nova senha
+	exportPathInput      textinput.Model     // Arquivo de saída
+	exportFocus          int                 // Campo com foco no formulário
+	exportStatus         string              // Resultado da última exportação
+	exportFailed         bool
+
+	// Plano de herança e lembretes de verificação de backup
+	inheritanceStore    *inheritance.Store
+	inheritancePlan     *inheritance.Plan
+	backupReminderEvery time.Duration     // Intervalo entre lembretes (0 desativa)
+	inheritanceDir      string            // Diretório padrão dos pacotes de herança
+	inheritanceMode     int               // Visão geral ou formulário aberto
+	inheritanceInputs   []textinput.Model // Campos do formulário aberto
+	inheritanceFocus    int
+	selectedHolder      int
+	inheritanceStatus   string
+	inheritanceFailed   bool
+
+	// Modo de conformidade: exportações e exclusões exigem um segundo aprovador
+	complianceGate *compliance.Gate     // nil quando o modo está desativado
+	capabilities   wallet.CapabilitySet // O que o serviço de carteiras oferece; itens do menu dependem disso
+	approvalNotice string               // Aprovação pendente exibida na tela atual
+
+	// Sincronização de metadados entre instalações
+	syncer      *metasync.Syncer // nil quando não configurada
+	syncRunning bool
+	syncStatus  string // Resultado da última sincronização
+	syncFailed  bool
+
+	// Backup criptografado do banco de dados e de todas as keystores
+	backupRestore       bool            // Restaurar um backup em vez de exportar
+	backupPathInput     textinput.Model // Arquivo do backup
+	backupPasswordInput textinput.Model
+	backupConfirmInput  textinput.Model // Confirmação da senha, apenas ao exportar
+	backupKeyInput      textinput.Model // Identidade age ou chave secreta GPG, apenas ao restaurar
+	backupFocus         int
+	backupRunning       bool
+	backupStatus        string // Resultado da última exportação ou restauração
+	backupFailed        bool
+
+	// Senha mestra: definição na primeira execução, desbloqueio e troca
+	masterMode         int
+	masterCurrentInput textinput.Model // Senha atual, ao desbloquear ou trocar
+	masterNewInput     textinput.Model
+	masterConfirmInput textinput.Model
+	masterFocus        int
+	masterRunning      bool
+	masterStatus       string
+	masterFailed       bool
+	masterRestored     bool // Desbloqueio pedido após restaurar um backup
+
+	// Visualizador dos arquivos de log
+	logEntries  []logger.Entry // Fim de app.log e error.log, em ordem de tempo
+	logMinLevel int            // Nível mínimo exibido, de 0 (debug) a 3 (error)
+	logScroll   int            // Entradas roladas a partir do fim
+	logSeq      int            // Invalida a atualização periódica ao sair
+	logErr      error
+
+	// Verificação do banco de dados contra o diretório de keystores
+	storeReport        *wallet.StoreReport
+	storeErr           error
+	storeCursor        int  // Problema selecionado
+	storeReimporting   bool // Formulário de reimportação de uma keystore órfã aberto
+	storeNameInput     textinput.Model
+	storePasswordInput textinput.Model
+	storeFocus         int
+	storeStatus        string // Resultado do último reparo
+	storeFailed        bool
+
+	// Editor das configurações, aplicadas sem reiniciar
+	configFields       []configField
+	configEditorFocus  int
+	configEditorStatus string // Resultado do último salvamento ou recarga
+	configEditorFailed bool
+
+	// Perfis, cada um com seu diretório, banco de dados, keystores e redes
+	profileOpener    ProfileOpener // nil quando a troca não está disponível
+	profiles         []string
+	profileCursor    int
+	profileCreating  bool // Formulário de novo perfil aberto
+	profileInput     textinput.Model
+	profileSwitching bool // Abrindo o perfil selecionado em segundo plano
+	profileStatus    string
+	profileFailed    bool
+
+	// Tema de cores da interface
+	themeName    string       // Tema configurado; a pré-visualização não o altera
+	themeEntries []themeEntry // Temas embutidos e arquivos do usuário
+	themeCursor  int
+	themeStatus  string
+	themeFailed  bool
+
+	// Paleta de comandos (ctrl+p) sobre a tela atual
+	paletteOpen    bool
+	paletteInput   textinput.Model
+	paletteEntries []paletteEntry // Ações dos menus e carteiras
+	paletteMatches []paletteMatch // Entradas que combinam com a busca, da melhor para a pior
+	paletteCursor  int
+
+	// Ajuda com as teclas da tela atual (?)
+	helpOpen bool
+
+	// Notificações por webhook (importações, backups e verificações de saúde)
+	notifier *notify.Notifier // nil quando não configurado
+
+	// Carteira cujo endereço não confere com a chave ao ser carregada
+	integrityIssue  *wallet.IntegrityError
+	integrityStatus string // Resultado da quarentena
+	integrityFailed bool
+
+	// Importação que substituiria uma keystore gerenciada diferente
+	keystoreOverwrite      *wallet.KeystoreOverwriteError
+	keystoreOverwriteRetry func() tea.Cmd // Refaz a importação após a confirmação
+
+	// Busca na lista de carteiras (nome, endereço, notas e referências)
+	walletFilter     textinput.Model
+	filteringWallets bool       // Campo de busca com o foco
+	walletSort       walletSort // Coluna de ordenação da lista (tecla s)
+	walletListHeight int        // Altura disponível para a lista, medida ao renderizar
+
+	// Relatório da lista filtrada em CSV ou Markdown
+	walletReportInput  textinput.Model // Caminho do arquivo
+	exportingReport    bool            // Campo do caminho com o foco
+	walletReportStatus string          // Resultado do último relatório
+	walletReportFailed bool
+
+	// Edição de notas e referências externas de uma carteira
+	notesWallet     *wallet.Wallet
+	notesInput      textarea.Model
+	referencesInput textinput.Model // Referências separadas por vírgula
+	notesFocus      int
+	notesStatus     string // Erro ao salvar
+	notesFailed     bool
+
+	// Criação de várias carteiras de uma vez
+	batchCountInput    textinput.Model
+	batchPatternInput  textinput.Model
+	batchPasswordInput textinput.Model
+	batchOutputInput   textinput.Model // Arquivo CSV com nomes e endereços
+	batchPolicy        wallet.PasswordPolicy
+	batchFocus         int
+	batchOptions       wallet.BatchCreateOptions // Lote em andamento
+	batchCreated       []wallet.BatchCreatedWallet
+	batchOutputPath    string
+	batchRunning       bool
+	batchCancelled     bool // Parar após a carteira em criação
+	batchStatus        string
+	batchFailed        bool
+
+	// Carteiras filhas derivadas da mnemônica da carteira aberta
+	childAllocations   []wallet.ChildAllocation
+	childLabelInput    textinput.Model
+	childIndexInput    textinput.Model
+	childPasswordInput textinput.Model // Senha do keystore da carteira filha
+	childFocus         int
+	childStatus        string
+	childFailed        bool
+
+	// Endpoints RPC próprios da carteira aberta, por chain ID
+	rpcOverrides map[int64]string
+	rpcCursor    int
+	rpcEditing   bool // Editando o endpoint da rede selecionada
+	rpcInput     textinput.Model
+	rpcStatus    string
+	rpcFailed    bool
+
+	// Importação dos donos de uma Safe como carteiras somente leitura
+	safeAddressInput textinput.Model
+	safeLabelInput   textinput.Model // Rótulo gravado nas referências dos donos
+	safeFocus        int
+	safeNetwork      int // Índice em usableNetworks
+	safeFetching     bool
+	safeChainID      int64
+	safeInfo         *blockchain.SafeInfo
+	safeOwners       []wallet.SafeOwner
+	safeImported     *wallet.SafeOwnerImport
+	safeStatus       string
+	safeFailed       bool
+
+	// Busca de endereços filhos usados após importar uma mnemônica
+	discoveryHistory   wallet.AddressHistory
+	discoveryGapLimit  int
+	discoveryPassword  string // Senha da importação, usada nas carteiras filhas
+	discoveryNext      uint32 // Próximo índice a verificar
+	discoveryGap       int    // Índices sem uso seguidos
+	discoveryFound     []wallet.DiscoveredChild
+	discoveryRunning   bool
+	discoveryCancelled bool
+	discoveryDone      bool // Carteiras filhas já registradas
+	discoveryStatus    string
+	discoveryFailed    bool
+
+	// Assinatura de transações sem transmissão
+	signInputs    []textinput.Model
+	signFocus     int
+	signFilling   bool // Lendo nonce e taxas da rede
+	signedTx      *types.Transaction
+	signedRequest wallet.TransferRequest
+	signedRaw     string   // Transação assinada em hex
+	signedQR      *qr.Code // nil quando não cabe em um QR
+	signStatus    string
+	signFailed    bool
+
+	// Envio de transferências pela rede
+	sendInputs  []textinput.Model
+	sendFocus   int
+	sendNetwork int                     // Índice em usableNetworks
+	sendSeq     int                     // Identifica a preparação em curso
+	sendRequest *wallet.TransferRequest // Preparada com nonce, gas e taxas da rede, aguardando confirmação
+	sendBusy    bool
+	sendSent    *wallet.RawTransaction
+	sendTracked bool // Incluída nas transações acompanhadas
+	sendStatus  string
+	sendFailed  bool
+	sendAsset   int                    // 0 para a moeda nativa, depois os tokens da rede
+	sendTokens  []blockchain.Token     // Tokens acompanhados na rede escolhida
+	sendFork    *blockchain.ForkResult // Execução da transferência em um fork local
+
+	// Forks locais com anvil para testar transações antes de assinar
+	forkRunnerSvc *blockchain.ForkRunner
+	forkAvailable bool // anvil encontrado
+
+	// Saldos por rede na tela de detalhes, atualizados conforme chegam
+	balanceCache    *blockchain.BalanceService
+	balances        map[string]blockchain.NetworkBalance
+	balanceNetworks map[string]config.Network // Redes consultadas, já com os endpoints da carteira
+	balanceSeq      int                       // Identifica a consulta em curso
+
+	// Tokens ERC-20 acompanhados
+	tokenSvc      *blockchain.TokenService
+	tokenBalances map[string][]blockchain.TokenBalance // Por rede, da carteira exibida
+	tokenList     []blockchain.Token
+	tokenCursor   int
+	tokenAdding   bool
+	tokenInput    textinput.Model
+	tokenNetwork  int // Índice em usableNetworks
+	tokenBusy     bool
+	tokenStatus   string
+	tokenFailed   bool
+	tokenReview   bool // Revisando os tokens ocultos como spam ou bloqueados
+	tokenHidden   int  // Tokens ocultos da lista
+
+	// Tela Sobre e relatório do ambiente
+	buildInfo   buildinfo.Info
+	aboutReport string // Gerado ao abrir a tela
+	aboutStatus string
+	aboutFailed bool
+
+	// Novidades das versões lançadas desde a última execução
+	releaseNotes []changelog.Release
+
+	// Transmissão de transações já assinadas
+	broadcastInput   textinput.Model
+	broadcastTx      *wallet.RawTransaction
+	broadcastSigner  string // Nome da carteira local que assinou, se houver
+	broadcastNetwork int    // Índice em usableNetworks
+	broadcastSending bool
+	broadcastSent    *blockchain.TxStatus // Estado após o envio
+	broadcastStatus  string
+	broadcastFailed  bool
+	broadcastTracked bool // Incluída nas transações acompanhadas
+
+	// Envio em lote a partir de várias carteiras, com estimativa por rede, ou
+	// varredura de todos os saldos para um endereço
+	batchSweep           bool
+	batchSendWallets     []wallet.Wallet // Carteiras com chave que podem enviar
+	batchSendNetworks    []config.Network
+	batchSendTokens      []blockchain.Token // Tokens acompanhados, para a varredura
+	batchSendPicked      map[string]bool    // Por endereço
+	batchSendNetPicked   map[int64]bool     // Por chain ID
+	batchSendAssetPicked map[string]bool    // Moeda nativa e tokens a varrer
+	batchSendCursor      int
+	batchSendNetCursor   int
+	batchSendAssetCursor int
+	batchSendFocus       int
+	batchSendTo          textinput.Model
+	batchSendAmount      textinput.Model
+	batchSendStage       int
+	batchSendSeq         int // Descarta estimativas de um lote já alterado
+	batchSendPlans       []batchSendNetwork
+	batchSendUnlockQueue []common.Address // Carteiras cuja senha ainda será pedida
+	batchSendUnlocking   wallet.Wallet
+	batchSendPopup       *PasswordPopupModel
+	batchSendUnlockBusy  bool
+	batchSendSigners     map[common.Address]wallet.Signer
+	batchSendLocked      map[common.Address]error // Carteiras não desbloqueadas ou puladas
+	batchSendDryRun      bool                     // Apenas assina, sem enviar
+	batchSendPosition    [2]int                   // Rede e item sendo enviados
+	batchSendCancelled   bool
+	batchSendTracked     bool
+	batchSendStatus      string
+	batchSendFailed      bool
+	batchSendReport      string
+
+	// Exportação em lote dos keystores para um diretório
+	batchExportMarked     map[string]bool // Carteiras marcadas na lista, por endereço
+	batchExporter         *wallet.BatchExportService
+	batchExportJobs       []wallet.ExportJob
+	batchExportDirInput   textinput.Model
+	batchExportDir        string
+	batchExportPasswords  bool // Grava um arquivo .pwd ao lado de cada keystore
+	batchExportFocus      int
+	batchExportStage      int
+	batchExportSeq        int // Descarta respostas de uma exportação abandonada
+	batchExportUnlocking  int // Job cuja senha está sendo pedida
+	batchExportPopup      *PasswordPopupModel
+	batchExportUnlockBusy bool
+	batchExportLeftOut    map[int]error // Jobs sem senha, deixados de fora
+	batchExportJobID      string
+	batchExportCancelling bool
+	batchExportProgress   wallet.ImportProgress
+	batchExportBar        progress.Model
+	batchExportResults    []wallet.ExportResult
+	batchExportStatus     string
+	batchExportFailed     bool
+
+	// Ações em lote sobre as carteiras marcadas na lista
+	walletBatchAction     walletBatchAction
+	walletBatchWallets    []wallet.Wallet
+	walletBatchInput      textinput.Model // Quantidade que confirma a exclusão ou a referência
+	walletBatchArchive    bool            // Copia as keystores sem mnemônica antes de excluir
+	walletBatchNetworks   map[string]config.Network
+	walletBatchStage      int
+	walletBatchSeq        int // Descarta respostas de um lote abandonado
+	walletBatchJobID      string
+	walletBatchCancelling bool
+	walletBatchProgress   ImportProgressModel
+	walletBatchResults    []walletBatchResult
+	walletBatchStatus     string
+	walletBatchFailed     bool
+
+	// Importação de redes de um arquivo JSON do chainlist
+	networkImportInput      textinput.Model // Caminho do arquivo
+	networkImportChains     []blockchain.ChainInfo
+	networkImportStage      int
+	networkImportSeq        int // Descarta respostas de uma importação abandonada
+	networkImportJobID      string
+	networkImportCancelling bool
+	networkImportProgress   ImportProgressModel
+	networkImportResults    []networkImportResult
+	networkImportStatus     string
+	networkImportFailed     bool
+
+	// Importação das contas de um cofre do MetaMask
+	metamaskInputs     []textinput.Model // Arquivo, senha do MetaMask, nome e senha das carteiras
+	metamaskFocus      int
+	metamaskStage      int
+	metamaskExport     *wallet.MetamaskExport // Contas lidas, descartadas ao fim da importação
+	metamaskSeq        int                    // Descarta respostas de uma leitura ou importação abandonada
+	metamaskJobID      string
+	metamaskCancelling bool
+	metamaskProgress   ImportProgressModel
+	metamaskResults    []metamaskImportResult
+	metamaskStatus     string
+	metamaskFailed     bool
+
+	// Endereço digitado cujo checksum EIP-55 não confere, já avisado: repetido, é aceito
+	addressChecksumWarned string
+
+	// Chave privada decodificada e seu endereço, exibidos para conferência antes da senha
+	privateKeyPreview *wallet.ParsedPrivateKey
+
+	// Simulação da transação em revisão
+	broadcastPreview    *blockchain.TxPreview // Variações de saldo e allowance esperadas
+	broadcastPreviewing bool
+	broadcastPreviewErr error
+	broadcastPreviewSeq int // Identifica a simulação em curso
+
+	// Decodificação de calldata
+	signatures       *blockchain.SignatureDB      // Carregadas no primeiro uso
+	signatureLookups map[blockchain.Selector]bool // Seletores já consultados no diretório 4byte
+
+	// Palavra visível quando a frase mnemônica é revelada uma a uma
+	mnemonicRevealIndex int
+
+	// Mostra os saldos abaixo dos limites de poeira nos detalhes da carteira
+	showDust bool
+
+	// Acompanhamento de transações transmitidas
+	trackedTxs      []wallet.TrackedTransaction // Mais recentes primeiro
+	pendingPolling  bool                        // Verificação agendada enquanto houver transações abertas
+	pendingPollSeq  int                         // Identifica a verificação em curso
+	pendingPollErr  error
+	pendingSelected int
+	pendingAction   string // Aceleração ou cancelamento aguardando a senha
+	pendingSender   *wallet.Wallet
+	pendingPassword textinput.Model
+	pendingBusy     bool
+	pendingStatus   string
+	pendingFailed   bool
+
+	// Sessões WalletConnect com dapps, mantidas apenas em memória
+	wcClient    *walletconnect.Client     // Criado no primeiro uso, com o project ID configurado
+	wcURI       textinput.Model           // URI wc: do dapp a parear
+	wcProposals []*walletconnect.Proposal // Propostas de sessão aguardando revisão, em ordem de chegada
+	wcRequests  []wcRequest               // Pedidos de assinatura aguardando revisão, em ordem de chegada
+	wcWallets   []wallet.Wallet           // Carteiras com chave que podem abrir uma sessão
+	wcWallet    int                       // Carteira escolhida para a proposta
+	wcSession   int                       // Sessão selecionada na lista
+	wcUnlocking bool                      // Senha pedida para aprovar o primeiro pedido
+	wcPassword  textinput.Model
+	wcBusy      bool
+	wcStatus    string
+	wcFailed    bool
 }
 
 // GetEnhancedImportState returns the enhanced import state
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"blocowallet/internal/platform"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+	"fmt"
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// clearClipboard allows tests to keep the real clipboard untouched
+var clearClipboard = platform.ClearClipboard
+
+// clipboardCopy is the text copied from the wallet details, kept until the
+// timer clears it from the clipboard
+type clipboardCopy struct {
+	text     string
+	method   platform.ClipboardMethod
+	clearsAt time.Time
+}
+
+// clipboardTickMsg counts down the copy with the same sequence number; ticks
+// of a copy replaced since are dropped
+type clipboardTickMsg struct{ seq int }
+
+func clipboardTickCmd(seq int) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return clipboardTickMsg{seq: seq}
+	})
+}
+
+// clipboardClearDelay is how long a copy stays on the clipboard; 0 keeps it
+func (m *CLIModel) clipboardClearDelay() time.Duration {
+	if m.currentConfig == nil {
+		if cfg, err := loadOrCreateConfig(); err == nil {
+			m.currentConfig = cfg
+		}
+	}
+	if m.currentConfig == nil {
+		return config.DefaultClipboardClearSeconds * time.Second
+	}
+	return time.Duration(m.currentConfig.Security.ClipboardClearSeconds) * time.Second
+}
+
+// copyWalletDetail copies the address, private key or mnemonic of the open
+// wallet for the keys a, p and m
+func (m *CLIModel) copyWalletDetail(key string) tea.Cmd {
+	details := m.walletDetails
+	if details == nil {
+		return nil
+	}
+	switch key {
+	case "a":
+		return m.copyWithTimer(localization.Labels["clipboard_address"], details.Wallet.Address)
+	case "p":
+		if details.PrivateKey != nil {
+			return m.copyWithTimer(localization.Labels["clipboard_private_key"], fmt.Sprintf("0x%x", crypto.FromECDSA(details.PrivateKey)))
+		}
+	case "m":
+		if details.Mnemonic != nil && *details.Mnemonic != "" {
+			return m.copyWithTimer(localization.Labels["clipboard_mnemonic"], *details.Mnemonic)
+		}
+	}
+	return nil
+}
+
+// copyWithTimer puts text on the clipboard and starts the countdown that
+// clears it, replacing the countdown of an earlier copy
+func (m *CLIModel) copyWithTimer(label, text string) tea.Cmd {
+	method := copyToClipboard(text)
+	if method == platform.ClipboardTerminal {
+		m.clipboardStatus = fmt.Sprintf(localization.Labels["clipboard_copied_terminal"], label)
+	} else {
+		m.clipboardStatus = fmt.Sprintf(localization.Labels["clipboard_copied"], label)
+	}
+
+	m.clipboardSeq++
+	delay := m.clipboardClearDelay()
+	if delay <= 0 {
+		m.clipboard = nil
+		return nil
+	}
+	m.clipboard = &clipboardCopy{text: text, method: method, clearsAt: time.Now().Add(delay)}
+	return clipboardTickCmd(m.clipboardSeq)
+}
+
+// handleClipboardTick clears the clipboard once the countdown is over
+func (m *CLIModel) handleClipboardTick(msg clipboardTickMsg) tea.Cmd {
+	if m.clipboard == nil || msg.seq != m.clipboardSeq {
+		return nil
+	}
+	if time.Now().Before(m.clipboard.clearsAt) {
+		return clipboardTickCmd(msg.seq)
+	}
+	m.clearCopiedText()
+	m.clipboardStatus = localization.Labels["clipboard_cleared"]
+	return nil
+}
+
+// clearCopiedText clears a copy still counting down; quitting calls it so no
+// secret outlives the program
+func (m *CLIModel) clearCopiedText() {
+	if m.clipboard == nil {
+		return
+	}
+	clearClipboard(m.clipboard.text, m.clipboard.method)
+	m.clipboard = nil
+}
+
+// clipboardStatusIndicator shows the seconds left before the clipboard is
+// cleared; empty when nothing is counting down
+func (m *CLIModel) clipboardStatusIndicator() string {
+	if m.clipboard == nil {
+		return ""
+	}
+	left := int(math.Ceil(time.Until(m.clipboard.clearsAt).Seconds()))
+	if left < 0 {
+		left = 0
+	}
+	return fmt.Sprintf(localization.Labels["clipboard_countdown"], left)
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/jobs"
+	"blocowallet/internal/platform"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClipboard stands in for the system clipboard
+type fakeClipboard struct {
+	contents string
+	cleared  []string
+}
+
+func withFakeClipboard(t *testing.T) *fakeClipboard {
+	t.Helper()
+	fake := &fakeClipboard{}
+	copyToClipboard = func(text string) platform.ClipboardMethod {
+		fake.contents = text
+		return platform.ClipboardSystem
+	}
+	clearClipboard = func(text string, method platform.ClipboardMethod) {
+		fake.cleared = append(fake.cleared, text)
+		if fake.contents == text {
+			fake.contents = ""
+		}
+	}
+	t.Cleanup(func() {
+		copyToClipboard = platform.CopyToClipboard
+		clearClipboard = platform.ClearClipboard
+	})
+	return fake
+}
+
+func newClipboardModel(t *testing.T, clearSeconds int) *CLIModel {
+	t.Helper()
+	localization.SetCurrentLanguage("en")
+	localization.AddClipboardMessages()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	mnemonic := "test test test test test test test test test test test junk"
+	return &CLIModel{
+		styles:        createStyles(),
+		currentView:   constants.WalletDetailsView,
+		currentConfig: &config.Config{Security: config.SecurityConfig{ClipboardClearSeconds: clearSeconds}},
+		walletDetails: &wallet.WalletDetails{
+			Wallet:      &wallet.Wallet{Name: "Hot", Address: crypto.PubkeyToAddress(key.PublicKey).Hex()},
+			PrivateKey:  key,
+			Mnemonic:    &mnemonic,
+			HasMnemonic: true,
+		},
+	}
+}
+
+func TestCopyWalletDetail_ClearsAfterCountdown(t *testing.T) {
+	fake := withFakeClipboard(t)
+	m := newClipboardModel(t, 30)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	require.NotNil(t, cmd)
+	assert.Equal(t, hexutil.Encode(crypto.FromECDSA(m.walletDetails.PrivateKey)), fake.contents)
+	assert.Equal(t, "Private key copied to the clipboard", m.clipboardStatus)
+	assert.True(t, hasKey(walletDetailsKeys(m), "p"), "the private key can be copied")
+	assert.Equal(t, "Clipboard clears in 30s", m.clipboardStatusIndicator())
+
+	// Ticks keep counting down until the deadline
+	cmd = m.handleClipboardTick(clipboardTickMsg{seq: m.clipboardSeq})
+	assert.NotNil(t, cmd)
+	assert.Empty(t, fake.cleared)
+
+	m.clipboard.clearsAt = time.Now().Add(-time.Second)
+	assert.Nil(t, m.handleClipboardTick(clipboardTickMsg{seq: m.clipboardSeq}))
+	assert.Empty(t, fake.contents)
+	assert.Empty(t, m.clipboardStatusIndicator())
+	assert.Equal(t, localization.Labels["clipboard_cleared"], m.clipboardStatus)
+}
+
+func TestCopyWalletDetail_NewCopyReplacesCountdown(t *testing.T) {
+	fake := withFakeClipboard(t)
+	m := newClipboardModel(t, 30)
+
+	m.copyWalletDetail("m")
+	first := m.clipboardSeq
+	m.copyWalletDetail("a")
+	assert.Equal(t, m.walletDetails.Wallet.Address, fake.contents)
+
+	// The tick of the mnemonic copy no longer clears anything
+	m.clipboard.clearsAt = time.Now().Add(-time.Second)
+	assert.Nil(t, m.handleClipboardTick(clipboardTickMsg{seq: first}))
+	assert.Empty(t, fake.cleared)
+}
+
+func TestCopyWalletDetail_ZeroNeverClears(t *testing.T) {
+	fake := withFakeClipboard(t)
+	m := newClipboardModel(t, 0)
+
+	assert.Nil(t, m.copyWalletDetail("m"))
+	assert.Equal(t, *m.walletDetails.Mnemonic, fake.contents)
+	assert.Empty(t, m.clipboardStatusIndicator())
+}
+
+func TestQuit_ClearsCopiedSecret(t *testing.T) {
+	fake := withFakeClipboard(t)
+	m := newClipboardModel(t, 30)
+	m.currentConfig.Operations.SessionSummary = false
+	m.jobManager = jobs.NewManager()
+
+	m.copyWalletDetail("p")
+	secret := fake.contents
+	m.currentView = constants.SessionSummaryView
+	_, cmd := m.quit()
+	require.NotNil(t, cmd)
+	assert.Equal(t, []string{secret}, fake.cleared)
+	assert.Empty(t, fake.contents)
+}
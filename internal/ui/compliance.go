@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"blocowallet/internal/compliance"
+	"blocowallet/pkg/localization"
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// approvedAction is a sensitive operation that compliance mode may hold until a second person approves it
+type approvedAction struct {
+	action  compliance.Action
+	subject string
+	run     func() error            // Performs the action once approved
+	done    func(err error) tea.Cmd // Reports the outcome (including denials) on screen
+}
+
+// approvalResultMsg carries the approver's decision for a pending action
+type approvalResultMsg struct {
+	request compliance.Request
+	pending approvedAction
+	err     error
+}
+
+// SetComplianceGate enables the approval workflow for exports and deletions
+func (m *CLIModel) SetComplianceGate(gate *compliance.Gate) {
+	m.complianceGate = gate
+}
+
+// withApproval runs the action right away when compliance mode is off;
+// otherwise it waits for the approval in the background
+func (m *CLIModel) withApproval(a approvedAction) tea.Cmd {
+	if !m.complianceGate.Enabled() {
+		return a.done(a.run())
+	}
+
+	gate := m.complianceGate
+	req := gate.NewRequest(a.action, a.subject)
+	m.approvalNotice = fmt.Sprintf(localization.Labels["approval_waiting"], req.ID, gate.Describe(req))
+	return func() tea.Msg {
+		return approvalResultMsg{request: req, pending: a, err: gate.Authorize(context.Background(), req)}
+	}
+}
+
+// handleApprovalResult runs or abandons the pending action once a decision arrives
+func (m *CLIModel) handleApprovalResult(msg approvalResultMsg) tea.Cmd {
+	m.approvalNotice = ""
+	if msg.err != nil {
+		return msg.pending.done(msg.err)
+	}
+	err := msg.pending.run()
+	if auditErr := m.complianceGate.RecordOutcome(msg.request, err); auditErr != nil && err == nil {
+		err = auditErr
+	}
+	return msg.pending.done(err)
+}
+
+// renderComplianceBanner reminds the operator on the main menu that compliance mode is on
+func (m *CLIModel) renderComplianceBanner() string {
+	if !m.complianceGate.Enabled() {
+		return ""
+	}
+	return "\n\n" + m.styles.MenuDesc.Render(localization.Labels["compliance_mode_label"])
+}
+
+// renderApprovalNotice shows which approval the application is waiting for
+func (m *CLIModel) renderApprovalNotice() string {
+	if m.approvalNotice == "" {
+		return ""
+	}
+	return "\n" + m.styles.MenuDesc.Render(glyphs.Warning+" "+m.approvalNotice)
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"blocowallet/internal/audit"
+	"blocowallet/internal/compliance"
+	"blocowallet/pkg/localization"
+
+	"filippo.io/age"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedApprover struct {
+	decision compliance.Decision
+}
+
+func (a fixedApprover) Approve(context.Context, compliance.Request) (compliance.Decision, error) {
+	return a.decision, nil
+}
+
+func (a fixedApprover) Describe(compliance.Request) string { return "test approver" }
+
+func withTestGate(t *testing.T, m *CLIModel, decision compliance.Decision) string {
+	t.Helper()
+	localization.AddComplianceMessages()
+	path := filepath.Join(t.TempDir(), audit.FileName)
+	log, err := audit.Open(path)
+	require.NoError(t, err)
+	m.SetComplianceGate(compliance.NewGateWithApprover(fixedApprover{decision: decision}, log, "alice", 0))
+	return path
+}
+
+// submitExport fills the export form and presses enter, returning the pending approval command
+func submitExport(t *testing.T, m *CLIModel) (string, tea.Cmd) {
+	t.Helper()
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	out := filepath.Join(t.TempDir(), "backup.age")
+	m.exportRecipientInput.SetValue(identity.Recipient().String())
+	m.exportPathInput.SetValue(out)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	return out, cmd
+}
+
+func TestComplianceGate_ApprovedExportRuns(t *testing.T) {
+	m := newSecureExportModel(t)
+	auditPath := withTestGate(t, m, compliance.Decision{Approved: true, Approver: "bob"})
+
+	out, cmd := submitExport(t, m)
+	assert.NoFileExists(t, out, "nothing is written before the approval arrives")
+	assert.Contains(t, m.viewSecureExport(), "test approver")
+
+	_, _ = m.Update(cmd())
+
+	assert.False(t, m.exportFailed, m.exportStatus)
+	assert.FileExists(t, out)
+	assert.Empty(t, m.approvalNotice)
+
+	require.NoError(t, audit.Verify(auditPath))
+	data, err := os.ReadFile(auditPath)
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(data), "\n"), "requested, approved and executed entries")
+	assert.Contains(t, string(data), `"approver":"bob"`)
+}
+
+func TestComplianceGate_DeniedExportIsBlocked(t *testing.T) {
+	m := newSecureExportModel(t)
+	withTestGate(t, m, compliance.Decision{Approved: false, Approver: "bob", Reason: "not today"})
+
+	out, cmd := submitExport(t, m)
+	_, _ = m.Update(cmd())
+
+	assert.True(t, m.exportFailed)
+	assert.Contains(t, m.exportStatus, "not today")
+	assert.NoFileExists(t, out)
+}
+
+func TestComplianceGate_DisabledRunsImmediately(t *testing.T) {
+	m := newSecureExportModel(t)
+
+	ran := false
+	cmd := m.withApproval(approvedAction{
+		action: compliance.ActionDeleteWallet,
+		run: func() error {
+			ran = true
+			return nil
+		},
+		done: func(error) tea.Cmd { return nil },
+	})
+
+	assert.Nil(t, cmd)
+	assert.True(t, ran)
+	assert.Empty(t, m.approvalNotice)
+}
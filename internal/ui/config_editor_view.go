@@ -0,0 +1,435 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+	"blocowallet/pkg/logger"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func init() {
+	registerKeyMap(constants.ConfigEditorView, configEditorKeys)
+}
+
+// configWatchInterval is how often config.toml is checked for edits made
+// outside the program
+const configWatchInterval = 2 * time.Second
+
+// configWatchMsg checks config.toml again
+type configWatchMsg struct{}
+
+func configWatchCmd() tea.Cmd {
+	return tea.Tick(configWatchInterval, func(time.Time) tea.Msg {
+		return configWatchMsg{}
+	})
+}
+
+// Kinds of settings in the configuration editor
+const (
+	configFieldText   = iota
+	configFieldNumber // Whole number between min and max
+	configFieldToggle // "on" or "off", switched with space or ←/→
+	configFieldChoice // One of choices, cycled with space or ←/→
+)
+
+// configField is one setting of the configuration editor. The value is kept
+// as text in input, also for toggles and choices, and written back to the
+// configuration by set once every field is valid.
+type configField struct {
+	section  string // Label of the section the field starts, if any
+	label    string
+	kind     int
+	choices  []string
+	min, max int
+	input    textinput.Model
+	get      func(cfg *config.Config) string
+	set      func(cfg *config.Config, value string)
+}
+
+func toggleValue(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+// numberField is a whole-number setting between min and max
+func numberField(label string, min, max int, get func(cfg *config.Config) int, set func(cfg *config.Config, n int)) configField {
+	return configField{
+		label: label, kind: configFieldNumber, min: min, max: max,
+		get: func(cfg *config.Config) string { return strconv.Itoa(get(cfg)) },
+		set: func(cfg *config.Config, value string) {
+			n, _ := strconv.Atoi(value)
+			set(cfg, n)
+		},
+	}
+}
+
+// toggleField is an on/off setting
+func toggleField(label string, get func(cfg *config.Config) bool, set func(cfg *config.Config, on bool)) configField {
+	return configField{
+		label: label, kind: configFieldToggle,
+		get: func(cfg *config.Config) string { return toggleValue(get(cfg)) },
+		set: func(cfg *config.Config, value string) { set(cfg, value == "on") },
+	}
+}
+
+// inSection starts section with f
+func inSection(section string, f configField) configField {
+	f.section = section
+	return f
+}
+
+// newConfigFields lists the settings the editor exposes, filled from cfg
+func newConfigFields(cfg *config.Config) []configField {
+	languages := localization.GetAvailableLanguages(cfg.LocaleDir)
+	slices.Sort(languages)
+
+	fields := []configField{
+		inSection("config_editor_section_general", configField{
+			label: "config_editor_language", kind: configFieldChoice, choices: languages,
+			get: func(cfg *config.Config) string { return cfg.Language },
+			set: func(cfg *config.Config, value string) { cfg.Language = value },
+		}),
+		{
+			label: "config_editor_fonts", kind: configFieldText,
+			get: func(cfg *config.Config) string { return strings.Join(cfg.Fonts, ", ") },
+			set: func(cfg *config.Config, value string) {
+				cfg.Fonts = nil
+				for _, font := range strings.Split(value, ",") {
+					if font = strings.TrimSpace(font); font != "" {
+						cfg.Fonts = append(cfg.Fonts, font)
+					}
+				}
+			},
+		},
+		inSection("config_editor_section_security", numberField("config_editor_argon2_time", 1, 100,
+			func(cfg *config.Config) int { return int(cfg.Security.Argon2Time) },
+			func(cfg *config.Config, n int) { cfg.Security.Argon2Time = uint32(n) })),
+		numberField("config_editor_argon2_memory", 8*1024, 4*1024*1024,
+			func(cfg *config.Config) int { return int(cfg.Security.Argon2Memory) },
+			func(cfg *config.Config, n int) { cfg.Security.Argon2Memory = uint32(n) }),
+		numberField("config_editor_argon2_threads", 1, 255,
+			func(cfg *config.Config) int { return int(cfg.Security.Argon2Threads) },
+			func(cfg *config.Config, n int) { cfg.Security.Argon2Threads = uint8(n) }),
+		{
+			label: "config_editor_keystore_kdf", kind: configFieldChoice, choices: []string{config.KeystoreKDFScrypt, config.KeystoreKDFArgon2id},
+			get: func(cfg *config.Config) string { return cfg.Security.KeystoreKDF },
+			set: func(cfg *config.Config, value string) { cfg.Security.KeystoreKDF = value },
+		},
+		numberField("config_editor_clipboard_clear", 0, 3600,
+			func(cfg *config.Config) int { return cfg.Security.ClipboardClearSeconds },
+			func(cfg *config.Config, n int) { cfg.Security.ClipboardClearSeconds = n }),
+		inSection("config_editor_section_import", numberField("config_editor_import_concurrency", 1, 256,
+			func(cfg *config.Config) int { return cfg.Import.Concurrency },
+			func(cfg *config.Config, n int) { cfg.Import.Concurrency = n })),
+		numberField("config_editor_import_memory", 0, 1<<20,
+			func(cfg *config.Config) int { return cfg.Import.MemoryLimitMB },
+			func(cfg *config.Config, n int) { cfg.Import.MemoryLimitMB = n }),
+		inSection("config_editor_section_networks", toggleField("config_editor_strict_rpc",
+			func(cfg *config.Config) bool { return cfg.Security.StrictRPC },
+			func(cfg *config.Config, on bool) { cfg.Security.StrictRPC = on })),
+		toggleField("config_editor_hide_testnets",
+			func(cfg *config.Config) bool { return cfg.Balances.HideTestnets },
+			func(cfg *config.Config, on bool) { cfg.Balances.HideTestnets = on }),
+	}
+
+	for i := range fields {
+		input := textinput.New()
+		input.CharLimit = 10
+		input.Width = 12
+		if fields[i].kind == configFieldText {
+			input.CharLimit = 200
+			input.Width = 50
+		}
+		input.SetValue(fields[i].get(cfg))
+		fields[i].input = input
+	}
+	return fields
+}
+
+// validate checks the value of a number or choice field
+func (f *configField) validate() error {
+	value := strings.TrimSpace(f.input.Value())
+	switch f.kind {
+	case configFieldNumber:
+		n, err := strconv.Atoi(value)
+		if err != nil || n < f.min || n > f.max {
+			return fmt.Errorf(localization.Labels["config_editor_out_of_range"], localization.Labels[f.label], f.min, f.max)
+		}
+	case configFieldChoice:
+		if !slices.Contains(f.choices, value) {
+			return fmt.Errorf(localization.Labels["config_editor_invalid_choice"], localization.Labels[f.label], strings.Join(f.choices, ", "))
+		}
+	}
+	return nil
+}
+
+// cycle moves a toggle or choice field to its next or previous value
+func (f *configField) cycle(step int) {
+	switch f.kind {
+	case configFieldToggle:
+		f.input.SetValue(toggleValue(f.input.Value() != "on"))
+	case configFieldChoice:
+		if len(f.choices) == 0 {
+			return
+		}
+		i := slices.Index(f.choices, f.input.Value())
+		f.input.SetValue(f.choices[(i+step+len(f.choices))%len(f.choices)])
+	}
+}
+
+// initConfigEditor opens the settings form from the configuration menu
+func (m *CLIModel) initConfigEditor() {
+	cfg, err := getConfigurationManager().LoadConfiguration()
+	if err != nil {
+		m.configFields = nil
+		m.setConfigEditorResult(fmt.Sprintf(localization.Labels["config_editor_load_failed"], err), true)
+		m.currentView = constants.ConfigEditorView
+		return
+	}
+	m.currentConfig = cfg
+	m.configFields = newConfigFields(cfg)
+	m.setConfigEditorResult("", false)
+	m.setConfigEditorFocus(0)
+	m.currentView = constants.ConfigEditorView
+}
+
+// closeConfigEditor discards the edits and goes back to the configuration menu
+func (m *CLIModel) closeConfigEditor() {
+	m.configFields = nil
+	m.initConfigMenu()
+}
+
+func (m *CLIModel) setConfigEditorResult(status string, failed bool) {
+	m.configEditorStatus = status
+	m.configEditorFailed = failed
+}
+
+func (m *CLIModel) setConfigEditorFocus(focus int) {
+	m.configEditorFocus = focus
+	for i := range m.configFields {
+		m.configFields[i].input.Blur()
+	}
+	if focus < len(m.configFields) && m.configFields[focus].kind <= configFieldNumber {
+		m.configFields[focus].input.Focus()
+	}
+}
+
+// configEditorDirty reports whether a field differs from the configuration loaded
+func (m *CLIModel) configEditorDirty() bool {
+	if m.currentConfig == nil {
+		return false
+	}
+	for _, f := range m.configFields {
+		if strings.TrimSpace(f.input.Value()) != f.get(m.currentConfig) {
+			return true
+		}
+	}
+	return false
+}
+
+// configEditorKeys are the keys of the settings editor; only toggles and
+// choices change with space and the arrows
+func configEditorKeys(m *CLIModel) []key.Binding {
+	choice := m.configEditorFocus < len(m.configFields) && m.configFields[m.configEditorFocus].kind > configFieldNumber
+	return []key.Binding{
+		newBinding("↑/↓/tab", "key_field", "up", "down", "tab", "shift+tab"),
+		bindingIf(choice, newBinding("space/←/→", "key_change", " ", "left", "right")),
+		newBinding("ctrl+s", "key_save_apply", "ctrl+s"),
+	}
+}
+
+// updateConfigEditor moves between the settings, edits them and saves on ctrl+s
+func (m *CLIModel) updateConfigEditor(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || len(m.configFields) == 0 {
+		return m, nil
+	}
+	count := len(m.configFields)
+	field := &m.configFields[m.configEditorFocus]
+	switch keyMsg.String() {
+	case "tab", "down":
+		m.setConfigEditorFocus((m.configEditorFocus + 1) % count)
+		return m, nil
+	case "shift+tab", "up":
+		m.setConfigEditorFocus((m.configEditorFocus + count - 1) % count)
+		return m, nil
+	case "ctrl+s":
+		return m, m.saveConfigEditor()
+	case " ", "right", "left":
+		if field.kind == configFieldToggle || field.kind == configFieldChoice {
+			step := 1
+			if keyMsg.String() == "left" {
+				step = -1
+			}
+			field.cycle(step)
+			return m, nil
+		}
+	}
+	if field.kind > configFieldNumber {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	field.input, cmd = updateTextInput(field.input, msg)
+	return m, cmd
+}
+
+// saveConfigEditor writes the settings to config.toml and applies them
+// without restarting. The file is read again first, so settings the form does
+// not show keep any edit made to them by hand.
+func (m *CLIModel) saveConfigEditor() tea.Cmd {
+	for i := range m.configFields {
+		if err := m.configFields[i].validate(); err != nil {
+			m.setConfigEditorFocus(i)
+			m.setConfigEditorResult(err.Error(), true)
+			return nil
+		}
+	}
+
+	cm := getConfigurationManager()
+	cfg, err := cm.ReloadConfiguration()
+	if err != nil {
+		m.setConfigEditorResult(fmt.Sprintf(localization.Labels["config_editor_save_failed"], err), true)
+		return nil
+	}
+	for _, f := range m.configFields {
+		f.set(cfg, strings.TrimSpace(f.input.Value()))
+	}
+	if err := cm.SaveConfiguration(cfg); err != nil {
+		m.setConfigEditorResult(fmt.Sprintf(localization.Labels["config_editor_save_failed"], err), true)
+		return nil
+	}
+	// Read back what was written, with the defaults filled in as on startup
+	if cfg, err = cm.ReloadConfiguration(); err != nil {
+		m.setConfigEditorResult(fmt.Sprintf(localization.Labels["config_editor_save_failed"], err), true)
+		return nil
+	}
+	if err := m.applyConfig(cfg); err != nil {
+		m.setConfigEditorResult(fmt.Sprintf(localization.Labels["config_editor_apply_failed"], err), true)
+		return nil
+	}
+	m.configFields = newConfigFields(cfg)
+	m.setConfigEditorFocus(m.configEditorFocus)
+	m.setConfigEditorResult(localization.Labels["config_editor_saved"], false)
+	return nil
+}
+
+// applyConfig makes cfg the running configuration: the language, the wallet
+// service settings and the banner fonts change at once, and the views read
+// the rest from currentConfig when they are opened.
+func (m *CLIModel) applyConfig(cfg *config.Config) error {
+	previous := m.currentConfig
+	m.currentConfig = cfg
+	if previous == nil || previous.Language != cfg.Language {
+		if err := localization.InitLocalization(cfg); err != nil {
+			return err
+		}
+	}
+	if m.Service != nil {
+		m.Service.ApplyConfig(cfg)
+	}
+	if previous == nil || previous.Theme != cfg.Theme {
+		if err := m.applyTheme(cfg.AppDir, cfg.Theme); err != nil && uiLogger != nil {
+			uiLogger.Warn("Failed to load the configured theme", logger.Error(err), logger.String("component", "themes"))
+		}
+	}
+	if previous == nil || !slices.Equal(previous.Fonts, cfg.Fonts) {
+		if err := initializeFont(m); err != nil && uiLogger != nil {
+			uiLogger.Warn("Failed to load the configured fonts", logger.Error(err), logger.String("component", "fonts"))
+		}
+	}
+	return nil
+}
+
+// handleConfigWatch applies config.toml when it was edited outside the
+// program and schedules the next check
+func (m *CLIModel) handleConfigWatch() tea.Cmd {
+	cm := getConfigurationManager()
+	if !cm.Changed() {
+		return configWatchCmd()
+	}
+	cfg, err := cm.ReloadConfiguration()
+	if err != nil {
+		// Probably saved half-way by the editor; the next check reads it again
+		if uiLogger != nil {
+			uiLogger.Warn("Failed to reload the edited configuration", logger.Error(err))
+		}
+		return configWatchCmd()
+	}
+	dirty := m.currentView == constants.ConfigEditorView && m.configEditorDirty()
+	if err := m.applyConfig(cfg); err != nil && uiLogger != nil {
+		uiLogger.Warn("Failed to apply the edited configuration", logger.Error(err))
+	}
+	if uiLogger != nil {
+		uiLogger.Info("Configuration reloaded", logger.String("path", cm.GetConfigPath()))
+	}
+	if m.currentView == constants.ConfigEditorView {
+		if dirty {
+			// Keep the edits in progress; saving them overwrites the file
+			m.setConfigEditorResult(localization.Labels["config_editor_changed_on_disk"], true)
+		} else {
+			m.configFields = newConfigFields(cfg)
+			m.setConfigEditorFocus(m.configEditorFocus)
+			m.setConfigEditorResult(localization.Labels["config_editor_reloaded"], false)
+		}
+	}
+	return configWatchCmd()
+}
+
+// viewConfigEditor renders the settings form
+func (m *CLIModel) viewConfigEditor() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["config_editor_title"]))
+	b.WriteString("\n")
+	if path := getConfigurationManager().GetConfigPath(); path != "" {
+		b.WriteString(m.styles.MenuDesc.Render(path))
+		b.WriteString("\n")
+	}
+
+	labelWidth := 0
+	for _, f := range m.configFields {
+		labelWidth = max(labelWidth, lipgloss.Width(localization.Labels[f.label]))
+	}
+	for i, f := range m.configFields {
+		if f.section != "" {
+			b.WriteString("\n")
+			b.WriteString(m.styles.SelectedTitle.Render(localization.Labels[f.section]))
+			b.WriteString("\n")
+		}
+		cursor := "  "
+		if i == m.configEditorFocus {
+			cursor = "> "
+		}
+		value := f.input.View()
+		if f.kind == configFieldToggle || f.kind == configFieldChoice {
+			value = "‹ " + f.input.Value() + " ›"
+			if i == m.configEditorFocus {
+				value = m.styles.MenuSelected.Render(value)
+			}
+		}
+		label := localization.Labels[f.label]
+		b.WriteString(cursor + label + strings.Repeat(" ", labelWidth-lipgloss.Width(label)+1) + value + "\n")
+	}
+
+	if m.configEditorStatus != "" {
+		b.WriteString("\n")
+		if m.configEditorFailed {
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.configEditorStatus))
+		} else {
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.configEditorStatus))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// configFieldIndex returns the position of the field with label
+func configFieldIndex(t *testing.T, m *CLIModel, label string) int {
+	for i, f := range m.configFields {
+		if f.label == label {
+			return i
+		}
+	}
+	t.Fatalf("no field %s", label)
+	return -1
+}
+
+func TestConfigEditor_SavesAppliesAndReloads(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddConfigEditorMessages()
+	t.Setenv("BLOCO_WALLET_APP_APP_DIR", t.TempDir())
+	previousManager := globalConfigManager
+	globalConfigManager = nil
+	defer func() { globalConfigManager = previousManager }()
+
+	m := &CLIModel{
+		Service:     &wallet.WalletService{},
+		styles:      createStyles(),
+		currentView: constants.ConfigurationView,
+	}
+	m.initConfigMenu()
+	m.selectedMenu = 7
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, constants.ConfigEditorView, m.currentView)
+	require.NotEmpty(t, m.configFields)
+	assert.Contains(t, m.viewConfigEditor(), "Import concurrency")
+
+	// Out of range values are refused with the field focused
+	threads := configFieldIndex(t, m, "config_editor_argon2_threads")
+	m.configFields[threads].input.SetValue("0")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	assert.True(t, m.configEditorFailed)
+	assert.Contains(t, m.configEditorStatus, "Argon2 threads")
+	assert.Equal(t, threads, m.configEditorFocus)
+	m.configFields[threads].input.SetValue("2")
+
+	concurrency := configFieldIndex(t, m, "config_editor_import_concurrency")
+	m.configFields[concurrency].input.SetValue("3")
+	hide := configFieldIndex(t, m, "config_editor_hide_testnets")
+	m.setConfigEditorFocus(hide)
+	assert.False(t, m.capturesTextInput(), "toggles do not take text")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	assert.Equal(t, "on", m.configFields[hide].input.Value())
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.False(t, m.configEditorFailed, m.configEditorStatus)
+	assert.Equal(t, 3, m.currentConfig.Import.Concurrency)
+	assert.Equal(t, uint8(2), m.currentConfig.Security.Argon2Threads)
+	assert.True(t, m.currentConfig.Balances.HideTestnets)
+	require.NotNil(t, m.Service.MemoryGuard, "applied to the wallet service")
+
+	path := getConfigurationManager().GetConfigPath()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "concurrency = 3")
+
+	// An edit by hand is picked up while the program runs
+	edited := strings.Replace(string(data), "concurrency = 3", "concurrency = 6", 1)
+	require.NoError(t, os.WriteFile(path, []byte(edited), 0644))
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, future, future))
+	assert.NotNil(t, m.handleConfigWatch())
+	assert.Equal(t, 6, m.currentConfig.Import.Concurrency)
+	assert.Equal(t, "6", m.configFields[concurrency].input.Value())
+	assert.False(t, m.configEditorFailed)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.ConfigurationView, m.currentView)
+}
@@ -107,3 +107,18 @@ func loadNetworksWithManager() (map[string]config.Network, error) {
 	nm := getNetworkManager()
 	return nm.LoadNetworks()
 }
+
+// updateThemeInConfig stores the theme chosen in the picker
+func updateThemeInConfig(theme string) error {
+	cm := getConfigurationManager()
+
+	cfg, err := cm.LoadConfiguration()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.Theme = theme
+	if err := cm.SaveConfiguration(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"blocowallet/internal/compliance"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-errors/errors"
+)
+
+// openDeleteWallet opens the deletion dialog. A wallet whose key exists only
+// in its keystore asks for its name or the end of its address first, and
+// offers to copy the keystore before it is removed.
+func (m *CLIModel) openDeleteWallet(w *wallet.Wallet) tea.Cmd {
+	m.deletingWallet = w
+	m.dialogButtonIndex = 0
+	m.deleteStatus = ""
+	m.deletePhraseRequired = !w.WatchOnly() && !w.HasMnemonic()
+	if !m.deletePhraseRequired {
+		return nil
+	}
+	m.deleteArchive = m.deleteArchiveDir() != "" && m.currentConfig.Backup.ArchiveBeforeDelete
+	m.deleteConfirmInput = textinput.New()
+	m.deleteConfirmInput.Placeholder = localization.Labels["delete_phrase_placeholder"]
+	m.deleteConfirmInput.CharLimit = 128
+	m.deleteConfirmInput.Width = 30
+	return m.deleteConfirmInput.Focus()
+}
+
+// closeDeleteWallet closes the deletion dialog without deleting
+func (m *CLIModel) closeDeleteWallet() {
+	m.deletingWallet = nil
+	m.dialogButtonIndex = 0
+	m.deletePhraseRequired = false
+	m.deleteConfirmInput.Blur()
+	m.deleteConfirmInput.SetValue("")
+}
+
+// deleteConfirmed reports whether the Delete button is enabled
+func (m *CLIModel) deleteConfirmed() bool {
+	return !m.deletePhraseRequired || m.deletingWallet.ConfirmsDeletion(m.deleteConfirmInput.Value())
+}
+
+// deleteArchiveDir returns where keystores are copied before deletion, empty
+// without a configuration
+func (m *CLIModel) deleteArchiveDir() string {
+	if m.currentConfig == nil {
+		return ""
+	}
+	if m.currentConfig.Backup.ArchiveDir != "" {
+		return m.currentConfig.Backup.ArchiveDir
+	}
+	return filepath.Join(m.currentConfig.AppDir, "deleted")
+}
+
+// deleteWallet deletes the wallet, after copying its keystore to archiveDir
+// when it is not empty; the wallet is kept if the copy fails
+func (m *CLIModel) deleteWallet(w *wallet.Wallet, archiveDir string) tea.Cmd {
+	archived := ""
+	return m.withApproval(approvedAction{
+		action:  compliance.ActionDeleteWallet,
+		subject: w.Address,
+		run: func() error {
+			if archiveDir != "" {
+				path, err := m.Service.ArchiveKeystore(w, archiveDir, time.Now())
+				if err != nil {
+					return fmt.Errorf(localization.Labels["delete_archive_failed"], err)
+				}
+				archived = path
+			}
+			return m.Service.DeleteWallet(w)
+		},
+		done: func(err error) tea.Cmd {
+			if errors.Is(err, wallet.ErrStaleWallet) {
+				// Changed in another session: the list is reloaded for review
+				m.err = errors.Wrap(errors.New(localization.Labels["wallet_changed_elsewhere"]), 0)
+			} else if err != nil {
+				m.err = errors.Wrap(err, 0)
+			} else if archived != "" {
+				m.deleteStatus = fmt.Sprintf(localization.Labels["delete_archived"], w.Name, archived)
+			}
+			return m.refreshWalletsTable()
+		},
+	})
+}
+
+// renderDeletePhrase renders the confirmation phrase and the keystore copy
+// option of the deletion dialog
+func (m *CLIModel) renderDeletePhrase() string {
+	if !m.deletePhraseRequired {
+		return ""
+	}
+	w := m.deletingWallet
+	suffix := w.Address
+	if len(suffix) > 4 {
+		suffix = suffix[len(suffix)-4:]
+	}
+	lines := []string{
+		m.styles.ErrorStyle.Render(glyphs.Warning + " " + localization.Labels["delete_no_mnemonic"]),
+		fmt.Sprintf(localization.Labels["delete_phrase_prompt"], w.Name, suffix),
+		m.deleteConfirmInput.View(),
+	}
+	if dir := m.deleteArchiveDir(); dir != "" {
+		box := glyphs.Unchecked
+		if m.deleteArchive {
+			box = glyphs.Checked
+		}
+		lines = append(lines, "", box+" "+fmt.Sprintf(localization.Labels["delete_archive_option"], dir))
+	}
+	lines = append(lines, m.styles.MenuDesc.Render(localization.Labels["delete_phrase_help"]))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// renderDeleteStatus shows where the keystore of the last deleted wallet was copied
+func (m *CLIModel) renderDeleteStatus() string {
+	if m.deleteStatus == "" {
+		return ""
+	}
+	return "\n" + m.styles.SuccessStyle.Render(glyphs.Check+" "+m.deleteStatus)
+}
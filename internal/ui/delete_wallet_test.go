@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDeleteWalletModel(t *testing.T, wallets ...*wallet.Wallet) *CLIModel {
+	t.Helper()
+	localization.SetCurrentLanguage("en")
+	localization.AddNotesMessages()
+
+	dir := t.TempDir()
+	cfg := &config.Config{AppDir: dir, DatabasePath: filepath.Join(dir, "wallets.db"), Backup: config.BackupConfig{ArchiveBeforeDelete: true}}
+	repo, err := storage.NewWalletRepository(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = repo.Close() })
+	for _, w := range wallets {
+		require.NoError(t, repo.AddWallet(w))
+	}
+
+	m := &CLIModel{Service: &wallet.WalletService{Repo: repo}, styles: createStyles(), width: 160, currentConfig: cfg}
+	m.initListWallets()
+	require.Equal(t, constants.ListWalletsView, m.currentView)
+	return m
+}
+
+func TestDeleteWallet_KeystoreOnlyNeedsThePhrase(t *testing.T) {
+	keystorePath := filepath.Join(t.TempDir(), "hot.json")
+	require.NoError(t, os.WriteFile(keystorePath, []byte(`{"version":3}`), 0600))
+	m := newDeleteWalletModel(t, &wallet.Wallet{Name: "Hot", Address: "0x00000000000000000000000000000000000000aB", KeyStorePath: keystorePath,
+		ImportMethod: string(wallet.ImportMethodKeystore), SourceHash: "hot"})
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	require.NotNil(t, m.deletingWallet)
+	assert.True(t, m.deletePhraseRequired)
+	assert.True(t, m.deleteArchive, "preselected by the configuration")
+	assert.True(t, m.capturesTextInput(), "q is typed into the phrase")
+	assert.Contains(t, m.viewListWallets(), "Copy the keystore to "+filepath.Join(m.currentConfig.AppDir, "deleted"))
+
+	// Delete stays disabled until the phrase matches
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, m.deletingWallet)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("00AB")})
+	assert.True(t, m.deleteConfirmed())
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, m.deletingWallet)
+	require.NoError(t, m.err)
+
+	wallets, err := m.Service.GetAllWallets()
+	require.NoError(t, err)
+	assert.Empty(t, wallets)
+	assert.NoFileExists(t, keystorePath)
+	copies, err := filepath.Glob(filepath.Join(m.currentConfig.AppDir, "deleted", "UTC--*--"+"00000000000000000000000000000000000000ab.json"))
+	require.NoError(t, err)
+	require.Len(t, copies, 1)
+	data, err := os.ReadFile(copies[0])
+	require.NoError(t, err)
+	assert.Equal(t, `{"version":3}`, string(data))
+	assert.Contains(t, m.deleteStatus, copies[0])
+}
+
+func TestDeleteWallet_CopyCanBeTurnedOff(t *testing.T) {
+	keystorePath := filepath.Join(t.TempDir(), "hot.json")
+	require.NoError(t, os.WriteFile(keystorePath, []byte(`{"version":3}`), 0600))
+	m := newDeleteWalletModel(t, &wallet.Wallet{Name: "Hot", Address: "0x01", KeyStorePath: keystorePath,
+		ImportMethod: string(wallet.ImportMethodPrivateKey), SourceHash: "hot"})
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	assert.False(t, m.deleteArchive)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Hot")})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NoError(t, m.err)
+	assert.NoDirExists(t, filepath.Join(m.currentConfig.AppDir, "deleted"))
+	assert.Empty(t, m.deleteStatus)
+}
+
+func TestDeleteWallet_MnemonicWalletsKeepTheSimpleDialog(t *testing.T) {
+	mnemonic := "encrypted"
+	m := newDeleteWalletModel(t, &wallet.Wallet{Name: "Seed", Address: "0x02", KeyStorePath: filepath.Join(t.TempDir(), "seed.json"),
+		ImportMethod: string(wallet.ImportMethodMnemonic), Mnemonic: &mnemonic, SourceHash: "seed"})
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	require.NotNil(t, m.deletingWallet)
+	assert.False(t, m.deletePhraseRequired)
+	assert.NotContains(t, m.viewListWallets(), localization.Labels["delete_no_mnemonic"])
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NoError(t, m.err)
+	wallets, err := m.Service.GetAllWallets()
+	require.NoError(t, err)
+	assert.Empty(t, wallets)
+}
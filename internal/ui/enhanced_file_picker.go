@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"blocowallet/internal/platform"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -129,21 +130,21 @@ func DefaultEnhancedFilePickerStyles() EnhancedFilePickerStyles {
 // DefaultEnhancedFilePickerStylesWithRenderer returns default styling with a specific renderer
 func DefaultEnhancedFilePickerStylesWithRenderer(r *lipgloss.Renderer) EnhancedFilePickerStyles {
 	return EnhancedFilePickerStyles{
-		Cursor:           r.NewStyle().Foreground(lipgloss.Color("212")),
-		Directory:        r.NewStyle().Foreground(lipgloss.Color("99")).Bold(true),
-		File:             r.NewStyle().Foreground(lipgloss.Color("252")),
-		DisabledFile:     r.NewStyle().Foreground(lipgloss.Color("243")),
-		Selected:         r.NewStyle().Background(lipgloss.Color("238")),
-		SelectedFile:     r.NewStyle().Foreground(lipgloss.Color("212")).Bold(true),
-		SelectedDir:      r.NewStyle().Foreground(lipgloss.Color("99")).Bold(true).Background(lipgloss.Color("238")),
-		Checkbox:         r.NewStyle().Foreground(lipgloss.Color("244")),
-		CheckboxSelected: r.NewStyle().Foreground(lipgloss.Color("70")).Bold(true),
-		FileSize:         r.NewStyle().Foreground(lipgloss.Color("240")).Width(8).Align(lipgloss.Right),
-		Permission:       r.NewStyle().Foreground(lipgloss.Color("244")),
-		EmptyDirectory:   r.NewStyle().Foreground(lipgloss.Color("240")).Italic(true),
-		Header:           r.NewStyle().Bold(true).Foreground(lipgloss.Color("99")),
-		Footer:           r.NewStyle().Foreground(lipgloss.Color("244")),
-		Instructions:     r.NewStyle().Foreground(lipgloss.Color("244")).Italic(true),
+		Cursor:           r.NewStyle().Foreground(palette.Accent),
+		Directory:        r.NewStyle().Foreground(palette.Accent).Bold(true),
+		File:             r.NewStyle().Foreground(palette.Text),
+		DisabledFile:     r.NewStyle().Foreground(palette.Muted),
+		Selected:         r.NewStyle().Background(palette.Surface),
+		SelectedFile:     r.NewStyle().Foreground(palette.Accent).Bold(true),
+		SelectedDir:      r.NewStyle().Foreground(palette.Accent).Bold(true).Background(palette.Surface),
+		Checkbox:         r.NewStyle().Foreground(palette.Muted),
+		CheckboxSelected: r.NewStyle().Foreground(palette.Success).Bold(true),
+		FileSize:         r.NewStyle().Foreground(palette.Subtle).Width(8).Align(lipgloss.Right),
+		Permission:       r.NewStyle().Foreground(palette.Muted),
+		EmptyDirectory:   r.NewStyle().Foreground(palette.Subtle).Italic(true),
+		Header:           r.NewStyle().Bold(true).Foreground(palette.Accent),
+		Footer:           r.NewStyle().Foreground(palette.Muted),
+		Instructions:     r.NewStyle().Foreground(palette.Muted).Italic(true),
 	}
 }
 
@@ -453,7 +454,7 @@ func (m EnhancedFilePickerModel) Update(msg tea.Msg) (EnhancedFilePickerModel, t
 
 		case key.Matches(msg, m.KeyMap.Back), key.Matches(msg, m.KeyMap.Left):
 			// Navigate back to parent directory
-			if m.CurrentDirectory != "." && m.CurrentDirectory != "/" {
+			if m.CurrentDirectory != "." && !platform.IsRootDir(m.CurrentDirectory) {
 				m.CurrentDirectory = filepath.Dir(m.CurrentDirectory)
 
 				// Restore previous cursor position if available
@@ -493,7 +494,7 @@ func (m EnhancedFilePickerModel) View() string {
 	var content strings.Builder
 
 	// Header
-	header := fmt.Sprintf("📁 %s", m.CurrentDirectory)
+	header := fmt.Sprintf("%s %s", glyphs.Folder, m.CurrentDirectory)
 	if m.MultiSelect && len(m.SelectedFiles) > 0 {
 		header += fmt.Sprintf(" (%d selected)", len(m.SelectedFiles))
 	}
@@ -522,9 +523,9 @@ func (m EnhancedFilePickerModel) View() string {
 		// Checkbox for multi-select
 		if m.MultiSelect {
 			if isSelected {
-				line.WriteString(m.Styles.CheckboxSelected.Render("☑ "))
+				line.WriteString(m.Styles.CheckboxSelected.Render(glyphs.Checked + " "))
 			} else {
-				line.WriteString(m.Styles.Checkbox.Render("☐ "))
+				line.WriteString(m.Styles.Checkbox.Render(glyphs.Unchecked + " "))
 			}
 		}
 
@@ -533,25 +534,25 @@ func (m EnhancedFilePickerModel) View() string {
 		var icon string
 
 		if file.IsDir() {
-			icon = "📁 "
+			icon = glyphs.Folder + " "
 			if isCursor && isSelected {
 				nameStyle = m.Styles.SelectedDir
 			} else if isCursor {
-				nameStyle = m.Styles.Directory.Background(lipgloss.Color("238"))
+				nameStyle = m.Styles.Directory.Background(palette.Surface)
 			} else if isSelected {
 				nameStyle = m.Styles.SelectedDir
 			} else {
 				nameStyle = m.Styles.Directory
 			}
 		} else {
-			icon = "📄 "
+			icon = glyphs.File + " "
 			canSelect := m.canSelectFile(file.Name())
 			if !canSelect {
 				nameStyle = m.Styles.DisabledFile
 			} else if isCursor && isSelected {
 				nameStyle = m.Styles.SelectedFile
 			} else if isCursor {
-				nameStyle = m.Styles.File.Background(lipgloss.Color("238"))
+				nameStyle = m.Styles.File.Background(palette.Surface)
 			} else if isSelected {
 				nameStyle = m.Styles.SelectedFile
 			} else {
@@ -11,6 +11,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
 	"blocowallet/pkg/logger"
 )
 
@@ -57,6 +58,14 @@ type BatchImportServiceInterface interface {
 	GetImportSummary(results []wallet.ImportResult) wallet.ImportSummary
 }
 
+// ResumableImportService is implemented by batch services that keep the state
+// of the last batch, so an interrupted import can be resumed
+type ResumableImportService interface {
+	PendingImport() (*wallet.PendingImport, error)
+	ResumeImportJobs() ([]wallet.ImportJob, []string, error)
+	DiscardPendingImport() error
+}
+
 // EnhancedImportState manages the complete state of the enhanced import process
 type EnhancedImportState struct {
 	// Current phase of the import process
@@ -65,11 +74,15 @@ type EnhancedImportState struct {
 	// File selection state
 	SelectedFiles []string
 	SelectedDir   string
+	Paste         *keystorePaste // Paste mode replacing the file picker, nil when off
 
 	// Import job management
 	ImportJobs []wallet.ImportJob
 	Results    []wallet.ImportResult
 
+	// Jobs already imported, reviewed before the batch starts; nil when none
+	Duplicates []wallet.ImportDuplicate
+
 	// Progress tracking
 	CurrentProgress wallet.ImportProgress
 
@@ -84,6 +97,11 @@ type EnhancedImportState struct {
 	Completion   *ImportCompletionModel
 	BatchService BatchImportServiceInterface
 
+	// Where the report of the batch is offered to be written, and in which
+	// format; see config.ImportConfig
+	ReportDir    string
+	ReportFormat string
+
 	// Communication channels
 	progressChan         chan wallet.ImportProgress
 	passwordRequestChan  chan wallet.PasswordRequest
@@ -97,6 +115,10 @@ type EnhancedImportState struct {
 	errorMessage   string
 	pendingCommand tea.Cmd
 
+	// Interrupted batch offered for resuming, nil when there is none
+	PendingImport *wallet.PendingImport
+	resumeNotice  string
+
 	// Cleanup tracking
 	cleanupFuncs []func()
 }
@@ -117,6 +139,7 @@ func NewEnhancedImportState(batchService BatchImportServiceInterface, styles Sty
 
 	// Initialize file picker
 	filePicker := NewEnhancedFilePicker()
+	filePicker.SetAllowedTypes([]string{".json", ".zip", ".tar.gz", ".tgz"})
 	// Set the current directory to the keystores directory if it exists
 	if keystoreDir := "keystores"; fileExists(keystoreDir) {
 		filePicker.CurrentDirectory = keystoreDir
@@ -127,6 +150,7 @@ func NewEnhancedImportState(batchService BatchImportServiceInterface, styles Sty
 	progressBar := NewImportProgressModel(0, styles)
 	state.ProgressBar = &progressBar
 
+	state.loadPendingImport()
 	return state
 }
 
@@ -196,16 +220,22 @@ func (s *EnhancedImportState) setupFileSelectionPhase() {
 	s.SelectedDir = ""
 	s.ImportJobs = []wallet.ImportJob{}
 	s.Results = []wallet.ImportResult{}
+	s.Duplicates = nil
 	s.completed = false
 	s.cancelled = false
 	s.errorMessage = ""
 	s.ShowingPopup = false
 	s.PendingPassword = nil
+	s.Paste = nil
 
 	// Reset file picker
 	if s.FilePicker != nil {
 		s.FilePicker.ClearAll()
 	}
+
+	// The batch that just ended may have left files to resume
+	s.resumeNotice = ""
+	s.loadPendingImport()
 }
 
 // setupImportingPhase initializes the importing phase
@@ -239,6 +269,7 @@ func (s *EnhancedImportState) setupPasswordInputPhase() {
 	// Create password popup if we have a pending request
 	if s.PendingPassword != nil {
 		popup := NewPasswordPopupModel(s.PendingPassword.KeystoreFile, 3)
+		popup.OfferApplyToAll()
 		if s.PendingPassword.IsRetry && s.PendingPassword.ErrorMessage != "" {
 			popup.SetError(s.PendingPassword.ErrorMessage)
 		}
@@ -261,6 +292,7 @@ func (s *EnhancedImportState) setupCompletePhase() {
 	if s.BatchService != nil && len(s.Results) > 0 {
 		summary := s.BatchService.GetImportSummary(s.Results)
 		completion := NewImportCompletionModel(summary, s.Results, s.startTime, Styles{})
+		completion.SetReportDefaults(s.ReportDir, s.ReportFormat)
 		s.Completion = &completion
 	}
 }
@@ -311,11 +343,82 @@ func (s *EnhancedImportState) StartImport() error {
 	}
 
 	s.ImportJobs = jobs
+	s.resumeNotice = ""
+
+	// Files already imported are reviewed before the batch starts
+	if review, err := s.findDuplicates(); err != nil || review {
+		return err
+	}
 
 	// Transition to importing phase (call internal method to avoid double lock)
 	return s.transitionToPhaseInternal(PhaseImporting)
 }
 
+// loadPendingImport looks up an interrupted batch to offer for resuming
+func (s *EnhancedImportState) loadPendingImport() {
+	s.PendingImport = nil
+	service, ok := s.BatchService.(ResumableImportService)
+	if !ok {
+		return
+	}
+	pending, err := service.PendingImport()
+	if err != nil {
+		if uiLogger != nil {
+			uiLogger.Warn("Cannot read the state of the last batch import", logger.Error(err))
+		}
+		return
+	}
+	s.PendingImport = pending
+}
+
+// ResumeImport starts importing the files an interrupted batch did not import.
+// It reports false, staying in file selection, when none of them is left to
+// import because they were imported already or no longer exist.
+func (s *EnhancedImportState) ResumeImport() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Phase != PhaseFileSelection {
+		return false, fmt.Errorf("cannot resume import from phase %s", s.Phase)
+	}
+	service, ok := s.BatchService.(ResumableImportService)
+	if !ok || s.PendingImport == nil {
+		return false, fmt.Errorf("there is no interrupted import to resume")
+	}
+
+	jobs, skipped, err := service.ResumeImportJobs()
+	if err != nil {
+		return false, fmt.Errorf("failed to resume import: %w", err)
+	}
+	s.PendingImport = nil
+	if len(jobs) == 0 {
+		s.resumeNotice = fmt.Sprintf(localization.Labels["import_resume_nothing"], len(skipped))
+		return false, nil
+	}
+	if err := s.BatchService.ValidateImportJobs(jobs); err != nil {
+		return false, fmt.Errorf("import job validation failed: %w", err)
+	}
+
+	s.ImportJobs = jobs
+	return true, s.transitionToPhaseInternal(PhaseImporting)
+}
+
+// DiscardPendingImport forgets the interrupted batch instead of resuming it
+func (s *EnhancedImportState) DiscardPendingImport() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	service, ok := s.BatchService.(ResumableImportService)
+	if !ok || s.PendingImport == nil {
+		return nil
+	}
+	if err := service.DiscardPendingImport(); err != nil {
+		return fmt.Errorf("failed to discard the interrupted import: %w", err)
+	}
+	s.PendingImport = nil
+	return nil
+}
+
 // transitionToPhaseInternal transitions to a new phase without acquiring the lock
 func (s *EnhancedImportState) transitionToPhaseInternal(newPhase ImportPhase) error {
 	// Validate phase transition
@@ -370,6 +473,16 @@ func (s *EnhancedImportState) HandlePasswordRequest(request wallet.PasswordReque
 
 // SubmitPassword submits a password response and transitions back to importing
 func (s *EnhancedImportState) SubmitPassword(password string) error {
+	return s.submitPassword(wallet.PasswordResponse{Password: password})
+}
+
+// SubmitPasswordForAll submits a password the batch service also tries on the
+// remaining locked files before prompting again
+func (s *EnhancedImportState) SubmitPasswordForAll(password string) error {
+	return s.submitPassword(wallet.PasswordResponse{Password: password, ApplyToAll: true})
+}
+
+func (s *EnhancedImportState) submitPassword(response wallet.PasswordResponse) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -377,13 +490,6 @@ func (s *EnhancedImportState) SubmitPassword(password string) error {
 		return fmt.Errorf("cannot submit password from phase %s", s.Phase)
 	}
 
-	// Send password response
-	response := wallet.PasswordResponse{
-		Password:  password,
-		Cancelled: false,
-		Skip:      false,
-	}
-
 	select {
 	case s.passwordResponseChan <- response:
 		// Successfully sent response
@@ -492,7 +598,7 @@ func (s *EnhancedImportState) UpdateProgress(progress wallet.ImportProgress) {
 			TotalFiles:     progress.TotalFiles,
 			Completed:      progress.ProcessedFiles >= progress.TotalFiles,
 			Paused:         progress.PendingPassword,
-			PauseReason:    "Waiting for password input",
+			PauseReason:    localization.Labels["import_status_waiting_password"],
 		}
 
 		// Add the most recent error if any
@@ -685,6 +791,9 @@ func (s *EnhancedImportState) HandleCompletionUpdate(msg tea.Msg) tea.Cmd {
 	case RetryImportMsg:
 		return s.handleRetryRequest(msg.Strategy)
 	case RetrySpecificFileMsg:
+		if msg.Job != nil {
+			return s.retryFile(*msg.Job)
+		}
 		return s.handleRetrySpecificFile(msg.File)
 	case ReturnToMenuMsg:
 		return s.handleReturnToMenu()
@@ -738,14 +847,14 @@ func (s *EnhancedImportState) getRetryableFiles(strategy string) []string {
 		// Return all failed (non-skipped) files
 		for _, result := range s.Results {
 			if !result.Success && !result.Skipped {
-				files = append(files, result.Job.KeystorePath)
+				files = appendRetryable(files, result)
 			}
 		}
 	case "manual_passwords":
 		// Return all failed files that might need manual password input
 		for _, result := range s.Results {
 			if !result.Success && s.isPasswordRelatedError(result.Error) {
-				files = append(files, result.Job.KeystorePath)
+				files = appendRetryable(files, result)
 			}
 		}
 	case "retry_specific":
@@ -882,6 +991,9 @@ func (s *EnhancedImportState) Init() tea.Cmd {
 func (s *EnhancedImportState) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch s.Phase {
 	case PhaseFileSelection:
+		if s.Paste != nil {
+			return s, s.updatePaste(msg)
+		}
 		if s.FilePicker != nil {
 			var cmd tea.Cmd
 			*s.FilePicker, cmd = s.FilePicker.Update(msg)
@@ -901,9 +1013,27 @@ func (s *EnhancedImportState) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case PhasePasswordInput:
 		if s.ShowingPopup && s.PasswordPopup != nil {
-			var cmd tea.Cmd
-			*s.PasswordPopup, cmd = s.PasswordPopup.Update(msg)
-			return s, cmd
+			popup, cmd := s.PasswordPopup.Update(msg)
+			*s.PasswordPopup = popup
+			if !popup.IsCompleted() {
+				return s, cmd
+			}
+
+			// The popup quits once answered: only its result is used
+			result := popup.GetResult()
+			var err error
+			switch {
+			case result.Skip:
+				err = s.SkipPasswordInput()
+			case result.ApplyToAll:
+				err = s.SubmitPasswordForAll(result.Password)
+			default:
+				err = s.SubmitPassword(result.Password)
+			}
+			if err != nil && uiLogger != nil {
+				uiLogger.Warn("Failed to answer password request", logger.Error(err))
+			}
+			return s, nil
 		}
 
 	case PhaseComplete:
@@ -931,8 +1061,18 @@ func (s *EnhancedImportState) View() string {
 
 	switch s.Phase {
 	case PhaseFileSelection:
+		if s.Paste != nil {
+			return s.renderPaste()
+		}
+		if len(s.Duplicates) > 0 {
+			return s.renderDuplicateReview()
+		}
 		if s.FilePicker != nil {
-			return s.FilePicker.View()
+			view := s.renderResumeBanner() + s.FilePicker.View()
+			if s.CanPaste() {
+				view += "\n" + lipgloss.NewStyle().Foreground(palette.Muted).Render(localization.Labels["paste_hint"])
+			}
+			return view
 		}
 		return "File picker not initialized"
 
@@ -973,28 +1113,28 @@ func (s *EnhancedImportState) renderCompletionView() string {
 	var sections []string
 
 	// Title
-	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("70")).Render("✓ Import Complete")
+	title := lipgloss.NewStyle().Bold(true).Foreground(palette.Success).Render(glyphs.Check + " " + localization.Labels["completion_title_done"])
 	sections = append(sections, title)
 
 	// Summary statistics
-	stats := fmt.Sprintf("Total: %d | Success: %d | Failed: %d | Skipped: %d",
+	stats := fmt.Sprintf(localization.Labels["completion_stats"],
 		summary.TotalFiles, summary.SuccessfulImports, summary.FailedImports, summary.SkippedImports)
 	sections = append(sections, stats)
 
 	// Elapsed time
 	if !s.startTime.IsZero() {
 		elapsed := time.Since(s.startTime)
-		sections = append(sections, fmt.Sprintf("Completed in: %v", elapsed.Round(time.Second)))
+		sections = append(sections, fmt.Sprintf(localization.Labels["completion_elapsed"], elapsed.Round(time.Second)))
 	}
 
 	// Show errors if any
 	if len(summary.Errors) > 0 {
 		sections = append(sections, "")
-		sections = append(sections, "Errors:")
+		sections = append(sections, localization.Labels["completion_errors"])
 		for _, err := range summary.Errors {
-			errorType := "Failed"
+			errorType := localization.Labels["completion_failed"]
 			if err.Skipped {
-				errorType = "Skipped"
+				errorType = localization.Labels["completion_skipped"]
 			}
 			sections = append(sections, fmt.Sprintf("• %s: %s", errorType, err.File))
 		}
@@ -1002,30 +1142,43 @@ func (s *EnhancedImportState) renderCompletionView() string {
 
 	// Instructions
 	sections = append(sections, "")
-	sections = append(sections, "Press ENTER to return to menu or R to retry failed imports")
+	sections = append(sections, localization.Labels["completion_help_done"])
 
 	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
 
+// renderResumeBanner offers to resume an interrupted batch above the file picker
+func (s *EnhancedImportState) renderResumeBanner() string {
+	if s.PendingImport != nil {
+		p := s.PendingImport
+		banner := glyphs.Warning + " " + fmt.Sprintf(localization.Labels["import_resume_banner"], p.Imported, p.Total, len(p.Remaining))
+		return lipgloss.NewStyle().Foreground(palette.Warning).Render(banner) + "\n\n"
+	}
+	if s.resumeNotice != "" {
+		return s.resumeNotice + "\n\n"
+	}
+	return ""
+}
+
 // renderCancellationView renders the cancellation phase view
 func (s *EnhancedImportState) renderCancellationView() string {
 	var sections []string
 
-	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196")).Render("✗ Import Cancelled")
+	title := lipgloss.NewStyle().Bold(true).Foreground(palette.Error).Render(glyphs.Cross + " " + localization.Labels["completion_cancelled"])
 	sections = append(sections, title)
 
 	if len(s.Results) > 0 {
 		summary := s.GetSummary()
-		stats := fmt.Sprintf("Processed: %d/%d files before cancellation", len(s.Results), len(s.ImportJobs))
+		stats := fmt.Sprintf(localization.Labels["completion_processed"], len(s.Results), len(s.ImportJobs))
 		sections = append(sections, stats)
 
 		if summary.SuccessfulImports > 0 {
-			sections = append(sections, fmt.Sprintf("Successfully imported: %d wallets", summary.SuccessfulImports))
+			sections = append(sections, fmt.Sprintf(localization.Labels["completion_imported"], summary.SuccessfulImports))
 		}
 	}
 
 	sections = append(sections, "")
-	sections = append(sections, "Press ENTER to return to menu")
+	sections = append(sections, localization.Labels["completion_help_cancelled"])
 
 	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
@@ -4,10 +4,13 @@ import (
 	"testing"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
 )
 
 // MockBatchImportService provides a mock implementation for testing
@@ -247,6 +250,21 @@ func TestPasswordHandling(t *testing.T) {
 		assert.Nil(t, state.PendingPassword)
 	})
 
+	t.Run("Answer the popup with the password for all files", func(t *testing.T) {
+		testState := NewEnhancedImportState(mockService, styles)
+		testState.Phase = PhaseImporting
+		testState.passwordResponseChan = make(chan wallet.PasswordResponse, 1)
+		require.NoError(t, testState.HandlePasswordRequest(wallet.PasswordRequest{KeystoreFile: "test.json"}))
+
+		testState.PasswordPopup.SetValue("shared")
+		testState.Update(tea.KeyMsg{Type: tea.KeyTab})
+		_, cmd := testState.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+		assert.Nil(t, cmd, "the popup must not quit the program")
+		assert.Equal(t, PhaseImporting, testState.GetCurrentPhase())
+		assert.Equal(t, wallet.PasswordResponse{Password: "shared", ApplyToAll: true}, <-testState.passwordResponseChan)
+	})
+
 	t.Run("Cancel password input", func(t *testing.T) {
 		// Create a new state with buffered channels for testing
 		testState := NewEnhancedImportState(mockService, styles)
@@ -508,3 +526,65 @@ func TestPhaseSpecificSetup(t *testing.T) {
 		assert.True(t, cleanupCalled)
 	})
 }
+
+// resumableBatchService adds the state of an interrupted batch to the mock
+type resumableBatchService struct {
+	MockBatchImportService
+	pending   *wallet.PendingImport
+	skipped   []string
+	discarded bool
+}
+
+func (r *resumableBatchService) PendingImport() (*wallet.PendingImport, error) {
+	return r.pending, nil
+}
+
+func (r *resumableBatchService) ResumeImportJobs() ([]wallet.ImportJob, []string, error) {
+	r.pending = nil
+	return r.jobs, r.skipped, nil
+}
+
+func (r *resumableBatchService) DiscardPendingImport() error {
+	r.pending, r.discarded = nil, true
+	return nil
+}
+
+func TestEnhancedImportState_ResumeImport(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddImportCompletionMessages()
+
+	service := &resumableBatchService{
+		MockBatchImportService: MockBatchImportService{jobs: []wallet.ImportJob{{KeystorePath: "c.json"}}},
+		pending:                &wallet.PendingImport{Total: 3, Imported: 1, Remaining: []string{"b.json", "c.json"}},
+		skipped:                []string{"b.json"},
+	}
+	state := NewEnhancedImportState(service, Styles{})
+	require.NotNil(t, state.PendingImport)
+	assert.Contains(t, state.View(), "1 of 3 files imported, 2 left")
+
+	started, err := state.ResumeImport()
+	require.NoError(t, err)
+	assert.True(t, started)
+	assert.Equal(t, PhaseImporting, state.GetCurrentPhase())
+	assert.Equal(t, service.jobs, state.ImportJobs, "only the files left are imported")
+	assert.Nil(t, state.PendingImport)
+
+	// Nothing is started when every file left was imported already
+	service.pending = &wallet.PendingImport{Total: 1, Remaining: []string{"a.json"}}
+	service.jobs, service.skipped = nil, []string{"a.json"}
+	state = NewEnhancedImportState(service, Styles{})
+	started, err = state.ResumeImport()
+	require.NoError(t, err)
+	assert.False(t, started)
+	assert.Equal(t, PhaseFileSelection, state.GetCurrentPhase())
+	assert.Contains(t, state.View(), "Nothing left to resume")
+
+	service.pending = &wallet.PendingImport{Total: 2, Remaining: []string{"a.json"}}
+	state = NewEnhancedImportState(service, Styles{})
+	require.NoError(t, state.DiscardPendingImport())
+	assert.True(t, service.discarded)
+	assert.NotContains(t, state.View(), "interrupted")
+
+	// Services without import state offer nothing to resume
+	assert.Nil(t, NewEnhancedImportState(&MockBatchImportService{}, Styles{}).PendingImport)
+}
@@ -0,0 +1,68 @@
+package ui
+
+import "blocowallet/internal/platform"
+
+// glyphSet holds the symbols used across the views. The ASCII set is used on
+// terminals without the required fonts, such as the legacy Windows console.
+type glyphSet struct {
+	Check     string
+	Cross     string
+	Warning   string
+	Bullet    string
+	BarFull   string
+	BarEmpty  string
+	Folder    string
+	File      string
+	Standard  string
+	Custom    string
+	Checked   string
+	Unchecked string
+	Sorted    string
+}
+
+var unicodeGlyphs = glyphSet{
+	Check:     "✓",
+	Cross:     "✗",
+	Warning:   "⚠",
+	Bullet:    "•",
+	BarFull:   "█",
+	BarEmpty:  "░",
+	Folder:    "📁",
+	File:      "📄",
+	Standard:  "✅",
+	Custom:    "🔧",
+	Checked:   "☑",
+	Unchecked: "☐",
+	Sorted:    "▲",
+}
+
+var asciiGlyphs = glyphSet{
+	Check:     "[ok]",
+	Cross:     "[x]",
+	Warning:   "[!]",
+	Bullet:    "*",
+	BarFull:   "#",
+	BarEmpty:  "-",
+	Folder:    "[D]",
+	File:      "   ",
+	Standard:  "[S]",
+	Custom:    "[C]",
+	Checked:   "[*]",
+	Unchecked: "[ ]",
+	Sorted:    "^",
+}
+
+// glyphs is the active set, chosen once from the terminal capabilities
+var glyphs = selectGlyphs(platform.DetectCapabilities())
+
+func selectGlyphs(caps platform.Capabilities) glyphSet {
+	if !caps.UnicodeGlyphs {
+		return asciiGlyphs
+	}
+	set := unicodeGlyphs
+	if !caps.Emoji {
+		set.Folder, set.File = asciiGlyphs.Folder, asciiGlyphs.File
+		set.Standard, set.Custom = asciiGlyphs.Standard, asciiGlyphs.Custom
+	}
+	return set
+}
@@ -0,0 +1,21 @@
+package ui
+
+import (
+	"testing"
+
+	"blocowallet/internal/platform"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectGlyphs_ASCIIFallback(t *testing.T) {
+	assert.Equal(t, unicodeGlyphs, selectGlyphs(platform.Capabilities{UnicodeGlyphs: true, Emoji: true}))
+
+	ascii := selectGlyphs(platform.Capabilities{})
+	assert.Equal(t, asciiGlyphs, ascii)
+	for _, g := range []string{ascii.Check, ascii.Cross, ascii.BarFull, ascii.Folder, ascii.Checked} {
+		for _, r := range g {
+			assert.Less(t, r, rune(128), "glyph %q is not ASCII", g)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/pkg/localization"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// helpColumnSize is how many keys of a view go in each column of the overlay
+const helpColumnSize = 8
+
+// keyMaps holds the keys of each view. Views register theirs from init in
+// their own file; views without one only show the keys that work everywhere.
+var keyMaps = make(map[string]func(m *CLIModel) []key.Binding)
+
+// registerKeyMap sets the keys listed for view in the footer and in the help
+// overlay. keys is called on every render, so it can leave out the keys the
+// current state of the view does not accept.
+func registerKeyMap(view string, keys func(m *CLIModel) []key.Binding) {
+	keyMaps[view] = keys
+}
+
+// newBinding makes a binding described by a label of the current language;
+// help is how the keys are written, e.g. "↑/↓"
+func newBinding(help, label string, keys ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(help, localization.Labels[label]))
+}
+
+// bindingIf returns b disabled unless enabled, which hides it from the help
+func bindingIf(enabled bool, b key.Binding) key.Binding {
+	b.SetEnabled(enabled)
+	return b
+}
+
+// viewKeyMap is the help.KeyMap of the current view: its own keys followed
+// by those that work on every view
+type viewKeyMap struct {
+	view   []key.Binding
+	global []key.Binding
+}
+
+// ShortHelp lists the keys shown in the footer. The help key comes first so
+// narrow terminals do not cut it off.
+func (k viewKeyMap) ShortHelp() []key.Binding {
+	if len(k.global) == 0 || !slices.Contains(k.global[0].Keys(), "?") {
+		return append(slices.Clone(k.view), k.global...)
+	}
+	keys := append([]key.Binding{k.global[0]}, k.view...)
+	return append(keys, k.global[1:]...)
+}
+
+// FullHelp lists every key in columns, the global keys in the last one
+func (k viewKeyMap) FullHelp() [][]key.Binding {
+	var columns [][]key.Binding
+	for start := 0; start < len(k.view); start += helpColumnSize {
+		columns = append(columns, k.view[start:min(start+helpColumnSize, len(k.view))])
+	}
+	return append(columns, k.global)
+}
+
+// keyMap returns the keys the current view accepts; with the help overlay
+// open, only the keys that close it
+func (m *CLIModel) keyMap() viewKeyMap {
+	if m.helpOpen {
+		return viewKeyMap{global: []key.Binding{newBinding("?/esc", "key_close_help", "?", "esc")}}
+	}
+	return m.viewKeys()
+}
+
+// viewKeys returns the keys of the current view and the global ones it allows
+func (m *CLIModel) viewKeys() viewKeyMap {
+	var view []key.Binding
+	if m.paletteOpen {
+		view = m.paletteKeys()
+	} else if keys, ok := keyMaps[m.currentView]; ok {
+		view = keys(m)
+	}
+
+	var global []key.Binding
+	if m.helpAvailable() {
+		global = append(global, newBinding("?", "key_help", "?"))
+	}
+	if m.currentView != constants.DefaultView && !m.paletteOpen && !hasKey(view, "esc") {
+		global = append(global, newBinding("esc", "key_back", "esc"))
+	}
+	if m.paletteAvailable() && !m.paletteOpen {
+		global = append(global, newBinding("ctrl+p", "key_commands", "ctrl+p"))
+	}
+	if !m.capturesTextInput() && !m.paletteOpen {
+		global = append(global, newBinding("q", "key_quit", "q"))
+	}
+	return viewKeyMap{view: view, global: global}
+}
+
+// hasKey tells whether one of the enabled bindings takes k
+func hasKey(bindings []key.Binding, k string) bool {
+	for _, b := range bindings {
+		if b.Enabled() && slices.Contains(b.Keys(), k) {
+			return true
+		}
+	}
+	return false
+}
+
+// helpAvailable tells whether "?" opens the help overlay: not while typing,
+// where "?" is text, nor before the main menu
+func (m *CLIModel) helpAvailable() bool {
+	return m.currentView != constants.SplashView && !m.capturesTextInput() && !m.paletteOpen
+}
+
+// renderShortHelp shows the keys of the current view in one line of width
+// cells. It is unstyled, so the status bar colours it as a whole.
+func (m *CLIModel) renderShortHelp(width int) string {
+	h := help.New()
+	h.Width = width
+	h.ShortSeparator = " • "
+	h.Styles = help.Styles{}
+	return h.ShortHelpView(m.keyMap().ShortHelp())
+}
+
+// viewHelp shows every key of the current view, with its description, over
+// the content of the view; the footer tells how to close it
+func (m *CLIModel) viewHelp() string {
+	h := help.New()
+	h.FullSeparator = "    "
+	h.Styles.FullKey = lipgloss.NewStyle().Foreground(palette.Accent).Bold(true)
+	h.Styles.FullDesc = lipgloss.NewStyle().Foreground(palette.Text)
+	h.Styles.FullSeparator = lipgloss.NewStyle()
+
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["help_title"]))
+	b.WriteString("\n\n")
+	b.WriteString(h.FullHelpView(m.viewKeys().FullHelp()))
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(palette.Accent).
+		Padding(1, 2).
+		Render(b.String())
+}
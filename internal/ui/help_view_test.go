@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelpOverlay_OpensAndCloses(t *testing.T) {
+	require.NoError(t, localization.SetLanguage("en", t.TempDir()))
+	m := &CLIModel{styles: createStyles(), currentView: constants.DefaultView, width: 120}
+	m.initConfigMenu()
+
+	footer := m.renderShortHelp(0)
+	assert.Contains(t, footer, "? help")
+	assert.Contains(t, footer, "↑/↓ move")
+	assert.Contains(t, footer, "esc back")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	require.True(t, m.helpOpen)
+	view := m.View()
+	assert.Contains(t, view, "Keyboard shortcuts")
+	assert.Contains(t, view, "select")
+	assert.Contains(t, view, "ctrl+p")
+	assert.Contains(t, m.renderShortHelp(0), "?/esc close help")
+
+	// Keys of the view are ignored while the overlay is open
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 0, m.selectedMenu)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, m.helpOpen)
+	assert.Equal(t, constants.ConfigurationView, m.currentView, "esc closes the overlay, not the view")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	assert.False(t, m.helpOpen)
+}
+
+func TestHelpOverlay_TypedIntoTextInputs(t *testing.T) {
+	require.NoError(t, localization.SetLanguage("en", t.TempDir()))
+	m := &CLIModel{styles: createStyles(), currentView: constants.DefaultView}
+	m.initCreateWallet()
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("why?q")})
+	assert.False(t, m.helpOpen)
+	assert.Equal(t, constants.CreateWalletNameView, m.currentView)
+	assert.Equal(t, "why?q", m.nameInput.Value())
+	assert.NotContains(t, m.renderShortHelp(0), "? help")
+	assert.NotContains(t, m.renderShortHelp(0), "q quit")
+}
+
+func TestWalletDetailsKeys_FollowTheWallet(t *testing.T) {
+	require.NoError(t, localization.SetLanguage("en", t.TempDir()))
+	m := &CLIModel{styles: createStyles(), currentView: constants.WalletDetailsView}
+	m.walletDetails = &wallet.WalletDetails{
+		Wallet: &wallet.Wallet{Name: "Cold", Address: "0x01", ImportMethod: string(wallet.ImportMethodWatchOnly)},
+	}
+
+	keys := walletDetailsKeys(m)
+	assert.False(t, hasKey(keys, "s"), "watch-only wallets cannot send")
+	assert.False(t, hasKey(keys, "c"), "children need a mnemonic")
+	assert.True(t, hasKey(keys, "a"))
+	assert.Contains(t, m.viewHelp(), "refresh balances")
+	assert.NotContains(t, m.viewHelp(), "send funds")
+}
@@ -1,14 +1,19 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
 )
 
 // CompletionAction represents actions available in the completion phase
@@ -22,6 +27,7 @@ const (
 	CompletionActionRetryAll
 	CompletionActionViewErrors
 	CompletionActionSelectDifferentFiles
+	CompletionActionExportReport
 )
 
 // ImportCompletionModel represents the completion phase UI component
@@ -40,6 +46,20 @@ type ImportCompletionModel struct {
 
 	// Available actions based on results
 	availableActions []CompletionActionItem
+
+	// Inline retry of the file shown in the error details, and the outcome
+	// of the last one
+	retry       *fileRetry
+	retryNotice string
+
+	// Report of the batch: where it is offered to be written, the path
+	// field and the outcome of the last export
+	reportDir       string
+	reportFormat    string
+	exportingReport bool
+	reportInput     textinput.Model
+	reportStatus    string
+	reportFailed    bool
 }
 
 // CompletionActionItem represents an available action in the completion phase
@@ -79,8 +99,8 @@ func (m *ImportCompletionModel) initializeActions() {
 	// Always available: Return to menu
 	m.availableActions = append(m.availableActions, CompletionActionItem{
 		Action:      CompletionActionReturnToMenu,
-		Label:       "Return to Main Menu",
-		Description: "Go back to the main wallet management menu",
+		Label:       localization.Labels["completion_action_return"],
+		Description: localization.Labels["completion_action_return_desc"],
 		Key:         "ENTER",
 		Enabled:     true,
 	})
@@ -88,8 +108,8 @@ func (m *ImportCompletionModel) initializeActions() {
 	// Always available: Select different files
 	m.availableActions = append(m.availableActions, CompletionActionItem{
 		Action:      CompletionActionSelectDifferentFiles,
-		Label:       "Select Different Files",
-		Description: "Choose different keystore files to import",
+		Label:       localization.Labels["completion_action_select_files"],
+		Description: localization.Labels["completion_action_select_files_desc"],
 		Key:         "S",
 		Enabled:     true,
 	})
@@ -98,8 +118,8 @@ func (m *ImportCompletionModel) initializeActions() {
 	if m.summary.FailedImports > 0 {
 		m.availableActions = append(m.availableActions, CompletionActionItem{
 			Action:      CompletionActionRetryFailed,
-			Label:       fmt.Sprintf("Retry Failed Imports (%d)", m.summary.FailedImports),
-			Description: "Retry importing files that failed due to errors",
+			Label:       fmt.Sprintf(localization.Labels["completion_action_retry_failed"], m.summary.FailedImports),
+			Description: localization.Labels["completion_action_retry_failed_desc"],
 			Key:         "F",
 			Enabled:     true,
 		})
@@ -109,8 +129,8 @@ func (m *ImportCompletionModel) initializeActions() {
 	if m.summary.SkippedImports > 0 {
 		m.availableActions = append(m.availableActions, CompletionActionItem{
 			Action:      CompletionActionRetrySkipped,
-			Label:       fmt.Sprintf("Retry Skipped Imports (%d)", m.summary.SkippedImports),
-			Description: "Retry importing files that were skipped (password input cancelled)",
+			Label:       fmt.Sprintf(localization.Labels["completion_action_retry_skipped"], m.summary.SkippedImports),
+			Description: localization.Labels["completion_action_retry_skipped_desc"],
 			Key:         "K",
 			Enabled:     true,
 		})
@@ -121,8 +141,8 @@ func (m *ImportCompletionModel) initializeActions() {
 		totalRetryable := m.summary.FailedImports + m.summary.SkippedImports
 		m.availableActions = append(m.availableActions, CompletionActionItem{
 			Action:      CompletionActionRetryAll,
-			Label:       fmt.Sprintf("Retry All Failed/Skipped (%d)", totalRetryable),
-			Description: "Retry all files that failed or were skipped",
+			Label:       fmt.Sprintf(localization.Labels["completion_action_retry_all"], totalRetryable),
+			Description: localization.Labels["completion_action_retry_all_desc"],
 			Key:         "A",
 			Enabled:     true,
 		})
@@ -132,13 +152,22 @@ func (m *ImportCompletionModel) initializeActions() {
 	if len(m.summary.Errors) > 0 {
 		m.availableActions = append(m.availableActions, CompletionActionItem{
 			Action:      CompletionActionViewErrors,
-			Label:       fmt.Sprintf("View Error Details (%d)", len(m.summary.Errors)),
-			Description: "View detailed information about errors that occurred",
+			Label:       fmt.Sprintf(localization.Labels["completion_action_view_errors"], len(m.summary.Errors)),
+			Description: localization.Labels["completion_action_view_errors_desc"],
 			Key:         "E",
 			Enabled:     true,
 		})
 		m.maxErrorIndex = len(m.summary.Errors) - 1
 	}
+
+	// Always available: Export a report of the batch
+	m.availableActions = append(m.availableActions, CompletionActionItem{
+		Action:      CompletionActionExportReport,
+		Label:       localization.Labels["completion_action_export_report"],
+		Description: localization.Labels["completion_action_export_report_desc"],
+		Key:         "X",
+		Enabled:     true,
+	})
 }
 
 // Init initializes the completion model
@@ -161,6 +190,9 @@ func (m *ImportCompletionModel) handleKeyPress(msg tea.KeyMsg) (ImportCompletion
 	if m.showingErrors {
 		return m.handleErrorViewKeyPress(msg)
 	}
+	if m.exportingReport {
+		return *m, m.handleReportKeyPress(msg)
+	}
 
 	switch msg.String() {
 	case "up":
@@ -208,6 +240,9 @@ func (m *ImportCompletionModel) handleKeyPress(msg tea.KeyMsg) (ImportCompletion
 			return *m, m.executeActionByKey("E")
 		}
 
+	case "x", "X":
+		return *m, m.executeActionByKey("X")
+
 	case "esc", "q":
 		// ESC or Q returns to menu
 		return *m, m.executeAction(CompletionActionReturnToMenu)
@@ -218,6 +253,14 @@ func (m *ImportCompletionModel) handleKeyPress(msg tea.KeyMsg) (ImportCompletion
 
 // handleErrorViewKeyPress handles keyboard input when viewing error details
 func (m *ImportCompletionModel) handleErrorViewKeyPress(msg tea.KeyMsg) (ImportCompletionModel, tea.Cmd) {
+	if m.retry != nil && !m.retry.running {
+		return *m, m.handleRetryKeyPress(msg)
+	}
+	if m.retry != nil {
+		// The file is being imported again
+		return *m, nil
+	}
+
 	switch msg.String() {
 	case "up", "k":
 		if m.errorIndex > 0 {
@@ -234,13 +277,12 @@ func (m *ImportCompletionModel) handleErrorViewKeyPress(msg tea.KeyMsg) (ImportC
 		m.errorIndex = 0
 
 	case "r", "R":
-		// Retry this specific file
-		if m.errorIndex < len(m.summary.Errors) {
-			errorItem := m.summary.Errors[m.errorIndex]
-			return *m, func() tea.Msg {
-				return RetrySpecificFileMsg{File: errorItem.File}
-			}
-		}
+		// Retry this specific file, asking its password when needed
+		return *m, m.startRetry(false)
+
+	case "n", "N":
+		// Retry this specific file under another wallet name
+		return *m, m.startRetry(true)
 	}
 
 	return *m, nil
@@ -289,6 +331,9 @@ func (m *ImportCompletionModel) executeAction(action CompletionAction) tea.Cmd {
 			return SelectDifferentFilesMsg{}
 		}
 
+	case CompletionActionExportReport:
+		return m.startReportExport()
+
 	default:
 		return nil
 	}
@@ -318,6 +363,12 @@ func (m ImportCompletionModel) renderCompletionSummaryView() string {
 	// Elapsed time
 	timeInfo := m.renderTimeInfo()
 	sections = append(sections, timeInfo)
+	if m.retryNotice != "" {
+		sections = append(sections, m.retryNotice)
+	}
+	if report := m.renderReport(); report != "" {
+		sections = append(sections, report)
+	}
 
 	// Quick error summary if there are errors
 	if len(m.summary.Errors) > 0 {
@@ -325,6 +376,11 @@ func (m ImportCompletionModel) renderCompletionSummaryView() string {
 		sections = append(sections, errorSummary)
 	}
 
+	// Password manifest rows that were left out
+	if len(m.summary.ManifestProblems) > 0 {
+		sections = append(sections, m.renderManifestProblems())
+	}
+
 	// Available actions
 	actions := m.renderAvailableActions()
 	sections = append(sections, actions)
@@ -343,16 +399,16 @@ func (m ImportCompletionModel) renderCompletionTitle() string {
 
 	if m.summary.FailedImports == 0 && m.summary.SkippedImports == 0 {
 		// Complete success
-		title = "✓ Import Completed Successfully"
-		style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("70")) // Green
+		title = glyphs.Check + " " + localization.Labels["completion_title_success"]
+		style = lipgloss.NewStyle().Bold(true).Foreground(palette.Success) // Green
 	} else if m.summary.SuccessfulImports > 0 {
 		// Partial success
-		title = "⚠ Import Completed with Issues"
-		style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214")) // Orange
+		title = glyphs.Warning + " " + localization.Labels["completion_title_partial"]
+		style = lipgloss.NewStyle().Bold(true).Foreground(palette.Warning) // Orange
 	} else {
 		// Complete failure
-		title = "✗ Import Failed"
-		style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196")) // Red
+		title = glyphs.Cross + " " + localization.Labels["completion_title_failed"]
+		style = lipgloss.NewStyle().Bold(true).Foreground(palette.Error) // Red
 	}
 
 	return style.Render(title)
@@ -363,7 +419,7 @@ func (m ImportCompletionModel) renderSummaryStats() string {
 	var sections []string
 
 	// Main statistics line
-	stats := fmt.Sprintf("Total: %d | Success: %d | Failed: %d | Skipped: %d",
+	stats := fmt.Sprintf(localization.Labels["completion_stats"],
 		m.summary.TotalFiles,
 		m.summary.SuccessfulImports,
 		m.summary.FailedImports,
@@ -374,15 +430,15 @@ func (m ImportCompletionModel) renderSummaryStats() string {
 	// Success rate if there were any files processed
 	if m.summary.TotalFiles > 0 {
 		successRate := float64(m.summary.SuccessfulImports) / float64(m.summary.TotalFiles) * 100
-		rateText := fmt.Sprintf("Success Rate: %.1f%%", successRate)
+		rateText := fmt.Sprintf(localization.Labels["completion_success_rate"], successRate)
 
 		var rateStyle lipgloss.Style
 		if successRate >= 90 {
-			rateStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("70")) // Green
+			rateStyle = lipgloss.NewStyle().Foreground(palette.Success) // Green
 		} else if successRate >= 70 {
-			rateStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214")) // Orange
+			rateStyle = lipgloss.NewStyle().Foreground(palette.Warning) // Orange
 		} else {
-			rateStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196")) // Red
+			rateStyle = lipgloss.NewStyle().Foreground(palette.Error) // Red
 		}
 
 		sections = append(sections, rateStyle.Render(rateText))
@@ -393,12 +449,12 @@ func (m ImportCompletionModel) renderSummaryStats() string {
 
 // renderTimeInfo renders timing information
 func (m ImportCompletionModel) renderTimeInfo() string {
-	timeText := fmt.Sprintf("Completed in: %v", m.elapsedTime.Round(time.Second))
+	timeText := fmt.Sprintf(localization.Labels["completion_elapsed"], m.elapsedTime.Round(time.Second))
 
 	// Add performance info if we have multiple files
 	if m.summary.TotalFiles > 1 {
 		avgTime := m.elapsedTime / time.Duration(m.summary.TotalFiles)
-		timeText += fmt.Sprintf(" (avg: %v per file)", avgTime.Round(time.Millisecond))
+		timeText += fmt.Sprintf(localization.Labels["completion_elapsed_avg"], avgTime.Round(time.Millisecond))
 	}
 
 	return timeText
@@ -410,7 +466,7 @@ func (m ImportCompletionModel) renderQuickErrorSummary() string {
 
 	sections = append(sections, "")
 
-	errorTitle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196")).Render("Issues Encountered:")
+	errorTitle := lipgloss.NewStyle().Bold(true).Foreground(palette.Error).Render(localization.Labels["completion_issues"])
 	sections = append(sections, errorTitle)
 
 	// Group errors by type
@@ -427,10 +483,10 @@ func (m ImportCompletionModel) renderQuickErrorSummary() string {
 
 	// Show failed files (up to 3)
 	if len(failedFiles) > 0 {
-		sections = append(sections, lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("Failed:"))
+		sections = append(sections, lipgloss.NewStyle().Foreground(palette.Error).Render(localization.Labels["completion_failed"]+":"))
 		for i, file := range failedFiles {
 			if i >= 3 {
-				sections = append(sections, fmt.Sprintf("  ... and %d more", len(failedFiles)-3))
+				sections = append(sections, "  "+fmt.Sprintf(localization.Labels["completion_more"], len(failedFiles)-3))
 				break
 			}
 			sections = append(sections, fmt.Sprintf("  • %s", file))
@@ -439,10 +495,10 @@ func (m ImportCompletionModel) renderQuickErrorSummary() string {
 
 	// Show skipped files (up to 3)
 	if len(skippedFiles) > 0 {
-		sections = append(sections, lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("Skipped:"))
+		sections = append(sections, lipgloss.NewStyle().Foreground(palette.Warning).Render(localization.Labels["completion_skipped"]+":"))
 		for i, file := range skippedFiles {
 			if i >= 3 {
-				sections = append(sections, fmt.Sprintf("  ... and %d more", len(skippedFiles)-3))
+				sections = append(sections, "  "+fmt.Sprintf(localization.Labels["completion_more"], len(skippedFiles)-3))
 				break
 			}
 			sections = append(sections, fmt.Sprintf("  • %s", file))
@@ -452,6 +508,35 @@ func (m ImportCompletionModel) renderQuickErrorSummary() string {
 	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
 
+// manifestProblemLabels maps each manifest problem to its localization key
+var manifestProblemLabels = map[wallet.ManifestProblemType]string{
+	wallet.ManifestMissingFile:    "manifest_problem_missing_file",
+	wallet.ManifestEmptyPassword:  "manifest_problem_empty_password",
+	wallet.ManifestDuplicateEntry: "manifest_problem_duplicate",
+	wallet.ManifestInvalidPath:    "manifest_problem_invalid_path",
+}
+
+// renderManifestProblems lists the password manifest rows left out of the import
+func (m ImportCompletionModel) renderManifestProblems() string {
+	sections := []string{"", lipgloss.NewStyle().Bold(true).Foreground(palette.Warning).Render(localization.Labels["completion_manifest"])}
+	for i, problem := range m.summary.ManifestProblems {
+		if i >= 3 {
+			sections = append(sections, "  "+fmt.Sprintf(localization.Labels["completion_more"], len(m.summary.ManifestProblems)-3))
+			break
+		}
+		location := filepath.Base(problem.Manifest)
+		if problem.Line > 0 {
+			location = fmt.Sprintf("%s:%d", location, problem.Line)
+		}
+		text := localization.Labels["manifest_problem_malformed"]
+		if key, ok := manifestProblemLabels[problem.Type]; ok {
+			text = fmt.Sprintf(localization.Labels[key], problem.File)
+		}
+		sections = append(sections, fmt.Sprintf("  • %s: %s", location, text))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
 // renderAvailableActions renders the list of available actions
 func (m ImportCompletionModel) renderAvailableActions() string {
 	if len(m.availableActions) == 0 {
@@ -461,7 +546,7 @@ func (m ImportCompletionModel) renderAvailableActions() string {
 	var sections []string
 	sections = append(sections, "")
 
-	actionsTitle := lipgloss.NewStyle().Bold(true).Render("Available Actions:")
+	actionsTitle := lipgloss.NewStyle().Bold(true).Render(localization.Labels["completion_actions"])
 	sections = append(sections, actionsTitle)
 
 	for i, action := range m.availableActions {
@@ -473,8 +558,8 @@ func (m ImportCompletionModel) renderAvailableActions() string {
 		if i == m.selectedAction {
 			// Highlight selected action
 			style = lipgloss.NewStyle().
-				Background(lipgloss.Color("62")).
-				Foreground(lipgloss.Color("230")).
+				Background(palette.SelectionBg).
+				Foreground(palette.SelectionFg).
 				Bold(true)
 		} else {
 			style = lipgloss.NewStyle()
@@ -486,7 +571,7 @@ func (m ImportCompletionModel) renderAvailableActions() string {
 		// Add description for selected action
 		if i == m.selectedAction {
 			descStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("244")).
+				Foreground(palette.Muted).
 				Italic(true)
 			sections = append(sections, descStyle.Render(fmt.Sprintf("      %s", action.Description)))
 		}
@@ -501,17 +586,18 @@ func (m ImportCompletionModel) renderInstructions() string {
 	sections = append(sections, "")
 
 	instructions := []string{
-		"Use ↑/↓ or j/k to navigate actions",
-		"Press ENTER to execute selected action",
-		"Press ESC or Q to return to main menu",
+		localization.Labels["completion_help_navigate"],
+		localization.Labels["completion_help_execute"],
+		localization.Labels["completion_help_return"],
 	}
 
 	// Add specific key instructions if actions are available
 	if m.hasActionWithKey("E") {
-		instructions = append(instructions, "Press E to view detailed error information")
+		instructions = append(instructions, localization.Labels["completion_help_errors"])
 	}
+	instructions = append(instructions, localization.Labels["completion_help_report"])
 
-	instructionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	instructionStyle := lipgloss.NewStyle().Foreground(palette.Muted)
 	for _, instruction := range instructions {
 		sections = append(sections, instructionStyle.Render(instruction))
 	}
@@ -522,18 +608,21 @@ func (m ImportCompletionModel) renderInstructions() string {
 // renderErrorDetailsView renders the detailed error view
 func (m ImportCompletionModel) renderErrorDetailsView() string {
 	if len(m.summary.Errors) == 0 {
-		return "No errors to display"
+		return localization.Labels["completion_no_errors"]
 	}
 
 	var sections []string
 
 	// Title
-	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196")).Render("Error Details")
+	title := lipgloss.NewStyle().Bold(true).Foreground(palette.Error).Render(localization.Labels["completion_error_details"])
 	sections = append(sections, title)
 
 	// Error navigation info
-	navInfo := fmt.Sprintf("Error %d of %d", m.errorIndex+1, len(m.summary.Errors))
+	navInfo := fmt.Sprintf(localization.Labels["completion_error_position"], m.errorIndex+1, len(m.summary.Errors))
 	sections = append(sections, navInfo)
+	if m.retryNotice != "" {
+		sections = append(sections, m.retryNotice)
+	}
 
 	// Current error details
 	if m.errorIndex < len(m.summary.Errors) {
@@ -541,15 +630,22 @@ func (m ImportCompletionModel) renderErrorDetailsView() string {
 		sections = append(sections, errorDetails)
 	}
 
+	// Inline retry form
+	if retry := m.renderRetry(); retry != "" {
+		sections = append(sections, "", retry)
+		return lipgloss.JoinVertical(lipgloss.Left, sections...)
+	}
+
 	// Navigation instructions
 	sections = append(sections, "")
 	instructions := []string{
-		"Use ↑/↓ or j/k to navigate between errors",
-		"Press R to retry this specific file",
-		"Press ESC or Q to return to summary",
+		localization.Labels["completion_error_help_navigate"],
+		localization.Labels["completion_error_help_retry"],
+		localization.Labels["completion_error_help_rename"],
+		localization.Labels["completion_error_help_back"],
 	}
 
-	instructionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	instructionStyle := lipgloss.NewStyle().Foreground(palette.Muted)
 	for _, instruction := range instructions {
 		sections = append(sections, instructionStyle.Render(instruction))
 	}
@@ -563,20 +659,21 @@ func (m ImportCompletionModel) renderSingleErrorDetails(err wallet.ImportError)
 
 	// File information
 	fileStyle := lipgloss.NewStyle().Bold(true)
-	sections = append(sections, fileStyle.Render(fmt.Sprintf("File: %s", err.File)))
+	sections = append(sections, fileStyle.Render(fmt.Sprintf(localization.Labels["completion_error_file"], err.File)))
 
 	// Error type
-	errorType := "Failed"
-	typeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	errorType := localization.Labels["completion_failed"]
+	typeStyle := lipgloss.NewStyle().Foreground(palette.Error)
 	if err.Skipped {
-		errorType = "Skipped"
-		typeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+		errorType = localization.Labels["completion_skipped"]
+		typeStyle = lipgloss.NewStyle().Foreground(palette.Warning)
 	}
-	sections = append(sections, typeStyle.Render(fmt.Sprintf("Status: %s", errorType)))
+	sections = append(sections, typeStyle.Render(fmt.Sprintf(localization.Labels["completion_error_status"], errorType)))
+	sections = append(sections, fmt.Sprintf(localization.Labels["completion_error_type"], localization.Labels[importErrorCategories[importErrorCode(err)]]))
 
 	// Error message
 	sections = append(sections, "")
-	sections = append(sections, "Error Details:")
+	sections = append(sections, localization.Labels["completion_error_message"])
 
 	errorMsg := err.Error.Error()
 	// Wrap long error messages
@@ -585,9 +682,9 @@ func (m ImportCompletionModel) renderSingleErrorDetails(err wallet.ImportError)
 	}
 
 	errorStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("196")).
+		Foreground(palette.Error).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("196")).
+		BorderForeground(palette.Error).
 		Padding(1)
 
 	sections = append(sections, errorStyle.Render(errorMsg))
@@ -596,7 +693,7 @@ func (m ImportCompletionModel) renderSingleErrorDetails(err wallet.ImportError)
 	suggestions := m.getSuggestedActions(err)
 	if len(suggestions) > 0 {
 		sections = append(sections, "")
-		sections = append(sections, "Suggested Actions:")
+		sections = append(sections, localization.Labels["completion_suggestions"])
 		for _, suggestion := range suggestions {
 			sections = append(sections, fmt.Sprintf("  • %s", suggestion))
 		}
@@ -605,30 +702,102 @@ func (m ImportCompletionModel) renderSingleErrorDetails(err wallet.ImportError)
 	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
 
-// getSuggestedActions returns suggested actions based on the error type
-func (m ImportCompletionModel) getSuggestedActions(err wallet.ImportError) []string {
-	var suggestions []string
+// importErrorSuggestions lists, by error code, the localization keys of the
+// recovery suggestions shown for a failed or skipped file
+var importErrorSuggestions = map[string][]string{
+	"skipped":       {"suggest_skipped", "suggest_manual_password"},
+	"password":      {"suggest_verify_password", "suggest_check_pwd_file", "suggest_manual_password"},
+	"password_file": {"suggest_fix_pwd_file", "suggest_manual_password"},
+	"format":        {"suggest_valid_format", "suggest_corrupted"},
+	"file_access":   {"suggest_permissions", "suggest_locked"},
+	"file_missing":  {"suggest_reselect", "suggest_permissions"},
+	"overwrite":     {"suggest_overwrite", "suggest_overwrite_review"},
+	"generic":       {"suggest_check_details", "suggest_verify_accessible"},
+}
 
-	errorMsg := strings.ToLower(err.Error.Error())
+// importErrorCategories maps the error codes to their error_category_* label
+var importErrorCategories = map[string]string{
+	"skipped":       "error_category_user_action",
+	"password":      "error_category_password",
+	"password_file": "error_category_password",
+	"format":        "error_category_validation",
+	"file_access":   "error_category_filesystem",
+	"file_missing":  "error_category_filesystem",
+	"overwrite":     "error_category_validation",
+	"generic":       "error_category_unknown",
+}
 
+// importErrorCode classifies an import error for the suggestion lookup. The
+// typed errors of the import carry their code; other errors are matched by
+// their message.
+func importErrorCode(err wallet.ImportError) string {
 	if err.Skipped {
-		suggestions = append(suggestions, "File was skipped due to user cancellation")
-		suggestions = append(suggestions, "Retry with manual password input")
-	} else if strings.Contains(errorMsg, "password") || strings.Contains(errorMsg, "decrypt") {
-		suggestions = append(suggestions, "Verify the password is correct")
-		suggestions = append(suggestions, "Check if a .pwd file exists with the correct password")
-		suggestions = append(suggestions, "Retry with manual password input")
-	} else if strings.Contains(errorMsg, "format") || strings.Contains(errorMsg, "invalid") {
-		suggestions = append(suggestions, "Verify the file is a valid KeyStore V3 format")
-		suggestions = append(suggestions, "Check if the file is corrupted")
-	} else if strings.Contains(errorMsg, "permission") || strings.Contains(errorMsg, "access") {
-		suggestions = append(suggestions, "Check file permissions")
-		suggestions = append(suggestions, "Ensure the file is not locked by another process")
-	} else {
-		suggestions = append(suggestions, "Check the error details above")
-		suggestions = append(suggestions, "Verify the file is accessible and valid")
+		return "skipped"
+	}
+
+	var overwriteErr *wallet.KeystoreOverwriteError
+	if errors.As(err.Error, &overwriteErr) {
+		return "overwrite"
+	}
+
+	var keystoreErr *wallet.KeystoreImportError
+	if errors.As(err.Error, &keystoreErr) {
+		switch keystoreErr.Type {
+		case wallet.ErrorIncorrectPassword, wallet.ErrorMaxAttemptsExceeded, wallet.ErrorPasswordInputTimeout:
+			return "password"
+		case wallet.ErrorPasswordFileNotFound, wallet.ErrorPasswordFileUnreadable, wallet.ErrorPasswordFileEmpty,
+			wallet.ErrorPasswordFileInvalid, wallet.ErrorPasswordFileOversized, wallet.ErrorPasswordFileCorrupted:
+			return "password_file"
+		case wallet.ErrorPasswordInputCancelled, wallet.ErrorPasswordInputSkipped:
+			return "skipped"
+		case wallet.ErrorFileNotFound, wallet.ErrorDirectoryScanFailed:
+			return "file_missing"
+		case wallet.ErrorInvalidJSON, wallet.ErrorInvalidKeystore, wallet.ErrorInvalidVersion, wallet.ErrorCorruptedFile,
+			wallet.ErrorAddressMismatch, wallet.ErrorMissingRequiredFields, wallet.ErrorInvalidAddress, wallet.ErrorImportJobValidation:
+			return "format"
+		}
+	}
+
+	var passwordFileErr *wallet.PasswordFileError
+	if errors.As(err.Error, &passwordFileErr) {
+		return "password_file"
 	}
 
+	var passwordInputErr *wallet.PasswordInputError
+	if errors.As(err.Error, &passwordInputErr) {
+		if passwordInputErr.Type == wallet.PasswordInputCancelled || passwordInputErr.Type == wallet.PasswordInputSkipped {
+			return "skipped"
+		}
+		return "password"
+	}
+
+	switch {
+	case errors.Is(err.Error, fs.ErrNotExist):
+		return "file_missing"
+	case errors.Is(err.Error, fs.ErrPermission):
+		return "file_access"
+	}
+
+	errorMsg := strings.ToLower(err.Error.Error())
+	switch {
+	case strings.Contains(errorMsg, "password") || strings.Contains(errorMsg, "decrypt"):
+		return "password"
+	case strings.Contains(errorMsg, "format") || strings.Contains(errorMsg, "invalid"):
+		return "format"
+	case strings.Contains(errorMsg, "permission") || strings.Contains(errorMsg, "access"):
+		return "file_access"
+	default:
+		return "generic"
+	}
+}
+
+// getSuggestedActions returns the localized suggested actions for the error code
+func (m ImportCompletionModel) getSuggestedActions(err wallet.ImportError) []string {
+	keys := importErrorSuggestions[importErrorCode(err)]
+	suggestions := make([]string, 0, len(keys))
+	for _, key := range keys {
+		suggestions = append(suggestions, localization.Labels[key])
+	}
 	return suggestions
 }
 
@@ -710,19 +879,19 @@ func (m ImportCompletionModel) GetRetryableFiles(strategy string) []string {
 	case "retry_failed":
 		for _, result := range m.results {
 			if !result.Success && !result.Skipped {
-				files = append(files, result.Job.KeystorePath)
+				files = appendRetryable(files, result)
 			}
 		}
 	case "retry_skipped":
 		for _, result := range m.results {
 			if result.Skipped {
-				files = append(files, result.Job.KeystorePath)
+				files = appendRetryable(files, result)
 			}
 		}
 	case "retry_all":
 		for _, result := range m.results {
 			if !result.Success {
-				files = append(files, result.Job.KeystorePath)
+				files = appendRetryable(files, result)
 			}
 		}
 	}
@@ -735,8 +904,11 @@ type RetryImportMsg struct {
 	Strategy string
 }
 
+// RetrySpecificFileMsg asks to import one file again. Job, when set,
+// overrides the job of the batch, e.g. with another wallet name.
 type RetrySpecificFileMsg struct {
 	File string
+	Job  *wallet.ImportJob
 }
 
 type ReturnToMenuMsg struct{}
@@ -5,6 +5,8 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
+
+	"blocowallet/pkg/localization"
 )
 
 func TestNewImportCompletionDemo(t *testing.T) {
@@ -76,6 +78,9 @@ func TestImportCompletionDemo_Update_Navigation(t *testing.T) {
 }
 
 func TestImportCompletionDemo_View(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddImportCompletionMessages()
+
 	demo := NewImportCompletionDemo()
 
 	// Test normal view
@@ -96,6 +101,9 @@ func TestImportCompletionDemo_View(t *testing.T) {
 }
 
 func TestImportCompletionDemo_ErrorDetailsView(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddImportCompletionMessages()
+
 	demo := NewImportCompletionDemo()
 
 	// Enter error details view
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
 )
 
 func TestNewImportCompletionModel(t *testing.T) {
@@ -73,7 +74,7 @@ func TestImportCompletionModel_InitializeActions(t *testing.T) {
 				SkippedImports:    0,
 				Errors:            []wallet.ImportError{},
 			},
-			expectedActions: []string{"ENTER", "S"}, // Return to menu, Select different files
+			expectedActions: []string{"ENTER", "S", "X"}, // Return to menu, Select different files, Export report
 		},
 		{
 			name: "with failed imports",
@@ -86,7 +87,7 @@ func TestImportCompletionModel_InitializeActions(t *testing.T) {
 					{File: "failed.json", Error: fmt.Errorf("error"), Skipped: false},
 				},
 			},
-			expectedActions: []string{"ENTER", "S", "F", "A", "E"}, // + Retry failed, Retry all, View errors
+			expectedActions: []string{"ENTER", "S", "F", "A", "E", "X"}, // + Retry failed, Retry all, View errors
 		},
 		{
 			name: "with skipped imports",
@@ -99,7 +100,7 @@ func TestImportCompletionModel_InitializeActions(t *testing.T) {
 					{File: "skipped.json", Error: fmt.Errorf("skipped"), Skipped: true},
 				},
 			},
-			expectedActions: []string{"ENTER", "S", "K", "A", "E"}, // + Retry skipped, Retry all, View errors
+			expectedActions: []string{"ENTER", "S", "K", "A", "E", "X"}, // + Retry skipped, Retry all, View errors
 		},
 		{
 			name: "with both failed and skipped",
@@ -113,7 +114,7 @@ func TestImportCompletionModel_InitializeActions(t *testing.T) {
 					{File: "skipped.json", Error: fmt.Errorf("skipped"), Skipped: true},
 				},
 			},
-			expectedActions: []string{"ENTER", "S", "F", "K", "A", "E"}, // All actions
+			expectedActions: []string{"ENTER", "S", "F", "K", "A", "E", "X"}, // All actions
 		},
 	}
 
@@ -282,6 +283,9 @@ func TestImportCompletionModel_GetRetryableFiles(t *testing.T) {
 }
 
 func TestImportCompletionModel_SuggestedActions(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddImportCompletionMessages()
+
 	model := NewImportCompletionModel(wallet.ImportSummary{}, []wallet.ImportResult{}, time.Now(), Styles{})
 
 	tests := []struct {
@@ -404,6 +408,9 @@ func TestImportCompletionModel_WrapText(t *testing.T) {
 }
 
 func TestImportCompletionModel_View(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddImportCompletionMessages()
+
 	summary := wallet.ImportSummary{
 		TotalFiles:        3,
 		SuccessfulImports: 2,
@@ -450,3 +457,63 @@ func TestImportCompletionModel_GettersAndSetters(t *testing.T) {
 	model.showingErrors = true
 	assert.True(t, model.IsShowingErrors())
 }
+
+func TestImportCompletionModel_LocalizedSuggestions(t *testing.T) {
+	localization.SetCurrentLanguage("pt")
+	localization.AddEnhancedImportMessages()
+	localization.AddImportCompletionMessages()
+	defer func() {
+		localization.SetCurrentLanguage("en")
+		localization.AddEnhancedImportMessages()
+		localization.AddImportCompletionMessages()
+	}()
+
+	// The error code is read from the typed error, whatever the message says
+	wrongPassword := wallet.ImportError{
+		File:  "alice.json",
+		Error: fmt.Errorf("keystore import failed: %w", wallet.NewKeystoreImportError(wallet.ErrorIncorrectPassword, "mac mismatch", nil)),
+	}
+	overwrite := wallet.ImportError{
+		File:  "bob.json",
+		Error: fmt.Errorf("keystore import failed: %w", &wallet.KeystoreOverwriteError{Path: "/keystore/0xB0B.json", Address: "0xB0B"}),
+	}
+	summary := wallet.ImportSummary{TotalFiles: 2, FailedImports: 2, Errors: []wallet.ImportError{wrongPassword, overwrite}}
+	model := NewImportCompletionModel(summary, nil, time.Now(), Styles{})
+
+	assert.Equal(t, []string{
+		"Verifique se a senha está correta",
+		"Verifique se existe um arquivo .pwd com a senha correta",
+		"Tente novamente digitando a senha",
+	}, model.getSuggestedActions(wrongPassword))
+	assert.Equal(t, []string{
+		localization.Labels["suggest_overwrite"],
+		localization.Labels["suggest_overwrite_review"],
+	}, model.getSuggestedActions(overwrite))
+
+	assert.Contains(t, model.View(), "Importação Falhou")
+	model.showingErrors = true
+	view := model.View()
+	assert.Contains(t, view, "Erro 1 de 2")
+	assert.Contains(t, view, "Tipo: Senha")
+	assert.Contains(t, view, "Ações Sugeridas:")
+}
+
+func TestImportCompletionModel_ManifestProblems(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddImportCompletionMessages()
+
+	summary := wallet.ImportSummary{
+		TotalFiles:        1,
+		SuccessfulImports: 1,
+		ManifestProblems: []wallet.PasswordManifestProblem{
+			{Manifest: "/backup/passwords.csv", Line: 3, File: "erin.json", Type: wallet.ManifestMissingFile},
+			{Manifest: "/backup/passwords.csv", Line: 5, Type: wallet.ManifestMalformedRow},
+		},
+	}
+	view := NewImportCompletionModel(summary, nil, time.Now(), Styles{}).View()
+	assert.Contains(t, view, "Password manifest rows not used:")
+	assert.Contains(t, view, "passwords.csv:3: erin.json does not exist")
+	assert.Contains(t, view, "passwords.csv:5: the row needs a file name and a password")
+
+	assert.NotContains(t, NewImportCompletionModel(wallet.ImportSummary{TotalFiles: 1, SuccessfulImports: 1}, nil, time.Now(), Styles{}).View(), "manifest")
+}
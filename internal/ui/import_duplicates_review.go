@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxDuplicateLines bounds the duplicates listed by name in the review
+const maxDuplicateLines = 10
+
+// DuplicateCheckingImportService is implemented by batch services that can
+// tell which keystores of a batch are already imported before it runs
+type DuplicateCheckingImportService interface {
+	FindImportDuplicates(jobs []wallet.ImportJob) ([]wallet.ImportDuplicate, error)
+	ResolveImportDuplicates(jobs []wallet.ImportJob, duplicates []wallet.ImportDuplicate, action wallet.DuplicateAction) ([]wallet.ImportJob, error)
+}
+
+// findDuplicates looks up the jobs already imported, for the review shown
+// before the batch starts. It reports whether there is anything to review.
+func (s *EnhancedImportState) findDuplicates() (bool, error) {
+	service, ok := s.BatchService.(DuplicateCheckingImportService)
+	if !ok {
+		return false, nil
+	}
+	duplicates, err := service.FindImportDuplicates(s.ImportJobs)
+	if err != nil {
+		return false, fmt.Errorf("duplicate check failed: %w", err)
+	}
+	s.Duplicates = duplicates
+	return len(duplicates) > 0, nil
+}
+
+// ReviewingDuplicates reports whether the review of the files already
+// imported is waiting for an answer
+func (s *EnhancedImportState) ReviewingDuplicates() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.Phase == PhaseFileSelection && len(s.Duplicates) > 0
+}
+
+// ResolveDuplicates applies the answer of the review and starts the batch.
+// It reports false, staying in file selection, when no file is left.
+func (s *EnhancedImportState) ResolveDuplicates(action wallet.DuplicateAction) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	service, ok := s.BatchService.(DuplicateCheckingImportService)
+	if !ok || len(s.Duplicates) == 0 {
+		return false, fmt.Errorf("there are no duplicates to review")
+	}
+	jobs, err := service.ResolveImportDuplicates(s.ImportJobs, s.Duplicates, action)
+	if err != nil {
+		return false, err
+	}
+	total := len(s.ImportJobs)
+	s.Duplicates = nil
+	if len(jobs) == 0 {
+		s.ImportJobs = []wallet.ImportJob{}
+		s.resumeNotice = fmt.Sprintf(localization.Labels["import_duplicates_nothing"], total)
+		return false, nil
+	}
+
+	s.ImportJobs = jobs
+	return true, s.transitionToPhaseInternal(PhaseImporting)
+}
+
+// CancelDuplicateReview goes back to the file selection without importing
+func (s *EnhancedImportState) CancelDuplicateReview() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Duplicates = nil
+	s.ImportJobs = []wallet.ImportJob{}
+}
+
+// renderDuplicateReview lists the files already imported with the choices
+func (s *EnhancedImportState) renderDuplicateReview() string {
+	warning := lipgloss.NewStyle().Foreground(palette.Warning)
+	dim := lipgloss.NewStyle().Foreground(palette.Muted)
+
+	var b strings.Builder
+	b.WriteString(warning.Bold(true).Render(glyphs.Warning+" "+localization.Labels["import_duplicates_title"]) + "\n\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["import_duplicates_summary"], len(s.Duplicates), len(s.ImportJobs)) + "\n\n")
+	for i, d := range s.Duplicates {
+		if i == maxDuplicateLines {
+			b.WriteString(dim.Render(fmt.Sprintf(localization.Labels["import_duplicates_more"], len(s.Duplicates)-i)) + "\n")
+			break
+		}
+		kind := localization.Labels["import_duplicates_different"]
+		if d.Identical {
+			kind = localization.Labels["import_duplicates_identical"]
+		}
+		b.WriteString(fmt.Sprintf(localization.Labels["import_duplicates_line"], filepath.Base(d.Job.KeystorePath), d.Wallet.Name, kind) + "\n")
+	}
+	b.WriteString("\n" + dim.Render(localization.Labels["import_duplicates_help"]))
+	return b.String()
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+)
+
+// duplicateCheckingService reports the jobs named in duplicates as imported
+type duplicateCheckingService struct {
+	MockBatchImportService
+	duplicates map[string]bool
+	action     wallet.DuplicateAction
+}
+
+func (d *duplicateCheckingService) FindImportDuplicates(jobs []wallet.ImportJob) ([]wallet.ImportDuplicate, error) {
+	var found []wallet.ImportDuplicate
+	for _, job := range jobs {
+		if d.duplicates[job.KeystorePath] {
+			found = append(found, wallet.ImportDuplicate{Job: job, Wallet: wallet.Wallet{Name: "existing " + job.WalletName}, Identical: true})
+		}
+	}
+	return found, nil
+}
+
+func (d *duplicateCheckingService) ResolveImportDuplicates(jobs []wallet.ImportJob, duplicates []wallet.ImportDuplicate, action wallet.DuplicateAction) ([]wallet.ImportJob, error) {
+	d.action = action
+	if action == wallet.DuplicateImportAnyway {
+		return jobs, nil
+	}
+	var remaining []wallet.ImportJob
+	for _, job := range jobs {
+		if !d.duplicates[job.KeystorePath] {
+			remaining = append(remaining, job)
+		}
+	}
+	return remaining, nil
+}
+
+func TestStartImport_ReviewsDuplicates(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddImportCompletionMessages()
+	service := &duplicateCheckingService{
+		MockBatchImportService: MockBatchImportService{jobs: []wallet.ImportJob{
+			{KeystorePath: "a.json", WalletName: "a"},
+			{KeystorePath: "b.json", WalletName: "b"},
+		}},
+		duplicates: map[string]bool{"a.json": true},
+	}
+	state := NewEnhancedImportState(service, createStyles())
+	state.SelectedFiles = []string{"a.json", "b.json"}
+
+	// The batch waits for an answer about the file already imported
+	require.NoError(t, state.StartImport())
+	assert.True(t, state.ReviewingDuplicates())
+	assert.Equal(t, PhaseFileSelection, state.GetCurrentPhase())
+	view := state.View()
+	assert.Contains(t, view, "1 of 2 files are already imported")
+	assert.Contains(t, view, "existing a")
+
+	started, err := state.ResolveDuplicates(wallet.DuplicateSkip)
+	require.NoError(t, err)
+	assert.True(t, started)
+	assert.Equal(t, wallet.DuplicateSkip, service.action)
+	assert.Equal(t, PhaseImporting, state.GetCurrentPhase())
+	assert.Equal(t, []wallet.ImportJob{{KeystorePath: "b.json", WalletName: "b"}}, state.ImportJobs)
+}
+
+func TestStartImport_EverythingAlreadyImported(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddImportCompletionMessages()
+	service := &duplicateCheckingService{
+		MockBatchImportService: MockBatchImportService{jobs: []wallet.ImportJob{{KeystorePath: "a.json", WalletName: "a"}}},
+		duplicates:             map[string]bool{"a.json": true},
+	}
+	state := NewEnhancedImportState(service, createStyles())
+	state.SelectedFiles = []string{"a.json"}
+
+	require.NoError(t, state.StartImport())
+	started, err := state.ResolveDuplicates(wallet.DuplicateSkip)
+	require.NoError(t, err)
+	assert.False(t, started)
+	assert.False(t, state.ReviewingDuplicates())
+	assert.Equal(t, PhaseFileSelection, state.GetCurrentPhase())
+	assert.Contains(t, state.View(), "all 1 files are already imported")
+
+	// Going back from the review leaves nothing queued
+	state.SelectedFiles = []string{"a.json"}
+	require.NoError(t, state.StartImport())
+	state.CancelDuplicateReview()
+	assert.False(t, state.ReviewingDuplicates())
+	assert.Empty(t, state.ImportJobs)
+}
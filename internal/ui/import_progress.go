@@ -188,7 +188,7 @@ func (m ImportProgressModel) View() string {
 		elapsed := time.Since(m.startTime)
 		successCount := m.processedFiles - len(m.errors)
 
-		statusText := fmt.Sprintf("✓ Import completed in %v", elapsed.Round(time.Second))
+		statusText := fmt.Sprintf("%s Import completed in %v", glyphs.Check, elapsed.Round(time.Second))
 		sections = append(sections, m.styles.SuccessStyle.Render(statusText))
 
 		summaryText := fmt.Sprintf("Success: %d, Failed: %d, Skipped: %d",
@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+)
+
+// defaultImportReportPath names the report of a batch finished at the given
+// time, in dir and with the extension of format
+func defaultImportReportPath(dir, format string, at time.Time) string {
+	return filepath.Join(dir, "import-"+at.Format("20060102-150405")+"."+format)
+}
+
+// SetReportDefaults sets where the report of the batch is offered to be
+// written and in which format, "json" or "csv"
+func (m *ImportCompletionModel) SetReportDefaults(dir, format string) {
+	m.reportDir = dir
+	if format == wallet.ImportReportCSV {
+		m.reportFormat = format
+	} else {
+		m.reportFormat = wallet.ImportReportJSON
+	}
+}
+
+// startReportExport opens the path field of the report, filled with the
+// default path so it can be edited
+func (m *ImportCompletionModel) startReportExport() tea.Cmd {
+	format := m.reportFormat
+	if format == "" {
+		format = wallet.ImportReportJSON
+	}
+	m.reportInput = textinput.New()
+	m.reportInput.CharLimit = 512
+	m.reportInput.Width = 60
+	m.reportInput.SetValue(defaultImportReportPath(m.reportDir, format, m.startTime.Add(m.elapsedTime)))
+	m.reportInput.CursorEnd()
+	m.exportingReport = true
+	m.reportStatus = ""
+	return m.reportInput.Focus()
+}
+
+// closeReportExport leaves the path field without writing
+func (m *ImportCompletionModel) closeReportExport() {
+	m.exportingReport = false
+	m.reportInput.Blur()
+}
+
+// handleReportKeyPress edits the path: enter writes the report, esc cancels
+func (m *ImportCompletionModel) handleReportKeyPress(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		m.writeReport()
+		return nil
+	case "esc":
+		m.closeReportExport()
+		return nil
+	}
+	var cmd tea.Cmd
+	m.reportInput, cmd = updateTextInput(m.reportInput, msg)
+	return cmd
+}
+
+// writeReport writes the summary and the result of every file, as CSV when
+// the path ends in .csv and as JSON otherwise
+func (m *ImportCompletionModel) writeReport() {
+	path := strings.TrimSpace(m.reportInput.Value())
+	if path == "" {
+		path = defaultImportReportPath(m.reportDir, wallet.ImportReportJSON, m.startTime.Add(m.elapsedTime))
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	m.closeReportExport()
+	report := wallet.NewImportReport(m.summary, m.results, m.startTime, m.startTime.Add(m.elapsedTime))
+	if err := wallet.WriteImportReportFile(path, report); err != nil {
+		m.reportStatus = fmt.Sprintf(localization.Labels["completion_report_failed"], err)
+		m.reportFailed = true
+		return
+	}
+	m.reportStatus = fmt.Sprintf(localization.Labels["completion_report_written"], len(report.Files), path)
+	m.reportFailed = false
+}
+
+// renderReport shows the path field while it is open, or the result of the
+// last report
+func (m ImportCompletionModel) renderReport() string {
+	if m.exportingReport {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			"",
+			localization.Labels["completion_report_label"]+" "+m.reportInput.View(),
+			lipgloss.NewStyle().Foreground(palette.Muted).Render(localization.Labels["completion_report_help"]),
+		)
+	}
+	if m.reportStatus == "" {
+		return ""
+	}
+	color := palette.Success
+	if m.reportFailed {
+		color = palette.Error
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(m.reportStatus)
+}
+
+// ExportingReport reports whether the path field of the import report takes
+// the keys
+func (s *EnhancedImportState) ExportingReport() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.Phase == PhaseComplete && s.Completion != nil && s.Completion.exportingReport
+}
+
+// CancelReportExport closes the path field of the import report
+func (s *EnhancedImportState) CancelReportExport() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Completion != nil {
+		s.Completion.closeReportExport()
+	}
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+)
+
+func TestImportCompletionModel_ExportReport(t *testing.T) {
+	localization.AddImportCompletionMessages()
+
+	results := []wallet.ImportResult{
+		{Job: wallet.ImportJob{KeystorePath: "/keys/a.json", WalletName: "A"}, Success: true, Duration: time.Second},
+		{Job: wallet.ImportJob{KeystorePath: "/keys/b.json", WalletName: "B"}, Error: errors.New("boom")},
+	}
+	summary := wallet.ImportSummary{TotalFiles: 2, SuccessfulImports: 1, FailedImports: 1,
+		Errors: []wallet.ImportError{{File: "/keys/b.json", Error: results[1].Error}}}
+	dir := t.TempDir()
+
+	state := NewEnhancedImportState(&MockBatchImportService{}, createStyles())
+	state.ReportDir, state.ReportFormat = dir, "csv"
+	state.Results = results
+	state.Phase = PhaseImporting
+	require.NoError(t, state.TransitionToPhase(PhaseComplete))
+	state.Completion.summary = summary
+	state.Completion.initializeActions()
+
+	// The path is offered in the configured directory and format
+	state.HandleCompletionUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	require.True(t, state.ExportingReport())
+	offered := state.Completion.reportInput.Value()
+	assert.Equal(t, dir, filepath.Dir(offered))
+	assert.True(t, strings.HasSuffix(offered, ".csv"))
+
+	// Keys edit the path instead of running actions
+	state.Completion.reportInput.SetValue(filepath.Join(dir, "audit", "run"))
+	state.HandleCompletionUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(".json")})
+	state.HandleCompletionUpdate(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, state.ExportingReport())
+	assert.False(t, state.Completion.reportFailed)
+	assert.Contains(t, state.View(), filepath.Join(dir, "audit", "run.json"))
+
+	data, err := os.ReadFile(filepath.Join(dir, "audit", "run.json"))
+	require.NoError(t, err)
+	var report wallet.ImportReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, 1, report.Failed)
+	require.Len(t, report.Files, 2)
+	assert.Equal(t, int64(1000), report.Files[0].DurationMS)
+	assert.Equal(t, "UNKNOWN", report.Files[1].ErrorType)
+
+	// Esc leaves the field without writing
+	state.HandleCompletionUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	require.True(t, state.ExportingReport())
+	state.CancelReportExport()
+	assert.False(t, state.ExportingReport())
+}
@@ -0,0 +1,279 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+)
+
+type retryFocus int
+
+const (
+	retryFocusName retryFocus = iota
+	retryFocusPassword
+)
+
+// fileRetry is the inline form that imports one file of the batch again from
+// the error details, with the wallet name and password it needs
+type fileRetry struct {
+	job         wallet.ImportJob
+	name        textinput.Model
+	password    textinput.Model
+	editName    bool
+	askPassword bool
+	focus       retryFocus
+	running     bool
+	errorText   string
+}
+
+// RetryFileDoneMsg carries the result of importing one file again
+type RetryFileDoneMsg struct {
+	Result wallet.ImportResult
+}
+
+// jobForFile returns the job of the batch that imported file
+func (m *ImportCompletionModel) jobForFile(file string) (wallet.ImportJob, bool) {
+	for _, result := range m.results {
+		if result.Job.KeystorePath == file {
+			return result.Job, true
+		}
+	}
+	return wallet.ImportJob{}, false
+}
+
+// startRetry imports the file shown in the error details again. The wallet
+// name is asked when editName is set, and the password when the batch had
+// none for the file or the password was the problem; otherwise the file is
+// imported again right away.
+func (m *ImportCompletionModel) startRetry(editName bool) tea.Cmd {
+	if m.errorIndex >= len(m.summary.Errors) {
+		return nil
+	}
+	item := m.summary.Errors[m.errorIndex]
+	job, ok := m.jobForFile(item.File)
+	if !ok || job.KeystorePath == wallet.PastedKeystoreSource {
+		return nil
+	}
+
+	code := importErrorCode(item)
+	retry := &fileRetry{
+		job:         job,
+		editName:    editName,
+		askPassword: job.RequiresInput || (job.PasswordPath == "" && job.ManualPassword == "") || code == "password" || code == "password_file" || code == "skipped",
+	}
+	m.retry = retry
+	if !retry.editName && !retry.askPassword {
+		return m.submitRetry()
+	}
+
+	retry.name = textinput.New()
+	retry.name.CharLimit = 100
+	retry.name.Width = 40
+	retry.name.SetValue(job.WalletName)
+	retry.password = textinput.New()
+	retry.password.Placeholder = localization.Labels["password_popup_placeholder"]
+	retry.password.CharLimit = 256
+	retry.password.Width = 40
+	retry.password.EchoMode = textinput.EchoPassword
+	retry.password.EchoCharacter = '•'
+	if retry.editName {
+		retry.name.Focus()
+		retry.name.CursorEnd()
+	} else {
+		retry.focus = retryFocusPassword
+		retry.password.Focus()
+	}
+	return nil
+}
+
+// handleRetryKeyPress edits the retry form
+func (m *ImportCompletionModel) handleRetryKeyPress(msg tea.KeyMsg) tea.Cmd {
+	retry := m.retry
+	switch msg.String() {
+	case "esc":
+		m.retry = nil
+		return nil
+	case "tab", "shift+tab", "up", "down":
+		if retry.editName && retry.askPassword {
+			retry.setFocus(1 - retry.focus)
+		}
+		return nil
+	case "enter":
+		if retry.focus == retryFocusName && retry.askPassword && retry.password.Value() == "" {
+			retry.setFocus(retryFocusPassword)
+			return nil
+		}
+		return m.submitRetry()
+	}
+
+	var cmd tea.Cmd
+	if retry.focus == retryFocusName {
+		retry.name, cmd = updateTextInput(retry.name, msg)
+	} else {
+		retry.password, cmd = updateTextInput(retry.password, msg)
+	}
+	retry.errorText = ""
+	return cmd
+}
+
+func (r *fileRetry) setFocus(focus retryFocus) {
+	r.focus = focus
+	if focus == retryFocusName {
+		r.password.Blur()
+		r.name.Focus()
+	} else {
+		r.name.Blur()
+		r.password.Focus()
+	}
+}
+
+// submitRetry sends the job of the form to be imported again
+func (m *ImportCompletionModel) submitRetry() tea.Cmd {
+	retry := m.retry
+	job := retry.job
+	if retry.editName {
+		job.WalletName = strings.TrimSpace(retry.name.Value())
+		if job.WalletName == "" {
+			retry.errorText = localization.Labels["completion_retry_name_required"]
+			return nil
+		}
+	}
+	if retry.askPassword {
+		if retry.password.Value() == "" {
+			retry.errorText = localization.Labels["completion_retry_password_required"]
+			return nil
+		}
+		job.ManualPassword, job.RequiresInput = retry.password.Value(), false
+	}
+	retry.running = true
+	return func() tea.Msg {
+		return RetrySpecificFileMsg{File: job.KeystorePath, Job: &job}
+	}
+}
+
+// applyRetryResult shows the summary of the batch once a file was imported
+// again, staying on the error details while errors are left
+func (m *ImportCompletionModel) applyRetryResult(summary wallet.ImportSummary, results []wallet.ImportResult, result wallet.ImportResult) {
+	m.summary, m.results = summary, results
+	m.retry = nil
+	file := filepath.Base(result.Job.KeystorePath)
+	if result.Success {
+		m.retryNotice = fmt.Sprintf(localization.Labels["completion_retry_success"], file, result.Job.WalletName)
+	} else {
+		m.retryNotice = fmt.Sprintf(localization.Labels["completion_retry_failed"], file, result.Error)
+	}
+
+	selected := m.GetSelectedAction()
+	m.initializeActions()
+	m.selectedAction = 0
+	for i, action := range m.availableActions {
+		if action.Action == selected {
+			m.selectedAction = i
+		}
+	}
+	if len(m.summary.Errors) == 0 {
+		m.showingErrors = false
+		m.errorIndex = 0
+	} else if m.errorIndex > m.maxErrorIndex {
+		m.errorIndex = m.maxErrorIndex
+	}
+}
+
+// renderRetry renders the retry form while it is open
+func (m ImportCompletionModel) renderRetry() string {
+	retry := m.retry
+	if retry == nil {
+		return ""
+	}
+	if retry.running {
+		return lipgloss.NewStyle().Foreground(palette.Warning).Render(fmt.Sprintf(localization.Labels["completion_retry_running"], filepath.Base(retry.job.KeystorePath)))
+	}
+
+	var sections []string
+	if retry.editName {
+		sections = append(sections, localization.Labels["completion_retry_name"], retry.name.View())
+	}
+	if retry.askPassword {
+		sections = append(sections, localization.Labels["completion_retry_password"], retry.password.View())
+	}
+	if retry.errorText != "" {
+		sections = append(sections, lipgloss.NewStyle().Foreground(palette.Error).Render(retry.errorText))
+	}
+	sections = append(sections, "", lipgloss.NewStyle().Foreground(palette.Muted).Render(localization.Labels["completion_retry_help"]))
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+// retryFile imports one file of the finished batch again, off the UI thread
+func (s *EnhancedImportState) retryFile(job wallet.ImportJob) tea.Cmd {
+	service := s.BatchService
+	return func() tea.Msg {
+		progress := make(chan wallet.ImportProgress, 100)
+		go func() {
+			for range progress {
+			}
+		}()
+		results := service.ImportBatch([]wallet.ImportJob{job}, progress, make(chan wallet.PasswordRequest, 1), make(chan wallet.PasswordResponse, 1))
+		if len(results) == 0 {
+			return RetryFileDoneMsg{Result: wallet.ImportResult{Job: job, Error: errors.New("the import returned no result")}}
+		}
+		return RetryFileDoneMsg{Result: results[0]}
+	}
+}
+
+// ApplyRetryResult replaces the result of a file imported again and updates
+// the summary of the batch
+func (s *EnhancedImportState) ApplyRetryResult(result wallet.ImportResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Phase != PhaseComplete || s.Completion == nil {
+		return
+	}
+	for i := range s.Results {
+		if s.Results[i].Job.KeystorePath == result.Job.KeystorePath {
+			s.Results[i] = result
+		}
+	}
+	s.Completion.applyRetryResult(s.BatchService.GetImportSummary(s.Results), s.Results, result)
+}
+
+// InErrorDetails reports whether the error details of the finished batch, or
+// the retry form over them, are shown
+func (s *EnhancedImportState) InErrorDetails() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.Phase == PhaseComplete && s.Completion != nil && s.Completion.showingErrors
+}
+
+// RetryEditing reports whether the retry form takes the keys
+func (s *EnhancedImportState) RetryEditing() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.Phase == PhaseComplete && s.Completion != nil && s.Completion.retry != nil && !s.Completion.retry.running
+}
+
+// BackFromErrorDetails closes the retry form, or the error details when no
+// form is open. A running retry is left to finish.
+func (s *EnhancedImportState) BackFromErrorDetails() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Completion == nil {
+		return
+	}
+	switch {
+	case s.Completion.retry != nil && s.Completion.retry.running:
+	case s.Completion.retry != nil:
+		s.Completion.retry = nil
+	default:
+		s.Completion.showingErrors = false
+		s.Completion.errorIndex = 0
+	}
+}
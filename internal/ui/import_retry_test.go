@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+)
+
+func TestImportCompletionModel_RetryUnderAnotherName(t *testing.T) {
+	localization.AddImportCompletionMessages()
+
+	job := wallet.ImportJob{KeystorePath: "/keys/a.json", WalletName: "Wallet A", ManualPassword: "secret"}
+	failed := wallet.ImportResult{Job: job, Error: errors.New("a wallet named Wallet A already exists")}
+	summary := wallet.ImportSummary{
+		TotalFiles:    1,
+		FailedImports: 1,
+		Errors:        []wallet.ImportError{{File: job.KeystorePath, Error: failed.Error}},
+	}
+
+	renamed := job
+	renamed.WalletName = "Wallet B"
+	service := &MockBatchImportService{results: []wallet.ImportResult{{Job: renamed, Success: true}}}
+	state := NewEnhancedImportState(service, createStyles())
+	model := NewImportCompletionModel(summary, []wallet.ImportResult{failed}, time.Now(), Styles{})
+	state.Phase, state.Results, state.Completion = PhaseComplete, []wallet.ImportResult{failed}, &model
+
+	// Open the error details and the rename form
+	state.HandleCompletionUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	state.HandleCompletionUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	require.NotNil(t, state.Completion.retry)
+	assert.True(t, state.RetryEditing())
+	assert.False(t, state.Completion.retry.askPassword, "the batch password is reused")
+
+	// An empty name is refused
+	state.Completion.retry.name.SetValue("")
+	assert.Nil(t, state.HandleCompletionUpdate(tea.KeyMsg{Type: tea.KeyEnter}))
+	assert.NotEmpty(t, state.Completion.retry.errorText)
+
+	state.HandleCompletionUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Wallet B")})
+	cmd := state.HandleCompletionUpdate(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(RetrySpecificFileMsg)
+	require.True(t, ok)
+	require.NotNil(t, msg.Job)
+	assert.Equal(t, "Wallet B", msg.Job.WalletName)
+	assert.Equal(t, "secret", msg.Job.ManualPassword)
+	assert.False(t, state.RetryEditing(), "keys are ignored while the file is imported")
+
+	// Only that file is imported again, and its result replaces the failure
+	cmd = state.HandleCompletionUpdate(msg)
+	require.NotNil(t, cmd)
+	done, ok := cmd().(RetryFileDoneMsg)
+	require.True(t, ok)
+	state.ApplyRetryResult(done.Result)
+
+	assert.True(t, state.Results[0].Success)
+	assert.Equal(t, 1, state.Completion.summary.SuccessfulImports)
+	assert.Nil(t, state.Completion.retry)
+	assert.False(t, state.Completion.showingErrors)
+	assert.Contains(t, state.Completion.retryNotice, "Wallet B")
+}
+
+func TestEnhancedImportState_BackFromErrorDetails(t *testing.T) {
+	localization.AddImportCompletionMessages()
+
+	job := wallet.ImportJob{KeystorePath: "/keys/a.json", WalletName: "Wallet A"}
+	failed := wallet.ImportResult{Job: job, Error: errors.New("boom")}
+	summary := wallet.ImportSummary{TotalFiles: 1, FailedImports: 1, Errors: []wallet.ImportError{{File: job.KeystorePath, Error: failed.Error}}}
+	state := NewEnhancedImportState(&MockBatchImportService{}, createStyles())
+	model := NewImportCompletionModel(summary, []wallet.ImportResult{failed}, time.Now(), Styles{})
+	state.Phase, state.Results, state.Completion = PhaseComplete, []wallet.ImportResult{failed}, &model
+
+	state.HandleCompletionUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	state.HandleCompletionUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	require.True(t, state.RetryEditing(), "the password is asked when the batch had none")
+	assert.Contains(t, state.View(), localization.Labels["completion_retry_password"])
+
+	state.BackFromErrorDetails()
+	assert.False(t, state.RetryEditing())
+	assert.True(t, state.InErrorDetails())
+
+	state.BackFromErrorDetails()
+	assert.False(t, state.InErrorDetails())
+}
@@ -0,0 +1,390 @@
+package ui
+
+import (
+	"blocowallet/internal/compliance"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/inheritance"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"blocowallet/pkg/logger"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	inheritanceModeOverview = iota
+	inheritanceModeAddHolder
+	inheritanceModeGenerate
+)
+
+// inheritancePlanLoadedMsg carries the inheritance plan loaded at startup
+type inheritancePlanLoadedMsg struct {
+	store    *inheritance.Store
+	plan     *inheritance.Plan
+	interval time.Duration
+	appDir   string
+	err      error
+}
+
+// loadInheritancePlanCmd loads the plan and reminder interval in the background
+func loadInheritancePlanCmd() tea.Cmd {
+	return func() tea.Msg {
+		cfg, err := loadOrCreateConfig()
+		if err != nil {
+			return inheritancePlanLoadedMsg{err: err}
+		}
+		store := inheritance.NewStore(cfg.AppDir)
+		plan, err := store.Load()
+		return inheritancePlanLoadedMsg{
+			store:    store,
+			plan:     plan,
+			interval: time.Duration(cfg.Backup.ReminderIntervalDays) * 24 * time.Hour,
+			appDir:   cfg.AppDir,
+			err:      err,
+		}
+	}
+}
+
+// handleInheritancePlanLoaded stores the loaded plan on the model
+func (m *CLIModel) handleInheritancePlanLoaded(msg inheritancePlanLoadedMsg) {
+	if msg.err != nil {
+		if uiLogger != nil {
+			uiLogger.Error("Failed to load inheritance plan", logger.Error(msg.err))
+		}
+		return
+	}
+	m.inheritanceStore = msg.store
+	m.inheritancePlan = msg.plan
+	m.backupReminderEvery = msg.interval
+	m.inheritanceDir = filepath.Join(msg.appDir, "inheritance")
+}
+
+// renderBackupReminder returns the reminder shown on the main menu when backups are due for verification
+func (m *CLIModel) renderBackupReminder() string {
+	if m.inheritancePlan == nil || !m.inheritancePlan.ReminderDue(time.Now(), m.backupReminderEvery) {
+		return ""
+	}
+	return "\n\n" + m.styles.ErrorStyle.Render(glyphs.Warning+" "+localization.Labels["backup_reminder_due"])
+}
+
+// initInheritanceView opens the backup and inheritance plan screen
+func (m *CLIModel) initInheritanceView() {
+	if m.inheritancePlan == nil {
+		msg, _ := loadInheritancePlanCmd()().(inheritancePlanLoadedMsg)
+		m.handleInheritancePlanLoaded(msg)
+		if m.inheritancePlan == nil {
+			m.inheritancePlan = &inheritance.Plan{}
+		}
+	}
+	m.inheritanceMode = inheritanceModeOverview
+	m.selectedHolder = 0
+	m.setInheritanceStatus("", false)
+	m.currentView = constants.InheritanceView
+}
+
+func (m *CLIModel) setInheritanceStatus(status string, failed bool) {
+	m.inheritanceStatus = status
+	m.inheritanceFailed = failed
+}
+
+// saveInheritancePlan persists the plan after a change, reporting failures on screen
+func (m *CLIModel) saveInheritancePlan() {
+	if m.inheritanceStore == nil {
+		return
+	}
+	if err := m.inheritanceStore.Save(m.inheritancePlan); err != nil {
+		m.setInheritanceStatus(err.Error(), true)
+	}
+}
+
+// openInheritanceForm switches to one of the input forms
+func (m *CLIModel) openInheritanceForm(mode int) {
+	var placeholders []string
+	switch mode {
+	case inheritanceModeAddHolder:
+		placeholders = []string{localization.Labels["inheritance_holder_name"], localization.Labels["inheritance_holder_contact"]}
+	case inheritanceModeGenerate:
+		placeholders = []string{localization.Labels["secure_export_recipient_placeholder"], fmt.Sprintf(localization.Labels["inheritance_output_placeholder"], m.inheritanceDir)}
+	}
+
+	m.inheritanceInputs = make([]textinput.Model, len(placeholders))
+	for i, placeholder := range placeholders {
+		ti := textinput.New()
+		ti.Placeholder = placeholder
+		ti.CharLimit = 512
+		ti.Width = 70
+		m.inheritanceInputs[i] = ti
+	}
+	m.inheritanceInputs[0].Focus()
+	m.inheritanceFocus = 0
+	m.inheritanceMode = mode
+	m.setInheritanceStatus("", false)
+}
+
+// closeInheritanceForm returns to the plan overview
+func (m *CLIModel) closeInheritanceForm() {
+	m.inheritanceMode = inheritanceModeOverview
+	m.inheritanceInputs = nil
+}
+
+// updateInheritance handles input on the inheritance plan screen
+func (m *CLIModel) updateInheritance(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if m.inheritanceMode != inheritanceModeOverview {
+		return m.updateInheritanceForm(keyMsg)
+	}
+
+	plan := m.inheritancePlan
+	now := time.Now()
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.selectedHolder > 0 {
+			m.selectedHolder--
+		}
+	case "down", "j":
+		if m.selectedHolder < len(plan.Holders)-1 {
+			m.selectedHolder++
+		}
+	case "v":
+		plan.MarkBackupsVerified(now)
+		m.setInheritanceStatus(localization.Labels["inheritance_verified"], false)
+		m.saveInheritancePlan()
+	case "a":
+		m.openInheritanceForm(inheritanceModeAddHolder)
+	case "g":
+		m.openInheritanceForm(inheritanceModeGenerate)
+	case "x":
+		if err := plan.RemoveHolder(m.selectedHolder); err == nil {
+			if m.selectedHolder >= len(plan.Holders) && m.selectedHolder > 0 {
+				m.selectedHolder--
+			}
+			m.saveInheritancePlan()
+		}
+	case " ", "d":
+		if err := plan.ToggleDelivered(m.selectedHolder, now); err == nil {
+			m.saveInheritancePlan()
+		}
+	case "+", "=":
+		if err := plan.SetThreshold(plan.Threshold + 1); err == nil {
+			m.saveInheritancePlan()
+		}
+	case "-":
+		if err := plan.SetThreshold(plan.Threshold - 1); err == nil {
+			m.saveInheritancePlan()
+		}
+	case "i":
+		plan.InstructionsStored = !plan.InstructionsStored
+		m.saveInheritancePlan()
+	}
+	return m, nil
+}
+
+// updateInheritanceForm handles the add-holder and package generation forms
+func (m *CLIModel) updateInheritanceForm(keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch keyMsg.String() {
+	case "tab", "shift+tab", "down", "up":
+		m.inheritanceInputs[m.inheritanceFocus].Blur()
+		m.inheritanceFocus = (m.inheritanceFocus + 1) % len(m.inheritanceInputs)
+		m.inheritanceInputs[m.inheritanceFocus].Focus()
+		return m, nil
+	case "enter":
+		return m, m.submitInheritanceForm()
+	}
+
+	input := m.inheritanceInputs[m.inheritanceFocus]
+	if text, ok := pastedText(keyMsg); ok && m.inheritanceMode == inheritanceModeGenerate && m.inheritanceFocus == 1 {
+		input.SetValue(cleanPastedPath(text))
+		input.CursorEnd()
+		m.inheritanceInputs[m.inheritanceFocus] = input
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.inheritanceInputs[m.inheritanceFocus], cmd = updateTextInput(input, keyMsg)
+	return m, cmd
+}
+
+func (m *CLIModel) submitInheritanceForm() tea.Cmd {
+	plan := m.inheritancePlan
+	switch m.inheritanceMode {
+	case inheritanceModeAddHolder:
+		if err := plan.AddHolder(m.inheritanceInputs[0].Value(), m.inheritanceInputs[1].Value()); err != nil {
+			m.setInheritanceStatus(err.Error(), true)
+			return nil
+		}
+		m.selectedHolder = len(plan.Holders) - 1
+		m.closeInheritanceForm()
+		m.saveInheritancePlan()
+
+	case inheritanceModeGenerate:
+		recipient, err := wallet.ParseExportRecipient(m.inheritanceInputs[0].Value())
+		if err != nil {
+			m.setInheritanceStatus(err.Error(), true)
+			return nil
+		}
+		outputDir := strings.TrimSpace(m.inheritanceInputs[1].Value())
+		if outputDir == "" {
+			outputDir = m.inheritanceDir
+		}
+		wallets, err := m.Service.GetAllWallets()
+		if err != nil {
+			m.setInheritanceStatus(err.Error(), true)
+			return nil
+		}
+		// The package carries every wallet's details, so it is gated like any other secret export
+		var dir string
+		bundled := 0
+		for _, w := range wallets {
+			if !w.WatchOnly() {
+				bundled++
+			}
+		}
+		m.setInheritanceStatus("", false)
+		return m.withApproval(approvedAction{
+			action:  compliance.ActionExportSecret,
+			subject: fmt.Sprintf("inheritance package (%d wallets)", bundled),
+			run: func() error {
+				dir, err = inheritance.GeneratePackage(plan, inheritance.PackageOptions{
+					OutputDir: outputDir,
+					Wallets:   wallets,
+					Recipient: recipient,
+					Now:       time.Now(),
+				})
+				return err
+			},
+			done: func(err error) tea.Cmd {
+				if err != nil {
+					m.setInheritanceStatus(err.Error(), true)
+					return nil
+				}
+				m.closeInheritanceForm()
+				m.setInheritanceStatus(fmt.Sprintf(localization.Labels["inheritance_package_created"], dir), false)
+				m.saveInheritancePlan()
+				return m.notifyBackupCompleted("inheritance package", dir)
+			},
+		})
+	}
+	return nil
+}
+
+// viewInheritance renders the checklist, the share holders and any open form
+func (m *CLIModel) viewInheritance() string {
+	plan := m.inheritancePlan
+	now := time.Now()
+
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["inheritance_title"]))
+	b.WriteString("\n\n")
+
+	done, total := plan.Completion(now, m.backupReminderEvery)
+	b.WriteString(fmt.Sprintf(localization.Labels["inheritance_progress"], done, total))
+	b.WriteString("\n\n")
+
+	for _, step := range inheritance.Steps {
+		mark := glyphs.Unchecked
+		if plan.IsDone(step, now, m.backupReminderEvery) {
+			mark = glyphs.Checked
+		}
+		b.WriteString(fmt.Sprintf("%s %s", mark, localization.Labels["inheritance_step_"+string(step)]))
+		if detail := m.inheritanceStepDetail(step, now); detail != "" {
+			b.WriteString(m.styles.MenuDesc.Render(" — " + detail))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["inheritance_threshold"], plan.Threshold, len(plan.Holders)))
+	b.WriteString("\n")
+	if len(plan.Holders) == 0 {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["inheritance_no_holders"]))
+		b.WriteString("\n")
+	}
+	for i, holder := range plan.Holders {
+		mark := glyphs.Unchecked
+		if holder.Delivered {
+			mark = glyphs.Checked
+		}
+		line := fmt.Sprintf("  %s #%d %s", mark, holder.ShareIndex, holder.Name)
+		if holder.Contact != "" {
+			line += " (" + holder.Contact + ")"
+		}
+		if i == m.selectedHolder && m.inheritanceMode == inheritanceModeOverview {
+			line = m.styles.SelectedTitle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if m.inheritanceMode != inheritanceModeOverview {
+		title := localization.Labels["inheritance_add_holder"]
+		if m.inheritanceMode == inheritanceModeGenerate {
+			title = localization.Labels["inheritance_generate"]
+		}
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render(title))
+		b.WriteString("\n")
+		for _, input := range m.inheritanceInputs {
+			b.WriteString(input.View())
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if notice := m.renderApprovalNotice(); notice != "" {
+		b.WriteString(notice)
+		b.WriteString("\n\n")
+	}
+	if m.inheritanceStatus != "" {
+		if m.inheritanceFailed {
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.inheritanceStatus))
+		} else {
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.inheritanceStatus))
+		}
+		b.WriteString("\n\n")
+	}
+
+	help := localization.Labels["inheritance_help"]
+	if m.inheritanceMode != inheritanceModeOverview {
+		help = localization.Labels["inheritance_form_help"]
+	}
+	b.WriteString(m.styles.MenuDesc.Render(help))
+	return b.String()
+}
+
+// inheritanceStepDetail describes the state of a checklist step
+func (m *CLIModel) inheritanceStepDetail(step inheritance.Step, now time.Time) string {
+	plan := m.inheritancePlan
+	switch step {
+	case inheritance.StepBackupsVerified:
+		if plan.LastVerifiedAt == nil {
+			return localization.Labels["inheritance_never_verified"]
+		}
+		detail := fmt.Sprintf(localization.Labels["inheritance_last_verified"], plan.LastVerifiedAt.Format("2006-01-02"))
+		if m.backupReminderEvery > 0 {
+			detail += ", " + fmt.Sprintf(localization.Labels["inheritance_next_reminder"], plan.NextReminder(m.backupReminderEvery).Format("2006-01-02"))
+		}
+		return detail
+	case inheritance.StepSharesDistributed:
+		delivered := 0
+		for _, h := range plan.Holders {
+			if h.Delivered {
+				delivered++
+			}
+		}
+		return fmt.Sprintf(localization.Labels["inheritance_delivered_count"], delivered, len(plan.Holders))
+	case inheritance.StepPackageGenerated:
+		if plan.PackageGeneratedAt == nil {
+			return ""
+		}
+		return fmt.Sprintf("%s (%s)", plan.PackagePath, plan.PackageGeneratedAt.Format("2006-01-02"))
+	}
+	return ""
+}
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/inheritance"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newInheritanceModel(t *testing.T) (*CLIModel, *inheritance.Store) {
+	t.Helper()
+	localization.SetCurrentLanguage("en")
+	localization.AddInheritanceMessages()
+
+	store := inheritance.NewStore(t.TempDir())
+	m := &CLIModel{styles: createStyles(), currentView: constants.DefaultView}
+	m.handleInheritancePlanLoaded(inheritancePlanLoadedMsg{
+		store:    store,
+		plan:     &inheritance.Plan{},
+		interval: 90 * 24 * time.Hour,
+		appDir:   t.TempDir(),
+	})
+	return m, store
+}
+
+func typeText(m *CLIModel, text string) {
+	for _, r := range text {
+		_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestBackupReminder_ShownUntilVerified(t *testing.T) {
+	m, store := newInheritanceModel(t)
+	assert.Contains(t, m.getContentView(), localization.Labels["backup_reminder_due"])
+
+	m.initInheritanceView()
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+
+	saved, err := store.Load()
+	require.NoError(t, err)
+	assert.NotNil(t, saved.LastVerifiedAt)
+
+	m.currentView = constants.DefaultView
+	assert.NotContains(t, m.getContentView(), localization.Labels["backup_reminder_due"])
+}
+
+func TestInheritance_AddHolderAndToggleDelivered(t *testing.T) {
+	m, store := newInheritanceModel(t)
+	m.initInheritanceView()
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	require.Equal(t, inheritanceModeAddHolder, m.inheritanceMode)
+	// Letters such as "q" are typed into the form instead of quitting
+	typeText(m, "Quinn")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(m, "bank vault")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.Equal(t, inheritanceModeOverview, m.inheritanceMode)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+
+	saved, err := store.Load()
+	require.NoError(t, err)
+	require.Len(t, saved.Holders, 1)
+	assert.Equal(t, "Quinn", saved.Holders[0].Name)
+	assert.Equal(t, "bank vault", saved.Holders[0].Contact)
+	assert.True(t, saved.Holders[0].Delivered)
+	assert.Contains(t, m.viewInheritance(), "1 of 1 delivered")
+}
+
+func TestInheritance_EscClosesFormFirst(t *testing.T) {
+	m, _ := newInheritanceModel(t)
+	m.initInheritanceView()
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	require.Equal(t, inheritanceModeGenerate, m.inheritanceMode)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.InheritanceView, m.currentView)
+	assert.Equal(t, inheritanceModeOverview, m.inheritanceMode)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.DefaultView, m.currentView)
+}
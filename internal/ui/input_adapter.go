@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"runtime"
+	"strings"
+	"unicode"
+
+	"blocowallet/pkg/logger"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// archDetector allows tests to mock architecture; defaults to runtime.GOARCH
+var archDetector = func() string { return runtime.GOARCH }
+
+// needsRuneFallback reports whether text inputs must be edited manually.
+// On some ARM64 terminals Bubble Tea delivers rune and backspace events that
+// textinput.Model fails to echo, so the edit is applied by hand instead.
+func needsRuneFallback() bool {
+	return archDetector() == "arm64"
+}
+
+// updateTextInput is the single entry point for feeding messages to a
+// textinput.Model. Every text field in the UI goes through it so the ARM64
+// fallback behaves the same for passwords, mnemonic words and file paths.
+func updateTextInput(input textinput.Model, msg tea.Msg) (textinput.Model, tea.Cmd) {
+	if input.Focused() && rejectsPaste(input, msg) {
+		return input, func() tea.Msg { return pasteRejectedMsg{} }
+	}
+	if text, ok := pastedText(msg); ok && input.EchoMode != textinput.EchoNormal {
+		// Drop the trailing line break copied along with the password
+		keyMsg := msg.(tea.KeyMsg)
+		keyMsg.Runes = []rune(strings.TrimRight(text, "\r\n"))
+		msg = keyMsg
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !input.Focused() || !needsRuneFallback() {
+		return input.Update(msg)
+	}
+
+	switch {
+	case keyMsg.Type == tea.KeyBackspace:
+		applyBackspace(&input)
+		logFallbackEdit(input, keyMsg)
+		return input, nil
+	case keyMsg.Type == tea.KeyRunes && !keyMsg.Paste:
+		if inserted := insertRunes(&input, keyMsg.Runes); inserted {
+			logFallbackEdit(input, keyMsg)
+			return input, nil
+		}
+	}
+
+	// Anything else (navigation, deletion shortcuts, paste) uses the default handling
+	return input.Update(msg)
+}
+
+// applyBackspace removes the rune before the cursor
+func applyBackspace(input *textinput.Model) {
+	value := []rune(input.Value())
+	pos := input.Position()
+	if pos <= 0 || len(value) == 0 {
+		return
+	}
+	if pos > len(value) {
+		pos = len(value)
+	}
+	input.SetValue(string(append(value[:pos-1:pos-1], value[pos:]...)))
+	input.SetCursor(pos - 1)
+}
+
+// insertRunes inserts printable runes at the cursor, honoring CharLimit.
+// It returns false when nothing could be inserted.
+func insertRunes(input *textinput.Model, runes []rune) bool {
+	filtered := make([]rune, 0, len(runes))
+	for _, r := range runes {
+		if r == '\n' || r == '\r' || r == '\t' {
+			continue
+		}
+		if unicode.IsPrint(r) || unicode.IsSpace(r) {
+			filtered = append(filtered, r)
+		}
+	}
+	if len(filtered) == 0 {
+		return false
+	}
+
+	value := []rune(input.Value())
+	if input.CharLimit > 0 {
+		room := input.CharLimit - len(value)
+		if room <= 0 {
+			return true
+		}
+		if len(filtered) > room {
+			filtered = filtered[:room]
+		}
+	}
+
+	pos := input.Position()
+	if pos > len(value) {
+		pos = len(value)
+	}
+	newValue := make([]rune, 0, len(value)+len(filtered))
+	newValue = append(newValue, value[:pos]...)
+	newValue = append(newValue, filtered...)
+	newValue = append(newValue, value[pos:]...)
+
+	input.SetValue(string(newValue))
+	input.SetCursor(pos + len(filtered))
+	return true
+}
+
+// logFallbackEdit records fallback edits without ever logging the field contents
+func logFallbackEdit(input textinput.Model, keyMsg tea.KeyMsg) {
+	if uiLogger == nil {
+		return
+	}
+	uiLogger.Debug("input_key_arm64_fallback",
+		logger.String("key_type", keyMsg.Type.String()),
+		logger.Int("runes", len(keyMsg.Runes)),
+		logger.Int("length", len([]rune(input.Value()))),
+	)
+}
+
+// --- helpers: rune-safe string operations ---
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if n < 0 {
+		return ""
+	}
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
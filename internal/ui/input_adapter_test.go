@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func withArch(t *testing.T, arch string) {
+	t.Helper()
+	old := archDetector
+	archDetector = func() string { return arch }
+	t.Cleanup(func() { archDetector = old })
+}
+
+func focusedInput() textinput.Model {
+	ti := textinput.New()
+	ti.Focus()
+	return ti
+}
+
+func typeRunes(ti textinput.Model, s string) textinput.Model {
+	for _, r := range s {
+		ti, _ = updateTextInput(ti, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return ti
+}
+
+func TestArchDetector_DefaultsToRuntime(t *testing.T) {
+	assert.Equal(t, runtime.GOARCH, archDetector())
+	assert.Equal(t, runtime.GOARCH == "arm64", needsRuneFallback())
+}
+
+func TestUpdateTextInput_TypingAndBackspaceAcrossArchitectures(t *testing.T) {
+	for _, arch := range []string{"amd64", "arm64"} {
+		t.Run(arch, func(t *testing.T) {
+			withArch(t, arch)
+
+			ti := typeRunes(focusedInput(), "abc")
+			assert.Equal(t, "abc", ti.Value())
+
+			ti, _ = updateTextInput(ti, tea.KeyMsg{Type: tea.KeyBackspace})
+			assert.Equal(t, "ab", ti.Value())
+			assert.Equal(t, 2, ti.Position())
+		})
+	}
+}
+
+func TestUpdateTextInput_ARM64InsertsAtCursor(t *testing.T) {
+	withArch(t, "arm64")
+
+	ti := typeRunes(focusedInput(), "ac")
+	ti.SetCursor(1)
+	ti = typeRunes(ti, "b")
+	assert.Equal(t, "abc", ti.Value())
+	assert.Equal(t, 2, ti.Position())
+
+	ti, _ = updateTextInput(ti, tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.Equal(t, "ac", ti.Value())
+	assert.Equal(t, 1, ti.Position())
+}
+
+func TestUpdateTextInput_ARM64HonorsCharLimitAndFiltersControlRunes(t *testing.T) {
+	withArch(t, "arm64")
+
+	ti := focusedInput()
+	ti.CharLimit = 4
+	ti, _ = updateTextInput(ti, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a\tb\nc\rdef")})
+	assert.Equal(t, "abcd", ti.Value())
+
+	ti = typeRunes(ti, "z")
+	assert.Equal(t, "abcd", ti.Value())
+}
+
+func TestUpdateTextInput_ARM64PasswordField(t *testing.T) {
+	withArch(t, "arm64")
+
+	ti := focusedInput()
+	ti.EchoMode = textinput.EchoPassword
+	ti = typeRunes(ti, "Sécret1!")
+	assert.Equal(t, "Sécret1!", ti.Value())
+}
+
+func TestUpdateTextInput_IgnoresUnfocusedInput(t *testing.T) {
+	withArch(t, "arm64")
+
+	ti := textinput.New()
+	ti = typeRunes(ti, "abc")
+	assert.Empty(t, ti.Value())
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"blocowallet/internal/compliance"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"blocowallet/pkg/logger"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openIntegrityWarning shows why a wallet failed the integrity check on load
+func (m *CLIModel) openIntegrityWarning(issue *wallet.IntegrityError) {
+	if uiLogger != nil {
+		uiLogger.Error("Wallet failed the integrity check",
+			logger.String("address", issue.Wallet.Address),
+			logger.String("problems", strings.Join(issue.Problems, "; ")))
+	}
+	m.integrityIssue = issue
+	m.integrityStatus = ""
+	m.integrityFailed = false
+	m.currentView = constants.WalletIntegrityView
+}
+
+// updateWalletIntegrity quarantines the wallet on "x" or goes back to the list
+func (m *CLIModel) updateWalletIntegrity(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "x":
+		if m.integrityIssue == nil || (m.integrityStatus != "" && !m.integrityFailed) {
+			return m, nil
+		}
+		issue := m.integrityIssue
+		var quarantineDir string
+		// Quarantine removes the wallet from the list, so compliance mode treats it as a deletion
+		return m, m.withApproval(approvedAction{
+			action:  compliance.ActionDeleteWallet,
+			subject: issue.Wallet.Address,
+			run: func() error {
+				var err error
+				quarantineDir, err = m.Service.QuarantineWallet(issue.Wallet, issue.Problems)
+				return err
+			},
+			done: func(err error) tea.Cmd {
+				if err != nil {
+					m.integrityStatus = fmt.Sprintf(localization.Labels["integrity_quarantine_failed"], err)
+					m.integrityFailed = true
+					return nil
+				}
+				m.integrityStatus = fmt.Sprintf(localization.Labels["integrity_quarantined"], quarantineDir)
+				m.integrityFailed = false
+				return m.refreshWalletsTable()
+			},
+		})
+	case "enter":
+		m.closeIntegrityWarning()
+		return m, m.refreshWalletsTable()
+	}
+	return m, nil
+}
+
+// closeIntegrityWarning returns to the wallet list
+func (m *CLIModel) closeIntegrityWarning() {
+	m.integrityIssue = nil
+	m.integrityStatus = ""
+	m.integrityFailed = false
+	m.currentView = constants.ListWalletsView
+}
+
+// viewWalletIntegrity explains which addresses diverge and offers the quarantine
+func (m *CLIModel) viewWalletIntegrity() string {
+	if m.integrityIssue == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(m.styles.ErrorStyle.Render(glyphs.Warning + " " + localization.Labels["integrity_title"]))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["integrity_desc"], m.integrityIssue.Wallet.Name, wallet.ChecksumAddress(m.integrityIssue.Wallet.Address)))
+	b.WriteString("\n\n")
+	for _, problem := range m.integrityIssue.Problems {
+		b.WriteString(fmt.Sprintf("  %s %s\n", glyphs.Bullet, problem))
+	}
+	b.WriteString("\n")
+	b.WriteString(localization.Labels["integrity_advice"])
+	b.WriteString("\n")
+
+	if m.integrityStatus != "" {
+		b.WriteString("\n")
+		if m.integrityFailed {
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.integrityStatus))
+		} else {
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.integrityStatus))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(m.renderApprovalNotice())
+	b.WriteString("\n")
+
+	help := localization.Labels["integrity_help"]
+	if m.integrityStatus != "" && !m.integrityFailed {
+		help = localization.Labels["integrity_help_back"]
+	}
+	b.WriteString(m.styles.MenuDesc.Render(help))
+	return b.String()
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrityWarning_QuarantinesWallet(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddIntegrityMessages()
+
+	dir := t.TempDir()
+	repo, err := storage.NewWalletRepository(&config.Config{AppDir: dir, DatabasePath: filepath.Join(dir, "wallets.db")})
+	require.NoError(t, err)
+	defer repo.Close()
+
+	keystorePath := filepath.Join(dir, "keystore", "0xABC.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(keystorePath), 0700))
+	require.NoError(t, os.WriteFile(keystorePath, []byte(`{"address":"abc"}`), 0600))
+	w := &wallet.Wallet{Name: "Suspect", Address: "0xABC", KeyStorePath: keystorePath, ImportMethod: "keystore", SourceHash: "suspect"}
+	require.NoError(t, repo.AddWallet(w))
+
+	m := &CLIModel{Service: &wallet.WalletService{Repo: repo}, styles: createStyles()}
+	m.openIntegrityWarning(&wallet.IntegrityError{Wallet: w, Problems: []string{"the keystore file declares 0xDEAD"}})
+	assert.Equal(t, constants.WalletIntegrityView, m.currentView)
+	assert.Contains(t, m.viewWalletIntegrity(), "0xDEAD")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	assert.False(t, m.integrityFailed, m.integrityStatus)
+	assert.Contains(t, m.viewWalletIntegrity(), filepath.Join(dir, wallet.QuarantineDirName))
+	assert.NoFileExists(t, keystorePath)
+
+	wallets, err := repo.GetAllWallets()
+	require.NoError(t, err)
+	assert.Empty(t, wallets)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.ListWalletsView, m.currentView)
+}
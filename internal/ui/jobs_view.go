@@ -0,0 +1,207 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/internal/jobs"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-errors/errors"
+)
+
+// jobsRefreshInterval controls how often the Jobs view re-renders while open
+const jobsRefreshInterval = 500 * time.Millisecond
+
+func init() {
+	registerKeyMap(constants.JobsView, jobsKeys)
+}
+
+// jobsTickMsg triggers a redraw of the Jobs view
+type jobsTickMsg struct{}
+
+func jobsTickCmd() tea.Cmd {
+	return tea.Tick(jobsRefreshInterval, func(time.Time) tea.Msg {
+		return jobsTickMsg{}
+	})
+}
+
+// initJobsView opens the Jobs view and starts its refresh ticker
+func (m *CLIModel) initJobsView() tea.Cmd {
+	m.selectedJob = 0
+	m.currentView = constants.JobsView
+	return jobsTickCmd()
+}
+
+// updateJobs handles input on the Jobs view
+func (m *CLIModel) updateJobs(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	list := m.jobManager.List()
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.selectedJob > 0 {
+			m.selectedJob--
+		}
+	case "down", "j":
+		if m.selectedJob < len(list)-1 {
+			m.selectedJob++
+		}
+	case "c":
+		if m.selectedJob < len(list) {
+			if err := m.jobManager.Cancel(list[m.selectedJob].ID); err != nil {
+				m.err = errors.Wrap(err, 0)
+			}
+		}
+	case "x":
+		m.jobManager.ClearFinished()
+		m.selectedJob = 0
+	}
+	return m, nil
+}
+
+// jobsKeys are the keys of the Jobs view; with no jobs there is nothing to select
+func jobsKeys(m *CLIModel) []key.Binding {
+	hasJobs := m.jobManager != nil && len(m.jobManager.List()) > 0
+	return []key.Binding{
+		bindingIf(hasJobs, newBinding("↑/↓", "key_move", "up", "k", "down", "j")),
+		bindingIf(hasJobs, newBinding("c", "key_cancel_job", "c")),
+		bindingIf(hasJobs, newBinding("x", "key_clear_finished", "x")),
+	}
+}
+
+// viewJobs renders the list of background jobs with their progress
+func (m *CLIModel) viewJobs() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["jobs_title"]))
+	b.WriteString("\n\n")
+
+	list := m.jobManager.List()
+	if len(list) == 0 {
+		b.WriteString(localization.Labels["jobs_empty"])
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	if m.selectedJob >= len(list) {
+		m.selectedJob = len(list) - 1
+	}
+
+	header := fmt.Sprintf("  %-28s %-12s %-22s %s",
+		localization.Labels["job_column_name"],
+		localization.Labels["job_column_status"],
+		localization.Labels["job_column_progress"],
+		localization.Labels["job_column_message"],
+	)
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(header))
+	b.WriteString("\n")
+
+	for i, job := range list {
+		cursor := "  "
+		if i == m.selectedJob {
+			cursor = "> "
+		}
+		details := job.Message
+		if job.Err != nil {
+			details = job.Err.Error()
+		}
+		line := fmt.Sprintf("%s%-28s %-12s %-22s %s",
+			cursor,
+			truncateRunes(jobDisplayName(job), 28),
+			jobStatusLabel(job.Status),
+			renderJobProgressBar(job.Progress, 14),
+			truncateRunes(details, 60),
+		)
+		if i == m.selectedJob {
+			line = m.styles.SelectedTitle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func jobDisplayName(job jobs.Job) string {
+	if job.Name != "" {
+		return job.Name
+	}
+	if label, ok := localization.Labels["job_kind_"+string(job.Kind)]; ok {
+		return label
+	}
+	return string(job.Kind)
+}
+
+func jobStatusLabel(status jobs.Status) string {
+	if label, ok := localization.Labels["job_status_"+string(status)]; ok {
+		return label
+	}
+	return string(status)
+}
+
+func renderJobProgressBar(progress float64, width int) string {
+	filled := int(progress * float64(width))
+	if filled > width {
+		filled = width
+	}
+	return fmt.Sprintf("[%s%s] %3.0f%%", strings.Repeat(glyphs.BarFull, filled), strings.Repeat(glyphs.BarEmpty, width-filled), progress*100)
+}
+
+// startImportBatchJob runs the enhanced batch import through the job manager so
+// it shows up in the Jobs view and a second import cannot start concurrently
+func (m *CLIModel) startImportBatchJob() (tea.Cmd, error) {
+	state := m.enhancedImportState
+	resultChan := make(chan []wallet.ImportResult, 1)
+
+	name := fmt.Sprintf("%s (%d)", localization.Labels["job_kind_batch_import"], len(state.ImportJobs))
+	id, err := m.jobManager.Submit(jobs.KindBatchImport, name, func(ctx context.Context, report jobs.ProgressFunc) error {
+		done := make(chan []wallet.ImportResult, 1)
+		go func() {
+			done <- state.BatchService.ImportBatch(
+				state.ImportJobs,
+				state.progressChan,
+				state.passwordRequestChan,
+				state.passwordResponseChan,
+			)
+		}()
+
+		select {
+		case results := <-done:
+			resultChan <- results
+			return nil
+		case <-ctx.Done():
+			_ = state.CancelImport()
+			// The batch service has no cancellation hook; wait for it to drain so a
+			// new import can't overlap with this one
+			resultChan <- <-done
+			return ctx.Err()
+		}
+	})
+	if err != nil {
+		if errors.Is(err, jobs.ErrConflict) {
+			return nil, fmt.Errorf("%s", localization.Labels["job_conflict"])
+		}
+		return nil, err
+	}
+	m.importJobID = id
+
+	return func() tea.Msg {
+		return ImportBatchCompleteMsg{Results: <-resultChan}
+	}, nil
+}
+
+// reportImportJobProgress mirrors batch import progress into the job manager
+func (m *CLIModel) reportImportJobProgress(progress wallet.ImportProgress) {
+	if m.importJobID == "" {
+		return
+	}
+	m.jobManager.Report(m.importJobID, progress.Percentage/100, progress.CurrentFile)
+}
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"context"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/jobs"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobsView_CancelSelectedJob(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddJobMessages()
+
+	m := &CLIModel{
+		styles:     createStyles(),
+		jobManager: jobs.NewManager(),
+	}
+	cmd := m.initJobsView()
+	assert.NotNil(t, cmd)
+	assert.Equal(t, constants.JobsView, m.currentView)
+	assert.Contains(t, m.viewJobs(), localization.Labels["jobs_empty"])
+
+	id, err := m.jobManager.Submit(jobs.KindHistorySync, "sync", func(ctx context.Context, report jobs.ProgressFunc) error {
+		report(0.25, "block 100")
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.NoError(t, err)
+
+	m.updateJobs(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+
+	job, err := m.jobManager.Wait(id)
+	require.NoError(t, err)
+	assert.Equal(t, jobs.StatusCancelled, job.Status)
+	assert.Contains(t, m.viewJobs(), "sync")
+
+	m.updateJobs(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	assert.Empty(t, m.jobManager.List())
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/platform"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+	"blocowallet/pkg/logger"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-errors/errors"
+)
+
+// kdfTickInterval is how often the elapsed time and CPU activity are refreshed
+const kdfTickInterval = 500 * time.Millisecond
+
+// kdfIdleAfter is how long the process may go without using CPU before the
+// operation is reported as possibly hung instead of computing
+const kdfIdleAfter = 3 * time.Second
+
+// kdfKind tells what a key derivation is for
+type kdfKind int
+
+const (
+	kdfUnlock kdfKind = iota
+	kdfImport
+)
+
+// kdfOperation is an unlock or import deriving its key in the background.
+// scrypt gives no progress, so the heartbeat watches the CPU time of the
+// process: while it grows the derivation is computing, when it stops the
+// operation is waiting on something and may be hung.
+type kdfOperation struct {
+	id      int
+	kind    kdfKind
+	started time.Time
+	cpu     time.Duration // CPU time of the process at the last tick
+	active  time.Time     // Last tick at which the CPU time had grown
+	finish  func(*wallet.WalletDetails, error) tea.Cmd
+}
+
+// kdfTickMsg refreshes the heartbeat of operation id
+type kdfTickMsg struct {
+	id int
+	at time.Time
+}
+
+// kdfDoneMsg carries the result of operation id
+type kdfDoneMsg struct {
+	id      int
+	kind    kdfKind
+	details *wallet.WalletDetails
+	err     error
+}
+
+func kdfTickCmd(id int) tea.Cmd {
+	return tea.Tick(kdfTickInterval, func(t time.Time) tea.Msg {
+		return kdfTickMsg{id: id, at: t}
+	})
+}
+
+// startKDF runs an unlock or import off the UI thread and shows its
+// heartbeat until finish handles the result
+func (m *CLIModel) startKDF(kind kdfKind, run func() (*wallet.WalletDetails, error), finish func(*wallet.WalletDetails, error) tea.Cmd) tea.Cmd {
+	m.kdfSeq++
+	now := time.Now()
+	op := &kdfOperation{id: m.kdfSeq, kind: kind, started: now, active: now, finish: finish}
+	op.cpu, _ = platform.ProcessCPUTime()
+	m.kdfOp = op
+	id := op.id
+	return tea.Batch(
+		func() tea.Msg {
+			details, err := run()
+			return kdfDoneMsg{id: id, kind: kind, details: details, err: err}
+		},
+		kdfTickCmd(id),
+	)
+}
+
+// kdfStallLimit is how long an operation runs before it can be abandoned
+func (m *CLIModel) kdfStallLimit() time.Duration {
+	if m.currentConfig != nil && m.currentConfig.Operations.StallSeconds > 0 {
+		return time.Duration(m.currentConfig.Operations.StallSeconds) * time.Second
+	}
+	return config.DefaultStallSeconds * time.Second
+}
+
+// handleKDFTick samples the CPU time of the process
+func (m *CLIModel) handleKDFTick(msg kdfTickMsg) tea.Cmd {
+	op := m.kdfOp
+	if op == nil || op.id != msg.id {
+		return nil
+	}
+	cpu, err := platform.ProcessCPUTime()
+	if err != nil || cpu > op.cpu {
+		// Without a measurement the operation is not reported as hung
+		op.cpu = cpu
+		op.active = msg.at
+	}
+	return kdfTickCmd(op.id)
+}
+
+// handleKDFDone hands the result to the operation that is still awaited.
+// An abandoned import may still have added its wallet, so the list is reloaded.
+func (m *CLIModel) handleKDFDone(msg kdfDoneMsg) tea.Cmd {
+	op := m.kdfOp
+	if op == nil || op.id != msg.id {
+		if msg.kind == kdfImport && msg.err == nil {
+			return m.refreshWalletsTable()
+		}
+		return nil
+	}
+	m.kdfOp = nil
+	return op.finish(msg.details, msg.err)
+}
+
+// handleKDFKey ignores keys while the key is derived, except esc once the
+// stall limit is reached, which stops waiting for the operation
+func (m *CLIModel) handleKDFKey(msg tea.KeyMsg) {
+	op := m.kdfOp
+	elapsed := time.Since(op.started)
+	if msg.String() != "esc" || elapsed < m.kdfStallLimit() {
+		return
+	}
+	m.kdfOp = nil
+	if uiLogger != nil {
+		uiLogger.Warn("Abandoned a stalled key derivation", logger.String("elapsed", elapsed.String()))
+	}
+	label := "kdf_aborted"
+	if op.kind == kdfImport {
+		label = "kdf_aborted_import"
+	}
+	m.err = errors.Wrap(fmt.Errorf(localization.Labels[label], elapsed.Round(time.Second)), 0)
+	m.currentView = constants.DefaultView
+}
+
+// viewKDFStatus shows the elapsed time, whether the process is still
+// computing and, past the stall limit, how to stop waiting
+func (m *CLIModel) viewKDFStatus() string {
+	op := m.kdfOp
+	title := localization.Labels["kdf_unlocking"]
+	if op.kind == kdfImport {
+		title = localization.Labels["kdf_importing"]
+	}
+	elapsed := time.Since(op.started)
+
+	var view strings.Builder
+	view.WriteString(lipgloss.NewStyle().Bold(true).Render(title) + "\n\n")
+	view.WriteString(fmt.Sprintf(localization.Labels["kdf_elapsed"], elapsed.Truncate(time.Second)) + "\n")
+	if idle := time.Since(op.active); idle >= kdfIdleAfter {
+		view.WriteString(m.styles.ErrorStyle.Render(fmt.Sprintf(localization.Labels["kdf_idle"], idle.Truncate(time.Second))))
+	} else {
+		view.WriteString(localization.Labels["kdf_computing"])
+	}
+	if limit := m.kdfStallLimit(); elapsed >= limit {
+		view.WriteString("\n\n" + m.styles.ErrorStyle.Render(fmt.Sprintf(localization.Labels["kdf_stalled"], limit)) + "\n")
+		view.WriteString(localization.Labels["kdf_abort_help"])
+	}
+	return view.String()
+}
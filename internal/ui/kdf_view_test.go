@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKDF_DeliversResultToFinish(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddOperationMessages()
+
+	m := &CLIModel{styles: createStyles(), currentView: constants.WalletPasswordView}
+	want := &wallet.WalletDetails{Wallet: &wallet.Wallet{Name: "Main"}}
+	var got *wallet.WalletDetails
+	cmd := m.startKDF(kdfUnlock, func() (*wallet.WalletDetails, error) {
+		return want, nil
+	}, func(details *wallet.WalletDetails, err error) tea.Cmd {
+		got = details
+		return nil
+	})
+	require.NotNil(t, m.kdfOp)
+	assert.Contains(t, m.viewWalletPassword(), "Unlocking wallet")
+
+	// Typing does not reach the password input while the key is derived
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	assert.NotNil(t, m.kdfOp)
+
+	batch, ok := cmd().(tea.BatchMsg)
+	require.True(t, ok)
+	_, _ = m.Update(batch[0]())
+	assert.Same(t, want, got)
+	assert.Nil(t, m.kdfOp)
+}
+
+func TestKDF_AbortAfterStallLimit(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddOperationMessages()
+
+	m := &CLIModel{
+		styles:        createStyles(),
+		currentView:   constants.ImportWalletPasswordView,
+		currentConfig: &config.Config{Operations: config.OperationsConfig{StallSeconds: 10}},
+	}
+	finished := false
+	m.startKDF(kdfImport, func() (*wallet.WalletDetails, error) {
+		return nil, errors.New("unused")
+	}, func(*wallet.WalletDetails, error) tea.Cmd {
+		finished = true
+		return nil
+	})
+	op := m.kdfOp
+	assert.Contains(t, m.viewImportWalletPassword(), "Still deriving the key")
+
+	// esc is ignored before the stall limit
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.ImportWalletPasswordView, m.currentView)
+	assert.NotNil(t, m.kdfOp)
+
+	op.started = time.Now().Add(-15 * time.Second)
+	op.active = time.Now().Add(-5 * time.Second)
+	view := m.viewImportWalletPassword()
+	assert.Contains(t, view, "may be hung")
+	assert.Contains(t, view, "longer than 10s")
+	assert.Contains(t, view, "esc: stop waiting")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, m.kdfOp)
+	assert.Equal(t, constants.DefaultView, m.currentView)
+	require.Error(t, m.err)
+	assert.Contains(t, m.err.Error(), "Stopped waiting after 15s")
+
+	// A result arriving after the abort is not handed to finish
+	m.handleKDFDone(kdfDoneMsg{id: op.id, kind: kdfUnlock})
+	assert.False(t, finished)
+}
+
+func TestKDF_TickTracksCPUActivity(t *testing.T) {
+	m := &CLIModel{}
+	m.startKDF(kdfUnlock, func() (*wallet.WalletDetails, error) { return nil, nil }, func(*wallet.WalletDetails, error) tea.Cmd { return nil })
+	op := m.kdfOp
+	op.cpu = 0
+	at := time.Now().Add(time.Hour)
+
+	assert.NotNil(t, m.handleKDFTick(kdfTickMsg{id: op.id, at: at}))
+	assert.Equal(t, at, op.active)
+
+	// Ticks of an operation no longer awaited stop
+	assert.Nil(t, m.handleKDFTick(kdfTickMsg{id: op.id + 1, at: at}))
+}
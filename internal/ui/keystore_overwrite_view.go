@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openKeystoreOverwrite shows how the keystore being imported differs from the
+// managed one it would replace; retry runs the import again once confirmed
+func (m *CLIModel) openKeystoreOverwrite(overwrite *wallet.KeystoreOverwriteError, retry func() tea.Cmd) {
+	m.keystoreOverwrite = overwrite
+	m.keystoreOverwriteRetry = retry
+	m.currentView = constants.KeystoreOverwriteView
+}
+
+// closeKeystoreOverwrite keeps the managed keystore and returns to the menu
+func (m *CLIModel) closeKeystoreOverwrite() {
+	m.keystoreOverwrite = nil
+	m.keystoreOverwriteRetry = nil
+	m.menuItems = m.mainMenu()
+	m.selectedMenu = 0
+	m.currentView = constants.DefaultView
+}
+
+// updateKeystoreOverwrite replaces the keystore on "y"; "n" keeps it
+func (m *CLIModel) updateKeystoreOverwrite(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.keystoreOverwrite == nil {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "y", "Y":
+		m.Service.ApproveKeystoreOverwrite(m.keystoreOverwrite)
+		retry := m.keystoreOverwriteRetry
+		m.keystoreOverwrite = nil
+		m.keystoreOverwriteRetry = nil
+		m.currentView = constants.ImportWalletPasswordView
+		return m, retry()
+	case "n", "N":
+		m.closeKeystoreOverwrite()
+	}
+	return m, nil
+}
+
+// viewKeystoreOverwrite lists the structural changes, weaker ones first in red
+func (m *CLIModel) viewKeystoreOverwrite() string {
+	overwrite := m.keystoreOverwrite
+	if overwrite == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(m.styles.ErrorStyle.Render(glyphs.Warning + " " + localization.Labels["overwrite_title"]))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["overwrite_desc"], overwrite.Address, overwrite.Path))
+	b.WriteString("\n\n")
+
+	width := len(localization.Labels["overwrite_field"])
+	for _, c := range overwrite.Changes {
+		width = max(width, len(c.Field))
+	}
+	row := func(field, current, replacement string) string {
+		return fmt.Sprintf("  %-*s  %-20s  %s", width, field, current, replacement)
+	}
+	b.WriteString(m.styles.MenuDesc.Render(row(localization.Labels["overwrite_field"], localization.Labels["overwrite_current"], localization.Labels["overwrite_new"])))
+	b.WriteString("\n")
+	if len(overwrite.Changes) == 0 {
+		b.WriteString("  " + localization.Labels["overwrite_no_changes"] + "\n")
+	}
+	for _, c := range overwrite.Changes {
+		line := row(c.Field, c.Old, c.New)
+		if c.Weaker {
+			line = m.styles.ErrorStyle.Render(line + "  " + glyphs.Warning)
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+
+	if overwrite.Weaker() {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render(m.styles.ErrorStyle.Render(localization.Labels["overwrite_weaker"])))
+		b.WriteString("\n\n")
+	}
+	b.WriteString(m.styles.MenuDesc.Render(localization.Labels["overwrite_help"]))
+	return b.String()
+}
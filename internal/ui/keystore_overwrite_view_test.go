@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeystoreOverwrite_Confirm(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddOverwriteMessages()
+
+	overwrite := &wallet.KeystoreOverwriteError{
+		Path:    "/keystore/0xABC.json",
+		Address: "0xABC",
+		Changes: []wallet.KeystoreChange{{Field: "kdfparams.n", Old: "262144", New: "1024", Weaker: true}},
+	}
+	retried := 0
+	retry := func() tea.Cmd {
+		retried++
+		return nil
+	}
+	m := &CLIModel{Service: &wallet.WalletService{}, styles: createStyles()}
+
+	m.openKeystoreOverwrite(overwrite, retry)
+	assert.Equal(t, constants.KeystoreOverwriteView, m.currentView)
+	view := m.viewKeystoreOverwrite()
+	assert.Contains(t, view, "kdfparams.n")
+	assert.Contains(t, view, "262144")
+	assert.Contains(t, view, localization.Labels["overwrite_weaker"])
+
+	// Keeping the current keystore does not run the import again
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	assert.Equal(t, constants.DefaultView, m.currentView)
+	assert.Zero(t, retried)
+	assert.Nil(t, m.keystoreOverwrite)
+
+	m.openKeystoreOverwrite(overwrite, retry)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	assert.Equal(t, 1, retried)
+	assert.Equal(t, constants.ImportWalletPasswordView, m.currentView)
+	assert.Nil(t, m.keystoreOverwrite)
+}
@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PastedKeystoreImporter is implemented by batch services that import a
+// keystore pasted as JSON instead of read from a file
+type PastedKeystoreImporter interface {
+	ImportKeystoreJSON(name string, keyJSON []byte, password string) wallet.ImportResult
+}
+
+type pasteFocus int
+
+const (
+	pasteFocusJSON pasteFocus = iota
+	pasteFocusName
+	pasteFocusPassword
+	pasteFocusCount
+)
+
+// keystorePaste holds the fields of the paste mode of the file selection
+type keystorePaste struct {
+	json         textarea.Model
+	name         textinput.Model
+	password     textinput.Model
+	focus        pasteFocus
+	errorMessage string
+}
+
+func newKeystorePaste() *keystorePaste {
+	p := &keystorePaste{
+		json:     textarea.New(),
+		name:     textinput.New(),
+		password: textinput.New(),
+	}
+	p.json.Placeholder = `{"version":3,"id":"…","address":"…","crypto":{…}}`
+	// A scrypt keystore is under 1 KB; leave room for pretty-printed ones
+	p.json.CharLimit = 16 * 1024
+	p.json.SetWidth(70)
+	p.json.SetHeight(8)
+	p.json.Focus()
+
+	p.name.Placeholder = localization.Labels["paste_name_placeholder"]
+	p.name.CharLimit = 100
+	p.name.Width = 50
+
+	p.password.Placeholder = localization.Labels["password_popup_placeholder"]
+	p.password.CharLimit = 256
+	p.password.Width = 50
+	p.password.EchoMode = textinput.EchoPassword
+	p.password.EchoCharacter = '•'
+	return p
+}
+
+// setFocus moves the cursor to field f
+func (p *keystorePaste) setFocus(f pasteFocus) {
+	p.focus = (f + pasteFocusCount) % pasteFocusCount
+	p.json.Blur()
+	p.name.Blur()
+	p.password.Blur()
+	switch p.focus {
+	case pasteFocusJSON:
+		p.json.Focus()
+	case pasteFocusName:
+		p.name.Focus()
+	case pasteFocusPassword:
+		p.password.Focus()
+	}
+}
+
+// CanPaste reports whether the batch service imports pasted keystores
+func (s *EnhancedImportState) CanPaste() bool {
+	_, ok := s.BatchService.(PastedKeystoreImporter)
+	return ok
+}
+
+// IsPasting reports whether the file selection shows the paste mode
+func (s *EnhancedImportState) IsPasting() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.Paste != nil
+}
+
+// OpenPaste switches the file selection to the paste mode
+func (s *EnhancedImportState) OpenPaste() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Phase == PhaseFileSelection && s.CanPaste() {
+		s.Paste = newKeystorePaste()
+	}
+}
+
+// ClosePaste returns to the file picker
+func (s *EnhancedImportState) ClosePaste() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Paste = nil
+}
+
+// updatePaste moves between the fields and imports on ctrl+s
+func (s *EnhancedImportState) updatePaste(msg tea.Msg) tea.Cmd {
+	p := s.Paste
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "tab":
+			p.setFocus(p.focus + 1)
+			return nil
+		case "shift+tab":
+			p.setFocus(p.focus - 1)
+			return nil
+		case "ctrl+s":
+			return s.submitPaste()
+		}
+	}
+
+	var cmd tea.Cmd
+	switch p.focus {
+	case pasteFocusJSON:
+		p.json, cmd = p.json.Update(msg)
+	case pasteFocusName:
+		p.name, cmd = updateTextInput(p.name, msg)
+	case pasteFocusPassword:
+		p.password, cmd = updateTextInput(p.password, msg)
+	}
+	return cmd
+}
+
+// submitPaste checks the pasted keystore and imports it off the UI thread;
+// the result completes the import like a batch of one file
+func (s *EnhancedImportState) submitPaste() tea.Cmd {
+	p := s.Paste
+	keyJSON := []byte(strings.TrimSpace(p.json.Value()))
+	validator := &wallet.KeystoreValidator{}
+	keystore, err := validator.ValidateKeystoreV3(keyJSON)
+	if err != nil {
+		p.errorMessage = err.Error()
+		var keystoreErr *wallet.KeystoreImportError
+		if errors.As(err, &keystoreErr) {
+			p.errorMessage = localization.FormatKeystoreErrorWithField(keystoreErr.GetLocalizedMessage(), keystoreErr.Field)
+		}
+		p.setFocus(pasteFocusJSON)
+		return nil
+	}
+	password := p.password.Value()
+	if password == "" {
+		p.errorMessage = localization.Labels["paste_password_required"]
+		p.setFocus(pasteFocusPassword)
+		return nil
+	}
+	name := strings.TrimSpace(p.name.Value())
+	if name == "" {
+		address := keystore.Address
+		if !strings.HasPrefix(address, "0x") {
+			address = "0x" + address
+		}
+		name = fmt.Sprintf(localization.Labels["paste_default_name"], shortHash(address))
+	}
+
+	importer := s.BatchService.(PastedKeystoreImporter)
+	s.mu.Lock()
+	s.Paste = nil
+	s.ImportJobs = []wallet.ImportJob{{KeystorePath: wallet.PastedKeystoreSource, WalletName: name}}
+	err = s.transitionToPhaseInternal(PhaseImporting)
+	s.mu.Unlock()
+	if err != nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return ImportBatchCompleteMsg{Results: []wallet.ImportResult{importer.ImportKeystoreJSON(name, keyJSON, password)}}
+	}
+}
+
+// appendRetryable adds the keystore of result to files; a pasted keystore has
+// no file to import again, so it is pasted again instead
+func appendRetryable(files []string, result wallet.ImportResult) []string {
+	if result.Job.KeystorePath == wallet.PastedKeystoreSource {
+		return files
+	}
+	return append(files, result.Job.KeystorePath)
+}
+
+// renderPaste shows the paste mode in place of the file picker
+func (s *EnhancedImportState) renderPaste() string {
+	p := s.Paste
+	label := func(text string, f pasteFocus) string {
+		style := lipgloss.NewStyle().Bold(true)
+		if p.focus == f {
+			style = style.Foreground(palette.Accent)
+		}
+		return style.Render(text)
+	}
+
+	sections := []string{
+		lipgloss.NewStyle().Bold(true).Foreground(palette.Accent).Render(localization.Labels["paste_title"]),
+		localization.Labels["paste_desc"],
+		"",
+		label(localization.Labels["paste_json"], pasteFocusJSON),
+		p.json.View(),
+		"",
+		label(localization.Labels["paste_name"], pasteFocusName),
+		p.name.View(),
+		"",
+		label(localization.Labels["paste_password"], pasteFocusPassword),
+		p.password.View(),
+	}
+	if p.errorMessage != "" {
+		sections = append(sections, "", lipgloss.NewStyle().Foreground(palette.Error).Render(p.errorMessage))
+	}
+	sections = append(sections, "", lipgloss.NewStyle().Foreground(palette.Muted).Render(localization.Labels["paste_help"]))
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"testing"
+
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pasteImportService records the keystore pasted into the enhanced import
+type pasteImportService struct {
+	MockBatchImportService
+	name     string
+	keyJSON  string
+	password string
+}
+
+func (p *pasteImportService) ImportKeystoreJSON(name string, keyJSON []byte, password string) wallet.ImportResult {
+	p.name, p.keyJSON, p.password = name, string(keyJSON), password
+	return wallet.ImportResult{Job: wallet.ImportJob{KeystorePath: wallet.PastedKeystoreSource, WalletName: name}, Success: true}
+}
+
+const pastedKeystoreJSON = `{"version":3,"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","address":"008aeeda4d805471df9b2a5b0f38a0c3bcba786b","crypto":{"cipher":"aes-128-ctr","ciphertext":"d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c","cipherparams":{"iv":"83dbcc02d8ccb40e466191a123791e0e"},"kdf":"scrypt","kdfparams":{"dklen":32,"n":262144,"p":8,"r":1,"salt":"ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},"mac":"2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"}}`
+
+func TestKeystorePaste(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddPasteImportMessages()
+	localization.AddImportCompletionMessages()
+
+	service := &pasteImportService{}
+	state := NewEnhancedImportState(service, createStyles())
+	require.True(t, state.CanPaste())
+	assert.Contains(t, state.View(), localization.Labels["paste_hint"])
+
+	state.OpenPaste()
+	require.True(t, state.IsPasting())
+	assert.Contains(t, state.View(), localization.Labels["paste_title"])
+
+	// The pasted text must be a keystore
+	state.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("not json"), Paste: true})
+	_, cmd := state.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	assert.Nil(t, cmd)
+	assert.NotEmpty(t, state.Paste.errorMessage)
+
+	// and the password is required
+	state.Paste.json.SetValue("\n" + pastedKeystoreJSON + "\n")
+	_, cmd = state.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	assert.Nil(t, cmd)
+	assert.Equal(t, localization.Labels["paste_password_required"], state.Paste.errorMessage)
+	assert.Equal(t, pasteFocusPassword, state.Paste.focus)
+
+	state.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("testpassword")})
+	_, cmd = state.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+	assert.False(t, state.IsPasting())
+	assert.Equal(t, PhaseImporting, state.GetCurrentPhase())
+
+	msg, ok := cmd().(ImportBatchCompleteMsg)
+	require.True(t, ok)
+	require.Len(t, msg.Results, 1)
+	assert.True(t, msg.Results[0].Success)
+	assert.Equal(t, pastedKeystoreJSON, service.keyJSON, "the surrounding line breaks are dropped")
+	assert.Equal(t, "testpassword", service.password)
+	assert.Contains(t, service.name, "Pasted keystore 0x008aeeda", "named after the address")
+
+	// A pasted keystore has no file to retry
+	assert.Empty(t, appendRetryable(nil, wallet.ImportResult{Job: wallet.ImportJob{KeystorePath: wallet.PastedKeystoreSource}}))
+}
+
+func TestKeystorePaste_NotOffered(t *testing.T) {
+	state := NewEnhancedImportState(&MockBatchImportService{}, createStyles())
+	assert.False(t, state.CanPaste())
+	state.OpenPaste()
+	assert.False(t, state.IsPasting())
+}
@@ -0,0 +1,230 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/pkg/localization"
+	"blocowallet/pkg/logger"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// logViewerLimit is how many of the last log entries the viewer keeps
+const logViewerLimit = 2000
+
+// logRefreshInterval is how often the viewer reads the log files again
+const logRefreshInterval = 2 * time.Second
+
+// logLevels are the minimum levels the viewer cycles through, by rank
+var logLevels = []string{"debug", "info", "warn", "error"}
+
+// logTickMsg reads the log files again while the viewer with the same
+// sequence number is open
+type logTickMsg struct{ seq int }
+
+func logTickCmd(seq int) tea.Cmd {
+	return tea.Tick(logRefreshInterval, func(time.Time) tea.Msg {
+		return logTickMsg{seq: seq}
+	})
+}
+
+// logDir is where the file logger writes, as set up in main
+func (m *CLIModel) logDir() string {
+	if m.currentConfig == nil {
+		if cfg, err := loadOrCreateConfig(); err == nil {
+			m.currentConfig = cfg
+		}
+	}
+	if m.currentConfig == nil {
+		return ""
+	}
+	return filepath.Join(m.currentConfig.AppDir, "logs")
+}
+
+// initLogViewer opens the log viewer from the configuration menu, following
+// the end of the logs at the configured level
+func (m *CLIModel) initLogViewer() tea.Cmd {
+	m.logMinLevel = logger.LevelRank("info")
+	if m.currentConfig != nil {
+		m.logMinLevel = logger.LevelRank(m.currentConfig.Logging.Level)
+	}
+	m.logScroll = 0
+	m.logSeq++
+	m.readLogs()
+	m.currentView = constants.LogViewerView
+	return logTickCmd(m.logSeq)
+}
+
+// closeLogViewer goes back to the configuration menu
+func (m *CLIModel) closeLogViewer() {
+	m.logSeq++ // Stops the refresh
+	m.logEntries = nil
+	m.initConfigMenu()
+}
+
+// readLogs reads the end of the log files again
+func (m *CLIModel) readLogs() {
+	dir := m.logDir()
+	if dir == "" {
+		m.logErr = fmt.Errorf("%s", localization.Labels["logs_no_config"])
+		return
+	}
+	entries, err := logger.ReadTail(dir, logViewerLimit)
+	m.logEntries, m.logErr = entries, err
+}
+
+// handleLogTick refreshes the viewer and schedules the next read
+func (m *CLIModel) handleLogTick(msg logTickMsg) tea.Cmd {
+	if msg.seq != m.logSeq || m.currentView != constants.LogViewerView {
+		return nil
+	}
+	m.readLogs()
+	return logTickCmd(m.logSeq)
+}
+
+// visibleLogEntries returns the entries at or above the chosen level
+func (m *CLIModel) visibleLogEntries() []logger.Entry {
+	var visible []logger.Entry
+	for _, entry := range m.logEntries {
+		if logger.LevelRank(entry.Level) >= m.logMinLevel {
+			visible = append(visible, entry)
+		}
+	}
+	return visible
+}
+
+func init() {
+	registerKeyMap(constants.LogViewerView, func(m *CLIModel) []key.Binding {
+		return []key.Binding{
+			newBinding("↑/↓", "key_scroll", "up", "k", "down", "j"),
+			newBinding("pgup/pgdn", "key_page", "pgup", "pgdown"),
+			newBinding("g/G", "key_oldest_newest", "home", "g", "end", "G"),
+			newBinding("l/tab", "key_level", "l", "tab", "shift+tab"),
+			newBinding("r", "key_refresh", "r"),
+		}
+	})
+}
+
+// logViewerRows is how many entries fit on the screen
+func (m *CLIModel) logViewerRows() int {
+	return max(m.height-16, 8)
+}
+
+// updateLogViewer scrolls the logs and changes the level filter. The scroll
+// counts entries back from the end, so new entries keep the view at the end
+// unless it was scrolled.
+func (m *CLIModel) updateLogViewer(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	rows := m.logViewerRows()
+	maxScroll := max(len(m.visibleLogEntries())-rows, 0)
+	switch keyMsg.String() {
+	case "up", "k":
+		m.logScroll = min(m.logScroll+1, maxScroll)
+	case "down", "j":
+		m.logScroll = max(m.logScroll-1, 0)
+	case "pgup":
+		m.logScroll = min(m.logScroll+rows, maxScroll)
+	case "pgdown":
+		m.logScroll = max(m.logScroll-rows, 0)
+	case "home", "g":
+		m.logScroll = maxScroll
+	case "end", "G":
+		m.logScroll = 0
+	case "l", "tab":
+		m.logMinLevel = (m.logMinLevel + 1) % len(logLevels)
+		m.logScroll = 0
+	case "shift+tab":
+		m.logMinLevel = (m.logMinLevel + len(logLevels) - 1) % len(logLevels)
+		m.logScroll = 0
+	case "r":
+		m.readLogs()
+	}
+	return m, nil
+}
+
+// viewLogViewer shows the end of the logs, newest at the bottom
+func (m *CLIModel) viewLogViewer() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["logs_title"]))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["logs_location"], homeRelative(m.logDir())))
+	b.WriteString("\n")
+
+	levels := make([]string, len(logLevels))
+	for i, level := range logLevels {
+		if i == m.logMinLevel {
+			levels[i] = m.styles.SelectedTitle.Render(level)
+		} else {
+			levels[i] = level
+		}
+	}
+	b.WriteString(fmt.Sprintf(localization.Labels["logs_level"], strings.Join(levels, " ")))
+	b.WriteString("\n\n")
+
+	visible := m.visibleLogEntries()
+	switch {
+	case m.logErr != nil:
+		b.WriteString(m.styles.ErrorStyle.Render(fmt.Sprintf(localization.Labels["logs_read_failed"], m.logErr)))
+		b.WriteString("\n")
+	case len(visible) == 0:
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["logs_empty"]))
+		b.WriteString("\n")
+	default:
+		rows := m.logViewerRows()
+		end := len(visible) - min(m.logScroll, len(visible))
+		start := max(end-rows, 0)
+		for _, entry := range visible[start:end] {
+			b.WriteString(m.renderLogEntry(entry))
+			b.WriteString("\n")
+		}
+		b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf(localization.Labels["logs_position"], start+1, end, len(visible))))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderLogEntry renders an entry on one line, colored by level
+func (m *CLIModel) renderLogEntry(entry logger.Entry) string {
+	if entry.Level == "" {
+		return truncateLogLine(entry.Raw, m.width)
+	}
+
+	var color lipgloss.Color
+	switch logger.LevelRank(entry.Level) {
+	case 0:
+		color = palette.Muted
+	case 2:
+		color = palette.Warning
+	case 3:
+		color = palette.Error
+	default:
+		color = palette.Info
+	}
+	line := entry.Time.Local().Format("01-02 15:04:05") + " " + fmt.Sprintf("%-5s", strings.ToUpper(entry.Level)) + " " + entry.Message
+	if entry.Fields != "" {
+		line += " " + entry.Fields
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(truncateLogLine(line, m.width))
+}
+
+// truncateLogLine keeps a line within the screen width
+func truncateLogLine(line string, width int) string {
+	limit := width - 6
+	if limit <= 0 {
+		limit = 120
+	}
+	runes := []rune(line)
+	if len(runes) <= limit {
+		return line
+	}
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+	"blocowallet/pkg/logger"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogViewer_FiltersByLevel(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddLogMessages()
+
+	appDir := t.TempDir()
+	lgr, err := logger.NewFileLogger(logger.LoggingConfig{LogDir: filepath.Join(appDir, "logs"), LogLevel: "debug"})
+	require.NoError(t, err)
+	lgr.Debug("debug-entry")
+	lgr.Info("info-entry", logger.String("wallet", "main"))
+	lgr.Error("error-entry")
+	require.NoError(t, lgr.Sync())
+
+	m := &CLIModel{
+		Service:       &wallet.WalletService{},
+		styles:        createStyles(),
+		currentView:   constants.ConfigurationView,
+		currentConfig: &config.Config{AppDir: appDir, Logging: config.LoggingConfig{Level: "info"}},
+		width:         120,
+		height:        40,
+	}
+	m.initConfigMenu()
+	m.selectedMenu = 5
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, constants.LogViewerView, m.currentView)
+	require.NotNil(t, cmd, "the logs are followed")
+
+	// The configured level is shown first
+	view := m.viewLogViewer()
+	assert.NotContains(t, view, "debug-entry")
+	assert.Contains(t, view, "info-entry")
+	assert.Contains(t, view, `{"wallet":"main"}`)
+	assert.Contains(t, view, "error-entry")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	view = m.viewLogViewer()
+	assert.NotContains(t, view, "info-entry")
+	assert.Contains(t, view, "error-entry")
+
+	// Wrapping around shows everything
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	assert.Contains(t, m.viewLogViewer(), "debug-entry")
+
+	// New entries appear on the next refresh; ticks of a closed viewer stop
+	lgr.Warn("warn-entry")
+	require.NoError(t, lgr.Sync())
+	assert.NotNil(t, m.handleLogTick(logTickMsg{seq: m.logSeq}))
+	assert.Contains(t, m.viewLogViewer(), "warn-entry")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.ConfigurationView, m.currentView)
+	assert.Nil(t, m.handleLogTick(logTickMsg{seq: m.logSeq - 1}))
+}
@@ -0,0 +1,294 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// masterModeFirstRun offers to set a master password after the splash
+	masterModeFirstRun = iota
+	// masterModeUnlock asks for the master password before the main menu
+	masterModeUnlock
+	// masterModeSet sets one from the configuration menu
+	masterModeSet
+	// masterModeChange changes the one in use from the configuration menu
+	masterModeChange
+)
+
+// masterPasswordDoneMsg carries the outcome of a master key derivation run
+// in the background
+type masterPasswordDoneMsg struct {
+	err error
+}
+
+// openMasterPasswordAtStart shows the first-run offer or the unlock screen
+// after the splash; it reports whether one was opened
+func (m *CLIModel) openMasterPasswordAtStart() bool {
+	if m.Service == nil {
+		return false
+	}
+	state, err := m.Service.MasterPasswordState()
+	if err != nil {
+		log.Println("Erro ao ler a senha mestra:", err)
+		return false
+	}
+	switch state {
+	case wallet.MasterPasswordUnset:
+		m.initMasterPasswordView(masterModeFirstRun)
+	case wallet.MasterPasswordLocked:
+		m.initMasterPasswordView(masterModeUnlock)
+	default:
+		return false
+	}
+	return true
+}
+
+// initMasterPasswordFromConfig opens the set or change screen from the
+// configuration menu
+func (m *CLIModel) initMasterPasswordFromConfig() {
+	mode := masterModeSet
+	if state, err := m.Service.MasterPasswordState(); err == nil && state == wallet.MasterPasswordUnlocked {
+		mode = masterModeChange
+	}
+	m.initMasterPasswordView(mode)
+}
+
+func (m *CLIModel) initMasterPasswordView(mode int) {
+	m.masterMode = mode
+	m.masterRestored = false
+	m.masterCurrentInput = newExportPasswordInput(localization.Labels["master_password_placeholder"])
+	m.masterNewInput = newExportPasswordInput(localization.Labels["master_password_placeholder"])
+	m.masterConfirmInput = newExportPasswordInput(localization.Labels["master_confirm_placeholder"])
+	m.masterRunning = false
+	m.setMasterResult("", false)
+	m.setMasterFocus(0)
+	m.currentView = constants.MasterPasswordView
+}
+
+// masterFields returns the inputs shown in the current mode, in focus order
+func (m *CLIModel) masterFields() []*textinput.Model {
+	switch m.masterMode {
+	case masterModeUnlock:
+		return []*textinput.Model{&m.masterCurrentInput}
+	case masterModeChange:
+		return []*textinput.Model{&m.masterCurrentInput, &m.masterNewInput, &m.masterConfirmInput}
+	default:
+		return []*textinput.Model{&m.masterNewInput, &m.masterConfirmInput}
+	}
+}
+
+func (m *CLIModel) setMasterFocus(focus int) {
+	fields := m.masterFields()
+	m.masterFocus = (focus + len(fields)) % len(fields)
+	for i, field := range fields {
+		if i == m.masterFocus {
+			field.Focus()
+		} else {
+			field.Blur()
+		}
+	}
+}
+
+// focusMasterField moves the focus to field and clears it
+func (m *CLIModel) focusMasterField(field *textinput.Model) {
+	field.SetValue("")
+	for i, f := range m.masterFields() {
+		if f == field {
+			m.setMasterFocus(i)
+		}
+	}
+}
+
+func (m *CLIModel) setMasterResult(status string, failed bool) {
+	m.masterStatus = status
+	m.masterFailed = failed
+}
+
+// updateMasterPassword handles input on the master password screen
+func (m *CLIModel) updateMasterPassword(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.masterRunning {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "tab", "down":
+		m.setMasterFocus(m.masterFocus + 1)
+		return m, nil
+	case "shift+tab", "up":
+		m.setMasterFocus(m.masterFocus - 1)
+		return m, nil
+	case "enter":
+		return m, m.submitMasterPassword()
+	}
+
+	fields := m.masterFields()
+	var cmd tea.Cmd
+	*fields[m.masterFocus], cmd = updateTextInput(*fields[m.masterFocus], msg)
+	return m, cmd
+}
+
+// submitMasterPassword checks the inputs and derives the master key in the
+// background, which takes as long as the [security] Argon2 costs ask
+func (m *CLIModel) submitMasterPassword() tea.Cmd {
+	current := m.masterCurrentInput.Value()
+	next := m.masterNewInput.Value()
+	if m.masterMode == masterModeUnlock || m.masterMode == masterModeChange {
+		if current == "" {
+			m.setMasterResult(localization.Labels["master_required"], true)
+			m.focusMasterField(&m.masterCurrentInput)
+			return nil
+		}
+	}
+	if m.masterMode != masterModeUnlock {
+		if validationErr, ok := wallet.ValidatePassword(next); !ok {
+			m.setMasterResult(validationErr.GetErrorMessage(), true)
+			m.focusMasterField(&m.masterNewInput)
+			return nil
+		}
+		if m.masterConfirmInput.Value() != next {
+			m.setMasterResult(localization.Labels["master_mismatch"], true)
+			m.focusMasterField(&m.masterConfirmInput)
+			return nil
+		}
+	}
+
+	m.masterRunning = true
+	m.setMasterResult(localization.Labels["master_working"], false)
+	service := m.Service
+	unlock := m.masterMode == masterModeUnlock
+	return func() tea.Msg {
+		if unlock {
+			return masterPasswordDoneMsg{err: service.UnlockMasterPassword(current)}
+		}
+		return masterPasswordDoneMsg{err: service.SetMasterPassword(current, next)}
+	}
+}
+
+// handleMasterPasswordDone continues to the main menu once the database is
+// unlocked at start, or shows the outcome of a set or change
+func (m *CLIModel) handleMasterPasswordDone(msg masterPasswordDoneMsg) tea.Cmd {
+	m.masterRunning = false
+	switch {
+	case errors.Is(msg.err, wallet.ErrMasterPassword):
+		m.setMasterResult(localization.Labels["master_wrong"], true)
+		m.focusMasterField(&m.masterCurrentInput)
+		return nil
+	case msg.err != nil:
+		m.setMasterResult(msg.err.Error(), true)
+		return nil
+	}
+
+	switch m.masterMode {
+	case masterModeUnlock:
+		if m.masterRestored {
+			// Back to the backup screen, with the restored wallets
+			m.currentView = constants.BackupView
+			return m.refreshWalletsTable()
+		}
+		return m.enterMainMenu()
+	case masterModeFirstRun:
+		return m.enterMainMenu()
+	}
+
+	status := localization.Labels["master_set_success"]
+	if m.masterMode == masterModeChange {
+		status = localization.Labels["master_change_success"]
+	}
+	// The next run of this screen changes the password just set
+	m.initMasterPasswordView(masterModeChange)
+	m.setMasterResult(status, false)
+	return nil
+}
+
+// closeMasterPassword leaves the screen with esc: the first-run offer is
+// declined, the unlock screen quits and the others go back to the menu
+func (m *CLIModel) closeMasterPassword() (tea.Model, tea.Cmd) {
+	if m.masterRunning {
+		return m, nil
+	}
+	switch m.masterMode {
+	case masterModeFirstRun:
+		if err := m.Service.DeclineMasterPassword(); err != nil {
+			log.Println("Erro ao gravar a recusa da senha mestra:", err)
+		}
+		return m, m.enterMainMenu()
+	case masterModeUnlock:
+		return m.quit()
+	}
+	m.menuItems = m.mainMenu()
+	m.selectedMenu = 0
+	m.currentView = constants.DefaultView
+	return m, nil
+}
+
+// masterPasswordTitle returns the title of the screen in the current mode
+func (m *CLIModel) masterPasswordTitle() string {
+	switch m.masterMode {
+	case masterModeUnlock:
+		return localization.Labels["master_title_unlock"]
+	case masterModeChange:
+		return localization.Labels["master_title_change"]
+	default:
+		return localization.Labels["master_title_setup"]
+	}
+}
+
+// viewMasterPassword renders the master password screen
+func (m *CLIModel) viewMasterPassword() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(m.masterPasswordTitle()))
+	b.WriteString("\n\n")
+
+	desc, help := localization.Labels["master_setup_desc"], localization.Labels["master_help_change"]
+	switch m.masterMode {
+	case masterModeFirstRun:
+		help = localization.Labels["master_help_setup"]
+	case masterModeUnlock:
+		desc, help = localization.Labels["master_unlock_desc"], localization.Labels["master_help_unlock"]
+		if m.masterRestored {
+			desc = localization.Labels["master_unlock_restored_desc"]
+		}
+	case masterModeChange:
+		desc = localization.Labels["master_change_desc"]
+	}
+	b.WriteString(desc)
+	b.WriteString("\n\n")
+
+	if m.masterMode == masterModeUnlock || m.masterMode == masterModeChange {
+		b.WriteString(localization.Labels["master_current"])
+		b.WriteString("\n")
+		b.WriteString(m.masterCurrentInput.View())
+		b.WriteString("\n\n")
+	}
+	if m.masterMode != masterModeUnlock {
+		b.WriteString(localization.Labels["master_new"])
+		b.WriteString("\n")
+		b.WriteString(m.masterNewInput.View())
+		b.WriteString("\n")
+		b.WriteString(m.masterConfirmInput.View())
+		b.WriteString("\n\n")
+	}
+
+	if m.masterStatus != "" {
+		switch {
+		case m.masterRunning:
+			b.WriteString(m.styles.MenuDesc.Render(m.masterStatus))
+		case m.masterFailed:
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.masterStatus))
+		default:
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.masterStatus))
+		}
+		b.WriteString("\n\n")
+	}
+	b.WriteString(m.styles.MenuDesc.Render(help))
+	return b.String()
+}
@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMasterPasswordModel(t *testing.T) (*CLIModel, *storage.GORMRepository) {
+	t.Helper()
+	localization.SetCurrentLanguage("en")
+	localization.AddMasterPasswordMessages()
+
+	dir := t.TempDir()
+	repo, err := storage.NewWalletRepository(&config.Config{AppDir: dir, DatabasePath: filepath.Join(dir, "wallets.db")})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = repo.Close() })
+
+	m := &CLIModel{Service: &wallet.WalletService{Repo: repo}, styles: createStyles(), currentView: constants.SplashView}
+	return m, repo
+}
+
+// typeMaster types text into the focused field
+func typeMaster(m *CLIModel, text string) {
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+}
+
+// submitMaster presses enter and runs the key derivation it starts
+func submitMaster(t *testing.T, m *CLIModel) {
+	t.Helper()
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		return
+	}
+	require.True(t, m.masterRunning)
+	_, _ = m.Update(cmd())
+}
+
+func TestMasterPassword_FirstRunSetsItBeforeTheMenu(t *testing.T) {
+	m, _ := newMasterPasswordModel(t)
+	_, _ = m.Update(splashMsg{})
+	require.Equal(t, constants.MasterPasswordView, m.currentView)
+	assert.Equal(t, masterModeFirstRun, m.masterMode)
+	assert.True(t, m.capturesTextInput(), "q is typed into the password")
+
+	typeMaster(m, "Master-Pass1")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeMaster(m, "Master-Pass2")
+	submitMaster(t, m)
+	assert.True(t, m.masterFailed)
+	assert.Equal(t, localization.Labels["master_mismatch"], m.masterStatus)
+
+	typeMaster(m, "Master-Pass1")
+	submitMaster(t, m)
+	assert.False(t, m.masterFailed, m.masterStatus)
+	assert.Equal(t, constants.DefaultView, m.currentView)
+
+	state, err := m.Service.MasterPasswordState()
+	require.NoError(t, err)
+	assert.Equal(t, wallet.MasterPasswordUnlocked, state)
+}
+
+func TestMasterPassword_FirstRunSkipIsRemembered(t *testing.T) {
+	m, _ := newMasterPasswordModel(t)
+	_, _ = m.Update(splashMsg{})
+	require.Equal(t, constants.MasterPasswordView, m.currentView)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.DefaultView, m.currentView)
+
+	// The offer is not repeated on the next start
+	m.currentView = constants.SplashView
+	_, _ = m.Update(splashMsg{})
+	assert.Equal(t, constants.DefaultView, m.currentView)
+}
+
+func TestMasterPassword_UnlockAndChange(t *testing.T) {
+	m, repo := newMasterPasswordModel(t)
+	require.NoError(t, m.Service.SetMasterPassword("", "Master-Pass1"))
+	repo.SetFieldCipher(nil)
+	m.Service = &wallet.WalletService{Repo: repo}
+
+	_, _ = m.Update(splashMsg{})
+	require.Equal(t, constants.MasterPasswordView, m.currentView)
+	assert.Equal(t, masterModeUnlock, m.masterMode)
+
+	// A wrong password is cleared for another try
+	typeMaster(m, "Wrong-Pass1")
+	submitMaster(t, m)
+	assert.True(t, m.masterFailed)
+	assert.Equal(t, localization.Labels["master_wrong"], m.masterStatus)
+	assert.Empty(t, m.masterCurrentInput.Value())
+
+	typeMaster(m, "Master-Pass1")
+	submitMaster(t, m)
+	assert.False(t, m.masterFailed, m.masterStatus)
+	assert.Equal(t, constants.DefaultView, m.currentView)
+
+	// Changing it from the configuration menu asks for the current one
+	m.initMasterPasswordFromConfig()
+	require.Equal(t, masterModeChange, m.masterMode)
+	assert.Contains(t, m.viewMasterPassword(), localization.Labels["master_current"])
+	typeMaster(m, "Master-Pass1")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeMaster(m, "Master-Pass2")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeMaster(m, "Master-Pass2")
+	submitMaster(t, m)
+	assert.False(t, m.masterFailed, m.masterStatus)
+	assert.Equal(t, localization.Labels["master_change_success"], m.masterStatus)
+
+	repo.SetFieldCipher(nil)
+	locked := &wallet.WalletService{Repo: repo}
+	assert.ErrorIs(t, locked.UnlockMasterPassword("Master-Pass1"), wallet.ErrMasterPassword)
+	assert.NoError(t, locked.UnlockMasterPassword("Master-Pass2"))
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.DefaultView, m.currentView)
+}
+
+func TestMasterPassword_NotOfferedWithoutSupport(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	m := &CLIModel{Service: &wallet.WalletService{}, styles: createStyles(), currentView: constants.SplashView}
+	_, _ = m.Update(splashMsg{})
+	assert.Equal(t, constants.DefaultView, m.currentView)
+}
+
+func TestMasterPassword_OpenedFromTheConfigMenu(t *testing.T) {
+	m, _ := newMasterPasswordModel(t)
+	m.initConfigMenu()
+	m.selectedMenu = 4
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, constants.MasterPasswordView, m.currentView)
+	assert.Equal(t, masterModeSet, m.masterMode)
+}
@@ -1,10 +1,36 @@
 package ui
 
 import (
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
 	"blocowallet/pkg/config"
 	"blocowallet/pkg/localization"
+
+	"github.com/charmbracelet/bubbles/key"
 )
 
+func init() {
+	registerKeyMap(constants.DefaultView, gridMenuKeys)
+	registerKeyMap(constants.LanguageSelectionView, gridMenuKeys)
+	registerKeyMap(constants.ConfigurationView, listMenuKeys)
+	registerKeyMap(constants.ImportMethodSelectionView, listMenuKeys)
+	registerKeyMap(constants.NetworkMenuView, listMenuKeys)
+}
+
+// listMenuKeys são as teclas dos menus em lista
+func listMenuKeys(m *CLIModel) []key.Binding {
+	return []key.Binding{
+		newBinding("↑/↓", "key_move", "up", "k", "down", "j"),
+		newBinding("enter", "key_select", "enter"),
+	}
+}
+
+// gridMenuKeys são as teclas dos menus em duas colunas, que também andam
+// entre as colunas
+func gridMenuKeys(m *CLIModel) []key.Binding {
+	return append(listMenuKeys(m), newBinding("←/→", "key_column", "left", "h", "right", "l"))
+}
+
 // menuItem representa uma única opção no menu
 type menuItem struct {
 	title       string
@@ -30,19 +56,64 @@ func (i menuItem) FilterValue() string {
 func NewMenu() []menuItem {
 	return []menuItem{
 		{title: localization.Labels["create_new_wallet"], description: localization.Labels["create_new_wallet_desc"]},
+		{title: localization.Labels["batch_create"], description: localization.Labels["batch_create_desc_short"]},
 		{title: localization.Labels["import_wallet"], description: localization.Labels["import_wallet_desc"]},
 		{title: localization.Labels["list_wallets"], description: localization.Labels["list_wallets_desc"]},
+		{title: localization.Labels["broadcast_tx"], description: localization.Labels["broadcast_tx_desc"]},
+		{title: localization.Labels["batch_send"], description: localization.Labels["batch_send_desc"]},
+		{title: localization.Labels["sweep"], description: localization.Labels["sweep_desc"]},
+		{title: localization.Labels["pending_tx"], description: localization.Labels["pending_tx_desc"]},
+		{title: localization.Labels["walletconnect"], description: localization.Labels["walletconnect_desc"]},
+		{title: localization.Labels["inheritance"], description: localization.Labels["inheritance_desc"]},
+		{title: localization.Labels["jobs"], description: localization.Labels["jobs_desc"]},
 		{title: localization.Labels["configuration"], description: localization.Labels["configuration_desc"]},
+		{title: localization.Labels["about"], description: localization.Labels["about_menu_desc"]},
 		{title: localization.Labels["exit"], description: localization.Labels["exit_desc"]},
 	}
 }
 
+// menuCapabilities associa itens do menu principal à capacidade do serviço
+// de carteiras de que dependem
+var menuCapabilities = map[string]wallet.Capability{
+	"create_new_wallet": wallet.CapCreate,
+	"batch_create":      wallet.CapBatchCreate,
+	"pending_tx":        wallet.CapTrackTransactions,
+}
+
+// mainMenu retorna o menu principal sem os itens que o serviço não oferece
+func (m *CLIModel) mainMenu() []menuItem {
+	items := NewMenu()
+	if m.capabilities == nil {
+		return items
+	}
+	visible := items[:0]
+	for _, item := range items {
+		if c, ok := menuCapability(item.title); ok && !m.capabilities.Has(c) {
+			continue
+		}
+		visible = append(visible, item)
+	}
+	return visible
+}
+
+// menuCapability retorna a capacidade exigida pelo item com este título
+func menuCapability(title string) (wallet.Capability, bool) {
+	for key, c := range menuCapabilities {
+		if title != "" && localization.Labels[key] == title {
+			return c, true
+		}
+	}
+	return "", false
+}
+
 // NewImportMenu cria e retorna uma lista de itens do menu de importação
 func NewImportMenu() []menuItem {
 	return []menuItem{
 		{title: localization.Labels["import_mnemonic"], description: localization.Labels["import_mnemonic_desc"]},
 		{title: localization.Labels["import_private_key"], description: localization.Labels["import_private_key_desc"]},
 		{title: localization.Labels["import_keystore"], description: localization.Labels["import_keystore_desc"]},
+		{title: localization.Labels["import_safe_owners"], description: localization.Labels["import_safe_owners_desc"]},
+		{title: localization.Labels["import_metamask"], description: localization.Labels["import_metamask_desc"]},
 		{title: localization.Labels["back_to_menu"], description: localization.Labels["back_to_menu_desc"]},
 	}
 }
@@ -52,6 +123,14 @@ func NewConfigMenu() []menuItem {
 	return []menuItem{
 		{title: localization.Labels["networks"], description: localization.Labels["networks_desc"]},
 		{title: localization.Labels["language"], description: localization.Labels["language_desc"]},
+		{title: localization.Labels["sync"], description: localization.Labels["sync_menu_desc"]},
+		{title: localization.Labels["backup"], description: localization.Labels["backup_menu_desc"]},
+		{title: localization.Labels["master_password"], description: localization.Labels["master_password_menu_desc"]},
+		{title: localization.Labels["logs"], description: localization.Labels["logs_menu_desc"]},
+		{title: localization.Labels["store_check"], description: localization.Labels["store_check_menu_desc"]},
+		{title: localization.Labels["config_editor"], description: localization.Labels["config_editor_menu_desc"]},
+		{title: localization.Labels["profiles"], description: localization.Labels["profiles_menu_desc"]},
+		{title: localization.Labels["theme"], description: localization.Labels["theme_menu_desc"]},
 		{title: localization.Labels["back_to_menu"], description: localization.Labels["back_to_menu_desc"]},
 	}
 }
@@ -61,6 +140,7 @@ func NewNetworkMenu() []menuItem {
 	return []menuItem{
 		{title: localization.Labels["add_network"], description: localization.Labels["add_network_desc"]},
 		{title: localization.Labels["network_list"], description: localization.Labels["network_list_desc"]},
+		{title: localization.Labels["import_networks"], description: localization.Labels["import_networks_desc"]},
 		{title: localization.Labels["back_to_menu"], description: localization.Labels["back_to_menu_desc"]},
 	}
 }
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"testing"
+
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMainMenu_FollowsServiceCapabilities(t *testing.T) {
+	localization.Labels = map[string]string{
+		"create_new_wallet": "Create New",
+		"batch_create":      "Create Multiple",
+		"pending_tx":        "Transactions",
+		"exit":              "Exit",
+	}
+
+	titles := func(items []menuItem) []string {
+		var out []string
+		for _, item := range items {
+			out = append(out, item.title)
+		}
+		return out
+	}
+
+	m := &CLIModel{capabilities: wallet.Capabilities((*wallet.WalletService)(nil))}
+	assert.Equal(t, titles(NewMenu()), titles(m.mainMenu()), "the current service offers everything")
+
+	m.capabilities = wallet.Capabilities(struct{ wallet.ServiceV1 }{})
+	menu := titles(m.mainMenu())
+	assert.Contains(t, menu, localization.Labels["create_new_wallet"])
+	assert.NotContains(t, menu, localization.Labels["batch_create"])
+	assert.NotContains(t, menu, localization.Labels["pending_tx"])
+	assert.Contains(t, menu, "Exit")
+}
@@ -0,0 +1,506 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/internal/jobs"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Fields of the MetaMask import form
+const (
+	metamaskFocusFile = iota
+	metamaskFocusVaultPassword
+	metamaskFocusName
+	metamaskFocusWalletPassword
+	metamaskFocusTotal
+)
+
+// Stages of a MetaMask import: the form, opening the vault, the accounts
+// found, running, the summary
+const (
+	metamaskStageForm = iota
+	metamaskStageReading
+	metamaskStageReview
+	metamaskStageRunning
+	metamaskStageDone
+)
+
+// metamaskImportResult is the outcome of the import for one account. It
+// keeps no key or phrase, as it stays on screen after the import.
+type metamaskImportResult struct {
+	Address common.Address
+	Detail  string
+	Skipped bool
+	Err     error
+}
+
+// metamaskReadMsg carries the accounts read from the file
+type metamaskReadMsg struct {
+	seq    int
+	export *wallet.MetamaskExport
+	err    error
+}
+
+// metamaskImportStepMsg carries the outcome of one account of the running import
+type metamaskImportStepMsg struct {
+	seq     int
+	result  metamaskImportResult
+	updates <-chan metamaskImportResult
+}
+
+// metamaskImportDoneMsg tells the import stopped, after the last account or when cancelled
+type metamaskImportDoneMsg struct {
+	seq int
+}
+
+// errMetamaskNotProcessed marks the accounts left when the import was stopped
+var errMetamaskNotProcessed = errors.New("not processed")
+
+// initMetamaskImport opens the form asking for the MetaMask file and passwords
+func (m *CLIModel) initMetamaskImport() tea.Cmd {
+	newInput := func(placeholder string, limit, width int, secret bool) textinput.Model {
+		ti := textinput.New()
+		ti.Placeholder = placeholder
+		ti.CharLimit = limit
+		ti.Width = width
+		if secret {
+			ti.EchoMode = textinput.EchoPassword
+			ti.EchoCharacter = '•'
+		}
+		return ti
+	}
+	m.metamaskInputs = []textinput.Model{
+		metamaskFocusFile:           newInput(localization.Labels["metamask_file_placeholder"], 512, 60, false),
+		metamaskFocusVaultPassword:  newInput(localization.Labels["enter_password"], 256, constants.PasswordWidth, true),
+		metamaskFocusName:           newInput("MetaMask", 40, 40, false),
+		metamaskFocusWalletPassword: newInput(localization.Labels["enter_password"], constants.PasswordCharLimit, constants.PasswordWidth, true),
+	}
+	m.metamaskInputs[metamaskFocusName].SetValue("MetaMask")
+	m.metamaskStage = metamaskStageForm
+	m.metamaskExport = nil
+	m.metamaskResults = nil
+	m.setMetamaskStatus("", false)
+	m.setMetamaskFocus(metamaskFocusFile)
+	m.currentView = constants.MetamaskImportView
+	return textinput.Blink
+}
+
+func (m *CLIModel) setMetamaskFocus(focus int) {
+	m.metamaskFocus = focus
+	for i := range m.metamaskInputs {
+		if i == focus {
+			m.metamaskInputs[i].Focus()
+		} else {
+			m.metamaskInputs[i].Blur()
+		}
+	}
+}
+
+// metamaskCapturesText reports whether keys go to the form fields
+func (m *CLIModel) metamaskCapturesText() bool {
+	return m.metamaskStage == metamaskStageForm
+}
+
+// updateMetamaskImport handles input on the MetaMask import screen
+func (m *CLIModel) updateMetamaskImport(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, isKey := msg.(tea.KeyMsg)
+	switch m.metamaskStage {
+	case metamaskStageReading, metamaskStageRunning:
+		return m, nil
+	case metamaskStageReview:
+		if isKey {
+			switch keyMsg.String() {
+			case "enter":
+				return m, m.startMetamaskImport()
+			case "backspace":
+				m.metamaskStage = metamaskStageForm
+				m.metamaskExport = nil
+				m.setMetamaskFocus(metamaskFocusFile)
+				return m, nil
+			}
+		}
+		return m, nil
+	case metamaskStageDone:
+		if isKey && keyMsg.String() == "enter" {
+			m.initListWallets()
+		}
+		return m, nil
+	}
+
+	if text, ok := pastedText(msg); ok && m.metamaskFocus == metamaskFocusFile {
+		m.metamaskInputs[metamaskFocusFile].SetValue(cleanPastedPath(text))
+		m.metamaskInputs[metamaskFocusFile].CursorEnd()
+		return m, nil
+	}
+	if isKey {
+		switch keyMsg.String() {
+		case "tab", "down":
+			m.setMetamaskFocus((m.metamaskFocus + 1) % metamaskFocusTotal)
+			return m, nil
+		case "shift+tab", "up":
+			m.setMetamaskFocus((m.metamaskFocus + metamaskFocusTotal - 1) % metamaskFocusTotal)
+			return m, nil
+		case "enter":
+			return m, m.readMetamaskFile()
+		}
+	}
+	var cmd tea.Cmd
+	m.metamaskInputs[m.metamaskFocus], cmd = updateTextInput(m.metamaskInputs[m.metamaskFocus], msg)
+	return m, cmd
+}
+
+// readMetamaskFile checks the form and opens the file in the background, as
+// deriving the vault key takes a moment
+func (m *CLIModel) readMetamaskFile() tea.Cmd {
+	path := strings.TrimSpace(m.metamaskInputs[metamaskFocusFile].Value())
+	if path == "" {
+		m.setMetamaskStatus(localization.Labels["metamask_path_required"], true)
+		m.setMetamaskFocus(metamaskFocusFile)
+		return nil
+	}
+	if strings.TrimSpace(m.metamaskInputs[metamaskFocusName].Value()) == "" {
+		m.setMetamaskStatus(localization.Labels["metamask_name_required"], true)
+		m.setMetamaskFocus(metamaskFocusName)
+		return nil
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		m.setMetamaskStatus(fmt.Sprintf(localization.Labels["metamask_read_failed"], err), true)
+		m.setMetamaskFocus(metamaskFocusFile)
+		return nil
+	}
+
+	password := m.metamaskInputs[metamaskFocusVaultPassword].Value()
+	m.metamaskSeq++
+	seq := m.metamaskSeq
+	m.metamaskStage = metamaskStageReading
+	m.setMetamaskStatus("", false)
+	return func() tea.Msg {
+		export, err := wallet.ReadMetamaskExport(data, password)
+		return metamaskReadMsg{seq: seq, export: export, err: err}
+	}
+}
+
+// handleMetamaskRead lists the accounts found, or goes back to the field at fault
+func (m *CLIModel) handleMetamaskRead(msg metamaskReadMsg) tea.Cmd {
+	if msg.seq != m.metamaskSeq || m.metamaskStage != metamaskStageReading {
+		return nil
+	}
+	m.metamaskStage = metamaskStageForm
+	switch {
+	case errors.Is(msg.err, wallet.ErrMetamaskPassword):
+		m.setMetamaskStatus(localization.Labels["metamask_vault_password_failed"], true)
+		m.setMetamaskFocus(metamaskFocusVaultPassword)
+		return nil
+	case msg.err != nil:
+		m.setMetamaskStatus(msg.err.Error(), true)
+		m.setMetamaskFocus(metamaskFocusFile)
+		return nil
+	case len(msg.export.Accounts) == 0:
+		m.setMetamaskStatus(localization.Labels["metamask_no_accounts"], true)
+		m.setMetamaskFocus(metamaskFocusFile)
+		return nil
+	}
+	m.metamaskExport = msg.export
+	m.metamaskStage = metamaskStageReview
+	m.setMetamaskFocus(-1)
+	return nil
+}
+
+// metamaskPassword is the password of the new wallets: the one typed for
+// them, or the MetaMask password
+func (m *CLIModel) metamaskPassword() string {
+	if password := strings.TrimSpace(m.metamaskInputs[metamaskFocusWalletPassword].Value()); password != "" {
+		return password
+	}
+	return strings.TrimSpace(m.metamaskInputs[metamaskFocusVaultPassword].Value())
+}
+
+// startMetamaskImport submits the import of the accounts found to the job
+// manager, so it shows in the Jobs view and can be cancelled from there too
+func (m *CLIModel) startMetamaskImport() tea.Cmd {
+	password := m.metamaskPassword()
+	if validationErr, ok := wallet.ValidatePassword(password); !ok {
+		m.metamaskStage = metamaskStageForm
+		m.setMetamaskStatus(validationErr.GetErrorMessage(), true)
+		m.setMetamaskFocus(metamaskFocusWalletPassword)
+		return nil
+	}
+	accounts := m.metamaskExport.Accounts
+	base := strings.TrimSpace(m.metamaskInputs[metamaskFocusName].Value())
+	service := m.Service
+
+	m.metamaskSeq++
+	updates := make(chan metamaskImportResult, len(accounts))
+	name := fmt.Sprintf("%s (%d)", localization.Labels["metamask_title"], len(accounts))
+	id, err := m.jobManager.Submit(jobs.KindBatchImport, name, func(ctx context.Context, report jobs.ProgressFunc) error {
+		defer close(updates)
+		for i, account := range accounts {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			report(float64(i)/float64(len(accounts)), account.Address.Hex())
+			updates <- importMetamaskAccount(service, metamaskWalletName(base, i), account, password)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, jobs.ErrConflict) {
+			err = errors.New(localization.Labels["job_conflict"])
+		}
+		m.setMetamaskStatus(err.Error(), true)
+		return nil
+	}
+	m.metamaskJobID = id
+	m.metamaskCancelling = false
+	m.metamaskStage = metamaskStageRunning
+	m.metamaskProgress = NewImportProgressModel(len(accounts), m.styles)
+	m.setMetamaskStatus("", false)
+	return waitForMetamaskImport(m.metamaskSeq, updates)
+}
+
+// metamaskWalletName numbers the wallets of an import from 1, as MetaMask
+// numbers its accounts
+func metamaskWalletName(base string, i int) string {
+	return fmt.Sprintf("%s %d", base, i+1)
+}
+
+// metamaskSource tells where an account comes from in the vault
+func metamaskSource(account wallet.MetamaskAccount) string {
+	if account.PrivateKey != "" {
+		return localization.Labels["metamask_source_key"]
+	}
+	return fmt.Sprintf(localization.Labels["metamask_source_phrase"], account.Index+1)
+}
+
+// importMetamaskAccount imports one account. Accounts already in the list
+// are skipped, so the same vault can be imported again after a failure.
+func importMetamaskAccount(service *wallet.WalletService, name string, account wallet.MetamaskAccount, password string) metamaskImportResult {
+	result := metamaskImportResult{Address: account.Address}
+	_, err := service.ImportMetamaskAccount(name, account, password)
+	var duplicate *wallet.DuplicateWalletError
+	switch {
+	case errors.As(err, &duplicate), errors.Is(err, wallet.ErrChildIndexAllocated):
+		result.Skipped = true
+		result.Detail = localization.Labels["metamask_exists"]
+	case err != nil:
+		result.Err = err
+	default:
+		result.Detail = fmt.Sprintf(localization.Labels["metamask_imported"], name)
+	}
+	return result
+}
+
+// waitForMetamaskImport delivers the outcome of the next account, or the end
+// of the import once its channel is closed
+func waitForMetamaskImport(seq int, updates <-chan metamaskImportResult) tea.Cmd {
+	return func() tea.Msg {
+		result, ok := <-updates
+		if !ok {
+			return metamaskImportDoneMsg{seq: seq}
+		}
+		return metamaskImportStepMsg{seq: seq, result: result, updates: updates}
+	}
+}
+
+// handleMetamaskImportStep shows the outcome of one account and waits for the next
+func (m *CLIModel) handleMetamaskImportStep(msg metamaskImportStepMsg) tea.Cmd {
+	if msg.seq == m.metamaskSeq {
+		m.metamaskResults = append(m.metamaskResults, msg.result)
+		label := msg.result.Address.Hex()
+		m.metamaskProgress.UpdateProgress(label, len(m.metamaskResults))
+		switch {
+		case msg.result.Err != nil:
+			m.metamaskProgress.AddError(label, msg.result.Err, false)
+			m.session.errors++
+		case msg.result.Skipped:
+			m.metamaskProgress.AddError(label, errors.New(msg.result.Detail), true)
+		default:
+			m.session.imported++
+		}
+	}
+	// Drained either way so the import never waits on the screen
+	return waitForMetamaskImport(msg.seq, msg.updates)
+}
+
+// handleMetamaskImportDone lists the accounts a stopped import did not
+// reach, drops the keys read from the vault and shows the summary
+func (m *CLIModel) handleMetamaskImportDone(msg metamaskImportDoneMsg) tea.Cmd {
+	if msg.seq != m.metamaskSeq || m.metamaskExport == nil {
+		return nil
+	}
+	for _, account := range m.metamaskExport.Accounts[len(m.metamaskResults):] {
+		m.metamaskResults = append(m.metamaskResults, metamaskImportResult{Address: account.Address, Skipped: true, Err: errMetamaskNotProcessed})
+		m.metamaskProgress.AddError(account.Address.Hex(), errMetamaskNotProcessed, true)
+	}
+	m.metamaskProgress.Complete()
+	m.metamaskStage = metamaskStageDone
+	m.metamaskJobID = ""
+	m.clearMetamaskSecrets()
+	return m.refreshWalletsTable()
+}
+
+// clearMetamaskSecrets forgets the passwords typed and the keys read
+func (m *CLIModel) clearMetamaskSecrets() {
+	m.metamaskExport = nil
+	for _, field := range []int{metamaskFocusVaultPassword, metamaskFocusWalletPassword} {
+		if field < len(m.metamaskInputs) {
+			m.metamaskInputs[field].SetValue("")
+		}
+	}
+}
+
+// closeMetamaskImport handles esc: it cancels the running import after the
+// current account, or goes back to the import menu
+func (m *CLIModel) closeMetamaskImport() {
+	if m.metamaskStage == metamaskStageRunning {
+		if m.metamaskJobID != "" && !m.metamaskCancelling {
+			m.metamaskCancelling = true
+			_ = m.jobManager.Cancel(m.metamaskJobID)
+		}
+		return
+	}
+	// A vault still being opened is ignored when it arrives
+	m.metamaskSeq++
+	m.clearMetamaskSecrets()
+	m.initImportMethodSelection()
+}
+
+func (m *CLIModel) setMetamaskStatus(status string, failed bool) {
+	m.metamaskStatus = status
+	m.metamaskFailed = failed
+}
+
+// viewMetamaskImport renders the form, the accounts found, the progress and
+// the summary
+func (m *CLIModel) viewMetamaskImport() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["metamask_title"]))
+	b.WriteString("\n\n")
+
+	help := "metamask_form_help"
+	switch m.metamaskStage {
+	case metamaskStageForm:
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["metamask_desc"]))
+		b.WriteString("\n\n")
+		labels := []string{
+			metamaskFocusFile:           "metamask_file",
+			metamaskFocusVaultPassword:  "metamask_vault_password",
+			metamaskFocusName:           "metamask_name",
+			metamaskFocusWalletPassword: "metamask_wallet_password",
+		}
+		for i, label := range labels {
+			b.WriteString(localization.Labels[label])
+			b.WriteString("\n")
+			b.WriteString(m.metamaskInputs[i].View())
+			b.WriteString("\n\n")
+		}
+	case metamaskStageReading:
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["metamask_reading"]))
+		b.WriteString("\n\n")
+		help = ""
+	case metamaskStageReview:
+		help = "metamask_review_help"
+		b.WriteString(m.viewMetamaskAccounts())
+	case metamaskStageRunning:
+		help = "metamask_running_help"
+		p := m.metamaskProgress
+		total := len(m.metamaskExport.Accounts)
+		b.WriteString(fmt.Sprintf(localization.Labels["metamask_running"], total))
+		b.WriteString("\n\n")
+		b.WriteString(p.ViewAs(p.GetPercentage()))
+		b.WriteString(fmt.Sprintf("  %d/%d\n", len(m.metamaskResults), total))
+		if n := len(m.metamaskResults); n > 0 {
+			b.WriteString(m.styles.MenuDesc.Render(m.metamaskResults[n-1].Address.Hex()))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		if m.metamaskCancelling {
+			b.WriteString(m.styles.MenuDesc.Render(localization.Labels["metamask_cancelling"]))
+			b.WriteString("\n\n")
+		}
+	case metamaskStageDone:
+		help = "metamask_done_help"
+		b.WriteString(m.viewMetamaskSummary())
+	}
+
+	if m.metamaskStatus != "" {
+		if m.metamaskFailed {
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.metamaskStatus))
+		} else {
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.metamaskStatus))
+		}
+		b.WriteString("\n\n")
+	}
+	if help != "" {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels[help]))
+	}
+	return b.String()
+}
+
+// viewMetamaskAccounts lists the accounts found, with the names they will get
+func (m *CLIModel) viewMetamaskAccounts() string {
+	var b strings.Builder
+	export := m.metamaskExport
+	base := strings.TrimSpace(m.metamaskInputs[metamaskFocusName].Value())
+	b.WriteString(fmt.Sprintf(localization.Labels["metamask_found"], len(export.Accounts)))
+	b.WriteString("\n\n")
+	for i, account := range export.Accounts {
+		b.WriteString(fmt.Sprintf("  %-16s %s  %s\n", truncateRunes(metamaskWalletName(base, i), 16), account.Address.Hex(), metamaskSource(account)))
+	}
+	b.WriteString("\n")
+	if len(export.Unsupported) > 0 {
+		b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf(localization.Labels["metamask_unsupported"], strings.Join(export.Unsupported, ", "))))
+		b.WriteString("\n\n")
+	}
+	if export.PhraseOnly {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["metamask_phrase_only"]))
+		b.WriteString("\n\n")
+	}
+	return b.String()
+}
+
+// viewMetamaskSummary lists how each account went, as the batch import does
+func (m *CLIModel) viewMetamaskSummary() string {
+	var b strings.Builder
+	failed := len(m.metamaskProgress.GetFailedErrors())
+	skipped := len(m.metamaskProgress.GetSkippedErrors())
+	title := m.styles.SuccessStyle.Render(glyphs.Check + " " + localization.Labels["metamask_done"])
+	if failed > 0 {
+		title = m.styles.ErrorStyle.Render(glyphs.Cross + " " + localization.Labels["metamask_done_errors"])
+	}
+	b.WriteString(title)
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["metamask_summary"], len(m.metamaskResults)-failed-skipped, failed, skipped))
+	b.WriteString("\n\n")
+	for _, result := range m.metamaskResults {
+		name := result.Address.Hex()
+		switch {
+		case errors.Is(result.Err, errMetamaskNotProcessed):
+			b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf("– %s %s", name, localization.Labels["metamask_not_processed"])))
+		case result.Err != nil:
+			b.WriteString(m.styles.ErrorStyle.Render(fmt.Sprintf("%s %s %s", glyphs.Cross, name, result.Err)))
+		case result.Skipped:
+			b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf("– %s %s", name, result.Detail)))
+		default:
+			b.WriteString(m.styles.SuccessStyle.Render(fmt.Sprintf("%s %s %s", glyphs.Check, name, result.Detail)))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/jobs"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// writeMetamaskVault writes keyrings encrypted as a MetaMask vault from
+// before keyMetadata, which uses 10000 PBKDF2 iterations
+func writeMetamaskVault(t *testing.T, password string, keyrings any) string {
+	t.Helper()
+	plain, err := json.Marshal(keyrings)
+	require.NoError(t, err)
+	salt, iv := []byte("metamask-test-salt-32-bytes-long"), []byte("metamask-test-iv")
+	block, err := aes.NewCipher(pbkdf2.Key([]byte(password), salt, 10000, 32, sha256.New))
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	require.NoError(t, err)
+	b64 := base64.StdEncoding.EncodeToString
+	vault := fmt.Sprintf(`{"data":"%s","iv":"%s","salt":"%s"}`, b64(gcm.Seal(nil, iv, plain, nil)), b64(iv), b64(salt))
+	path := filepath.Join(t.TempDir(), "vault.json")
+	require.NoError(t, os.WriteFile(path, []byte(vault), 0600))
+	return path
+}
+
+func TestMetamaskImport_Vault(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddMetamaskMessages()
+
+	dir := t.TempDir()
+	repo, err := storage.NewWalletRepository(&config.Config{AppDir: dir, DatabasePath: filepath.Join(dir, "wallets.db")})
+	require.NoError(t, err)
+	defer repo.Close()
+	n, p := wallet.GetTestKeystoreParams()
+	ws := &wallet.WalletService{Repo: repo, KeyStore: keystore.NewKeyStore(filepath.Join(dir, "keystore"), n, p)}
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	path := writeMetamaskVault(t, "extension password", []map[string]any{
+		{"type": "HD Key Tree", "data": map[string]any{"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "numberOfAccounts": 2, "hdPath": "m/44'/60'/0'/0"}},
+		{"type": "Simple Key Pair", "data": []string{fmt.Sprintf("%x", crypto.FromECDSA(key))}},
+		{"type": "Trezor Hardware", "data": map[string]any{}},
+	})
+
+	m := &CLIModel{Service: ws, jobManager: jobs.NewManager(), styles: createStyles(), width: 160}
+	m.initImportMethodSelection()
+	m.selectedMenu = 4
+	batchKey(m, "enter")
+	require.Equal(t, constants.MetamaskImportView, m.currentView)
+	assert.True(t, m.capturesTextInput())
+
+	// A wrong password sends the cursor back to it
+	m.metamaskInputs[metamaskFocusFile].SetValue(path)
+	m.metamaskInputs[metamaskFocusVaultPassword].SetValue("wrong")
+	runBatchExportCmds(m, batchKey(m, "enter"))
+	assert.Equal(t, metamaskStageForm, m.metamaskStage)
+	assert.Equal(t, metamaskFocusVaultPassword, m.metamaskFocus)
+	assert.Contains(t, m.viewMetamaskImport(), "The MetaMask password is wrong")
+
+	m.metamaskInputs[metamaskFocusVaultPassword].SetValue("extension password")
+	m.metamaskInputs[metamaskFocusWalletPassword].SetValue("Str0ngPassword")
+	runBatchExportCmds(m, batchKey(m, "enter"))
+	require.Equal(t, metamaskStageReview, m.metamaskStage, m.metamaskStatus)
+	view := m.viewMetamaskImport()
+	assert.Contains(t, view, "3 accounts found")
+	assert.Contains(t, view, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94")
+	assert.Contains(t, view, "MetaMask 2")
+	assert.Contains(t, view, "Not imported, they hold no key: Trezor")
+
+	runBatchExportCmds(m, batchKey(m, "enter"))
+	require.Equal(t, metamaskStageDone, m.metamaskStage)
+	assert.Contains(t, m.viewMetamaskImport(), "3 imported, 0 failed, 0 skipped")
+	assert.Nil(t, m.metamaskExport, "the keys read are dropped after the import")
+	assert.Empty(t, m.metamaskInputs[metamaskFocusVaultPassword].Value())
+
+	wallets, err := ws.GetAllWallets()
+	require.NoError(t, err)
+	require.Len(t, wallets, 3)
+	methods := map[string]string{}
+	for _, w := range wallets {
+		methods[w.Name] = w.ImportMethod
+	}
+	assert.Equal(t, map[string]string{
+		"MetaMask 1": string(wallet.ImportMethodMnemonic),
+		"MetaMask 2": string(wallet.ImportMethodDerived),
+		"MetaMask 3": string(wallet.ImportMethodPrivateKey),
+	}, methods)
+
+	// Importing the vault again skips the accounts already in the list
+	m.initMetamaskImport()
+	m.metamaskInputs[metamaskFocusFile].SetValue(path)
+	m.metamaskInputs[metamaskFocusVaultPassword].SetValue("extension password")
+	m.metamaskInputs[metamaskFocusWalletPassword].SetValue("Str0ngPassword")
+	runBatchExportCmds(m, batchKey(m, "enter"))
+	runBatchExportCmds(m, batchKey(m, "enter"))
+	require.Equal(t, metamaskStageDone, m.metamaskStage)
+	assert.Contains(t, m.viewMetamaskImport(), "0 imported, 0 failed, 3 skipped")
+
+	batchKey(m, "esc")
+	assert.Equal(t, constants.ImportMethodSelectionView, m.currentView)
+}
@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// mnemonicMask replaces the words that are not being revealed
+const mnemonicMask = "••••••"
+
+// loadMnemonicLayout reads the configuration for the mnemonic screens when it
+// is not loaded yet; without it the default layout is used
+func (m *CLIModel) loadMnemonicLayout() {
+	m.mnemonicRevealIndex = 0
+	if m.currentConfig == nil {
+		if cfg, err := loadOrCreateConfig(); err == nil {
+			m.currentConfig = cfg
+		}
+	}
+}
+
+// mnemonicLayout returns the configured grid and reveal mode
+func (m *CLIModel) mnemonicLayout() config.MnemonicConfig {
+	if m.currentConfig == nil || m.currentConfig.Mnemonic.WordsPerRow < 1 {
+		return config.MnemonicConfig{WordsPerRow: config.DefaultMnemonicWordsPerRow, Reveal: config.MnemonicRevealAll}
+	}
+	return m.currentConfig.Mnemonic
+}
+
+// revealOneWord reports whether phrases are revealed one word at a time
+func (m *CLIModel) revealOneWord() bool {
+	return m.mnemonicLayout().Reveal == config.MnemonicRevealWord
+}
+
+// stepMnemonicReveal moves the revealed word of a phrase of count words
+func (m *CLIModel) stepMnemonicReveal(step, count int) {
+	if count == 0 {
+		return
+	}
+	m.mnemonicRevealIndex = (m.mnemonicRevealIndex + step + count) % count
+}
+
+// renderMnemonic lays the words of phrase out in the configured grid, masking
+// all but the revealed word when revealing one at a time
+func (m *CLIModel) renderMnemonic(phrase string) string {
+	words := strings.Fields(phrase)
+	if len(words) == 0 {
+		return ""
+	}
+	layout := m.mnemonicLayout()
+	oneWord := layout.Reveal == config.MnemonicRevealWord
+	if m.mnemonicRevealIndex >= len(words) {
+		m.mnemonicRevealIndex = 0
+	}
+
+	cells := make([]string, len(words))
+	for i, word := range words {
+		if oneWord && i != m.mnemonicRevealIndex {
+			word = mnemonicMask
+		}
+		cell := fmt.Sprintf("%2d. %s", i+1, word)
+		if oneWord && i == m.mnemonicRevealIndex {
+			cell = lipgloss.NewStyle().Bold(true).Foreground(palette.Success).Render(cell)
+		}
+		cells[i] = cell
+	}
+	grid := mnemonicGrid(cells, layout.WordsPerRow)
+	if oneWord {
+		grid += "\n" + fmt.Sprintf(localization.Labels["mnemonic_reveal_hint"], m.mnemonicRevealIndex+1, len(words))
+	}
+	return grid
+}
+
+// renderMnemonicInputs lays the word inputs of the import screen out in the
+// configured grid. When revealing one word at a time, the words already typed
+// are masked and only the one being typed is visible.
+func (m *CLIModel) renderMnemonicInputs(inputs []textinput.Model, active int, activeStyle, inactiveStyle lipgloss.Style) string {
+	layout := m.mnemonicLayout()
+	cells := make([]string, len(inputs))
+	for i, ti := range inputs {
+		label := fmt.Sprintf("%s %d:", localization.Labels["word"], i+1)
+		if i == active {
+			if layout.WordsPerRow > 1 {
+				// Narrow the field so the row fits; no BIP-39 word is longer than 8 letters
+				ti.Width = 10
+			}
+			cells[i] = activeStyle.Render(fmt.Sprintf("%-10s", label)) + " " + ti.View()
+			continue
+		}
+		value := ti.Value()
+		if value != "" && layout.Reveal == config.MnemonicRevealWord {
+			value = mnemonicMask
+		}
+		cells[i] = inactiveStyle.Render(fmt.Sprintf("%-10s", label)) + " " + value
+	}
+	return mnemonicGrid(cells, layout.WordsPerRow)
+}
+
+// mnemonicGrid places cells in rows of perRow, aligned in columns
+func mnemonicGrid(cells []string, perRow int) string {
+	if perRow < 1 {
+		perRow = 1
+	}
+	width := 0
+	for _, cell := range cells {
+		width = max(width, lipgloss.Width(cell))
+	}
+	column := lipgloss.NewStyle().Width(width + 3)
+
+	var b strings.Builder
+	for start := 0; start < len(cells); start += perRow {
+		end := min(start+perRow, len(cells))
+		row := make([]string, 0, end-start)
+		for _, cell := range cells[start:end] {
+			row = append(row, column.Render(cell))
+		}
+		b.WriteString(strings.TrimRight(lipgloss.JoinHorizontal(lipgloss.Top, row...), " "))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
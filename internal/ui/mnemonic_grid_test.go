@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gridPhrase = "abandon ability able about above absent absorb abstract absurd abuse access accident"
+
+func TestRenderMnemonic_Grid(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	m := &CLIModel{styles: createStyles(), currentConfig: &config.Config{Mnemonic: config.MnemonicConfig{WordsPerRow: 3, Reveal: config.MnemonicRevealAll}}}
+
+	lines := strings.Split(strings.TrimRight(m.renderMnemonic(gridPhrase), "\n"), "\n")
+	require.Len(t, lines, 4, "12 words in rows of 3")
+	assert.Contains(t, lines[0], " 1. abandon")
+	assert.Contains(t, lines[0], " 3. able")
+	assert.Contains(t, lines[3], "12. accident")
+	// Columns line up
+	assert.Equal(t, strings.Index(lines[0], "2."), strings.Index(lines[1], "5."))
+
+	m.currentConfig.Mnemonic.WordsPerRow = 6
+	assert.Len(t, strings.Split(strings.TrimRight(m.renderMnemonic(gridPhrase), "\n"), "\n"), 2)
+}
+
+func TestRenderMnemonic_OneWordAtATime(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddMnemonicMessages()
+	m := &CLIModel{styles: createStyles(), currentConfig: &config.Config{Mnemonic: config.MnemonicConfig{WordsPerRow: 4, Reveal: config.MnemonicRevealWord}}}
+	m.mnemonic = gridPhrase
+	m.currentView = constants.CreateWalletView
+
+	view := m.renderMnemonic(gridPhrase)
+	assert.Contains(t, view, "abandon")
+	assert.NotContains(t, view, "ability")
+	assert.Contains(t, view, "Word 1 of 12")
+
+	_, _ = m.updateCreateWalletPassword(tea.KeyMsg{Type: tea.KeyTab})
+	view = m.renderMnemonic(gridPhrase)
+	assert.NotContains(t, view, "abandon")
+	assert.Contains(t, view, "ability")
+
+	// shift+tab from the first word wraps to the last
+	_, _ = m.updateCreateWalletPassword(tea.KeyMsg{Type: tea.KeyShiftTab})
+	_, _ = m.updateCreateWalletPassword(tea.KeyMsg{Type: tea.KeyShiftTab})
+	assert.Contains(t, m.renderMnemonic(gridPhrase), "accident")
+	assert.Empty(t, m.passwordInput.Value(), "tab does not reach the password")
+}
+
+func TestRenderMnemonicInputs(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddMnemonicMessages()
+	m := newMnemonicImportModel()
+	m.currentConfig = &config.Config{Mnemonic: config.MnemonicConfig{WordsPerRow: 4, Reveal: config.MnemonicRevealWord}}
+	m.textInputs[0].SetValue("abandon")
+	m.textInputs[0].Blur()
+	m.importStage = 1
+	m.textInputs[1].Focus()
+	m.textInputs[1].SetValue("abil")
+
+	grid := m.renderMnemonicInputs(m.textInputs, m.importStage, lipgloss.NewStyle(), lipgloss.NewStyle())
+	assert.Len(t, strings.Split(strings.TrimRight(grid, "\n"), "\n"), 3)
+	assert.NotContains(t, grid, "abandon", "typed words are masked")
+	assert.Contains(t, grid, mnemonicMask)
+	assert.Contains(t, grid, "abil", "the word being typed stays visible")
+}
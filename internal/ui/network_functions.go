@@ -62,6 +62,7 @@ func (m *CLIModel) initNetworkList() {
 func (m *CLIModel) initAddNetwork() {
 	// Initialize the add network component if it hasn't been initialized yet
 	m.addNetworkComponent = NewAddNetworkComponent()
+	m.editingNetworkKey = ""
 
 	// Ensure configuration and networks are loaded
 	if err := m.ensureConfigAndNetworksLoaded(); err != nil {
@@ -83,8 +84,8 @@ func (m *CLIModel) viewNetworkList() string {
 		m.networkListComponent.SetSize(m.width, m.height)
 	}
 
-	// Render the component
-	return m.networkListComponent.View()
+	// Render the component, with the removal confirmation below it
+	return m.networkListComponent.View() + m.renderNetworkDelete()
 }
 
 // viewAddNetwork renders the add network view
@@ -102,6 +103,9 @@ func (m *CLIModel) updateNetworkList(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.networkDeleteKey != "" {
+			return m, m.updateNetworkDelete(msg)
+		}
 		switch msg.String() {
 		case "a":
 			// Add a new network
@@ -135,14 +139,9 @@ func (m *CLIModel) updateNetworkList(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
-			// Initialize add network component for editing
+			// Initialize add network component for editing, pre-filled with the network
 			m.addNetworkComponent = NewAddNetworkComponent()
-
-			// Pre-fill the form with existing network data
-			m.addNetworkComponent.nameInput.SetValue(network.Name)
-			m.addNetworkComponent.chainIDInput.SetValue(strconv.FormatInt(network.ChainID, 10))
-			m.addNetworkComponent.symbolInput.SetValue(network.Symbol)
-			m.addNetworkComponent.rpcEndpointInput.SetValue(network.RPCEndpoint)
+			m.addNetworkComponent.EditNetwork(network)
 
 			// Store the key for updating later
 			m.editingNetworkKey = key
@@ -151,6 +150,16 @@ func (m *CLIModel) updateNetworkList(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.currentView = constants.AddNetworkView
 			return m, nil
 
+		case "t":
+			// Mark the selected network as a test network or a mainnet
+			key := m.networkListComponent.GetSelectedNetworkKey()
+			if key == "" {
+				m.networkListComponent.SetError(errors.New(localization.Labels["no_network_selected"]))
+				return m, nil
+			}
+			m.toggleNetworkTestnet(key)
+			return m, nil
+
 		case "d":
 			// Delete the selected network
 			key := m.networkListComponent.GetSelectedNetworkKey()
@@ -171,24 +180,14 @@ func (m *CLIModel) updateNetworkList(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
-			// Remove the network using NetworkManager
-			err := removeNetworkWithManager(key)
-			if err != nil {
-				m.networkListComponent.SetError(fmt.Errorf("failed to remove network: %v", err))
-				return m, nil
-			}
-
-			// Reload configuration to get the updated networks
-			if err := m.ensureConfigAndNetworksLoaded(); err != nil {
-				m.networkListComponent.SetError(fmt.Errorf("failed to reload configuration: %v", err))
-				return m, nil
-			}
-
-			// Update the network list
-			m.networkListComponent.UpdateNetworks(m.currentConfig)
-
+			// Ask before removing, with what the wallets still have on it
+			m.openNetworkDelete(key)
 			return m, nil
 
+		case "v":
+			// Verify the selected network's endpoint again
+			return m, m.verifyNetworkCmd(m.networkListComponent.GetSelectedNetworkKey())
+
 		case "esc", "backspace":
 			// Return to the network menu
 			m.menuItems = NewNetworkMenu()
@@ -300,6 +299,10 @@ func (m *CLIModel) updateAddNetwork(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.editingNetworkKey != "" {
+			return m, m.saveEditedNetwork(network)
+		}
+
 		// Add the network using NetworkManager with classification info
 		classificationInfo, err := addNetworkWithClassificationInfo(network)
 		if err != nil {
@@ -356,3 +359,171 @@ func (m *CLIModel) updateAddNetwork(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	return m, cmd
 }
+
+// saveEditedNetwork replaces the network being edited, keeping its state
+// such as whether it is active, and returns to the list
+func (m *CLIModel) saveEditedNetwork(edited config.Network) tea.Cmd {
+	network, exists := m.currentConfig.Networks[m.editingNetworkKey]
+	if !exists {
+		m.addNetworkComponent.SetError(fmt.Errorf("network not found"))
+		return nil
+	}
+	network.Name = edited.Name
+	network.RPCEndpoint = edited.RPCEndpoint
+	network.ChainID = edited.ChainID
+	network.Symbol = edited.Symbol
+	if err := getNetworkManager().UpdateNetwork(m.editingNetworkKey, network); err != nil {
+		m.addNetworkComponent.SetError(fmt.Errorf("failed to update network: %v", err))
+		return nil
+	}
+	if err := m.ensureConfigAndNetworksLoaded(); err != nil {
+		m.addNetworkComponent.SetError(fmt.Errorf("failed to reload configuration: %v", err))
+		return nil
+	}
+	m.editingNetworkKey = ""
+	m.networkListComponent.UpdateNetworks(m.currentConfig)
+	m.networkListComponent.SetNotice(fmt.Sprintf(localization.Labels["network_updated"], network.Name))
+	m.currentView = constants.NetworkListView
+	return nil
+}
+
+// toggleNetworkTestnet flips the testnet flag of a network, for the networks
+// the chain ID and name did not detect right, and keeps it selected
+func (m *CLIModel) toggleNetworkTestnet(key string) {
+	network, exists := m.currentConfig.Networks[key]
+	if !exists {
+		m.networkListComponent.SetError(fmt.Errorf("network not found"))
+		return
+	}
+	network.Testnet = !network.Testnet
+	if err := getNetworkManager().UpdateNetwork(key, network); err != nil {
+		m.networkListComponent.SetError(fmt.Errorf("failed to update network: %v", err))
+		return
+	}
+	if err := m.ensureConfigAndNetworksLoaded(); err != nil {
+		m.networkListComponent.SetError(fmt.Errorf("failed to reload configuration: %v", err))
+		return
+	}
+	m.networkListComponent.UpdateNetworks(m.currentConfig)
+	m.networkListComponent.SelectNetwork(key)
+	notice := "network_marked_mainnet"
+	if network.Testnet {
+		notice = "network_marked_testnet"
+	}
+	m.networkListComponent.SetNotice(fmt.Sprintf(localization.Labels[notice], network.Name))
+}
+
+// closeNetworkEdit discards the edited values and returns to the list
+func (m *CLIModel) closeNetworkEdit() {
+	m.editingNetworkKey = ""
+	m.currentView = constants.NetworkListView
+}
+
+// networkUsage is what the wallets still have on a network about to be removed
+type networkUsage struct {
+	Funded  int // wallets with a non-zero cached balance on its chain
+	Pending int // open tracked transactions sent on its chain
+}
+
+// inUse reports whether removing the network needs to be forced
+func (u networkUsage) inUse() bool {
+	return u.Funded > 0 || u.Pending > 0
+}
+
+// String lists the usage for the confirmation
+func (u networkUsage) String() string {
+	var parts []string
+	if u.Funded > 0 {
+		parts = append(parts, fmt.Sprintf(localization.Labels["network_delete_balances"], u.Funded))
+	}
+	if u.Pending > 0 {
+		parts = append(parts, fmt.Sprintf(localization.Labels["network_delete_pending"], u.Pending))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// networkUsageOf counts the balances seen and the pending transactions on the
+// chain of the network. None are counted while another configured network
+// reaches the same chain.
+func (m *CLIModel) networkUsageOf(key string) networkUsage {
+	network := m.currentConfig.Networks[key]
+	for other, n := range m.currentConfig.Networks {
+		if other != key && n.ChainID == network.ChainID {
+			return networkUsage{}
+		}
+	}
+	var usage networkUsage
+	if m.balanceCache != nil {
+		usage.Funded = len(m.balanceCache.FundedAddresses(network.ChainID))
+	}
+	if m.Service != nil {
+		if txs, err := m.Service.TrackedTransactions(true); err == nil {
+			for _, tx := range txs {
+				if tx.ChainID == network.ChainID {
+					usage.Pending++
+				}
+			}
+		}
+	}
+	return usage
+}
+
+// openNetworkDelete asks to confirm the removal of the network
+func (m *CLIModel) openNetworkDelete(key string) {
+	m.networkDeleteKey = key
+	m.networkDeleteUsage = m.networkUsageOf(key)
+}
+
+// closeNetworkDelete keeps the network
+func (m *CLIModel) closeNetworkDelete() {
+	m.networkDeleteKey = ""
+	m.networkDeleteUsage = networkUsage{}
+}
+
+// updateNetworkDelete confirms the removal; a network the wallets still use
+// is removed only with f
+func (m *CLIModel) updateNetworkDelete(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "y", "enter":
+		if !m.networkDeleteUsage.inUse() {
+			m.removeNetwork(m.networkDeleteKey)
+		}
+	case "f":
+		m.removeNetwork(m.networkDeleteKey)
+	case "n":
+		m.closeNetworkDelete()
+	}
+	return nil
+}
+
+// removeNetwork removes the network from the configuration and reloads the list
+func (m *CLIModel) removeNetwork(key string) {
+	name := m.currentConfig.Networks[key].Name
+	m.closeNetworkDelete()
+	if err := removeNetworkWithManager(key); err != nil {
+		m.networkListComponent.SetError(fmt.Errorf("failed to remove network: %v", err))
+		return
+	}
+	if err := m.ensureConfigAndNetworksLoaded(); err != nil {
+		m.networkListComponent.SetError(fmt.Errorf("failed to reload configuration: %v", err))
+		return
+	}
+	m.networkListComponent.UpdateNetworks(m.currentConfig)
+	m.networkListComponent.SetNotice(fmt.Sprintf(localization.Labels["network_removed"], name))
+}
+
+// renderNetworkDelete renders the removal confirmation of the network list
+func (m *CLIModel) renderNetworkDelete() string {
+	if m.networkDeleteKey == "" {
+		return ""
+	}
+	network := m.currentConfig.Networks[m.networkDeleteKey]
+	lines := []string{fmt.Sprintf(localization.Labels["network_delete_confirm"], network.Name, network.ChainID)}
+	help := localization.Labels["network_delete_help"]
+	if m.networkDeleteUsage.inUse() {
+		lines = append(lines, m.styles.ErrorStyle.Render(glyphs.Warning+" "+fmt.Sprintf(localization.Labels["network_delete_in_use"], network.Name, m.networkDeleteUsage)))
+		help = localization.Labels["network_delete_force_help"]
+	}
+	lines = append(lines, m.styles.MenuDesc.Render(help))
+	return "\n\n" + m.styles.Dialog.Render(strings.Join(lines, "\n"))
+}
@@ -1,13 +1,21 @@
 package ui
 
 import (
+	"blocowallet/internal/constants"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
 	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSaveConfigToFile(t *testing.T) {
@@ -173,3 +181,151 @@ func TestSanitizeNetworkKey(t *testing.T) {
 		assert.Equal(t, tc.expected, result)
 	}
 }
+
+// newNetworkListModel opens the network list over a configuration with two
+// networks, classified offline so nothing is looked up on ChainList
+func newNetworkListModel(t *testing.T) *CLIModel {
+	t.Helper()
+	localization.SetCurrentLanguage("en")
+	if localization.Labels == nil {
+		localization.Labels = make(map[string]string)
+	}
+	for k, v := range localization.DefaultNetworkMessagesEnglish() {
+		localization.Labels[k] = v
+	}
+
+	dir := t.TempDir()
+	t.Setenv("BLOCO_WALLET_APP_APP_DIR", dir)
+	globalConfigManager = nil
+	// The edited endpoints answer with the chain ID of the network, and no
+	// chain is known upstream so the networks stay custom
+	chainList := &MockChainListService{}
+	chainList.On("ValidateRPCEndpoint", mock.Anything).Return(nil).Maybe()
+	chainList.On("GetChainIDFromRPC", mock.Anything).Return(4242, nil).Maybe()
+	chainList.On("GetChainInfo", mock.Anything).Return(nil, assert.AnError).Maybe()
+	chainList.On("GetChainInfoWithRetry", mock.Anything).Return(nil, "", assert.AnError).Maybe()
+	globalNetworkManager = NewNetworkManager(getConfigurationManager(), chainList)
+	t.Cleanup(func() {
+		globalConfigManager = nil
+		globalNetworkManager = nil
+	})
+
+	cfg, err := loadOrCreateConfig()
+	require.NoError(t, err)
+	cfg.Networks = map[string]config.Network{
+		"custom_devnet_1337":  {Name: "Devnet", ChainID: 1337, Symbol: "DEV", RPCEndpoint: "http://127.0.0.1:8545", IsActive: true},
+		"custom_testnet_4242": {Name: "Testnet", ChainID: 4242, Symbol: "TST", RPCEndpoint: "http://127.0.0.1:9545"},
+	}
+	require.NoError(t, getConfigurationManager().SaveConfiguration(cfg))
+
+	repo, err := storage.NewWalletRepository(&config.Config{AppDir: dir, DatabasePath: filepath.Join(dir, "wallets.db")})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = repo.Close() })
+
+	m := &CLIModel{Service: &wallet.WalletService{Repo: repo}, styles: createStyles(), width: 160, height: 50}
+	m.initNetworkList()
+	require.Equal(t, constants.NetworkListView, m.currentView)
+	return m
+}
+
+// selectNetwork moves the network list cursor to the network named name
+func selectNetwork(t *testing.T, m *CLIModel, name string) {
+	t.Helper()
+	for i, row := range m.networkListComponent.table.Rows() {
+		if row[1] == name {
+			m.networkListComponent.table.SetCursor(i)
+			return
+		}
+	}
+	t.Fatalf("network %s not listed", name)
+}
+
+func TestNetworkList_EditKeepsTheNetwork(t *testing.T) {
+	m := newNetworkListModel(t)
+	selectNetwork(t, m, "Testnet")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	require.Equal(t, constants.AddNetworkView, m.currentView)
+	assert.Equal(t, "custom_testnet_4242", m.editingNetworkKey)
+	assert.Equal(t, "http://127.0.0.1:9545", m.addNetworkComponent.GetRPCEndpoint())
+	assert.Contains(t, m.viewAddNetwork(), "Edit Network")
+	assert.True(t, m.capturesTextInput(), "q is typed into the form")
+
+	// Saving replaces the network and keeps it inactive
+	_, _ = m.Update(AddNetworkRequestMsg{Name: "Testnet", ChainID: "4242", Symbol: "TST", RPCEndpoint: "http://127.0.0.1:7545"})
+	require.Equal(t, constants.NetworkListView, m.currentView)
+	assert.Empty(t, m.editingNetworkKey)
+	require.Len(t, m.currentConfig.Networks, 2)
+	edited := m.currentConfig.Networks["custom_testnet_4242"]
+	assert.Equal(t, "http://127.0.0.1:7545", edited.RPCEndpoint)
+	assert.False(t, edited.IsActive)
+	assert.Contains(t, m.viewNetworkList(), "Testnet updated")
+
+	// esc leaves an edit without saving, back on the list
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.NetworkListView, m.currentView)
+	assert.Empty(t, m.editingNetworkKey)
+}
+
+func TestNetworkList_DeleteAsksFirst(t *testing.T) {
+	m := newNetworkListModel(t)
+	selectNetwork(t, m, "Testnet")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	assert.Contains(t, m.viewNetworkList(), "Remove Testnet (chain ID 4242) from the configuration?")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.NetworkListView, m.currentView)
+	assert.Len(t, m.currentConfig.Networks, 2, "esc keeps the network")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	assert.NotContains(t, m.currentConfig.Networks, "custom_testnet_4242")
+	assert.Contains(t, m.viewNetworkList(), "Testnet removed")
+}
+
+func TestNetworkList_DeleteInUseNeedsForce(t *testing.T) {
+	m := newNetworkListModel(t)
+	require.NoError(t, m.Service.Repo.(wallet.TrackedTransactionStore).AddTrackedTransaction(&wallet.TrackedTransaction{
+		Hash: "0x01", ChainID: 1337, Network: "Devnet", From: "0x02", RawTx: "0x", State: string(wallet.TrackedPending), SubmittedAt: time.Now(),
+	}))
+	selectNetwork(t, m, "Devnet")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	view := m.viewNetworkList()
+	assert.Contains(t, view, "Wallets still depend on Devnet: 1 pending transactions")
+	assert.Contains(t, view, "f: remove anyway")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Contains(t, m.currentConfig.Networks, "custom_devnet_1337", "enter does not remove it")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	assert.NotContains(t, m.currentConfig.Networks, "custom_devnet_1337")
+}
+
+func TestNetworkList_TestnetsAfterMainnets(t *testing.T) {
+	m := newNetworkListModel(t)
+	rows := m.networkListComponent.table.Rows()
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{"Devnet", "Testnet"}, []string{rows[0][1], rows[1][1]})
+
+	// t marks the selected network as a test network; it moves after the mainnets
+	selectNetwork(t, m, "Devnet")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	assert.True(t, m.currentConfig.Networks["custom_devnet_1337"].Testnet)
+	rows = m.networkListComponent.table.Rows()
+	assert.Equal(t, []string{"Testnet", "Devnet"}, []string{rows[0][1], rows[1][1]})
+	assert.Equal(t, "Testnet", rows[1][3])
+	assert.Equal(t, "custom_devnet_1337", m.networkListComponent.GetSelectedNetworkKey(), "the network stays selected")
+	view := m.viewNetworkList()
+	assert.Contains(t, view, "Devnet marked as a test network")
+	assert.Contains(t, view, "Test network: its coins have no value")
+
+	// hide_testnets leaves it out of the list
+	cfg, err := getConfigurationManager().LoadConfiguration()
+	require.NoError(t, err)
+	assert.True(t, cfg.Networks["custom_devnet_1337"].Testnet, "the flag is saved")
+	m.currentConfig.Balances.HideTestnets = true
+	m.initNetworkList()
+	require.Len(t, m.networkListComponent.table.Rows(), 1)
+	assert.Contains(t, m.viewNetworkList(), "1 test networks hidden")
+}
@@ -0,0 +1,376 @@
+package ui
+
+import (
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/jobs"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Stages of a network import: the file form, running, the summary
+const (
+	networkImportStageForm = iota
+	networkImportStageRunning
+	networkImportStageDone
+)
+
+// networkImportResult is the outcome of the import for one chain of the file
+type networkImportResult struct {
+	Chain   blockchain.ChainInfo
+	Detail  string
+	Skipped bool
+	Err     error
+}
+
+// networkImportStepMsg carries the outcome of one chain of the running import
+type networkImportStepMsg struct {
+	seq     int
+	result  networkImportResult
+	updates <-chan networkImportResult
+}
+
+// networkImportDoneMsg tells the import stopped, after the last chain or when cancelled
+type networkImportDoneMsg struct {
+	seq int
+}
+
+// errNetworkImportNotProcessed marks the chains left when the import was stopped
+var errNetworkImportNotProcessed = errors.New("not processed")
+
+// initNetworkImport opens the form asking for the chains file
+func (m *CLIModel) initNetworkImport() tea.Cmd {
+	if err := m.ensureConfigAndNetworksLoaded(); err != nil {
+		m.err = err
+		m.currentView = constants.DefaultView
+		return nil
+	}
+	m.networkImportStage = networkImportStageForm
+	m.networkImportChains = nil
+	m.networkImportResults = nil
+	m.setNetworkImportStatus("", false)
+	m.networkImportInput = textinput.New()
+	m.networkImportInput.Placeholder = localization.Labels["network_import_placeholder"]
+	m.networkImportInput.CharLimit = 512
+	m.networkImportInput.Width = 60
+	m.currentView = constants.NetworkImportView
+	return m.networkImportInput.Focus()
+}
+
+// networkImportCapturesText reports whether keys go to the path field
+func (m *CLIModel) networkImportCapturesText() bool {
+	return m.networkImportStage == networkImportStageForm
+}
+
+// updateNetworkImport handles input on the network import screen
+func (m *CLIModel) updateNetworkImport(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch m.networkImportStage {
+	case networkImportStageRunning:
+		return m, nil
+	case networkImportStageDone:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "enter" {
+			m.initNetworkList()
+		}
+		return m, nil
+	}
+
+	if text, ok := pastedText(msg); ok {
+		m.networkImportInput.SetValue(cleanPastedPath(text))
+		m.networkImportInput.CursorEnd()
+		return m, nil
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "enter" {
+		return m, m.startNetworkImport()
+	}
+	var cmd tea.Cmd
+	m.networkImportInput, cmd = updateTextInput(m.networkImportInput, msg)
+	return m, cmd
+}
+
+// startNetworkImport reads the chains file and runs the import
+func (m *CLIModel) startNetworkImport() tea.Cmd {
+	path := strings.TrimSpace(m.networkImportInput.Value())
+	if path == "" {
+		m.setNetworkImportStatus(localization.Labels["network_import_path_required"], true)
+		return nil
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		m.setNetworkImportStatus(fmt.Sprintf(localization.Labels["network_import_read_failed"], err), true)
+		return nil
+	}
+	chains, err := blockchain.ParseChainsFile(data)
+	if err != nil {
+		m.setNetworkImportStatus(err.Error(), true)
+		return nil
+	}
+	m.networkImportChains = chains
+
+	updates, err := m.runNetworkImport()
+	if err != nil {
+		m.setNetworkImportStatus(err.Error(), true)
+		return nil
+	}
+	m.setNetworkImportStatus("", false)
+	m.networkImportStage = networkImportStageRunning
+	m.networkImportCancelling = false
+	m.networkImportProgress = NewImportProgressModel(len(chains), m.styles)
+	m.networkImportInput.Blur()
+	return waitForNetworkImport(m.networkImportSeq, updates)
+}
+
+// runNetworkImport submits the import to the job manager, so it shows in the
+// Jobs view and can be cancelled from there too. Each chain is reported on
+// the returned channel, closed once the import stops.
+func (m *CLIModel) runNetworkImport() (<-chan networkImportResult, error) {
+	chains := m.networkImportChains
+	nm := getNetworkManager()
+	configured := make(map[int64]string, len(m.currentConfig.Networks))
+	for _, network := range m.currentConfig.Networks {
+		configured[network.ChainID] = network.Name
+	}
+
+	m.networkImportSeq++
+	updates := make(chan networkImportResult, len(chains))
+	name := fmt.Sprintf("%s (%d)", localization.Labels["network_import_title"], len(chains))
+	id, err := m.jobManager.Submit(jobs.KindNetworkImport, name, func(ctx context.Context, report jobs.ProgressFunc) error {
+		defer close(updates)
+		for i, chain := range chains {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			report(float64(i)/float64(len(chains)), chainLabel(chain))
+			result := importChain(ctx, nm, chain, configured)
+			if result.Err == nil && !result.Skipped {
+				configured[int64(chain.ChainID)] = chain.Name
+			}
+			updates <- result
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, jobs.ErrConflict) {
+			err = errors.New(localization.Labels["job_conflict"])
+		}
+		return nil, err
+	}
+	m.networkImportJobID = id
+	return updates, nil
+}
+
+// importChain adds one chain of the file with the first of its RPCs that
+// answers with the chain's ID. Chains already configured are skipped, so the
+// file can be imported again after fixing the entries that failed.
+func importChain(ctx context.Context, nm *NetworkManager, chain blockchain.ChainInfo, configured map[int64]string) networkImportResult {
+	result := networkImportResult{Chain: chain}
+	name := strings.TrimSpace(chain.Name)
+	symbol := strings.TrimSpace(chain.NativeCurrency.Symbol)
+	switch {
+	case name == "" || chain.ChainID <= 0:
+		result.Err = errors.New(localization.Labels["network_import_invalid"])
+		return result
+	case configured[int64(chain.ChainID)] != "":
+		result.Skipped = true
+		result.Detail = fmt.Sprintf(localization.Labels["network_import_exists"], configured[int64(chain.ChainID)])
+		return result
+	case symbol == "":
+		result.Err = errors.New(localization.Labels["network_import_no_symbol"])
+		return result
+	}
+	rpcs := chain.ImportableRPCs()
+	if len(rpcs) == 0 {
+		result.Err = errors.New(localization.Labels["network_import_no_rpc"])
+		return result
+	}
+
+	var lastErr error
+	for _, rpc := range rpcs {
+		if err := ctx.Err(); err != nil {
+			result.Err = err
+			return result
+		}
+		network := config.Network{
+			Name:        name,
+			RPCEndpoint: rpc,
+			ChainID:     int64(chain.ChainID),
+			Symbol:      symbol,
+			IsActive:    true,
+			Testnet:     chain.Testnet(),
+		}
+		if err := nm.ValidateNetwork(network); err != nil {
+			lastErr = err
+			continue
+		}
+		if err := nm.AddNetwork(network); err != nil {
+			result.Err = err
+			return result
+		}
+		result.Detail = fmt.Sprintf(localization.Labels["network_import_added"], rpc)
+		return result
+	}
+	result.Err = fmt.Errorf(localization.Labels["network_import_rpc_failed"], chain.ChainID, lastErr)
+	return result
+}
+
+// chainLabel names a chain of the file in the progress and the summary
+func chainLabel(chain blockchain.ChainInfo) string {
+	if name := strings.TrimSpace(chain.Name); name != "" {
+		return name
+	}
+	return fmt.Sprintf("chain %d", chain.ChainID)
+}
+
+// waitForNetworkImport delivers the outcome of the next chain, or the end of
+// the import once its channel is closed
+func waitForNetworkImport(seq int, updates <-chan networkImportResult) tea.Cmd {
+	return func() tea.Msg {
+		result, ok := <-updates
+		if !ok {
+			return networkImportDoneMsg{seq: seq}
+		}
+		return networkImportStepMsg{seq: seq, result: result, updates: updates}
+	}
+}
+
+// handleNetworkImportStep shows the outcome of one chain and waits for the next
+func (m *CLIModel) handleNetworkImportStep(msg networkImportStepMsg) tea.Cmd {
+	if msg.seq == m.networkImportSeq {
+		m.networkImportResults = append(m.networkImportResults, msg.result)
+		label := chainLabel(msg.result.Chain)
+		m.networkImportProgress.UpdateProgress(label, len(m.networkImportResults))
+		if msg.result.Err != nil {
+			m.networkImportProgress.AddError(label, msg.result.Err, false)
+		} else if msg.result.Skipped {
+			m.networkImportProgress.AddError(label, errors.New(msg.result.Detail), true)
+		}
+	}
+	// Drained either way so the import never waits on the screen
+	return waitForNetworkImport(msg.seq, msg.updates)
+}
+
+// handleNetworkImportDone lists the chains a stopped import did not reach,
+// reloads the networks and shows the summary
+func (m *CLIModel) handleNetworkImportDone(msg networkImportDoneMsg) {
+	if msg.seq != m.networkImportSeq {
+		return
+	}
+	for _, chain := range m.networkImportChains[len(m.networkImportResults):] {
+		m.networkImportResults = append(m.networkImportResults, networkImportResult{Chain: chain, Skipped: true, Err: errNetworkImportNotProcessed})
+		m.networkImportProgress.AddError(chainLabel(chain), errNetworkImportNotProcessed, true)
+	}
+	m.networkImportProgress.Complete()
+	m.networkImportStage = networkImportStageDone
+	m.networkImportJobID = ""
+	if err := m.ensureConfigAndNetworksLoaded(); err != nil {
+		m.setNetworkImportStatus(err.Error(), true)
+	}
+}
+
+// closeNetworkImport handles esc: it cancels the running import after the
+// current chain, or goes back to the network menu
+func (m *CLIModel) closeNetworkImport() {
+	if m.networkImportStage == networkImportStageRunning {
+		if m.networkImportJobID != "" && !m.networkImportCancelling {
+			m.networkImportCancelling = true
+			_ = m.jobManager.Cancel(m.networkImportJobID)
+		}
+		return
+	}
+	m.menuItems = NewNetworkMenu()
+	m.selectedMenu = 0
+	m.currentView = constants.NetworkMenuView
+}
+
+func (m *CLIModel) setNetworkImportStatus(status string, failed bool) {
+	m.networkImportStatus = status
+	m.networkImportFailed = failed
+}
+
+// viewNetworkImport renders the file form, the progress and the summary
+func (m *CLIModel) viewNetworkImport() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["network_import_title"]))
+	b.WriteString("\n\n")
+
+	help := "network_import_form_help"
+	switch m.networkImportStage {
+	case networkImportStageForm:
+		b.WriteString(localization.Labels["network_import_prompt"])
+		b.WriteString("\n")
+		b.WriteString(m.networkImportInput.View())
+		b.WriteString("\n\n")
+	case networkImportStageRunning:
+		help = "network_import_running_help"
+		p := m.networkImportProgress
+		b.WriteString(fmt.Sprintf(localization.Labels["network_import_running"], len(m.networkImportChains)))
+		b.WriteString("\n\n")
+		b.WriteString(p.ViewAs(p.GetPercentage()))
+		b.WriteString(fmt.Sprintf("  %d/%d\n", len(m.networkImportResults), len(m.networkImportChains)))
+		if n := len(m.networkImportResults); n > 0 {
+			b.WriteString(m.styles.MenuDesc.Render(chainLabel(m.networkImportResults[n-1].Chain)))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		if m.networkImportCancelling {
+			b.WriteString(m.styles.MenuDesc.Render(localization.Labels["network_import_cancelling"]))
+			b.WriteString("\n\n")
+		}
+	case networkImportStageDone:
+		help = "network_import_done_help"
+		b.WriteString(m.viewNetworkImportSummary())
+	}
+
+	if m.networkImportStatus != "" {
+		if m.networkImportFailed {
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.networkImportStatus))
+		} else {
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.networkImportStatus))
+		}
+		b.WriteString("\n\n")
+	}
+	b.WriteString(m.styles.MenuDesc.Render(localization.Labels[help]))
+	return b.String()
+}
+
+// viewNetworkImportSummary lists how each chain went, as the batch import does
+func (m *CLIModel) viewNetworkImportSummary() string {
+	var b strings.Builder
+	failed := len(m.networkImportProgress.GetFailedErrors())
+	skipped := len(m.networkImportProgress.GetSkippedErrors())
+	title := m.styles.SuccessStyle.Render(glyphs.Check + " " + localization.Labels["network_import_done"])
+	if failed > 0 {
+		title = m.styles.ErrorStyle.Render(glyphs.Cross + " " + localization.Labels["network_import_done_errors"])
+	}
+	b.WriteString(title)
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["network_import_summary"], len(m.networkImportResults)-failed-skipped, failed, skipped))
+	b.WriteString("\n\n")
+	for _, result := range m.networkImportResults {
+		name := fmt.Sprintf("%-24s %8d", truncateRunes(chainLabel(result.Chain), 24), result.Chain.ChainID)
+		switch {
+		case errors.Is(result.Err, errNetworkImportNotProcessed):
+			b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf("– %s %s", name, localization.Labels["network_import_not_processed"])))
+		case result.Err != nil:
+			b.WriteString(m.styles.ErrorStyle.Render(fmt.Sprintf("%s %s %s", glyphs.Cross, name, result.Err)))
+		case result.Skipped:
+			b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf("– %s %s", name, result.Detail)))
+		default:
+			b.WriteString(m.styles.SuccessStyle.Render(fmt.Sprintf("%s %s %s", glyphs.Check, name, result.Detail)))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
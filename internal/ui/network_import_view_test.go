@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/jobs"
+	"blocowallet/pkg/localization"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkImport_ReportsEachChain(t *testing.T) {
+	m := newNetworkListModel(t)
+	localization.AddNetworkImportMessages()
+	m.jobManager = jobs.NewManager()
+
+	// Only the Gamma endpoint answers with the chain ID of its entry
+	chainList := &MockChainListService{}
+	chainList.On("ValidateRPCEndpoint", mock.Anything).Return(nil).Maybe()
+	chainList.On("GetChainIDFromRPC", "https://gamma.example").Return(5555, nil).Maybe()
+	chainList.On("GetChainIDFromRPC", mock.Anything).Return(1, nil).Maybe()
+	chainList.On("GetChainInfo", mock.Anything).Return(nil, assert.AnError).Maybe()
+	chainList.On("GetChainInfoWithRetry", mock.Anything).Return(nil, "", assert.AnError).Maybe()
+	globalNetworkManager = NewNetworkManager(getConfigurationManager(), chainList)
+
+	path := filepath.Join(t.TempDir(), "rpcs.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"name": "Devnet", "chainId": 1337, "nativeCurrency": {"symbol": "DEV"}, "rpc": ["http://127.0.0.1:8545"]},
+		{"name": "Gamma", "chainId": 5555, "nativeCurrency": {"symbol": "GAM"}, "rpc": [{"url": "https://bad.example"}, {"url": "https://gamma.example"}]},
+		{"name": "Keyed", "chainId": 6666, "nativeCurrency": {"symbol": "KEY"}, "rpc": ["https://keyed.example/${API_KEY}"]},
+		{"name": "Wrong", "chainId": 7777, "nativeCurrency": {"symbol": "WRG"}, "rpc": ["https://wrong.example"]}
+	]`), 0600))
+
+	m.menuItems = NewNetworkMenu()
+	m.selectedMenu = 2
+	m.currentView = constants.NetworkMenuView
+	batchKey(m, "enter")
+	require.Equal(t, constants.NetworkImportView, m.currentView)
+	assert.True(t, m.capturesTextInput())
+
+	m.networkImportInput.SetValue(filepath.Join(t.TempDir(), "missing.json"))
+	runBatchExportCmds(m, batchKey(m, "enter"))
+	assert.Contains(t, m.viewNetworkImport(), "Could not read the file")
+
+	m.networkImportInput.SetValue(path)
+	runBatchExportCmds(m, batchKey(m, "enter"))
+	require.Equal(t, networkImportStageDone, m.networkImportStage)
+	view := m.viewNetworkImport()
+	assert.Contains(t, view, "1 added, 2 failed, 1 skipped")
+	assert.Contains(t, view, "already configured as Devnet")
+	assert.Contains(t, view, "added with https://gamma.example")
+	assert.Contains(t, view, "no HTTP RPC without an API key")
+	assert.Contains(t, view, "no RPC answered with chain ID 7777")
+
+	job, err := m.jobManager.Get(m.jobManager.List()[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, jobs.KindNetworkImport, job.Kind)
+
+	// The added network is configured and listed
+	require.Len(t, m.currentConfig.Networks, 3)
+	batchKey(m, "enter")
+	require.Equal(t, constants.NetworkListView, m.currentView)
+	selectNetwork(t, m, "Gamma")
+}
+
+func TestNetworkImport_InvalidFile(t *testing.T) {
+	m := newNetworkListModel(t)
+	localization.AddNetworkImportMessages()
+	m.jobManager = jobs.NewManager()
+	m.initNetworkImport()
+	require.Equal(t, constants.NetworkImportView, m.currentView)
+
+	path := filepath.Join(t.TempDir(), "rpcs.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[]`), 0600))
+	m.networkImportInput.SetValue(path)
+	runBatchExportCmds(m, batchKey(m, "enter"))
+	assert.Equal(t, networkImportStageForm, m.networkImportStage)
+	assert.Contains(t, m.viewNetworkImport(), "the file lists no chains")
+	assert.Empty(t, m.jobManager.List())
+
+	batchKey(m, "esc")
+	assert.Equal(t, constants.NetworkMenuView, m.currentView)
+}
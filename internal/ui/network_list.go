@@ -5,6 +5,7 @@ import (
 	"blocowallet/pkg/config"
 	"blocowallet/pkg/localization"
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/charmbracelet/bubbles/table"
@@ -19,6 +20,10 @@ type NetworkListComponent struct {
 	height int
 	table  table.Model
 	err    error
+	notice string // outcome of the last action, shown when there is no error
+	// Test networks left out of the table by hide_testnets
+	hiddenTestnets int
+	testnets       map[string]bool // keys of the listed test networks
 
 	// Cached classification info to avoid network calls during View rendering
 	networksInfo map[string]NetworkInfo
@@ -44,6 +49,7 @@ func (c *NetworkListComponent) initTable() {
 		{Title: "#", Width: 4},
 		{Title: localization.Labels["network_name"], Width: 18},
 		{Title: "Type", Width: 12},
+		{Title: localization.Labels["network_kind"], Width: 9},
 		{Title: localization.Labels["chain_id"], Width: 10},
 		{Title: localization.Labels["symbol"], Width: 8},
 		{Title: localization.Labels["status"], Width: 10},
@@ -62,12 +68,12 @@ func (c *NetworkListComponent) initTable() {
 	s := table.DefaultStyles()
 	s.Header = s.Header.
 		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		BorderForeground(palette.Subtle).
 		BorderBottom(true).
 		Bold(true)
 	s.Selected = s.Selected.
-		Foreground(lipgloss.Color("229")).
-		Background(lipgloss.Color("57")).
+		Foreground(palette.SelectionFg).
+		Background(palette.SelectionBg).
 		Bold(true)
 	t.SetStyles(s)
 
@@ -93,6 +99,12 @@ func (c *NetworkListComponent) SetError(err error) {
 	c.err = err
 }
 
+// SetNotice shows an informational message, clearing the error
+func (c *NetworkListComponent) SetNotice(notice string) {
+	c.err = nil
+	c.notice = notice
+}
+
 // UpdateNetworks updates the table with networks from the configuration
 func (c *NetworkListComponent) UpdateNetworks(cfg *config.Config) {
 	// Ensure cfg and cfg.Networks are not nil
@@ -110,36 +122,72 @@ func (c *NetworkListComponent) UpdateNetworks(cfg *config.Config) {
 	// Cache to avoid repeated network calls during table navigation/render
 	c.networksInfo = networksWithInfo
 
+	// Mainnets come first and test networks after them, each by name, so
+	// their coins are not mistaken for each other
+	keys := make([]string, 0, len(cfg.Networks))
+	c.hiddenTestnets = 0
+	c.testnets = make(map[string]bool)
+	for key, network := range cfg.Networks {
+		switch {
+		case network.Testnet && cfg.Balances.HideTestnets:
+			c.hiddenTestnets++
+			continue
+		case network.Testnet:
+			c.testnets[key] = true
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := cfg.Networks[keys[i]], cfg.Networks[keys[j]]
+		if a.Testnet != b.Testnet {
+			return !a.Testnet
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return keys[i] < keys[j]
+	})
+
 	var rows []table.Row
 
 	i := 1
-	for key, network := range cfg.Networks {
+	for _, key := range keys {
+		network := cfg.Networks[key]
 		status := localization.Labels["inactive"]
-		if network.IsActive {
+		switch {
+		case network.Quarantine != "":
+			status = localization.Labels["quarantined"]
+		case network.IsActive:
 			status = localization.Labels["active"]
 		}
 
 		// Get network type and source information
 		networkType := "Custom"
-		typeIcon := "🔧"
+		typeIcon := glyphs.Custom
 		if networkInfo, exists := networksWithInfo[key]; exists {
 			switch networkInfo.Type {
 			case blockchain.NetworkTypeStandard:
 				networkType = "Standard"
-				typeIcon = "✅"
+				typeIcon = glyphs.Standard
 				if networkInfo.IsValidated {
-					networkType = "Standard ✓"
+					networkType = "Standard " + glyphs.Check
 				}
 			case blockchain.NetworkTypeCustom:
 				networkType = "Custom"
-				typeIcon = "🔧"
+				typeIcon = glyphs.Custom
 			}
 		}
 
+		kind := localization.Labels["mainnet"]
+		if network.Testnet {
+			kind = localization.Labels["testnet"]
+		}
+
 		rows = append(rows, table.Row{
 			strconv.Itoa(i),
 			network.Name,
 			fmt.Sprintf("%s %s", typeIcon, networkType),
+			kind,
 			strconv.FormatInt(network.ChainID, 10),
 			network.Symbol,
 			status,
@@ -164,11 +212,21 @@ func (c *NetworkListComponent) GetSelectedNetworkKey() string {
 	}
 
 	selectedRow := c.table.SelectedRow()
-	if len(selectedRow) < 7 {
+	if len(selectedRow) < 8 {
 		return ""
 	}
 
-	return selectedRow[6] // Network key is stored in the hidden column (now index 6)
+	return selectedRow[7] // Network key is stored in the hidden column (now index 7)
+}
+
+// SelectNetwork moves the cursor to the network stored under key, when listed
+func (c *NetworkListComponent) SelectNetwork(key string) {
+	for i, row := range c.table.Rows() {
+		if len(row) > 7 && row[7] == key {
+			c.table.SetCursor(i)
+			return
+		}
+	}
 }
 
 // GetSelectedNetworkInfo returns detailed information about the selected network
@@ -207,8 +265,8 @@ func (c *NetworkListComponent) View() string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FFF")).
-		Background(lipgloss.Color("#874BFD")).
+		Foreground(palette.SelectionFg).
+		Background(palette.Accent).
 		MarginLeft(2).
 		MarginBottom(1)
 	content = headerStyle.Render("🌐 " + localization.Labels["networks"])
@@ -222,14 +280,27 @@ func (c *NetworkListComponent) View() string {
 		content += "No networks found. Add a network to get started."
 	}
 	content += "\n\n"
+	if c.hiddenTestnets > 0 {
+		hiddenStyle := lipgloss.NewStyle().
+			Foreground(palette.Muted).
+			MarginLeft(2)
+		content += hiddenStyle.Render(fmt.Sprintf(localization.Labels["network_testnets_hidden"], c.hiddenTestnets))
+		content += "\n\n"
+	}
 
 	// Error message
 	if c.err != nil {
 		errorStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF0000")).
+			Foreground(palette.Error).
 			MarginLeft(2)
 		content += errorStyle.Render(fmt.Sprintf("❌ %s", c.err.Error()))
 		content += "\n\n"
+	} else if c.notice != "" {
+		noticeStyle := lipgloss.NewStyle().
+			Foreground(palette.Success).
+			MarginLeft(2)
+		content += noticeStyle.Render(c.notice)
+		content += "\n\n"
 	}
 
 	// Selected network details
@@ -237,8 +308,8 @@ func (c *NetworkListComponent) View() string {
 		selectedNetworkInfo, err := c.GetSelectedNetworkInfo()
 		if err == nil && selectedNetworkInfo != nil {
 			detailStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#DDDDDD")).
-				Background(lipgloss.Color("#333333")).
+				Foreground(palette.Text).
+				Background(palette.Surface).
 				Padding(0, 1).
 				MarginLeft(2).
 				MarginBottom(1)
@@ -259,21 +330,33 @@ func (c *NetworkListComponent) View() string {
 			content += detailStyle.Render(details)
 			content += "\n"
 		}
+		if c.testnets[c.GetSelectedNetworkKey()] {
+			testnetStyle := lipgloss.NewStyle().
+				Foreground(palette.Warning).
+				MarginLeft(2).
+				MarginBottom(1)
+			note := "🧪 " + localization.Labels["network_testnet_note"]
+			if err == nil && selectedNetworkInfo.ChainInfo != nil && len(selectedNetworkInfo.ChainInfo.Faucets) > 0 {
+				note += " • " + fmt.Sprintf(localization.Labels["network_faucet"], selectedNetworkInfo.ChainInfo.Faucets[0])
+			}
+			content += testnetStyle.Render(note)
+			content += "\n"
+		}
 	}
 
 	// Network type legend
 	legendStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888888")).
+		Foreground(palette.Muted).
 		MarginLeft(2).
 		MarginBottom(1)
 
-	legend := "Network Types: ✅ Standard (ChainList verified) • 🔧 Custom (Manual configuration)"
+	legend := fmt.Sprintf("Network Types: %s Standard (ChainList verified) %s %s Custom (Manual configuration)", glyphs.Standard, glyphs.Bullet, glyphs.Custom)
 	content += legendStyle.Render(legend)
 	content += "\n"
 
 	// Instructions
 	infoStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#666666")).
+		Foreground(palette.Muted).
 		MarginLeft(2)
 
 	content += infoStyle.Render(localization.Labels["network_list_instructions"])
@@ -281,12 +364,14 @@ func (c *NetworkListComponent) View() string {
 
 	// Footer
 	footerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#874BFD")).
+		Foreground(palette.Accent).
 		MarginTop(1)
 
 	footer := footerStyle.Render("a: " + localization.Labels["add_network"] + " • ")
 	footer += footerStyle.Render("e: " + localization.Labels["edit_network"] + " • ")
 	footer += footerStyle.Render("d: " + localization.Labels["delete_network"] + " • ")
+	footer += footerStyle.Render("v: " + localization.Labels["verify_network"] + " • ")
+	footer += footerStyle.Render("t: " + localization.Labels["toggle_testnet"] + " • ")
 	footer += footerStyle.Render("esc: " + localization.Labels["back"])
 
 	content += footer
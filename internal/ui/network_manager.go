@@ -70,6 +70,12 @@ func (nm *NetworkManager) AddNetwork(network config.Network) error {
 		}
 	}
 
+	// Flag test networks from the chain metadata, or from the chain ID and name
+	if !network.Testnet {
+		network.Testnet = config.LooksLikeTestnet(network.ChainID, network.Name) ||
+			(classification.ChainInfo != nil && classification.ChainInfo.Testnet())
+	}
+
 	// Check if network already exists
 	if _, exists := cfg.Networks[classification.Key]; exists {
 		return fmt.Errorf("network with key '%s' already exists", classification.Key)
@@ -135,6 +141,25 @@ func (nm *NetworkManager) UpdateNetwork(key string, network config.Network) erro
 	return nil
 }
 
+// SetQuarantine records why strict RPC mode disabled a network; an empty
+// reason releases it
+func (nm *NetworkManager) SetQuarantine(key, reason string) error {
+	cfg, err := nm.configManager.LoadConfiguration()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	network, exists := cfg.Networks[key]
+	if !exists {
+		return fmt.Errorf("network with key '%s' not found", key)
+	}
+	network.Quarantine = reason
+	cfg.Networks[key] = network
+	if err := nm.configManager.SaveConfiguration(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	return nil
+}
+
 // RemoveNetwork removes a network from the configuration
 func (nm *NetworkManager) RemoveNetwork(key string) error {
 	// Load current configuration
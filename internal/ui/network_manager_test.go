@@ -182,6 +182,20 @@ func TestNetworkManager_AddNetwork_Custom(t *testing.T) {
 	savedConfig := mockConfigManager.config
 	assert.NotNil(t, savedConfig)
 	assert.Contains(t, savedConfig.Networks, "custom_test_network_12345")
+	assert.False(t, savedConfig.Networks["custom_test_network_12345"].Testnet)
+}
+
+func TestNetworkManager_AddNetwork_DetectsTestnet(t *testing.T) {
+	mockConfigManager := &MockConfigurationManager{}
+	mockChainListService := &MockChainListService{}
+	cfg := &config.Config{Networks: make(map[string]config.Network)}
+	mockConfigManager.On("LoadConfiguration").Return(cfg, nil)
+	mockConfigManager.On("SaveConfiguration", mock.AnythingOfType("*config.Config")).Return(nil)
+	mockChainListService.On("GetChainInfo", 31337).Return(nil, assert.AnError)
+
+	nm := NewNetworkManager(mockConfigManager, mockChainListService)
+	assert.NoError(t, nm.AddNetwork(config.Network{Name: "Anvil", RPCEndpoint: "http://127.0.0.1:8545", ChainID: 31337, Symbol: "ETH", IsActive: true}))
+	assert.True(t, mockConfigManager.config.Networks["custom_anvil_31337"].Testnet)
 }
 
 func TestNetworkManager_RemoveNetwork(t *testing.T) {
@@ -216,6 +230,26 @@ func TestNetworkManager_RemoveNetwork(t *testing.T) {
 	assert.NotContains(t, savedConfig.Networks, "custom_my_network_12345")
 }
 
+func TestNetworkManager_SetQuarantine(t *testing.T) {
+	mockConfigManager := &MockConfigurationManager{}
+	cfg := &config.Config{
+		Networks: map[string]config.Network{
+			"ethereum": {Name: "Ethereum", RPCEndpoint: "https://rpc.example.com", ChainID: 1, IsActive: true},
+		},
+	}
+	mockConfigManager.On("LoadConfiguration").Return(cfg, nil)
+	mockConfigManager.On("SaveConfiguration", mock.AnythingOfType("*config.Config")).Return(nil)
+	nm := NewNetworkManager(mockConfigManager, &MockChainListService{})
+
+	assert.NoError(t, nm.SetQuarantine("ethereum", "chain ID is 10, expected 1"))
+	saved := mockConfigManager.config.Networks["ethereum"]
+	assert.Equal(t, "chain ID is 10, expected 1", saved.Quarantine)
+	assert.True(t, saved.IsActive, "releasing the quarantine restores the network as it was")
+	assert.False(t, saved.Usable())
+
+	assert.Error(t, nm.SetQuarantine("missing", "reason"))
+}
+
 // Integration test using real ConfigurationManager
 func TestNetworkManager_Integration(t *testing.T) {
 	// Create a temporary directory for the test
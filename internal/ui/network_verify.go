@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"blocowallet/internal/blockchain"
+	"blocowallet/pkg/localization"
+	"blocowallet/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// networkVerifiedMsg carries the strict RPC check of one network
+type networkVerifiedMsg struct {
+	check blockchain.EndpointCheck
+}
+
+// SetQuarantinedNetworks sets the networks that strict RPC mode quarantined on
+// startup; they are listed in the security warning after the splash screen
+func (m *CLIModel) SetQuarantinedNetworks(checks []blockchain.EndpointCheck) {
+	m.quarantinedNetworks = checks
+}
+
+// verifyNetworkCmd checks the endpoint of a network in the background, so
+// that a quarantine can be lifted or a suspicious endpoint caught on demand
+func (m *CLIModel) verifyNetworkCmd(key string) tea.Cmd {
+	if key == "" {
+		m.networkListComponent.SetError(errors.New(localization.Labels["no_network_selected"]))
+		return nil
+	}
+	if err := m.ensureConfigAndNetworksLoaded(); err != nil {
+		m.networkListComponent.SetError(fmt.Errorf("failed to load configuration: %v", err))
+		return nil
+	}
+	network, ok := m.currentConfig.Networks[key]
+	if !ok {
+		m.networkListComponent.SetError(fmt.Errorf("network not found"))
+		return nil
+	}
+	m.networkListComponent.SetNotice(fmt.Sprintf(localization.Labels["verifying_network"], network.Name))
+	return func() tea.Msg {
+		check := blockchain.EndpointCheck{Key: key, Network: network}
+		check.Err = blockchain.VerifyEndpoint(context.Background(), network)
+		return networkVerifiedMsg{check: check}
+	}
+}
+
+// handleNetworkVerified saves the quarantine decision and reports it in the
+// network list
+func (m *CLIModel) handleNetworkVerified(msg networkVerifiedMsg) {
+	check := msg.check
+	if m.currentConfig == nil {
+		return
+	}
+	networks := m.currentConfig.Networks
+	if changed := blockchain.ApplyEndpointChecks(networks, []blockchain.EndpointCheck{check}); len(changed) > 0 {
+		if err := getNetworkManager().SetQuarantine(check.Key, networks[check.Key].Quarantine); err != nil {
+			m.networkListComponent.SetError(err)
+			return
+		}
+		if uiLogger != nil {
+			uiLogger.Info("Network quarantine changed", logger.String("network", check.Network.Name), logger.String("quarantine", networks[check.Key].Quarantine))
+		}
+		m.networkListComponent.UpdateNetworks(m.currentConfig)
+	}
+	m.quarantinedNetworks = removeEndpointCheck(m.quarantinedNetworks, check.Key)
+
+	switch {
+	case check.Quarantine():
+		m.networkListComponent.SetError(fmt.Errorf(localization.Labels["network_quarantined"], check.Network.Name, check.Err))
+	case check.Err != nil:
+		m.networkListComponent.SetError(fmt.Errorf(localization.Labels["network_unreachable"], check.Network.Name, check.Err))
+	default:
+		m.networkListComponent.SetNotice(fmt.Sprintf(localization.Labels["network_verified"], check.Network.Name))
+	}
+}
+
+func removeEndpointCheck(checks []blockchain.EndpointCheck, key string) []blockchain.EndpointCheck {
+	kept := checks[:0]
+	for _, c := range checks {
+		if c.Key != key {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,219 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func init() {
+	registerKeyMap(constants.WalletNotesView, func(m *CLIModel) []key.Binding {
+		return []key.Binding{
+			newBinding("tab", "key_switch_field", "tab", "shift+tab"),
+			newBinding("ctrl+s", "key_save", "ctrl+s"),
+			newBinding("esc", "key_discard", "esc"),
+		}
+	})
+}
+
+const (
+	notesFocusNotes = iota
+	notesFocusReferences
+	notesFocusCount
+)
+
+// openWalletNotes opens the notes editor for a wallet of the list
+func (m *CLIModel) openWalletNotes(w *wallet.Wallet) {
+	m.notesWallet = w
+
+	m.notesInput = textarea.New()
+	m.notesInput.Placeholder = localization.Labels["notes_placeholder"]
+	m.notesInput.CharLimit = wallet.MaxWalletNotesLength
+	m.notesInput.SetWidth(70)
+	m.notesInput.SetHeight(6)
+	m.notesInput.SetValue(w.Notes)
+
+	m.referencesInput = textinput.New()
+	m.referencesInput.Placeholder = localization.Labels["notes_references_placeholder"]
+	m.referencesInput.CharLimit = 1024
+	m.referencesInput.Width = 70
+	m.referencesInput.SetValue(strings.Join(w.References, ", "))
+
+	m.notesFocus = notesFocusNotes
+	m.notesInput.Focus()
+	m.referencesInput.Blur()
+	m.notesStatus = ""
+	m.notesFailed = false
+	m.currentView = constants.WalletNotesView
+}
+
+// closeWalletNotes returns to the wallet list
+func (m *CLIModel) closeWalletNotes() {
+	m.notesWallet = nil
+	m.notesStatus = ""
+	m.notesFailed = false
+	m.currentView = constants.ListWalletsView
+}
+
+// updateWalletNotes moves between the fields and saves on ctrl+s
+func (m *CLIModel) updateWalletNotes(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "tab", "shift+tab":
+			m.notesFocus = (m.notesFocus + 1) % notesFocusCount
+			if m.notesFocus == notesFocusNotes {
+				m.referencesInput.Blur()
+				return m, m.notesInput.Focus()
+			}
+			m.notesInput.Blur()
+			return m, m.referencesInput.Focus()
+		case "ctrl+s":
+			return m, m.saveWalletNotes()
+		}
+	}
+
+	var cmd tea.Cmd
+	if m.notesFocus == notesFocusNotes {
+		m.notesInput, cmd = m.notesInput.Update(msg)
+	} else {
+		m.referencesInput, cmd = updateTextInput(m.referencesInput, msg)
+	}
+	return m, cmd
+}
+
+// saveWalletNotes stores the notes and goes back to the refreshed list. Notes
+// are not secrets, so compliance mode does not ask for an approval.
+func (m *CLIModel) saveWalletNotes() tea.Cmd {
+	if m.notesWallet == nil {
+		return nil
+	}
+	references := strings.Split(m.referencesInput.Value(), ",")
+	err := m.Service.UpdateWalletNotes(m.notesWallet, m.notesInput.Value(), references, time.Now().UTC())
+	switch {
+	case errors.Is(err, wallet.ErrStaleWallet):
+		m.notesStatus = localization.Labels["wallet_changed_elsewhere"]
+		m.notesFailed = true
+		return nil
+	case err != nil:
+		m.notesStatus = fmt.Sprintf(localization.Labels["notes_save_failed"], err)
+		m.notesFailed = true
+		return nil
+	}
+	m.closeWalletNotes()
+	return m.refreshWalletsTable()
+}
+
+// viewWalletNotes renders the notes editor
+func (m *CLIModel) viewWalletNotes() string {
+	if m.notesWallet == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["notes_title"]))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["notes_wallet"], m.notesWallet.Name, wallet.ChecksumAddress(m.notesWallet.Address)))
+	b.WriteString("\n\n")
+	b.WriteString(localization.Labels["notes_label"])
+	b.WriteString("\n")
+	b.WriteString(m.notesInput.View())
+	b.WriteString("\n\n")
+	b.WriteString(localization.Labels["notes_references_label"])
+	b.WriteString("\n")
+	b.WriteString(m.referencesInput.View())
+	b.WriteString("\n")
+	if m.notesStatus != "" {
+		b.WriteString("\n")
+		if m.notesFailed {
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.notesStatus))
+		} else {
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.notesStatus))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// visibleWallets returns the wallets matching the list search, in the order
+// of the sort column
+func (m *CLIModel) visibleWallets() []wallet.Wallet {
+	query := m.walletFilter.Value()
+	if strings.TrimSpace(query) == "" {
+		return sortWallets(m.wallets, m.walletSort)
+	}
+	var out []wallet.Wallet
+	for _, w := range m.wallets {
+		if w.Matches(query) {
+			out = append(out, w)
+		}
+	}
+	return sortWallets(out, m.walletSort)
+}
+
+// startWalletSearch focuses the search field of the wallet list
+func (m *CLIModel) startWalletSearch() tea.Cmd {
+	if m.walletFilter.Placeholder == "" {
+		m.walletFilter = textinput.New()
+		m.walletFilter.Placeholder = localization.Labels["wallet_search_placeholder"]
+		m.walletFilter.CharLimit = 128
+		m.walletFilter.Width = 40
+	}
+	m.filteringWallets = true
+	return m.walletFilter.Focus()
+}
+
+// updateWalletSearch edits the search: enter keeps the filter, esc clears it
+func (m *CLIModel) updateWalletSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.filteringWallets = false
+		m.walletFilter.Blur()
+		return m, nil
+	case "esc":
+		m.clearWalletSearch()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.walletFilter, cmd = updateTextInput(m.walletFilter, msg)
+	m.applyWalletFilter()
+	return m, cmd
+}
+
+// clearWalletSearch removes the filter and shows every wallet again
+func (m *CLIModel) clearWalletSearch() {
+	m.filteringWallets = false
+	m.walletFilter.Blur()
+	m.walletFilter.SetValue("")
+	m.applyWalletFilter()
+}
+
+// applyWalletFilter refreshes the table rows after the search changed
+func (m *CLIModel) applyWalletFilter() {
+	rows := walletTableRows(m.visibleWallets(), m.batchExportMarked)
+	m.walletTable.SetRows(rows)
+	if m.walletTable.Cursor() >= len(rows) {
+		m.walletTable.SetCursor(max(len(rows)-1, 0))
+	}
+}
+
+// renderWalletSearch shows the search field while it is in use
+func (m *CLIModel) renderWalletSearch() string {
+	if !m.filteringWallets && m.walletFilter.Value() == "" {
+		return ""
+	}
+	visible := len(m.visibleWallets())
+	line := localization.Labels["wallet_search_label"] + " " + m.walletFilter.View() + "  " +
+		m.styles.MenuDesc.Render(fmt.Sprintf(localization.Labels["wallet_search_count"], visible, len(m.wallets)))
+	if visible == 0 {
+		line += "\n" + m.styles.MenuDesc.Render(fmt.Sprintf(localization.Labels["wallet_search_no_matches"], m.walletFilter.Value()))
+	}
+	return line + "\n"
+}
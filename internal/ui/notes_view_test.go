@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The references and search inputs go through updateTextInput, so they take
+// typing on every architecture
+func TestWalletNotes_EditAndSearch(t *testing.T) {
+	for _, arch := range []string{"amd64", "arm64"} {
+		t.Run(arch, func(t *testing.T) {
+			withArch(t, arch)
+			testWalletNotesEditAndSearch(t)
+		})
+	}
+}
+
+func testWalletNotesEditAndSearch(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddNotesMessages()
+
+	dir := t.TempDir()
+	repo, err := storage.NewWalletRepository(&config.Config{AppDir: dir, DatabasePath: filepath.Join(dir, "wallets.db")})
+	require.NoError(t, err)
+	defer repo.Close()
+
+	require.NoError(t, repo.AddWallet(&wallet.Wallet{Name: "Treasury", Address: "0xAAA", ImportMethod: "keystore", SourceHash: "a"}))
+	require.NoError(t, repo.AddWallet(&wallet.Wallet{Name: "Payroll", Address: "0xBBB", ImportMethod: "keystore", SourceHash: "b"}))
+
+	m := &CLIModel{Service: &wallet.WalletService{Repo: repo}, styles: createStyles(), width: 120}
+	m.initListWallets()
+	m.currentView = constants.ListWalletsView
+	require.Len(t, m.walletTable.Rows(), 2)
+
+	// Annotate the selected wallet
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	require.Equal(t, constants.WalletNotesView, m.currentView)
+	assert.Equal(t, "Treasury", m.notesWallet.Name)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("quarterly audit")})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("OPS-9, OPS-9")})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	assert.False(t, m.notesFailed, m.notesStatus)
+	assert.Equal(t, constants.ListWalletsView, m.currentView)
+
+	saved, err := repo.FindBySourceHash("a")
+	require.NoError(t, err)
+	assert.Equal(t, "quarterly audit", saved.Notes)
+	assert.Equal(t, wallet.WalletReferences{"OPS-9"}, saved.References)
+
+	// Search by reference; "q" is typed instead of quitting
+	m.applyWallets([]wallet.Wallet{*saved, m.wallets[1]})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	assert.Equal(t, "zq", m.walletFilter.Value())
+	assert.Empty(t, m.walletTable.Rows())
+	assert.Contains(t, m.viewListWallets(), "No wallet matches")
+
+	m.walletFilter.SetValue("ops-9")
+	m.applyWalletFilter()
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, m.filteringWallets)
+	require.Len(t, m.walletTable.Rows(), 1)
+	assert.Equal(t, "0xAAA", m.walletTable.Rows()[0][4])
+
+	// esc clears the search before leaving the list
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.ListWalletsView, m.currentView)
+	assert.Len(t, m.walletTable.Rows(), 2)
+}
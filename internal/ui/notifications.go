@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"blocowallet/internal/notify"
+	"blocowallet/internal/platform"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/logger"
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SetNotifier enables webhook notifications
+func (m *CLIModel) SetNotifier(n *notify.Notifier) {
+	m.notifier = n
+}
+
+// notifyCmd delivers a webhook notification in the background; failures are only logged
+func (m *CLIModel) notifyCmd(event notify.Event, summary string, details map[string]any) tea.Cmd {
+	n := m.notifier
+	if !n.Wants(event) {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := n.Send(context.Background(), event, summary, details); err != nil && uiLogger != nil {
+			uiLogger.Warn("Webhook notification failed", logger.Error(err))
+		}
+		return nil
+	}
+}
+
+// notifyImportCompleted reports the outcome of a batch import
+func (m *CLIModel) notifyImportCompleted(results []wallet.ImportResult) tea.Cmd {
+	var imported, failed, skipped int
+	for _, r := range results {
+		switch {
+		case r.Success:
+			imported++
+		case r.Skipped:
+			skipped++
+		default:
+			failed++
+		}
+	}
+	return m.notifyCmd(notify.EventImportCompleted,
+		fmt.Sprintf("Batch import finished: %d imported, %d failed, %d skipped", imported, failed, skipped),
+		map[string]any{"total": len(results), "imported": imported, "failed": failed, "skipped": skipped})
+}
+
+// notifyBackupCompleted reports a finished export or inheritance package; only
+// the kind and the location are sent, never the content
+func (m *CLIModel) notifyBackupCompleted(kind, location string) tea.Cmd {
+	return m.notifyCmd(notify.EventBackupCompleted,
+		fmt.Sprintf("Backup completed: %s", kind),
+		map[string]any{"kind": kind, "location": location})
+}
+
+// notifySettledTransactions reports tracked transactions that became final on
+// their network or reverted
+func (m *CLIModel) notifySettledTransactions(txs []wallet.TrackedTransaction) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, tx := range txs {
+		details := map[string]any{"hash": tx.Hash, "network": tx.Network, "from": tx.From, "nonce": tx.Nonce, "block": tx.BlockNumber}
+		switch wallet.TrackedTxState(tx.State) {
+		case wallet.TrackedFinal:
+			details["confirmations"] = tx.Confirmations
+			cmds = append(cmds, m.notifyCmd(notify.EventTxFinal,
+				fmt.Sprintf("Transaction final on %s: %s", tx.Network, tx.Hash), details))
+		case wallet.TrackedReverted:
+			cmds = append(cmds, m.notifyCmd(notify.EventTxFailed,
+				fmt.Sprintf("Transaction reverted on %s: %s", tx.Network, tx.Hash), details))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// notifyPermissionIssues reports sensitive files whose permissions could not be fixed
+func (m *CLIModel) notifyPermissionIssues(issues []platform.PermissionIssue) tea.Cmd {
+	if len(issues) == 0 {
+		return nil
+	}
+	paths := make([]string, len(issues))
+	for i, issue := range issues {
+		paths[i] = issue.Path
+	}
+	return m.notifyCmd(notify.EventHealthCheckFailed,
+		fmt.Sprintf("Health check failed: %d sensitive files are readable by other users", len(issues)),
+		map[string]any{"check": "file_permissions", "paths": paths})
+}
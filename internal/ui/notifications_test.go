@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"blocowallet/internal/notify"
+	"blocowallet/internal/platform"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifyImportCompleted(t *testing.T) {
+	payloads := make(chan notify.Payload, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p notify.Payload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+		payloads <- p
+	}))
+	defer srv.Close()
+
+	n, err := notify.New(config.NotificationsConfig{WebhookURL: srv.URL})
+	require.NoError(t, err)
+	m := &CLIModel{}
+	m.SetNotifier(n)
+
+	cmd := m.notifyImportCompleted([]wallet.ImportResult{
+		{Success: true},
+		{Success: true},
+		{Error: errors.New("bad password")},
+		{Skipped: true},
+	})
+	require.NotNil(t, cmd)
+	assert.Nil(t, cmd())
+
+	p := <-payloads
+	assert.Equal(t, notify.EventImportCompleted, p.Event)
+	assert.EqualValues(t, 2, p.Details["imported"])
+	assert.EqualValues(t, 1, p.Details["failed"])
+	assert.EqualValues(t, 1, p.Details["skipped"])
+}
+
+func TestNotify_DisabledProducesNoCommand(t *testing.T) {
+	m := &CLIModel{}
+	assert.Nil(t, m.notifyBackupCompleted("inheritance package", "/tmp/x"))
+	assert.Nil(t, m.notifyPermissionIssues([]platform.PermissionIssue{{Path: "/tmp/db"}}))
+}
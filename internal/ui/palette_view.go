@@ -0,0 +1,312 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// paletteVisible is how many matches the palette shows
+const paletteVisible = 10
+
+// paletteEntry is an action or a wallet offered by the command palette
+type paletteEntry struct {
+	title  string
+	group  string // Menu the action comes from, or the wallets
+	detail string // Description of the action or address of the wallet
+	run    func(m *CLIModel) (tea.Model, tea.Cmd)
+}
+
+// paletteMatch is an entry that matches the query, with its score
+type paletteMatch struct {
+	entry paletteEntry
+	score int
+}
+
+// paletteAvailable tells whether ctrl+p opens the palette. It stays closed
+// on the splash and lock screens, while a task would be cancelled by leaving
+// its view, and in the keystore import, where ctrl+p pastes a keystore.
+func (m *CLIModel) paletteAvailable() bool {
+	if m.kdfOp != nil || m.profileSwitching {
+		return false
+	}
+	switch m.currentView {
+	case constants.SplashView, constants.MasterPasswordView, constants.EnhancedImportView,
+		constants.KeystoreOverwriteView, constants.ChildDiscoveryView, constants.NetworkImportView,
+		constants.MetamaskImportView, constants.BatchExportView, constants.WalletBatchView:
+		return false
+	case constants.BatchCreateView:
+		return !m.batchRunning
+	case constants.BatchSendView:
+		return m.batchSendStage != batchSendStageUnlock && m.batchSendStage != batchSendStageRunning
+	case constants.ListWalletsView:
+		return m.deletingWallet == nil
+	}
+	return true
+}
+
+// openPalette opens the palette over the current view with every action of
+// the menus and the wallets
+func (m *CLIModel) openPalette() tea.Cmd {
+	m.paletteInput = textinput.New()
+	m.paletteInput.Placeholder = localization.Labels["palette_placeholder"]
+	m.paletteInput.CharLimit = 64
+	m.paletteInput.Width = 48
+	m.paletteInput.Focus()
+
+	m.paletteEntries = m.paletteActions()
+	if m.Service != nil {
+		if wallets, err := m.Service.GetAllWallets(); err == nil {
+			m.paletteEntries = append(m.paletteEntries, paletteWallets(wallets)...)
+		}
+	}
+	m.paletteOpen = true
+	m.filterPalette()
+	return textinput.Blink
+}
+
+func (m *CLIModel) closePalette() {
+	m.paletteOpen = false
+	m.paletteInput.Blur()
+	m.paletteEntries = nil
+	m.paletteMatches = nil
+}
+
+// paletteActions lists the items of the main, import, configuration and
+// network menus. Running one selects it in its menu and presses enter, so it
+// does exactly what the menu does.
+func (m *CLIModel) paletteActions() []paletteEntry {
+	var entries []paletteEntry
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	add := func(items []menuItem, group string, open func(m *CLIModel), update func(m *CLIModel) (tea.Model, tea.Cmd)) {
+		for i, item := range items {
+			entries = append(entries, paletteEntry{
+				title:  item.title,
+				group:  group,
+				detail: item.description,
+				run: func(m *CLIModel) (tea.Model, tea.Cmd) {
+					open(m)
+					m.selectedMenu = i
+					return update(m)
+				},
+			})
+		}
+	}
+
+	add(m.mainMenu(), localization.Labels["main_menu_title"], func(m *CLIModel) {
+		m.menuItems = m.mainMenu()
+		m.currentView = constants.DefaultView
+	}, func(m *CLIModel) (tea.Model, tea.Cmd) { return m.updateMenu(enter) })
+
+	// The last item of the submenus goes back and is left out
+	importMenu := NewImportMenu()
+	add(importMenu[:len(importMenu)-1], localization.Labels["import_wallet"], (*CLIModel).initImportMethodSelection,
+		func(m *CLIModel) (tea.Model, tea.Cmd) { return m.updateImportMethodSelection(enter) })
+
+	configMenu := NewConfigMenu()
+	add(configMenu[:len(configMenu)-1], localization.Labels["configuration"], (*CLIModel).initConfigMenu,
+		func(m *CLIModel) (tea.Model, tea.Cmd) { return m.updateConfigMenu(enter) })
+
+	networkMenu := NewNetworkMenu()
+	add(networkMenu[:len(networkMenu)-1], localization.Labels["networks"], func(m *CLIModel) {
+		m.menuItems = NewNetworkMenu()
+		m.currentView = constants.NetworkMenuView
+	}, func(m *CLIModel) (tea.Model, tea.Cmd) { return m.updateNetworkMenu(enter) })
+
+	return entries
+}
+
+// paletteWallets lists the wallets; running one opens it from the wallet
+// list, asking for its password as the list does
+func paletteWallets(wallets []wallet.Wallet) []paletteEntry {
+	entries := make([]paletteEntry, 0, len(wallets))
+	for _, w := range wallets {
+		address := w.Address
+		entries = append(entries, paletteEntry{
+			title:  w.Name,
+			group:  localization.Labels["palette_wallet"],
+			detail: address,
+			run: func(m *CLIModel) (tea.Model, tea.Cmd) {
+				m.initListWallets()
+				if m.currentView != constants.ListWalletsView {
+					return m, nil
+				}
+				for i, row := range m.walletTable.Rows() {
+					if len(row) > 4 && wallet.SameAddress(row[4], address) {
+						m.walletTable.SetCursor(i)
+						return m.updateListWallets(tea.KeyMsg{Type: tea.KeyEnter})
+					}
+				}
+				return m, nil
+			},
+		})
+	}
+	return entries
+}
+
+// filterPalette ranks the entries by how well they match the query
+func (m *CLIModel) filterPalette() {
+	query := strings.Fields(strings.ToLower(m.paletteInput.Value()))
+	var matches []paletteMatch
+	for _, entry := range m.paletteEntries {
+		score, ok := paletteScore(query, entry)
+		if ok {
+			matches = append(matches, paletteMatch{entry: entry, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	m.paletteMatches = matches
+	m.paletteCursor = 0
+}
+
+// paletteScore matches every word of the query against the entry: letters in
+// order in the title or menu, or the whole word in the description or address
+func paletteScore(query []string, entry paletteEntry) (int, bool) {
+	total := 0
+	for _, word := range query {
+		title, inTitle := fuzzyScore(word, entry.title)
+		group, inGroup := fuzzyScore(word, entry.group)
+		switch {
+		case inTitle && (!inGroup || 2*title >= group):
+			// The title counts twice, so actions rank above their menu
+			total += 2 * title
+		case inGroup:
+			total += group
+		case strings.Contains(strings.ToLower(entry.detail), word):
+			total += len(word)
+		default:
+			return 0, false
+		}
+	}
+	return total, true
+}
+
+// fuzzyScore tells whether the letters of query appear in target in order,
+// scoring letters that follow each other or start a word higher
+func fuzzyScore(query, target string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	t := []rune(strings.ToLower(target))
+	score, qi, last := 0, 0, -2
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			continue
+		}
+		score++
+		if ti == last+1 {
+			score += 4
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 6
+		}
+		last = ti
+		qi++
+	}
+	return score, qi == len(q)
+}
+
+// paletteKeys are the keys of the palette while it is open
+func (m *CLIModel) paletteKeys() []key.Binding {
+	return []key.Binding{
+		newBinding("↑/↓", "key_move", "up", "down"),
+		newBinding("enter", "key_run", "enter"),
+		newBinding("esc", "key_close", "esc", "ctrl+p"),
+	}
+}
+
+// updatePalette filters the entries as the query is typed and runs the
+// selected one with enter
+func (m *CLIModel) updatePalette(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+p":
+		m.closePalette()
+		return m, nil
+	case "up":
+		if m.paletteCursor > 0 {
+			m.paletteCursor--
+		}
+		return m, nil
+	case "down":
+		if m.paletteCursor < len(m.paletteMatches)-1 {
+			m.paletteCursor++
+		}
+		return m, nil
+	case "enter":
+		if m.paletteCursor >= len(m.paletteMatches) {
+			return m, nil
+		}
+		return m.runPaletteEntry(m.paletteMatches[m.paletteCursor].entry)
+	}
+	var cmd tea.Cmd
+	m.paletteInput, cmd = updateTextInput(m.paletteInput, msg)
+	m.filterPalette()
+	return m, cmd
+}
+
+// runPaletteEntry leaves the current view the way esc does, so forms are
+// discarded and previews undone, and then runs the entry from the main menu
+func (m *CLIModel) runPaletteEntry(entry paletteEntry) (tea.Model, tea.Cmd) {
+	m.closePalette()
+	var cmds []tea.Cmd
+	for i := 0; i < 8 && m.currentView != constants.DefaultView; i++ {
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		cmds = append(cmds, cmd)
+	}
+	if m.currentView != constants.DefaultView {
+		return m, tea.Batch(cmds...)
+	}
+	_, cmd := entry.run(m)
+	return m, tea.Batch(append(cmds, cmd)...)
+}
+
+// viewPalette shows the query and the best matches
+func (m *CLIModel) viewPalette() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["palette_title"]))
+	b.WriteString("\n\n")
+	b.WriteString(m.paletteInput.View())
+	b.WriteString("\n\n")
+
+	if len(m.paletteMatches) == 0 {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["palette_no_match"]))
+		b.WriteString("\n")
+	}
+	start := 0
+	if m.paletteCursor >= paletteVisible {
+		start = m.paletteCursor - paletteVisible + 1
+	}
+	end := start + paletteVisible
+	if end > len(m.paletteMatches) {
+		end = len(m.paletteMatches)
+	}
+	for i := start; i < end; i++ {
+		entry := m.paletteMatches[i].entry
+		line := fmt.Sprintf("%s %s", entry.title, lipgloss.NewStyle().Foreground(palette.Muted).Render("("+entry.group+")"))
+		if i == m.paletteCursor {
+			b.WriteString(m.styles.MenuSelected.Render("> " + line))
+			b.WriteString("\n")
+			b.WriteString(m.styles.MenuDesc.Render("    " + entry.detail))
+		} else {
+			b.WriteString(m.styles.MenuItem.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+	if len(m.paletteMatches) > end {
+		b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf(localization.Labels["palette_more"], len(m.paletteMatches)-end)))
+		b.WriteString("\n")
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(palette.Accent).
+		Padding(1, 2).
+		Render(b.String())
+}
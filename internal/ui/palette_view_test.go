@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	_, ok := fuzzyScore("adnet", "Add Network")
+	assert.True(t, ok)
+	_, ok = fuzzyScore("netadd", "Add Network")
+	assert.False(t, ok, "the letters must appear in order")
+
+	prefix, _ := fuzzyScore("net", "Network List")
+	scattered, _ := fuzzyScore("net", "Inheritance")
+	assert.Greater(t, prefix, scattered, "a word start beats scattered letters")
+}
+
+func TestPalette_RunsActionsAndOpensWallets(t *testing.T) {
+	appDir := t.TempDir()
+	require.NoError(t, localization.SetLanguage("en", appDir))
+	t.Setenv("BLOCO_WALLET_APP_APP_DIR", appDir)
+	previousManager, previousPalette := globalConfigManager, palette
+	globalConfigManager = nil
+	defer func() { globalConfigManager, palette = previousManager, previousPalette }()
+
+	repo, err := storage.NewWalletRepository(&config.Config{AppDir: appDir, DatabasePath: filepath.Join(appDir, "wallets.db")})
+	require.NoError(t, err)
+	defer repo.Close()
+	require.NoError(t, repo.AddWallet(&wallet.Wallet{Name: "Treasury", Address: "0xAAA", ImportMethod: "keystore", SourceHash: "a"}))
+	require.NoError(t, repo.AddWallet(&wallet.Wallet{Name: "Payroll", Address: "0xBBB", ImportMethod: "keystore", SourceHash: "b"}))
+
+	m := &CLIModel{Service: &wallet.WalletService{Repo: repo}, styles: createStyles(), width: 120}
+	m.initConfigMenu()
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	require.True(t, m.paletteOpen)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("add net")})
+	require.NotEmpty(t, m.paletteMatches)
+	assert.Equal(t, localization.Labels["add_network"], m.paletteMatches[0].entry.title)
+	assert.Contains(t, m.View(), localization.Labels["palette_title"])
+
+	// "q" is typed, not quit
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	assert.Equal(t, "add netq", m.paletteInput.Value())
+	assert.Empty(t, m.paletteMatches)
+	assert.Contains(t, m.viewPalette(), "Nothing matches.")
+
+	// esc closes the palette and keeps the view
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, m.paletteOpen)
+	assert.Equal(t, constants.ConfigurationView, m.currentView)
+
+	// An action runs as if chosen in its menu
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("theme")})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, m.paletteOpen)
+	require.Equal(t, constants.ThemeView, m.currentView)
+
+	// A wallet is found by address and opened from the list, asking for its password
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("0xbbb")})
+	require.NotEmpty(t, m.paletteMatches)
+	assert.Equal(t, "Payroll", m.paletteMatches[0].entry.title)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, constants.WalletPasswordView, m.currentView)
+	require.NotNil(t, m.selectedWallet)
+	assert.Equal(t, "Payroll", m.selectedWallet.Name)
+	assert.Empty(t, m.themeEntries, "the theme picker was closed on the way")
+
+	// The lock screen cannot be skipped
+	m.currentView = constants.MasterPasswordView
+	assert.False(t, m.paletteAvailable())
+}
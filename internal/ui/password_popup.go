@@ -7,6 +7,8 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"blocowallet/pkg/localization"
 )
 
 // PasswordPopupModel represents the password input popup component
@@ -20,19 +22,25 @@ type PasswordPopupModel struct {
 	confirmed    bool
 	width        int
 	height       int
+
+	// applyToAll is the "use this password for all remaining locked files"
+	// checkbox, only shown once offerApplyToAll enables it
+	offerApplyToAll bool
+	applyToAll      bool
 }
 
 // PasswordPopupResult represents the result of the password popup interaction
 type PasswordPopupResult struct {
-	Password  string
-	Cancelled bool
-	Skip      bool
+	Password   string
+	Cancelled  bool
+	Skip       bool
+	ApplyToAll bool
 }
 
 // NewPasswordPopupModel creates a new password popup model
 func NewPasswordPopupModel(keystoreFile string, maxRetries int) PasswordPopupModel {
 	ti := textinput.New()
-	ti.Placeholder = "Enter keystore password..."
+	ti.Placeholder = localization.Labels["password_popup_placeholder"]
 	ti.Focus()
 	ti.CharLimit = 256
 	ti.Width = 40
@@ -72,10 +80,20 @@ func (m PasswordPopupModel) Update(msg tea.Msg) (PasswordPopupModel, tea.Cmd) {
 			// Skip this file
 			m.cancelled = true
 			return m, tea.Quit
+		case "tab":
+			if m.offerApplyToAll {
+				m.applyToAll = !m.applyToAll
+				return m, nil
+			}
 		}
 	}
 
-	m.Model, cmd = m.Model.Update(msg)
+	if rejectsPaste(m.Model, msg) {
+		m.errorMessage = localization.Labels["paste_multiline_rejected"]
+		return m, nil
+	}
+
+	m.Model, cmd = updateTextInput(m.Model, msg)
 	return m, cmd
 }
 
@@ -84,7 +102,7 @@ func (m PasswordPopupModel) View() string {
 	// Create the popup box style
 	popupStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("62")).
+		BorderForeground(palette.Accent).
 		Padding(1, 2).
 		Width(m.width).
 		Align(lipgloss.Center)
@@ -92,13 +110,13 @@ func (m PasswordPopupModel) View() string {
 	// Title
 	title := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("205")).
-		Render("Password Required")
+		Foreground(palette.Accent).
+		Render(localization.Labels["password_popup_title"])
 
 	// Keystore filename
 	filename := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
-		Render(fmt.Sprintf("File: %s", m.keystoreFile))
+		Foreground(palette.Muted).
+		Render(fmt.Sprintf(localization.Labels["password_popup_file"], m.keystoreFile))
 
 	// Retry counter if there have been attempts
 	retryInfo := ""
@@ -106,12 +124,12 @@ func (m PasswordPopupModel) View() string {
 		remaining := m.maxRetries - m.retryCount
 		if remaining > 0 {
 			retryInfo = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("214")).
-				Render(fmt.Sprintf("Attempts remaining: %d", remaining))
+				Foreground(palette.Warning).
+				Render(fmt.Sprintf(localization.Labels["password_popup_attempts"], remaining))
 		} else {
 			retryInfo = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("196")).
-				Render("Maximum attempts reached")
+				Foreground(palette.Error).
+				Render(localization.Labels["password_popup_max_attempts"])
 		}
 	}
 
@@ -119,14 +137,18 @@ func (m PasswordPopupModel) View() string {
 	errorMsg := ""
 	if m.errorMessage != "" {
 		errorMsg = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
-			Render(fmt.Sprintf("Error: %s", m.errorMessage))
+			Foreground(palette.Error).
+			Render(fmt.Sprintf(localization.Labels["password_popup_error"], m.errorMessage))
 	}
 
 	// Instructions
+	help := localization.Labels["password_popup_help"]
+	if m.offerApplyToAll {
+		help = localization.Labels["password_popup_help_apply_all"]
+	}
 	instructions := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244")).
-		Render("Enter: Confirm • Esc: Cancel • Ctrl+S: Skip file")
+		Foreground(palette.Muted).
+		Render(help)
 
 	// Build the content
 	content := []string{title, "", filename}
@@ -139,7 +161,17 @@ func (m PasswordPopupModel) View() string {
 		content = append(content, "", errorMsg)
 	}
 
-	content = append(content, "", m.Model.View(), "", instructions)
+	content = append(content, "", m.Model.View())
+
+	if m.offerApplyToAll {
+		box := "[ ]"
+		if m.applyToAll {
+			box = "[x]"
+		}
+		content = append(content, "", box+" "+localization.Labels["password_popup_apply_all"])
+	}
+
+	content = append(content, "", instructions)
 
 	// Join all content
 	popupContent := strings.Join(content, "\n")
@@ -151,10 +183,12 @@ func (m PasswordPopupModel) View() string {
 	return lipgloss.Place(m.width+10, m.height+5, lipgloss.Center, lipgloss.Center, popup)
 }
 
-// SetError sets an error message to display in the popup
+// SetError sets an error message to display in the popup and asks for the
+// password again
 func (m *PasswordPopupModel) SetError(err string) {
 	m.errorMessage = err
 	m.retryCount++
+	m.confirmed = false
 	m.SetValue("") // Clear the password input
 }
 
@@ -169,13 +203,20 @@ func (m PasswordPopupModel) GetResult() PasswordPopupResult {
 
 	if m.confirmed {
 		return PasswordPopupResult{
-			Password: strings.TrimSpace(m.Value()),
+			Password:   strings.TrimSpace(m.Value()),
+			ApplyToAll: m.applyToAll,
 		}
 	}
 
 	return PasswordPopupResult{}
 }
 
+// OfferApplyToAll shows the checkbox that reuses the password for the
+// remaining locked files of a batch
+func (m *PasswordPopupModel) OfferApplyToAll() {
+	m.offerApplyToAll = true
+}
+
 // IsCompleted returns true if the popup interaction is complete
 func (m PasswordPopupModel) IsCompleted() bool {
 	return m.cancelled || m.confirmed
@@ -193,5 +234,6 @@ func (m *PasswordPopupModel) Reset(keystoreFile string) {
 	m.retryCount = 0
 	m.cancelled = false
 	m.confirmed = false
+	m.applyToAll = false
 	m.SetValue("")
 }
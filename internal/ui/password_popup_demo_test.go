@@ -5,6 +5,8 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
+
+	"blocowallet/pkg/localization"
 )
 
 func TestNewPasswordPopupDemo(t *testing.T) {
@@ -79,6 +81,9 @@ func TestPasswordPopupDemo_Update_PopupCompleted(t *testing.T) {
 }
 
 func TestPasswordPopupDemo_View_NotFinished(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddImportCompletionMessages()
+
 	demo := NewPasswordPopupDemo()
 
 	view := demo.View()
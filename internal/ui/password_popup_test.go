@@ -5,6 +5,8 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
+
+	"blocowallet/pkg/localization"
 )
 
 func TestNewPasswordPopupModel(t *testing.T) {
@@ -86,6 +88,7 @@ func TestPasswordPopupModel_Update_CtrlS_Skip(t *testing.T) {
 func TestPasswordPopupModel_SetError(t *testing.T) {
 	model := NewPasswordPopupModel("test.json", 3)
 	model.SetValue("wrongpassword")
+	model.confirmed = true
 
 	errorMsg := "Invalid password"
 	model.SetError(errorMsg)
@@ -93,6 +96,7 @@ func TestPasswordPopupModel_SetError(t *testing.T) {
 	assert.Equal(t, errorMsg, model.errorMessage)
 	assert.Equal(t, 1, model.retryCount)
 	assert.Empty(t, model.Value()) // Password should be cleared
+	assert.False(t, model.IsCompleted(), "the password is asked again")
 }
 
 func TestPasswordPopupModel_GetResult_Confirmed(t *testing.T) {
@@ -107,6 +111,30 @@ func TestPasswordPopupModel_GetResult_Confirmed(t *testing.T) {
 	assert.False(t, result.Skip)
 }
 
+func TestPasswordPopupModel_ApplyToAll(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddImportCompletionMessages()
+
+	model := NewPasswordPopupModel("test.json", 3)
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	assert.False(t, model.applyToAll, "tab does nothing until the checkbox is offered")
+	assert.NotContains(t, model.View(), localization.Labels["password_popup_apply_all"])
+
+	model.OfferApplyToAll()
+	assert.Contains(t, model.View(), "[ ] "+localization.Labels["password_popup_apply_all"])
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	assert.Contains(t, model.View(), "[x] "+localization.Labels["password_popup_apply_all"])
+
+	model.SetValue("shared")
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	result := model.GetResult()
+	assert.Equal(t, "shared", result.Password)
+	assert.True(t, result.ApplyToAll)
+
+	model.Reset("next.json")
+	assert.False(t, model.applyToAll)
+}
+
 func TestPasswordPopupModel_GetResult_Cancelled(t *testing.T) {
 	model := NewPasswordPopupModel("test.json", 3)
 	model.cancelled = true
@@ -191,6 +219,9 @@ func TestPasswordPopupModel_Reset(t *testing.T) {
 }
 
 func TestPasswordPopupModel_View(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddImportCompletionMessages()
+
 	model := NewPasswordPopupModel("test-wallet.json", 3)
 
 	view := model.View()
@@ -204,6 +235,9 @@ func TestPasswordPopupModel_View(t *testing.T) {
 }
 
 func TestPasswordPopupModel_View_WithError(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddImportCompletionMessages()
+
 	model := NewPasswordPopupModel("test-wallet.json", 3)
 	model.SetError("Invalid password provided")
 
@@ -214,6 +248,9 @@ func TestPasswordPopupModel_View_WithError(t *testing.T) {
 }
 
 func TestPasswordPopupModel_View_MaxRetriesReached(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddImportCompletionMessages()
+
 	model := NewPasswordPopupModel("test-wallet.json", 2)
 	model.SetError("error 1")
 	model.SetError("error 2")
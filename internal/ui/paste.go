@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/pkg/localization"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mnemonicPasteLengths are the BIP-39 phrase lengths accepted in a single paste
+var mnemonicPasteLengths = map[int]bool{12: true, 24: true}
+
+// pasteRejectedMsg is emitted when a paste is refused (e.g. multi-line text in a password field)
+type pasteRejectedMsg struct{}
+
+// pastedText returns the text of a bracketed paste event
+func pastedText(msg tea.Msg) (string, bool) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !keyMsg.Paste {
+		return "", false
+	}
+	return string(keyMsg.Runes), true
+}
+
+// isMultilinePaste reports whether text spans several lines, ignoring a single trailing line break
+func isMultilinePaste(text string) bool {
+	return strings.ContainsAny(strings.TrimRight(text, "\r\n"), "\r\n")
+}
+
+// rejectsPaste reports whether a paste must not reach a masked (password) input.
+// Multi-line pastes into password fields are almost always accidents and would
+// otherwise be silently flattened into a different password.
+func rejectsPaste(input textinput.Model, msg tea.Msg) bool {
+	text, ok := pastedText(msg)
+	if !ok || input.EchoMode == textinput.EchoNormal {
+		return false
+	}
+	return isMultilinePaste(text)
+}
+
+// splitMnemonicPaste splits a pasted phrase into words, dropping numbering
+// such as "1." or "2)" that password managers and backups often include
+func splitMnemonicPaste(text string) []string {
+	var words []string
+	for _, field := range strings.Fields(text) {
+		trimmed := strings.TrimRight(field, ".):")
+		if trimmed == "" || strings.IndexFunc(trimmed, func(r rune) bool { return !unicode.IsDigit(r) }) == -1 {
+			continue
+		}
+		words = append(words, strings.ToLower(trimmed))
+	}
+	return words
+}
+
+// cleanPastedKey removes whitespace and surrounding quotes from a pasted private key
+func cleanPastedKey(text string) string {
+	text = strings.Trim(strings.TrimSpace(text), "\"'`")
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// cleanPastedPath normalizes a pasted or drag-and-dropped file path: trims
+// whitespace and quotes, strips file:// URLs and shell escaping of spaces
+func cleanPastedPath(text string) string {
+	text = strings.TrimSpace(text)
+	if isMultilinePaste(text) {
+		text = strings.SplitN(strings.ReplaceAll(text, "\r\n", "\n"), "\n", 2)[0]
+	}
+	text = strings.Trim(strings.TrimSpace(text), "\"'")
+	if strings.HasPrefix(text, "file://") {
+		if u, err := url.Parse(text); err == nil {
+			text = u.Path
+		}
+	}
+	return strings.ReplaceAll(text, `\ `, " ")
+}
+
+// newMnemonicWordInputs creates the word inputs used by the mnemonic import screen
+func newMnemonicWordInputs(count int) []textinput.Model {
+	inputs := make([]textinput.Model, count)
+	for i := 0; i < count; i++ {
+		ti := textinput.New()
+		ti.Placeholder = fmt.Sprintf("%s %d", localization.Labels["word"], i+1)
+		ti.CharLimit = 50
+		ti.Width = 30
+		inputs[i] = ti
+	}
+	return inputs
+}
+
+// pasteMnemonicWords fills the word inputs from a pasted phrase. A full 12 or
+// 24-word phrase pasted into the first field replaces every word; shorter
+// pastes fill the fields starting at the current one.
+func (m *CLIModel) pasteMnemonicWords(words []string) {
+	start := m.importStage
+	if start == 0 && mnemonicPasteLengths[len(words)] {
+		if len(words) != len(m.textInputs) {
+			m.textInputs = newMnemonicWordInputs(len(words))
+			m.importWords = make([]string, len(words))
+		}
+	}
+
+	m.textInputs[m.importStage].Blur()
+	for i, word := range words {
+		idx := start + i
+		if idx >= len(m.textInputs) {
+			break
+		}
+		m.textInputs[idx].SetValue(word)
+		m.importWords[idx] = word
+	}
+
+	// Focus the first empty field, or move on to the password when all words are set
+	for i := range m.textInputs {
+		if strings.TrimSpace(m.textInputs[i].Value()) == "" {
+			m.importStage = i
+			m.textInputs[i].Focus()
+			return
+		}
+	}
+	m.importStage = len(m.textInputs)
+	m.startImportPasswordInput()
+}
+
+// startImportPasswordInput prepares the password field and switches to the import password view
+func (m *CLIModel) startImportPasswordInput() {
+	m.passwordInput = newWalletPasswordInput()
+	m.passwordInput.Focus()
+	m.currentView = constants.ImportWalletPasswordView
+}
+
+// renderInputNotice renders a transient warning about the last input event
+func (m *CLIModel) renderInputNotice() string {
+	if m.inputNotice == "" {
+		return ""
+	}
+	return "\n" + m.styles.ErrorStyle.Render(m.inputNotice)
+}
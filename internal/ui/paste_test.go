@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/pkg/localization"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pasteMsg(text string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text), Paste: true}
+}
+
+func newMnemonicImportModel() *CLIModel {
+	m := &CLIModel{styles: createStyles(), currentView: constants.ImportWalletView}
+	m.textInputs = newMnemonicWordInputs(constants.MnemonicWordCount)
+	m.importWords = make([]string, constants.MnemonicWordCount)
+	m.textInputs[0].Focus()
+	return m
+}
+
+func TestSplitMnemonicPaste(t *testing.T) {
+	assert.Equal(t, []string{"abandon", "ability", "able"}, splitMnemonicPaste("  Abandon\nability\t able \n"))
+	assert.Equal(t, []string{"abandon", "ability"}, splitMnemonicPaste("1. abandon 2) ability"))
+	assert.Empty(t, splitMnemonicPaste(" \n "))
+}
+
+func TestPasteMnemonic_FillsAllWords(t *testing.T) {
+	m := newMnemonicImportModel()
+	phrase := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	_, _ = m.updateImportWallet(pasteMsg(phrase + "\n"))
+
+	assert.Equal(t, strings.Fields(phrase), m.importWords)
+	assert.Equal(t, constants.ImportWalletPasswordView, m.currentView)
+	assert.True(t, m.passwordInput.Focused())
+}
+
+func TestPasteMnemonic_24WordsExpandsInputs(t *testing.T) {
+	m := newMnemonicImportModel()
+	words := make([]string, 24)
+	for i := range words {
+		words[i] = "zoo"
+	}
+
+	_, _ = m.updateImportWallet(pasteMsg(strings.Join(words, " ")))
+
+	require.Len(t, m.textInputs, 24)
+	assert.Equal(t, words, m.importWords)
+	assert.Equal(t, constants.ImportWalletPasswordView, m.currentView)
+}
+
+func TestPasteMnemonic_PartialPasteFocusesNextEmptyWord(t *testing.T) {
+	m := newMnemonicImportModel()
+
+	_, _ = m.updateImportWallet(pasteMsg("one two three"))
+
+	assert.Equal(t, constants.ImportWalletView, m.currentView)
+	assert.Equal(t, 3, m.importStage)
+	assert.True(t, m.textInputs[3].Focused())
+	assert.Equal(t, "three", m.textInputs[2].Value())
+}
+
+func TestPastePrivateKey_StripsWhitespaceAndQuotes(t *testing.T) {
+	m := &CLIModel{styles: createStyles(), currentView: constants.ImportPrivateKeyView}
+	m.privateKeyInput = textinput.New()
+	m.privateKeyInput.CharLimit = 66
+	m.privateKeyInput.Focus()
+
+	key := "0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	_, _ = m.updateImportPrivateKey(pasteMsg("  \"" + key[:30] + "\n" + key[30:] + "\"\n"))
+
+	assert.Equal(t, key, m.privateKeyInput.Value())
+}
+
+func TestImportPrivateKey_ShowsAddressBeforePassword(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddPrivateKeyMessages()
+	m := &CLIModel{styles: createStyles(), currentView: constants.ImportPrivateKeyView}
+	m.privateKeyInput = textinput.New()
+	m.privateKeyInput.CharLimit = 66
+	m.privateKeyInput.Focus()
+
+	// A WIF key is decoded and its address shown; the password comes after a second enter
+	_, _ = m.updateImportPrivateKey(pasteMsg("KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98617"))
+	_, _ = m.updateImportPrivateKey(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, m.privateKeyPreview)
+	assert.Equal(t, constants.ImportPrivateKeyView, m.currentView)
+	assert.Contains(t, m.viewImportPrivateKey(), m.privateKeyPreview.Address.Hex())
+	assert.Contains(t, m.viewImportPrivateKey(), "WIF")
+
+	// Editing the key asks for the address check again
+	_, _ = m.updateImportPrivateKey(tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.Nil(t, m.privateKeyPreview)
+	_, _ = m.updateImportPrivateKey(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, m.privateKeyPreview)
+	assert.Error(t, m.err)
+
+	_, _ = m.updateImportPrivateKey(pasteMsg("0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d"))
+	_, _ = m.updateImportPrivateKey(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, m.privateKeyPreview)
+	_, _ = m.updateImportPrivateKey(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, constants.ImportWalletPasswordView, m.currentView)
+}
+
+func TestCleanPastedPath(t *testing.T) {
+	assert.Equal(t, "/tmp/my keystore.json", cleanPastedPath("'/tmp/my keystore.json'\n"))
+	assert.Equal(t, "/tmp/my keystore.json", cleanPastedPath(`/tmp/my\ keystore.json`))
+	assert.Equal(t, "/tmp/key.json", cleanPastedPath("file:///tmp/key.json"))
+	assert.Equal(t, "/tmp/a.json", cleanPastedPath("/tmp/a.json\n/tmp/b.json"))
+}
+
+func TestPasteIntoPassword_RejectsMultiline(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddWalletImportMessages()
+
+	m := &CLIModel{styles: createStyles(), currentView: constants.WalletPasswordView}
+	m.passwordInput = newWalletPasswordInput()
+	m.passwordInput.Focus()
+
+	var cmd tea.Cmd
+	m.passwordInput, cmd = updateTextInput(m.passwordInput, pasteMsg("line one\nline two"))
+	assert.Empty(t, m.passwordInput.Value())
+	require.NotNil(t, cmd)
+	assert.IsType(t, pasteRejectedMsg{}, cmd())
+
+	_, _ = m.Update(pasteRejectedMsg{})
+	assert.Contains(t, m.viewWalletPassword(), localization.Labels["paste_multiline_rejected"])
+
+	// A single line with a trailing newline is accepted
+	m.passwordInput, _ = updateTextInput(m.passwordInput, pasteMsg("Secret123!\n"))
+	assert.Equal(t, "Secret123!", m.passwordInput.Value())
+}
+
+func TestPasswordPopup_RejectsMultilinePaste(t *testing.T) {
+	localization.SetCurrentLanguage("pt")
+	localization.AddWalletImportMessages()
+	t.Cleanup(func() {
+		localization.SetCurrentLanguage("en")
+		localization.AddWalletImportMessages()
+	})
+
+	popup := NewPasswordPopupModel("wallet.json", 3)
+	popup, _ = popup.Update(pasteMsg("a\nb"))
+	assert.Empty(t, popup.Value())
+	assert.Equal(t, localization.Labels["paste_multiline_rejected"], popup.errorMessage)
+	assert.Contains(t, popup.errorMessage, "Colagem com várias linhas")
+}
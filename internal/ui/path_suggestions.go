@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"blocowallet/internal/platform"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// pathSuggestions lists the entries matching a partially typed path, used for
+// autocomplete in the keystore path inputs. Directories get the platform
+// separator appended so completion can continue into them.
+func pathSuggestions(currentPath string) ([]string, error) {
+	if currentPath == "" {
+		currentPath = "."
+	}
+
+	// Get the directory and partial filename
+	dir := filepath.Dir(currentPath)
+	if dir == "." && !platform.IsExplicitPath(currentPath) {
+		dir = currentPath
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []string
+	partial := filepath.Base(currentPath)
+	for _, file := range files {
+		if strings.HasPrefix(file.Name(), partial) {
+			fullPath := filepath.Join(dir, file.Name())
+			if file.IsDir() {
+				fullPath += platform.DirSuffix()
+			}
+			matches = append(matches, fullPath)
+		}
+	}
+	return matches, nil
+}
@@ -0,0 +1,23 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathSuggestions_AppendsSeparatorToDirectories(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "keys"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "keystore.json"), []byte("{}"), 0600))
+
+	matches, err := pathSuggestions(filepath.Join(dir, "key"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(dir, "keys") + string(filepath.Separator),
+		filepath.Join(dir, "keystore.json"),
+	}, matches)
+}
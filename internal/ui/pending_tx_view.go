@@ -0,0 +1,412 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/internal/txtrack"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pendingPollInterval is how often open transactions are checked
+const pendingPollInterval = 15 * time.Second
+
+// maxListedTransactions caps the transactions shown, newest first
+const maxListedTransactions = 50
+
+// pendingTickMsg starts the next check of the open transactions
+type pendingTickMsg struct {
+	seq int
+}
+
+// pendingPolledMsg carries the tracked transactions after a check. Checks of
+// an older seq, superseded by a manual refresh, do not schedule another.
+type pendingPolledMsg struct {
+	seq    int
+	txs    []wallet.TrackedTransaction
+	closed []wallet.TrackedTransaction // open before this check, settled by it
+	err    error
+}
+
+// pendingReplacedMsg carries the outcome of a speed-up or cancel
+type pendingReplacedMsg struct {
+	tracked *wallet.TrackedTransaction
+	cancel  bool
+	err     error
+}
+
+// initPendingTransactions opens the list of tracked transactions
+func (m *CLIModel) initPendingTransactions() tea.Cmd {
+	m.pendingSelected = 0
+	m.closePendingAction()
+	m.setPendingResult("", false)
+	m.currentView = constants.PendingTransactionsView
+	return m.startPendingPolling()
+}
+
+// startPendingPolling checks the tracked transactions now and keeps doing so
+// while any is open. Nothing happens when a check is already scheduled.
+func (m *CLIModel) startPendingPolling() tea.Cmd {
+	if m.pendingPolling {
+		return nil
+	}
+	return m.refreshPendingNow()
+}
+
+// refreshPendingNow checks the tracked transactions right away, replacing any
+// scheduled check
+func (m *CLIModel) refreshPendingNow() tea.Cmd {
+	if m.Service == nil {
+		return nil
+	}
+	m.pendingPollSeq++
+	m.pendingPolling = true
+	return m.pendingPollCmd(m.pendingPollSeq)
+}
+
+func (m *CLIModel) pendingTracker() *txtrack.Tracker {
+	tracker := &txtrack.Tracker{Service: m.Service}
+	if m.currentConfig != nil {
+		tracker.Networks = m.currentConfig.Networks
+	}
+	return tracker
+}
+
+// pendingPollCmd refreshes the open transactions in the background and
+// returns the recent ones for display
+func (m *CLIModel) pendingPollCmd(seq int) tea.Cmd {
+	tracker := m.pendingTracker()
+	return func() tea.Msg {
+		polled, pollErr := tracker.Poll(context.Background())
+		var closed []wallet.TrackedTransaction
+		for _, tx := range polled {
+			if !tx.Open() {
+				closed = append(closed, tx)
+			}
+		}
+		txs, err := tracker.Service.TrackedTransactions(false)
+		if err != nil {
+			return pendingPolledMsg{seq: seq, closed: closed, err: err}
+		}
+		return pendingPolledMsg{seq: seq, txs: txs, closed: closed, err: pollErr}
+	}
+}
+
+// handlePendingTick runs the scheduled check, unless a refresh replaced it
+func (m *CLIModel) handlePendingTick(msg pendingTickMsg) tea.Cmd {
+	if msg.seq != m.pendingPollSeq {
+		return nil
+	}
+	return m.pendingPollCmd(msg.seq)
+}
+
+// handlePendingPolled keeps the list, reports settled transactions and
+// schedules the next check while a transaction is open
+func (m *CLIModel) handlePendingPolled(msg pendingPolledMsg) tea.Cmd {
+	notified := m.notifySettledTransactions(msg.closed)
+	if msg.txs != nil || msg.err == nil {
+		if len(msg.txs) > maxListedTransactions {
+			msg.txs = msg.txs[:maxListedTransactions]
+		}
+		m.trackedTxs = msg.txs
+	}
+	m.pendingPollErr = msg.err
+	if msg.seq != m.pendingPollSeq {
+		return notified
+	}
+	if m.openTransactions() == 0 {
+		m.pendingPolling = false
+		return notified
+	}
+	return tea.Batch(notified, tea.Tick(pendingPollInterval, func(time.Time) tea.Msg {
+		return pendingTickMsg{seq: msg.seq}
+	}))
+}
+
+// openTransactions counts the transactions still pending or gathering confirmations
+func (m *CLIModel) openTransactions() int {
+	open := 0
+	for _, tx := range m.trackedTxs {
+		if tx.Open() {
+			open++
+		}
+	}
+	return open
+}
+
+// updatePendingTransactions handles input on the transactions screen
+func (m *CLIModel) updatePendingTransactions(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.pendingBusy {
+		return m, nil
+	}
+	if m.pendingAction != "" {
+		if keyMsg.String() == "enter" {
+			return m, m.replacePendingTransaction()
+		}
+		var cmd tea.Cmd
+		m.pendingPassword, cmd = updateTextInput(m.pendingPassword, msg)
+		return m, cmd
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.pendingSelected > 0 {
+			m.pendingSelected--
+		}
+		return m, m.lookupPendingSignature()
+	case "down", "j":
+		if m.pendingSelected < len(m.trackedTxs)-1 {
+			m.pendingSelected++
+		}
+		return m, m.lookupPendingSignature()
+	case "r":
+		return m, m.refreshPendingNow()
+	case "s":
+		m.openPendingAction(wallet.TrackedKindSpeedUp)
+	case "x":
+		m.openPendingAction(wallet.TrackedKindCancel)
+	}
+	return m, nil
+}
+
+// lookupPendingSignature looks up the function called by the selected transaction
+func (m *CLIModel) lookupPendingSignature() tea.Cmd {
+	if m.pendingSelected >= len(m.trackedTxs) {
+		return nil
+	}
+	tx, err := m.trackedTxs[m.pendingSelected].Transaction()
+	if err != nil {
+		return nil
+	}
+	return m.lookupSignature(tx.Data())
+}
+
+// openPendingAction asks for the sender's password to speed up or cancel the
+// selected transaction
+func (m *CLIModel) openPendingAction(kind string) {
+	if m.pendingSelected >= len(m.trackedTxs) {
+		return
+	}
+	tx := m.trackedTxs[m.pendingSelected]
+	if wallet.TrackedTxState(tx.State) != wallet.TrackedPending {
+		m.setPendingResult(localization.Labels["pending_tx_not_replaceable"], true)
+		return
+	}
+	sender, err := m.Service.FindWalletByAddress(tx.From)
+	if err != nil {
+		m.setPendingResult(localization.Labels["pending_tx_not_local"], true)
+		return
+	}
+	m.pendingAction = kind
+	m.pendingSender = sender
+	m.pendingPassword = textinput.New()
+	m.pendingPassword.Placeholder = localization.Labels["pending_tx_password_placeholder"]
+	m.pendingPassword.EchoMode = textinput.EchoPassword
+	m.pendingPassword.EchoCharacter = '*'
+	m.pendingPassword.CharLimit = 128
+	m.pendingPassword.Width = 40
+	m.pendingPassword.Focus()
+	m.setPendingResult("", false)
+}
+
+// closePendingAction forgets the password prompt
+func (m *CLIModel) closePendingAction() {
+	m.pendingAction = ""
+	m.pendingSender = nil
+	m.pendingPassword = textinput.Model{}
+}
+
+// replacePendingTransaction unlocks the sender and sends the speed-up or
+// cancel transaction in the background
+func (m *CLIModel) replacePendingTransaction() tea.Cmd {
+	tx := m.trackedTxs[m.pendingSelected]
+	sender, password := m.pendingSender, m.pendingPassword.Value()
+	cancel := m.pendingAction == wallet.TrackedKindCancel
+	m.closePendingAction()
+	m.pendingBusy = true
+	m.setPendingResult(localization.Labels["pending_tx_replacing"], false)
+
+	tracker := m.pendingTracker()
+	return func() tea.Msg {
+		details, err := tracker.Service.LoadWallet(sender, password)
+		if err != nil {
+			return pendingReplacedMsg{cancel: cancel, err: err}
+		}
+		tracked, err := tracker.Replace(context.Background(), tx, details.Signer, cancel)
+		return pendingReplacedMsg{tracked: tracked, cancel: cancel, err: err}
+	}
+}
+
+// handlePendingReplaced reports the replacement and follows it
+func (m *CLIModel) handlePendingReplaced(msg pendingReplacedMsg) tea.Cmd {
+	m.pendingBusy = false
+	if msg.err != nil {
+		m.setPendingResult(fmt.Sprintf(localization.Labels["pending_tx_replace_failed"], msg.err), true)
+		return nil
+	}
+	label := "pending_tx_sped_up"
+	if msg.cancel {
+		label = "pending_tx_cancel_sent"
+	}
+	m.setPendingResult(fmt.Sprintf(localization.Labels[label], msg.tracked.Hash), false)
+	m.trackedTxs = append([]wallet.TrackedTransaction{*msg.tracked}, m.trackedTxs...)
+	m.pendingSelected = 0
+	return m.startPendingPolling()
+}
+
+func (m *CLIModel) setPendingResult(status string, failed bool) {
+	m.pendingStatus = status
+	m.pendingFailed = failed
+}
+
+// viewPendingTransactions renders the tracked transactions with their progress
+func (m *CLIModel) viewPendingTransactions() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["pending_tx_title"]))
+	b.WriteString("\n\n")
+
+	if len(m.trackedTxs) == 0 {
+		b.WriteString(localization.Labels["pending_tx_empty"])
+		b.WriteString("\n\n")
+	} else {
+		if m.pendingSelected >= len(m.trackedTxs) {
+			m.pendingSelected = len(m.trackedTxs) - 1
+		}
+		header := fmt.Sprintf("  %-12s %-14s %-19s %-6s %-20s %s",
+			localization.Labels["pending_tx_column_state"],
+			localization.Labels["pending_tx_column_network"],
+			localization.Labels["pending_tx_column_hash"],
+			localization.Labels["pending_tx_column_nonce"],
+			localization.Labels["pending_tx_column_confirmations"],
+			localization.Labels["pending_tx_column_kind"],
+		)
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render(header))
+		b.WriteString("\n")
+		tracker := m.pendingTracker()
+		for i, tx := range m.trackedTxs {
+			cursor := "  "
+			if i == m.pendingSelected {
+				cursor = "> "
+			}
+			line := fmt.Sprintf("%s%-12s %-14s %-19s %-6d %-20s %s",
+				cursor,
+				pendingStateLabel(tx.State),
+				truncateRunes(tx.Network, 14),
+				shortHash(tx.Hash),
+				tx.Nonce,
+				renderConfirmations(tx, tracker.Required(tx)),
+				localization.Labels["pending_tx_kind_"+tx.Kind],
+			)
+			if i == m.pendingSelected {
+				line = m.styles.SelectedTitle.Render(line)
+			}
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		selected := m.trackedTxs[m.pendingSelected]
+		b.WriteString(fmt.Sprintf(localization.Labels["pending_tx_selected"], selected.Hash, selected.From))
+		b.WriteString("\n")
+		if tx, err := selected.Transaction(); err == nil {
+			if call := m.describeCalldata(tx.Data()); call != "" {
+				b.WriteString(fmt.Sprintf(localization.Labels["calldata_call"], call))
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	if m.pendingAction != "" {
+		label := "pending_tx_confirm_speed_up"
+		if m.pendingAction == wallet.TrackedKindCancel {
+			label = "pending_tx_confirm_cancel"
+		}
+		b.WriteString(fmt.Sprintf(localization.Labels[label], m.pendingSender.Name, wallet.ReplacementBump))
+		b.WriteString("\n")
+		b.WriteString(m.pendingPassword.View())
+		b.WriteString("\n\n")
+	}
+
+	switch {
+	case m.pendingStatus != "" && m.pendingBusy:
+		b.WriteString(m.styles.MenuDesc.Render(m.pendingStatus))
+		b.WriteString("\n\n")
+	case m.pendingStatus != "" && m.pendingFailed:
+		b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.pendingStatus))
+		b.WriteString("\n\n")
+	case m.pendingStatus != "":
+		b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.pendingStatus))
+		b.WriteString("\n\n")
+	case m.pendingPollErr != nil:
+		b.WriteString(m.styles.ErrorStyle.Render(glyphs.Warning + " " + fmt.Sprintf(localization.Labels["pending_tx_poll_failed"], m.pendingPollErr)))
+		b.WriteString("\n\n")
+	}
+
+	help := localization.Labels["pending_tx_help"]
+	if m.pendingAction != "" {
+		help = localization.Labels["pending_tx_help_password"]
+	}
+	b.WriteString(m.styles.MenuDesc.Render(help))
+	return b.String()
+}
+
+func pendingStateLabel(state string) string {
+	if label, ok := localization.Labels["pending_tx_state_"+state]; ok {
+		return label
+	}
+	return state
+}
+
+// shortHash abbreviates a transaction hash as 0x1234abcd…5678
+func shortHash(hash string) string {
+	if len(hash) <= 19 {
+		return hash
+	}
+	return hash[:10] + "…" + hash[len(hash)-6:]
+}
+
+// renderConfirmations shows how close a mined transaction is to being final
+func renderConfirmations(tx wallet.TrackedTransaction, required uint64) string {
+	if tx.BlockNumber == 0 {
+		return "-"
+	}
+	confirmations := tx.Confirmations
+	if confirmations > required {
+		confirmations = required
+	}
+	return fmt.Sprintf("%s %d/%d", renderJobProgressBar(float64(confirmations)/float64(required), 8), confirmations, required)
+}
+
+// pendingStatusIndicator summarizes the open transactions for the status bar
+func (m *CLIModel) pendingStatusIndicator() string {
+	open := m.openTransactions()
+	if open == 0 {
+		return ""
+	}
+	// The open transaction furthest from its network's threshold shows how long is left
+	tracker := m.pendingTracker()
+	var least *wallet.TrackedTransaction
+	var leastProgress float64
+	for i := range m.trackedTxs {
+		tx := &m.trackedTxs[i]
+		if !tx.Open() {
+			continue
+		}
+		if tx.BlockNumber == 0 {
+			return fmt.Sprintf("Tx: %d pending", open)
+		}
+		progress := float64(tx.Confirmations) / float64(tracker.Required(*tx))
+		if least == nil || progress < leastProgress {
+			least, leastProgress = tx, progress
+		}
+	}
+	return fmt.Sprintf("Tx: %d %s", open, renderConfirmations(*least, tracker.Required(*least)))
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingTransactions_ListAndActions(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddPendingTxMessages()
+	localization.AddBroadcastMessages()
+
+	dir := t.TempDir()
+	repo, err := storage.NewWalletRepository(&config.Config{AppDir: dir, DatabasePath: filepath.Join(dir, "wallets.db")})
+	require.NoError(t, err)
+	defer repo.Close()
+	ws := &wallet.WalletService{Repo: repo}
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	tx, err := wallet.SignTransfer(wallet.NewKeySigner(key), wallet.TransferRequest{
+		ChainID: big.NewInt(1), To: common.HexToAddress("0x000000000000000000000000000000000000dEaD"), Value: big.NewInt(1),
+		GasLimit: wallet.TxGas, MaxFeePerGas: big.NewInt(2e9), MaxPriorityFeePerGas: big.NewInt(1e9),
+	})
+	require.NoError(t, err)
+
+	// A successful broadcast adds the transaction to the tracker and starts polling
+	m := &CLIModel{Service: ws, styles: createStyles()}
+	m.currentConfig = &config.Config{Networks: map[string]config.Network{}}
+	m.initBroadcast()
+	m.broadcastTx = &wallet.RawTransaction{Tx: tx, From: from}
+	_, err = ws.TrackTransaction(m.broadcastTx, "Ethereum", "", "")
+	require.NoError(t, err)
+	m.broadcastSending = true
+	cmd := m.handleBroadcastResult(broadcastResultMsg{status: blockchain.TxStatus{Hash: tx.Hash(), State: blockchain.TxPending}, tracked: true})
+	require.NotNil(t, cmd)
+	assert.True(t, m.pendingPolling)
+	assert.Contains(t, m.viewBroadcast(), "Followed in Transactions")
+
+	// Without networks the check fails, but the list is loaded and polling goes on
+	polled, ok := cmd().(pendingPolledMsg)
+	require.True(t, ok)
+	assert.Error(t, polled.err)
+	next := m.handlePendingPolled(polled)
+	assert.NotNil(t, next, "the next check is scheduled while a transaction is open")
+	require.Len(t, m.trackedTxs, 1)
+	assert.Equal(t, "Tx: 1 pending", m.pendingStatusIndicator())
+
+	// A refresh supersedes the scheduled check
+	refresh := m.refreshPendingNow()
+	require.NotNil(t, refresh)
+	assert.Nil(t, m.handlePendingTick(pendingTickMsg{seq: polled.seq}))
+	assert.Nil(t, m.handlePendingPolled(polled), "an old check does not schedule another")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	_ = m.initPendingTransactions()
+	require.Equal(t, constants.PendingTransactionsView, m.currentView)
+	view := m.viewPendingTransactions()
+	assert.Contains(t, view, "Pending")
+	assert.Contains(t, view, shortHash(tx.Hash().Hex()))
+	assert.Contains(t, view, "could not be checked")
+
+	// The sender is not a local wallet, so nothing can be signed
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	assert.True(t, m.pendingFailed)
+	assert.Empty(t, m.pendingAction)
+
+	// With the sender stored, x asks for its password and esc gives up
+	require.NoError(t, repo.AddWallet(&wallet.Wallet{Name: "Hot", Address: from.Hex(), KeyStorePath: "hot.json"}))
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	require.Equal(t, wallet.TrackedKindCancel, m.pendingAction)
+	assert.True(t, m.capturesTextInput())
+	assert.Contains(t, m.viewPendingTransactions(), "Password of \"Hot\"")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Empty(t, m.pendingAction)
+	assert.Equal(t, constants.PendingTransactionsView, m.currentView)
+
+	// A sent cancel appears at the top of the list
+	cancel := wallet.TrackedTransaction{Hash: "0xcancel", Kind: wallet.TrackedKindCancel, State: string(wallet.TrackedPending)}
+	m.pendingBusy = true
+	_ = m.handlePendingReplaced(pendingReplacedMsg{tracked: &cancel, cancel: true})
+	assert.False(t, m.pendingBusy)
+	assert.Equal(t, "0xcancel", m.trackedTxs[0].Hash)
+	assert.Contains(t, m.pendingStatus, "Cancel sent")
+
+	// Finished transactions stop the polling and leave the status bar
+	for i := range m.trackedTxs {
+		m.trackedTxs[i].State = string(wallet.TrackedFinal)
+	}
+	assert.Empty(t, m.pendingStatusIndicator())
+}
+
+func TestRenderConfirmations(t *testing.T) {
+	assert.Equal(t, "-", renderConfirmations(wallet.TrackedTransaction{}, 12))
+	assert.Contains(t, renderConfirmations(wallet.TrackedTransaction{BlockNumber: 5, Confirmations: 3}, 12), "3/12")
+	assert.Contains(t, renderConfirmations(wallet.TrackedTransaction{BlockNumber: 5, Confirmations: 30}, 12), "12/12")
+}
+
+func TestPendingStatusIndicator_NetworkThreshold(t *testing.T) {
+	m := &CLIModel{styles: createStyles()}
+	m.currentConfig = &config.Config{Networks: map[string]config.Network{
+		"base": {Name: "Base", ChainID: 8453, RPCEndpoint: "http://base", IsActive: true, Confirmations: 2},
+	}}
+	m.trackedTxs = []wallet.TrackedTransaction{
+		{Hash: "0x1", Network: "Base", ChainID: 8453, State: string(wallet.TrackedIncluded), BlockNumber: 9, Confirmations: 1},
+		{Hash: "0x2", Network: "Ethereum", ChainID: 1, State: string(wallet.TrackedIncluded), BlockNumber: 9, Confirmations: 6},
+	}
+	assert.Contains(t, m.pendingStatusIndicator(), "1/2", "the furthest from its own threshold is shown")
+
+	m.trackedTxs[0].Confirmations = 2
+	assert.Contains(t, m.pendingStatusIndicator(), "6/12")
+	assert.Nil(t, m.notifySettledTransactions(m.trackedTxs), "nothing is sent without a webhook")
+}
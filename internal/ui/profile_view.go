@@ -0,0 +1,301 @@
+package ui
+
+import (
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/compliance"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/metasync"
+	"blocowallet/internal/notify"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+	"blocowallet/pkg/logger"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func init() {
+	registerKeyMap(constants.ProfilesView, profilesKeys)
+}
+
+// ProfileSession is a profile opened by the ProfileOpener: its configuration
+// and the services built on its database and keystores
+type ProfileSession struct {
+	Name           string
+	Config         *config.Config
+	Service        *wallet.WalletService
+	Syncer         *metasync.Syncer
+	Notifier       *notify.Notifier
+	Gate           *compliance.Gate
+	ProtectedPaths []string
+	Quarantined    []blockchain.EndpointCheck
+}
+
+// ProfileOpener opens the named profile. It releases the database of the
+// profile in use only once the new one is open, so a failure leaves the
+// current profile working.
+type ProfileOpener func(name string) (*ProfileSession, error)
+
+// profileOpenedMsg carries the profile opened in the background
+type profileOpenedMsg struct {
+	session *ProfileSession
+	err     error
+}
+
+// SetProfileOpener enables switching profiles from the configuration menu
+func (m *CLIModel) SetProfileOpener(open ProfileOpener) {
+	m.profileOpener = open
+}
+
+// profileDirectory returns the directory holding the profiles and the name of
+// the profile in use
+func profileDirectory() (baseDir, current string, err error) {
+	cm := getConfigurationManager()
+	if cm.GetBaseDirectory() == "" {
+		if _, err := loadOrCreateConfig(); err != nil {
+			return "", "", err
+		}
+	}
+	return cm.GetBaseDirectory(), cm.GetProfile(), nil
+}
+
+// initProfiles opens the profile list from the configuration menu
+func (m *CLIModel) initProfiles() {
+	m.profileInput = textinput.New()
+	m.profileInput.Placeholder = localization.Labels["profile_name_placeholder"]
+	m.profileInput.CharLimit = 32
+	m.profileInput.Width = 32
+
+	m.profileCreating = false
+	m.setProfileResult("", false)
+	m.loadProfiles()
+	m.currentView = constants.ProfilesView
+}
+
+// loadProfiles lists the profiles with the cursor on the one in use
+func (m *CLIModel) loadProfiles() {
+	baseDir, current, err := profileDirectory()
+	if err == nil {
+		m.profiles, err = config.ListProfiles(baseDir)
+	}
+	if err != nil {
+		m.profiles = nil
+		m.setProfileResult(fmt.Sprintf(localization.Labels["profile_list_failed"], err), true)
+		return
+	}
+	m.profileCursor = 0
+	for i, name := range m.profiles {
+		if name == current {
+			m.profileCursor = i
+		}
+	}
+}
+
+func (m *CLIModel) setProfileResult(status string, failed bool) {
+	m.profileStatus = status
+	m.profileFailed = failed
+}
+
+// closeProfiles closes the new profile form, or goes back to the
+// configuration menu
+func (m *CLIModel) closeProfiles() {
+	if m.profileCreating {
+		m.profileCreating = false
+		m.profileInput.Blur()
+		return
+	}
+	m.profiles = nil
+	m.initConfigMenu()
+}
+
+// profilesKeys are the keys of the profile list, or of the name of a new
+// profile while it is typed
+func profilesKeys(m *CLIModel) []key.Binding {
+	if m.profileCreating {
+		return []key.Binding{
+			newBinding("enter", "key_create", "enter"),
+			newBinding("esc", "key_cancel", "esc"),
+		}
+	}
+	return []key.Binding{
+		newBinding("↑/↓", "key_move", "up", "k", "down", "j"),
+		newBinding("enter", "key_switch", "enter"),
+		newBinding("n", "key_new_profile", "n"),
+	}
+}
+
+// updateProfiles moves through the profiles, creates one or switches to the
+// selected one
+func (m *CLIModel) updateProfiles(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.profileSwitching {
+		return m, nil
+	}
+	if m.profileCreating {
+		if keyMsg.String() == "enter" {
+			m.createProfile()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.profileInput, cmd = updateTextInput(m.profileInput, keyMsg)
+		return m, cmd
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.profileCursor > 0 {
+			m.profileCursor--
+		}
+	case "down", "j":
+		if m.profileCursor < len(m.profiles)-1 {
+			m.profileCursor++
+		}
+	case "n":
+		m.profileInput.SetValue("")
+		m.profileInput.Focus()
+		m.profileCreating = true
+		m.setProfileResult("", false)
+	case "enter":
+		return m, m.switchProfile()
+	}
+	return m, nil
+}
+
+// createProfile makes the profile typed in the form and selects it
+func (m *CLIModel) createProfile() {
+	name := strings.TrimSpace(m.profileInput.Value())
+	baseDir, _, err := profileDirectory()
+	if err == nil {
+		err = config.CreateProfile(baseDir, name)
+	}
+	if err != nil {
+		m.setProfileResult(fmt.Sprintf(localization.Labels["profile_create_failed"], err), true)
+		return
+	}
+	m.profileCreating = false
+	m.profileInput.Blur()
+	m.loadProfiles()
+	for i, profile := range m.profiles {
+		if profile == name {
+			m.profileCursor = i
+		}
+	}
+	m.setProfileResult(fmt.Sprintf(localization.Labels["profile_created"], name), false)
+}
+
+// switchProfile opens the selected profile in the background; opening the
+// database and checking the networks may take a while
+func (m *CLIModel) switchProfile() tea.Cmd {
+	if m.profileCursor >= len(m.profiles) {
+		return nil
+	}
+	if m.profileOpener == nil {
+		m.setProfileResult(localization.Labels["profile_switch_unavailable"], true)
+		return nil
+	}
+	name := m.profiles[m.profileCursor]
+	if _, current, err := profileDirectory(); err == nil && name == current {
+		return nil
+	}
+	m.profileSwitching = true
+	m.setProfileResult(fmt.Sprintf(localization.Labels["profile_switching"], name), false)
+	open := m.profileOpener
+	return func() tea.Msg {
+		session, err := open(name)
+		return profileOpenedMsg{session: session, err: err}
+	}
+}
+
+// handleProfileOpened replaces the services of the previous profile with
+// those of the opened one. The master password of the profile is asked for
+// as on startup, before its main menu.
+func (m *CLIModel) handleProfileOpened(msg profileOpenedMsg) tea.Cmd {
+	m.profileSwitching = false
+	if msg.err != nil {
+		m.setProfileResult(fmt.Sprintf(localization.Labels["profile_switch_failed"], msg.err), true)
+		return nil
+	}
+	s := msg.session
+
+	// Both managers keep the config.toml of the previous profile
+	globalConfigManager = nil
+	globalNetworkManager = nil
+
+	m.Service = s.Service
+	m.SetSyncer(s.Syncer)
+	m.SetNotifier(s.Notifier)
+	m.SetComplianceGate(s.Gate)
+	m.SetProtectedPaths(s.ProtectedPaths...)
+	m.SetQuarantinedNetworks(s.Quarantined)
+	m.permissionIssues = nil
+	m.releaseNotes = nil
+	m.walletDetails = nil
+	m.wallets = nil
+	m.profiles = nil
+	if err := m.applyConfig(s.Config); err != nil && uiLogger != nil {
+		uiLogger.Warn("Failed to apply the configuration of the profile", logger.Error(err), logger.String("profile", s.Name))
+	}
+	if uiLogger != nil {
+		uiLogger.Info("Switched profile", logger.String("profile", s.Name), logger.String("app_dir", s.Config.AppDir))
+	}
+
+	cmds := []tea.Cmd{m.refreshWalletsTable(), loadInheritancePlanCmd(), m.enforcePermissionsCmd()}
+	m.menuItems = m.mainMenu()
+	m.selectedMenu = 0
+	if !m.openMasterPasswordAtStart() {
+		cmds = append(cmds, m.enterMainMenu())
+	}
+	return tea.Batch(cmds...)
+}
+
+// viewProfiles lists the profiles, marking the one in use
+func (m *CLIModel) viewProfiles() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["profiles_title"]))
+	b.WriteString("\n\n")
+
+	baseDir, current, _ := profileDirectory()
+	if baseDir != "" {
+		b.WriteString(fmt.Sprintf(localization.Labels["profiles_base_dir"], baseDir))
+		b.WriteString("\n\n")
+	}
+	for i, name := range m.profiles {
+		line := name
+		if name == current {
+			line += " " + localization.Labels["profile_current"]
+		}
+		if i == m.profileCursor {
+			b.WriteString(m.styles.MenuSelected.Render("> " + line))
+		} else {
+			b.WriteString(m.styles.MenuItem.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+	if m.profileCursor < len(m.profiles) {
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("%s %s\n", glyphs.Bullet, config.ProfileDir(baseDir, m.profiles[m.profileCursor])))
+	}
+
+	if m.profileCreating {
+		b.WriteString("\n")
+		b.WriteString(localization.Labels["profile_name"])
+		b.WriteString("\n")
+		b.WriteString(m.profileInput.View())
+		b.WriteString("\n")
+	}
+
+	if m.profileStatus != "" {
+		b.WriteString("\n")
+		if m.profileFailed {
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.profileStatus))
+		} else {
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.profileStatus))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
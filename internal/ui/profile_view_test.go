@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiles_CreateAndSwitch(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddProfileMessages()
+	baseDir := t.TempDir()
+	t.Setenv("BLOCO_WALLET_APP_APP_DIR", baseDir)
+	t.Setenv(config.ProfileEnv, "")
+	previousManager := globalConfigManager
+	globalConfigManager = nil
+	defer func() { globalConfigManager = previousManager }()
+
+	original := &wallet.WalletService{}
+	m := &CLIModel{
+		Service:     original,
+		styles:      createStyles(),
+		currentView: constants.ConfigurationView,
+	}
+	m.initConfigMenu()
+	m.selectedMenu = 8
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, constants.ProfilesView, m.currentView)
+	assert.Equal(t, []string{config.DefaultProfile}, m.profiles)
+	assert.Contains(t, m.viewProfiles(), "default (in use)")
+
+	// Without an opener the profile can only be chosen on startup
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	assert.True(t, m.capturesTextInput(), "the name is typed")
+	m.profileInput.SetValue("work")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, m.profileFailed, m.profileStatus)
+	assert.Equal(t, []string{config.DefaultProfile, "work"}, m.profiles)
+	assert.Equal(t, 1, m.profileCursor, "the new profile is selected")
+	assert.DirExists(t, filepath.Join(baseDir, "profiles", "work"))
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.True(t, m.profileFailed)
+
+	// A profile that fails to open leaves the current one in use
+	m.SetProfileOpener(func(name string) (*ProfileSession, error) {
+		return nil, errors.New("database locked")
+	})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	_, _ = m.Update(cmd())
+	assert.True(t, m.profileFailed)
+	assert.Contains(t, m.profileStatus, "database locked")
+	assert.Same(t, original, m.Service)
+
+	// The opened profile replaces the services and asks for its master password
+	workDir := filepath.Join(baseDir, "profiles", "work")
+	repo, err := storage.NewWalletRepository(&config.Config{AppDir: workDir, DatabasePath: filepath.Join(workDir, "bloco.db")})
+	require.NoError(t, err)
+	defer repo.Close()
+	opened := &wallet.WalletService{Repo: repo}
+	m.SetProfileOpener(func(name string) (*ProfileSession, error) {
+		t.Setenv(config.ProfileEnv, name)
+		return &ProfileSession{Name: name, Config: &config.Config{AppDir: workDir, Language: "en"}, Service: opened}, nil
+	})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.True(t, m.profileSwitching)
+	_, _ = m.Update(cmd())
+	assert.False(t, m.profileFailed, m.profileStatus)
+	assert.Same(t, opened, m.Service)
+	assert.Equal(t, workDir, m.currentConfig.AppDir)
+	assert.Equal(t, constants.MasterPasswordView, m.currentView)
+
+	_, current, err := profileDirectory()
+	require.NoError(t, err)
+	assert.Equal(t, "work", current)
+}
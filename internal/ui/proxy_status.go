@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"blocowallet/internal/blockchain"
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// proxyCheckInterval is how often the SOCKS5 proxy is checked while the
+// application runs, so the status bar notices a stopped Tor
+const proxyCheckInterval = time.Minute
+
+// proxyCheckedMsg carries the outcome of a proxy check
+type proxyCheckedMsg struct {
+	err error
+}
+
+// proxyCheckCmd checks the configured proxy in the background; nil without one
+func proxyCheckCmd() tea.Cmd {
+	if blockchain.ProxyAddress() == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return proxyCheckedMsg{err: blockchain.CheckProxy(ctx)}
+	}
+}
+
+// handleProxyChecked keeps the result and schedules the next check
+func (m *CLIModel) handleProxyChecked(msg proxyCheckedMsg) tea.Cmd {
+	m.proxyChecked = true
+	m.proxyErr = msg.err
+	return tea.Tick(proxyCheckInterval, func(time.Time) tea.Msg {
+		return proxyCheckMsg{}
+	})
+}
+
+// proxyCheckMsg asks for the next scheduled check
+type proxyCheckMsg struct{}
+
+// proxyStatusIndicator shows whether outbound traffic can go through the
+// proxy; empty when no proxy is configured
+func (m *CLIModel) proxyStatusIndicator() string {
+	switch {
+	case blockchain.ProxyAddress() == "":
+		return ""
+	case !m.proxyChecked:
+		return "SOCKS5 …"
+	case m.proxyErr != nil:
+		return "SOCKS5 " + glyphs.Cross
+	default:
+		return "SOCKS5 " + glyphs.Check
+	}
+}
@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"testing"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyStatusIndicator(t *testing.T) {
+	m := &CLIModel{styles: createStyles()}
+	assert.Empty(t, m.proxyStatusIndicator(), "hidden without a proxy")
+	assert.Nil(t, proxyCheckCmd())
+
+	require.NoError(t, blockchain.SetProxy(config.ProxyConfig{URL: "socks5h://127.0.0.1:1", RPC: true}))
+	t.Cleanup(func() { _ = blockchain.SetProxy(config.ProxyConfig{}) })
+	assert.Contains(t, m.proxyStatusIndicator(), "…")
+
+	// Nothing listens on port 1: the check fails and is scheduled again
+	msg, ok := proxyCheckCmd()().(proxyCheckedMsg)
+	require.True(t, ok)
+	assert.Error(t, msg.err)
+	assert.NotNil(t, m.handleProxyChecked(msg))
+	assert.Equal(t, "SOCKS5 "+glyphs.Cross, m.proxyStatusIndicator())
+
+	m.handleProxyChecked(proxyCheckedMsg{})
+	assert.Equal(t, "SOCKS5 "+glyphs.Check, m.proxyStatusIndicator())
+}
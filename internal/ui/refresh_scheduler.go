@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"blocowallet/internal/wallet"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/go-errors/errors"
+)
+
+// walletRefreshMinInterval is the minimum time between two wallet reloads
+const walletRefreshMinInterval = 300 * time.Millisecond
+
+// walletsRefreshDueMsg signals that the coalescing window elapsed and a reload can start
+type walletsRefreshDueMsg struct{}
+
+// walletsLoadedMsg carries wallets read off the UI thread
+type walletsLoadedMsg struct {
+	wallets []wallet.Wallet
+	err     error
+}
+
+// refreshScheduler coalesces wallet refresh requests: bursts are merged into a
+// single reload, reloads are spaced by a minimum interval and a request made
+// while a reload is in flight schedules exactly one follow-up reload.
+type refreshScheduler struct {
+	minInterval time.Duration
+	lastRun     time.Time
+	scheduled   bool // a reload is waiting for its tick or running
+	rerun       bool // another request arrived while a reload was running
+	now         func() time.Time
+}
+
+func newRefreshScheduler(minInterval time.Duration) *refreshScheduler {
+	return &refreshScheduler{minInterval: minInterval, now: time.Now}
+}
+
+// Request schedules a reload. It returns nil when the request was merged into
+// one that is already scheduled.
+func (s *refreshScheduler) Request() tea.Cmd {
+	if s.scheduled {
+		s.rerun = true
+		return nil
+	}
+	s.scheduled = true
+	s.rerun = false
+
+	wait := s.minInterval - s.now().Sub(s.lastRun)
+	if wait <= 0 {
+		return func() tea.Msg { return walletsRefreshDueMsg{} }
+	}
+	return tea.Tick(wait, func(time.Time) tea.Msg { return walletsRefreshDueMsg{} })
+}
+
+// Done marks the running reload as finished and returns a follow-up command
+// when requests arrived in the meantime.
+func (s *refreshScheduler) Done() tea.Cmd {
+	s.lastRun = s.now()
+	s.scheduled = false
+	if s.rerun {
+		return s.Request()
+	}
+	return nil
+}
+
+// loadWalletsCmd reads wallets from the service off the UI thread
+func loadWalletsCmd(service *wallet.WalletService) tea.Cmd {
+	return func() tea.Msg {
+		wallets, err := service.GetAllWallets()
+		return walletsLoadedMsg{wallets: wallets, err: err}
+	}
+}
+
+// getRefreshScheduler returns the model scheduler, creating it on first use
+func (m *CLIModel) getRefreshScheduler() *refreshScheduler {
+	if m.refreshScheduler == nil {
+		m.refreshScheduler = newRefreshScheduler(walletRefreshMinInterval)
+	}
+	return m.refreshScheduler
+}
+
+// handleWalletsRefresh processes the scheduler messages; ok is false for other messages
+func (m *CLIModel) handleWalletsRefresh(msg tea.Msg) (cmd tea.Cmd, ok bool) {
+	switch msg := msg.(type) {
+	case walletsRefreshDueMsg:
+		if m.Service == nil {
+			return m.getRefreshScheduler().Done(), true
+		}
+		return loadWalletsCmd(m.Service), true
+	case walletsLoadedMsg:
+		if msg.err != nil {
+			m.err = errors.Wrap(msg.err, 0)
+		} else {
+			m.applyWallets(msg.wallets)
+		}
+		return m.getRefreshScheduler().Done(), true
+	}
+	return nil, false
+}
+
+// applyWallets updates the model with a fresh wallet list, touching the table
+// only when something actually changed
+func (m *CLIModel) applyWallets(wallets []wallet.Wallet) {
+	m.walletCount = len(wallets)
+	if walletListsEqual(m.wallets, wallets) {
+		return
+	}
+	m.wallets = wallets
+
+	if len(wallets) == 0 {
+		m.walletTable.SetRows(nil)
+		return
+	}
+
+	// Same column layout: replacing only the rows keeps the cursor and avoids flicker
+	if len(m.walletTable.Columns()) == walletTableColumnCount {
+		cursor := m.walletTable.Cursor()
+		rows := walletTableRows(m.visibleWallets(), m.batchExportMarked)
+		m.walletTable.SetRows(rows)
+		if cursor >= len(rows) {
+			cursor = max(len(rows)-1, 0)
+		}
+		m.walletTable.SetCursor(cursor)
+		return
+	}
+
+	m.rebuildWalletsTable()
+}
+
+// walletListsEqual compares the fields shown in the wallet table. The version
+// catches edits to fields that are only searched, such as notes.
+func walletListsEqual(a, b []wallet.Wallet) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID ||
+			a[i].Name != b[i].Name ||
+			a[i].Address != b[i].Address ||
+			a[i].ImportMethod != b[i].ImportMethod ||
+			a[i].Version != b[i].Version ||
+			!a[i].CreatedAt.Equal(b[i].CreatedAt) {
+			return false
+		}
+	}
+	return true
+}
+
+// walletTableColumnCount is the number of columns built by rebuildWalletsTable
+const walletTableColumnCount = 5
+
+// walletTableRows converts wallets into wallet table rows, flagging the names
+// of those marked for a batch export
+func walletTableRows(wallets []wallet.Wallet, marked map[string]bool) []table.Row {
+	rows := make([]table.Row, 0, len(wallets))
+	for _, w := range wallets {
+		name := w.Name
+		if marked[w.Address] {
+			name = "● " + name
+		}
+		rows = append(rows, table.Row{
+			fmt.Sprintf("%d", w.ID),
+			name,
+			determineWalletType(w),
+			w.CreatedAt.Format("2006-01-02 15:04"),
+			wallet.ChecksumAddress(w.Address),
+		})
+	}
+	return rows
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"blocowallet/internal/wallet"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshScheduler_CoalescesBursts(t *testing.T) {
+	s := newRefreshScheduler(time.Second)
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	first := s.Request()
+	require.NotNil(t, first)
+	assert.IsType(t, walletsRefreshDueMsg{}, first())
+
+	// Requests while a reload is scheduled are merged
+	assert.Nil(t, s.Request())
+	assert.Nil(t, s.Request())
+
+	// Finishing the reload schedules exactly one follow-up, delayed by the interval
+	followUp := s.Done()
+	require.NotNil(t, followUp)
+
+	// Nothing new was requested during the follow-up
+	assert.Nil(t, s.Done())
+}
+
+func TestRefreshScheduler_NoFollowUpWithoutNewRequests(t *testing.T) {
+	s := newRefreshScheduler(0)
+	require.NotNil(t, s.Request())
+	assert.Nil(t, s.Done())
+	assert.NotNil(t, s.Request())
+}
+
+func TestApplyWallets_SkipsUnchangedLists(t *testing.T) {
+	m := &CLIModel{styles: createStyles(), width: 120, height: 40}
+	created := time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC)
+	wallets := []wallet.Wallet{
+		{ID: 1, Name: "one", Address: "0x01", ImportMethod: string(wallet.ImportMethodMnemonic), CreatedAt: created},
+		{ID: 2, Name: "two", Address: "0x02", ImportMethod: string(wallet.ImportMethodPrivateKey), CreatedAt: created},
+	}
+
+	m.applyWallets(wallets)
+	require.Len(t, m.walletTable.Rows(), 2)
+	assert.Equal(t, 2, m.walletCount)
+
+	m.walletTable.SetCursor(1)
+	m.applyWallets(append([]wallet.Wallet(nil), wallets...))
+	assert.Equal(t, 1, m.walletTable.Cursor(), "unchanged list must not reset the table")
+
+	// Removing the selected wallet keeps the cursor within bounds
+	m.applyWallets(wallets[:1])
+	assert.Len(t, m.walletTable.Rows(), 1)
+	assert.Equal(t, 0, m.walletTable.Cursor())
+	assert.Equal(t, "one", m.walletTable.Rows()[0][1])
+}
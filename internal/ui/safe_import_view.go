@@ -0,0 +1,277 @@
+package ui
+
+import (
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	safeFocusAddress = iota
+	safeFocusNetwork
+	safeFocusLabel
+	safeFocusCount
+)
+
+// safeFetchedMsg carries the owners read from a Safe
+type safeFetchedMsg struct {
+	chainID int64
+	info    blockchain.SafeInfo
+	err     error
+}
+
+// initSafeImport opens the form that imports the owners of a Safe
+func (m *CLIModel) initSafeImport() {
+	if m.currentConfig == nil {
+		if err := m.ensureConfigAndNetworksLoaded(); err != nil {
+			m.setSafeResult(err.Error(), true)
+		}
+	}
+	m.safeAddressInput = textinput.New()
+	m.safeAddressInput.Placeholder = "0x..."
+	m.safeAddressInput.CharLimit = 42
+	m.safeAddressInput.Width = 44
+
+	m.safeLabelInput = textinput.New()
+	m.safeLabelInput.Placeholder = localization.Labels["safe_label_placeholder"]
+	m.safeLabelInput.CharLimit = 40
+	m.safeLabelInput.Width = 40
+
+	m.safeNetwork = 0
+	m.resetSafeImport()
+	m.setSafeFocus(safeFocusAddress)
+	m.currentView = constants.SafeImportView
+}
+
+// resetSafeImport goes back to the form, keeping what was typed
+func (m *CLIModel) resetSafeImport() {
+	m.safeFetching = false
+	m.safeInfo = nil
+	m.safeOwners = nil
+	m.safeImported = nil
+	m.setSafeResult("", false)
+}
+
+func (m *CLIModel) setSafeFocus(focus int) {
+	m.safeFocus = focus
+	m.safeAddressInput.Blur()
+	m.safeLabelInput.Blur()
+	switch focus {
+	case safeFocusAddress:
+		m.safeAddressInput.Focus()
+	case safeFocusLabel:
+		m.safeLabelInput.Focus()
+	}
+}
+
+func (m *CLIModel) setSafeResult(status string, failed bool) {
+	m.safeStatus = status
+	m.safeFailed = failed
+}
+
+// updateSafeImport handles input on the Safe owners screen: the form, the
+// review of the owners found and the result
+func (m *CLIModel) updateSafeImport(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.safeFetching {
+		return m, nil
+	}
+
+	if m.safeImported != nil {
+		if keyMsg.String() == "enter" {
+			m.safeAddressInput.SetValue("")
+			m.resetSafeImport()
+			m.setSafeFocus(safeFocusAddress)
+		}
+		return m, nil
+	}
+
+	if m.safeInfo != nil {
+		switch keyMsg.String() {
+		case "enter":
+			return m, m.importSafeOwners()
+		case "backspace":
+			m.resetSafeImport()
+		}
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "tab", "down":
+		m.setSafeFocus((m.safeFocus + 1) % safeFocusCount)
+		return m, nil
+	case "shift+tab", "up":
+		m.setSafeFocus((m.safeFocus + safeFocusCount - 1) % safeFocusCount)
+		return m, nil
+	case "left", "right":
+		if m.safeFocus == safeFocusNetwork {
+			if networks := m.usableNetworks(); len(networks) > 0 {
+				step := 1
+				if keyMsg.String() == "left" {
+					step = -1
+				}
+				m.safeNetwork = (m.safeNetwork + step + len(networks)) % len(networks)
+			}
+			return m, nil
+		}
+	case "enter":
+		return m, m.fetchSafeOwners()
+	}
+
+	var cmd tea.Cmd
+	switch m.safeFocus {
+	case safeFocusAddress:
+		m.safeAddressInput, cmd = updateTextInput(m.safeAddressInput, msg)
+	case safeFocusLabel:
+		m.safeLabelInput, cmd = updateTextInput(m.safeLabelInput, msg)
+	}
+	return m, cmd
+}
+
+// fetchSafeOwners reads the owners of the Safe on the selected network
+func (m *CLIModel) fetchSafeOwners() tea.Cmd {
+	safe, err := m.readAddress(m.safeAddressInput.Value(), localization.Labels["safe_invalid_address"])
+	if err != nil {
+		m.setSafeResult(err.Error(), true)
+		m.setSafeFocus(safeFocusAddress)
+		return nil
+	}
+	networks := m.usableNetworks()
+	if m.safeNetwork < 0 || m.safeNetwork >= len(networks) {
+		m.setSafeResult(localization.Labels["broadcast_no_networks"], true)
+		return nil
+	}
+	network := networks[m.safeNetwork]
+	m.safeFetching = true
+	m.setSafeResult(fmt.Sprintf(localization.Labels["safe_fetching"], network.Name), false)
+	return func() tea.Msg {
+		client, err := blockchain.NewEthereum(network.RPCEndpoint, blockchain.DefaultTimeout, network.Symbol, 18, network.Name)
+		if err != nil {
+			return safeFetchedMsg{err: err}
+		}
+		defer client.Close()
+		info, err := client.SafeOwners(context.Background(), safe.Hex())
+		return safeFetchedMsg{chainID: network.ChainID, info: info, err: err}
+	}
+}
+
+// handleSafeFetched shows the owners found and which of them are known
+func (m *CLIModel) handleSafeFetched(msg safeFetchedMsg) {
+	if !m.safeFetching {
+		return
+	}
+	m.safeFetching = false
+	if msg.err != nil {
+		m.setSafeResult(fmt.Sprintf(localization.Labels["safe_fetch_failed"], msg.err), true)
+		return
+	}
+	owners, err := m.Service.SafeOwners(msg.info.Owners)
+	if err != nil {
+		m.setSafeResult(err.Error(), true)
+		return
+	}
+	info := msg.info
+	m.safeInfo = &info
+	m.safeChainID = msg.chainID
+	m.safeOwners = owners
+	m.setSafeResult("", false)
+}
+
+// importSafeOwners adds the watch-only entries and tags the known owners
+func (m *CLIModel) importSafeOwners() tea.Cmd {
+	result, err := m.Service.ImportSafeOwners(m.safeChainID, m.safeInfo.Address, m.safeOwners, m.safeLabelInput.Value(), time.Now())
+	m.safeImported = &result
+	if err != nil {
+		m.setSafeResult(fmt.Sprintf(localization.Labels["safe_import_failed"], err, len(result.Created)), true)
+	} else {
+		m.setSafeResult(fmt.Sprintf(localization.Labels["safe_imported"], len(result.Created), len(result.Tagged)), false)
+	}
+	// Show the owners with their new entries
+	if owners, err := m.Service.SafeOwners(m.safeInfo.Owners); err == nil {
+		m.safeOwners = owners
+	}
+	return m.refreshWalletsTable()
+}
+
+// viewSafeImport renders the form, the owners found or the result
+func (m *CLIModel) viewSafeImport() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["safe_title"]))
+	b.WriteString("\n\n")
+
+	if m.safeInfo == nil {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["safe_desc"]))
+		b.WriteString("\n\n")
+		b.WriteString(localization.Labels["safe_address"])
+		b.WriteString("\n")
+		b.WriteString(m.safeAddressInput.View())
+		b.WriteString("\n\n")
+		networkName := "-"
+		if networks := m.usableNetworks(); m.safeNetwork < len(networks) {
+			networkName = networks[m.safeNetwork].Name
+		}
+		line := fmt.Sprintf("%s < %s >", localization.Labels["broadcast_network"], networkName)
+		if m.safeFocus == safeFocusNetwork {
+			line = m.styles.SelectedTitle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n\n")
+		b.WriteString(localization.Labels["safe_label"])
+		b.WriteString("\n")
+		b.WriteString(m.safeLabelInput.View())
+		b.WriteString("\n\n")
+	} else {
+		b.WriteString(fmt.Sprintf(localization.Labels["safe_summary"], m.safeInfo.Address.Hex(), m.safeInfo.Threshold, len(m.safeInfo.Owners)))
+		b.WriteString("\n\n")
+		for _, owner := range m.safeOwners {
+			b.WriteString(fmt.Sprintf("%s %s  %s\n", glyphs.Bullet, owner.Address.Hex(), safeOwnerState(owner)))
+		}
+		b.WriteString("\n")
+	}
+
+	if m.safeStatus != "" {
+		switch {
+		case m.safeFetching:
+			b.WriteString(m.styles.MenuDesc.Render(m.safeStatus))
+		case m.safeFailed:
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.safeStatus))
+		default:
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.safeStatus))
+		}
+		b.WriteString("\n\n")
+	}
+
+	help := localization.Labels["safe_help"]
+	switch {
+	case m.safeImported != nil:
+		help = localization.Labels["safe_help_done"]
+	case m.safeInfo != nil:
+		help = localization.Labels["safe_help_review"]
+	}
+	b.WriteString(m.styles.MenuDesc.Render(help))
+	return b.String()
+}
+
+// safeOwnerState describes what the wallet list knows of an owner
+func safeOwnerState(owner wallet.SafeOwner) string {
+	if len(owner.Wallets) == 0 {
+		return localization.Labels["safe_owner_new"]
+	}
+	names := make([]string, 0, len(owner.Wallets))
+	for _, w := range owner.Wallets {
+		names = append(names, w.Name)
+	}
+	key := "safe_owner_watched"
+	if owner.HasKey() {
+		key = "safe_owner_local"
+	}
+	return fmt.Sprintf(localization.Labels[key], strings.Join(names, ", "))
+}
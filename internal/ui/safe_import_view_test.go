@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeImport_WatchOnlyOwners(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddSafeMessages()
+
+	dir := t.TempDir()
+	repo, err := storage.NewWalletRepository(&config.Config{AppDir: dir, DatabasePath: filepath.Join(dir, "wallets.db")})
+	require.NoError(t, err)
+	defer repo.Close()
+	ws := &wallet.WalletService{Repo: repo}
+
+	local := common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")
+	remote := common.HexToAddress("0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0")
+	safe := common.HexToAddress("0x5aFE3855358E112B5647B952709E6165e1c1eEEe")
+	require.NoError(t, repo.AddWallet(&wallet.Wallet{Name: "Alice", Address: local.Hex(), KeyStorePath: "alice.json",
+		ImportMethod: string(wallet.ImportMethodPrivateKey), SourceHash: "alice"}))
+
+	m := &CLIModel{Service: ws, styles: createStyles()}
+	m.currentConfig = &config.Config{Networks: map[string]config.Network{
+		"ethereum": {Name: "Ethereum", ChainID: 1, RPCEndpoint: "https://eth.example", IsActive: true},
+	}}
+	m.initSafeImport()
+	require.Equal(t, constants.SafeImportView, m.currentView)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, m.safeFailed, "an address is required")
+
+	m.safeAddressInput.SetValue(safe.Hex())
+	m.safeLabelInput.SetValue("treasury")
+	m.safeFetching = true
+	_, _ = m.Update(safeFetchedMsg{chainID: 1, info: blockchain.SafeInfo{Address: safe, Owners: []common.Address{local, remote}, Threshold: 2}})
+	require.NotNil(t, m.safeInfo)
+	view := m.viewSafeImport()
+	assert.Contains(t, view, "2 of 2 owners")
+	assert.Contains(t, view, "local key: Alice")
+	assert.Contains(t, view, "new watch-only wallet")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, m.safeFailed, m.safeStatus)
+	require.NotNil(t, m.safeImported)
+	assert.Len(t, m.safeImported.Created, 1)
+	assert.Len(t, m.safeImported.Tagged, 1)
+
+	wallets, err := repo.GetAllWallets()
+	require.NoError(t, err)
+	require.Len(t, wallets, 2)
+	ref := wallet.SafeReference(1, safe)
+	assert.Equal(t, wallet.WalletReferences{ref, "treasury"}, wallets[0].References, "the local key is tagged")
+	watched := wallets[1]
+	assert.True(t, watched.WatchOnly())
+	assert.Equal(t, remote.Hex(), watched.Address)
+	assert.Equal(t, "treasury owner 2", watched.Name)
+	assert.Equal(t, wallet.WalletReferences{ref, "treasury"}, watched.References)
+
+	// Importing again changes nothing
+	result, err := ws.ImportSafeOwners(1, safe, m.safeOwners, "treasury", watched.CreatedAt)
+	require.NoError(t, err)
+	assert.Empty(t, result.Created)
+	assert.Empty(t, result.Tagged)
+
+	// A watch-only wallet opens without a password and cannot sign
+	details, err := ws.LoadWallet(&watched, "")
+	require.NoError(t, err)
+	assert.Nil(t, details.Signer)
+	_, err = ws.FindWalletByAddress(remote.Hex())
+	assert.Error(t, err)
+	m.walletDetails = details
+	m.currentView = constants.WalletDetailsView
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	assert.Equal(t, constants.WalletDetailsView, m.currentView)
+}
@@ -0,0 +1,392 @@
+package ui
+
+import (
+	"blocowallet/internal/compliance"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	exportFocusSecret = iota
+	exportFocusRecipient
+	exportFocusPassword
+	exportFocusConfirm
+	exportFocusOutput
+)
+
+// initSecureExport opens the encrypted export screen for the wallet being displayed
+func (m *CLIModel) initSecureExport() {
+	m.exportSecret = wallet.ExportSecretKeystore
+	if m.walletDetails.HasMnemonic && m.walletDetails.Mnemonic != nil {
+		m.exportSecret = wallet.ExportSecretMnemonic
+	}
+
+	m.exportRecipientInput = textinput.New()
+	m.exportRecipientInput.Placeholder = localization.Labels["secure_export_recipient_placeholder"]
+	m.exportRecipientInput.CharLimit = 512
+	m.exportRecipientInput.Width = 70
+
+	m.exportPasswordInput = newExportPasswordInput(localization.Labels["secure_export_password_placeholder"])
+	m.exportConfirmInput = newExportPasswordInput(localization.Labels["secure_export_confirm_placeholder"])
+
+	m.exportPathInput = textinput.New()
+	m.exportPathInput.Placeholder = localization.Labels["secure_export_output_placeholder"]
+	m.exportPathInput.CharLimit = 512
+	m.exportPathInput.Width = 70
+
+	m.exportStatus = ""
+	m.exportFailed = false
+	m.setExportFocus(exportFocusRecipient)
+	m.currentView = constants.SecureExportView
+}
+
+func newExportPasswordInput(placeholder string) textinput.Model {
+	input := textinput.New()
+	input.Placeholder = placeholder
+	input.EchoMode = textinput.EchoPassword
+	input.EchoCharacter = '•'
+	input.CharLimit = 128
+	input.Width = 40
+	return input
+}
+
+func (m *CLIModel) setExportFocus(focus int) {
+	m.exportFocus = focus
+	m.exportRecipientInput.Blur()
+	m.exportPasswordInput.Blur()
+	m.exportConfirmInput.Blur()
+	m.exportPathInput.Blur()
+	switch focus {
+	case exportFocusRecipient:
+		m.exportRecipientInput.Focus()
+	case exportFocusPassword:
+		m.exportPasswordInput.Focus()
+	case exportFocusConfirm:
+		m.exportConfirmInput.Focus()
+	case exportFocusOutput:
+		m.exportPathInput.Focus()
+	}
+}
+
+// exportFields lists the fields of the form for the selected secret, in tab order
+func (m *CLIModel) exportFields() []int {
+	if m.exportSecret == wallet.ExportSecretKeystoreV3 {
+		return []int{exportFocusSecret, exportFocusPassword, exportFocusConfirm, exportFocusOutput}
+	}
+	return []int{exportFocusSecret, exportFocusRecipient, exportFocusOutput}
+}
+
+// stepExportFocus moves the focus to the next or previous field
+func (m *CLIModel) stepExportFocus(step int) {
+	fields := m.exportFields()
+	for i, field := range fields {
+		if field == m.exportFocus {
+			m.setExportFocus(fields[(i+step+len(fields))%len(fields)])
+			return
+		}
+	}
+	m.setExportFocus(exportFocusSecret)
+}
+
+// exportSecrets lists the secrets the displayed wallet can export: the
+// mnemonic when it has one, its keystore, and a KeyStore V3 file under a new
+// password when the application holds its key
+func (m *CLIModel) exportSecrets() []wallet.ExportSecret {
+	var secrets []wallet.ExportSecret
+	if m.walletDetails.HasMnemonic && m.walletDetails.Mnemonic != nil {
+		secrets = append(secrets, wallet.ExportSecretMnemonic)
+	}
+	secrets = append(secrets, wallet.ExportSecretKeystore)
+	if m.walletDetails.PrivateKey != nil {
+		secrets = append(secrets, wallet.ExportSecretKeystoreV3)
+	}
+	return secrets
+}
+
+// toggleExportSecret moves to the next or previous secret available
+func (m *CLIModel) toggleExportSecret(step int) {
+	secrets := m.exportSecrets()
+	current := 0
+	for i, secret := range secrets {
+		if secret == m.exportSecret {
+			current = i
+		}
+	}
+	m.exportSecret = secrets[(current+step+len(secrets))%len(secrets)]
+	m.exportPathInput.Placeholder = localization.Labels["secure_export_output_placeholder"]
+	if m.exportSecret == wallet.ExportSecretKeystoreV3 {
+		m.exportPathInput.Placeholder = localization.Labels["secure_export_keystore_v3_output_placeholder"]
+	}
+}
+
+// updateSecureExport handles input on the encrypted export screen
+func (m *CLIModel) updateSecureExport(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if text, ok := pastedText(msg); ok && m.exportFocus == exportFocusOutput {
+		m.exportPathInput.SetValue(cleanPastedPath(text))
+		m.exportPathInput.CursorEnd()
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "tab", "down":
+		m.stepExportFocus(1)
+		return m, nil
+	case "shift+tab", "up":
+		m.stepExportFocus(-1)
+		return m, nil
+	case "enter":
+		return m, m.runSecureExport()
+	}
+
+	var cmd tea.Cmd
+	switch m.exportFocus {
+	case exportFocusSecret:
+		switch keyMsg.String() {
+		case "left", "h":
+			m.toggleExportSecret(-1)
+		case "right", " ", "l":
+			m.toggleExportSecret(1)
+		}
+	case exportFocusRecipient:
+		m.exportRecipientInput, cmd = updateTextInput(m.exportRecipientInput, msg)
+	case exportFocusPassword:
+		m.exportPasswordInput, cmd = updateTextInput(m.exportPasswordInput, msg)
+	case exportFocusConfirm:
+		m.exportConfirmInput, cmd = updateTextInput(m.exportConfirmInput, msg)
+	case exportFocusOutput:
+		m.exportPathInput, cmd = updateTextInput(m.exportPathInput, msg)
+	}
+	return m, cmd
+}
+
+// runSecureExport encrypts the selected secret and reports the outcome on screen
+func (m *CLIModel) runSecureExport() tea.Cmd {
+	if m.exportSecret == wallet.ExportSecretKeystoreV3 {
+		return m.runKeystoreExport()
+	}
+	recipient, err := wallet.ParseExportRecipient(m.exportRecipientInput.Value())
+	if err != nil {
+		m.setExportResult(err.Error(), true)
+		m.setExportFocus(exportFocusRecipient)
+		return nil
+	}
+
+	outputPath := strings.TrimSpace(m.exportPathInput.Value())
+	if outputPath == "" {
+		outputPath = wallet.DefaultExportFileName(m.walletDetails.Wallet, m.exportSecret, recipient)
+	}
+	if abs, err := filepath.Abs(outputPath); err == nil {
+		outputPath = abs
+	}
+
+	details, secret := m.walletDetails, m.exportSecret
+	m.setExportResult("", false)
+	return m.withApproval(approvedAction{
+		action:  compliance.ActionExportSecret,
+		subject: fmt.Sprintf("%s %s", details.Wallet.Address, secret),
+		run: func() error {
+			return m.Service.ExportEncryptedSecret(details, secret, recipient, outputPath)
+		},
+		done: func(err error) tea.Cmd {
+			switch {
+			case errors.Is(err, wallet.ErrNoMnemonic):
+				m.setExportResult(localization.Labels["secure_export_no_mnemonic"], true)
+			case err != nil:
+				m.setExportResult(err.Error(), true)
+			default:
+				m.setExportResult(fmt.Sprintf(localization.Labels["secure_export_success"], recipient.Description, outputPath), false)
+				return m.notifyBackupCompleted("encrypted "+string(secret)+" export", outputPath)
+			}
+			return nil
+		},
+	})
+}
+
+// runKeystoreExport writes the key as a KeyStore V3 file under the new password
+func (m *CLIModel) runKeystoreExport() tea.Cmd {
+	password := m.exportPasswordInput.Value()
+	if validationErr, ok := wallet.ValidatePassword(password); !ok {
+		m.setExportResult(validationErr.GetErrorMessage(), true)
+		m.setExportFocus(exportFocusPassword)
+		return nil
+	}
+	if m.exportConfirmInput.Value() != password {
+		m.setExportResult(localization.Labels["secure_export_password_mismatch"], true)
+		m.exportConfirmInput.SetValue("")
+		m.setExportFocus(exportFocusConfirm)
+		return nil
+	}
+
+	outputPath := strings.TrimSpace(m.exportPathInput.Value())
+	if outputPath == "" {
+		outputPath = wallet.DefaultKeystoreFileName(m.walletDetails.Wallet, time.Now())
+	}
+	if abs, err := filepath.Abs(outputPath); err == nil {
+		outputPath = abs
+	}
+
+	details := m.walletDetails
+	m.setExportResult("", false)
+	return m.withApproval(approvedAction{
+		action:  compliance.ActionExportSecret,
+		subject: fmt.Sprintf("%s %s", details.Wallet.Address, wallet.ExportSecretKeystoreV3),
+		run: func() error {
+			return m.Service.ExportKeystore(details, password, outputPath)
+		},
+		done: func(err error) tea.Cmd {
+			if err != nil {
+				m.setExportResult(err.Error(), true)
+				return nil
+			}
+			m.exportPasswordInput.SetValue("")
+			m.exportConfirmInput.SetValue("")
+			m.setExportResult(fmt.Sprintf(localization.Labels["secure_export_keystore_v3_success"], outputPath), false)
+			return m.notifyBackupCompleted("keystore V3 export", outputPath)
+		},
+	})
+}
+
+func (m *CLIModel) setExportResult(status string, failed bool) {
+	m.exportStatus = status
+	m.exportFailed = failed
+}
+
+// viewSecureExport renders the encrypted export form
+func (m *CLIModel) viewSecureExport() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["secure_export_title"]))
+	b.WriteString("\n\n")
+	if m.exportSecret == wallet.ExportSecretKeystoreV3 {
+		b.WriteString(localization.Labels["secure_export_keystore_v3_desc"])
+	} else {
+		b.WriteString(localization.Labels["secure_export_desc"])
+	}
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf("%s %s\n\n", localization.Labels["ethereum_address"], wallet.ChecksumAddress(m.walletDetails.Wallet.Address)))
+
+	secretLabel := localization.Labels["secure_export_keystore"]
+	switch m.exportSecret {
+	case wallet.ExportSecretMnemonic:
+		secretLabel = localization.Labels["secure_export_mnemonic"]
+	case wallet.ExportSecretKeystoreV3:
+		secretLabel = localization.Labels["secure_export_keystore_v3"]
+	}
+	secretLine := fmt.Sprintf("%s < %s >", localization.Labels["secure_export_secret"], secretLabel)
+	if m.exportFocus == exportFocusSecret {
+		secretLine = m.styles.SelectedTitle.Render(secretLine)
+	}
+	b.WriteString(secretLine)
+	b.WriteString("\n\n")
+
+	if m.exportSecret == wallet.ExportSecretKeystoreV3 {
+		b.WriteString(localization.Labels["secure_export_password"])
+		b.WriteString("\n")
+		b.WriteString(m.exportPasswordInput.View())
+		b.WriteString("\n")
+		b.WriteString(m.exportConfirmInput.View())
+		b.WriteString("\n\n")
+	} else {
+		b.WriteString(localization.Labels["secure_export_recipient"])
+		b.WriteString("\n")
+		b.WriteString(m.exportRecipientInput.View())
+		b.WriteString("\n\n")
+	}
+	b.WriteString(localization.Labels["secure_export_output"])
+	b.WriteString("\n")
+	b.WriteString(m.exportPathInput.View())
+	b.WriteString("\n\n")
+
+	if notice := m.renderApprovalNotice(); notice != "" {
+		b.WriteString(notice)
+		b.WriteString("\n\n")
+	}
+	if m.exportStatus != "" {
+		if m.exportFailed {
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.exportStatus))
+		} else {
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.exportStatus))
+		}
+		b.WriteString("\n\n")
+	}
+	b.WriteString(m.styles.MenuDesc.Render(localization.Labels["secure_export_help"]))
+	return b.String()
+}
+
+// capturesTextInput reports whether the current view takes free text, where
+// single-letter shortcuts such as "q" must be typed instead of handled globally
+func (m *CLIModel) capturesTextInput() bool {
+	switch m.currentView {
+	case constants.CreateWalletNameView, constants.CreateWalletView, constants.ImportWalletView,
+		constants.ImportWalletPasswordView, constants.ImportKeystoreView, constants.WalletPasswordView:
+		return true
+	case constants.ImportPrivateKeyView:
+		return m.privateKeyPreview == nil
+	case constants.SecureExportView:
+		return m.exportFocus != exportFocusSecret
+	case constants.InheritanceView:
+		return m.inheritanceMode != inheritanceModeOverview
+	case constants.BackupView:
+		return m.backupFocus != backupFocusMode
+	case constants.MasterPasswordView:
+		return true
+	case constants.ListWalletsView:
+		return m.filteringWallets || m.exportingReport || (m.deletingWallet != nil && m.deletePhraseRequired)
+	case constants.WalletNotesView:
+		return true
+	case constants.BatchCreateView:
+		return m.batchFocus != batchFocusPolicy
+	case constants.ChildWalletsView:
+		return true
+	case constants.WalletRPCView:
+		return m.rpcEditing
+	case constants.SafeImportView:
+		return m.safeInfo == nil && m.safeFocus != safeFocusNetwork
+	case constants.SignTransactionView:
+		return m.signedTx == nil
+	case constants.SendTransactionView:
+		return m.sendRequest == nil && m.sendSent == nil
+	case constants.BroadcastView:
+		return m.broadcastTx == nil
+	case constants.PendingTransactionsView:
+		return m.pendingAction != ""
+	case constants.TokensView:
+		return m.tokenAdding
+	case constants.BatchSendView:
+		return m.batchSendCapturesText()
+	case constants.BatchExportView:
+		return m.batchExportCapturesText()
+	case constants.WalletBatchView:
+		return m.walletBatchCapturesText()
+	case constants.NetworkImportView:
+		return m.networkImportCapturesText()
+	case constants.MetamaskImportView:
+		return m.metamaskCapturesText()
+	case constants.AddNetworkView:
+		return true
+	case constants.StoreCheckView:
+		return m.storeReimporting
+	case constants.ProfilesView:
+		return m.profileCreating
+	case constants.ConfigEditorView:
+		return m.configEditorFocus < len(m.configFields) && m.configFields[m.configEditorFocus].kind <= configFieldNumber
+	case constants.WalletConnectView:
+		return m.wcCapturesText()
+	case constants.EnhancedImportView:
+		return m.enhancedImportState != nil && (m.enhancedImportState.IsPasting() || m.enhancedImportState.GetCurrentPhase() == PhasePasswordInput || m.enhancedImportState.RetryEditing() || m.enhancedImportState.ExportingReport())
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+
+	"filippo.io/age"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSecureExportModel(t *testing.T) *CLIModel {
+	t.Helper()
+	localization.SetCurrentLanguage("en")
+	localization.AddExportMessages()
+
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	m := &CLIModel{
+		Service:     &wallet.WalletService{},
+		styles:      createStyles(),
+		currentView: constants.WalletDetailsView,
+		walletDetails: &wallet.WalletDetails{
+			Wallet:      &wallet.Wallet{Address: "0xABC"},
+			Mnemonic:    &mnemonic,
+			HasMnemonic: true,
+		},
+	}
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	require.Equal(t, constants.SecureExportView, m.currentView)
+	return m
+}
+
+func TestSecureExport_WritesEncryptedFile(t *testing.T) {
+	m := newSecureExportModel(t)
+	assert.Equal(t, wallet.ExportSecretMnemonic, m.exportSecret)
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	out := filepath.Join(t.TempDir(), "backup.age")
+	m.exportRecipientInput.SetValue(identity.Recipient().String())
+	m.exportPathInput.SetValue(out)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.False(t, m.exportFailed, m.exportStatus)
+	assert.FileExists(t, out)
+	assert.Contains(t, m.viewSecureExport(), out)
+}
+
+func TestSecureExport_InvalidRecipientStaysOnForm(t *testing.T) {
+	m := newSecureExportModel(t)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.True(t, m.exportFailed)
+	assert.Equal(t, exportFocusRecipient, m.exportFocus)
+}
+
+func TestSecureExport_TypingQDoesNotQuit(t *testing.T) {
+	m := newSecureExportModel(t)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+
+	// The global quit shortcut would have returned before reaching the input
+	assert.Equal(t, "q", m.exportRecipientInput.Value())
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.WalletDetailsView, m.currentView)
+}
+
+func TestSecureExport_KeystoreV3(t *testing.T) {
+	m := newSecureExportModel(t)
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	m.walletDetails.PrivateKey = privateKey
+
+	// The secret cycles through mnemonic, keystore and KeyStore V3
+	m.setExportFocus(exportFocusSecret)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.Equal(t, wallet.ExportSecretKeystoreV3, m.exportSecret)
+	assert.Contains(t, m.viewSecureExport(), "Password of the file:")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	assert.Equal(t, exportFocusPassword, m.exportFocus)
+
+	out := filepath.Join(t.TempDir(), "backup.json")
+	m.exportPasswordInput.SetValue("Export-Pass1")
+	m.exportConfirmInput.SetValue("Export-Pass2")
+	m.exportPathInput.SetValue(out)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, m.exportFailed)
+	assert.Equal(t, exportFocusConfirm, m.exportFocus)
+	assert.NoFileExists(t, out)
+
+	m.exportConfirmInput.SetValue("Export-Pass1")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, m.exportFailed, m.exportStatus)
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	key, err := keystore.DecryptKey(data, "Export-Pass1")
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(privateKey.PublicKey), key.Address)
+	assert.Empty(t, m.exportPasswordInput.Value())
+}
@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/internal/integrity"
+	"blocowallet/internal/platform"
+	"blocowallet/pkg/localization"
+	"blocowallet/pkg/logger"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// permissionReportMsg carries the result of a permission enforcement pass
+type permissionReportMsg struct {
+	report platform.EnforceReport
+}
+
+// SetProtectedPaths sets the keystore directories and database files whose
+// permissions are enforced on startup and after imports
+func (m *CLIModel) SetProtectedPaths(paths ...string) {
+	m.protectedPaths = paths
+}
+
+// SetIntegrityIssues sets what the startup integrity check found modified
+// since the build; it is listed in the security warning after the splash screen
+func (m *CLIModel) SetIntegrityIssues(issues []integrity.Issue) {
+	m.integrityIssues = issues
+}
+
+// enforcePermissionsCmd tightens the permissions of the protected paths in the background
+func (m *CLIModel) enforcePermissionsCmd() tea.Cmd {
+	if len(m.protectedPaths) == 0 {
+		return nil
+	}
+	paths := append([]string(nil), m.protectedPaths...)
+	return func() tea.Msg {
+		return permissionReportMsg{report: platform.EnforcePrivate(paths...)}
+	}
+}
+
+// handlePermissionReport logs fixed files and opens the security warning when
+// some files are still readable by other users
+func (m *CLIModel) handlePermissionReport(report platform.EnforceReport) {
+	if uiLogger != nil {
+		for _, path := range report.Fixed {
+			uiLogger.Warn("Restricted permissions of exposed file", logger.String("path", path))
+		}
+		for _, issue := range report.Issues {
+			uiLogger.Error("File permissions could not be fixed", logger.String("path", issue.Path), logger.String("reason", issue.Reason))
+		}
+	}
+
+	m.permissionIssues = report.Issues
+	if len(report.Issues) == 0 || m.currentView == constants.SplashView {
+		// The warning is shown once the splash screen ends
+		return
+	}
+	m.openSecurityWarning()
+}
+
+// openSecurityWarning shows the warning view, remembering where to return
+func (m *CLIModel) openSecurityWarning() {
+	if m.currentView != constants.SecurityWarningView {
+		m.securityReturnView = m.currentView
+	}
+	m.currentView = constants.SecurityWarningView
+}
+
+// updateSecurityWarning dismisses the warning and returns to the previous view
+func (m *CLIModel) updateSecurityWarning(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "enter", " ":
+		m.currentView = m.securityReturnView
+		if m.currentView == "" || m.currentView == constants.SplashView {
+			m.currentView = constants.DefaultView
+		}
+	}
+	return m, nil
+}
+
+// viewSecurityWarning lists the files that are still accessible to other
+// users, the networks quarantined by strict RPC mode and what was modified
+// since the build
+func (m *CLIModel) viewSecurityWarning() string {
+	var b strings.Builder
+	b.WriteString(m.styles.ErrorStyle.Render(glyphs.Warning + " " + localization.Labels["security_warning_title"]))
+	b.WriteString("\n\n")
+
+	if len(m.integrityIssues) > 0 {
+		b.WriteString(m.styles.ErrorStyle.Render(localization.Labels["security_integrity_desc"]))
+		b.WriteString("\n\n")
+		for _, issue := range m.integrityIssues {
+			b.WriteString(fmt.Sprintf("  %s %s\n", glyphs.Bullet, issue.Subject))
+			b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf("    %s", issue.Reason)))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(localization.Labels["security_integrity_fix"])
+		b.WriteString("\n\n")
+	}
+
+	if len(m.permissionIssues) > 0 {
+		b.WriteString(localization.Labels["security_warning_desc"])
+		b.WriteString("\n\n")
+		for _, issue := range m.permissionIssues {
+			b.WriteString(fmt.Sprintf("  %s %s\n", glyphs.Bullet, issue.Path))
+			b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf("    %s", issue.Reason)))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		if platform.IsWindows() {
+			b.WriteString(localization.Labels["security_warning_fix_windows"])
+		} else {
+			b.WriteString(localization.Labels["security_warning_fix_unix"])
+		}
+		b.WriteString("\n\n")
+	}
+
+	if len(m.quarantinedNetworks) > 0 {
+		b.WriteString(localization.Labels["security_quarantine_desc"])
+		b.WriteString("\n\n")
+		for _, check := range m.quarantinedNetworks {
+			b.WriteString(fmt.Sprintf("  %s %s (%s)\n", glyphs.Bullet, check.Network.Name, check.Network.RPCEndpoint))
+			b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf("    %s", check.Network.Quarantine)))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(localization.Labels["security_quarantine_fix"])
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(m.styles.MenuDesc.Render(localization.Labels["security_warning_help"]))
+	return b.String()
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"testing"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/integrity"
+	"blocowallet/internal/platform"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionReport_ShowsWarningAfterSplash(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddSecurityMessages()
+
+	m := &CLIModel{styles: createStyles(), currentView: constants.SplashView}
+	report := platform.EnforceReport{Issues: []platform.PermissionIssue{{Path: "/wallets/key.json", Reason: "mode 0644 grants group/other access"}}}
+
+	_, _ = m.Update(permissionReportMsg{report: report})
+	assert.Equal(t, constants.SplashView, m.currentView)
+
+	_, _ = m.Update(splashMsg{})
+	assert.Equal(t, constants.SecurityWarningView, m.currentView)
+	assert.Contains(t, m.viewSecurityWarning(), "/wallets/key.json")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, constants.DefaultView, m.currentView)
+}
+
+func TestPermissionReport_ReturnsToPreviousView(t *testing.T) {
+	m := &CLIModel{styles: createStyles(), currentView: constants.WalletDetailsView}
+	report := platform.EnforceReport{Issues: []platform.PermissionIssue{{Path: "/wallets/key.json"}}}
+
+	_, _ = m.Update(permissionReportMsg{report: report})
+	assert.Equal(t, constants.SecurityWarningView, m.currentView)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, constants.WalletDetailsView, m.currentView)
+}
+
+func TestPermissionReport_NoIssuesKeepsView(t *testing.T) {
+	m := &CLIModel{styles: createStyles(), currentView: constants.DefaultView}
+	_, _ = m.Update(permissionReportMsg{report: platform.EnforceReport{Fixed: []string{"/wallets/key.json"}}})
+	assert.Equal(t, constants.DefaultView, m.currentView)
+}
+
+func TestQuarantinedNetworks_ShowWarningAfterSplash(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddSecurityMessages()
+
+	m := &CLIModel{styles: createStyles(), currentView: constants.SplashView}
+	m.SetQuarantinedNetworks([]blockchain.EndpointCheck{{
+		Key:     "ethereum",
+		Network: config.Network{Name: "Ethereum", RPCEndpoint: "https://rpc.example.com", Quarantine: "chain ID is 10, expected 1"},
+	}})
+
+	_, _ = m.Update(splashMsg{})
+	assert.Equal(t, constants.SecurityWarningView, m.currentView)
+	view := m.viewSecurityWarning()
+	assert.Contains(t, view, "Ethereum (https://rpc.example.com)")
+	assert.Contains(t, view, "chain ID is 10, expected 1")
+	assert.NotContains(t, view, "chmod", "no file permission advice without file issues")
+}
+
+func TestIntegrityIssues_ShowWarningAfterSplash(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddSecurityMessages()
+
+	m := &CLIModel{styles: createStyles(), currentView: constants.SplashView}
+	m.SetIntegrityIssues([]integrity.Issue{{Subject: "/usr/local/bin/bloco-wallet", Reason: "SHA-256 is abc, stamped def"}})
+
+	_, _ = m.Update(splashMsg{})
+	assert.Equal(t, constants.SecurityWarningView, m.currentView)
+	view := m.viewSecurityWarning()
+	assert.Contains(t, view, "modified after it was built")
+	assert.Contains(t, view, "/usr/local/bin/bloco-wallet")
+	assert.Contains(t, view, "SHA-256 is abc, stamped def")
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// forkResultMsg carries what the transfer under review did on a local fork
+type forkResultMsg struct {
+	seq    int
+	result blockchain.ForkResult
+	err    error
+}
+
+// forkRunner returns the runner of local anvil forks, looking anvil up once
+func (m *CLIModel) forkRunner() *blockchain.ForkRunner {
+	if m.forkRunnerSvc == nil {
+		cfg := config.ForkConfig{}
+		if m.currentConfig != nil {
+			cfg = m.currentConfig.Fork
+		}
+		m.forkRunnerSvc = blockchain.NewForkRunner(cfg)
+		m.forkAvailable = m.forkRunnerSvc.Available()
+	}
+	return m.forkRunnerSvc
+}
+
+// forkTestSend executes the transfer under review on a fork of the network
+// started with anvil, without signing it, to show what it changes
+func (m *CLIModel) forkTestSend() tea.Cmd {
+	runner := m.forkRunner()
+	if !m.forkAvailable {
+		m.setSendResult(localization.Labels["fork_unavailable"], true)
+		return nil
+	}
+	network, ok := m.selectedSendNetwork()
+	if !ok || m.sendRequest == nil {
+		return nil
+	}
+	m.sendFork = nil
+	m.sendBusy = true
+	m.setSendResult(fmt.Sprintf(localization.Labels["fork_starting"], network.Name), false)
+	seq, tx := m.sendSeq, m.sendRequest.Transaction()
+	from := common.HexToAddress(m.walletDetails.Wallet.Address)
+	return func() tea.Msg {
+		fork, err := runner.Start(context.Background(), network)
+		if err != nil {
+			return forkResultMsg{seq: seq, err: err}
+		}
+		defer fork.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), blockchain.DefaultTimeout)
+		defer cancel()
+		result, err := fork.Execute(ctx, tx, from, network.Symbol)
+		return forkResultMsg{seq: seq, result: result, err: err}
+	}
+}
+
+// handleForkResult shows the outcome on the fork next to the transfer under
+// review; results of a transfer since changed are dropped
+func (m *CLIModel) handleForkResult(msg forkResultMsg) {
+	if msg.seq != m.sendSeq || !m.sendBusy {
+		return
+	}
+	m.sendBusy = false
+	if msg.err != nil {
+		m.setSendResult(fmt.Sprintf(localization.Labels["fork_failed"], msg.err), true)
+		return
+	}
+	result := msg.result
+	m.sendFork = &result
+	m.setSendResult("", false)
+}
+
+// viewSendFork renders the state changes the transfer made on the fork
+func (m *CLIModel) viewSendFork(networkName, symbol string) string {
+	result := m.sendFork
+	if result == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(localization.Labels["fork_title"], networkName))
+	b.WriteString("\n")
+	from := common.HexToAddress(m.walletDetails.Wallet.Address)
+	for _, change := range result.Changes {
+		b.WriteString("  " + m.txPreviewLine(change, from))
+		b.WriteString("\n")
+	}
+	if result.Reverted {
+		reason := result.RevertReason
+		if reason == "" {
+			reason = localization.Labels["fork_no_reason"]
+		}
+		b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + fmt.Sprintf(localization.Labels["fork_reverted"], reason)))
+	} else {
+		b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + fmt.Sprintf(localization.Labels["fork_succeeded"], result.GasUsed, wallet.FormatUnits(result.Fee, 18), symbol)))
+	}
+	b.WriteString("\n")
+	b.WriteString(m.styles.MenuDesc.Render("  " + localization.Labels["fork_note"]))
+	b.WriteString("\n\n")
+	return b.String()
+}
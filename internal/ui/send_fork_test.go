@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newForkTestModel(t *testing.T) *CLIModel {
+	localization.SetCurrentLanguage("en")
+	localization.AddSignTxMessages()
+	localization.AddBroadcastMessages()
+	localization.AddSendMessages()
+	localization.AddTxPreviewMessages()
+	localization.AddForkMessages()
+
+	m := &CLIModel{styles: createStyles(), currentView: constants.SendTransactionView}
+	m.currentConfig = &config.Config{
+		Networks: map[string]config.Network{
+			"sepolia": {Name: "Sepolia", ChainID: 11155111, Symbol: "SEP", RPCEndpoint: "http://127.0.0.1:1", IsActive: true},
+		},
+		Fork: config.ForkConfig{AnvilPath: filepath.Join(t.TempDir(), "anvil"), StartupTimeout: 1},
+	}
+	m.walletDetails = &wallet.WalletDetails{Wallet: &wallet.Wallet{Name: "Hot", Address: "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"}}
+	m.sendRequest = &wallet.TransferRequest{
+		ChainID:              big.NewInt(11155111),
+		To:                   common.HexToAddress("0x000000000000000000000000000000000000dEaD"),
+		Value:                big.NewInt(5e17),
+		GasLimit:             wallet.TxGas,
+		MaxFeePerGas:         big.NewInt(3e9),
+		MaxPriorityFeePerGas: big.NewInt(1e9),
+	}
+	return m
+}
+
+func TestSendFork_WithoutAnvil(t *testing.T) {
+	m := newForkTestModel(t)
+	assert.NotContains(t, m.viewSendTransaction(), "f: try on a")
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	assert.Nil(t, cmd)
+	assert.True(t, m.sendFailed)
+	assert.Contains(t, m.sendStatus, "anvil was not found")
+	require.NotNil(t, m.sendRequest, "still reviewing the transfer")
+}
+
+func TestSendFork_ShowsStateChanges(t *testing.T) {
+	m := newForkTestModel(t)
+	m.forkRunner()
+	m.forkAvailable = true
+	assert.Contains(t, m.viewSendTransaction(), "f: try on a")
+
+	from := common.HexToAddress(m.walletDetails.Wallet.Address)
+	m.sendBusy = true
+	// A result for a transfer since changed is dropped
+	m.handleForkResult(forkResultMsg{seq: m.sendSeq - 1, result: blockchain.ForkResult{GasUsed: 1}})
+	assert.Nil(t, m.sendFork)
+
+	m.handleForkResult(forkResultMsg{seq: m.sendSeq, result: blockchain.ForkResult{
+		GasUsed: 21000,
+		Fee:     big.NewInt(21000 * 2e9),
+		Changes: []blockchain.BalanceChange{
+			{Account: from, Symbol: "SEP", Decimals: 18, Before: big.NewInt(1e18), After: big.NewInt(1e18 - 5e17 - 21000*2e9)},
+			{Account: m.sendRequest.To, Symbol: "SEP", Decimals: 18, Before: big.NewInt(0), After: big.NewInt(5e17)},
+		},
+	}})
+	assert.False(t, m.sendBusy)
+	view := m.viewSendTransaction()
+	assert.Contains(t, view, "Executed on a local fork of Sepolia:")
+	assert.Contains(t, view, "You  -0.500042 SEP")
+	assert.Contains(t, view, "+0.5 SEP")
+	assert.Contains(t, view, "Succeeded using 21000 gas (fee 0.000042 SEP)")
+
+	m.sendFork = &blockchain.ForkResult{Reverted: true, Fee: new(big.Int)}
+	assert.Contains(t, m.viewSendTransaction(), "would fail on the network too: no reason given")
+
+	// Changing the transfer forgets the result
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.Nil(t, m.sendFork)
+}
@@ -0,0 +1,435 @@
+package ui
+
+import (
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	sendFocusNetwork = iota
+	sendFocusAsset
+	sendFocusTo
+	sendFocusAmount
+	sendFocusCount
+)
+
+// sendPreparedMsg carries the transfer built from the network: nonce, gas
+// limit and fees
+type sendPreparedMsg struct {
+	seq int
+	req wallet.TransferRequest
+	err error
+}
+
+// sendResultMsg carries the outcome of sending the prepared transfer
+type sendResultMsg struct {
+	raw     *wallet.RawTransaction
+	status  blockchain.TxStatus
+	tracked bool
+	err     error
+}
+
+// initSendTransaction opens the send form for the wallet being displayed
+func (m *CLIModel) initSendTransaction() {
+	m.sendNetwork = 0
+	m.sendAsset = 0
+	m.resetSend()
+	if m.currentConfig == nil {
+		if err := m.ensureConfigAndNetworksLoaded(); err != nil {
+			m.setSendResult(err.Error(), true)
+		}
+	}
+	newInput := func(placeholder string, width int) textinput.Model {
+		ti := textinput.New()
+		ti.Placeholder = placeholder
+		ti.CharLimit = 64
+		ti.Width = width
+		return ti
+	}
+	m.sendInputs = []textinput.Model{
+		// The network and asset are picked with ←/→; their inputs only keep the indexes aligned
+		sendFocusNetwork: textinput.New(),
+		sendFocusAsset:   textinput.New(),
+		sendFocusTo:      newInput("0x…", 44),
+		sendFocusAmount:  newInput("0.0", 24),
+	}
+	m.setSendFocus(sendFocusTo)
+	m.loadSendTokens()
+	m.currentView = constants.SendTransactionView
+}
+
+// loadSendTokens reads the tokens that can be sent on the selected network
+func (m *CLIModel) loadSendTokens() {
+	m.sendTokens = nil
+	if network, ok := m.selectedSendNetwork(); ok {
+		m.sendTokens = m.networkTokens(network)
+	}
+	if m.sendAsset > len(m.sendTokens) {
+		m.sendAsset = 0
+	}
+}
+
+// selectedSendToken returns the token being sent, or nil for the native coin
+func (m *CLIModel) selectedSendToken() *blockchain.Token {
+	if m.sendAsset < 1 || m.sendAsset > len(m.sendTokens) {
+		return nil
+	}
+	return &m.sendTokens[m.sendAsset-1]
+}
+
+// resetSend forgets the prepared transfer and any result, keeping the form
+func (m *CLIModel) resetSend() {
+	// A preparation still running no longer applies
+	m.sendSeq++
+	m.sendRequest = nil
+	m.sendFork = nil
+	m.sendBusy = false
+	m.sendSent = nil
+	m.sendTracked = false
+	m.setSendResult("", false)
+}
+
+// selectedSendNetwork returns the network the transfer goes through, with the
+// wallet's RPC override when it has one
+func (m *CLIModel) selectedSendNetwork() (config.Network, bool) {
+	networks := m.usableNetworks()
+	if m.sendNetwork < 0 || m.sendNetwork >= len(networks) {
+		return config.Network{}, false
+	}
+	return m.Service.NetworkFor(m.walletDetails.Wallet.Address, networks[m.sendNetwork]), true
+}
+
+func (m *CLIModel) setSendFocus(focus int) {
+	m.sendFocus = focus
+	for i := range m.sendInputs {
+		if i == focus {
+			m.sendInputs[i].Focus()
+		} else {
+			m.sendInputs[i].Blur()
+		}
+	}
+}
+
+// updateSendTransaction handles input on the send screen: fill the form,
+// review the transfer prepared from the network, then send it
+func (m *CLIModel) updateSendTransaction(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.sendBusy {
+		return m, nil
+	}
+
+	if m.sendSent != nil {
+		switch keyMsg.String() {
+		case "enter":
+			m.sendInputs[sendFocusTo].SetValue("")
+			m.sendInputs[sendFocusAmount].SetValue("")
+			m.resetSend()
+			m.setSendFocus(sendFocusTo)
+		}
+		return m, nil
+	}
+
+	if m.sendRequest != nil {
+		switch keyMsg.String() {
+		case "enter":
+			return m, m.sendTransfer()
+		case "f":
+			return m, m.forkTestSend()
+		case "backspace":
+			// Back to the form to change the transfer
+			m.resetSend()
+		}
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "tab", "down":
+		m.setSendFocus((m.sendFocus + 1) % sendFocusCount)
+		return m, nil
+	case "shift+tab", "up":
+		m.setSendFocus((m.sendFocus + sendFocusCount - 1) % sendFocusCount)
+		return m, nil
+	case "left", "right":
+		step := 1
+		if keyMsg.String() == "left" {
+			step = -1
+		}
+		switch m.sendFocus {
+		case sendFocusNetwork:
+			if networks := m.usableNetworks(); len(networks) > 0 {
+				m.sendNetwork = (m.sendNetwork + step + len(networks)) % len(networks)
+				// Tokens belong to one network
+				m.sendAsset = 0
+				m.loadSendTokens()
+			}
+			return m, nil
+		case sendFocusAsset:
+			assets := len(m.sendTokens) + 1
+			m.sendAsset = (m.sendAsset + step + assets) % assets
+			return m, nil
+		}
+	case "enter":
+		return m, m.prepareSend()
+	}
+	if m.sendFocus == sendFocusNetwork || m.sendFocus == sendFocusAsset {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.sendInputs[m.sendFocus], cmd = updateTextInput(m.sendInputs[m.sendFocus], msg)
+	return m, cmd
+}
+
+// sendForm reads the recipient and amount, in units of the asset being sent,
+// returning the field at fault on error
+func (m *CLIModel) sendForm() (common.Address, *big.Int, int, error) {
+	to, err := m.readAddress(m.sendInputs[sendFocusTo].Value(), localization.Labels["sign_tx_invalid_to"])
+	if err != nil {
+		return common.Address{}, nil, sendFocusTo, err
+	}
+	decimals := 18
+	if token := m.selectedSendToken(); token != nil {
+		decimals = token.Decimals
+	}
+	amount, err := wallet.ParseUnits(strings.TrimSpace(m.sendInputs[sendFocusAmount].Value()), decimals)
+	if err != nil {
+		return common.Address{}, nil, sendFocusAmount, err
+	}
+	return to, amount, -1, nil
+}
+
+// prepareSend reads the nonce, fees and gas of the transfer from the network
+// and checks the balance covers it, for review before sending
+func (m *CLIModel) prepareSend() tea.Cmd {
+	network, ok := m.selectedSendNetwork()
+	if !ok {
+		m.setSendResult(localization.Labels["send_no_networks"], true)
+		return nil
+	}
+	to, amount, field, err := m.sendForm()
+	if err != nil {
+		m.setSendResult(err.Error(), true)
+		m.setSendFocus(field)
+		return nil
+	}
+	// A token transfer is a call to the token contract, with no value
+	var tokens *blockchain.TokenService
+	var token *blockchain.Token
+	if t := m.selectedSendToken(); t != nil {
+		if tokens, err = m.tokenService(); err != nil {
+			m.setSendResult(err.Error(), true)
+			return nil
+		}
+		selected := *t
+		token = &selected
+	}
+
+	m.sendSeq++
+	m.sendBusy = true
+	m.setSendResult(fmt.Sprintf(localization.Labels["send_preparing"], network.Name), false)
+	seq, from := m.sendSeq, common.HexToAddress(m.walletDetails.Wallet.Address)
+	return func() tea.Msg {
+		client, err := blockchain.NewEthereum(network.RPCEndpoint, blockchain.DefaultTimeout, network.Symbol, 18, network.Name)
+		if err != nil {
+			return sendPreparedMsg{seq: seq, err: err}
+		}
+		defer client.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), blockchain.DefaultTimeout)
+		defer cancel()
+		if token == nil {
+			req, err := wallet.NewTransactionService(client.Client()).BuildTransfer(ctx, from, to, amount, nil)
+			return sendPreparedMsg{seq: seq, req: req, err: err}
+		}
+		if err := tokens.CheckTransfer(ctx, network, *token, from, amount); err != nil {
+			return sendPreparedMsg{seq: seq, err: err}
+		}
+		contract := common.HexToAddress(token.Address)
+		req, err := wallet.NewTransactionService(client.Client()).BuildTransfer(ctx, from, contract, new(big.Int), blockchain.TransferData(to, amount))
+		return sendPreparedMsg{seq: seq, req: req, err: err}
+	}
+}
+
+// handleSendPrepared shows the prepared transfer for review
+func (m *CLIModel) handleSendPrepared(msg sendPreparedMsg) {
+	if msg.seq != m.sendSeq || !m.sendBusy {
+		return
+	}
+	m.sendBusy = false
+	if msg.err != nil {
+		m.setSendResult(fmt.Sprintf(localization.Labels["send_prepare_failed"], msg.err), true)
+		return
+	}
+	req := msg.req
+	m.sendRequest = &req
+	m.setSendResult("", false)
+}
+
+// sendTransfer signs the reviewed transfer with the wallet's key, sends it
+// and follows it until final
+func (m *CLIModel) sendTransfer() tea.Cmd {
+	network, ok := m.selectedSendNetwork()
+	if !ok {
+		m.setSendResult(localization.Labels["send_no_networks"], true)
+		return nil
+	}
+	m.sendBusy = true
+	m.setSendResult(fmt.Sprintf(localization.Labels["broadcast_sending"], network.Name), false)
+	req, signer, ws := *m.sendRequest, m.walletDetails.Signer, m.Service
+	return func() tea.Msg {
+		client, err := blockchain.NewEthereum(network.RPCEndpoint, blockchain.DefaultTimeout, network.Symbol, 18, network.Name)
+		if err != nil {
+			return sendResultMsg{err: err}
+		}
+		defer client.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), blockchain.DefaultTimeout)
+		defer cancel()
+		raw, err := wallet.NewTransactionService(client.Client()).SendTransfer(ctx, signer, req)
+		if err != nil {
+			return sendResultMsg{err: err}
+		}
+		// Follow it until final; a failure here does not undo the transfer
+		tracked := false
+		if ws != nil {
+			_, err := ws.TrackTransaction(raw, network.Name, "", "")
+			tracked = err == nil
+		}
+		status, err := client.TransactionStatus(ctx, raw.Tx.Hash())
+		if err != nil {
+			// The transfer is out; only the first status check failed
+			status = blockchain.TxStatus{Hash: raw.Tx.Hash(), State: blockchain.TxPending}
+		}
+		return sendResultMsg{raw: raw, status: status, tracked: tracked}
+	}
+}
+
+// handleSendResult shows the hash and state of the sent transfer
+func (m *CLIModel) handleSendResult(msg sendResultMsg) tea.Cmd {
+	if !m.sendBusy {
+		return nil
+	}
+	m.sendBusy = false
+	if msg.err != nil {
+		m.setSendResult(fmt.Sprintf(localization.Labels["send_failed"], msg.err), true)
+		return nil
+	}
+	status := msg.status
+	m.sendSent = msg.raw
+	m.setSendResult(broadcastStateLabel(status), status.State == blockchain.TxReverted || status.State == blockchain.TxNotFound)
+	if !msg.tracked {
+		return nil
+	}
+	m.sendTracked = true
+	return m.refreshPendingNow()
+}
+
+func (m *CLIModel) setSendResult(status string, failed bool) {
+	m.sendStatus = status
+	m.sendFailed = failed
+}
+
+// viewSendTransaction renders the form, the transfer under review or the sent one
+func (m *CLIModel) viewSendTransaction() string {
+	if m.walletDetails == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["send_title"]))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["sign_tx_from"], m.walletDetails.Wallet.Name, wallet.ChecksumAddress(m.walletDetails.Wallet.Address)))
+	b.WriteString("\n")
+
+	network, hasNetwork := m.selectedSendNetwork()
+	symbol := "ETH"
+	if hasNetwork && network.Symbol != "" {
+		symbol = network.Symbol
+	}
+
+	if m.sendRequest == nil {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["send_desc"]))
+		b.WriteString("\n\n")
+		networkName := localization.Labels["send_no_networks"]
+		if hasNetwork {
+			networkName = "< " + network.Name + " >"
+		}
+		line := fmt.Sprintf("%-24s %s", localization.Labels["send_network"], networkName)
+		if m.sendFocus == sendFocusNetwork {
+			line = m.styles.SelectedTitle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+		asset := symbol
+		if token := m.selectedSendToken(); token != nil {
+			asset = token.Symbol
+		}
+		line = fmt.Sprintf("%-24s < %s >", localization.Labels["send_asset"], asset)
+		if m.sendFocus == sendFocusAsset {
+			line = m.styles.SelectedTitle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("%-24s %s\n", localization.Labels["sign_tx_to"], m.sendInputs[sendFocusTo].View()))
+		b.WriteString(fmt.Sprintf("%-24s %s %s\n\n", localization.Labels["sign_tx_amount"], m.sendInputs[sendFocusAmount].View(), asset))
+	} else {
+		req := m.sendRequest
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("%s %s\n", localization.Labels["send_network"], network.Name))
+		if token := m.selectedSendToken(); token != nil {
+			if call, ok := blockchain.DecodeTokenCall(req.Data); ok && call.Method == blockchain.TokenTransfer {
+				b.WriteString(fmt.Sprintf(localization.Labels["send_token_transfer"], wallet.FormatUnits(call.Amount, token.Decimals), token.Symbol, call.To.Hex()))
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString(fmt.Sprintf(localization.Labels["sign_tx_summary"],
+			req.To.Hex(), wallet.FormatUnits(req.Value, 18), symbol, req.Nonce, req.ChainID,
+			req.GasLimit, wallet.FormatUnits(req.MaxFeePerGas, 9), wallet.FormatUnits(req.MaxPriorityFeePerGas, 9),
+			wallet.FormatUnits(req.MaxCost(), 18), symbol))
+		b.WriteString("\n")
+		if m.sendSent != nil {
+			b.WriteString(fmt.Sprintf(localization.Labels["sign_tx_hash"], m.sendSent.Tx.Hash().Hex()))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(m.viewSendFork(network.Name, symbol))
+	}
+
+	if m.sendStatus != "" {
+		switch {
+		case m.sendBusy:
+			b.WriteString(m.styles.MenuDesc.Render(m.sendStatus))
+		case m.sendFailed:
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.sendStatus))
+		default:
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.sendStatus))
+		}
+		b.WriteString("\n\n")
+	}
+	if m.sendTracked {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["broadcast_tracked"]))
+		b.WriteString("\n\n")
+	}
+
+	help := localization.Labels["send_help"]
+	switch {
+	case m.sendSent != nil:
+		help = localization.Labels["send_help_sent"]
+	case m.sendRequest != nil:
+		help = localization.Labels["send_help_review"]
+		// Offer the fork test only when anvil is installed
+		if m.forkRunner(); m.forkAvailable {
+			help = localization.Labels["send_help_review_fork"]
+		}
+	}
+	b.WriteString(m.styles.MenuDesc.Render(help))
+	return b.String()
+}
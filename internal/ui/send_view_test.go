@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendTransaction_ReviewAndSend(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddSignTxMessages()
+	localization.AddBroadcastMessages()
+	localization.AddSendMessages()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	m := &CLIModel{styles: createStyles(), currentView: constants.WalletDetailsView}
+	m.currentConfig = &config.Config{Networks: map[string]config.Network{
+		"sepolia": {Name: "Sepolia", ChainID: 11155111, Symbol: "SEP", RPCEndpoint: "http://127.0.0.1:1", IsActive: true},
+		"holesky": {Name: "Holesky", ChainID: 17000, Symbol: "ETH", RPCEndpoint: "http://127.0.0.1:1", IsActive: true},
+	}}
+	m.walletDetails = &wallet.WalletDetails{
+		Wallet: &wallet.Wallet{Name: "Hot", Address: crypto.PubkeyToAddress(key.PublicKey).Hex()},
+		Signer: wallet.NewKeySigner(key),
+	}
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	require.Equal(t, constants.SendTransactionView, m.currentView)
+	assert.True(t, m.capturesTextInput())
+	assert.Contains(t, m.viewSendTransaction(), "Holesky")
+	m.setSendFocus(sendFocusNetwork)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	assert.Contains(t, m.viewSendTransaction(), "< Sepolia >")
+
+	m.sendInputs[sendFocusTo].SetValue("not an address")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, m.sendFailed)
+	assert.Equal(t, sendFocusTo, m.sendFocus)
+
+	m.sendInputs[sendFocusTo].SetValue("0x000000000000000000000000000000000000dEaD")
+	m.sendInputs[sendFocusAmount].SetValue("0.5")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd, "the transfer is prepared from the network")
+	assert.True(t, m.sendBusy)
+
+	// A preparation that was abandoned is ignored
+	m.handleSendPrepared(sendPreparedMsg{seq: m.sendSeq - 1, err: errors.New("stale")})
+	assert.False(t, m.sendFailed)
+
+	req := wallet.TransferRequest{
+		ChainID:              big.NewInt(11155111),
+		To:                   common.HexToAddress("0x000000000000000000000000000000000000dEaD"),
+		Value:                big.NewInt(5e17),
+		Nonce:                3,
+		GasLimit:             wallet.TxGas,
+		MaxFeePerGas:         big.NewInt(3e9),
+		MaxPriorityFeePerGas: big.NewInt(1e9),
+	}
+	m.handleSendPrepared(sendPreparedMsg{seq: m.sendSeq, req: req})
+	require.NotNil(t, m.sendRequest)
+	assert.False(t, m.capturesTextInput())
+	view := m.viewSendTransaction()
+	assert.Contains(t, view, "Amount 0.5 SEP • nonce 3 • chain 11155111")
+	assert.Contains(t, view, "sign and send")
+
+	signed, err := wallet.SignTransfer(m.walletDetails.Signer, req)
+	require.NoError(t, err)
+	m.sendBusy = true
+	m.handleSendResult(sendResultMsg{
+		raw:    &wallet.RawTransaction{Tx: signed, From: m.walletDetails.Signer.Address()},
+		status: blockchain.TxStatus{Hash: signed.Hash(), State: blockchain.TxPending},
+	})
+	assert.False(t, m.sendFailed)
+	assert.Contains(t, m.viewSendTransaction(), signed.Hash().Hex())
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, m.sendRequest, "enter starts another transfer")
+	assert.Empty(t, m.sendInputs[sendFocusAmount].Value())
+}
+
+func TestSendTransaction_PrepareFailed(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddSendMessages()
+
+	m := &CLIModel{styles: createStyles()}
+	m.currentConfig = &config.Config{}
+	m.walletDetails = &wallet.WalletDetails{Wallet: &wallet.Wallet{Name: "Hot", Address: "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"}}
+	m.initSendTransaction()
+	m.sendInputs[sendFocusTo].SetValue("0x000000000000000000000000000000000000dEaD")
+	m.sendInputs[sendFocusAmount].SetValue("1")
+	assert.Nil(t, m.prepareSend())
+	assert.Contains(t, m.sendStatus, "No active network")
+
+	m.sendBusy = true
+	m.handleSendPrepared(sendPreparedMsg{seq: m.sendSeq, err: wallet.ErrInsufficientFunds})
+	assert.True(t, m.sendFailed)
+	assert.Nil(t, m.sendRequest)
+	assert.Contains(t, m.viewSendTransaction(), "insufficient funds")
+}
+
+func TestSendTransaction_WatchOnly(t *testing.T) {
+	m := &CLIModel{styles: createStyles(), currentView: constants.WalletDetailsView}
+	m.walletDetails = &wallet.WalletDetails{
+		Wallet: &wallet.Wallet{Address: "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", ImportMethod: string(wallet.ImportMethodWatchOnly)},
+	}
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	assert.Equal(t, constants.WalletDetailsView, m.currentView)
+}
+
+func TestSendTransaction_ChecksumMismatch(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddSignTxMessages()
+	localization.AddSendMessages()
+	localization.AddAddressMessages()
+
+	m := &CLIModel{styles: createStyles()}
+	m.currentConfig = &config.Config{}
+	m.walletDetails = &wallet.WalletDetails{Wallet: &wallet.Wallet{Name: "Hot", Address: "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"}}
+	m.initSendTransaction()
+
+	// The last letter of the EIP-55 vector has the wrong case
+	m.sendInputs[sendFocusTo].SetValue("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD")
+	m.sendInputs[sendFocusAmount].SetValue("1")
+	_, _, field, err := m.sendForm()
+	require.Error(t, err)
+	assert.Equal(t, sendFocusTo, field)
+	assert.Contains(t, err.Error(), "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+
+	// Entering the same address again uses it
+	to, _, _, err := m.sendForm()
+	require.NoError(t, err)
+	assert.Equal(t, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", to.Hex())
+
+	// Addresses without a checksum are taken as they are
+	m.sendInputs[sendFocusTo].SetValue("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	_, _, _, err = m.sendForm()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/internal/jobs"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"blocowallet/pkg/logger"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sessionStats counts what happened since the program started; the summary
+// is shown before quitting and always written to the log
+type sessionStats struct {
+	startedAt time.Time
+	created   int
+	imported  int
+	errors    int
+	lastError string // Error on screen, so it is counted once while shown
+}
+
+// noteError counts err unless it is the error already counted
+func (s *sessionStats) noteError(err error) {
+	if err == nil {
+		s.lastError = ""
+		return
+	}
+	if msg := err.Error(); msg != s.lastError {
+		s.errors++
+		s.lastError = msg
+	}
+}
+
+// noteImportResults counts the wallets a batch import added and the files
+// that failed; skipped files are not errors
+func (s *sessionStats) noteImportResults(results []wallet.ImportResult) {
+	for _, result := range results {
+		switch {
+		case result.Success:
+			s.imported++
+		case !result.Skipped:
+			s.errors++
+		}
+	}
+}
+
+// runningJobs returns the background jobs that quitting would cancel
+func (m *CLIModel) runningJobs() []jobs.Job {
+	var running []jobs.Job
+	for _, job := range m.jobManager.List() {
+		if !job.Status.IsFinal() {
+			running = append(running, job)
+		}
+	}
+	return running
+}
+
+// showsSessionSummary reports whether the summary is shown before quitting
+func (m *CLIModel) showsSessionSummary() bool {
+	if m.currentConfig == nil {
+		if cfg, err := loadOrCreateConfig(); err == nil {
+			m.currentConfig = cfg
+		}
+	}
+	return m.currentConfig == nil || m.currentConfig.Operations.SessionSummary
+}
+
+// quit leaves the program, first showing the session summary when enabled or
+// when background jobs are still running; asked again from the summary, it quits
+func (m *CLIModel) quit() (tea.Model, tea.Cmd) {
+	if m.currentView != constants.SessionSummaryView && (len(m.runningJobs()) > 0 || m.showsSessionSummary()) {
+		m.currentView = constants.SessionSummaryView
+		return m, nil
+	}
+
+	for _, job := range m.runningJobs() {
+		_ = m.jobManager.Cancel(job.ID)
+	}
+	m.clearCopiedText()
+	m.logSessionSummary()
+	return m, tea.Quit
+}
+
+// cancelledJobs counts the jobs cancelled during the session, including those
+// quitting is about to cancel
+func (m *CLIModel) cancelledJobs() int {
+	cancelled := 0
+	for _, job := range m.jobManager.List() {
+		if job.Status == jobs.StatusCancelled || !job.Status.IsFinal() {
+			cancelled++
+		}
+	}
+	return cancelled
+}
+
+func (m *CLIModel) logSessionSummary() {
+	if uiLogger == nil {
+		return
+	}
+	uiLogger.Info("Session summary",
+		logger.Int("wallets_created", m.session.created),
+		logger.Int("wallets_imported", m.session.imported),
+		logger.Int("errors", m.session.errors),
+		logger.Int("jobs_cancelled", m.cancelledJobs()),
+		logger.String("elapsed", m.sessionElapsed().String()),
+	)
+}
+
+func (m *CLIModel) sessionElapsed() time.Duration {
+	if m.session.startedAt.IsZero() {
+		return 0
+	}
+	return time.Since(m.session.startedAt).Round(time.Second)
+}
+
+// updateSessionSummary quits on enter; q reaches quit through the global keys
+// and esc returns to the menu
+func (m *CLIModel) updateSessionSummary(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "enter" {
+		return m.quit()
+	}
+	return m, nil
+}
+
+// viewSessionSummary lists the session counters and warns about the jobs
+// that quitting cancels
+func (m *CLIModel) viewSessionSummary() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["session_title"]))
+	b.WriteString("\n\n")
+	for _, line := range []string{
+		fmt.Sprintf(localization.Labels["session_created"], m.session.created),
+		fmt.Sprintf(localization.Labels["session_imported"], m.session.imported),
+		fmt.Sprintf(localization.Labels["session_errors"], m.session.errors),
+		fmt.Sprintf(localization.Labels["session_cancelled"], m.cancelledJobs()),
+		fmt.Sprintf(localization.Labels["session_elapsed"], m.sessionElapsed()),
+	} {
+		b.WriteString("  " + line + "\n")
+	}
+
+	if running := m.runningJobs(); len(running) > 0 {
+		b.WriteString("\n")
+		b.WriteString(m.styles.ErrorStyle.Render(glyphs.Warning + " " + localization.Labels["session_running"]))
+		b.WriteString("\n")
+		for _, job := range running {
+			b.WriteString(fmt.Sprintf("  • %s %s\n", jobDisplayName(job), renderJobProgressBar(job.Progress, 14)))
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.MenuDesc.Render(localization.Labels["session_help"]))
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/jobs"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionStats(t *testing.T) {
+	var s sessionStats
+	s.noteError(errors.New("boom"))
+	s.noteError(errors.New("boom"))
+	assert.Equal(t, 1, s.errors, "an error on screen is counted once")
+
+	s.noteError(nil)
+	s.noteError(errors.New("boom"))
+	assert.Equal(t, 2, s.errors)
+
+	s.noteImportResults([]wallet.ImportResult{{Success: true}, {Skipped: true}, {Error: errors.New("bad password")}})
+	assert.Equal(t, 1, s.imported)
+	assert.Equal(t, 3, s.errors)
+}
+
+func TestQuit_ShowsSummaryAndWarnsAboutRunningJobs(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddJobMessages()
+	localization.AddSessionMessages()
+
+	m := &CLIModel{
+		styles:        createStyles(),
+		jobManager:    jobs.NewManager(),
+		currentConfig: &config.Config{Operations: config.OperationsConfig{SessionSummary: false}},
+		currentView:   constants.DefaultView,
+		session:       sessionStats{created: 2, imported: 3},
+	}
+
+	// Without the summary and with no jobs running, q quits right away
+	_, cmd := m.quit()
+	require.NotNil(t, cmd)
+	assert.IsType(t, tea.QuitMsg{}, cmd())
+
+	id, err := m.jobManager.Submit(jobs.KindBatchImport, "import", func(ctx context.Context, report jobs.ProgressFunc) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.NoError(t, err)
+
+	// A running job always asks first
+	_, cmd = m.quit()
+	assert.Nil(t, cmd)
+	assert.Equal(t, constants.SessionSummaryView, m.currentView)
+	view := m.viewSessionSummary()
+	assert.Contains(t, view, "Wallets created: 2")
+	assert.Contains(t, view, "Wallets imported: 3")
+	assert.Contains(t, view, localization.Labels["session_running"])
+	assert.Contains(t, view, "import")
+
+	// Confirming cancels the job before quitting
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.IsType(t, tea.QuitMsg{}, cmd())
+	job, err := m.jobManager.Wait(id)
+	require.NoError(t, err)
+	assert.Equal(t, jobs.StatusCancelled, job.Status)
+}
+
+func TestQuit_SummaryEnabled(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddSessionMessages()
+
+	m := &CLIModel{
+		styles:        createStyles(),
+		jobManager:    jobs.NewManager(),
+		currentConfig: &config.Config{Operations: config.OperationsConfig{SessionSummary: true}},
+		currentView:   constants.DefaultView,
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	assert.Nil(t, cmd)
+	assert.Equal(t, constants.SessionSummaryView, m.currentView)
+
+	// esc goes back instead of quitting
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, cmd)
+	assert.Equal(t, constants.DefaultView, m.currentView)
+}
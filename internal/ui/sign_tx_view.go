@@ -0,0 +1,437 @@
+package ui
+
+import (
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/qr"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	signFocusChainID = iota
+	signFocusTo
+	signFocusAmount
+	signFocusData
+	signFocusNonce
+	signFocusGasLimit
+	signFocusMaxFee
+	signFocusTip
+	signFocusOutput
+	signFocusCount
+)
+
+// signFillMsg carries the nonce and fees read from the network
+type signFillMsg struct {
+	nonce  uint64
+	maxFee *big.Int
+	tip    *big.Int
+	err    error
+}
+
+// initSignTransaction opens the sign-only form for the wallet being displayed
+func (m *CLIModel) initSignTransaction() {
+	newInput := func(placeholder string, width int) textinput.Model {
+		ti := textinput.New()
+		ti.Placeholder = placeholder
+		ti.CharLimit = 512
+		ti.Width = width
+		return ti
+	}
+	m.signInputs = []textinput.Model{
+		signFocusChainID:  newInput("1", 12),
+		signFocusTo:       newInput("0x…", 44),
+		signFocusAmount:   newInput("0.0", 24),
+		signFocusData:     newInput(localization.Labels["sign_tx_data_placeholder"], 66),
+		signFocusNonce:    newInput("0", 12),
+		signFocusGasLimit: newInput(strconv.Itoa(wallet.TxGas), 12),
+		signFocusMaxFee:   newInput("30", 12),
+		signFocusTip:      newInput("1", 12),
+		signFocusOutput:   newInput(localization.Labels["sign_tx_output_placeholder"], 60),
+	}
+	m.signInputs[signFocusGasLimit].SetValue(strconv.Itoa(wallet.TxGas))
+	if networks := m.signNetworks(); len(networks) > 0 {
+		m.signInputs[signFocusChainID].SetValue(strconv.FormatInt(networks[0].ChainID, 10))
+	}
+
+	m.signedTx = nil
+	m.signedRaw = ""
+	m.signedQR = nil
+	m.signFilling = false
+	m.setSignResult("", false)
+	m.setSignFocus(signFocusTo)
+	m.currentView = constants.SignTransactionView
+}
+
+// signNetworks lists the configured networks by name, to pick a chain ID from
+func (m *CLIModel) signNetworks() []config.Network {
+	if m.currentConfig == nil {
+		return nil
+	}
+	networks := make([]config.Network, 0, len(m.currentConfig.Networks))
+	for _, n := range m.currentConfig.Networks {
+		if n.ChainID > 0 {
+			networks = append(networks, n)
+		}
+	}
+	sort.Slice(networks, func(i, j int) bool { return networks[i].Name < networks[j].Name })
+	return networks
+}
+
+// signNetwork returns the configured network of the chain ID being entered
+func (m *CLIModel) signNetwork() (config.Network, bool) {
+	chainID, err := strconv.ParseInt(strings.TrimSpace(m.signInputs[signFocusChainID].Value()), 10, 64)
+	if err != nil {
+		return config.Network{}, false
+	}
+	for _, n := range m.signNetworks() {
+		if n.ChainID == chainID {
+			return n, true
+		}
+	}
+	return config.Network{}, false
+}
+
+// cycleSignNetwork moves the chain ID to the previous or next configured network
+func (m *CLIModel) cycleSignNetwork(step int) {
+	networks := m.signNetworks()
+	if len(networks) == 0 {
+		return
+	}
+	current, _ := m.signNetwork()
+	next := 0
+	for i, n := range networks {
+		if n.ChainID == current.ChainID {
+			next = (i + step + len(networks)) % len(networks)
+		}
+	}
+	m.signInputs[signFocusChainID].SetValue(strconv.FormatInt(networks[next].ChainID, 10))
+}
+
+func (m *CLIModel) setSignFocus(focus int) {
+	m.signFocus = focus
+	for i := range m.signInputs {
+		if i == focus {
+			m.signInputs[i].Focus()
+		} else {
+			m.signInputs[i].Blur()
+		}
+	}
+}
+
+// updateSignTransaction handles input on the sign-only screen
+func (m *CLIModel) updateSignTransaction(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.signFilling {
+		return m, nil
+	}
+	if m.signedTx != nil {
+		// The result stays on screen until esc; enter starts over
+		if keyMsg.String() == "enter" {
+			m.signedTx = nil
+			m.signedRaw = ""
+			m.signedQR = nil
+			m.setSignResult("", false)
+			m.setSignFocus(signFocusTo)
+		}
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "tab", "down":
+		m.setSignFocus((m.signFocus + 1) % signFocusCount)
+		return m, nil
+	case "shift+tab", "up":
+		m.setSignFocus((m.signFocus + signFocusCount - 1) % signFocusCount)
+		return m, nil
+	case "left", "right":
+		if m.signFocus == signFocusChainID {
+			step := 1
+			if keyMsg.String() == "left" {
+				step = -1
+			}
+			m.cycleSignNetwork(step)
+			return m, nil
+		}
+	case "ctrl+f":
+		return m, m.fillFromNetwork()
+	case "enter":
+		m.signTransaction()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.signInputs[m.signFocus], cmd = updateTextInput(m.signInputs[m.signFocus], msg)
+	return m, cmd
+}
+
+// signTransferRequest reads the form, returning the field at fault on error
+func (m *CLIModel) signTransferRequest() (wallet.TransferRequest, int, error) {
+	value := func(field int) string { return strings.TrimSpace(m.signInputs[field].Value()) }
+	var req wallet.TransferRequest
+
+	chainID, ok := new(big.Int).SetString(value(signFocusChainID), 10)
+	if !ok {
+		return req, signFocusChainID, errors.New(localization.Labels["sign_tx_invalid_chain"])
+	}
+	req.ChainID = chainID
+	to, err := m.readAddress(value(signFocusTo), localization.Labels["sign_tx_invalid_to"])
+	if err != nil {
+		return req, signFocusTo, err
+	}
+	req.To = to
+	amount := value(signFocusAmount)
+	if amount == "" {
+		amount = "0"
+	}
+	if req.Value, err = wallet.ParseUnits(amount, 18); err != nil {
+		return req, signFocusAmount, err
+	}
+	if data := value(signFocusData); data != "" {
+		if req.Data, err = hexutil.Decode(data); err != nil {
+			return req, signFocusData, fmt.Errorf("%s: %w", localization.Labels["sign_tx_invalid_data"], err)
+		}
+	}
+	if req.Nonce, err = strconv.ParseUint(value(signFocusNonce), 10, 64); err != nil {
+		return req, signFocusNonce, errors.New(localization.Labels["sign_tx_invalid_nonce"])
+	}
+	if req.GasLimit, err = strconv.ParseUint(value(signFocusGasLimit), 10, 64); err != nil {
+		return req, signFocusGasLimit, errors.New(localization.Labels["sign_tx_invalid_gas"])
+	}
+	if req.MaxFeePerGas, err = wallet.ParseUnits(value(signFocusMaxFee), 9); err != nil {
+		return req, signFocusMaxFee, err
+	}
+	if req.MaxPriorityFeePerGas, err = wallet.ParseUnits(value(signFocusTip), 9); err != nil {
+		return req, signFocusTip, err
+	}
+	// Errors that involve several fields leave the focus where it is
+	return req, -1, req.Validate()
+}
+
+// signTransaction signs the form and writes the output file, if one was given.
+// Nothing is sent to the network.
+func (m *CLIModel) signTransaction() {
+	req, field, err := m.signTransferRequest()
+	if err != nil {
+		m.setSignResult(err.Error(), true)
+		if field >= 0 {
+			m.setSignFocus(field)
+		}
+		return
+	}
+	signed, err := wallet.SignTransfer(m.walletDetails.Signer, req)
+	if err != nil {
+		m.setSignResult(fmt.Sprintf(localization.Labels["sign_tx_failed"], err), true)
+		return
+	}
+	raw, err := wallet.EncodeRawTransaction(signed)
+	if err != nil {
+		m.setSignResult(fmt.Sprintf(localization.Labels["sign_tx_failed"], err), true)
+		return
+	}
+
+	code, qrErr := qr.Encode(raw, qr.Low)
+	m.signedTx = signed
+	m.signedRequest = req
+	m.signedRaw = raw
+	m.signedQR = code
+
+	output := strings.TrimSpace(m.signInputs[signFocusOutput].Value())
+	switch {
+	case output != "":
+		path, err := writeSignedTransaction(output, raw, code)
+		if err != nil {
+			m.setSignResult(fmt.Sprintf(localization.Labels["sign_tx_write_failed"], err), true)
+			return
+		}
+		m.setSignResult(fmt.Sprintf(localization.Labels["sign_tx_written"], path), false)
+	case qrErr != nil:
+		m.setSignResult(localization.Labels["sign_tx_qr_too_long"], false)
+	default:
+		m.setSignResult(localization.Labels["sign_tx_signed"], false)
+	}
+}
+
+// writeSignedTransaction saves the raw transaction as hex text, or as a QR
+// image when path ends in .png
+func writeSignedTransaction(path, raw string, code *qr.Code) (string, error) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if !strings.EqualFold(filepath.Ext(path), ".png") {
+		return path, os.WriteFile(path, []byte(raw+"\n"), 0600)
+	}
+	if code == nil {
+		return path, errors.New(localization.Labels["sign_tx_qr_too_long"])
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return path, err
+	}
+	if err := code.WritePNG(f, 8); err != nil {
+		_ = f.Close()
+		return path, err
+	}
+	return path, f.Close()
+}
+
+// fillFromNetwork reads the pending nonce and suggested fees from the network
+// of the chain ID, for wallets that are online when signing
+func (m *CLIModel) fillFromNetwork() tea.Cmd {
+	network, ok := m.signNetwork()
+	if !ok || network.RPCEndpoint == "" || network.Quarantine != "" {
+		m.setSignResult(localization.Labels["sign_tx_no_network"], true)
+		return nil
+	}
+	m.signFilling = true
+	m.setSignResult(fmt.Sprintf(localization.Labels["sign_tx_filling"], network.Name), false)
+	address := m.walletDetails.Wallet.Address
+	network = m.Service.NetworkFor(address, network)
+	return func() tea.Msg {
+		client, err := blockchain.NewEthereum(network.RPCEndpoint, blockchain.DefaultTimeout, network.Symbol, 18, network.Name)
+		if err != nil {
+			return signFillMsg{err: err}
+		}
+		defer client.Close()
+		ctx := context.Background()
+		nonce, err := client.GetPendingNonce(ctx, address)
+		if err != nil {
+			return signFillMsg{err: err}
+		}
+		maxFee, tip, err := client.SuggestFees(ctx)
+		return signFillMsg{nonce: nonce, maxFee: maxFee, tip: tip, err: err}
+	}
+}
+
+// handleSignFill puts the values read from the network in the form
+func (m *CLIModel) handleSignFill(msg signFillMsg) {
+	if !m.signFilling {
+		return
+	}
+	m.signFilling = false
+	if msg.err != nil {
+		m.setSignResult(fmt.Sprintf(localization.Labels["sign_tx_fill_failed"], msg.err), true)
+		return
+	}
+	m.signInputs[signFocusNonce].SetValue(strconv.FormatUint(msg.nonce, 10))
+	m.signInputs[signFocusMaxFee].SetValue(wallet.FormatUnits(msg.maxFee, 9))
+	m.signInputs[signFocusTip].SetValue(wallet.FormatUnits(msg.tip, 9))
+	m.setSignResult(localization.Labels["sign_tx_filled"], false)
+}
+
+func (m *CLIModel) setSignResult(status string, failed bool) {
+	m.signStatus = status
+	m.signFailed = failed
+}
+
+// viewSignTransaction renders the form, or the signed transaction
+func (m *CLIModel) viewSignTransaction() string {
+	if m.walletDetails == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["sign_tx_title"]))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["sign_tx_from"], m.walletDetails.Wallet.Name, wallet.ChecksumAddress(m.walletDetails.Wallet.Address)))
+	b.WriteString("\n")
+
+	if m.signedTx != nil {
+		b.WriteString(m.viewSignedTransaction())
+	} else {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["sign_tx_desc"]))
+		b.WriteString("\n\n")
+		labels := []string{"sign_tx_chain_id", "sign_tx_to", "sign_tx_amount", "sign_tx_data", "sign_tx_nonce", "sign_tx_gas_limit", "sign_tx_max_fee", "sign_tx_tip", "sign_tx_output"}
+		for i, key := range labels {
+			line := fmt.Sprintf("%-24s %s", localization.Labels[key], m.signInputs[i].View())
+			if i == signFocusChainID {
+				if network, ok := m.signNetwork(); ok {
+					line += " " + m.styles.MenuDesc.Render(network.Name)
+				}
+			}
+			b.WriteString(line)
+			b.WriteString("\n")
+			if i == signFocusData {
+				if data, err := hexutil.Decode(strings.TrimSpace(m.signInputs[i].Value())); err == nil {
+					if call := m.describeCalldata(data); call != "" {
+						b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf("%-24s %s", "", call)))
+						b.WriteString("\n")
+					}
+				}
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	if m.signStatus != "" {
+		switch {
+		case m.signFilling:
+			b.WriteString(m.styles.MenuDesc.Render(m.signStatus))
+		case m.signFailed:
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.signStatus))
+		default:
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.signStatus))
+		}
+		b.WriteString("\n\n")
+	}
+
+	help := localization.Labels["sign_tx_help"]
+	if m.signedTx != nil {
+		help = localization.Labels["sign_tx_help_done"]
+	}
+	b.WriteString(m.styles.MenuDesc.Render(help))
+	return b.String()
+}
+
+// viewSignedTransaction summarizes the signed transaction and shows it as hex and QR
+func (m *CLIModel) viewSignedTransaction() string {
+	var b strings.Builder
+	tx, req := m.signedTx, m.signedRequest
+	symbol := "ETH"
+	if network, ok := m.signNetwork(); ok && network.Symbol != "" {
+		symbol = network.Symbol
+	}
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["sign_tx_summary"],
+		tx.To().Hex(), wallet.FormatUnits(tx.Value(), 18), symbol, tx.Nonce(), tx.ChainId(),
+		tx.Gas(), wallet.FormatUnits(tx.GasFeeCap(), 9), wallet.FormatUnits(tx.GasTipCap(), 9),
+		wallet.FormatUnits(req.MaxCost(), 18), symbol))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["sign_tx_hash"], tx.Hash().Hex()))
+	b.WriteString("\n\n")
+	b.WriteString(localization.Labels["sign_tx_raw"])
+	b.WriteString("\n")
+	b.WriteString(wrapText(m.signedRaw, 80))
+	b.WriteString("\n\n")
+	if m.signedQR != nil && (m.width == 0 || m.signedQR.Width() <= m.width) {
+		b.WriteString(m.signedQR.Terminal())
+		b.WriteString("\n")
+	} else if m.signedQR != nil {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["sign_tx_qr_too_wide"]))
+		b.WriteString("\n\n")
+	}
+	return b.String()
+}
+
+// wrapText breaks s into lines of width characters, for strings without spaces
+func wrapText(s string, width int) string {
+	var lines []string
+	for len(s) > width {
+		lines = append(lines, s[:width])
+		s = s[width:]
+	}
+	return strings.Join(append(lines, s), "\n")
+}
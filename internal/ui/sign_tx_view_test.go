@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignTransaction_SignOnly(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddSignTxMessages()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	m := &CLIModel{styles: createStyles(), currentView: constants.WalletDetailsView}
+	m.currentConfig = &config.Config{Networks: map[string]config.Network{
+		"sepolia": {Name: "Sepolia", ChainID: 11155111, Symbol: "ETH"},
+		"polygon": {Name: "Polygon", ChainID: 137, Symbol: "POL"},
+	}}
+	m.walletDetails = &wallet.WalletDetails{
+		Wallet: &wallet.Wallet{Name: "Cold", Address: from.Hex()},
+		Signer: wallet.NewKeySigner(key),
+	}
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	require.Equal(t, constants.SignTransactionView, m.currentView)
+	assert.Equal(t, "137", m.signInputs[signFocusChainID].Value(), "networks are offered by name")
+	m.setSignFocus(signFocusChainID)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	assert.Equal(t, "11155111", m.signInputs[signFocusChainID].Value())
+	assert.Contains(t, m.viewSignTransaction(), "Sepolia")
+
+	m.signInputs[signFocusTo].SetValue("not an address")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, m.signFailed)
+	assert.Equal(t, signFocusTo, m.signFocus)
+
+	out := filepath.Join(t.TempDir(), "signed.txt")
+	m.signInputs[signFocusTo].SetValue("0x000000000000000000000000000000000000dEaD")
+	m.signInputs[signFocusAmount].SetValue("0.25")
+	m.signInputs[signFocusNonce].SetValue("4")
+	m.signInputs[signFocusMaxFee].SetValue("12.5")
+	m.signInputs[signFocusTip].SetValue("1")
+	m.signInputs[signFocusOutput].SetValue(out)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, m.signFailed, m.signStatus)
+	require.NotNil(t, m.signedTx)
+
+	// The saved hex is the signed transaction, sent by the wallet
+	saved, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, m.signedRaw, strings.TrimSpace(string(saved)))
+	tx := new(types.Transaction)
+	require.NoError(t, tx.UnmarshalBinary(hexutil.MustDecode(m.signedRaw)))
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	require.NoError(t, err)
+	assert.Equal(t, from, sender)
+	assert.Equal(t, uint64(4), tx.Nonce())
+	assert.Equal(t, "250000000000000000", tx.Value().String())
+	assert.Equal(t, "12500000000", tx.GasFeeCap().String())
+
+	view := m.viewSignTransaction()
+	assert.Contains(t, view, tx.Hash().Hex())
+	assert.Contains(t, view, "▀", "the QR code is shown")
+
+	// enter starts over and a .png output writes the QR code
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Nil(t, m.signedTx)
+	png := filepath.Join(t.TempDir(), "signed.png")
+	m.signInputs[signFocusOutput].SetValue(png)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, m.signFailed, m.signStatus)
+	data, err := os.ReadFile(png)
+	require.NoError(t, err)
+	assert.Equal(t, "\x89PNG", string(data[:4]))
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.WalletDetailsView, m.currentView)
+}
@@ -0,0 +1,312 @@
+package ui
+
+import (
+	"blocowallet/internal/compliance"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func init() {
+	registerKeyMap(constants.StoreCheckView, storeCheckKeys)
+}
+
+// Fields of the re-import form
+const (
+	storeFocusName = iota
+	storeFocusPassword
+	storeFocusCount
+)
+
+// initStoreCheck opens the integrity check of the wallet store from the
+// configuration menu
+func (m *CLIModel) initStoreCheck() {
+	m.storeNameInput = textinput.New()
+	m.storeNameInput.Placeholder = localization.Labels["store_check_name_placeholder"]
+	m.storeNameInput.CharLimit = 50
+	m.storeNameInput.Width = 50
+
+	m.storePasswordInput = textinput.New()
+	m.storePasswordInput.Placeholder = localization.Labels["enter_password"]
+	m.storePasswordInput.CharLimit = constants.PasswordCharLimit
+	m.storePasswordInput.Width = constants.PasswordWidth
+	m.storePasswordInput.EchoMode = textinput.EchoPassword
+	m.storePasswordInput.EchoCharacter = '•'
+
+	m.storeReimporting = false
+	m.storeCursor = 0
+	m.setStoreResult("", false)
+	m.scanStore()
+	m.currentView = constants.StoreCheckView
+}
+
+// scanStore runs the check again, keeping the cursor on the list
+func (m *CLIModel) scanStore() {
+	m.storeReport, m.storeErr = m.Service.VerifyStore()
+	if m.storeReport != nil && m.storeCursor >= len(m.storeReport.Issues) {
+		m.storeCursor = max(len(m.storeReport.Issues)-1, 0)
+	}
+}
+
+func (m *CLIModel) setStoreResult(status string, failed bool) {
+	m.storeStatus = status
+	m.storeFailed = failed
+}
+
+// selectedStoreIssue is the issue under the cursor
+func (m *CLIModel) selectedStoreIssue() (wallet.StoreIssue, bool) {
+	if m.storeReport == nil || m.storeCursor >= len(m.storeReport.Issues) {
+		return wallet.StoreIssue{}, false
+	}
+	return m.storeReport.Issues[m.storeCursor], true
+}
+
+// closeStoreCheck closes the re-import form, or goes back to the
+// configuration menu
+func (m *CLIModel) closeStoreCheck() {
+	if m.storeReimporting {
+		m.storeReimporting = false
+		m.storePasswordInput.SetValue("")
+		return
+	}
+	m.storeReport = nil
+	m.initConfigMenu()
+}
+
+func (m *CLIModel) setStoreFocus(focus int) {
+	m.storeFocus = focus
+	m.storeNameInput.Blur()
+	m.storePasswordInput.Blur()
+	if focus == storeFocusName {
+		m.storeNameInput.Focus()
+	} else {
+		m.storePasswordInput.Focus()
+	}
+}
+
+// storeCheckKeys are the keys of the store check: the repairs offered for the
+// selected issue, or those of the re-import form
+func storeCheckKeys(m *CLIModel) []key.Binding {
+	if m.storeReimporting {
+		return []key.Binding{
+			newBinding("tab", "key_next_field", "tab", "down", "shift+tab", "up"),
+			newBinding("enter", "key_import", "enter"),
+			newBinding("esc", "key_cancel", "esc"),
+		}
+	}
+	issue, selected := m.selectedStoreIssue()
+	offered := func(r wallet.StoreRepair) bool { return selected && storeRepairOffered(issue, r) }
+	return []key.Binding{
+		bindingIf(m.storeReport != nil && len(m.storeReport.Issues) > 1, newBinding("↑/↓", "key_move", "up", "k", "down", "j")),
+		bindingIf(offered(wallet.RepairRelink), newBinding("l", "key_relink", "l")),
+		bindingIf(offered(wallet.RepairReimport), newBinding("i", "key_reimport", "i")),
+		bindingIf(offered(wallet.RepairRemoveRow), newBinding("d", "key_remove_row", "d")),
+		bindingIf(offered(wallet.RepairQuarantine), newBinding("x", "key_quarantine", "x")),
+		newBinding("r", "key_check_again", "r"),
+	}
+}
+
+// updateStoreCheck moves through the issues and applies the repair chosen
+func (m *CLIModel) updateStoreCheck(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if m.storeReimporting {
+		return m.updateStoreReimport(keyMsg)
+	}
+
+	issue, selected := m.selectedStoreIssue()
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.storeCursor > 0 {
+			m.storeCursor--
+		}
+	case "down", "j":
+		if m.storeReport != nil && m.storeCursor < len(m.storeReport.Issues)-1 {
+			m.storeCursor++
+		}
+	case "r":
+		m.setStoreResult("", false)
+		m.scanStore()
+	case "l":
+		if selected {
+			return m, m.repairStore(issue, wallet.RepairRelink, "", "")
+		}
+	case "d":
+		if selected {
+			return m, m.repairStore(issue, wallet.RepairRemoveRow, "", "")
+		}
+	case "x":
+		if selected {
+			return m, m.repairStore(issue, wallet.RepairQuarantine, "", "")
+		}
+	case "i":
+		if selected && storeRepairOffered(issue, wallet.RepairReimport) {
+			m.storeNameInput.SetValue("")
+			m.storePasswordInput.SetValue("")
+			m.storeReimporting = true
+			m.setStoreResult("", false)
+			m.setStoreFocus(storeFocusName)
+		}
+	}
+	return m, nil
+}
+
+// updateStoreReimport handles the name and password of the orphan keystore
+func (m *CLIModel) updateStoreReimport(keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch keyMsg.String() {
+	case "tab", "down":
+		m.setStoreFocus((m.storeFocus + 1) % storeFocusCount)
+		return m, nil
+	case "shift+tab", "up":
+		m.setStoreFocus((m.storeFocus + storeFocusCount - 1) % storeFocusCount)
+		return m, nil
+	case "enter":
+		issue, ok := m.selectedStoreIssue()
+		if !ok {
+			return m, nil
+		}
+		name := strings.TrimSpace(m.storeNameInput.Value())
+		if name == "" {
+			name = fmt.Sprintf(localization.Labels["store_check_default_name"], wallet.ChecksumAddress(issue.Address))
+		}
+		password := m.storePasswordInput.Value()
+		m.storePasswordInput.SetValue("")
+		return m, m.repairStore(issue, wallet.RepairReimport, name, password)
+	}
+
+	var cmd tea.Cmd
+	if m.storeFocus == storeFocusName {
+		m.storeNameInput, cmd = updateTextInput(m.storeNameInput, keyMsg)
+	} else {
+		m.storePasswordInput, cmd = updateTextInput(m.storePasswordInput, keyMsg)
+	}
+	return m, cmd
+}
+
+func storeRepairOffered(issue wallet.StoreIssue, repair wallet.StoreRepair) bool {
+	for _, r := range issue.Repairs() {
+		if r == repair {
+			return true
+		}
+	}
+	return false
+}
+
+// repairStore applies repair to issue and checks the store again. Removing
+// or quarantining a wallet takes it off the list, so compliance mode treats
+// both as a deletion.
+func (m *CLIModel) repairStore(issue wallet.StoreIssue, repair wallet.StoreRepair, name, password string) tea.Cmd {
+	if !storeRepairOffered(issue, repair) {
+		return nil
+	}
+	run := func() error {
+		return m.Service.RepairStoreIssue(issue, repair, name, password)
+	}
+	done := func(err error) tea.Cmd {
+		if err != nil {
+			m.setStoreResult(fmt.Sprintf(localization.Labels["store_check_repair_failed"], err), true)
+			return nil
+		}
+		m.storeReimporting = false
+		m.setStoreResult(localization.Labels["store_check_repaired_"+string(repair)], false)
+		m.scanStore()
+		return m.refreshWalletsTable()
+	}
+	if repair == wallet.RepairRemoveRow || repair == wallet.RepairQuarantine {
+		return m.withApproval(approvedAction{
+			action:  compliance.ActionDeleteWallet,
+			subject: issue.Wallet.Address,
+			run:     run,
+			done:    done,
+		})
+	}
+	return done(run())
+}
+
+// storeIssueTitle names the issue and the wallet or file it concerns
+func storeIssueTitle(issue wallet.StoreIssue) string {
+	if issue.Wallet == nil {
+		return fmt.Sprintf(localization.Labels["store_check_issue_"+string(issue.Kind)], issue.Path, wallet.ChecksumAddress(issue.Address))
+	}
+	return fmt.Sprintf(localization.Labels["store_check_issue_"+string(issue.Kind)], issue.Wallet.Name, wallet.ChecksumAddress(issue.Wallet.Address))
+}
+
+// viewStoreCheck lists the issues found, with the repairs of the selected one
+func (m *CLIModel) viewStoreCheck() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["store_check_title"]))
+	b.WriteString("\n\n")
+
+	if m.storeErr != nil {
+		b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + fmt.Sprintf(localization.Labels["store_check_failed"], m.storeErr)))
+		b.WriteString("\n")
+		return b.String()
+	}
+	report := m.storeReport
+	if report == nil {
+		return b.String()
+	}
+	b.WriteString(fmt.Sprintf(localization.Labels["store_check_summary"], localization.Plural("wallet_count", report.Wallets), localization.Plural("keystore_count", report.Keystores), report.KeystoreDir))
+	b.WriteString("\n\n")
+
+	if report.OK() {
+		b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + localization.Labels["store_check_ok"]))
+		b.WriteString("\n")
+	}
+	for i, issue := range report.Issues {
+		line := fmt.Sprintf("%s %s", glyphs.Warning, storeIssueTitle(issue))
+		if i == m.storeCursor {
+			b.WriteString(m.styles.MenuSelected.Render("> " + line))
+		} else {
+			b.WriteString(m.styles.MenuItem.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	issue, selected := m.selectedStoreIssue()
+	if selected {
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("%s %s\n", glyphs.Bullet, issue.Path))
+		b.WriteString(fmt.Sprintf("%s %s\n", glyphs.Bullet, issue.Detail))
+		if issue.Candidate != "" {
+			b.WriteString(fmt.Sprintf("%s %s\n", glyphs.Bullet, fmt.Sprintf(localization.Labels["store_check_candidate"], issue.Candidate)))
+		}
+		if len(issue.Repairs()) == 0 {
+			b.WriteString(fmt.Sprintf("%s %s\n", glyphs.Bullet, localization.Labels["store_check_no_repair"]))
+		}
+	}
+
+	if m.storeReimporting {
+		b.WriteString("\n")
+		b.WriteString(localization.Labels["store_check_reimport_desc"])
+		b.WriteString("\n\n")
+		b.WriteString(localization.Labels["store_check_name"])
+		b.WriteString("\n")
+		b.WriteString(m.storeNameInput.View())
+		b.WriteString("\n")
+		b.WriteString(localization.Labels["store_check_password"])
+		b.WriteString("\n")
+		b.WriteString(m.storePasswordInput.View())
+		b.WriteString("\n")
+	}
+
+	if m.storeStatus != "" {
+		b.WriteString("\n")
+		if m.storeFailed {
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.storeStatus))
+		} else {
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.storeStatus))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(m.renderApprovalNotice())
+	return b.String()
+}
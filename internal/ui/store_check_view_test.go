@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreCheck_RemovesWalletWithoutKeystore(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddStoreCheckMessages()
+
+	dir := t.TempDir()
+	repo, err := storage.NewWalletRepository(&config.Config{AppDir: dir, DatabasePath: filepath.Join(dir, "wallets.db")})
+	require.NoError(t, err)
+	defer repo.Close()
+
+	keystoreDir := filepath.Join(dir, "keystore")
+	require.NoError(t, os.MkdirAll(keystoreDir, 0700))
+	w := &wallet.Wallet{Name: "Lost", Address: "0x00000000000000000000000000000000000000b1", KeyStorePath: filepath.Join(keystoreDir, "lost.json"), ImportMethod: "keystore", SourceHash: "lost"}
+	require.NoError(t, repo.AddWallet(w))
+
+	m := &CLIModel{
+		Service:     &wallet.WalletService{Repo: repo, KeystoreDir: keystoreDir},
+		styles:      createStyles(),
+		currentView: constants.ConfigurationView,
+	}
+	m.initConfigMenu()
+	m.selectedMenu = 6
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, constants.StoreCheckView, m.currentView)
+	require.NoError(t, m.storeErr)
+	require.Len(t, m.storeReport.Issues, 1)
+	assert.Contains(t, m.viewStoreCheck(), "Missing keystore: Lost")
+	assert.Contains(t, m.renderShortHelp(0), "d remove row")
+	assert.NotContains(t, m.renderShortHelp(0), "x quarantine")
+
+	// Quarantine is not offered for a missing keystore
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	assert.Empty(t, m.storeStatus)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	assert.False(t, m.storeFailed, m.storeStatus)
+	assert.True(t, m.storeReport.OK())
+	assert.Contains(t, m.viewStoreCheck(), "The database and the keystore directory agree.")
+
+	wallets, err := repo.GetAllWallets()
+	require.NoError(t, err)
+	assert.Empty(t, wallets)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.ConfigurationView, m.currentView)
+}
@@ -32,6 +32,7 @@ type Styles struct {
 	SelectedStyle      lipgloss.Style
 }
 
+// createStyles builds the shared styles from the active theme
 func createStyles() Styles {
 	return Styles{
 		Header: lipgloss.NewStyle().
@@ -46,7 +47,7 @@ func createStyles() Styles {
 			Align(lipgloss.Left).
 			PaddingLeft(1).
 			PaddingRight(1).
-			Background(lipgloss.Color("#7D56F4")),
+			Background(palette.Accent),
 
 		TopStrip: lipgloss.NewStyle().Margin(1, constants.StyleMargin).Padding(0, constants.StyleMargin),
 		MenuItem: lipgloss.NewStyle().
@@ -55,7 +56,7 @@ func createStyles() Styles {
 			Padding(0, constants.StyleMargin).
 			Border(lipgloss.HiddenBorder(), false, false, false, true),
 		MenuSelected: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("99")).
+			Foreground(palette.Accent).
 			Bold(true).
 			Margin(0, constants.StyleMargin).
 			Padding(0, constants.StyleMargin).
@@ -64,7 +65,7 @@ func createStyles() Styles {
 		SelectedTitle: lipgloss.NewStyle().Bold(true).
 			Margin(0, constants.StyleMargin).
 			Padding(0, constants.StyleMargin).
-			Foreground(lipgloss.Color("99")),
+			Foreground(palette.Accent),
 		MenuTitle: lipgloss.NewStyle().
 			Margin(0, constants.StyleMargin).
 			Padding(0, constants.StyleMargin).
@@ -73,63 +74,63 @@ func createStyles() Styles {
 			Margin(0, constants.StyleMargin).
 			Padding(0, constants.StyleMargin).
 			Width(constants.StyleWidth).
-			Foreground(lipgloss.Color("244")),
+			Foreground(palette.Muted),
 		ErrorStyle: lipgloss.NewStyle().
 			Padding(1, 2).
 			Margin(1, constants.StyleMargin).
-			Foreground(lipgloss.Color("#FF0000")),
+			Foreground(palette.Error),
 		SuccessStyle: lipgloss.NewStyle().
 			Padding(1, 2).
 			Margin(1, constants.StyleMargin).
-			Foreground(lipgloss.Color("#00AA00")),
+			Foreground(palette.Success),
 		WalletDetails: lipgloss.NewStyle().
 			Margin(1, constants.StyleMargin).
 			Padding(1, 2),
 		StatusBar: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")).
+			Foreground(palette.Muted).
 			Padding(0, constants.StyleMargin),
 		Splash: lipgloss.NewStyle().
 			Align(lipgloss.Center).Padding(1, 2),
 		StatusBarLeft: lipgloss.NewStyle().
-			Background(lipgloss.Color("#7D56F4")).
+			Background(palette.Accent).
 			PaddingLeft(1).
 			PaddingRight(1),
 		StatusBarCenter: lipgloss.NewStyle().
-			Background(lipgloss.Color("#454544")).
+			Background(palette.Surface).
 			PaddingLeft(1).
 			PaddingRight(1),
 		StatusBarRight: lipgloss.NewStyle().
-			Background(lipgloss.Color("#CC5C87")).
+			Background(palette.AccentAlt).
 			PaddingLeft(1).
 			PaddingRight(1),
 		Dialog: lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#7D56F4")).
-			Foreground(lipgloss.Color("#F5F5F5")).
+			BorderForeground(palette.Accent).
+			Foreground(palette.Text).
 			Padding(1, 4).
 			Align(lipgloss.Center),
 		DialogButton: lipgloss.NewStyle().
 			Padding(0, 2).
 			Margin(0, 1).
 			Bold(true).
-			Foreground(lipgloss.Color("#7D56F4")).
-			Background(lipgloss.Color("#F5F5F5")).
+			Foreground(palette.Accent).
+			Background(palette.Surface).
 			Border(lipgloss.HiddenBorder()).
-			BorderForeground(lipgloss.Color("#7D56F4")),
+			BorderForeground(palette.Accent),
 		DialogButtonActive: lipgloss.NewStyle().
 			Padding(0, 2).
 			Margin(0, 1).
 			Bold(true).
-			Foreground(lipgloss.Color("#F5F5F5")).
-			Background(lipgloss.Color("#7D56F4")).
+			Foreground(palette.SelectionFg).
+			Background(palette.SelectionBg).
 			Border(lipgloss.HiddenBorder()).
-			BorderForeground(lipgloss.Color("#7D56F4")),
+			BorderForeground(palette.Accent),
 		GreenCheck: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("70")),
+			Foreground(palette.Success),
 		RedCross: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("1")),
+			Foreground(palette.Error),
 		SelectedStyle: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("99")).
+			Foreground(palette.Accent).
 			Bold(true),
 	}
 }
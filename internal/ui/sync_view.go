@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/internal/metasync"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// syncDoneMsg carries the outcome of a metadata sync run
+type syncDoneMsg struct {
+	report *metasync.Report
+	err    error
+}
+
+// SetSyncer enables remote sync of wallet metadata
+func (m *CLIModel) SetSyncer(s *metasync.Syncer) {
+	m.syncer = s
+}
+
+// initSyncView opens the metadata sync screen
+func (m *CLIModel) initSyncView() {
+	m.syncStatus = ""
+	m.syncFailed = false
+	m.currentView = constants.SyncView
+}
+
+// updateSync handles input on the sync screen
+func (m *CLIModel) updateSync(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || keyMsg.String() != "enter" || m.syncer == nil || m.syncRunning {
+		return m, nil
+	}
+	m.syncRunning = true
+	m.syncStatus = localization.Labels["sync_running"]
+	m.syncFailed = false
+	return m, runSyncCmd(m.syncer, m.Service)
+}
+
+// runSyncCmd syncs in the background and saves merged networks to the configuration
+func runSyncCmd(s *metasync.Syncer, ws *wallet.WalletService) tea.Cmd {
+	return func() tea.Msg {
+		cfg, err := loadOrCreateConfig()
+		if err != nil {
+			return syncDoneMsg{err: err}
+		}
+		report, err := s.Sync(context.Background(), ws, cfg.Networks)
+		if err != nil {
+			return syncDoneMsg{err: err}
+		}
+		if report.NetworksChanged {
+			cfg.Networks = report.Networks
+			if err := getConfigurationManager().SaveConfiguration(cfg); err != nil {
+				return syncDoneMsg{err: fmt.Errorf("failed to save synced networks: %w", err)}
+			}
+		}
+		return syncDoneMsg{report: report}
+	}
+}
+
+// handleSyncDone shows the sync outcome and reloads the wallets that changed
+func (m *CLIModel) handleSyncDone(msg syncDoneMsg) tea.Cmd {
+	m.syncRunning = false
+	if msg.err != nil {
+		m.syncStatus = msg.err.Error()
+		m.syncFailed = true
+		return nil
+	}
+
+	r := msg.report
+	status := fmt.Sprintf(localization.Labels["sync_success"], r.Wallets, len(r.Networks), r.Renamed, r.KeystoresImported, r.RemoteOnly)
+	if len(r.Conflicts) > 0 {
+		keys := make([]string, len(r.Conflicts))
+		for i, c := range r.Conflicts {
+			keys[i] = c.Key
+		}
+		status += "\n" + fmt.Sprintf(localization.Labels["sync_conflicts"], strings.Join(keys, ", "))
+	}
+	m.syncStatus = status
+	m.syncFailed = false
+	if r.Renamed > 0 || r.NotesUpdated > 0 || r.KeystoresImported > 0 {
+		return m.refreshWalletsTable()
+	}
+	return nil
+}
+
+// viewSync renders the sync screen
+func (m *CLIModel) viewSync() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["sync_title"]))
+	b.WriteString("\n\n")
+	b.WriteString(localization.Labels["sync_desc"])
+	b.WriteString("\n\n")
+
+	if m.syncer == nil {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["sync_not_configured"]))
+		b.WriteString("\n\n")
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["sync_help_back"]))
+		return b.String()
+	}
+
+	b.WriteString(fmt.Sprintf(localization.Labels["sync_target"], m.syncer.Describe()))
+	b.WriteString("\n")
+	keystores := localization.Labels["sync_keystores_local"]
+	if m.syncer.IncludesKeystores() {
+		keystores = localization.Labels["sync_keystores_included"]
+	}
+	b.WriteString(keystores)
+	b.WriteString("\n")
+	last := localization.Labels["sync_never"]
+	if t := m.syncer.LastSync(); !t.IsZero() {
+		last = t.Local().Format("2006-01-02 15:04")
+	}
+	b.WriteString(fmt.Sprintf(localization.Labels["sync_last"], last))
+	b.WriteString("\n\n")
+
+	if m.syncStatus != "" {
+		switch {
+		case m.syncRunning:
+			b.WriteString(m.styles.MenuDesc.Render(m.syncStatus))
+		case m.syncFailed:
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.syncStatus))
+		default:
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.syncStatus))
+		}
+		b.WriteString("\n\n")
+	}
+	b.WriteString(m.styles.MenuDesc.Render(localization.Labels["sync_help"]))
+	return b.String()
+}
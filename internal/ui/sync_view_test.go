@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/metasync"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSyncModel(t *testing.T, syncer *metasync.Syncer) *CLIModel {
+	t.Helper()
+	localization.SetCurrentLanguage("en")
+	localization.AddSyncMessages()
+	m := &CLIModel{styles: createStyles()}
+	m.SetSyncer(syncer)
+	m.initSyncView()
+	return m
+}
+
+func TestSyncView_NotConfigured(t *testing.T) {
+	m := newSyncModel(t, nil)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.Nil(t, cmd)
+	assert.Equal(t, constants.SyncView, m.currentView)
+	assert.Contains(t, m.viewSync(), "BLOCO_WALLET_SYNC_PASSPHRASE")
+}
+
+func TestSyncView_ShowsOutcome(t *testing.T) {
+	dir := t.TempDir()
+	backend := &metasync.DirBackend{Path: filepath.Join(dir, metasync.DocumentName)}
+	m := newSyncModel(t, metasync.NewWithBackend(backend, "pass", false, dir, dir))
+
+	view := m.viewSync()
+	assert.Contains(t, view, backend.Path)
+	assert.Contains(t, view, localization.Labels["sync_never"])
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.True(t, m.syncRunning)
+
+	// A second enter while running does not start another sync
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+
+	_, _ = m.Update(syncDoneMsg{report: &metasync.Report{
+		Wallets:    3,
+		RemoteOnly: 1,
+		Conflicts:  []metasync.Conflict{{Kind: "network", Key: "eth"}},
+	}})
+	assert.False(t, m.syncRunning)
+	assert.False(t, m.syncFailed)
+	assert.Contains(t, m.viewSync(), "Synced 3 wallets")
+	assert.Contains(t, m.viewSync(), "eth")
+
+	_, _ = m.Update(syncDoneMsg{err: errors.New("backend offline")})
+	assert.True(t, m.syncFailed)
+	assert.Contains(t, m.viewSync(), "backend offline")
+}
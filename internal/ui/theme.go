@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultTheme is used when the configuration names no theme
+const DefaultTheme = "dark"
+
+// themeSet maps the roles of the colours used across the views to lipgloss
+// colours. Views pick a role, never a colour, so every theme reaches them.
+type themeSet struct {
+	Accent      lipgloss.Color // Titles, selected items, dialog borders and the status bar
+	AccentAlt   lipgloss.Color // Right side of the status bar
+	Text        lipgloss.Color // Text on dialogs and lists
+	Muted       lipgloss.Color // Descriptions, help lines and disabled items
+	Subtle      lipgloss.Color // Table and list borders
+	Surface     lipgloss.Color // Backgrounds of the status bar and highlighted rows
+	SelectionFg lipgloss.Color // Text of selected table rows and buttons
+	SelectionBg lipgloss.Color // Background of selected table rows and buttons
+	Success     lipgloss.Color
+	Warning     lipgloss.Color
+	Error       lipgloss.Color
+	Info        lipgloss.Color
+}
+
+var builtinThemes = map[string]themeSet{
+	"dark": {
+		Accent:      "#7D56F4",
+		AccentAlt:   "#CC5C87",
+		Text:        "#F5F5F5",
+		Muted:       "244",
+		Subtle:      "240",
+		Surface:     "#454544",
+		SelectionFg: "229",
+		SelectionBg: "57",
+		Success:     "70",
+		Warning:     "214",
+		Error:       "196",
+		Info:        "75",
+	},
+	"light": {
+		Accent:      "#5A3FC0",
+		AccentAlt:   "#B0406A",
+		Text:        "#1F1F1F",
+		Muted:       "#6C6C6C",
+		Subtle:      "#B0B0B0",
+		Surface:     "#E4E4E4",
+		SelectionFg: "#FFFFFF",
+		SelectionBg: "#5A3FC0",
+		Success:     "#007A00",
+		Warning:     "#B35C00",
+		Error:       "#C00000",
+		Info:        "#0060C0",
+	},
+	"high-contrast": {
+		Accent:      "#FFFF00",
+		AccentAlt:   "#00FFFF",
+		Text:        "#FFFFFF",
+		Muted:       "#D0D0D0",
+		Subtle:      "#FFFFFF",
+		Surface:     "#000000",
+		SelectionFg: "#000000",
+		SelectionBg: "#FFFF00",
+		Success:     "#00FF00",
+		Warning:     "#FFA500",
+		Error:       "#FF3030",
+		Info:        "#00FFFF",
+	},
+	"solarized": {
+		Accent:      "#6C71C4",
+		AccentAlt:   "#D33682",
+		Text:        "#93A1A1",
+		Muted:       "#839496",
+		Subtle:      "#586E75",
+		Surface:     "#073642",
+		SelectionFg: "#FDF6E3",
+		SelectionBg: "#268BD2",
+		Success:     "#859900",
+		Warning:     "#B58900",
+		Error:       "#DC322F",
+		Info:        "#2AA198",
+	},
+}
+
+// palette is the active theme; createStyles and the views read it
+var palette = builtinThemes[DefaultTheme]
+
+// themeFile is a user theme in <app_dir>/config/themes/<name>.toml. Colours
+// left out come from base, the dark theme unless it names another built-in.
+type themeFile struct {
+	Base        string `toml:"base"`
+	Accent      string `toml:"accent"`
+	AccentAlt   string `toml:"accent_alt"`
+	Text        string `toml:"text"`
+	Muted       string `toml:"muted"`
+	Subtle      string `toml:"subtle"`
+	Surface     string `toml:"surface"`
+	SelectionFg string `toml:"selection_fg"`
+	SelectionBg string `toml:"selection_bg"`
+	Success     string `toml:"success"`
+	Warning     string `toml:"warning"`
+	Error       string `toml:"error"`
+	Info        string `toml:"info"`
+}
+
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// validColor accepts "#RGB", "#RRGGBB" and ANSI colour numbers 0 to 255
+func validColor(value string) bool {
+	if hexColorPattern.MatchString(value) {
+		return true
+	}
+	n, err := strconv.Atoi(value)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// themesDir is where user themes are read from
+func themesDir(appDir string) string {
+	return filepath.Join(appDir, "config", "themes")
+}
+
+// loadThemeFile reads a user theme over its base
+func loadThemeFile(path string) (themeSet, error) {
+	var file themeFile
+	if _, err := toml.DecodeFile(path, &file); err != nil {
+		return themeSet{}, err
+	}
+	baseName := file.Base
+	if baseName == "" {
+		baseName = DefaultTheme
+	}
+	set, ok := builtinThemes[baseName]
+	if !ok {
+		return themeSet{}, fmt.Errorf("unknown base theme %q", baseName)
+	}
+	colors := []struct {
+		key   string
+		value string
+		dst   *lipgloss.Color
+	}{
+		{"accent", file.Accent, &set.Accent},
+		{"accent_alt", file.AccentAlt, &set.AccentAlt},
+		{"text", file.Text, &set.Text},
+		{"muted", file.Muted, &set.Muted},
+		{"subtle", file.Subtle, &set.Subtle},
+		{"surface", file.Surface, &set.Surface},
+		{"selection_fg", file.SelectionFg, &set.SelectionFg},
+		{"selection_bg", file.SelectionBg, &set.SelectionBg},
+		{"success", file.Success, &set.Success},
+		{"warning", file.Warning, &set.Warning},
+		{"error", file.Error, &set.Error},
+		{"info", file.Info, &set.Info},
+	}
+	for _, c := range colors {
+		value := strings.TrimSpace(c.value)
+		if value == "" {
+			continue
+		}
+		if !validColor(value) {
+			return themeSet{}, fmt.Errorf("%s: invalid colour %q; use #RRGGBB or an ANSI number from 0 to 255", c.key, value)
+		}
+		*c.dst = lipgloss.Color(value)
+	}
+	return set, nil
+}
+
+// themeEntry is a theme offered by the picker
+type themeEntry struct {
+	name string
+	set  themeSet
+	path string // Empty for the built-in themes
+	err  error  // Why a user theme cannot be used
+}
+
+// availableThemes lists the built-in themes followed by the user themes of
+// appDir, sorted by name. A user theme named like a built-in one replaces it.
+func availableThemes(appDir string) []themeEntry {
+	var builtins, user []themeEntry
+	byName := make(map[string]int)
+	for name, set := range builtinThemes {
+		builtins = append(builtins, themeEntry{name: name, set: set})
+	}
+	sort.Slice(builtins, func(i, j int) bool { return builtins[i].name < builtins[j].name })
+	for i, entry := range builtins {
+		byName[entry.name] = i
+	}
+
+	paths, _ := filepath.Glob(filepath.Join(themesDir(appDir), "*.toml"))
+	sort.Strings(paths)
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".toml")
+		set, err := loadThemeFile(path)
+		entry := themeEntry{name: name, set: set, path: path, err: err}
+		if i, ok := byName[name]; ok && err == nil {
+			builtins[i] = entry
+			continue
+		}
+		user = append(user, entry)
+	}
+	return append(builtins, user...)
+}
+
+// findTheme returns the usable theme called name
+func findTheme(appDir, name string) (themeSet, error) {
+	if name == "" {
+		name = DefaultTheme
+	}
+	for _, entry := range availableThemes(appDir) {
+		if entry.name == name {
+			if entry.err != nil {
+				return themeSet{}, fmt.Errorf("theme %s: %w", entry.path, entry.err)
+			}
+			return entry.set, nil
+		}
+	}
+	return themeSet{}, fmt.Errorf("unknown theme %q", name)
+}
+
+// ensureThemesDir creates the directory of the user themes, so users find
+// where to put them
+func ensureThemesDir(appDir string) error {
+	return os.MkdirAll(themesDir(appDir), os.ModePerm)
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTheme(t *testing.T, appDir, name, content string) string {
+	require.NoError(t, os.MkdirAll(themesDir(appDir), 0755))
+	path := filepath.Join(themesDir(appDir), name+".toml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestBuiltinThemes_SetEveryColor(t *testing.T) {
+	for name, set := range builtinThemes {
+		for _, color := range []lipgloss.Color{set.Accent, set.AccentAlt, set.Text, set.Muted, set.Subtle, set.Surface, set.SelectionFg, set.SelectionBg, set.Success, set.Warning, set.Error, set.Info} {
+			assert.True(t, validColor(string(color)), "%s: %q", name, color)
+		}
+	}
+	for _, name := range []string{"dark", "light", "high-contrast", "solarized"} {
+		assert.Contains(t, builtinThemes, name)
+	}
+}
+
+func TestLoadThemeFile(t *testing.T) {
+	appDir := t.TempDir()
+	path := writeTheme(t, appDir, "ocean", "base = \"light\"\naccent = \"#0088CC\"\nerror = \"160\"\n")
+
+	set, err := loadThemeFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, lipgloss.Color("#0088CC"), set.Accent)
+	assert.Equal(t, lipgloss.Color("160"), set.Error)
+	assert.Equal(t, builtinThemes["light"].Text, set.Text, "unset colors come from the base")
+
+	_, err = loadThemeFile(writeTheme(t, appDir, "bad", "accent = \"blue\"\n"))
+	assert.ErrorContains(t, err, "accent")
+	_, err = loadThemeFile(writeTheme(t, appDir, "orphan", "base = \"neon\"\n"))
+	assert.ErrorContains(t, err, "neon")
+	_, err = loadThemeFile(writeTheme(t, appDir, "broken", "accent = \n"))
+	assert.Error(t, err)
+}
+
+func TestAvailableThemes(t *testing.T) {
+	appDir := t.TempDir()
+	writeTheme(t, appDir, "ocean", "accent = \"#0088CC\"\n")
+	writeTheme(t, appDir, "dark", "accent = \"#FF00FF\"\n")
+	writeTheme(t, appDir, "bad", "accent = \"256\"\n")
+
+	var names []string
+	for _, entry := range availableThemes(appDir) {
+		names = append(names, entry.name)
+	}
+	assert.Equal(t, []string{"dark", "high-contrast", "light", "solarized", "bad", "ocean"}, names)
+
+	set, err := findTheme(appDir, "dark")
+	require.NoError(t, err)
+	assert.Equal(t, lipgloss.Color("#FF00FF"), set.Accent, "a file replaces the built-in theme of the same name")
+
+	_, err = findTheme(appDir, "bad")
+	assert.Error(t, err)
+	_, err = findTheme(appDir, "missing")
+	assert.ErrorContains(t, err, "unknown theme")
+
+	set, err = findTheme(appDir, "")
+	require.NoError(t, err)
+	assert.Equal(t, lipgloss.Color("#FF00FF"), set.Accent, "no name is the dark theme")
+}
@@ -0,0 +1,219 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/pkg/localization"
+	"blocowallet/pkg/logger"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func init() {
+	registerKeyMap(constants.ThemeView, func(m *CLIModel) []key.Binding {
+		return []key.Binding{
+			newBinding("↑/↓", "key_preview", "up", "k", "down", "j"),
+			newBinding("enter", "key_use", "enter"),
+			newBinding("r", "key_reload_files", "r"),
+		}
+	})
+}
+
+// initializeTheme applies the configured theme when the model is created;
+// the dark theme stays when it cannot be used
+func initializeTheme(model *CLIModel) {
+	cfg, err := loadOrCreateConfig()
+	if err != nil {
+		return
+	}
+	if err := ensureThemesDir(cfg.AppDir); err != nil && uiLogger != nil {
+		uiLogger.Warn("Failed to create the themes directory", logger.Error(err), logger.String("component", "themes"))
+	}
+	if err := model.applyTheme(cfg.AppDir, cfg.Theme); err != nil && uiLogger != nil {
+		uiLogger.Warn("Failed to load the configured theme", logger.Error(err), logger.String("component", "themes"))
+	}
+}
+
+// applyTheme makes the theme called name the active one and rebuilds the
+// styles that were made from the previous one
+func (m *CLIModel) applyTheme(appDir, name string) error {
+	if name == "" {
+		name = DefaultTheme
+	}
+	set, err := findTheme(appDir, name)
+	if err != nil {
+		return err
+	}
+	m.themeName = name
+	m.usePalette(set)
+	return nil
+}
+
+// usePalette switches the colours without changing the configured theme
+func (m *CLIModel) usePalette(set themeSet) {
+	palette = set
+	m.styles = createStyles()
+	m.walletTable.SetStyles(walletTableStyles())
+}
+
+// themeAppDir is the application directory the user themes are read from
+func (m *CLIModel) themeAppDir() string {
+	if m.currentConfig != nil {
+		return m.currentConfig.AppDir
+	}
+	if cfg, err := loadOrCreateConfig(); err == nil {
+		return cfg.AppDir
+	}
+	return ""
+}
+
+// initThemePicker opens the theme picker from the configuration menu, with
+// the cursor on the theme in use
+func (m *CLIModel) initThemePicker() {
+	m.themeEntries = availableThemes(m.themeAppDir())
+	m.themeCursor = 0
+	for i, entry := range m.themeEntries {
+		if entry.name == m.currentThemeName() {
+			m.themeCursor = i
+		}
+	}
+	m.themeStatus = ""
+	m.themeFailed = false
+	m.currentView = constants.ThemeView
+}
+
+func (m *CLIModel) currentThemeName() string {
+	if m.themeName == "" {
+		return DefaultTheme
+	}
+	return m.themeName
+}
+
+// closeThemePicker restores the theme in use after a preview and goes back
+// to the configuration menu
+func (m *CLIModel) closeThemePicker() {
+	if set, err := findTheme(m.themeAppDir(), m.currentThemeName()); err == nil {
+		m.usePalette(set)
+	}
+	m.themeEntries = nil
+	m.initConfigMenu()
+}
+
+// updateThemePicker previews the theme under the cursor and stores the one
+// chosen with enter
+func (m *CLIModel) updateThemePicker(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.themeCursor > 0 {
+			m.themeCursor--
+			m.previewTheme()
+		}
+	case "down", "j":
+		if m.themeCursor < len(m.themeEntries)-1 {
+			m.themeCursor++
+			m.previewTheme()
+		}
+	case "r":
+		m.initThemePicker()
+		m.previewTheme()
+	case "enter":
+		m.chooseTheme()
+	}
+	return m, nil
+}
+
+// previewTheme shows the views in the theme under the cursor
+func (m *CLIModel) previewTheme() {
+	if m.themeCursor >= len(m.themeEntries) {
+		return
+	}
+	if entry := m.themeEntries[m.themeCursor]; entry.err == nil {
+		m.usePalette(entry.set)
+	}
+}
+
+// chooseTheme stores the theme under the cursor in config.toml
+func (m *CLIModel) chooseTheme() {
+	if m.themeCursor >= len(m.themeEntries) {
+		return
+	}
+	entry := m.themeEntries[m.themeCursor]
+	if entry.err != nil {
+		m.themeStatus = fmt.Sprintf(localization.Labels["theme_invalid"], entry.path, entry.err)
+		m.themeFailed = true
+		return
+	}
+	if err := updateThemeInConfig(entry.name); err != nil {
+		m.themeStatus = fmt.Sprintf(localization.Labels["theme_save_failed"], err)
+		m.themeFailed = true
+		return
+	}
+	m.themeName = entry.name
+	m.usePalette(entry.set)
+	if m.currentConfig != nil {
+		m.currentConfig.Theme = entry.name
+	}
+	m.themeStatus = fmt.Sprintf(localization.Labels["theme_saved"], entry.name)
+	m.themeFailed = false
+}
+
+// themeSwatch shows the colours of a theme
+func themeSwatch(set themeSet) string {
+	var b strings.Builder
+	for _, color := range []lipgloss.Color{set.Accent, set.AccentAlt, set.Text, set.Muted, set.Surface, set.SelectionBg, set.Success, set.Warning, set.Error, set.Info} {
+		b.WriteString(lipgloss.NewStyle().Foreground(color).Render("■"))
+	}
+	return b.String()
+}
+
+// viewThemePicker lists the themes with their colours
+func (m *CLIModel) viewThemePicker() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["theme_title"]))
+	b.WriteString("\n\n")
+
+	for i, entry := range m.themeEntries {
+		line := fmt.Sprintf("%-16s", entry.name)
+		if entry.err == nil {
+			line += " " + themeSwatch(entry.set)
+		}
+		if entry.path != "" {
+			line += " " + localization.Labels["theme_user"]
+		}
+		if entry.name == m.currentThemeName() {
+			line += " " + localization.Labels["theme_current"]
+		}
+		if i == m.themeCursor {
+			b.WriteString(m.styles.MenuSelected.Render("> " + line))
+		} else {
+			b.WriteString(m.styles.MenuItem.Render("  " + line))
+		}
+		b.WriteString("\n")
+		if entry.err != nil {
+			b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf("    %s %v", glyphs.Warning, entry.err)))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf(localization.Labels["theme_dir"], themesDir(m.themeAppDir()))))
+	b.WriteString("\n")
+
+	if m.themeStatus != "" {
+		b.WriteString("\n")
+		if m.themeFailed {
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.themeStatus))
+		} else {
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.themeStatus))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"os"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThemePicker_PreviewsAndSaves(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddThemeMessages()
+	appDir := t.TempDir()
+	t.Setenv("BLOCO_WALLET_APP_APP_DIR", appDir)
+	previousManager, previousPalette := globalConfigManager, palette
+	globalConfigManager = nil
+	defer func() { globalConfigManager, palette = previousManager, previousPalette }()
+	writeTheme(t, appDir, "ocean", "accent = \"#0088CC\"\n")
+
+	m := &CLIModel{
+		Service:     &wallet.WalletService{},
+		styles:      createStyles(),
+		currentView: constants.ConfigurationView,
+	}
+	require.NoError(t, m.applyTheme(appDir, DefaultTheme))
+	m.initConfigMenu()
+	m.selectedMenu = 9
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, constants.ThemeView, m.currentView)
+	assert.Equal(t, 0, m.themeCursor, "the cursor starts on the theme in use")
+	assert.Contains(t, m.viewThemePicker(), "ocean")
+
+	// Moving the cursor previews the theme; esc restores the one in use
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, builtinThemes["high-contrast"], palette)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.ConfigurationView, m.currentView)
+	assert.Equal(t, builtinThemes[DefaultTheme], palette)
+
+	// enter stores the theme in config.toml
+	m.selectedMenu = 9
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.themeCursor = len(m.themeEntries) - 1
+	require.Equal(t, "ocean", m.themeEntries[m.themeCursor].name)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, m.themeFailed, m.themeStatus)
+	assert.Equal(t, "ocean", m.themeName)
+	assert.Equal(t, "#0088CC", string(palette.Accent))
+
+	data, err := os.ReadFile(getConfigurationManager().GetConfigPath())
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "theme = 'ocean'")
+}
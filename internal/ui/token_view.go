@@ -0,0 +1,398 @@
+package ui
+
+import (
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tokenAddedMsg carries the outcome of following a new token
+type tokenAddedMsg struct {
+	token blockchain.Token
+	err   error
+}
+
+// tokenBalanceMsg carries the balance of the displayed wallet in one token
+type tokenBalanceMsg struct {
+	seq     int
+	balance blockchain.TokenBalance
+	updates <-chan blockchain.TokenBalance
+}
+
+// tokenService returns the service keeping the followed tokens in the wallet
+// repository, creating it on first use
+func (m *CLIModel) tokenService() (*blockchain.TokenService, error) {
+	if m.tokenSvc != nil {
+		return m.tokenSvc, nil
+	}
+	if m.Service == nil {
+		return nil, errors.New(localization.Labels["tokens_unsupported"])
+	}
+	store, ok := m.Service.Repo.(blockchain.TokenStore)
+	if !ok {
+		return nil, errors.New(localization.Labels["tokens_unsupported"])
+	}
+	m.tokenSvc = blockchain.NewTokenService(store)
+	if m.currentConfig != nil && m.currentConfig.AppDir != "" {
+		_ = m.tokenSvc.LoadSpamList(filepath.Join(m.currentConfig.AppDir, blockchain.SpamListFileName))
+	}
+	return m.tokenSvc, nil
+}
+
+// networkTokens lists the tokens followed on network that are not hidden as
+// spam; none when they cannot be read
+func (m *CLIModel) networkTokens(network config.Network) []blockchain.Token {
+	service, err := m.tokenService()
+	if err != nil {
+		return nil
+	}
+	tokens, err := service.VisibleTokens(network.ChainID)
+	if err != nil {
+		return nil
+	}
+	return tokens
+}
+
+// fetchTokenBalances starts reading the balances of the displayed wallet in
+// the tokens followed on m.balanceNetworks; it shares the sequence of the
+// native balances
+func (m *CLIModel) fetchTokenBalances() tea.Cmd {
+	service, err := m.tokenService()
+	if err != nil || len(m.balanceNetworks) == 0 {
+		return nil
+	}
+	updates := service.Balances(context.Background(), m.walletDetails.Wallet.Address, m.balanceNetworks)
+	return waitForTokenBalance(m.balanceSeq, updates)
+}
+
+// waitForTokenBalance delivers the next token balance to arrive
+func waitForTokenBalance(seq int, updates <-chan blockchain.TokenBalance) tea.Cmd {
+	return func() tea.Msg {
+		balance, ok := <-updates
+		if !ok {
+			return nil
+		}
+		return tokenBalanceMsg{seq: seq, balance: balance, updates: updates}
+	}
+}
+
+// handleTokenBalance shows one token balance and waits for the next
+func (m *CLIModel) handleTokenBalance(msg tokenBalanceMsg) tea.Cmd {
+	if msg.seq != m.balanceSeq {
+		return nil
+	}
+	key := msg.balance.NetworkKey
+	m.tokenBalances[key] = append(m.tokenBalances[key], msg.balance)
+	return waitForTokenBalance(msg.seq, msg.updates)
+}
+
+// initTokens opens the list of followed tokens
+func (m *CLIModel) initTokens() {
+	m.tokenInput = textinput.New()
+	m.tokenInput.Placeholder = "0x…"
+	m.tokenInput.CharLimit = 42
+	m.tokenInput.Width = 44
+	m.tokenCursor = 0
+	m.tokenAdding = false
+	m.tokenBusy = false
+	m.tokenReview = false
+	m.setTokenResult("", false)
+	m.loadTokens()
+	m.currentView = constants.TokensView
+}
+
+// loadTokens reads the tokens of the usable networks, sorted by network: the
+// visible ones, or under review the ones flagged as spam or reviewed before
+func (m *CLIModel) loadTokens() {
+	m.tokenList = nil
+	m.tokenHidden = 0
+	service, err := m.tokenService()
+	if err != nil {
+		m.setTokenResult(err.Error(), true)
+		return
+	}
+	for _, network := range m.usableNetworks() {
+		tokens, err := service.Tokens(network.ChainID)
+		if err != nil {
+			continue
+		}
+		for _, t := range tokens {
+			visible := service.Visible(t)
+			if !visible {
+				m.tokenHidden++
+			}
+			reviewable := t.Status != "" || service.SpamReason(t) != ""
+			if (m.tokenReview && reviewable) || (!m.tokenReview && visible) {
+				m.tokenList = append(m.tokenList, t)
+			}
+		}
+	}
+	if m.tokenCursor >= len(m.tokenList) {
+		m.tokenCursor = max(len(m.tokenList)-1, 0)
+	}
+}
+
+func (m *CLIModel) setTokenResult(status string, failed bool) {
+	m.tokenStatus = status
+	m.tokenFailed = failed
+}
+
+// closeTokenAdd discards the token being added and stays on the list
+func (m *CLIModel) closeTokenAdd() {
+	m.tokenAdding = false
+	m.tokenInput.Blur()
+	m.tokenInput.SetValue("")
+}
+
+// closeTokenReview leaves the review of the hidden tokens for the list
+func (m *CLIModel) closeTokenReview() {
+	m.tokenReview = false
+	m.tokenCursor = 0
+	m.setTokenResult("", false)
+	m.loadTokens()
+}
+
+// reviewToken allows or blocks the selected token, or returns it to the spam
+// detection when status is empty
+func (m *CLIModel) reviewToken(status string) {
+	if m.tokenCursor >= len(m.tokenList) {
+		return
+	}
+	token := m.tokenList[m.tokenCursor]
+	service, err := m.tokenService()
+	if err == nil {
+		err = service.SetStatus(token.ChainID, token.Address, status)
+	}
+	if err != nil {
+		m.setTokenResult(err.Error(), true)
+		return
+	}
+	key := "tokens_reset"
+	switch status {
+	case blockchain.TokenStatusAllowed:
+		key = "tokens_allowed"
+	case blockchain.TokenStatusBlocked:
+		key = "tokens_blocked"
+	}
+	m.setTokenResult(fmt.Sprintf(localization.Labels[key], token.Symbol), false)
+	m.loadTokens()
+}
+
+// tokenReviewState describes why a token is hidden or shown under review
+func (m *CLIModel) tokenReviewState(token blockchain.Token) string {
+	switch token.Status {
+	case blockchain.TokenStatusAllowed:
+		return localization.Labels["tokens_state_allowed"]
+	case blockchain.TokenStatusBlocked:
+		return localization.Labels["tokens_state_blocked"]
+	}
+	if service, err := m.tokenService(); err == nil {
+		if reason := service.SpamReason(token); reason != "" {
+			return localization.Labels["tokens_spam_"+string(reason)]
+		}
+	}
+	return ""
+}
+
+// updateTokens handles the token list and the form to follow a new one
+func (m *CLIModel) updateTokens(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.tokenBusy {
+		return m, nil
+	}
+
+	if m.tokenAdding {
+		networks := m.usableNetworks()
+		switch keyMsg.String() {
+		case "left", "right":
+			if len(networks) > 0 {
+				step := 1
+				if keyMsg.String() == "left" {
+					step = -1
+				}
+				m.tokenNetwork = (m.tokenNetwork + step + len(networks)) % len(networks)
+			}
+			return m, nil
+		case "enter":
+			if m.tokenNetwork >= len(networks) {
+				m.setTokenResult(localization.Labels["send_no_networks"], true)
+				return m, nil
+			}
+			return m, m.addToken(networks[m.tokenNetwork], strings.TrimSpace(m.tokenInput.Value()))
+		}
+		var cmd tea.Cmd
+		m.tokenInput, cmd = updateTextInput(m.tokenInput, msg)
+		return m, cmd
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.tokenCursor > 0 {
+			m.tokenCursor--
+		}
+	case "down", "j":
+		if m.tokenCursor < len(m.tokenList)-1 {
+			m.tokenCursor++
+		}
+	case "r":
+		if !m.tokenReview {
+			m.tokenReview = true
+			m.tokenCursor = 0
+			m.setTokenResult("", false)
+			m.loadTokens()
+		}
+	case "b":
+		m.reviewToken(blockchain.TokenStatusBlocked)
+	case "u":
+		if m.tokenReview {
+			m.reviewToken(blockchain.TokenStatusAllowed)
+		}
+	case "c":
+		if m.tokenReview {
+			m.reviewToken("")
+		}
+	case "a":
+		if m.tokenReview {
+			return m, nil
+		}
+		if _, err := m.tokenService(); err == nil {
+			m.tokenAdding = true
+			m.tokenNetwork = 0
+			m.tokenInput.SetValue("")
+			m.tokenInput.Focus()
+			m.setTokenResult("", false)
+		}
+	case "d", "delete":
+		if m.tokenCursor < len(m.tokenList) && !m.tokenReview {
+			token := m.tokenList[m.tokenCursor]
+			service, err := m.tokenService()
+			if err == nil {
+				err = service.RemoveToken(token.ChainID, token.Address)
+			}
+			if err != nil {
+				m.setTokenResult(err.Error(), true)
+				return m, nil
+			}
+			m.setTokenResult(fmt.Sprintf(localization.Labels["tokens_removed"], token.Symbol), false)
+			m.loadTokens()
+		}
+	}
+	return m, nil
+}
+
+// addToken reads the symbol and decimals of the contract and follows it
+func (m *CLIModel) addToken(network config.Network, address string) tea.Cmd {
+	service, err := m.tokenService()
+	if err != nil {
+		m.setTokenResult(err.Error(), true)
+		return nil
+	}
+	contract, err := m.readAddress(address, localization.Labels["address_invalid"])
+	if err != nil {
+		m.setTokenResult(err.Error(), true)
+		return nil
+	}
+	if m.walletDetails != nil {
+		network = m.Service.NetworkFor(m.walletDetails.Wallet.Address, network)
+	}
+	m.tokenBusy = true
+	m.setTokenResult(fmt.Sprintf(localization.Labels["tokens_reading"], network.Name), false)
+	return func() tea.Msg {
+		token, err := service.AddToken(context.Background(), network, contract.Hex())
+		return tokenAddedMsg{token: token, err: err}
+	}
+}
+
+// handleTokenAdded shows the token just followed, or why it was refused
+func (m *CLIModel) handleTokenAdded(msg tokenAddedMsg) {
+	if !m.tokenBusy {
+		return
+	}
+	m.tokenBusy = false
+	if msg.err != nil {
+		m.setTokenResult(fmt.Sprintf(localization.Labels["tokens_add_failed"], msg.err), true)
+		return
+	}
+	m.closeTokenAdd()
+	m.setTokenResult(fmt.Sprintf(localization.Labels["tokens_added"], msg.token.Symbol, msg.token.Decimals), false)
+	m.loadTokens()
+}
+
+// viewTokens renders the followed tokens and the form to add one
+func (m *CLIModel) viewTokens() string {
+	var b strings.Builder
+	title, desc, none := "tokens_title", "tokens_desc", "tokens_none"
+	if m.tokenReview {
+		title, desc, none = "tokens_review_title", "tokens_review_desc", "tokens_review_none"
+	}
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels[title]))
+	b.WriteString("\n\n")
+	b.WriteString(m.styles.MenuDesc.Render(localization.Labels[desc]))
+	b.WriteString("\n\n")
+
+	names := make(map[int64]string)
+	networks := m.usableNetworks()
+	for _, n := range networks {
+		names[n.ChainID] = n.Name
+	}
+	if len(m.tokenList) == 0 {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels[none]))
+		b.WriteString("\n")
+	}
+	for i, t := range m.tokenList {
+		cursor := "  "
+		if i == m.tokenCursor && !m.tokenAdding {
+			cursor = "> "
+		}
+		line := fmt.Sprintf("%s%-20s %-10s %s  (%d)", cursor, names[t.ChainID], truncateRunes(t.Symbol, 10), t.Address, t.Decimals)
+		if m.tokenReview {
+			line += "  " + m.tokenReviewState(t)
+		}
+		b.WriteString(line + "\n")
+	}
+	if !m.tokenReview && m.tokenHidden > 0 {
+		b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf(localization.Labels["tokens_hidden"], m.tokenHidden)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if m.tokenAdding {
+		networkName := localization.Labels["send_no_networks"]
+		if m.tokenNetwork < len(networks) {
+			networkName = "< " + networks[m.tokenNetwork].Name + " >"
+		}
+		b.WriteString(fmt.Sprintf("%-24s %s\n", localization.Labels["send_network"], networkName))
+		b.WriteString(fmt.Sprintf("%-24s %s\n\n", localization.Labels["tokens_contract"], m.tokenInput.View()))
+	}
+
+	if m.tokenStatus != "" {
+		switch {
+		case m.tokenBusy:
+			b.WriteString(m.styles.MenuDesc.Render(m.tokenStatus))
+		case m.tokenFailed:
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.tokenStatus))
+		default:
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.tokenStatus))
+		}
+		b.WriteString("\n\n")
+	}
+
+	help := localization.Labels["tokens_help"]
+	switch {
+	case m.tokenAdding:
+		help = localization.Labels["tokens_help_add"]
+	case m.tokenReview:
+		help = localization.Labels["tokens_help_review"]
+	}
+	b.WriteString(m.styles.MenuDesc.Render(help))
+	return b.String()
+}
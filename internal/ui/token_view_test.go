@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTokenRPCServer answers like a node with a USDC-like token (6 decimals)
+// in which every account holds 2.5
+func newTokenRPCServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	word := func(v uint64) string { return fmt.Sprintf("%064x", v) }
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		result := "0x0"
+		if req.Method == "eth_call" {
+			var call struct {
+				Data  string `json:"data"`
+				Input string `json:"input"`
+			}
+			require.NoError(t, json.Unmarshal(req.Params[0], &call))
+			data := call.Input + call.Data
+			switch {
+			case strings.HasPrefix(data, "0x313ce567"): // decimals()
+				result = "0x" + word(6)
+			case strings.HasPrefix(data, "0x95d89b41"): // symbol()
+				result = "0x" + word(32) + word(4) + fmt.Sprintf("%-64s", "55534443")
+				result = strings.ReplaceAll(result, " ", "0")
+			case strings.HasPrefix(data, "0x70a08231"): // balanceOf(address)
+				result = "0x" + word(2_500_000)
+			}
+		}
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%q}`, req.ID, result)
+	}))
+}
+
+func TestTokens_FollowShowAndSend(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddBalanceMessages()
+	localization.AddTokenMessages()
+	localization.AddSendMessages()
+	localization.AddSignTxMessages()
+
+	server := newTokenRPCServer(t)
+	defer server.Close()
+	dir := t.TempDir()
+	repo, err := storage.NewWalletRepository(&config.Config{AppDir: dir, DatabasePath: filepath.Join(dir, "wallets.db")})
+	require.NoError(t, err)
+	defer repo.Close()
+
+	m := &CLIModel{Service: &wallet.WalletService{Repo: repo}, styles: createStyles(), currentView: constants.WalletDetailsView}
+	m.walletDetails = &wallet.WalletDetails{Wallet: &wallet.Wallet{Name: "Hot", Address: "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"}}
+	m.currentConfig = &config.Config{Networks: map[string]config.Network{
+		"ethereum": {Name: "Ethereum", ChainID: 1, Symbol: "ETH", RPCEndpoint: server.URL, IsActive: true},
+	}}
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	require.Equal(t, constants.TokensView, m.currentView)
+	assert.Contains(t, m.viewTokens(), "No tokens followed yet")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	require.True(t, m.capturesTextInput())
+	m.tokenInput.SetValue("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	_, _ = m.Update(cmd())
+	require.False(t, m.tokenFailed, m.tokenStatus)
+	require.Len(t, m.tokenList, 1)
+	assert.Equal(t, blockchain.Token{ID: m.tokenList[0].ID, ChainID: 1, Address: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Symbol: "USDC", Decimals: 6, CreatedAt: m.tokenList[0].CreatedAt}, m.tokenList[0])
+
+	// Back in the details the token balance is read with the native one
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.Equal(t, constants.WalletDetailsView, m.currentView)
+	drainBalances(m, cmd)
+	assert.Contains(t, m.renderWalletBalances(), "2.500000 USDC")
+
+	// Sending picks the token as the asset, in its own decimals
+	m.initSendTransaction()
+	m.setSendFocus(sendFocusAsset)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	assert.Contains(t, m.viewSendTransaction(), "< USDC >")
+	m.sendInputs[sendFocusTo].SetValue("0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0")
+	m.sendInputs[sendFocusAmount].SetValue("1.5")
+	_, amount, _, err := m.sendForm()
+	require.NoError(t, err)
+	assert.Equal(t, "1500000", amount.String())
+
+	m.sendInputs[sendFocusAmount].SetValue("3")
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	_, _ = m.Update(cmd())
+	assert.True(t, m.sendFailed)
+	assert.Contains(t, m.sendStatus, "insufficient token balance")
+
+	// Removing the token
+	m.initTokens()
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	assert.Empty(t, m.tokenList)
+}
+
+func TestTokens_SpamReview(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddTokenMessages()
+
+	dir := t.TempDir()
+	repo, err := storage.NewWalletRepository(&config.Config{AppDir: dir, DatabasePath: filepath.Join(dir, "wallets.db")})
+	require.NoError(t, err)
+	defer repo.Close()
+	usdc := blockchain.Token{ChainID: 1, Address: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Symbol: "USDC", Decimals: 6}
+	airdrop := blockchain.Token{ChainID: 1, Address: "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0", Symbol: "Visit usdc-claim.xyz", Decimals: 0}
+	require.NoError(t, repo.AddToken(&usdc))
+	require.NoError(t, repo.AddToken(&airdrop))
+
+	m := &CLIModel{Service: &wallet.WalletService{Repo: repo}, styles: createStyles()}
+	m.currentConfig = &config.Config{AppDir: dir, Networks: map[string]config.Network{
+		"ethereum": {Name: "Ethereum", ChainID: 1, Symbol: "ETH", RPCEndpoint: "http://localhost", IsActive: true},
+	}}
+	m.initTokens()
+
+	// The airdropped token is hidden; the review lists it with the reason
+	require.Len(t, m.tokenList, 1)
+	assert.Equal(t, "USDC", m.tokenList[0].Symbol)
+	assert.Contains(t, m.viewTokens(), "1 tokens hidden as spam or blocked")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	require.True(t, m.tokenReview)
+	require.Len(t, m.tokenList, 1)
+	assert.Contains(t, m.viewTokens(), "symbol advertises a site")
+
+	// Unhiding it shows it in the list and in the assets to send
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	assert.Contains(t, m.viewTokens(), "unhidden")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.False(t, m.tokenReview)
+	assert.Len(t, m.tokenList, 2)
+	assert.Len(t, m.networkTokens(m.currentConfig.Networks["ethereum"]), 2)
+
+	// Blocking USDC hides it for good, and it cannot be followed again
+	require.Equal(t, "USDC", m.tokenList[0].Symbol)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	assert.Equal(t, "USDC is blocked", m.tokenStatus)
+	require.Len(t, m.tokenList, 1)
+	_, err = m.tokenSvc.AddToken(t.Context(), m.currentConfig.Networks["ethereum"], usdc.Address)
+	assert.ErrorIs(t, err, blockchain.ErrTokenBlocked)
+
+	// The review clears back to the spam detection
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	require.Len(t, m.tokenList, 2)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	assert.Len(t, m.tokenList, 1, "only the airdrop stays flagged")
+}
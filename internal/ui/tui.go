@@ -2,11 +2,13 @@ package ui
 
 import (
 	"blocowallet/internal/constants"
+	"blocowallet/internal/jobs"
+	"blocowallet/internal/sandbox"
 	"blocowallet/internal/wallet"
 	"blocowallet/pkg/localization"
 	"blocowallet/pkg/logger"
-	"bytes"
 	"fmt"
+	"io"
 	"log"
 	"math/rand"
 	"os"
@@ -14,8 +16,8 @@ import (
 	"strings"
 	"time"
 
-	"github.com/arsham/figurine/figurine"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -33,6 +35,10 @@ func determineWalletType(w wallet.Wallet) string {
 		return localization.Labels["imported_private_key"]
 	case wallet.ImportMethodKeystore:
 		return localization.Labels["imported_keystore"]
+	case wallet.ImportMethodDerived:
+		return localization.Labels["derived_child"]
+	case wallet.ImportMethodWatchOnly:
+		return localization.Labels["watch_only"]
 	default:
 		// Fallback to old logic for backward compatibility with wallets missing ImportMethod
 		if w.Mnemonic == nil {
@@ -79,11 +85,15 @@ type splashMsg struct{}
 func NewCLIModel(service *wallet.WalletService) *CLIModel {
 	model := &CLIModel{
 		Service:      service,
+		capabilities: wallet.Capabilities(service),
 		currentView:  constants.SplashView,
-		menuItems:    NewMenu(),
 		selectedMenu: 0,
 		styles:       createStyles(),
+		jobManager:   jobs.NewManager(),
+		session:      sessionStats{startedAt: time.Now()},
 	}
+	model.menuItems = model.mainMenu()
+	initializeTheme(model)
 
 	if err := initializeFont(model); err != nil {
 		model.err = err
@@ -246,6 +256,10 @@ func (m *CLIModel) Init() tea.Cmd {
 	return tea.Batch(
 		splashCmd(),
 		walletCountCmd(m.Service),
+		m.enforcePermissionsCmd(),
+		loadInheritancePlanCmd(),
+		proxyCheckCmd(),
+		configWatchCmd(),
 	)
 }
 
@@ -255,28 +269,182 @@ func splashCmd() tea.Cmd {
 	})
 }
 
+// enterMainMenu transita para o menu principal, mostrando antes as novidades
+// da atualização e os avisos de segurança
+func (m *CLIModel) enterMainMenu() tea.Cmd {
+	m.currentView = constants.DefaultView
+	if len(m.releaseNotes) > 0 {
+		// Mostrar as novidades da atualização antes do menu
+		m.currentView = constants.WhatsNewView
+	}
+	if len(m.permissionIssues) > 0 || len(m.quarantinedNetworks) > 0 || len(m.integrityIssues) > 0 {
+		m.openSecurityWarning()
+	}
+	// Buscar a quantidade de wallets e retomar o acompanhamento de transações
+	return tea.Batch(walletCountCmd(m.Service), m.startPendingPolling())
+}
+
 func (m *CLIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if msg == nil {
 		return m, nil
 	}
+	// Contar os erros exibidos para o resumo da sessão
+	defer func() { m.session.noteError(m.err) }()
 
 	// Tratar as teclas de navegação global (esc/backspace) antes de qualquer outro processamento
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.kdfOp != nil {
+		// Enquanto a chave é derivada, só esc após o limite de espera tem efeito
+		m.handleKDFKey(keyMsg)
+		return m, nil
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.paletteOpen {
+		// A paleta de comandos recebe todas as teclas enquanto está aberta
+		return m.updatePalette(keyMsg)
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "ctrl+p" && m.paletteAvailable() {
+		return m, m.openPalette()
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.helpOpen {
+		// Com a ajuda aberta, "?" ou esc a fecham e as demais teclas são ignoradas
+		if s := keyMsg.String(); s == "?" || s == "esc" {
+			m.helpOpen = false
+		}
+		return m, nil
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "?" && m.helpAvailable() {
+		m.helpOpen = true
+		return m, nil
+	}
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		// Avisos de entrada valem apenas até a próxima tecla
+		m.inputNotice = ""
+
 		switch keyMsg.String() {
 		case "esc":
 			// Se estiver na tela de lista de wallets e tiver um diálogo de exclusão aberto,
 			// não faça nada aqui e deixe o handler específico da view tratar
 			if m.currentView == constants.ListWalletsView && m.deletingWallet != nil {
 				// Não faz nada, deixa o handler específico tratar
+			} else if m.currentView == constants.ListWalletsView && m.exportingReport {
+				// Fechar o campo do relatório sem gravar
+				m.closeWalletReport()
+				return m, nil
+			} else if m.currentView == constants.ListWalletsView && (m.filteringWallets || m.walletFilter.Value() != "") {
+				// Limpar a busca antes de sair da lista
+				m.clearWalletSearch()
+				return m, nil
+			} else if m.currentView == constants.ListWalletsView && len(m.batchExportMarked) > 0 {
+				// Desmarcar as wallets antes de sair da lista
+				m.clearWalletMarks()
+				return m, nil
+			} else if m.currentView == constants.NetworkListView && m.networkDeleteKey != "" {
+				// Manter a rede e fechar a confirmação
+				m.closeNetworkDelete()
+				return m, nil
+			} else if m.currentView == constants.AddNetworkView && m.editingNetworkKey != "" {
+				// Descartar a edição e voltar para a lista de redes
+				m.closeNetworkEdit()
+				return m, nil
 			} else if m.currentView != constants.DefaultView && m.currentView != constants.SplashView {
 				// Para a maioria das telas, voltar para o menu principal
-				if m.currentView == constants.WalletDetailsView {
+				if m.currentView == constants.InheritanceView && m.inheritanceMode != inheritanceModeOverview {
+					// Fechar o formulário aberto e permanecer no plano
+					m.closeInheritanceForm()
+				} else if m.currentView == constants.WalletRPCView && m.rpcEditing {
+					// Descartar o endpoint em edição e permanecer na lista de redes
+					m.closeWalletRPCEdit()
+				} else if m.currentView == constants.TokensView && m.tokenAdding {
+					// Descartar o token em edição e permanecer na lista
+					m.closeTokenAdd()
+				} else if m.currentView == constants.TokensView && m.tokenReview {
+					// Sair da revisão dos tokens ocultos e voltar para a lista
+					m.closeTokenReview()
+				} else if m.currentView == constants.TokensView {
+					// Voltar para os detalhes, com os saldos dos tokens atualizados
+					m.currentView = constants.WalletDetailsView
+					return m, m.fetchBalances(false)
+				} else if m.currentView == constants.SecureExportView || m.currentView == constants.ChildWalletsView || m.currentView == constants.SignTransactionView || m.currentView == constants.SendTransactionView || m.currentView == constants.WalletRPCView {
+					// Voltar para os detalhes da wallet sendo exportada, derivada, usada para assinar ou enviar, ou com endpoints próprios
+					m.currentView = constants.WalletDetailsView
+				} else if m.currentView == constants.WalletDetailsView {
 					// Comportamento específico para tela de detalhes: voltar para lista de wallets
 					m.walletDetails = nil
 					m.currentView = constants.ListWalletsView
+				} else if m.currentView == constants.WalletIntegrityView {
+					// Voltar para a lista, recarregada caso a carteira tenha ido para a quarentena
+					m.closeIntegrityWarning()
+					return m, m.refreshWalletsTable()
+				} else if m.currentView == constants.BatchCreateView && m.batchRunning {
+					// Parar o lote; as carteiras já criadas são mantidas
+					m.cancelBatchCreate()
+				} else if m.currentView == constants.BatchSendView && (m.batchSendStage == batchSendStageUnlock || m.batchSendStage == batchSendStageRunning) {
+					// Parar o envio após a transferência atual; as já enviadas seguem rastreadas
+					return m, m.cancelBatchSend()
+				} else if m.currentView == constants.EnhancedImportView && m.enhancedImportState != nil && m.enhancedImportState.ExportingReport() {
+					// Fechar o campo do caminho do relatório da importação
+					m.enhancedImportState.CancelReportExport()
+				} else if m.currentView == constants.EnhancedImportView && m.enhancedImportState != nil && m.enhancedImportState.InErrorDetails() {
+					// Fechar o formulário de nova tentativa ou voltar ao resumo da importação
+					m.enhancedImportState.BackFromErrorDetails()
+				} else if m.currentView == constants.EnhancedImportView && m.enhancedImportState != nil && m.enhancedImportState.ReviewingDuplicates() {
+					// Voltar à seleção de arquivos sem importar
+					m.enhancedImportState.CancelDuplicateReview()
+				} else if m.currentView == constants.EnhancedImportView && m.enhancedImportState != nil && m.enhancedImportState.IsPasting() {
+					// Fechar o modo de colar e voltar à escolha de arquivos
+					m.enhancedImportState.ClosePaste()
+				} else if m.currentView == constants.BatchExportView {
+					// Parar de pedir senhas, cancelar a exportação em curso ou voltar para a lista
+					return m, m.closeBatchExport()
+				} else if m.currentView == constants.WalletBatchView {
+					// Cancelar o lote em curso ou voltar para a lista
+					return m, m.closeWalletBatch()
+				} else if m.currentView == constants.NetworkImportView {
+					// Cancelar a importação em curso ou voltar ao menu de redes
+					m.closeNetworkImport()
+					return m, nil
+				} else if m.currentView == constants.MetamaskImportView {
+					// Cancelar a importação em curso ou voltar ao menu de importação
+					m.closeMetamaskImport()
+					return m, nil
+				} else if m.currentView == constants.ChildDiscoveryView {
+					// Parar a busca ou seguir para a carteira importada sem registrar
+					m.skipChildDiscovery()
+				} else if m.currentView == constants.PendingTransactionsView && m.pendingAction != "" {
+					// Desistir da aceleração ou do cancelamento
+					m.closePendingAction()
+				} else if m.currentView == constants.WalletConnectView && m.wcUnlocking {
+					// Desistir de aprovar o pedido; ele continua na fila
+					m.closeWCPassword()
+				} else if m.currentView == constants.WalletNotesView {
+					// Descartar as alterações e voltar para a lista
+					m.closeWalletNotes()
+				} else if m.currentView == constants.MasterPasswordView {
+					// Recusar a oferta inicial, sair sem desbloquear ou voltar ao menu
+					return m.closeMasterPassword()
+				} else if m.currentView == constants.LogViewerView {
+					// Voltar ao menu de configuração
+					m.closeLogViewer()
+				} else if m.currentView == constants.StoreCheckView {
+					// Fechar a reimportação ou voltar ao menu de configuração
+					m.closeStoreCheck()
+				} else if m.currentView == constants.ConfigEditorView {
+					// Descartar as alterações e voltar ao menu de configuração
+					m.closeConfigEditor()
+				} else if m.currentView == constants.ProfilesView {
+					// Fechar o formulário de novo perfil ou voltar ao menu de configuração
+					m.closeProfiles()
+				} else if m.currentView == constants.ThemeView {
+					// Desfazer a pré-visualização e voltar ao menu de configuração
+					m.closeThemePicker()
+				} else if m.currentView == constants.KeystoreOverwriteView {
+					// Manter a keystore atual e voltar ao menu principal
+					m.closeKeystoreOverwrite()
+				} else if m.currentView == constants.ImportPrivateKeyView && m.privateKeyPreview != nil {
+					// Voltar a editar a chave privada
+					m.privateKeyPreview = nil
 				} else {
 					// Comportamento padrão: voltar ao menu principal
-					m.menuItems = NewMenu()
+					m.menuItems = m.mainMenu()
 					m.selectedMenu = 0
 					m.currentView = constants.DefaultView
 				}
@@ -284,8 +452,8 @@ func (m *CLIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 		case "q":
-			if m.currentView != constants.SplashView {
-				return m, tea.Quit
+			if m.currentView != constants.SplashView && !m.capturesTextInput() {
+				return m.quit()
 			}
 		}
 	}
@@ -305,17 +473,149 @@ func (m *CLIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case walletsRefreshedMsg:
-		// Apenas retornar o modelo sem fazer nada, pois a atualização já foi feita
-		// Isso evita que a tela inteira seja redesenhada
-		return m, nil
+	case walletsRefreshDueMsg, walletsLoadedMsg:
+		// Recargas de wallets passam pelo agendador para coalescer rajadas
+		cmd, _ := m.handleWalletsRefresh(msg)
+		return m, cmd
 
 	case splashMsg:
-		// Transitar para o menu principal após a splash screen
-		m.currentView = constants.DefaultView
-		// Iniciar o comando para buscar a quantidade de wallets
-		return m, walletCountCmd(m.Service)
+		// Pedir ou oferecer a senha mestra antes do menu principal
+		if m.openMasterPasswordAtStart() {
+			return m, nil
+		}
+		return m, m.enterMainMenu()
+	case masterPasswordDoneMsg:
+		return m, m.handleMasterPasswordDone(msg)
+	case permissionReportMsg:
+		m.handlePermissionReport(msg.report)
+		return m, m.notifyPermissionIssues(msg.report.Issues)
+	case inheritancePlanLoadedMsg:
+		m.handleInheritancePlanLoaded(msg)
+		return m, nil
+	case approvalResultMsg:
+		return m, m.handleApprovalResult(msg)
+	case syncDoneMsg:
+		return m, m.handleSyncDone(msg)
+	case backupDoneMsg:
+		return m, m.handleBackupDone(msg)
+	case batchCreateStepMsg:
+		return m, m.handleBatchCreateStep(msg)
+	case childScanStepMsg:
+		return m, m.handleChildScanStep(msg)
+	case signFillMsg:
+		m.handleSignFill(msg)
+		return m, nil
+	case broadcastResultMsg:
+		return m, m.handleBroadcastResult(msg)
+	case sendPreparedMsg:
+		m.handleSendPrepared(msg)
+		return m, nil
+	case forkResultMsg:
+		m.handleForkResult(msg)
+		return m, nil
+	case sendResultMsg:
+		return m, m.handleSendResult(msg)
+	case batchPlanMsg:
+		m.handleBatchPlan(msg)
+		return m, nil
+	case batchUnlockMsg:
+		return m, m.handleBatchUnlock(msg)
+	case batchSendStepMsg:
+		return m, m.handleBatchSendStep(msg)
+	case batchExportUnlockMsg:
+		return m, m.handleBatchExportUnlock(msg)
+	case batchExportProgressMsg:
+		return m, m.handleBatchExportProgress(msg)
+	case batchExportDoneMsg:
+		m.handleBatchExportDone(msg)
+		return m, nil
+	case walletBatchStepMsg:
+		return m, m.handleWalletBatchStep(msg)
+	case walletBatchDoneMsg:
+		m.handleWalletBatchDone(msg)
+		return m, nil
+	case networkImportStepMsg:
+		return m, m.handleNetworkImportStep(msg)
+	case networkImportDoneMsg:
+		m.handleNetworkImportDone(msg)
+		return m, nil
+	case metamaskReadMsg:
+		return m, m.handleMetamaskRead(msg)
+	case metamaskImportStepMsg:
+		return m, m.handleMetamaskImportStep(msg)
+	case metamaskImportDoneMsg:
+		return m, m.handleMetamaskImportDone(msg)
+	case balanceUpdateMsg:
+		return m, m.handleBalanceUpdate(msg)
+	case tokenBalanceMsg:
+		return m, m.handleTokenBalance(msg)
+	case tokenAddedMsg:
+		m.handleTokenAdded(msg)
+		return m, nil
+	case txPreviewMsg:
+		m.handleTxPreview(msg)
+		return m, nil
+	case signaturesFetchedMsg:
+		m.handleSignaturesFetched(msg)
+		return m, nil
+	case safeFetchedMsg:
+		m.handleSafeFetched(msg)
+		return m, nil
+	case proxyCheckMsg:
+		return m, proxyCheckCmd()
+	case proxyCheckedMsg:
+		return m, m.handleProxyChecked(msg)
+	case networkVerifiedMsg:
+		m.handleNetworkVerified(msg)
+		return m, nil
+	case pendingTickMsg:
+		return m, m.handlePendingTick(msg)
+	case pendingPolledMsg:
+		return m, m.handlePendingPolled(msg)
+	case pendingReplacedMsg:
+		return m, m.handlePendingReplaced(msg)
+	case wcEventMsg:
+		return m, m.handleWCEvent(msg)
+	case wcPairedMsg:
+		m.handleWCPaired(msg)
+		return m, nil
+	case wcApprovedMsg:
+		m.handleWCApproved(msg)
+		return m, nil
+	case wcRespondedMsg:
+		return m, m.handleWCResponded(msg)
+	case wcReconnectMsg:
+		return m, m.handleWCReconnect(msg)
+	case wcDisconnectedMsg:
+		m.handleWCDisconnected(msg)
+		return m, nil
+	case clipboardTickMsg:
+		return m, m.handleClipboardTick(msg)
+	case logTickMsg:
+		return m, m.handleLogTick(msg)
+	case configWatchMsg:
+		return m, m.handleConfigWatch()
+	case profileOpenedMsg:
+		return m, m.handleProfileOpened(msg)
+	case kdfTickMsg:
+		return m, m.handleKDFTick(msg)
+	case kdfDoneMsg:
+		return m, m.handleKDFDone(msg)
+	case pasteRejectedMsg:
+		m.inputNotice = localization.Labels["paste_multiline_rejected"]
+		return m, nil
+
+	case jobsTickMsg:
+		// Manter a tela de Jobs atualizada enquanto estiver aberta
+		if m.currentView == constants.JobsView {
+			return m, jobsTickCmd()
+		}
+		return m, nil
 	case walletCountMsg:
+		if errors.Is(msg.err, wallet.ErrMasterPasswordLocked) {
+			// A contagem é refeita ao desbloquear
+			return m, nil
+		}
 		if msg.err != nil {
 			m.err = msg.err
 			log.Println("Erro ao buscar a quantidade de wallets:", msg.err)
@@ -372,6 +672,74 @@ func (m *CLIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.updateNetworkList(msg)
 	case constants.AddNetworkView:
 		return m.updateAddNetwork(msg)
+	case constants.JobsView:
+		return m.updateJobs(msg)
+	case constants.SecurityWarningView:
+		return m.updateSecurityWarning(msg)
+	case constants.SecureExportView:
+		return m.updateSecureExport(msg)
+	case constants.InheritanceView:
+		return m.updateInheritance(msg)
+	case constants.SyncView:
+		return m.updateSync(msg)
+	case constants.WalletIntegrityView:
+		return m.updateWalletIntegrity(msg)
+	case constants.WalletNotesView:
+		return m.updateWalletNotes(msg)
+	case constants.BatchCreateView:
+		return m.updateBatchCreate(msg)
+	case constants.ChildWalletsView:
+		return m.updateChildWallets(msg)
+	case constants.WalletRPCView:
+		return m.updateWalletRPC(msg)
+	case constants.SafeImportView:
+		return m.updateSafeImport(msg)
+	case constants.ChildDiscoveryView:
+		return m.updateChildDiscovery(msg)
+	case constants.SignTransactionView:
+		return m.updateSignTransaction(msg)
+	case constants.SendTransactionView:
+		return m.updateSendTransaction(msg)
+	case constants.BroadcastView:
+		return m.updateBroadcast(msg)
+	case constants.PendingTransactionsView:
+		return m.updatePendingTransactions(msg)
+	case constants.AboutView:
+		return m.updateAbout(msg)
+	case constants.TokensView:
+		return m.updateTokens(msg)
+	case constants.WhatsNewView:
+		return m.updateWhatsNew(msg)
+	case constants.BatchSendView:
+		return m.updateBatchSend(msg)
+	case constants.BatchExportView:
+		return m.updateBatchExport(msg)
+	case constants.WalletConnectView:
+		return m.updateWalletConnect(msg)
+	case constants.KeystoreOverwriteView:
+		return m.updateKeystoreOverwrite(msg)
+	case constants.SessionSummaryView:
+		return m.updateSessionSummary(msg)
+	case constants.BackupView:
+		return m.updateBackup(msg)
+	case constants.MasterPasswordView:
+		return m.updateMasterPassword(msg)
+	case constants.WalletBatchView:
+		return m.updateWalletBatch(msg)
+	case constants.NetworkImportView:
+		return m.updateNetworkImport(msg)
+	case constants.MetamaskImportView:
+		return m.updateMetamaskImport(msg)
+	case constants.LogViewerView:
+		return m.updateLogViewer(msg)
+	case constants.StoreCheckView:
+		return m.updateStoreCheck(msg)
+	case constants.ConfigEditorView:
+		return m.updateConfigEditor(msg)
+	case constants.ProfilesView:
+		return m.updateProfiles(msg)
+	case constants.ThemeView:
+		return m.updateThemePicker(msg)
 	default:
 		m.currentView = constants.DefaultView
 		return m, nil
@@ -387,6 +755,10 @@ func (m *CLIModel) View() string {
 	case constants.SplashView:
 		return m.renderSplash()
 	case constants.ListWalletsView:
+		// A paleta de comandos e a ajuda ocupam a área de conteúdo no lugar da tabela
+		if m.paletteOpen || m.helpOpen {
+			return m.renderMainView()
+		}
 		// Tratamento especial para a visualização de listagem de carteiras
 		// para garantir que ela se encaixe corretamente no layout
 		return m.renderListWalletsWithLayout()
@@ -398,35 +770,7 @@ func (m *CLIModel) View() string {
 // renderListWalletsWithLayout renderiza a tela de listagem de carteiras com o layout completo
 func (m *CLIModel) renderListWalletsWithLayout() string {
 	// Renderizar o cabeçalho da mesma forma que renderMainView
-	var logoBuffer bytes.Buffer
-	err := figurine.Write(&logoBuffer, "bloco", "Test1.flf")
-	if err != nil {
-		log.Println(errors.Wrap(err, 0))
-		logoBuffer.WriteString("bloco")
-	}
-	renderedLogo := logoBuffer.String()
-
-	walletCount := m.walletCount
-	currentTime := time.Now().Format("02-01-2006 15:04:05")
-
-	headerLeft := lipgloss.JoinVertical(
-		lipgloss.Left,
-		renderedLogo,
-		fmt.Sprintf("Wallets: %d", walletCount),
-		fmt.Sprintf("Date: %s", currentTime),
-		fmt.Sprintf("Version: %s", localization.Labels["version"]),
-	)
-
-	menuItems := m.renderMenuItems()
-	menuGrid := lipgloss.JoinVertical(lipgloss.Left, menuItems...)
-
-	// Montar header
-	headerContent := lipgloss.JoinHorizontal(
-		lipgloss.Top,
-		headerLeft,
-		lipgloss.NewStyle().Width(m.width-lipgloss.Width(headerLeft)-lipgloss.Width(menuGrid)).Render(""),
-		menuGrid,
-	)
+	headerContent := m.renderHeader()
 
 	// Renderizar header com altura fixa
 	renderedHeader := m.styles.Header.Render(headerContent)
@@ -441,13 +785,8 @@ func (m *CLIModel) renderListWalletsWithLayout() string {
 
 	// Ajustar o tamanho da tabela para caber na área de conteúdo
 	if contentHeight > 0 {
-		// Reservar espaço para título e instruções
-		titleAndInstructionsHeight := 4
-		tableHeight := contentHeight - titleAndInstructionsHeight
-
-		if tableHeight > 0 && len(m.wallets) > 0 {
-			m.walletTable.SetHeight(tableHeight)
-		}
+		// A tabela é ajustada em viewListWallets, descontando as linhas ao redor
+		m.walletListHeight = contentHeight - m.styles.Content.GetVerticalPadding()
 	}
 
 	// Obter conteúdo da visualização de carteiras
@@ -477,6 +816,17 @@ func (m *CLIModel) renderListWalletsWithLayout() string {
 
 func (m *CLIModel) renderMenuItems() []string {
 	var menuItems []string
+	// No modo acessível, um item por linha, com o selecionado marcado por ">"
+	if accessible {
+		for i, item := range m.menuItems {
+			marker := "  "
+			if i == m.selectedMenu {
+				marker = "> "
+			}
+			menuItems = append(menuItems, fmt.Sprintf("%s%s: %s", marker, item.title, item.description))
+		}
+		return menuItems
+	}
 	for i, item := range m.menuItems {
 		style := m.styles.MenuItem
 		titleStyle := m.styles.MenuTitle
@@ -503,9 +853,15 @@ func (m *CLIModel) renderMenuItems() []string {
 }
 
 func (m *CLIModel) getContentView() string {
+	if m.helpOpen {
+		return m.viewHelp()
+	}
+	if m.paletteOpen {
+		return m.viewPalette()
+	}
 	switch m.currentView {
 	case constants.DefaultView:
-		return localization.Labels["welcome_message"]
+		return localization.Labels["welcome_message"] + m.renderComplianceBanner() + m.renderBackupReminder()
 	case constants.CreateWalletNameView:
 		return m.viewCreateWalletName()
 	case constants.CreateWalletView:
@@ -538,6 +894,74 @@ func (m *CLIModel) getContentView() string {
 		return m.viewNetworkList()
 	case constants.AddNetworkView:
 		return m.viewAddNetwork()
+	case constants.JobsView:
+		return m.viewJobs()
+	case constants.SecurityWarningView:
+		return m.viewSecurityWarning()
+	case constants.SecureExportView:
+		return m.viewSecureExport()
+	case constants.InheritanceView:
+		return m.viewInheritance()
+	case constants.SyncView:
+		return m.viewSync()
+	case constants.WalletIntegrityView:
+		return m.viewWalletIntegrity()
+	case constants.WalletNotesView:
+		return m.viewWalletNotes()
+	case constants.BatchCreateView:
+		return m.viewBatchCreate()
+	case constants.ChildWalletsView:
+		return m.viewChildWallets()
+	case constants.WalletRPCView:
+		return m.viewWalletRPC()
+	case constants.SafeImportView:
+		return m.viewSafeImport()
+	case constants.ChildDiscoveryView:
+		return m.viewChildDiscovery()
+	case constants.SignTransactionView:
+		return m.viewSignTransaction()
+	case constants.SendTransactionView:
+		return m.viewSendTransaction()
+	case constants.BroadcastView:
+		return m.viewBroadcast()
+	case constants.PendingTransactionsView:
+		return m.viewPendingTransactions()
+	case constants.AboutView:
+		return m.viewAbout()
+	case constants.TokensView:
+		return m.viewTokens()
+	case constants.WhatsNewView:
+		return m.viewWhatsNew()
+	case constants.BatchSendView:
+		return m.viewBatchSend()
+	case constants.BatchExportView:
+		return m.viewBatchExport()
+	case constants.WalletConnectView:
+		return m.viewWalletConnect()
+	case constants.KeystoreOverwriteView:
+		return m.viewKeystoreOverwrite()
+	case constants.SessionSummaryView:
+		return m.viewSessionSummary()
+	case constants.BackupView:
+		return m.viewBackup()
+	case constants.MasterPasswordView:
+		return m.viewMasterPassword()
+	case constants.WalletBatchView:
+		return m.viewWalletBatch()
+	case constants.NetworkImportView:
+		return m.viewNetworkImport()
+	case constants.MetamaskImportView:
+		return m.viewMetamaskImport()
+	case constants.LogViewerView:
+		return m.viewLogViewer()
+	case constants.StoreCheckView:
+		return m.viewStoreCheck()
+	case constants.ConfigEditorView:
+		return m.viewConfigEditor()
+	case constants.ProfilesView:
+		return m.viewProfiles()
+	case constants.ThemeView:
+		return m.viewThemePicker()
 	default:
 		return localization.Labels["unknown_state"]
 	}
@@ -567,21 +991,39 @@ func (m *CLIModel) updateMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch m.menuItems[m.selectedMenu].title {
 			case localization.Labels["create_new_wallet"]:
 				m.initCreateWallet()
+			case localization.Labels["batch_create"]:
+				m.initBatchCreate()
+			case localization.Labels["broadcast_tx"]:
+				m.initBroadcast()
+			case localization.Labels["batch_send"]:
+				m.initBatchSend()
+			case localization.Labels["sweep"]:
+				m.initSweep()
+			case localization.Labels["pending_tx"]:
+				return m, m.initPendingTransactions()
+			case localization.Labels["walletconnect"]:
+				return m, m.initWalletConnect()
 			case localization.Labels["import_wallet"]:
 				m.initImportWallet()
 			case localization.Labels["list_wallets"]:
 				m.initListWallets()
+			case localization.Labels["inheritance"]:
+				m.initInheritanceView()
+			case localization.Labels["jobs"]:
+				return m, m.initJobsView()
 			case localization.Labels["configuration"]:
 				m.initConfigMenu()
+			case localization.Labels["about"]:
+				m.initAbout()
 			case tea.KeyCtrlX.String(), "q", localization.Labels["exit"]:
-				return m, tea.Quit
+				return m.quit()
 			}
 		case tea.KeyCtrlX.String(), "q":
-			return m, tea.Quit
+			return m.quit()
 		case "esc":
 			// Voltar para o menu principal
-			m.menuItems = NewMenu() // Recarregar o menu principal
-			m.selectedMenu = 0      // Resetar a seleção
+			m.menuItems = m.mainMenu() // Recarregar o menu principal
+			m.selectedMenu = 0         // Resetar a seleção
 			m.currentView = constants.DefaultView
 		}
 	}
@@ -614,7 +1056,7 @@ func (m *CLIModel) updateCreateWalletName(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.currentView = constants.DefaultView
 		default:
 			var cmd tea.Cmd
-			m.nameInput, cmd = m.nameInput.Update(msg)
+			m.nameInput, cmd = updateTextInput(m.nameInput, msg)
 			return m, cmd
 		}
 	}
@@ -645,6 +1087,7 @@ func (m *CLIModel) updateCreateWalletPassword(msg tea.Msg) (tea.Model, tea.Cmd)
 				return m, nil
 			}
 			m.walletDetails = walletDetails
+			m.session.created++
 			// Ensure networks/config are loaded for balances rendering
 			if err := m.ensureConfigAndNetworksLoaded(); err != nil {
 				// Log error but continue execution - network loading is non-fatal
@@ -653,15 +1096,24 @@ func (m *CLIModel) updateCreateWalletPassword(msg tea.Msg) (tea.Model, tea.Cmd)
 			m.currentView = constants.WalletDetailsView
 
 			// Atualizar a contagem de wallets
-			return m, m.refreshWalletsTable()
+			return m, tea.Batch(m.refreshWalletsTable(), m.fetchBalances(false))
 		case "esc":
 			// Go back to name input
 			m.nameInput.Focus()
 			m.currentView = constants.CreateWalletNameView
 			return m, nil
+		case "tab", "shift+tab":
+			if m.revealOneWord() {
+				step := 1
+				if msg.String() == "shift+tab" {
+					step = -1
+				}
+				m.stepMnemonicReveal(step, len(strings.Fields(m.mnemonic)))
+			}
+			return m, nil
 		default:
 			var cmd tea.Cmd
-			m.passwordInput, cmd = m.passwordInput.Update(msg)
+			m.passwordInput, cmd = updateTextInput(m.passwordInput, msg)
 			return m, cmd
 		}
 	}
@@ -669,6 +1121,14 @@ func (m *CLIModel) updateCreateWalletPassword(msg tea.Msg) (tea.Model, tea.Cmd)
 }
 
 func (m *CLIModel) updateImportWallet(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Colar a frase inteira preenche todos os campos de palavras de uma vez
+	if text, ok := pastedText(msg); ok {
+		if words := splitMnemonicPaste(text); len(words) > 1 {
+			m.pasteMnemonicWords(words)
+			return m, nil
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -685,33 +1145,37 @@ func (m *CLIModel) updateImportWallet(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.importStage < len(m.textInputs) {
 				m.textInputs[m.importStage].Focus()
 			} else {
-				m.passwordInput = textinput.New()
-				m.passwordInput.Placeholder = localization.Labels["enter_password"]
-				m.passwordInput.CharLimit = constants.PasswordCharLimit
-				m.passwordInput.Width = constants.PasswordWidth
-				m.passwordInput.EchoMode = textinput.EchoPassword
-				m.passwordInput.EchoCharacter = '•'
-				m.passwordInput.Validate = func(s string) error {
-					_, isValid := wallet.ValidatePassword(s)
-					if !isValid && s != "" {
-						return fmt.Errorf("")
-					}
-					return nil
-				}
-				m.passwordInput.Focus()
-				m.currentView = constants.ImportWalletPasswordView
+				m.startImportPasswordInput()
 			}
 		case "esc":
 			m.currentView = constants.DefaultView
 		default:
 			var cmd tea.Cmd
-			m.textInputs[m.importStage], cmd = m.textInputs[m.importStage].Update(msg)
+			m.textInputs[m.importStage], cmd = updateTextInput(m.textInputs[m.importStage], msg)
 			return m, cmd
 		}
 	}
 	return m, nil
 }
 
+// newWalletPasswordInput creates a masked password input with the wallet password rules
+func newWalletPasswordInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = localization.Labels["enter_password"]
+	ti.CharLimit = constants.PasswordCharLimit
+	ti.Width = constants.PasswordWidth
+	ti.EchoMode = textinput.EchoPassword
+	ti.EchoCharacter = '•'
+	ti.Validate = func(s string) error {
+		_, isValid := wallet.ValidatePassword(s)
+		if !isValid && s != "" {
+			return fmt.Errorf("")
+		}
+		return nil
+	}
+	return ti
+}
+
 func (m *CLIModel) updateImportWalletPassword(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -727,9 +1191,6 @@ func (m *CLIModel) updateImportWalletPassword(msg tea.Msg) (tea.Model, tea.Cmd)
 				return m, nil
 			}
 
-			var walletDetails *wallet.WalletDetails
-			var err error
-
 			// Use a default name based on the import method
 			var name string
 			if m.currentView == constants.ImportWalletPasswordView && len(m.privateKeyInput.Value()) > 0 {
@@ -741,76 +1202,98 @@ func (m *CLIModel) updateImportWalletPassword(msg tea.Msg) (tea.Model, tea.Cmd)
 				name = "Imported Mnemonic Wallet"
 			}
 
-			// Check which import method we're using
+			// Check which import method we're using; the key derivation runs off the UI thread
+			var run func() (*wallet.WalletDetails, error)
 			if m.currentView == constants.ImportWalletPasswordView && len(m.privateKeyInput.Value()) > 0 {
 				// Import from private key
 				privateKey := strings.TrimSpace(m.privateKeyInput.Value())
-				walletDetails, err = m.Service.ImportWalletFromPrivateKey(name, privateKey, password)
+				run = func() (*wallet.WalletDetails, error) {
+					return m.Service.ImportWalletFromPrivateKey(name, privateKey, password)
+				}
 			} else if m.mnemonic != "" && m.currentView == constants.ImportWalletPasswordView {
 				// Import from keystore file
 				keystorePath := m.mnemonic // We stored the keystore path in the mnemonic field
-				walletDetails, err = m.Service.ImportWalletFromKeystore(name, keystorePath, password)
+				run = func() (*wallet.WalletDetails, error) {
+					return m.Service.ImportWalletFromKeystoreV3(name, keystorePath, password)
+				}
 			} else {
 				// Import from mnemonic
 				mnemonic := strings.Join(m.importWords, " ")
-				walletDetails, err = m.Service.ImportWallet(name, mnemonic, password)
+				run = func() (*wallet.WalletDetails, error) {
+					return m.Service.ImportWallet(name, mnemonic, password)
+				}
 			}
 
-			if err != nil {
-				// Check if it's a KeystoreImportError
-				if keystoreErr, ok := err.(*wallet.KeystoreImportError); ok {
-					// Get localized error message
-					localizedMsg := localization.FormatKeystoreErrorWithField(
-						keystoreErr.GetLocalizedMessage(),
-						keystoreErr.Field,
-					)
-
-					// Add recovery suggestion based on error type
-					var recoverySuggestion string
-					switch keystoreErr.Type {
-					case wallet.ErrorFileNotFound:
-						recoverySuggestion = localization.Labels["keystore_recovery_file_not_found"]
-					case wallet.ErrorInvalidJSON:
-						recoverySuggestion = localization.Labels["keystore_recovery_invalid_json"]
-					case wallet.ErrorInvalidKeystore:
-						recoverySuggestion = localization.Labels["keystore_recovery_invalid_structure"]
-					case wallet.ErrorIncorrectPassword:
-						recoverySuggestion = localization.Labels["keystore_recovery_incorrect_password"]
-						// Stay on password screen for password errors
-						m.err = errors.Wrap(fmt.Errorf("%s\n%s", localizedMsg, recoverySuggestion), 0)
-						log.Println(m.err.(*errors.Error).ErrorStack())
-						return m, nil
-					default:
-						recoverySuggestion = localization.Labels["keystore_recovery_general"]
+			var finish func(*wallet.WalletDetails, error) tea.Cmd
+			finish = func(walletDetails *wallet.WalletDetails, err error) tea.Cmd {
+				if err != nil {
+					// Uma keystore diferente do mesmo endereço só é substituída após confirmação
+					var overwriteErr *wallet.KeystoreOverwriteError
+					if errors.As(err, &overwriteErr) {
+						m.openKeystoreOverwrite(overwriteErr, func() tea.Cmd {
+							return m.startKDF(kdfImport, run, finish)
+						})
+						return nil
 					}
 
-					m.err = errors.Wrap(fmt.Errorf("%s\n%s", localizedMsg, recoverySuggestion), 0)
-				} else {
-					// Detect duplicate wallet conflicts and show context-aware localized message
-					if dupErr, ok := err.(*wallet.DuplicateWalletError); ok {
-						// Use the conflict type as both the import method context and conflict type when unknown
-						formatted := localization.FormatDuplicateImportError(dupErr.Type, dupErr.Type, dupErr.Address)
-						m.err = errors.Wrap(errors.New(formatted), 0)
+					// Check if it's a KeystoreImportError
+					if keystoreErr, ok := err.(*wallet.KeystoreImportError); ok {
+						// Get localized error message
+						localizedMsg := localization.FormatKeystoreErrorWithField(
+							keystoreErr.GetLocalizedMessage(),
+							keystoreErr.Field,
+						)
+
+						// Add recovery suggestion based on error type
+						var recoverySuggestion string
+						switch keystoreErr.Type {
+						case wallet.ErrorFileNotFound:
+							recoverySuggestion = localization.Labels["keystore_recovery_file_not_found"]
+						case wallet.ErrorInvalidJSON:
+							recoverySuggestion = localization.Labels["keystore_recovery_invalid_json"]
+						case wallet.ErrorInvalidKeystore:
+							recoverySuggestion = localization.Labels["keystore_recovery_invalid_structure"]
+						case wallet.ErrorIncorrectPassword:
+							recoverySuggestion = localization.Labels["keystore_recovery_incorrect_password"]
+							// Stay on password screen for password errors
+							m.err = errors.Wrap(fmt.Errorf("%s\n%s", localizedMsg, recoverySuggestion), 0)
+							log.Println(m.err.(*errors.Error).ErrorStack())
+							return nil
+						default:
+							recoverySuggestion = localization.Labels["keystore_recovery_general"]
+						}
+
+						m.err = errors.Wrap(fmt.Errorf("%s\n%s", localizedMsg, recoverySuggestion), 0)
 					} else {
-						m.err = errors.Wrap(err, 0)
+						// Detect duplicate wallet conflicts and show context-aware localized message
+						if dupErr, ok := err.(*wallet.DuplicateWalletError); ok {
+							// Use the conflict type as both the import method context and conflict type when unknown
+							formatted := localization.FormatDuplicateImportError(dupErr.Type, dupErr.Type, dupErr.Address)
+							m.err = errors.Wrap(errors.New(formatted), 0)
+						} else {
+							m.err = errors.Wrap(err, 0)
+						}
 					}
-				}
 
-				log.Println(m.err.(*errors.Error).ErrorStack())
-				m.currentView = constants.DefaultView
-				return m, nil
-			}
+					log.Println(m.err.(*errors.Error).ErrorStack())
+					m.currentView = constants.DefaultView
+					return nil
+				}
 
-			m.walletDetails = walletDetails
-			m.currentView = constants.WalletDetailsView
+				m.walletDetails = walletDetails
+				m.session.imported++
+				m.currentView = constants.WalletDetailsView
 
-			// Atualizar a contagem de wallets
-			return m, m.refreshWalletsTable()
+				// Atualizar a contagem de wallets e revisar as permissões do novo keystore,
+				// procurando endereços filhos já usados quando for uma mnemônica
+				return tea.Batch(m.refreshWalletsTable(), m.enforcePermissionsCmd(), m.startChildDiscovery(password), m.fetchBalances(false))
+			}
+			return m, m.startKDF(kdfImport, run, finish)
 		case "esc":
 			m.currentView = constants.DefaultView
 		default:
 			var cmd tea.Cmd
-			m.passwordInput, cmd = m.passwordInput.Update(msg)
+			m.passwordInput, cmd = updateTextInput(m.passwordInput, msg)
 			return m, cmd
 		}
 	}
@@ -837,19 +1320,11 @@ func (m *CLIModel) updateImportMethodSelection(msg tea.Msg) (tea.Model, tea.Cmd)
 			switch m.selectedMenu {
 			case 0: // Primeira opção: Importar por frase mnemônica
 				// Preparar campos de entrada para as 12 palavras
-				m.textInputs = make([]textinput.Model, constants.MnemonicWordCount)
+				m.textInputs = newMnemonicWordInputs(constants.MnemonicWordCount)
 				m.importWords = make([]string, constants.MnemonicWordCount)
-				for i := 0; i < constants.MnemonicWordCount; i++ {
-					ti := textinput.New()
-					ti.Placeholder = fmt.Sprintf("%s %d", localization.Labels["word"], i+1)
-					ti.CharLimit = 50
-					ti.Width = 30
-					if i == 0 {
-						ti.Focus()
-					}
-					m.textInputs[i] = ti
-				}
+				m.textInputs[0].Focus()
 				m.importStage = 0
+				m.loadMnemonicLayout()
 				m.currentView = constants.ImportWalletView
 
 			case 1: // Segunda opção: Importar por chave privada
@@ -858,20 +1333,27 @@ func (m *CLIModel) updateImportMethodSelection(msg tea.Msg) (tea.Model, tea.Cmd)
 				m.privateKeyInput.CharLimit = 66 // 0x + 64 caracteres hexadecimais
 				m.privateKeyInput.Width = 66
 				m.privateKeyInput.Focus()
+				m.privateKeyPreview = nil
 				m.currentView = constants.ImportPrivateKeyView
 
 			case 2: // Terceira opção: Importar por arquivo keystore
 				cmd := m.initEnhancedImport()
 				return m, cmd
 
-			case 3: // Quarta opção: Voltar ao menu principal
-				m.menuItems = NewMenu() // Recarregar o menu principal
-				m.selectedMenu = 0      // Resetar a seleção
+			case 3: // Quarta opção: Importar os donos de uma Safe como somente leitura
+				m.initSafeImport()
+
+			case 4: // Quinta opção: Importar as contas de um cofre do MetaMask
+				return m, m.initMetamaskImport()
+
+			case 5: // Sexta opção: Voltar ao menu principal
+				m.menuItems = m.mainMenu() // Recarregar o menu principal
+				m.selectedMenu = 0         // Resetar a seleção
 				m.currentView = constants.DefaultView
 			}
 		case "esc":
-			m.menuItems = NewMenu() // Recarregar o menu principal
-			m.selectedMenu = 0      // Resetar a seleção
+			m.menuItems = m.mainMenu() // Recarregar o menu principal
+			m.selectedMenu = 0         // Resetar a seleção
 			m.currentView = constants.DefaultView
 		}
 	}
@@ -908,14 +1390,45 @@ func (m *CLIModel) updateConfigMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.initLanguageSelection()
 				return m, nil
 
-			case 2: // Terceira opção: Voltar ao menu principal
-				m.menuItems = NewMenu() // Recarregar o menu principal
-				m.selectedMenu = 0      // Resetar a seleção
+			case 2: // Terceira opção: Sincronização de metadados
+				m.initSyncView()
+				return m, nil
+
+			case 3: // Quarta opção: Backup criptografado
+				m.initBackupView()
+				return m, nil
+
+			case 4: // Quinta opção: Senha mestra
+				m.initMasterPasswordFromConfig()
+				return m, nil
+
+			case 5: // Sexta opção: Visualizar os logs
+				return m, m.initLogViewer()
+
+			case 6: // Sétima opção: Verificar o banco de dados e as keystores
+				m.initStoreCheck()
+				return m, nil
+
+			case 7: // Oitava opção: Editar as configurações
+				m.initConfigEditor()
+				return m, nil
+
+			case 8: // Nona opção: Trocar de perfil
+				m.initProfiles()
+				return m, nil
+
+			case 9: // Décima opção: Escolher o tema de cores
+				m.initThemePicker()
+				return m, nil
+
+			case 10: // Décima primeira opção: Voltar ao menu principal
+				m.menuItems = m.mainMenu() // Recarregar o menu principal
+				m.selectedMenu = 0         // Resetar a seleção
 				m.currentView = constants.DefaultView
 			}
 		case "esc":
-			m.menuItems = NewMenu() // Recarregar o menu principal
-			m.selectedMenu = 0      // Resetar a seleção
+			m.menuItems = m.mainMenu() // Recarregar o menu principal
+			m.selectedMenu = 0         // Resetar a seleção
 			m.currentView = constants.DefaultView
 		}
 	}
@@ -923,74 +1436,45 @@ func (m *CLIModel) updateConfigMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *CLIModel) updateImportPrivateKey(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Uma chave colada substitui o conteúdo do campo, sem espaços ou aspas
+	if text, ok := pastedText(msg); ok {
+		m.privateKeyInput.SetValue(cleanPastedKey(text))
+		m.privateKeyInput.CursorEnd()
+		m.privateKeyPreview = nil
+		return m, nil
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "enter":
-			privateKey := strings.TrimSpace(m.privateKeyInput.Value())
-			if privateKey == "" {
-				m.err = errors.Wrap(errors.New(localization.Labels["invalid_private_key"]), 0)
-				log.Println(m.err.(*errors.Error).ErrorStack())
+			// O endereço já foi conferido: seguir para a senha
+			if m.privateKeyPreview != nil {
+				m.startImportPasswordInput()
 				return m, nil
 			}
 
-			// Move to password input screen
-			m.passwordInput = textinput.New()
-			m.passwordInput.Placeholder = localization.Labels["enter_password"]
-			m.passwordInput.CharLimit = constants.PasswordCharLimit
-			m.passwordInput.Width = constants.PasswordWidth
-			m.passwordInput.EchoMode = textinput.EchoPassword
-			m.passwordInput.EchoCharacter = '•'
-			m.passwordInput.Validate = func(s string) error {
-				_, isValid := wallet.ValidatePassword(s)
-				if !isValid && s != "" {
-					return fmt.Errorf("")
-				}
-				return nil
+			// Detectar o formato e mostrar o endereço derivado antes da senha
+			parsed, err := wallet.ParsePrivateKey(m.privateKeyInput.Value())
+			if err != nil {
+				m.err = errors.Wrap(errors.New(localization.Labels["private_key_unrecognized"]), 0)
+				log.Println(m.err.(*errors.Error).ErrorStack())
+				return m, nil
 			}
-			m.passwordInput.Focus()
-			m.currentView = constants.ImportWalletPasswordView
+			m.err = nil
+			m.privateKeyPreview = parsed
 
 		case "esc":
 			m.currentView = constants.DefaultView
 		default:
 			var cmd tea.Cmd
-			m.privateKeyInput, cmd = m.privateKeyInput.Update(msg)
+			m.privateKeyInput, cmd = updateTextInput(m.privateKeyInput, msg)
 
-			// Update suggestions as the user types
+			// Editar a chave descarta o endereço conferido
 			if msg.Type == tea.KeyRunes || msg.Type == tea.KeyBackspace || msg.Type == tea.KeyDelete {
-				// Get current path
-				currentPath := m.privateKeyInput.Value()
-				if currentPath == "" {
-					currentPath = "."
-				}
-
-				// Get the directory and partial filename
-				dir := filepath.Dir(currentPath)
-				if dir == "." && !strings.HasPrefix(currentPath, "./") && !strings.HasPrefix(currentPath, "/") {
-					dir = currentPath
-				}
-
-				// Read the directory
-				files, err := os.ReadDir(dir)
-				if err == nil {
-					// Find matching files
-					var matches []string
-					partial := filepath.Base(currentPath)
-					for _, file := range files {
-						if strings.HasPrefix(file.Name(), partial) {
-							fullPath := filepath.Join(dir, file.Name())
-							if file.IsDir() {
-								fullPath += "/"
-							}
-							matches = append(matches, fullPath)
-						}
-					}
-
-					// Set all matches as suggestions
-					if len(matches) > 0 {
-						m.privateKeyInput.SetSuggestions(matches)
-					}
+				m.privateKeyPreview = nil
+				if matches, err := pathSuggestions(m.privateKeyInput.Value()); err == nil && len(matches) > 0 {
+					m.privateKeyInput.SetSuggestions(matches)
 				}
 			}
 
@@ -1001,6 +1485,13 @@ func (m *CLIModel) updateImportPrivateKey(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *CLIModel) updateImportKeystore(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Caminhos colados ou arrastados para o terminal chegam com aspas ou escapes
+	if text, ok := pastedText(msg); ok {
+		m.privateKeyInput.SetValue(cleanPastedPath(text))
+		m.privateKeyInput.CursorEnd()
+		return m, nil
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -1076,56 +1567,17 @@ func (m *CLIModel) updateImportKeystore(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.privateKeyInput.SetValue("")
 
 			// Move to password input screen
-			m.passwordInput = textinput.New()
-			m.passwordInput.Placeholder = localization.Labels["enter_password"]
-			m.passwordInput.CharLimit = constants.PasswordCharLimit
-			m.passwordInput.Width = constants.PasswordWidth
-			m.passwordInput.EchoMode = textinput.EchoPassword
-			m.passwordInput.EchoCharacter = '•'
-			m.passwordInput.Validate = func(s string) error {
-				_, isValid := wallet.ValidatePassword(s)
-				if !isValid && s != "" {
-					return fmt.Errorf("")
-				}
-				return nil
-			}
-			m.passwordInput.Focus()
-			m.currentView = constants.ImportWalletPasswordView
+			m.startImportPasswordInput()
 
 		case "esc":
 			m.currentView = constants.ImportMethodSelectionView
 		case "tab":
 			// Implement path autocomplete
-			currentPath := m.privateKeyInput.Value()
-			if currentPath == "" {
-				currentPath = "."
-			}
-
-			// Get the directory and partial filename
-			dir := filepath.Dir(currentPath)
-			if dir == "." && !strings.HasPrefix(currentPath, "./") && !strings.HasPrefix(currentPath, "/") {
-				dir = currentPath
-			}
-
-			// Read the directory
-			files, err := os.ReadDir(dir)
+			matches, err := pathSuggestions(m.privateKeyInput.Value())
 			if err != nil {
 				return m, nil
 			}
 
-			// Find matching files
-			var matches []string
-			partial := filepath.Base(currentPath)
-			for _, file := range files {
-				if strings.HasPrefix(file.Name(), partial) {
-					fullPath := filepath.Join(dir, file.Name())
-					if file.IsDir() {
-						fullPath += "/"
-					}
-					matches = append(matches, fullPath)
-				}
-			}
-
 			// Set all matches as suggestions
 			if len(matches) > 0 {
 				m.privateKeyInput.SetSuggestions(matches)
@@ -1138,46 +1590,16 @@ func (m *CLIModel) updateImportKeystore(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			// Let the textinput component handle the tab key
 			var cmd tea.Cmd
-			m.privateKeyInput, cmd = m.privateKeyInput.Update(msg)
+			m.privateKeyInput, cmd = updateTextInput(m.privateKeyInput, msg)
 			return m, cmd
 		default:
 			var cmd tea.Cmd
-			m.privateKeyInput, cmd = m.privateKeyInput.Update(msg)
+			m.privateKeyInput, cmd = updateTextInput(m.privateKeyInput, msg)
 
 			// Update suggestions as the user types
 			if msg.Type == tea.KeyRunes || msg.Type == tea.KeyBackspace || msg.Type == tea.KeyDelete {
-				// Get current path
-				currentPath := m.privateKeyInput.Value()
-				if currentPath == "" {
-					currentPath = "."
-				}
-
-				// Get the directory and partial filename
-				dir := filepath.Dir(currentPath)
-				if dir == "." && !strings.HasPrefix(currentPath, "./") && !strings.HasPrefix(currentPath, "/") {
-					dir = currentPath
-				}
-
-				// Read the directory
-				files, err := os.ReadDir(dir)
-				if err == nil {
-					// Find matching files
-					var matches []string
-					partial := filepath.Base(currentPath)
-					for _, file := range files {
-						if strings.HasPrefix(file.Name(), partial) {
-							fullPath := filepath.Join(dir, file.Name())
-							if file.IsDir() {
-								fullPath += "/"
-							}
-							matches = append(matches, fullPath)
-						}
-					}
-
-					// Set all matches as suggestions
-					if len(matches) > 0 {
-						m.privateKeyInput.SetSuggestions(matches)
-					}
+				if matches, err := pathSuggestions(m.privateKeyInput.Value()); err == nil && len(matches) > 0 {
+					m.privateKeyInput.SetSuggestions(matches)
 				}
 			}
 
@@ -1191,6 +1613,20 @@ func (m *CLIModel) updateListWallets(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Diálogo de confirmação de exclusão
 	if m.deletingWallet != nil {
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			if m.deletePhraseRequired {
+				// Frase de confirmação das carteiras sem mnemônica
+				switch keyMsg.String() {
+				case "tab":
+					// Ligar ou desligar a cópia da keystore antes da exclusão
+					m.deleteArchive = !m.deleteArchive && m.deleteArchiveDir() != ""
+					return m, nil
+				case "left", "right", "enter", "esc":
+				default:
+					var cmd tea.Cmd
+					m.deleteConfirmInput, cmd = updateTextInput(m.deleteConfirmInput, msg)
+					return m, cmd
+				}
+			}
 			switch keyMsg.String() {
 			case "left", "h":
 				if m.dialogButtonIndex > 0 {
@@ -1205,39 +1641,28 @@ func (m *CLIModel) updateListWallets(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "enter":
 				walletToDelete := m.deletingWallet
 				shouldDelete := m.dialogButtonIndex == 0
+				if shouldDelete && !m.deleteConfirmed() {
+					// O botão Excluir só vale após digitar a frase de confirmação
+					return m, nil
+				}
+				archiveDir := ""
+				if m.deletePhraseRequired && m.deleteArchive {
+					archiveDir = m.deleteArchiveDir()
+				}
 
 				// Limpar a referência do diálogo antes de qualquer outra operação
-				m.deletingWallet = nil
-				m.dialogButtonIndex = 0
+				m.closeDeleteWallet()
 
 				if shouldDelete {
-					// Executar a exclusão
-					err := m.Service.DeleteWallet(walletToDelete)
-					if err != nil {
-						m.err = errors.Wrap(err, 0)
-					}
-
-					// Recarregar a lista de wallets
-					wallets, err := m.Service.GetAllWallets()
-					if err == nil {
-						m.wallets = wallets
-						m.walletCount = len(wallets)
-
-						// Reconstruir linhas da tabela
-						rows := make([]table.Row, len(wallets))
-						for i, w := range wallets {
-							rows[i] = table.Row{fmt.Sprintf("%d", w.ID), w.Name, w.Address}
-						}
-						m.walletTable.SetRows(rows)
-					}
+					// Executar a exclusão (no modo de conformidade, após a aprovação)
+					return m, m.deleteWallet(walletToDelete, archiveDir)
 				}
 
-				// Forçar uma atualização da tela
+				// Recarregar a lista de wallets
 				return m, m.refreshWalletsTable()
 			case "esc":
 				// Limpar a referência do diálogo e forçar atualização
-				m.deletingWallet = nil
-				m.dialogButtonIndex = 0
+				m.closeDeleteWallet()
 				// Forçar uma atualização da tela
 				return m, m.refreshWalletsTable()
 			}
@@ -1245,10 +1670,66 @@ func (m *CLIModel) updateListWallets(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Campo de busca com o foco recebe todas as teclas
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.filteringWallets {
+		return m.updateWalletSearch(keyMsg)
+	}
+
+	// Campo do caminho do relatório com o foco
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.exportingReport {
+		return m.updateWalletReport(keyMsg)
+	}
+
 	// Continuar com o código existente para quando não houver diálogo
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
+		case "/":
+			return m, m.startWalletSearch()
+		case "s":
+			// Ordenar pela próxima coluna: criação, nome ou tipo
+			if len(m.wallets) > 0 {
+				m.cycleWalletSort()
+			}
+			return m, nil
+		case "r":
+			// Exportar a lista filtrada, com as colunas da tabela
+			if len(m.wallets) > 0 {
+				return m, m.startWalletReport()
+			}
+			return m, nil
+		case " ":
+			// Marcar a wallet selecionada para a exportação em lote
+			m.toggleBatchExportMark()
+			return m, nil
+		case "x":
+			if len(m.wallets) > 0 {
+				m.initBatchExport()
+			}
+			return m, nil
+		case "a":
+			// Marcar todas as wallets exibidas, ou desmarcá-las
+			m.toggleAllWalletMarks()
+			return m, nil
+		case "D":
+			// Excluir, etiquetar ou atualizar os saldos das wallets marcadas
+			return m, m.initWalletBatch(walletBatchDelete)
+		case "t":
+			return m, m.initWalletBatch(walletBatchTag)
+		case "b":
+			return m, m.initWalletBatch(walletBatchBalances)
+		case "n":
+			// Editar notas e referências da wallet selecionada
+			selectedRow := m.walletTable.SelectedRow()
+			if len(selectedRow) > 4 {
+				for i, w := range m.wallets {
+					if wallet.SameAddress(w.Address, selectedRow[4]) {
+						m.openWalletNotes(&m.wallets[i])
+						return m, textarea.Blink
+					}
+				}
+			}
+			return m, nil
 		case "d", "delete":
 			// Only try to access the table if there are wallets
 			if len(m.wallets) > 0 {
@@ -1256,9 +1737,8 @@ func (m *CLIModel) updateListWallets(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if len(selectedRow) > 4 {
 					address := selectedRow[4]
 					for i, w := range m.wallets {
-						if w.Address == address {
-							m.deletingWallet = &m.wallets[i]
-							return m, nil
+						if wallet.SameAddress(w.Address, address) {
+							return m, m.openDeleteWallet(&m.wallets[i])
 						}
 					}
 				}
@@ -1271,8 +1751,14 @@ func (m *CLIModel) updateListWallets(msg tea.Msg) (tea.Model, tea.Cmd) {
 					address := selectedRow[4]
 					// Buscar wallet pela address
 					for _, w := range m.wallets {
-						if w.Address == address {
+						if wallet.SameAddress(w.Address, address) {
 							m.selectedWallet = &w
+							// Carteiras somente leitura não têm keystore nem senha
+							if w.WatchOnly() {
+								m.walletDetails, _ = m.Service.LoadWallet(m.selectedWallet, "")
+								m.currentView = constants.WalletDetailsView
+								return m, m.fetchBalances(false)
+							}
 							m.initWalletPassword()
 							return m, nil
 						}
@@ -1306,20 +1792,31 @@ func (m *CLIModel) updateWalletPassword(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.currentView = constants.DefaultView
 				return m, nil
 			}
-			walletDetails, err := m.Service.LoadWallet(m.selectedWallet, password)
-			if err != nil {
-				m.err = errors.Wrap(err, 0)
-				log.Println(m.err.(*errors.Error).ErrorStack())
-				m.currentView = constants.DefaultView
-				return m, nil
-			}
-			m.walletDetails = walletDetails
-			m.currentView = constants.WalletDetailsView
+			// O scrypt pode levar segundos; a derivação roda fora do Update
+			selected := m.selectedWallet
+			return m, m.startKDF(kdfUnlock, func() (*wallet.WalletDetails, error) {
+				return m.Service.LoadWallet(selected, password)
+			}, func(walletDetails *wallet.WalletDetails, err error) tea.Cmd {
+				var integrityErr *wallet.IntegrityError
+				if errors.As(err, &integrityErr) {
+					m.openIntegrityWarning(integrityErr)
+					return nil
+				}
+				if err != nil {
+					m.err = errors.Wrap(err, 0)
+					log.Println(m.err.(*errors.Error).ErrorStack())
+					m.currentView = constants.DefaultView
+					return nil
+				}
+				m.walletDetails = walletDetails
+				m.currentView = constants.WalletDetailsView
+				return m.fetchBalances(false)
+			})
 		case "esc":
 			m.currentView = constants.DefaultView
 		default:
 			var cmd tea.Cmd
-			m.passwordInput, cmd = m.passwordInput.Update(msg)
+			m.passwordInput, cmd = updateTextInput(m.passwordInput, msg)
 			return m, cmd
 		}
 	}
@@ -1330,8 +1827,59 @@ func (m *CLIModel) updateWalletDetails(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
+		case "e":
+			if m.walletDetails != nil && !m.walletDetails.Wallet.WatchOnly() {
+				m.initSecureExport()
+			}
+			return m, nil
+		case "t":
+			if m.walletDetails != nil && !m.walletDetails.Wallet.WatchOnly() {
+				m.initSignTransaction()
+			}
+			return m, nil
+		case "s":
+			if m.walletDetails != nil && !m.walletDetails.Wallet.WatchOnly() {
+				m.initSendTransaction()
+			}
+			return m, nil
+		case "o":
+			if m.walletDetails != nil {
+				m.initWalletRPC()
+			}
+			return m, nil
+		case "r":
+			// Ignora o cache e consulta todas as redes de novo
+			return m, m.fetchBalances(true)
+		case "h":
+			m.showDust = !m.showDust
+			return m, nil
+		case "k":
+			if m.walletDetails != nil {
+				m.initTokens()
+			}
+			return m, nil
+		case "c":
+			// Carteiras filhas só existem para carteiras com mnemônica
+			if m.walletDetails != nil && m.walletDetails.HasMnemonic {
+				m.initChildWallets()
+			}
+			return m, nil
+		case "a", "p", "m":
+			// Copia endereço, chave privada ou mnemônica; o prazo apaga a cópia
+			return m, m.copyWalletDetail(msg.String())
+		case "tab", "shift+tab":
+			if m.walletDetails != nil && m.walletDetails.Mnemonic != nil && m.revealOneWord() {
+				step := 1
+				if msg.String() == "shift+tab" {
+					step = -1
+				}
+				m.stepMnemonicReveal(step, len(strings.Fields(*m.walletDetails.Mnemonic)))
+			}
+			return m, nil
 		case "esc":
 			m.walletDetails = nil
+			m.mnemonicRevealIndex = 0
+			m.clipboardStatus = ""
 			m.currentView = constants.ListWalletsView
 
 			// Ensure the wallet list is properly initialized before showing it
@@ -1363,16 +1911,25 @@ func (m *CLIModel) updateEnhancedImport(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case ImportBatchCompleteMsg:
 		// Import batch completed
+		m.importJobID = ""
+		m.session.noteImportResults(msg.Results)
 		err := m.enhancedImportState.CompleteImport(msg.Results)
 		if err != nil {
 			m.err = errors.Wrap(err, 0)
 			m.currentView = constants.DefaultView
 		}
-		return m, nil
+		return m, tea.Batch(m.enforcePermissionsCmd(), m.notifyImportCompleted(msg.Results))
+
+	case RetryFileDoneMsg:
+		// One file of the batch was imported again from the error details
+		m.session.noteImportResults([]wallet.ImportResult{msg.Result})
+		m.enhancedImportState.ApplyRetryResult(msg.Result)
+		return m, m.enforcePermissionsCmd()
 
 	case ImportProgressUpdateMsg:
 		// Update progress
 		m.enhancedImportState.UpdateProgress(msg.Progress)
+		m.reportImportJobProgress(msg.Progress)
 
 		// Collect commands to execute
 		var cmds []tea.Cmd
@@ -1423,11 +1980,15 @@ func (m *CLIModel) updateEnhancedImport(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case ReturnToMenuMsg:
 		// Return to main menu
-		m.enhancedImportState = nil
+		m.closeEnhancedImport()
 		m.currentView = constants.DefaultView
 		return m, nil
 
 	case tea.KeyMsg:
+		// The files already imported are skipped, overwritten or imported anyway
+		if m.enhancedImportState.ReviewingDuplicates() {
+			return m, m.updateDuplicateReview(msg)
+		}
 		switch msg.String() {
 		case "esc":
 			// Handle escape key based on current phase
@@ -1435,7 +1996,7 @@ func (m *CLIModel) updateEnhancedImport(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch phase {
 			case PhaseFileSelection:
 				// Return to main menu
-				m.enhancedImportState = nil
+				m.closeEnhancedImport()
 				m.currentView = constants.DefaultView
 				return m, nil
 			case PhaseImporting:
@@ -1454,7 +2015,7 @@ func (m *CLIModel) updateEnhancedImport(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			case PhaseComplete, PhaseCancelled:
 				// Return to main menu
-				m.enhancedImportState = nil
+				m.closeEnhancedImport()
 				m.currentView = constants.DefaultView
 				return m, nil
 			}
@@ -1463,26 +2024,50 @@ func (m *CLIModel) updateEnhancedImport(msg tea.Msg) (tea.Model, tea.Cmd) {
 			phase := m.enhancedImportState.GetCurrentPhase()
 			switch phase {
 			case PhaseFileSelection:
-				// Start import if files are selected
-				if len(m.enhancedImportState.SelectedFiles) > 0 || m.enhancedImportState.SelectedDir != "" {
+				// Start import if files are selected; while pasting, enter breaks the line
+				if !m.enhancedImportState.IsPasting() && (len(m.enhancedImportState.SelectedFiles) > 0 || m.enhancedImportState.SelectedDir != "") {
 					err := m.enhancedImportState.StartImport()
 					if err != nil {
 						m.err = errors.Wrap(err, 0)
 						return m, nil
 					}
-					// Start the import batch processing and progress listening
-					return m, tea.Batch(
-						m.enhancedImportState.ProcessImportBatch(),
-						m.listenForProgressUpdates(),
-						m.listenForPasswordRequests(),
-					)
+					if m.enhancedImportState.ReviewingDuplicates() {
+						return m, nil
+					}
+					return m, m.runEnhancedImportBatch()
 				}
 			case PhaseComplete, PhaseCancelled:
 				// Return to main menu
-				m.enhancedImportState = nil
+				m.closeEnhancedImport()
 				m.currentView = constants.DefaultView
 				return m, nil
 			}
+		case "ctrl+p":
+			// Paste a keystore instead of choosing its file
+			if m.enhancedImportState.GetCurrentPhase() == PhaseFileSelection && !m.enhancedImportState.IsPasting() {
+				m.enhancedImportState.OpenPaste()
+				return m, textarea.Blink
+			}
+		case "ctrl+r":
+			// Resume the files an interrupted batch did not import
+			if m.enhancedImportState.GetCurrentPhase() == PhaseFileSelection && m.enhancedImportState.PendingImport != nil && !m.enhancedImportState.IsPasting() {
+				started, err := m.enhancedImportState.ResumeImport()
+				if err != nil {
+					m.err = errors.Wrap(err, 0)
+					return m, nil
+				}
+				if started {
+					return m, m.runEnhancedImportBatch()
+				}
+				return m, nil
+			}
+		case "ctrl+x":
+			if m.enhancedImportState.GetCurrentPhase() == PhaseFileSelection && m.enhancedImportState.PendingImport != nil && !m.enhancedImportState.IsPasting() {
+				if err := m.enhancedImportState.DiscardPendingImport(); err != nil {
+					m.err = errors.Wrap(err, 0)
+				}
+				return m, nil
+			}
 		}
 	}
 
@@ -1492,6 +2077,58 @@ func (m *CLIModel) updateEnhancedImport(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// updateDuplicateReview answers the review of the files already imported
+func (m *CLIModel) updateDuplicateReview(msg tea.KeyMsg) tea.Cmd {
+	var action wallet.DuplicateAction
+	switch msg.String() {
+	case "s", "enter":
+		action = wallet.DuplicateSkip
+	case "o":
+		action = wallet.DuplicateOverwrite
+	case "i":
+		action = wallet.DuplicateImportAnyway
+	default:
+		return nil
+	}
+	started, err := m.enhancedImportState.ResolveDuplicates(action)
+	if err != nil {
+		m.err = errors.Wrap(err, 0)
+		return nil
+	}
+	if !started {
+		return nil
+	}
+	return m.runEnhancedImportBatch()
+}
+
+// runEnhancedImportBatch runs the jobs of the import state as a background job
+// and starts listening for its progress and password requests
+func (m *CLIModel) runEnhancedImportBatch() tea.Cmd {
+	processCmd, err := m.startImportBatchJob()
+	if err != nil {
+		m.closeEnhancedImport()
+		m.err = errors.Wrap(err, 0)
+		return nil
+	}
+	return tea.Batch(
+		processCmd,
+		m.listenForProgressUpdates(),
+		m.listenForPasswordRequests(),
+	)
+}
+
+// closeEnhancedImport leaves the enhanced import, removing the keystores
+// extracted from archives
+func (m *CLIModel) closeEnhancedImport() {
+	if m.enhancedImportState == nil {
+		return
+	}
+	if closer, ok := m.enhancedImportState.BatchService.(io.Closer); ok {
+		_ = closer.Close()
+	}
+	m.enhancedImportState = nil
+}
+
 func (m *CLIModel) updateTableDimensions() {
 	if m.currentView != constants.ListWalletsView || len(m.wallets) == 0 {
 		return
@@ -1519,32 +2156,15 @@ func (m *CLIModel) updateTableDimensions() {
 		m.walletTable.SetHeight(contentAreaHeight)
 	}
 
-	// Calcular larguras das colunas
-	idColWidth := 10
-	nameColWidth := 20
-	typeColWidth := 20
-	createdAtColWidth := 20
-	// Aumentar a margem para evitar quebra de linha
-	addressColWidth := m.width - idColWidth - nameColWidth - typeColWidth - createdAtColWidth - 20
-
-	if addressColWidth < 20 {
-		addressColWidth = 20
-	}
-
-	// Atualizar colunas - manter consistente com initListWallets e rebuildWalletsTable
-	m.walletTable.SetColumns([]table.Column{
-		{Title: localization.Labels["id"], Width: idColWidth},
-		{Title: "Nome", Width: nameColWidth},
-		{Title: localization.Labels["wallet_type"], Width: typeColWidth},
-		{Title: localization.Labels["created_at"], Width: createdAtColWidth},
-		{Title: localization.Labels["ethereum_address"], Width: addressColWidth},
-	})
+	// Atualizar colunas - as mesmas de initListWallets e rebuildWalletsTable
+	m.walletTable.SetColumns(m.walletTableColumns())
 }
 
 // Funções de inicialização
 
 func (m *CLIModel) initCreateWallet() {
 	m.mnemonic, _ = wallet.GenerateMnemonic()
+	m.loadMnemonicLayout()
 
 	// Initialize name input first
 	m.nameInput = textinput.New()
@@ -1594,9 +2214,22 @@ func (m *CLIModel) initImportWallet() {
 func (m *CLIModel) initEnhancedImport() tea.Cmd {
 	// Create batch import service
 	batchService := wallet.NewBatchImportService(m.Service)
+	if m.currentConfig != nil {
+		batchService.Concurrency = m.currentConfig.Import.Concurrency
+		if sandbox.Active() {
+			batchService.ArchiveDir = m.currentConfig.AppDir
+		}
+	}
 
 	// Initialize enhanced import state
 	m.enhancedImportState = NewEnhancedImportState(batchService, m.styles)
+	if m.currentConfig != nil {
+		m.enhancedImportState.ReportDir = m.currentConfig.Import.ReportDir
+		if m.enhancedImportState.ReportDir == "" {
+			m.enhancedImportState.ReportDir = filepath.Join(m.currentConfig.AppDir, "reports")
+		}
+		m.enhancedImportState.ReportFormat = m.currentConfig.Import.ReportFormat
+	}
 
 	// Set current view
 	m.currentView = constants.EnhancedImportView
@@ -1615,41 +2248,8 @@ func (m *CLIModel) initListWallets() {
 	}
 	m.wallets = wallets
 
-	// Inicialize as colunas com larguras adequadas
-	idColWidth := 10
-	nameColWidth := 20
-	typeColWidth := 20
-	createdAtColWidth := 20
-	addressColWidth := m.width - idColWidth - nameColWidth - typeColWidth - createdAtColWidth - 20 // Subtrai 20 para padding e margens
-
-	if addressColWidth < 20 {
-		addressColWidth = 20
-	}
-
-	columns := []table.Column{
-		{Title: localization.Labels["id"], Width: idColWidth},
-		{Title: "Nome", Width: nameColWidth},
-		{Title: localization.Labels["wallet_type"], Width: typeColWidth},
-		{Title: localization.Labels["created_at"], Width: createdAtColWidth},
-		{Title: localization.Labels["ethereum_address"], Width: addressColWidth},
-	}
-
-	var rows []table.Row
-	for _, w := range m.wallets {
-		// Determine wallet type using ImportMethod as primary source
-		walletType := determineWalletType(w)
-
-		// Format created at date
-		createdAt := w.CreatedAt.Format("2006-01-02 15:04")
-
-		rows = append(rows, table.Row{
-			fmt.Sprintf("%d", w.ID),
-			w.Name,
-			walletType,
-			createdAt,
-			w.Address,
-		})
-	}
+	columns := m.walletTableColumns()
+	rows := walletTableRows(m.visibleWallets(), m.batchExportMarked)
 
 	m.walletTable = table.New(
 		table.WithColumns(columns),
@@ -1661,18 +2261,7 @@ func (m *CLIModel) initListWallets() {
 	m.walletTable.SetWidth(m.width - 12)
 
 	// Ajustar os estilos da tabela
-	s := table.DefaultStyles()
-	s.Header = s.Header.
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("240")).
-		BorderBottom(true).
-		Bold(true)
-	s.Selected = s.Selected.
-		Foreground(lipgloss.Color("229")).
-		Background(lipgloss.Color("57")).
-		Bold(false)
-	s.Cell = s.Cell.Align(lipgloss.Left)
-	m.walletTable.SetStyles(s)
+	m.walletTable.SetStyles(walletTableStyles())
 
 	// Definir altura da tabela para usar totalmente o espaço disponível
 	contentAreaHeight := m.height - lipgloss.Height(m.styles.Header.Render("")) - lipgloss.Height(m.styles.Footer.Render("")) - 2
@@ -1862,6 +2451,8 @@ func (m *CLIModel) updateNetworkMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case 1: // Network List
 				m.initNetworkList()
 				return m, nil
+			case 2: // Import Networks from File
+				return m, m.initNetworkImport()
 			}
 		case "esc":
 			// Return to the config menu
@@ -1873,33 +2464,26 @@ func (m *CLIModel) updateNetworkMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// walletsRefreshedMsg é uma mensagem personalizada para indicar que a lista de wallets foi atualizada
-type walletsRefreshedMsg struct{}
-
+// refreshWalletsTable requests a wallet reload through the coalescing scheduler.
+// Bursts of calls result in a single reload, spaced by walletRefreshMinInterval.
 func (m *CLIModel) refreshWalletsTable() tea.Cmd {
-	return func() tea.Msg {
-		// Recarregar a lista de wallets do serviço
-		wallets, err := m.Service.GetAllWallets()
-		if err != nil {
-			m.err = errors.Wrap(err, 0)
-			return nil
-		}
-
-		// Atualizar a lista de wallets no modelo
-		m.wallets = wallets
-
-		// Atualizar a contagem de wallets
-		m.walletCount = len(wallets)
-
-		// Se houver wallets, reconstruir a tabela completamente
-		// para garantir que ela seja inicializada corretamente
-		if len(m.wallets) > 0 {
-			m.rebuildWalletsTable()
-		}
+	return m.getRefreshScheduler().Request()
+}
 
-		// Retornar uma mensagem personalizada para indicar que a lista foi atualizada
-		return walletsRefreshedMsg{}
-	}
+// walletTableStyles styles the wallet table in the active theme
+func walletTableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(palette.Subtle).
+		BorderBottom(true).
+		Bold(true)
+	s.Selected = s.Selected.
+		Foreground(palette.SelectionFg).
+		Background(palette.SelectionBg).
+		Bold(false)
+	s.Cell = s.Cell.Align(lipgloss.Left)
+	return s
 }
 
 func (m *CLIModel) rebuildWalletsTable() {
@@ -1908,41 +2492,8 @@ func (m *CLIModel) rebuildWalletsTable() {
 		return
 	}
 
-	// Inicialize as colunas com larguras adequadas
-	idColWidth := 10
-	nameColWidth := 20
-	typeColWidth := 20
-	createdAtColWidth := 20
-	addressColWidth := m.width - idColWidth - nameColWidth - typeColWidth - createdAtColWidth - 20 // Subtrai 20 para padding e margens
-
-	if addressColWidth < 20 {
-		addressColWidth = 20
-	}
-
-	columns := []table.Column{
-		{Title: localization.Labels["id"], Width: idColWidth},
-		{Title: "Nome", Width: nameColWidth},
-		{Title: localization.Labels["wallet_type"], Width: typeColWidth},
-		{Title: localization.Labels["created_at"], Width: createdAtColWidth},
-		{Title: localization.Labels["ethereum_address"], Width: addressColWidth},
-	}
-
-	var rows []table.Row
-	for _, w := range m.wallets {
-		// Determine wallet type using ImportMethod as primary source
-		walletType := determineWalletType(w)
-
-		// Format created at date
-		createdAt := w.CreatedAt.Format("2006-01-02 15:04")
-
-		rows = append(rows, table.Row{
-			fmt.Sprintf("%d", w.ID),
-			w.Name,
-			walletType,
-			createdAt,
-			w.Address,
-		})
-	}
+	columns := m.walletTableColumns()
+	rows := walletTableRows(m.visibleWallets(), m.batchExportMarked)
 
 	m.walletTable = table.New(
 		table.WithColumns(columns),
@@ -1954,18 +2505,7 @@ func (m *CLIModel) rebuildWalletsTable() {
 	m.walletTable.SetWidth(m.width - 12)
 
 	// Ajustar os estilos da tabela
-	s := table.DefaultStyles()
-	s.Header = s.Header.
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("240")).
-		BorderBottom(true).
-		Bold(true)
-	s.Selected = s.Selected.
-		Foreground(lipgloss.Color("229")).
-		Background(lipgloss.Color("57")).
-		Bold(false)
-	s.Cell = s.Cell.Align(lipgloss.Left)
-	m.walletTable.SetStyles(s)
+	m.walletTable.SetStyles(walletTableStyles())
 
 	// Definir altura da tabela para usar totalmente o espaço disponível
 	contentAreaHeight := m.height - lipgloss.Height(m.styles.Header.Render("")) - lipgloss.Height(m.styles.Footer.Render("")) - 2
@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// txPreviewMsg carries the simulated effects of the transaction under review
+type txPreviewMsg struct {
+	seq     int
+	preview blockchain.TxPreview
+	err     error
+}
+
+// previewBroadcast simulates the decoded transaction on the selected network,
+// so the review shows what it will change before it is sent
+func (m *CLIModel) previewBroadcast() tea.Cmd {
+	m.broadcastPreviewSeq++
+	m.broadcastPreview = nil
+	m.broadcastPreviewErr = nil
+	network, ok := m.selectedBroadcastNetwork()
+	if !ok || m.broadcastTx == nil {
+		m.broadcastPreviewing = false
+		return nil
+	}
+	m.broadcastPreviewing = true
+	seq, raw := m.broadcastPreviewSeq, m.broadcastTx
+	return func() tea.Msg {
+		client, err := blockchain.NewEthereum(network.RPCEndpoint, blockchain.DefaultTimeout, network.Symbol, 18, network.Name)
+		if err != nil {
+			return txPreviewMsg{seq: seq, err: err}
+		}
+		defer client.Close()
+		preview, err := client.PreviewTransaction(context.Background(), raw.Tx, raw.From)
+		return txPreviewMsg{seq: seq, preview: preview, err: err}
+	}
+}
+
+// handleTxPreview keeps the result of the latest simulation only
+func (m *CLIModel) handleTxPreview(msg txPreviewMsg) {
+	if msg.seq != m.broadcastPreviewSeq || !m.broadcastPreviewing {
+		return
+	}
+	m.broadcastPreviewing = false
+	if msg.err != nil {
+		m.broadcastPreviewErr = msg.err
+		return
+	}
+	preview := msg.preview
+	m.broadcastPreview = &preview
+}
+
+// viewBroadcastPreview renders the expected balance and allowance changes
+func (m *CLIModel) viewBroadcastPreview(networkName string) string {
+	var b strings.Builder
+	switch {
+	case m.broadcastPreviewing:
+		b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf(localization.Labels["tx_preview_running"], networkName)))
+		b.WriteString("\n\n")
+		return b.String()
+	case m.broadcastPreviewErr != nil:
+		b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + fmt.Sprintf(localization.Labels["tx_preview_failed"], m.broadcastPreviewErr)))
+		b.WriteString("\n\n")
+		return b.String()
+	case m.broadcastPreview == nil:
+		return ""
+	}
+
+	preview, from := m.broadcastPreview, m.broadcastTx.From
+	b.WriteString(fmt.Sprintf(localization.Labels["tx_preview_title"], networkName))
+	b.WriteString("\n")
+	for _, change := range preview.Changes {
+		line := "  " + m.txPreviewLine(change, from)
+		if change.Insufficient {
+			line = m.styles.ErrorStyle.Render(line + " " + localization.Labels["tx_preview_insufficient"])
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	b.WriteString(m.styles.MenuDesc.Render("  " + localization.Labels["tx_preview_fee_note"]))
+	b.WriteString("\n")
+
+	if call := preview.Token; call != nil && call.Unlimited() {
+		b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + fmt.Sprintf(localization.Labels["tx_preview_unlimited_warning"], m.txPreviewAccount(call.Spender, from))))
+		b.WriteString("\n")
+	}
+	if preview.RevertReason != "" {
+		b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + fmt.Sprintf(localization.Labels["tx_preview_reverted"], preview.RevertReason)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// txPreviewLine describes one balance or allowance change
+func (m *CLIModel) txPreviewLine(change blockchain.BalanceChange, from common.Address) string {
+	account := m.txPreviewAccount(change.Account, from)
+	if change.Spender != nil {
+		return fmt.Sprintf(localization.Labels["tx_preview_allowance"], account, m.txPreviewAccount(*change.Spender, from),
+			previewAmount(change.Before, change.Decimals), previewAmount(change.After, change.Decimals), change.Symbol)
+	}
+	delta := new(big.Int).Sub(change.After, change.Before)
+	sign := "+"
+	if delta.Sign() < 0 {
+		sign = ""
+	}
+	return fmt.Sprintf(localization.Labels["tx_preview_balance"], account, sign+wallet.FormatUnits(delta, change.Decimals), change.Symbol,
+		wallet.FormatUnits(change.Before, change.Decimals), wallet.FormatUnits(change.After, change.Decimals))
+}
+
+// txPreviewAccount names an address as the sender, a local wallet or itself
+func (m *CLIModel) txPreviewAccount(address, from common.Address) string {
+	if address == from {
+		return localization.Labels["tx_preview_you"]
+	}
+	if name := m.localWalletName(address); name != "" {
+		return fmt.Sprintf("%s (%s)", name, address.Hex())
+	}
+	return address.Hex()
+}
+
+// previewAmount formats an allowance, calling the maximum ones unlimited
+func previewAmount(value *big.Int, decimals int) string {
+	if value.BitLen() >= 255 {
+		return localization.Labels["tx_preview_unlimited"]
+	}
+	return wallet.FormatUnits(value, decimals)
+}
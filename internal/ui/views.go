@@ -1,26 +1,29 @@
 package ui
 
 import (
-	"blocowallet/internal/blockchain"
 	"blocowallet/internal/constants"
 	"blocowallet/internal/wallet"
 	"blocowallet/pkg/localization"
 	"bytes"
-	"context"
 	"fmt"
 	"log"
-	"math/big"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/arsham/figurine/figurine"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/digitallyserviced/tdfgo/tdf"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/go-errors/errors"
 )
 
+func init() {
+	registerKeyMap(constants.ListWalletsView, listWalletsKeys)
+	registerKeyMap(constants.WalletDetailsView, walletDetailsKeys)
+}
+
 // viewCreateWalletName renderiza a visualização de entrada do nome da wallet
 func (m *CLIModel) viewCreateWalletName() string {
 	if localization.Labels == nil {
@@ -29,7 +32,7 @@ func (m *CLIModel) viewCreateWalletName() string {
 
 	var view strings.Builder
 	view.WriteString(
-		lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#00FF00")).Render("Criar Nova Wallet") + "\n\n" +
+		lipgloss.NewStyle().Bold(true).Foreground(palette.Success).Render("Criar Nova Wallet") + "\n\n" +
 			"Digite o nome para sua nova wallet:" + "\n\n" +
 			m.nameInput.View() + "\n\n" +
 			localization.Labels["press_enter"],
@@ -45,40 +48,40 @@ func (m *CLIModel) renderPasswordValidation(password string) string {
 
 	// Check for minimum length
 	if password == "" {
-		builder.WriteString(m.styles.RedCross.Render("✗"))
+		builder.WriteString(m.styles.RedCross.Render(glyphs.Cross))
 		builder.WriteString(" required\n")
 	} else if validationErr.TooShort {
-		builder.WriteString(m.styles.RedCross.Render("✗"))
+		builder.WriteString(m.styles.RedCross.Render(glyphs.Cross))
 		builder.WriteString(" has 8 characters or more\n")
 	} else {
-		builder.WriteString(m.styles.GreenCheck.Render("✓"))
+		builder.WriteString(m.styles.GreenCheck.Render(glyphs.Check))
 		builder.WriteString(" has 8 characters or more\n")
 	}
 
 	// Check for lowercase letter
 	if password == "" || validationErr.NoLowercase {
-		builder.WriteString(m.styles.RedCross.Render("✗"))
+		builder.WriteString(m.styles.RedCross.Render(glyphs.Cross))
 		builder.WriteString(" has a lowercase letter\n")
 	} else {
-		builder.WriteString(m.styles.GreenCheck.Render("✓"))
+		builder.WriteString(m.styles.GreenCheck.Render(glyphs.Check))
 		builder.WriteString(" has a lowercase letter\n")
 	}
 
 	// Check for uppercase letter
 	if password == "" || validationErr.NoUppercase {
-		builder.WriteString(m.styles.RedCross.Render("✗"))
+		builder.WriteString(m.styles.RedCross.Render(glyphs.Cross))
 		builder.WriteString(" has an uppercase letter\n")
 	} else {
-		builder.WriteString(m.styles.GreenCheck.Render("✓"))
+		builder.WriteString(m.styles.GreenCheck.Render(glyphs.Check))
 		builder.WriteString(" has an uppercase letter\n")
 	}
 
 	// Check for digit or special character
 	if password == "" || validationErr.NoDigitOrSpecial {
-		builder.WriteString(m.styles.RedCross.Render("✗"))
+		builder.WriteString(m.styles.RedCross.Render(glyphs.Cross))
 		builder.WriteString(" has a digit or special character")
 	} else {
-		builder.WriteString(m.styles.GreenCheck.Render("✓"))
+		builder.WriteString(m.styles.GreenCheck.Render(glyphs.Check))
 		builder.WriteString(" has a digit or special character")
 	}
 
@@ -93,18 +96,24 @@ func (m *CLIModel) viewCreateWalletPassword() string {
 
 	var view strings.Builder
 	view.WriteString(
-		lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#00FF00")).Render(localization.Labels["mnemonic_phrase"]) + "\n\n" +
-			fmt.Sprintf("%s\n\n", m.mnemonic) +
+		lipgloss.NewStyle().Bold(true).Foreground(palette.Success).Render(localization.Labels["mnemonic_phrase"]) + "\n\n" +
+			m.renderMnemonic(m.mnemonic) + "\n" +
 			localization.Labels["enter_password"] + "\n\n" +
 			m.passwordInput.View() + "\n\n" +
 			m.renderPasswordValidation(m.passwordInput.Value()) + "\n\n" +
-			localization.Labels["press_enter"],
+			localization.Labels["press_enter"] +
+			m.renderInputNotice(),
 	)
 	return view.String()
 }
 
 // renderSplash renderiza a tela de splash screen
 func (m *CLIModel) renderSplash() string {
+	// O modo acessível não usa fontes TDF
+	if accessible {
+		return fmt.Sprintf("%s v%s", "BLOCO Wallet", localization.Labels["version"])
+	}
+
 	// Verificar se a fonte selecionada está disponível
 	if m.selectedFont == nil {
 		log.Println("Fonte selecionada não está carregada.")
@@ -147,16 +156,37 @@ func (m *CLIModel) renderSplash() string {
 func (m *CLIModel) renderStatusBar() string {
 	// Left part: Number of wallets
 	leftStyle := m.styles.StatusBarLeft // Used assignment for copying.
+	leftText := fmt.Sprintf("Wallets: %d", m.walletCount)
+	// Progress of the broadcast transactions still being followed
+	if pending := m.pendingStatusIndicator(); pending != "" {
+		leftText += " | " + pending
+	}
+	// WalletConnect proposals and requests waiting for the user
+	if wc := m.wcStatusIndicator(); wc != "" {
+		leftText += " | " + wc
+	}
+	// Whether traffic can go through the SOCKS5 proxy
+	if proxy := m.proxyStatusIndicator(); proxy != "" {
+		leftText += " | " + proxy
+	}
+	// Seconds before a copied secret is cleared from the clipboard
+	if clip := m.clipboardStatusIndicator(); clip != "" {
+		leftText += " | " + clip
+	}
 	left := leftStyle.
-		SetString(fmt.Sprintf("Wallets: %d", m.walletCount)).
+		SetString(leftText).
 		String()
 
-	// Right part: Current date and time
-	currentTime := time.Now().Format("02-01-2006 15:04:05")
-	rightStyle := m.styles.StatusBarRight // Used assignment for copying.
-	right := rightStyle.
-		SetString(fmt.Sprintf("Date: %s", currentTime)).
-		String()
+	// Right part: Current date and time, left out in accessible mode so the
+	// status bar is not announced every second
+	right := ""
+	if !accessible {
+		currentTime := time.Now().Format("02-01-2006 15:04:05")
+		rightStyle := m.styles.StatusBarRight // Used assignment for copying.
+		right = rightStyle.
+			SetString(fmt.Sprintf("Date: %s", currentTime)).
+			String()
+	}
 
 	// Map view constants to human-readable names
 	viewNames := map[string]string{
@@ -176,6 +206,40 @@ func (m *CLIModel) renderStatusBar() string {
 		constants.NetworkMenuView:           localization.Labels["networks"],
 		constants.NetworkListView:           localization.Labels["network_list"],
 		constants.AddNetworkView:            localization.Labels["add_network"],
+		constants.JobsView:                  localization.Labels["jobs_title"],
+		constants.SecurityWarningView:       localization.Labels["security_warning_title"],
+		constants.SecureExportView:          localization.Labels["secure_export_title"],
+		constants.InheritanceView:           localization.Labels["inheritance_title"],
+		constants.SyncView:                  localization.Labels["sync_title"],
+		constants.WalletIntegrityView:       localization.Labels["integrity_title"],
+		constants.WalletNotesView:           localization.Labels["notes_title"],
+		constants.BatchCreateView:           localization.Labels["batch_create_title"],
+		constants.ChildWalletsView:          localization.Labels["child_title"],
+		constants.WalletRPCView:             localization.Labels["rpc_override_title"],
+		constants.SafeImportView:            localization.Labels["safe_title"],
+		constants.ChildDiscoveryView:        localization.Labels["discovery_title"],
+		constants.SignTransactionView:       localization.Labels["sign_tx_title"],
+		constants.SendTransactionView:       localization.Labels["send_title"],
+		constants.BroadcastView:             localization.Labels["broadcast_title"],
+		constants.PendingTransactionsView:   localization.Labels["pending_tx_title"],
+		constants.AboutView:                 localization.Labels["about_title"],
+		constants.TokensView:                localization.Labels["tokens_title"],
+		constants.WhatsNewView:              localization.Labels["whats_new_title"],
+		constants.BatchSendView:             localization.Labels["batch_send_title"],
+		constants.BatchExportView:           localization.Labels["batch_export_title"],
+		constants.WalletConnectView:         localization.Labels["wc_title"],
+		constants.KeystoreOverwriteView:     localization.Labels["overwrite_title"],
+		constants.SessionSummaryView:        localization.Labels["session_title"],
+		constants.BackupView:                localization.Labels["backup_title"],
+		constants.MasterPasswordView:        m.masterPasswordTitle(),
+		constants.WalletBatchView:           m.walletBatchTitle(),
+		constants.NetworkImportView:         localization.Labels["network_import_title"],
+		constants.MetamaskImportView:        localization.Labels["metamask_title"],
+		constants.LogViewerView:             localization.Labels["logs_title"],
+		constants.StoreCheckView:            localization.Labels["store_check_title"],
+		constants.ConfigEditorView:          localization.Labels["config_editor_title"],
+		constants.ProfilesView:              localization.Labels["profiles_title"],
+		constants.ThemeView:                 localization.Labels["theme_title"],
 	}
 
 	// Get the view name from the map, or use the current view constant if not found
@@ -184,16 +248,16 @@ func (m *CLIModel) renderStatusBar() string {
 		viewName = m.currentView
 	}
 
-	// Center part: Current view and shortcut keys
-	var centerContent string
-	if m.currentView == constants.ListWalletsView {
-		// Special case for the wallet list view to include delete instruction
-		centerContent = fmt.Sprintf("View: %s | Press 'd' to delete | Press 'esc' to return | Press 'q' to quit", viewName)
-	} else {
-		centerContent = fmt.Sprintf("View: %s | Press 'esc' to return | Press 'q' to quit", viewName)
+	// Center part: Current view and the keys it accepts, cut to the space left
+	centerContent := fmt.Sprintf("View: %s | ", viewName)
+
+	if accessible {
+		// Labeled text in one line, without padding to the width of the terminal
+		return leftText + " | " + centerContent + m.renderShortHelp(0)
 	}
 
 	centerWidth := m.width - lipgloss.Width(left) - lipgloss.Width(right)
+	centerContent += m.renderShortHelp(max(centerWidth-lipgloss.Width(centerContent)-m.styles.StatusBarCenter.GetHorizontalFrameSize(), 1))
 	centerStyle := m.styles.StatusBarCenter // Used assignment for copying.
 	center := centerStyle.
 		SetString(centerContent).
@@ -212,7 +276,19 @@ func (m *CLIModel) renderStatusBar() string {
 	return statusBar
 }
 
-func (m *CLIModel) renderMainView() string {
+// renderHeaderInfo renderiza o logo e as informações à esquerda do cabeçalho
+func (m *CLIModel) renderHeaderInfo() string {
+	// No modo acessível o logo é texto simples e o relógio não é exibido,
+	// para que a tela não mude a cada segundo
+	if accessible {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			"BLOCO Wallet",
+			fmt.Sprintf("Wallets: %d", m.walletCount),
+			fmt.Sprintf("Version: %s", localization.Labels["version"]),
+		)
+	}
+
 	var logoBuffer bytes.Buffer
 	err := figurine.Write(&logoBuffer, "bloco", "Test1.flf")
 	if err != nil {
@@ -232,9 +308,20 @@ func (m *CLIModel) renderMainView() string {
 		fmt.Sprintf("Version: %s", localization.Labels["version"]),
 	)
 
+	return headerLeft
+}
+
+// renderHeader monta o cabeçalho com as informações à esquerda e o menu à
+// direita; no modo acessível, um abaixo do outro, para serem lidos em ordem
+func (m *CLIModel) renderHeader() string {
+	headerLeft := m.renderHeaderInfo()
 	menuItems := m.renderMenuItems()
 	menuGrid := lipgloss.JoinVertical(lipgloss.Left, menuItems...)
 
+	if accessible {
+		return lipgloss.JoinVertical(lipgloss.Left, headerLeft, "", menuGrid)
+	}
+
 	// Montar header
 	headerContent := lipgloss.JoinHorizontal(
 		lipgloss.Top,
@@ -242,6 +329,11 @@ func (m *CLIModel) renderMainView() string {
 		lipgloss.NewStyle().Width(m.width-lipgloss.Width(headerLeft)-lipgloss.Width(menuGrid)).Render(""),
 		menuGrid,
 	)
+	return headerContent
+}
+
+func (m *CLIModel) renderMainView() string {
+	headerContent := m.renderHeader()
 
 	// Renderizar header com altura fixa
 	renderedHeader := m.styles.Header.Render(headerContent)
@@ -294,7 +386,7 @@ func (m *CLIModel) viewImportWallet() string {
 	// Renderizando o título com destaque
 	title := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#7D56F4")).
+		Foreground(palette.Accent).
 		MarginBottom(1).
 		Render(localization.Labels["import_wallet_title"])
 
@@ -302,32 +394,21 @@ func (m *CLIModel) viewImportWallet() string {
 
 	// Pequena descrição do método de importação por mnemônica
 	desc := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#AAAAAA")).
+		Foreground(palette.Muted).
 		Render(localization.Labels["import_mnemonic_desc"])
 	view.WriteString(desc + "\n\n")
 
 	// Estilo para o campo ativo
 	activeStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#00FF00")).
+		Foreground(palette.Success).
 		Bold(true)
 
 	// Estilo para campos inativos
 	inactiveStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#AAAAAA"))
+		Foreground(palette.Muted)
 
-	// Renderizar cada campo de entrada
-	for i, ti := range m.textInputs {
-		wordLabel := fmt.Sprintf("%s %d:", localization.Labels["word"], i+1)
-		paddedLabel := fmt.Sprintf("%-10s", wordLabel) // Padding para alinhamento
-
-		if i == m.importStage {
-			// Campo ativo com destaque
-			view.WriteString(activeStyle.Render(paddedLabel) + " " + ti.View() + "\n\n")
-		} else {
-			// Campos inativos
-			view.WriteString(inactiveStyle.Render(paddedLabel) + " " + ti.Value() + "\n")
-		}
-	}
+	// Renderizar os campos de entrada na grade configurada
+	view.WriteString(m.renderMnemonicInputs(m.textInputs, m.importStage, activeStyle, inactiveStyle))
 
 	// Instruções para o usuário
 	instructions := lipgloss.NewStyle().
@@ -341,7 +422,7 @@ func (m *CLIModel) viewImportWallet() string {
 	content := view.String()
 	return lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#7D56F4")).
+		BorderForeground(palette.Accent).
 		Padding(1, 2).
 		Render(content)
 }
@@ -351,13 +432,17 @@ func (m *CLIModel) viewImportWalletPassword() string {
 	if localization.Labels == nil {
 		return "Localization labels not initialized."
 	}
+	if m.kdfOp != nil {
+		return m.viewKDFStatus()
+	}
 
 	var view strings.Builder
 	view.WriteString(
 		lipgloss.NewStyle().Bold(true).Render(localization.Labels["enter_password"]+"\n\n") +
 			m.passwordInput.View() + "\n\n" +
 			m.renderPasswordValidation(m.passwordInput.Value()) + "\n\n" +
-			localization.Labels["press_enter"],
+			localization.Labels["press_enter"] +
+			m.renderInputNotice(),
 	)
 	return view.String()
 }
@@ -393,6 +478,19 @@ func (m *CLIModel) viewImportPrivateKey() string {
 	// Use MenuDesc instead of non-existent Instructions style
 	instructions := m.styles.MenuDesc.Render(localization.Labels["press_enter"])
 
+	// A chave decodificada mostra o formato e o endereço antes da senha
+	if preview := m.privateKeyPreview; preview != nil {
+		detected := fmt.Sprintf(localization.Labels["private_key_detected"], localization.Labels["private_key_format_"+string(preview.Format)])
+		address := fmt.Sprintf(localization.Labels["private_key_address"], preview.Address.Hex())
+		instructions = lipgloss.JoinVertical(
+			lipgloss.Left,
+			detected,
+			m.styles.SelectedTitle.Render(address),
+			"",
+			m.styles.MenuDesc.Render(localization.Labels["private_key_confirm"]),
+		)
+	}
+
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		title,
@@ -449,6 +547,51 @@ func (m *CLIModel) viewImportKeystore() string {
 	)
 }
 
+// listWalletsKeys são as teclas da lista de wallets. O diálogo de exclusão,
+// a busca e o campo do relatório têm as suas.
+func listWalletsKeys(m *CLIModel) []key.Binding {
+	switch {
+	case m.deletingWallet != nil:
+		return []key.Binding{
+			newBinding("←/→", "key_choose", "left", "h", "right", "l"),
+			newBinding("enter", "key_confirm", "enter"),
+			bindingIf(m.deletePhraseRequired && m.deleteArchiveDir() != "", newBinding("tab", "key_delete_archive", "tab")),
+			newBinding("esc", "key_cancel", "esc"),
+		}
+	case m.filteringWallets:
+		return []key.Binding{
+			newBinding("enter", "key_keep_filter", "enter"),
+			newBinding("esc", "key_clear_filter", "esc"),
+		}
+	case m.exportingReport:
+		return []key.Binding{
+			newBinding("enter", "key_save", "enter"),
+			newBinding("esc", "key_cancel", "esc"),
+		}
+	}
+
+	hasWallets := len(m.wallets) > 0
+	marked := len(m.markedWallets()) > 0
+	return []key.Binding{
+		bindingIf(hasWallets, newBinding("↑/↓", "key_move", "up", "k", "down", "j")),
+		bindingIf(hasWallets, newBinding("pgup/pgdn", "key_page", "pgup", "pgdown")),
+		bindingIf(hasWallets, newBinding("enter", "key_details", "enter")),
+		newBinding("/", "key_search", "/"),
+		bindingIf(hasWallets, newBinding("s", "key_sort", "s")),
+		bindingIf(hasWallets, newBinding("n", "key_notes", "n")),
+		bindingIf(hasWallets, newBinding("d", "key_delete", "d", "delete")),
+		bindingIf(hasWallets, newBinding("space", "key_mark", " ")),
+		bindingIf(hasWallets, newBinding("a", "key_mark_all", "a")),
+		bindingIf(hasWallets, newBinding("x", "key_export", "x")),
+		bindingIf(hasWallets, newBinding("r", "key_report", "r")),
+		// Ações sobre as wallets marcadas
+		bindingIf(marked, newBinding("D", "key_delete_marked", "D")),
+		bindingIf(marked, newBinding("t", "key_tag_marked", "t")),
+		bindingIf(marked, newBinding("b", "key_balances_marked", "b")),
+		bindingIf(marked, newBinding("esc", "key_clear_marks", "esc")),
+	}
+}
+
 // viewListWallets renderiza a visualização de listagem de wallets
 func (m *CLIModel) viewListWallets() string {
 	if localization.Labels == nil {
@@ -462,7 +605,7 @@ func (m *CLIModel) viewListWallets() string {
 		// Adicionar título à visualização
 		title := lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("#7D56F4")).
+			Foreground(palette.Accent).
 			MarginBottom(1).
 			Render(localization.Labels["list_wallets_title"])
 
@@ -476,24 +619,24 @@ func (m *CLIModel) viewListWallets() string {
 				message = val
 			}
 			noWalletsMsg := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#5C5C5C")).
+				Foreground(palette.Muted).
 				Render(message)
 
 			view.WriteString(noWalletsMsg)
+			view.WriteString(m.renderApprovalNotice())
 		} else {
-			// Adicionar a visualização da tabela
-			tableView := m.walletTable.View()
-			view.WriteString(tableView)
-
-			// Se houver espaço, adicionar instruções na parte inferior
-			if m.walletTable.Height() < len(m.wallets) {
-				// Só mostra instruções de rolagem se houver mais itens que o espaço disponível
-				instructions := "\n" + lipgloss.NewStyle().
-					Foreground(lipgloss.Color("#5C5C5C")).
-					Render(localization.Labels["list_wallets_instructions"])
-
-				view.WriteString(instructions)
-			}
+			// Campo de busca acima da tabela, quando em uso
+			search := m.renderWalletSearch()
+
+			below := m.renderWalletSelection() + m.renderWalletReport() + m.renderDeleteStatus() + m.renderApprovalNotice()
+
+			// A tabela ocupa o espaço que sobra das demais linhas, com a posição
+			// e a página logo abaixo dela
+			m.fitWalletTable(title + "\n" + search + m.renderWalletPosition() + below)
+			view.WriteString(search)
+			view.WriteString(m.walletTable.View())
+			view.WriteString("\n" + m.renderWalletPosition())
+			view.WriteString(below)
 		}
 
 		return view.String()
@@ -516,7 +659,7 @@ func (m *CLIModel) renderDeleteConfirmationDialog() string {
 
 	// Caixa de diálogo centralizada com botões estilizados e seleção
 	question := localization.Labels["confirm_delete_wallet"]
-	address := fmt.Sprintf("%s: %s", localization.Labels["ethereum_address"], m.deletingWallet.Address)
+	address := fmt.Sprintf("%s: %s", localization.Labels["ethereum_address"], wallet.ChecksumAddress(m.deletingWallet.Address))
 
 	// Botões com seleção (garante espaçamento entre os textos)
 	var confirmBtn, cancelBtn string
@@ -527,9 +670,16 @@ func (m *CLIModel) renderDeleteConfirmationDialog() string {
 		confirmBtn = m.styles.DialogButton.Render(localization.Labels["confirm"])
 		cancelBtn = m.styles.DialogButtonActive.Render(localization.Labels["cancel"])
 	}
+	if !m.deleteConfirmed() {
+		// Excluir fica apagado até a frase de confirmação ser digitada
+		confirmBtn = m.styles.DialogButton.Faint(true).Render(localization.Labels["confirm"])
+	}
 
 	buttons := lipgloss.JoinHorizontal(lipgloss.Center, confirmBtn, "   ", cancelBtn)
 	content := lipgloss.JoinVertical(lipgloss.Center, question, address, "", buttons)
+	if phrase := m.renderDeletePhrase(); phrase != "" {
+		content = lipgloss.JoinVertical(lipgloss.Center, question, address, "", phrase, "", buttons)
+	}
 	dialog := m.styles.Dialog.Render(content)
 
 	// Calcular a posição do diálogo para centralizá-lo na área da tabela
@@ -544,8 +694,13 @@ func (m *CLIModel) renderDeleteConfirmationDialog() string {
 		leftPadding = 0
 	}
 
-	// Dividir a tabela em linhas
+	// Dividir a tabela em linhas, completando com linhas vazias quando o
+	// diálogo for mais alto que ela
 	tableLines := strings.Split(tableView, "\n")
+	for len(tableLines) < dialogHeight {
+		tableLines = append(tableLines, "")
+	}
+	tableHeight = len(tableLines)
 
 	// Calcular a linha inicial para o diálogo
 	startLine := (tableHeight - dialogHeight) / 2
@@ -586,13 +741,17 @@ func (m *CLIModel) viewWalletPassword() string {
 	if localization.Labels == nil {
 		return "Localization labels not initialized."
 	}
+	if m.kdfOp != nil {
+		return m.viewKDFStatus()
+	}
 
 	var view strings.Builder
 	view.WriteString(
 		lipgloss.NewStyle().Bold(true).Render(localization.Labels["enter_wallet_password"]+"\n\n") +
 			m.passwordInput.View() + "\n\n" +
 			m.renderPasswordValidation(m.passwordInput.Value()) + "\n\n" +
-			localization.Labels["press_enter"],
+			localization.Labels["press_enter"] +
+			m.renderInputNotice(),
 	)
 	return view.String()
 }
@@ -621,6 +780,10 @@ func (m *CLIModel) viewWalletDetails() string {
 			} else {
 				methodName = "Keystore File" // Fallback
 			}
+		case wallet.ImportMethodDerived:
+			methodName = fmt.Sprintf(localization.Labels["method_derived"], m.walletDetails.Wallet.DerivationPath)
+		case wallet.ImportMethodWatchOnly:
+			methodName = localization.Labels["watch_only"]
 		default:
 			methodName = string(m.walletDetails.ImportMethod)
 		}
@@ -628,7 +791,8 @@ func (m *CLIModel) viewWalletDetails() string {
 		// Determine mnemonic text based on import method
 		mnemonicText := ""
 		if m.walletDetails.HasMnemonic && m.walletDetails.Mnemonic != nil && *m.walletDetails.Mnemonic != "" {
-			mnemonicText = *m.walletDetails.Mnemonic
+			// The words go below the label, in the configured grid
+			mnemonicText = "\n" + strings.TrimRight(m.renderMnemonic(*m.walletDetails.Mnemonic), "\n")
 		} else {
 			// Use specific message based on import method
 			switch m.walletDetails.ImportMethod {
@@ -641,89 +805,66 @@ func (m *CLIModel) viewWalletDetails() string {
 			}
 		}
 
+		// Keys held by a device or a KMS have no private key to show
+		keyLines := ""
+		if m.walletDetails.PrivateKey != nil {
+			keyLines += fmt.Sprintf("%-*s 0x%x\n", 20, localization.Labels["private_key"], crypto.FromECDSA(m.walletDetails.PrivateKey))
+		}
+		if m.walletDetails.PublicKey != nil {
+			keyLines += fmt.Sprintf("%-*s %x\n", 20, localization.Labels["public_key"], crypto.FromECDSAPub(m.walletDetails.PublicKey))
+		}
+
+		// Notes and external references, when the wallet has any
+		notesLines := ""
+		if m.walletDetails.Wallet.Notes != "" {
+			notesLines += fmt.Sprintf("%-*s %s\n", 20, localization.Labels["notes_details_label"], m.walletDetails.Wallet.Notes)
+		}
+		if len(m.walletDetails.Wallet.References) > 0 {
+			notesLines += fmt.Sprintf("%-*s %s\n", 20, localization.Labels["references_details_label"], strings.Join(m.walletDetails.Wallet.References, ", "))
+		}
+
 		view.WriteString(
 			lipgloss.NewStyle().Bold(true).Render(localization.Labels["wallet_details_title"]+"\n\n") +
-				fmt.Sprintf("%-*s %s\n", 20, localization.Labels["ethereum_address"], m.walletDetails.Wallet.Address) +
-				fmt.Sprintf("%-*s 0x%x\n", 20, localization.Labels["private_key"], crypto.FromECDSA(m.walletDetails.PrivateKey)) +
-				fmt.Sprintf("%-*s %x\n", 20, localization.Labels["public_key"], crypto.FromECDSAPub(m.walletDetails.PublicKey)) +
+				fmt.Sprintf("%-*s %s\n", 20, localization.Labels["ethereum_address"], wallet.ChecksumAddress(m.walletDetails.Wallet.Address)) +
+				keyLines +
 				fmt.Sprintf("%-*s %s\n", 20, methodLabel+":", methodName) +
-				fmt.Sprintf("%-*s %s\n\n", 20, localization.Labels["mnemonic_phrase_label"], mnemonicText),
+				fmt.Sprintf("%-*s %s\n", 20, localization.Labels["mnemonic_phrase_label"], mnemonicText) +
+				notesLines + "\n",
 		)
 
 		// Add balance information
 		view.WriteString(m.renderWalletBalances())
 
-		view.WriteString("\n" + localization.Labels["press_esc"])
+		if m.clipboardStatus != "" {
+			view.WriteString("\n" + m.styles.SuccessStyle.Render(glyphs.Check+" "+m.clipboardStatus))
+		}
 		return view.String()
 	}
 	return localization.Labels["select_wallet_prompt"]
 }
 
-// renderWalletBalances renders balance information for the wallet
-func (m *CLIModel) renderWalletBalances() string {
+// walletDetailsKeys são as teclas dos detalhes da wallet; carteiras somente
+// leitura não exportam, assinam nem enviam
+func walletDetailsKeys(m *CLIModel) []key.Binding {
 	if m.walletDetails == nil {
-		return ""
-	}
-
-	var balanceView strings.Builder
-	balanceView.WriteString(lipgloss.NewStyle().Bold(true).Render("Balance Information:\n"))
-
-	// Create a simple provider for Ethereum mainnet
-	ethProvider, err := blockchain.NewEthereum("https://eth.llamarpc.com", 5*time.Second, "ETH", 18, "Ethereum")
-	if err != nil {
-		balanceView.WriteString("❌ Failed to connect to Ethereum network\n")
-		return balanceView.String()
-	}
-	defer ethProvider.Close()
-
-	// Get balance
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	balance, err := ethProvider.GetBalance(ctx, m.walletDetails.Wallet.Address)
-	if err != nil {
-		balanceView.WriteString("❌ Failed to fetch balance: " + err.Error() + "\n")
-		return balanceView.String()
+		return nil
+	}
+	signs := !m.walletDetails.Wallet.WatchOnly()
+	mnemonic := m.walletDetails.Mnemonic != nil && *m.walletDetails.Mnemonic != ""
+	return []key.Binding{
+		bindingIf(signs, newBinding("e", "key_export_wallet", "e")),
+		bindingIf(signs, newBinding("t", "key_sign", "t")),
+		bindingIf(signs, newBinding("s", "key_send", "s")),
+		newBinding("o", "key_rpc", "o"),
+		newBinding("r", "key_refresh_balances", "r"),
+		newBinding("h", "key_dust", "h"),
+		newBinding("k", "key_tokens", "k"),
+		bindingIf(m.walletDetails.HasMnemonic, newBinding("c", "key_children", "c")),
+		newBinding("a", "key_copy_address", "a"),
+		bindingIf(m.walletDetails.PrivateKey != nil, newBinding("p", "key_copy_private_key", "p")),
+		bindingIf(mnemonic, newBinding("m", "key_copy_mnemonic", "m")),
+		bindingIf(mnemonic && m.revealOneWord(), newBinding("tab/shift+tab", "key_reveal_word", "tab", "shift+tab")),
 	}
-
-	// Convert Wei to ETH
-	ethBalance := new(big.Float)
-	ethBalance.SetString(balance.String())
-	ethBalance.Quo(ethBalance, big.NewFloat(1e18))
-
-	balanceView.WriteString(fmt.Sprintf("🔹 Ethereum Mainnet: %s ETH\n", ethBalance.Text('f', 6)))
-
-	// Add other networks if available
-	if m.currentConfig != nil && m.currentConfig.Networks != nil {
-		for _, network := range m.currentConfig.Networks {
-			if !network.IsActive || network.RPCEndpoint == "" {
-				continue
-			}
-
-			provider, err := blockchain.NewEthereum(network.RPCEndpoint, 10*time.Second, network.Symbol, 18, network.Name)
-			if err != nil {
-				balanceView.WriteString(fmt.Sprintf("❌ %s: Connection failed\n", network.Name))
-				continue
-			}
-
-			balance, err := provider.GetBalance(ctx, m.walletDetails.Wallet.Address)
-			provider.Close()
-
-			if err != nil {
-				balanceView.WriteString(fmt.Sprintf("❌ %s: %s\n", network.Name, err.Error()))
-				continue
-			}
-
-			// Convert to human readable format
-			tokenBalance := new(big.Float)
-			tokenBalance.SetString(balance.String())
-			tokenBalance.Quo(tokenBalance, big.NewFloat(1e18))
-
-			balanceView.WriteString(fmt.Sprintf("🔹 %s: %s %s\n", network.Name, tokenBalance.Text('f', 6), network.Symbol))
-		}
-	}
-
-	return balanceView.String()
 }
 
 // viewLanguageSelection renderiza a visualização de seleção de idioma
@@ -0,0 +1,622 @@
+package ui
+
+import (
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/compliance"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/jobs"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// walletBatchAction is what a batch does to each marked wallet
+type walletBatchAction int
+
+const (
+	walletBatchDelete walletBatchAction = iota
+	walletBatchTag
+	walletBatchBalances
+)
+
+// Stages of a batch: the confirmation form, running, the summary
+const (
+	walletBatchStageForm = iota
+	walletBatchStageRunning
+	walletBatchStageDone
+)
+
+// walletBatchListed bounds the wallets listed on the form
+const walletBatchListed = 8
+
+// walletBatchResult is the outcome of the batch for one wallet
+type walletBatchResult struct {
+	Wallet wallet.Wallet
+	Detail string
+	Err    error
+}
+
+// walletBatchStepMsg carries the outcome of one wallet of the running batch
+type walletBatchStepMsg struct {
+	seq     int
+	result  walletBatchResult
+	updates <-chan walletBatchResult
+}
+
+// walletBatchDoneMsg tells the batch stopped, after the last wallet or when cancelled
+type walletBatchDoneMsg struct {
+	seq int
+}
+
+// errWalletBatchNotProcessed marks the wallets left when the batch was stopped
+var errWalletBatchNotProcessed = errors.New("not processed")
+
+// markedWallets returns the wallets marked in the list, in the list order
+func (m *CLIModel) markedWallets() []wallet.Wallet {
+	var marked []wallet.Wallet
+	for _, w := range sortWallets(m.wallets, m.walletSort) {
+		if m.batchExportMarked[w.Address] {
+			marked = append(marked, w)
+		}
+	}
+	return marked
+}
+
+// toggleAllWalletMarks marks every wallet shown by the search, or unmarks
+// them when they all are
+func (m *CLIModel) toggleAllWalletMarks() {
+	visible := m.visibleWallets()
+	if len(visible) == 0 {
+		return
+	}
+	if m.batchExportMarked == nil {
+		m.batchExportMarked = make(map[string]bool)
+	}
+	all := true
+	for _, w := range visible {
+		if !m.batchExportMarked[w.Address] {
+			all = false
+			break
+		}
+	}
+	for _, w := range visible {
+		if all {
+			delete(m.batchExportMarked, w.Address)
+		} else {
+			m.batchExportMarked[w.Address] = true
+		}
+	}
+	m.applyWalletFilter()
+}
+
+// clearWalletMarks unmarks every wallet of the list
+func (m *CLIModel) clearWalletMarks() {
+	m.batchExportMarked = nil
+	m.applyWalletFilter()
+}
+
+// renderWalletSelection lists the batch actions while wallets are marked
+func (m *CLIModel) renderWalletSelection() string {
+	count := len(m.markedWallets())
+	if count == 0 {
+		return ""
+	}
+	return "\n" + m.styles.SelectedTitle.Render(localization.Plural("wallets_selected", count))
+}
+
+// initWalletBatch opens the confirmation of a batch action on the marked wallets
+func (m *CLIModel) initWalletBatch(action walletBatchAction) tea.Cmd {
+	selected := m.markedWallets()
+	if len(selected) == 0 {
+		return nil
+	}
+	m.walletBatchAction = action
+	m.walletBatchWallets = selected
+	m.walletBatchStage = walletBatchStageForm
+	m.walletBatchResults = nil
+	m.walletBatchArchive = false
+	m.walletBatchNetworks = nil
+	m.setWalletBatchStatus("", false)
+	m.walletBatchInput = textinput.New()
+	m.walletBatchInput.CharLimit = 128
+	m.walletBatchInput.Width = 30
+	m.currentView = constants.WalletBatchView
+
+	switch action {
+	case walletBatchDelete:
+		m.walletBatchArchive = m.deleteArchiveDir() != "" && m.currentConfig.Backup.ArchiveBeforeDelete
+		m.walletBatchInput.Placeholder = strconv.Itoa(len(selected))
+	case walletBatchTag:
+		m.walletBatchInput.Placeholder = localization.Labels["wallet_batch_tag_placeholder"]
+	case walletBatchBalances:
+		if m.currentConfig == nil {
+			if err := m.ensureConfigAndNetworksLoaded(); err != nil {
+				m.setWalletBatchStatus(err.Error(), true)
+				return nil
+			}
+		}
+		m.walletBatchNetworks = make(map[string]config.Network)
+		for key, network := range m.currentConfig.Networks {
+			if network.Usable() && network.RPCEndpoint != "" && !m.hidesNetwork(network) {
+				m.walletBatchNetworks[key] = network
+			}
+		}
+		return nil
+	}
+	return m.walletBatchInput.Focus()
+}
+
+// walletBatchWithoutMnemonic counts the marked wallets whose key exists only
+// in their keystore
+func (m *CLIModel) walletBatchWithoutMnemonic() int {
+	count := 0
+	for _, w := range m.walletBatchWallets {
+		if !w.WatchOnly() && !w.HasMnemonic() {
+			count++
+		}
+	}
+	return count
+}
+
+// walletBatchCapturesText reports whether keys go to the text field
+func (m *CLIModel) walletBatchCapturesText() bool {
+	return m.walletBatchStage == walletBatchStageForm && m.walletBatchAction != walletBatchBalances
+}
+
+// updateWalletBatch handles input on the batch screen
+func (m *CLIModel) updateWalletBatch(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch m.walletBatchStage {
+	case walletBatchStageRunning:
+		return m, nil
+	case walletBatchStageDone:
+		if keyMsg.String() == "enter" {
+			return m, m.closeWalletBatch()
+		}
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "enter":
+		if m.approvalNotice != "" {
+			// Already waiting for the approval of this batch
+			return m, nil
+		}
+		return m, m.startWalletBatch()
+	case "tab":
+		if m.walletBatchAction == walletBatchDelete && m.deleteArchiveDir() != "" && m.walletBatchWithoutMnemonic() > 0 {
+			m.walletBatchArchive = !m.walletBatchArchive
+		}
+		return m, nil
+	}
+	if m.walletBatchAction == walletBatchBalances {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.walletBatchInput, cmd = updateTextInput(m.walletBatchInput, msg)
+	return m, cmd
+}
+
+// startWalletBatch checks the form and runs the batch; deleting waits for
+// the approval of the whole batch when compliance mode is on
+func (m *CLIModel) startWalletBatch() tea.Cmd {
+	value := strings.TrimSpace(m.walletBatchInput.Value())
+	switch m.walletBatchAction {
+	case walletBatchDelete:
+		if value != strconv.Itoa(len(m.walletBatchWallets)) {
+			m.setWalletBatchStatus(localization.Labels["wallet_batch_delete_mismatch"], true)
+			return nil
+		}
+		addresses := make([]string, len(m.walletBatchWallets))
+		for i, w := range m.walletBatchWallets {
+			addresses[i] = w.Address
+		}
+		var updates <-chan walletBatchResult
+		return m.withApproval(approvedAction{
+			action:  compliance.ActionDeleteWallet,
+			subject: fmt.Sprintf("%d wallets: %s", len(addresses), strings.Join(addresses, ", ")),
+			run: func() error {
+				var err error
+				updates, err = m.runWalletBatch()
+				return err
+			},
+			done: func(err error) tea.Cmd {
+				return m.walletBatchStarted(updates, err)
+			},
+		})
+	case walletBatchTag:
+		if value == "" {
+			m.setWalletBatchStatus(localization.Labels["wallet_batch_tag_required"], true)
+			return nil
+		}
+		if strings.Contains(value, ",") {
+			m.setWalletBatchStatus(localization.Labels["wallet_batch_tag_comma"], true)
+			return nil
+		}
+	case walletBatchBalances:
+		if len(m.walletBatchNetworks) == 0 {
+			m.setWalletBatchStatus(localization.Labels["balance_no_networks"], true)
+			return nil
+		}
+	}
+	updates, err := m.runWalletBatch()
+	return m.walletBatchStarted(updates, err)
+}
+
+// runWalletBatch submits the batch to the job manager, so it shows in the
+// Jobs view and can be cancelled from there too. Each wallet is reported on
+// the returned channel, closed once the batch stops.
+func (m *CLIModel) runWalletBatch() (<-chan walletBatchResult, error) {
+	step := m.walletBatchStep()
+	wallets := m.walletBatchWallets
+	kind, name := jobs.KindWalletBatch, fmt.Sprintf("%s (%d)", m.walletBatchTitle(), len(wallets))
+	if m.walletBatchAction == walletBatchBalances {
+		kind = jobs.KindBalanceRefresh
+	}
+
+	m.walletBatchSeq++
+	updates := make(chan walletBatchResult, len(wallets))
+	id, err := m.jobManager.Submit(kind, name, func(ctx context.Context, report jobs.ProgressFunc) error {
+		defer close(updates)
+		for i, w := range wallets {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			report(float64(i)/float64(len(wallets)), w.Name)
+			detail, err := step(ctx, w)
+			updates <- walletBatchResult{Wallet: w, Detail: detail, Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, jobs.ErrConflict) {
+			err = errors.New(localization.Labels["job_conflict"])
+		}
+		return nil, err
+	}
+	m.walletBatchJobID = id
+	return updates, nil
+}
+
+// walletBatchStep returns the work done on each wallet, built here so the job
+// never reads the model
+func (m *CLIModel) walletBatchStep() func(ctx context.Context, w wallet.Wallet) (string, error) {
+	ws := m.Service
+	switch m.walletBatchAction {
+	case walletBatchDelete:
+		archiveDir := ""
+		if m.walletBatchArchive {
+			archiveDir = m.deleteArchiveDir()
+		}
+		return func(ctx context.Context, w wallet.Wallet) (string, error) {
+			return deleteBatchWallet(ws, w, archiveDir)
+		}
+	case walletBatchTag:
+		tag := strings.TrimSpace(m.walletBatchInput.Value())
+		return func(ctx context.Context, w wallet.Wallet) (string, error) {
+			for _, ref := range w.References {
+				if ref == tag {
+					return fmt.Sprintf(localization.Labels["wallet_batch_already_tagged"], tag), nil
+				}
+			}
+			references := append([]string{}, w.References...)
+			if err := ws.UpdateWalletNotes(&w, w.Notes, append(references, tag), time.Now()); err != nil {
+				return "", walletBatchError(err)
+			}
+			return fmt.Sprintf(localization.Labels["wallet_batch_tagged"], tag), nil
+		}
+	default:
+		balances, networks := m.balanceService(), m.walletBatchNetworks
+		return func(ctx context.Context, w wallet.Wallet) (string, error) {
+			return refreshBatchBalances(ctx, ws, balances, networks, w)
+		}
+	}
+}
+
+// deleteBatchWallet deletes one wallet of the batch, copying its keystore
+// first when it has no mnemonic and archiveDir is set
+func deleteBatchWallet(ws *wallet.WalletService, w wallet.Wallet, archiveDir string) (string, error) {
+	archived := ""
+	if archiveDir != "" && !w.WatchOnly() && !w.HasMnemonic() {
+		path, err := ws.ArchiveKeystore(&w, archiveDir, time.Now())
+		if err != nil {
+			return "", fmt.Errorf(localization.Labels["delete_archive_failed"], err)
+		}
+		archived = path
+	}
+	if err := ws.DeleteWallet(&w); err != nil {
+		return "", walletBatchError(err)
+	}
+	if archived != "" {
+		return fmt.Sprintf(localization.Labels["wallet_batch_archived"], archived), nil
+	}
+	return localization.Labels["wallet_batch_deleted"], nil
+}
+
+// refreshBatchBalances drops the cached balances of the wallet and reads its
+// native balance on every network again, through its RPC overrides
+func refreshBatchBalances(ctx context.Context, ws *wallet.WalletService, balances *blockchain.BalanceService, base map[string]config.Network, w wallet.Wallet) (string, error) {
+	networks := make(map[string]config.Network, len(base))
+	for key, network := range base {
+		networks[key] = ws.NetworkFor(w.Address, network)
+	}
+	balances.Invalidate(w.Address)
+
+	var funded []string
+	failed := 0
+	for balance := range balances.Fetch(ctx, w.Address, networks) {
+		if balance.Error != nil || balance.Amount == nil {
+			failed++
+			continue
+		}
+		if balance.Amount.Sign() > 0 {
+			funded = append(funded, formatBalance(balance.Amount, balance.Decimals)+" "+networks[balance.NetworkKey].Symbol)
+		}
+	}
+	if failed == len(networks) {
+		return "", errors.New(localization.Labels["wallet_batch_all_failed"])
+	}
+	sort.Strings(funded)
+	detail := strings.Join(funded, " · ")
+	if detail == "" {
+		detail = localization.Labels["wallet_batch_no_funds"]
+	}
+	if failed > 0 {
+		detail += " (" + fmt.Sprintf(localization.Labels["wallet_batch_networks_failed"], failed) + ")"
+	}
+	return detail, nil
+}
+
+// walletBatchError explains a wallet changed in another session
+func walletBatchError(err error) error {
+	if errors.Is(err, wallet.ErrStaleWallet) {
+		return errors.New(localization.Labels["wallet_changed_elsewhere"])
+	}
+	return err
+}
+
+// walletBatchStarted shows the progress of the submitted batch, or why it
+// could not start. A batch approved after leaving the screen still runs and
+// shows only in the Jobs view.
+func (m *CLIModel) walletBatchStarted(updates <-chan walletBatchResult, err error) tea.Cmd {
+	if m.currentView != constants.WalletBatchView || m.walletBatchStage != walletBatchStageForm {
+		return nil
+	}
+	if err != nil {
+		m.setWalletBatchStatus(err.Error(), true)
+		return nil
+	}
+	m.setWalletBatchStatus("", false)
+	m.walletBatchStage = walletBatchStageRunning
+	m.walletBatchCancelling = false
+	m.walletBatchProgress = NewImportProgressModel(len(m.walletBatchWallets), m.styles)
+	m.walletBatchInput.Blur()
+	return waitForWalletBatch(m.walletBatchSeq, updates)
+}
+
+// waitForWalletBatch delivers the outcome of the next wallet, or the end of
+// the batch once its channel is closed
+func waitForWalletBatch(seq int, updates <-chan walletBatchResult) tea.Cmd {
+	return func() tea.Msg {
+		result, ok := <-updates
+		if !ok {
+			return walletBatchDoneMsg{seq: seq}
+		}
+		return walletBatchStepMsg{seq: seq, result: result, updates: updates}
+	}
+}
+
+// handleWalletBatchStep shows the outcome of one wallet and waits for the next
+func (m *CLIModel) handleWalletBatchStep(msg walletBatchStepMsg) tea.Cmd {
+	if msg.seq == m.walletBatchSeq {
+		m.walletBatchResults = append(m.walletBatchResults, msg.result)
+		m.walletBatchProgress.UpdateProgress(msg.result.Wallet.Name, len(m.walletBatchResults))
+		if msg.result.Err != nil {
+			m.walletBatchProgress.AddError(msg.result.Wallet.Name, msg.result.Err, false)
+		} else if m.walletBatchAction == walletBatchDelete {
+			delete(m.batchExportMarked, msg.result.Wallet.Address)
+		}
+	}
+	// Drained either way so the batch never waits on the screen
+	return waitForWalletBatch(msg.seq, msg.updates)
+}
+
+// handleWalletBatchDone lists the wallets a stopped batch did not reach and
+// shows the summary
+func (m *CLIModel) handleWalletBatchDone(msg walletBatchDoneMsg) {
+	if msg.seq != m.walletBatchSeq {
+		return
+	}
+	for _, w := range m.walletBatchWallets[len(m.walletBatchResults):] {
+		m.walletBatchResults = append(m.walletBatchResults, walletBatchResult{Wallet: w, Err: errWalletBatchNotProcessed})
+		m.walletBatchProgress.AddError(w.Name, errWalletBatchNotProcessed, true)
+	}
+	m.walletBatchProgress.Complete()
+	m.walletBatchStage = walletBatchStageDone
+	m.walletBatchJobID = ""
+}
+
+// closeWalletBatch handles esc: it cancels the running batch after the
+// current wallet, or goes back to the list
+func (m *CLIModel) closeWalletBatch() tea.Cmd {
+	if m.walletBatchStage == walletBatchStageRunning {
+		if m.walletBatchJobID != "" && !m.walletBatchCancelling {
+			m.walletBatchCancelling = true
+			_ = m.jobManager.Cancel(m.walletBatchJobID)
+		}
+		return nil
+	}
+	m.currentView = constants.ListWalletsView
+	return m.refreshWalletsTable()
+}
+
+func (m *CLIModel) setWalletBatchStatus(status string, failed bool) {
+	m.walletBatchStatus = status
+	m.walletBatchFailed = failed
+}
+
+// walletBatchTitle is the title of the batch screen for its action
+func (m *CLIModel) walletBatchTitle() string {
+	switch m.walletBatchAction {
+	case walletBatchTag:
+		return localization.Labels["wallet_batch_tag_title"]
+	case walletBatchBalances:
+		return localization.Labels["wallet_batch_balances_title"]
+	}
+	return localization.Labels["wallet_batch_delete_title"]
+}
+
+// walletBatchRunningLabel is the key of the line shown while the batch runs
+func (m *CLIModel) walletBatchRunningLabel() string {
+	switch m.walletBatchAction {
+	case walletBatchTag:
+		return "wallet_batch_running_tag"
+	case walletBatchBalances:
+		return "wallet_batch_running_balances"
+	}
+	return "wallet_batch_running_delete"
+}
+
+// viewWalletBatch renders the batch form, its progress and summary
+func (m *CLIModel) viewWalletBatch() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(m.walletBatchTitle()))
+	b.WriteString("\n\n")
+
+	help := "wallet_batch_form_help"
+	switch m.walletBatchStage {
+	case walletBatchStageForm:
+		b.WriteString(m.viewWalletBatchForm())
+		if m.walletBatchAction == walletBatchDelete {
+			help = "wallet_batch_delete_help"
+		}
+	case walletBatchStageRunning:
+		help = "wallet_batch_running_help"
+		p := m.walletBatchProgress
+		b.WriteString(fmt.Sprintf(localization.Labels[m.walletBatchRunningLabel()], len(m.walletBatchWallets)))
+		b.WriteString("\n\n")
+		b.WriteString(p.ViewAs(p.GetPercentage()))
+		b.WriteString(fmt.Sprintf("  %d/%d\n", len(m.walletBatchResults), len(m.walletBatchWallets)))
+		if n := len(m.walletBatchResults); n > 0 {
+			b.WriteString(m.styles.MenuDesc.Render(m.walletBatchResults[n-1].Wallet.Name))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		if m.walletBatchCancelling {
+			b.WriteString(m.styles.MenuDesc.Render(localization.Labels["wallet_batch_cancelling"]))
+			b.WriteString("\n\n")
+		}
+	case walletBatchStageDone:
+		help = "wallet_batch_done_help"
+		b.WriteString(m.viewWalletBatchSummary())
+	}
+
+	if m.walletBatchStatus != "" {
+		if m.walletBatchFailed {
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.walletBatchStatus))
+		} else {
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.walletBatchStatus))
+		}
+		b.WriteString("\n\n")
+	}
+	if m.approvalNotice != "" {
+		b.WriteString(m.renderApprovalNotice())
+		b.WriteString("\n\n")
+	}
+	b.WriteString(m.styles.MenuDesc.Render(localization.Labels[help]))
+	return b.String()
+}
+
+// viewWalletBatchForm lists the marked wallets and asks what the action needs
+func (m *CLIModel) viewWalletBatchForm() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(localization.Labels["wallet_batch_wallets"], len(m.walletBatchWallets)))
+	b.WriteString("\n")
+	for i, w := range m.walletBatchWallets {
+		if i == walletBatchListed {
+			b.WriteString(m.styles.MenuDesc.Render("  " + fmt.Sprintf(localization.Labels["wallet_batch_more"], len(m.walletBatchWallets)-i)))
+			b.WriteString("\n")
+			break
+		}
+		line := fmt.Sprintf("  %-24s %s", truncateRunes(w.Name, 24), wallet.ChecksumAddress(w.Address))
+		if m.walletBatchAction == walletBatchDelete && !w.WatchOnly() && !w.HasMnemonic() {
+			line += " " + m.styles.ErrorStyle.Render("("+localization.Labels["wallet_batch_no_mnemonic"]+")")
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	switch m.walletBatchAction {
+	case walletBatchDelete:
+		if without := m.walletBatchWithoutMnemonic(); without > 0 {
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Warning + " " + fmt.Sprintf(localization.Labels["wallet_batch_delete_warning"], without)))
+			b.WriteString("\n")
+			if dir := m.deleteArchiveDir(); dir != "" {
+				box := glyphs.Unchecked
+				if m.walletBatchArchive {
+					box = glyphs.Checked
+				}
+				b.WriteString(box + " " + fmt.Sprintf(localization.Labels["wallet_batch_archive_option"], dir))
+				b.WriteString("\n")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(fmt.Sprintf(localization.Labels["wallet_batch_delete_prompt"], len(m.walletBatchWallets)))
+		b.WriteString("\n")
+		b.WriteString(m.walletBatchInput.View())
+		b.WriteString("\n\n")
+	case walletBatchTag:
+		b.WriteString(localization.Labels["wallet_batch_tag_prompt"])
+		b.WriteString("\n")
+		b.WriteString(m.walletBatchInput.View())
+		b.WriteString("\n\n")
+	case walletBatchBalances:
+		b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf(localization.Labels["wallet_batch_balances_desc"], len(m.walletBatchNetworks))))
+		b.WriteString("\n\n")
+	}
+	return b.String()
+}
+
+// viewWalletBatchSummary lists how each wallet went, as the batch export does
+func (m *CLIModel) viewWalletBatchSummary() string {
+	var b strings.Builder
+	failed := len(m.walletBatchProgress.GetFailedErrors())
+	skipped := len(m.walletBatchProgress.GetSkippedErrors())
+	title := m.styles.SuccessStyle.Render(glyphs.Check + " " + localization.Labels["wallet_batch_done"])
+	if failed > 0 {
+		title = m.styles.ErrorStyle.Render(glyphs.Cross + " " + localization.Labels["wallet_batch_done_errors"])
+	}
+	b.WriteString(title)
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["wallet_batch_summary"], len(m.walletBatchResults)-failed-skipped, failed, skipped))
+	b.WriteString("\n\n")
+	for _, result := range m.walletBatchResults {
+		name := truncateRunes(result.Wallet.Name, 24)
+		switch {
+		case errors.Is(result.Err, errWalletBatchNotProcessed):
+			b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf("– %-24s %s", name, localization.Labels["wallet_batch_not_processed"])))
+		case result.Err != nil:
+			b.WriteString(m.styles.ErrorStyle.Render(fmt.Sprintf("%s %-24s %s", glyphs.Cross, name, result.Err)))
+		default:
+			b.WriteString(m.styles.SuccessStyle.Render(fmt.Sprintf("%s %-24s %s", glyphs.Check, name, result.Detail)))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/jobs"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWalletBatchModel(t *testing.T, wallets ...*wallet.Wallet) *CLIModel {
+	t.Helper()
+	localization.SetCurrentLanguage("en")
+	localization.AddNotesMessages()
+	localization.AddWalletBatchMessages()
+	localization.AddBalanceMessages()
+
+	dir := t.TempDir()
+	cfg := &config.Config{AppDir: dir, DatabasePath: filepath.Join(dir, "wallets.db"), Backup: config.BackupConfig{ArchiveBeforeDelete: true}}
+	repo, err := storage.NewWalletRepository(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = repo.Close() })
+	for _, w := range wallets {
+		require.NoError(t, repo.AddWallet(w))
+	}
+
+	m := &CLIModel{Service: &wallet.WalletService{Repo: repo}, jobManager: jobs.NewManager(), styles: createStyles(), width: 160, currentConfig: cfg}
+	m.initListWallets()
+	require.Equal(t, constants.ListWalletsView, m.currentView)
+	return m
+}
+
+func TestWalletBatch_DeleteMarkedWallets(t *testing.T) {
+	keystorePath := filepath.Join(t.TempDir(), "hot.json")
+	require.NoError(t, os.WriteFile(keystorePath, []byte(`{"version":3}`), 0600))
+	mnemonic := "encrypted"
+	m := newWalletBatchModel(t,
+		&wallet.Wallet{Name: "Hot", Address: "0x01", KeyStorePath: keystorePath, ImportMethod: string(wallet.ImportMethodPrivateKey), SourceHash: "hot"},
+		&wallet.Wallet{Name: "Seed", Address: "0x02", KeyStorePath: filepath.Join(t.TempDir(), "seed.json"), ImportMethod: string(wallet.ImportMethodMnemonic), Mnemonic: &mnemonic, SourceHash: "seed"},
+		&wallet.Wallet{Name: "Kept", Address: "0x03", ImportMethod: string(wallet.ImportMethodWatchOnly), SourceHash: "kept"},
+	)
+
+	// a marks every wallet, space unmarks the one under the cursor
+	batchKey(m, "a")
+	assert.Len(t, m.markedWallets(), 3)
+	m.walletTable.SetCursor(2)
+	batchKey(m, " ")
+	assert.Contains(t, m.viewListWallets(), "2 wallets selected")
+	assert.Contains(t, m.renderShortHelp(0), "D delete marked")
+
+	batchKey(m, "D")
+	require.Equal(t, constants.WalletBatchView, m.currentView)
+	assert.True(t, m.capturesTextInput())
+	view := m.viewWalletBatch()
+	assert.Contains(t, view, "1 of these wallets have no mnemonic")
+	assert.Contains(t, view, "Copy their keystores to "+filepath.Join(m.currentConfig.AppDir, "deleted"))
+
+	// The count of wallets confirms the deletion
+	m.walletBatchInput.SetValue("3")
+	runBatchExportCmds(m, batchKey(m, "enter"))
+	assert.Equal(t, walletBatchStageForm, m.walletBatchStage)
+	assert.Contains(t, m.viewWalletBatch(), "Type the number of selected wallets")
+	m.walletBatchInput.SetValue("2")
+	runBatchExportCmds(m, batchKey(m, "enter"))
+
+	require.Equal(t, walletBatchStageDone, m.walletBatchStage)
+	assert.Contains(t, m.viewWalletBatch(), "2 succeeded, 0 failed, 0 not processed")
+	assert.Empty(t, m.batchExportMarked, "deleted wallets are no longer marked")
+	copies, err := filepath.Glob(filepath.Join(m.currentConfig.AppDir, "deleted", "*.json"))
+	require.NoError(t, err)
+	assert.Len(t, copies, 1, "only the wallet without a mnemonic is copied")
+	assert.NoFileExists(t, keystorePath)
+
+	job, err := m.jobManager.Get(m.jobManager.List()[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, jobs.KindWalletBatch, job.Kind)
+
+	runBatchExportCmds(m, batchKey(m, "enter"))
+	assert.Equal(t, constants.ListWalletsView, m.currentView)
+	wallets, err := m.Service.GetAllWallets()
+	require.NoError(t, err)
+	require.Len(t, wallets, 1)
+	assert.Equal(t, "Kept", wallets[0].Name)
+}
+
+func TestWalletBatch_TagMarkedWallets(t *testing.T) {
+	m := newWalletBatchModel(t,
+		&wallet.Wallet{Name: "One", Address: "0x01", ImportMethod: string(wallet.ImportMethodWatchOnly), SourceHash: "one"},
+		&wallet.Wallet{Name: "Two", Address: "0x02", ImportMethod: string(wallet.ImportMethodWatchOnly), SourceHash: "two", References: wallet.WalletReferences{"payroll"}},
+	)
+	batchKey(m, "a")
+	batchKey(m, "t")
+	require.Equal(t, constants.WalletBatchView, m.currentView)
+
+	m.walletBatchInput.SetValue("pay,roll")
+	runBatchExportCmds(m, batchKey(m, "enter"))
+	assert.Contains(t, m.viewWalletBatch(), "cannot contain commas")
+
+	m.walletBatchInput.SetValue("payroll")
+	runBatchExportCmds(m, batchKey(m, "enter"))
+	require.Equal(t, walletBatchStageDone, m.walletBatchStage)
+	view := m.viewWalletBatch()
+	assert.Contains(t, view, "tagged payroll")
+	assert.Contains(t, view, "already tagged payroll")
+
+	wallets, err := m.Service.GetAllWallets()
+	require.NoError(t, err)
+	for _, w := range wallets {
+		assert.Equal(t, wallet.WalletReferences{"payroll"}, w.References, w.Name)
+	}
+
+	// The marks stay for the next action until esc clears them
+	runBatchExportCmds(m, batchKey(m, "enter"))
+	require.Len(t, m.markedWallets(), 2)
+	batchKey(m, "esc")
+	assert.Equal(t, constants.ListWalletsView, m.currentView)
+	assert.Empty(t, m.markedWallets())
+}
+
+func TestWalletBatch_BalancesNeedNetworks(t *testing.T) {
+	m := newWalletBatchModel(t, &wallet.Wallet{Name: "One", Address: "0x01", ImportMethod: string(wallet.ImportMethodWatchOnly), SourceHash: "one"})
+
+	// Nothing happens until a wallet is marked
+	batchKey(m, "b")
+	assert.Equal(t, constants.ListWalletsView, m.currentView)
+
+	batchKey(m, " ")
+	batchKey(m, "b")
+	require.Equal(t, constants.WalletBatchView, m.currentView)
+	assert.False(t, m.capturesTextInput())
+	runBatchExportCmds(m, batchKey(m, "enter"))
+	assert.Equal(t, walletBatchStageForm, m.walletBatchStage)
+	assert.Contains(t, m.viewWalletBatch(), localization.Labels["balance_no_networks"])
+	assert.Empty(t, m.jobManager.List())
+}
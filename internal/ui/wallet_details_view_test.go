@@ -177,8 +177,9 @@ func TestWalletDetailsViewMnemonicHandling(t *testing.T) {
 
 		view := model.viewWalletDetails()
 
-		// Should show actual mnemonic
-		assert.Contains(t, view, testMnemonic, "Should show actual mnemonic phrase")
+		// Should show actual mnemonic, numbered in the word grid
+		assert.Contains(t, view, " 1. abandon", "Should show actual mnemonic phrase")
+		assert.Contains(t, view, "12. about", "Should show actual mnemonic phrase")
 		assert.NotContains(t, view, "not available", "Should not show 'not available' message when mnemonic exists")
 	})
 }
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"blocowallet/pkg/localization"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultWalletReportPath names the report written when no path is typed
+func defaultWalletReportPath(at time.Time) string {
+	return "wallets-" + at.Format("20060102-150405") + ".csv"
+}
+
+// startWalletReport opens the path field of the list report
+func (m *CLIModel) startWalletReport() tea.Cmd {
+	m.walletReportInput = textinput.New()
+	m.walletReportInput.Placeholder = defaultWalletReportPath(time.Now())
+	m.walletReportInput.CharLimit = 512
+	m.walletReportInput.Width = 50
+	m.exportingReport = true
+	m.walletReportStatus = ""
+	return m.walletReportInput.Focus()
+}
+
+// closeWalletReport leaves the path field without writing
+func (m *CLIModel) closeWalletReport() {
+	m.exportingReport = false
+	m.walletReportInput.Blur()
+}
+
+// updateWalletReport edits the path: enter writes the report, esc cancels
+func (m *CLIModel) updateWalletReport(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.writeWalletReport()
+		return m, nil
+	case "esc":
+		m.closeWalletReport()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.walletReportInput, cmd = updateTextInput(m.walletReportInput, msg)
+	return m, cmd
+}
+
+// writeWalletReport writes the wallets left by the search, with the columns of
+// the table, as Markdown when the path ends in .md and as CSV otherwise
+func (m *CLIModel) writeWalletReport() {
+	path := strings.TrimSpace(m.walletReportInput.Value())
+	if path == "" {
+		path = m.walletReportInput.Placeholder
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	columns := m.walletTable.Columns()
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		// Without the sort marker
+		header[i] = strings.TrimSuffix(column.Title, " "+glyphs.Sorted)
+	}
+	var rows [][]string
+	for _, row := range walletTableRows(m.visibleWallets(), nil) {
+		rows = append(rows, row)
+	}
+
+	m.closeWalletReport()
+	if err := saveWalletReport(path, header, rows); err != nil {
+		m.walletReportStatus = fmt.Sprintf(localization.Labels["wallet_report_failed"], err)
+		m.walletReportFailed = true
+		return
+	}
+	m.walletReportStatus = fmt.Sprintf(localization.Labels["wallet_report_written"], len(rows), path)
+	m.walletReportFailed = false
+}
+
+// saveWalletReport writes a table to path in the format its extension names
+func saveWalletReport(path string, header []string, rows [][]string) error {
+	var content string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		content = markdownTable(header, rows)
+	default:
+		var b strings.Builder
+		w := csv.NewWriter(&b)
+		_ = w.Write(header)
+		_ = w.WriteAll(rows)
+		if err := w.Error(); err != nil {
+			return err
+		}
+		content = b.String()
+	}
+	return os.WriteFile(path, []byte(content), 0600)
+}
+
+// markdownTable renders a GitHub-flavored Markdown table
+func markdownTable(header []string, rows [][]string) string {
+	cell := strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ")
+	line := func(cells []string) string {
+		escaped := make([]string, len(cells))
+		for i, c := range cells {
+			escaped[i] = cell.Replace(c)
+		}
+		return "| " + strings.Join(escaped, " | ") + " |\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(line(header))
+	separator := make([]string, len(header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	b.WriteString(line(separator))
+	for _, row := range rows {
+		b.WriteString(line(row))
+	}
+	return b.String()
+}
+
+// renderWalletReport shows the path field while it is open, or the result of
+// the last report
+func (m *CLIModel) renderWalletReport() string {
+	if m.exportingReport {
+		return "\n" + localization.Labels["wallet_report_label"] + " " + m.walletReportInput.View() + "\n" +
+			m.styles.MenuDesc.Render(localization.Labels["wallet_report_help"])
+	}
+	if m.walletReportStatus == "" {
+		return ""
+	}
+	if m.walletReportFailed {
+		return "\n" + m.styles.ErrorStyle.Render(m.walletReportStatus)
+	}
+	return "\n" + m.styles.SuccessStyle.Render(m.walletReportStatus)
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletReport_FilteredList(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddNotesMessages()
+
+	dir := t.TempDir()
+	repo, err := storage.NewWalletRepository(&config.Config{AppDir: dir, DatabasePath: filepath.Join(dir, "wallets.db")})
+	require.NoError(t, err)
+	defer repo.Close()
+	require.NoError(t, repo.AddWallet(&wallet.Wallet{Name: "Treasury | main", Address: "0xAAA", ImportMethod: "keystore", SourceHash: "a"}))
+	require.NoError(t, repo.AddWallet(&wallet.Wallet{Name: "Payroll", Address: "0xBBB", ImportMethod: "keystore", SourceHash: "b"}))
+
+	m := &CLIModel{Service: &wallet.WalletService{Repo: repo}, styles: createStyles(), width: 120}
+	m.initListWallets()
+	m.batchExportMarked = map[string]bool{"0xAAA": true}
+	m.walletFilter.SetValue("treasury")
+	m.applyWalletFilter()
+
+	report := filepath.Join(dir, "inventory.md")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	require.True(t, m.exportingReport)
+	assert.True(t, m.capturesTextInput())
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(report)})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, m.exportingReport)
+	assert.False(t, m.walletReportFailed, m.walletReportStatus)
+	assert.Contains(t, m.viewListWallets(), "Wrote 1 wallets")
+
+	content, err := os.ReadFile(report)
+	require.NoError(t, err)
+	lines := string(content)
+	assert.Contains(t, lines, " | Nome | ", "the columns of the table")
+	assert.Contains(t, lines, "| --- | --- | --- | --- | --- |")
+	assert.Contains(t, lines, `| 1 | Treasury \| main | `, "the mark of the batch export is not part of the report")
+	assert.NotContains(t, lines, "Payroll")
+
+	// esc closes the field without writing
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, m.exportingReport)
+	assert.Equal(t, constants.ListWalletsView, m.currentView)
+}
+
+func TestSaveWalletReport_CSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wallets.csv")
+	require.NoError(t, saveWalletReport(path, []string{"Name", "Address"}, [][]string{{"Ops, EU", "0xAAA"}}))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Name,Address\n\"Ops, EU\",0xAAA\n", string(content))
+}
@@ -0,0 +1,188 @@
+package ui
+
+import (
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// initWalletRPC opens the RPC endpoints of the wallet being displayed
+func (m *CLIModel) initWalletRPC() {
+	if m.currentConfig == nil {
+		if err := m.ensureConfigAndNetworksLoaded(); err != nil {
+			m.setWalletRPCResult(err.Error(), true)
+		}
+	}
+	m.rpcInput = textinput.New()
+	m.rpcInput.Placeholder = localization.Labels["rpc_override_placeholder"]
+	m.rpcInput.CharLimit = 256
+	m.rpcInput.Width = 60
+
+	m.rpcCursor = 0
+	m.rpcEditing = false
+	m.rpcStatus = ""
+	m.rpcFailed = false
+	m.loadWalletRPCOverrides()
+	m.currentView = constants.WalletRPCView
+}
+
+// walletRPCNetworks lists the configured networks with a chain ID, by name
+func (m *CLIModel) walletRPCNetworks() []config.Network {
+	if m.currentConfig == nil {
+		return nil
+	}
+	networks := make([]config.Network, 0, len(m.currentConfig.Networks))
+	for _, n := range m.currentConfig.Networks {
+		if n.ChainID > 0 {
+			networks = append(networks, n)
+		}
+	}
+	sort.Slice(networks, func(i, j int) bool { return networks[i].Name < networks[j].Name })
+	return networks
+}
+
+// loadWalletRPCOverrides reads the overrides of the wallet, by chain ID
+func (m *CLIModel) loadWalletRPCOverrides() {
+	overrides, err := m.Service.RPCOverrides(m.walletDetails.Wallet.Address)
+	if err != nil {
+		m.setWalletRPCResult(err.Error(), true)
+		return
+	}
+	m.rpcOverrides = make(map[int64]string, len(overrides))
+	for _, o := range overrides {
+		m.rpcOverrides[o.ChainID] = o.RPCEndpoint
+	}
+}
+
+// updateWalletRPC handles input on the wallet RPC endpoints screen
+func (m *CLIModel) updateWalletRPC(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	networks := m.walletRPCNetworks()
+
+	if m.rpcEditing {
+		if keyMsg.String() == "enter" && m.rpcCursor < len(networks) {
+			m.saveWalletRPC(networks[m.rpcCursor], m.rpcInput.Value())
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.rpcInput, cmd = updateTextInput(m.rpcInput, msg)
+		return m, cmd
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.rpcCursor > 0 {
+			m.rpcCursor--
+		}
+	case "down", "j":
+		if m.rpcCursor < len(networks)-1 {
+			m.rpcCursor++
+		}
+	case "enter":
+		if m.rpcCursor < len(networks) {
+			m.rpcInput.SetValue(m.rpcOverrides[networks[m.rpcCursor].ChainID])
+			m.rpcInput.CursorEnd()
+			m.rpcInput.Focus()
+			m.rpcEditing = true
+			m.setWalletRPCResult("", false)
+		}
+	case "d":
+		if m.rpcCursor < len(networks) {
+			m.saveWalletRPC(networks[m.rpcCursor], "")
+		}
+	}
+	return m, nil
+}
+
+// saveWalletRPC stores the endpoint of the wallet on network; empty removes it
+func (m *CLIModel) saveWalletRPC(network config.Network, endpoint string) {
+	endpoint = strings.TrimSpace(endpoint)
+	if err := m.Service.SetRPCOverride(m.walletDetails.Wallet.Address, network.ChainID, endpoint); err != nil {
+		m.setWalletRPCResult(fmt.Sprintf(localization.Labels["rpc_override_failed"], err), true)
+		return
+	}
+	m.closeWalletRPCEdit()
+	m.loadWalletRPCOverrides()
+	if endpoint == "" {
+		m.setWalletRPCResult(fmt.Sprintf(localization.Labels["rpc_override_removed"], network.Name), false)
+	} else {
+		m.setWalletRPCResult(fmt.Sprintf(localization.Labels["rpc_override_saved"], network.Name, endpoint), false)
+	}
+}
+
+// closeWalletRPCEdit leaves the endpoint being edited without saving it
+func (m *CLIModel) closeWalletRPCEdit() {
+	m.rpcEditing = false
+	m.rpcInput.Blur()
+	m.rpcInput.SetValue("")
+}
+
+func (m *CLIModel) setWalletRPCResult(status string, failed bool) {
+	m.rpcStatus = status
+	m.rpcFailed = failed
+}
+
+// viewWalletRPC renders the networks with the endpoint the wallet uses on each
+func (m *CLIModel) viewWalletRPC() string {
+	if m.walletDetails == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["rpc_override_title"]))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["rpc_override_wallet"], m.walletDetails.Wallet.Name, wallet.ChecksumAddress(m.walletDetails.Wallet.Address)))
+	b.WriteString("\n")
+	b.WriteString(m.styles.MenuDesc.Render(localization.Labels["rpc_override_desc"]))
+	b.WriteString("\n\n")
+
+	networks := m.walletRPCNetworks()
+	if len(networks) == 0 {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["rpc_override_none"]))
+		b.WriteString("\n")
+	}
+	for i, n := range networks {
+		cursor := "  "
+		if i == m.rpcCursor {
+			cursor = "> "
+		}
+		endpoint, ok := m.rpcOverrides[n.ChainID]
+		if !ok {
+			endpoint = m.styles.MenuDesc.Render(localization.Labels["rpc_override_default"])
+		}
+		b.WriteString(fmt.Sprintf("%s%-20s %-10d %s\n", cursor, n.Name, n.ChainID, endpoint))
+	}
+	b.WriteString("\n")
+
+	if m.rpcEditing && m.rpcCursor < len(networks) {
+		n := networks[m.rpcCursor]
+		b.WriteString(fmt.Sprintf(localization.Labels["rpc_override_endpoint"], n.Name, n.ChainID))
+		b.WriteString("\n")
+		b.WriteString(m.rpcInput.View())
+		b.WriteString("\n\n")
+	}
+
+	if m.rpcStatus != "" {
+		if m.rpcFailed {
+			b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.rpcStatus))
+		} else {
+			b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.rpcStatus))
+		}
+		b.WriteString("\n\n")
+	}
+	if m.rpcEditing {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["rpc_override_help_editing"]))
+	} else {
+		b.WriteString(m.styles.MenuDesc.Render(localization.Labels["rpc_override_help"]))
+	}
+	return b.String()
+}
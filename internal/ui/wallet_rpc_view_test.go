@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/storage"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletRPC_SetAndRemoveOverride(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddRPCOverrideMessages()
+
+	dir := t.TempDir()
+	repo, err := storage.NewWalletRepository(&config.Config{AppDir: dir, DatabasePath: filepath.Join(dir, "wallets.db")})
+	require.NoError(t, err)
+	defer repo.Close()
+	ws := &wallet.WalletService{Repo: repo}
+
+	w := &wallet.Wallet{Name: "Vault", Address: "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"}
+	m := &CLIModel{Service: ws, styles: createStyles(), currentView: constants.WalletDetailsView}
+	m.walletDetails = &wallet.WalletDetails{Wallet: w}
+	m.currentConfig = &config.Config{Networks: map[string]config.Network{
+		"ethereum": {Name: "Ethereum", ChainID: 1, RPCEndpoint: "https://eth.example", IsActive: true},
+		"polygon":  {Name: "Polygon", ChainID: 137, RPCEndpoint: "https://polygon.example", IsActive: true},
+	}}
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	require.Equal(t, constants.WalletRPCView, m.currentView)
+	assert.Contains(t, m.viewWalletRPC(), "network default")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, m.rpcEditing)
+	assert.True(t, m.capturesTextInput())
+	m.rpcInput.SetValue("ftp://node")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, m.rpcFailed, "only http(s) and ws(s) endpoints are accepted")
+
+	m.rpcInput.SetValue("https://private.example")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, m.rpcFailed, m.rpcStatus)
+	assert.False(t, m.rpcEditing)
+	assert.Equal(t, map[int64]string{137: "https://private.example"}, m.rpcOverrides)
+
+	network := ws.NetworkFor(w.Address, m.currentConfig.Networks["polygon"])
+	assert.Equal(t, "https://private.example", network.RPCEndpoint)
+	assert.Equal(t, "https://eth.example", ws.NetworkFor(w.Address, m.currentConfig.Networks["ethereum"]).RPCEndpoint)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	assert.Empty(t, m.rpcOverrides)
+	assert.Equal(t, "https://polygon.example", ws.NetworkFor(w.Address, m.currentConfig.Networks["polygon"]).RPCEndpoint)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.WalletDetailsView, m.currentView)
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// walletSort is the column the wallet list is sorted by; `s` cycles through them
+type walletSort int
+
+const (
+	walletSortCreated walletSort = iota
+	walletSortName
+	walletSortType
+	walletSortCount
+)
+
+// label returns the localized name of the sort column
+func (s walletSort) label() string {
+	switch s {
+	case walletSortName:
+		return localization.Labels["wallet_sort_name"]
+	case walletSortType:
+		return localization.Labels["wallet_sort_type"]
+	default:
+		return localization.Labels["wallet_sort_created"]
+	}
+}
+
+// column returns the index of the sort column in the wallet table
+func (s walletSort) column() int {
+	switch s {
+	case walletSortName:
+		return 1
+	case walletSortType:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// sortWallets returns a sorted copy of wallets; ties keep the creation order
+func sortWallets(wallets []wallet.Wallet, by walletSort) []wallet.Wallet {
+	sorted := append([]wallet.Wallet(nil), wallets...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		switch by {
+		case walletSortName:
+			if !strings.EqualFold(a.Name, b.Name) {
+				return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+			}
+		case walletSortType:
+			if ta, tb := determineWalletType(a), determineWalletType(b); ta != tb {
+				return ta < tb
+			}
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
+	return sorted
+}
+
+// cycleWalletSort sorts the list by the next column, keeping the cursor on
+// the selected wallet
+func (m *CLIModel) cycleWalletSort() {
+	selected := ""
+	if row := m.walletTable.SelectedRow(); len(row) > 4 {
+		selected = row[4]
+	}
+	m.walletSort = (m.walletSort + 1) % walletSortCount
+	m.walletTable.SetColumns(m.walletTableColumns())
+	m.applyWalletFilter()
+	for i, row := range m.walletTable.Rows() {
+		if row[4] == selected {
+			m.walletTable.SetCursor(i)
+			break
+		}
+	}
+}
+
+// walletTableColumns returns the wallet table columns for the terminal width,
+// with a marker on the sort column
+func (m *CLIModel) walletTableColumns() []table.Column {
+	idColWidth := 10
+	nameColWidth := 20
+	typeColWidth := 20
+	createdAtColWidth := 20
+	addressColWidth := m.width - idColWidth - nameColWidth - typeColWidth - createdAtColWidth - 20 // Padding and margins
+	if addressColWidth < 20 {
+		addressColWidth = 20
+	}
+
+	columns := []table.Column{
+		{Title: localization.Labels["id"], Width: idColWidth},
+		{Title: "Nome", Width: nameColWidth},
+		{Title: localization.Labels["wallet_type"], Width: typeColWidth},
+		{Title: localization.Labels["created_at"], Width: createdAtColWidth},
+		{Title: localization.Labels["ethereum_address"], Width: addressColWidth},
+	}
+	sorted := &columns[m.walletSort.column()]
+	sorted.Title += " " + glyphs.Sorted
+	return columns
+}
+
+// renderWalletPosition shows the selected row, the page and the sort column
+// under the wallet table
+func (m *CLIModel) renderWalletPosition() string {
+	total := len(m.walletTable.Rows())
+	if total == 0 {
+		return ""
+	}
+	perPage := max(m.walletTable.Height(), 1)
+	cursor := m.walletTable.Cursor()
+	pages := (total + perPage - 1) / perPage
+	line := fmt.Sprintf(localization.Labels["wallet_list_position"], cursor+1, total, cursor/perPage+1, pages, m.walletSort.label())
+	return lipgloss.NewStyle().Foreground(palette.Muted).Render(line)
+}
+
+// fitWalletTable sizes the table so that it and the lines around it fill the
+// content area without pushing the footer off the screen
+func (m *CLIModel) fitWalletTable(around string) {
+	if m.walletListHeight <= 0 {
+		return
+	}
+	// The height includes the two header lines; at least one row stays visible
+	m.walletTable.SetHeight(max(m.walletListHeight-lipgloss.Height(around), 3))
+}
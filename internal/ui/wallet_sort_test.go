@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWalletSortModel(wallets ...wallet.Wallet) *CLIModel {
+	localization.SetCurrentLanguage("en")
+	localization.AddNotesMessages()
+
+	m := &CLIModel{
+		Service:     &wallet.WalletService{},
+		styles:      createStyles(),
+		currentView: constants.ListWalletsView,
+		width:       160,
+		wallets:     wallets,
+	}
+	m.rebuildWalletsTable()
+	return m
+}
+
+// walletTableNames returns the name column of the table rows
+func walletTableNames(m *CLIModel) []string {
+	var names []string
+	for _, row := range m.walletTable.Rows() {
+		names = append(names, row[1])
+	}
+	return names
+}
+
+func TestWalletList_SortCycle(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := newWalletSortModel(
+		wallet.Wallet{ID: 1, Name: "payroll", Address: "0x01", ImportMethod: string(wallet.ImportMethodPrivateKey), CreatedAt: start.Add(2 * time.Hour)},
+		wallet.Wallet{ID: 2, Name: "Treasury", Address: "0x02", ImportMethod: string(wallet.ImportMethodMnemonic), CreatedAt: start},
+		wallet.Wallet{ID: 3, Name: "Cold", Address: "0x03", ImportMethod: string(wallet.ImportMethodWatchOnly), CreatedAt: start.Add(time.Hour)},
+	)
+	assert.Equal(t, []string{"Treasury", "Cold", "payroll"}, walletTableNames(m), "oldest first by default")
+	assert.Contains(t, m.walletTable.Columns()[3].Title, glyphs.Sorted)
+
+	// The cursor follows the selected wallet
+	m.walletTable.SetCursor(2)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	assert.Equal(t, []string{"Cold", "payroll", "Treasury"}, walletTableNames(m), "names ignore case")
+	assert.Equal(t, "payroll", m.walletTable.SelectedRow()[1])
+	assert.Contains(t, m.walletTable.Columns()[1].Title, glyphs.Sorted)
+	assert.NotContains(t, m.walletTable.Columns()[3].Title, glyphs.Sorted)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	assert.Equal(t, walletSortType, m.walletSort)
+	types := []string{}
+	for _, row := range m.walletTable.Rows() {
+		types = append(types, row[2])
+	}
+	assert.IsNonDecreasing(t, types)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	assert.Equal(t, walletSortCreated, m.walletSort)
+	assert.Equal(t, []string{"Treasury", "Cold", "payroll"}, walletTableNames(m))
+}
+
+func TestWalletList_FitsTheContentArea(t *testing.T) {
+	var wallets []wallet.Wallet
+	for i := 1; i <= 300; i++ {
+		wallets = append(wallets, wallet.Wallet{ID: i, Name: fmt.Sprintf("wallet-%03d", i), Address: fmt.Sprintf("0x%040d", i), ImportMethod: string(wallet.ImportMethodMnemonic)})
+	}
+	m := newWalletSortModel(wallets...)
+	m.walletListHeight = 20
+
+	view := m.viewListWallets()
+	assert.Equal(t, 20, lipgloss.Height(view), "the footer stays on the screen")
+	rows := m.walletTable.Height()
+	require.Greater(t, rows, 0)
+	assert.Contains(t, view, fmt.Sprintf("1 of 300 • page 1/%d • sorted by created", (300+rows-1)/rows))
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	assert.Contains(t, m.viewListWallets(), fmt.Sprintf("%d of 300 • page 2/", rows+1))
+
+	// The search line takes its space from the table
+	m.walletFilter.SetValue("wallet-00")
+	m.applyWalletFilter()
+	assert.Equal(t, 20, lipgloss.Height(m.viewListWallets()))
+}
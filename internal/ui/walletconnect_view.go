@@ -0,0 +1,713 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"blocowallet/internal/blockchain"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/wallet"
+	"blocowallet/internal/walletconnect"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// wcReconnectDelay is how long to wait before reconnecting to the relay
+// after the connection dropped while sessions are open
+const wcReconnectDelay = 10 * time.Second
+
+// wcMetadata is how the wallet presents itself to dapps
+var wcMetadata = walletconnect.Metadata{
+	Name:        "bloco wallet",
+	Description: "Terminal wallet for Ethereum networks",
+	URL:         "https://github.com/italoag/bloco-wallet",
+	Icons:       []string{},
+}
+
+// wcRequest is a dapp request waiting for the user, decoded when possible
+type wcRequest struct {
+	req  *walletconnect.Request
+	sign *walletconnect.SignRequest
+	err  error // Why the request cannot be served
+}
+
+// wcEventMsg carries the next event of the WalletConnect client
+type wcEventMsg struct {
+	event walletconnect.Event
+}
+
+// wcPairedMsg reports the subscription to a pairing URI
+type wcPairedMsg struct {
+	err error
+}
+
+// wcApprovedMsg reports the approval or rejection of a proposal
+type wcApprovedMsg struct {
+	session  *walletconnect.Session
+	rejected bool
+	err      error
+}
+
+// wcRespondedMsg reports the answer sent for a request
+type wcRespondedMsg struct {
+	result   walletconnect.Result
+	network  string
+	rejected bool
+	tracked  bool
+	err      error
+}
+
+// wcReconnectMsg reports a reconnection to the relay
+type wcReconnectMsg struct {
+	err error
+}
+
+// wcDisconnectedMsg reports a session closed by the user
+type wcDisconnectedMsg struct {
+	name string
+	err  error
+}
+
+// initWalletConnect opens the WalletConnect screen, creating the client on
+// first use. The client and its sessions last until the wallet is closed.
+func (m *CLIModel) initWalletConnect() tea.Cmd {
+	m.currentView = constants.WalletConnectView
+	m.closeWCPassword()
+	m.setWCResult("", false)
+	if m.currentConfig == nil {
+		if err := m.ensureConfigAndNetworksLoaded(); err != nil {
+			m.setWCResult(err.Error(), true)
+		}
+	}
+	m.wcURI = textinput.New()
+	m.wcURI.Placeholder = "wc:…@2?relay-protocol=irn&symKey=…"
+	m.wcURI.CharLimit = 512
+	m.wcURI.Width = 60
+	m.wcURI.Focus()
+	m.loadWCWallets()
+
+	if m.wcClient != nil || m.currentConfig == nil {
+		return nil
+	}
+	cfg := m.currentConfig.WalletConnect
+	if cfg.ProjectID == "" {
+		return nil
+	}
+	host := ""
+	if u, err := url.Parse(cfg.RelayURL); err == nil {
+		host = u.Hostname()
+	}
+	m.wcClient = walletconnect.NewClient(blockchain.NewWebsocketDialer(blockchain.ProxyWalletConnect, host), cfg.RelayURL, cfg.ProjectID, wcMetadata)
+	return wcListenCmd(m.wcClient)
+}
+
+// loadWCWallets lists the wallets a session can be opened for: those holding a key
+func (m *CLIModel) loadWCWallets() {
+	m.wcWallets = nil
+	if m.Service == nil {
+		return
+	}
+	wallets, err := m.Service.GetAllWallets()
+	if err != nil {
+		m.setWCResult(err.Error(), true)
+		return
+	}
+	for _, w := range wallets {
+		if !w.WatchOnly() {
+			m.wcWallets = append(m.wcWallets, w)
+		}
+	}
+	if m.wcWallet >= len(m.wcWallets) {
+		m.wcWallet = 0
+	}
+}
+
+// wcListenCmd waits for the next event of client
+func wcListenCmd(client *walletconnect.Client) tea.Cmd {
+	return func() tea.Msg {
+		return wcEventMsg{event: <-client.Events()}
+	}
+}
+
+// handleWCEvent queues proposals and requests for the user and keeps
+// listening. Events arrive on any screen; the status bar shows what waits.
+func (m *CLIModel) handleWCEvent(msg wcEventMsg) tea.Cmd {
+	listen := wcListenCmd(m.wcClient)
+	event := msg.event
+	switch {
+	case event.Proposal != nil:
+		m.wcProposals = append(m.wcProposals, event.Proposal)
+		m.loadWCWallets()
+	case event.Request != nil:
+		sign, err := walletconnect.ParseRequest(event.Request)
+		m.wcRequests = append(m.wcRequests, wcRequest{req: event.Request, sign: sign, err: err})
+		if sign != nil && sign.Tx != nil {
+			return tea.Batch(listen, m.lookupSignature(sign.Tx.Payload()))
+		}
+	case event.Settled != nil:
+		m.setWCResult(fmt.Sprintf(localization.Labels["wc_session_settled"], event.Settled.Peer.Name), false)
+	case event.Deleted != nil:
+		m.dropWCRequests(event.Deleted.Topic)
+		m.setWCResult(fmt.Sprintf(localization.Labels["wc_session_deleted"], event.Deleted.Peer.Name), false)
+	case event.Err != nil:
+		m.setWCResult(fmt.Sprintf(localization.Labels["wc_relay_error"], event.Err), true)
+		if !m.wcClient.Connected() && len(m.wcClient.Sessions()) > 0 {
+			return tea.Batch(listen, m.wcReconnectCmd())
+		}
+	}
+	return listen
+}
+
+// wcReconnectCmd reconnects to the relay after wcReconnectDelay, so the
+// dapps of the open sessions can reach the wallet again
+func (m *CLIModel) wcReconnectCmd() tea.Cmd {
+	client := m.wcClient
+	return tea.Tick(wcReconnectDelay, func(time.Time) tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), blockchain.DefaultTimeout)
+		defer cancel()
+		return wcReconnectMsg{err: client.Connect(ctx)}
+	})
+}
+
+// handleWCReconnect retries until the relay answers, while sessions are open
+func (m *CLIModel) handleWCReconnect(msg wcReconnectMsg) tea.Cmd {
+	if msg.err == nil {
+		m.setWCResult(localization.Labels["wc_reconnected"], false)
+		return nil
+	}
+	if len(m.wcClient.Sessions()) == 0 {
+		return nil
+	}
+	return m.wcReconnectCmd()
+}
+
+// dropWCRequests forgets the requests of a closed session
+func (m *CLIModel) dropWCRequests(topic string) {
+	m.wcRequests = slices.DeleteFunc(m.wcRequests, func(r wcRequest) bool { return r.req.Topic == topic })
+	if len(m.wcRequests) == 0 {
+		m.closeWCPassword()
+	}
+}
+
+// updateWalletConnect handles input on the WalletConnect screen: the first
+// proposal, then the first request, are reviewed before anything else
+func (m *CLIModel) updateWalletConnect(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.wcBusy || m.wcClient == nil {
+		return m, nil
+	}
+	switch {
+	case len(m.wcProposals) > 0:
+		return m, m.updateWCProposal(keyMsg)
+	case len(m.wcRequests) > 0:
+		return m, m.updateWCRequest(keyMsg)
+	}
+
+	sessions := m.wcClient.Sessions()
+	switch keyMsg.String() {
+	case "enter":
+		return m, m.pairWalletConnect()
+	case "up":
+		if m.wcSession > 0 {
+			m.wcSession--
+		}
+		return m, nil
+	case "down":
+		if m.wcSession < len(sessions)-1 {
+			m.wcSession++
+		}
+		return m, nil
+	case "ctrl+d":
+		if m.wcSession < len(sessions) {
+			return m, m.disconnectWalletConnect(sessions[m.wcSession])
+		}
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.wcURI, cmd = updateTextInput(m.wcURI, msg)
+	return m, cmd
+}
+
+// pairWalletConnect subscribes to the URI typed in
+func (m *CLIModel) pairWalletConnect() tea.Cmd {
+	uri, err := walletconnect.ParseURI(m.wcURI.Value())
+	if err != nil {
+		m.setWCResult(err.Error(), true)
+		return nil
+	}
+	m.wcBusy = true
+	m.setWCResult(localization.Labels["wc_pairing"], false)
+	client := m.wcClient
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), blockchain.DefaultTimeout)
+		defer cancel()
+		return wcPairedMsg{err: client.Pair(ctx, uri)}
+	}
+}
+
+func (m *CLIModel) handleWCPaired(msg wcPairedMsg) {
+	m.wcBusy = false
+	if msg.err != nil {
+		m.setWCResult(fmt.Sprintf(localization.Labels["wc_pair_failed"], msg.err), true)
+		return
+	}
+	m.wcURI.SetValue("")
+	m.setWCResult(localization.Labels["wc_paired"], false)
+}
+
+// disconnectWalletConnect closes session s for the dapp too
+func (m *CLIModel) disconnectWalletConnect(s walletconnect.Session) tea.Cmd {
+	m.wcBusy = true
+	m.dropWCRequests(s.Topic)
+	client := m.wcClient
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), blockchain.DefaultTimeout)
+		defer cancel()
+		return wcDisconnectedMsg{name: s.Peer.Name, err: client.Disconnect(ctx, s)}
+	}
+}
+
+func (m *CLIModel) handleWCDisconnected(msg wcDisconnectedMsg) {
+	m.wcBusy = false
+	m.wcSession = 0
+	if msg.err != nil {
+		// The session is gone here; the dapp only missed the notice
+		m.setWCResult(fmt.Sprintf(localization.Labels["wc_disconnect_failed"], msg.name, msg.err), true)
+		return
+	}
+	m.setWCResult(fmt.Sprintf(localization.Labels["wc_disconnected"], msg.name), false)
+}
+
+// wcChainNetworks returns the networks serving chains, and the chains no
+// active network serves
+func (m *CLIModel) wcChainNetworks(chains []int64) (map[int64]config.Network, []int64) {
+	served := make(map[int64]config.Network)
+	var missing []int64
+	for _, id := range chains {
+		found := false
+		for _, n := range m.usableNetworks() {
+			if n.ChainID == id {
+				served[id], found = n, true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, id)
+		}
+	}
+	return served, missing
+}
+
+// wcProposalChains returns the chains to approve the first proposal on: the
+// required ones and the optional ones a network serves. Missing lists the
+// required chains no network serves, which prevent the approval.
+func (m *CLIModel) wcProposalChains() (chains, missing []int64, err error) {
+	p := m.wcProposals[0]
+	required, err := p.RequiredChains()
+	if err != nil {
+		return nil, nil, err
+	}
+	_, missing = m.wcChainNetworks(required)
+	optional, _ := m.wcChainNetworks(p.OptionalChains())
+	chains = slices.Clone(required)
+	for id := range optional {
+		if !slices.Contains(chains, id) {
+			chains = append(chains, id)
+		}
+	}
+	slices.Sort(chains)
+	return chains, missing, nil
+}
+
+// updateWCProposal picks the wallet with ←/→ and approves or rejects the
+// first proposal
+func (m *CLIModel) updateWCProposal(msg tea.KeyMsg) tea.Cmd {
+	p := m.wcProposals[0]
+	client := m.wcClient
+	switch msg.String() {
+	case "left", "right":
+		if n := len(m.wcWallets); n > 0 {
+			step := 1
+			if msg.String() == "left" {
+				step = -1
+			}
+			m.wcWallet = (m.wcWallet + step + n) % n
+		}
+	case "enter":
+		chains, missing, err := m.wcProposalChains()
+		switch {
+		case err != nil:
+			m.setWCResult(err.Error(), true)
+			return nil
+		case len(missing) > 0:
+			m.setWCResult(fmt.Sprintf(localization.Labels["wc_missing_chains"], missing), true)
+			return nil
+		case len(chains) == 0:
+			m.setWCResult(localization.Labels["wc_no_chains"], true)
+			return nil
+		case m.wcWallet >= len(m.wcWallets):
+			m.setWCResult(localization.Labels["wc_no_wallets"], true)
+			return nil
+		}
+		address := common.HexToAddress(m.wcWallets[m.wcWallet].Address)
+		m.wcBusy = true
+		m.setWCResult(localization.Labels["wc_approving"], false)
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), blockchain.DefaultTimeout)
+			defer cancel()
+			session, err := client.Approve(ctx, p, address, chains)
+			return wcApprovedMsg{session: session, err: err}
+		}
+	case "x":
+		m.wcBusy = true
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), blockchain.DefaultTimeout)
+			defer cancel()
+			return wcApprovedMsg{rejected: true, err: client.Reject(ctx, p)}
+		}
+	}
+	return nil
+}
+
+// handleWCApproved moves on to the next proposal. A proposal that could not
+// be answered is dropped too: the dapp shows a new URI to try again.
+func (m *CLIModel) handleWCApproved(msg wcApprovedMsg) {
+	m.wcBusy = false
+	p := m.wcProposals[0]
+	m.wcProposals = m.wcProposals[1:]
+	switch {
+	case msg.err != nil:
+		m.setWCResult(fmt.Sprintf(localization.Labels["wc_approve_failed"], p.Proposer.Name, msg.err), true)
+	case msg.rejected:
+		m.setWCResult(fmt.Sprintf(localization.Labels["wc_proposal_rejected"], p.Proposer.Name), false)
+	default:
+		m.setWCResult(fmt.Sprintf(localization.Labels["wc_session_opened"], p.Proposer.Name, msg.session.Address.Hex()), false)
+	}
+}
+
+// updateWCRequest asks for the wallet's password to approve the first
+// request, or rejects it
+func (m *CLIModel) updateWCRequest(msg tea.KeyMsg) tea.Cmd {
+	r := m.wcRequests[0]
+	if m.wcUnlocking {
+		if msg.String() == "enter" {
+			return m.approveWCRequest()
+		}
+		var cmd tea.Cmd
+		m.wcPassword, cmd = updateTextInput(m.wcPassword, msg)
+		return cmd
+	}
+	switch msg.String() {
+	case "enter":
+		if r.err != nil {
+			return nil
+		}
+		m.wcUnlocking = true
+		m.wcPassword = textinput.New()
+		m.wcPassword.Placeholder = localization.Labels["wc_password_placeholder"]
+		m.wcPassword.EchoMode = textinput.EchoPassword
+		m.wcPassword.EchoCharacter = '*'
+		m.wcPassword.CharLimit = 128
+		m.wcPassword.Width = 40
+		m.wcPassword.Focus()
+	case "x":
+		return m.respondWCRequest(nil, nil)
+	}
+	return nil
+}
+
+// closeWCPassword forgets the password prompt
+func (m *CLIModel) closeWCPassword() {
+	m.wcUnlocking = false
+	m.wcPassword = textinput.Model{}
+}
+
+// approveWCRequest unlocks the session's wallet, showing the key derivation
+// heartbeat, then signs and answers the first request
+func (m *CLIModel) approveWCRequest() tea.Cmd {
+	r := m.wcRequests[0]
+	password := m.wcPassword.Value()
+	m.closeWCPassword()
+	w, err := m.Service.FindWalletByAddress(r.req.Address.Hex())
+	if err != nil {
+		m.setWCResult(fmt.Sprintf(localization.Labels["wc_wallet_missing"], r.req.Address.Hex()), true)
+		return nil
+	}
+	ws := m.Service
+	return m.startKDF(kdfUnlock, func() (*wallet.WalletDetails, error) {
+		return ws.LoadWallet(w, password)
+	}, func(details *wallet.WalletDetails, err error) tea.Cmd {
+		if err != nil {
+			// Wrong password: the request stays for another try
+			m.setWCResult(fmt.Sprintf(localization.Labels["wc_unlock_failed"], err), true)
+			return nil
+		}
+		return m.respondWCRequest(r.sign, details.Signer)
+	})
+}
+
+// respondWCRequest executes the first request with signer and sends the
+// result to the dapp, or rejects the request when signer is nil
+func (m *CLIModel) respondWCRequest(sign *walletconnect.SignRequest, signer wallet.Signer) tea.Cmd {
+	r := m.wcRequests[0]
+	client, ws := m.wcClient, m.Service
+	network, hasNetwork := config.Network{}, false
+	if sign != nil && sign.Tx != nil {
+		networks, _ := m.wcChainNetworks([]int64{sign.ChainID})
+		network, hasNetwork = networks[sign.ChainID]
+		if !hasNetwork {
+			m.setWCResult(fmt.Sprintf(localization.Labels["wc_missing_chains"], []int64{sign.ChainID}), true)
+			return nil
+		}
+		network = ws.NetworkFor(r.req.Address.Hex(), network)
+	}
+	m.wcBusy = true
+	m.setWCResult(localization.Labels["wc_responding"], false)
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*blockchain.DefaultTimeout)
+		defer cancel()
+		if signer == nil {
+			return wcRespondedMsg{rejected: true, err: client.RespondError(ctx, r.req, walletconnect.CodeUserRejected, "User rejected.")}
+		}
+
+		var ts *wallet.TransactionService
+		if hasNetwork {
+			eth, err := blockchain.NewEthereum(network.RPCEndpoint, blockchain.DefaultTimeout, network.Symbol, 18, network.Name)
+			if err != nil {
+				return wcRespondedMsg{err: err}
+			}
+			defer eth.Close()
+			ts = wallet.NewTransactionService(eth.Client())
+		}
+		result, err := sign.Execute(ctx, signer, ts)
+		if err != nil {
+			// The dapp learns the request failed; the user sees why
+			_ = client.RespondError(ctx, r.req, walletconnect.CodeRequestFailed, err.Error())
+			return wcRespondedMsg{err: err}
+		}
+		// Follow a sent transaction until final; a failure here does not undo it
+		tracked := false
+		if result.Sent != nil && ws != nil {
+			_, trackErr := ws.TrackTransaction(result.Sent, network.Name, "", "")
+			tracked = trackErr == nil
+		}
+		return wcRespondedMsg{result: result, network: network.Name, tracked: tracked, err: client.Respond(ctx, r.req, result.Value)}
+	}
+}
+
+// handleWCResponded moves on to the next request
+func (m *CLIModel) handleWCResponded(msg wcRespondedMsg) tea.Cmd {
+	m.wcBusy = false
+	if len(m.wcRequests) > 0 {
+		m.wcRequests = m.wcRequests[1:]
+	}
+	switch {
+	case msg.err != nil && msg.result.Value != "":
+		m.setWCResult(fmt.Sprintf(localization.Labels["wc_reply_failed"], msg.err), true)
+	case msg.err != nil:
+		m.setWCResult(fmt.Sprintf(localization.Labels["wc_request_failed"], msg.err), true)
+	case msg.rejected:
+		m.setWCResult(localization.Labels["wc_request_rejected"], false)
+	case msg.result.Sent != nil:
+		m.setWCResult(fmt.Sprintf(localization.Labels["wc_tx_sent"], msg.result.Value, msg.network), false)
+	default:
+		m.setWCResult(localization.Labels["wc_request_signed"], false)
+	}
+	if msg.tracked {
+		return m.refreshPendingNow()
+	}
+	return nil
+}
+
+func (m *CLIModel) setWCResult(status string, failed bool) {
+	m.wcStatus = status
+	m.wcFailed = failed
+}
+
+// wcCapturesText reports whether typed keys go to a text input
+func (m *CLIModel) wcCapturesText() bool {
+	if len(m.wcProposals) > 0 {
+		return false
+	}
+	if len(m.wcRequests) > 0 {
+		return m.wcUnlocking
+	}
+	return m.wcClient != nil
+}
+
+// wcStatusIndicator shows the proposals and requests waiting for the user
+func (m *CLIModel) wcStatusIndicator() string {
+	if waiting := len(m.wcProposals) + len(m.wcRequests); waiting > 0 {
+		return fmt.Sprintf("WC: %d waiting", waiting)
+	}
+	return ""
+}
+
+// wcChainLabel names a chain after the network serving it
+func (m *CLIModel) wcChainLabel(id int64) string {
+	if networks, _ := m.wcChainNetworks([]int64{id}); networks[id].Name != "" {
+		return fmt.Sprintf("%s (%d)", networks[id].Name, id)
+	}
+	return fmt.Sprintf("%d", id)
+}
+
+// viewWalletConnect renders the first proposal or request waiting, or else
+// the URI input and the open sessions
+func (m *CLIModel) viewWalletConnect() string {
+	if m.kdfOp != nil {
+		return m.viewKDFStatus()
+	}
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["wc_title"]))
+	b.WriteString("\n\n")
+
+	help := localization.Labels["wc_help"]
+	switch {
+	case m.wcClient == nil:
+		b.WriteString(m.styles.ErrorStyle.Render(localization.Labels["wc_no_project_id"]))
+		b.WriteString("\n\n")
+		help = localization.Labels["wc_help_back"]
+	case len(m.wcProposals) > 0:
+		m.viewWCProposal(&b)
+		help = localization.Labels["wc_help_proposal"]
+	case len(m.wcRequests) > 0:
+		m.viewWCRequest(&b)
+		help = localization.Labels["wc_help_request"]
+		if m.wcUnlocking {
+			help = localization.Labels["wc_help_password"]
+		}
+	default:
+		m.viewWCSessions(&b)
+	}
+
+	switch {
+	case m.wcStatus != "" && m.wcBusy:
+		b.WriteString(m.styles.MenuDesc.Render(m.wcStatus))
+		b.WriteString("\n\n")
+	case m.wcStatus != "" && m.wcFailed:
+		b.WriteString(m.styles.ErrorStyle.Render(glyphs.Cross + " " + m.wcStatus))
+		b.WriteString("\n\n")
+	case m.wcStatus != "":
+		b.WriteString(m.styles.SuccessStyle.Render(glyphs.Check + " " + m.wcStatus))
+		b.WriteString("\n\n")
+	}
+	b.WriteString(m.styles.MenuDesc.Render(help))
+	return b.String()
+}
+
+func (m *CLIModel) viewWCProposal(b *strings.Builder) {
+	p := m.wcProposals[0]
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf(localization.Labels["wc_proposal"], p.Proposer.Name)))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["wc_dapp_url"], p.Proposer.URL))
+	b.WriteString("\n")
+	if p.Proposer.Description != "" {
+		b.WriteString(m.styles.MenuDesc.Render(p.Proposer.Description))
+		b.WriteString("\n")
+	}
+	chains, missing, err := m.wcProposalChains()
+	if err != nil {
+		b.WriteString(m.styles.ErrorStyle.Render(err.Error()))
+		b.WriteString("\n")
+	} else {
+		labels := make([]string, 0, len(chains))
+		for _, id := range chains {
+			labels = append(labels, m.wcChainLabel(id))
+		}
+		b.WriteString(fmt.Sprintf(localization.Labels["wc_chains"], strings.Join(labels, ", ")))
+		b.WriteString("\n")
+		if len(missing) > 0 {
+			b.WriteString(m.styles.ErrorStyle.Render(fmt.Sprintf(localization.Labels["wc_missing_chains"], missing)))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+	if len(m.wcWallets) == 0 {
+		b.WriteString(m.styles.ErrorStyle.Render(localization.Labels["wc_no_wallets"]))
+	} else {
+		w := m.wcWallets[m.wcWallet]
+		b.WriteString(fmt.Sprintf(localization.Labels["wc_wallet"], w.Name, w.Address, m.wcWallet+1, len(m.wcWallets)))
+	}
+	b.WriteString("\n\n")
+}
+
+func (m *CLIModel) viewWCRequest(b *strings.Builder) {
+	r := m.wcRequests[0]
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf(localization.Labels["wc_request"], r.req.Peer.Name, r.req.Method)))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf(localization.Labels["wc_request_account"], r.req.Address.Hex(), m.wcChainLabel(r.req.ChainID)))
+	b.WriteString("\n")
+	if len(m.wcRequests) > 1 {
+		b.WriteString(m.styles.MenuDesc.Render(fmt.Sprintf(localization.Labels["wc_more_requests"], len(m.wcRequests)-1)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	if r.err != nil {
+		b.WriteString(m.styles.ErrorStyle.Render(fmt.Sprintf(localization.Labels["wc_request_invalid"], r.err)))
+		b.WriteString("\n\n")
+		return
+	}
+	b.WriteString(r.sign.Summary())
+	b.WriteString("\n")
+	if r.sign.Tx != nil {
+		if call := m.describeCalldata(r.sign.Tx.Payload()); call != "" {
+			b.WriteString(fmt.Sprintf(localization.Labels["calldata_call"], call))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+	if m.wcUnlocking {
+		b.WriteString(localization.Labels["wc_password_prompt"])
+		b.WriteString("\n")
+		b.WriteString(m.wcPassword.View())
+		b.WriteString("\n\n")
+	}
+}
+
+func (m *CLIModel) viewWCSessions(b *strings.Builder) {
+	b.WriteString(localization.Labels["wc_uri_prompt"])
+	b.WriteString("\n")
+	b.WriteString(m.wcURI.View())
+	b.WriteString("\n\n")
+
+	sessions := m.wcClient.Sessions()
+	if len(sessions) == 0 {
+		b.WriteString(localization.Labels["wc_no_sessions"])
+		b.WriteString("\n\n")
+		return
+	}
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(localization.Labels["wc_sessions"]))
+	b.WriteString("\n")
+	if m.wcSession >= len(sessions) {
+		m.wcSession = len(sessions) - 1
+	}
+	for i, s := range sessions {
+		cursor := "  "
+		if i == m.wcSession {
+			cursor = "> "
+		}
+		chains := make([]string, 0, len(s.Chains))
+		for _, id := range s.Chains {
+			chains = append(chains, m.wcChainLabel(id))
+		}
+		line := fmt.Sprintf("%s%-20s %s  %s", cursor, truncateRunes(s.Peer.Name, 20), s.Address.Hex(), strings.Join(chains, ", "))
+		if !s.Acknowledged {
+			line += " " + localization.Labels["wc_session_unconfirmed"]
+		}
+		if i == m.wcSession {
+			line = m.styles.SelectedTitle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"encoding/json"
+	"testing"
+
+	"blocowallet/internal/constants"
+	"blocowallet/internal/walletconnect"
+	"blocowallet/pkg/config"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWalletConnectModel(projectID string) *CLIModel {
+	localization.SetCurrentLanguage("en")
+	localization.AddWalletConnectMessages()
+	m := &CLIModel{styles: createStyles()}
+	m.currentConfig = &config.Config{
+		WalletConnect: config.WalletConnectConfig{ProjectID: projectID, RelayURL: "wss://relay.example.org"},
+		Networks: map[string]config.Network{
+			"mainnet": {Name: "Ethereum", ChainID: 1, Symbol: "ETH", RPCEndpoint: "https://rpc.example.org", IsActive: true},
+		},
+	}
+	return m
+}
+
+func TestWalletConnect_RequiresProjectID(t *testing.T) {
+	m := newWalletConnectModel("")
+	assert.Nil(t, m.initWalletConnect())
+	assert.Equal(t, constants.WalletConnectView, m.currentView)
+	assert.Nil(t, m.wcClient)
+	assert.Contains(t, m.viewWalletConnect(), "walletconnect.project_id")
+}
+
+func TestWalletConnect_ProposalReview(t *testing.T) {
+	m := newWalletConnectModel("project-1")
+	require.NotNil(t, m.initWalletConnect(), "the client starts listening for events")
+	require.NotNil(t, m.wcClient)
+	assert.True(t, m.wcCapturesText(), "the URI input takes the keys")
+
+	m.handleWCEvent(wcEventMsg{event: walletconnect.Event{Proposal: &walletconnect.Proposal{
+		ID:       1,
+		Proposer: walletconnect.Metadata{Name: "Uniswap", URL: "https://app.uniswap.org"},
+		Required: map[string]walletconnect.Namespace{"eip155": {Chains: []string{"eip155:1", "eip155:10"}}},
+	}}})
+	assert.Equal(t, "WC: 1 waiting", m.wcStatusIndicator())
+	assert.False(t, m.wcCapturesText())
+	view := m.viewWalletConnect()
+	assert.Contains(t, view, "Uniswap asks to connect")
+	assert.Contains(t, view, "Ethereum (1), 10")
+	assert.Contains(t, view, "No active network serves chains [10]")
+
+	// A required chain without a network prevents the approval
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, m.wcFailed)
+	assert.False(t, m.wcBusy)
+
+	m.handleWCApproved(wcApprovedMsg{rejected: true})
+	assert.Empty(t, m.wcProposals)
+	assert.Empty(t, m.wcStatusIndicator())
+	assert.Contains(t, m.viewWalletConnect(), "Rejected Uniswap")
+}
+
+func TestWalletConnect_RequestReview(t *testing.T) {
+	m := newWalletConnectModel("project-1")
+	m.initWalletConnect()
+	account := common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	params, _ := json.Marshal([]string{"0x48656c6c6f", account.Hex()})
+	m.handleWCEvent(wcEventMsg{event: walletconnect.Event{Request: &walletconnect.Request{
+		ID: 2, Topic: "topic", Peer: walletconnect.Metadata{Name: "OpenSea"}, Address: account, ChainID: 1, Method: "personal_sign", Params: params,
+	}}})
+	view := m.viewWalletConnect()
+	assert.Contains(t, view, "OpenSea requests personal_sign")
+	assert.Contains(t, view, "Hello")
+
+	// Approving asks for the wallet's password; esc keeps the request queued
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, m.wcUnlocking)
+	assert.True(t, m.capturesTextInput())
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, m.wcUnlocking)
+	assert.Equal(t, constants.WalletConnectView, m.currentView)
+	assert.Len(t, m.wcRequests, 1)
+
+	// A closed session drops its requests
+	m.handleWCEvent(wcEventMsg{event: walletconnect.Event{Deleted: &walletconnect.Session{Topic: "topic", Peer: walletconnect.Metadata{Name: "OpenSea"}}}})
+	assert.Empty(t, m.wcRequests)
+	assert.Contains(t, m.viewWalletConnect(), "OpenSea closed the session")
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"blocowallet/internal/changelog"
+	"blocowallet/internal/constants"
+	"blocowallet/pkg/localization"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SetReleaseNotes sets the releases shown on the "What's new" screen after
+// the splash; none skips the screen
+func (m *CLIModel) SetReleaseNotes(releases []changelog.Release) {
+	m.releaseNotes = releases
+}
+
+// updateWhatsNew leaves the notes for the main menu
+func (m *CLIModel) updateWhatsNew(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && (msg.String() == "enter" || msg.String() == " ") {
+		m.currentView = constants.DefaultView
+	}
+	return m, nil
+}
+
+// viewWhatsNew lists the new features and breaking changes of each release
+// since the last run, in the current language
+func (m *CLIModel) viewWhatsNew() string {
+	var b strings.Builder
+	b.WriteString(m.styles.MenuTitle.Render(localization.Labels["whats_new_title"]))
+	b.WriteString("\n\n")
+
+	lang := localization.GetCurrentLanguage()
+	for _, release := range m.releaseNotes {
+		notes := release.Localized(lang)
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf(localization.Labels["whats_new_version"], release.Version)))
+		b.WriteString("\n")
+		for _, feature := range notes.Features {
+			b.WriteString(fmt.Sprintf("  %s %s\n", glyphs.Bullet, feature))
+		}
+		if len(notes.Breaking) > 0 {
+			b.WriteString(fmt.Sprintf("\n  %s %s\n", glyphs.Warning, localization.Labels["whats_new_breaking"]))
+			for _, change := range notes.Breaking {
+				b.WriteString(fmt.Sprintf("    %s %s\n", glyphs.Bullet, change))
+			}
+		}
+		// The link is left unstyled so terminals can detect it
+		b.WriteString("\n  " + fmt.Sprintf(localization.Labels["whats_new_release_notes"], release.URL()))
+		b.WriteString("\n\n")
+	}
+	b.WriteString(m.styles.MenuDesc.Render(localization.Labels["whats_new_help"]))
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"testing"
+
+	"blocowallet/internal/changelog"
+	"blocowallet/internal/constants"
+	"blocowallet/internal/platform"
+	"blocowallet/pkg/localization"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func testReleaseNotes() []changelog.Release {
+	return []changelog.Release{{Version: "0.3.0", Notes: map[string]changelog.Notes{
+		"en": {Features: []string{"Live balances"}, Breaking: []string{"No fixed mainnet endpoint"}},
+		"pt": {Features: []string{"Saldos ao vivo"}},
+	}}}
+}
+
+func TestWhatsNew_ShownAfterSplash(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddWhatsNewMessages()
+
+	m := &CLIModel{styles: createStyles(), currentView: constants.SplashView}
+	m.SetReleaseNotes(testReleaseNotes())
+
+	_, _ = m.Update(splashMsg{})
+	assert.Equal(t, constants.WhatsNewView, m.currentView)
+	view := m.viewWhatsNew()
+	assert.Contains(t, view, "Version 0.3.0")
+	assert.Contains(t, view, "Live balances")
+	assert.Contains(t, view, "Breaking changes:")
+	assert.Contains(t, view, "No fixed mainnet endpoint")
+	assert.Contains(t, view, changelog.ReleasesURL+"/tag/v0.3.0")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, constants.DefaultView, m.currentView)
+}
+
+func TestWhatsNew_Localized(t *testing.T) {
+	localization.SetCurrentLanguage("pt")
+	localization.AddWhatsNewMessages()
+	defer func() {
+		localization.SetCurrentLanguage("en")
+		localization.AddWhatsNewMessages()
+	}()
+
+	m := &CLIModel{styles: createStyles(), currentView: constants.WhatsNewView}
+	m.SetReleaseNotes(testReleaseNotes())
+	view := m.viewWhatsNew()
+	assert.Contains(t, view, "Versão 0.3.0")
+	assert.Contains(t, view, "Saldos ao vivo")
+	assert.NotContains(t, view, "Live balances")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, constants.DefaultView, m.currentView)
+}
+
+func TestWhatsNew_SecurityWarningReturnsToNotes(t *testing.T) {
+	localization.SetCurrentLanguage("en")
+	localization.AddSecurityMessages()
+	localization.AddWhatsNewMessages()
+
+	m := &CLIModel{styles: createStyles(), currentView: constants.SplashView}
+	m.SetReleaseNotes(testReleaseNotes())
+	_, _ = m.Update(permissionReportMsg{report: platform.EnforceReport{Issues: []platform.PermissionIssue{{Path: "/wallets/key.json"}}}})
+
+	_, _ = m.Update(splashMsg{})
+	assert.Equal(t, constants.SecurityWarningView, m.currentView)
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, constants.WhatsNewView, m.currentView)
+}
+
+func TestWhatsNew_SkippedWithoutNotes(t *testing.T) {
+	m := &CLIModel{styles: createStyles(), currentView: constants.SplashView}
+	_, _ = m.Update(splashMsg{})
+	assert.Equal(t, constants.DefaultView, m.currentView)
+}
@@ -0,0 +1,53 @@
+package wallet
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrInvalidAddress is returned for input that is not a 20-byte hex address
+var ErrInvalidAddress = errors.New("invalid Ethereum address")
+
+// ErrAddressChecksum is returned for a mixed-case address whose letters do
+// not match its EIP-55 checksum, which usually means a mistyped character
+var ErrAddressChecksum = errors.New("the address does not match its EIP-55 checksum")
+
+// ChecksumAddress returns the EIP-55 form of address, the one shown
+// everywhere. Anything that is not an address is returned as given.
+func ChecksumAddress(address string) string {
+	trimmed := strings.TrimSpace(address)
+	if !common.IsHexAddress(trimmed) {
+		return address
+	}
+	return common.HexToAddress(trimmed).Hex()
+}
+
+// SameAddress reports whether a and b are the same address, whatever their case
+func SameAddress(a, b string) bool {
+	if a == b {
+		return true
+	}
+	return common.IsHexAddress(a) && common.IsHexAddress(b) && common.HexToAddress(a) == common.HexToAddress(b)
+}
+
+// ParseAddress reads an address typed by the user. All-lowercase and
+// all-uppercase input carry no checksum and are accepted; mixed-case input
+// must match EIP-55. On ErrAddressChecksum the parsed address is returned as
+// well, for callers that let the user confirm it anyway.
+func ParseAddress(input string) (common.Address, error) {
+	input = strings.TrimSpace(input)
+	if !common.IsHexAddress(input) {
+		return common.Address{}, ErrInvalidAddress
+	}
+	address := common.HexToAddress(input)
+	digits := strings.TrimPrefix(strings.TrimPrefix(input, "0x"), "0X")
+	if digits == strings.ToLower(digits) || digits == strings.ToUpper(digits) {
+		return address, nil
+	}
+	if digits != address.Hex()[2:] {
+		return address, ErrAddressChecksum
+	}
+	return address, nil
+}
@@ -0,0 +1,39 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eip55Address is one of the test vectors of EIP-55
+const eip55Address = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+
+func TestChecksumAddress(t *testing.T) {
+	assert.Equal(t, eip55Address, ChecksumAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"))
+	assert.Equal(t, eip55Address, ChecksumAddress(" 5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED "))
+	assert.Equal(t, "not an address", ChecksumAddress("not an address"))
+}
+
+func TestSameAddress(t *testing.T) {
+	assert.True(t, SameAddress(eip55Address, "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"))
+	assert.False(t, SameAddress(eip55Address, "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"))
+	assert.False(t, SameAddress(eip55Address, ""))
+}
+
+func TestParseAddress(t *testing.T) {
+	for _, input := range []string{eip55Address, "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED"} {
+		address, err := ParseAddress(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, eip55Address, address.Hex())
+	}
+
+	// One letter with the wrong case
+	address, err := ParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD")
+	assert.ErrorIs(t, err, ErrAddressChecksum)
+	assert.Equal(t, eip55Address, address.Hex())
+
+	_, err = ParseAddress("0x1234")
+	assert.ErrorIs(t, err, ErrInvalidAddress)
+}
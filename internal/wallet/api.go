@@ -0,0 +1,252 @@
+package wallet
+
+import (
+	"time"
+
+	"blocowallet/pkg/config"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// APIVersion identifies a generation of the WalletService API. Methods of an
+// older version stay available, marked deprecated, until their callers have
+// moved to the replacement.
+type APIVersion int
+
+const (
+	// APIV1 is the original service: create, import and load wallets
+	APIV1 APIVersion = 1
+	// APIV2 adds KeyStore V3 imports with progress and diagnostics, and the
+	// capabilities listed in Capability
+	APIV2 APIVersion = 2
+
+	CurrentAPIVersion = APIV2
+)
+
+// ServiceV1 is the API of the first version. Integrations written against it
+// keep compiling; see Deprecations for what to call instead.
+type ServiceV1 interface {
+	CreateWallet(name, password string) (*WalletDetails, error)
+	ImportWallet(name, mnemonic, password string) (*WalletDetails, error)
+	ImportWalletFromPrivateKey(name, privateKeyHex, password string) (*WalletDetails, error)
+	ImportWalletFromKeystore(name, keystorePath, password string) (*WalletDetails, error)
+	LoadWallet(wallet *Wallet, password string) (*WalletDetails, error)
+	GetAllWallets() ([]Wallet, error)
+	DeleteWallet(wallet *Wallet) error
+}
+
+// ServiceV2 is the current API: the V1 methods that are not deprecated, plus
+// one interface per capability
+type ServiceV2 interface {
+	Creator
+	MnemonicImporter
+	PrivateKeyImporter
+	KeystoreImporter
+	LoadWallet(wallet *Wallet, password string) (*WalletDetails, error)
+	GetAllWallets() ([]Wallet, error)
+	DeleteWallet(wallet *Wallet) error
+	BatchCreator
+	MetadataEditor
+	WatchOnlyImporter
+	SafeOwnerImporter
+	ChildWalletCreator
+	KeystoreExporter
+	EncryptedExporter
+	RPCOverrider
+	TransactionTracker
+	Quarantiner
+	StoreVerifier
+}
+
+// The service implements every version it claims; a removed or changed
+// method breaks the build here instead of at a call site
+var (
+	_ ServiceV1 = (*WalletService)(nil)
+	_ ServiceV2 = (*WalletService)(nil)
+)
+
+// Capability names a feature of the wallet service that callers can check
+// for before offering it
+type Capability string
+
+const (
+	CapCreate            Capability = "create"
+	CapImportMnemonic    Capability = "import_mnemonic"
+	CapImportPrivateKey  Capability = "import_private_key"
+	CapImportKeystore    Capability = "import_keystore"
+	CapBatchCreate       Capability = "batch_create"
+	CapEditMetadata      Capability = "edit_metadata"
+	CapWatchOnly         Capability = "watch_only"
+	CapSafeOwners        Capability = "safe_owners"
+	CapChildWallets      Capability = "child_wallets"
+	CapExportKeystore    Capability = "export_keystore"
+	CapEncryptedExport   Capability = "encrypted_export"
+	CapRPCOverrides      Capability = "rpc_overrides"
+	CapTrackTransactions Capability = "track_transactions"
+	CapQuarantine        Capability = "quarantine"
+	CapVerifyStore       Capability = "verify_store"
+)
+
+// capabilityMatrix ties each capability to the API version that introduced
+// it and to the interface that provides it. Capabilities are checked against
+// the interfaces rather than assumed from the version, so a service that only
+// implements part of a version still reports exactly what it offers.
+var capabilityMatrix = []struct {
+	capability Capability
+	since      APIVersion
+	provided   func(svc any) bool
+}{
+	{CapCreate, APIV1, provides[Creator]},
+	{CapImportMnemonic, APIV1, provides[MnemonicImporter]},
+	{CapImportPrivateKey, APIV1, provides[PrivateKeyImporter]},
+	{CapImportKeystore, APIV2, provides[KeystoreImporter]},
+	{CapBatchCreate, APIV2, provides[BatchCreator]},
+	{CapEditMetadata, APIV2, provides[MetadataEditor]},
+	{CapWatchOnly, APIV2, provides[WatchOnlyImporter]},
+	{CapSafeOwners, APIV2, provides[SafeOwnerImporter]},
+	{CapChildWallets, APIV2, provides[ChildWalletCreator]},
+	{CapExportKeystore, APIV2, provides[KeystoreExporter]},
+	{CapEncryptedExport, APIV2, provides[EncryptedExporter]},
+	{CapRPCOverrides, APIV2, provides[RPCOverrider]},
+	{CapTrackTransactions, APIV2, provides[TransactionTracker]},
+	{CapQuarantine, APIV2, provides[Quarantiner]},
+	{CapVerifyStore, APIV2, provides[StoreVerifier]},
+}
+
+func provides[T any](svc any) bool {
+	_, ok := svc.(T)
+	return ok
+}
+
+// CapabilitySet is what a service offers, from Capabilities
+type CapabilitySet map[Capability]bool
+
+// Has reports whether c is offered
+func (s CapabilitySet) Has(c Capability) bool {
+	return s[c]
+}
+
+// Capabilities checks svc against every interface of the capability matrix
+func Capabilities(svc any) CapabilitySet {
+	set := make(CapabilitySet, len(capabilityMatrix))
+	for _, entry := range capabilityMatrix {
+		set[entry.capability] = entry.provided(svc)
+	}
+	return set
+}
+
+// CapabilitySince returns the API version that introduced c, zero when c is unknown
+func CapabilitySince(c Capability) APIVersion {
+	for _, entry := range capabilityMatrix {
+		if entry.capability == c {
+			return entry.since
+		}
+	}
+	return 0
+}
+
+// Deprecation describes a method kept for callers of an older API version
+type Deprecation struct {
+	Method      string
+	Since       APIVersion // Version that deprecated it
+	Replacement string
+	Reason      string
+}
+
+// Deprecations lists the deprecated WalletService methods. Each one also has
+// a "Deprecated:" doc comment, which editors and linters show at call sites.
+var Deprecations = []Deprecation{
+	{
+		Method:      "ImportWalletFromKeystore",
+		Since:       APIV2,
+		Replacement: "ImportWalletFromKeystoreV3",
+		Reason:      "the V3 import validates the file, reports progress and explains why a keystore cannot be imported",
+	},
+}
+
+// Creator creates wallets from a new mnemonic
+type Creator interface {
+	CreateWallet(name, password string) (*WalletDetails, error)
+}
+
+// MnemonicImporter imports wallets from a mnemonic
+type MnemonicImporter interface {
+	ImportWallet(name, mnemonic, password string) (*WalletDetails, error)
+}
+
+// PrivateKeyImporter imports wallets from a hex private key
+type PrivateKeyImporter interface {
+	ImportWalletFromPrivateKey(name, privateKeyHex, password string) (*WalletDetails, error)
+}
+
+// KeystoreImporter imports KeyStore V3 files
+type KeystoreImporter interface {
+	ImportWalletFromKeystoreV3(name, keystorePath, password string) (*WalletDetails, error)
+	ImportWalletFromKeystoreV3WithProgress(name, keystorePath, password string, progressChan chan<- ImportProgress) (*WalletDetails, error)
+	ImportWalletFromKeystoreJSON(name string, keyJSON []byte, password string) (*WalletDetails, error)
+}
+
+// BatchCreator creates many wallets at once
+type BatchCreator interface {
+	CreateWallets(opts BatchCreateOptions, progress func(done, total int)) ([]BatchCreatedWallet, error)
+}
+
+// MetadataEditor renames wallets and edits their notes
+type MetadataEditor interface {
+	RenameWallet(wallet *Wallet, name string, at time.Time) error
+	UpdateWalletNotes(wallet *Wallet, notes string, references []string, at time.Time) error
+}
+
+// WatchOnlyImporter adds addresses without a key
+type WatchOnlyImporter interface {
+	AddWatchOnlyWallet(name, address, notes string, references []string) (*Wallet, error)
+}
+
+// SafeOwnerImporter adds the owners of a Safe as watch-only wallets
+type SafeOwnerImporter interface {
+	SafeOwners(owners []common.Address) ([]SafeOwner, error)
+	ImportSafeOwners(chainID int64, safe common.Address, owners []SafeOwner, label string, at time.Time) (SafeOwnerImport, error)
+}
+
+// ChildWalletCreator derives child wallets from a master mnemonic
+type ChildWalletCreator interface {
+	ChildAllocations(master *Wallet) ([]ChildAllocation, error)
+	CreateChildWallet(master *WalletDetails, index uint32, label, password string) (*WalletDetails, error)
+	RegisterDiscoveredChildren(master *WalletDetails, found []DiscoveredChild, password string) (registered, skipped int, err error)
+}
+
+// KeystoreExporter exports keys as KeyStore V3 files
+type KeystoreExporter interface {
+	ExportKeystore(details *WalletDetails, password, destPath string) error
+}
+
+// EncryptedExporter exports secrets encrypted to a recipient
+type EncryptedExporter interface {
+	ExportEncryptedSecret(details *WalletDetails, secret ExportSecret, recipient *ExportRecipient, outputPath string) error
+}
+
+// RPCOverrider keeps per-wallet RPC endpoints
+type RPCOverrider interface {
+	SetRPCOverride(address string, chainID int64, endpoint string) error
+	RPCOverrides(address string) ([]RPCOverride, error)
+	NetworkFor(address string, network config.Network) config.Network
+}
+
+// TransactionTracker follows sent transactions until they are final
+type TransactionTracker interface {
+	TrackTransaction(raw *RawTransaction, network, kind, replaces string) (*TrackedTransaction, error)
+	TrackedTransactions(openOnly bool) ([]TrackedTransaction, error)
+	UpdateTrackedTransaction(tx *TrackedTransaction) error
+}
+
+// Quarantiner moves aside wallets whose keystore fails its checks
+type Quarantiner interface {
+	QuarantineWallet(wallet *Wallet, problems []string) (string, error)
+}
+
+// StoreVerifier cross-checks the database with the keystore directory and
+// repairs what disagrees
+type StoreVerifier interface {
+	VerifyStore() (*StoreReport, error)
+	RepairStoreIssue(issue StoreIssue, repair StoreRepair, name, password string) error
+}
@@ -0,0 +1,42 @@
+package wallet
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapabilities(t *testing.T) {
+	current := Capabilities((*WalletService)(nil))
+	assert.Len(t, current, len(capabilityMatrix))
+	for c, ok := range current {
+		assert.True(t, ok, c)
+		assert.NotZero(t, CapabilitySince(c), c)
+	}
+
+	// An integration written against the first API only offers its capabilities
+	v1 := Capabilities(struct{ ServiceV1 }{})
+	for _, entry := range capabilityMatrix {
+		assert.Equal(t, entry.since == APIV1, v1.Has(entry.capability), entry.capability)
+	}
+	assert.Zero(t, CapabilitySince("teleport"))
+}
+
+func TestDeprecations(t *testing.T) {
+	svc := reflect.TypeOf((*WalletService)(nil))
+	v2 := reflect.TypeOf((*ServiceV2)(nil)).Elem()
+	for _, d := range Deprecations {
+		old, ok := svc.MethodByName(d.Method)
+		if assert.True(t, ok, "%s still exists for old callers", d.Method) {
+			_, inV2 := v2.MethodByName(d.Method)
+			assert.False(t, inV2, "%s is not part of the current API", d.Method)
+		}
+		replacement, ok := svc.MethodByName(d.Replacement)
+		if assert.True(t, ok, d.Replacement) {
+			assert.Equal(t, old.Type, replacement.Type, "%s can replace %s without changing the call", d.Replacement, d.Method)
+		}
+		assert.LessOrEqual(t, d.Since, CurrentAPIVersion)
+		assert.NotEmpty(t, d.Reason)
+	}
+}
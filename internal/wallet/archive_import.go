@@ -0,0 +1,183 @@
+package wallet
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// maxArchiveEntrySize bounds each extracted file; a keystore is a few KB
+	maxArchiveEntrySize = 1 << 20
+	// maxArchiveFiles bounds how many files one archive may hold
+	maxArchiveFiles = 10000
+)
+
+// IsKeystoreArchive reports whether path names an archive keystores can be
+// imported from: .zip, .tar.gz or .tgz
+func IsKeystoreArchive(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasSuffix(lower, ".zip") || strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz")
+}
+
+// extractArchive copies the keystore (.json), password (.pwd) and password
+// manifest files of an archive into a new temporary directory, keeping their
+// relative paths so each password file stays next to its keystore. Entries
+// that would land outside the directory, links and oversized files are left out.
+// With ArchiveDir set the archive must be inside it and is extracted under it.
+func (bis *BatchImportService) extractArchive(archivePath string) (string, error) {
+	tempDir := ""
+	if bis.ArchiveDir != "" {
+		rel, err := filepath.Rel(bis.ArchiveDir, archivePath)
+		if err != nil || !filepath.IsLocal(rel) {
+			return "", fmt.Errorf("process hardening only lets archives be read from %s: copy %s into it and pick it there", bis.ArchiveDir, filepath.Base(archivePath))
+		}
+		tempDir = filepath.Join(bis.ArchiveDir, "tmp")
+		if err := os.MkdirAll(tempDir, 0700); err != nil {
+			return "", fmt.Errorf("cannot create a directory for the archive: %w", err)
+		}
+	}
+	dir, err := os.MkdirTemp(tempDir, "bloco-archive-")
+	if err != nil {
+		return "", fmt.Errorf("cannot create a directory for the archive: %w", err)
+	}
+	if strings.HasSuffix(strings.ToLower(archivePath), ".zip") {
+		err = extractZip(archivePath, dir)
+	} else {
+		err = extractTarGz(archivePath, dir)
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("cannot extract %s: %w", filepath.Base(archivePath), err)
+	}
+
+	bis.extractedMu.Lock()
+	bis.extractedDirs = append(bis.extractedDirs, dir)
+	bis.extractedMu.Unlock()
+	return dir, nil
+}
+
+// Close removes the files extracted from archives. Failed imports can be
+// retried until then, so it is called when the import screen is left.
+func (bis *BatchImportService) Close() error {
+	bis.extractedMu.Lock()
+	defer bis.extractedMu.Unlock()
+	var errs []error
+	for _, dir := range bis.extractedDirs {
+		if err := os.RemoveAll(dir); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	bis.extractedDirs = nil
+	return errors.Join(errs...)
+}
+
+// archiveTarget returns where an archive entry is written inside dir, or ""
+// when the entry is not a keystore, password file or password manifest or is
+// not a local path
+func archiveTarget(dir, name string) string {
+	name = filepath.FromSlash(name)
+	if !filepath.IsLocal(name) {
+		return ""
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".pwd":
+		return filepath.Join(dir, name)
+	}
+	if IsPasswordManifest(name) {
+		return filepath.Join(dir, name)
+	}
+	return ""
+}
+
+// writeArchiveEntry writes one extracted file, refusing files over the size limit
+func writeArchiveEntry(target string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(f, io.LimitReader(r, maxArchiveEntrySize+1))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && n > maxArchiveEntrySize {
+		err = fmt.Errorf("%s is larger than %d bytes", filepath.Base(target), maxArchiveEntrySize)
+	}
+	return err
+}
+
+func extractZip(archivePath, dir string) error {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if len(r.File) > maxArchiveFiles {
+		return fmt.Errorf("the archive holds more than %d files", maxArchiveFiles)
+	}
+	for _, entry := range r.File {
+		if !entry.Mode().IsRegular() {
+			continue
+		}
+		target := archiveTarget(dir, entry.Name)
+		if target == "" || entry.UncompressedSize64 > maxArchiveEntrySize {
+			continue
+		}
+		rc, err := entry.Open()
+		if err != nil {
+			return err
+		}
+		err = writeArchiveEntry(target, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractTarGz(archivePath, dir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for files := 0; ; files++ {
+		if files > maxArchiveFiles {
+			return fmt.Errorf("the archive holds more than %d files", maxArchiveFiles)
+		}
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		target := archiveTarget(dir, header.Name)
+		if target == "" || header.Size > maxArchiveEntrySize {
+			continue
+		}
+		if err := writeArchiveEntry(target, tr); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package wallet
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// archiveEntries are the files written into the test archives
+func archiveEntries(t *testing.T) map[string][]byte {
+	path, _ := createTestKeystoreFile(t, "secret")
+	keyJSON, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return map[string][]byte{
+		"backup/alice.json": keyJSON,
+		"backup/alice.pwd":  []byte("secret"),
+		"../escape.json":    keyJSON,
+		"README.txt":        []byte("not a keystore"),
+	}
+}
+
+func writeZip(t *testing.T, path string, entries map[string][]byte) {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w := zip.NewWriter(f)
+	for name, data := range entries {
+		entry, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+}
+
+func writeTarGz(t *testing.T, path string, entries map[string][]byte) {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	gz := gzip.NewWriter(f)
+	w := tar.NewWriter(gz)
+	for name, data := range entries {
+		require.NoError(t, w.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), Typeflag: tar.TypeReg}))
+		_, err = w.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, gz.Close())
+	require.NoError(t, f.Close())
+}
+
+func TestCreateImportJobsFromArchive(t *testing.T) {
+	dir := t.TempDir()
+	entries := archiveEntries(t)
+	zipPath := filepath.Join(dir, "keystores.zip")
+	tarPath := filepath.Join(dir, "keystores.tar.gz")
+	writeZip(t, zipPath, entries)
+	writeTarGz(t, tarPath, entries)
+
+	for _, archive := range []string{zipPath, tarPath} {
+		t.Run(filepath.Base(archive), func(t *testing.T) {
+			service := NewBatchImportService(&WalletService{})
+			jobs, err := service.CreateImportJobsFromDirectory(archive)
+			require.NoError(t, err)
+			require.Len(t, jobs, 1, "only the keystore inside the archive is imported")
+			job := jobs[0]
+			assert.Equal(t, "alice", job.WalletName)
+			assert.NotEmpty(t, job.PasswordPath, "the password file is extracted next to its keystore")
+			assert.False(t, job.RequiresInput)
+			extracted := filepath.Dir(filepath.Dir(job.KeystorePath))
+			assert.NoFileExists(t, filepath.Join(filepath.Dir(extracted), "escape.json"))
+
+			require.NoError(t, service.Close())
+			assert.NoDirExists(t, extracted, "Close removes the extracted files")
+		})
+	}
+
+	// Archives can be picked among keystore files
+	path, _ := createTestKeystoreFile(t, "secret")
+	service := NewBatchImportService(&WalletService{})
+	defer service.Close()
+	jobs, err := service.CreateImportJobsFromFiles([]string{path, zipPath})
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, path, jobs[0].KeystorePath)
+
+	// Other files are still refused
+	notes := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(notes, []byte("notes"), 0600))
+	_, err = service.CreateImportJobsFromDirectory(notes)
+	assert.ErrorContains(t, err, "not a directory or an archive")
+	assert.True(t, IsKeystoreArchive("old/BACKUP.TGZ"))
+	assert.False(t, IsKeystoreArchive("wallet.json"))
+}
+
+func TestCreateImportJobsFromArchive_ArchiveDir(t *testing.T) {
+	appDir, elsewhere := t.TempDir(), t.TempDir()
+	outside := filepath.Join(elsewhere, "keystores.zip")
+	writeZip(t, outside, archiveEntries(t))
+
+	service := NewBatchImportService(&WalletService{})
+	service.ArchiveDir = appDir
+	defer service.Close()
+
+	// An archive outside the directory is refused, naming where to copy it
+	_, err := service.CreateImportJobsFromDirectory(outside)
+	assert.ErrorContains(t, err, "read from "+appDir)
+	assert.ErrorContains(t, err, "copy keystores.zip into it")
+	_, err = service.CreateImportJobsFromDirectory(filepath.Join(appDir, "..", filepath.Base(elsewhere), "keystores.zip"))
+	assert.ErrorContains(t, err, appDir)
+
+	// Copied into it, the archive is read and extracted there
+	inside := filepath.Join(appDir, "imports", "keystores.zip")
+	require.NoError(t, os.MkdirAll(filepath.Dir(inside), 0700))
+	data, err := os.ReadFile(outside)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(inside, data, 0600))
+	jobs, err := service.CreateImportJobsFromDirectory(inside)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	rel, err := filepath.Rel(filepath.Join(appDir, "tmp"), jobs[0].KeystorePath)
+	require.NoError(t, err)
+	assert.True(t, filepath.IsLocal(rel), "extracted under the directory")
+}
@@ -0,0 +1,616 @@
+package wallet
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+)
+
+// BackupFormatVersion is written in the header of new backups; ImportBackup
+// restores backups of this version and older ones. Version 2 names the
+// encryption in the header and may carry extra files.
+const BackupFormatVersion = 2
+
+// backupMagic starts the plain-text header line of a backup, followed by the
+// format version and the encryption; the encrypted archive comes after it
+const backupMagic = "BLOCO-WALLET-BACKUP"
+
+// Encryption of the archive, named in the header of a backup
+const (
+	// BackupEncryptionPassword is age with a scrypt password, written by ExportBackup
+	BackupEncryptionPassword = "password"
+	// BackupEncryptionAge is age to public keys, written by ExportBackupTo
+	BackupEncryptionAge = "age"
+	// BackupEncryptionGPG is OpenPGP to a public key, written by ExportBackupTo
+	BackupEncryptionGPG = "gpg"
+)
+
+// Names inside the backup archive
+const (
+	backupManifestName   = "manifest.json"
+	backupDatabaseName   = "wallets.db"
+	backupKeystorePrefix = "keystore/"
+	// Files such as the configuration, checked on restore but not written back
+	backupExtraPrefix = "extra/"
+)
+
+// backupScryptWorkFactor is the age scrypt work factor protecting the
+// password; tests lower it
+var backupScryptWorkFactor = 18
+
+var (
+	// ErrBackupUnsupported is returned when the repository cannot copy or replace its database
+	ErrBackupUnsupported = errors.New("the wallet database does not support backups")
+	// ErrNotBackup is returned for files without the backup header
+	ErrNotBackup = errors.New("not a wallet backup")
+	// ErrBackupVersion is returned for backups written by a newer version
+	ErrBackupVersion = errors.New("backup was written by a newer version")
+	// ErrBackupPassword is returned when the backup cannot be decrypted
+	ErrBackupPassword = errors.New("wrong backup password")
+	// ErrBackupKeyRequired is returned when a backup encrypted to a public key
+	// is opened without the matching identity file
+	ErrBackupKeyRequired = errors.New("the backup is encrypted to a key: give its age identity or GPG secret key file")
+	// ErrBackupKey is returned when the identity file does not open the backup
+	ErrBackupKey = errors.New("the key does not open this backup")
+	// ErrBackupCorrupted is returned when the archive does not match its manifest
+	ErrBackupCorrupted = errors.New("backup is damaged")
+)
+
+// BackupDatabase is implemented by repositories whose database can be copied
+// into a backup and replaced by the copy in one
+type BackupDatabase interface {
+	BackupTo(path string) error
+	// RestoreFrom replaces the content of the database with the one at path;
+	// keystorePath maps the keystore path of each restored wallet to this machine
+	RestoreFrom(path string, keystorePath func(stored string) string) error
+}
+
+// BackupManifest is the first entry of a backup archive. It lists every other
+// entry with its SHA-256 so a restore detects damaged or missing files.
+type BackupManifest struct {
+	Version   int          `json:"version"`
+	CreatedAt time.Time    `json:"created_at"`
+	Wallets   int          `json:"wallets"`
+	Files     []BackupFile `json:"files"`
+}
+
+// BackupFile is one entry of a backup archive
+type BackupFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Keystores counts the keystore files in the backup
+func (m *BackupManifest) Keystores() int {
+	count := 0
+	for _, f := range m.Files {
+		if strings.HasPrefix(f.Name, backupKeystorePrefix) {
+			count++
+		}
+	}
+	return count
+}
+
+// backupEntry is a file to put in the archive
+type backupEntry struct {
+	name string
+	path string
+}
+
+// backupEncrypter wraps the archive stream in the encryption of the backup
+type backupEncrypter func(dst io.Writer) (io.WriteCloser, error)
+
+// ExportBackup writes the wallet database and every keystore to a single
+// file encrypted with password. The file starts with a plain-text version
+// header; the archive after it is encrypted with age and holds a manifest
+// with the hash of each file. The output is created exclusively with
+// owner-only permissions and removed if anything fails.
+func (ws *WalletService) ExportBackup(path, password string) (*BackupManifest, error) {
+	if validationErr, ok := ValidatePassword(password); !ok {
+		return nil, errors.New(validationErr.GetErrorMessage())
+	}
+	encrypt := func(dst io.Writer) (io.WriteCloser, error) {
+		recipient, err := age.NewScryptRecipient(password)
+		if err != nil {
+			return nil, err
+		}
+		recipient.SetWorkFactor(backupScryptWorkFactor)
+		return age.Encrypt(dst, recipient)
+	}
+	return ws.exportBackup(path, BackupEncryptionPassword, encrypt, nil)
+}
+
+// ExportBackupTo writes the same backup as ExportBackup, encrypted to
+// recipient instead of a password, so unattended jobs can write it. extras
+// are files such as the configuration stored alongside; a restore checks
+// them but leaves them for the user to copy back.
+func (ws *WalletService) ExportBackupTo(path string, recipient *ExportRecipient, extras []string) (*BackupManifest, error) {
+	return ws.exportBackup(path, string(recipient.Type), recipient.encryptStream, extras)
+}
+
+func (ws *WalletService) exportBackup(path, encryption string, encrypt backupEncrypter, extras []string) (*BackupManifest, error) {
+	db, ok := ws.Repo.(BackupDatabase)
+	if !ok {
+		return nil, ErrBackupUnsupported
+	}
+	wallets, err := ws.Repo.GetAllWallets()
+	if err != nil {
+		return nil, err
+	}
+	keystoreDir, err := ws.keystoreDir()
+	if err != nil {
+		return nil, err
+	}
+
+	// A consistent copy of the database, even while another session writes to it
+	tmpDir, err := os.MkdirTemp(filepath.Dir(path), ".backup-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+	dbCopy := filepath.Join(tmpDir, backupDatabaseName)
+	if err := db.BackupTo(dbCopy); err != nil {
+		return nil, fmt.Errorf("copying database: %w", err)
+	}
+
+	entries, err := backupKeystores(keystoreDir, wallets)
+	if err != nil {
+		return nil, err
+	}
+	entries = append([]backupEntry{{name: backupDatabaseName, path: dbCopy}}, entries...)
+	for _, extra := range extras {
+		entries = append(entries, backupEntry{name: backupExtraPrefix + filepath.Base(extra), path: extra})
+	}
+
+	manifest := &BackupManifest{Version: BackupFormatVersion, CreatedAt: time.Now().UTC(), Wallets: len(wallets)}
+	for _, e := range entries {
+		file, err := hashBackupFile(e)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup file: %w", err)
+	}
+	if err := writeBackup(out, encryption, encrypt, manifest, entries); err != nil {
+		out.Close()
+		os.Remove(path)
+		return nil, err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to write backup file: %w", err)
+	}
+	return manifest, nil
+}
+
+// DefaultBackupFileName suggests a file name for a backup written at
+func DefaultBackupFileName(at time.Time) string {
+	return "bloco-wallet-" + at.UTC().Format("20060102T150405Z") + ".backup"
+}
+
+// backupKeystores lists the files of the keystore directory and the
+// keystores of wallets kept elsewhere, by file name
+func backupKeystores(keystoreDir string, wallets []Wallet) ([]backupEntry, error) {
+	var entries []backupEntry
+	paths := make(map[string]string)
+	add := func(path string) error {
+		name := backupKeystorePrefix + keystoreBaseName(path)
+		if existing, ok := paths[name]; ok {
+			if filepath.Clean(existing) == filepath.Clean(path) {
+				return nil
+			}
+			return fmt.Errorf("two keystores are named %s: %s and %s", keystoreBaseName(path), existing, path)
+		}
+		paths[name] = path
+		entries = append(entries, backupEntry{name: name, path: path})
+		return nil
+	}
+
+	dirEntries, err := os.ReadDir(keystoreDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range dirEntries {
+		if d.Type().IsRegular() && !strings.HasPrefix(d.Name(), ".") {
+			if err := add(filepath.Join(keystoreDir, d.Name())); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, w := range wallets {
+		if w.WatchOnly() || w.KeyStorePath == "" {
+			continue
+		}
+		if _, err := os.Stat(w.KeyStorePath); err != nil {
+			continue
+		}
+		if err := add(w.KeyStorePath); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// keystoreBaseName is the file name of a keystore path recorded on any
+// system, so a backup from Windows restores on Linux and the other way round
+func keystoreBaseName(path string) string {
+	if i := strings.LastIndexAny(path, `/\`); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
+
+func hashBackupFile(e backupEntry) (BackupFile, error) {
+	f, err := os.Open(e.path)
+	if err != nil {
+		return BackupFile{}, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return BackupFile{}, err
+	}
+	return BackupFile{Name: e.name, Size: n, SHA256: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// writeBackup writes the header line and the encrypted tar.gz with the
+// manifest first
+func writeBackup(dst io.Writer, encryption string, encrypt backupEncrypter, manifest *BackupManifest, entries []backupEntry) error {
+	if _, err := fmt.Fprintf(dst, "%s %d %s\n", backupMagic, manifest.Version, encryption); err != nil {
+		return err
+	}
+	encrypted, err := encrypt(dst)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(encrypted)
+	tw := tar.NewWriter(gz)
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: backupManifestName, Mode: 0600, Size: int64(len(manifestJSON)), ModTime: manifest.CreatedAt}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(manifestJSON); err != nil {
+		return err
+	}
+	for i, e := range entries {
+		if err := writeBackupEntry(tw, e, manifest.Files[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return encrypted.Close()
+}
+
+func writeBackupEntry(tw *tar.Writer, e backupEntry, file BackupFile) error {
+	f, err := os.Open(e.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: e.name, Mode: 0600, Size: file.Size, ModTime: info.ModTime()}); err != nil {
+		return err
+	}
+	// The file must not change between hashing and archiving
+	h := sha256.New()
+	if _, err := io.Copy(tw, io.TeeReader(io.LimitReader(f, file.Size), h)); err != nil {
+		return err
+	}
+	if hex.EncodeToString(h.Sum(nil)) != file.SHA256 {
+		return fmt.Errorf("%s changed while the backup was written", e.path)
+	}
+	return nil
+}
+
+// readBackupHeader returns the format version and the encryption of a
+// backup; version 1 backups were always encrypted with a password
+func readBackupHeader(r *bufio.Reader) (int, string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, "", err
+	}
+	fields := strings.Fields(line)
+	if len(fields) < 2 || fields[0] != backupMagic {
+		return 0, "", ErrNotBackup
+	}
+	v, err := strconv.Atoi(fields[1])
+	if err != nil || v < 1 {
+		return 0, "", ErrNotBackup
+	}
+	if v > BackupFormatVersion {
+		return v, "", fmt.Errorf("%w (format %d, this version reads up to %d)", ErrBackupVersion, v, BackupFormatVersion)
+	}
+	switch {
+	case v == 1 && len(fields) == 2:
+		return v, BackupEncryptionPassword, nil
+	case v > 1 && len(fields) == 3:
+		return v, fields[2], nil
+	}
+	return v, "", ErrNotBackup
+}
+
+// BackupKey opens a backup: the password of ExportBackup, or the age identity
+// or GPG secret key file for a backup written by ExportBackupTo, with
+// Password as the passphrase of a protected GPG key
+type BackupKey struct {
+	Password     string
+	IdentityFile string
+}
+
+// decrypt opens the archive that follows the header
+func (k BackupKey) decrypt(encryption string, src io.Reader) (io.Reader, error) {
+	switch encryption {
+	case BackupEncryptionPassword:
+		identity, err := age.NewScryptIdentity(k.Password)
+		if err != nil {
+			return nil, ErrBackupPassword
+		}
+		decrypted, err := age.Decrypt(src, identity)
+		var noMatch *age.NoIdentityMatchError
+		if errors.As(err, &noMatch) {
+			return nil, ErrBackupPassword
+		}
+		return decrypted, err
+	case BackupEncryptionAge:
+		if k.IdentityFile == "" {
+			return nil, ErrBackupKeyRequired
+		}
+		f, err := os.Open(k.IdentityFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		identities, err := age.ParseIdentities(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid age identity file: %w", err)
+		}
+		decrypted, err := age.Decrypt(src, identities...)
+		var noMatch *age.NoIdentityMatchError
+		if errors.As(err, &noMatch) {
+			return nil, ErrBackupKey
+		}
+		return decrypted, err
+	case BackupEncryptionGPG:
+		if k.IdentityFile == "" {
+			return nil, ErrBackupKeyRequired
+		}
+		return decryptPGP(src, k.IdentityFile, k.Password)
+	}
+	return nil, fmt.Errorf("%w (unknown encryption %q)", ErrBackupVersion, encryption)
+}
+
+// ImportBackup restores a backup written by ExportBackup: it checks every
+// file against the manifest, copies the keystores into the keystore
+// directory and replaces the wallet database with the one in the backup.
+// Nothing is changed unless the whole backup is intact, and a keystore
+// already present with a different content stops the restore.
+func (ws *WalletService) ImportBackup(path, password string) (*BackupManifest, error) {
+	return ws.ImportBackupWithKey(path, BackupKey{Password: password})
+}
+
+// ImportBackupWithKey restores a backup like ImportBackup, including the
+// ones ExportBackupTo encrypted to a public key
+func (ws *WalletService) ImportBackupWithKey(path string, key BackupKey) (*BackupManifest, error) {
+	db, ok := ws.Repo.(BackupDatabase)
+	if !ok {
+		return nil, ErrBackupUnsupported
+	}
+	keystoreDir, err := ws.keystoreDir()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "bloco-restore-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+	dbPath := filepath.Join(tmpDir, backupDatabaseName)
+
+	manifest, keystores, err := readBackup(path, key, dbPath)
+	defer func() {
+		for _, data := range keystores {
+			zeroBytes(data)
+		}
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	written, err := restoreKeystores(keystoreDir, keystores)
+	if err != nil {
+		return nil, err
+	}
+	restored := func(stored string) string {
+		if _, ok := keystores[keystoreBaseName(stored)]; ok {
+			return filepath.Join(keystoreDir, keystoreBaseName(stored))
+		}
+		return stored
+	}
+	if err := db.RestoreFrom(dbPath, restored); err != nil {
+		for _, p := range written {
+			os.Remove(p)
+		}
+		return nil, fmt.Errorf("restoring database: %w", err)
+	}
+	// The restored database carries its own master password, if any
+	ws.lockMasterPassword()
+	return manifest, nil
+}
+
+// readBackup decrypts the backup at path, writes its database to dbPath and
+// returns the keystores by file name once every file matches the manifest
+func readBackup(path string, key BackupKey, dbPath string) (*BackupManifest, map[string][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	br := bufio.NewReader(f)
+	_, encryption, err := readBackupHeader(br)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decrypted, err := key.decrypt(encryption, br)
+	if err != nil {
+		if errors.Is(err, ErrBackupPassword) || errors.Is(err, ErrBackupKey) || errors.Is(err, ErrBackupKeyRequired) || errors.Is(err, ErrBackupVersion) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("%w: %v", ErrBackupCorrupted, err)
+	}
+	gz, err := gzip.NewReader(decrypted)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrBackupCorrupted, err)
+	}
+	tr := tar.NewReader(gz)
+
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != backupManifestName || hdr.Size > maxArchiveEntrySize {
+		return nil, nil, fmt.Errorf("%w: the manifest is missing", ErrBackupCorrupted)
+	}
+	var manifest BackupManifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrBackupCorrupted, err)
+	}
+	if manifest.Version > BackupFormatVersion {
+		return nil, nil, ErrBackupVersion
+	}
+	expected := make(map[string]BackupFile, len(manifest.Files))
+	for _, file := range manifest.Files {
+		expected[file.Name] = file
+	}
+
+	keystores := make(map[string][]byte)
+	seen := make(map[string]bool)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, keystores, fmt.Errorf("%w: %v", ErrBackupCorrupted, err)
+		}
+		file, ok := expected[hdr.Name]
+		if !ok || seen[hdr.Name] {
+			return nil, keystores, fmt.Errorf("%w: unexpected file %s", ErrBackupCorrupted, hdr.Name)
+		}
+		seen[hdr.Name] = true
+
+		var dst io.Writer
+		var keystore bytes.Buffer
+		switch {
+		case hdr.Name == backupDatabaseName:
+			out, err := os.OpenFile(dbPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+			if err != nil {
+				return nil, keystores, err
+			}
+			defer out.Close()
+			dst = out
+		case strings.HasPrefix(hdr.Name, backupKeystorePrefix) && isPlainFileName(strings.TrimPrefix(hdr.Name, backupKeystorePrefix)):
+			if file.Size > maxArchiveEntrySize {
+				return nil, keystores, fmt.Errorf("%w: %s is too large", ErrBackupCorrupted, hdr.Name)
+			}
+			dst = &keystore
+		case strings.HasPrefix(hdr.Name, backupExtraPrefix) && isPlainFileName(strings.TrimPrefix(hdr.Name, backupExtraPrefix)):
+			dst = io.Discard
+		default:
+			return nil, keystores, fmt.Errorf("%w: unexpected file %s", ErrBackupCorrupted, hdr.Name)
+		}
+
+		h := sha256.New()
+		n, err := io.Copy(io.MultiWriter(dst, h), io.LimitReader(tr, file.Size+1))
+		if err != nil {
+			return nil, keystores, fmt.Errorf("%w: %v", ErrBackupCorrupted, err)
+		}
+		if n != file.Size || hex.EncodeToString(h.Sum(nil)) != file.SHA256 {
+			return nil, keystores, fmt.Errorf("%w: %s does not match the manifest", ErrBackupCorrupted, hdr.Name)
+		}
+		if strings.HasPrefix(hdr.Name, backupKeystorePrefix) {
+			keystores[strings.TrimPrefix(hdr.Name, backupKeystorePrefix)] = keystore.Bytes()
+		}
+	}
+
+	for name := range expected {
+		if !seen[name] {
+			return nil, keystores, fmt.Errorf("%w: %s is missing", ErrBackupCorrupted, name)
+		}
+	}
+	if !seen[backupDatabaseName] {
+		return nil, keystores, fmt.Errorf("%w: the database is missing", ErrBackupCorrupted)
+	}
+	// Reading to the end checks the authentication of the last encrypted chunk
+	if _, err := io.Copy(io.Discard, decrypted); err != nil {
+		return nil, keystores, fmt.Errorf("%w: %v", ErrBackupCorrupted, err)
+	}
+	return &manifest, keystores, nil
+}
+
+// isPlainFileName rejects names that would leave the keystore directory
+func isPlainFileName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
+
+// restoreKeystores writes the keystores missing from dir. Keystores already
+// there are kept when identical; a different one stops the restore before
+// anything is written.
+func restoreKeystores(dir string, keystores map[string][]byte) ([]string, error) {
+	missing := make(map[string][]byte)
+	for name, data := range keystores {
+		path := filepath.Join(dir, name)
+		existing, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			missing[path] = data
+		case err != nil:
+			return nil, err
+		case !bytes.Equal(existing, data):
+			return nil, fmt.Errorf("%s already exists with a different content", path)
+		}
+	}
+
+	var written []string
+	for path, data := range missing {
+		if err := writeExclusive(path, data); err != nil {
+			for _, p := range written {
+				os.Remove(p)
+			}
+			return nil, err
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
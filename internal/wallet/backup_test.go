@@ -0,0 +1,186 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgpArmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// backupRepository keeps wallets in memory and copies them to a JSON file as
+// its database
+type backupRepository struct {
+	*MockWalletRepository
+	wallets []Wallet
+}
+
+func (r *backupRepository) GetAllWallets() ([]Wallet, error) {
+	return append([]Wallet(nil), r.wallets...), nil
+}
+
+func (r *backupRepository) BackupTo(path string) error {
+	data, err := json.Marshal(r.wallets)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func (r *backupRepository) RestoreFrom(path string, keystorePath func(string) string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var wallets []Wallet
+	if err := json.Unmarshal(data, &wallets); err != nil {
+		return err
+	}
+	for i := range wallets {
+		wallets[i].KeyStorePath = keystorePath(wallets[i].KeyStorePath)
+	}
+	r.wallets = wallets
+	return nil
+}
+
+func TestBackupRoundTrip(t *testing.T) {
+	backupScryptWorkFactor = 10
+	defer func() { backupScryptWorkFactor = 18 }()
+
+	oldDir := t.TempDir()
+	keystorePath := filepath.Join(oldDir, "UTC--2024-03-01T12-00-00.000000000Z--aa")
+	require.NoError(t, os.WriteFile(keystorePath, []byte(`{"version":3}`), 0600))
+	old := &backupRepository{wallets: []Wallet{
+		{ID: 1, Name: "Hot", Address: "0xaa", KeyStorePath: keystorePath, ImportMethod: string(ImportMethodKeystore)},
+		{ID: 2, Name: "Watch", Address: "0xbb", ImportMethod: string(ImportMethodWatchOnly)},
+	}}
+
+	backupPath := filepath.Join(t.TempDir(), "wallets.backup")
+	_, err := (&WalletService{Repo: old, KeystoreDir: oldDir}).ExportBackup(backupPath, "weak")
+	assert.Error(t, err, "the password policy applies")
+	manifest, err := (&WalletService{Repo: old, KeystoreDir: oldDir}).ExportBackup(backupPath, "Backup-Pass1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, manifest.Wallets)
+	assert.Equal(t, 1, manifest.Keystores())
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(backupPath)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+	data, err := os.ReadFile(backupPath)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("BLOCO-WALLET-BACKUP 2 password\n")))
+	assert.NotContains(t, string(data), "Hot", "the archive is encrypted")
+
+	// Restored on another machine, the wallet points at the new keystore directory
+	newDir := t.TempDir()
+	restoredRepo := &backupRepository{}
+	ws := &WalletService{Repo: restoredRepo, KeystoreDir: newDir}
+	_, err = ws.ImportBackup(backupPath, "Wrong-Pass1")
+	assert.ErrorIs(t, err, ErrBackupPassword)
+
+	restored, err := ws.ImportBackup(backupPath, "Backup-Pass1")
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Files, restored.Files)
+	require.Len(t, restoredRepo.wallets, 2)
+	assert.Equal(t, filepath.Join(newDir, filepath.Base(keystorePath)), restoredRepo.wallets[0].KeyStorePath)
+	assert.Empty(t, restoredRepo.wallets[1].KeyStorePath)
+	keystore, err := os.ReadFile(restoredRepo.wallets[0].KeyStorePath)
+	require.NoError(t, err)
+	assert.Equal(t, `{"version":3}`, string(keystore))
+
+	// Restoring again keeps the identical keystore, a different one stops it
+	_, err = ws.ImportBackup(backupPath, "Backup-Pass1")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(restoredRepo.wallets[0].KeyStorePath, []byte(`{"version":3,"changed":true}`), 0600))
+	_, err = ws.ImportBackup(backupPath, "Backup-Pass1")
+	assert.ErrorContains(t, err, "different content")
+}
+
+func TestImportBackup_RejectsDamagedAndNewerBackups(t *testing.T) {
+	backupScryptWorkFactor = 10
+	defer func() { backupScryptWorkFactor = 18 }()
+
+	dir := t.TempDir()
+	repo := &backupRepository{wallets: []Wallet{{ID: 1, Name: "Watch", Address: "0xbb", ImportMethod: string(ImportMethodWatchOnly)}}}
+	ws := &WalletService{Repo: repo, KeystoreDir: dir}
+	backupPath := filepath.Join(t.TempDir(), "wallets.backup")
+	_, err := ws.ExportBackup(backupPath, "Backup-Pass1")
+	require.NoError(t, err)
+	data, err := os.ReadFile(backupPath)
+	require.NoError(t, err)
+
+	write := func(content []byte) string {
+		path := filepath.Join(t.TempDir(), "changed.backup")
+		require.NoError(t, os.WriteFile(path, content, 0600))
+		return path
+	}
+
+	_, err = ws.ImportBackup(write([]byte(`{"version":3}`)), "Backup-Pass1")
+	assert.ErrorIs(t, err, ErrNotBackup)
+
+	newer := bytes.Replace(data, []byte("BLOCO-WALLET-BACKUP 2 password\n"), []byte("BLOCO-WALLET-BACKUP 9 password\n"), 1)
+	_, err = ws.ImportBackup(write(newer), "Backup-Pass1")
+	assert.ErrorIs(t, err, ErrBackupVersion)
+
+	damaged := append([]byte(nil), data...)
+	damaged[len(damaged)-20] ^= 0xff
+	_, err = ws.ImportBackup(write(damaged), "Backup-Pass1")
+	assert.ErrorIs(t, err, ErrBackupCorrupted)
+
+	_, err = (&WalletService{Repo: &MockWalletRepository{}, KeystoreDir: dir}).ImportBackup(backupPath, "Backup-Pass1")
+	assert.ErrorIs(t, err, ErrBackupUnsupported)
+}
+
+func TestBackupToRecipient_GPG(t *testing.T) {
+	entity, err := openpgp.NewEntity("Backups", "", "backups@example.com", nil)
+	require.NoError(t, err)
+	var public, private bytes.Buffer
+	w, err := pgpArmor.Encode(&public, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+	w, err = pgpArmor.Encode(&private, openpgp.PrivateKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.SerializePrivate(w, nil))
+	require.NoError(t, w.Close())
+	recipient, err := ParseExportRecipient(public.String())
+	require.NoError(t, err)
+
+	oldDir := t.TempDir()
+	keystorePath := filepath.Join(oldDir, "aa.json")
+	require.NoError(t, os.WriteFile(keystorePath, []byte(`{"version":3}`), 0600))
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configPath, []byte("language = \"en\"\n"), 0600))
+	old := &backupRepository{wallets: []Wallet{{ID: 1, Name: "Hot", Address: "0xaa", KeyStorePath: keystorePath, ImportMethod: string(ImportMethodKeystore)}}}
+
+	backupPath := filepath.Join(t.TempDir(), "wallets.backup")
+	manifest, err := (&WalletService{Repo: old, KeystoreDir: oldDir}).ExportBackupTo(backupPath, recipient, []string{configPath})
+	require.NoError(t, err)
+	assert.Equal(t, 1, manifest.Keystores())
+	data, err := os.ReadFile(backupPath)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("BLOCO-WALLET-BACKUP 2 gpg\n")))
+
+	keyFile := filepath.Join(t.TempDir(), "secret.asc")
+	require.NoError(t, os.WriteFile(keyFile, private.Bytes(), 0600))
+	newDir := t.TempDir()
+	restoredRepo := &backupRepository{}
+	ws := &WalletService{Repo: restoredRepo, KeystoreDir: newDir}
+	_, err = ws.ImportBackup(backupPath, "Backup-Pass1")
+	assert.ErrorIs(t, err, ErrBackupKeyRequired)
+
+	// The configuration is checked against the manifest but not written back
+	restored, err := ws.ImportBackupWithKey(backupPath, BackupKey{IdentityFile: keyFile})
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Files, restored.Files)
+	require.Len(t, restoredRepo.wallets, 1)
+	assert.Equal(t, filepath.Join(newDir, "aa.json"), restoredRepo.wallets[0].KeyStorePath)
+	assert.NoFileExists(t, filepath.Join(newDir, "config.toml"))
+}
@@ -0,0 +1,206 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PasswordPolicy decides how the wallets of a batch are encrypted
+type PasswordPolicy string
+
+const (
+	// PasswordShared encrypts every wallet with the password given by the user
+	PasswordShared PasswordPolicy = "shared"
+	// PasswordPerWallet generates a random password for each wallet, which is
+	// written to the CSV report since it is stored nowhere else
+	PasswordPerWallet PasswordPolicy = "per_wallet"
+)
+
+// MaxBatchCreateCount bounds a single batch; every wallet costs a scrypt run
+const MaxBatchCreateCount = 1000
+
+// generatedPasswordLength is the length of per-wallet passwords
+const generatedPasswordLength = 24
+
+// generatedPasswordAlphabet leaves out look-alike characters and anything that
+// needs quoting in CSV or a shell
+const generatedPasswordAlphabet = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789-_.+"
+
+// namePlaceholder matches the number placeholder of a name pattern: {}, {:d},
+// {:3d} or {:03d}, as in Python format strings
+var namePlaceholder = regexp.MustCompile(`\{(?::(0?)(\d*)d)?\}`)
+
+// BatchCreateOptions describes a batch of new wallets
+type BatchCreateOptions struct {
+	Count       int
+	NamePattern string // e.g. "airdrop-{:03d}"
+	First       int    // number given to the first wallet
+	Policy      PasswordPolicy
+	Password    string // used with PasswordShared
+}
+
+// BatchCreatedWallet is one line of the batch report
+type BatchCreatedWallet struct {
+	Name     string
+	Address  string
+	Password string // set with PasswordPerWallet only
+}
+
+// FormatWalletName replaces the placeholder of pattern with n
+func FormatWalletName(pattern string, n int) (string, error) {
+	matches := namePlaceholder.FindAllStringSubmatchIndex(pattern, -1)
+	if len(matches) != 1 {
+		return "", fmt.Errorf("name pattern %q must contain one number placeholder such as {} or {:03d}", pattern)
+	}
+	loc := matches[0]
+	number := strconv.Itoa(n)
+	if loc[4] >= 0 && loc[5] > loc[4] {
+		width, _ := strconv.Atoi(pattern[loc[4]:loc[5]])
+		pad := " "
+		if loc[3] > loc[2] {
+			pad = "0"
+		}
+		if missing := width - len(number); missing > 0 {
+			number = strings.Repeat(pad, missing) + number
+		}
+	}
+	return pattern[:loc[0]] + number + pattern[loc[1]:], nil
+}
+
+// Validate checks the options before any wallet is created
+func (o BatchCreateOptions) Validate() error {
+	if o.Count < 1 || o.Count > MaxBatchCreateCount {
+		return fmt.Errorf("the number of wallets must be between 1 and %d", MaxBatchCreateCount)
+	}
+	if o.First < 0 {
+		return errors.New("the first number cannot be negative")
+	}
+	name, err := FormatWalletName(o.NamePattern, o.First)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(name) == "" {
+		return errors.New("the name pattern produces empty names")
+	}
+	switch o.Policy {
+	case PasswordShared:
+		if validationErr, ok := ValidatePassword(o.Password); !ok {
+			return errors.New(validationErr.GetErrorMessage())
+		}
+	case PasswordPerWallet:
+	default:
+		return fmt.Errorf("unknown password policy %q", o.Policy)
+	}
+	return nil
+}
+
+// CreateBatchWallet creates the wallet at position index (from zero) of the
+// batch. Each wallet is created atomically, so a failure leaves the wallets
+// created before it in place.
+func (ws *WalletService) CreateBatchWallet(opts BatchCreateOptions, index int) (BatchCreatedWallet, error) {
+	name, err := FormatWalletName(opts.NamePattern, opts.First+index)
+	if err != nil {
+		return BatchCreatedWallet{}, err
+	}
+	created := BatchCreatedWallet{Name: name}
+
+	password := opts.Password
+	if opts.Policy == PasswordPerWallet {
+		if password, err = GenerateWalletPassword(); err != nil {
+			return BatchCreatedWallet{}, err
+		}
+		created.Password = password
+	}
+
+	details, err := ws.CreateWallet(name, password)
+	if err != nil {
+		return BatchCreatedWallet{}, fmt.Errorf("creating %s: %w", name, err)
+	}
+	created.Address = details.Wallet.Address
+	return created, nil
+}
+
+// CreateWallets creates the whole batch, calling progress after each wallet.
+// On failure it returns the wallets created so far along with the error.
+func (ws *WalletService) CreateWallets(opts BatchCreateOptions, progress func(done, total int)) ([]BatchCreatedWallet, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	created := make([]BatchCreatedWallet, 0, opts.Count)
+	for i := 0; i < opts.Count; i++ {
+		w, err := ws.CreateBatchWallet(opts, i)
+		if err != nil {
+			return created, err
+		}
+		created = append(created, w)
+		if progress != nil {
+			progress(i+1, opts.Count)
+		}
+	}
+	return created, nil
+}
+
+// GenerateWalletPassword returns a random password that passes ValidatePassword
+func GenerateWalletPassword() (string, error) {
+	max := big.NewInt(int64(len(generatedPasswordAlphabet)))
+	buf := make([]byte, generatedPasswordLength)
+	for {
+		for i := range buf {
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return "", err
+			}
+			buf[i] = generatedPasswordAlphabet[n.Int64()]
+		}
+		if _, ok := ValidatePassword(string(buf)); ok {
+			return string(buf), nil
+		}
+	}
+}
+
+// WriteBatchCSV writes the name/address pairs of a batch, plus the passwords
+// when they were generated per wallet
+func WriteBatchCSV(out io.Writer, created []BatchCreatedWallet, policy PasswordPolicy) error {
+	withPasswords := policy == PasswordPerWallet
+	w := csv.NewWriter(out)
+	header := []string{"name", "address"}
+	if withPasswords {
+		header = append(header, "password")
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, c := range created {
+		record := []string{c.Name, c.Address}
+		if withPasswords {
+			record = append(record, c.Password)
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// WriteBatchCSVFile writes the batch report to path, readable by the current
+// user only since it may hold passwords
+func WriteBatchCSVFile(path string, created []BatchCreatedWallet, policy PasswordPolicy) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if err := WriteBatchCSV(f, created, policy); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,105 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatWalletName(t *testing.T) {
+	tests := []struct {
+		pattern string
+		n       int
+		want    string
+	}{
+		{"airdrop-{:03d}", 7, "airdrop-007"},
+		{"airdrop-{:03d}", 1234, "airdrop-1234"},
+		{"wallet {}", 12, "wallet 12"},
+		{"{:d}-test", 3, "3-test"},
+		{"w{:4d}", 5, "w   5"},
+	}
+	for _, tt := range tests {
+		got, err := FormatWalletName(tt.pattern, tt.n)
+		require.NoError(t, err, tt.pattern)
+		assert.Equal(t, tt.want, got)
+	}
+
+	for _, pattern := range []string{"no placeholder", "{}-{}", "{:x}"} {
+		_, err := FormatWalletName(pattern, 1)
+		assert.Error(t, err, pattern)
+	}
+}
+
+func TestBatchCreateOptions_Validate(t *testing.T) {
+	valid := BatchCreateOptions{Count: 3, NamePattern: "airdrop-{:03d}", First: 1, Policy: PasswordShared, Password: "Str0ngPassword"}
+	assert.NoError(t, valid.Validate())
+
+	perWallet := valid
+	perWallet.Policy, perWallet.Password = PasswordPerWallet, ""
+	assert.NoError(t, perWallet.Validate())
+
+	for name, edit := range map[string]func(*BatchCreateOptions){
+		"zero count":     func(o *BatchCreateOptions) { o.Count = 0 },
+		"too many":       func(o *BatchCreateOptions) { o.Count = MaxBatchCreateCount + 1 },
+		"bad pattern":    func(o *BatchCreateOptions) { o.NamePattern = "airdrop" },
+		"weak password":  func(o *BatchCreateOptions) { o.Password = "weak" },
+		"unknown policy": func(o *BatchCreateOptions) { o.Policy = "none" },
+	} {
+		opts := valid
+		edit(&opts)
+		assert.Error(t, opts.Validate(), name)
+	}
+}
+
+func TestGenerateWalletPassword(t *testing.T) {
+	a, err := GenerateWalletPassword()
+	require.NoError(t, err)
+	b, err := GenerateWalletPassword()
+	require.NoError(t, err)
+
+	assert.Len(t, a, generatedPasswordLength)
+	assert.NotEqual(t, a, b)
+	_, ok := ValidatePassword(a)
+	assert.True(t, ok)
+}
+
+func TestCreateWallets(t *testing.T) {
+	InitCryptoService(CreateMockConfig())
+	n, p := GetTestKeystoreParams()
+	mockRepo := new(MockWalletRepository)
+	ws := &WalletService{Repo: mockRepo, KeyStore: keystore.NewKeyStore(t.TempDir(), n, p)}
+
+	mockRepo.On("AddWallet", mock.AnythingOfType("*wallet.Wallet")).Return(nil).Twice()
+	mockRepo.On("AddWallet", mock.AnythingOfType("*wallet.Wallet")).Return(errors.New("disk full")).Once()
+
+	opts := BatchCreateOptions{Count: 3, NamePattern: "airdrop-{:03d}", First: 1, Policy: PasswordPerWallet}
+	var progress []int
+	created, err := ws.CreateWallets(opts, func(done, total int) { progress = append(progress, done) })
+
+	// The third wallet fails; the first two are reported
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "airdrop-003")
+	require.Len(t, created, 2)
+	assert.Equal(t, []int{1, 2}, progress)
+	assert.Equal(t, "airdrop-001", created[0].Name)
+	assert.Equal(t, "airdrop-002", created[1].Name)
+	assert.NotEqual(t, created[0].Address, created[1].Address)
+	assert.NotEqual(t, created[0].Password, created[1].Password)
+
+	var out bytes.Buffer
+	require.NoError(t, WriteBatchCSV(&out, created, PasswordPerWallet))
+	records, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "address", "password"}, records[0])
+	assert.Equal(t, []string{"airdrop-001", created[0].Address, created[0].Password}, records[1])
+
+	out.Reset()
+	require.NoError(t, WriteBatchCSV(&out, created, PasswordShared))
+	assert.Equal(t, "name,address\nairdrop-001,"+created[0].Address+"\nairdrop-002,"+created[1].Address+"\n", out.String())
+}
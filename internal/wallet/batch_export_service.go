@@ -0,0 +1,237 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrWatchOnlyExport is reported for watch-only wallets, which hold no keystore
+var ErrWatchOnlyExport = errors.New("watch-only wallets have no keystore to export")
+
+// ExportJob represents a single wallet of a batch export
+type ExportJob struct {
+	Wallet   Wallet // The wallet whose keystore is exported
+	FileName string // Name of the keystore file in the target directory
+	Password string // Written to a .pwd file next to the keystore when set
+}
+
+// ExportResult represents the result of a single export operation
+type ExportResult struct {
+	Job          ExportJob // The original export job
+	Success      bool      // Whether the export was successful
+	KeystorePath string    // Where the keystore was written (if successful)
+	PasswordPath string    // Where the password was written (if any)
+	Error        error     // Error that occurred, or why the wallet was skipped
+	Skipped      bool      // Whether the wallet was left out rather than failed
+}
+
+// ExportSummary represents a summary of batch export results
+type ExportSummary struct {
+	TotalWallets    int           // Total number of wallets processed
+	ExportedWallets int           // Number of wallets written
+	FailedExports   int           // Number of failed exports
+	SkippedExports  int           // Number of wallets left out
+	Errors          []ImportError // List of errors that occurred
+}
+
+// BatchExportService writes the keystores of many wallets to a directory, the
+// counterpart of BatchImportService: each keystore with its optional .pwd file
+// is laid out so a batch import of the directory finds them again
+type BatchExportService struct {
+	mu sync.Mutex // A single export at a time
+}
+
+// NewBatchExportService creates a new BatchExportService instance
+func NewBatchExportService() *BatchExportService {
+	return &BatchExportService{}
+}
+
+// CreateExportJobs names the keystore of each wallet after the wallet, as the
+// batch import names wallets after their files. Names that are repeated or
+// not usable as file names are numbered or replaced.
+func (bes *BatchExportService) CreateExportJobs(wallets []Wallet) []ExportJob {
+	used := make(map[string]bool)
+	jobs := make([]ExportJob, 0, len(wallets))
+	for _, w := range wallets {
+		base := exportFileBase(w)
+		name := base
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			name = base + "-" + strconv.Itoa(n)
+		}
+		used[strings.ToLower(name)] = true
+		jobs = append(jobs, ExportJob{Wallet: w, FileName: name + ".json"})
+	}
+	return jobs
+}
+
+// exportFileBase keeps the characters of the wallet name that are safe in a
+// file name on every platform, falling back to the address
+func exportFileBase(w Wallet) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.', r == ' ':
+			return r
+		case r > 127 && r != 0xFFFD:
+			return r
+		default:
+			return '_'
+		}
+	}, strings.TrimSpace(w.Name))
+	name = strings.Trim(name, ". ")
+	if name == "" {
+		return w.Address
+	}
+	return name
+}
+
+// ExportBatch writes the keystore of each job to dirPath, creating it when
+// missing, with a .pwd file when the job has a password. Existing files are
+// never overwritten. Progress is reported like a batch import; when ctx is
+// cancelled the wallets not yet written are skipped.
+func (bes *BatchExportService) ExportBatch(ctx context.Context, jobs []ExportJob, dirPath string, progressChan chan<- ImportProgress) []ExportResult {
+	bes.mu.Lock()
+	defer bes.mu.Unlock()
+	if progressChan != nil {
+		defer close(progressChan)
+	}
+
+	results := make([]ExportResult, 0, len(jobs))
+	if len(jobs) == 0 {
+		return results
+	}
+	dirErr := os.MkdirAll(dirPath, 0700)
+	if dirErr != nil {
+		dirErr = fmt.Errorf("failed to create export directory: %w", dirErr)
+	}
+
+	startTime := time.Now()
+	progress := ImportProgress{TotalFiles: len(jobs), StartTime: startTime}
+	bes.sendProgressUpdate(progress, progressChan)
+
+	for i, job := range jobs {
+		if err := ctx.Err(); err != nil {
+			results = append(results, ExportResult{Job: job, Error: err, Skipped: true})
+			continue
+		}
+		progress.CurrentFile = job.FileName
+		progress.ProcessedFiles = i
+		progress.Percentage = float64(i) / float64(len(jobs)) * 100
+		progress.ElapsedTime = time.Since(startTime)
+		bes.sendProgressUpdate(progress, progressChan)
+
+		result := ExportResult{Job: job, Error: dirErr}
+		if dirErr == nil {
+			result = bes.processExportJob(job, dirPath)
+		}
+		results = append(results, result)
+		if !result.Success {
+			progress.Errors = append(progress.Errors, ImportError{File: job.FileName, Error: result.Error, Skipped: result.Skipped})
+		}
+	}
+
+	progress.CurrentFile = ""
+	progress.ProcessedFiles = len(jobs)
+	progress.Percentage = 100.0
+	progress.ElapsedTime = time.Since(startTime)
+	bes.sendProgressUpdate(progress, progressChan)
+	return results
+}
+
+// processExportJob copies the keystore of one wallet, checking first that the
+// password to write opens it
+func (bes *BatchExportService) processExportJob(job ExportJob, dirPath string) ExportResult {
+	result := ExportResult{Job: job}
+	if job.Wallet.WatchOnly() {
+		result.Error, result.Skipped = ErrWatchOnlyExport, true
+		return result
+	}
+	keyJSON, err := os.ReadFile(job.Wallet.KeyStorePath)
+	if err != nil {
+		result.Error = fmt.Errorf("failed to read keystore: %w", err)
+		return result
+	}
+
+	// The .pwd holds the form of the password that opened the keystore
+	password := job.Password
+	if password != "" {
+		if _, password, err = decryptKeystoreJSON(keyJSON, password); err != nil {
+			result.Error = errors.New("the password does not open the keystore")
+			return result
+		}
+	}
+
+	keystorePath := filepath.Join(dirPath, job.FileName)
+	if err := writeExclusive(keystorePath, keyJSON); err != nil {
+		result.Error = err
+		return result
+	}
+	if password != "" {
+		passwordPath := strings.TrimSuffix(keystorePath, filepath.Ext(keystorePath)) + ".pwd"
+		if err := writeExclusive(passwordPath, []byte(password)); err != nil {
+			os.Remove(keystorePath)
+			result.Error = err
+			return result
+		}
+		result.PasswordPath = passwordPath
+	}
+	result.Success = true
+	result.KeystorePath = keystorePath
+	return result
+}
+
+// writeExclusive creates a file readable only by its owner, refusing to
+// replace an existing one
+func writeExclusive(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Base(path), err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(path)
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// sendProgressUpdate safely sends a progress update through the channel
+func (bes *BatchExportService) sendProgressUpdate(progress ImportProgress, progressChan chan<- ImportProgress) {
+	if progressChan == nil {
+		return
+	}
+	select {
+	case progressChan <- progress:
+	case <-time.After(500 * time.Millisecond):
+		log.Printf("Export progress update dropped - channel may be blocked (file: %s, progress: %.1f%%)",
+			progress.CurrentFile, progress.Percentage)
+	}
+}
+
+// GetExportSummary creates a summary of export results
+func (bes *BatchExportService) GetExportSummary(results []ExportResult) ExportSummary {
+	summary := ExportSummary{TotalWallets: len(results), Errors: []ImportError{}}
+	for _, result := range results {
+		switch {
+		case result.Success:
+			summary.ExportedWallets++
+		case result.Skipped:
+			summary.SkippedExports++
+		default:
+			summary.FailedExports++
+			summary.Errors = append(summary.Errors, ImportError{File: result.Job.FileName, Error: result.Error})
+		}
+	}
+	return summary
+}
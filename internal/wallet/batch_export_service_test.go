@@ -0,0 +1,109 @@
+package wallet
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportTestWallet stores a light keystore under password for a wallet named name
+func exportTestWallet(t *testing.T, name, password string) Wallet {
+	t.Helper()
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key := &keystore.Key{Id: uuid.New(), Address: crypto.PubkeyToAddress(privateKey.PublicKey), PrivateKey: privateKey}
+	data, err := keystore.EncryptKey(key, password, keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), key.Address.Hex()+".json")
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return Wallet{Name: name, Address: key.Address.Hex(), KeyStorePath: path, ImportMethod: string(ImportMethodPrivateKey)}
+}
+
+func TestBatchExportService_CreateExportJobs(t *testing.T) {
+	bes := NewBatchExportService()
+	jobs := bes.CreateExportJobs([]Wallet{
+		{Name: "Treasury", Address: "0x01"},
+		{Name: "treasury", Address: "0x02"},
+		{Name: "a/b:c", Address: "0x03"},
+		{Name: " .. ", Address: "0x04"},
+	})
+	names := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		names = append(names, job.FileName)
+	}
+	assert.Equal(t, []string{"Treasury.json", "treasury-2.json", "a_b_c.json", "0x04.json"}, names)
+}
+
+func TestBatchExportService_ExportBatch(t *testing.T) {
+	hot := exportTestWallet(t, "Hot", "hot-pass")
+	cold := exportTestWallet(t, "Cold", "cold-pass")
+	watch := Wallet{Name: "Watch", Address: "0x0000000000000000000000000000000000000009", ImportMethod: string(ImportMethodWatchOnly)}
+
+	bes := NewBatchExportService()
+	jobs := bes.CreateExportJobs([]Wallet{hot, cold, watch})
+	jobs[0].Password = "hot-pass"
+	jobs[1].Password = "wrong"
+	dir := filepath.Join(t.TempDir(), "export")
+
+	progress := make(chan ImportProgress, 16)
+	results := bes.ExportBatch(context.Background(), jobs, dir, progress)
+	var updates []ImportProgress
+	for p := range progress {
+		updates = append(updates, p)
+	}
+	require.NotEmpty(t, updates)
+	assert.Equal(t, 100.0, updates[len(updates)-1].Percentage)
+	assert.Len(t, updates[len(updates)-1].Errors, 2)
+
+	// The keystore is copied as stored, with the password that opens it
+	require.True(t, results[0].Success, results[0].Error)
+	assert.Equal(t, filepath.Join(dir, "Hot.json"), results[0].KeystorePath)
+	stored, err := os.ReadFile(hot.KeyStorePath)
+	require.NoError(t, err)
+	exported, err := os.ReadFile(results[0].KeystorePath)
+	require.NoError(t, err)
+	assert.Equal(t, stored, exported)
+	password, err := NewPasswordFileManager().GetPasswordForKeystore(results[0].KeystorePath)
+	require.NoError(t, err)
+	assert.Equal(t, "hot-pass", password)
+
+	// A password that does not open the keystore writes nothing
+	assert.False(t, results[1].Success)
+	assert.NoFileExists(t, filepath.Join(dir, "Cold.json"))
+	assert.True(t, results[2].Skipped)
+	assert.ErrorIs(t, results[2].Error, ErrWatchOnlyExport)
+
+	summary := bes.GetExportSummary(results)
+	assert.Equal(t, ExportSummary{TotalWallets: 3, ExportedWallets: 1, FailedExports: 1, SkippedExports: 1, Errors: summary.Errors}, summary)
+	assert.Len(t, summary.Errors, 1)
+
+	// Exporting again never replaces the files
+	results = bes.ExportBatch(context.Background(), jobs[:1], dir, nil)
+	assert.False(t, results[0].Success)
+	assert.Error(t, results[0].Error)
+}
+
+func TestBatchExportService_Cancelled(t *testing.T) {
+	bes := NewBatchExportService()
+	jobs := bes.CreateExportJobs([]Wallet{exportTestWallet(t, "One", "pass"), exportTestWallet(t, "Two", "pass")})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	dir := t.TempDir()
+	results := bes.ExportBatch(ctx, jobs, dir, nil)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.True(t, result.Skipped)
+		assert.ErrorIs(t, result.Error, context.Canceled)
+	}
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
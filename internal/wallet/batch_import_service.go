@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -8,17 +9,16 @@ import (
 	"strings"
 	"sync"
 	"time"
-
-	"github.com/ethereum/go-ethereum/accounts/keystore"
 )
 
 // ImportJob represents a single keystore import job
 type ImportJob struct {
-	KeystorePath   string // Path to the keystore file
-	PasswordPath   string // Path to the password file (if exists)
-	ManualPassword string // Manual password (if no password file)
-	WalletName     string // Name for the imported wallet
-	RequiresInput  bool   // Whether this job requires manual password input
+	KeystorePath   string    // Path to the keystore file
+	PasswordPath   string    // Path to the password file (if exists)
+	ManualPassword string    // Manual password (if no password file)
+	WalletName     string    // Name for the imported wallet
+	RequiresInput  bool      // Whether this job requires manual password input
+	CreatedAt      time.Time // Creation time read from a geth keystore name (zero if unknown)
 }
 
 // ImportResult represents the result of a single import operation
@@ -28,6 +28,8 @@ type ImportResult struct {
 	Wallet  *WalletDetails // The imported wallet details (if successful)
 	Error   error          // Error that occurred (if any)
 	Skipped bool           // Whether the import was skipped by user
+	// Time spent on the file, waiting for its password included
+	Duration time.Duration
 }
 
 // ImportProgress represents the current progress of a batch import operation
@@ -60,9 +62,10 @@ type PasswordRequest struct {
 
 // PasswordResponse represents the user's response to a password request
 type PasswordResponse struct {
-	Password  string // The provided password
-	Cancelled bool   // Whether the user cancelled
-	Skip      bool   // Whether the user chose to skip this file
+	Password   string // The provided password
+	Cancelled  bool   // Whether the user cancelled
+	Skip       bool   // Whether the user chose to skip this file
+	ApplyToAll bool   // Whether to try this password on the remaining locked files
 }
 
 // PasswordInputError represents errors that occur during password input
@@ -122,6 +125,23 @@ type BatchImportService struct {
 	passwordMgr     *PasswordFileManager
 	errorAggregator *ErrorAggregator
 	mu              sync.RWMutex // Protects concurrent access to service state
+
+	// Concurrency is how many keystores ImportBatch decrypts at the same time;
+	// values below 2 import one file after the other
+	Concurrency int
+
+	// ArchiveDir, when set, is the only directory archives are read from and
+	// extracted into, as under process hardening, which lets the process read
+	// little beyond the app directory; archives elsewhere are refused
+	ArchiveDir string
+
+	// Temporary directories holding keystores extracted from archives
+	extractedMu   sync.Mutex
+	extractedDirs []string
+
+	// Password manifest rows left out of the last import
+	manifestMu       sync.Mutex
+	manifestProblems []PasswordManifestProblem
 }
 
 // NewBatchImportService creates a new BatchImportService instance
@@ -132,15 +152,40 @@ func NewBatchImportService(walletService *WalletService) *BatchImportService {
 	}
 }
 
-// CreateImportJobsFromFiles creates import jobs from a list of keystore file paths
+// CreateImportJobsFromFiles creates import jobs from a list of keystore file paths.
+// Keystores without a .pwd file take their password from the password manifest
+// of their directory, if any
 func (bis *BatchImportService) CreateImportJobsFromFiles(keystorePaths []string) ([]ImportJob, error) {
+	manifests := make(passwordManifests)
+	jobs, err := bis.createImportJobsFromFiles(keystorePaths, "", manifests)
+	bis.recordManifestProblems(manifests)
+	return jobs, err
+}
+
+func (bis *BatchImportService) createImportJobsFromFiles(keystorePaths []string, root string, manifests passwordManifests) ([]ImportJob, error) {
 	if len(keystorePaths) == 0 {
 		return nil, fmt.Errorf("no keystore files provided")
 	}
 
 	var jobs []ImportJob
+	gethIndexes := make(gethAccountIndexes)
 
 	for _, keystorePath := range keystorePaths {
+		// An archive adds the keystores it holds
+		if IsKeystoreArchive(keystorePath) {
+			archiveJobs, err := bis.createImportJobsFromDirectory(keystorePath, manifests)
+			if err != nil {
+				return nil, err
+			}
+			jobs = append(jobs, archiveJobs...)
+			continue
+		}
+
+		// A password manifest picked among the keystores is not a keystore
+		if IsPasswordManifest(keystorePath) {
+			continue
+		}
+
 		// Validate that the file exists and is accessible
 		if _, err := os.Stat(keystorePath); os.IsNotExist(err) {
 			return nil, fmt.Errorf("keystore file not found: %s", keystorePath)
@@ -152,15 +197,38 @@ func (bis *BatchImportService) CreateImportJobsFromFiles(keystorePaths []string)
 		baseName := filepath.Base(keystorePath)
 		walletName := strings.TrimSuffix(baseName, filepath.Ext(baseName))
 
+		// A geth keystore is named after its account and keeps its creation time
+		var createdAt time.Time
+		if gethName, ok := ParseGethKeystoreName(baseName); ok {
+			if name := gethWalletName(keystorePath, gethName, gethIndexes.index(keystorePath)); name != "" {
+				walletName = name
+			}
+			createdAt = gethName.Created
+		}
+
 		// Check for password file
 		passwordPath, err := bis.passwordMgr.FindPasswordFile(keystorePath)
 		requiresInput := err != nil // If we can't find password file, manual input required
 
+		// Without a .pwd file, the password manifest may list the keystore
+		var manifestPassword string
+		if requiresInput {
+			password, found, err := bis.manifestPassword(manifests, keystorePath, root)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				manifestPassword, requiresInput = password, false
+			}
+		}
+
 		job := ImportJob{
-			KeystorePath:  keystorePath,
-			PasswordPath:  passwordPath,
-			WalletName:    walletName,
-			RequiresInput: requiresInput,
+			KeystorePath:   keystorePath,
+			PasswordPath:   passwordPath,
+			ManualPassword: manifestPassword,
+			WalletName:     walletName,
+			RequiresInput:  requiresInput,
+			CreatedAt:      createdAt,
 		}
 
 		jobs = append(jobs, job)
@@ -169,8 +237,17 @@ func (bis *BatchImportService) CreateImportJobsFromFiles(keystorePaths []string)
 	return jobs, nil
 }
 
-// CreateImportJobsFromDirectory creates import jobs by scanning a directory for keystore files
+// CreateImportJobsFromDirectory creates import jobs by scanning a directory for keystore files.
+// A .zip or .tar.gz archive is extracted to a temporary directory first, removed by Close.
+// A password manifest at the top of the directory may list keystores of its subdirectories
 func (bis *BatchImportService) CreateImportJobsFromDirectory(dirPath string) ([]ImportJob, error) {
+	manifests := make(passwordManifests)
+	jobs, err := bis.createImportJobsFromDirectory(dirPath, manifests)
+	bis.recordManifestProblems(manifests)
+	return jobs, err
+}
+
+func (bis *BatchImportService) createImportJobsFromDirectory(dirPath string, manifests passwordManifests) ([]ImportJob, error) {
 	if dirPath == "" {
 		return nil, fmt.Errorf("directory path cannot be empty")
 	}
@@ -183,25 +260,32 @@ func (bis *BatchImportService) CreateImportJobsFromDirectory(dirPath string) ([]
 		return nil, fmt.Errorf("cannot access directory %s: %v", dirPath, err)
 	}
 
+	// An archive is extracted and its files scanned like a directory
+	scanPath := dirPath
 	if !dirInfo.IsDir() {
-		return nil, fmt.Errorf("path is not a directory: %s", dirPath)
+		if !dirInfo.Mode().IsRegular() || !IsKeystoreArchive(dirPath) {
+			return nil, fmt.Errorf("path is not a directory or an archive: %s", dirPath)
+		}
+		if scanPath, err = bis.extractArchive(dirPath); err != nil {
+			return nil, err
+		}
 	}
 
 	// Scan directory for keystore files
-	keystoreFiles, scanErrors, err := bis.ScanDirectoryForKeystores(dirPath)
+	keystoreFiles, scanErrors, err := bis.ScanDirectoryForKeystores(scanPath)
 	if err != nil {
 		return nil, fmt.Errorf("error scanning directory %s: %v", dirPath, err)
 	}
 
 	if len(keystoreFiles) == 0 {
 		if len(scanErrors) > 0 {
-			return nil, fmt.Errorf("no valid keystore files found in directory: %s (found %d invalid files)", dirPath, len(scanErrors))
+			return nil, fmt.Errorf("no valid keystore files found in %s (found %d invalid files)", dirPath, len(scanErrors))
 		}
-		return nil, fmt.Errorf("no valid keystore files found in directory: %s", dirPath)
+		return nil, fmt.Errorf("no valid keystore files found in %s", dirPath)
 	}
 
 	// Create import jobs from found files
-	return bis.CreateImportJobsFromFiles(keystoreFiles)
+	return bis.createImportJobsFromFiles(keystoreFiles, scanPath, manifests)
 }
 
 // ScanDirectoryForKeystores recursively scans a directory for valid keystore files
@@ -225,8 +309,10 @@ func (bis *BatchImportService) ScanDirectoryForKeystores(dirPath string) ([]stri
 			return nil
 		}
 
-		// Check if it's a JSON file
-		if strings.ToLower(filepath.Ext(path)) == ".json" {
+		// Check if it's a JSON file, other than a password manifest, or a
+		// geth keystore, whose names have no extension
+		_, gethKeystore := ParseGethKeystoreName(path)
+		if (gethKeystore || strings.ToLower(filepath.Ext(path)) == ".json") && !IsPasswordManifest(path) {
 			// Validate if it's a proper keystore file
 			if bis.isValidKeystoreFile(path) {
 				keystoreFiles = append(keystoreFiles, path)
@@ -334,7 +420,8 @@ type KeystoreDiscoveryReport struct {
 	PasswordFilesFound int                  // Number of corresponding password files found
 }
 
-// ImportBatch processes a batch of import jobs with progress reporting and password handling
+// ImportBatch processes a batch of import jobs with progress reporting and password handling.
+// Up to Concurrency keystores are decrypted at the same time; results keep the order of jobs
 func (bis *BatchImportService) ImportBatch(
 	jobs []ImportJob,
 	progressChan chan<- ImportProgress,
@@ -349,82 +436,166 @@ func (bis *BatchImportService) ImportBatch(
 		return []ImportResult{}
 	}
 
-	startTime := time.Now()
-	results := make([]ImportResult, 0, len(jobs))
-	var errors []ImportError
+	results := make([]ImportResult, len(jobs))
 
 	// Initialize error aggregator for this batch
 	bis.errorAggregator = NewErrorAggregator(len(jobs))
 
+	// Each outcome is saved as it happens, so an interrupted batch can be resumed
+	journal := bis.startImportJournal(jobs)
+
 	// Send initial progress
-	progress := ImportProgress{
-		CurrentFile:     "",
-		TotalFiles:      len(jobs),
-		ProcessedFiles:  0,
-		Percentage:      0.0,
-		Errors:          errors,
-		PendingPassword: false,
-		PendingFile:     "",
-		StartTime:       startTime,
-		ElapsedTime:     0,
+	tracker := newBatchProgress(bis, len(jobs), progressChan)
+	tracker.send()
+
+	// The per-file steps of a single import only make sense while one file is
+	// processed at a time; in parallel they would interleave with the batch progress
+	workers := bis.workerCount(len(jobs))
+	fileProgressChan := progressChan
+	if workers > 1 {
+		fileProgressChan = nil
 	}
 
-	bis.sendProgressUpdate(progress, progressChan)
-
-	// Process each job
-	for i, job := range jobs {
-		// Update progress for current file
-		progress.CurrentFile = filepath.Base(job.KeystorePath)
-		progress.ProcessedFiles = i
-		progress.Percentage = float64(i) / float64(len(jobs)) * 100
-		progress.ElapsedTime = time.Since(startTime)
-
-		bis.sendProgressUpdate(progress, progressChan)
-
-		// Process the import job
-		result := bis.processImportJob(job, passwordRequestChan, passwordResponseChan, &progress, progressChan)
-		results = append(results, result)
-
-		// Track errors and skipped files using error aggregator
-		if !result.Success {
-			userAction := UserActionNone
-			if result.Skipped {
-				userAction = UserActionSkip
+	// Password prompts share one pair of channels, so only one worker asks at a time
+	prompts := &passwordPrompts{}
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				job := jobs[i]
+				tracker.start(job)
+				started := time.Now()
+				result := bis.processImportJob(job, passwordRequestChan, passwordResponseChan, tracker, prompts, fileProgressChan)
+				result.Duration = time.Since(started)
+				results[i] = result
+				journal.record(i, result)
+				tracker.finish(job, result)
 			}
-			bis.errorAggregator.AddError(result.Error, job.KeystorePath, userAction)
-
-			errors = append(errors, ImportError{
-				File:    job.KeystorePath,
-				Error:   result.Error,
-				Skipped: result.Skipped,
-			})
-		} else {
-			bis.errorAggregator.AddSuccess()
-		}
-
-		progress.Errors = errors
+		}()
+	}
+	for i := range jobs {
+		next <- i
 	}
+	close(next)
+	wg.Wait()
+	journal.finish(results)
 
 	// Send final progress
-	progress.CurrentFile = ""
-	progress.ProcessedFiles = len(jobs)
-	progress.Percentage = 100.0
-	progress.PendingPassword = false
-	progress.PendingFile = ""
-	progress.ElapsedTime = time.Since(startTime)
-
-	bis.sendProgressUpdate(progress, progressChan)
+	tracker.complete()
 
 	close(progressChan)
 	return results
 }
 
+// workerCount returns how many jobs of a batch of size n run at the same time
+func (bis *BatchImportService) workerCount(n int) int {
+	return max(min(bis.Concurrency, n), 1)
+}
+
+// batchProgress keeps the progress of a batch consistent while several workers
+// report on it: processed files only grow and updates are sent one at a time
+type batchProgress struct {
+	mu           sync.Mutex
+	bis          *BatchImportService
+	progress     ImportProgress
+	errors       []ImportError
+	progressChan chan<- ImportProgress
+}
+
+func newBatchProgress(bis *BatchImportService, total int, progressChan chan<- ImportProgress) *batchProgress {
+	return &batchProgress{
+		bis: bis,
+		progress: ImportProgress{
+			TotalFiles: total,
+			StartTime:  time.Now(),
+		},
+		progressChan: progressChan,
+	}
+}
+
+// send publishes the current progress; the caller holds mu or is the only writer
+func (p *batchProgress) send() {
+	p.progress.ElapsedTime = time.Since(p.progress.StartTime)
+	p.bis.sendProgressUpdate(p.progress, p.progressChan)
+}
+
+// start reports the file a worker begins to process
+func (p *batchProgress) start(job ImportJob) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.progress.CurrentFile = filepath.Base(job.KeystorePath)
+	p.send()
+}
+
+// finish counts a processed job and tracks its error, if any
+func (p *batchProgress) finish(job ImportJob, result ImportResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Track errors and skipped files using error aggregator
+	if !result.Success {
+		userAction := UserActionNone
+		if result.Skipped {
+			userAction = UserActionSkip
+		}
+		p.bis.errorAggregator.AddError(result.Error, job.KeystorePath, userAction)
+
+		p.errors = append(p.errors, ImportError{
+			File:    job.KeystorePath,
+			Error:   result.Error,
+			Skipped: result.Skipped,
+		})
+	} else {
+		p.bis.errorAggregator.AddSuccess()
+	}
+
+	p.progress.Errors = p.errors
+	p.progress.ProcessedFiles++
+	p.progress.Percentage = float64(p.progress.ProcessedFiles) / float64(p.progress.TotalFiles) * 100
+}
+
+// awaitPassword marks the batch as waiting for the password of a file
+func (p *batchProgress) awaitPassword(keystoreFile string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.progress.PendingPassword = true
+	p.progress.PendingFile = filepath.Base(keystoreFile)
+	p.send()
+}
+
+// passwordAnswered clears the pending password state, sending an update unless quiet
+func (p *batchProgress) passwordAnswered(quiet bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.progress.PendingPassword = false
+	p.progress.PendingFile = ""
+	if !quiet {
+		p.send()
+	}
+}
+
+// complete sends the final progress of the batch
+func (p *batchProgress) complete() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.progress.CurrentFile = ""
+	p.progress.ProcessedFiles = p.progress.TotalFiles
+	p.progress.Percentage = 100.0
+	p.progress.PendingPassword = false
+	p.progress.PendingFile = ""
+	p.send()
+}
+
 // processImportJob processes a single import job with enhanced error handling
 func (bis *BatchImportService) processImportJob(
 	job ImportJob,
 	passwordRequestChan chan<- PasswordRequest,
 	passwordResponseChan <-chan PasswordResponse,
-	progress *ImportProgress,
+	tracker *batchProgress,
+	prompts *passwordPrompts,
 	progressChan chan<- ImportProgress,
 ) ImportResult {
 	var password string
@@ -442,7 +613,7 @@ func (bis *BatchImportService) processImportJob(
 
 	// If we need manual password input
 	if job.RequiresInput && password == "" {
-		password, err = bis.requestManualPassword(job.KeystorePath, passwordRequestChan, passwordResponseChan, progress, progressChan)
+		password, err = bis.promptPassword(job.KeystorePath, passwordRequestChan, passwordResponseChan, tracker, prompts)
 		if err != nil {
 			// Check if this is a password input error
 			if passwordErr, ok := err.(*PasswordInputError); ok {
@@ -482,8 +653,10 @@ func (bis *BatchImportService) processImportJob(
 		}
 	}
 
-	// Attempt the import with progress tracking
-	walletDetails, err := bis.walletService.ImportWalletFromKeystoreV3WithProgress(job.WalletName, job.KeystorePath, password, progressChan)
+	// Attempt the import with progress tracking, once the key derivation fits in memory
+	release := bis.reserveKDFMemory(job.KeystorePath)
+	walletDetails, err := bis.walletService.importKeystoreFile(job.WalletName, job.KeystorePath, password, job.CreatedAt, progressChan)
+	release()
 	if err != nil {
 		return ImportResult{
 			Job:     job,
@@ -503,23 +676,68 @@ func (bis *BatchImportService) processImportJob(
 	}
 }
 
-// requestManualPassword requests manual password input from the user with retry mechanism
-func (bis *BatchImportService) requestManualPassword(
+// passwordPrompts serializes the password prompts of a batch and keeps the
+// password the user chose to apply to all remaining locked files
+type passwordPrompts struct {
+	sync.Mutex
+	sharedMu sync.Mutex
+	shared   string
+}
+
+func (p *passwordPrompts) sharedPassword() string {
+	p.sharedMu.Lock()
+	defer p.sharedMu.Unlock()
+	return p.shared
+}
+
+func (p *passwordPrompts) share(password string) {
+	p.sharedMu.Lock()
+	p.shared = password
+	p.sharedMu.Unlock()
+}
+
+// promptPassword tries the password shared by an earlier answer before asking
+// the user for the password of keystoreFile
+func (bis *BatchImportService) promptPassword(
 	keystoreFile string,
 	passwordRequestChan chan<- PasswordRequest,
 	passwordResponseChan <-chan PasswordResponse,
-	progress *ImportProgress,
-	progressChan chan<- ImportProgress,
+	tracker *batchProgress,
+	prompts *passwordPrompts,
 ) (string, error) {
+	tried := prompts.sharedPassword()
+	if tried != "" && bis.testKeystorePassword(keystoreFile, tried) {
+		return tried, nil
+	}
+
+	prompts.Lock()
+	defer prompts.Unlock()
+
+	// Another worker may have shared a password while this one waited
+	if shared := prompts.sharedPassword(); shared != "" && shared != tried && bis.testKeystorePassword(keystoreFile, shared) {
+		return shared, nil
+	}
+
+	password, applyToAll, err := bis.requestManualPassword(keystoreFile, passwordRequestChan, passwordResponseChan, tracker)
+	if err == nil && applyToAll {
+		prompts.share(password)
+	}
+	return password, err
+}
+
+// requestManualPassword requests manual password input from the user with retry
+// mechanism; applyToAll reports whether the user chose to reuse the password
+func (bis *BatchImportService) requestManualPassword(
+	keystoreFile string,
+	passwordRequestChan chan<- PasswordRequest,
+	passwordResponseChan <-chan PasswordResponse,
+	tracker *batchProgress,
+) (password string, applyToAll bool, err error) {
 	const maxRetries = 3
 
 	for attempt := 1; attempt <= maxRetries; attempt++ {
 		// Update progress to show we're waiting for password
-		progress.PendingPassword = true
-		progress.PendingFile = filepath.Base(keystoreFile)
-
-		// Send progress update
-		bis.sendProgressUpdate(*progress, progressChan)
+		tracker.awaitPassword(keystoreFile)
 
 		// Create password request with appropriate error messaging
 		var previousError error
@@ -532,9 +750,8 @@ func (bis *BatchImportService) requestManualPassword(
 		// Send password request
 		if err := bis.sendPasswordRequest(request, passwordRequestChan); err != nil {
 			// Clear pending state and return error if we can't send request
-			progress.PendingPassword = false
-			progress.PendingFile = ""
-			return "", &PasswordInputError{
+			tracker.passwordAnswered(true)
+			return "", false, &PasswordInputError{
 				Type:    PasswordInputTimeout,
 				Message: "failed to send password request - communication error",
 				File:    keystoreFile,
@@ -544,16 +761,12 @@ func (bis *BatchImportService) requestManualPassword(
 		// Wait for response with timeout
 		select {
 		case response := <-passwordResponseChan:
-			// Clear pending password state
-			progress.PendingPassword = false
-			progress.PendingFile = ""
-
-			// Send progress update to clear pending state
-			bis.sendProgressUpdate(*progress, progressChan)
+			// Clear pending password state and send progress update
+			tracker.passwordAnswered(false)
 
 			// Handle user cancellation
 			if response.Cancelled {
-				return "", &PasswordInputError{
+				return "", false, &PasswordInputError{
 					Type:    PasswordInputCancelled,
 					Message: "password input cancelled by user",
 					File:    keystoreFile,
@@ -562,7 +775,7 @@ func (bis *BatchImportService) requestManualPassword(
 
 			// Handle user skip
 			if response.Skip {
-				return "", &PasswordInputError{
+				return "", false, &PasswordInputError{
 					Type:    PasswordInputSkipped,
 					Message: "import skipped by user",
 					File:    keystoreFile,
@@ -574,7 +787,7 @@ func (bis *BatchImportService) requestManualPassword(
 				if attempt < maxRetries {
 					continue // Try again with empty password error
 				}
-				return "", &PasswordInputError{
+				return "", false, &PasswordInputError{
 					Type:    PasswordInputInvalid,
 					Message: "empty password provided",
 					File:    keystoreFile,
@@ -583,7 +796,7 @@ func (bis *BatchImportService) requestManualPassword(
 
 			// Test the provided password
 			if bis.testKeystorePassword(keystoreFile, response.Password) {
-				return response.Password, nil
+				return response.Password, response.ApplyToAll, nil
 			}
 
 			// Password was incorrect
@@ -593,7 +806,7 @@ func (bis *BatchImportService) requestManualPassword(
 			}
 
 			// Maximum attempts reached with incorrect password
-			return "", &PasswordInputError{
+			return "", false, &PasswordInputError{
 				Type:    PasswordInputMaxAttemptsExceeded,
 				Message: fmt.Sprintf("incorrect password after %d attempts", maxRetries),
 				File:    keystoreFile,
@@ -601,12 +814,9 @@ func (bis *BatchImportService) requestManualPassword(
 
 		case <-time.After(5 * time.Minute): // Timeout after 5 minutes
 			// Clear pending state on timeout
-			progress.PendingPassword = false
-			progress.PendingFile = ""
-
-			bis.sendProgressUpdate(*progress, progressChan)
+			tracker.passwordAnswered(false)
 
-			return "", &PasswordInputError{
+			return "", false, &PasswordInputError{
 				Type:    PasswordInputTimeout,
 				Message: "password input timeout after 5 minutes",
 				File:    keystoreFile,
@@ -614,7 +824,7 @@ func (bis *BatchImportService) requestManualPassword(
 		}
 	}
 
-	return "", &PasswordInputError{
+	return "", false, &PasswordInputError{
 		Type:    PasswordInputMaxAttemptsExceeded,
 		Message: "maximum password attempts exceeded",
 		File:    keystoreFile,
@@ -623,16 +833,25 @@ func (bis *BatchImportService) requestManualPassword(
 
 // testKeystorePassword tests if a password can decrypt a keystore file
 func (bis *BatchImportService) testKeystorePassword(keystorePath, password string) bool {
-	// Read the keystore file
-	keyJSON, err := os.ReadFile(keystorePath)
+	// Decrypt with the go-ethereum keystore package directly (NFKD and as-typed forms).
+	// This avoids creating a wallet in the database during testing
+	release := bis.reserveKDFMemory(keystorePath)
+	defer release()
+	return keystoreFileAcceptsPassword(keystorePath, password)
+}
+
+// reserveKDFMemory waits until the memory guard of the wallet service has
+// room to decrypt the keystore at path. Without a guard nothing is reserved.
+func (bis *BatchImportService) reserveKDFMemory(keystorePath string) func() {
+	if bis.walletService == nil || bis.walletService.MemoryGuard == nil {
+		return func() {}
+	}
+	guard := bis.walletService.MemoryGuard
+	release, err := guard.Acquire(context.Background(), KeystoreKDFMemory(keystorePath))
 	if err != nil {
-		return false
+		return func() {}
 	}
-
-	// Try to decrypt using the go-ethereum keystore package directly
-	// This avoids creating a wallet in the database during testing
-	_, err = keystore.DecryptKey(keyJSON, password)
-	return err == nil
+	return release
 }
 
 // createPasswordRequest creates a password request with appropriate error messaging
@@ -697,6 +916,7 @@ func (bis *BatchImportService) GetImportSummary(results []ImportResult) ImportSu
 		FailedImports:     0,
 		SkippedImports:    0,
 		Errors:            []ImportError{},
+		ManifestProblems:  bis.ManifestProblems(),
 	}
 
 	for _, result := range results {
@@ -724,6 +944,20 @@ type ImportSummary struct {
 	FailedImports     int           // Number of failed imports
 	SkippedImports    int           // Number of skipped imports
 	Errors            []ImportError // List of errors that occurred
+
+	// Password manifest rows left out of the import
+	ManifestProblems []PasswordManifestProblem
+}
+
+// ImportKeystoreJSON imports a pasted keystore, reporting it like a file of a
+// batch so the import summary covers it too
+func (bis *BatchImportService) ImportKeystoreJSON(name string, keyJSON []byte, password string) ImportResult {
+	job := ImportJob{KeystorePath: PastedKeystoreSource, WalletName: name}
+	walletDetails, err := bis.walletService.ImportWalletFromKeystoreJSON(name, keyJSON, password)
+	if err != nil {
+		return ImportResult{Job: job, Error: fmt.Errorf("keystore import failed: %w", err)}
+	}
+	return ImportResult{Job: job, Success: true, Wallet: walletDetails}
 }
 
 // ValidateImportJobs validates a list of import jobs before processing
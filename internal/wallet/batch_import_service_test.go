@@ -7,7 +7,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -948,3 +952,164 @@ func TestSendProgressUpdate(t *testing.T) {
 		service.sendProgressUpdate(progress, progressChan)
 	})
 }
+
+func TestImportBatchConcurrent(t *testing.T) {
+	repo := new(MockWalletRepository)
+	repo.On("AddWallet", mock.Anything).Return(nil)
+	n, p := GetTestKeystoreParams()
+	// An account keeps the imported keystores in the test's directory
+	ks := keystore.NewKeyStore(t.TempDir(), n, p)
+	_, err := ks.NewAccount("secret")
+	require.NoError(t, err)
+	walletService := &WalletService{Repo: repo, KeyStore: ks}
+	service := NewBatchImportService(walletService)
+	service.Concurrency = 3
+
+	var jobs []ImportJob
+	var addresses []common.Address
+	for i := range 6 {
+		path, address := createTestKeystoreFile(t, "secret")
+		job := ImportJob{KeystorePath: path, WalletName: fmt.Sprintf("wallet %d", i), ManualPassword: "secret"}
+		if i%3 == 0 {
+			job.ManualPassword = ""
+			job.RequiresInput = true
+		}
+		jobs = append(jobs, job)
+		addresses = append(addresses, address)
+	}
+
+	progressChan := make(chan ImportProgress, 100)
+	passwordRequestChan := make(chan PasswordRequest, 2)
+	passwordResponseChan := make(chan PasswordResponse, 1)
+
+	var updates []ImportProgress
+	collected := make(chan struct{})
+	go func() {
+		for progress := range progressChan {
+			updates = append(updates, progress)
+		}
+		close(collected)
+	}()
+
+	done := make(chan []ImportResult)
+	go func() {
+		done <- service.ImportBatch(jobs, progressChan, passwordRequestChan, passwordResponseChan)
+	}()
+
+	// Both prompts are asked one after the other, never together
+	for range 2 {
+		<-passwordRequestChan
+		time.Sleep(50 * time.Millisecond)
+		assert.Empty(t, passwordRequestChan, "a second prompt was sent before the first was answered")
+		passwordResponseChan <- PasswordResponse{Password: "secret"}
+	}
+
+	results := <-done
+	<-collected
+
+	// Results keep the order of the jobs
+	require.Len(t, results, len(jobs))
+	for i, result := range results {
+		require.True(t, result.Success, "job %d: %v", i, result.Error)
+		assert.Equal(t, jobs[i].WalletName, result.Wallet.Wallet.Name)
+		assert.Equal(t, addresses[i].Hex(), result.Wallet.Wallet.Address)
+	}
+
+	// Progress only moves forward and counts every file once
+	processed := 0
+	for _, progress := range updates {
+		assert.Equal(t, len(jobs), progress.TotalFiles, "per-file steps are not mixed into the batch progress")
+		assert.GreaterOrEqual(t, progress.ProcessedFiles, processed)
+		processed = progress.ProcessedFiles
+	}
+	assert.Equal(t, len(jobs), processed)
+	assert.Equal(t, 100.0, updates[len(updates)-1].Percentage)
+}
+
+func TestImportBatchApplyPasswordToAll(t *testing.T) {
+	repo := new(MockWalletRepository)
+	repo.On("AddWallet", mock.Anything).Return(nil)
+	n, p := GetTestKeystoreParams()
+	ks := keystore.NewKeyStore(t.TempDir(), n, p)
+	_, err := ks.NewAccount("secret")
+	require.NoError(t, err)
+	service := NewBatchImportService(&WalletService{Repo: repo, KeyStore: ks})
+
+	var jobs []ImportJob
+	for i, password := range []string{"shared", "shared", "other", "shared"} {
+		path, _ := createTestKeystoreFile(t, password)
+		jobs = append(jobs, ImportJob{KeystorePath: path, WalletName: fmt.Sprintf("wallet %d", i), RequiresInput: true})
+	}
+
+	progressChan := make(chan ImportProgress, 100)
+	passwordRequestChan := make(chan PasswordRequest, 1)
+	passwordResponseChan := make(chan PasswordResponse, 1)
+
+	done := make(chan []ImportResult)
+	go func() {
+		done <- service.ImportBatch(jobs, progressChan, passwordRequestChan, passwordResponseChan)
+	}()
+
+	// The first answer is reused; only the file with another password asks again
+	request := <-passwordRequestChan
+	assert.Equal(t, jobs[0].KeystorePath, request.KeystoreFile)
+	passwordResponseChan <- PasswordResponse{Password: "shared", ApplyToAll: true}
+
+	request = <-passwordRequestChan
+	assert.Equal(t, jobs[2].KeystorePath, request.KeystoreFile)
+	passwordResponseChan <- PasswordResponse{Password: "other"}
+
+	results := <-done
+	assert.Empty(t, passwordRequestChan, "no prompt for files opened by the shared password")
+	require.Len(t, results, len(jobs))
+	for i, result := range results {
+		assert.True(t, result.Success, "job %d: %v", i, result.Error)
+	}
+}
+
+func TestCreateImportJobsFromDirectory_GethKeystores(t *testing.T) {
+	dir := t.TempDir()
+	n, p := GetTestKeystoreParams()
+	ks := keystore.NewKeyStore(dir, n, p)
+	first, err := ks.NewAccount("secret")
+	require.NoError(t, err)
+	second, err := ks.NewAccount("secret")
+	require.NoError(t, err)
+
+	repo := new(MockWalletRepository)
+	service := NewBatchImportService(&WalletService{Repo: repo, KeyStore: keystore.NewKeyStore(t.TempDir(), n, p)})
+	jobs, err := service.CreateImportJobsFromDirectory(dir)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+
+	// Jobs are named after the account number and address, as geth lists them
+	byPath := map[string]ImportJob{jobs[0].KeystorePath: jobs[0], jobs[1].KeystorePath: jobs[1]}
+	for i, account := range []accounts.Account{first, second} {
+		job := byPath[account.URL.Path]
+		assert.Equal(t, fmt.Sprintf("Account #%d %s", i, shortAddress(account.Address)), job.WalletName)
+		name, ok := ParseGethKeystoreName(account.URL.Path)
+		require.True(t, ok)
+		assert.True(t, name.Created.Equal(job.CreatedAt))
+		assert.False(t, job.CreatedAt.IsZero())
+	}
+
+	// The wallet keeps the creation time of its keystore
+	job := byPath[first.URL.Path]
+	job.ManualPassword, job.RequiresInput = "secret", false
+	repo.On("AddWallet", mock.MatchedBy(func(w *Wallet) bool {
+		return w.CreatedAt.Equal(job.CreatedAt) && w.Name == job.WalletName
+	})).Return(nil).Once()
+	results := service.ImportBatch([]ImportJob{job}, make(chan ImportProgress, 100), make(chan PasswordRequest), make(chan PasswordResponse))
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Success, "%v", results[0].Error)
+	repo.AssertExpectations(t)
+}
+
+func TestWorkerCount(t *testing.T) {
+	service := NewBatchImportService(nil)
+	assert.Equal(t, 1, service.workerCount(10), "imports are sequential by default")
+
+	service.Concurrency = 4
+	assert.Equal(t, 4, service.workerCount(10))
+	assert.Equal(t, 2, service.workerCount(2), "no more workers than jobs")
+}
@@ -0,0 +1,219 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrNothingToSweep is returned for a native sweep when the balance left after
+// the gas of the wallet's transfers is nothing
+var ErrNothingToSweep = errors.New("nothing left to sweep after the gas")
+
+// BatchTransfer is one transfer of a batch sending from many wallets, such as
+// a sweep of several wallets to a treasury address
+type BatchTransfer struct {
+	Label string // Name of the source wallet, for the plan and reports
+	From  common.Address
+	To    common.Address
+	Value *big.Int // in wei
+	Data  []byte   // Calldata when the transfer calls a contract, such as a token
+	Asset string   // What a token transfer moves, such as "2.5 USDC"; empty for the native coin
+}
+
+// BatchItem is a transfer of a batch prepared from the network, or why it
+// cannot be sent
+type BatchItem struct {
+	Transfer BatchTransfer
+	Request  TransferRequest
+	Err      error
+}
+
+// BatchSource is what one source wallet holds and pays for all its
+// transfers in the batch
+type BatchSource struct {
+	Label   string
+	From    common.Address
+	Balance *big.Int
+	Needed  *big.Int // Amounts plus the most the gas can cost
+}
+
+// Insufficient reports whether the balance cannot cover every transfer of
+// the source
+func (s BatchSource) Insufficient() bool {
+	return s.Balance == nil || s.Balance.Cmp(s.Needed) < 0
+}
+
+// BatchPlan is the estimate of a batch of transfers on one network
+type BatchPlan struct {
+	ChainID  *big.Int
+	Items    []BatchItem
+	Sources  []BatchSource // In the order they first appear
+	GasLimit uint64        // Sum of the gas limits of the prepared transfers
+	MaxFees  *big.Int      // Most the gas of the prepared transfers can cost
+	Value    *big.Int      // Sum of the amounts of the prepared transfers
+}
+
+// Ready counts the transfers that can be sent
+func (p BatchPlan) Ready() int {
+	ready := 0
+	for _, item := range p.Items {
+		if item.Err == nil {
+			ready++
+		}
+	}
+	return ready
+}
+
+// PlanBatch prepares every transfer on the service's network for review.
+// The fees are read once and shared, the nonces of each source follow each
+// other, and each source's balance is checked against all its transfers: when
+// it cannot cover them none of them is sent. Failures of single transfers are
+// kept in their item; only failing to read the network is returned.
+func (ts *TransactionService) PlanBatch(ctx context.Context, transfers []BatchTransfer) (BatchPlan, error) {
+	plan, err := ts.prepareBatch(ctx, transfers)
+	if err != nil {
+		return plan, err
+	}
+	plan.settle()
+	return plan, nil
+}
+
+// prepareBatch estimates each transfer and adds up what each source needs,
+// leaving the balance check and the totals to settle
+func (ts *TransactionService) prepareBatch(ctx context.Context, transfers []BatchTransfer) (BatchPlan, error) {
+	plan := BatchPlan{MaxFees: new(big.Int), Value: new(big.Int)}
+	chainID, err := ts.backend.ChainID(ctx)
+	if err != nil {
+		return plan, fmt.Errorf("failed to read chain ID: %w", err)
+	}
+	plan.ChainID = chainID
+	maxFee, tip, err := ts.fees(ctx)
+	if err != nil {
+		return plan, err
+	}
+
+	sources := make(map[common.Address]int)
+	sourceErrs := make(map[common.Address]error)
+	nonces := make(map[common.Address]uint64)
+	for _, t := range transfers {
+		item := BatchItem{Transfer: t, Request: TransferRequest{
+			ChainID:              chainID,
+			To:                   t.To,
+			Value:                t.Value,
+			Data:                 common.CopyBytes(t.Data),
+			MaxFeePerGas:         maxFee,
+			MaxPriorityFeePerGas: tip,
+		}}
+		i, seen := sources[t.From]
+		if !seen {
+			i = len(plan.Sources)
+			sources[t.From] = i
+			source := BatchSource{Label: t.Label, From: t.From, Needed: new(big.Int)}
+			if source.Balance, err = ts.backend.BalanceAt(ctx, t.From, nil); err != nil {
+				sourceErrs[t.From] = fmt.Errorf("failed to get balance for address %s: %w", t.From.Hex(), err)
+			} else if nonces[t.From], err = ts.nonce(ctx, t.From); err != nil {
+				sourceErrs[t.From] = err
+			}
+			plan.Sources = append(plan.Sources, source)
+		}
+		item.Err = sourceErrs[t.From]
+		if item.Err == nil && (t.Value == nil || t.Value.Sign() < 0) {
+			item.Err = errors.New("the amount cannot be negative")
+		}
+		if item.Err == nil {
+			item.Request.GasLimit, item.Err = ts.estimateGas(ctx, t.From, item.Request)
+		}
+		if item.Err == nil {
+			item.Request.Nonce = nonces[t.From]
+			nonces[t.From]++
+			plan.Sources[i].Needed.Add(plan.Sources[i].Needed, item.Request.MaxCost())
+		}
+		plan.Items = append(plan.Items, item)
+	}
+	return plan, nil
+}
+
+// source returns the source sending from
+func (p *BatchPlan) source(from common.Address) *BatchSource {
+	for i := range p.Sources {
+		if p.Sources[i].From == from {
+			return &p.Sources[i]
+		}
+	}
+	return nil
+}
+
+// settle refuses every transfer of a source that cannot pay for all of them,
+// so its nonces stay in sequence, and adds up the transfers left
+func (p *BatchPlan) settle() {
+	for i := range p.Items {
+		item := &p.Items[i]
+		source := p.source(item.Transfer.From)
+		if item.Err == nil && source.Insufficient() {
+			item.Err = fmt.Errorf("%w: the balance is %s wei, up to %s wei are needed", ErrInsufficientFunds, source.Balance, source.Needed)
+		}
+		if item.Err != nil {
+			continue
+		}
+		p.GasLimit += item.Request.GasLimit
+		p.MaxFees.Add(p.MaxFees, new(big.Int).Mul(new(big.Int).SetUint64(item.Request.GasLimit), item.Request.MaxFeePerGas))
+		p.Value.Add(p.Value, item.Request.Value)
+	}
+}
+
+// Sweep moves everything out of one source wallet: its token transfers, then
+// when Native the native balance left after the most their gas can cost
+type Sweep struct {
+	Label  string
+	From   common.Address
+	To     common.Address
+	Tokens []BatchTransfer // Calls to the token contracts, with no value
+	Native bool
+}
+
+// PlanSweep plans the sweeps like PlanBatch, each source's native transfer
+// last so its nonce follows the tokens'. The native amount is what the balance
+// has left once every transfer of the source paid the most its gas can cost;
+// the part of that gas not spent stays behind.
+func (ts *TransactionService) PlanSweep(ctx context.Context, sweeps []Sweep) (BatchPlan, error) {
+	var transfers []BatchTransfer
+	var natives []int
+	for _, sweep := range sweeps {
+		for _, t := range sweep.Tokens {
+			t.Label, t.From = sweep.Label, sweep.From
+			transfers = append(transfers, t)
+		}
+		if sweep.Native {
+			// Estimated without value: the amount depends on the gas
+			natives = append(natives, len(transfers))
+			transfers = append(transfers, BatchTransfer{Label: sweep.Label, From: sweep.From, To: sweep.To, Value: new(big.Int)})
+		}
+	}
+	plan, err := ts.prepareBatch(ctx, transfers)
+	if err != nil {
+		return plan, err
+	}
+
+	for _, i := range natives {
+		item := &plan.Items[i]
+		if item.Err != nil {
+			continue
+		}
+		source := plan.source(item.Transfer.From)
+		left := new(big.Int).Sub(source.Balance, source.Needed)
+		if left.Sign() <= 0 {
+			// Not sending it leaves more for the tokens' gas
+			item.Err = ErrNothingToSweep
+			source.Needed.Sub(source.Needed, item.Request.MaxCost())
+			continue
+		}
+		item.Transfer.Value, item.Request.Value = left, left
+		source.Needed.Add(source.Needed, left)
+	}
+	plan.settle()
+	return plan, nil
+}
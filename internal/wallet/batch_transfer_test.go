@@ -0,0 +1,129 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchTxBackend holds a balance per account and fails estimates to a
+// reverting recipient
+type batchTxBackend struct {
+	*fakeTxBackend
+	balances map[common.Address]*big.Int
+	reverts  common.Address
+}
+
+func (f *batchTxBackend) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if b, ok := f.balances[account]; ok {
+		return b, nil
+	}
+	return new(big.Int), nil
+}
+
+func (f *batchTxBackend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	if *msg.To == f.reverts {
+		return 0, errors.New("execution reverted")
+	}
+	return f.fakeTxBackend.EstimateGas(ctx, msg)
+}
+
+func TestTransactionService_PlanBatch(t *testing.T) {
+	rich := common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")
+	poor := common.HexToAddress("0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0")
+	reverting := common.HexToAddress("0x00000000000000000000000000000000000BaD00")
+	backend := &batchTxBackend{
+		fakeTxBackend: &fakeTxBackend{nonce: 7},
+		balances: map[common.Address]*big.Int{
+			rich: big.NewInt(1e18),
+			// Covers one transfer with its gas, not two
+			poor: big.NewInt(1e17 + 21000*21_000_000_000),
+		},
+		reverts: reverting,
+	}
+	ts := NewTransactionService(backend)
+
+	value := big.NewInt(1e17)
+	plan, err := ts.PlanBatch(context.Background(), []BatchTransfer{
+		{Label: "rich", From: rich, To: serviceRecipient, Value: value},
+		{Label: "poor", From: poor, To: serviceRecipient, Value: value},
+		{Label: "rich", From: rich, To: reverting, Value: value},
+		{Label: "rich", From: rich, To: serviceRecipient, Value: value},
+		{Label: "poor", From: poor, To: serviceRecipient, Value: value},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(11155111), plan.ChainID)
+	require.Len(t, plan.Items, 5)
+	require.Len(t, plan.Sources, 2)
+
+	// The nonces of a source follow each other, skipping transfers that fail
+	assert.NoError(t, plan.Items[0].Err)
+	assert.Equal(t, uint64(7), plan.Items[0].Request.Nonce)
+	assert.ErrorContains(t, plan.Items[2].Err, "execution reverted")
+	assert.NoError(t, plan.Items[3].Err)
+	assert.Equal(t, uint64(8), plan.Items[3].Request.Nonce)
+
+	// A source that cannot pay for all its transfers sends none of them
+	assert.ErrorIs(t, plan.Items[1].Err, ErrInsufficientFunds)
+	assert.ErrorIs(t, plan.Items[4].Err, ErrInsufficientFunds)
+	assert.True(t, plan.Sources[1].Insufficient())
+	assert.False(t, plan.Sources[0].Insufficient())
+
+	fee := big.NewInt(21000 * 21_000_000_000)
+	assert.Equal(t, 2, plan.Ready())
+	assert.Equal(t, uint64(2*TxGas), plan.GasLimit)
+	assert.Equal(t, new(big.Int).Mul(fee, big.NewInt(2)), plan.MaxFees)
+	assert.Equal(t, big.NewInt(2e17), plan.Value)
+	assert.Equal(t, new(big.Int).Mul(new(big.Int).Add(value, fee), big.NewInt(2)), plan.Sources[0].Needed)
+}
+
+func TestTransactionService_PlanSweep(t *testing.T) {
+	rich := common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")
+	dust := common.HexToAddress("0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0")
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	fee := big.NewInt(21000 * 21_000_000_000)
+	backend := &batchTxBackend{
+		fakeTxBackend: &fakeTxBackend{nonce: 3},
+		balances: map[common.Address]*big.Int{
+			rich: big.NewInt(1e18),
+			// Pays the gas of the token transfer, nothing is left to sweep
+			dust: new(big.Int).Add(fee, big.NewInt(1)),
+		},
+	}
+	ts := NewTransactionService(backend)
+
+	tokenTransfer := BatchTransfer{To: token, Value: new(big.Int), Data: []byte{0xa9, 0x05, 0x9c, 0xbb}, Asset: "2.5 USDC"}
+	plan, err := ts.PlanSweep(context.Background(), []Sweep{
+		{Label: "rich", From: rich, To: serviceRecipient, Tokens: []BatchTransfer{tokenTransfer}, Native: true},
+		{Label: "dust", From: dust, To: serviceRecipient, Tokens: []BatchTransfer{tokenTransfer}, Native: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Items, 4)
+
+	// The native sweep follows the tokens and takes what the gas leaves
+	assert.Equal(t, "rich", plan.Items[0].Transfer.Label)
+	assert.Equal(t, rich, plan.Items[0].Transfer.From)
+	assert.Equal(t, uint64(3), plan.Items[0].Request.Nonce)
+	native := plan.Items[1]
+	require.NoError(t, native.Err)
+	assert.Equal(t, uint64(4), native.Request.Nonce)
+	left := new(big.Int).Sub(big.NewInt(1e18), new(big.Int).Mul(fee, big.NewInt(2)))
+	assert.Equal(t, left, native.Request.Value)
+	assert.Equal(t, left, native.Transfer.Value)
+	assert.Equal(t, big.NewInt(1e18), plan.Sources[0].Needed, "the whole balance is planned")
+
+	assert.NoError(t, plan.Items[2].Err, "the token transfer is still paid for")
+	assert.ErrorIs(t, plan.Items[3].Err, ErrNothingToSweep)
+	assert.False(t, plan.Sources[1].Insufficient())
+
+	assert.Equal(t, 3, plan.Ready())
+	assert.Equal(t, uint64(3*TxGas), plan.GasLimit)
+	assert.Equal(t, new(big.Int).Mul(fee, big.NewInt(3)), plan.MaxFees)
+	assert.Equal(t, left, plan.Value)
+}
@@ -0,0 +1,101 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip32"
+)
+
+// AddressHistory tells whether an address was ever used on chain
+type AddressHistory interface {
+	HasHistory(ctx context.Context, address string) (bool, error)
+}
+
+// DiscoveredChild is an address of a master mnemonic found with on-chain history
+type DiscoveredChild struct {
+	Index   uint32
+	Address string
+}
+
+// ChildAddress returns the address at m/44'/60'/0'/0/index of mnemonic
+func ChildAddress(mnemonic string, index uint32) (string, error) {
+	if index >= bip32.FirstHardenedChild {
+		return "", fmt.Errorf("index must be below %d", bip32.FirstHardenedChild)
+	}
+	privateKeyHex, err := deriveAddressKey(NormalizeMnemonic(mnemonic), index)
+	if err != nil {
+		return "", err
+	}
+	privKey, err := HexToECDSA(privateKeyHex)
+	if err != nil {
+		return "", err
+	}
+	return crypto.PubkeyToAddress(privKey.PublicKey).Hex(), nil
+}
+
+// CheckChildAddress derives the address at index and asks history whether it was used
+func CheckChildAddress(ctx context.Context, mnemonic string, index uint32, history AddressHistory) (DiscoveredChild, bool, error) {
+	address, err := ChildAddress(mnemonic, index)
+	if err != nil {
+		return DiscoveredChild{}, false, err
+	}
+	used, err := history.HasHistory(ctx, address)
+	if err != nil {
+		return DiscoveredChild{}, false, fmt.Errorf("checking %s: %w", ChildDerivationPath(index), err)
+	}
+	return DiscoveredChild{Index: index, Address: address}, used, nil
+}
+
+// DiscoverChildren walks the indices of mnemonic from 1 and returns the used
+// addresses, stopping after gapLimit unused addresses in a row. On failure it
+// returns what was found so far along with the error.
+func DiscoverChildren(ctx context.Context, mnemonic string, gapLimit int, history AddressHistory) ([]DiscoveredChild, error) {
+	if gapLimit < 1 {
+		return nil, errors.New("the gap limit must be at least 1")
+	}
+	var found []DiscoveredChild
+	gap := 0
+	for index := uint32(1); gap < gapLimit && index < bip32.FirstHardenedChild; index++ {
+		if err := ctx.Err(); err != nil {
+			return found, err
+		}
+		child, used, err := CheckChildAddress(ctx, mnemonic, index, history)
+		if err != nil {
+			return found, err
+		}
+		if !used {
+			gap++
+			continue
+		}
+		found = append(found, child)
+		gap = 0
+	}
+	return found, nil
+}
+
+// ChildLabel is the label given to a discovered child of master
+func ChildLabel(masterName string, index uint32) string {
+	return fmt.Sprintf("%s #%d", masterName, index)
+}
+
+// RegisterDiscoveredChildren registers the discovered addresses of master as
+// child wallets encrypted with password. Indices that are already allocated and
+// keys already registered as wallets are skipped and counted as such.
+func (ws *WalletService) RegisterDiscoveredChildren(master *WalletDetails, found []DiscoveredChild, password string) (registered, skipped int, err error) {
+	for _, child := range found {
+		_, err := ws.CreateChildWallet(master, child.Index, ChildLabel(master.Wallet.Name, child.Index), password)
+		var duplicate *DuplicateWalletError
+		switch {
+		case err == nil:
+			registered++
+		case errors.Is(err, ErrChildIndexAllocated), errors.As(err, &duplicate):
+			skipped++
+		default:
+			return registered, skipped, err
+		}
+	}
+	return registered, skipped, nil
+}
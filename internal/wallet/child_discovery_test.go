@@ -0,0 +1,78 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHistory marks the addresses of the listed indices as used
+type fakeHistory struct {
+	used    map[string]bool
+	failAt  string
+	checked int
+}
+
+func newFakeHistory(t *testing.T, indices ...uint32) *fakeHistory {
+	h := &fakeHistory{used: make(map[string]bool)}
+	for _, index := range indices {
+		address, err := ChildAddress(childTestMnemonic, index)
+		require.NoError(t, err)
+		h.used[address] = true
+	}
+	return h
+}
+
+func (h *fakeHistory) HasHistory(ctx context.Context, address string) (bool, error) {
+	h.checked++
+	if address == h.failAt {
+		return false, errors.New("rpc unavailable")
+	}
+	return h.used[address], nil
+}
+
+func TestChildAddress(t *testing.T) {
+	address, err := ChildAddress(childTestMnemonic, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0", address)
+
+	_, err = ChildAddress(childTestMnemonic, 1<<31)
+	assert.Error(t, err)
+}
+
+func TestDiscoverChildren(t *testing.T) {
+	ctx := context.Background()
+
+	// Index 6 is within the gap of 3 after index 4; index 12 is beyond it
+	history := newFakeHistory(t, 1, 4, 6, 12)
+	found, err := DiscoverChildren(ctx, childTestMnemonic, 3, history)
+	require.NoError(t, err)
+	require.Len(t, found, 3)
+	assert.Equal(t, []uint32{1, 4, 6}, []uint32{found[0].Index, found[1].Index, found[2].Index})
+	assert.Equal(t, "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0", found[0].Address)
+	assert.Equal(t, 9, history.checked, "indices 1 to 9")
+
+	found, err = DiscoverChildren(ctx, childTestMnemonic, 5, newFakeHistory(t))
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	_, err = DiscoverChildren(ctx, childTestMnemonic, 0, newFakeHistory(t))
+	assert.Error(t, err)
+}
+
+func TestDiscoverChildren_Failure(t *testing.T) {
+	history := newFakeHistory(t, 1)
+	history.failAt, _ = ChildAddress(childTestMnemonic, 2)
+
+	found, err := DiscoverChildren(context.Background(), childTestMnemonic, 3, history)
+	assert.ErrorContains(t, err, "m/44'/60'/0'/0/2")
+	require.Len(t, found, 1, "what was found before the failure is kept")
+	assert.Equal(t, uint32(1), found[0].Index)
+}
+
+func TestChildLabel(t *testing.T) {
+	assert.Equal(t, "Savings #3", ChildLabel("Savings", 3))
+}
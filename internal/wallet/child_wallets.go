@@ -0,0 +1,186 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tyler-smith/go-bip32"
+)
+
+// ErrChildIndexAllocated is returned when the derivation index of a master
+// mnemonic was already handed out
+var ErrChildIndexAllocated = errors.New("derivation index is already allocated")
+
+// ErrNotMasterWallet is returned when child wallets are requested from a
+// wallet that has no mnemonic
+var ErrNotMasterWallet = errors.New("only wallets created or imported from a mnemonic can derive child wallets")
+
+// ChildAllocation records that the address at an index of a master mnemonic
+// was handed out. It outlives the child wallet, so deleting a child does not
+// let the same address be given to someone else.
+type ChildAllocation struct {
+	ID               int       `gorm:"primaryKey"`
+	MasterSourceHash string    `gorm:"not null;uniqueIndex:idx_child_allocation"`
+	Index            uint32    `gorm:"column:derivation_index;not null;uniqueIndex:idx_child_allocation"`
+	Label            string    `gorm:"not null"`
+	Address          string    `gorm:"not null"`
+	AllocatedAt      time.Time `gorm:"not null;autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados
+func (ChildAllocation) TableName() string {
+	return "child_allocations"
+}
+
+// ChildAllocationStore is implemented by repositories that track child
+// allocations. AddChildAllocation fails with ErrChildIndexAllocated when the
+// index is taken.
+type ChildAllocationStore interface {
+	AddChildAllocation(allocation *ChildAllocation) error
+	ChildAllocations(masterSourceHash string) ([]ChildAllocation, error)
+}
+
+// ChildDerivationPath returns the BIP-44 path of the address at index. Index 0
+// is the address of the master wallet itself.
+func ChildDerivationPath(index uint32) string {
+	return fmt.Sprintf("m/44'/60'/0'/0/%d", index)
+}
+
+func (ws *WalletService) childAllocationStore() (ChildAllocationStore, error) {
+	store, ok := ws.Repo.(ChildAllocationStore)
+	if !ok {
+		return nil, errors.New("the wallet repository does not track child wallets")
+	}
+	return store, nil
+}
+
+// masterSourceHash identifies the master mnemonic of a wallet
+func masterSourceHash(master *Wallet) (string, error) {
+	if ImportMethod(master.ImportMethod) != ImportMethodMnemonic || master.Mnemonic == nil {
+		return "", ErrNotMasterWallet
+	}
+	return master.SourceHash, nil
+}
+
+// ChildAllocations lists the indices handed out from a master wallet, by index
+func (ws *WalletService) ChildAllocations(master *Wallet) ([]ChildAllocation, error) {
+	hash, err := masterSourceHash(master)
+	if err != nil {
+		return nil, err
+	}
+	store, err := ws.childAllocationStore()
+	if err != nil {
+		return nil, err
+	}
+	allocations, err := store.ChildAllocations(hash)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(allocations, func(i, j int) bool { return allocations[i].Index < allocations[j].Index })
+	return allocations, nil
+}
+
+// NextChildIndex returns the lowest free index above 0
+func NextChildIndex(allocations []ChildAllocation) uint32 {
+	taken := make(map[uint32]bool, len(allocations))
+	for _, a := range allocations {
+		taken[a.Index] = true
+	}
+	next := uint32(1)
+	for taken[next] {
+		next++
+	}
+	return next
+}
+
+// CreateChildWallet derives the key at m/44'/60'/0'/0/index from the master's
+// mnemonic and registers it as a wallet of its own, encrypted with password.
+// The child keeps no copy of the mnemonic, so it can be handed out without
+// exposing the master secret.
+func (ws *WalletService) CreateChildWallet(master *WalletDetails, index uint32, label, password string) (*WalletDetails, error) {
+	if master == nil || master.Wallet == nil {
+		return nil, ErrNotMasterWallet
+	}
+	hash, err := masterSourceHash(master.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	if !master.HasMnemonic || master.Mnemonic == nil {
+		return nil, errors.New("the master wallet must be unlocked to derive child wallets")
+	}
+	if index == 0 {
+		return nil, errors.New("index 0 is the address of the master wallet itself")
+	}
+	if index >= bip32.FirstHardenedChild {
+		return nil, fmt.Errorf("index must be below %d", bip32.FirstHardenedChild)
+	}
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return nil, errors.New("child wallet label cannot be empty")
+	}
+	store, err := ws.childAllocationStore()
+	if err != nil {
+		return nil, err
+	}
+
+	// Fail early on a taken index; the allocation below is the real guard
+	allocations, err := store.ChildAllocations(hash)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range allocations {
+		if a.Index == index {
+			return nil, fmt.Errorf("%w: %d is held by %q", ErrChildIndexAllocated, index, a.Label)
+		}
+	}
+
+	privateKeyHex, err := deriveAddressKey(*master.Mnemonic, index)
+	if err != nil {
+		return nil, err
+	}
+	sourceHash := (&SourceHashGenerator{}).GenerateFromPrivateKey(privateKeyHex)
+	if existing, err := ws.Repo.FindBySourceHash(sourceHash); err != nil {
+		return nil, err
+	} else if existing != nil {
+		return nil, NewDuplicateWalletError(string(ImportMethodDerived), existing.Address, "This child key is already registered as a wallet")
+	}
+	privKey, err := HexToECDSA(privateKeyHex)
+	if err != nil {
+		return nil, err
+	}
+
+	op := &operation{}
+	newPath, address, err := ws.importKey(op, privKey, NormalizeSecret(password))
+	if err != nil {
+		return nil, op.rollback(err)
+	}
+	child := &Wallet{
+		Name:             label,
+		Address:          address,
+		KeyStorePath:     newPath,
+		ImportMethod:     string(ImportMethodDerived),
+		SourceHash:       sourceHash,
+		MasterSourceHash: hash,
+		DerivationPath:   ChildDerivationPath(index),
+	}
+	if err := ws.Repo.AddWallet(child); err != nil {
+		return nil, op.rollback(err)
+	}
+	op.onRollback(func() error { return ws.Repo.DeleteWallet(child.ID, child.Version) })
+
+	allocation := &ChildAllocation{MasterSourceHash: hash, Index: index, Label: label, Address: address}
+	if err := store.AddChildAllocation(allocation); err != nil {
+		return nil, op.rollback(err)
+	}
+
+	return &WalletDetails{
+		Wallet:       child,
+		PrivateKey:   privKey,
+		PublicKey:    &privKey.PublicKey,
+		Signer:       signerForKey(privKey, ImportMethodDerived),
+		ImportMethod: ImportMethodDerived,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const childTestMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestDeriveAddressKey(t *testing.T) {
+	// Addresses other BIP-44 wallets (e.g. MetaMask) show for the test mnemonic
+	for index, want := range map[uint32]string{
+		0: "0x9858EfFD232B4033E47d90003D41EC34EcaEda94",
+		1: "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0",
+	} {
+		keyHex, err := deriveAddressKey(childTestMnemonic, index)
+		require.NoError(t, err)
+		key, err := crypto.HexToECDSA(keyHex)
+		require.NoError(t, err)
+		assert.Equal(t, want, crypto.PubkeyToAddress(key.PublicKey).Hex(), "index %d", index)
+	}
+
+	masterKey, err := DerivePrivateKey(childTestMnemonic)
+	require.NoError(t, err)
+	first, _ := deriveAddressKey(childTestMnemonic, 0)
+	assert.Equal(t, first, masterKey, "the master wallet is index 0")
+}
+
+func TestNextChildIndex(t *testing.T) {
+	assert.Equal(t, uint32(1), NextChildIndex(nil))
+	assert.Equal(t, uint32(3), NextChildIndex([]ChildAllocation{{Index: 1}, {Index: 2}, {Index: 5}}))
+	assert.Equal(t, uint32(1), NextChildIndex([]ChildAllocation{{Index: 2}}))
+}
+
+func TestCreateChildWallet_Rejects(t *testing.T) {
+	mnemonic := childTestMnemonic
+	encrypted := "encrypted"
+	master := &WalletDetails{
+		Wallet:      &Wallet{Name: "Team", ImportMethod: string(ImportMethodMnemonic), Mnemonic: &encrypted, SourceHash: "team"},
+		Mnemonic:    &mnemonic,
+		HasMnemonic: true,
+	}
+	ws := &WalletService{Repo: new(MockWalletRepository)}
+
+	_, err := ws.CreateChildWallet(master, 0, "alice", "Str0ngPassword")
+	assert.ErrorContains(t, err, "index 0")
+
+	_, err = ws.CreateChildWallet(master, 1<<31, "alice", "Str0ngPassword")
+	assert.Error(t, err)
+
+	_, err = ws.CreateChildWallet(master, 1, " ", "Str0ngPassword")
+	assert.ErrorContains(t, err, "label")
+
+	// The mock repository does not track allocations
+	_, err = ws.CreateChildWallet(master, 1, "alice", "Str0ngPassword")
+	assert.ErrorContains(t, err, "does not track child wallets")
+
+	imported := &WalletDetails{Wallet: &Wallet{ImportMethod: string(ImportMethodPrivateKey)}}
+	_, err = ws.CreateChildWallet(imported, 1, "alice", "Str0ngPassword")
+	assert.ErrorIs(t, err, ErrNotMasterWallet)
+}
@@ -0,0 +1,51 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// HasMnemonic reports whether the key of the wallet can be derived again
+// from a mnemonic: it was imported from one or derived from a stored one.
+// Deleting any other wallet with a keystore loses its key for good.
+func (w Wallet) HasMnemonic() bool {
+	if w.MasterSourceHash != "" {
+		return true
+	}
+	return ImportMethod(w.ImportMethod) == ImportMethodMnemonic && w.Mnemonic != nil && *w.Mnemonic != ""
+}
+
+// ConfirmsDeletion reports whether typed is the wallet name or the last four
+// characters of its address, the phrase asked before deleting a wallet
+// without a mnemonic
+func (w Wallet) ConfirmsDeletion(typed string) bool {
+	typed = strings.TrimSpace(typed)
+	if typed == "" {
+		return false
+	}
+	if typed == strings.TrimSpace(w.Name) {
+		return true
+	}
+	return len(typed) == 4 && len(w.Address) > 4 && strings.EqualFold(typed, w.Address[len(w.Address)-4:])
+}
+
+// ArchiveKeystore copies the keystore of the wallet into dir before it is
+// deleted, still encrypted with the wallet password. The copy is named like
+// geth's files and never overwrites another one.
+func (ws *WalletService) ArchiveKeystore(w *Wallet, dir string, at time.Time) (string, error) {
+	data, err := os.ReadFile(w.KeyStorePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read keystore file: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	path := filepath.Join(dir, DefaultKeystoreFileName(w, at)+".json")
+	if err := writeExclusive(path, data); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,48 @@
+package wallet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWallet_HasMnemonic(t *testing.T) {
+	mnemonic := "sealed"
+	assert.True(t, Wallet{ImportMethod: string(ImportMethodMnemonic), Mnemonic: &mnemonic}.HasMnemonic())
+	assert.True(t, Wallet{ImportMethod: string(ImportMethodDerived), MasterSourceHash: "master"}.HasMnemonic())
+	assert.False(t, Wallet{ImportMethod: string(ImportMethodMnemonic)}.HasMnemonic(), "no mnemonic stored")
+	assert.False(t, Wallet{ImportMethod: string(ImportMethodKeystore)}.HasMnemonic())
+	assert.False(t, Wallet{ImportMethod: string(ImportMethodPrivateKey)}.HasMnemonic())
+}
+
+func TestWallet_ConfirmsDeletion(t *testing.T) {
+	w := Wallet{Name: "Treasury", Address: "0x52908400098527886E0F7030069857D2E4169EE7"}
+	assert.True(t, w.ConfirmsDeletion("Treasury"))
+	assert.True(t, w.ConfirmsDeletion(" 9ee7 "), "the address is not case sensitive")
+	assert.False(t, w.ConfirmsDeletion("treasury"))
+	assert.False(t, w.ConfirmsDeletion("69EE7"))
+	assert.False(t, w.ConfirmsDeletion(""))
+}
+
+func TestArchiveKeystore(t *testing.T) {
+	keystorePath := filepath.Join(t.TempDir(), "key.json")
+	require.NoError(t, os.WriteFile(keystorePath, []byte(`{"version":3}`), 0600))
+	w := &Wallet{Name: "Hot", Address: "0xAbCd", KeyStorePath: keystorePath}
+	dir := filepath.Join(t.TempDir(), "deleted")
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	path, err := (&WalletService{}).ArchiveKeystore(w, dir, at)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "UTC--2026-03-01T12-00-00.000000000Z--abcd.json"), path)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"version":3}`, string(data))
+
+	// An earlier copy is kept
+	_, err = (&WalletService{}).ArchiveKeystore(w, dir, at)
+	assert.ErrorIs(t, err, os.ErrExist)
+}
@@ -74,14 +74,22 @@ func (eks *EnhancedKeyStoreService) ReadKeyStore(filePath, password string) (*En
 		MAC:          keystore.Crypto.MAC,
 	}
 
-	derivedKey, err := eks.kdfService.DeriveKey(password, cryptoParams)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao derivar chave: %w", err)
-	}
+	// Tenta a senha normalizada (NFKD) e, se diferente, a senha como digitada
+	var derivedKey []byte
+	var macErr error
+	for _, candidate := range PasswordCandidates(password) {
+		derivedKey, err = eks.kdfService.DeriveKey(candidate, cryptoParams)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao derivar chave: %w", err)
+		}
 
-	// Verifica a integridade usando MAC
-	if err := eks.verifyMAC(derivedKey, cryptoParams); err != nil {
-		return nil, fmt.Errorf("senha incorreta ou arquivo corrompido: %w", err)
+		// Verifica a integridade usando MAC
+		if macErr = eks.verifyMAC(derivedKey, cryptoParams); macErr == nil {
+			break
+		}
+	}
+	if macErr != nil {
+		return nil, fmt.Errorf("senha incorreta ou arquivo corrompido: %w", macErr)
 	}
 
 	// Descriptografa a chave privada
@@ -138,33 +146,29 @@ func (eks *EnhancedKeyStoreService) verifyMAC(derivedKey []byte, cryptoParams *C
 func (eks *EnhancedKeyStoreService) decryptPrivateKey(derivedKey []byte, cryptoParams *CryptoParams) ([]byte, error) {
 	// Suporta diferentes algoritmos de cifra
 	switch cryptoParams.Cipher {
-	case "aes-128-ctr":
+	case CipherAES128CTR, CipherAES256CTR:
 		return eks.decryptAESCTR(derivedKey, cryptoParams)
-	case "aes-128-cbc":
+	case CipherAES128CBC:
 		return eks.decryptAESCBC(derivedKey, cryptoParams)
+	case CipherAES128GCM, CipherAES256GCM:
+		return eks.decryptAESGCM(derivedKey, cryptoParams)
 	default:
 		return nil, fmt.Errorf("algoritmo de cifra não suportado: %s", cryptoParams.Cipher)
 	}
 }
 
-// decryptAESCTR descriptografa usando AES-128-CTR
+// decryptAESCTR descriptografa usando AES-128-CTR ou AES-256-CTR
 func (eks *EnhancedKeyStoreService) decryptAESCTR(derivedKey []byte, cryptoParams *CryptoParams) ([]byte, error) {
-	// Usa os primeiros 16 bytes da chave derivada para descriptografia
-	key := derivedKey[:16]
+	// Usa o início da chave derivada (16 ou 32 bytes) para descriptografia
+	key, err := aesKey(derivedKey, cryptoParams.Cipher)
 
-	ivInterface, exists := cryptoParams.CipherParams["iv"]
-	if !exists {
-		return nil, fmt.Errorf("IV não encontrado nos parâmetros de cifra")
-	}
-
-	ivStr, ok := ivInterface.(string)
-	if !ok {
-		return nil, fmt.Errorf("IV deve ser uma string")
+	if err != nil {
+		return nil, err
 	}
 
-	iv, err := hex.DecodeString(ivStr)
+	iv, err := cipherIV(cryptoParams)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao decodificar IV: %w", err)
+		return nil, err
 	}
 
 	cipherText, err := hex.DecodeString(cryptoParams.CipherText)
@@ -190,21 +194,15 @@ func (eks *EnhancedKeyStoreService) decryptAESCTR(derivedKey []byte, cryptoParam
 // decryptAESCBC descriptografa usando AES-128-CBC (para compatibilidade)
 func (eks *EnhancedKeyStoreService) decryptAESCBC(derivedKey []byte, cryptoParams *CryptoParams) ([]byte, error) {
 	// Implementação básica de AES-CBC
-	key := derivedKey[:16]
+	key, err := aesKey(derivedKey, cryptoParams.Cipher)
 
-	ivInterface, exists := cryptoParams.CipherParams["iv"]
-	if !exists {
-		return nil, fmt.Errorf("IV não encontrado nos parâmetros de cifra")
-	}
-
-	ivStr, ok := ivInterface.(string)
-	if !ok {
-		return nil, fmt.Errorf("IV deve ser uma string")
+	if err != nil {
+		return nil, err
 	}
 
-	iv, err := hex.DecodeString(ivStr)
+	iv, err := cipherIV(cryptoParams)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao decodificar IV: %w", err)
+		return nil, err
 	}
 
 	cipherText, err := hex.DecodeString(cryptoParams.CipherText)
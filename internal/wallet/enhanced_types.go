@@ -15,6 +15,13 @@ const (
 	ImportMethodMnemonic   ImportMethod = "mnemonic"
 	ImportMethodPrivateKey ImportMethod = "private_key"
 	ImportMethodKeystore   ImportMethod = "keystore"
+	// ImportMethodDerived marks a child wallet derived from a registered master mnemonic
+	ImportMethodDerived ImportMethod = "derived"
+	// ImportMethodWatchOnly marks an address tracked without any key
+	ImportMethodWatchOnly ImportMethod = "watch_only"
+	// ImportMethodMetamask names the import of the accounts of a MetaMask
+	// vault; each account is stored with the method of its key
+	ImportMethodMetamask ImportMethod = "metamask"
 )
 
 // EnhancedWallet represents an enhanced wallet with import method tracking
@@ -0,0 +1,102 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// gethKeystorePattern matches the file names geth gives its keystores,
+// UTC--2016-03-22T12-57-55.920751759Z--<address>, and those of Parity and
+// OpenEthereum, which end in the key's UUID instead of its address. Zones
+// other than UTC are written by geth as the hour offset followed by 00,
+// such as -0300.
+var gethKeystorePattern = regexp.MustCompile(`^UTC--(\d{4}-\d{2}-\d{2}T\d{2}-\d{2}-\d{2})(?:\.(\d{1,9}))?(Z|[+-]?\d{3,5})--([0-9a-fA-F]{40}|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})(?:\.json)?$`)
+
+// GethKeystoreName is what the name of a geth or Parity keystore file tells
+// about the key: when it was created and, for geth, its address
+type GethKeystoreName struct {
+	Created time.Time
+	Address *common.Address // nil when the name ends in a UUID
+}
+
+// ParseGethKeystoreName reads the file name of a keystore written by geth,
+// Parity or OpenEthereum. ok is false for any other name.
+func ParseGethKeystoreName(fileName string) (name GethKeystoreName, ok bool) {
+	match := gethKeystorePattern.FindStringSubmatch(filepath.Base(fileName))
+	if match == nil {
+		return GethKeystoreName{}, false
+	}
+
+	created, err := time.Parse("2006-01-02T15-04-05", match[1])
+	if err != nil {
+		return GethKeystoreName{}, false
+	}
+	if match[2] != "" {
+		nanos, _ := strconv.Atoi((match[2] + "00000000")[:9])
+		created = created.Add(time.Duration(nanos))
+	}
+	if match[3] != "Z" {
+		offset, err := strconv.Atoi(match[3])
+		if err != nil {
+			return GethKeystoreName{}, false
+		}
+		created = created.Add(-time.Duration(offset/100) * time.Hour)
+	}
+	name.Created = created.UTC()
+
+	if len(match[4]) == 2*common.AddressLength {
+		address := common.HexToAddress(match[4])
+		name.Address = &address
+	}
+	return name, true
+}
+
+// gethAccountIndexes numbers the geth keystores of a directory in the order of
+// their names, which is their creation order and the order in which
+// "geth account list" shows them
+type gethAccountIndexes map[string]map[string]int
+
+// index returns the account number of keystorePath among the geth keystores
+// of its directory
+func (g gethAccountIndexes) index(keystorePath string) int {
+	dir := filepath.Dir(keystorePath)
+	indexes, ok := g[dir]
+	if !ok {
+		indexes = make(map[string]int)
+		var names []string
+		if entries, err := os.ReadDir(dir); err == nil {
+			for _, entry := range entries {
+				if _, ok := ParseGethKeystoreName(entry.Name()); ok && !entry.IsDir() {
+					names = append(names, entry.Name())
+				}
+			}
+		}
+		sort.Strings(names)
+		for i, name := range names {
+			indexes[name] = i
+		}
+		g[dir] = indexes
+	}
+	return indexes[filepath.Base(keystorePath)]
+}
+
+// gethWalletName names the wallet of a geth keystore after its account number
+// and address, as "Account #0 0x1234…abcd". The address is read from the
+// keystore when the file name ends in a UUID; "" is returned if it has none.
+func gethWalletName(keystorePath string, name GethKeystoreName, index int) string {
+	if name.Address != nil {
+		return fmt.Sprintf("Account #%d %s", index, shortAddress(*name.Address))
+	}
+	address := keystoreFileAddress(keystorePath)
+	if address == "" {
+		return ""
+	}
+	return fmt.Sprintf("Account #%d %s", index, shortAddress(common.HexToAddress(address)))
+}
@@ -0,0 +1,48 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGethKeystoreName(t *testing.T) {
+	address := common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+
+	tests := []struct {
+		name        string
+		file        string
+		ok          bool
+		created     time.Time
+		withAddress bool
+	}{
+		{"geth", "UTC--2016-03-22T12-57-55.920751759Z--5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", true, time.Date(2016, 3, 22, 12, 57, 55, 920751759, time.UTC), true},
+		{"geth with path", "/keystore/UTC--2016-03-22T12-57-55.9Z--5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", true, time.Date(2016, 3, 22, 12, 57, 55, 900000000, time.UTC), true},
+		{"geth local zone", "UTC--2016-03-22T09-57-55.000000000-0300--5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", true, time.Date(2016, 3, 22, 12, 57, 55, 0, time.UTC), true},
+		{"parity", "UTC--2017-08-09T11-39-14Z--3198bc9c-6672-5ab3-d995-4942343ae5b6", true, time.Date(2017, 8, 9, 11, 39, 14, 0, time.UTC), false},
+		{"json suffix", "UTC--2016-03-22T12-57-55Z--5aaeb6053f3e94c9b9a09f33669435e7ef1beaed.json", true, time.Date(2016, 3, 22, 12, 57, 55, 0, time.UTC), true},
+		{"plain name", "my-wallet.json", false, time.Time{}, false},
+		{"bad date", "UTC--2016-13-22T12-57-55Z--5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", false, time.Time{}, false},
+		{"short address", "UTC--2016-03-22T12-57-55Z--5aaeb6053f", false, time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, ok := ParseGethKeystoreName(tt.file)
+			require.Equal(t, tt.ok, ok)
+			if !ok {
+				return
+			}
+			assert.True(t, tt.created.Equal(name.Created), "created %v", name.Created)
+			if tt.withAddress {
+				require.NotNil(t, name.Address)
+				assert.Equal(t, address, *name.Address)
+			} else {
+				assert.Nil(t, name.Address)
+			}
+		})
+	}
+}
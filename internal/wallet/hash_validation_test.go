@@ -3,6 +3,7 @@ package wallet
 import (
 	"errors"
 	"testing"
+	"time"
 )
 
 type mockRepo struct {
@@ -10,9 +11,9 @@ type mockRepo struct {
 	retErr error
 }
 
-func (m *mockRepo) AddWallet(w *Wallet) error        { return nil }
-func (m *mockRepo) GetAllWallets() ([]Wallet, error) { return nil, nil }
-func (m *mockRepo) DeleteWallet(walletID int) error  { return nil }
+func (m *mockRepo) AddWallet(w *Wallet) error                { return nil }
+func (m *mockRepo) GetAllWallets() ([]Wallet, error)         { return nil, nil }
+func (m *mockRepo) DeleteWallet(walletID, version int) error { return nil }
 func (m *mockRepo) FindBySourceHash(sourceHash string) (*Wallet, error) {
 	return m.ret, m.retErr
 }
@@ -21,6 +22,10 @@ func (m *mockRepo) FindByAddress(address string) ([]Wallet, error) { return nil,
 func (m *mockRepo) FindByAddressAndMethod(address, importMethod string) ([]Wallet, error) {
 	return nil, nil
 }
+func (m *mockRepo) UpdateWalletName(int, int, string, time.Time) error { return nil }
+func (m *mockRepo) UpdateWalletNotes(int, int, string, WalletReferences, time.Time) error {
+	return nil
+}
 
 func TestValidateUniqueSourceHash_Empty(t *testing.T) {
 	repo := &mockRepo{}
@@ -0,0 +1,105 @@
+package wallet
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DuplicateAction is what a batch import does with keystores whose address
+// already has a wallet
+type DuplicateAction string
+
+const (
+	// DuplicateSkip leaves the duplicates out of the batch
+	DuplicateSkip DuplicateAction = "skip"
+	// DuplicateOverwrite replaces the managed keystores of the duplicates
+	// without asking file by file; identical files are left out, as there
+	// is nothing to replace
+	DuplicateOverwrite DuplicateAction = "overwrite"
+	// DuplicateImportAnyway runs the batch unchanged, the duplicates failing
+	// or asking to be overwritten as they are reached
+	DuplicateImportAnyway DuplicateAction = "import_anyway"
+)
+
+// ImportDuplicate is a keystore of a batch whose address already has a wallet
+type ImportDuplicate struct {
+	Job     ImportJob
+	Address string
+	Wallet  Wallet // The wallet already holding the address
+	// Identical is set when this very keystore was imported already, so
+	// importing it again can only fail
+	Identical bool
+
+	replacement [sha256.Size]byte
+}
+
+// FindImportDuplicates checks the jobs of a batch against the wallets before
+// it runs. The address of a keystore is stored in clear, so no password is
+// needed; files whose address cannot be read are left to the import itself.
+func (bis *BatchImportService) FindImportDuplicates(jobs []ImportJob) ([]ImportDuplicate, error) {
+	var duplicates []ImportDuplicate
+	for _, job := range jobs {
+		address := keystoreFileAddress(job.KeystorePath)
+		if address == "" {
+			continue
+		}
+		existing, err := bis.walletService.FindWalletByAddress(address)
+		if err != nil || existing == nil {
+			continue
+		}
+
+		keyJSON, err := os.ReadFile(job.KeystorePath)
+		if err != nil {
+			continue
+		}
+		same, err := bis.walletService.Repo.FindBySourceHash((&SourceHashGenerator{}).GenerateFromKeystore(keyJSON))
+		if err != nil {
+			return nil, fmt.Errorf("cannot check %s against the wallets: %w", job.KeystorePath, err)
+		}
+		duplicates = append(duplicates, ImportDuplicate{
+			Job:         job,
+			Address:     address,
+			Wallet:      *existing,
+			Identical:   same != nil,
+			replacement: sha256.Sum256(keyJSON),
+		})
+	}
+	return duplicates, nil
+}
+
+// ResolveImportDuplicates returns the jobs of a batch once action is applied
+// to its duplicates, found by FindImportDuplicates. For DuplicateOverwrite,
+// the replacement of each managed keystore is approved as if confirmed in
+// the overwrite dialog.
+func (bis *BatchImportService) ResolveImportDuplicates(jobs []ImportJob, duplicates []ImportDuplicate, action DuplicateAction) ([]ImportJob, error) {
+	if action == DuplicateImportAnyway {
+		return jobs, nil
+	}
+
+	dir, err := bis.walletService.keystoreDir()
+	if err != nil {
+		return nil, err
+	}
+	drop := make(map[string]bool, len(duplicates))
+	for _, d := range duplicates {
+		if action == DuplicateSkip || d.Identical {
+			drop[d.Job.KeystorePath] = true
+			continue
+		}
+		bis.walletService.ApproveKeystoreOverwrite(&KeystoreOverwriteError{
+			Path:        filepath.Join(dir, fmt.Sprintf("%s.json", d.Address)),
+			Address:     d.Address,
+			replacement: d.replacement,
+		})
+	}
+
+	remaining := make([]ImportJob, 0, len(jobs))
+	for _, job := range jobs {
+		if !drop[job.KeystorePath] {
+			remaining = append(remaining, job)
+		}
+	}
+	return remaining, nil
+}
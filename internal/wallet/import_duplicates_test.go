@@ -0,0 +1,67 @@
+package wallet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindAndResolveImportDuplicates(t *testing.T) {
+	n, p := GetTestKeystoreParams()
+	source := keystore.NewKeyStore(t.TempDir(), n, p)
+	var files []accounts.Account
+	for i := 0; i < 3; i++ {
+		account, err := source.NewAccount("secret")
+		require.NoError(t, err)
+		files = append(files, account)
+	}
+	sourceHash := func(account accounts.Account) string {
+		keyJSON, err := os.ReadFile(account.URL.Path)
+		require.NoError(t, err)
+		return (&SourceHashGenerator{}).GenerateFromKeystore(keyJSON)
+	}
+
+	// The first keystore was imported as is, the second is another keystore of a known address
+	repo := new(MockWalletRepository)
+	repo.On("FindByAddress", files[0].Address.Hex()).Return([]Wallet{{Name: "Same", ImportMethod: string(ImportMethodKeystore)}}, nil)
+	repo.On("FindByAddress", files[1].Address.Hex()).Return([]Wallet{{Name: "Other", ImportMethod: string(ImportMethodKeystore)}}, nil)
+	repo.On("FindByAddress", files[2].Address.Hex()).Return([]Wallet{}, nil)
+	repo.On("FindBySourceHash", sourceHash(files[0])).Return(&Wallet{Name: "Same"}, nil)
+	repo.On("FindBySourceHash", sourceHash(files[1])).Return(nil, nil)
+
+	keystoreDir := t.TempDir()
+	ws := &WalletService{Repo: repo, KeyStore: keystore.NewKeyStore(keystoreDir, n, p), KeystoreDir: keystoreDir}
+	service := NewBatchImportService(ws)
+	var jobs []ImportJob
+	for _, f := range files {
+		jobs = append(jobs, ImportJob{KeystorePath: f.URL.Path, WalletName: "w"})
+	}
+
+	duplicates, err := service.FindImportDuplicates(jobs)
+	require.NoError(t, err)
+	require.Len(t, duplicates, 2)
+	assert.Equal(t, "Same", duplicates[0].Wallet.Name)
+	assert.True(t, duplicates[0].Identical)
+	assert.Equal(t, "Other", duplicates[1].Wallet.Name)
+	assert.False(t, duplicates[1].Identical)
+
+	skipped, err := service.ResolveImportDuplicates(jobs, duplicates, DuplicateSkip)
+	require.NoError(t, err)
+	assert.Equal(t, []ImportJob{jobs[2]}, skipped)
+
+	anyway, err := service.ResolveImportDuplicates(jobs, duplicates, DuplicateImportAnyway)
+	require.NoError(t, err)
+	assert.Equal(t, jobs, anyway)
+
+	// Overwriting leaves out the identical file and approves replacing the other one
+	overwritten, err := service.ResolveImportDuplicates(jobs, duplicates, DuplicateOverwrite)
+	require.NoError(t, err)
+	assert.Equal(t, []ImportJob{jobs[1], jobs[2]}, overwritten)
+	managed := filepath.Join(keystoreDir, files[1].Address.Hex()+".json")
+	assert.Contains(t, ws.approvedOverwrites, managed)
+}
@@ -0,0 +1,181 @@
+package wallet
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats of the import report, named after the extension of its file
+const (
+	ImportReportJSON = "json"
+	ImportReportCSV  = "csv"
+)
+
+// ImportReport is the record of a batch import written for audits, with the
+// totals of the summary and the outcome of every file
+type ImportReport struct {
+	StartedAt  time.Time          `json:"started_at"`
+	FinishedAt time.Time          `json:"finished_at"`
+	DurationMS int64              `json:"duration_ms"`
+	Total      int                `json:"total_files"`
+	Successful int                `json:"successful"`
+	Failed     int                `json:"failed"`
+	Skipped    int                `json:"skipped"`
+	Files      []ImportReportFile `json:"files"`
+	// Password manifest rows left out of the import
+	ManifestProblems []ImportReportManifestProblem `json:"manifest_problems,omitempty"`
+}
+
+// ImportReportFile is the outcome of one file of the batch. Passwords are
+// never written, only where they came from.
+type ImportReportFile struct {
+	File           string `json:"file"`
+	WalletName     string `json:"wallet_name"`
+	Address        string `json:"address,omitempty"`
+	Status         string `json:"status"` // "success", "failed" or "skipped"
+	PasswordSource string `json:"password_source"`
+	ErrorType      string `json:"error_type,omitempty"`
+	Error          string `json:"error,omitempty"`
+	DurationMS     int64  `json:"duration_ms"`
+}
+
+// ImportReportManifestProblem is a password manifest row left out of the batch
+type ImportReportManifestProblem struct {
+	Manifest string `json:"manifest"`
+	Line     int    `json:"line,omitempty"`
+	File     string `json:"file,omitempty"`
+	Type     string `json:"type"`
+}
+
+// NewImportReport builds the report of a batch from its summary and results
+func NewImportReport(summary ImportSummary, results []ImportResult, startedAt, finishedAt time.Time) ImportReport {
+	report := ImportReport{
+		StartedAt:  startedAt.UTC(),
+		FinishedAt: finishedAt.UTC(),
+		DurationMS: finishedAt.Sub(startedAt).Milliseconds(),
+		Total:      summary.TotalFiles,
+		Successful: summary.SuccessfulImports,
+		Failed:     summary.FailedImports,
+		Skipped:    summary.SkippedImports,
+		Files:      make([]ImportReportFile, 0, len(results)),
+	}
+	for _, result := range results {
+		file := ImportReportFile{
+			File:           result.Job.KeystorePath,
+			WalletName:     result.Job.WalletName,
+			Status:         "failed",
+			PasswordSource: importPasswordSource(result.Job),
+			DurationMS:     result.Duration.Milliseconds(),
+		}
+		switch {
+		case result.Success:
+			file.Status = "success"
+		case result.Skipped:
+			file.Status = "skipped"
+		}
+		if result.Wallet != nil && result.Wallet.Wallet != nil {
+			file.Address = result.Wallet.Wallet.Address
+		}
+		if result.Error != nil {
+			file.ErrorType = ImportErrorType(result.Error)
+			file.Error = result.Error.Error()
+		}
+		report.Files = append(report.Files, file)
+	}
+	for _, problem := range summary.ManifestProblems {
+		report.ManifestProblems = append(report.ManifestProblems, ImportReportManifestProblem{
+			Manifest: problem.Manifest,
+			Line:     problem.Line,
+			File:     problem.File,
+			Type:     problem.Type.String(),
+		})
+	}
+	return report
+}
+
+// importPasswordSource tells where the password of a job came from
+func importPasswordSource(job ImportJob) string {
+	switch {
+	case job.ManualPassword != "":
+		return "manual"
+	case job.PasswordPath != "" && !job.RequiresInput:
+		return "file"
+	default:
+		return "prompt"
+	}
+}
+
+// ImportErrorType names the kind of an import error with a stable code, the
+// same the keystore errors use, so reports can be grouped and filtered
+func ImportErrorType(err error) string {
+	var keystoreErr *KeystoreImportError
+	if errors.As(err, &keystoreErr) {
+		return keystoreErr.Type.String()
+	}
+	var passwordErr *PasswordInputError
+	if errors.As(err, &passwordErr) {
+		return "PASSWORD_INPUT_" + passwordErr.Type.String()
+	}
+	var overwriteErr *KeystoreOverwriteError
+	if errors.As(err, &overwriteErr) {
+		return "KEYSTORE_OVERWRITE"
+	}
+	return "UNKNOWN"
+}
+
+// ImportReportFormat returns the format named by the extension of path,
+// JSON unless it ends in .csv
+func ImportReportFormat(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return ImportReportCSV
+	}
+	return ImportReportJSON
+}
+
+// WriteImportReport writes the report as indented JSON or as CSV, one row per
+// file; the CSV leaves out the totals, which are the count of each status
+func WriteImportReport(out io.Writer, report ImportReport, format string) error {
+	if format != ImportReportCSV {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"file", "wallet_name", "address", "status", "password_source", "error_type", "error", "duration_ms"}); err != nil {
+		return err
+	}
+	for _, f := range report.Files {
+		if err := w.Write([]string{f.File, f.WalletName, f.Address, f.Status, f.PasswordSource, f.ErrorType, f.Error, strconv.FormatInt(f.DurationMS, 10)}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// WriteImportReportFile writes the report to path in the format its extension
+// names, creating the directory. The file is readable by the current user
+// only, as it lists the paths and addresses of the keystores.
+func WriteImportReportFile(path string, report ImportReport) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("cannot create the report directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if err := WriteImportReport(f, report, ImportReportFormat(path)); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,103 @@
+package wallet
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testImportReport() ImportReport {
+	started := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	results := []ImportResult{
+		{
+			Job:      ImportJob{KeystorePath: "/keys/a.json", WalletName: "A", PasswordPath: "/keys/a.pwd"},
+			Success:  true,
+			Wallet:   &WalletDetails{Wallet: &Wallet{Address: "0x1111111111111111111111111111111111111111"}},
+			Duration: 1500 * time.Millisecond,
+		},
+		{
+			Job:      ImportJob{KeystorePath: "/keys/b.json", WalletName: "B", ManualPassword: "secret"},
+			Error:    NewKeystoreImportError(ErrorIncorrectPassword, "wrong password, try again", nil),
+			Duration: 2 * time.Second,
+		},
+		{
+			Job:     ImportJob{KeystorePath: "/keys/c.json", WalletName: "C", RequiresInput: true},
+			Error:   &PasswordInputError{Type: PasswordInputSkipped, Message: "skipped by user"},
+			Skipped: true,
+		},
+	}
+	summary := ImportSummary{
+		TotalFiles:        3,
+		SuccessfulImports: 1,
+		FailedImports:     1,
+		SkippedImports:    1,
+		ManifestProblems:  []PasswordManifestProblem{{Manifest: "/keys/passwords.csv", Line: 4, File: "d.json", Type: ManifestMissingFile}},
+	}
+	return NewImportReport(summary, results, started, started.Add(5*time.Second))
+}
+
+func TestNewImportReport(t *testing.T) {
+	report := testImportReport()
+
+	assert.Equal(t, int64(5000), report.DurationMS)
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 1, report.Successful)
+	require.Len(t, report.Files, 3)
+
+	assert.Equal(t, ImportReportFile{
+		File: "/keys/a.json", WalletName: "A", Address: "0x1111111111111111111111111111111111111111",
+		Status: "success", PasswordSource: "file", DurationMS: 1500,
+	}, report.Files[0])
+	assert.Equal(t, "failed", report.Files[1].Status)
+	assert.Equal(t, "manual", report.Files[1].PasswordSource)
+	assert.Equal(t, "INCORRECT_PASSWORD", report.Files[1].ErrorType)
+	assert.Equal(t, "skipped", report.Files[2].Status)
+	assert.Equal(t, "prompt", report.Files[2].PasswordSource)
+	assert.Equal(t, "PASSWORD_INPUT_SKIPPED", report.Files[2].ErrorType)
+
+	require.Len(t, report.ManifestProblems, 1)
+	assert.Equal(t, "MISSING_FILE", report.ManifestProblems[0].Type)
+}
+
+func TestImportErrorType(t *testing.T) {
+	assert.Equal(t, "KEYSTORE_OVERWRITE", ImportErrorType(&KeystoreOverwriteError{Path: "x"}))
+	assert.Equal(t, "UNKNOWN", ImportErrorType(errors.New("disk full")))
+}
+
+func TestWriteImportReportFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "reports")
+	report := testImportReport()
+
+	jsonPath := filepath.Join(dir, "import.json")
+	require.NoError(t, WriteImportReportFile(jsonPath, report))
+	data, err := os.ReadFile(jsonPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret", "passwords are never written")
+	var decoded ImportReport
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, report, decoded)
+
+	info, err := os.Stat(jsonPath)
+	require.NoError(t, err)
+	if filepath.Separator == '/' {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	csvPath := filepath.Join(dir, "import.CSV")
+	require.NoError(t, WriteImportReportFile(csvPath, report))
+	data, err = os.ReadFile(csvPath)
+	require.NoError(t, err)
+	rows, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	assert.Equal(t, "duration_ms", rows[0][7])
+	assert.Equal(t, []string{"/keys/b.json", "B", "", "failed", "manual", "INCORRECT_PASSWORD", "wrong password, try again", "2000"}, rows[2])
+}
@@ -0,0 +1,219 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ImportFileStatus is the state of one file of a batch import
+type ImportFileStatus string
+
+const (
+	ImportFilePending ImportFileStatus = "pending"
+	ImportFileSuccess ImportFileStatus = "success"
+	ImportFileFailed  ImportFileStatus = "failed"
+)
+
+// ImportFileState records one keystore of a batch import as it is processed,
+// so a batch interrupted by a crash or a cancel can be resumed
+type ImportFileState struct {
+	ID           int              `gorm:"primaryKey"`
+	BatchID      string           `gorm:"not null;index"`
+	Position     int              `gorm:"not null"`
+	KeystorePath string           `gorm:"not null"`
+	Status       ImportFileStatus `gorm:"not null"`
+	Address      string
+	Error        string
+	UpdatedAt    time.Time `gorm:"not null;autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados
+func (ImportFileState) TableName() string {
+	return "import_batch_files"
+}
+
+// ImportStateStore is implemented by repositories that keep the state of the
+// last batch import. Starting a batch replaces the state of the previous one.
+type ImportStateStore interface {
+	StartImportBatch(files []ImportFileState) error
+	UpdateImportFile(batchID string, position int, status ImportFileStatus, address, message string) error
+	ImportBatchFiles() ([]ImportFileState, error)
+	ClearImportBatch() error
+}
+
+// PendingImport describes the last batch import when it did not finish
+type PendingImport struct {
+	Total     int      // Files in the batch
+	Imported  int      // Files imported before it stopped
+	Remaining []string // Keystores still to import, in batch order
+}
+
+func (bis *BatchImportService) importStateStore() ImportStateStore {
+	if bis.walletService == nil {
+		return nil
+	}
+	store, _ := bis.walletService.Repo.(ImportStateStore)
+	return store
+}
+
+// PendingImport returns the last batch import when some of its files were not
+// imported, or nil when it finished or the repository keeps no import state
+func (bis *BatchImportService) PendingImport() (*PendingImport, error) {
+	store := bis.importStateStore()
+	if store == nil {
+		return nil, nil
+	}
+	files, err := store.ImportBatchFiles()
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+	pending := &PendingImport{Total: len(files)}
+	for _, f := range files {
+		if f.Status == ImportFileSuccess {
+			pending.Imported++
+		} else {
+			pending.Remaining = append(pending.Remaining, f.KeystorePath)
+		}
+	}
+	if len(pending.Remaining) == 0 {
+		return nil, nil
+	}
+	return pending, nil
+}
+
+// ResumeImportJobs creates the jobs of the files the last batch did not
+// import. Keystores whose address already belongs to a local wallet, e.g.
+// imported just before a crash, are marked as imported and skipped, as are
+// files that no longer exist. The skipped paths are returned with the jobs.
+func (bis *BatchImportService) ResumeImportJobs() ([]ImportJob, []string, error) {
+	pending, err := bis.PendingImport()
+	if err != nil {
+		return nil, nil, err
+	}
+	if pending == nil {
+		return nil, nil, errors.New("there is no interrupted import to resume")
+	}
+	store := bis.importStateStore()
+	files, err := store.ImportBatchFiles()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var remaining, skipped []string
+	for _, f := range files {
+		if f.Status == ImportFileSuccess {
+			continue
+		}
+		if _, err := os.Stat(f.KeystorePath); err != nil {
+			skipped = append(skipped, f.KeystorePath)
+			continue
+		}
+		if address := keystoreFileAddress(f.KeystorePath); address != "" {
+			if _, err := bis.walletService.FindWalletByAddress(address); err == nil {
+				if err := store.UpdateImportFile(f.BatchID, f.Position, ImportFileSuccess, address, ""); err != nil {
+					return nil, nil, err
+				}
+				skipped = append(skipped, f.KeystorePath)
+				continue
+			}
+		}
+		remaining = append(remaining, f.KeystorePath)
+	}
+	if len(remaining) == 0 {
+		return nil, skipped, store.ClearImportBatch()
+	}
+	jobs, err := bis.CreateImportJobsFromFiles(remaining)
+	return jobs, skipped, err
+}
+
+// DiscardPendingImport forgets the last batch import, so it is not offered
+// for resuming again
+func (bis *BatchImportService) DiscardPendingImport() error {
+	if store := bis.importStateStore(); store != nil {
+		return store.ClearImportBatch()
+	}
+	return nil
+}
+
+// importJournal writes the outcome of each job of a batch to the store as it
+// finishes. Write failures are logged: they must not stop the import itself.
+type importJournal struct {
+	store   ImportStateStore
+	batchID string
+}
+
+// startImportJournal records jobs as pending; it returns nil when the
+// repository keeps no import state
+func (bis *BatchImportService) startImportJournal(jobs []ImportJob) *importJournal {
+	store := bis.importStateStore()
+	if store == nil {
+		return nil
+	}
+	j := &importJournal{store: store, batchID: strconv.FormatInt(time.Now().UnixNano(), 36)}
+	files := make([]ImportFileState, len(jobs))
+	for i, job := range jobs {
+		files[i] = ImportFileState{BatchID: j.batchID, Position: i, KeystorePath: job.KeystorePath, Status: ImportFilePending}
+	}
+	if err := store.StartImportBatch(files); err != nil {
+		log.Printf("batch import: cannot record the import state: %v", err)
+		return nil
+	}
+	return j
+}
+
+// record saves the result of the job at position i
+func (j *importJournal) record(i int, result ImportResult) {
+	if j == nil {
+		return
+	}
+	status, address, message := ImportFileFailed, "", ""
+	if result.Success {
+		status = ImportFileSuccess
+		if result.Wallet != nil && result.Wallet.Wallet != nil {
+			address = result.Wallet.Wallet.Address
+		}
+	} else if result.Error != nil {
+		message = result.Error.Error()
+	}
+	if err := j.store.UpdateImportFile(j.batchID, i, status, address, message); err != nil {
+		log.Printf("batch import: cannot record the state of %s: %v", result.Job.KeystorePath, err)
+	}
+}
+
+// finish forgets the batch once every file was imported; otherwise its state
+// is kept so the failed and skipped files can be resumed
+func (j *importJournal) finish(results []ImportResult) {
+	if j == nil {
+		return
+	}
+	for _, r := range results {
+		if !r.Success {
+			return
+		}
+	}
+	if err := j.store.ClearImportBatch(); err != nil {
+		log.Printf("batch import: cannot clear the import state: %v", err)
+	}
+}
+
+// keystoreFileAddress returns the checksummed address a keystore file
+// declares, or "" when it cannot be read
+func keystoreFileAddress(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	var file struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil || !common.IsHexAddress(file.Address) {
+		return ""
+	}
+	return common.HexToAddress(file.Address).Hex()
+}
@@ -0,0 +1,102 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stateRepository keeps the import state of a MockWalletRepository in memory
+type stateRepository struct {
+	*MockWalletRepository
+	files []ImportFileState
+}
+
+func (r *stateRepository) StartImportBatch(files []ImportFileState) error {
+	r.files = append([]ImportFileState(nil), files...)
+	return nil
+}
+
+func (r *stateRepository) UpdateImportFile(batchID string, position int, status ImportFileStatus, address, message string) error {
+	for i := range r.files {
+		if r.files[i].BatchID == batchID && r.files[i].Position == position {
+			r.files[i].Status, r.files[i].Address, r.files[i].Error = status, address, message
+		}
+	}
+	return nil
+}
+
+func (r *stateRepository) ImportBatchFiles() ([]ImportFileState, error) {
+	return append([]ImportFileState(nil), r.files...), nil
+}
+
+func (r *stateRepository) ClearImportBatch() error {
+	r.files = nil
+	return nil
+}
+
+func importAll(service *BatchImportService, jobs []ImportJob) []ImportResult {
+	progressChan := make(chan ImportProgress, 100)
+	go func() {
+		for range progressChan {
+		}
+	}()
+	return service.ImportBatch(jobs, progressChan, make(chan PasswordRequest, 1), make(chan PasswordResponse, 1))
+}
+
+func TestResumeImport(t *testing.T) {
+	mockRepo := new(MockWalletRepository)
+	mockRepo.On("AddWallet", mock.Anything).Return(nil)
+	repo := &stateRepository{MockWalletRepository: mockRepo}
+	n, p := GetTestKeystoreParams()
+	ks := keystore.NewKeyStore(t.TempDir(), n, p)
+	_, err := ks.NewAccount("secret")
+	require.NoError(t, err)
+	service := NewBatchImportService(&WalletService{Repo: repo, KeyStore: ks})
+
+	pending, err := service.PendingImport()
+	require.NoError(t, err)
+	assert.Nil(t, pending, "nothing to resume before the first batch")
+
+	first, _ := createTestKeystoreFile(t, "secret")
+	failed, failedAddress := createTestKeystoreFile(t, "secret")
+	third, thirdAddress := createTestKeystoreFile(t, "secret")
+	results := importAll(service, []ImportJob{
+		{KeystorePath: first, WalletName: "first", ManualPassword: "secret"},
+		{KeystorePath: failed, WalletName: "failed", ManualPassword: "wrong"},
+		{KeystorePath: third, WalletName: "third", ManualPassword: "secret"},
+	})
+	require.False(t, results[1].Success)
+
+	pending, err = service.PendingImport()
+	require.NoError(t, err)
+	require.NotNil(t, pending)
+	assert.Equal(t, 3, pending.Total)
+	assert.Equal(t, 2, pending.Imported)
+	assert.Equal(t, []string{failed}, pending.Remaining)
+	assert.Equal(t, thirdAddress.Hex(), repo.files[2].Address)
+	assert.NotEmpty(t, repo.files[1].Error)
+
+	// A crash after the third wallet was saved leaves its file pending
+	repo.files[2].Status = ImportFilePending
+	mockRepo.On("FindByAddress", thirdAddress.Hex()).Return([]Wallet{{Address: thirdAddress.Hex(), ImportMethod: string(ImportMethodKeystore)}}, nil)
+	mockRepo.On("FindByAddress", failedAddress.Hex()).Return([]Wallet{}, nil)
+
+	jobs, skipped, err := service.ResumeImportJobs()
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, failed, jobs[0].KeystorePath)
+	assert.Equal(t, []string{third}, skipped, "addresses already imported are skipped")
+	assert.Equal(t, ImportFileSuccess, repo.files[2].Status)
+
+	jobs[0].ManualPassword, jobs[0].RequiresInput = "secret", false
+	results = importAll(service, jobs)
+	require.True(t, results[0].Success, "%v", results[0].Error)
+	assert.Empty(t, repo.files, "a batch without failures is forgotten")
+	pending, err = service.PendingImport()
+	require.NoError(t, err)
+	assert.Nil(t, pending)
+}
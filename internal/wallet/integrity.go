@@ -0,0 +1,118 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// QuarantineDirName is the directory, next to the keystore directory, where
+// quarantined wallets are kept
+const QuarantineDirName = "quarantine"
+
+// IntegrityError is returned by LoadWallet when the decrypted key does not
+// belong to the wallet, a sign that the keystore file or the database row was
+// tampered with or corrupted
+type IntegrityError struct {
+	Wallet   *Wallet
+	Problems []string
+}
+
+func (e *IntegrityError) Error() string {
+	return fmt.Sprintf("wallet %s failed the integrity check: %s", e.Wallet.Address, strings.Join(e.Problems, "; "))
+}
+
+// verifyWalletKey cross-checks the address stored for the wallet with the
+// address derived from the decrypted key, the address field of the keystore
+// file and, for mnemonic wallets, the address derived from the mnemonic
+func verifyWalletKey(wallet *Wallet, keyJSON []byte, key *keystore.Key, mnemonic *string) []string {
+	var problems []string
+	stored := common.HexToAddress(wallet.Address)
+
+	if key.Address != stored {
+		problems = append(problems, fmt.Sprintf("the decrypted key derives %s, not the stored address", key.Address.Hex()))
+	}
+
+	var file struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(keyJSON, &file); err == nil && file.Address != "" {
+		if fileAddress := common.HexToAddress(file.Address); fileAddress != stored {
+			problems = append(problems, fmt.Sprintf("the keystore file declares %s, not the stored address", fileAddress.Hex()))
+		}
+	}
+
+	if mnemonic != nil && wallet.ImportMethod == string(ImportMethodMnemonic) {
+		privateKeyHex, err := DerivePrivateKey(*mnemonic)
+		if err != nil {
+			problems = append(problems, "the stored mnemonic cannot be derived: "+err.Error())
+		} else if privKey, err := crypto.HexToECDSA(privateKeyHex); err != nil {
+			problems = append(problems, "the stored mnemonic cannot be derived: "+err.Error())
+		} else if derived := crypto.PubkeyToAddress(privKey.PublicKey); derived != stored {
+			problems = append(problems, fmt.Sprintf("the stored mnemonic derives %s, not the stored address", derived.Hex()))
+		}
+	}
+	return problems
+}
+
+// quarantineRecord is written next to the quarantined keystore so the wallet
+// can be examined or restored by hand
+type quarantineRecord struct {
+	Wallet        *Wallet   `json:"wallet"`
+	Problems      []string  `json:"problems"`
+	QuarantinedAt time.Time `json:"quarantined_at"`
+	KeystoreFrom  string    `json:"keystore_from"`
+}
+
+// QuarantineWallet moves the wallet's keystore file and database row into a
+// quarantine folder and removes the wallet from the active set. Nothing is
+// destroyed: the folder keeps the keystore, the encrypted mnemonic and the
+// reasons. It returns the folder path.
+func (ws *WalletService) QuarantineWallet(wallet *Wallet, problems []string) (string, error) {
+	keystoreDir := filepath.Dir(wallet.KeyStorePath)
+	at := time.Now().UTC()
+	dir := filepath.Join(filepath.Dir(keystoreDir), QuarantineDirName,
+		fmt.Sprintf("%s-%s", wallet.Address, at.Format("20060102T150405Z")))
+
+	op := &operation{}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	op.onRollback(func() error { return os.RemoveAll(dir) })
+
+	movedKeystore := filepath.Join(dir, filepath.Base(wallet.KeyStorePath))
+	if err := os.Rename(wallet.KeyStorePath, movedKeystore); err != nil && !os.IsNotExist(err) {
+		return "", op.rollback(fmt.Errorf("moving keystore to quarantine: %w", err))
+	} else if err == nil {
+		// Registered after the folder removal so it runs first on rollback
+		op.onRollback(func() error { return os.Rename(movedKeystore, wallet.KeyStorePath) })
+	}
+
+	record, err := json.MarshalIndent(quarantineRecord{
+		Wallet:        wallet,
+		Problems:      problems,
+		QuarantinedAt: at,
+		KeystoreFrom:  wallet.KeyStorePath,
+	}, "", "  ")
+	if err != nil {
+		return "", op.rollback(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "wallet.json"), record, 0600); err != nil {
+		return "", op.rollback(err)
+	}
+
+	if err := ws.Repo.DeleteWallet(wallet.ID, wallet.Version); err != nil {
+		return "", op.rollback(err)
+	}
+	if svcLogger != nil {
+		svcLogger.Warn("Wallet quarantined: " + wallet.Address + " -> " + dir)
+	}
+	return dir, nil
+}
@@ -0,0 +1,95 @@
+package wallet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const integrityTestKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+// importIntegrityWallet imports a private key and returns the stored wallet
+func importIntegrityWallet(t *testing.T) (*WalletService, *MockWalletRepository, *Wallet) {
+	t.Helper()
+	repo := new(MockWalletRepository)
+	repo.On("FindBySourceHash", mock.Anything).Return(nil, nil)
+	repo.On("AddWallet", mock.AnythingOfType("*wallet.Wallet")).Return(nil)
+	ws, _ := newOperationTestService(t, repo)
+
+	details, err := ws.ImportWalletFromPrivateKey("Test", integrityTestKey, "password")
+	require.NoError(t, err)
+	return ws, repo, details.Wallet
+}
+
+func TestLoadWallet_VerifiesAddress(t *testing.T) {
+	ws, _, w := importIntegrityWallet(t)
+
+	details, err := ws.LoadWallet(w, "password")
+	require.NoError(t, err)
+	assert.Equal(t, w.Address, details.Wallet.Address)
+}
+
+func TestLoadWallet_DetectsTamperedRow(t *testing.T) {
+	ws, _, w := importIntegrityWallet(t)
+	w.Address = "0x000000000000000000000000000000000000dEaD"
+
+	_, err := ws.LoadWallet(w, "password")
+	var integrityErr *IntegrityError
+	require.ErrorAs(t, err, &integrityErr)
+	assert.Len(t, integrityErr.Problems, 2, "derived key and keystore address both differ")
+}
+
+func TestLoadWallet_DetectsTamperedKeystoreAddress(t *testing.T) {
+	ws, _, w := importIntegrityWallet(t)
+
+	data, err := os.ReadFile(w.KeyStorePath)
+	require.NoError(t, err)
+	var ks map[string]any
+	require.NoError(t, json.Unmarshal(data, &ks))
+	ks["address"] = "000000000000000000000000000000000000dead"
+	data, err = json.Marshal(ks)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(w.KeyStorePath, data, 0600))
+
+	_, err = ws.LoadWallet(w, "password")
+	var integrityErr *IntegrityError
+	require.ErrorAs(t, err, &integrityErr)
+	require.Len(t, integrityErr.Problems, 1)
+	assert.Contains(t, integrityErr.Problems[0], "keystore file declares")
+}
+
+func TestQuarantineWallet(t *testing.T) {
+	ws, repo, w := importIntegrityWallet(t)
+	w.ID, w.Version = 5, 1
+	repo.On("DeleteWallet", 5, 1).Return(nil)
+	original := w.KeyStorePath
+
+	dir, err := ws.QuarantineWallet(w, []string{"tampered"})
+	require.NoError(t, err)
+
+	assert.NoFileExists(t, original)
+	assert.FileExists(t, filepath.Join(dir, filepath.Base(original)))
+	record, err := os.ReadFile(filepath.Join(dir, "wallet.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(record), "tampered")
+	assert.Equal(t, QuarantineDirName, filepath.Base(filepath.Dir(dir)))
+	repo.AssertExpectations(t)
+}
+
+func TestQuarantineWallet_RestoresKeystoreWhenRowDeleteFails(t *testing.T) {
+	ws, repo, w := importIntegrityWallet(t)
+	repo.On("DeleteWallet", 0, 0).Return(ErrStaleWallet)
+
+	_, err := ws.QuarantineWallet(w, []string{"tampered"})
+	require.ErrorIs(t, err, ErrStaleWallet)
+
+	assert.FileExists(t, w.KeyStorePath)
+	entries, err := os.ReadDir(filepath.Join(filepath.Dir(filepath.Dir(w.KeyStorePath)), QuarantineDirName))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
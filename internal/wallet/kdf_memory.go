@@ -0,0 +1,163 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+
+	"blocowallet/internal/platform"
+)
+
+// DefaultKDFMemoryLimit is the KDF memory budget used when the physical
+// memory of the machine cannot be read
+const DefaultKDFMemoryLimit = 512 << 20
+
+// KDFMemoryLimit returns the memory budget for concurrent key derivations:
+// limitMB when positive, otherwise half of the physical memory
+func KDFMemoryLimit(limitMB int) uint64 {
+	if limitMB > 0 {
+		return uint64(limitMB) << 20
+	}
+	total, err := platform.TotalMemory()
+	if err != nil || total == 0 {
+		return DefaultKDFMemoryLimit
+	}
+	return total / 2
+}
+
+// KDFMemory estimates the memory a key derivation allocates. scrypt needs
+// 128·N·r bytes for its table plus 128·r·p for its blocks; Argon2 the memory
+// parameter, in KiB; PBKDF2 next to nothing.
+func KDFMemory(kdf string, params map[string]any) uint64 {
+	param := func(name string) uint64 {
+		if v, ok := params[name].(float64); ok && v > 0 {
+			return uint64(v)
+		}
+		return 0
+	}
+	switch strings.ToLower(kdf) {
+	case "scrypt":
+		n, r, p := param("n"), param("r"), param("p")
+		if r == 0 {
+			r = 8
+		}
+		if p == 0 {
+			p = 1
+		}
+		return 128*n*r + 128*r*p
+	case "argon2", "argon2id", "argon2i":
+		return param("memory") << 10
+	default:
+		return 0
+	}
+}
+
+// KeystoreKDFMemory estimates the memory needed to decrypt the keystore at
+// path. Unreadable files are estimated as the standard geth scrypt setting,
+// which is what most keystores use.
+func KeystoreKDFMemory(path string) uint64 {
+	standard := KDFMemory("scrypt", map[string]any{"n": float64(1 << 18), "r": float64(8), "p": float64(1)})
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return standard
+	}
+	var file struct {
+		Crypto      *KeystoreV3Crypto `json:"crypto"`
+		CryptoUpper *KeystoreV3Crypto `json:"Crypto"`
+	}
+	if json.Unmarshal(data, &file) != nil {
+		return standard
+	}
+	crypto := file.Crypto
+	if crypto == nil {
+		crypto = file.CryptoUpper
+	}
+	if crypto == nil {
+		return standard
+	}
+	params, _ := crypto.KDFParams.(map[string]any)
+	return KDFMemory(crypto.KDF, params)
+}
+
+// MemoryGuard keeps the memory of concurrent key derivations under a limit.
+// Each derivation reserves its estimated memory before it starts and waits
+// while the reservations would exceed the limit, so parallel work is queued
+// instead of running the machine out of memory. A derivation larger than the
+// whole limit still runs, alone.
+type MemoryGuard struct {
+	mu      sync.Mutex
+	limit   uint64
+	inUse   uint64
+	running int
+	waiting int
+	freed   chan struct{} // Closed and replaced whenever memory is released
+}
+
+// NewMemoryGuard returns a guard allowing limit bytes of concurrent derivations
+func NewMemoryGuard(limit uint64) *MemoryGuard {
+	return &MemoryGuard{limit: limit, freed: make(chan struct{})}
+}
+
+// Acquire reserves need bytes, waiting until they fit or ctx is done. The
+// returned function releases the reservation; calling it again does nothing.
+func (g *MemoryGuard) Acquire(ctx context.Context, need uint64) (func(), error) {
+	for {
+		g.mu.Lock()
+		if g.running == 0 || g.inUse+need <= g.limit {
+			g.inUse += need
+			g.running++
+			g.mu.Unlock()
+			return sync.OnceFunc(func() { g.release(need) }), nil
+		}
+		g.waiting++
+		freed := g.freed
+		g.mu.Unlock()
+
+		select {
+		case <-freed:
+			g.mu.Lock()
+			g.waiting--
+			g.mu.Unlock()
+		case <-ctx.Done():
+			g.mu.Lock()
+			g.waiting--
+			g.mu.Unlock()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// SetLimit changes the limit; derivations waiting for memory check it again
+func (g *MemoryGuard) SetLimit(limit uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.limit = limit
+	close(g.freed)
+	g.freed = make(chan struct{})
+}
+
+func (g *MemoryGuard) release(need uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.inUse -= need
+	g.running--
+	close(g.freed)
+	g.freed = make(chan struct{})
+}
+
+// MemoryGuardStats is a snapshot of a guard
+type MemoryGuardStats struct {
+	Limit   uint64 // Bytes allowed
+	InUse   uint64 // Bytes reserved by running derivations
+	Running int    // Derivations holding a reservation
+	Waiting int    // Derivations queued for memory
+}
+
+// Stats returns the current reservations of the guard
+func (g *MemoryGuard) Stats() MemoryGuardStats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return MemoryGuardStats{Limit: g.limit, InUse: g.inUse, Running: g.running, Waiting: g.waiting}
+}
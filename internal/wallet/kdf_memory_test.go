@@ -0,0 +1,94 @@
+package wallet
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKDFMemory(t *testing.T) {
+	// The standard geth setting needs 256 MiB
+	assert.Equal(t, uint64(256<<20+1024), KDFMemory("scrypt", map[string]any{"n": float64(1 << 18), "r": float64(8), "p": float64(1)}))
+	assert.Equal(t, uint64(64<<20), KDFMemory("argon2id", map[string]any{"memory": float64(64 << 10)}))
+	assert.Zero(t, KDFMemory("pbkdf2", map[string]any{"c": float64(262144)}))
+	// An invalid N only counts the blocks
+	assert.Equal(t, uint64(1024), KDFMemory("scrypt", map[string]any{"n": float64(-1)}))
+}
+
+func TestKeystoreKDFMemory(t *testing.T) {
+	assert.Equal(t, uint64(128*4096*8+128*8*6), KeystoreKDFMemory(filepath.Join("testdata", "keystores", "invalid_mac.json")))
+
+	// Unreadable files are estimated as a standard keystore
+	standard := KDFMemory("scrypt", map[string]any{"n": float64(1 << 18)})
+	assert.Equal(t, standard, KeystoreKDFMemory(filepath.Join(t.TempDir(), "missing.json")))
+
+	upper := filepath.Join(t.TempDir(), "upper.json")
+	require.NoError(t, os.WriteFile(upper, []byte(`{"Crypto":{"kdf":"pbkdf2","kdfparams":{"c":262144}}}`), 0600))
+	assert.Zero(t, KeystoreKDFMemory(upper))
+}
+
+func TestMemoryGuard_QueuesWorkOverTheLimit(t *testing.T) {
+	guard := NewMemoryGuard(100)
+	releaseFirst, err := guard.Acquire(context.Background(), 60)
+	require.NoError(t, err)
+
+	acquired := make(chan func())
+	go func() {
+		release, _ := guard.Acquire(context.Background(), 60)
+		acquired <- release
+	}()
+	require.Eventually(t, func() bool { return guard.Stats().Waiting == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, MemoryGuardStats{Limit: 100, InUse: 60, Running: 1, Waiting: 1}, guard.Stats())
+
+	releaseFirst()
+	releaseFirst() // Releasing twice does not free the memory twice
+	releaseSecond := <-acquired
+	assert.Equal(t, MemoryGuardStats{Limit: 100, InUse: 60, Running: 1}, guard.Stats())
+	releaseSecond()
+	assert.Equal(t, MemoryGuardStats{Limit: 100}, guard.Stats())
+}
+
+func TestMemoryGuard_OversizedWorkRunsAlone(t *testing.T) {
+	guard := NewMemoryGuard(100)
+	release, err := guard.Acquire(context.Background(), 500)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = guard.Acquire(ctx, 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Zero(t, guard.Stats().Waiting)
+
+	release()
+	release, err = guard.Acquire(context.Background(), 1)
+	require.NoError(t, err)
+	release()
+}
+
+func TestMemoryGuard_SetLimitWakesWaiting(t *testing.T) {
+	guard := NewMemoryGuard(100)
+	release, err := guard.Acquire(context.Background(), 60)
+	require.NoError(t, err)
+	defer release()
+
+	acquired := make(chan func())
+	go func() {
+		release, _ := guard.Acquire(context.Background(), 60)
+		acquired <- release
+	}()
+	require.Eventually(t, func() bool { return guard.Stats().Waiting == 1 }, time.Second, time.Millisecond)
+
+	guard.SetLimit(200)
+	(<-acquired)()
+	assert.Equal(t, uint64(200), guard.Stats().Limit)
+}
+
+func TestKDFMemoryLimit(t *testing.T) {
+	assert.Equal(t, uint64(768<<20), KDFMemoryLimit(768))
+	assert.NotZero(t, KDFMemoryLimit(0))
+}
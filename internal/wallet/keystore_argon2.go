@@ -0,0 +1,162 @@
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/argon2"
+)
+
+// Key derivation functions new keystores can be written with
+const (
+	KDFScrypt   = "scrypt"
+	KDFArgon2id = "argon2id"
+)
+
+// Argon2idParams are the cost parameters of Argon2id keystores. Memory is in
+// KiB, as in the [security] section of the configuration.
+type Argon2idParams struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+}
+
+// argon2idDKLen is the derived key length of Argon2id keystores: the v3 format
+// takes the AES key from the first 16 bytes and the MAC key from the next 16
+const argon2idDKLen = 32
+
+// EncryptKeyArgon2id writes a keystore v3 file for the key with aes-128-ctr
+// and the MAC geth uses, deriving the key with Argon2id instead of scrypt.
+// geth cannot read these files; this application reads them through the
+// Argon2idHandler of UniversalKDFService.
+func EncryptKeyArgon2id(key *keystore.Key, password string, params Argon2idParams) ([]byte, error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	derivedKey := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, argon2idDKLen)
+	defer zeroBytes(derivedKey)
+
+	block, err := aes.NewCipher(derivedKey[:16])
+	if err != nil {
+		return nil, err
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+	privateKey := crypto.FromECDSA(key.PrivateKey)
+	defer zeroBytes(privateKey)
+	cipherText := make([]byte, len(privateKey))
+	cipher.NewCTR(block, iv).XORKeyStream(cipherText, privateKey)
+
+	return json.Marshal(KeystoreV3{
+		Version: 3,
+		ID:      key.Id.String(),
+		Address: hex.EncodeToString(key.Address[:]),
+		Crypto: KeystoreV3Crypto{
+			Cipher:       CipherAES128CTR,
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: KeystoreV3CipherParams{IV: hex.EncodeToString(iv)},
+			KDF:          KDFArgon2id,
+			KDFParams: map[string]interface{}{
+				"time":    params.Time,
+				"memory":  params.Memory,
+				"threads": params.Threads,
+				"dklen":   argon2idDKLen,
+				"salt":    hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(crypto.Keccak256(derivedKey[16:32], cipherText)),
+		},
+	})
+}
+
+// validate applies the limits the Argon2idHandler enforces when reading, so
+// no file is written that could not be opened again
+func (p Argon2idParams) validate() error {
+	return (&Argon2idHandler{}).ValidateParams(map[string]interface{}{
+		"time":    int(p.Time),
+		"memory":  int(p.Memory),
+		"threads": int(p.Threads),
+		"salt":    "00",
+	})
+}
+
+// Argon2idHandler derives keystore keys with Argon2id. The parameters are
+// time (iterations), memory (KiB) and threads, with the names other tools use
+// accepted as well.
+type Argon2idHandler struct {
+	// Parameter parsing is the same as scrypt's
+	params ScryptHandler
+}
+
+func (ah *Argon2idHandler) DeriveKey(password string, params map[string]interface{}) ([]byte, error) {
+	salt, err := ah.params.getSaltParam(params)
+	if err != nil {
+		return nil, err
+	}
+	passes, memory, threads, dklen := ah.costParams(params)
+	return argon2.IDKey([]byte(password), salt, uint32(passes), uint32(memory), uint8(threads), uint32(dklen)), nil
+}
+
+func (ah *Argon2idHandler) costParams(params map[string]interface{}) (passes, memory, threads, dklen int) {
+	passes = ah.params.getIntParam(params, []string{"time", "t", "iterations"}, 1)
+	memory = ah.params.getIntParam(params, []string{"memory", "m", "memoryKiB"}, 64*1024)
+	threads = ah.params.getIntParam(params, []string{"threads", "parallelism", "p"}, 4)
+	dklen = ah.params.getIntParam(params, []string{"dklen", "dkLen", "keylen", "length"}, argon2idDKLen)
+	return passes, memory, threads, dklen
+}
+
+func (ah *Argon2idHandler) ValidateParams(params map[string]interface{}) error {
+	passes, memory, threads, dklen := ah.costParams(params)
+	if passes < 1 || passes > 100 {
+		return fmt.Errorf("argon2id time %d out of range 1-100", passes)
+	}
+	if threads < 1 || threads > 255 {
+		return fmt.Errorf("argon2id threads %d out of range 1-255", threads)
+	}
+	// Argon2 needs 8 KiB per thread; 4 GiB bounds what a file can make us allocate
+	if memory < 8*threads || memory > 4*1024*1024 {
+		return fmt.Errorf("argon2id memory %d KiB out of range %d-%d", memory, 8*threads, 4*1024*1024)
+	}
+	if dklen < 16 || dklen > 128 {
+		return fmt.Errorf("argon2id dklen %d out of range 16-128", dklen)
+	}
+	if _, err := ah.params.getSaltParam(params); err != nil {
+		return fmt.Errorf("invalid salt: %w", err)
+	}
+	return nil
+}
+
+func (ah *Argon2idHandler) GetDefaultParams() map[string]interface{} {
+	return map[string]interface{}{
+		"time":    1,
+		"memory":  64 * 1024,
+		"threads": 4,
+		"dklen":   argon2idDKLen,
+	}
+}
+
+func (ah *Argon2idHandler) GetParamRange(param string) (min, max interface{}) {
+	ranges := map[string][2]int{
+		"time":    {1, 100},
+		"memory":  {8, 4 * 1024 * 1024},
+		"threads": {1, 255},
+		"dklen":   {16, 128},
+	}
+
+	if r, exists := ranges[param]; exists {
+		return r[0], r[1]
+	}
+	return nil, nil
+}
@@ -0,0 +1,82 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Cheap Argon2id costs for tests
+var testArgon2idParams = Argon2idParams{Time: 1, Memory: 64, Threads: 1}
+
+func TestEncryptKeyArgon2id_RoundTrip(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key := &keystore.Key{Id: uuid.New(), Address: crypto.PubkeyToAddress(privateKey.PublicKey), PrivateKey: privateKey}
+
+	keyJSON, err := EncryptKeyArgon2id(key, "secret", testArgon2idParams)
+	require.NoError(t, err)
+
+	var file KeystoreV3
+	require.NoError(t, json.Unmarshal(keyJSON, &file))
+	assert.Equal(t, KDFArgon2id, file.Crypto.KDF)
+	assert.Equal(t, CipherAES128CTR, file.Crypto.Cipher)
+	_, err = (&KeystoreValidator{}).ValidateKeystoreV3(keyJSON)
+	assert.NoError(t, err, "imports accept the file")
+	_, err = keystore.DecryptKey(keyJSON, "secret")
+	assert.Error(t, err, "geth has no Argon2id")
+
+	decrypted, used, err := decryptKeystoreJSON(keyJSON, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", used)
+	assert.Equal(t, key.Address, decrypted.Address)
+	assert.Equal(t, crypto.FromECDSA(privateKey), crypto.FromECDSA(decrypted.PrivateKey))
+
+	_, _, err = decryptKeystoreJSON(keyJSON, "wrong")
+	assert.ErrorIs(t, err, keystore.ErrDecrypt)
+
+	// The memory guard budgets the derivation from the file
+	params, _ := file.Crypto.KDFParams.(map[string]any)
+	assert.Equal(t, uint64(64<<10), KDFMemory(file.Crypto.KDF, params))
+}
+
+func TestEncryptKeyArgon2id_RejectsParamsItCannotRead(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key := &keystore.Key{Id: uuid.New(), Address: crypto.PubkeyToAddress(privateKey.PublicKey), PrivateKey: privateKey}
+
+	_, err = EncryptKeyArgon2id(key, "secret", Argon2idParams{Time: 0, Memory: 64, Threads: 1})
+	assert.Error(t, err)
+	_, err = EncryptKeyArgon2id(key, "secret", Argon2idParams{Time: 1, Memory: 8, Threads: 4})
+	assert.Error(t, err, "Argon2 needs 8 KiB per thread")
+}
+
+func TestImportKey_Argon2idProfile(t *testing.T) {
+	params := testArgon2idParams
+	ws := &WalletService{KeystoreDir: t.TempDir(), KeystoreArgon2id: &params}
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	op := &operation{}
+	path, address, err := ws.importKey(op, privateKey, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(privateKey.PublicKey).Hex(), address)
+	keyJSON, err := os.ReadFile(path)
+	require.NoError(t, err)
+	key, _, err := decryptKeystoreJSON(keyJSON, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, address, key.Address.Hex())
+
+	// The same key is not written twice, and a rollback removes the file
+	_, _, err = ws.importKey(&operation{}, privateKey, "secret")
+	assert.ErrorIs(t, err, keystore.ErrAccountAlreadyExists)
+	_ = op.rollback(errors.New("later step failed"))
+	assert.NoFileExists(t, path)
+}
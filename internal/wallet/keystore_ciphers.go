@@ -0,0 +1,231 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Ciphers accepted in keystore files. geth only reads and writes aes-128-ctr;
+// the others are emitted by tools that deviate from the v3 format.
+const (
+	CipherAES128CTR = "aes-128-ctr"
+	CipherAES128CBC = "aes-128-cbc"
+	CipherAES256CTR = "aes-256-ctr"
+	CipherAES128GCM = "aes-128-gcm"
+	CipherAES256GCM = "aes-256-gcm"
+)
+
+// cipherKeySizes maps each supported cipher to its AES key size. The AES key
+// is taken from the start of the derived key, while the MAC keeps using bytes
+// 16 to 32 as the v3 format defines, so AES-256 keys include the MAC key.
+var cipherKeySizes = map[string]int{
+	CipherAES128CTR: 16,
+	CipherAES128CBC: 16,
+	CipherAES256CTR: 32,
+	CipherAES128GCM: 16,
+	CipherAES256GCM: 32,
+}
+
+// isSupportedCipher reports whether decryptPrivateKey handles the cipher
+func isSupportedCipher(name string) bool {
+	_, ok := cipherKeySizes[name]
+	return ok
+}
+
+// aesKey returns the part of the derived key used by the cipher
+func aesKey(derivedKey []byte, cipherName string) ([]byte, error) {
+	size, ok := cipherKeySizes[cipherName]
+	if !ok {
+		return nil, fmt.Errorf("unsupported cipher: %s", cipherName)
+	}
+	if len(derivedKey) < size {
+		return nil, fmt.Errorf("%s needs a %d byte key, the KDF derived %d bytes", cipherName, size, len(derivedKey))
+	}
+	return derivedKey[:size], nil
+}
+
+// cipherIV decodes the IV (the nonce for GCM) of the cipher parameters
+func cipherIV(cryptoParams *CryptoParams) ([]byte, error) {
+	ivStr, ok := cryptoParams.CipherParams["iv"].(string)
+	if !ok || ivStr == "" {
+		return nil, errors.New("missing IV in the cipher parameters")
+	}
+	iv, err := hex.DecodeString(ivStr)
+	if err != nil {
+		return nil, fmt.Errorf("decoding IV: %w", err)
+	}
+	return iv, nil
+}
+
+// decryptAESGCM decrypts GCM ciphertexts, which carry the authentication tag
+// at the end. The keystore MAC is still checked before this runs.
+func (eks *EnhancedKeyStoreService) decryptAESGCM(derivedKey []byte, cryptoParams *CryptoParams) ([]byte, error) {
+	key, err := aesKey(derivedKey, cryptoParams.Cipher)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := cipherIV(cryptoParams)
+	if err != nil {
+		return nil, err
+	}
+	cipherText, err := hex.DecodeString(cryptoParams.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("decoding ciphertext: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating AES cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(nonce))
+	if err != nil {
+		return nil, err
+	}
+	return gcm.Open(nil, nonce, cipherText, nil)
+}
+
+// DecryptKeyJSON decrypts keystores that keystore.DecryptKey rejects because
+// of their cipher or KDF variant. Like decryptKeystoreJSON it returns the
+// password candidate that worked, and keystore.ErrDecrypt for a wrong password.
+func (eks *EnhancedKeyStoreService) DecryptKeyJSON(keyJSON []byte, password string) (*keystore.Key, string, error) {
+	var keystoreData KeystoreV3
+	if err := json.Unmarshal(keyJSON, &keystoreData); err != nil {
+		return nil, "", err
+	}
+	kdfParams, ok := keystoreData.Crypto.KDFParams.(map[string]interface{})
+	if !ok {
+		return nil, "", errors.New("invalid KDF parameters in keystore")
+	}
+	cryptoParams := &CryptoParams{
+		KDF:          keystoreData.Crypto.KDF,
+		KDFParams:    kdfParams,
+		Cipher:       keystoreData.Crypto.Cipher,
+		CipherText:   keystoreData.Crypto.CipherText,
+		CipherParams: map[string]interface{}{"iv": keystoreData.Crypto.CipherParams.IV},
+		MAC:          keystoreData.Crypto.MAC,
+	}
+
+	for _, candidate := range PasswordCandidates(password) {
+		derivedKey, err := eks.kdfService.DeriveKey(candidate, cryptoParams)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(derivedKey) < 32 {
+			return nil, "", fmt.Errorf("the KDF derived %d bytes, at least 32 are needed", len(derivedKey))
+		}
+		if eks.verifyMAC(derivedKey, cryptoParams) != nil {
+			zeroBytes(derivedKey)
+			continue
+		}
+
+		privateKeyBytes, err := eks.decryptPrivateKey(derivedKey, cryptoParams)
+		zeroBytes(derivedKey)
+		if err != nil {
+			return nil, "", err
+		}
+		privateKey, err := crypto.ToECDSA(privateKeyBytes)
+		zeroBytes(privateKeyBytes)
+		if err != nil {
+			return nil, "", err
+		}
+		id, _ := uuid.Parse(keystoreData.ID)
+		return &keystore.Key{
+			Id:         id,
+			Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+			PrivateKey: privateKey,
+		}, candidate, nil
+	}
+	return nil, "", keystore.ErrDecrypt
+}
+
+// EncryptKeyWithCipher writes a keystore v3 file for the key using scrypt and
+// the given cipher. aes-128-ctr produces exactly what geth writes; the other
+// ciphers are for tools that expect them and can only be read back by this
+// application and those tools.
+func EncryptKeyWithCipher(key *keystore.Key, password, cipherName string, scryptN, scryptP int) ([]byte, error) {
+	if cipherName == CipherAES128CTR {
+		return keystore.EncryptKey(key, password, scryptN, scryptP)
+	}
+	if !isSupportedCipher(cipherName) {
+		return nil, fmt.Errorf("unsupported cipher: %s", cipherName)
+	}
+
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	const scryptR, scryptDKLen = 8, 32
+	derivedKey, err := scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroBytes(derivedKey)
+
+	block, err := aes.NewCipher(derivedKey[:cipherKeySizes[cipherName]])
+	if err != nil {
+		return nil, err
+	}
+	privateKey := crypto.FromECDSA(key.PrivateKey)
+	defer zeroBytes(privateKey)
+
+	var iv, cipherText []byte
+	switch cipherName {
+	case CipherAES128GCM, CipherAES256GCM:
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		iv = make([]byte, gcm.NonceSize())
+		if _, err := rand.Read(iv); err != nil {
+			return nil, err
+		}
+		cipherText = gcm.Seal(nil, iv, privateKey, nil)
+	case CipherAES128CBC:
+		iv = make([]byte, aes.BlockSize)
+		if _, err := rand.Read(iv); err != nil {
+			return nil, err
+		}
+		padding := aes.BlockSize - len(privateKey)%aes.BlockSize
+		padded := append(append([]byte{}, privateKey...), bytes.Repeat([]byte{byte(padding)}, padding)...)
+		defer zeroBytes(padded)
+		cipherText = make([]byte, len(padded))
+		cipher.NewCBCEncrypter(block, iv).CryptBlocks(cipherText, padded)
+	default:
+		iv = make([]byte, aes.BlockSize)
+		if _, err := rand.Read(iv); err != nil {
+			return nil, err
+		}
+		cipherText = make([]byte, len(privateKey))
+		cipher.NewCTR(block, iv).XORKeyStream(cipherText, privateKey)
+	}
+
+	return json.Marshal(KeystoreV3{
+		Version: 3,
+		ID:      key.Id.String(),
+		Address: hex.EncodeToString(key.Address[:]),
+		Crypto: KeystoreV3Crypto{
+			Cipher:       cipherName,
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: KeystoreV3CipherParams{IV: hex.EncodeToString(iv)},
+			KDF:          "scrypt",
+			KDFParams: map[string]interface{}{
+				"n":     scryptN,
+				"r":     scryptR,
+				"p":     scryptP,
+				"dklen": scryptDKLen,
+				"salt":  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(crypto.Keccak256(derivedKey[16:32], cipherText)),
+		},
+	})
+}
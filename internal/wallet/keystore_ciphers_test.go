@@ -0,0 +1,91 @@
+package wallet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Address of the key in the fixtures written by testdata/generate_cipher_keystores.go
+const cipherFixtureAddress = "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"
+
+func TestDecryptKeyJSON_CipherFixtures(t *testing.T) {
+	eks := NewEnhancedKeyStoreService()
+	for _, file := range []string{
+		"real_keystore_v3_aes256_ctr.json",
+		"real_keystore_v3_aes128_gcm.json",
+		"real_keystore_v3_aes256_gcm.json",
+	} {
+		t.Run(file, func(t *testing.T) {
+			keyJSON, err := os.ReadFile(filepath.Join("testdata", "keystores", file))
+			require.NoError(t, err)
+
+			_, err = keystore.DecryptKey(keyJSON, "testpassword")
+			require.Error(t, err, "geth rejects the cipher")
+
+			key, used, err := eks.DecryptKeyJSON(keyJSON, "testpassword")
+			require.NoError(t, err)
+			assert.Equal(t, "testpassword", used)
+			assert.Equal(t, common.HexToAddress(cipherFixtureAddress), key.Address)
+
+			_, _, err = eks.DecryptKeyJSON(keyJSON, "wrong")
+			assert.ErrorIs(t, err, keystore.ErrDecrypt)
+		})
+	}
+}
+
+func TestDecryptKeyJSON_RelabelledCipherFails(t *testing.T) {
+	// The ciphertext is aes-128-ctr but the file claims aes-256-gcm
+	keyJSON, err := os.ReadFile(filepath.Join("testdata", "keystores", "non_standard_cipher.json"))
+	require.NoError(t, err)
+
+	_, _, err = NewEnhancedKeyStoreService().DecryptKeyJSON(keyJSON, "testpassword")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, keystore.ErrDecrypt)
+}
+
+func TestLoadWallet_NonStandardCipher(t *testing.T) {
+	ws := &WalletService{}
+	w := &Wallet{
+		Address:      cipherFixtureAddress,
+		KeyStorePath: filepath.Join("testdata", "keystores", "real_keystore_v3_aes256_gcm.json"),
+		ImportMethod: string(ImportMethodKeystore),
+	}
+
+	details, err := ws.LoadWallet(w, "testpassword")
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress(cipherFixtureAddress), crypto.PubkeyToAddress(details.PrivateKey.PublicKey))
+
+	_, err = ws.LoadWallet(w, "wrong")
+	assert.Error(t, err)
+}
+
+func TestEncryptKeyWithCipher_RoundTrip(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key := &keystore.Key{Id: uuid.New(), Address: crypto.PubkeyToAddress(privateKey.PublicKey), PrivateKey: privateKey}
+	n, p := GetTestKeystoreParams()
+
+	for _, cipherName := range []string{CipherAES128CTR, CipherAES128CBC, CipherAES256CTR, CipherAES128GCM, CipherAES256GCM} {
+		t.Run(cipherName, func(t *testing.T) {
+			keyJSON, err := EncryptKeyWithCipher(key, "secret", cipherName, n, p)
+			require.NoError(t, err)
+
+			d, _, err := decryptKeystoreJSON(keyJSON, "secret")
+			require.NoError(t, err)
+			assert.Equal(t, key.Address, d.Address)
+			assert.Equal(t, key.Id, d.Id)
+			assert.Equal(t, crypto.FromECDSA(privateKey), crypto.FromECDSA(d.PrivateKey))
+		})
+	}
+
+	_, err = EncryptKeyWithCipher(key, "secret", "des-ede3-cbc", n, p)
+	assert.Error(t, err)
+}
@@ -0,0 +1,342 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Verdicts of a keystore diagnosis
+const (
+	VerdictCompatible    = "compatible"
+	VerdictNeedsPassword = "needs_password"
+	VerdictWrongPassword = "wrong_password_or_corrupted"
+	VerdictIncompatible  = "incompatible"
+)
+
+// MAC check results of a keystore diagnosis
+const (
+	MACNotChecked = "not_checked"
+	MACValid      = "valid"
+	MACInvalid    = "invalid"
+	MACError      = "error"
+)
+
+// KDFParamCheck compares a KDF parameter with the range the KDF handler accepts
+type KDFParamCheck struct {
+	Name    string `json:"name"`
+	Value   int    `json:"value"`
+	Default bool   `json:"default"`
+	Min     int    `json:"min"`
+	Max     int    `json:"max"`
+	InRange bool   `json:"in_range"`
+}
+
+// KeystoreDiagnosis explains whether a keystore file can be imported and why
+// not. It never contains the derived key or the private key.
+type KeystoreDiagnosis struct {
+	File            string          `json:"file"`
+	Version         int             `json:"version"`
+	Address         string          `json:"address,omitempty"`
+	KDF             string          `json:"kdf,omitempty"`
+	NormalizedKDF   string          `json:"normalized_kdf,omitempty"`
+	KDFSupported    bool            `json:"kdf_supported"`
+	Parameters      []KDFParamCheck `json:"parameters,omitempty"`
+	SecurityLevel   string          `json:"security_level,omitempty"`
+	Cipher          string          `json:"cipher,omitempty"`
+	CipherSupported bool            `json:"cipher_supported"`
+	MAC             string          `json:"mac"`
+	AddressMatches  *bool           `json:"address_matches,omitempty"`
+	Verdict         string          `json:"verdict"`
+	Problems        []string        `json:"problems,omitempty"`
+	Guidance        []string        `json:"guidance,omitempty"`
+}
+
+// kdfParamNames lists, per KDF, the parameters that have a supported range and
+// the aliases the handlers accept for them
+var kdfParamNames = map[string][]struct {
+	name    string
+	aliases []string
+}{
+	"scrypt": {
+		{"n", []string{"n", "N", "cost"}},
+		{"r", []string{"r", "R", "blocksize"}},
+		{"p", []string{"p", "P", "parallel"}},
+		{"dklen", []string{"dklen", "dkLen", "keylen", "length"}},
+	},
+	"pbkdf2": {
+		{"c", []string{"c", "iter", "iterations", "rounds"}},
+		{"dklen", []string{"dklen", "dkLen", "keylen", "length"}},
+	},
+}
+
+// DiagnoseKeystore inspects a keystore file and reports its KDF, parameters,
+// cipher and, when a password is given, whether the MAC matches. The error is
+// only set when the file cannot be read; everything else ends up in the report.
+func (eks *EnhancedKeyStoreService) DiagnoseKeystore(filePath, password string) (*KeystoreDiagnosis, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	d := &KeystoreDiagnosis{File: filePath, MAC: MACNotChecked}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		d.problem("the file is not valid JSON: "+err.Error(), "Make sure you selected the keystore file itself and not a backup archive or a password file.")
+		return d.finish(), nil
+	}
+	var keystore KeystoreV3
+	if err := json.Unmarshal(data, &keystore); err != nil {
+		d.problem("the file does not have the keystore v3 layout: "+err.Error(), "Only Ethereum keystore v3 files (geth, MyEtherWallet, MyCrypto) can be imported.")
+		return d.finish(), nil
+	}
+	d.Version = keystore.Version
+	d.Address = keystore.Address
+	if keystore.Version != 3 {
+		d.problem(fmt.Sprintf("unsupported keystore version %d", keystore.Version), "Export the wallet again as a keystore v3 file from the application that created it.")
+	}
+
+	ksCrypto := keystore.Crypto
+	d.KDF = ksCrypto.KDF
+	d.Cipher = ksCrypto.Cipher
+	d.CipherSupported = isSupportedCipher(ksCrypto.Cipher)
+	if !d.CipherSupported {
+		d.problem(fmt.Sprintf("unsupported cipher %q", ksCrypto.Cipher), "Re-encrypt the key with aes-128-ctr, the cipher used by geth and most wallets; aes-128-cbc, aes-256-ctr and aes-128/256-gcm are also read.")
+	}
+
+	kdfParams, _ := ksCrypto.KDFParams.(map[string]interface{})
+	d.checkKDF(eks.kdfService, kdfParams)
+
+	if ksCrypto.MAC == "" || ksCrypto.CipherText == "" {
+		d.problem("the crypto section is missing the MAC or the ciphertext", "The file is truncated or was edited by hand; restore it from a backup.")
+	}
+	if len(d.Problems) > 0 {
+		return d.finish(), nil
+	}
+	if password == "" {
+		return d.finish(), nil
+	}
+
+	cryptoParams := &CryptoParams{
+		KDF:          ksCrypto.KDF,
+		KDFParams:    kdfParams,
+		Cipher:       ksCrypto.Cipher,
+		CipherText:   ksCrypto.CipherText,
+		CipherParams: map[string]interface{}{"iv": ksCrypto.CipherParams.IV},
+		MAC:          strings.ToLower(ksCrypto.MAC),
+	}
+	d.checkMAC(eks, cryptoParams, password)
+	return d.finish(), nil
+}
+
+// checkKDF records whether the KDF is supported and how its parameters compare
+// with the ranges of its handler
+func (d *KeystoreDiagnosis) checkKDF(service *UniversalKDFService, params map[string]interface{}) {
+	normalized := service.normalizeKDFName(d.KDF)
+	handler, ok := service.supportedKDFs[normalized]
+	if !ok {
+		d.problem(fmt.Sprintf("unsupported KDF %q", d.KDF), "Supported KDFs are scrypt and pbkdf2 (hmac-sha256 or hmac-sha512).")
+		return
+	}
+	d.KDFSupported = true
+	d.NormalizedKDF = normalized
+	if params == nil {
+		d.problem("the KDF parameters are missing", "The file is truncated or was edited by hand; restore it from a backup.")
+		return
+	}
+
+	family := normalized
+	if strings.HasPrefix(family, "pbkdf2") {
+		family = "pbkdf2"
+	}
+	defaults := handler.GetDefaultParams()
+	for _, p := range kdfParamNames[family] {
+		check := KDFParamCheck{Name: p.name}
+		value, found := intParam(params, p.aliases)
+		if !found {
+			value, _ = intParam(defaults, []string{p.name})
+			check.Default = true
+		}
+		check.Value = value
+		min, max := handler.GetParamRange(p.name)
+		check.Min, _ = min.(int)
+		check.Max, _ = max.(int)
+		check.InRange = value >= check.Min && value <= check.Max
+		d.Parameters = append(d.Parameters, check)
+		if !check.InRange {
+			d.problem(fmt.Sprintf("%s parameter %s=%d is outside the supported range %d-%d", family, p.name, value, check.Min, check.Max),
+				"Re-encrypt the key with standard parameters in the wallet that created it, then import the new file.")
+		}
+	}
+
+	if err := handler.ValidateParams(params); err != nil {
+		// Out of range values were already reported above
+		if !d.hasParamOutOfRange() {
+			d.problem("invalid KDF parameters: "+err.Error(), "Check that the salt is hex encoded and that scrypt N is a power of two.")
+		}
+		return
+	}
+	d.SecurityLevel = NewKDFCompatibilityAnalyzer().analyzeParameterSecurity(family, params).Level
+	if d.SecurityLevel == "Low" {
+		d.Guidance = append(d.Guidance, "The KDF parameters are weak; after importing, export the wallet again to re-encrypt it with stronger settings.")
+	}
+}
+
+// checkMAC derives the key and compares the MAC. The derived key and the
+// decrypted private key are wiped before returning.
+func (d *KeystoreDiagnosis) checkMAC(eks *EnhancedKeyStoreService, params *CryptoParams, password string) {
+	var derivedKey []byte
+	var macErr error
+	for _, candidate := range PasswordCandidates(password) {
+		key, err := eks.kdfService.DeriveKey(candidate, params)
+		if err != nil {
+			d.MAC = MACError
+			d.problem("key derivation failed: "+err.Error(), "Check the KDF parameters above.")
+			return
+		}
+		if len(key) < 32 {
+			d.MAC = MACError
+			d.problem(fmt.Sprintf("the derived key has %d bytes, at least 32 are needed for the MAC", len(key)), "Set dklen to 32 when re-encrypting the key.")
+			return
+		}
+		if macErr = eks.verifyMAC(key, params); macErr == nil {
+			derivedKey = key
+			break
+		}
+		zeroBytes(key)
+	}
+	if macErr != nil {
+		d.MAC = MACInvalid
+		d.problem("the MAC does not match", "Either the password is wrong or the ciphertext was altered. Check keyboard layout, trailing spaces and Unicode characters in the password before assuming corruption.")
+		return
+	}
+	d.MAC = MACValid
+	defer zeroBytes(derivedKey)
+
+	if !d.CipherSupported || d.Address == "" {
+		return
+	}
+	privateKey, err := eks.decryptPrivateKey(derivedKey, params)
+	if err != nil {
+		d.problem("decryption failed after a valid MAC: "+err.Error(), "Check the cipher parameters (IV) of the file.")
+		return
+	}
+	defer zeroBytes(privateKey)
+	key, err := crypto.ToECDSA(privateKey)
+	if err != nil {
+		d.problem("the decrypted data is not a valid private key", "The file was encrypted with a different cipher than the one it declares.")
+		return
+	}
+	matches := crypto.PubkeyToAddress(key.PublicKey) == common.HexToAddress(d.Address)
+	d.AddressMatches = &matches
+	if !matches {
+		d.problem("the decrypted key does not belong to the address in the file", "The address field was edited; the key will be imported under its real address.")
+	}
+}
+
+func (d *KeystoreDiagnosis) problem(problem, guidance string) {
+	d.Problems = append(d.Problems, problem)
+	d.Guidance = append(d.Guidance, guidance)
+}
+
+func (d *KeystoreDiagnosis) hasParamOutOfRange() bool {
+	for _, p := range d.Parameters {
+		if !p.InRange {
+			return true
+		}
+	}
+	return false
+}
+
+// finish sets the verdict from what was found
+func (d *KeystoreDiagnosis) finish() *KeystoreDiagnosis {
+	switch {
+	case d.MAC == MACInvalid:
+		d.Verdict = VerdictWrongPassword
+	case len(d.Problems) > 0:
+		d.Verdict = VerdictIncompatible
+	case d.MAC == MACNotChecked:
+		d.Verdict = VerdictNeedsPassword
+		d.Guidance = append(d.Guidance, "The file looks importable; give the password to check the MAC.")
+	default:
+		d.Verdict = VerdictCompatible
+	}
+	return d
+}
+
+// intParam reads an integer KDF parameter under any of its names
+func intParam(params map[string]interface{}, names []string) (int, bool) {
+	for _, name := range names {
+		switch v := params[name].(type) {
+		case int:
+			return v, true
+		case float64:
+			return int(v), true
+		case string:
+			if i, err := strconv.Atoi(v); err == nil {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// String formats the diagnosis for a terminal
+func (d *KeystoreDiagnosis) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "File:     %s\n", d.File)
+	fmt.Fprintf(&b, "Version:  %d\n", d.Version)
+	if d.Address != "" {
+		fmt.Fprintf(&b, "Address:  %s\n", ChecksumAddress(d.Address))
+	}
+	kdf := d.KDF
+	if d.NormalizedKDF != "" && d.NormalizedKDF != d.KDF {
+		kdf += " (" + d.NormalizedKDF + ")"
+	}
+	fmt.Fprintf(&b, "KDF:      %s, supported: %s\n", kdf, yesNo(d.KDFSupported))
+	for _, p := range d.Parameters {
+		source := ""
+		if p.Default {
+			source = " (default)"
+		}
+		fmt.Fprintf(&b, "  %-6s %d%s, supported %d-%d: %s\n", p.Name, p.Value, source, p.Min, p.Max, okOutOfRange(p.InRange))
+	}
+	if d.SecurityLevel != "" {
+		fmt.Fprintf(&b, "Security: %s\n", d.SecurityLevel)
+	}
+	fmt.Fprintf(&b, "Cipher:   %s, supported: %s\n", d.Cipher, yesNo(d.CipherSupported))
+	fmt.Fprintf(&b, "MAC:      %s\n", d.MAC)
+	if d.AddressMatches != nil {
+		fmt.Fprintf(&b, "Address matches key: %s\n", yesNo(*d.AddressMatches))
+	}
+	fmt.Fprintf(&b, "Verdict:  %s\n", d.Verdict)
+	for _, p := range d.Problems {
+		fmt.Fprintf(&b, "  ✗ %s\n", p)
+	}
+	if len(d.Guidance) > 0 {
+		b.WriteString("What to do:\n")
+		for _, g := range d.Guidance {
+			fmt.Fprintf(&b, "  - %s\n", g)
+		}
+	}
+	return b.String()
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
+
+func okOutOfRange(v bool) string {
+	if v {
+		return "ok"
+	}
+	return "out of range"
+}
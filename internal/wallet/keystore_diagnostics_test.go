@@ -0,0 +1,106 @@
+package wallet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnoseKeystore(t *testing.T) {
+	eks := NewEnhancedKeyStoreService()
+	light := filepath.Join("testdata", "keystores", "real_keystore_v3_light.json")
+
+	t.Run("without password", func(t *testing.T) {
+		d, err := eks.DiagnoseKeystore(light, "")
+		require.NoError(t, err)
+		assert.Equal(t, VerdictNeedsPassword, d.Verdict)
+		assert.Equal(t, MACNotChecked, d.MAC)
+		assert.Equal(t, "scrypt", d.NormalizedKDF)
+		assert.True(t, d.CipherSupported)
+		require.Len(t, d.Parameters, 4)
+		assert.Equal(t, KDFParamCheck{Name: "n", Value: 4096, Min: 1024, Max: 67108864, InRange: true}, d.Parameters[0])
+	})
+
+	t.Run("right password", func(t *testing.T) {
+		d, err := eks.DiagnoseKeystore(light, "testpassword")
+		require.NoError(t, err)
+		assert.Equal(t, VerdictCompatible, d.Verdict)
+		assert.Equal(t, MACValid, d.MAC)
+		require.NotNil(t, d.AddressMatches)
+		assert.True(t, *d.AddressMatches)
+		assert.Empty(t, d.Problems)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		d, err := eks.DiagnoseKeystore(light, "wrong")
+		require.NoError(t, err)
+		assert.Equal(t, VerdictWrongPassword, d.Verdict)
+		assert.Equal(t, MACInvalid, d.MAC)
+		assert.Nil(t, d.AddressMatches)
+	})
+
+	t.Run("unsupported cipher", func(t *testing.T) {
+		path := modifiedKeystore(t, light, func(c map[string]interface{}) { c["cipher"] = "des-ede3-cbc" })
+		d, err := eks.DiagnoseKeystore(path, "testpassword")
+		require.NoError(t, err)
+		assert.Equal(t, VerdictIncompatible, d.Verdict)
+		assert.False(t, d.CipherSupported)
+		assert.Equal(t, MACNotChecked, d.MAC, "the MAC is not checked for files that cannot be imported anyway")
+	})
+
+	t.Run("non-standard cipher", func(t *testing.T) {
+		d, err := eks.DiagnoseKeystore(filepath.Join("testdata", "keystores", "real_keystore_v3_aes256_gcm.json"), "testpassword")
+		require.NoError(t, err)
+		assert.Equal(t, VerdictCompatible, d.Verdict)
+		assert.True(t, d.CipherSupported)
+	})
+
+	t.Run("cipher does not match the ciphertext", func(t *testing.T) {
+		d, err := eks.DiagnoseKeystore(filepath.Join("testdata", "keystores", "non_standard_cipher.json"), "testpassword")
+		require.NoError(t, err)
+		assert.Equal(t, VerdictIncompatible, d.Verdict)
+		assert.Equal(t, MACValid, d.MAC)
+	})
+
+	t.Run("parameter out of range", func(t *testing.T) {
+		path := modifiedKeystore(t, light, func(c map[string]interface{}) {
+			c["kdfparams"].(map[string]interface{})["p"] = 64
+		})
+		d, err := eks.DiagnoseKeystore(path, "testpassword")
+		require.NoError(t, err)
+		assert.Equal(t, VerdictIncompatible, d.Verdict)
+		assert.False(t, d.Parameters[2].InRange)
+		assert.Len(t, d.Problems, 1)
+		assert.Contains(t, d.Problems[0], "p=64")
+	})
+
+	t.Run("not a keystore", func(t *testing.T) {
+		d, err := eks.DiagnoseKeystore(filepath.Join("testdata", "keystores", "invalid_json.json"), "")
+		require.NoError(t, err)
+		assert.Equal(t, VerdictIncompatible, d.Verdict)
+		assert.NotEmpty(t, d.Guidance)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := eks.DiagnoseKeystore(filepath.Join(t.TempDir(), "missing.json"), "")
+		assert.Error(t, err)
+	})
+}
+
+// modifiedKeystore writes a copy of a keystore fixture with its crypto section edited
+func modifiedKeystore(t *testing.T, fixture string, edit func(crypto map[string]interface{})) string {
+	data, err := os.ReadFile(fixture)
+	require.NoError(t, err)
+	var raw map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &raw))
+	edit(raw["crypto"].(map[string]interface{}))
+	data, err = json.Marshal(raw)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "keystore.json")
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
@@ -0,0 +1,181 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// KeystoreChange is one difference between a managed keystore and the file
+// that would replace it. Only the structure is compared: ciphertexts, IVs,
+// salts and MACs are never part of a change.
+type KeystoreChange struct {
+	Field string
+	Old   string
+	New   string
+	// Weaker is set when the new value lowers the cost of guessing the password
+	Weaker bool
+}
+
+// KeystoreOverwriteError is returned by an import that would replace a
+// managed keystore file with different content, e.g. the same key encrypted
+// again. After ApproveKeystoreOverwrite, running the same import replaces it.
+type KeystoreOverwriteError struct {
+	Path    string
+	Address string
+	Changes []KeystoreChange
+
+	replacement [sha256.Size]byte
+}
+
+func (e *KeystoreOverwriteError) Error() string {
+	if e.Weaker() {
+		return fmt.Sprintf("importing would replace the keystore of %s with weaker encryption; confirm to overwrite %s", e.Address, e.Path)
+	}
+	return fmt.Sprintf("importing would replace the keystore of %s; confirm to overwrite %s", e.Address, e.Path)
+}
+
+// Weaker reports whether the replacement lowers the encryption strength
+func (e *KeystoreOverwriteError) Weaker() bool {
+	for _, c := range e.Changes {
+		if c.Weaker {
+			return true
+		}
+	}
+	return false
+}
+
+// ApproveKeystoreOverwrite lets the next import of the same file replace the
+// managed keystore described by e. The approval is used once.
+func (ws *WalletService) ApproveKeystoreOverwrite(e *KeystoreOverwriteError) {
+	ws.overwriteMu.Lock()
+	defer ws.overwriteMu.Unlock()
+	if ws.approvedOverwrites == nil {
+		ws.approvedOverwrites = make(map[string][sha256.Size]byte)
+	}
+	ws.approvedOverwrites[e.Path] = e.replacement
+}
+
+// checkKeystoreOverwrite returns a *KeystoreOverwriteError when destPath holds
+// a keystore other than keyJSON and replacing it was not approved
+func (ws *WalletService) checkKeystoreOverwrite(destPath, sourcePath, address string, keyJSON []byte) error {
+	existing, err := os.ReadFile(destPath)
+	if err != nil || bytes.Equal(existing, keyJSON) {
+		return nil
+	}
+	replacement := sha256.Sum256(keyJSON)
+
+	ws.overwriteMu.Lock()
+	approved, ok := ws.approvedOverwrites[destPath]
+	if ok && approved == replacement {
+		delete(ws.approvedOverwrites, destPath)
+		ws.overwriteMu.Unlock()
+		return nil
+	}
+	ws.overwriteMu.Unlock()
+
+	changes := DiffKeystores(existing, keyJSON)
+	if oldInfo, err := os.Stat(destPath); err == nil {
+		if newInfo, err := os.Stat(sourcePath); err == nil && !oldInfo.ModTime().Equal(newInfo.ModTime()) {
+			changes = append(changes, KeystoreChange{
+				Field: "modified",
+				Old:   oldInfo.ModTime().Format(time.DateTime),
+				New:   newInfo.ModTime().Format(time.DateTime),
+			})
+		}
+	}
+	return &KeystoreOverwriteError{
+		Path:        destPath,
+		Address:     address,
+		Changes:     changes,
+		replacement: replacement,
+	}
+}
+
+// keystoreShape is the part of a keystore that can be shown
+type keystoreShape struct {
+	Version int    `json:"version"`
+	ID      string `json:"id"`
+	Address string `json:"address"`
+	Crypto  struct {
+		Cipher    string                 `json:"cipher"`
+		KDF       string                 `json:"kdf"`
+		KDFParams map[string]interface{} `json:"kdfparams"`
+	} `json:"crypto"`
+}
+
+// securityRanks orders the levels of analyzeParameterSecurity
+var securityRanks = map[string]int{"Low": 1, "Medium": 2, "High": 3, "Very High": 4}
+
+// DiffKeystores lists how the structure of newJSON differs from oldJSON:
+// version, ID, address, cipher, KDF, KDF cost parameters and security level
+func DiffKeystores(oldJSON, newJSON []byte) []KeystoreChange {
+	var oldKS, newKS keystoreShape
+	if json.Unmarshal(oldJSON, &oldKS) != nil || json.Unmarshal(newJSON, &newKS) != nil {
+		return []KeystoreChange{{Field: "content", Old: "unreadable", New: "keystore"}}
+	}
+
+	var changes []KeystoreChange
+	add := func(field, oldValue, newValue string, weaker bool) {
+		if oldValue != newValue {
+			changes = append(changes, KeystoreChange{Field: field, Old: oldValue, New: newValue, Weaker: weaker})
+		}
+	}
+	add("version", strconv.Itoa(oldKS.Version), strconv.Itoa(newKS.Version), false)
+	add("id", oldKS.ID, newKS.ID, false)
+	add("address", shapeAddress(oldKS.Address), shapeAddress(newKS.Address), false)
+	add("cipher", oldKS.Crypto.Cipher, newKS.Crypto.Cipher, false)
+
+	oldFamily, newFamily := kdfFamily(oldKS.Crypto.KDF), kdfFamily(newKS.Crypto.KDF)
+	add("kdf", oldKS.Crypto.KDF, newKS.Crypto.KDF, oldFamily == "scrypt" && newFamily == "pbkdf2")
+
+	seen := make(map[string]bool)
+	for _, family := range []string{oldFamily, newFamily} {
+		for _, p := range kdfParamNames[family] {
+			if seen[p.name] {
+				continue
+			}
+			seen[p.name] = true
+			oldValue, oldFound := intParam(oldKS.Crypto.KDFParams, p.aliases)
+			newValue, newFound := intParam(newKS.Crypto.KDFParams, p.aliases)
+			add("kdfparams."+p.name, paramText(oldValue, oldFound), paramText(newValue, newFound),
+				oldFamily == newFamily && oldFound && newFound && newValue < oldValue)
+		}
+	}
+
+	analyzer := NewKDFCompatibilityAnalyzer()
+	oldLevel := analyzer.analyzeParameterSecurity(oldFamily, oldKS.Crypto.KDFParams).Level
+	newLevel := analyzer.analyzeParameterSecurity(newFamily, newKS.Crypto.KDFParams).Level
+	add("security", oldLevel, newLevel, securityRanks[newLevel] < securityRanks[oldLevel])
+	return changes
+}
+
+// kdfFamily returns scrypt or pbkdf2 for the names the KDF handlers accept
+func kdfFamily(kdf string) string {
+	normalized := NewUniversalKDFService().normalizeKDFName(kdf)
+	if strings.HasPrefix(normalized, "pbkdf2") {
+		return "pbkdf2"
+	}
+	return normalized
+}
+
+func shapeAddress(address string) string {
+	if !common.IsHexAddress(address) {
+		return address
+	}
+	return common.HexToAddress(address).Hex()
+}
+
+func paramText(value int, found bool) string {
+	if !found {
+		return "-"
+	}
+	return strconv.Itoa(value)
+}
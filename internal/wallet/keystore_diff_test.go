@@ -0,0 +1,104 @@
+package wallet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// encryptTestKey writes key encrypted with the given scrypt n into dir
+func encryptTestKey(t *testing.T, dir, name string, key *keystore.Key, n int) string {
+	keyJSON, err := keystore.EncryptKey(key, "secret", n, keystore.LightScryptP)
+	require.NoError(t, err)
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, keyJSON, 0600))
+	return path
+}
+
+func TestDiffKeystores(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key := &keystore.Key{Id: uuid.New(), Address: crypto.PubkeyToAddress(privateKey.PublicKey), PrivateKey: privateKey}
+	dir := t.TempDir()
+	strong, err := os.ReadFile(encryptTestKey(t, dir, "strong.json", key, 1<<12))
+	require.NoError(t, err)
+	weak, err := os.ReadFile(encryptTestKey(t, dir, "weak.json", key, 1<<10))
+	require.NoError(t, err)
+
+	assert.Empty(t, DiffKeystores(strong, strong), "salts and ciphertexts are not compared")
+
+	changes := DiffKeystores(strong, weak)
+	require.Len(t, changes, 1)
+	assert.Equal(t, KeystoreChange{Field: "kdfparams.n", Old: "4096", New: "1024", Weaker: true}, changes[0])
+
+	changes = DiffKeystores(weak, strong)
+	require.Len(t, changes, 1)
+	assert.False(t, changes[0].Weaker, "raising the cost is not a downgrade")
+
+	pbkdf2 := []byte(`{"version":3,"id":"` + key.Id.String() + `","address":"` + key.Address.Hex()[2:] + `",
+		"crypto":{"cipher":"aes-128-ctr","kdf":"pbkdf2","kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256"}}}`)
+	changes = DiffKeystores(strong, pbkdf2)
+	fields := make(map[string]KeystoreChange)
+	for _, c := range changes {
+		fields[c.Field] = c
+	}
+	assert.True(t, fields["kdf"].Weaker, "scrypt to pbkdf2 is a downgrade")
+	assert.Equal(t, "-", fields["kdfparams.n"].New)
+	assert.Equal(t, "262144", fields["kdfparams.c"].New)
+
+	assert.Equal(t, "content", DiffKeystores([]byte("{"), strong)[0].Field)
+}
+
+func TestImportWalletFromKeystoreV3_Overwrite(t *testing.T) {
+	InitCryptoService(CreateMockConfig())
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key := &keystore.Key{Id: uuid.New(), Address: crypto.PubkeyToAddress(privateKey.PublicKey), PrivateKey: privateKey}
+	sourceDir := t.TempDir()
+	strongPath := encryptTestKey(t, sourceDir, "strong.json", key, 1<<12)
+	weakPath := encryptTestKey(t, sourceDir, "weak.json", key, 1<<10)
+
+	mockRepo := new(MockWalletRepository)
+	mockRepo.On("AddWallet", mock.AnythingOfType("*wallet.Wallet")).Return(nil)
+	n, p := GetTestKeystoreParams()
+	ws := NewWalletService(mockRepo, keystore.NewKeyStore(t.TempDir(), n, p))
+
+	details, err := ws.ImportWalletFromKeystoreV3("Strong", strongPath, "secret")
+	require.NoError(t, err)
+	managed := details.Wallet.KeyStorePath
+	original, err := os.ReadFile(managed)
+	require.NoError(t, err)
+
+	// Importing the same file again does not ask
+	_, err = ws.ImportWalletFromKeystoreV3("Strong again", strongPath, "secret")
+	require.NoError(t, err)
+
+	_, err = ws.ImportWalletFromKeystoreV3("Weak", weakPath, "secret")
+	var overwriteErr *KeystoreOverwriteError
+	require.ErrorAs(t, err, &overwriteErr)
+	assert.Equal(t, managed, overwriteErr.Path)
+	assert.True(t, overwriteErr.Weaker())
+	current, err := os.ReadFile(managed)
+	require.NoError(t, err)
+	assert.Equal(t, original, current, "the managed keystore is kept until the overwrite is approved")
+
+	ws.ApproveKeystoreOverwrite(overwriteErr)
+	_, err = ws.ImportWalletFromKeystoreV3("Weak", weakPath, "secret")
+	require.NoError(t, err)
+	weak, err := os.ReadFile(weakPath)
+	require.NoError(t, err)
+	current, err = os.ReadFile(managed)
+	require.NoError(t, err)
+	assert.Equal(t, weak, current)
+
+	// The approval is used once
+	_, err = ws.ImportWalletFromKeystoreV3("Strong", strongPath, "secret")
+	assert.ErrorAs(t, err, &overwriteErr)
+}
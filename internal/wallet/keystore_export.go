@@ -0,0 +1,67 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+// ErrNoPrivateKey is returned when exporting a wallet whose key is held
+// outside the application, such as by a device or a KMS
+var ErrNoPrivateKey = errors.New("the private key of this wallet is not held by the application")
+
+// Scrypt cost of exported keystores: the standard one, which other tools
+// expect. Tests lower it.
+var exportScryptN, exportScryptP = keystore.StandardScryptN, keystore.StandardScryptP
+
+// ExportKeystore writes the key of a loaded wallet to destPath as a standard
+// KeyStore V3 file encrypted with password, which may differ from the one the
+// wallet is stored with, for backups or to open the wallet in another tool.
+// With the Argon2id profile the file uses Argon2id, which geth cannot read;
+// otherwise scrypt with the configured export cipher. The file is created
+// exclusively with owner-only permissions.
+func (ws *WalletService) ExportKeystore(details *WalletDetails, password, destPath string) error {
+	if details == nil || details.Wallet == nil {
+		return errors.New("no wallet selected")
+	}
+	if details.PrivateKey == nil {
+		return ErrNoPrivateKey
+	}
+	if validationErr, ok := ValidatePassword(password); !ok {
+		return errors.New(validationErr.GetErrorMessage())
+	}
+	password = NormalizeSecret(password)
+
+	key := &keystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(details.PrivateKey.PublicKey),
+		PrivateKey: details.PrivateKey,
+	}
+	var data []byte
+	var err error
+	if ws.KeystoreArgon2id != nil {
+		data, err = EncryptKeyArgon2id(key, password, *ws.KeystoreArgon2id)
+	} else {
+		cipherName := ws.ExportCipher
+		if cipherName == "" {
+			cipherName = CipherAES128CTR
+		}
+		data, err = EncryptKeyWithCipher(key, password, cipherName, exportScryptN, exportScryptP)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encrypt keystore: %w", err)
+	}
+
+	return writeExclusive(destPath, data)
+}
+
+// DefaultKeystoreFileName suggests a file name for an exported keystore, in
+// the UTC--<time>--<address> form geth and other tools use
+func DefaultKeystoreFileName(w *Wallet, at time.Time) string {
+	return fmt.Sprintf("UTC--%s--%s", at.UTC().Format("2006-01-02T15-04-05.000000000Z"), strings.ToLower(strings.TrimPrefix(w.Address, "0x")))
+}
@@ -0,0 +1,85 @@
+package wallet
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportKeystore(t *testing.T) {
+	exportScryptN, exportScryptP = keystore.LightScryptN, keystore.LightScryptP
+	defer func() { exportScryptN, exportScryptP = keystore.StandardScryptN, keystore.StandardScryptP }()
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	details := &WalletDetails{Wallet: &Wallet{Address: address.Hex()}, PrivateKey: privateKey}
+	ws := &WalletService{}
+
+	out := filepath.Join(t.TempDir(), DefaultKeystoreFileName(details.Wallet, time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "UTC--2024-03-01T12-00-00.000000000Z--"+strings.ToLower(address.Hex()[2:]), filepath.Base(out))
+	require.NoError(t, ws.ExportKeystore(details, "Export-Pass1", out))
+
+	// A standard V3 file that opens with the new password only
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	key, err := keystore.DecryptKey(data, "Export-Pass1")
+	require.NoError(t, err)
+	assert.Equal(t, address, key.Address)
+	assert.Equal(t, crypto.FromECDSA(privateKey), crypto.FromECDSA(key.PrivateKey))
+	_, err = keystore.DecryptKey(data, "wrong")
+	assert.Error(t, err)
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(out)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	// Never overwrite an existing file
+	assert.Error(t, ws.ExportKeystore(details, "Export-Pass1", out))
+
+	// Weak passwords and keys held elsewhere are refused, writing nothing
+	weak := filepath.Join(t.TempDir(), "weak")
+	assert.Error(t, ws.ExportKeystore(details, "short", weak))
+	assert.NoFileExists(t, weak)
+	assert.ErrorIs(t, ws.ExportKeystore(&WalletDetails{Wallet: details.Wallet}, "Export-Pass1", weak), ErrNoPrivateKey)
+}
+
+func TestExportKeystore_Cipher(t *testing.T) {
+	exportScryptN, exportScryptP = keystore.LightScryptN, keystore.LightScryptP
+	defer func() { exportScryptN, exportScryptP = keystore.StandardScryptN, keystore.StandardScryptP }()
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	details := &WalletDetails{Wallet: &Wallet{Address: crypto.PubkeyToAddress(privateKey.PublicKey).Hex()}, PrivateKey: privateKey}
+	ws := &WalletService{ExportCipher: CipherAES256GCM}
+
+	// A composed accent is written in its normalized form, so the file opens
+	// with the password however the accent is typed
+	out := filepath.Join(t.TempDir(), "gcm.json")
+	require.NoError(t, ws.ExportKeystore(details, "Export-Pass1-\u00e9", out))
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"cipher":"aes-256-gcm"`)
+	key, used, err := NewEnhancedKeyStoreService().DecryptKeyJSON(data, "Export-Pass1-e\u0301")
+	require.NoError(t, err)
+	assert.Equal(t, NormalizeSecret("Export-Pass1-\u00e9"), used)
+	assert.Equal(t, crypto.FromECDSA(privateKey), crypto.FromECDSA(key.PrivateKey))
+
+	// The default cipher stays the one geth reads
+	ws.ExportCipher = ""
+	out = filepath.Join(t.TempDir(), "ctr.json")
+	require.NoError(t, ws.ExportKeystore(details, "Export-Pass1-\u00e9", out))
+	data, err = os.ReadFile(out)
+	require.NoError(t, err)
+	_, err = keystore.DecryptKey(data, "Export-Pass1-e\u0301")
+	assert.NoError(t, err)
+}
@@ -261,6 +261,8 @@ func (kv *KeystoreValidator) ValidateCrypto(crypto *KeystoreV3Crypto) error {
 		return kv.validateScryptParams(crypto.KDFParams)
 	case "pbkdf2":
 		return kv.validatePBKDF2Params(crypto.KDFParams)
+	case KDFArgon2id:
+		return kv.validateArgon2idParams(crypto.KDFParams)
 	default:
 		return NewKeystoreImportErrorWithField(
 			ErrorInvalidKeystore,
@@ -329,6 +331,31 @@ func (kv *KeystoreValidator) validatePBKDF2Params(params any) error {
 	return nil
 }
 
+// validateArgon2idParams validates Argon2id KDF parameters, as written by
+// EncryptKeyArgon2id
+func (kv *KeystoreValidator) validateArgon2idParams(params any) error {
+	paramsMap, ok := params.(map[string]any)
+	if !ok {
+		return NewKeystoreImportErrorWithField(
+			ErrorInvalidKeystore,
+			"Invalid Argon2id parameters format",
+			"crypto.kdfparams",
+			nil,
+		)
+	}
+
+	if err := (&Argon2idHandler{}).ValidateParams(paramsMap); err != nil {
+		return NewKeystoreImportErrorWithField(
+			ErrorInvalidKeystore,
+			fmt.Sprintf("Invalid Argon2id parameters: %v", err),
+			"crypto.kdfparams",
+			err,
+		)
+	}
+
+	return nil
+}
+
 // NewKeystoreImportError creates a new KeystoreImportError
 func NewKeystoreImportError(errorType KeystoreErrorType, message string, cause error) *KeystoreImportError {
 	return &KeystoreImportError{
@@ -0,0 +1,339 @@
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+)
+
+var (
+	// ErrMasterPasswordLocked is returned when wallet fields sealed with the
+	// master password are read or written before it was entered
+	ErrMasterPasswordLocked = errors.New("the wallet database is locked by the master password")
+	// ErrMasterPassword is returned for a wrong master password
+	ErrMasterPassword = errors.New("incorrect master password")
+	// ErrMasterPasswordUnsupported is returned when the repository cannot
+	// seal its fields, e.g. an in-memory repository in tests
+	ErrMasterPasswordUnsupported = errors.New("the wallet database does not support a master password")
+)
+
+// sealedPrefix marks database values sealed with the master key, so rows
+// written before the master password was set are told apart
+const sealedPrefix = "mk1:"
+
+// plainPrefix escapes plain values that start like a sealed or escaped one,
+// so a note typed as "mk1:..." is not taken for a sealed value
+const plainPrefix = "mk0:"
+
+// masterPasswordCheck is sealed into the record to check a master password
+// without opening any wallet
+const masterPasswordCheck = "bloco-wallet master password"
+
+// MasterPassword is the stored master password record: the Argon2id salt and
+// costs of the key and a value sealed with it. Neither the password nor the
+// key is stored. A disabled record means the user chose not to set one.
+type MasterPassword struct {
+	ID        int    `gorm:"primaryKey"`
+	Enabled   bool   `gorm:"not null"`
+	Salt      string // Hex
+	Time      uint32
+	Memory    uint32 // KiB
+	Threads   uint8
+	Verifier  string // masterPasswordCheck sealed with the key
+	UpdatedAt time.Time
+}
+
+// TableName define o nome da tabela no banco de dados
+func (MasterPassword) TableName() string {
+	return "master_password"
+}
+
+// MasterPasswordState tells whether the application has to ask for the
+// master password before the wallets are used
+type MasterPasswordState int
+
+const (
+	// MasterPasswordUnset means no choice was made yet: offer to set one
+	MasterPasswordUnset MasterPasswordState = iota
+	// MasterPasswordDisabled means the user chose not to set one
+	MasterPasswordDisabled
+	// MasterPasswordLocked means one is set and has not been entered
+	MasterPasswordLocked
+	// MasterPasswordUnlocked means one is set and was entered
+	MasterPasswordUnlocked
+)
+
+// MasterPasswordDatabase is implemented by repositories that can seal wallet
+// fields (encrypted mnemonics, notes and references) with the master key
+type MasterPasswordDatabase interface {
+	// MasterPassword returns the stored record, nil when there is none
+	MasterPassword() (*MasterPassword, error)
+	// SetFieldCipher makes reads open and writes seal the wrapped fields; nil
+	// leaves sealed values unreadable
+	SetFieldCipher(fields *FieldCipher)
+	// SaveMasterPassword stores record and, in the same transaction, seals
+	// every wrapped field again with next, opening it with the current cipher.
+	// Reads and writes then use next.
+	SaveMasterPassword(record *MasterPassword, next *FieldCipher) error
+}
+
+// DatabaseRekeyer is implemented by repositories whose database file can be
+// encrypted with the master password, so a new one encrypts it again
+type DatabaseRekeyer interface {
+	// RekeyWithPassword re-encrypts the database with password when it is
+	// encrypted with the master password, and does nothing otherwise. restore
+	// puts back the key that was in effect before, which is not always the
+	// previous master password: the first one replaces the startup password.
+	RekeyWithPassword(password string) (restore func() error, err error)
+}
+
+// FieldCipher seals database fields with AES-256-GCM under the master key
+type FieldCipher struct {
+	aead cipher.AEAD
+}
+
+func newFieldCipher(key []byte) (*FieldCipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &FieldCipher{aead: aead}, nil
+}
+
+// IsSealed reports whether a stored value was sealed with a master key
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, sealedPrefix)
+}
+
+// Seal encrypts value for storage; empty values stay empty. A nil cipher
+// stores value in plain text, escaped when it starts like a sealed value.
+func (c *FieldCipher) Seal(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if c == nil {
+		if strings.HasPrefix(value, sealedPrefix) || strings.HasPrefix(value, plainPrefix) {
+			return plainPrefix + value, nil
+		}
+		return value, nil
+	}
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(value), nil)
+	return sealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a value written by Seal. Values that were never sealed are
+// returned as they are, without the escape Seal added.
+func (c *FieldCipher) Open(value string) (string, error) {
+	if strings.HasPrefix(value, plainPrefix) {
+		return strings.TrimPrefix(value, plainPrefix), nil
+	}
+	if !IsSealed(value) {
+		return value, nil
+	}
+	if c == nil {
+		return "", ErrMasterPasswordLocked
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, sealedPrefix))
+	if err != nil || len(data) < c.aead.NonceSize() {
+		return "", errors.New("damaged sealed value")
+	}
+	nonce, sealed := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+	plain, err := c.aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", ErrMasterPassword
+	}
+	return string(plain), nil
+}
+
+// masterFieldCipher derives the field cipher of record from password
+func masterFieldCipher(record *MasterPassword, password string) (*FieldCipher, error) {
+	salt, err := hex.DecodeString(record.Salt)
+	if err != nil || len(salt) == 0 {
+		return nil, errors.New("damaged master password record")
+	}
+	key := argon2.IDKey([]byte(NormalizeSecret(password)), salt, record.Time, record.Memory, record.Threads, 32)
+	defer zeroBytes(key)
+	return newFieldCipher(key)
+}
+
+// masterPasswordDatabase returns the repository as a MasterPasswordDatabase
+func (ws *WalletService) masterPasswordDatabase() (MasterPasswordDatabase, error) {
+	db, ok := ws.Repo.(MasterPasswordDatabase)
+	if !ok {
+		return nil, ErrMasterPasswordUnsupported
+	}
+	return db, nil
+}
+
+// MasterPasswordState tells whether the master password has to be set or
+// entered before the wallets are used
+func (ws *WalletService) MasterPasswordState() (MasterPasswordState, error) {
+	db, ok := ws.Repo.(MasterPasswordDatabase)
+	if !ok {
+		return MasterPasswordDisabled, nil
+	}
+	record, err := db.MasterPassword()
+	switch {
+	case err != nil:
+		return MasterPasswordLocked, err
+	case record == nil:
+		return MasterPasswordUnset, nil
+	case !record.Enabled:
+		return MasterPasswordDisabled, nil
+	case ws.masterFields == nil:
+		return MasterPasswordLocked, nil
+	default:
+		return MasterPasswordUnlocked, nil
+	}
+}
+
+// UnlockMasterPassword checks password against the stored record and opens
+// the sealed fields with it
+func (ws *WalletService) UnlockMasterPassword(password string) error {
+	db, err := ws.masterPasswordDatabase()
+	if err != nil {
+		return err
+	}
+	record, err := db.MasterPassword()
+	if err != nil {
+		return err
+	}
+	if record == nil || !record.Enabled {
+		return errors.New("no master password is set")
+	}
+	fields, err := masterFieldCipher(record, password)
+	if err != nil {
+		return err
+	}
+	if check, err := fields.Open(record.Verifier); err != nil || check != masterPasswordCheck {
+		return ErrMasterPassword
+	}
+	db.SetFieldCipher(fields)
+	ws.masterFields = fields
+	return nil
+}
+
+// SetMasterPassword sets the master password, or changes it when one is set,
+// in which case current must be the one in use. Every sealed field is sealed
+// again with the new key; fields stored before the first one was set are
+// sealed for the first time.
+func (ws *WalletService) SetMasterPassword(current, next string) error {
+	db, err := ws.masterPasswordDatabase()
+	if err != nil {
+		return err
+	}
+	if validationErr, ok := ValidatePassword(next); !ok {
+		return errors.New(validationErr.GetErrorMessage())
+	}
+	record, err := db.MasterPassword()
+	if err != nil {
+		return err
+	}
+	if record != nil && record.Enabled {
+		// The change needs the current password even while unlocked
+		if err := ws.UnlockMasterPassword(current); err != nil {
+			return err
+		}
+	}
+
+	params := masterPasswordParams()
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	updated := &MasterPassword{
+		Enabled:   true,
+		Salt:      hex.EncodeToString(salt),
+		Time:      params.Time,
+		Memory:    params.Memory,
+		Threads:   params.Threads,
+		UpdatedAt: time.Now(),
+	}
+	if record != nil {
+		updated.ID = record.ID
+	}
+	fields, err := masterFieldCipher(updated, next)
+	if err != nil {
+		return err
+	}
+	if updated.Verifier, err = fields.Seal(masterPasswordCheck); err != nil {
+		return err
+	}
+	// The database file is re-encrypted first: a failed rekey leaves both it
+	// and the sealed fields under the current password
+	restoreKey := func() error { return nil }
+	if rekeyer, ok := ws.Repo.(DatabaseRekeyer); ok {
+		if restoreKey, err = rekeyer.RekeyWithPassword(next); err != nil {
+			return fmt.Errorf("failed to re-encrypt the wallet database: %w", err)
+		}
+	}
+	if err := db.SaveMasterPassword(updated, fields); err != nil {
+		if rollbackErr := restoreKey(); rollbackErr != nil {
+			return fmt.Errorf("failed to seal the wallet database: %w (and to restore its previous key: %v)", err, rollbackErr)
+		}
+		return fmt.Errorf("failed to seal the wallet database: %w", err)
+	}
+	ws.masterFields = fields
+	return nil
+}
+
+// DeclineMasterPassword records that the user chose not to set a master
+// password, so it is not offered on every start
+func (ws *WalletService) DeclineMasterPassword() error {
+	db, err := ws.masterPasswordDatabase()
+	if err != nil {
+		return err
+	}
+	record, err := db.MasterPassword()
+	if err != nil {
+		return err
+	}
+	if record != nil {
+		return nil
+	}
+	return db.SaveMasterPassword(&MasterPassword{Enabled: false, UpdatedAt: time.Now()}, nil)
+}
+
+// lockMasterPassword forgets the master key, e.g. after a restore replaced
+// the record it belonged to
+func (ws *WalletService) lockMasterPassword() {
+	if db, ok := ws.Repo.(MasterPasswordDatabase); ok {
+		db.SetFieldCipher(nil)
+	}
+	ws.masterFields = nil
+}
+
+// masterPasswordParams returns the Argon2id costs of new master keys: those
+// of the [security] section, as for the mnemonics
+func masterPasswordParams() Argon2idParams {
+	params := Argon2idParams{Time: 1, Memory: 64 * 1024, Threads: 4}
+	if defaultCryptoService != nil && defaultCryptoService.config != nil {
+		security := defaultCryptoService.config.Security
+		if security.Argon2Time > 0 {
+			params.Time = security.Argon2Time
+		}
+		if security.Argon2Memory > 0 {
+			params.Memory = security.Argon2Memory
+		}
+		if security.Argon2Threads > 0 {
+			params.Threads = security.Argon2Threads
+		}
+	}
+	return params
+}
@@ -0,0 +1,280 @@
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// metamaskLegacyIterations is the PBKDF2 cost of vaults written before
+	// MetaMask recorded it in keyMetadata
+	metamaskLegacyIterations = 10000
+	// metamaskMaxIterations bounds the cost a vault may ask for
+	metamaskMaxIterations = 10_000_000
+	// metamaskHDPath is the only derivation path of MetaMask's HD keyring
+	metamaskHDPath = "m/44'/60'/0'/0"
+	// metamaskMaxAccounts bounds the accounts derived from one phrase
+	metamaskMaxAccounts = 1000
+)
+
+var (
+	// ErrMetamaskNoVault is returned for a file holding neither a MetaMask
+	// vault nor a Secret Recovery Phrase
+	ErrMetamaskNoVault = errors.New("no MetaMask vault or Secret Recovery Phrase found in the file")
+	// ErrMetamaskPassword is returned when the vault does not open with the password
+	ErrMetamaskPassword = errors.New("wrong password for the MetaMask vault")
+)
+
+// metamaskVaultPattern finds a vault in the extension's storage, where it is
+// an escaped JSON string inside the state ("vault":"{\"data\":…}"), as well
+// as in a plain vault file. The keyMetadata object only exists in vaults
+// written by MetaMask 11 and later.
+var metamaskVaultPattern = regexp.MustCompile(`\\*"data\\*"\s*:\s*\\*"([A-Za-z0-9+/=]+)\\*"\s*,\s*\\*"iv\\*"\s*:\s*\\*"([A-Za-z0-9+/=]+)\\*"\s*,\s*(?:\\*"keyMetadata\\*"\s*:\s*\{[^{}]*\{\s*\\*"iterations\\*"\s*:\s*(\d+)\s*\}\s*\}\s*,\s*)?\\*"salt\\*"\s*:\s*\\*"([A-Za-z0-9+/=]+)\\*"`)
+
+// metamaskVault is the encrypted KeyringController vault of the extension:
+// AES-GCM with a key derived by PBKDF2-SHA256 from the extension password
+type metamaskVault struct {
+	Data       string
+	IV         string
+	Salt       string
+	Iterations int
+}
+
+// MetamaskAccount is one account found in a MetaMask export. Accounts of a
+// Secret Recovery Phrase carry the phrase and their index; imported accounts
+// carry their key.
+type MetamaskAccount struct {
+	Address    common.Address
+	Mnemonic   string
+	Index      uint32
+	PrivateKey string
+}
+
+// MetamaskExport lists the accounts read from a MetaMask export, with the
+// keyrings that hold no key to import, such as hardware wallets. PhraseOnly
+// marks a bare Secret Recovery Phrase, which does not tell how many accounts
+// were used.
+type MetamaskExport struct {
+	Accounts    []MetamaskAccount
+	Unsupported []string
+	PhraseOnly  bool
+}
+
+// metamaskKeyring is one entry of a decrypted vault
+type metamaskKeyring struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// metamaskHDData is the data of an "HD Key Tree" keyring. MetaMask stores
+// the phrase as a string or, in recent versions, as its UTF-8 bytes.
+type metamaskHDData struct {
+	Mnemonic         json.RawMessage `json:"mnemonic"`
+	NumberOfAccounts int             `json:"numberOfAccounts"`
+	HDPath           string          `json:"hdPath"`
+}
+
+// findMetamaskVault locates the vault in a vault backup, a state export or
+// the log files of the extension's storage. The last vault of the file is
+// returned, as the storage logs keep older copies before the current one.
+func findMetamaskVault(data []byte) (*metamaskVault, error) {
+	matches := metamaskVaultPattern.FindAllSubmatch(data, -1)
+	if len(matches) == 0 {
+		return nil, ErrMetamaskNoVault
+	}
+	match := matches[len(matches)-1]
+	vault := &metamaskVault{Data: string(match[1]), IV: string(match[2]), Salt: string(match[4]), Iterations: metamaskLegacyIterations}
+	if len(match[3]) > 0 {
+		iterations, err := strconv.Atoi(string(match[3]))
+		if err != nil || iterations <= 0 || iterations > metamaskMaxIterations {
+			return nil, fmt.Errorf("unsupported PBKDF2 iterations in the vault: %s", match[3])
+		}
+		vault.Iterations = iterations
+	}
+	return vault, nil
+}
+
+// decrypt opens the vault with the extension password and returns its keyrings
+func (v *metamaskVault) decrypt(password string) ([]metamaskKeyring, error) {
+	data, err := base64.StdEncoding.DecodeString(v.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vault data: %w", err)
+	}
+	iv, err := base64.StdEncoding.DecodeString(v.IV)
+	if err != nil || len(iv) == 0 {
+		return nil, errors.New("invalid vault IV")
+	}
+	salt, err := base64.StdEncoding.DecodeString(v.Salt)
+	if err != nil {
+		return nil, errors.New("invalid vault salt")
+	}
+	iterations := v.Iterations
+	if iterations == 0 {
+		iterations = metamaskLegacyIterations
+	}
+
+	key := pbkdf2.Key([]byte(password), salt, iterations, 32, sha256.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, iv, data, nil)
+	if err != nil {
+		return nil, ErrMetamaskPassword
+	}
+	var keyrings []metamaskKeyring
+	if err := json.Unmarshal(plain, &keyrings); err != nil {
+		return nil, fmt.Errorf("unexpected vault contents: %w", err)
+	}
+	return keyrings, nil
+}
+
+// ReadMetamaskExport reads the accounts of a MetaMask export: a vault, found
+// as by findMetamaskVault and opened with password, or a file holding just
+// the Secret Recovery Phrase, for which only the first account is known.
+func ReadMetamaskExport(data []byte, password string) (*MetamaskExport, error) {
+	if phrase := NormalizeMnemonic(strings.Join(strings.Fields(string(data)), " ")); bip39.IsMnemonicValid(phrase) {
+		accounts, err := metamaskHDAccounts(phrase, 1)
+		if err != nil {
+			return nil, err
+		}
+		return &MetamaskExport{Accounts: accounts, PhraseOnly: true}, nil
+	}
+
+	vault, err := findMetamaskVault(data)
+	if err != nil {
+		return nil, err
+	}
+	keyrings, err := vault.decrypt(password)
+	if err != nil {
+		return nil, err
+	}
+	export := &MetamaskExport{}
+	for _, keyring := range keyrings {
+		var accounts []MetamaskAccount
+		switch keyring.Type {
+		case "HD Key Tree":
+			accounts, err = metamaskHDKeyring(keyring.Data)
+		case "Simple Key Pair":
+			accounts, err = metamaskSimpleKeyring(keyring.Data)
+		default:
+			export.Unsupported = append(export.Unsupported, keyring.Type)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s keyring: %w", keyring.Type, err)
+		}
+		export.Accounts = append(export.Accounts, accounts...)
+	}
+	if len(export.Accounts) == 0 && len(export.Unsupported) == 0 {
+		return nil, errors.New("the vault holds no accounts")
+	}
+	return export, nil
+}
+
+// metamaskHDKeyring lists the accounts of an "HD Key Tree" keyring
+func metamaskHDKeyring(raw json.RawMessage) ([]MetamaskAccount, error) {
+	var data metamaskHDData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+	if data.HDPath != "" && data.HDPath != metamaskHDPath {
+		return nil, fmt.Errorf("unsupported derivation path %s", data.HDPath)
+	}
+	var phrase string
+	if err := json.Unmarshal(data.Mnemonic, &phrase); err != nil {
+		var bytes []byte
+		var codes []int
+		if err := json.Unmarshal(data.Mnemonic, &codes); err != nil {
+			return nil, errors.New("unreadable Secret Recovery Phrase")
+		}
+		for _, c := range codes {
+			if c < 0 || c > 255 {
+				return nil, errors.New("unreadable Secret Recovery Phrase")
+			}
+			bytes = append(bytes, byte(c))
+		}
+		phrase = string(bytes)
+	}
+	count := min(max(data.NumberOfAccounts, 1), metamaskMaxAccounts)
+	return metamaskHDAccounts(NormalizeMnemonic(phrase), count)
+}
+
+// metamaskHDAccounts derives the first count accounts of a phrase
+func metamaskHDAccounts(phrase string, count int) ([]MetamaskAccount, error) {
+	accounts := make([]MetamaskAccount, 0, count)
+	for i := 0; i < count; i++ {
+		keyHex, err := deriveAddressKey(phrase, uint32(i))
+		if err != nil {
+			return nil, err
+		}
+		key, err := HexToECDSA(keyHex)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, MetamaskAccount{Address: crypto.PubkeyToAddress(key.PublicKey), Mnemonic: phrase, Index: uint32(i)})
+	}
+	return accounts, nil
+}
+
+// metamaskSimpleKeyring lists the accounts of a "Simple Key Pair" keyring,
+// the keys imported into MetaMask one by one
+func metamaskSimpleKeyring(raw json.RawMessage) ([]MetamaskAccount, error) {
+	var keys []string
+	if err := json.Unmarshal(raw, &keys); err != nil {
+		return nil, err
+	}
+	accounts := make([]MetamaskAccount, 0, len(keys))
+	for _, k := range keys {
+		k = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(k), "0x"), "0X")
+		key, err := HexToECDSA(k)
+		if err != nil {
+			return nil, errors.New("invalid private key")
+		}
+		accounts = append(accounts, MetamaskAccount{Address: crypto.PubkeyToAddress(key.PublicKey), PrivateKey: k})
+	}
+	return accounts, nil
+}
+
+// ImportMetamaskAccount imports one account of a MetaMask export, encrypted
+// with password. The first account of a Secret Recovery Phrase becomes a
+// mnemonic wallet and the following ones its child wallets, so the phrase
+// is stored once; imported keys become private key wallets.
+func (ws *WalletService) ImportMetamaskAccount(name string, account MetamaskAccount, password string) (*WalletDetails, error) {
+	switch {
+	case account.PrivateKey != "":
+		return ws.ImportWalletFromPrivateKey(name, account.PrivateKey, password)
+	case account.Mnemonic == "":
+		return nil, NewInvalidImportDataError(string(ImportMethodMetamask), "The account has no key")
+	case account.Index == 0:
+		return ws.ImportWallet(name, account.Mnemonic, password)
+	}
+
+	mnemonic := NormalizeMnemonic(account.Mnemonic)
+	master, err := ws.Repo.FindBySourceHash((&SourceHashGenerator{}).GenerateFromMnemonic(mnemonic))
+	if err != nil {
+		return nil, err
+	}
+	if master == nil {
+		return nil, NewInvalidImportDataError(string(ImportMethodMetamask), "The first account of the Secret Recovery Phrase must be imported first")
+	}
+	details := &WalletDetails{Wallet: master, Mnemonic: &mnemonic, HasMnemonic: true}
+	return ws.CreateChildWallet(details, account.Index, name, password)
+}
@@ -0,0 +1,104 @@
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// metamaskTestVault encrypts keyrings the way MetaMask's browser-passworder
+// does, returning the vault JSON
+func metamaskTestVault(t *testing.T, password string, iterations int, keyrings any) string {
+	t.Helper()
+	plain, err := json.Marshal(keyrings)
+	require.NoError(t, err)
+	salt := []byte("0123456789abcdef0123456789abcdef")
+	iv := []byte("fedcba9876543210")
+	block, err := aes.NewCipher(pbkdf2.Key([]byte(password), salt, iterations, 32, sha256.New))
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	require.NoError(t, err)
+	data := gcm.Seal(nil, iv, plain, nil)
+
+	b64 := base64.StdEncoding.EncodeToString
+	if iterations == metamaskLegacyIterations {
+		return fmt.Sprintf(`{"data":"%s","iv":"%s","salt":"%s"}`, b64(data), b64(iv), b64(salt))
+	}
+	return fmt.Sprintf(`{"data":"%s","iv":"%s","keyMetadata":{"algorithm":"PBKDF2","params":{"iterations":%d}},"salt":"%s"}`, b64(data), b64(iv), iterations, b64(salt))
+}
+
+func TestReadMetamaskExport_Vault(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	keyHex := fmt.Sprintf("%x", crypto.FromECDSA(key))
+	phraseBytes := make([]int, 0, len(childTestMnemonic))
+	for _, c := range []byte(childTestMnemonic) {
+		phraseBytes = append(phraseBytes, int(c))
+	}
+	keyrings := []map[string]any{
+		{"type": "HD Key Tree", "data": map[string]any{"mnemonic": phraseBytes, "numberOfAccounts": 2, "hdPath": "m/44'/60'/0'/0"}},
+		{"type": "Simple Key Pair", "data": []string{keyHex}},
+		{"type": "Ledger Hardware", "data": map[string]any{"hdPath": "m/44'/60'/0'"}},
+	}
+	vault := metamaskTestVault(t, "hunter22", 600000, keyrings)
+
+	export, err := ReadMetamaskExport([]byte(vault), "hunter22")
+	require.NoError(t, err)
+	require.Len(t, export.Accounts, 3)
+	assert.Equal(t, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", export.Accounts[0].Address.Hex())
+	assert.Equal(t, childTestMnemonic, export.Accounts[0].Mnemonic)
+	assert.Equal(t, "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0", export.Accounts[1].Address.Hex())
+	assert.Equal(t, uint32(1), export.Accounts[1].Index)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), export.Accounts[2].Address)
+	assert.Equal(t, keyHex, export.Accounts[2].PrivateKey)
+	assert.Equal(t, []string{"Ledger Hardware"}, export.Unsupported)
+
+	_, err = ReadMetamaskExport([]byte(vault), "wrong")
+	assert.ErrorIs(t, err, ErrMetamaskPassword)
+}
+
+func TestReadMetamaskExport_ExtensionStorage(t *testing.T) {
+	keyrings := []map[string]any{
+		{"type": "HD Key Tree", "data": map[string]any{"mnemonic": childTestMnemonic, "numberOfAccounts": 1}},
+	}
+	older := metamaskTestVault(t, "old password", metamaskLegacyIterations, keyrings)
+	current := metamaskTestVault(t, "hunter22", metamaskLegacyIterations, keyrings)
+
+	// The storage logs keep the vault as an escaped string, older copies first
+	state := func(vault string) string {
+		return `{"KeyringController":{"vault":` + strconv.Quote(vault) + `}}`
+	}
+	log := []byte("\x00\x01garbage" + state(older) + "\x02\x03" + state(current) + "\x00")
+	export, err := ReadMetamaskExport(log, "hunter22")
+	require.NoError(t, err)
+	require.Len(t, export.Accounts, 1)
+	assert.Equal(t, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", export.Accounts[0].Address.Hex())
+}
+
+func TestReadMetamaskExport_RecoveryPhrase(t *testing.T) {
+	export, err := ReadMetamaskExport([]byte("  "+childTestMnemonic+"\n"), "")
+	require.NoError(t, err)
+	require.Len(t, export.Accounts, 1)
+	assert.Equal(t, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", export.Accounts[0].Address.Hex())
+
+	_, err = ReadMetamaskExport([]byte(`{"accounts":[]}`), "")
+	assert.ErrorIs(t, err, ErrMetamaskNoVault)
+}
+
+func TestReadMetamaskExport_UnsupportedPath(t *testing.T) {
+	keyrings := []map[string]any{
+		{"type": "HD Key Tree", "data": map[string]any{"mnemonic": childTestMnemonic, "numberOfAccounts": 1, "hdPath": "m/44'/60'/0'"}},
+	}
+	_, err := ReadMetamaskExport([]byte(metamaskTestVault(t, "pw", metamaskLegacyIterations, keyrings)), "pw")
+	assert.ErrorContains(t, err, "unsupported derivation path")
+}
@@ -0,0 +1,80 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeSecret returns the NFKD form of a password, as BIP-39 mandates for
+// passphrases. Composed and decomposed accents ("é" vs "é") produce the
+// same bytes once normalized, so passwords typed on different platforms match.
+func NormalizeSecret(secret string) string {
+	return norm.NFKD.String(secret)
+}
+
+// NormalizeMnemonic returns the NFKD form of a mnemonic phrase with runs of
+// whitespace collapsed into single spaces
+func NormalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(norm.NFKD.String(mnemonic)), " ")
+}
+
+// PasswordCandidates returns the passwords to try when decrypting existing
+// material: the NFKD form first, then the password exactly as typed when it
+// differs. Keystores created by other tools (or by older versions of this
+// application) may have been encrypted with the un-normalized bytes.
+func PasswordCandidates(password string) []string {
+	normalized := NormalizeSecret(password)
+	if normalized == password {
+		return []string{password}
+	}
+	return []string{normalized, password}
+}
+
+// decryptKeystoreJSON decrypts a keystore trying every password candidate.
+// It returns the key together with the candidate that worked, so the same
+// form can be used for data encrypted alongside the keystore (e.g. the mnemonic).
+func decryptKeystoreJSON(keyJSON []byte, password string) (*keystore.Key, string, error) {
+	// geth only reads aes-128-ctr with scrypt or pbkdf2; other ciphers and
+	// Argon2id go through the enhanced service
+	var header struct {
+		Crypto struct {
+			Cipher string `json:"cipher"`
+			KDF    string `json:"kdf"`
+		} `json:"crypto"`
+	}
+	if json.Unmarshal(keyJSON, &header) == nil {
+		otherCipher := header.Crypto.Cipher != CipherAES128CTR && isSupportedCipher(header.Crypto.Cipher)
+		if otherCipher || strings.EqualFold(header.Crypto.KDF, KDFArgon2id) {
+			return NewEnhancedKeyStoreService().DecryptKeyJSON(keyJSON, password)
+		}
+	}
+
+	var lastErr error
+	for _, candidate := range PasswordCandidates(password) {
+		key, err := keystore.DecryptKey(keyJSON, candidate)
+		if err == nil {
+			return key, candidate, nil
+		}
+		lastErr = err
+		// Only a wrong password is worth retrying with another form
+		if !errors.Is(err, keystore.ErrDecrypt) {
+			break
+		}
+	}
+	return nil, "", lastErr
+}
+
+// keystoreFileAcceptsPassword reports whether any password candidate decrypts the keystore file
+func keystoreFileAcceptsPassword(keystorePath, password string) bool {
+	keyJSON, err := os.ReadFile(keystorePath)
+	if err != nil {
+		return false
+	}
+	_, _, err = decryptKeystoreJSON(keyJSON, password)
+	return err == nil
+}
@@ -0,0 +1,93 @@
+package wallet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	composedPassword   = "CaféSenha1!"  // "é" as a single code point (NFC)
+	decomposedPassword = "CaféSenha1!" // "e" + combining acute accent (NFD/NFKD)
+)
+
+func TestNormalizeSecret(t *testing.T) {
+	assert.Equal(t, decomposedPassword, NormalizeSecret(composedPassword))
+	assert.Equal(t, decomposedPassword, NormalizeSecret(decomposedPassword))
+	// NFKD also folds compatibility characters
+	assert.Equal(t, "fi", NormalizeSecret("ﬁ"))
+}
+
+func TestNormalizeMnemonic(t *testing.T) {
+	assert.Equal(t, "abandon ability able", NormalizeMnemonic("  abandon\tability \n able "))
+}
+
+func TestPasswordCandidates(t *testing.T) {
+	assert.Equal(t, []string{"plain"}, PasswordCandidates("plain"))
+	assert.Equal(t, []string{decomposedPassword, composedPassword}, PasswordCandidates(composedPassword))
+}
+
+// writeLegacyKeystore stores a keystore encrypted with the password bytes as given, without normalization
+func writeLegacyKeystore(t *testing.T, dir, password string) (string, string) {
+	t.Helper()
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	key := &keystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(privKey.PublicKey),
+		PrivateKey: privKey,
+	}
+	keyJSON, err := keystore.EncryptKey(key, password, keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, key.Address.Hex()+".json")
+	require.NoError(t, os.WriteFile(path, keyJSON, 0600))
+	return path, key.Address.Hex()
+}
+
+func TestLoadWallet_FallsBackToUnnormalizedPassword(t *testing.T) {
+	InitCryptoService(CreateMockConfig())
+	dir := t.TempDir()
+	ws := NewWalletService(new(MockWalletRepository), keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP))
+
+	// Keystore created elsewhere with the composed (NFC) bytes
+	path, address := writeLegacyKeystore(t, dir, composedPassword)
+
+	details, err := ws.LoadWallet(&Wallet{Address: address, KeyStorePath: path}, composedPassword)
+	require.NoError(t, err)
+	assert.Equal(t, address, crypto.PubkeyToAddress(details.PrivateKey.PublicKey).Hex())
+}
+
+func TestCreateWallet_AcceptsAnyUnicodeFormOfPassword(t *testing.T) {
+	InitCryptoService(CreateMockConfig())
+	dir := t.TempDir()
+
+	repo := new(MockWalletRepository)
+	repo.On("AddWallet", mock.AnythingOfType("*wallet.Wallet")).Return(nil)
+	ws := NewWalletService(repo, keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP))
+
+	created, err := ws.CreateWallet("accented", composedPassword)
+	require.NoError(t, err)
+
+	// Typed with a decomposed accent on another platform
+	details, err := ws.LoadWallet(created.Wallet, decomposedPassword)
+	require.NoError(t, err)
+	require.NotNil(t, details.Mnemonic)
+	assert.Equal(t, *created.Mnemonic, *details.Mnemonic)
+}
+
+func TestKeystoreFileAcceptsPassword_Fallback(t *testing.T) {
+	dir := t.TempDir()
+	path, _ := writeLegacyKeystore(t, dir, composedPassword)
+
+	assert.True(t, keystoreFileAcceptsPassword(path, composedPassword))
+	assert.False(t, keystoreFileAcceptsPassword(path, "wrong"))
+}
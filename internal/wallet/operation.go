@@ -0,0 +1,105 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+// operation groups the steps of a change that spans the keystore directory and
+// the database. The file system cannot join a database transaction, so every
+// step registers how to undo itself and a failure later on runs those
+// compensations in reverse order, leaving neither orphaned keystore files nor
+// rows pointing at missing files.
+type operation struct {
+	undo []func() error
+}
+
+// onRollback registers the compensation of a step that has completed
+func (op *operation) onRollback(fn func() error) {
+	op.undo = append(op.undo, fn)
+}
+
+// rollback undoes the completed steps and returns cause, together with any
+// compensation that failed so the leftover can be cleaned up by hand
+func (op *operation) rollback(cause error) error {
+	var failed []error
+	for i := len(op.undo) - 1; i >= 0; i-- {
+		if err := op.undo[i](); err != nil {
+			failed = append(failed, err)
+		}
+	}
+	op.undo = nil
+	if len(failed) == 0 {
+		return cause
+	}
+	if svcLogger != nil {
+		svcLogger.Error("Rollback incomplete: " + errors.Join(failed...).Error())
+	}
+	return fmt.Errorf("%w (rollback incomplete: %v)", cause, errors.Join(failed...))
+}
+
+// removeFile is the compensation of a file created by the operation
+func removeFile(path string) func() error {
+	return func() error {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+}
+
+// importKey encrypts the key into the keystore directory as "<address>.json"
+// and returns the file path and address, registering the file's removal
+func (ws *WalletService) importKey(op *operation, privKey *ecdsa.PrivateKey, password string) (string, string, error) {
+	if ws.KeystoreArgon2id != nil {
+		return ws.importKeyArgon2id(op, privKey, password)
+	}
+	account, err := ws.KeyStore.ImportECDSA(privKey, password)
+	if err != nil {
+		return "", "", err
+	}
+	path := account.URL.Path
+	op.onRollback(func() error { return removeFile(path)() })
+
+	newPath := filepath.Join(filepath.Dir(path), fmt.Sprintf("%s.json", account.Address.Hex()))
+	if err := os.Rename(path, newPath); err != nil {
+		return "", "", fmt.Errorf("error renaming the wallet file: %v", err)
+	}
+	path = newPath
+	return path, account.Address.Hex(), nil
+}
+
+// importKeyArgon2id writes the Argon2id keystore of importKey. geth's KeyStore
+// only writes scrypt files, so the file is created here under the same name.
+func (ws *WalletService) importKeyArgon2id(op *operation, privKey *ecdsa.PrivateKey, password string) (string, string, error) {
+	dir, err := ws.keystoreDir()
+	if err != nil {
+		return "", "", err
+	}
+	key := &keystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(privKey.PublicKey),
+		PrivateKey: privKey,
+	}
+	data, err := EncryptKeyArgon2id(key, password, *ws.KeystoreArgon2id)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encrypt keystore: %w", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s.json", key.Address.Hex()))
+	if err := writeExclusive(path, data); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return "", "", keystore.ErrAccountAlreadyExists
+		}
+		return "", "", err
+	}
+	op.onRollback(removeFile(path))
+	return path, key.Address.Hex(), nil
+}
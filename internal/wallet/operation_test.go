@@ -0,0 +1,77 @@
+package wallet
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationRollbackRunsInReverse(t *testing.T) {
+	var order []int
+	op := &operation{}
+	op.onRollback(func() error { order = append(order, 1); return nil })
+	op.onRollback(func() error { order = append(order, 2); return errors.New("stuck") })
+
+	err := op.rollback(assert.AnError)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.ErrorContains(t, err, "rollback incomplete: stuck")
+	assert.Equal(t, []int{2, 1}, order)
+}
+
+func newOperationTestService(t *testing.T, repo *MockWalletRepository) (*WalletService, string) {
+	InitCryptoService(CreateMockConfig())
+	dir := t.TempDir()
+	n, p := GetTestKeystoreParams()
+	return NewWalletService(repo, keystore.NewKeyStore(dir, n, p)), dir
+}
+
+func TestImportWalletFromPrivateKey_RemovesKeystoreWhenSaveFails(t *testing.T) {
+	repo := new(MockWalletRepository)
+	repo.On("FindBySourceHash", mock.Anything).Return(nil, nil)
+	repo.On("AddWallet", mock.AnythingOfType("*wallet.Wallet")).Return(assert.AnError)
+	ws, dir := newOperationTestService(t, repo)
+
+	_, err := ws.ImportWalletFromPrivateKey("Test", "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318", "password")
+	require.ErrorIs(t, err, assert.AnError)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files, "no orphaned keystore file")
+}
+
+func TestDeleteWallet_RestoresKeystoreWhenRowDeleteFails(t *testing.T) {
+	repo := new(MockWalletRepository)
+	repo.On("DeleteWallet", 7, 2).Return(assert.AnError).Once()
+	repo.On("DeleteWallet", 7, 2).Return(nil).Once()
+	ws, dir := newOperationTestService(t, repo)
+
+	path := filepath.Join(dir, "0xabc.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"address":"abc"}`), 0600))
+	w := &Wallet{ID: 7, Version: 2, KeyStorePath: path}
+
+	require.ErrorIs(t, ws.DeleteWallet(w), assert.AnError)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err, "keystore is back in place")
+	assert.JSONEq(t, `{"address":"abc"}`, string(data))
+
+	require.NoError(t, ws.DeleteWallet(w))
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+	repo.AssertExpectations(t)
+}
+
+func TestDeleteWallet_MissingKeystore(t *testing.T) {
+	repo := new(MockWalletRepository)
+	repo.On("DeleteWallet", 3, 0).Return(nil)
+	ws, dir := newOperationTestService(t, repo)
+
+	assert.NoError(t, ws.DeleteWallet(&Wallet{ID: 3, KeyStorePath: filepath.Join(dir, "gone.json")}))
+	repo.AssertExpectations(t)
+}
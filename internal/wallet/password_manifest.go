@@ -0,0 +1,274 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PasswordManifestNames are the manifests looked up next to the keystores of
+// a batch import, in order of preference. A manifest maps keystore file names,
+// relative to its directory, to their passwords:
+//
+//	passwords.csv:  alice.json,secret (an optional "file,password" header)
+//	passwords.json: {"alice.json": "secret"}
+var PasswordManifestNames = []string{"passwords.csv", "passwords.json"}
+
+// maxManifestSize bounds a password manifest; a row is a few dozen bytes
+const maxManifestSize = 1 << 20
+
+// ManifestProblemType is why a manifest row cannot be used
+type ManifestProblemType int
+
+const (
+	ManifestMissingFile ManifestProblemType = iota
+	ManifestEmptyPassword
+	ManifestDuplicateEntry
+	ManifestInvalidPath
+	ManifestMalformedRow
+)
+
+// String returns a string representation of the manifest problem type
+func (t ManifestProblemType) String() string {
+	switch t {
+	case ManifestMissingFile:
+		return "MISSING_FILE"
+	case ManifestEmptyPassword:
+		return "EMPTY_PASSWORD"
+	case ManifestDuplicateEntry:
+		return "DUPLICATE_ENTRY"
+	case ManifestInvalidPath:
+		return "INVALID_PATH"
+	case ManifestMalformedRow:
+		return "MALFORMED_ROW"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// PasswordManifestProblem is a manifest row left out of the import
+type PasswordManifestProblem struct {
+	Manifest string              // Path of the manifest
+	Line     int                 // Line of the row in a CSV manifest, 0 for JSON
+	File     string              // Keystore file named by the row
+	Type     ManifestProblemType // Why the row is not used
+}
+
+func (p PasswordManifestProblem) String() string {
+	location := filepath.Base(p.Manifest)
+	if p.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, p.Line)
+	}
+	switch p.Type {
+	case ManifestMissingFile:
+		return fmt.Sprintf("%s: %s does not exist", location, p.File)
+	case ManifestEmptyPassword:
+		return fmt.Sprintf("%s: %s has an empty password", location, p.File)
+	case ManifestDuplicateEntry:
+		return fmt.Sprintf("%s: %s is listed more than once", location, p.File)
+	case ManifestInvalidPath:
+		return fmt.Sprintf("%s: %s is outside the manifest directory", location, p.File)
+	default:
+		return fmt.Sprintf("%s: the row needs a file name and a password", location)
+	}
+}
+
+// PasswordManifest holds the passwords of a manifest, keyed by the
+// slash-separated path of each keystore relative to the manifest directory
+type PasswordManifest struct {
+	Path      string
+	Problems  []PasswordManifestProblem
+	dir       string
+	passwords map[string]string
+}
+
+// IsPasswordManifest reports whether path names a password manifest
+func IsPasswordManifest(path string) bool {
+	base := filepath.Base(path)
+	for _, name := range PasswordManifestNames {
+		if strings.EqualFold(base, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// PasswordFor returns the password the manifest lists for keystorePath
+func (m *PasswordManifest) PasswordFor(keystorePath string) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(m.dir, keystorePath)
+	if err != nil {
+		return "", false
+	}
+	password, ok := m.passwords[filepath.ToSlash(rel)]
+	return password, ok
+}
+
+// LoadPasswordManifest reads the password manifest of dir, returning nil
+// when dir has none. Rows naming files that do not exist, duplicated rows and
+// rows without a password are left out and listed in Problems.
+func (pfm *PasswordFileManager) LoadPasswordManifest(dir string) (*PasswordManifest, error) {
+	for _, name := range PasswordManifestNames {
+		path := filepath.Join(dir, name)
+		info, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, NewPasswordFileError(PasswordFileUnreadable, path, fmt.Sprintf("Cannot access password manifest: %s", path), err)
+		}
+		if !info.Mode().IsRegular() {
+			return nil, NewPasswordFileError(PasswordFileInvalid, path, fmt.Sprintf("Password manifest is not a regular file: %s", path), nil)
+		}
+		if info.Size() > maxManifestSize {
+			return nil, NewPasswordFileError(PasswordFileOversized, path, fmt.Sprintf("Password manifest is too large (max %d bytes): %s", maxManifestSize, path), nil)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, NewPasswordFileError(PasswordFileUnreadable, path, fmt.Sprintf("Failed to read password manifest: %s", path), err)
+		}
+		manifest := &PasswordManifest{Path: path, dir: dir, passwords: make(map[string]string)}
+		if strings.EqualFold(filepath.Ext(name), ".csv") {
+			err = manifest.parseCSV(data)
+		} else {
+			err = manifest.parseJSON(data)
+		}
+		if err != nil {
+			return nil, NewPasswordFileError(PasswordFileCorrupted, path, fmt.Sprintf("Password manifest cannot be parsed: %s", path), err)
+		}
+		return manifest, nil
+	}
+	return nil, nil
+}
+
+func (m *PasswordManifest) parseCSV(data []byte) error {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	for first := true; ; first = false {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := r.FieldPos(0)
+		if first && len(record) >= 2 && isManifestHeader(record[0], record[1]) {
+			continue
+		}
+		if len(record) < 2 || strings.TrimSpace(record[0]) == "" {
+			m.Problems = append(m.Problems, PasswordManifestProblem{Manifest: m.Path, Line: line, Type: ManifestMalformedRow})
+			continue
+		}
+		m.add(line, record[0], record[1])
+	}
+}
+
+func (m *PasswordManifest) parseJSON(data []byte) error {
+	var entries map[string]string
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	files := make([]string, 0, len(entries))
+	for file := range entries {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		m.add(0, file, entries[file])
+	}
+	return nil
+}
+
+// add records the password of a row, or the reason it cannot be used
+func (m *PasswordManifest) add(line int, file, password string) {
+	file = strings.TrimSpace(file)
+	problem := PasswordManifestProblem{Manifest: m.Path, Line: line, File: file}
+	key := filepath.ToSlash(filepath.Clean(filepath.FromSlash(file)))
+	switch {
+	case !filepath.IsLocal(filepath.FromSlash(file)):
+		problem.Type = ManifestInvalidPath
+	case password == "":
+		problem.Type = ManifestEmptyPassword
+	case m.passwords[key] != "":
+		problem.Type = ManifestDuplicateEntry
+	default:
+		if _, err := os.Stat(filepath.Join(m.dir, filepath.FromSlash(key))); err != nil {
+			problem.Type = ManifestMissingFile
+		} else {
+			m.passwords[key] = password
+			return
+		}
+	}
+	m.Problems = append(m.Problems, problem)
+}
+
+func isManifestHeader(file, password string) bool {
+	file = strings.ToLower(strings.TrimSpace(file))
+	return (file == "file" || file == "filename" || file == "keystore") && strings.EqualFold(strings.TrimSpace(password), "password")
+}
+
+// passwordManifests caches the manifests read while creating the jobs of one
+// import, by directory; a nil entry means the directory has none
+type passwordManifests map[string]*PasswordManifest
+
+// manifestPassword looks keystorePath up in the manifest of its directory and
+// then in the manifest of root, the directory being imported
+func (bis *BatchImportService) manifestPassword(manifests passwordManifests, keystorePath, root string) (string, bool, error) {
+	dirs := []string{filepath.Dir(keystorePath)}
+	if root != "" && root != dirs[0] {
+		dirs = append(dirs, root)
+	}
+	for _, dir := range dirs {
+		manifest, loaded := manifests[dir]
+		if !loaded {
+			var err error
+			if manifest, err = bis.passwordMgr.LoadPasswordManifest(dir); err != nil {
+				return "", false, err
+			}
+			manifests[dir] = manifest
+		}
+		if password, ok := manifest.PasswordFor(keystorePath); ok {
+			return password, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// recordManifestProblems keeps the problems of the manifests read for the
+// last import, reported by ManifestProblems and the import summary
+func (bis *BatchImportService) recordManifestProblems(manifests passwordManifests) {
+	var problems []PasswordManifestProblem
+	for _, manifest := range manifests {
+		if manifest != nil {
+			problems = append(problems, manifest.Problems...)
+		}
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Manifest != problems[j].Manifest {
+			return problems[i].Manifest < problems[j].Manifest
+		}
+		return problems[i].Line < problems[j].Line
+	})
+
+	bis.manifestMu.Lock()
+	bis.manifestProblems = problems
+	bis.manifestMu.Unlock()
+}
+
+// ManifestProblems returns the manifest rows left out of the last import
+func (bis *BatchImportService) ManifestProblems() []PasswordManifestProblem {
+	bis.manifestMu.Lock()
+	defer bis.manifestMu.Unlock()
+	return append([]PasswordManifestProblem(nil), bis.manifestProblems...)
+}
@@ -0,0 +1,90 @@
+package wallet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// copyKeystore copies a new keystore encrypted with password to dir/name
+func copyKeystore(t *testing.T, dir, name, password string) string {
+	source, _ := createTestKeystoreFile(t, password)
+	data, err := os.ReadFile(source)
+	require.NoError(t, err)
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+func TestCreateImportJobs_PasswordManifest(t *testing.T) {
+	dir := t.TempDir()
+	alice := copyKeystore(t, dir, "alice.json", "alice-secret")
+	bob := copyKeystore(t, dir, "nested/bob.json", "bob-secret")
+	carol := copyKeystore(t, dir, "carol.json", "carol-secret")
+	dave := copyKeystore(t, dir, "dave.json", "dave-secret")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dave.pwd"), []byte("dave-secret"), 0600))
+
+	manifest := "file,password\n" +
+		"alice.json,alice-secret\n" +
+		"nested/bob.json,bob-secret\n" +
+		"# carol is typed by hand\n" +
+		"dave.json,wrong\n" +
+		"erin.json,erin-secret\n" +
+		"alice.json,other\n" +
+		"../outside.json,secret\n" +
+		"frank.json,\n" +
+		"lonely\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "passwords.csv"), []byte(manifest), 0600))
+
+	service := NewBatchImportService(&WalletService{})
+	jobs, err := service.CreateImportJobsFromDirectory(dir)
+	require.NoError(t, err)
+	byPath := make(map[string]ImportJob)
+	for _, job := range jobs {
+		byPath[job.KeystorePath] = job
+	}
+	require.Len(t, byPath, 4)
+
+	assert.Equal(t, "alice-secret", byPath[alice].ManualPassword)
+	assert.False(t, byPath[alice].RequiresInput)
+	assert.Equal(t, "bob-secret", byPath[bob].ManualPassword, "the manifest of the imported directory lists its subdirectories")
+	assert.True(t, byPath[carol].RequiresInput, "keystores missing from the manifest still ask for their password")
+	assert.Empty(t, byPath[dave].ManualPassword, "a .pwd file takes precedence over the manifest")
+	assert.NotEmpty(t, byPath[dave].PasswordPath)
+
+	problems := service.ManifestProblems()
+	require.Len(t, problems, 5)
+	assert.Equal(t, PasswordManifestProblem{Manifest: filepath.Join(dir, "passwords.csv"), Line: 6, File: "erin.json", Type: ManifestMissingFile}, problems[0])
+	assert.Equal(t, ManifestDuplicateEntry, problems[1].Type)
+	assert.Equal(t, ManifestInvalidPath, problems[2].Type)
+	assert.Equal(t, ManifestEmptyPassword, problems[3].Type)
+	assert.Equal(t, ManifestMalformedRow, problems[4].Type)
+	assert.Equal(t, "passwords.csv:6: erin.json does not exist", problems[0].String())
+	assert.Equal(t, problems, service.GetImportSummary(nil).ManifestProblems)
+
+	// A JSON manifest is not mistaken for an invalid keystore
+	require.NoError(t, os.Remove(filepath.Join(dir, "passwords.csv")))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "passwords.json"), []byte(`{"carol.json": "carol-secret", "gone.json": "x"}`), 0600))
+	files, scanErrors, err := service.ScanDirectoryForKeystores(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 4)
+	assert.Empty(t, scanErrors)
+
+	jobs, err = service.CreateImportJobsFromFiles([]string{carol, filepath.Join(dir, "passwords.json")})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1, "a manifest picked among the files is not imported")
+	assert.Equal(t, "carol-secret", jobs[0].ManualPassword)
+	require.Len(t, service.ManifestProblems(), 1)
+	assert.Equal(t, "gone.json", service.ManifestProblems()[0].File)
+
+	// A manifest that cannot be parsed stops the import
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "passwords.json"), []byte(`["carol.json"]`), 0600))
+	_, err = service.CreateImportJobsFromFiles([]string{carol})
+	var passwordErr *PasswordFileError
+	require.ErrorAs(t, err, &passwordErr)
+	assert.Equal(t, PasswordFileCorrupted, passwordErr.Type)
+}
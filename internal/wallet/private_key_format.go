@@ -0,0 +1,118 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// PrivateKeyFormat is the encoding a private key was given in
+type PrivateKeyFormat string
+
+const (
+	PrivateKeyFormatHex    PrivateKeyFormat = "hex"
+	PrivateKeyFormatBase64 PrivateKeyFormat = "base64"
+	PrivateKeyFormatWIF    PrivateKeyFormat = "wif"
+)
+
+// ErrPrivateKeyFormat is returned for input in none of the supported encodings
+var ErrPrivateKeyFormat = errors.New("unrecognized private key format")
+
+// wifVersion is the version byte of Bitcoin mainnet WIF keys, the form
+// paper wallets and QR codes of other wallets often carry the key in
+const wifVersion = 0x80
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// ParsedPrivateKey is a private key read by ParsePrivateKey, with the address
+// it controls so the user can check it before importing
+type ParsedPrivateKey struct {
+	Hex     string // 64 hex digits, without 0x
+	Format  PrivateKeyFormat
+	Address common.Address
+}
+
+// ParsePrivateKey detects the encoding of a private key and decodes it. It
+// accepts 64 hex digits with or without 0x, the 32 bytes in standard or
+// URL-safe base64, and WIF (Base58Check with version 0x80 and an optional
+// compression flag). Hex digits are kept as typed, as they feed the source
+// hash of private key wallets.
+func ParsePrivateKey(input string) (*ParsedPrivateKey, error) {
+	input = strings.TrimSpace(input)
+
+	var keyHex string
+	var format PrivateKeyFormat
+	digits := strings.TrimPrefix(strings.TrimPrefix(input, "0x"), "0X")
+	if _, err := hex.DecodeString(digits); err == nil && len(digits) == 64 {
+		keyHex, format = digits, PrivateKeyFormatHex
+	} else if key, ok := decodeBase64Key(input); ok {
+		keyHex, format = hex.EncodeToString(key), PrivateKeyFormatBase64
+	} else if key, ok := decodeWIF(input); ok {
+		keyHex, format = hex.EncodeToString(key), PrivateKeyFormatWIF
+	} else {
+		return nil, ErrPrivateKeyFormat
+	}
+
+	key, err := HexToECDSA(keyHex)
+	if err != nil {
+		return nil, ErrPrivateKeyFormat
+	}
+	return &ParsedPrivateKey{Hex: keyHex, Format: format, Address: crypto.PubkeyToAddress(key.PublicKey)}, nil
+}
+
+// decodeBase64Key decodes a 32-byte key in any of the base64 alphabets
+func decodeBase64Key(input string) ([]byte, bool) {
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if key, err := encoding.DecodeString(input); err == nil && len(key) == 32 {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// decodeWIF decodes a key in Wallet Import Format
+func decodeWIF(input string) ([]byte, bool) {
+	data, ok := decodeBase58(input)
+	if !ok || len(data) < 5 {
+		return nil, false
+	}
+	payload, checksum := data[:len(data)-4], data[len(data)-4:]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:4], checksum) || payload[0] != wifVersion {
+		return nil, false
+	}
+	switch key := payload[1:]; {
+	case len(key) == 32:
+		return key, true
+	case len(key) == 33 && key[32] == 0x01:
+		return key[:32], true
+	}
+	return nil, false
+}
+
+// decodeBase58 decodes the Bitcoin base58 alphabet, leading 1s being zero bytes
+func decodeBase58(input string) ([]byte, bool) {
+	if input == "" {
+		return nil, false
+	}
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for _, r := range input {
+		digit := strings.IndexRune(base58Alphabet, r)
+		if digit < 0 {
+			return nil, false
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+	zeros := len(input) - len(strings.TrimLeft(input, "1"))
+	return append(make([]byte, zeros), n.Bytes()...), true
+}
@@ -0,0 +1,66 @@
+package wallet
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePrivateKey(t *testing.T) {
+	// Key and WIF strings of the Bitcoin wiki example
+	keyHex := "0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d"
+	keyBytes, err := hex.DecodeString(keyHex)
+	require.NoError(t, err)
+	key, err := crypto.ToECDSA(keyBytes)
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	tests := []struct {
+		name   string
+		input  string
+		format PrivateKeyFormat
+	}{
+		{"hex", keyHex, PrivateKeyFormatHex},
+		{"hex with 0x", "0x" + keyHex, PrivateKeyFormatHex},
+		{"hex with spaces", "  0X" + keyHex + "\n", PrivateKeyFormatHex},
+		{"base64", base64.StdEncoding.EncodeToString(keyBytes), PrivateKeyFormatBase64},
+		{"base64 url unpadded", base64.RawURLEncoding.EncodeToString(keyBytes), PrivateKeyFormatBase64},
+		{"wif", "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ", PrivateKeyFormatWIF},
+		{"wif compressed", "KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98617", PrivateKeyFormatWIF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParsePrivateKey(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.format, parsed.Format)
+			assert.Equal(t, address, parsed.Address)
+			assert.Equal(t, keyHex, parsed.Hex)
+		})
+	}
+}
+
+func TestParsePrivateKey_KeepsHexAsTyped(t *testing.T) {
+	upper := "0C28FCA386C7A227600B2FE50B7CAE11EC86D3BF1FBE471BE89827E19D72AA1D"
+	parsed, err := ParsePrivateKey("0x" + upper)
+	require.NoError(t, err)
+	assert.Equal(t, upper, parsed.Hex)
+}
+
+func TestParsePrivateKey_Invalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"1234",
+		"0x" + "zz28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d",
+		"5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTK", // checksum
+		base64.StdEncoding.EncodeToString(make([]byte, 31)),
+		"0x" + "0000000000000000000000000000000000000000000000000000000000000000",
+	} {
+		_, err := ParsePrivateKey(input)
+		assert.ErrorIs(t, err, ErrPrivateKeyFormat, "input %q", input)
+	}
+}
@@ -1,11 +1,24 @@
 package wallet
 
+import (
+	"errors"
+	"time"
+)
+
+// ErrStaleWallet is returned when a wallet was changed or removed by another
+// session since it was loaded; the caller should reload it and try again
+var ErrStaleWallet = errors.New("wallet was changed by another session")
+
+// WalletRepository persists wallets. Updates and deletes take the version the
+// caller loaded and fail with ErrStaleWallet when the stored row has moved on.
 type WalletRepository interface {
 	AddWallet(wallet *Wallet) error
 	GetAllWallets() ([]Wallet, error)
-	DeleteWallet(walletID int) error
+	DeleteWallet(walletID, version int) error
 	FindBySourceHash(sourceHash string) (*Wallet, error)
 	FindByAddress(address string) ([]Wallet, error)
 	FindByAddressAndMethod(address, importMethod string) ([]Wallet, error)
+	UpdateWalletName(walletID, version int, name string, updatedAt time.Time) error
+	UpdateWalletNotes(walletID, version int, notes string, references WalletReferences, updatedAt time.Time) error
 	Close() error
 }
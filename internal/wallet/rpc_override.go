@@ -0,0 +1,107 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"blocowallet/pkg/config"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RPCOverride is the RPC endpoint a wallet uses on one chain instead of the
+// endpoint of the network, e.g. a personal node or a private mempool
+type RPCOverride struct {
+	ID          int       `gorm:"primaryKey"`
+	Address     string    `gorm:"not null;uniqueIndex:idx_rpc_override"`
+	ChainID     int64     `gorm:"not null;uniqueIndex:idx_rpc_override"`
+	RPCEndpoint string    `gorm:"not null"`
+	UpdatedAt   time.Time `gorm:"not null;autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados
+func (RPCOverride) TableName() string {
+	return "wallet_rpc_overrides"
+}
+
+// RPCOverrideStore is implemented by repositories that keep per-wallet RPC
+// endpoints. Addresses are stored checksummed.
+type RPCOverrideStore interface {
+	SetRPCOverride(override *RPCOverride) error
+	DeleteRPCOverride(address string, chainID int64) error
+	RPCOverrides(address string) ([]RPCOverride, error)
+}
+
+func (ws *WalletService) rpcOverrideStore() (RPCOverrideStore, error) {
+	store, ok := ws.Repo.(RPCOverrideStore)
+	if !ok {
+		return nil, errors.New("the wallet repository does not keep RPC overrides")
+	}
+	return store, nil
+}
+
+// SetRPCOverride makes address use endpoint on chainID; an empty endpoint
+// removes the override
+func (ws *WalletService) SetRPCOverride(address string, chainID int64, endpoint string) error {
+	store, err := ws.rpcOverrideStore()
+	if err != nil {
+		return err
+	}
+	if !common.IsHexAddress(address) {
+		return fmt.Errorf("invalid address %q", address)
+	}
+	address = common.HexToAddress(address).Hex()
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		return store.DeleteRPCOverride(address, chainID)
+	}
+	if err := validateRPCOverride(endpoint); err != nil {
+		return err
+	}
+	return store.SetRPCOverride(&RPCOverride{Address: address, ChainID: chainID, RPCEndpoint: endpoint})
+}
+
+// RPCOverrides lists the overrides of address, by chain ID
+func (ws *WalletService) RPCOverrides(address string) ([]RPCOverride, error) {
+	store, err := ws.rpcOverrideStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.RPCOverrides(common.HexToAddress(address).Hex())
+}
+
+// NetworkFor returns network as address should reach it: with the endpoint
+// of the wallet's override for the chain, when there is one. Repositories
+// without overrides and lookup failures leave network unchanged.
+func (ws *WalletService) NetworkFor(address string, network config.Network) config.Network {
+	if ws == nil || address == "" {
+		return network
+	}
+	overrides, err := ws.RPCOverrides(address)
+	if err != nil {
+		return network
+	}
+	for _, o := range overrides {
+		if o.ChainID == network.ChainID {
+			network.RPCEndpoint = o.RPCEndpoint
+			break
+		}
+	}
+	return network
+}
+
+func validateRPCOverride(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid RPC endpoint %q", endpoint)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "ws", "wss":
+		return nil
+	default:
+		return fmt.Errorf("invalid RPC endpoint %q: expected an http(s) or ws(s) URL", endpoint)
+	}
+}
@@ -0,0 +1,242 @@
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+	ageArmor "filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgpArmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	pgpErrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+)
+
+// ExportSecret identifies which secret of a wallet is exported
+type ExportSecret string
+
+const (
+	ExportSecretMnemonic ExportSecret = "mnemonic"
+	ExportSecretKeystore ExportSecret = "keystore"
+	// A KeyStore V3 file under a new password, written by ExportKeystore
+	// rather than encrypted to a recipient
+	ExportSecretKeystoreV3 ExportSecret = "keystore-v3"
+)
+
+// RecipientType identifies the encryption scheme of an export recipient
+type RecipientType string
+
+const (
+	RecipientAge RecipientType = "age"
+	RecipientGPG RecipientType = "gpg"
+)
+
+const pgpPublicKeyHeader = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+
+var (
+	ErrUnknownRecipient = errors.New("recipient must be an age public key (age1...), an age recipients file or an armored GPG public key")
+	ErrNoMnemonic       = errors.New("wallet has no mnemonic to export")
+)
+
+// ExportRecipient is the public key secrets are encrypted to before leaving the application
+type ExportRecipient struct {
+	Type        RecipientType
+	Description string // Human readable identity (age key or GPG user ID)
+
+	ageRecipients []age.Recipient
+	pgpEntities   openpgp.EntityList
+}
+
+// ParseExportRecipient accepts an age public key, the path to an age recipients
+// file or armored GPG public key, or a pasted armored GPG public key
+func ParseExportRecipient(spec string) (*ExportRecipient, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, ErrUnknownRecipient
+	}
+
+	if strings.HasPrefix(spec, "age1") {
+		recipient, err := age.ParseX25519Recipient(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid age recipient: %w", err)
+		}
+		return &ExportRecipient{Type: RecipientAge, Description: spec, ageRecipients: []age.Recipient{recipient}}, nil
+	}
+
+	data := []byte(spec)
+	if !strings.Contains(spec, pgpPublicKeyHeader) {
+		fileData, err := os.ReadFile(spec)
+		if err != nil {
+			return nil, ErrUnknownRecipient
+		}
+		data = fileData
+	}
+
+	if bytes.Contains(data, []byte(pgpPublicKeyHeader)) {
+		entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid GPG public key: %w", err)
+		}
+		return &ExportRecipient{Type: RecipientGPG, Description: describePGPEntity(entities[0]), pgpEntities: entities}, nil
+	}
+
+	recipients, err := age.ParseRecipients(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnknownRecipient
+	}
+	return &ExportRecipient{Type: RecipientAge, Description: fmt.Sprintf("%s (%d)", spec, len(recipients)), ageRecipients: recipients}, nil
+}
+
+func describePGPEntity(entity *openpgp.Entity) string {
+	if identity := entity.PrimaryIdentity(); identity != nil {
+		return fmt.Sprintf("%s [%s]", identity.Name, entity.PrimaryKey.KeyIdString())
+	}
+	return entity.PrimaryKey.KeyIdString()
+}
+
+// FileExtension returns the conventional extension for files encrypted to this recipient
+func (r *ExportRecipient) FileExtension() string {
+	if r.Type == RecipientGPG {
+		return ".asc"
+	}
+	return ".age"
+}
+
+// Encrypt writes plaintext encrypted to the recipient as ASCII armor
+func (r *ExportRecipient) Encrypt(dst io.Writer, plaintext []byte) error {
+	switch r.Type {
+	case RecipientAge:
+		armorWriter := ageArmor.NewWriter(dst)
+		w, err := age.Encrypt(armorWriter, r.ageRecipients...)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(plaintext); err != nil {
+			return err
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+		return armorWriter.Close()
+	case RecipientGPG:
+		armorWriter, err := pgpArmor.Encode(dst, "PGP MESSAGE", nil)
+		if err != nil {
+			return err
+		}
+		w, err := openpgp.Encrypt(armorWriter, r.pgpEntities, nil, &openpgp.FileHints{IsBinary: true}, nil)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(plaintext); err != nil {
+			return err
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+		return armorWriter.Close()
+	default:
+		return ErrUnknownRecipient
+	}
+}
+
+// encryptStream returns a writer encrypting to the recipient in binary form,
+// for archives too large to hold in memory
+func (r *ExportRecipient) encryptStream(dst io.Writer) (io.WriteCloser, error) {
+	switch r.Type {
+	case RecipientAge:
+		return age.Encrypt(dst, r.ageRecipients...)
+	case RecipientGPG:
+		return openpgp.Encrypt(dst, r.pgpEntities, nil, &openpgp.FileHints{IsBinary: true}, nil)
+	default:
+		return nil, ErrUnknownRecipient
+	}
+}
+
+// decryptPGP opens a message encrypted to the secret key in keyFile, armored
+// or binary; passphrase unlocks a protected key
+func decryptPGP(src io.Reader, keyFile, passphrase string) (io.Reader, error) {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroBytes(data)
+	var keys openpgp.EntityList
+	if bytes.Contains(data, []byte("-----BEGIN PGP")) {
+		keys, err = openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	} else {
+		keys, err = openpgp.ReadKeyRing(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid GPG secret key: %w", err)
+	}
+	for _, entity := range keys {
+		if err := entity.DecryptPrivateKeys([]byte(passphrase)); err != nil {
+			return nil, fmt.Errorf("%w: the GPG key passphrase is wrong", ErrBackupPassword)
+		}
+	}
+	md, err := openpgp.ReadMessage(src, keys, nil, nil)
+	if errors.Is(err, pgpErrors.ErrKeyIncorrect) {
+		return nil, ErrBackupKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	return md.UnverifiedBody, nil
+}
+
+// ExportEncryptedSecret encrypts a wallet secret to the recipient and writes
+// only the ciphertext to outputPath. The plaintext never touches the disk; the
+// output file is created exclusively with owner-only permissions and removed
+// if encryption fails.
+func (ws *WalletService) ExportEncryptedSecret(details *WalletDetails, secret ExportSecret, recipient *ExportRecipient, outputPath string) error {
+	if details == nil || details.Wallet == nil {
+		return errors.New("no wallet selected")
+	}
+
+	var plaintext []byte
+	switch secret {
+	case ExportSecretMnemonic:
+		if details.Mnemonic == nil || *details.Mnemonic == "" {
+			return ErrNoMnemonic
+		}
+		plaintext = []byte(*details.Mnemonic)
+	case ExportSecretKeystore:
+		data, err := os.ReadFile(details.Wallet.KeyStorePath)
+		if err != nil {
+			return fmt.Errorf("failed to read keystore: %w", err)
+		}
+		plaintext = data
+	default:
+		return fmt.Errorf("unsupported export secret: %s", secret)
+	}
+	defer zeroBytes(plaintext)
+
+	file, err := os.OpenFile(outputPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	if err := recipient.Encrypt(file, plaintext); err != nil {
+		file.Close()
+		os.Remove(outputPath)
+		return fmt.Errorf("failed to encrypt export: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(outputPath)
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	return nil
+}
+
+// DefaultExportFileName suggests a file name for an encrypted export
+func DefaultExportFileName(w *Wallet, secret ExportSecret, recipient *ExportRecipient) string {
+	return fmt.Sprintf("%s-%s%s", w.Address, secret, recipient.FileExtension())
+}
+
+func zeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,127 @@
+package wallet
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"filippo.io/age"
+	ageArmor "filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgpArmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exportTestMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func exportTestDetails(t *testing.T) *WalletDetails {
+	t.Helper()
+	keystorePath := filepath.Join(t.TempDir(), "key.json")
+	require.NoError(t, os.WriteFile(keystorePath, []byte(`{"address":"abc"}`), 0600))
+	mnemonic := exportTestMnemonic
+	return &WalletDetails{
+		Wallet:   &Wallet{Address: "0xABC", KeyStorePath: keystorePath},
+		Mnemonic: &mnemonic,
+	}
+}
+
+func TestExportEncryptedSecret_Age(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	recipient, err := ParseExportRecipient(identity.Recipient().String())
+	require.NoError(t, err)
+	assert.Equal(t, RecipientAge, recipient.Type)
+
+	details := exportTestDetails(t)
+	out := filepath.Join(t.TempDir(), DefaultExportFileName(details.Wallet, ExportSecretMnemonic, recipient))
+	ws := &WalletService{}
+	require.NoError(t, ws.ExportEncryptedSecret(details, ExportSecretMnemonic, recipient, out))
+
+	ciphertext, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.NotContains(t, string(ciphertext), "abandon")
+
+	r, err := age.Decrypt(ageArmor.NewReader(bytes.NewReader(ciphertext)), identity)
+	require.NoError(t, err)
+	plaintext, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, exportTestMnemonic, string(plaintext))
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(out)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	// Never overwrite an existing export
+	assert.Error(t, ws.ExportEncryptedSecret(details, ExportSecretMnemonic, recipient, out))
+}
+
+func TestExportEncryptedSecret_GPG(t *testing.T) {
+	entity, err := openpgp.NewEntity("Heir", "", "heir@example.com", nil)
+	require.NoError(t, err)
+	var pub bytes.Buffer
+	w, err := pgpArmor.Encode(&pub, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+
+	keyFile := filepath.Join(t.TempDir(), "heir.asc")
+	require.NoError(t, os.WriteFile(keyFile, pub.Bytes(), 0600))
+	recipient, err := ParseExportRecipient(keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, RecipientGPG, recipient.Type)
+	assert.Contains(t, recipient.Description, "heir@example.com")
+
+	details := exportTestDetails(t)
+	out := filepath.Join(t.TempDir(), "keystore.asc")
+	require.NoError(t, (&WalletService{}).ExportEncryptedSecret(details, ExportSecretKeystore, recipient, out))
+
+	ciphertext, err := os.ReadFile(out)
+	require.NoError(t, err)
+	block, err := pgpArmor.Decode(bytes.NewReader(ciphertext))
+	require.NoError(t, err)
+	md, err := openpgp.ReadMessage(block.Body, openpgp.EntityList{entity}, nil, nil)
+	require.NoError(t, err)
+	plaintext, err := io.ReadAll(md.UnverifiedBody)
+	require.NoError(t, err)
+	assert.Equal(t, `{"address":"abc"}`, string(plaintext))
+}
+
+func TestDescribePGPEntity_PrimaryIdentity(t *testing.T) {
+	entity, err := openpgp.NewEntity("Heir", "", "heir@example.com", nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.AddUserId("Heir", "work", "heir@work.example", nil))
+	require.Len(t, entity.Identities, 2)
+
+	// Always the primary user ID, never whichever comes first in the map
+	for range 20 {
+		assert.Equal(t, "Heir <heir@example.com> ["+entity.PrimaryKey.KeyIdString()+"]", describePGPEntity(entity))
+	}
+}
+
+func TestExportEncryptedSecret_NoMnemonic(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	recipient, err := ParseExportRecipient(identity.Recipient().String())
+	require.NoError(t, err)
+
+	details := exportTestDetails(t)
+	details.Mnemonic = nil
+	out := filepath.Join(t.TempDir(), "out.age")
+	assert.ErrorIs(t, (&WalletService{}).ExportEncryptedSecret(details, ExportSecretMnemonic, recipient, out), ErrNoMnemonic)
+	assert.NoFileExists(t, out)
+}
+
+func TestParseExportRecipient_Invalid(t *testing.T) {
+	_, err := ParseExportRecipient("")
+	assert.ErrorIs(t, err, ErrUnknownRecipient)
+	_, err = ParseExportRecipient(filepath.Join(t.TempDir(), "missing.asc"))
+	assert.ErrorIs(t, err, ErrUnknownRecipient)
+	_, err = ParseExportRecipient("age1invalid")
+	assert.Error(t, err)
+}
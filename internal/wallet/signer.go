@@ -0,0 +1,159 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SignerKind tells where the key behind a Signer lives
+type SignerKind string
+
+const (
+	SignerLocalKeystore SignerKind = "local_keystore"
+	SignerMnemonic      SignerKind = "mnemonic"
+	SignerHardware      SignerKind = "hardware"
+	SignerKMS           SignerKind = "kms"
+	SignerSmartAccount  SignerKind = "smart_account"
+)
+
+// ErrSignerUnsupported is returned when the key custody cannot perform an
+// operation, e.g. raw hash signing on a hardware wallet
+var ErrSignerUnsupported = errors.New("operation not supported by this signer")
+
+// Signer signs on behalf of a wallet without exposing where the key lives.
+// Signatures are 65 bytes in the [R || S || V] form used by go-ethereum.
+type Signer interface {
+	// Address is the account the signatures are for
+	Address() common.Address
+	Kind() SignerKind
+	// SignHash signs a 32-byte digest, with V as 0 or 1
+	SignHash(hash []byte) ([]byte, error)
+	// SignText signs an EIP-191 personal message, with V as 27 or 28
+	SignText(text []byte) ([]byte, error)
+	// SignTx returns the transaction signed for the chain
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// keySigner signs with a private key held in memory, decrypted from a
+// keystore file or derived from a mnemonic
+type keySigner struct {
+	key  *ecdsa.PrivateKey
+	kind SignerKind
+}
+
+// NewKeySigner returns a signer for a key decrypted from a keystore file
+func NewKeySigner(key *ecdsa.PrivateKey) Signer {
+	return &keySigner{key: key, kind: SignerLocalKeystore}
+}
+
+// NewMnemonicSigner derives the wallet key from the mnemonic the same way
+// wallets are created and returns a signer for it
+func NewMnemonicSigner(mnemonic string) (Signer, error) {
+	privateKeyHex, err := DerivePrivateKey(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	key, err := crypto.HexToECDSA(privateKeyHex)
+	if err != nil {
+		return nil, err
+	}
+	return &keySigner{key: key, kind: SignerMnemonic}, nil
+}
+
+// signerForKey picks the signer kind from how the wallet was imported
+func signerForKey(key *ecdsa.PrivateKey, method ImportMethod) Signer {
+	if method == ImportMethodMnemonic {
+		return &keySigner{key: key, kind: SignerMnemonic}
+	}
+	return NewKeySigner(key)
+}
+
+func (s *keySigner) Address() common.Address { return crypto.PubkeyToAddress(s.key.PublicKey) }
+func (s *keySigner) Kind() SignerKind        { return s.kind }
+
+func (s *keySigner) SignHash(hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, s.key)
+}
+
+func (s *keySigner) SignText(text []byte) ([]byte, error) {
+	return textSignature(s.SignHash(accounts.TextHash(text)))
+}
+
+func (s *keySigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+// textSignature moves V to 27/28, as EIP-191 signatures expect
+func textSignature(sig []byte, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+// accountSigner signs through a go-ethereum accounts.Wallet, which is how
+// Ledger and Trezor devices are driven (accounts/usbwallet)
+type accountSigner struct {
+	wallet  accounts.Wallet
+	account accounts.Account
+}
+
+// NewHardwareSigner returns a signer for an account of a hardware wallet.
+// Devices confirm every signature on screen and do not sign raw hashes.
+func NewHardwareSigner(wallet accounts.Wallet, account accounts.Account) Signer {
+	return &accountSigner{wallet: wallet, account: account}
+}
+
+func (s *accountSigner) Address() common.Address { return s.account.Address }
+func (s *accountSigner) Kind() SignerKind        { return SignerHardware }
+
+func (s *accountSigner) SignHash([]byte) ([]byte, error) {
+	return nil, ErrSignerUnsupported
+}
+
+func (s *accountSigner) SignText(text []byte) ([]byte, error) {
+	sig, err := s.wallet.SignText(s.account, text)
+	if err != nil {
+		return nil, err
+	}
+	// Some backends return V as 0/1, others as 27/28
+	if len(sig) == crypto.SignatureLength && sig[crypto.RecoveryIDOffset] < 27 {
+		sig[crypto.RecoveryIDOffset] += 27
+	}
+	return sig, nil
+}
+
+func (s *accountSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return s.wallet.SignTx(s.account, tx, chainID)
+}
+
+// smartAccountSigner signs for a contract account (ERC-4337 or Safe) with the
+// key of its owner. The contract validates the owner's signatures (EIP-1271);
+// transactions are sent by the owner or a bundler, not signed by the account.
+type smartAccountSigner struct {
+	account common.Address
+	owner   Signer
+}
+
+// NewSmartAccountSigner returns a signer for a contract account owned by owner
+func NewSmartAccountSigner(account common.Address, owner Signer) Signer {
+	return &smartAccountSigner{account: account, owner: owner}
+}
+
+func (s *smartAccountSigner) Address() common.Address { return s.account }
+func (s *smartAccountSigner) Kind() SignerKind        { return SignerSmartAccount }
+
+func (s *smartAccountSigner) SignHash(hash []byte) ([]byte, error) { return s.owner.SignHash(hash) }
+func (s *smartAccountSigner) SignText(text []byte) ([]byte, error) { return s.owner.SignText(text) }
+
+func (s *smartAccountSigner) SignTx(*types.Transaction, *big.Int) (*types.Transaction, error) {
+	return nil, fmt.Errorf("%w: smart account %s executes through its owner %s", ErrSignerUnsupported, s.account.Hex(), s.owner.Address().Hex())
+}
@@ -0,0 +1,97 @@
+package wallet
+
+import (
+	"context"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// kmsTimeout bounds each signing request to the key management service
+const kmsTimeout = 30 * time.Second
+
+// KMSClient is the part of a key management service the signer needs: a
+// secp256k1 signature of a digest, in ASN.1 DER (as AWS and GCP return it) or
+// as raw 64-byte R || S
+type KMSClient interface {
+	SignDigest(ctx context.Context, keyID string, digest []byte) ([]byte, error)
+}
+
+// kmsSigner signs with a key that never leaves the key management service
+type kmsSigner struct {
+	client  KMSClient
+	keyID   string
+	address common.Address
+}
+
+// NewKMSSigner returns a signer for a KMS key whose Ethereum address is known
+func NewKMSSigner(client KMSClient, keyID string, address common.Address) Signer {
+	return &kmsSigner{client: client, keyID: keyID, address: address}
+}
+
+func (s *kmsSigner) Address() common.Address { return s.address }
+func (s *kmsSigner) Kind() SignerKind        { return SignerKMS }
+
+// SignHash asks the service for R and S, then finds the recovery id, which
+// KMS signatures do not carry, by recovering the address
+func (s *kmsSigner) SignHash(hash []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kmsTimeout)
+	defer cancel()
+	raw, err := s.client.SignDigest(ctx, s.keyID, hash)
+	if err != nil {
+		return nil, fmt.Errorf("kms signing failed: %w", err)
+	}
+	r, sValue, err := parseKMSSignature(raw)
+	if err != nil {
+		return nil, err
+	}
+	// Ethereum only accepts the lower of the two valid S values (EIP-2)
+	n := crypto.S256().Params().N
+	if sValue.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		sValue.Sub(n, sValue)
+	}
+
+	sig := make([]byte, crypto.SignatureLength)
+	r.FillBytes(sig[:32])
+	sValue.FillBytes(sig[32:64])
+	for v := byte(0); v < 2; v++ {
+		sig[crypto.RecoveryIDOffset] = v
+		if pub, err := crypto.SigToPub(hash, sig); err == nil && crypto.PubkeyToAddress(*pub) == s.address {
+			return sig, nil
+		}
+	}
+	return nil, fmt.Errorf("kms key %s does not sign for %s", s.keyID, s.address.Hex())
+}
+
+func (s *kmsSigner) SignText(text []byte) ([]byte, error) {
+	return textSignature(s.SignHash(accounts.TextHash(text)))
+}
+
+func (s *kmsSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(chainID)
+	sig, err := s.SignHash(signer.Hash(tx).Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(signer, sig)
+}
+
+// parseKMSSignature reads R and S from a DER or raw signature
+func parseKMSSignature(raw []byte) (*big.Int, *big.Int, error) {
+	if len(raw) == 64 {
+		return new(big.Int).SetBytes(raw[:32]), new(big.Int).SetBytes(raw[32:]), nil
+	}
+	var der struct{ R, S *big.Int }
+	rest, err := asn1.Unmarshal(raw, &der)
+	if err != nil || len(rest) > 0 || der.R == nil || der.S == nil {
+		return nil, nil, errors.New("kms returned an unrecognized signature encoding")
+	}
+	return der.R, der.S, nil
+}
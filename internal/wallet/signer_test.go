@@ -0,0 +1,167 @@
+package wallet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTx() *types.Transaction {
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(11155111),
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	})
+}
+
+// assertSigner checks that the signatures of s recover to its address
+func assertSigner(t *testing.T, s Signer) {
+	t.Helper()
+	chainID := big.NewInt(11155111)
+	signed, err := s.SignTx(testTx(), chainID)
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	assert.Equal(t, s.Address(), sender)
+
+	sig, err := s.SignText([]byte("hello"))
+	require.NoError(t, err)
+	require.Len(t, sig, crypto.SignatureLength)
+	assert.GreaterOrEqual(t, sig[crypto.RecoveryIDOffset], byte(27))
+	sig[crypto.RecoveryIDOffset] -= 27
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte("hello")), sig)
+	require.NoError(t, err)
+	assert.Equal(t, s.Address(), crypto.PubkeyToAddress(*pub))
+}
+
+func TestKeySigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	s := NewKeySigner(key)
+	assert.Equal(t, SignerLocalKeystore, s.Kind())
+	assertSigner(t, s)
+}
+
+func TestMnemonicSigner(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	s, err := NewMnemonicSigner(mnemonic)
+	require.NoError(t, err)
+	assert.Equal(t, SignerMnemonic, s.Kind())
+
+	privateKeyHex, err := DerivePrivateKey(mnemonic)
+	require.NoError(t, err)
+	key, err := crypto.HexToECDSA(privateKeyHex)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), s.Address())
+	assertSigner(t, s)
+
+	_, err = NewMnemonicSigner("not a mnemonic")
+	assert.Error(t, err)
+}
+
+func TestHardwareSigner_UsesAccountsWallet(t *testing.T) {
+	// The keystore implements accounts.Wallet like the USB wallets do
+	n, p := GetTestKeystoreParams()
+	ks := keystore.NewKeyStore(t.TempDir(), n, p)
+	account, err := ks.NewAccount("password")
+	require.NoError(t, err)
+	require.NoError(t, ks.Unlock(account, "password"))
+
+	s := NewHardwareSigner(ks.Wallets()[0], account)
+	assert.Equal(t, SignerHardware, s.Kind())
+	assertSigner(t, s)
+
+	_, err = s.SignHash(make([]byte, 32))
+	assert.ErrorIs(t, err, ErrSignerUnsupported)
+}
+
+// fakeKMS signs like a KMS would: DER encoded, without a recovery id and
+// with whichever S the library produced
+type fakeKMS struct {
+	key    *ecdsa.PrivateKey
+	highS  bool
+	called int
+}
+
+func (f *fakeKMS) SignDigest(_ context.Context, _ string, digest []byte) ([]byte, error) {
+	f.called++
+	sig, err := crypto.Sign(digest, f.key)
+	if err != nil {
+		return nil, err
+	}
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	if f.highS {
+		s.Sub(crypto.S256().Params().N, s)
+	}
+	return asn1.Marshal(struct{ R, S *big.Int }{r, s})
+}
+
+func TestKMSSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	for _, highS := range []bool{false, true} {
+		kms := &fakeKMS{key: key, highS: highS}
+		s := NewKMSSigner(kms, "alias/wallet", address)
+		assert.Equal(t, SignerKMS, s.Kind())
+		assertSigner(t, s)
+		assert.Equal(t, 2, kms.called)
+	}
+
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	_, err = NewKMSSigner(&fakeKMS{key: other}, "alias/wallet", address).SignHash(make([]byte, 32))
+	assert.Error(t, err, "a key that does not belong to the address is rejected")
+}
+
+func TestSmartAccountSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := NewKeySigner(key)
+	account := common.HexToAddress("0x00000000000000000000000000000000000a11ce")
+	s := NewSmartAccountSigner(account, owner)
+
+	assert.Equal(t, account, s.Address())
+	assert.Equal(t, SignerSmartAccount, s.Kind())
+
+	hash := crypto.Keccak256([]byte("message"))
+	sig, err := s.SignHash(hash)
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(hash, sig)
+	require.NoError(t, err)
+	assert.Equal(t, owner.Address(), crypto.PubkeyToAddress(*pub), "the owner signs for the account")
+
+	_, err = s.SignTx(testTx(), big.NewInt(11155111))
+	assert.ErrorIs(t, err, ErrSignerUnsupported)
+}
+
+func TestLoadWallet_SetsSigner(t *testing.T) {
+	ws := &WalletService{}
+	w := &Wallet{
+		Address:      cipherFixtureAddress,
+		KeyStorePath: "testdata/keystores/real_keystore_v3_aes256_ctr.json",
+		ImportMethod: string(ImportMethodKeystore),
+	}
+	details, err := ws.LoadWallet(w, "testpassword")
+	require.NoError(t, err)
+	require.NotNil(t, details.Signer)
+	assert.Equal(t, SignerLocalKeystore, details.Signer.Kind())
+	assert.Equal(t, common.HexToAddress(cipherFixtureAddress), details.Signer.Address())
+}
@@ -0,0 +1,292 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StoreIssueKind classifies a disagreement between the wallet database and
+// the keystore directory
+type StoreIssueKind string
+
+const (
+	// StoreIssueMissingKeystore is a wallet whose keystore file is gone
+	StoreIssueMissingKeystore StoreIssueKind = "missing_keystore"
+	// StoreIssueOrphanKeystore is a keystore file no wallet points at
+	StoreIssueOrphanKeystore StoreIssueKind = "orphan_keystore"
+	// StoreIssueAddressMismatch is a keystore file declaring another address
+	// than the wallet pointing at it
+	StoreIssueAddressMismatch StoreIssueKind = "address_mismatch"
+	// StoreIssueUnreadableKeystore is a wallet whose keystore file cannot be
+	// read or is not a keystore
+	StoreIssueUnreadableKeystore StoreIssueKind = "unreadable_keystore"
+)
+
+// StoreRepair is a fix offered for a StoreIssue
+type StoreRepair string
+
+const (
+	// RepairRelink points the wallet at the keystore in Candidate
+	RepairRelink StoreRepair = "relink"
+	// RepairReimport imports an orphan keystore as a new wallet
+	RepairReimport StoreRepair = "reimport"
+	// RepairRemoveRow deletes a wallet whose keystore is gone
+	RepairRemoveRow StoreRepair = "remove"
+	// RepairQuarantine moves the wallet and its keystore to the quarantine
+	RepairQuarantine StoreRepair = "quarantine"
+)
+
+// StoreIssue is one problem found by VerifyStore
+type StoreIssue struct {
+	Kind StoreIssueKind `json:"kind"`
+	// Wallet is the database row concerned; nil for orphan keystores
+	Wallet *Wallet `json:"wallet,omitempty"`
+	// Path is the keystore file concerned
+	Path string `json:"path"`
+	// Address is the address declared by the keystore file, when it has one
+	Address string `json:"address,omitempty"`
+	// Candidate is an unreferenced keystore in the directory holding the
+	// wallet's address, to relink the wallet to
+	Candidate string `json:"candidate,omitempty"`
+	// Duplicate is set on an orphan holding the address of a wallet already
+	// in the database, which importing again would duplicate
+	Duplicate bool   `json:"duplicate,omitempty"`
+	Detail    string `json:"detail"`
+}
+
+// Repairs lists the fixes that apply to the issue, the safest first
+func (i StoreIssue) Repairs() []StoreRepair {
+	var repairs []StoreRepair
+	if i.Candidate != "" {
+		repairs = append(repairs, RepairRelink)
+	}
+	switch i.Kind {
+	case StoreIssueMissingKeystore:
+		repairs = append(repairs, RepairRemoveRow)
+	case StoreIssueOrphanKeystore:
+		if !i.Duplicate {
+			repairs = append(repairs, RepairReimport)
+		}
+	case StoreIssueAddressMismatch, StoreIssueUnreadableKeystore:
+		repairs = append(repairs, RepairQuarantine)
+	}
+	return repairs
+}
+
+// StoreReport is the outcome of VerifyStore
+type StoreReport struct {
+	KeystoreDir string       `json:"keystore_dir"`
+	Wallets     int          `json:"wallets"`
+	Keystores   int          `json:"keystores"`
+	Issues      []StoreIssue `json:"issues"`
+}
+
+// OK reports whether the database and the keystore directory agree
+func (r *StoreReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// KeystorePathUpdater is implemented by repositories that can point a wallet
+// at another keystore file, with the same version check as renames
+type KeystorePathUpdater interface {
+	UpdateKeystorePath(walletID, version int, path string) error
+}
+
+// declaredAddress reads the address a keystore file declares, without
+// decrypting it
+func declaredAddress(data []byte) (common.Address, error) {
+	var file struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return common.Address{}, fmt.Errorf("not a keystore file: %v", err)
+	}
+	if !common.IsHexAddress(file.Address) {
+		return common.Address{}, errors.New("not a keystore file: no address field")
+	}
+	return common.HexToAddress(file.Address), nil
+}
+
+// VerifyStore cross-checks the wallet database with the keystore directory:
+// wallets whose keystore is missing, unreadable or declares another address,
+// and keystores no wallet points at. Keys are never decrypted, so it needs no
+// password. Watch-only wallets have no keystore and are skipped.
+func (ws *WalletService) VerifyStore() (*StoreReport, error) {
+	dir, err := ws.keystoreDir()
+	if err != nil {
+		return nil, err
+	}
+	wallets, err := ws.Repo.GetAllWallets()
+	if err != nil {
+		return nil, err
+	}
+	report := &StoreReport{KeystoreDir: dir, Wallets: len(wallets)}
+
+	// Keystores of the directory by path, with the address they declare
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]common.Address)
+	var order []string
+	for _, entry := range entries {
+		// Hidden files are keystores being deleted
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if address, err := declaredAddress(data); err == nil {
+			files[path] = address
+			order = append(order, path)
+		}
+	}
+	report.Keystores = len(files)
+
+	referenced := make(map[string]bool)
+	known := make(map[common.Address]*Wallet)
+	for i := range wallets {
+		if wallets[i].WatchOnly() || wallets[i].KeyStorePath == "" {
+			continue
+		}
+		referenced[filepath.Clean(wallets[i].KeyStorePath)] = true
+		known[common.HexToAddress(wallets[i].Address)] = &wallets[i]
+	}
+	// candidate is an unreferenced keystore of address
+	candidate := func(address common.Address) string {
+		for _, path := range order {
+			if !referenced[path] && files[path] == address {
+				return path
+			}
+		}
+		return ""
+	}
+
+	offered := make(map[string]bool)
+	for i := range wallets {
+		w := &wallets[i]
+		if w.WatchOnly() || w.KeyStorePath == "" {
+			continue
+		}
+		stored := common.HexToAddress(w.Address)
+		issue := StoreIssue{Wallet: w, Path: w.KeyStorePath}
+		data, err := os.ReadFile(w.KeyStorePath)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			issue.Kind = StoreIssueMissingKeystore
+			issue.Detail = "the keystore file does not exist"
+		case err != nil:
+			issue.Kind = StoreIssueUnreadableKeystore
+			issue.Detail = err.Error()
+		default:
+			address, err := declaredAddress(data)
+			if err != nil {
+				issue.Kind = StoreIssueUnreadableKeystore
+				issue.Detail = err.Error()
+				break
+			}
+			if address == stored {
+				continue
+			}
+			issue.Kind = StoreIssueAddressMismatch
+			issue.Address = address.Hex()
+			issue.Detail = fmt.Sprintf("the keystore file declares %s, not %s", address.Hex(), stored.Hex())
+		}
+		issue.Candidate = candidate(stored)
+		offered[issue.Candidate] = true
+		report.Issues = append(report.Issues, issue)
+	}
+
+	// Orphans offered for a relink are fixed with the wallet
+	for _, path := range order {
+		if referenced[path] || offered[path] {
+			continue
+		}
+		issue := StoreIssue{
+			Kind:    StoreIssueOrphanKeystore,
+			Path:    path,
+			Address: files[path].Hex(),
+			Detail:  "no wallet points at this keystore file",
+		}
+		if owner, ok := known[files[path]]; ok {
+			issue.Duplicate = true
+			issue.Detail = fmt.Sprintf("another keystore of wallet %q, which points at %s", owner.Name, owner.KeyStorePath)
+		}
+		report.Issues = append(report.Issues, issue)
+	}
+	return report, nil
+}
+
+// RepairStoreIssue applies repair to an issue found by VerifyStore. Only
+// re-importing an orphan uses name and password; the copy written by the
+// import replaces the orphan file.
+func (ws *WalletService) RepairStoreIssue(issue StoreIssue, repair StoreRepair, name, password string) error {
+	offered := false
+	for _, r := range issue.Repairs() {
+		offered = offered || r == repair
+	}
+	if !offered {
+		return fmt.Errorf("%s does not apply to a %s issue", repair, issue.Kind)
+	}
+
+	switch repair {
+	case RepairRelink:
+		return ws.relinkKeystore(issue.Wallet, issue.Candidate)
+	case RepairRemoveRow:
+		// Only a wallet whose key is really gone is removed
+		if _, err := os.Stat(issue.Wallet.KeyStorePath); !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("the keystore file %s is present again", issue.Wallet.KeyStorePath)
+		}
+		return ws.Repo.DeleteWallet(issue.Wallet.ID, issue.Wallet.Version)
+	case RepairQuarantine:
+		_, err := ws.QuarantineWallet(issue.Wallet, []string{issue.Detail})
+		return err
+	case RepairReimport:
+		details, err := ws.ImportWalletFromKeystoreV3(name, issue.Path, password)
+		if err != nil {
+			return err
+		}
+		if filepath.Clean(details.Wallet.KeyStorePath) != filepath.Clean(issue.Path) {
+			if err := os.Remove(issue.Path); err != nil && svcLogger != nil {
+				svcLogger.Warn("Failed to remove re-imported keystore file " + issue.Path + ": " + err.Error())
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown repair %q", repair)
+}
+
+// relinkKeystore points the wallet at path, once the file is confirmed to
+// declare the wallet's address
+func (ws *WalletService) relinkKeystore(w *Wallet, path string) error {
+	updater, ok := ws.Repo.(KeystorePathUpdater)
+	if !ok {
+		return errors.New("the wallet repository cannot relink keystores")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	address, err := declaredAddress(data)
+	if err != nil {
+		return err
+	}
+	if address != common.HexToAddress(w.Address) {
+		return fmt.Errorf("%s declares %s, not %s", path, address.Hex(), w.Address)
+	}
+	if err := updater.UpdateKeystorePath(w.ID, w.Version, path); err != nil {
+		return err
+	}
+	w.KeyStorePath = path
+	w.Version++
+	return nil
+}
@@ -0,0 +1,178 @@
+package wallet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// storeRepository keeps wallets in memory and can relink their keystores
+type storeRepository struct {
+	*MockWalletRepository
+	wallets []Wallet
+}
+
+func (r *storeRepository) GetAllWallets() ([]Wallet, error) {
+	return append([]Wallet(nil), r.wallets...), nil
+}
+
+func (r *storeRepository) AddWallet(w *Wallet) error {
+	w.ID = len(r.wallets) + 100
+	w.Version = 1
+	r.wallets = append(r.wallets, *w)
+	return nil
+}
+
+func (r *storeRepository) DeleteWallet(walletID, version int) error {
+	for i, w := range r.wallets {
+		if w.ID == walletID {
+			if w.Version != version {
+				return ErrStaleWallet
+			}
+			r.wallets = append(r.wallets[:i], r.wallets[i+1:]...)
+		}
+	}
+	return nil
+}
+
+func (r *storeRepository) UpdateKeystorePath(walletID, version int, path string) error {
+	for i, w := range r.wallets {
+		if w.ID == walletID && w.Version == version {
+			r.wallets[i].KeyStorePath = path
+			r.wallets[i].Version++
+			return nil
+		}
+	}
+	return ErrStaleWallet
+}
+
+func newStoreTestKey(t *testing.T) *keystore.Key {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return &keystore.Key{Id: uuid.New(), Address: crypto.PubkeyToAddress(privateKey.PublicKey), PrivateKey: privateKey}
+}
+
+// findIssue returns the issue of kind, failing the test when there is none
+func findIssue(t *testing.T, report *StoreReport, kind StoreIssueKind) StoreIssue {
+	for _, issue := range report.Issues {
+		if issue.Kind == kind {
+			return issue
+		}
+	}
+	t.Fatalf("no %s issue in %+v", kind, report.Issues)
+	return StoreIssue{}
+}
+
+func TestVerifyStore(t *testing.T) {
+	dir := t.TempDir()
+	healthy, moved, swapped, orphan := newStoreTestKey(t), newStoreTestKey(t), newStoreTestKey(t), newStoreTestKey(t)
+	healthyPath := encryptTestKey(t, dir, "healthy.json", healthy, 1<<10)
+	movedPath := encryptTestKey(t, dir, "UTC--moved", moved, 1<<10)
+	swappedPath := encryptTestKey(t, dir, "swapped.json", orphan, 1<<10)
+	copyPath := encryptTestKey(t, dir, "healthy-copy.json", healthy, 1<<10)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a keystore"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gone.json.deleting"), []byte(`{"address":"`+moved.Address.Hex()[2:]+`"}`), 0600))
+	garbledPath := filepath.Join(dir, "garbled.json")
+	require.NoError(t, os.WriteFile(garbledPath, []byte("{"), 0600))
+
+	repo := &storeRepository{wallets: []Wallet{
+		{ID: 1, Name: "Healthy", Address: healthy.Address.Hex(), KeyStorePath: healthyPath, ImportMethod: string(ImportMethodKeystore), Version: 1},
+		{ID: 2, Name: "Moved", Address: moved.Address.Hex(), KeyStorePath: filepath.Join(dir, "moved.json"), ImportMethod: string(ImportMethodKeystore), Version: 1},
+		{ID: 3, Name: "Swapped", Address: swapped.Address.Hex(), KeyStorePath: swappedPath, ImportMethod: string(ImportMethodKeystore), Version: 1},
+		{ID: 4, Name: "Garbled", Address: "0x00000000000000000000000000000000000000a4", KeyStorePath: garbledPath, ImportMethod: string(ImportMethodKeystore), Version: 1},
+		{ID: 5, Name: "Watch", Address: "0x00000000000000000000000000000000000000a5", ImportMethod: string(ImportMethodWatchOnly), Version: 1},
+	}}
+	ws := &WalletService{Repo: repo, KeystoreDir: dir}
+
+	report, err := ws.VerifyStore()
+	require.NoError(t, err)
+	assert.False(t, report.OK())
+	assert.Equal(t, 5, report.Wallets)
+	assert.Equal(t, 4, report.Keystores, "only files declaring an address, hidden ones skipped")
+	require.Len(t, report.Issues, 4)
+
+	missing := findIssue(t, report, StoreIssueMissingKeystore)
+	assert.Equal(t, "Moved", missing.Wallet.Name)
+	assert.Equal(t, movedPath, missing.Candidate, "the unreferenced file of the same address")
+	assert.Equal(t, []StoreRepair{RepairRelink, RepairRemoveRow}, missing.Repairs())
+
+	mismatch := findIssue(t, report, StoreIssueAddressMismatch)
+	assert.Equal(t, "Swapped", mismatch.Wallet.Name)
+	assert.Equal(t, orphan.Address.Hex(), mismatch.Address)
+	assert.Empty(t, mismatch.Candidate)
+	assert.Equal(t, []StoreRepair{RepairQuarantine}, mismatch.Repairs())
+
+	unreadable := findIssue(t, report, StoreIssueUnreadableKeystore)
+	assert.Equal(t, "Garbled", unreadable.Wallet.Name)
+
+	duplicate := findIssue(t, report, StoreIssueOrphanKeystore)
+	assert.Equal(t, copyPath, duplicate.Path)
+	assert.True(t, duplicate.Duplicate)
+	assert.Empty(t, duplicate.Repairs(), "importing it again would duplicate Healthy")
+	assert.Error(t, ws.RepairStoreIssue(duplicate, RepairReimport, "Copy", "secret"))
+
+	// Relinking points the wallet at the file found
+	require.NoError(t, ws.RepairStoreIssue(missing, RepairRelink, "", ""))
+	assert.Equal(t, movedPath, repo.wallets[1].KeyStorePath)
+	assert.Equal(t, 2, repo.wallets[1].Version)
+
+	require.NoError(t, os.Remove(copyPath))
+	report, err = ws.VerifyStore()
+	require.NoError(t, err)
+	assert.Len(t, report.Issues, 2)
+}
+
+func TestRepairStoreIssue_RemoveRow(t *testing.T) {
+	dir := t.TempDir()
+	repo := &storeRepository{wallets: []Wallet{
+		{ID: 1, Name: "Lost", Address: "0x00000000000000000000000000000000000000b1", KeyStorePath: filepath.Join(dir, "lost.json"), ImportMethod: string(ImportMethodKeystore), Version: 1},
+	}}
+	ws := &WalletService{Repo: repo, KeystoreDir: dir}
+	report, err := ws.VerifyStore()
+	require.NoError(t, err)
+	missing := findIssue(t, report, StoreIssueMissingKeystore)
+	assert.Equal(t, []StoreRepair{RepairRemoveRow}, missing.Repairs())
+	assert.Error(t, ws.RepairStoreIssue(missing, RepairRelink, "", ""), "no candidate to relink")
+
+	// A keystore that came back is not removed with the row
+	require.NoError(t, os.WriteFile(missing.Path, []byte(`{}`), 0600))
+	assert.ErrorContains(t, ws.RepairStoreIssue(missing, RepairRemoveRow, "", ""), "present again")
+	require.NoError(t, os.Remove(missing.Path))
+
+	require.NoError(t, ws.RepairStoreIssue(missing, RepairRemoveRow, "", ""))
+	assert.Empty(t, repo.wallets)
+}
+
+func TestRepairStoreIssue_Reimport(t *testing.T) {
+	InitCryptoService(CreateMockConfig())
+	dir := t.TempDir()
+	key := newStoreTestKey(t)
+	orphanPath := encryptTestKey(t, dir, "UTC--orphan", key, 1<<10)
+	repo := &storeRepository{}
+	n, p := GetTestKeystoreParams()
+	ws := NewWalletService(repo, keystore.NewKeyStore(t.TempDir(), n, p))
+	ws.KeystoreDir = dir
+
+	report, err := ws.VerifyStore()
+	require.NoError(t, err)
+	orphan := findIssue(t, report, StoreIssueOrphanKeystore)
+	assert.Equal(t, key.Address.Hex(), orphan.Address)
+	assert.Equal(t, []StoreRepair{RepairReimport}, orphan.Repairs())
+
+	assert.Error(t, ws.RepairStoreIssue(orphan, RepairReimport, "Recovered", "wrong"))
+	require.NoError(t, ws.RepairStoreIssue(orphan, RepairReimport, "Recovered", "secret"))
+	require.Len(t, repo.wallets, 1)
+	assert.Equal(t, "Recovered", repo.wallets[0].Name)
+	_, err = os.Stat(orphanPath)
+	assert.ErrorIs(t, err, os.ErrNotExist, "the managed copy replaces the orphan")
+
+	report, err = ws.VerifyStore()
+	require.NoError(t, err)
+	assert.True(t, report.OK())
+}
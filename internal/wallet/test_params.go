@@ -5,6 +5,9 @@ package wallet
 
 import "github.com/ethereum/go-ethereum/accounts/keystore"
 
+// ProductionBuild reports whether the binary was built with the production tag
+const ProductionBuild = false
+
 // Test-optimized scrypt parameters for development
 // These parameters are much faster but less secure - only for testing!
 const (
@@ -5,6 +5,9 @@ package wallet
 
 import "github.com/ethereum/go-ethereum/accounts/keystore"
 
+// ProductionBuild reports whether the binary was built with the production tag
+const ProductionBuild = true
+
 // Production scrypt parameters - secure but slower
 const (
 	// Use standard secure parameters in production
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Generates keystores with the non-standard ciphers some tools emit. The
+// encryption is done here with the primitives directly, independently of the
+// wallet package, so the fixtures check its decryption against another
+// implementation. Run with: go run generate_cipher_keystores.go
+func main() {
+	const privateKeyHex = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+	if err != nil {
+		log.Fatal(err)
+	}
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	plaintext := crypto.FromECDSA(privateKey)
+
+	for _, c := range []struct {
+		file, cipher string
+		keySize      int
+		gcm          bool
+	}{
+		{"real_keystore_v3_aes256_ctr.json", "aes-256-ctr", 32, false},
+		{"real_keystore_v3_aes128_gcm.json", "aes-128-gcm", 16, true},
+		{"real_keystore_v3_aes256_gcm.json", "aes-256-gcm", 32, true},
+	} {
+		salt := randomBytes(32)
+		derivedKey, err := scrypt.Key([]byte("testpassword"), salt, 4096, 8, 6, 32)
+		if err != nil {
+			log.Fatal(err)
+		}
+		block, err := aes.NewCipher(derivedKey[:c.keySize])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var iv, cipherText []byte
+		if c.gcm {
+			gcm, err := cipher.NewGCM(block)
+			if err != nil {
+				log.Fatal(err)
+			}
+			iv = randomBytes(gcm.NonceSize())
+			cipherText = gcm.Seal(nil, iv, plaintext, nil)
+		} else {
+			iv = randomBytes(aes.BlockSize)
+			cipherText = make([]byte, len(plaintext))
+			cipher.NewCTR(block, iv).XORKeyStream(cipherText, plaintext)
+		}
+
+		keystoreJSON, err := json.MarshalIndent(map[string]interface{}{
+			"address": hex.EncodeToString(address[:]),
+			"crypto": map[string]interface{}{
+				"cipher":       c.cipher,
+				"ciphertext":   hex.EncodeToString(cipherText),
+				"cipherparams": map[string]string{"iv": hex.EncodeToString(iv)},
+				"kdf":          "scrypt",
+				"kdfparams": map[string]interface{}{
+					"dklen": 32, "n": 4096, "p": 6, "r": 8,
+					"salt": hex.EncodeToString(salt),
+				},
+				"mac": hex.EncodeToString(crypto.Keccak256(derivedKey[16:32], cipherText)),
+			},
+			"id":      uuid.New().String(),
+			"version": 3,
+		}, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join("keystores", c.file), keystoreJSON, 0644); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Created %s (%s) for %s\n", c.file, c.cipher, address.Hex())
+	}
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatal(err)
+	}
+	return b
+}
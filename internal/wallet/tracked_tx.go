@@ -0,0 +1,152 @@
+package wallet
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TrackedTxState is where a broadcast transaction stands in the tracker
+type TrackedTxState string
+
+const (
+	TrackedPending   TrackedTxState = "pending"   // waiting to be mined
+	TrackedIncluded  TrackedTxState = "included"  // mined, gathering confirmations
+	TrackedFinal     TrackedTxState = "final"     // mined with enough confirmations
+	TrackedReverted  TrackedTxState = "reverted"  // mined, but execution failed
+	TrackedReplaced  TrackedTxState = "replaced"  // its nonce was used by another transaction
+	TrackedCancelled TrackedTxState = "cancelled" // withdrawn by its cancel transaction
+)
+
+// Kinds of tracked transactions
+const (
+	TrackedKindTransfer = "transfer"
+	TrackedKindSpeedUp  = "speed_up"
+	TrackedKindCancel   = "cancel"
+)
+
+// TrackedTransaction is a broadcast transaction followed until it is final.
+// The raw transaction is kept so it can be replaced with higher fees.
+type TrackedTransaction struct {
+	ID            int       `gorm:"primaryKey"`
+	Hash          string    `gorm:"not null;uniqueIndex"`
+	ChainID       int64     `gorm:"not null"`
+	Network       string    `gorm:"not null"` // name of the network it was sent to
+	From          string    `gorm:"column:from_address;not null;index"`
+	Nonce         uint64    `gorm:"not null"`
+	RawTx         string    `gorm:"not null"`
+	Kind          string    `gorm:"not null;default:transfer"`
+	Replaces      string    // hash of the transaction this one speeds up or cancels
+	State         string    `gorm:"not null;index"`
+	BlockNumber   uint64    // set once mined
+	Confirmations uint64    // as of the last check
+	SubmittedAt   time.Time `gorm:"not null"`
+	CheckedAt     *time.Time
+}
+
+// TableName define o nome da tabela no banco de dados
+func (TrackedTransaction) TableName() string {
+	return "tracked_transactions"
+}
+
+// Open reports whether the transaction still needs to be polled
+func (t TrackedTransaction) Open() bool {
+	return TrackedTxState(t.State) == TrackedPending || TrackedTxState(t.State) == TrackedIncluded
+}
+
+// Transaction decodes the stored raw transaction
+func (t TrackedTransaction) Transaction() (*types.Transaction, error) {
+	raw, err := DecodeRawTransaction(t.RawTx)
+	if err != nil {
+		return nil, err
+	}
+	return raw.Tx, nil
+}
+
+// TrackedTransactionStore is implemented by repositories that persist the
+// transaction tracker
+type TrackedTransactionStore interface {
+	AddTrackedTransaction(tx *TrackedTransaction) error
+	UpdateTrackedTransaction(tx *TrackedTransaction) error
+	TrackedTransactions(openOnly bool) ([]TrackedTransaction, error)
+}
+
+func (ws *WalletService) trackedTransactionStore() (TrackedTransactionStore, error) {
+	store, ok := ws.Repo.(TrackedTransactionStore)
+	if !ok {
+		return nil, errors.New("the wallet repository does not track transactions")
+	}
+	return store, nil
+}
+
+// TrackTransaction starts following a transaction just broadcast on network.
+// kind and replaces describe speed-up and cancel transactions; replaces is
+// empty for any other.
+func (ws *WalletService) TrackTransaction(raw *RawTransaction, network, kind, replaces string) (*TrackedTransaction, error) {
+	if raw == nil || raw.Tx == nil {
+		return nil, errors.New("no transaction to track")
+	}
+	store, err := ws.trackedTransactionStore()
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := EncodeRawTransaction(raw.Tx)
+	if err != nil {
+		return nil, err
+	}
+	if kind == "" {
+		kind = TrackedKindTransfer
+	}
+	tracked := &TrackedTransaction{
+		Hash:        raw.Tx.Hash().Hex(),
+		ChainID:     raw.Tx.ChainId().Int64(),
+		Network:     network,
+		From:        raw.From.Hex(),
+		Nonce:       raw.Tx.Nonce(),
+		RawTx:       encoded,
+		Kind:        kind,
+		Replaces:    replaces,
+		State:       string(TrackedPending),
+		SubmittedAt: time.Now(),
+	}
+	if err := store.AddTrackedTransaction(tracked); err != nil {
+		return nil, err
+	}
+	return tracked, nil
+}
+
+// TrackedTransactions lists the tracked transactions, newest first. With
+// openOnly, only those still pending or gathering confirmations.
+func (ws *WalletService) TrackedTransactions(openOnly bool) ([]TrackedTransaction, error) {
+	store, err := ws.trackedTransactionStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.TrackedTransactions(openOnly)
+}
+
+// UpdateTrackedTransaction saves the state of a tracked transaction
+func (ws *WalletService) UpdateTrackedTransaction(tx *TrackedTransaction) error {
+	store, err := ws.trackedTransactionStore()
+	if err != nil {
+		return err
+	}
+	return store.UpdateTrackedTransaction(tx)
+}
+
+// FindWalletByAddress returns the stored wallet with address, for signing on
+// its behalf; watch-only entries are skipped
+func (ws *WalletService) FindWalletByAddress(address string) (*Wallet, error) {
+	wallets, err := ws.Repo.FindByAddress(common.HexToAddress(address).Hex())
+	if err != nil {
+		return nil, err
+	}
+	for i := range wallets {
+		if !wallets[i].WatchOnly() {
+			return &wallets[i], nil
+		}
+	}
+	return nil, errors.New("no local wallet has this address")
+}
@@ -0,0 +1,221 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxGas is the gas used by a plain transfer
+const TxGas = 21000
+
+// TransferRequest describes an EIP-1559 transaction. Every field is given by
+// the caller, so it can be signed on a machine without network access.
+type TransferRequest struct {
+	ChainID              *big.Int
+	To                   common.Address
+	Value                *big.Int // in wei
+	Data                 []byte
+	Nonce                uint64
+	GasLimit             uint64
+	MaxFeePerGas         *big.Int // in wei
+	MaxPriorityFeePerGas *big.Int // in wei
+}
+
+// IntrinsicGas is the least gas a transaction carrying data can use
+func IntrinsicGas(data []byte) uint64 {
+	gas := uint64(TxGas)
+	for _, b := range data {
+		if b == 0 {
+			gas += 4
+		} else {
+			gas += 16
+		}
+	}
+	return gas
+}
+
+// Validate checks the request before it is signed
+func (r TransferRequest) Validate() error {
+	if r.ChainID == nil || r.ChainID.Sign() <= 0 {
+		return errors.New("the chain ID must be a positive number")
+	}
+	if r.To == (common.Address{}) {
+		return errors.New("the recipient address is required")
+	}
+	if r.Value == nil || r.Value.Sign() < 0 {
+		return errors.New("the amount cannot be negative")
+	}
+	if min := IntrinsicGas(r.Data); r.GasLimit < min {
+		return fmt.Errorf("the gas limit must be at least %d", min)
+	}
+	if r.MaxFeePerGas == nil || r.MaxFeePerGas.Sign() <= 0 {
+		return errors.New("the max fee per gas must be positive")
+	}
+	if r.MaxPriorityFeePerGas == nil || r.MaxPriorityFeePerGas.Sign() < 0 {
+		return errors.New("the priority fee cannot be negative")
+	}
+	if r.MaxPriorityFeePerGas.Cmp(r.MaxFeePerGas) > 0 {
+		return errors.New("the priority fee cannot exceed the max fee per gas")
+	}
+	return nil
+}
+
+// Transaction builds the unsigned transaction
+func (r TransferRequest) Transaction() *types.Transaction {
+	to := r.To
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   new(big.Int).Set(r.ChainID),
+		Nonce:     r.Nonce,
+		GasTipCap: new(big.Int).Set(r.MaxPriorityFeePerGas),
+		GasFeeCap: new(big.Int).Set(r.MaxFeePerGas),
+		Gas:       r.GasLimit,
+		To:        &to,
+		Value:     new(big.Int).Set(r.Value),
+		Data:      common.CopyBytes(r.Data),
+	})
+}
+
+// MaxCost is the most the transaction can take from the sender: value plus
+// gas limit times the max fee per gas
+func (r TransferRequest) MaxCost() *big.Int {
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(r.GasLimit), r.MaxFeePerGas)
+	return cost.Add(cost, r.Value)
+}
+
+// SignTransfer validates and signs the request without sending it anywhere
+func SignTransfer(signer Signer, r TransferRequest) (*types.Transaction, error) {
+	if signer == nil {
+		return nil, errors.New("the wallet must be unlocked to sign")
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return signer.SignTx(r.Transaction(), r.ChainID)
+}
+
+// EncodeRawTransaction returns the signed transaction as the 0x-prefixed hex
+// accepted by eth_sendRawTransaction
+func EncodeRawTransaction(tx *types.Transaction) (string, error) {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return hexutil.Encode(raw), nil
+}
+
+// ParseUnits converts a decimal amount such as "1.5" to an integer with
+// decimals digits, e.g. ether to wei with 18
+func ParseUnits(amount string, decimals int) (*big.Int, error) {
+	amount = strings.TrimSpace(amount)
+	if amount == "" {
+		return nil, errors.New("amount is empty")
+	}
+	whole, fraction, _ := strings.Cut(amount, ".")
+	if len(fraction) > decimals {
+		return nil, fmt.Errorf("amount %q has more than %d decimals", amount, decimals)
+	}
+	digits := whole + fraction + strings.Repeat("0", decimals-len(fraction))
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return nil, fmt.Errorf("invalid amount %q", amount)
+		}
+	}
+	value, _ := new(big.Int).SetString(digits, 10)
+	return value, nil
+}
+
+// FormatUnits is the reverse of ParseUnits, without trailing zeros
+func FormatUnits(value *big.Int, decimals int) string {
+	if value == nil {
+		return "0"
+	}
+	sign := ""
+	if value.Sign() < 0 {
+		sign = "-"
+	}
+	digits := new(big.Int).Abs(value).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	whole, fraction := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+	if fraction == "" {
+		return sign + whole
+	}
+	return sign + whole + "." + fraction
+}
+
+// RawTransaction is a signed transaction decoded from its hex form
+type RawTransaction struct {
+	Tx   *types.Transaction
+	From common.Address // recovered from the signature
+}
+
+// DecodeRawTransaction decodes the hex of a signed transaction, of any type,
+// and recovers its sender
+func DecodeRawTransaction(raw string) (*RawTransaction, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.HasPrefix(raw, "0x") && !strings.HasPrefix(raw, "0X") {
+		raw = "0x" + raw
+	}
+	data, err := hexutil.Decode(strings.ToLower(raw))
+	if err != nil {
+		return nil, fmt.Errorf("the transaction is not valid hex: %w", err)
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return nil, fmt.Errorf("the data is not a signed transaction: %w", err)
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, fmt.Errorf("the signature cannot be verified: %w", err)
+	}
+	return &RawTransaction{Tx: tx, From: from}, nil
+}
+
+// ReplacementBump is the fee increase, in percent, of a speed-up or cancel
+// transaction; nodes only replace a pending transaction for at least 10% more
+const ReplacementBump = 15
+
+// ReplacementRequest builds a transaction that takes the nonce of original
+// with fees raised by ReplacementBump percent, or to the suggested fees when
+// those are higher. A speed-up repeats the original; a cancel sends nothing to
+// the sender itself, so the original can no longer be mined.
+func ReplacementRequest(original *types.Transaction, from common.Address, cancel bool, suggestedMaxFee, suggestedTip *big.Int) (TransferRequest, error) {
+	bump := func(fee, suggested *big.Int) *big.Int {
+		raised := new(big.Int).Mul(fee, big.NewInt(100+ReplacementBump))
+		raised.Add(raised, big.NewInt(99)).Div(raised, big.NewInt(100))
+		if suggested != nil && suggested.Cmp(raised) > 0 {
+			return new(big.Int).Set(suggested)
+		}
+		return raised
+	}
+	req := TransferRequest{
+		ChainID:              original.ChainId(),
+		Nonce:                original.Nonce(),
+		MaxFeePerGas:         bump(original.GasFeeCap(), suggestedMaxFee),
+		MaxPriorityFeePerGas: bump(original.GasTipCap(), suggestedTip),
+	}
+	if req.MaxPriorityFeePerGas.Cmp(req.MaxFeePerGas) > 0 {
+		req.MaxFeePerGas = new(big.Int).Set(req.MaxPriorityFeePerGas)
+	}
+	if cancel {
+		req.To = from
+		req.Value = new(big.Int)
+		req.GasLimit = TxGas
+		return req, nil
+	}
+	if original.To() == nil {
+		return req, errors.New("contract deployments cannot be sped up, only cancelled")
+	}
+	req.To = *original.To()
+	req.Value = original.Value()
+	req.Data = original.Data()
+	req.GasLimit = original.Gas()
+	return req, nil
+}
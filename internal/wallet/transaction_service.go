@@ -0,0 +1,147 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrInsufficientFunds is returned when the sender cannot pay the amount plus
+// the most the gas can cost
+var ErrInsufficientFunds = errors.New("insufficient funds for the amount plus gas")
+
+// GasMarginPercent is added to gas estimates above a plain transfer, as
+// contract execution can cost a little more once mined than when estimated
+const GasMarginPercent = 20
+
+// TxBackend is the part of an RPC client needed to send transfers;
+// *ethclient.Client implements it
+type TxBackend interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// TransactionService builds, signs and sends transfers through one network.
+// The nonce, gas limit and fees are read from the network, so callers only
+// give the recipient and the amount.
+type TransactionService struct {
+	backend TxBackend
+
+	mu        sync.Mutex
+	nextNonce map[common.Address]uint64 // after the last transfer sent by this service
+}
+
+// NewTransactionService returns a service sending through backend
+func NewTransactionService(backend TxBackend) *TransactionService {
+	return &TransactionService{backend: backend, nextNonce: make(map[common.Address]uint64)}
+}
+
+// BuildTransfer prepares a transfer of value wei, with data when calling a
+// contract, from the account at from. It fails with ErrInsufficientFunds when
+// the balance cannot cover the amount and the gas.
+func (ts *TransactionService) BuildTransfer(ctx context.Context, from, to common.Address, value *big.Int, data []byte) (TransferRequest, error) {
+	req := TransferRequest{To: to, Value: value, Data: common.CopyBytes(data)}
+	if value == nil || value.Sign() < 0 {
+		return req, errors.New("the amount cannot be negative")
+	}
+
+	chainID, err := ts.backend.ChainID(ctx)
+	if err != nil {
+		return req, fmt.Errorf("failed to read chain ID: %w", err)
+	}
+	req.ChainID = chainID
+
+	if req.Nonce, err = ts.nonce(ctx, from); err != nil {
+		return req, err
+	}
+	if req.MaxFeePerGas, req.MaxPriorityFeePerGas, err = ts.fees(ctx); err != nil {
+		return req, err
+	}
+	if req.GasLimit, err = ts.estimateGas(ctx, from, req); err != nil {
+		return req, err
+	}
+
+	balance, err := ts.backend.BalanceAt(ctx, from, nil)
+	if err != nil {
+		return req, fmt.Errorf("failed to get balance for address %s: %w", from.Hex(), err)
+	}
+	if cost := req.MaxCost(); balance.Cmp(cost) < 0 {
+		return req, fmt.Errorf("%w: the balance is %s wei, up to %s wei are needed", ErrInsufficientFunds, balance, cost)
+	}
+	return req, req.Validate()
+}
+
+// SendTransfer signs req with signer and sends it, returning the signed
+// transaction once the node accepted it
+func (ts *TransactionService) SendTransfer(ctx context.Context, signer Signer, req TransferRequest) (*RawTransaction, error) {
+	signed, err := SignTransfer(signer, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := ts.backend.SendTransaction(ctx, signed); err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	from := signer.Address()
+	ts.mu.Lock()
+	if next := req.Nonce + 1; next > ts.nextNonce[from] {
+		ts.nextNonce[from] = next
+	}
+	ts.mu.Unlock()
+	return &RawTransaction{Tx: signed, From: from}, nil
+}
+
+// nonce returns the next nonce of from: the node's pending nonce, unless this
+// service already sent transfers the node does not count yet
+func (ts *TransactionService) nonce(ctx context.Context, from common.Address) (uint64, error) {
+	pending, err := ts.backend.PendingNonceAt(ctx, from)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pending nonce for address %s: %w", from.Hex(), err)
+	}
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return max(pending, ts.nextNonce[from]), nil
+}
+
+// fees suggests EIP-1559 fees: the node's priority fee, and a fee cap of
+// twice the latest base fee plus that priority fee
+func (ts *TransactionService) fees(ctx context.Context) (maxFee, tip *big.Int, err error) {
+	tip, err = ts.backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get priority fee: %w", err)
+	}
+	head, err := ts.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	if head.BaseFee == nil {
+		return nil, nil, errors.New("the network does not support EIP-1559 fees")
+	}
+	maxFee = new(big.Int).Mul(head.BaseFee, big.NewInt(2))
+	return maxFee.Add(maxFee, tip), tip, nil
+}
+
+// estimateGas asks the node for the gas of req, adding GasMarginPercent when
+// the recipient runs code
+func (ts *TransactionService) estimateGas(ctx context.Context, from common.Address, req TransferRequest) (uint64, error) {
+	to := req.To
+	gas, err := ts.backend.EstimateGas(ctx, ethereum.CallMsg{From: from, To: &to, Value: req.Value, Data: req.Data})
+	if err != nil {
+		return 0, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	if gas > IntrinsicGas(req.Data) {
+		gas += gas * GasMarginPercent / 100
+	}
+	return gas, nil
+}
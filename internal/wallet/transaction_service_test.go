@@ -0,0 +1,122 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTxBackend is a node that accepts every transaction without mining it
+type fakeTxBackend struct {
+	balance *big.Int
+	nonce   uint64
+	gas     uint64
+	sendErr error
+	sent    []*types.Transaction
+}
+
+func (f *fakeTxBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(11155111), nil
+}
+
+func (f *fakeTxBackend) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return f.balance, nil
+}
+
+func (f *fakeTxBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return f.nonce, nil
+}
+
+func (f *fakeTxBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1_000_000_000), nil
+}
+
+func (f *fakeTxBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: big.NewInt(10_000_000_000)}, nil
+}
+
+func (f *fakeTxBackend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	if f.gas == 0 {
+		return TxGas, nil
+	}
+	return f.gas, nil
+}
+
+func (f *fakeTxBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if f.sendErr != nil {
+		return f.sendErr
+	}
+	f.sent = append(f.sent, tx)
+	return nil
+}
+
+var serviceRecipient = common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+
+func TestTransactionService_BuildTransfer(t *testing.T) {
+	backend := &fakeTxBackend{balance: big.NewInt(1e18), nonce: 4}
+	ts := NewTransactionService(backend)
+	from := common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")
+
+	req, err := ts.BuildTransfer(context.Background(), from, serviceRecipient, big.NewInt(1e17), nil)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(11155111), req.ChainID)
+	assert.Equal(t, uint64(4), req.Nonce)
+	assert.Equal(t, uint64(TxGas), req.GasLimit, "no margin on a plain transfer")
+	assert.Equal(t, big.NewInt(21_000_000_000), req.MaxFeePerGas, "twice the base fee plus the tip")
+	assert.Equal(t, big.NewInt(1_000_000_000), req.MaxPriorityFeePerGas)
+
+	backend.gas = 50_000
+	req, err = ts.BuildTransfer(context.Background(), from, serviceRecipient, big.NewInt(1e17), nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(60_000), req.GasLimit, "margin for a recipient running code")
+}
+
+func TestTransactionService_InsufficientFunds(t *testing.T) {
+	ts := NewTransactionService(&fakeTxBackend{balance: big.NewInt(1e17)})
+	_, err := ts.BuildTransfer(context.Background(), common.Address{1}, serviceRecipient, big.NewInt(1e17), nil)
+	assert.ErrorIs(t, err, ErrInsufficientFunds, "the gas is not covered")
+
+	_, err = ts.BuildTransfer(context.Background(), common.Address{1}, serviceRecipient, big.NewInt(-1), nil)
+	assert.Error(t, err)
+}
+
+func TestTransactionService_SendTransfer(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := NewKeySigner(key)
+	backend := &fakeTxBackend{balance: big.NewInt(1e18), nonce: 2}
+	ts := NewTransactionService(backend)
+	ctx := context.Background()
+
+	req, err := ts.BuildTransfer(ctx, signer.Address(), serviceRecipient, big.NewInt(1e15), nil)
+	require.NoError(t, err)
+	raw, err := ts.SendTransfer(ctx, signer, req)
+	require.NoError(t, err)
+	assert.Equal(t, signer.Address(), raw.From)
+	require.Len(t, backend.sent, 1)
+	assert.Equal(t, backend.sent[0].Hash(), raw.Tx.Hash())
+
+	// The node has not seen the first transfer yet; the next takes the following nonce
+	req, err = ts.BuildTransfer(ctx, signer.Address(), serviceRecipient, big.NewInt(1e15), nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), req.Nonce)
+
+	// A rejected transfer does not use up its nonce
+	backend.sendErr = errors.New("replacement transaction underpriced")
+	_, err = ts.SendTransfer(ctx, signer, req)
+	assert.Error(t, err)
+	req, err = ts.BuildTransfer(ctx, signer.Address(), serviceRecipient, big.NewInt(1e15), nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), req.Nonce)
+
+	_, err = ts.SendTransfer(ctx, nil, req)
+	assert.Error(t, err, "locked wallet")
+}
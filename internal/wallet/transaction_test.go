@@ -0,0 +1,157 @@
+package wallet
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTransferRequest() TransferRequest {
+	return TransferRequest{
+		ChainID:              big.NewInt(11155111),
+		To:                   common.HexToAddress("0x000000000000000000000000000000000000dEaD"),
+		Value:                big.NewInt(1_500_000_000_000_000_000),
+		Nonce:                7,
+		GasLimit:             TxGas,
+		MaxFeePerGas:         big.NewInt(30_000_000_000),
+		MaxPriorityFeePerGas: big.NewInt(1_000_000_000),
+	}
+}
+
+func TestTransferRequest_Validate(t *testing.T) {
+	assert.NoError(t, testTransferRequest().Validate())
+
+	for name, change := range map[string]func(*TransferRequest){
+		"chain":     func(r *TransferRequest) { r.ChainID = big.NewInt(0) },
+		"recipient": func(r *TransferRequest) { r.To = common.Address{} },
+		"amount":    func(r *TransferRequest) { r.Value = big.NewInt(-1) },
+		"gas":       func(r *TransferRequest) { r.Data = []byte{1}; r.GasLimit = TxGas },
+		"fee":       func(r *TransferRequest) { r.MaxFeePerGas = big.NewInt(0) },
+		"tip":       func(r *TransferRequest) { r.MaxPriorityFeePerGas = big.NewInt(31_000_000_000) },
+	} {
+		r := testTransferRequest()
+		change(&r)
+		assert.Error(t, r.Validate(), name)
+	}
+}
+
+func TestSignTransfer(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	req := testTransferRequest()
+
+	signed, err := SignTransfer(NewKeySigner(key), req)
+	require.NoError(t, err)
+	raw, err := EncodeRawTransaction(signed)
+	require.NoError(t, err)
+
+	// The hex decodes back to the same transaction, sent by the wallet
+	decoded := new(types.Transaction)
+	require.NoError(t, decoded.UnmarshalBinary(hexutil.MustDecode(raw)))
+	assert.Equal(t, signed.Hash(), decoded.Hash())
+	sender, err := types.Sender(types.LatestSignerForChainID(req.ChainID), decoded)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), sender)
+	assert.Equal(t, uint64(7), decoded.Nonce())
+
+	_, err = SignTransfer(nil, req)
+	assert.Error(t, err)
+}
+
+func TestParseAndFormatUnits(t *testing.T) {
+	for in, want := range map[string]string{
+		"1.5":  "1500000000000000000",
+		"0":    "0",
+		".25":  "250000000000000000",
+		"10.":  "10000000000000000000",
+		"0.01": "10000000000000000",
+	} {
+		v, err := ParseUnits(in, 18)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, v.String(), in)
+	}
+	for _, bad := range []string{"", "1,5", "-1", "1e18", "0.0000000000000000001"} {
+		_, err := ParseUnits(bad, 18)
+		assert.Error(t, err, bad)
+	}
+
+	assert.Equal(t, "1.5", FormatUnits(big.NewInt(1_500_000_000_000_000_000), 18))
+	assert.Equal(t, "0.000000001", FormatUnits(big.NewInt(1_000_000_000), 18))
+	assert.Equal(t, "30", FormatUnits(big.NewInt(30_000_000_000), 9))
+	assert.Equal(t, "0", FormatUnits(nil, 18))
+}
+
+func TestDecodeRawTransaction(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signed, err := SignTransfer(NewKeySigner(key), testTransferRequest())
+	require.NoError(t, err)
+	raw, err := EncodeRawTransaction(signed)
+	require.NoError(t, err)
+
+	for _, input := range []string{raw, " " + strings.TrimPrefix(raw, "0x") + "\n", strings.ToUpper(raw)} {
+		decoded, err := DecodeRawTransaction(input)
+		require.NoError(t, err)
+		assert.Equal(t, signed.Hash(), decoded.Tx.Hash())
+		assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), decoded.From)
+	}
+
+	_, err = DecodeRawTransaction("0xzz")
+	assert.ErrorContains(t, err, "hex")
+	_, err = DecodeRawTransaction("0x01020304")
+	assert.ErrorContains(t, err, "signed transaction")
+
+	// An unsigned transaction has no sender
+	unsigned, err := testTransferRequest().Transaction().MarshalBinary()
+	require.NoError(t, err)
+	_, err = DecodeRawTransaction(hexutil.Encode(unsigned))
+	assert.Error(t, err)
+}
+
+func TestReplacementRequest(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	req := testTransferRequest()
+	req.Data = []byte{0xa9, 0x05}
+	req.GasLimit = 60000
+	original, err := SignTransfer(NewKeySigner(key), req)
+	require.NoError(t, err)
+
+	// A speed-up repeats the transaction with fees raised by at least 10%
+	speedUp, err := ReplacementRequest(original, from, false, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, original.Nonce(), speedUp.Nonce)
+	assert.Equal(t, *original.To(), speedUp.To)
+	assert.Equal(t, original.Value(), speedUp.Value)
+	assert.Equal(t, original.Data(), speedUp.Data)
+	assert.Equal(t, original.Gas(), speedUp.GasLimit)
+	minFee := new(big.Int).Div(new(big.Int).Mul(original.GasFeeCap(), big.NewInt(110)), big.NewInt(100))
+	minTip := new(big.Int).Div(new(big.Int).Mul(original.GasTipCap(), big.NewInt(110)), big.NewInt(100))
+	assert.True(t, speedUp.MaxFeePerGas.Cmp(minFee) >= 0)
+	assert.True(t, speedUp.MaxPriorityFeePerGas.Cmp(minTip) >= 0)
+	require.NoError(t, speedUp.Validate())
+
+	// Higher suggested fees win over the bump
+	suggested := new(big.Int).Mul(original.GasFeeCap(), big.NewInt(3))
+	speedUp, err = ReplacementRequest(original, from, false, suggested, nil)
+	require.NoError(t, err)
+	assert.Equal(t, suggested, speedUp.MaxFeePerGas)
+
+	// A cancel sends nothing to the sender with the same nonce
+	cancel, err := ReplacementRequest(original, from, true, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, from, cancel.To)
+	assert.Zero(t, cancel.Value.Sign())
+	assert.Empty(t, cancel.Data)
+	assert.Equal(t, uint64(TxGas), cancel.GasLimit)
+	assert.Equal(t, original.Nonce(), cancel.Nonce)
+	require.NoError(t, cancel.Validate())
+}
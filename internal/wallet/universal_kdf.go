@@ -9,6 +9,8 @@ import (
 	"strconv"
 	"time"
 
+	"blocowallet/internal/metrics"
+
 	"golang.org/x/crypto/pbkdf2"
 	"golang.org/x/crypto/scrypt"
 )
@@ -84,6 +86,7 @@ func NewUniversalKDFService() *UniversalKDFService {
 	service.RegisterKDF("pbkdf2", &PBKDF2Handler{})
 	service.RegisterKDF("pbkdf2-sha256", &PBKDF2Handler{hashFunc: sha256.New})
 	service.RegisterKDF("pbkdf2-sha512", &PBKDF2Handler{hashFunc: sha512.New})
+	service.RegisterKDF(KDFArgon2id, &Argon2idHandler{})
 
 	return service
 }
@@ -118,6 +121,7 @@ func (uks *UniversalKDFService) DeriveKey(password string, crypto *CryptoParams)
 	start := getCurrentTime()
 	derivedKey, err := handler.DeriveKey(password, crypto.KDFParams)
 	duration := getElapsedTime(start)
+	metrics.KDFDuration.ObserveDuration(start, normalizedKDF)
 
 	if err != nil {
 		uks.logger.LogKDFError(normalizedKDF, err)
@@ -140,6 +144,9 @@ func (uks *UniversalKDFService) normalizeKDFName(kdf string) string {
 		"pbkdf2-sha512": "pbkdf2-sha512",
 		"pbkdf2_sha256": "pbkdf2-sha256",
 		"pbkdf2_sha512": "pbkdf2-sha512",
+		"argon2id":      "argon2id",
+		"Argon2id":      "argon2id",
+		"ARGON2ID":      "argon2id",
 	}
 
 	if normalized, exists := kdfMap[kdf]; exists {
@@ -622,6 +629,20 @@ func (kca *KDFCompatibilityAnalyzer) analyzeParameterSecurity(kdf string, params
 			analysis.Level = "High"
 			analysis.Suggestions = append(analysis.Suggestions, "Boas iterações, mas considere migrar para scrypt")
 		}
+
+	case "argon2id":
+		memory := kca.getIntParam(params, "memory", 64*1024)
+
+		if memory < 19*1024 { // Mínimo recomendado pela OWASP
+			analysis.Level = "Low"
+			analysis.Suggestions = append(analysis.Suggestions, "Memória do Argon2id abaixo do mínimo recomendado (19 MiB)")
+		} else if memory < 256*1024 {
+			analysis.Level = "High"
+			analysis.Suggestions = append(analysis.Suggestions, "Argon2id resiste melhor que scrypt a ataques com GPU e ASIC")
+		} else {
+			analysis.Level = "Very High"
+			analysis.Suggestions = append(analysis.Suggestions, "Segurança muito alta, adequada para aplicações críticas")
+		}
 	}
 
 	return analysis
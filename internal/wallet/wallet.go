@@ -1,6 +1,12 @@
 package wallet
 
-import "time"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
 
 // Wallet representa uma carteira de criptomoeda
 type Wallet struct {
@@ -12,9 +18,70 @@ type Wallet struct {
 	ImportMethod string    `gorm:"not null"`             // import method: mnemonic, private_key, keystore
 	SourceHash   string    `gorm:"uniqueIndex;not null"` // unique hash of source data
 	CreatedAt    time.Time `gorm:"not null;autoCreateTime"`
+	UpdatedAt    time.Time // last metadata change (e.g. rename); zero for rows created before it existed
+	// Version is incremented on every change so concurrent sessions detect
+	// that the row they loaded is stale instead of overwriting each other
+	Version int `gorm:"not null;default:1"`
+	// Notes and References let teams record who a wallet belongs to, e.g.
+	// a customer ID or the URL of the ticket it was created for
+	Notes      string           `gorm:"type:text"`
+	References WalletReferences `gorm:"column:external_references;type:text"`
+	// Child wallets record the source hash of the master mnemonic they were
+	// derived from and their derivation path
+	MasterSourceHash string `gorm:"index"`
+	DerivationPath   string
 }
 
 // TableName define o nome da tabela no banco de dados
 func (Wallet) TableName() string {
 	return "wallets"
 }
+
+// WalletReferences is a list of external references stored as a JSON array
+type WalletReferences []string
+
+// Value implements driver.Valuer
+func (r WalletReferences) Value() (driver.Value, error) {
+	if len(r) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal([]string(r))
+	return string(data), err
+}
+
+// Scan implements sql.Scanner
+func (r *WalletReferences) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*r = nil
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("cannot scan %T into WalletReferences", src)
+	}
+	if len(data) == 0 {
+		*r = nil
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(r))
+}
+
+// Matches reports whether the query appears, ignoring case, in the wallet's
+// name, address, notes or references
+func (w Wallet) Matches(query string) bool {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return true
+	}
+	fields := append([]string{w.Name, w.Address, w.Notes}, w.References...)
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), query) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,14 +1,21 @@
 package wallet
 
 import (
+	"bytes"
 	"crypto/ecdsa"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
+	"blocowallet/internal/metrics"
+	"blocowallet/pkg/config"
+
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -18,9 +25,10 @@ import (
 
 type WalletDetails struct {
 	Wallet       *Wallet
-	Mnemonic     *string // Nullable for non-mnemonic imports
-	PrivateKey   *ecdsa.PrivateKey
+	Mnemonic     *string           // Nullable for non-mnemonic imports
+	PrivateKey   *ecdsa.PrivateKey // Nil when the key is held by a device or a KMS
 	PublicKey    *ecdsa.PublicKey
+	Signer       Signer       // Signs for the wallet wherever the key lives
 	ImportMethod ImportMethod // Track import method
 	HasMnemonic  bool         // Helper field for UI
 	KDFInfo      *KDFInfo     // KDF analysis information
@@ -29,6 +37,29 @@ type WalletDetails struct {
 type WalletService struct {
 	Repo     WalletRepository
 	KeyStore *keystore.KeyStore
+	// MemoryGuard bounds the memory of key derivations run in parallel by
+	// batch operations; nil leaves them unbounded
+	MemoryGuard *MemoryGuard
+	// KeystoreDir is where imported and restored keystores are written; when
+	// empty it is the directory of the managed accounts
+	KeystoreDir string
+	// KeystoreArgon2id, when set, encrypts the keystores of new wallets and
+	// exported keys with Argon2id instead of geth's scrypt
+	KeystoreArgon2id *Argon2idParams
+	// ExportCipher encrypts exported scrypt keystores; empty is aes-128-ctr
+	ExportCipher string
+
+	// masterFields is the master key entered in this session, nil while locked
+	masterFields *FieldCipher
+
+	// importMu serializes the copy of keystores and the wallet rows written
+	// by imports decrypting in parallel, e.g. two files of the same address
+	importMu sync.Mutex
+
+	// approvedOverwrites holds, by managed keystore path, the hash of the file
+	// an import was allowed to replace it with
+	overwriteMu        sync.Mutex
+	approvedOverwrites map[string][sha256.Size]byte
 }
 
 func NewWalletService(repo WalletRepository, ks *keystore.KeyStore) *WalletService {
@@ -43,7 +74,55 @@ func NewWalletService(repo WalletRepository, ks *keystore.KeyStore) *WalletServi
 	}
 }
 
+// ApplyConfig takes the settings the wallet service depends on from cfg: the
+// Argon2 parameters of the crypto service, the memory limit of batch key
+// derivations and the KDF of new keystores. It is called at startup and
+// again when the configuration changes while the program runs.
+func (ws *WalletService) ApplyConfig(cfg *config.Config) {
+	InitCryptoService(cfg)
+	limit := KDFMemoryLimit(cfg.Import.MemoryLimitMB)
+	if ws.MemoryGuard == nil {
+		ws.MemoryGuard = NewMemoryGuard(limit)
+	} else {
+		ws.MemoryGuard.SetLimit(limit)
+	}
+	ws.KeystoreArgon2id = nil
+	if cfg.Security.KeystoreKDF == config.KeystoreKDFArgon2id {
+		ws.KeystoreArgon2id = &Argon2idParams{
+			Time:    cfg.Security.Argon2Time,
+			Memory:  cfg.Security.Argon2Memory,
+			Threads: cfg.Security.Argon2Threads,
+		}
+	}
+	ws.ExportCipher = CipherAES128CTR
+	if isSupportedCipher(cfg.Security.ExportCipher) {
+		ws.ExportCipher = cfg.Security.ExportCipher
+	}
+}
+
+// keystoreDir returns the directory keystores are copied to, creating it
+func (ws *WalletService) keystoreDir() (string, error) {
+	dir := ws.KeystoreDir
+	if dir == "" {
+		if accounts := ws.KeyStore.Accounts(); len(accounts) > 0 {
+			dir = filepath.Dir(accounts[0].URL.Path)
+		} else {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			dir = filepath.Join(homeDir, ".wallets", "keystore")
+		}
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
 func (ws *WalletService) CreateWallet(name, password string) (*WalletDetails, error) {
+	password = NormalizeSecret(password)
+
 	mnemonic, err := GenerateMnemonic()
 	if err != nil {
 		return nil, err
@@ -59,28 +138,22 @@ func (ws *WalletService) CreateWallet(name, password string) (*WalletDetails, er
 		return nil, err
 	}
 
-	account, err := ws.KeyStore.ImportECDSA(privKey, password)
+	// The keystore file and the wallet row are created together or not at all
+	op := &operation{}
+	newPath, address, err := ws.importKey(op, privKey, password)
 	if err != nil {
-		return nil, err
-	}
-
-	originalPath := account.URL.Path
-	newFilename := fmt.Sprintf("%s.json", account.Address.Hex())
-	newPath := filepath.Join(filepath.Dir(originalPath), newFilename)
-	err = os.Rename(originalPath, newPath)
-	if err != nil {
-		return nil, fmt.Errorf("error renaming the wallet file: %v", err)
+		return nil, op.rollback(err)
 	}
 
 	// Encrypt the mnemonic before storing
 	encryptedMnemonic, err := EncryptMnemonic(mnemonic, password)
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt mnemonic: %v", err)
+		return nil, op.rollback(fmt.Errorf("failed to encrypt mnemonic: %v", err))
 	}
 
 	wallet := &Wallet{
 		Name:         name,
-		Address:      account.Address.Hex(),
+		Address:      address,
 		KeyStorePath: newPath,
 		Mnemonic:     &encryptedMnemonic, // Store the encrypted mnemonic
 		ImportMethod: string(ImportMethodMnemonic),
@@ -88,7 +161,7 @@ func (ws *WalletService) CreateWallet(name, password string) (*WalletDetails, er
 	}
 
 	if err = ws.Repo.AddWallet(wallet); err != nil {
-		return nil, err
+		return nil, op.rollback(err)
 	}
 
 	walletDetails := &WalletDetails{
@@ -96,6 +169,7 @@ func (ws *WalletService) CreateWallet(name, password string) (*WalletDetails, er
 		Mnemonic:     &mnemonic,
 		PrivateKey:   privKey,
 		PublicKey:    &privKey.PublicKey,
+		Signer:       signerForKey(privKey, ImportMethodMnemonic),
 		ImportMethod: ImportMethodMnemonic,
 		HasMnemonic:  true,
 	}
@@ -103,7 +177,18 @@ func (ws *WalletService) CreateWallet(name, password string) (*WalletDetails, er
 	return walletDetails, nil
 }
 
-func (ws *WalletService) ImportWallet(name, mnemonic, password string) (*WalletDetails, error) {
+// countImport records the result of an import in the metrics
+func countImport(method ImportMethod, err error) {
+	metrics.Imports.Inc(string(method), metrics.Result(err))
+}
+
+func (ws *WalletService) ImportWallet(name, mnemonic, password string) (_ *WalletDetails, err error) {
+	defer func() { countImport(ImportMethodMnemonic, err) }()
+
+	// BIP-39 requires NFKD for both the phrase and the password
+	mnemonic = NormalizeMnemonic(mnemonic)
+	password = NormalizeSecret(password)
+
 	// 5.2 Validate mnemonic before any processing
 	if !bip39.IsMnemonicValid(mnemonic) {
 		return nil, NewInvalidImportDataError(string(ImportMethodMnemonic), "Invalid mnemonic phrase")
@@ -128,28 +213,22 @@ func (ws *WalletService) ImportWallet(name, mnemonic, password string) (*WalletD
 		return nil, err
 	}
 
-	account, err := ws.KeyStore.ImportECDSA(privKey, password)
+	// The keystore file and the wallet row are created together or not at all
+	op := &operation{}
+	newPath, address, err := ws.importKey(op, privKey, password)
 	if err != nil {
-		return nil, err
-	}
-
-	originalPath := account.URL.Path
-	newFilename := fmt.Sprintf("%s.json", account.Address.Hex())
-	newPath := filepath.Join(filepath.Dir(originalPath), newFilename)
-	err = os.Rename(originalPath, newPath)
-	if err != nil {
-		return nil, fmt.Errorf("error renaming the wallet file: %v", err)
+		return nil, op.rollback(err)
 	}
 
 	// Encrypt the mnemonic before storing
 	encryptedMnemonic, err := EncryptMnemonic(mnemonic, password)
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt mnemonic: %v", err)
+		return nil, op.rollback(fmt.Errorf("failed to encrypt mnemonic: %v", err))
 	}
 
 	wallet := &Wallet{
 		Name:         name,
-		Address:      account.Address.Hex(),
+		Address:      address,
 		KeyStorePath: newPath,
 		Mnemonic:     &encryptedMnemonic, // Store the encrypted mnemonic
 		ImportMethod: string(ImportMethodMnemonic),
@@ -157,7 +236,7 @@ func (ws *WalletService) ImportWallet(name, mnemonic, password string) (*WalletD
 	}
 
 	if err = ws.Repo.AddWallet(wallet); err != nil {
-		return nil, err
+		return nil, op.rollback(err)
 	}
 
 	walletDetails := &WalletDetails{
@@ -165,6 +244,7 @@ func (ws *WalletService) ImportWallet(name, mnemonic, password string) (*WalletD
 		Mnemonic:     &mnemonic,
 		PrivateKey:   privKey,
 		PublicKey:    &privKey.PublicKey,
+		Signer:       signerForKey(privKey, ImportMethodMnemonic),
 		ImportMethod: ImportMethodMnemonic,
 		HasMnemonic:  true,
 	}
@@ -172,20 +252,18 @@ func (ws *WalletService) ImportWallet(name, mnemonic, password string) (*WalletD
 	return walletDetails, nil
 }
 
-func (ws *WalletService) ImportWalletFromPrivateKey(name, privateKeyHex, password string) (*WalletDetails, error) {
-	// Normalize: remove 0x prefix if present
-	if len(privateKeyHex) > 2 && (privateKeyHex[:2] == "0x" || privateKeyHex[:2] == "0X") {
-		privateKeyHex = privateKeyHex[2:]
-	}
+func (ws *WalletService) ImportWalletFromPrivateKey(name, privateKeyHex, password string) (_ *WalletDetails, err error) {
+	defer func() { countImport(ImportMethodPrivateKey, err) }()
 
-	// 6.3 Validate private key format before processing
-	if len(privateKeyHex) != 64 {
-		return nil, NewInvalidImportDataError(string(ImportMethodPrivateKey), "Invalid private key format")
-	}
-	// ensure hex characters decode
-	if _, err := hex.DecodeString(privateKeyHex); err != nil {
+	password = NormalizeSecret(password)
+
+	// 6.3 Detect the encoding (hex with or without 0x, base64 or WIF) and
+	// validate the key before processing
+	parsed, err := ParsePrivateKey(privateKeyHex)
+	if err != nil {
 		return nil, NewInvalidImportDataError(string(ImportMethodPrivateKey), "Invalid private key format")
 	}
+	privateKeyHex = parsed.Hex
 
 	// 6.2 Duplicate detection by source hash (private key)
 	hashGen := &SourceHashGenerator{}
@@ -202,18 +280,12 @@ func (ws *WalletService) ImportWalletFromPrivateKey(name, privateKeyHex, passwor
 		return nil, NewInvalidImportDataError(string(ImportMethodPrivateKey), "Invalid private key format")
 	}
 
-	// Import the private key to keystore
-	account, err := ws.KeyStore.ImportECDSA(privKey, password)
+	// Import the private key to keystore as "<address>.json"; the file is
+	// removed again if the wallet cannot be saved
+	op := &operation{}
+	newPath, address, err := ws.importKey(op, privKey, password)
 	if err != nil {
-		return nil, err
-	}
-
-	// Rename the keystore file to match Ethereum address
-	originalPath := account.URL.Path
-	newFilename := fmt.Sprintf("%s.json", account.Address.Hex())
-	newPath := filepath.Join(filepath.Dir(originalPath), newFilename)
-	if err = os.Rename(originalPath, newPath); err != nil {
-		return nil, fmt.Errorf("error renaming the wallet file: %v", err)
+		return nil, op.rollback(err)
 	}
 
 	// 6.1 Mnemonic must be unavailable for private key imports
@@ -222,7 +294,7 @@ func (ws *WalletService) ImportWalletFromPrivateKey(name, privateKeyHex, passwor
 	// Create the wallet entry without mnemonic
 	wallet := &Wallet{
 		Name:         name,
-		Address:      account.Address.Hex(),
+		Address:      address,
 		KeyStorePath: newPath,
 		Mnemonic:     nilMnemonic, // No mnemonic stored for private key imports
 		ImportMethod: string(ImportMethodPrivateKey),
@@ -231,7 +303,7 @@ func (ws *WalletService) ImportWalletFromPrivateKey(name, privateKeyHex, passwor
 
 	// Add wallet to repository
 	if err = ws.Repo.AddWallet(wallet); err != nil {
-		return nil, err
+		return nil, op.rollback(err)
 	}
 
 	// Return wallet details without mnemonic
@@ -240,6 +312,7 @@ func (ws *WalletService) ImportWalletFromPrivateKey(name, privateKeyHex, passwor
 		Mnemonic:     nil,
 		PrivateKey:   privKey,
 		PublicKey:    &privKey.PublicKey,
+		Signer:       signerForKey(privKey, ImportMethodPrivateKey),
 		ImportMethod: ImportMethodPrivateKey,
 		HasMnemonic:  false,
 	}
@@ -254,6 +327,14 @@ func (ws *WalletService) ImportWalletFromKeystoreV3(name, keystorePath, password
 
 // ImportWalletFromKeystoreV3WithProgress imports a wallet from a keystore v3 file with progress tracking
 func (ws *WalletService) ImportWalletFromKeystoreV3WithProgress(name, keystorePath, password string, progressChan chan<- ImportProgress) (*WalletDetails, error) {
+	return ws.importKeystoreFile(name, keystorePath, password, time.Time{}, progressChan)
+}
+
+// importKeystoreFile imports the keystore at keystorePath; a non-zero
+// createdAt, such as the time in a geth keystore name, dates the wallet
+func (ws *WalletService) importKeystoreFile(name, keystorePath, password string, createdAt time.Time, progressChan chan<- ImportProgress) (_ *WalletDetails, err error) {
+	defer func() { countImport(ImportMethodKeystore, err) }()
+
 	// Send initial progress update
 	ws.sendProgressUpdate(progressChan, ImportProgress{
 		CurrentFile:     keystorePath,
@@ -310,6 +391,32 @@ func (ws *WalletService) ImportWalletFromKeystoreV3WithProgress(name, keystorePa
 		)
 	}
 
+	return ws.importKeystoreJSON(name, keystorePath, keyJSON, password, createdAt, progressChan)
+}
+
+// ImportWalletFromKeystoreJSON imports a keystore v3 given as JSON, such as a
+// keystore pasted into the terminal when copying the file is awkward
+func (ws *WalletService) ImportWalletFromKeystoreJSON(name string, keyJSON []byte, password string) (_ *WalletDetails, err error) {
+	defer func() { countImport(ImportMethodKeystore, err) }()
+
+	keyJSON = bytes.TrimSpace(keyJSON)
+	if len(keyJSON) == 0 {
+		return nil, NewKeystoreImportError(
+			ErrorInvalidJSON,
+			"The pasted keystore is empty",
+			nil,
+		)
+	}
+	return ws.importKeystoreJSON(name, PastedKeystoreSource, keyJSON, password, time.Time{}, nil)
+}
+
+// PastedKeystoreSource stands for the file name of a keystore imported from JSON
+const PastedKeystoreSource = "pasted keystore"
+
+// importKeystoreJSON decrypts keyJSON, read from keystorePath, and stores it
+// as a new wallet created at createdAt, or now when it is zero; keystorePath
+// also names the keystore in progress updates
+func (ws *WalletService) importKeystoreJSON(name, keystorePath string, keyJSON []byte, password string, createdAt time.Time, progressChan chan<- ImportProgress) (*WalletDetails, error) {
 	// Step 3: Generate source hash from keystore JSON content for duplicate detection
 	hashGen := &SourceHashGenerator{}
 	sourceHash := hashGen.GenerateFromKeystore(keyJSON)
@@ -428,27 +535,35 @@ func (ws *WalletService) ImportWalletFromKeystoreV3WithProgress(name, keystorePa
 		ElapsedTime:     0,
 	})
 
-	derivedKey, err := kdfService.DeriveKey(password, cryptoParams)
-	if err != nil {
-		// Provide KDF-specific error context
-		kdfContext := fmt.Sprintf("KDF: %s (%s), Security Level: %s",
-			compatReport.KDFType, compatReport.NormalizedKDF, compatReport.SecurityLevel)
-		return nil, NewKeystoreImportError(
-			ErrorIncorrectPassword,
-			fmt.Sprintf("Failed to derive key using Universal KDF (%s): %v", kdfContext, err),
-			err,
-		)
-	}
-
 	// Step 11: Use Enhanced KeyStore Service for decryption
 	enhancedService := NewEnhancedKeyStoreService()
 
-	// Verify MAC using derived key
-	if err := enhancedService.verifyMAC(derivedKey, cryptoParams); err != nil {
+	// Try the NFKD password first, then the password as typed
+	var derivedKey []byte
+	var macErr error
+	for _, candidate := range PasswordCandidates(password) {
+		derivedKey, err = kdfService.DeriveKey(candidate, cryptoParams)
+		if err != nil {
+			// Provide KDF-specific error context
+			kdfContext := fmt.Sprintf("KDF: %s (%s), Security Level: %s",
+				compatReport.KDFType, compatReport.NormalizedKDF, compatReport.SecurityLevel)
+			return nil, NewKeystoreImportError(
+				ErrorIncorrectPassword,
+				fmt.Sprintf("Failed to derive key using Universal KDF (%s): %v", kdfContext, err),
+				err,
+			)
+		}
+
+		// Verify MAC using derived key
+		if macErr = enhancedService.verifyMAC(derivedKey, cryptoParams); macErr == nil {
+			break
+		}
+	}
+	if macErr != nil {
 		return nil, NewKeystoreImportError(
 			ErrorIncorrectPassword,
 			"Incorrect password or corrupted keystore file",
-			err,
+			macErr,
 		)
 	}
 
@@ -504,35 +619,27 @@ func (ws *WalletService) ImportWalletFromKeystoreV3WithProgress(name, keystorePa
 	var nilMnemonic *string = nil
 
 	// Step 16: Create destination path
+	ws.importMu.Lock()
+	defer ws.importMu.Unlock()
 	address := normalizedDerivedAddress
 	destFilename := fmt.Sprintf("%s.json", address)
 
-	var keystoreDir string
-	accounts := ws.KeyStore.Accounts()
-	if len(accounts) > 0 {
-		keystoreDir = filepath.Dir(accounts[0].URL.Path)
-	} else {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return nil, NewKeystoreImportError(
-				ErrorFileNotFound,
-				"Error getting user home directory",
-				err,
-			)
-		}
-		keystoreDir = filepath.Join(homeDir, ".wallets", "keystore")
-
-		if err := os.MkdirAll(keystoreDir, 0700); err != nil {
-			return nil, NewKeystoreImportError(
-				ErrorFileNotFound,
-				"Error creating keystore directory",
-				err,
-			)
-		}
+	keystoreDir, err := ws.keystoreDir()
+	if err != nil {
+		return nil, NewKeystoreImportError(
+			ErrorFileNotFound,
+			"Error creating keystore directory",
+			err,
+		)
 	}
 
 	destPath := filepath.Join(keystoreDir, destFilename)
 
+	// A different keystore of the same address is only replaced once approved
+	if err := ws.checkKeystoreOverwrite(destPath, keystorePath, address, keyJSON); err != nil {
+		return nil, err
+	}
+
 	// Step 17: Copy keystore file to destination
 	ws.sendProgressUpdate(progressChan, ImportProgress{
 		CurrentFile:     keystorePath,
@@ -546,6 +653,10 @@ func (ws *WalletService) ImportWalletFromKeystoreV3WithProgress(name, keystorePa
 		ElapsedTime:     0,
 	})
 
+	// The copied file and the wallet row are created together or not at all;
+	// a file already at the destination is restored if the import fails
+	op := &operation{}
+	previous, readErr := os.ReadFile(destPath)
 	destFile, err := os.Create(destPath)
 	if err != nil {
 		return nil, NewKeystoreImportError(
@@ -554,21 +665,22 @@ func (ws *WalletService) ImportWalletFromKeystoreV3WithProgress(name, keystorePa
 			err,
 		)
 	}
-	defer func() {
-		if err := destFile.Close(); err != nil {
-			// Avoid printing to terminal; write to file logger if available
-			if svcLogger != nil {
-				svcLogger.Warn("Error closing destination file: " + err.Error())
-			}
-		}
-	}()
+	if readErr == nil {
+		op.onRollback(func() error { return os.WriteFile(destPath, previous, 0600) })
+	} else {
+		op.onRollback(removeFile(destPath))
+	}
 
-	if _, err = destFile.Write(keyJSON); err != nil {
-		return nil, NewKeystoreImportError(
+	_, err = destFile.Write(keyJSON)
+	if closeErr := destFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, op.rollback(NewKeystoreImportError(
 			ErrorFileNotFound,
 			"Error writing to destination file",
 			err,
-		)
+		))
 	}
 
 	// Step 18: Create wallet entry with import method and source hash (no mnemonic)
@@ -579,6 +691,7 @@ func (ws *WalletService) ImportWalletFromKeystoreV3WithProgress(name, keystorePa
 		Mnemonic:     nilMnemonic, // No mnemonic for keystore imports
 		ImportMethod: string(ImportMethodKeystore),
 		SourceHash:   sourceHash,
+		CreatedAt:    createdAt,
 	}
 
 	// Step 19: Add wallet to repository
@@ -595,11 +708,11 @@ func (ws *WalletService) ImportWalletFromKeystoreV3WithProgress(name, keystorePa
 	})
 
 	if err = ws.Repo.AddWallet(wallet); err != nil {
-		return nil, NewKeystoreImportError(
+		return nil, op.rollback(NewKeystoreImportError(
 			ErrorCorruptedFile,
 			"Failed to add wallet to repository",
 			err,
-		)
+		))
 	}
 
 	// Step 20: Create KDF information for wallet details
@@ -628,6 +741,7 @@ func (ws *WalletService) ImportWalletFromKeystoreV3WithProgress(name, keystorePa
 		Mnemonic:     nil, // No mnemonic available for keystore imports
 		PrivateKey:   privateKey,
 		PublicKey:    &privateKey.PublicKey,
+		Signer:       signerForKey(privateKey, ImportMethodKeystore),
 		ImportMethod: ImportMethodKeystore,
 		HasMnemonic:  false, // Keystore imports don't have mnemonics
 		KDFInfo:      kdfInfo,
@@ -655,37 +769,48 @@ func (ws *WalletService) sendProgressUpdate(progressChan chan<- ImportProgress,
 	}
 }
 
-// ImportWalletFromKeystore is kept for backward compatibility
-// It calls the new ImportWalletFromKeystoreV3 function
+// ImportWalletFromKeystore imports a KeyStore V3 file.
+//
+// Deprecated: use ImportWalletFromKeystoreV3; kept for callers of APIV1.
 func (ws *WalletService) ImportWalletFromKeystore(name, keystorePath, password string) (*WalletDetails, error) {
 	return ws.ImportWalletFromKeystoreV3(name, keystorePath, password)
 }
 
 func (ws *WalletService) LoadWallet(wallet *Wallet, password string) (*WalletDetails, error) {
+	// Watch-only entries have no keystore to open
+	if wallet.WatchOnly() {
+		return &WalletDetails{Wallet: wallet, ImportMethod: ImportMethodWatchOnly}, nil
+	}
 	keyJSON, err := os.ReadFile(wallet.KeyStorePath)
 	if err != nil {
 		return nil, fmt.Errorf("error reading the wallet file: %v", err)
 	}
-	key, err := keystore.DecryptKey(keyJSON, password)
+	key, usedPassword, err := decryptKeystoreJSON(keyJSON, password)
 	if err != nil {
 		return nil, fmt.Errorf("incorrect password")
 	}
 
-	// Decrypt the mnemonic
+	// Decrypt the mnemonic with the same password form that opened the keystore
 	var mnemonicPtr *string
 	if wallet.Mnemonic != nil {
-		decryptedMnemonic, err := DecryptMnemonic(*wallet.Mnemonic, password)
+		decryptedMnemonic, err := DecryptMnemonic(*wallet.Mnemonic, usedPassword)
 		if err != nil {
 			return nil, fmt.Errorf("failed to decrypt mnemonic: %v", err)
 		}
 		mnemonicPtr = &decryptedMnemonic
 	}
 
+	// The key must belong to the wallet it was loaded for
+	if problems := verifyWalletKey(wallet, keyJSON, key, mnemonicPtr); len(problems) > 0 {
+		return nil, &IntegrityError{Wallet: wallet, Problems: problems}
+	}
+
 	walletDetails := &WalletDetails{
 		Wallet:       wallet,
 		Mnemonic:     mnemonicPtr,
 		PrivateKey:   key.PrivateKey,
 		PublicKey:    &key.PrivateKey.PublicKey,
+		Signer:       signerForKey(key.PrivateKey, ImportMethod(wallet.ImportMethod)),
 		ImportMethod: ImportMethod(wallet.ImportMethod),
 		HasMnemonic:  wallet.Mnemonic != nil,
 	}
@@ -696,14 +821,78 @@ func (ws *WalletService) GetAllWallets() ([]Wallet, error) {
 	return ws.Repo.GetAllWallets()
 }
 
+// DeleteWallet removes the wallet row and its keystore file. The file is first
+// moved aside and only removed once the row is gone, so a failure in either
+// step leaves the wallet intact.
 func (ws *WalletService) DeleteWallet(wallet *Wallet) error {
-	// Remove o arquivo keystore do sistema
-	err := os.Remove(wallet.KeyStorePath)
-	if err != nil && !os.IsNotExist(err) {
+	op := &operation{}
+	aside := ""
+	if _, err := os.Stat(wallet.KeyStorePath); err == nil {
+		// Hidden, so the keystore directory scanner skips it meanwhile
+		aside = filepath.Join(filepath.Dir(wallet.KeyStorePath), "."+filepath.Base(wallet.KeyStorePath)+".deleting")
+		if err := os.Rename(wallet.KeyStorePath, aside); err != nil {
+			return fmt.Errorf("failed to remove keystore file: %v", err)
+		}
+		op.onRollback(func() error { return os.Rename(aside, wallet.KeyStorePath) })
+	} else if !os.IsNotExist(err) {
 		return fmt.Errorf("failed to remove keystore file: %v", err)
 	}
-	// Remove do banco de dados
-	return ws.Repo.DeleteWallet(wallet.ID)
+
+	if err := ws.Repo.DeleteWallet(wallet.ID, wallet.Version); err != nil {
+		return op.rollback(err)
+	}
+
+	if aside != "" {
+		if err := os.Remove(aside); err != nil && svcLogger != nil {
+			// The wallet is gone; the leftover file is still encrypted
+			svcLogger.Warn("Failed to remove deleted keystore file " + aside + ": " + err.Error())
+		}
+	}
+	return nil
+}
+
+// RenameWallet changes a wallet's display name. The change time is kept so
+// metadata sync can tell which installation renamed it last.
+func (ws *WalletService) RenameWallet(wallet *Wallet, name string, at time.Time) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("wallet name cannot be empty")
+	}
+	if err := ws.Repo.UpdateWalletName(wallet.ID, wallet.Version, name, at); err != nil {
+		return err
+	}
+	wallet.Name = name
+	wallet.UpdatedAt = at
+	wallet.Version++
+	return nil
+}
+
+// MaxWalletNotesLength bounds the free-text notes of a wallet
+const MaxWalletNotesLength = 4000
+
+// UpdateWalletNotes replaces the notes and external references of a wallet.
+// Blank and repeated references are dropped.
+func (ws *WalletService) UpdateWalletNotes(wallet *Wallet, notes string, references []string, at time.Time) error {
+	notes = strings.TrimSpace(notes)
+	if len([]rune(notes)) > MaxWalletNotesLength {
+		return fmt.Errorf("notes cannot be longer than %d characters", MaxWalletNotesLength)
+	}
+	var refs WalletReferences
+	seen := map[string]bool{}
+	for _, ref := range references {
+		if ref = strings.TrimSpace(ref); ref != "" && !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+	if err := ws.Repo.UpdateWalletNotes(wallet.ID, wallet.Version, notes, refs, at); err != nil {
+		return err
+	}
+	wallet.Notes = notes
+	wallet.References = refs
+	wallet.UpdatedAt = at
+	wallet.Version++
+	return nil
 }
 
 // Helper functions
@@ -721,6 +910,11 @@ func GenerateMnemonic() (string, error) {
 }
 
 func DerivePrivateKey(mnemonic string) (string, error) {
+	return deriveAddressKey(mnemonic, 0)
+}
+
+// deriveAddressKey returns the hex private key at m/44'/60'/0'/0/index
+func deriveAddressKey(mnemonic string, index uint32) (string, error) {
 	if !bip39.IsMnemonicValid(mnemonic) {
 		return "", fmt.Errorf("invalid mnemonic phrase")
 	}
@@ -745,7 +939,7 @@ func DerivePrivateKey(mnemonic string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	addressKey, err := changeKey.NewChildKey(0)
+	addressKey, err := changeKey.NewChildKey(index)
 	if err != nil {
 		return "", err
 	}
@@ -5,12 +5,16 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"blocowallet/pkg/config"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // Mock repository for testing
@@ -50,6 +54,16 @@ func (m *MockWalletRepository) FindByAddressAndMethod(address, importMethod stri
 	return args.Get(0).([]Wallet), args.Error(1)
 }
 
+func (m *MockWalletRepository) UpdateWalletName(walletID, version int, name string, updatedAt time.Time) error {
+	args := m.Called(walletID, version, name, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) UpdateWalletNotes(walletID, version int, notes string, references WalletReferences, updatedAt time.Time) error {
+	args := m.Called(walletID, version, notes, references, updatedAt)
+	return args.Error(0)
+}
+
 func (m *MockWalletRepository) GetWalletByID(id int) (*Wallet, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
@@ -76,8 +90,8 @@ func (m *MockWalletRepository) UpdateWallet(wallet *Wallet) error {
 	return args.Error(0)
 }
 
-func (m *MockWalletRepository) DeleteWallet(id int) error {
-	args := m.Called(id)
+func (m *MockWalletRepository) DeleteWallet(id, version int) error {
+	args := m.Called(id, version)
 	return args.Error(0)
 }
 
@@ -693,6 +707,49 @@ func TestImportWalletFromKeystoreV3_RepositoryError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestImportWalletFromKeystoreJSON(t *testing.T) {
+	InitCryptoService(CreateMockConfig())
+	keystorePath, address := createTestKeystoreFile(t, "testpassword")
+	defer os.RemoveAll(filepath.Dir(keystorePath))
+	keyJSON, err := os.ReadFile(keystorePath)
+	require.NoError(t, err)
+
+	mockRepo := new(MockWalletRepository)
+	mockRepo.On("AddWallet", mock.AnythingOfType("*wallet.Wallet")).Return(nil)
+	n, p := GetTestKeystoreParams()
+	// An account keeps the imported keystore in the test's directory
+	ks := keystore.NewKeyStore(t.TempDir(), n, p)
+	_, err = ks.NewAccount("other")
+	require.NoError(t, err)
+	walletService := NewWalletService(mockRepo, ks)
+
+	t.Run("pasted keystore with surrounding whitespace", func(t *testing.T) {
+		pasted := append(append([]byte("\n  "), keyJSON...), "\n\n"...)
+		details, err := walletService.ImportWalletFromKeystoreJSON("Pasted", pasted, "testpassword")
+		require.NoError(t, err)
+		assert.Equal(t, address.Hex(), details.Wallet.Address)
+		assert.Equal(t, string(ImportMethodKeystore), details.Wallet.ImportMethod)
+
+		stored, err := os.ReadFile(details.Wallet.KeyStorePath)
+		require.NoError(t, err)
+		assert.Equal(t, keyJSON, stored)
+	})
+
+	t.Run("empty paste", func(t *testing.T) {
+		_, err := walletService.ImportWalletFromKeystoreJSON("Empty", []byte(" \n"), "testpassword")
+		var keystoreErr *KeystoreImportError
+		require.ErrorAs(t, err, &keystoreErr)
+		assert.Equal(t, ErrorInvalidJSON, keystoreErr.Type)
+	})
+
+	t.Run("incorrect password", func(t *testing.T) {
+		_, err := walletService.ImportWalletFromKeystoreJSON("Wrong", keyJSON, "wrong")
+		var keystoreErr *KeystoreImportError
+		require.ErrorAs(t, err, &keystoreErr)
+		assert.Equal(t, ErrorIncorrectPassword, keystoreErr.Type)
+	})
+}
+
 func TestImportWalletFromKeystore_BackwardCompatibility(t *testing.T) {
 	// Initialize crypto service for mnemonic encryption with mock config
 	mockConfig := CreateMockConfig()
@@ -861,3 +918,59 @@ func TestDeterministicMnemonicInImport(t *testing.T) {
 	// Verify that the repository was called
 	mockRepo.AssertExpectations(t)
 }
+
+func TestUpdateWalletNotes(t *testing.T) {
+	mockRepo := new(MockWalletRepository)
+	walletService := &WalletService{Repo: mockRepo}
+	w := &Wallet{ID: 7, Name: "Treasury", Address: "0xAbC", Version: 3}
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	// References are trimmed and deduplicated, empty entries dropped
+	mockRepo.On("UpdateWalletNotes", 7, 3, "Multisig signer", WalletReferences{"OPS-1", "https://wiki"}, at).Return(nil)
+	err := walletService.UpdateWalletNotes(w, "  Multisig signer \n", []string{" OPS-1", "", "https://wiki", "OPS-1 "}, at)
+	assert.NoError(t, err)
+	assert.Equal(t, "Multisig signer", w.Notes)
+	assert.Equal(t, 4, w.Version)
+	mockRepo.AssertExpectations(t)
+
+	long := make([]rune, MaxWalletNotesLength+1)
+	for i := range long {
+		long[i] = 'x'
+	}
+	assert.Error(t, walletService.UpdateWalletNotes(w, string(long), nil, at))
+	assert.Equal(t, "Multisig signer", w.Notes)
+}
+
+func TestWalletMatches(t *testing.T) {
+	w := Wallet{Name: "Treasury", Address: "0xAbC123", Notes: "Held by the finance team", References: WalletReferences{"OPS-42"}}
+
+	for _, query := range []string{"treasury", "0xabc", "FINANCE", "ops-42", "  "} {
+		assert.True(t, w.Matches(query), query)
+	}
+	assert.False(t, w.Matches("payroll"))
+}
+
+func TestWalletService_ApplyConfig(t *testing.T) {
+	cfg := CreateMockConfig()
+	cfg.Import.MemoryLimitMB = 256
+	cfg.Security.KeystoreKDF = config.KeystoreKDFArgon2id
+	cfg.Security.ExportCipher = CipherAES256CTR
+	ws := &WalletService{}
+	ws.ApplyConfig(cfg)
+	guard := ws.MemoryGuard
+	require.NotNil(t, guard)
+	assert.Equal(t, uint64(256<<20), guard.Stats().Limit)
+	require.NotNil(t, ws.KeystoreArgon2id)
+	assert.Equal(t, cfg.Security.Argon2Memory, ws.KeystoreArgon2id.Memory)
+	assert.Equal(t, CipherAES256CTR, ws.ExportCipher)
+
+	// Applied again, the guard of running imports is kept with the new limit
+	cfg.Import.MemoryLimitMB = 512
+	cfg.Security.KeystoreKDF = config.KeystoreKDFScrypt
+	cfg.Security.ExportCipher = "des-ede3-cbc"
+	ws.ApplyConfig(cfg)
+	assert.Same(t, guard, ws.MemoryGuard)
+	assert.Equal(t, uint64(512<<20), guard.Stats().Limit)
+	assert.Nil(t, ws.KeystoreArgon2id)
+	assert.Equal(t, CipherAES128CTR, ws.ExportCipher, "unsupported ciphers fall back to geth's")
+}
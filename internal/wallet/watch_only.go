@@ -0,0 +1,145 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// WatchOnly reports whether the wallet is an address tracked without a key
+func (w Wallet) WatchOnly() bool {
+	return ImportMethod(w.ImportMethod) == ImportMethodWatchOnly
+}
+
+// watchOnlySourceHash identifies a watch-only entry by its address, so an
+// address is watched at most once
+func watchOnlySourceHash(address common.Address) string {
+	return (&SourceHashGenerator{}).GenerateFromPrivateKey(string(ImportMethodWatchOnly) + ":" + address.Hex())
+}
+
+// AddWatchOnlyWallet stores an address without any key, to follow it and
+// record who it belongs to. It cannot sign or be exported.
+func (ws *WalletService) AddWatchOnlyWallet(name, address, notes string, references []string) (*Wallet, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid Ethereum address: %s", address)
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("a name is required")
+	}
+	addr := common.HexToAddress(address)
+	sourceHash := watchOnlySourceHash(addr)
+	if existing, err := ws.Repo.FindBySourceHash(sourceHash); err != nil {
+		return nil, err
+	} else if existing != nil {
+		return nil, NewDuplicateWalletError(string(ImportMethodWatchOnly), existing.Address, "This address is already watched")
+	}
+	w := &Wallet{
+		Name:         name,
+		Address:      addr.Hex(),
+		ImportMethod: string(ImportMethodWatchOnly),
+		SourceHash:   sourceHash,
+		Notes:        strings.TrimSpace(notes),
+		References:   references,
+	}
+	if err := ws.Repo.AddWallet(w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// SafeReference is the reference that tags the owners of a Safe, e.g.
+// "safe:1:0x5aFE..."
+func SafeReference(chainID int64, safe common.Address) string {
+	return fmt.Sprintf("safe:%d:%s", chainID, safe.Hex())
+}
+
+// SafeOwner is a signer of a Safe with the local entries of its address
+type SafeOwner struct {
+	Address common.Address
+	Wallets []Wallet
+}
+
+// HasKey reports whether a local wallet holds the key of the owner
+func (o SafeOwner) HasKey() bool {
+	for _, w := range o.Wallets {
+		if !w.WatchOnly() {
+			return true
+		}
+	}
+	return false
+}
+
+// SafeOwners looks up which owner addresses already have local entries
+func (ws *WalletService) SafeOwners(owners []common.Address) ([]SafeOwner, error) {
+	result := make([]SafeOwner, 0, len(owners))
+	for _, owner := range owners {
+		wallets, err := ws.Repo.FindByAddress(owner.Hex())
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, SafeOwner{Address: owner, Wallets: wallets})
+	}
+	return result, nil
+}
+
+// SafeOwnerImport is what ImportSafeOwners changed
+type SafeOwnerImport struct {
+	Created []Wallet // watch-only entries added for owners without one
+	Tagged  []Wallet // existing entries that gained the Safe's references
+}
+
+// ImportSafeOwners tags the local entries of each owner with the Safe's
+// reference and label, and adds a watch-only entry, named after label, for
+// every owner without one. On error the changes made so far are returned.
+func (ws *WalletService) ImportSafeOwners(chainID int64, safe common.Address, owners []SafeOwner, label string, at time.Time) (SafeOwnerImport, error) {
+	var result SafeOwnerImport
+	label = strings.TrimSpace(label)
+	if label == "" {
+		label = "Safe " + shortAddress(safe)
+	}
+	refs := []string{SafeReference(chainID, safe), label}
+
+	for i, owner := range owners {
+		if len(owner.Wallets) == 0 {
+			name := fmt.Sprintf("%s owner %d", label, i+1)
+			notes := fmt.Sprintf("Owner of Safe %s on chain %d", safe.Hex(), chainID)
+			w, err := ws.AddWatchOnlyWallet(name, owner.Address.Hex(), notes, refs)
+			if err != nil {
+				return result, err
+			}
+			result.Created = append(result.Created, *w)
+			continue
+		}
+		for j := range owner.Wallets {
+			w := &owner.Wallets[j]
+			if hasReferences(w.References, refs) {
+				continue
+			}
+			if err := ws.UpdateWalletNotes(w, w.Notes, append(append([]string{}, w.References...), refs...), at); err != nil {
+				return result, err
+			}
+			result.Tagged = append(result.Tagged, *w)
+		}
+	}
+	return result, nil
+}
+
+func hasReferences(have WalletReferences, want []string) bool {
+	for _, ref := range want {
+		if !slices.Contains(have, ref) {
+			return false
+		}
+	}
+	return true
+}
+
+// shortAddress abbreviates an address as 0x1234…abcd
+func shortAddress(address common.Address) string {
+	hex := address.Hex()
+	return hex[:6] + "…" + hex[len(hex)-4:]
+}
@@ -0,0 +1,42 @@
+package wallet
+
+import (
+	_ "embed"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+//go:embed bip39_english.txt
+var bundledWordList string
+
+// WordListVersion is the version stamp of the bundled BIP-39 wordlist
+var WordListVersion string
+
+func init() {
+	version, words := parseEmbeddedList(bundledWordList)
+	if len(words) != 2048 {
+		panic("bundled BIP-39 wordlist must hold 2048 words")
+	}
+	WordListVersion = version
+	bip39.SetWordList(words)
+}
+
+// parseEmbeddedList splits a bundled data file into its "# version:" stamp
+// and its entries, one per line, skipping blank lines and other comments
+func parseEmbeddedList(data string) (string, []string) {
+	var version string
+	var entries []string
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if v, ok := strings.CutPrefix(line, "# version:"); ok {
+			version = strings.TrimSpace(v)
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries = append(entries, line)
+	}
+	return version, entries
+}
@@ -0,0 +1,16 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tyler-smith/go-bip39"
+	"github.com/tyler-smith/go-bip39/wordlists"
+)
+
+func TestBundledWordList(t *testing.T) {
+	assert.NotEmpty(t, WordListVersion)
+	_, words := parseEmbeddedList(bundledWordList)
+	assert.Equal(t, wordlists.English, words, "the vendored list must match BIP-39 English")
+	assert.Equal(t, words, bip39.GetWordList())
+}
@@ -0,0 +1,622 @@
+package walletconnect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/websocket"
+)
+
+// Relay tags and time-to-live of each message, as the WalletConnect SDKs
+// publish them. Dapps drop messages whose tag does not match their kind.
+const (
+	tagPairingDelete         = 1000
+	tagPairingDeleteResponse = 1001
+	tagPairingPingResponse   = 1003
+	tagProposeApprove        = 1101
+	tagSessionSettle         = 1102
+	tagRequestResponse       = 1109
+	tagSessionDelete         = 1112
+	tagSessionDeleteResponse = 1113
+	tagSessionPingResponse   = 1115
+	tagProposeReject         = 1120
+
+	responseTTL   = 5 * time.Minute
+	deleteTTL     = 24 * time.Hour
+	pingTTL       = 30 * time.Second
+	sessionExpiry = 7 * 24 * time.Hour
+	publishWait   = 30 * time.Second
+)
+
+// Error codes of the WalletConnect SDKs
+const (
+	CodeUserRejected        = 5000
+	CodeUnsupportedChains   = 5100
+	CodeUnsupportedMethods  = 5101
+	CodeUnsupportedAccounts = 5103
+	CodeUserDisconnected    = 6000
+	// CodeRequestFailed reports a request that was approved but failed
+	CodeRequestFailed = -32000
+)
+
+// Metadata describes a dapp or this wallet to the peer
+type Metadata struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	URL         string   `json:"url"`
+	Icons       []string `json:"icons"`
+}
+
+// Namespace lists what a session covers in one chain family
+type Namespace struct {
+	Chains   []string `json:"chains,omitempty"`
+	Methods  []string `json:"methods"`
+	Events   []string `json:"events"`
+	Accounts []string `json:"accounts,omitempty"`
+}
+
+// Proposal is a dapp asking to open a session
+type Proposal struct {
+	ID           int64
+	PairingTopic string
+	Proposer     Metadata
+	Required     map[string]Namespace
+	Optional     map[string]Namespace
+	Expiry       time.Time
+
+	proposerKey string
+}
+
+// RequiredChains returns the EIP-155 chain IDs the dapp cannot work without
+func (p *Proposal) RequiredChains() ([]int64, error) {
+	return namespaceChains(p.Required)
+}
+
+// OptionalChains returns the EIP-155 chain IDs the dapp can also use
+func (p *Proposal) OptionalChains() []int64 {
+	chains, _ := namespaceChains(p.Optional)
+	return chains
+}
+
+// namespaceChains reads the eip155 chains of namespaces, which may be keyed
+// "eip155" with a chain list or "eip155:<id>" for a single chain. Other
+// chain families cannot be served.
+func namespaceChains(namespaces map[string]Namespace) ([]int64, error) {
+	var chains []int64
+	for key, ns := range namespaces {
+		family, _, _ := strings.Cut(key, ":")
+		if family != "eip155" {
+			return nil, fmt.Errorf("the dapp requires %s chains, which this wallet does not support", family)
+		}
+		refs := ns.Chains
+		if key != family {
+			refs = append(refs, key)
+		}
+		for _, ref := range refs {
+			id, err := ParseChainID(ref)
+			if err != nil {
+				return nil, err
+			}
+			if !slices.Contains(chains, id) {
+				chains = append(chains, id)
+			}
+		}
+	}
+	slices.Sort(chains)
+	return chains, nil
+}
+
+// ParseChainID reads a CAIP-2 chain such as "eip155:1"
+func ParseChainID(chain string) (int64, error) {
+	ref, ok := strings.CutPrefix(chain, "eip155:")
+	if !ok {
+		return 0, fmt.Errorf("chain %q is not an EIP-155 chain", chain)
+	}
+	id, err := strconv.ParseInt(ref, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid chain %q", chain)
+	}
+	return id, nil
+}
+
+// Session is an approved connection with a dapp for one wallet
+type Session struct {
+	Topic   string
+	Peer    Metadata
+	Address common.Address
+	Chains  []int64
+	Expiry  time.Time
+	// Acknowledged is set once the dapp confirmed the session
+	Acknowledged bool
+
+	pairingTopic string
+}
+
+// Request is a dapp asking a session's wallet to sign or send
+type Request struct {
+	ID      int64
+	Topic   string
+	Peer    Metadata
+	Address common.Address
+	ChainID int64
+	Method  string
+	Params  json.RawMessage
+}
+
+// Event is something the dapps did, delivered by Client.Events. Exactly one
+// field is set.
+type Event struct {
+	Proposal *Proposal
+	Request  *Request
+	Settled  *Session // The dapp acknowledged the session
+	Deleted  *Session // The dapp disconnected
+	Err      error    // The relay connection dropped or a message was rejected
+}
+
+// Client pairs with dapps and serves their sessions through one relay
+// connection. Sessions live as long as the client: they are not stored.
+type Client struct {
+	dialer    websocket.Dialer
+	relayURL  string
+	projectID string
+	metadata  Metadata
+
+	mu        sync.Mutex
+	relay     *relay
+	keys      map[string]SymKey // Key of every subscribed topic
+	proposals map[int64]*Proposal
+	sessions  map[string]*Session
+	settling  map[int64]string // Session topic of each settle request awaiting its response
+	events    chan Event
+}
+
+// NewClient returns a client for the relay at relayURL, identified by the
+// project ID registered with WalletConnect. dialer carries the proxy settings.
+func NewClient(dialer websocket.Dialer, relayURL, projectID string, metadata Metadata) *Client {
+	if relayURL == "" {
+		relayURL = DefaultRelayURL
+	}
+	return &Client{
+		dialer:    dialer,
+		relayURL:  relayURL,
+		projectID: projectID,
+		metadata:  metadata,
+		keys:      make(map[string]SymKey),
+		proposals: make(map[int64]*Proposal),
+		sessions:  make(map[string]*Session),
+		settling:  make(map[int64]string),
+		events:    make(chan Event, 16),
+	}
+}
+
+// Events delivers proposals, requests and session changes
+func (c *Client) Events() <-chan Event {
+	return c.events
+}
+
+// Connect opens the relay connection, or reopens it after it dropped, and
+// subscribes again to the topics of the pairings and sessions
+func (c *Client) Connect(ctx context.Context) error {
+	c.mu.Lock()
+	if c.relay != nil && c.relay.closedErr() == nil {
+		c.mu.Unlock()
+		return nil
+	}
+	c.mu.Unlock()
+
+	r, err := dialRelay(ctx, c.dialer, c.relayURL, c.projectID)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.relay = r
+	topics := make([]string, 0, len(c.keys))
+	for topic := range c.keys {
+		topics = append(topics, topic)
+	}
+	c.mu.Unlock()
+	go c.pump(r)
+
+	for _, topic := range topics {
+		if err := r.subscribe(ctx, topic); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Connected reports whether the relay connection is open
+func (c *Client) Connected() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.relay != nil && c.relay.closedErr() == nil
+}
+
+// Close disconnects from the relay. Sessions are not deleted: the dapps see
+// the wallet as offline.
+func (c *Client) Close() {
+	c.mu.Lock()
+	r := c.relay
+	c.relay = nil
+	c.mu.Unlock()
+	if r != nil {
+		r.close()
+	}
+}
+
+// Pair subscribes to the pairing topic of uri; the dapp's proposal arrives
+// as an Event
+func (c *Client) Pair(ctx context.Context, uri PairingURI) error {
+	if !uri.Expiry.IsZero() && time.Now().After(uri.Expiry) {
+		return errors.New("the WalletConnect URI has expired, ask the dapp for a new one")
+	}
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.keys[uri.Topic] = uri.SymKey
+	r := c.relay
+	c.mu.Unlock()
+	return r.subscribe(ctx, uri.Topic)
+}
+
+// Sessions returns the open sessions, by dapp name
+func (c *Client) Sessions() []Session {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sessions := make([]Session, 0, len(c.sessions))
+	for _, s := range c.sessions {
+		sessions = append(sessions, *s)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].Peer.Name != sessions[j].Peer.Name {
+			return sessions[i].Peer.Name < sessions[j].Peer.Name
+		}
+		return sessions[i].Topic < sessions[j].Topic
+	})
+	return sessions
+}
+
+// Approve opens a session of proposal p for address on chains, which must
+// include every chain the dapp requires. The dapp confirms with a Settled event.
+func (c *Client) Approve(ctx context.Context, p *Proposal, address common.Address, chains []int64) (*Session, error) {
+	required, err := p.RequiredChains()
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range required {
+		if !slices.Contains(chains, id) {
+			return nil, fmt.Errorf("the dapp requires chain %d, which is not among the networks", id)
+		}
+	}
+	if len(chains) == 0 {
+		return nil, errors.New("no chain to open the session on")
+	}
+
+	private, err := newKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	key, err := deriveSymKey(private, p.proposerKey)
+	if err != nil {
+		return nil, err
+	}
+	session := &Session{
+		Topic:        key.Topic(),
+		Peer:         p.Proposer,
+		Address:      address,
+		Chains:       slices.Sorted(slices.Values(chains)),
+		Expiry:       time.Now().Add(sessionExpiry),
+		pairingTopic: p.PairingTopic,
+	}
+
+	c.mu.Lock()
+	r := c.relay
+	c.keys[session.Topic] = key
+	c.mu.Unlock()
+	if r == nil {
+		return nil, ErrRelayClosed
+	}
+	if err := r.subscribe(ctx, session.Topic); err != nil {
+		return nil, err
+	}
+	publicKey := fmt.Sprintf("%x", private.PublicKey().Bytes())
+	if err := c.respond(ctx, p.PairingTopic, p.ID, tagProposeApprove, map[string]any{
+		"relay":              map[string]string{"protocol": RelayProtocol},
+		"responderPublicKey": publicKey,
+	}); err != nil {
+		return nil, err
+	}
+
+	settleID := payloadID()
+	c.mu.Lock()
+	c.sessions[session.Topic] = session
+	c.settling[settleID] = session.Topic
+	delete(c.proposals, p.ID)
+	c.mu.Unlock()
+	err = c.send(ctx, session.Topic, rpcMessage{ID: settleID, JSONRPC: "2.0", Method: "wc_sessionSettle"}, tagSessionSettle, responseTTL, map[string]any{
+		"relay":        map[string]string{"protocol": RelayProtocol},
+		"namespaces":   map[string]Namespace{"eip155": sessionNamespace(p, address, session.Chains)},
+		"controller":   map[string]any{"publicKey": publicKey, "metadata": c.metadata},
+		"expiry":       session.Expiry.Unix(),
+		"pairingTopic": p.PairingTopic,
+	})
+	if err != nil {
+		c.mu.Lock()
+		delete(c.sessions, session.Topic)
+		delete(c.settling, settleID)
+		c.mu.Unlock()
+		return nil, err
+	}
+	copied := *session
+	return &copied, nil
+}
+
+// sessionNamespace grants the dapp the methods and events it asked for on top
+// of the supported ones, as it refuses a session missing any it requires.
+// Unsupported methods are answered with an error when called.
+func sessionNamespace(p *Proposal, address common.Address, chains []int64) Namespace {
+	ns := Namespace{Methods: slices.Clone(SupportedMethods), Events: slices.Clone(SupportedEvents)}
+	for key, requested := range p.Required {
+		if family, _, _ := strings.Cut(key, ":"); family != "eip155" {
+			continue
+		}
+		for _, m := range requested.Methods {
+			if !slices.Contains(ns.Methods, m) {
+				ns.Methods = append(ns.Methods, m)
+			}
+		}
+		for _, e := range requested.Events {
+			if !slices.Contains(ns.Events, e) {
+				ns.Events = append(ns.Events, e)
+			}
+		}
+	}
+	for _, id := range chains {
+		chain := "eip155:" + strconv.FormatInt(id, 10)
+		ns.Chains = append(ns.Chains, chain)
+		ns.Accounts = append(ns.Accounts, chain+":"+address.Hex())
+	}
+	return ns
+}
+
+// Reject declines proposal p
+func (c *Client) Reject(ctx context.Context, p *Proposal) error {
+	c.mu.Lock()
+	delete(c.proposals, p.ID)
+	c.mu.Unlock()
+	return c.respondError(ctx, p.PairingTopic, p.ID, tagProposeReject, CodeUserRejected, "User rejected.")
+}
+
+// Respond answers request r with its result
+func (c *Client) Respond(ctx context.Context, r *Request, result any) error {
+	return c.respond(ctx, r.Topic, r.ID, tagRequestResponse, result)
+}
+
+// RespondError answers request r with an error, such as CodeUserRejected
+func (c *Client) RespondError(ctx context.Context, r *Request, code int, message string) error {
+	return c.respondError(ctx, r.Topic, r.ID, tagRequestResponse, code, message)
+}
+
+// Disconnect deletes session s for both sides
+func (c *Client) Disconnect(ctx context.Context, s Session) error {
+	err := c.send(ctx, s.Topic, rpcMessage{ID: payloadID(), JSONRPC: "2.0", Method: "wc_sessionDelete"}, tagSessionDelete, deleteTTL,
+		map[string]any{"code": CodeUserDisconnected, "message": "User disconnected."})
+	c.forgetSession(s.Topic)
+	return err
+}
+
+func (c *Client) forgetSession(topic string) *Session {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.sessions[topic]
+	delete(c.sessions, topic)
+	delete(c.keys, topic)
+	return s
+}
+
+func (c *Client) respond(ctx context.Context, topic string, id int64, tag int, result any) error {
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return c.send(ctx, topic, rpcMessage{ID: id, JSONRPC: "2.0", Result: raw}, tag, responseTTL, nil)
+}
+
+func (c *Client) respondError(ctx context.Context, topic string, id int64, tag, code int, message string) error {
+	return c.send(ctx, topic, rpcMessage{ID: id, JSONRPC: "2.0", Error: &rpcError{Code: code, Message: message}}, tag, responseTTL, nil)
+}
+
+// send encrypts msg, with params when it is a request, and publishes it on topic
+func (c *Client) send(ctx context.Context, topic string, msg rpcMessage, tag int, ttl time.Duration, params any) error {
+	if params != nil {
+		raw, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		msg.Params = raw
+	}
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	key, ok := c.keys[topic]
+	r := c.relay
+	c.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("unknown WalletConnect topic %s", topic)
+	}
+	if r == nil {
+		return ErrRelayClosed
+	}
+	message, err := Encrypt(key, payload)
+	if err != nil {
+		return err
+	}
+	return r.publish(ctx, topic, message, ttl, tag)
+}
+
+// pump decrypts the messages of r and turns them into events until r closes
+func (c *Client) pump(r *relay) {
+	for {
+		select {
+		case msg := <-r.messages:
+			if event, ok := c.handle(msg); ok {
+				c.events <- event
+			}
+		case <-r.done:
+			c.mu.Lock()
+			current := c.relay == r
+			c.mu.Unlock()
+			// A connection closed by Close or replaced by Connect is not an error
+			if current {
+				c.events <- Event{Err: r.closedErr()}
+			}
+			return
+		}
+	}
+}
+
+// handle reads one message from a peer, answering pings and deletions itself
+func (c *Client) handle(msg relayMessage) (Event, bool) {
+	c.mu.Lock()
+	key, ok := c.keys[msg.Topic]
+	c.mu.Unlock()
+	if !ok {
+		return Event{}, false
+	}
+	payload, err := Decrypt(key, msg.Message)
+	if err != nil {
+		return Event{Err: err}, true
+	}
+	var rpc rpcMessage
+	if err := json.Unmarshal(payload, &rpc); err != nil {
+		return Event{Err: fmt.Errorf("invalid WalletConnect message: %w", err)}, true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishWait)
+	defer cancel()
+	switch rpc.Method {
+	case "":
+		return c.handleResponse(rpc)
+	case "wc_sessionPropose":
+		return c.handleProposal(msg.Topic, rpc)
+	case "wc_sessionRequest":
+		return c.handleRequest(ctx, msg.Topic, rpc)
+	case "wc_sessionPing":
+		_ = c.respond(ctx, msg.Topic, rpc.ID, tagSessionPingResponse, true)
+	case "wc_pairingPing":
+		_ = c.respond(ctx, msg.Topic, rpc.ID, tagPairingPingResponse, true)
+	case "wc_sessionDelete":
+		_ = c.respond(ctx, msg.Topic, rpc.ID, tagSessionDeleteResponse, true)
+		if s := c.forgetSession(msg.Topic); s != nil {
+			return Event{Deleted: s}, true
+		}
+	case "wc_pairingDelete":
+		_ = c.respond(ctx, msg.Topic, rpc.ID, tagPairingDeleteResponse, true)
+		c.mu.Lock()
+		delete(c.keys, msg.Topic)
+		c.mu.Unlock()
+	default:
+		_ = c.respondError(ctx, msg.Topic, rpc.ID, tagRequestResponse, CodeUnsupportedMethods, "Unsupported method "+rpc.Method)
+	}
+	return Event{}, false
+}
+
+// handleResponse reads the dapp's answer to a session settlement
+func (c *Client) handleResponse(rpc rpcMessage) (Event, bool) {
+	c.mu.Lock()
+	topic, ok := c.settling[rpc.ID]
+	delete(c.settling, rpc.ID)
+	session := c.sessions[topic]
+	if ok && session != nil && rpc.Error == nil {
+		session.Acknowledged = true
+	}
+	c.mu.Unlock()
+	if !ok || session == nil {
+		return Event{}, false
+	}
+	if rpc.Error != nil {
+		c.forgetSession(topic)
+		return Event{Err: fmt.Errorf("%s refused the session: %w", session.Peer.Name, rpc.Error)}, true
+	}
+	copied := *session
+	return Event{Settled: &copied}, true
+}
+
+func (c *Client) handleProposal(topic string, rpc rpcMessage) (Event, bool) {
+	var params struct {
+		Required map[string]Namespace `json:"requiredNamespaces"`
+		Optional map[string]Namespace `json:"optionalNamespaces"`
+		Proposer struct {
+			PublicKey string   `json:"publicKey"`
+			Metadata  Metadata `json:"metadata"`
+		} `json:"proposer"`
+		ExpiryTimestamp int64 `json:"expiryTimestamp"`
+	}
+	if err := json.Unmarshal(rpc.Params, &params); err != nil {
+		return Event{Err: fmt.Errorf("invalid session proposal: %w", err)}, true
+	}
+	p := &Proposal{
+		ID:           rpc.ID,
+		PairingTopic: topic,
+		Proposer:     params.Proposer.Metadata,
+		Required:     params.Required,
+		Optional:     params.Optional,
+		proposerKey:  params.Proposer.PublicKey,
+	}
+	if params.ExpiryTimestamp > 0 {
+		p.Expiry = time.Unix(params.ExpiryTimestamp, 0)
+	}
+	c.mu.Lock()
+	c.proposals[p.ID] = p
+	c.mu.Unlock()
+	return Event{Proposal: p}, true
+}
+
+func (c *Client) handleRequest(ctx context.Context, topic string, rpc rpcMessage) (Event, bool) {
+	c.mu.Lock()
+	session := c.sessions[topic]
+	c.mu.Unlock()
+	if session == nil {
+		return Event{}, false
+	}
+	var params struct {
+		Request struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		} `json:"request"`
+		ChainID string `json:"chainId"`
+	}
+	if err := json.Unmarshal(rpc.Params, &params); err != nil {
+		return Event{Err: fmt.Errorf("invalid session request: %w", err)}, true
+	}
+	chainID, err := ParseChainID(params.ChainID)
+	if err != nil || !slices.Contains(session.Chains, chainID) {
+		_ = c.respondError(ctx, topic, rpc.ID, tagRequestResponse, CodeUnsupportedChains, "Unsupported chain "+params.ChainID)
+		return Event{}, false
+	}
+	if !slices.Contains(SupportedMethods, params.Request.Method) {
+		_ = c.respondError(ctx, topic, rpc.ID, tagRequestResponse, CodeUnsupportedMethods, "Unsupported method "+params.Request.Method)
+		return Event{}, false
+	}
+	return Event{Request: &Request{
+		ID:      rpc.ID,
+		Topic:   topic,
+		Peer:    session.Peer,
+		Address: session.Address,
+		ChainID: chainID,
+		Method:  params.Request.Method,
+		Params:  params.Request.Params,
+	}}, true
+}
@@ -0,0 +1,314 @@
+package walletconnect
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"blocowallet/internal/wallet"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// published is a message the client published on the fake relay
+type published struct {
+	Topic   string `json:"topic"`
+	Message string `json:"message"`
+	TTL     int    `json:"ttl"`
+	Tag     int    `json:"tag"`
+}
+
+// fakeRelay accepts one client, records its subscriptions and publications
+// and delivers the messages a test sends as the dapp
+type fakeRelay struct {
+	t         *testing.T
+	server    *httptest.Server
+	query     chan map[string][]string
+	published chan published
+
+	mu            sync.Mutex
+	conn          *websocket.Conn
+	subscriptions map[string]bool
+}
+
+func newFakeRelay(t *testing.T) *fakeRelay {
+	r := &fakeRelay{
+		t:             t,
+		query:         make(chan map[string][]string, 1),
+		published:     make(chan published, 16),
+		subscriptions: make(map[string]bool),
+	}
+	upgrader := websocket.Upgrader{}
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+		r.query <- req.URL.Query()
+		r.mu.Lock()
+		r.conn = conn
+		r.mu.Unlock()
+		for {
+			var msg rpcMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			switch msg.Method {
+			case "irn_subscribe":
+				var params struct{ Topic string }
+				_ = json.Unmarshal(msg.Params, &params)
+				r.mu.Lock()
+				r.subscriptions[params.Topic] = true
+				r.mu.Unlock()
+				r.write(rpcMessage{ID: msg.ID, JSONRPC: "2.0", Result: json.RawMessage(`"subscription-id"`)})
+			case "irn_publish":
+				var p published
+				_ = json.Unmarshal(msg.Params, &p)
+				r.published <- p
+				r.write(rpcMessage{ID: msg.ID, JSONRPC: "2.0", Result: json.RawMessage("true")})
+			}
+		}
+	}))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func (r *fakeRelay) url() string {
+	return "ws" + strings.TrimPrefix(r.server.URL, "http")
+}
+
+func (r *fakeRelay) write(msg rpcMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	require.NoError(r.t, r.conn.WriteJSON(msg))
+}
+
+func (r *fakeRelay) subscribed(topic string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.subscriptions[topic]
+}
+
+// deliver sends msg from the dapp, encrypted with key, on its topic
+func (r *fakeRelay) deliver(key SymKey, msg rpcMessage) {
+	payload, err := json.Marshal(msg)
+	require.NoError(r.t, err)
+	message, err := Encrypt(key, payload)
+	require.NoError(r.t, err)
+	params, _ := json.Marshal(map[string]any{"id": "sub", "data": relayMessage{Topic: key.Topic(), Message: message}})
+	r.write(rpcMessage{ID: payloadID(), JSONRPC: "2.0", Method: "irn_subscription", Params: params})
+}
+
+// next returns the next publication of the client, decrypted with key
+func (r *fakeRelay) next(key SymKey) (published, rpcMessage) {
+	select {
+	case p := <-r.published:
+		payload, err := Decrypt(key, p.Message)
+		require.NoError(r.t, err)
+		var msg rpcMessage
+		require.NoError(r.t, json.Unmarshal(payload, &msg))
+		return p, msg
+	case <-time.After(5 * time.Second):
+		r.t.Fatal("the client published nothing")
+		return published{}, rpcMessage{}
+	}
+}
+
+func nextEvent(t *testing.T, c *Client) Event {
+	select {
+	case e := <-c.Events():
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event")
+		return Event{}
+	}
+}
+
+func TestDialRelay_Authenticates(t *testing.T) {
+	_, err := dialRelay(context.Background(), websocket.Dialer{}, "wss://relay.example.org", "")
+	assert.Error(t, err, "a project ID is required")
+
+	relay := newFakeRelay(t)
+	r, err := dialRelay(context.Background(), websocket.Dialer{}, relay.url(), "project-1")
+	require.NoError(t, err)
+	defer r.close()
+
+	query := <-relay.query
+	assert.Equal(t, []string{"project-1"}, query["projectId"])
+	parts := strings.Split(query["auth"][0], ".")
+	require.Len(t, parts, 3)
+	var claims struct {
+		Iss string `json:"iss"`
+		Aud string `json:"aud"`
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(raw, &claims))
+	assert.True(t, strings.HasPrefix(claims.Iss, "did:key:z6Mk"), "Ed25519 did:keys start with z6Mk")
+	assert.Equal(t, relay.url(), claims.Aud)
+}
+
+func TestDidKey(t *testing.T) {
+	// The public key of the all-zero seed
+	public := ed25519.NewKeyFromSeed(make([]byte, 32)).Public().(ed25519.PublicKey)
+	assert.Equal(t, "did:key:z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp", didKey(public))
+	assert.Equal(t, "112", base58([]byte{0, 0, 1}), "each leading zero byte is a 1")
+}
+
+func TestClient_SessionLifecycle(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	relay := newFakeRelay(t)
+	client := NewClient(websocket.Dialer{}, relay.url(), "project-1", Metadata{Name: "bloco wallet"})
+	defer client.Close()
+
+	// Pairing: the wallet subscribes to the topic of the URI
+	pairingKey := randomKey(t)
+	uri, err := ParseURI(fmt.Sprintf("wc:%s@2?relay-protocol=irn&symKey=%s", pairingKey.Topic(), hexKey(pairingKey)))
+	require.NoError(t, err)
+	require.NoError(t, client.Pair(ctx, uri))
+	assert.True(t, relay.subscribed(pairingKey.Topic()))
+
+	// The dapp proposes a session
+	dappKey, err := newKeyPair()
+	require.NoError(t, err)
+	proposeParams, _ := json.Marshal(map[string]any{
+		"requiredNamespaces": map[string]Namespace{"eip155": {Chains: []string{"eip155:1"}, Methods: []string{"personal_sign", "wallet_switchEthereumChain"}, Events: []string{"chainChanged"}}},
+		"optionalNamespaces": map[string]Namespace{"eip155:137": {Methods: []string{"eth_sendTransaction"}}},
+		"proposer":           map[string]any{"publicKey": fmt.Sprintf("%x", dappKey.PublicKey().Bytes()), "metadata": Metadata{Name: "Test dapp", URL: "https://dapp.example"}},
+	})
+	relay.deliver(pairingKey, rpcMessage{ID: 1, JSONRPC: "2.0", Method: "wc_sessionPropose", Params: proposeParams})
+	event := nextEvent(t, client)
+	require.NotNil(t, event.Proposal)
+	proposal := event.Proposal
+	assert.Equal(t, "Test dapp", proposal.Proposer.Name)
+	required, err := proposal.RequiredChains()
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, required)
+	assert.Equal(t, []int64{137}, proposal.OptionalChains())
+
+	_, err = client.Approve(ctx, proposal, common.Address{}, []int64{137})
+	assert.Error(t, err, "every required chain must be approved")
+
+	// Approval answers the proposal and settles the session under the derived key
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	session, err := client.Approve(ctx, proposal, address, []int64{1, 137})
+	require.NoError(t, err)
+
+	p, resp := relay.next(pairingKey)
+	assert.Equal(t, tagProposeApprove, p.Tag)
+	assert.EqualValues(t, 1, resp.ID)
+	var approval struct {
+		ResponderPublicKey string `json:"responderPublicKey"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Result, &approval))
+	sessionKey, err := deriveSymKey(dappKey, approval.ResponderPublicKey)
+	require.NoError(t, err)
+	assert.Equal(t, sessionKey.Topic(), session.Topic)
+	assert.True(t, relay.subscribed(session.Topic))
+
+	p, settle := relay.next(sessionKey)
+	assert.Equal(t, tagSessionSettle, p.Tag)
+	assert.Equal(t, "wc_sessionSettle", settle.Method)
+	var settleParams struct {
+		Namespaces map[string]Namespace `json:"namespaces"`
+	}
+	require.NoError(t, json.Unmarshal(settle.Params, &settleParams))
+	ns := settleParams.Namespaces["eip155"]
+	assert.Equal(t, []string{"eip155:1", "eip155:137"}, ns.Chains)
+	assert.Equal(t, []string{"eip155:1:" + address.Hex(), "eip155:137:" + address.Hex()}, ns.Accounts)
+	assert.Contains(t, ns.Methods, "wallet_switchEthereumChain", "required methods are granted so the dapp accepts the session")
+
+	relay.deliver(sessionKey, rpcMessage{ID: settle.ID, JSONRPC: "2.0", Result: json.RawMessage("true")})
+	event = nextEvent(t, client)
+	require.NotNil(t, event.Settled)
+	assert.True(t, event.Settled.Acknowledged)
+
+	// Requests for unsupported methods are answered without the user
+	unsupported, _ := json.Marshal(map[string]any{"chainId": "eip155:1", "request": map[string]any{"method": "wallet_switchEthereumChain", "params": []any{}}})
+	relay.deliver(sessionKey, rpcMessage{ID: 2, JSONRPC: "2.0", Method: "wc_sessionRequest", Params: unsupported})
+	p, resp = relay.next(sessionKey)
+	assert.Equal(t, tagRequestResponse, p.Tag)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, CodeUnsupportedMethods, resp.Error.Code)
+
+	// A signature request reaches the user, who signs it
+	sign, _ := json.Marshal(map[string]any{"chainId": "eip155:1", "request": map[string]any{"method": "personal_sign", "params": []string{"0x68656c6c6f", address.Hex()}}})
+	relay.deliver(sessionKey, rpcMessage{ID: 3, JSONRPC: "2.0", Method: "wc_sessionRequest", Params: sign})
+	event = nextEvent(t, client)
+	require.NotNil(t, event.Request)
+	assert.Equal(t, int64(1), event.Request.ChainID)
+	assert.Equal(t, address, event.Request.Address)
+
+	req, err := ParseRequest(event.Request)
+	require.NoError(t, err)
+	result, err := req.Execute(ctx, wallet.NewKeySigner(key), nil)
+	require.NoError(t, err)
+	require.NoError(t, client.Respond(ctx, event.Request, result.Value))
+	_, resp = relay.next(sessionKey)
+	assert.EqualValues(t, 3, resp.ID)
+	var signature string
+	require.NoError(t, json.Unmarshal(resp.Result, &signature))
+	sig := hexutil.MustDecode(signature)
+	sig[64] -= 27
+	signer, err := crypto.SigToPub(accounts.TextHash([]byte("hello")), sig)
+	require.NoError(t, err)
+	assert.Equal(t, address, crypto.PubkeyToAddress(*signer))
+
+	// The dapp disconnects
+	relay.deliver(sessionKey, rpcMessage{ID: 4, JSONRPC: "2.0", Method: "wc_sessionDelete", Params: json.RawMessage(`{"code":6000,"message":"bye"}`)})
+	p, _ = relay.next(sessionKey)
+	assert.Equal(t, tagSessionDeleteResponse, p.Tag)
+	event = nextEvent(t, client)
+	require.NotNil(t, event.Deleted)
+	assert.Empty(t, client.Sessions())
+}
+
+func TestClient_Reject(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	relay := newFakeRelay(t)
+	client := NewClient(websocket.Dialer{}, relay.url(), "project-1", Metadata{})
+	defer client.Close()
+
+	pairingKey := randomKey(t)
+	require.NoError(t, client.Pair(ctx, PairingURI{Topic: pairingKey.Topic(), SymKey: pairingKey}))
+	params, _ := json.Marshal(map[string]any{
+		"requiredNamespaces": map[string]Namespace{"solana": {Chains: []string{"solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp"}}},
+		"proposer":           map[string]any{"publicKey": strings.Repeat("ab", 32), "metadata": Metadata{Name: "Other chain"}},
+	})
+	relay.deliver(pairingKey, rpcMessage{ID: 7, JSONRPC: "2.0", Method: "wc_sessionPropose", Params: params})
+	event := nextEvent(t, client)
+	require.NotNil(t, event.Proposal)
+	_, err := event.Proposal.RequiredChains()
+	assert.Error(t, err, "only EIP-155 chains are served")
+
+	require.NoError(t, client.Reject(ctx, event.Proposal))
+	p, resp := relay.next(pairingKey)
+	assert.Equal(t, tagProposeReject, p.Tag)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, CodeUserRejected, resp.Error.Code)
+}
+
+func TestClient_PairRejectsExpiredURI(t *testing.T) {
+	client := NewClient(websocket.Dialer{}, "", "project-1", Metadata{})
+	err := client.Pair(context.Background(), PairingURI{Topic: "abc", Expiry: time.Now().Add(-time.Minute)})
+	assert.Error(t, err)
+	assert.False(t, client.Connected())
+}
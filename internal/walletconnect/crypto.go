@@ -0,0 +1,98 @@
+package walletconnect
+
+import (
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// SymKey encrypts the messages of a pairing or a session
+type SymKey [32]byte
+
+// Topic is the relay topic of the messages encrypted with k: the hex SHA-256
+// of the key
+func (k SymKey) Topic() string {
+	sum := sha256.Sum256(k[:])
+	return hex.EncodeToString(sum[:])
+}
+
+// envelopeType0 is a message encrypted with the topic's key. Type 1, which
+// also carries the sender's public key, is only used by flows this client
+// does not offer.
+const envelopeType0 = 0
+
+// Encrypt seals payload with key into a base64 type 0 envelope:
+// type byte, 12-byte nonce, ChaCha20-Poly1305 ciphertext
+func Encrypt(key SymKey, payload []byte) (string, error) {
+	aead, err := chacha20poly1305.New(key[:])
+	if err != nil {
+		return "", err
+	}
+	envelope := make([]byte, 1+aead.NonceSize(), 1+aead.NonceSize()+len(payload)+aead.Overhead())
+	envelope[0] = envelopeType0
+	if _, err := rand.Read(envelope[1:]); err != nil {
+		return "", err
+	}
+	envelope = aead.Seal(envelope, envelope[1:], payload, nil)
+	return base64.StdEncoding.EncodeToString(envelope), nil
+}
+
+// Decrypt opens a base64 envelope sealed with key
+func Decrypt(key SymKey, message string) ([]byte, error) {
+	envelope, err := base64.StdEncoding.DecodeString(message)
+	if err != nil {
+		return nil, fmt.Errorf("invalid envelope: %w", err)
+	}
+	aead, err := chacha20poly1305.New(key[:])
+	if err != nil {
+		return nil, err
+	}
+	if len(envelope) < 1+aead.NonceSize()+aead.Overhead() {
+		return nil, errors.New("invalid envelope: too short")
+	}
+	if envelope[0] != envelopeType0 {
+		return nil, fmt.Errorf("envelope type %d is not supported", envelope[0])
+	}
+	nonce, sealed := envelope[1:1+aead.NonceSize()], envelope[1+aead.NonceSize():]
+	payload, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, errors.New("invalid envelope: cannot decrypt")
+	}
+	return payload, nil
+}
+
+// newKeyPair generates the X25519 key agreed on when a session is approved
+func newKeyPair() (*ecdh.PrivateKey, error) {
+	return ecdh.X25519().GenerateKey(rand.Reader)
+}
+
+// deriveSymKey derives the session key from our private key and the peer's
+// hex public key: HKDF-SHA256 of the X25519 shared secret
+func deriveSymKey(private *ecdh.PrivateKey, peerPublicHex string) (SymKey, error) {
+	var key SymKey
+	raw, err := hex.DecodeString(peerPublicHex)
+	if err != nil {
+		return key, fmt.Errorf("invalid peer public key: %w", err)
+	}
+	peer, err := ecdh.X25519().NewPublicKey(raw)
+	if err != nil {
+		return key, fmt.Errorf("invalid peer public key: %w", err)
+	}
+	shared, err := private.ECDH(peer)
+	if err != nil {
+		return key, err
+	}
+	derived, err := hkdf.Key(sha256.New, shared, nil, "", len(key))
+	if err != nil {
+		return key, err
+	}
+	copy(key[:], derived)
+	return key, nil
+}
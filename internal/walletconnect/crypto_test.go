@@ -0,0 +1,68 @@
+package walletconnect
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hexKey(k SymKey) string { return hex.EncodeToString(k[:]) }
+
+func randomKey(t *testing.T) SymKey {
+	var k SymKey
+	_, err := rand.Read(k[:])
+	require.NoError(t, err)
+	return k
+}
+
+func TestSymKeyTopic(t *testing.T) {
+	var k SymKey
+	// sha256 of 32 zero bytes
+	assert.Equal(t, "66687aadf862bd776c8fc18b8e9f8e20089714856ee233b3902a591d0d5f2925", k.Topic())
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := randomKey(t)
+	message, err := Encrypt(key, []byte(`{"id":1}`))
+	require.NoError(t, err)
+
+	envelope, err := base64.StdEncoding.DecodeString(message)
+	require.NoError(t, err)
+	assert.Equal(t, byte(envelopeType0), envelope[0])
+	assert.Len(t, envelope, 1+12+len(`{"id":1}`)+16)
+
+	payload, err := Decrypt(key, message)
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":1}`, string(payload))
+
+	_, err = Decrypt(randomKey(t), message)
+	assert.Error(t, err, "another key cannot open it")
+	_, err = Decrypt(key, "AA==")
+	assert.Error(t, err)
+	envelope[0] = 1
+	_, err = Decrypt(key, base64.StdEncoding.EncodeToString(envelope))
+	assert.Error(t, err, "type 1 envelopes are not supported")
+}
+
+func TestDeriveSymKey_BothSidesAgree(t *testing.T) {
+	wallet, err := newKeyPair()
+	require.NoError(t, err)
+	dapp, err := newKeyPair()
+	require.NoError(t, err)
+
+	ours, err := deriveSymKey(wallet, fmt.Sprintf("%x", dapp.PublicKey().Bytes()))
+	require.NoError(t, err)
+	theirs, err := deriveSymKey(dapp, fmt.Sprintf("%x", wallet.PublicKey().Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, ours, theirs)
+
+	_, err = deriveSymKey(wallet, "zz")
+	assert.Error(t, err)
+	_, err = deriveSymKey(wallet, "abcd")
+	assert.Error(t, err)
+}
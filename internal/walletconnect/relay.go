@@ -0,0 +1,286 @@
+package walletconnect
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// RelayProtocol is the relay protocol of WalletConnect v2
+const RelayProtocol = "irn"
+
+// DefaultRelayURL is the public WalletConnect relay
+const DefaultRelayURL = "wss://relay.walletconnect.org"
+
+// authTTL is how long the relay accepts the token the connection was opened with
+const authTTL = 24 * time.Hour
+
+// ErrRelayClosed is returned by calls on a relay connection that was closed
+var ErrRelayClosed = errors.New("WalletConnect relay connection closed")
+
+// relayMessage is a message published on a topic this client subscribed to
+type relayMessage struct {
+	Topic       string `json:"topic"`
+	Message     string `json:"message"`
+	PublishedAt int64  `json:"publishedAt"`
+	Tag         int    `json:"tag"`
+}
+
+// rpcMessage is a JSON-RPC request or response, of the relay or of a peer
+type rpcMessage struct {
+	ID      int64           `json:"id"`
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+// payloadID returns a JSON-RPC id the way the WalletConnect SDKs do: the time
+// in milliseconds followed by three random digits
+func payloadID() int64 {
+	n, _ := rand.Int(rand.Reader, big.NewInt(1000))
+	return time.Now().UnixMilli()*1000 + n.Int64()
+}
+
+// relay is a websocket connection to the WalletConnect relay. Messages on
+// subscribed topics are acknowledged and delivered on messages.
+type relay struct {
+	conn     *websocket.Conn
+	writeMu  sync.Mutex
+	mu       sync.Mutex
+	pending  map[int64]chan rpcMessage
+	messages chan relayMessage
+	done     chan struct{}
+	err      error
+}
+
+// dialRelay connects to relayURL, authenticating with a fresh client key
+func dialRelay(ctx context.Context, dialer websocket.Dialer, relayURL, projectID string) (*relay, error) {
+	if projectID == "" {
+		return nil, errors.New("a WalletConnect project ID is required, see walletconnect.project_id in config.toml")
+	}
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	token, err := authToken(key, relayURL, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(relayURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid relay URL: %w", err)
+	}
+	query := u.Query()
+	query.Set("auth", token)
+	query.Set("projectId", projectID)
+	query.Set("ua", fmt.Sprintf("wc-2/go/%s/bloco-wallet", runtime.GOOS))
+	u.RawQuery = query.Encode()
+
+	conn, _, err := dialer.DialContext(ctx, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the WalletConnect relay: %w", err)
+	}
+	r := &relay{
+		conn:     conn,
+		pending:  make(map[int64]chan rpcMessage),
+		messages: make(chan relayMessage, 64),
+		done:     make(chan struct{}),
+	}
+	go r.readLoop()
+	return r, nil
+}
+
+// authToken signs the JWT the relay authenticates clients with. The issuer
+// is the did:key of the client's Ed25519 key.
+func authToken(key ed25519.PrivateKey, audience string, now time.Time) (string, error) {
+	subject := make([]byte, 32)
+	if _, err := rand.Read(subject); err != nil {
+		return "", err
+	}
+	header, _ := json.Marshal(map[string]string{"alg": "EdDSA", "typ": "JWT"})
+	payload, err := json.Marshal(map[string]any{
+		"iss": didKey(key.Public().(ed25519.PublicKey)),
+		"sub": hex.EncodeToString(subject),
+		"aud": audience,
+		"iat": now.Unix(),
+		"exp": now.Add(authTTL).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	encode := base64.RawURLEncoding.EncodeToString
+	signingInput := encode(header) + "." + encode(payload)
+	return signingInput + "." + encode(ed25519.Sign(key, []byte(signingInput))), nil
+}
+
+// didKey encodes an Ed25519 public key as did:key: the multicodec prefix
+// 0xed01 and the key, in base58btc
+func didKey(public ed25519.PublicKey) string {
+	return "did:key:z" + base58(append([]byte{0xed, 0x01}, public...))
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+func base58(data []byte) string {
+	n := new(big.Int).SetBytes(data)
+	radix, mod := big.NewInt(58), new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// readLoop answers the relay's deliveries and routes responses to calls
+func (r *relay) readLoop() {
+	for {
+		var msg rpcMessage
+		if err := r.conn.ReadJSON(&msg); err != nil {
+			r.shutdown(err)
+			return
+		}
+		if msg.Method == "" {
+			r.mu.Lock()
+			reply, ok := r.pending[msg.ID]
+			delete(r.pending, msg.ID)
+			r.mu.Unlock()
+			if ok {
+				reply <- msg
+			}
+			continue
+		}
+		if msg.Method != "irn_subscription" {
+			continue
+		}
+		var params struct {
+			Data relayMessage `json:"data"`
+		}
+		if json.Unmarshal(msg.Params, &params) != nil {
+			continue
+		}
+		// The relay redelivers messages that are not acknowledged
+		_ = r.write(rpcMessage{ID: msg.ID, JSONRPC: "2.0", Result: json.RawMessage("true")})
+		select {
+		case r.messages <- params.Data:
+		case <-r.done:
+			return
+		}
+	}
+}
+
+func (r *relay) write(msg rpcMessage) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	return r.conn.WriteJSON(msg)
+}
+
+// call sends a relay request and waits for its result
+func (r *relay) call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	msg := rpcMessage{ID: payloadID(), JSONRPC: "2.0", Method: method, Params: raw}
+	reply := make(chan rpcMessage, 1)
+	r.mu.Lock()
+	if r.err != nil {
+		r.mu.Unlock()
+		return nil, r.err
+	}
+	r.pending[msg.ID] = reply
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.pending, msg.ID)
+		r.mu.Unlock()
+	}()
+
+	if err := r.write(msg); err != nil {
+		return nil, err
+	}
+	select {
+	case resp := <-reply:
+		if resp.Error != nil {
+			return nil, fmt.Errorf("%s failed: %w", method, resp.Error)
+		}
+		return resp.Result, nil
+	case <-r.done:
+		return nil, r.closedErr()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// subscribe asks the relay for the messages published on topic
+func (r *relay) subscribe(ctx context.Context, topic string) error {
+	_, err := r.call(ctx, "irn_subscribe", map[string]string{"topic": topic})
+	return err
+}
+
+// publish sends an encrypted message to topic. The relay keeps it for ttl
+// seconds for a peer that is offline; tag tells the kind of message.
+func (r *relay) publish(ctx context.Context, topic, message string, ttl time.Duration, tag int) error {
+	_, err := r.call(ctx, "irn_publish", map[string]any{
+		"topic":   topic,
+		"message": message,
+		"ttl":     int(ttl.Seconds()),
+		"tag":     tag,
+		"prompt":  false,
+	})
+	return err
+}
+
+func (r *relay) closedErr() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *relay) shutdown(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	r.err = fmt.Errorf("%w: %v", ErrRelayClosed, err)
+	close(r.done)
+}
+
+// close disconnects from the relay
+func (r *relay) close() {
+	r.shutdown(errors.New("closed by the client"))
+	r.conn.Close()
+}
@@ -0,0 +1,219 @@
+package walletconnect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"blocowallet/internal/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// SupportedMethods are the requests a session serves. eth_sign, which signs
+// an arbitrary hash, is left out on purpose: it is the usual phishing vector.
+var SupportedMethods = []string{
+	"eth_sendTransaction",
+	"eth_signTransaction",
+	"personal_sign",
+	"eth_signTypedData",
+	"eth_signTypedData_v4",
+}
+
+// SupportedEvents are the session events announced to dapps
+var SupportedEvents = []string{"chainChanged", "accountsChanged"}
+
+// TxParams is the transaction of an eth_sendTransaction or
+// eth_signTransaction request. The nonce and fees are always taken from the
+// network; the gas limit is used when the dapp gives one.
+type TxParams struct {
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to"`
+	Value *hexutil.Big    `json:"value"`
+	Data  hexutil.Bytes   `json:"data"`
+	Input hexutil.Bytes   `json:"input"`
+	Gas   *hexutil.Uint64 `json:"gas"`
+}
+
+// Payload returns the call data, which dapps give as data or input
+func (t *TxParams) Payload() []byte {
+	if len(t.Data) > 0 {
+		return t.Data
+	}
+	return t.Input
+}
+
+// Amount returns the value in wei, zero when not given
+func (t *TxParams) Amount() *big.Int {
+	if t.Value == nil {
+		return new(big.Int)
+	}
+	return t.Value.ToInt()
+}
+
+// SignRequest is a decoded Request, ready to be shown and executed
+type SignRequest struct {
+	Method  string
+	From    common.Address
+	ChainID int64
+	// Message is the message of personal_sign, decoded when the dapp sent hex
+	Message   []byte
+	TypedData *apitypes.TypedData
+	Tx        *TxParams
+}
+
+// ParseRequest decodes the params of r and checks that they are for the
+// session's account
+func ParseRequest(r *Request) (*SignRequest, error) {
+	req := &SignRequest{Method: r.Method, ChainID: r.ChainID}
+	switch r.Method {
+	case "eth_sendTransaction", "eth_signTransaction":
+		var params []TxParams
+		if err := json.Unmarshal(r.Params, &params); err != nil || len(params) == 0 {
+			return nil, errors.New("invalid transaction request")
+		}
+		req.Tx, req.From = &params[0], params[0].From
+		if req.Tx.To == nil {
+			return nil, errors.New("contract deployments are not supported")
+		}
+	case "personal_sign":
+		var params []string
+		if err := json.Unmarshal(r.Params, &params); err != nil || len(params) < 2 {
+			return nil, errors.New("invalid personal_sign request")
+		}
+		// The message comes first, but some dapps swap it with the address
+		message, address := params[0], params[1]
+		if common.IsHexAddress(message) && !common.IsHexAddress(address) {
+			message, address = address, message
+		}
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid address %q", address)
+		}
+		req.From = common.HexToAddress(address)
+		req.Message = []byte(message)
+		if decoded, err := hexutil.Decode(message); err == nil {
+			req.Message = decoded
+		}
+	case "eth_signTypedData", "eth_signTypedData_v4":
+		var params []json.RawMessage
+		if err := json.Unmarshal(r.Params, &params); err != nil || len(params) < 2 {
+			return nil, errors.New("invalid typed data request")
+		}
+		var address string
+		if err := json.Unmarshal(params[0], &address); err != nil || !common.IsHexAddress(address) {
+			return nil, errors.New("invalid typed data request: missing address")
+		}
+		req.From = common.HexToAddress(address)
+		// The typed data is a JSON document, usually sent as a string
+		data := []byte(params[1])
+		var encoded string
+		if json.Unmarshal(params[1], &encoded) == nil {
+			data = []byte(encoded)
+		}
+		req.TypedData = new(apitypes.TypedData)
+		if err := json.Unmarshal(data, req.TypedData); err != nil {
+			return nil, fmt.Errorf("invalid typed data: %w", err)
+		}
+		if id := req.TypedData.Domain.ChainId; id != nil && (*big.Int)(id).Int64() != r.ChainID {
+			return nil, fmt.Errorf("the typed data is for chain %s, the request for chain %d", (*big.Int)(id), r.ChainID)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported method %s", r.Method)
+	}
+	if req.From != r.Address {
+		return nil, fmt.Errorf("the request is for %s, the session is for %s", req.From.Hex(), r.Address.Hex())
+	}
+	return req, nil
+}
+
+// Summary describes the request in one line for the user to review
+func (s *SignRequest) Summary() string {
+	switch {
+	case s.Tx != nil:
+		summary := fmt.Sprintf("%s ETH to %s", wallet.FormatUnits(s.Tx.Amount(), 18), s.Tx.To.Hex())
+		if data := s.Tx.Payload(); len(data) > 0 {
+			summary += fmt.Sprintf(", calling %s with %d bytes of data", hexutil.Encode(data[:min(4, len(data))]), len(data))
+		}
+		return summary
+	case s.TypedData != nil:
+		return fmt.Sprintf("%s of %s (%s)", s.TypedData.PrimaryType, s.TypedData.Domain.Name, s.TypedData.Domain.VerifyingContract)
+	default:
+		if isText(s.Message) {
+			return string(s.Message)
+		}
+		return hexutil.Encode(s.Message)
+	}
+}
+
+func isText(b []byte) bool {
+	return strings.ToValidUTF8(string(b), "�") == string(b) && !strings.ContainsFunc(string(b), func(r rune) bool {
+		return r < 0x20 && r != '\n' && r != '\t' && r != '\r'
+	})
+}
+
+// Result is the answer to an executed request
+type Result struct {
+	// Value is sent back to the dapp: a transaction hash, a raw transaction
+	// or a signature, all as hex
+	Value string
+	// Sent is the transaction sent for eth_sendTransaction, to be tracked
+	Sent *wallet.RawTransaction
+}
+
+// Execute signs the request with signer, sending transactions through ts
+func (s *SignRequest) Execute(ctx context.Context, signer wallet.Signer, ts *wallet.TransactionService) (Result, error) {
+	if signer == nil {
+		return Result{}, errors.New("the wallet must be unlocked to sign")
+	}
+	if signer.Address() != s.From {
+		return Result{}, fmt.Errorf("the unlocked wallet is %s, the request is for %s", signer.Address().Hex(), s.From.Hex())
+	}
+	switch {
+	case s.Tx != nil:
+		req, err := ts.BuildTransfer(ctx, s.From, *s.Tx.To, s.Tx.Amount(), s.Tx.Payload())
+		if err != nil {
+			return Result{}, err
+		}
+		if req.ChainID.Int64() != s.ChainID {
+			return Result{}, fmt.Errorf("the network is chain %s, the request is for chain %d", req.ChainID, s.ChainID)
+		}
+		if s.Tx.Gas != nil && uint64(*s.Tx.Gas) > req.GasLimit {
+			req.GasLimit = uint64(*s.Tx.Gas)
+		}
+		if s.Method == "eth_signTransaction" {
+			tx, err := wallet.SignTransfer(signer, req)
+			if err != nil {
+				return Result{}, err
+			}
+			raw, err := wallet.EncodeRawTransaction(tx)
+			return Result{Value: raw}, err
+		}
+		sent, err := ts.SendTransfer(ctx, signer, req)
+		if err != nil {
+			return Result{}, err
+		}
+		return Result{Value: sent.Tx.Hash().Hex(), Sent: sent}, nil
+	case s.TypedData != nil:
+		hash, _, err := apitypes.TypedDataAndHash(*s.TypedData)
+		if err != nil {
+			return Result{}, fmt.Errorf("invalid typed data: %w", err)
+		}
+		sig, err := signer.SignHash(hash)
+		if err != nil {
+			return Result{}, err
+		}
+		sig[64] += 27
+		return Result{Value: hexutil.Encode(sig)}, nil
+	default:
+		sig, err := signer.SignText(s.Message)
+		if err != nil {
+			return Result{}, err
+		}
+		return Result{Value: hexutil.Encode(sig)}, nil
+	}
+}
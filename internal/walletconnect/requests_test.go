@@ -0,0 +1,116 @@
+package walletconnect
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"blocowallet/internal/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testAccount = common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+
+func request(method string, params any) *Request {
+	raw, _ := json.Marshal(params)
+	return &Request{ID: 1, Address: testAccount, ChainID: 1, Method: method, Params: raw}
+}
+
+func TestParseRequest_PersonalSign(t *testing.T) {
+	req, err := ParseRequest(request("personal_sign", []string{"0x48656c6c6f", testAccount.Hex()}))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("Hello"), req.Message)
+	assert.Equal(t, "Hello", req.Summary())
+
+	// Some dapps put the address first; a message that is not hex is signed as typed
+	req, err = ParseRequest(request("personal_sign", []string{testAccount.Hex(), "Sign in"}))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("Sign in"), req.Message)
+
+	req, err = ParseRequest(request("personal_sign", []string{"0x00ff", testAccount.Hex()}))
+	require.NoError(t, err)
+	assert.Equal(t, "0x00ff", req.Summary(), "binary messages are shown as hex")
+
+	_, err = ParseRequest(request("personal_sign", []string{"0x48656c6c6f", "0x0000000000000000000000000000000000000001"}))
+	assert.Error(t, err, "the request must be for the session's account")
+}
+
+func TestParseRequest_Transaction(t *testing.T) {
+	to := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	req, err := ParseRequest(request("eth_sendTransaction", []map[string]string{{
+		"from":  testAccount.Hex(),
+		"to":    to.Hex(),
+		"value": "0xde0b6b3a7640000",
+		"data":  "0xa9059cbb00",
+		"gas":   "0x5208",
+	}}))
+	require.NoError(t, err)
+	assert.Equal(t, to, *req.Tx.To)
+	assert.Equal(t, "1000000000000000000", req.Tx.Amount().String())
+	assert.Equal(t, hexutil.MustDecode("0xa9059cbb00"), req.Tx.Payload())
+	assert.Contains(t, req.Summary(), "calling 0xa9059cbb with 5 bytes of data")
+
+	_, err = ParseRequest(request("eth_sendTransaction", []map[string]string{{"from": testAccount.Hex(), "data": "0x6080"}}))
+	assert.Error(t, err, "contract deployments are refused")
+	_, err = ParseRequest(request("eth_sign", []string{testAccount.Hex(), "0x00"}))
+	assert.Error(t, err, "eth_sign is not served")
+}
+
+func typedData(chainID int64) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {{Name: "name", Type: "string"}, {Name: "chainId", Type: "uint256"}},
+			"Mail":         {{Name: "contents", Type: "string"}},
+		},
+		PrimaryType: "Mail",
+		Domain:      apitypes.TypedDataDomain{Name: "Ether Mail", ChainId: math.NewHexOrDecimal256(chainID)},
+		Message:     apitypes.TypedDataMessage{"contents": "Hello, Bob!"},
+	}
+}
+
+func TestParseRequest_TypedData(t *testing.T) {
+	encoded, _ := json.Marshal(typedData(1))
+	// Sent as a JSON string, the usual way, and as an object
+	for _, data := range []any{string(encoded), json.RawMessage(encoded)} {
+		req, err := ParseRequest(request("eth_signTypedData_v4", []any{testAccount.Hex(), data}))
+		require.NoError(t, err)
+		assert.Equal(t, "Mail", req.TypedData.PrimaryType)
+		assert.Contains(t, req.Summary(), "Ether Mail")
+	}
+
+	other, _ := json.Marshal(typedData(5))
+	_, err := ParseRequest(request("eth_signTypedData_v4", []any{testAccount.Hex(), string(other)}))
+	assert.Error(t, err, "the domain must be for the request's chain")
+}
+
+func TestExecute_TypedData(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	encoded, _ := json.Marshal(typedData(1))
+	raw, _ := json.Marshal([]any{address.Hex(), string(encoded)})
+	req, err := ParseRequest(&Request{Address: address, ChainID: 1, Method: "eth_signTypedData_v4", Params: raw})
+	require.NoError(t, err)
+
+	result, err := req.Execute(context.Background(), wallet.NewKeySigner(key), nil)
+	require.NoError(t, err)
+	sig := hexutil.MustDecode(result.Value)
+	assert.Contains(t, []byte{27, 28}, sig[64])
+	sig[64] -= 27
+	hash, _, err := apitypes.TypedDataAndHash(typedData(1))
+	require.NoError(t, err)
+	public, err := crypto.SigToPub(hash, sig)
+	require.NoError(t, err)
+	assert.Equal(t, address, crypto.PubkeyToAddress(*public))
+
+	other, _ := crypto.GenerateKey()
+	_, err = req.Execute(context.Background(), wallet.NewKeySigner(other), nil)
+	assert.Error(t, err, "another wallet cannot answer")
+}
@@ -0,0 +1,64 @@
+// Package walletconnect is a WalletConnect v2 wallet client: it pairs with a
+// dapp from a wc: URI, settles sessions for local wallets and receives the
+// dapp's signing requests through the WalletConnect relay.
+package walletconnect
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PairingURI is a parsed wc: URI, as shown by a dapp in its QR code
+type PairingURI struct {
+	Topic  string    // Pairing topic the dapp publishes its proposal on
+	SymKey SymKey    // Key the pairing messages are encrypted with
+	Relay  string    // Relay protocol, "irn"
+	Expiry time.Time // When the dapp stops waiting; zero when not given
+}
+
+// ParseURI reads a "wc:<topic>@2?relay-protocol=irn&symKey=<hex>" URI.
+// Version 1 URIs, which used a bridge server, are no longer served.
+func ParseURI(raw string) (PairingURI, error) {
+	raw = strings.TrimSpace(raw)
+	rest, ok := strings.CutPrefix(raw, "wc:")
+	if !ok {
+		return PairingURI{}, fmt.Errorf("not a WalletConnect URI: expected wc:")
+	}
+	target, query, _ := strings.Cut(rest, "?")
+	topic, version, ok := strings.Cut(target, "@")
+	if !ok || topic == "" {
+		return PairingURI{}, fmt.Errorf("WalletConnect URI without a topic")
+	}
+	if version != "2" {
+		return PairingURI{}, fmt.Errorf("WalletConnect version %q is not supported, only version 2", version)
+	}
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return PairingURI{}, fmt.Errorf("invalid WalletConnect URI: %w", err)
+	}
+
+	uri := PairingURI{Topic: topic, Relay: params.Get("relay-protocol")}
+	if uri.Relay == "" {
+		uri.Relay = RelayProtocol
+	}
+	if uri.Relay != RelayProtocol {
+		return PairingURI{}, fmt.Errorf("relay protocol %q is not supported", uri.Relay)
+	}
+	key, err := hex.DecodeString(params.Get("symKey"))
+	if err != nil || len(key) != len(uri.SymKey) {
+		return PairingURI{}, fmt.Errorf("WalletConnect URI without a valid symKey")
+	}
+	copy(uri.SymKey[:], key)
+	if expiry := params.Get("expiryTimestamp"); expiry != "" {
+		seconds, err := strconv.ParseInt(expiry, 10, 64)
+		if err != nil {
+			return PairingURI{}, fmt.Errorf("invalid expiryTimestamp %q", expiry)
+		}
+		uri.Expiry = time.Unix(seconds, 0)
+	}
+	return uri, nil
+}
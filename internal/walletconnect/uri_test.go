@@ -0,0 +1,41 @@
+package walletconnect
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSymKey = "587d5484ce2a2a6ee3ba1962fdd7e8588e06200c46823bd18fbd67def96ad303"
+
+func TestParseURI(t *testing.T) {
+	uri, err := ParseURI(" wc:7f6e504bfad60b485450578e05678ed3e8e8c4751d3c6160be17160d63ec90f9@2?relay-protocol=irn&symKey=" + testSymKey + "&expiryTimestamp=1700000000 ")
+	require.NoError(t, err)
+	assert.Equal(t, "7f6e504bfad60b485450578e05678ed3e8e8c4751d3c6160be17160d63ec90f9", uri.Topic)
+	assert.Equal(t, RelayProtocol, uri.Relay)
+	assert.Equal(t, testSymKey, strings.ToLower(hexKey(uri.SymKey)))
+	assert.Equal(t, time.Unix(1700000000, 0), uri.Expiry)
+
+	uri, err = ParseURI("wc:abc@2?symKey=" + testSymKey)
+	require.NoError(t, err)
+	assert.Equal(t, RelayProtocol, uri.Relay, "the relay protocol defaults to irn")
+	assert.True(t, uri.Expiry.IsZero())
+}
+
+func TestParseURI_Rejects(t *testing.T) {
+	for name, raw := range map[string]string{
+		"not wc":      "https://example.com",
+		"version 1":   "wc:00e46b69-d0cc-4b3e-b6a2-cee442f97188@1?bridge=https%3A%2F%2Fbridge.walletconnect.org&key=abc",
+		"no topic":    "wc:@2?symKey=" + testSymKey,
+		"no key":      "wc:abc@2?relay-protocol=irn",
+		"short key":   "wc:abc@2?symKey=abcd",
+		"other relay": "wc:abc@2?relay-protocol=waku&symKey=" + testSymKey,
+		"bad expiry":  "wc:abc@2?symKey=" + testSymKey + "&expiryTimestamp=soon",
+	} {
+		_, err := ParseURI(raw)
+		assert.Error(t, err, name)
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"embed"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -16,21 +17,109 @@ var defaultConfig embed.FS
 
 // Config holds all application configuration
 type Config struct {
-	AppDir       string
-	Language     string
-	WalletsDir   string
-	DatabasePath string
-	LocaleDir    string
-	Fonts        []string
-	Database     DatabaseConfig
-	Security     SecurityConfig
-	Networks     map[string]Network
+	AppDir        string
+	Language      string
+	Theme         string // Colour scheme of the TUI: a built-in theme or a file in <app_dir>/config/themes
+	Accessible    bool   // Plain ASCII output without colours or banners, for screen readers and limited terminals
+	WalletsDir    string
+	DatabasePath  string
+	LocaleDir     string
+	Fonts         []string
+	Database      DatabaseConfig
+	Logging       LoggingConfig
+	Security      SecurityConfig
+	Backup        BackupConfig
+	Compliance    ComplianceConfig
+	Sync          SyncConfig
+	Notifications NotificationsConfig
+	Cron          CronConfig
+	Discovery     DiscoveryConfig
+	Proxy         ProxyConfig
+	Decoding      DecodingConfig
+	Fork          ForkConfig
+	Mnemonic      MnemonicConfig
+	Balances      BalancesConfig
+	Hardening     HardeningConfig
+	Metrics       MetricsConfig
+	Integrity     IntegrityConfig
+	Operations    OperationsConfig
+	Import        ImportConfig
+	WalletConnect WalletConnectConfig
+	Networks      map[string]Network
 }
 
 // DatabaseConfig holds database-specific configuration
 type DatabaseConfig struct {
 	Type string // sqlite, postgres, mysql
 	DSN  string // Data Source Name (connection string)
+	// SQLite tuning; empty or zero values use the repository defaults
+	JournalMode   string // journal_mode pragma, e.g. "WAL" or "DELETE"
+	BusyTimeoutMS int    // How long a connection waits for a lock before "database is locked"
+	Synchronous   string // synchronous pragma: "OFF", "NORMAL", "FULL" or "EXTRA"
+	// SQLCipher encryption of the SQLite file: "", "master_password" or "keyfile"
+	Encryption string
+	KeyFile    string // Key of the "keyfile" encryption; <app_dir>/database.key when empty
+}
+
+// Encryptions of the SQLite database
+const (
+	DatabaseEncryptionMasterPassword = "master_password"
+	DatabaseEncryptionKeyFile        = "keyfile"
+)
+
+// databaseConfigFromViper reads the [database] section
+func databaseConfigFromViper(v *viper.Viper) DatabaseConfig {
+	return DatabaseConfig{
+		Type:          v.GetString("database.type"),
+		DSN:           v.GetString("database.dsn"),
+		JournalMode:   strings.ToUpper(strings.TrimSpace(v.GetString("database.journal_mode"))),
+		BusyTimeoutMS: v.GetInt("database.busy_timeout_ms"),
+		Synchronous:   strings.ToUpper(strings.TrimSpace(v.GetString("database.synchronous"))),
+		Encryption:    strings.ToLower(strings.TrimSpace(v.GetString("database.encryption"))),
+		KeyFile:       strings.TrimSpace(v.GetString("database.key_file")),
+	}
+}
+
+// Defaults of the file logger
+const (
+	DefaultLogLevel      = "info"
+	DefaultLogMaxSizeMB  = 25
+	DefaultLogMaxBackups = 3
+	DefaultLogMaxAgeDays = 14
+)
+
+// LoggingConfig holds the file logger settings
+type LoggingConfig struct {
+	Level      string // debug, info, warn or error
+	MaxSizeMB  int    // Size at which app.log and error.log are rotated
+	MaxBackups int    // Rotated files kept
+	MaxAgeDays int    // Days a rotated file is kept
+}
+
+// loggingConfigFromViper reads the [logging] section, falling back to the
+// defaults for unknown levels and sizes below 1
+func loggingConfigFromViper(v *viper.Viper) LoggingConfig {
+	logging := LoggingConfig{
+		Level:      strings.ToLower(strings.TrimSpace(v.GetString("logging.level"))),
+		MaxSizeMB:  v.GetInt("logging.max_size_mb"),
+		MaxBackups: v.GetInt("logging.max_backups"),
+		MaxAgeDays: v.GetInt("logging.max_age_days"),
+	}
+	switch logging.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		logging.Level = DefaultLogLevel
+	}
+	if logging.MaxSizeMB <= 0 {
+		logging.MaxSizeMB = DefaultLogMaxSizeMB
+	}
+	if logging.MaxBackups <= 0 {
+		logging.MaxBackups = DefaultLogMaxBackups
+	}
+	if logging.MaxAgeDays <= 0 {
+		logging.MaxAgeDays = DefaultLogMaxAgeDays
+	}
+	return logging
 }
 
 // SecurityConfig holds security-specific configuration
@@ -40,6 +129,485 @@ type SecurityConfig struct {
 	Argon2Threads uint8
 	Argon2KeyLen  uint32
 	SaltLength    uint32
+	// StrictRPC re-verifies every network's endpoint on startup and
+	// quarantines those whose certificate, chain ID or genesis changed
+	StrictRPC bool
+	// ClipboardClearSeconds clears an address or secret copied from the
+	// wallet details after this many seconds; 0 leaves it on the clipboard
+	ClipboardClearSeconds int
+	// KeystoreKDF derives the keys of new and exported keystores: "scrypt",
+	// which geth reads, or "argon2id" with the Argon2 parameters above
+	KeystoreKDF string
+	// ExportCipher encrypts exported scrypt keystores: "aes-128-ctr", which
+	// geth reads, or "aes-256-ctr", "aes-128-gcm", "aes-256-gcm", "aes-128-cbc"
+	ExportCipher string
+}
+
+// DefaultExportCipher is the keystore V3 cipher geth reads and writes
+const DefaultExportCipher = "aes-128-ctr"
+
+// exportCipher reads security.export_cipher; the wallet service falls back
+// to DefaultExportCipher for names it does not support
+func exportCipher(v *viper.Viper) string {
+	if name := strings.ToLower(strings.TrimSpace(v.GetString("security.export_cipher"))); name != "" {
+		return name
+	}
+	return DefaultExportCipher
+}
+
+// Keystore KDF profiles
+const (
+	KeystoreKDFScrypt   = "scrypt"
+	KeystoreKDFArgon2id = "argon2id"
+)
+
+// keystoreKDF reads security.keystore_kdf; unknown values keep geth's scrypt
+func keystoreKDF(v *viper.Viper) string {
+	if strings.EqualFold(strings.TrimSpace(v.GetString("security.keystore_kdf")), KeystoreKDFArgon2id) {
+		return KeystoreKDFArgon2id
+	}
+	return KeystoreKDFScrypt
+}
+
+// DefaultClipboardClearSeconds is used when the clipboard timer is not configured
+const DefaultClipboardClearSeconds = 30
+
+// clipboardClearSeconds reads security.clipboard_clear_seconds, keeping an explicit 0 (never clear)
+func clipboardClearSeconds(v *viper.Viper) int {
+	if v.IsSet("security.clipboard_clear_seconds") {
+		return v.GetInt("security.clipboard_clear_seconds")
+	}
+	return DefaultClipboardClearSeconds
+}
+
+// BackupConfig holds backup verification reminder settings and the copy of
+// keystores made before deleting wallets without a mnemonic
+type BackupConfig struct {
+	ReminderIntervalDays int    // Days between backup verification reminders; 0 disables them
+	ArchiveBeforeDelete  bool   // Preselect copying the keystore before deleting a wallet without a mnemonic
+	ArchiveDir           string // Where those copies are written; defaults to "<app_dir>/deleted"
+}
+
+// DefaultBackupReminderDays is used when the interval is not configured
+const DefaultBackupReminderDays = 90
+
+// backupConfigFromViper reads the [backup] section, keeping an explicit 0 (disabled)
+func backupConfigFromViper(v *viper.Viper) BackupConfig {
+	backup := BackupConfig{
+		ReminderIntervalDays: DefaultBackupReminderDays,
+		ArchiveBeforeDelete:  true,
+		ArchiveDir:           v.GetString("backup.archive_dir"),
+	}
+	if v.IsSet("backup.reminder_interval_days") {
+		backup.ReminderIntervalDays = v.GetInt("backup.reminder_interval_days")
+	}
+	if v.IsSet("backup.archive_before_delete") {
+		backup.ArchiveBeforeDelete = v.GetBool("backup.archive_before_delete")
+	}
+	return backup
+}
+
+// ComplianceConfig holds the enterprise approval workflow settings
+type ComplianceConfig struct {
+	Enabled          bool              // Require a second approver for secret exports and deletions
+	Operator         string            // Name recorded as requester; defaults to the OS user
+	ApprovalDir      string            // Shared directory for approval files; defaults to "<app_dir>/approvals"
+	ApprovalEndpoint string            // Remote approval endpoint; takes precedence over ApprovalDir
+	ApproverKeys     map[string]string // Approver names to the base64 Ed25519 keys that sign their decisions
+	TimeoutSeconds   int               // How long to wait for a decision
+}
+
+// DefaultApprovalTimeoutSeconds is used when no approval timeout is configured
+const DefaultApprovalTimeoutSeconds = 600
+
+// complianceConfigFromViper reads the [compliance] section
+func complianceConfigFromViper(v *viper.Viper) ComplianceConfig {
+	compliance := ComplianceConfig{
+		Enabled:          v.GetBool("compliance.enabled"),
+		Operator:         v.GetString("compliance.operator"),
+		ApprovalDir:      v.GetString("compliance.approval_dir"),
+		ApprovalEndpoint: v.GetString("compliance.approval_endpoint"),
+		ApproverKeys:     v.GetStringMapString("compliance.approver_keys"),
+		TimeoutSeconds:   v.GetInt("compliance.approval_timeout_seconds"),
+	}
+	if compliance.TimeoutSeconds <= 0 {
+		compliance.TimeoutSeconds = DefaultApprovalTimeoutSeconds
+	}
+	return compliance
+}
+
+// SyncConfig holds the remote metadata sync settings. Secrets (password and
+// passphrase) are read from the environment only so they never get written back
+// to config.toml.
+type SyncConfig struct {
+	Backend          string // "", "dir", "webdav", "s3" or "git"; empty disables sync
+	URL              string // Directory, WebDAV/S3 object URL or git remote
+	Username         string // WebDAV user or S3 access key ID
+	Password         string // WebDAV password or S3 secret key (BLOCO_WALLET_SYNC_PASSWORD)
+	Region           string // S3 region
+	Branch           string // Git branch
+	Passphrase       string // Encrypts the synced document (BLOCO_WALLET_SYNC_PASSPHRASE)
+	IncludeKeystores bool   // Also sync the (password-encrypted) keystore files
+}
+
+// syncConfigFromViper reads the [sync] section
+func syncConfigFromViper(v *viper.Viper) SyncConfig {
+	return SyncConfig{
+		Backend:          strings.ToLower(strings.TrimSpace(v.GetString("sync.backend"))),
+		URL:              v.GetString("sync.url"),
+		Username:         v.GetString("sync.username"),
+		Password:         v.GetString("sync.password"),
+		Region:           v.GetString("sync.region"),
+		Branch:           v.GetString("sync.branch"),
+		Passphrase:       v.GetString("sync.passphrase"),
+		IncludeKeystores: v.GetBool("sync.include_keystores"),
+	}
+}
+
+// NotificationsConfig holds the webhook notification settings
+type NotificationsConfig struct {
+	WebhookURL     string   // Receives JSON payloads; empty disables notifications
+	Format         string   // "auto", "json", "slack" or "discord"
+	Events         []string // Events to send; empty sends all of them
+	TimeoutSeconds int
+}
+
+// notificationsConfigFromViper reads the [notifications] section
+func notificationsConfigFromViper(v *viper.Viper) NotificationsConfig {
+	return NotificationsConfig{
+		WebhookURL:     v.GetString("notifications.webhook_url"),
+		Format:         v.GetString("notifications.format"),
+		Events:         v.GetStringSlice("notifications.events"),
+		TimeoutSeconds: v.GetInt("notifications.timeout_seconds"),
+	}
+}
+
+// CronConfig holds the tasks run by "bloco-wallet cron"
+type CronConfig struct {
+	Tasks           []string // backup, balance_snapshot, integrity_check
+	ReportDir       string   // Where run reports are written; defaults to "<app_dir>/reports"
+	BackupDir       string   // Where encrypted backups are written; defaults to "<app_dir>/backups"
+	BackupRecipient string   // age public key or GPG/age public key file the backups are encrypted to
+}
+
+// cronConfigFromViper reads the [cron] section
+func cronConfigFromViper(v *viper.Viper) CronConfig {
+	return CronConfig{
+		Tasks:           v.GetStringSlice("cron.tasks"),
+		ReportDir:       v.GetString("cron.report_dir"),
+		BackupDir:       v.GetString("cron.backup_dir"),
+		BackupRecipient: v.GetString("cron.backup_recipient"),
+	}
+}
+
+// DiscoveryConfig holds the child address scan run after a mnemonic import
+type DiscoveryConfig struct {
+	GapLimit int // Consecutive unused indices that end the scan; 0 disables it
+}
+
+// DefaultGapLimit is used when the gap limit is not configured
+const DefaultGapLimit = 20
+
+// discoveryConfigFromViper reads the [discovery] section, keeping an explicit 0 (disabled)
+func discoveryConfigFromViper(v *viper.Viper) DiscoveryConfig {
+	discovery := DiscoveryConfig{GapLimit: DefaultGapLimit}
+	if v.IsSet("discovery.gap_limit") {
+		discovery.GapLimit = v.GetInt("discovery.gap_limit")
+	}
+	return discovery
+}
+
+// DecodingConfig holds how calldata is turned into function calls
+type DecodingConfig struct {
+	OnlineLookup bool // Ask the 4byte directory for selectors missing from the local signatures
+}
+
+// decodingConfigFromViper reads the [decoding] section
+func decodingConfigFromViper(v *viper.Viper) DecodingConfig {
+	return DecodingConfig{OnlineLookup: v.GetBool("decoding.online_lookup")}
+}
+
+// ForkConfig holds the local anvil fork that transactions can be tried on
+// before they are signed for the real network
+type ForkConfig struct {
+	AnvilPath      string // anvil binary; empty looks it up in PATH
+	StartupTimeout int    // Seconds to wait for the fork to answer
+}
+
+// DefaultForkStartupTimeout is used when the startup timeout is not set
+const DefaultForkStartupTimeout = 30
+
+// forkConfigFromViper reads the [fork] section
+func forkConfigFromViper(v *viper.Viper) ForkConfig {
+	fork := ForkConfig{
+		AnvilPath:      strings.TrimSpace(v.GetString("fork.anvil_path")),
+		StartupTimeout: v.GetInt("fork.startup_timeout"),
+	}
+	if fork.StartupTimeout <= 0 {
+		fork.StartupTimeout = DefaultForkStartupTimeout
+	}
+	return fork
+}
+
+// HardeningConfig holds the Linux process sandbox applied after startup
+type HardeningConfig struct {
+	Enabled bool // Restrict files to the app directory and forbid running programs
+	Offline bool // Refuse every TCP connection, not just those to unexpected ports
+}
+
+// hardeningConfigFromViper reads the [hardening] section
+func hardeningConfigFromViper(v *viper.Viper) HardeningConfig {
+	return HardeningConfig{
+		Enabled: v.GetBool("hardening.enabled"),
+		Offline: v.GetBool("hardening.offline"),
+	}
+}
+
+// DefaultMetricsListen is where the metrics endpoint listens unless configured
+const DefaultMetricsListen = "127.0.0.1:9479"
+
+// MetricsConfig holds the Prometheus endpoint served by long-running processes
+type MetricsConfig struct {
+	Enabled bool   // Serve /metrics while the TUI or cron runs
+	Listen  string // Loopback host:port of the endpoint
+}
+
+// metricsConfigFromViper reads the [metrics] section
+func metricsConfigFromViper(v *viper.Viper) MetricsConfig {
+	metrics := MetricsConfig{
+		Enabled: v.GetBool("metrics.enabled"),
+		Listen:  strings.TrimSpace(v.GetString("metrics.listen")),
+	}
+	if metrics.Listen == "" {
+		metrics.Listen = DefaultMetricsListen
+	}
+	return metrics
+}
+
+// IntegrityConfig holds what is verified against the build on startup
+type IntegrityConfig struct {
+	CheckBinary bool // Compare the executable with the hash stamped at build time
+	CheckAssets bool // Compare the compiled-in wordlists and fonts with their manifest
+}
+
+// integrityConfigFromViper reads the [integrity] section; the binary is
+// checked unless turned off
+func integrityConfigFromViper(v *viper.Viper) IntegrityConfig {
+	integrity := IntegrityConfig{
+		CheckBinary: true,
+		CheckAssets: v.GetBool("integrity.check_assets"),
+	}
+	if v.IsSet("integrity.check_binary") {
+		integrity.CheckBinary = v.GetBool("integrity.check_binary")
+	}
+	return integrity
+}
+
+// DefaultStallSeconds is how long an unlock or import may run before the
+// screen offers to abort it
+const DefaultStallSeconds = 30
+
+// OperationsConfig holds how long-running key derivations are watched and
+// what is reported when the program exits
+type OperationsConfig struct {
+	StallSeconds   int  // Seconds before a running unlock or import shows the stall warning
+	SessionSummary bool // Show the session summary before quitting; it is always logged
+}
+
+// operationsConfigFromViper reads the [operations] section, showing the
+// session summary unless it is explicitly turned off
+func operationsConfigFromViper(v *viper.Viper) OperationsConfig {
+	operations := OperationsConfig{StallSeconds: v.GetInt("operations.stall_seconds"), SessionSummary: true}
+	if operations.StallSeconds <= 0 {
+		operations.StallSeconds = DefaultStallSeconds
+	}
+	if v.IsSet("operations.session_summary") {
+		operations.SessionSummary = v.GetBool("operations.session_summary")
+	}
+	return operations
+}
+
+// ImportConfig holds how batch imports use the machine
+type ImportConfig struct {
+	// MemoryLimitMB caps the memory of key derivations running at the same
+	// time; 0 uses half of the physical memory
+	MemoryLimitMB int
+	// Concurrency is how many keystores are decrypted at the same time;
+	// 0 uses one per CPU
+	Concurrency int
+	// ReportDir is where import reports are offered to be written; defaults
+	// to "<app_dir>/reports"
+	ReportDir string
+	// ReportFormat is the format offered for import reports, "json" or "csv"
+	ReportFormat string
+}
+
+// importConfigFromViper reads the [import] section
+func importConfigFromViper(v *viper.Viper) ImportConfig {
+	concurrency := v.GetInt("import.concurrency")
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+	format := strings.ToLower(strings.TrimSpace(v.GetString("import.report_format")))
+	if format != "csv" {
+		format = "json"
+	}
+	return ImportConfig{
+		MemoryLimitMB: max(v.GetInt("import.memory_limit_mb"), 0),
+		Concurrency:   concurrency,
+		ReportDir:     strings.TrimSpace(v.GetString("import.report_dir")),
+		ReportFormat:  format,
+	}
+}
+
+// DefaultWalletConnectRelay is the public WalletConnect relay
+const DefaultWalletConnectRelay = "wss://relay.walletconnect.org"
+
+// WalletConnectConfig holds the connection to the WalletConnect relay
+type WalletConnectConfig struct {
+	ProjectID string // Project ID from the WalletConnect dashboard; required to pair
+	RelayURL  string // Relay websocket URL
+}
+
+// walletConnectConfigFromViper reads the [walletconnect] section
+func walletConnectConfigFromViper(v *viper.Viper) WalletConnectConfig {
+	wc := WalletConnectConfig{
+		ProjectID: strings.TrimSpace(v.GetString("walletconnect.project_id")),
+		RelayURL:  strings.TrimSpace(v.GetString("walletconnect.relay_url")),
+	}
+	if wc.RelayURL == "" {
+		wc.RelayURL = DefaultWalletConnectRelay
+	}
+	return wc
+}
+
+// Ways of revealing a mnemonic phrase on screen
+const (
+	MnemonicRevealAll  = "all"  // every word at once
+	MnemonicRevealWord = "word" // one word at a time, the others masked
+)
+
+// MnemonicConfig holds how mnemonic phrases are laid out when shown or typed in
+type MnemonicConfig struct {
+	WordsPerRow int    // Words on each row of the grid
+	Reveal      string // MnemonicRevealAll or MnemonicRevealWord
+}
+
+// DefaultMnemonicWordsPerRow is used when words_per_row is not set or out of range
+const DefaultMnemonicWordsPerRow = 4
+
+// mnemonicConfigFromViper reads the [mnemonic] section, falling back to a
+// 4-column grid showing every word
+func mnemonicConfigFromViper(v *viper.Viper) MnemonicConfig {
+	mnemonic := MnemonicConfig{
+		WordsPerRow: v.GetInt("mnemonic.words_per_row"),
+		Reveal:      strings.ToLower(strings.TrimSpace(v.GetString("mnemonic.reveal"))),
+	}
+	if mnemonic.WordsPerRow < 1 || mnemonic.WordsPerRow > 24 {
+		mnemonic.WordsPerRow = DefaultMnemonicWordsPerRow
+	}
+	if mnemonic.Reveal != MnemonicRevealWord {
+		mnemonic.Reveal = MnemonicRevealAll
+	}
+	return mnemonic
+}
+
+// BalancesConfig holds which balances are too small to be worth showing
+type BalancesConfig struct {
+	HideDust   bool   // Hide balances below the thresholds until shown on demand
+	NativeDust string // Native coin threshold, in whole units such as "0.0001"
+	TokenDust  string // Token threshold, in whole units of each token
+	FiatDust   string // Threshold in the currency of Prices, for assets with a price
+	// Price of one whole unit by lowercase symbol, such as "eth" = "2500"
+	Prices map[string]string
+	// Count zero balances as dust too; off so that followed tokens stay listed
+	HideZero bool
+	// Leave test networks out of the balances and the network list
+	HideTestnets bool
+}
+
+// Default dust thresholds, used when unset or not a non-negative decimal
+const (
+	DefaultNativeDust = "0.0001"
+	DefaultTokenDust  = "0.01"
+	DefaultFiatDust   = "1"
+)
+
+// Price returns the configured price of one whole unit of symbol
+func (b BalancesConfig) Price(symbol string) (string, bool) {
+	price, ok := b.Prices[strings.ToLower(strings.TrimSpace(symbol))]
+	return price, ok
+}
+
+// balancesConfigFromViper reads the [balances] section; dust is hidden unless
+// hide_dust is turned off
+func balancesConfigFromViper(v *viper.Viper) BalancesConfig {
+	balances := BalancesConfig{
+		HideDust:   true,
+		NativeDust: dustThreshold(v.GetString("balances.native_dust"), DefaultNativeDust),
+		TokenDust:  dustThreshold(v.GetString("balances.token_dust"), DefaultTokenDust),
+		FiatDust:   dustThreshold(v.GetString("balances.fiat_dust"), DefaultFiatDust),
+		HideZero:   v.GetBool("balances.hide_zero"),
+	}
+	if v.IsSet("balances.hide_dust") {
+		balances.HideDust = v.GetBool("balances.hide_dust")
+	}
+	// Prices that are not a non-negative decimal are dropped, so their
+	// assets fall back to the unit thresholds
+	for symbol, price := range v.GetStringMapString("balances.prices") {
+		if price = dustThreshold(price, ""); price != "" {
+			if balances.Prices == nil {
+				balances.Prices = make(map[string]string)
+			}
+			balances.Prices[strings.ToLower(symbol)] = price
+		}
+	}
+	balances.HideTestnets = v.GetBool("balances.hide_testnets")
+	return balances
+}
+
+// dustThreshold keeps a threshold written as a non-negative decimal
+func dustThreshold(value, fallback string) string {
+	value = strings.TrimSpace(value)
+	amount, ok := new(big.Rat).SetString(value)
+	if !ok || amount.Sign() < 0 || strings.ContainsAny(value, "/eE") {
+		return fallback
+	}
+	return value
+}
+
+// ProxyConfig routes outbound traffic through a SOCKS5 proxy, such as a local Tor
+type ProxyConfig struct {
+	URL           string // socks5:// or socks5h:// proxy; empty connects directly
+	ChainList     bool   // Route chainlist.org lookups through the proxy
+	RPC           bool   // Route the networks' RPC requests through the proxy
+	Signatures    bool   // Route 4byte signature lookups through the proxy
+	WalletConnect bool   // Route the WalletConnect relay connection through the proxy
+}
+
+// proxyConfigFromViper reads the [proxy] section; every service uses the
+// proxy unless turned off
+func proxyConfigFromViper(v *viper.Viper) ProxyConfig {
+	proxy := ProxyConfig{
+		URL:           strings.TrimSpace(v.GetString("proxy.url")),
+		ChainList:     true,
+		RPC:           true,
+		Signatures:    true,
+		WalletConnect: true,
+	}
+	if v.IsSet("proxy.chainlist") {
+		proxy.ChainList = v.GetBool("proxy.chainlist")
+	}
+	if v.IsSet("proxy.rpc") {
+		proxy.RPC = v.GetBool("proxy.rpc")
+	}
+	if v.IsSet("proxy.signatures") {
+		proxy.Signatures = v.GetBool("proxy.signatures")
+	}
+	if v.IsSet("proxy.walletconnect") {
+		proxy.WalletConnect = v.GetBool("proxy.walletconnect")
+	}
+	return proxy
 }
 
 // Network creates a new Config instance with default values
@@ -50,6 +618,32 @@ type Network struct {
 	Symbol      string
 	Explorer    string
 	IsActive    bool
+	// Confirmations after which a transaction is final; 0 uses DefaultConfirmations
+	Confirmations int
+	// GenesisHash optionally pins the hash of block 0, checked in strict RPC mode
+	GenesisHash string
+	// Quarantine is why strict RPC mode disabled the network; empty when it is usable
+	Quarantine string
+	// Testnet marks a test network, whose coins have no value
+	Testnet bool
+}
+
+// DefaultConfirmations is how many confirmations make a transaction final on
+// networks that do not set their own threshold
+const DefaultConfirmations = 12
+
+// Usable reports whether the network is active and not quarantined
+func (n Network) Usable() bool {
+	return n.IsActive && n.Quarantine == ""
+}
+
+// RequiredConfirmations returns the confirmations that make a transaction
+// final on the network
+func (n Network) RequiredConfirmations() uint64 {
+	if n.Confirmations <= 0 {
+		return DefaultConfirmations
+	}
+	return uint64(n.Confirmations)
 }
 
 // LoadConfig loads the configuration from a TOML file using Viper
@@ -99,22 +693,43 @@ func LoadConfig(appDir string) (*Config, error) {
 	cfg := &Config{
 		AppDir:       v.GetString("app.app_dir"),
 		Language:     v.GetString("app.language"),
+		Theme:        v.GetString("app.theme"),
+		Accessible:   v.GetBool("app.accessible"),
 		WalletsDir:   v.GetString("app.wallets_dir"),
 		DatabasePath: v.GetString("app.database_path"),
 		LocaleDir:    v.GetString("app.locale_dir"),
 		Fonts:        v.GetStringSlice("fonts.available"),
-		Database: DatabaseConfig{
-			Type: v.GetString("database.type"),
-			DSN:  v.GetString("database.dsn"),
-		},
+		Database:     databaseConfigFromViper(v),
+		Logging:      loggingConfigFromViper(v),
 		Security: SecurityConfig{
-			Argon2Time:    v.GetUint32("security.argon2_time"),
-			Argon2Memory:  v.GetUint32("security.argon2_memory"),
-			Argon2Threads: uint8(v.GetUint("security.argon2_threads")),
-			Argon2KeyLen:  v.GetUint32("security.argon2_key_len"),
-			SaltLength:    v.GetUint32("security.salt_length"),
+			Argon2Time:            v.GetUint32("security.argon2_time"),
+			Argon2Memory:          v.GetUint32("security.argon2_memory"),
+			Argon2Threads:         uint8(v.GetUint("security.argon2_threads")),
+			Argon2KeyLen:          v.GetUint32("security.argon2_key_len"),
+			SaltLength:            v.GetUint32("security.salt_length"),
+			StrictRPC:             v.GetBool("security.strict_rpc"),
+			ClipboardClearSeconds: clipboardClearSeconds(v),
+			KeystoreKDF:           keystoreKDF(v),
+			ExportCipher:          exportCipher(v),
 		},
-		Networks: make(map[string]Network),
+		Backup:        backupConfigFromViper(v),
+		Compliance:    complianceConfigFromViper(v),
+		Sync:          syncConfigFromViper(v),
+		Notifications: notificationsConfigFromViper(v),
+		Cron:          cronConfigFromViper(v),
+		Discovery:     discoveryConfigFromViper(v),
+		Proxy:         proxyConfigFromViper(v),
+		Decoding:      decodingConfigFromViper(v),
+		Fork:          forkConfigFromViper(v),
+		Mnemonic:      mnemonicConfigFromViper(v),
+		Balances:      balancesConfigFromViper(v),
+		Hardening:     hardeningConfigFromViper(v),
+		Metrics:       metricsConfigFromViper(v),
+		Integrity:     integrityConfigFromViper(v),
+		Operations:    operationsConfigFromViper(v),
+		Import:        importConfigFromViper(v),
+		WalletConnect: walletConnectConfigFromViper(v),
+		Networks:      make(map[string]Network),
 	}
 
 	// Load networks from config
@@ -122,13 +737,17 @@ func LoadConfig(appDir string) (*Config, error) {
 	for key := range networksMap {
 		networkKey := "networks." + key
 		network := Network{
-			Name:        v.GetString(networkKey + ".name"),
-			RPCEndpoint: v.GetString(networkKey + ".rpc_endpoint"),
-			ChainID:     v.GetInt64(networkKey + ".chain_id"),
-			Symbol:      v.GetString(networkKey + ".symbol"),
-			Explorer:    v.GetString(networkKey + ".explorer"),
-			IsActive:    v.GetBool(networkKey + ".is_active"),
+			Name:          v.GetString(networkKey + ".name"),
+			RPCEndpoint:   v.GetString(networkKey + ".rpc_endpoint"),
+			ChainID:       v.GetInt64(networkKey + ".chain_id"),
+			Symbol:        v.GetString(networkKey + ".symbol"),
+			Explorer:      v.GetString(networkKey + ".explorer"),
+			IsActive:      v.GetBool(networkKey + ".is_active"),
+			Confirmations: v.GetInt(networkKey + ".confirmations"),
+			GenesisHash:   v.GetString(networkKey + ".genesis_hash"),
+			Quarantine:    v.GetString(networkKey + ".quarantine"),
 		}
+		network.Testnet = testnetFromViper(v, networkKey, network)
 		cfg.Networks[key] = network
 	}
 
@@ -217,6 +836,11 @@ func LoadConfig(appDir string) (*Config, error) {
 		cfg.Security.SaltLength = 16
 	}
 
+	// Secrets mounted as files, e.g. BLOCO_WALLET_DATABASE_DSN_FILE
+	if err := applySecretFiles(cfg); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -22,6 +23,12 @@ type ConfigurationManager struct {
 	viper      *viper.Viper
 	configPath string
 	appDir     string
+	// baseDir is the application directory before the profile is applied
+	baseDir string
+	profile string
+	// modTime is when config.toml was last changed by the file read or
+	// written here; Changed compares it with the file on disk
+	modTime time.Time
 }
 
 // NewConfigurationManager creates a new ConfigurationManager instance
@@ -34,23 +41,22 @@ func NewConfigurationManager() *ConfigurationManager {
 // LoadConfiguration loads the configuration using Viper with proper directory resolution
 func (cm *ConfigurationManager) LoadConfiguration() (*Config, error) {
 	// Determine the application directory
-	appDir, err := cm.resolveAppDirectory()
+	baseDir, err := cm.resolveAppDirectory()
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve application directory: %w", err)
 	}
+	profile, err := selectedProfile(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	appDir := ProfileDir(baseDir, profile)
 
+	cm.baseDir = baseDir
+	cm.profile = profile
 	cm.appDir = appDir
 	cm.configPath = filepath.Join(appDir, "config.toml")
 
-	// Configure Viper
-	cm.viper.SetConfigName("config")
-	cm.viper.SetConfigType("toml")
-	cm.viper.AddConfigPath(appDir)
-
-	// Set up environment variables support
-	cm.viper.SetEnvPrefix("BLOCO_WALLET")
-	cm.viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-	cm.viper.AutomaticEnv()
+	cm.configureViper()
 
 	// Check if config file exists, create default if not
 	if err := cm.ensureConfigFile(); err != nil {
@@ -61,11 +67,25 @@ func (cm *ConfigurationManager) LoadConfiguration() (*Config, error) {
 	if err := cm.viper.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
+	cm.recordModTime()
 
 	// Build and return the Config struct
 	return cm.buildConfigStruct()
 }
 
+// configureViper points Viper at config.toml in the application directory,
+// with environment variables overriding it
+func (cm *ConfigurationManager) configureViper() {
+	cm.viper.SetConfigName("config")
+	cm.viper.SetConfigType("toml")
+	cm.viper.AddConfigPath(cm.appDir)
+
+	// Set up environment variables support
+	cm.viper.SetEnvPrefix("BLOCO_WALLET")
+	cm.viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	cm.viper.AutomaticEnv()
+}
+
 // SaveConfiguration saves the configuration maintaining Viper compatibility
 func (cm *ConfigurationManager) SaveConfiguration(cfg *Config) error {
 	if cm.configPath == "" {
@@ -75,14 +95,53 @@ func (cm *ConfigurationManager) SaveConfiguration(cfg *Config) error {
 	// Update Viper with the new configuration values
 	cm.updateViperFromConfig(cfg)
 
-	// Write the configuration to file
-	if err := cm.viper.WriteConfigAs(cm.configPath); err != nil {
+	// Write a temporary file next to it and rename it over config.toml, so a
+	// crash or a reader never sees a half-written configuration
+	tmp, err := os.CreateTemp(filepath.Dir(cm.configPath), ".config-*.toml")
+	if err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
+	tmpPath := tmp.Name()
+	_ = tmp.Close()
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(cm.configPath); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := cm.viper.WriteConfigAs(tmpPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.Rename(tmpPath, cm.configPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	cm.recordModTime()
 
 	return nil
 }
 
+// recordModTime remembers the modification time of config.toml as read or
+// written by this manager
+func (cm *ConfigurationManager) recordModTime() {
+	if info, err := os.Stat(cm.configPath); err == nil {
+		cm.modTime = info.ModTime()
+	}
+}
+
+// Changed reports whether config.toml was modified on disk since this
+// manager last read or wrote it, e.g. by hand in an editor
+func (cm *ConfigurationManager) Changed() bool {
+	if cm.configPath == "" {
+		return false
+	}
+	info, err := os.Stat(cm.configPath)
+	return err == nil && !info.ModTime().Equal(cm.modTime)
+}
+
 // GetConfigPath returns the path to the configuration file
 func (cm *ConfigurationManager) GetConfigPath() string {
 	return cm.configPath
@@ -93,6 +152,16 @@ func (cm *ConfigurationManager) GetAppDirectory() string {
 	return cm.appDir
 }
 
+// GetBaseDirectory returns the application directory holding the profiles
+func (cm *ConfigurationManager) GetBaseDirectory() string {
+	return cm.baseDir
+}
+
+// GetProfile returns the name of the profile loaded
+func (cm *ConfigurationManager) GetProfile() string {
+	return cm.profile
+}
+
 // resolveAppDirectory determines the appropriate application directory
 func (cm *ConfigurationManager) resolveAppDirectory() (string, error) {
 	// First check if there's an environment variable override
@@ -156,22 +225,43 @@ func (cm *ConfigurationManager) buildConfigStruct() (*Config, error) {
 	cfg := &Config{
 		AppDir:       cm.viper.GetString("app.app_dir"),
 		Language:     cm.viper.GetString("app.language"),
+		Theme:        cm.viper.GetString("app.theme"),
+		Accessible:   cm.viper.GetBool("app.accessible"),
 		WalletsDir:   cm.viper.GetString("app.wallets_dir"),
 		DatabasePath: cm.viper.GetString("app.database_path"),
 		LocaleDir:    cm.viper.GetString("app.locale_dir"),
 		Fonts:        cm.viper.GetStringSlice("fonts.available"),
-		Database: DatabaseConfig{
-			Type: cm.viper.GetString("database.type"),
-			DSN:  cm.viper.GetString("database.dsn"),
-		},
+		Database:     databaseConfigFromViper(cm.viper),
+		Logging:      loggingConfigFromViper(cm.viper),
 		Security: SecurityConfig{
-			Argon2Time:    cm.viper.GetUint32("security.argon2_time"),
-			Argon2Memory:  cm.viper.GetUint32("security.argon2_memory"),
-			Argon2Threads: uint8(cm.viper.GetUint("security.argon2_threads")),
-			Argon2KeyLen:  cm.viper.GetUint32("security.argon2_key_len"),
-			SaltLength:    cm.viper.GetUint32("security.salt_length"),
+			Argon2Time:            cm.viper.GetUint32("security.argon2_time"),
+			Argon2Memory:          cm.viper.GetUint32("security.argon2_memory"),
+			Argon2Threads:         uint8(cm.viper.GetUint("security.argon2_threads")),
+			Argon2KeyLen:          cm.viper.GetUint32("security.argon2_key_len"),
+			SaltLength:            cm.viper.GetUint32("security.salt_length"),
+			StrictRPC:             cm.viper.GetBool("security.strict_rpc"),
+			ClipboardClearSeconds: clipboardClearSeconds(cm.viper),
+			KeystoreKDF:           keystoreKDF(cm.viper),
+			ExportCipher:          exportCipher(cm.viper),
 		},
-		Networks: make(map[string]Network),
+		Backup:        backupConfigFromViper(cm.viper),
+		Compliance:    complianceConfigFromViper(cm.viper),
+		Sync:          syncConfigFromViper(cm.viper),
+		Notifications: notificationsConfigFromViper(cm.viper),
+		Cron:          cronConfigFromViper(cm.viper),
+		Discovery:     discoveryConfigFromViper(cm.viper),
+		Proxy:         proxyConfigFromViper(cm.viper),
+		Decoding:      decodingConfigFromViper(cm.viper),
+		Fork:          forkConfigFromViper(cm.viper),
+		Mnemonic:      mnemonicConfigFromViper(cm.viper),
+		Balances:      balancesConfigFromViper(cm.viper),
+		Hardening:     hardeningConfigFromViper(cm.viper),
+		Metrics:       metricsConfigFromViper(cm.viper),
+		Integrity:     integrityConfigFromViper(cm.viper),
+		Operations:    operationsConfigFromViper(cm.viper),
+		Import:        importConfigFromViper(cm.viper),
+		WalletConnect: walletConnectConfigFromViper(cm.viper),
+		Networks:      make(map[string]Network),
 	}
 
 	// Load networks from config
@@ -179,13 +269,17 @@ func (cm *ConfigurationManager) buildConfigStruct() (*Config, error) {
 	for key := range networksMap {
 		networkKey := "networks." + key
 		network := Network{
-			Name:        cm.viper.GetString(networkKey + ".name"),
-			RPCEndpoint: cm.viper.GetString(networkKey + ".rpc_endpoint"),
-			ChainID:     cm.viper.GetInt64(networkKey + ".chain_id"),
-			Symbol:      cm.viper.GetString(networkKey + ".symbol"),
-			Explorer:    cm.viper.GetString(networkKey + ".explorer"),
-			IsActive:    cm.viper.GetBool(networkKey + ".is_active"),
+			Name:          cm.viper.GetString(networkKey + ".name"),
+			RPCEndpoint:   cm.viper.GetString(networkKey + ".rpc_endpoint"),
+			ChainID:       cm.viper.GetInt64(networkKey + ".chain_id"),
+			Symbol:        cm.viper.GetString(networkKey + ".symbol"),
+			Explorer:      cm.viper.GetString(networkKey + ".explorer"),
+			IsActive:      cm.viper.GetBool(networkKey + ".is_active"),
+			Confirmations: cm.viper.GetInt(networkKey + ".confirmations"),
+			GenesisHash:   cm.viper.GetString(networkKey + ".genesis_hash"),
+			Quarantine:    cm.viper.GetString(networkKey + ".quarantine"),
 		}
+		network.Testnet = testnetFromViper(cm.viper, networkKey, network)
 		cfg.Networks[key] = network
 	}
 
@@ -236,9 +330,10 @@ func (cm *ConfigurationManager) buildConfigStruct() (*Config, error) {
 		cfg.Database.DSN = legacy
 	}
 
-	// Handle legacy app dir override - this affects dependent paths
+	// Handle legacy app dir override - this affects dependent paths. The
+	// resolved directory already holds it, with the profile applied.
 	if legacy := os.Getenv("BLOCO_WALLET_APP_APP_DIR"); legacy != "" {
-		cfg.AppDir = expandPath(legacy, homeDir)
+		cfg.AppDir = cm.appDir
 		// Re-derive dependent paths only if they were using defaults
 		if walletsWasDefault && os.Getenv("BLOCO_WALLET_APP_KEYSTORE_DIR") == "" && os.Getenv("BLOCO_WALLET_APP_WALLETS_DIR") == "" {
 			cfg.WalletsDir = filepath.Join(cfg.AppDir, "keystore")
@@ -268,6 +363,11 @@ func (cm *ConfigurationManager) buildConfigStruct() (*Config, error) {
 		cfg.Security.SaltLength = 16
 	}
 
+	// Secrets mounted as files, e.g. BLOCO_WALLET_DATABASE_DSN_FILE
+	if err := applySecretFiles(cfg); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
@@ -276,6 +376,8 @@ func (cm *ConfigurationManager) updateViperFromConfig(cfg *Config) {
 	// App settings
 	cm.viper.Set("app.app_dir", cfg.AppDir)
 	cm.viper.Set("app.language", cfg.Language)
+	cm.viper.Set("app.theme", cfg.Theme)
+	cm.viper.Set("app.accessible", cfg.Accessible)
 	cm.viper.Set("app.wallets_dir", cfg.WalletsDir)
 	cm.viper.Set("app.database_path", cfg.DatabasePath)
 	cm.viper.Set("app.locale_dir", cfg.LocaleDir)
@@ -285,7 +387,20 @@ func (cm *ConfigurationManager) updateViperFromConfig(cfg *Config) {
 
 	// Database
 	cm.viper.Set("database.type", cfg.Database.Type)
-	cm.viper.Set("database.dsn", cfg.Database.DSN)
+	if !secretFromFile("database.dsn") {
+		cm.viper.Set("database.dsn", cfg.Database.DSN)
+	}
+	cm.viper.Set("database.journal_mode", cfg.Database.JournalMode)
+	cm.viper.Set("database.busy_timeout_ms", cfg.Database.BusyTimeoutMS)
+	cm.viper.Set("database.synchronous", cfg.Database.Synchronous)
+	cm.viper.Set("database.encryption", cfg.Database.Encryption)
+	cm.viper.Set("database.key_file", cfg.Database.KeyFile)
+
+	// Logging
+	cm.viper.Set("logging.level", cfg.Logging.Level)
+	cm.viper.Set("logging.max_size_mb", cfg.Logging.MaxSizeMB)
+	cm.viper.Set("logging.max_backups", cfg.Logging.MaxBackups)
+	cm.viper.Set("logging.max_age_days", cfg.Logging.MaxAgeDays)
 
 	// Security
 	cm.viper.Set("security.argon2_time", cfg.Security.Argon2Time)
@@ -293,6 +408,97 @@ func (cm *ConfigurationManager) updateViperFromConfig(cfg *Config) {
 	cm.viper.Set("security.argon2_threads", cfg.Security.Argon2Threads)
 	cm.viper.Set("security.argon2_key_len", cfg.Security.Argon2KeyLen)
 	cm.viper.Set("security.salt_length", cfg.Security.SaltLength)
+	cm.viper.Set("security.strict_rpc", cfg.Security.StrictRPC)
+	cm.viper.Set("security.clipboard_clear_seconds", cfg.Security.ClipboardClearSeconds)
+	cm.viper.Set("security.keystore_kdf", cfg.Security.KeystoreKDF)
+	cm.viper.Set("security.export_cipher", cfg.Security.ExportCipher)
+
+	// Backup
+	cm.viper.Set("backup.reminder_interval_days", cfg.Backup.ReminderIntervalDays)
+	cm.viper.Set("backup.archive_before_delete", cfg.Backup.ArchiveBeforeDelete)
+	cm.viper.Set("backup.archive_dir", cfg.Backup.ArchiveDir)
+
+	// Compliance
+	cm.viper.Set("compliance.enabled", cfg.Compliance.Enabled)
+	cm.viper.Set("compliance.operator", cfg.Compliance.Operator)
+	cm.viper.Set("compliance.approval_dir", cfg.Compliance.ApprovalDir)
+	cm.viper.Set("compliance.approval_endpoint", cfg.Compliance.ApprovalEndpoint)
+	cm.viper.Set("compliance.approver_keys", cfg.Compliance.ApproverKeys)
+	cm.viper.Set("compliance.approval_timeout_seconds", cfg.Compliance.TimeoutSeconds)
+
+	// Sync (password and passphrase stay in the environment)
+	cm.viper.Set("sync.backend", cfg.Sync.Backend)
+	cm.viper.Set("sync.url", cfg.Sync.URL)
+	cm.viper.Set("sync.username", cfg.Sync.Username)
+	cm.viper.Set("sync.region", cfg.Sync.Region)
+	cm.viper.Set("sync.branch", cfg.Sync.Branch)
+	cm.viper.Set("sync.include_keystores", cfg.Sync.IncludeKeystores)
+
+	// Notifications (the webhook URL may embed a token, so it is not written back)
+	cm.viper.Set("notifications.format", cfg.Notifications.Format)
+	cm.viper.Set("notifications.events", cfg.Notifications.Events)
+	cm.viper.Set("notifications.timeout_seconds", cfg.Notifications.TimeoutSeconds)
+
+	// Cron
+	cm.viper.Set("cron.tasks", cfg.Cron.Tasks)
+	cm.viper.Set("cron.report_dir", cfg.Cron.ReportDir)
+	cm.viper.Set("cron.backup_dir", cfg.Cron.BackupDir)
+	cm.viper.Set("cron.backup_recipient", cfg.Cron.BackupRecipient)
+
+	// Discovery
+	cm.viper.Set("discovery.gap_limit", cfg.Discovery.GapLimit)
+
+	// Proxy
+	cm.viper.Set("proxy.url", cfg.Proxy.URL)
+	cm.viper.Set("proxy.chainlist", cfg.Proxy.ChainList)
+	cm.viper.Set("proxy.rpc", cfg.Proxy.RPC)
+	cm.viper.Set("proxy.signatures", cfg.Proxy.Signatures)
+	cm.viper.Set("proxy.walletconnect", cfg.Proxy.WalletConnect)
+
+	// Decoding
+	cm.viper.Set("decoding.online_lookup", cfg.Decoding.OnlineLookup)
+
+	// Fork
+	cm.viper.Set("fork.anvil_path", cfg.Fork.AnvilPath)
+	cm.viper.Set("fork.startup_timeout", cfg.Fork.StartupTimeout)
+
+	// Mnemonic
+	cm.viper.Set("mnemonic.words_per_row", cfg.Mnemonic.WordsPerRow)
+	cm.viper.Set("mnemonic.reveal", cfg.Mnemonic.Reveal)
+
+	// Balances
+	cm.viper.Set("balances.hide_dust", cfg.Balances.HideDust)
+	cm.viper.Set("balances.native_dust", cfg.Balances.NativeDust)
+	cm.viper.Set("balances.token_dust", cfg.Balances.TokenDust)
+	cm.viper.Set("balances.fiat_dust", cfg.Balances.FiatDust)
+	cm.viper.Set("balances.prices", cfg.Balances.Prices)
+	cm.viper.Set("balances.hide_zero", cfg.Balances.HideZero)
+	cm.viper.Set("balances.hide_testnets", cfg.Balances.HideTestnets)
+
+	// Hardening
+	cm.viper.Set("hardening.enabled", cfg.Hardening.Enabled)
+	cm.viper.Set("hardening.offline", cfg.Hardening.Offline)
+
+	// Metrics
+	cm.viper.Set("metrics.enabled", cfg.Metrics.Enabled)
+	cm.viper.Set("metrics.listen", cfg.Metrics.Listen)
+
+	// Integrity
+	cm.viper.Set("integrity.check_binary", cfg.Integrity.CheckBinary)
+	cm.viper.Set("integrity.check_assets", cfg.Integrity.CheckAssets)
+
+	// Operations
+	cm.viper.Set("operations.stall_seconds", cfg.Operations.StallSeconds)
+
+	// Import
+	cm.viper.Set("import.memory_limit_mb", cfg.Import.MemoryLimitMB)
+	cm.viper.Set("import.concurrency", cfg.Import.Concurrency)
+	cm.viper.Set("import.report_dir", cfg.Import.ReportDir)
+	cm.viper.Set("import.report_format", cfg.Import.ReportFormat)
+
+	// WalletConnect
+	cm.viper.Set("walletconnect.project_id", cfg.WalletConnect.ProjectID)
+	cm.viper.Set("walletconnect.relay_url", cfg.WalletConnect.RelayURL)
 
 	// Networks - completely replace the networks section
 	// First, clear all existing network keys
@@ -305,6 +511,10 @@ func (cm *ConfigurationManager) updateViperFromConfig(cfg *Config) {
 		cm.viper.Set("networks."+key+".symbol", nil)
 		cm.viper.Set("networks."+key+".explorer", nil)
 		cm.viper.Set("networks."+key+".is_active", nil)
+		cm.viper.Set("networks."+key+".confirmations", nil)
+		cm.viper.Set("networks."+key+".genesis_hash", nil)
+		cm.viper.Set("networks."+key+".quarantine", nil)
+		cm.viper.Set("networks."+key+".testnet", nil)
 	}
 
 	// Clear the entire networks section
@@ -318,6 +528,16 @@ func (cm *ConfigurationManager) updateViperFromConfig(cfg *Config) {
 		cm.viper.Set("networks."+key+".symbol", network.Symbol)
 		cm.viper.Set("networks."+key+".explorer", network.Explorer)
 		cm.viper.Set("networks."+key+".is_active", network.IsActive)
+		cm.viper.Set("networks."+key+".testnet", network.Testnet)
+		if network.Confirmations > 0 {
+			cm.viper.Set("networks."+key+".confirmations", network.Confirmations)
+		}
+		if network.GenesisHash != "" {
+			cm.viper.Set("networks."+key+".genesis_hash", network.GenesisHash)
+		}
+		if network.Quarantine != "" {
+			cm.viper.Set("networks."+key+".quarantine", network.Quarantine)
+		}
 	}
 }
 
@@ -327,9 +547,13 @@ func (cm *ConfigurationManager) ReloadConfiguration() (*Config, error) {
 		return nil, fmt.Errorf("configuration not initialized")
 	}
 
+	// Values set by a previous save would hide the ones edited in the file
+	cm.viper = viper.New()
+	cm.configureViper()
 	if err := cm.viper.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to reload config file: %w", err)
 	}
+	cm.recordModTime()
 
 	return cm.buildConfigStruct()
 }
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Redacted replaces secrets in Settings
+const Redacted = "<redacted>"
+
+// Setting is one effective configuration value, keyed as in config.toml
+type Setting struct {
+	Key   string
+	Value string
+}
+
+// settingKeys are the config.toml keys that do not follow from the field names
+var settingKeys = map[string]string{
+	"app.fonts":                  "fonts.available",
+	"compliance.timeout_seconds": "compliance.approval_timeout_seconds",
+	"proxy.chain_list":           "proxy.chainlist",
+	"proxy.wallet_connect":       "proxy.walletconnect",
+	"wallet_connect.project_id":  "walletconnect.project_id",
+	"wallet_connect.relay_url":   "walletconnect.relay_url",
+}
+
+// urlSettings may carry credentials in their user info, query or path
+var urlSettings = []string{"sync.url", "compliance.approval_endpoint", "proxy.url", "rpc_endpoint"}
+
+// Settings lists the effective configuration by config.toml key. It walks the
+// Config struct, so a new field shows up without changes here. Secrets are
+// replaced by Redacted and credentials are cut out of URLs.
+func Settings(cfg *Config) []Setting {
+	var settings []Setting
+	v := reflect.ValueOf(*cfg)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		switch {
+		case field.Name == "Networks":
+			keys := make([]string, 0, len(cfg.Networks))
+			for key := range cfg.Networks {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				settings = appendSection(settings, "networks."+key, reflect.ValueOf(cfg.Networks[key]))
+			}
+		case value.Kind() == reflect.Struct:
+			settings = appendSection(settings, snakeCase(field.Name), value)
+		default:
+			settings = append(settings, setting("app."+snakeCase(field.Name), value))
+		}
+	}
+	return settings
+}
+
+func appendSection(settings []Setting, section string, v reflect.Value) []Setting {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		settings = append(settings, setting(section+"."+snakeCase(t.Field(i).Name), v.Field(i)))
+	}
+	return settings
+}
+
+func setting(key string, v reflect.Value) Setting {
+	if mapped, ok := settingKeys[key]; ok {
+		key = mapped
+	}
+	if v.Kind() == reflect.String && v.String() != "" {
+		for _, s := range secretSettings {
+			if s.key == key {
+				return Setting{Key: key, Value: Redacted}
+			}
+		}
+		for _, u := range urlSettings {
+			if key == u || strings.HasSuffix(key, "."+u) {
+				return Setting{Key: key, Value: fmt.Sprintf("%q", redactURL(v.String()))}
+			}
+		}
+	}
+	return Setting{Key: key, Value: formatSetting(v)}
+}
+
+// formatSetting writes a value the way it is written in config.toml
+func formatSetting(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("%q", v.String())
+	case reflect.Slice:
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = formatSetting(v.Index(i))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// redactURL keeps the scheme, host and port of a URL. User info, query and
+// path segments long enough to be an API key are replaced by Redacted.
+// Values that are not URLs, such as SQLite file paths, are kept.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		if i := strings.LastIndex(raw, "@"); i >= 0 {
+			// An scp-like remote such as user@host:path
+			return Redacted + raw[i:]
+		}
+		return raw
+	}
+	if u.User != nil {
+		u.User = url.User(Redacted)
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = Redacted
+	}
+	segments := strings.Split(u.Path, "/")
+	for i, s := range segments {
+		if len(s) >= 20 {
+			segments[i] = Redacted
+		}
+	}
+	u.Path = strings.Join(segments, "/")
+	u.RawPath = ""
+	return strings.NewReplacer("%3Credacted%3E", Redacted, "%3credacted%3e", Redacted).Replace(u.String())
+}
+
+// snakeCase turns a Go field name into its config.toml key: StrictRPC is
+// strict_rpc and Argon2KeyLen is argon2_key_len
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
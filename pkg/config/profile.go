@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultProfile is the profile kept in the base application directory
+const DefaultProfile = "default"
+
+// ProfileEnv selects the profile; the --profile flag sets it
+const ProfileEnv = "BLOCO_WALLET_PROFILE"
+
+// profilesDirName is the directory under the base application directory
+// holding one application directory per profile
+const profilesDirName = "profiles"
+
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// ValidateProfileName accepts lower case letters, digits, "-" and "_", so a
+// profile name is always a safe directory name
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use up to 32 lower case letters, digits, \"-\" or \"_\"", name)
+	}
+	return nil
+}
+
+// ProfileDir is the application directory of the profile under baseDir. The
+// default profile uses baseDir itself, so existing installs keep their data.
+func ProfileDir(baseDir, name string) string {
+	if name == "" || name == DefaultProfile {
+		return baseDir
+	}
+	return filepath.Join(baseDir, profilesDirName, name)
+}
+
+// ListProfiles returns the default profile followed by the profiles created
+// under baseDir, sorted by name
+func ListProfiles(baseDir string) ([]string, error) {
+	profiles := []string{DefaultProfile}
+	entries, err := os.ReadDir(filepath.Join(baseDir, profilesDirName))
+	if os.IsNotExist(err) {
+		return profiles, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != DefaultProfile && ValidateProfileName(entry.Name()) == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return append(profiles, names...), nil
+}
+
+// CreateProfile makes the application directory of a new profile; its
+// config.toml is written from the defaults when it is first loaded
+func CreateProfile(baseDir, name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	if name == DefaultProfile {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+	dir := ProfileDir(baseDir, name)
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create profile %q: %w", name, err)
+	}
+	return nil
+}
+
+// selectedProfile is the profile named by ProfileEnv, else the
+// profiles.default of the config.toml in baseDir
+func selectedProfile(baseDir string) (string, error) {
+	name := strings.TrimSpace(os.Getenv(ProfileEnv))
+	if name == "" {
+		v := viper.New()
+		v.SetConfigFile(filepath.Join(baseDir, "config.toml"))
+		v.SetConfigType("toml")
+		if err := v.ReadInConfig(); err == nil {
+			name = strings.TrimSpace(v.GetString("profiles.default"))
+		}
+	}
+	if name == "" || name == DefaultProfile {
+		return DefaultProfile, nil
+	}
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateProfileName(t *testing.T) {
+	for _, name := range []string{"work", "testnets", "client-a", "p_2"} {
+		assert.NoError(t, ValidateProfileName(name), name)
+	}
+	for _, name := range []string{"", "Work", "../x", "a/b", "-lead", strings.Repeat("a", 33)} {
+		assert.Error(t, ValidateProfileName(name), name)
+	}
+}
+
+func TestProfiles_CreateListAndLoad(t *testing.T) {
+	baseDir := t.TempDir()
+	t.Setenv("BLOCO_WALLET_APP_APP_DIR", baseDir)
+	t.Setenv(ProfileEnv, "")
+
+	profiles, err := ListProfiles(baseDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{DefaultProfile}, profiles)
+
+	require.NoError(t, CreateProfile(baseDir, "work"))
+	require.NoError(t, CreateProfile(baseDir, "testnets"))
+	assert.Error(t, CreateProfile(baseDir, "work"), "already exists")
+	assert.Error(t, CreateProfile(baseDir, DefaultProfile))
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "profiles", "Not Valid"), 0700))
+
+	profiles, err = ListProfiles(baseDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{DefaultProfile, "testnets", "work"}, profiles)
+
+	// The default profile is the base directory itself
+	cm := NewConfigurationManager()
+	cfg, err := cm.LoadConfiguration()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultProfile, cm.GetProfile())
+	assert.Equal(t, baseDir, cfg.AppDir)
+	assert.Equal(t, baseDir, cm.GetBaseDirectory())
+
+	// The environment picks another profile, with its own paths
+	t.Setenv(ProfileEnv, "work")
+	cm = NewConfigurationManager()
+	cfg, err = cm.LoadConfiguration()
+	require.NoError(t, err)
+	workDir := filepath.Join(baseDir, "profiles", "work")
+	assert.Equal(t, "work", cm.GetProfile())
+	assert.Equal(t, workDir, cfg.AppDir)
+	assert.Equal(t, filepath.Join(workDir, "keystore"), cfg.WalletsDir)
+	assert.Equal(t, filepath.Join(workDir, "bloco.db"), cfg.DatabasePath)
+	assert.FileExists(t, filepath.Join(workDir, "config.toml"))
+	assert.Equal(t, baseDir, cm.GetBaseDirectory())
+
+	t.Setenv(ProfileEnv, "../escape")
+	_, err = NewConfigurationManager().LoadConfiguration()
+	assert.Error(t, err)
+}
+
+func TestProfiles_DefaultFromBaseConfig(t *testing.T) {
+	baseDir := t.TempDir()
+	t.Setenv("BLOCO_WALLET_APP_APP_DIR", baseDir)
+	t.Setenv(ProfileEnv, "")
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "config.toml"), []byte("[profiles]\ndefault = \"testnets\"\n"), 0644))
+
+	cm := NewConfigurationManager()
+	cfg, err := cm.LoadConfiguration()
+	require.NoError(t, err)
+	assert.Equal(t, "testnets", cm.GetProfile())
+	assert.Equal(t, filepath.Join(baseDir, "profiles", "testnets"), cfg.AppDir)
+
+	// --profile default still reaches the base directory
+	t.Setenv(ProfileEnv, DefaultProfile)
+	cfg, err = NewConfigurationManager().LoadConfiguration()
+	require.NoError(t, err)
+	assert.Equal(t, baseDir, cfg.AppDir)
+}
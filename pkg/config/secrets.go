@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// secretSettings are the settings that can also be read from a file named by
+// an "<ENV>_FILE" variable, the way Docker and Kubernetes mount secrets, e.g.
+// BLOCO_WALLET_SYNC_PASSPHRASE_FILE=/run/secrets/sync_passphrase
+var secretSettings = []struct {
+	key   string
+	field func(cfg *Config) *string
+}{
+	{"database.dsn", func(cfg *Config) *string { return &cfg.Database.DSN }},
+	{"sync.password", func(cfg *Config) *string { return &cfg.Sync.Password }},
+	{"sync.passphrase", func(cfg *Config) *string { return &cfg.Sync.Passphrase }},
+	{"notifications.webhook_url", func(cfg *Config) *string { return &cfg.Notifications.WebhookURL }},
+}
+
+// secretEnvName returns the environment variable holding a setting
+func secretEnvName(key string) string {
+	return "BLOCO_WALLET_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// secretFromFile reports whether the setting is read from a mounted secret file
+func secretFromFile(key string) bool {
+	return os.Getenv(secretEnvName(key)+"_FILE") != ""
+}
+
+// applySecretFiles replaces settings with the content of their secret files.
+// Setting both the variable and its _FILE variant is an error, since it is
+// unclear which one should win.
+func applySecretFiles(cfg *Config) error {
+	for _, s := range secretSettings {
+		env := secretEnvName(s.key)
+		path := os.Getenv(env + "_FILE")
+		if path == "" {
+			continue
+		}
+		if os.Getenv(env) != "" {
+			return fmt.Errorf("both %s and %s_FILE are set", env, env)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading %s_FILE: %w", env, err)
+		}
+		// Secret files usually end with a newline that is not part of the value
+		*s.field(cfg) = strings.TrimRight(string(data), "\r\n")
+	}
+	return nil
+}
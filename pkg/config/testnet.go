@@ -0,0 +1,60 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// knownTestnets are the chain IDs of well-known test networks and of the
+// local development chains of Hardhat, Anvil and Ganache
+var knownTestnets = map[int64]bool{
+	5:         true, // Goerli
+	97:        true, // BNB Smart Chain Testnet
+	300:       true, // zkSync Sepolia
+	1337:      true, // Local development chain
+	4002:      true, // Fantom Testnet
+	5003:      true, // Mantle Sepolia
+	10200:     true, // Gnosis Chiado
+	17000:     true, // Holesky
+	31337:     true, // Hardhat and Anvil
+	43113:     true, // Avalanche Fuji
+	44787:     true, // Celo Alfajores
+	59141:     true, // Linea Sepolia
+	80001:     true, // Polygon Mumbai
+	80002:     true, // Polygon Amoy
+	84532:     true, // Base Sepolia
+	421614:    true, // Arbitrum Sepolia
+	534351:    true, // Scroll Sepolia
+	560048:    true, // Hoodi
+	11155111:  true, // Sepolia
+	11155420:  true, // OP Sepolia
+	168587773: true, // Blast Sepolia
+}
+
+// testnetWords are found in the names of test networks
+var testnetWords = []string{"testnet", "sepolia", "goerli", "holesky", "hoodi", "ropsten", "rinkeby", "kovan", "devnet", "amoy", "mumbai", "fuji", "chiado", "alfajores"}
+
+// LooksLikeTestnet reports whether a network is a test network, from its
+// chain ID and name. It is used when the network does not set testnet.
+func LooksLikeTestnet(chainID int64, name string) bool {
+	if knownTestnets[chainID] {
+		return true
+	}
+	name = strings.ToLower(name)
+	for _, word := range testnetWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// testnetFromViper reads the testnet flag of the network under networkKey,
+// detected from its chain ID and name when the configuration leaves it out
+func testnetFromViper(v *viper.Viper, networkKey string, network Network) bool {
+	if v.IsSet(networkKey + ".testnet") {
+		return v.GetBool(networkKey + ".testnet")
+	}
+	return LooksLikeTestnet(network.ChainID, network.Name)
+}
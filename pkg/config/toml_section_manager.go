@@ -140,6 +140,16 @@ func (tsm *TOMLSectionManager) FormatNetworkSection(networks map[string]Network)
 		result = append(result, fmt.Sprintf("symbol = %q", network.Symbol))
 		result = append(result, fmt.Sprintf("explorer = %q", network.Explorer))
 		result = append(result, fmt.Sprintf("is_active = %t", network.IsActive))
+		result = append(result, fmt.Sprintf("testnet = %t", network.Testnet))
+		if network.Confirmations > 0 {
+			result = append(result, fmt.Sprintf("confirmations = %d", network.Confirmations))
+		}
+		if network.GenesisHash != "" {
+			result = append(result, fmt.Sprintf("genesis_hash = %q", network.GenesisHash))
+		}
+		if network.Quarantine != "" {
+			result = append(result, fmt.Sprintf("quarantine = %q", network.Quarantine))
+		}
 	}
 
 	return result
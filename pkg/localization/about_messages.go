@@ -0,0 +1,56 @@
+package localization
+
+// AddAboutMessages adds the messages of the About screen to the Labels map
+func AddAboutMessages() {
+	// English messages
+	english := map[string]string{
+		"about":                 "About",
+		"about_menu_desc":       "Version, build and environment report",
+		"about_title":           "About",
+		"about_desc":            "Attach this report to bug reports. It holds no addresses or keys, and paths in your home directory are shortened to ~.",
+		"about_copied":          "Environment report copied to the clipboard",
+		"about_copied_terminal": "Environment report sent to the terminal clipboard (OSC 52); if your terminal does not support it, select the text above",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"about":                 "Sobre",
+		"about_menu_desc":       "Versão, build e relatório do ambiente",
+		"about_title":           "Sobre",
+		"about_desc":            "Anexe este relatório a relatos de erro. Ele não contém endereços nem chaves, e caminhos na sua pasta pessoal aparecem abreviados como ~.",
+		"about_copied":          "Relatório do ambiente copiado para a área de transferência",
+		"about_copied_terminal": "Relatório do ambiente enviado à área de transferência do terminal (OSC 52); se o seu terminal não tiver suporte, selecione o texto acima",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"about":                 "Acerca de",
+		"about_menu_desc":       "Versión, compilación e informe del entorno",
+		"about_title":           "Acerca de",
+		"about_desc":            "Adjunte este informe a los reportes de errores. No contiene direcciones ni claves, y las rutas en su carpeta personal se abrevian como ~.",
+		"about_copied":          "Informe del entorno copiado al portapapeles",
+		"about_copied_terminal": "Informe del entorno enviado al portapapeles del terminal (OSC 52); si su terminal no lo admite, seleccione el texto de arriba",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
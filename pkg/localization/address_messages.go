@@ -0,0 +1,44 @@
+package localization
+
+// AddAddressMessages adds the messages of the EIP-55 address checks to the Labels map
+func AddAddressMessages() {
+	// English messages
+	english := map[string]string{
+		"address_invalid":           "Enter a valid 0x address",
+		"address_checksum_mismatch": "Checksum mismatch: a character of this address may be mistyped. Expected %s. Press enter again to use it anyway.",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"address_invalid":           "Digite um endereço 0x válido",
+		"address_checksum_mismatch": "O checksum não confere: um caractere deste endereço pode estar errado. Esperado %s. Pressione enter de novo para usá-lo mesmo assim.",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"address_invalid":           "Ingrese una dirección 0x válida",
+		"address_checksum_mismatch": "El checksum no coincide: un carácter de esta dirección puede estar mal escrito. Se esperaba %s. Presione enter de nuevo para usarla de todos modos.",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package localization
+
+// AddBackupMessages adds the messages of the encrypted backup and restore of
+// the whole wallet database
+func AddBackupMessages() {
+	// English messages
+	english := map[string]string{
+		"backup":                      "Backup",
+		"backup_menu_desc":            "Export or restore an encrypted backup of all wallets",
+		"backup_title":                "Encrypted Backup",
+		"backup_export_desc":          "Writes the wallet database and every keystore to a single file encrypted with a password, to restore on another machine.",
+		"backup_restore_desc":         "Restores a backup written here or by the scheduled backup task. The wallets of this installation are replaced by the ones in the backup; keystores already present are kept.",
+		"backup_key":                  "Key file (scheduled backups only):",
+		"backup_key_placeholder":      "empty: password backup; otherwise the age identity or GPG secret key",
+		"backup_key_required":         "The backup is encrypted to a key: enter its age identity or GPG secret key file",
+		"backup_wrong_key":            "The key file does not open this backup",
+		"backup_mode":                 "Action:",
+		"backup_mode_export":          "Export backup",
+		"backup_mode_restore":         "Restore backup",
+		"backup_file":                 "Backup file:",
+		"backup_file_placeholder":     "empty: bloco-wallet-<date>.backup in the current directory",
+		"backup_password":             "Backup password:",
+		"backup_password_placeholder": "password",
+		"backup_confirm_placeholder":  "repeat the password",
+		"backup_password_mismatch":    "The passwords do not match",
+		"backup_file_required":        "Enter the backup file to restore",
+		"backup_password_required":    "Enter the backup password",
+		"backup_restoring":            "Checking and restoring backup...",
+		"backup_export_success":       "Backup of %d wallets and %d keystores written to %s",
+		"backup_restore_success":      "Restored %d wallets and %d keystores from a backup of %s",
+		"backup_wrong_password":       "Wrong backup password",
+		"backup_damaged":              "The backup is damaged and was not restored: %s",
+		"backup_newer":                "The backup was written by a newer version of the application; update it to restore",
+		"backup_not_backup":           "The file is not a wallet backup",
+		"backup_help":                 "tab: next field • ←/→: export or restore • enter: run • esc: back",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"backup":                      "Backup",
+		"backup_menu_desc":            "Exportar ou restaurar um backup criptografado de todas as carteiras",
+		"backup_title":                "Backup Criptografado",
+		"backup_export_desc":          "Grava o banco de dados das carteiras e todas as keystores em um único arquivo criptografado com uma senha, para restaurar em outra máquina.",
+		"backup_restore_desc":         "Restaura um backup gravado aqui ou pela tarefa de backup agendada. As carteiras desta instalação são substituídas pelas do backup; keystores já presentes são mantidas.",
+		"backup_key":                  "Arquivo da chave (apenas backups agendados):",
+		"backup_key_placeholder":      "vazio: backup com senha; senão a identidade age ou a chave secreta GPG",
+		"backup_key_required":         "O backup é criptografado para uma chave: informe o arquivo da identidade age ou da chave secreta GPG",
+		"backup_wrong_key":            "O arquivo da chave não abre este backup",
+		"backup_mode":                 "Ação:",
+		"backup_mode_export":          "Exportar backup",
+		"backup_mode_restore":         "Restaurar backup",
+		"backup_file":                 "Arquivo do backup:",
+		"backup_file_placeholder":     "vazio: bloco-wallet-<data>.backup no diretório atual",
+		"backup_password":             "Senha do backup:",
+		"backup_password_placeholder": "senha",
+		"backup_confirm_placeholder":  "repita a senha",
+		"backup_password_mismatch":    "As senhas não conferem",
+		"backup_file_required":        "Informe o arquivo do backup a restaurar",
+		"backup_password_required":    "Informe a senha do backup",
+		"backup_restoring":            "Verificando e restaurando o backup...",
+		"backup_export_success":       "Backup de %d carteiras e %d keystores gravado em %s",
+		"backup_restore_success":      "Restauradas %d carteiras e %d keystores de um backup de %s",
+		"backup_wrong_password":       "Senha do backup incorreta",
+		"backup_damaged":              "O backup está danificado e não foi restaurado: %s",
+		"backup_newer":                "O backup foi gravado por uma versão mais nova do aplicativo; atualize-o para restaurar",
+		"backup_not_backup":           "O arquivo não é um backup de carteiras",
+		"backup_help":                 "tab: próximo campo • ←/→: exportar ou restaurar • enter: executar • esc: voltar",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"backup":                      "Copia de seguridad",
+		"backup_menu_desc":            "Exportar o restaurar una copia cifrada de todas las billeteras",
+		"backup_title":                "Copia de Seguridad Cifrada",
+		"backup_export_desc":          "Escribe la base de datos de billeteras y todos los keystores en un único archivo cifrado con una contraseña, para restaurarlo en otra máquina.",
+		"backup_restore_desc":         "Restaura una copia escrita aquí o por la tarea de copia programada. Las billeteras de esta instalación se reemplazan por las de la copia; los keystores ya presentes se conservan.",
+		"backup_key":                  "Archivo de la clave (solo copias programadas):",
+		"backup_key_placeholder":      "vacío: copia con contraseña; si no, la identidad age o la clave secreta GPG",
+		"backup_key_required":         "La copia está cifrada para una clave: indique el archivo de la identidad age o de la clave secreta GPG",
+		"backup_wrong_key":            "El archivo de la clave no abre esta copia",
+		"backup_mode":                 "Acción:",
+		"backup_mode_export":          "Exportar copia",
+		"backup_mode_restore":         "Restaurar copia",
+		"backup_file":                 "Archivo de la copia:",
+		"backup_file_placeholder":     "vacío: bloco-wallet-<fecha>.backup en el directorio actual",
+		"backup_password":             "Contraseña de la copia:",
+		"backup_password_placeholder": "contraseña",
+		"backup_confirm_placeholder":  "repita la contraseña",
+		"backup_password_mismatch":    "Las contraseñas no coinciden",
+		"backup_file_required":        "Indique el archivo de la copia a restaurar",
+		"backup_password_required":    "Indique la contraseña de la copia",
+		"backup_restoring":            "Verificando y restaurando la copia...",
+		"backup_export_success":       "Copia de %d billeteras y %d keystores escrita en %s",
+		"backup_restore_success":      "Restauradas %d billeteras y %d keystores de una copia del %s",
+		"backup_wrong_password":       "Contraseña de la copia incorrecta",
+		"backup_damaged":              "La copia está dañada y no se restauró: %s",
+		"backup_newer":                "La copia fue escrita por una versión más nueva de la aplicación; actualícela para restaurar",
+		"backup_not_backup":           "El archivo no es una copia de billeteras",
+		"backup_help":                 "tab: siguiente campo • ←/→: exportar o restaurar • enter: ejecutar • esc: volver",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
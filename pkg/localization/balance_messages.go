@@ -0,0 +1,56 @@
+package localization
+
+// AddBalanceMessages adds the messages of the per-network balances to the Labels map
+func AddBalanceMessages() {
+	// English messages
+	english := map[string]string{
+		"balance_loading":         "loading…",
+		"balance_no_networks":     "No active network with an RPC endpoint",
+		"balance_dust_hidden":     "%d small balances hidden · press 'h' to show them",
+		"balance_dust_shown":      "Showing every balance · press 'h' to hide the small ones",
+		"balance_testnets":        "Test networks · coins without value:",
+		"balance_testnets_hidden": "%d test networks hidden by hide_testnets",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"balance_loading":         "carregando…",
+		"balance_no_networks":     "Nenhuma rede ativa com endpoint RPC",
+		"balance_dust_hidden":     "%d saldos pequenos ocultos · pressione 'h' para mostrá-los",
+		"balance_dust_shown":      "Mostrando todos os saldos · pressione 'h' para ocultar os pequenos",
+		"balance_testnets":        "Redes de teste · moedas sem valor:",
+		"balance_testnets_hidden": "%d redes de teste ocultas por hide_testnets",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"balance_loading":         "cargando…",
+		"balance_no_networks":     "Ninguna red activa con endpoint RPC",
+		"balance_dust_hidden":     "%d saldos pequeños ocultos · presione 'h' para mostrarlos",
+		"balance_dust_shown":      "Mostrando todos los saldos · presione 'h' para ocultar los pequeños",
+		"balance_testnets":        "Redes de prueba · monedas sin valor:",
+		"balance_testnets_hidden": "%d redes de prueba ocultas por hide_testnets",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package localization
+
+// AddBatchCreateMessages adds the messages of the batch wallet creation screen to the Labels map
+func AddBatchCreateMessages() {
+	// English messages
+	english := map[string]string{
+		"batch_create":                    "Create Multiple",
+		"batch_create_desc_short":         "Generate a batch of named wallets",
+		"batch_create_title":              "Create Multiple Wallets",
+		"batch_create_desc":               "Generate several new wallets at once and save their names and addresses to a CSV file.",
+		"batch_create_count":              "Number of wallets",
+		"batch_create_pattern":            "Name pattern ({} or {:03d} is replaced by the wallet number)",
+		"batch_create_policy":             "Passwords:",
+		"batch_create_policy_shared":      "same password for all",
+		"batch_create_policy_per_wallet":  "random password per wallet",
+		"batch_create_password":           "Password",
+		"batch_create_per_wallet_warning": "The generated passwords are written to the CSV file. Keep it safe: they are stored nowhere else.",
+		"batch_create_output":             "CSV file",
+		"batch_create_output_placeholder": "wallets-<date>.csv in the current directory",
+		"batch_create_invalid_count":      "Enter a number of wallets between 1 and %d",
+		"batch_create_progress":           "Creating wallets... %d of %d",
+		"batch_create_stopping":           "Stopping after the current wallet...",
+		"batch_create_success":            "%d wallets created, list saved to %s",
+		"batch_create_cancelled":          "Stopped: %d of %d wallets created, list saved to %s",
+		"batch_create_failed":             "%v (%d of %d wallets created; the CSV lists them)",
+		"batch_create_csv_failed":         "The wallets were created but the CSV could not be written: %v",
+		"batch_create_help":               "tab: next field • ←/→: change passwords • enter: create • esc: back",
+		"batch_create_help_running":       "esc: stop after the current wallet",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"batch_create":                    "Criar Várias",
+		"batch_create_desc_short":         "Gerar um lote de carteiras nomeadas",
+		"batch_create_title":              "Criar Várias Carteiras",
+		"batch_create_desc":               "Gere várias carteiras novas de uma vez e salve seus nomes e endereços em um arquivo CSV.",
+		"batch_create_count":              "Quantidade de carteiras",
+		"batch_create_pattern":            "Padrão de nome ({} ou {:03d} é trocado pelo número da carteira)",
+		"batch_create_policy":             "Senhas:",
+		"batch_create_policy_shared":      "mesma senha para todas",
+		"batch_create_policy_per_wallet":  "senha aleatória por carteira",
+		"batch_create_password":           "Senha",
+		"batch_create_per_wallet_warning": "As senhas geradas são gravadas no arquivo CSV. Guarde-o com cuidado: elas não ficam salvas em nenhum outro lugar.",
+		"batch_create_output":             "Arquivo CSV",
+		"batch_create_output_placeholder": "wallets-<data>.csv no diretório atual",
+		"batch_create_invalid_count":      "Informe uma quantidade de carteiras entre 1 e %d",
+		"batch_create_progress":           "Criando carteiras... %d de %d",
+		"batch_create_stopping":           "Parando após a carteira atual...",
+		"batch_create_success":            "%d carteiras criadas, lista salva em %s",
+		"batch_create_cancelled":          "Interrompido: %d de %d carteiras criadas, lista salva em %s",
+		"batch_create_failed":             "%v (%d de %d carteiras criadas; o CSV as relaciona)",
+		"batch_create_csv_failed":         "As carteiras foram criadas, mas o CSV não pôde ser gravado: %v",
+		"batch_create_help":               "tab: próximo campo • ←/→: mudar senhas • enter: criar • esc: voltar",
+		"batch_create_help_running":       "esc: parar após a carteira atual",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"batch_create":                    "Crear Varias",
+		"batch_create_desc_short":         "Generar un lote de carteras con nombre",
+		"batch_create_title":              "Crear Varias Carteras",
+		"batch_create_desc":               "Genere varias carteras nuevas a la vez y guarde sus nombres y direcciones en un archivo CSV.",
+		"batch_create_count":              "Cantidad de carteras",
+		"batch_create_pattern":            "Patrón de nombre ({} o {:03d} se reemplaza por el número de la cartera)",
+		"batch_create_policy":             "Contraseñas:",
+		"batch_create_policy_shared":      "la misma para todas",
+		"batch_create_policy_per_wallet":  "aleatoria por cartera",
+		"batch_create_password":           "Contraseña",
+		"batch_create_per_wallet_warning": "Las contraseñas generadas se escriben en el archivo CSV. Guárdelo con cuidado: no se almacenan en ningún otro lugar.",
+		"batch_create_output":             "Archivo CSV",
+		"batch_create_output_placeholder": "wallets-<fecha>.csv en el directorio actual",
+		"batch_create_invalid_count":      "Indique una cantidad de carteras entre 1 y %d",
+		"batch_create_progress":           "Creando carteras... %d de %d",
+		"batch_create_stopping":           "Deteniendo después de la cartera actual...",
+		"batch_create_success":            "%d carteras creadas, lista guardada en %s",
+		"batch_create_cancelled":          "Detenido: %d de %d carteras creadas, lista guardada en %s",
+		"batch_create_failed":             "%v (%d de %d carteras creadas; el CSV las enumera)",
+		"batch_create_csv_failed":         "Las carteras se crearon pero no se pudo escribir el CSV: %v",
+		"batch_create_help":               "tab: siguiente campo • ←/→: cambiar contraseñas • enter: crear • esc: volver",
+		"batch_create_help_running":       "esc: detener después de la cartera actual",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
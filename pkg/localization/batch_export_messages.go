@@ -0,0 +1,99 @@
+package localization
+
+// AddBatchExportMessages adds the messages of exporting many wallets to a
+// directory to the Labels map
+func AddBatchExportMessages() {
+	// English messages
+	english := map[string]string{
+		"batch_export_title":             "Batch Export",
+		"batch_export_desc":              "Copy the keystores of the wallets to a directory, laid out so a batch import of it finds them again",
+		"batch_export_wallets":           "%d wallets (mark them with space in the list; none marked exports all):",
+		"batch_export_watch_only":        "watch-only, skipped",
+		"batch_export_dir":               "Target directory:",
+		"batch_export_passwords":         ".pwd files:",
+		"batch_export_passwords_yes":     "Yes, ask each wallet's password",
+		"batch_export_passwords_no":      "No",
+		"batch_export_passwords_warning": "Each password is written in plain text next to its keystore: keep the directory on encrypted storage.",
+		"batch_export_no_wallets":        "There are no wallets to export.",
+		"batch_export_unlock":            "Password of each wallet, checked before it is written to its .pwd file:",
+		"batch_export_running":           "Exporting to %s…",
+		"batch_export_cancelling":        "Stopping after the current wallet…",
+		"batch_export_done":              "Export finished",
+		"batch_export_done_errors":       "Export finished with errors",
+		"batch_export_summary":           "Exported: %d · Failed: %d · Skipped: %d · Directory: %s",
+		"batch_export_help":              "tab: next field • ←/→: .pwd files • enter: export • esc: back",
+		"batch_export_unlock_help":       "enter: check password • ctrl+s: leave this wallet out • esc: back to the form",
+		"batch_export_running_help":      "esc: cancel",
+		"batch_export_done_help":         "enter/esc: back to the list",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"batch_export_title":             "Exportação em Lote",
+		"batch_export_desc":              "Copia os keystores das carteiras para um diretório, organizados para que uma importação em lote dele os encontre de novo",
+		"batch_export_wallets":           "%d carteiras (marque-as com espaço na lista; sem marcação, todas são exportadas):",
+		"batch_export_watch_only":        "somente leitura, pulada",
+		"batch_export_dir":               "Diretório de destino:",
+		"batch_export_passwords":         "Arquivos .pwd:",
+		"batch_export_passwords_yes":     "Sim, pedir a senha de cada carteira",
+		"batch_export_passwords_no":      "Não",
+		"batch_export_passwords_warning": "Cada senha é gravada em texto puro ao lado do seu keystore: mantenha o diretório em armazenamento criptografado.",
+		"batch_export_no_wallets":        "Não há carteiras para exportar.",
+		"batch_export_unlock":            "Senha de cada carteira, verificada antes de ser gravada no arquivo .pwd:",
+		"batch_export_running":           "Exportando para %s…",
+		"batch_export_cancelling":        "Parando após a carteira atual…",
+		"batch_export_done":              "Exportação concluída",
+		"batch_export_done_errors":       "Exportação concluída com erros",
+		"batch_export_summary":           "Exportadas: %d · Falhas: %d · Puladas: %d · Diretório: %s",
+		"batch_export_help":              "tab: próximo campo • ←/→: arquivos .pwd • enter: exportar • esc: voltar",
+		"batch_export_unlock_help":       "enter: verificar senha • ctrl+s: deixar esta carteira de fora • esc: voltar ao formulário",
+		"batch_export_running_help":      "esc: cancelar",
+		"batch_export_done_help":         "enter/esc: voltar para a lista",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"batch_export_title":             "Exportación por Lotes",
+		"batch_export_desc":              "Copia los keystores de las billeteras a un directorio, organizados para que una importación por lotes de él los encuentre de nuevo",
+		"batch_export_wallets":           "%d billeteras (márquelas con espacio en la lista; sin marcas, se exportan todas):",
+		"batch_export_watch_only":        "solo lectura, omitida",
+		"batch_export_dir":               "Directorio de destino:",
+		"batch_export_passwords":         "Archivos .pwd:",
+		"batch_export_passwords_yes":     "Sí, pedir la contraseña de cada billetera",
+		"batch_export_passwords_no":      "No",
+		"batch_export_passwords_warning": "Cada contraseña se escribe en texto plano junto a su keystore: mantenga el directorio en almacenamiento cifrado.",
+		"batch_export_no_wallets":        "No hay billeteras para exportar.",
+		"batch_export_unlock":            "Contraseña de cada billetera, verificada antes de escribirse en su archivo .pwd:",
+		"batch_export_running":           "Exportando a %s…",
+		"batch_export_cancelling":        "Deteniendo tras la billetera actual…",
+		"batch_export_done":              "Exportación terminada",
+		"batch_export_done_errors":       "Exportación terminada con errores",
+		"batch_export_summary":           "Exportadas: %d · Fallidas: %d · Omitidas: %d · Directorio: %s",
+		"batch_export_help":              "tab: siguiente campo • ←/→: archivos .pwd • enter: exportar • esc: volver",
+		"batch_export_unlock_help":       "enter: verificar contraseña • ctrl+s: dejar fuera esta billetera • esc: volver al formulario",
+		"batch_export_running_help":      "esc: cancelar",
+		"batch_export_done_help":         "enter/esc: volver a la lista",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package localization
+
+// AddBatchSendMessages adds the messages of sending from several wallets at
+// once, and of sweeping them, to the Labels map
+func AddBatchSendMessages() {
+	// English messages
+	english := map[string]string{
+		"batch_send":                        "Batch Send",
+		"batch_send_title":                  "Batch Send",
+		"batch_send_desc":                   "Send from several wallets to one address, on one or more networks",
+		"batch_send_wallets":                "Source wallets",
+		"batch_send_networks":               "Networks",
+		"batch_send_amount":                 "Amount from each wallet",
+		"batch_send_no_wallets":             "Select at least one wallet with a private key",
+		"batch_send_no_networks":            "Select at least one network",
+		"batch_send_nothing_ready":          "No transfer of the plan can be sent",
+		"batch_send_wallet_missing":         "The wallet is no longer available",
+		"batch_send_estimating":             "%s: estimating…",
+		"batch_send_network_failed":         "%s: %v",
+		"batch_send_network_total":          "%s: %d of %d ready · gas %d · fees up to %s %s · total up to %s %s",
+		"batch_send_item":                   "nonce %d · gas %d",
+		"batch_send_insufficient":           "%s holds %s %s, needs up to %s %s",
+		"batch_send_skipped":                "skipped",
+		"batch_send_progress":               "Sending %d of %d: %s on %s…",
+		"batch_send_stopping":               "Stopping after the current transfer…",
+		"batch_send_cancelled":              "Stopped.",
+		"batch_send_summary":                "Sent %d of %d, %d failed, %d skipped",
+		"batch_send_help":                   "↑/↓: move · space: select · a: all wallets · tab: next field · enter: estimate · esc: back",
+		"batch_send_help_plan":              "enter: send the ready transfers in order · d: dry run, sign without sending · backspace: change the batch · esc: back",
+		"batch_send_help_running":           "esc: stop after the current transfer",
+		"batch_send_help_done":              "c: copy the report · enter: new batch · esc: back",
+		"batch_send_help_dry_run":           "c: copy the report · backspace: back to the plan · enter: new batch · esc: back",
+		"batch_send_help_unlock":            "enter: unlock · ctrl+s: skip this wallet · esc: stop",
+		"batch_send_unlocking":              "Unlocking…",
+		"batch_send_sent":                   "sent %s",
+		"batch_send_signed":                 "signed, not sent %s",
+		"batch_send_dry_run_summary":        "Dry run: signed %d of %d, %d failed, %d skipped; nothing was sent",
+		"batch_send_report_copied":          "Report copied to the clipboard",
+		"batch_send_report_copied_terminal": "Report sent to the terminal clipboard (OSC 52); if your terminal does not support it, select the text above",
+		"sweep":                             "Treasury Sweep",
+		"sweep_title":                       "Treasury Sweep",
+		"sweep_desc":                        "Move the balances of several wallets to one address, such as a treasury",
+		"sweep_assets":                      "Assets",
+		"sweep_native":                      "Native coin, less the gas",
+		"sweep_no_assets":                   "Select at least one asset to sweep",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"batch_send":                        "Envio em Lote",
+		"batch_send_title":                  "Envio em Lote",
+		"batch_send_desc":                   "Enviar de várias carteiras para um endereço, em uma ou mais redes",
+		"batch_send_wallets":                "Carteiras de origem",
+		"batch_send_networks":               "Redes",
+		"batch_send_amount":                 "Valor de cada carteira",
+		"batch_send_no_wallets":             "Selecione ao menos uma carteira com chave privada",
+		"batch_send_no_networks":            "Selecione ao menos uma rede",
+		"batch_send_nothing_ready":          "Nenhuma transferência do plano pode ser enviada",
+		"batch_send_wallet_missing":         "A carteira não está mais disponível",
+		"batch_send_estimating":             "%s: estimando…",
+		"batch_send_network_failed":         "%s: %v",
+		"batch_send_network_total":          "%s: %d de %d prontas · gas %d · taxas até %s %s · total até %s %s",
+		"batch_send_item":                   "nonce %d · gas %d",
+		"batch_send_insufficient":           "%s tem %s %s, precisa de até %s %s",
+		"batch_send_skipped":                "ignorada",
+		"batch_send_progress":               "Enviando %d de %d: %s em %s…",
+		"batch_send_stopping":               "Parando após a transferência atual…",
+		"batch_send_cancelled":              "Interrompido.",
+		"batch_send_summary":                "%d de %d enviadas, %d falharam, %d ignoradas",
+		"batch_send_help":                   "↑/↓: mover · espaço: selecionar · a: todas as carteiras · tab: próximo campo · enter: estimar · esc: voltar",
+		"batch_send_help_plan":              "enter: enviar as transferências prontas em ordem · d: simulação, assina sem enviar · backspace: alterar o lote · esc: voltar",
+		"batch_send_help_running":           "esc: parar após a transferência atual",
+		"batch_send_help_done":              "c: copiar o relatório · enter: novo lote · esc: voltar",
+		"batch_send_help_dry_run":           "c: copiar o relatório · backspace: voltar ao plano · enter: novo lote · esc: voltar",
+		"batch_send_help_unlock":            "enter: desbloquear · ctrl+s: pular esta carteira · esc: parar",
+		"batch_send_unlocking":              "Desbloqueando…",
+		"batch_send_sent":                   "enviada %s",
+		"batch_send_signed":                 "assinada, não enviada %s",
+		"batch_send_dry_run_summary":        "Simulação: %d de %d assinadas, %d falharam, %d ignoradas; nada foi enviado",
+		"batch_send_report_copied":          "Relatório copiado para a área de transferência",
+		"batch_send_report_copied_terminal": "Relatório enviado à área de transferência do terminal (OSC 52); se o terminal não suportar, selecione o texto acima",
+		"sweep":                             "Varredura para Tesouraria",
+		"sweep_title":                       "Varredura para Tesouraria",
+		"sweep_desc":                        "Mover os saldos de várias carteiras para um endereço, como uma tesouraria",
+		"sweep_assets":                      "Ativos",
+		"sweep_native":                      "Moeda nativa, menos o gas",
+		"sweep_no_assets":                   "Selecione ao menos um ativo para varrer",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"batch_send":                        "Envío en Lote",
+		"batch_send_title":                  "Envío en Lote",
+		"batch_send_desc":                   "Enviar desde varias billeteras a una dirección, en una o más redes",
+		"batch_send_wallets":                "Billeteras de origen",
+		"batch_send_networks":               "Redes",
+		"batch_send_amount":                 "Monto de cada billetera",
+		"batch_send_no_wallets":             "Seleccione al menos una billetera con clave privada",
+		"batch_send_no_networks":            "Seleccione al menos una red",
+		"batch_send_nothing_ready":          "Ninguna transferencia del plan puede enviarse",
+		"batch_send_wallet_missing":         "La billetera ya no está disponible",
+		"batch_send_estimating":             "%s: estimando…",
+		"batch_send_network_failed":         "%s: %v",
+		"batch_send_network_total":          "%s: %d de %d listas · gas %d · comisiones hasta %s %s · total hasta %s %s",
+		"batch_send_item":                   "nonce %d · gas %d",
+		"batch_send_insufficient":           "%s tiene %s %s, necesita hasta %s %s",
+		"batch_send_skipped":                "omitida",
+		"batch_send_progress":               "Enviando %d de %d: %s en %s…",
+		"batch_send_stopping":               "Deteniendo tras la transferencia actual…",
+		"batch_send_cancelled":              "Detenido.",
+		"batch_send_summary":                "%d de %d enviadas, %d fallaron, %d omitidas",
+		"batch_send_help":                   "↑/↓: mover · espacio: seleccionar · a: todas las billeteras · tab: siguiente campo · enter: estimar · esc: volver",
+		"batch_send_help_plan":              "enter: enviar las transferencias listas en orden · d: simulación, firma sin enviar · backspace: cambiar el lote · esc: volver",
+		"batch_send_help_running":           "esc: detener tras la transferencia actual",
+		"batch_send_help_done":              "c: copiar el informe · enter: nuevo lote · esc: volver",
+		"batch_send_help_dry_run":           "c: copiar el informe · backspace: volver al plan · enter: nuevo lote · esc: volver",
+		"batch_send_help_unlock":            "enter: desbloquear · ctrl+s: omitir esta billetera · esc: detener",
+		"batch_send_unlocking":              "Desbloqueando…",
+		"batch_send_sent":                   "enviada %s",
+		"batch_send_signed":                 "firmada, no enviada %s",
+		"batch_send_dry_run_summary":        "Simulación: %d de %d firmadas, %d fallaron, %d omitidas; no se envió nada",
+		"batch_send_report_copied":          "Informe copiado al portapapeles",
+		"batch_send_report_copied_terminal": "Informe enviado al portapapeles del terminal (OSC 52); si su terminal no lo admite, seleccione el texto de arriba",
+		"sweep":                             "Barrido a Tesorería",
+		"sweep_title":                       "Barrido a Tesorería",
+		"sweep_desc":                        "Mover los saldos de varias billeteras a una dirección, como una tesorería",
+		"sweep_assets":                      "Activos",
+		"sweep_native":                      "Moneda nativa, menos el gas",
+		"sweep_no_assets":                   "Seleccione al menos un activo para barrer",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package localization
+
+// AddBroadcastMessages adds the messages of the raw transaction broadcast screen to the Labels map
+func AddBroadcastMessages() {
+	// English messages
+	english := map[string]string{
+		"broadcast_tx":                  "Broadcast",
+		"broadcast_tx_desc":             "Send a transaction signed elsewhere",
+		"broadcast_title":               "Broadcast Signed Transaction",
+		"broadcast_desc":                "Paste the signed raw transaction as hex, or the path of a file holding it. It is decoded and shown before anything is sent.",
+		"broadcast_input_placeholder":   "0x02f8… or /path/to/signed-tx.txt",
+		"broadcast_empty":               "Paste a signed transaction or a file path",
+		"broadcast_invalid":             "Invalid transaction: %v",
+		"broadcast_no_networks":         "No active network with an RPC endpoint is configured",
+		"broadcast_no_matching_network": "No active network has chain ID %s; sending it elsewhere will be rejected",
+		"broadcast_summary":             "From %s\nTo %s\nAmount %s %s • nonce %d • chain %d\nGas limit %d • max fee %s gwei • priority fee %s gwei\nMax cost %s %s",
+		"broadcast_contract_creation":   "(contract creation)",
+		"broadcast_known_wallet":        "Signed by the local wallet \"%s\"",
+		"broadcast_network":             "Network (←/→)",
+		"broadcast_sending":             "Sending to %s…",
+		"broadcast_checking":            "Checking the status on %s…",
+		"broadcast_failed":              "Broadcast failed: %v",
+		"broadcast_check_failed":        "Could not check the status: %v",
+		"broadcast_state_pending":       "Sent. The transaction is pending, waiting to be mined.",
+		"broadcast_state_included":      "Included in block %d (%d confirmations)",
+		"broadcast_state_reverted":      "Included in block %d, but execution reverted",
+		"broadcast_state_not_found":     "The node no longer knows the transaction; it may have been dropped or replaced",
+		"broadcast_help":                "enter: decode • esc: back to menu",
+		"broadcast_help_review":         "←/→: network • enter: broadcast • backspace: change the transaction • esc: back to menu",
+		"broadcast_help_sent":           "r: refresh status • enter: broadcast another • esc: back to menu",
+		"broadcast_tracked":             "Followed in Transactions until final.",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"broadcast_tx":                  "Transmitir",
+		"broadcast_tx_desc":             "Enviar uma transação assinada em outro lugar",
+		"broadcast_title":               "Transmitir Transação Assinada",
+		"broadcast_desc":                "Cole a transação assinada em hex, ou o caminho de um arquivo que a contenha. Ela é decodificada e exibida antes de qualquer envio.",
+		"broadcast_input_placeholder":   "0x02f8… ou /caminho/para/tx-assinada.txt",
+		"broadcast_empty":               "Cole uma transação assinada ou o caminho de um arquivo",
+		"broadcast_invalid":             "Transação inválida: %v",
+		"broadcast_no_networks":         "Nenhuma rede ativa com endpoint RPC está configurada",
+		"broadcast_no_matching_network": "Nenhuma rede ativa tem o chain ID %s; enviá-la para outra rede será rejeitado",
+		"broadcast_summary":             "De %s\nPara %s\nValor %s %s • nonce %d • chain %d\nLimite de gas %d • taxa máxima %s gwei • taxa de prioridade %s gwei\nCusto máximo %s %s",
+		"broadcast_contract_creation":   "(criação de contrato)",
+		"broadcast_known_wallet":        "Assinada pela carteira local \"%s\"",
+		"broadcast_network":             "Rede (←/→)",
+		"broadcast_sending":             "Enviando para %s…",
+		"broadcast_checking":            "Verificando o estado em %s…",
+		"broadcast_failed":              "Falha na transmissão: %v",
+		"broadcast_check_failed":        "Não foi possível verificar o estado: %v",
+		"broadcast_state_pending":       "Enviada. A transação está pendente, aguardando mineração.",
+		"broadcast_state_included":      "Incluída no bloco %d (%d confirmações)",
+		"broadcast_state_reverted":      "Incluída no bloco %d, mas a execução foi revertida",
+		"broadcast_state_not_found":     "O nó não conhece mais a transação; ela pode ter sido descartada ou substituída",
+		"broadcast_help":                "enter: decodificar • esc: voltar ao menu",
+		"broadcast_help_review":         "←/→: rede • enter: transmitir • backspace: trocar a transação • esc: voltar ao menu",
+		"broadcast_help_sent":           "r: atualizar estado • enter: transmitir outra • esc: voltar ao menu",
+		"broadcast_tracked":             "Acompanhada em Transações até ser definitiva.",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"broadcast_tx":                  "Transmitir",
+		"broadcast_tx_desc":             "Enviar una transacción firmada en otro lugar",
+		"broadcast_title":               "Transmitir Transacción Firmada",
+		"broadcast_desc":                "Pegue la transacción firmada en hex, o la ruta de un archivo que la contenga. Se decodifica y se muestra antes de enviar nada.",
+		"broadcast_input_placeholder":   "0x02f8… o /ruta/a/tx-firmada.txt",
+		"broadcast_empty":               "Pegue una transacción firmada o la ruta de un archivo",
+		"broadcast_invalid":             "Transacción inválida: %v",
+		"broadcast_no_networks":         "No hay ninguna red activa con endpoint RPC configurada",
+		"broadcast_no_matching_network": "Ninguna red activa tiene el chain ID %s; enviarla a otra red será rechazado",
+		"broadcast_summary":             "De %s\nPara %s\nMonto %s %s • nonce %d • chain %d\nLímite de gas %d • tarifa máxima %s gwei • tarifa de prioridad %s gwei\nCosto máximo %s %s",
+		"broadcast_contract_creation":   "(creación de contrato)",
+		"broadcast_known_wallet":        "Firmada por la cartera local \"%s\"",
+		"broadcast_network":             "Red (←/→)",
+		"broadcast_sending":             "Enviando a %s…",
+		"broadcast_checking":            "Verificando el estado en %s…",
+		"broadcast_failed":              "Error al transmitir: %v",
+		"broadcast_check_failed":        "No se pudo verificar el estado: %v",
+		"broadcast_state_pending":       "Enviada. La transacción está pendiente, esperando ser minada.",
+		"broadcast_state_included":      "Incluida en el bloque %d (%d confirmaciones)",
+		"broadcast_state_reverted":      "Incluida en el bloque %d, pero la ejecución fue revertida",
+		"broadcast_state_not_found":     "El nodo ya no conoce la transacción; puede haber sido descartada o reemplazada",
+		"broadcast_help":                "enter: decodificar • esc: volver al menú",
+		"broadcast_help_review":         "←/→: red • enter: transmitir • backspace: cambiar la transacción • esc: volver al menú",
+		"broadcast_help_sent":           "r: actualizar estado • enter: transmitir otra • esc: volver al menú",
+		"broadcast_tracked":             "Seguida en Transacciones hasta ser definitiva.",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
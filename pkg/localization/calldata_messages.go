@@ -0,0 +1,44 @@
+package localization
+
+// AddCalldataMessages adds the messages of decoded calldata to the Labels map
+func AddCalldataMessages() {
+	// English messages
+	english := map[string]string{
+		"calldata_call":    "Call %s",
+		"calldata_unknown": "%s (unknown function)",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"calldata_call":    "Chamada %s",
+		"calldata_unknown": "%s (função desconhecida)",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"calldata_call":    "Llamada %s",
+		"calldata_unknown": "%s (función desconocida)",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package localization
+
+// AddChildWalletMessages adds the messages of the child wallet screen to the Labels map
+func AddChildWalletMessages() {
+	// English messages
+	english := map[string]string{
+		"child_title":               "Child Wallets",
+		"child_master":              "Master wallet \"%s\" (%s)",
+		"child_desc":                "Each child is the address at m/44'/60'/0'/0/<index> of the master mnemonic, stored as a wallet of its own without the mnemonic. Handed-out indices stay allocated even if the child wallet is deleted.",
+		"child_none":                "No child wallets yet.",
+		"child_deleted":             "(wallet deleted)",
+		"child_label":               "Label",
+		"child_label_placeholder":   "e.g. alice-payouts",
+		"child_index":               "Index",
+		"child_password":            "Password for the child keystore",
+		"child_invalid_index":       "The index must be a whole number",
+		"child_index_taken":         "Index %d is already allocated",
+		"child_created":             "Child wallet \"%s\" created at %s: %s",
+		"child_help":                "tab: next field • enter: derive • esc: back to the details",
+		"derived_child":             "Derived Child",
+		"method_derived":            "Derived child (%s)",
+		"discovery_title":           "Used Child Addresses",
+		"discovery_desc":            "Checking m/44'/60'/0'/0/1, /2, ... on the active networks until %d unused addresses in a row. Addresses with a balance or sent transactions count as used.",
+		"discovery_progress":        "Checking %s… %d used so far",
+		"discovery_none":            "No used child addresses were found.",
+		"discovery_stopping":        "Stopping after the current address…",
+		"discovery_failed":          "Scan stopped: %v",
+		"discovery_registered":      "%d child wallets registered, %d already known",
+		"discovery_register_failed": "Registration stopped: %v (%d registered)",
+		"discovery_help_running":    "esc: stop the scan",
+		"discovery_help_register":   "enter: register them as child wallets with the import password • esc: skip",
+		"discovery_help_done":       "enter/esc: open the imported wallet",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"child_title":               "Carteiras Filhas",
+		"child_master":              "Carteira mestre \"%s\" (%s)",
+		"child_desc":                "Cada filha é o endereço em m/44'/60'/0'/0/<índice> da mnemônica mestre, salvo como uma carteira própria sem a mnemônica. Índices entregues continuam reservados mesmo se a carteira filha for excluída.",
+		"child_none":                "Nenhuma carteira filha ainda.",
+		"child_deleted":             "(carteira excluída)",
+		"child_label":               "Rótulo",
+		"child_label_placeholder":   "ex.: pagamentos-alice",
+		"child_index":               "Índice",
+		"child_password":            "Senha do keystore da carteira filha",
+		"child_invalid_index":       "O índice deve ser um número inteiro",
+		"child_index_taken":         "O índice %d já está reservado",
+		"child_created":             "Carteira filha \"%s\" criada em %s: %s",
+		"child_help":                "tab: próximo campo • enter: derivar • esc: voltar aos detalhes",
+		"derived_child":             "Filha Derivada",
+		"method_derived":            "Filha derivada (%s)",
+		"discovery_title":           "Endereços Filhos Usados",
+		"discovery_desc":            "Verificando m/44'/60'/0'/0/1, /2, ... nas redes ativas até %d endereços sem uso seguidos. Endereços com saldo ou transações enviadas contam como usados.",
+		"discovery_progress":        "Verificando %s… %d usados até agora",
+		"discovery_none":            "Nenhum endereço filho usado foi encontrado.",
+		"discovery_stopping":        "Parando após o endereço atual…",
+		"discovery_failed":          "Busca interrompida: %v",
+		"discovery_registered":      "%d carteiras filhas registradas, %d já conhecidas",
+		"discovery_register_failed": "Registro interrompido: %v (%d registradas)",
+		"discovery_help_running":    "esc: parar a busca",
+		"discovery_help_register":   "enter: registrá-los como carteiras filhas com a senha da importação • esc: pular",
+		"discovery_help_done":       "enter/esc: abrir a carteira importada",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"child_title":               "Carteras Hijas",
+		"child_master":              "Cartera maestra \"%s\" (%s)",
+		"child_desc":                "Cada hija es la dirección en m/44'/60'/0'/0/<índice> de la mnemónica maestra, guardada como una cartera propia sin la mnemónica. Los índices entregados siguen reservados aunque la cartera hija se elimine.",
+		"child_none":                "Todavía no hay carteras hijas.",
+		"child_deleted":             "(cartera eliminada)",
+		"child_label":               "Etiqueta",
+		"child_label_placeholder":   "p. ej. pagos-alice",
+		"child_index":               "Índice",
+		"child_password":            "Contraseña del keystore de la cartera hija",
+		"child_invalid_index":       "El índice debe ser un número entero",
+		"child_index_taken":         "El índice %d ya está reservado",
+		"child_created":             "Cartera hija \"%s\" creada en %s: %s",
+		"child_help":                "tab: siguiente campo • enter: derivar • esc: volver a los detalles",
+		"derived_child":             "Hija Derivada",
+		"method_derived":            "Hija derivada (%s)",
+		"discovery_title":           "Direcciones Hijas Usadas",
+		"discovery_desc":            "Comprobando m/44'/60'/0'/0/1, /2, ... en las redes activas hasta %d direcciones sin uso seguidas. Las direcciones con saldo o transacciones enviadas cuentan como usadas.",
+		"discovery_progress":        "Comprobando %s… %d usadas hasta ahora",
+		"discovery_none":            "No se encontraron direcciones hijas usadas.",
+		"discovery_stopping":        "Deteniendo tras la dirección actual…",
+		"discovery_failed":          "Búsqueda detenida: %v",
+		"discovery_registered":      "%d carteras hijas registradas, %d ya conocidas",
+		"discovery_register_failed": "Registro detenido: %v (%d registradas)",
+		"discovery_help_running":    "esc: detener la búsqueda",
+		"discovery_help_register":   "enter: registrarlas como carteras hijas con la contraseña de la importación • esc: omitir",
+		"discovery_help_done":       "enter/esc: abrir la cartera importada",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
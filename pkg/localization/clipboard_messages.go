@@ -0,0 +1,60 @@
+package localization
+
+// AddClipboardMessages adds the messages of the copy actions in the wallet
+// details and of the clipboard countdown in the status bar
+func AddClipboardMessages() {
+	// English messages
+	english := map[string]string{
+		"clipboard_address":         "Address",
+		"clipboard_private_key":     "Private key",
+		"clipboard_mnemonic":        "Mnemonic",
+		"clipboard_copied":          "%s copied to the clipboard",
+		"clipboard_copied_terminal": "%s sent to the terminal clipboard (OSC 52)",
+		"clipboard_countdown":       "Clipboard clears in %ds",
+		"clipboard_cleared":         "Clipboard cleared",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"clipboard_address":         "Endereço",
+		"clipboard_private_key":     "Chave privada",
+		"clipboard_mnemonic":        "Mnemônica",
+		"clipboard_copied":          "Copiado para a área de transferência: %s",
+		"clipboard_copied_terminal": "Enviado à área de transferência do terminal (OSC 52): %s",
+		"clipboard_countdown":       "Área de transferência apagada em %ds",
+		"clipboard_cleared":         "Área de transferência apagada",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"clipboard_address":         "Dirección",
+		"clipboard_private_key":     "Clave privada",
+		"clipboard_mnemonic":        "Mnemónica",
+		"clipboard_copied":          "Copiado al portapapeles: %s",
+		"clipboard_copied_terminal": "Enviado al portapapeles del terminal (OSC 52): %s",
+		"clipboard_countdown":       "Portapapeles se borra en %ds",
+		"clipboard_cleared":         "Portapapeles borrado",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package localization
+
+// AddComplianceMessages adds compliance mode messages to the Labels map
+func AddComplianceMessages() {
+	// English messages
+	english := map[string]string{
+		"approval_waiting":      "Waiting for a second approver (request %s): %s",
+		"compliance_mode_label": "Compliance mode: exports and deletions require a second approver",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"approval_waiting":      "Aguardando um segundo aprovador (solicitação %s): %s",
+		"compliance_mode_label": "Modo de conformidade: exportações e exclusões exigem um segundo aprovador",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"approval_waiting":      "Esperando a un segundo aprobador (solicitud %s): %s",
+		"compliance_mode_label": "Modo de cumplimiento: las exportaciones y eliminaciones requieren un segundo aprobador",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
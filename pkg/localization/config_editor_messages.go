@@ -0,0 +1,116 @@
+package localization
+
+// AddConfigEditorMessages adds the messages of the settings editor to the Labels map
+func AddConfigEditorMessages() {
+	// English messages
+	english := map[string]string{
+		"config_editor":                    "Settings",
+		"config_editor_menu_desc":          "Edit language, fonts, security, import and network settings",
+		"config_editor_title":              "Settings",
+		"config_editor_section_general":    "General",
+		"config_editor_section_security":   "Security",
+		"config_editor_section_import":     "Import",
+		"config_editor_section_networks":   "Networks",
+		"config_editor_language":           "Language",
+		"config_editor_fonts":              "Banner fonts",
+		"config_editor_argon2_time":        "Argon2 iterations",
+		"config_editor_argon2_memory":      "Argon2 memory (KiB)",
+		"config_editor_argon2_threads":     "Argon2 threads",
+		"config_editor_keystore_kdf":       "Keystore KDF",
+		"config_editor_clipboard_clear":    "Clear clipboard after (s)",
+		"config_editor_import_concurrency": "Import concurrency",
+		"config_editor_import_memory":      "Import memory limit (MB, 0 = auto)",
+		"config_editor_strict_rpc":         "Strict RPC checks",
+		"config_editor_hide_testnets":      "Hide testnet balances",
+		"config_editor_out_of_range":       "%s must be a number between %d and %d",
+		"config_editor_invalid_choice":     "%s must be one of: %s",
+		"config_editor_load_failed":        "Cannot load the configuration: %v",
+		"config_editor_save_failed":        "Cannot save the configuration: %v",
+		"config_editor_apply_failed":       "Saved, but not applied: %v",
+		"config_editor_saved":              "Saved to config.toml and applied.",
+		"config_editor_reloaded":           "config.toml was edited elsewhere; the form shows it now.",
+		"config_editor_changed_on_disk":    "config.toml was edited elsewhere; ctrl+s overwrites it with this form.",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"config_editor":                    "Configurações",
+		"config_editor_menu_desc":          "Editar idioma, fontes, segurança, importação e redes",
+		"config_editor_title":              "Configurações",
+		"config_editor_section_general":    "Geral",
+		"config_editor_section_security":   "Segurança",
+		"config_editor_section_import":     "Importação",
+		"config_editor_section_networks":   "Redes",
+		"config_editor_language":           "Idioma",
+		"config_editor_fonts":              "Fontes do banner",
+		"config_editor_argon2_time":        "Iterações do Argon2",
+		"config_editor_argon2_memory":      "Memória do Argon2 (KiB)",
+		"config_editor_argon2_threads":     "Threads do Argon2",
+		"config_editor_keystore_kdf":       "KDF das keystores",
+		"config_editor_clipboard_clear":    "Limpar a área de transferência após (s)",
+		"config_editor_import_concurrency": "Importações simultâneas",
+		"config_editor_import_memory":      "Limite de memória da importação (MB, 0 = auto)",
+		"config_editor_strict_rpc":         "Verificação estrita do RPC",
+		"config_editor_hide_testnets":      "Ocultar saldos de testnets",
+		"config_editor_out_of_range":       "%s deve ser um número entre %d e %d",
+		"config_editor_invalid_choice":     "%s deve ser um destes: %s",
+		"config_editor_load_failed":        "Não foi possível carregar a configuração: %v",
+		"config_editor_save_failed":        "Não foi possível salvar a configuração: %v",
+		"config_editor_apply_failed":       "Salvo, mas não aplicado: %v",
+		"config_editor_saved":              "Salvo no config.toml e aplicado.",
+		"config_editor_reloaded":           "O config.toml foi editado fora do programa; o formulário já o mostra.",
+		"config_editor_changed_on_disk":    "O config.toml foi editado fora do programa; ctrl+s o sobrescreve com este formulário.",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"config_editor":                    "Ajustes",
+		"config_editor_menu_desc":          "Editar idioma, fuentes, seguridad, importación y redes",
+		"config_editor_title":              "Ajustes",
+		"config_editor_section_general":    "General",
+		"config_editor_section_security":   "Seguridad",
+		"config_editor_section_import":     "Importación",
+		"config_editor_section_networks":   "Redes",
+		"config_editor_language":           "Idioma",
+		"config_editor_fonts":              "Fuentes del banner",
+		"config_editor_argon2_time":        "Iteraciones de Argon2",
+		"config_editor_argon2_memory":      "Memoria de Argon2 (KiB)",
+		"config_editor_argon2_threads":     "Hilos de Argon2",
+		"config_editor_keystore_kdf":       "KDF de las keystores",
+		"config_editor_clipboard_clear":    "Limpiar el portapapeles tras (s)",
+		"config_editor_import_concurrency": "Importaciones simultáneas",
+		"config_editor_import_memory":      "Límite de memoria de importación (MB, 0 = auto)",
+		"config_editor_strict_rpc":         "Verificación estricta del RPC",
+		"config_editor_hide_testnets":      "Ocultar saldos de testnets",
+		"config_editor_out_of_range":       "%s debe ser un número entre %d y %d",
+		"config_editor_invalid_choice":     "%s debe ser uno de: %s",
+		"config_editor_load_failed":        "No se puede cargar la configuración: %v",
+		"config_editor_save_failed":        "No se puede guardar la configuración: %v",
+		"config_editor_apply_failed":       "Guardado, pero no aplicado: %v",
+		"config_editor_saved":              "Guardado en config.toml y aplicado.",
+		"config_editor_reloaded":           "config.toml se editó fuera del programa; el formulario ya lo muestra.",
+		"config_editor_changed_on_disk":    "config.toml se editó fuera del programa; ctrl+s lo sobrescribe con este formulario.",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
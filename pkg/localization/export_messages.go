@@ -0,0 +1,98 @@
+package localization
+
+// AddExportMessages adds encrypted export messages to the Labels map
+func AddExportMessages() {
+	// English messages
+	english := map[string]string{
+		"secure_export_title":                          "Encrypted Export",
+		"secure_export_desc":                           "Encrypt a secret to another person's or machine's public key. Only the encrypted file is written to disk.",
+		"secure_export_secret":                         "Secret:",
+		"secure_export_mnemonic":                       "Mnemonic phrase",
+		"secure_export_keystore":                       "Keystore file",
+		"secure_export_recipient":                      "Recipient public key:",
+		"secure_export_recipient_placeholder":          "age1... or path to a GPG/age public key file",
+		"secure_export_output":                         "Output file:",
+		"secure_export_output_placeholder":             "Leave empty for <address>-<secret>.age/.asc in the current directory",
+		"secure_export_help":                           "tab: next field • ←/→: change secret • enter: export • esc: back",
+		"secure_export_success":                        "Encrypted for %s and saved to %s",
+		"secure_export_no_mnemonic":                    "This wallet has no mnemonic; only the keystore can be exported.",
+		"secure_export_keystore_v3":                    "KeyStore V3 file (new password)",
+		"secure_export_keystore_v3_desc":               "Write the private key as a standard KeyStore V3 file under a password of your choice, to keep as a backup or open in another wallet.",
+		"secure_export_password":                       "Password of the file:",
+		"secure_export_password_placeholder":           "New password",
+		"secure_export_confirm_placeholder":            "Repeat the password",
+		"secure_export_password_mismatch":              "The passwords do not match.",
+		"secure_export_keystore_v3_output_placeholder": "Leave empty for UTC--<time>--<address> in the current directory",
+		"secure_export_keystore_v3_success":            "KeyStore V3 file saved to %s; it opens with the password you chose",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"secure_export_title":                          "Exportação Criptografada",
+		"secure_export_desc":                           "Criptografa um segredo para a chave pública de outra pessoa ou máquina. Apenas o arquivo criptografado é gravado no disco.",
+		"secure_export_secret":                         "Segredo:",
+		"secure_export_mnemonic":                       "Frase mnemônica",
+		"secure_export_keystore":                       "Arquivo keystore",
+		"secure_export_recipient":                      "Chave pública do destinatário:",
+		"secure_export_recipient_placeholder":          "age1... ou caminho para um arquivo de chave pública GPG/age",
+		"secure_export_output":                         "Arquivo de saída:",
+		"secure_export_output_placeholder":             "Deixe vazio para <endereço>-<segredo>.age/.asc no diretório atual",
+		"secure_export_help":                           "tab: próximo campo • ←/→: trocar segredo • enter: exportar • esc: voltar",
+		"secure_export_success":                        "Criptografado para %s e salvo em %s",
+		"secure_export_no_mnemonic":                    "Esta carteira não possui mnemônico; apenas o keystore pode ser exportado.",
+		"secure_export_keystore_v3":                    "Arquivo KeyStore V3 (nova senha)",
+		"secure_export_keystore_v3_desc":               "Grava a chave privada como um arquivo KeyStore V3 padrão sob uma senha de sua escolha, para guardar como backup ou abrir em outra carteira.",
+		"secure_export_password":                       "Senha do arquivo:",
+		"secure_export_password_placeholder":           "Nova senha",
+		"secure_export_confirm_placeholder":            "Repita a senha",
+		"secure_export_password_mismatch":              "As senhas não coincidem.",
+		"secure_export_keystore_v3_output_placeholder": "Deixe vazio para UTC--<hora>--<endereço> no diretório atual",
+		"secure_export_keystore_v3_success":            "Arquivo KeyStore V3 salvo em %s; ele abre com a senha escolhida",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"secure_export_title":                          "Exportación Cifrada",
+		"secure_export_desc":                           "Cifra un secreto con la clave pública de otra persona o máquina. Solo se escribe en disco el archivo cifrado.",
+		"secure_export_secret":                         "Secreto:",
+		"secure_export_mnemonic":                       "Frase mnemónica",
+		"secure_export_keystore":                       "Archivo keystore",
+		"secure_export_recipient":                      "Clave pública del destinatario:",
+		"secure_export_recipient_placeholder":          "age1... o ruta a un archivo de clave pública GPG/age",
+		"secure_export_output":                         "Archivo de salida:",
+		"secure_export_output_placeholder":             "Deje vacío para <dirección>-<secreto>.age/.asc en el directorio actual",
+		"secure_export_help":                           "tab: siguiente campo • ←/→: cambiar secreto • enter: exportar • esc: volver",
+		"secure_export_success":                        "Cifrado para %s y guardado en %s",
+		"secure_export_no_mnemonic":                    "Esta billetera no tiene mnemónico; solo se puede exportar el keystore.",
+		"secure_export_keystore_v3":                    "Archivo KeyStore V3 (nueva contraseña)",
+		"secure_export_keystore_v3_desc":               "Escribe la clave privada como un archivo KeyStore V3 estándar bajo una contraseña de su elección, para guardarlo como respaldo o abrirlo en otra billetera.",
+		"secure_export_password":                       "Contraseña del archivo:",
+		"secure_export_password_placeholder":           "Nueva contraseña",
+		"secure_export_confirm_placeholder":            "Repita la contraseña",
+		"secure_export_password_mismatch":              "Las contraseñas no coinciden.",
+		"secure_export_keystore_v3_output_placeholder": "Deje vacío para UTC--<hora>--<dirección> en el directorio actual",
+		"secure_export_keystore_v3_success":            "Archivo KeyStore V3 guardado en %s; se abre con la contraseña elegida",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package localization
+
+// AddForkMessages adds the messages of the local fork test of a transfer to
+// the Labels map
+func AddForkMessages() {
+	// English messages
+	english := map[string]string{
+		"fork_starting":         "Starting a local fork of %s with anvil and executing the transfer…",
+		"fork_unavailable":      "anvil was not found: install Foundry (https://getfoundry.sh) or set anvil_path under [fork] in config.toml",
+		"fork_failed":           "Fork test failed: %v",
+		"fork_title":            "Executed on a local fork of %s:",
+		"fork_succeeded":        "Succeeded using %d gas (fee %s %s)",
+		"fork_reverted":         "Reverted on the fork, so it would fail on the network too: %s",
+		"fork_no_reason":        "no reason given",
+		"fork_note":             "The fork starts at the latest block; the network can change before the transfer is mined.",
+		"send_help_review_fork": "enter: sign and send • f: try on a local fork • backspace: change the transfer • esc: back",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"fork_starting":         "Iniciando um fork local de %s com o anvil e executando a transferência…",
+		"fork_unavailable":      "anvil não encontrado: instale o Foundry (https://getfoundry.sh) ou defina anvil_path em [fork] no config.toml",
+		"fork_failed":           "O teste no fork falhou: %v",
+		"fork_title":            "Executada em um fork local de %s:",
+		"fork_succeeded":        "Concluída usando %d de gas (taxa %s %s)",
+		"fork_reverted":         "Revertida no fork, então também falharia na rede: %s",
+		"fork_no_reason":        "nenhum motivo informado",
+		"fork_note":             "O fork parte do bloco mais recente; a rede pode mudar antes de a transferência ser minerada.",
+		"send_help_review_fork": "enter: assinar e enviar • f: testar em um fork local • backspace: alterar a transferência • esc: voltar",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"fork_starting":         "Iniciando un fork local de %s con anvil y ejecutando la transferencia…",
+		"fork_unavailable":      "anvil no encontrado: instale Foundry (https://getfoundry.sh) o defina anvil_path en [fork] en config.toml",
+		"fork_failed":           "La prueba en el fork falló: %v",
+		"fork_title":            "Ejecutada en un fork local de %s:",
+		"fork_succeeded":        "Completada usando %d de gas (comisión %s %s)",
+		"fork_reverted":         "Revertida en el fork, así que también fallaría en la red: %s",
+		"fork_no_reason":        "sin motivo indicado",
+		"fork_note":             "El fork parte del bloque más reciente; la red puede cambiar antes de que se mine la transferencia.",
+		"send_help_review_fork": "enter: firmar y enviar • f: probar en un fork local • backspace: cambiar la transferencia • esc: volver",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package localization
+
+// AddHelpMessages adds the descriptions of the keys shown in the footer and in
+// the help overlay to the Labels map
+func AddHelpMessages() {
+	// English messages
+	english := map[string]string{
+		"help_title":           "Keyboard shortcuts",
+		"key_help":             "help",
+		"key_close_help":       "close help",
+		"key_back":             "back",
+		"key_commands":         "commands",
+		"key_quit":             "quit",
+		"key_move":             "move",
+		"key_select":           "select",
+		"key_column":           "column",
+		"key_run":              "run",
+		"key_close":            "close",
+		"key_cancel":           "cancel",
+		"key_confirm":          "confirm",
+		"key_choose":           "choose",
+		"key_delete_archive":   "keep a copy of the keystore",
+		"key_keep_filter":      "keep filter",
+		"key_clear_filter":     "clear filter",
+		"key_save":             "save",
+		"key_page":             "page",
+		"key_details":          "details",
+		"key_search":           "search",
+		"key_sort":             "sort",
+		"key_notes":            "notes",
+		"key_delete":           "delete",
+		"key_mark":             "mark",
+		"key_mark_all":         "mark all/none",
+		"key_export":           "export",
+		"key_report":           "report",
+		"key_delete_marked":    "delete marked",
+		"key_tag_marked":       "tag marked",
+		"key_balances_marked":  "balances of marked",
+		"key_clear_marks":      "clear marks",
+		"key_export_wallet":    "encrypted export",
+		"key_sign":             "sign transaction",
+		"key_send":             "send funds",
+		"key_rpc":              "own RPC endpoint",
+		"key_refresh_balances": "refresh balances",
+		"key_dust":             "show/hide dust",
+		"key_tokens":           "ERC-20 tokens",
+		"key_children":         "child wallets",
+		"key_copy_address":     "copy address",
+		"key_copy_private_key": "copy private key",
+		"key_copy_mnemonic":    "copy mnemonic",
+		"key_reveal_word":      "next/previous word",
+		"key_copy_report":      "copy the report",
+		"key_cancel_job":       "cancel job",
+		"key_clear_finished":   "clear finished",
+		"key_scroll":           "scroll",
+		"key_oldest_newest":    "oldest/newest",
+		"key_level":            "level",
+		"key_refresh":          "refresh",
+		"key_field":            "field",
+		"key_change":           "change",
+		"key_save_apply":       "save and apply",
+		"key_create":           "create",
+		"key_switch":           "switch",
+		"key_new_profile":      "new profile",
+		"key_preview":          "preview",
+		"key_use":              "use",
+		"key_reload_files":     "reload files",
+		"key_switch_field":     "switch field",
+		"key_discard":          "discard",
+		"key_next_field":       "next field",
+		"key_import":           "import",
+		"key_relink":           "relink",
+		"key_reimport":         "re-import",
+		"key_remove_row":       "remove row",
+		"key_quarantine":       "quarantine",
+		"key_check_again":      "check again",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"help_title":           "Atalhos de teclado",
+		"key_help":             "ajuda",
+		"key_close_help":       "fechar a ajuda",
+		"key_back":             "voltar",
+		"key_commands":         "comandos",
+		"key_quit":             "sair",
+		"key_move":             "mover",
+		"key_select":           "selecionar",
+		"key_column":           "coluna",
+		"key_run":              "executar",
+		"key_close":            "fechar",
+		"key_cancel":           "cancelar",
+		"key_confirm":          "confirmar",
+		"key_choose":           "escolher",
+		"key_delete_archive":   "guardar uma cópia da keystore",
+		"key_keep_filter":      "manter o filtro",
+		"key_clear_filter":     "limpar o filtro",
+		"key_save":             "salvar",
+		"key_page":             "página",
+		"key_details":          "detalhes",
+		"key_search":           "buscar",
+		"key_sort":             "ordenar",
+		"key_notes":            "notas",
+		"key_delete":           "excluir",
+		"key_mark":             "marcar",
+		"key_mark_all":         "marcar todas/nenhuma",
+		"key_export":           "exportar",
+		"key_report":           "relatório",
+		"key_delete_marked":    "excluir as marcadas",
+		"key_tag_marked":       "etiquetar as marcadas",
+		"key_balances_marked":  "saldos das marcadas",
+		"key_clear_marks":      "desmarcar",
+		"key_export_wallet":    "exportação criptografada",
+		"key_sign":             "assinar transação",
+		"key_send":             "enviar fundos",
+		"key_rpc":              "endpoint RPC próprio",
+		"key_refresh_balances": "atualizar saldos",
+		"key_dust":             "mostrar/ocultar saldos mínimos",
+		"key_tokens":           "tokens ERC-20",
+		"key_children":         "carteiras filhas",
+		"key_copy_address":     "copiar endereço",
+		"key_copy_private_key": "copiar chave privada",
+		"key_copy_mnemonic":    "copiar mnemônica",
+		"key_reveal_word":      "próxima/anterior palavra",
+		"key_copy_report":      "copiar o relatório",
+		"key_cancel_job":       "cancelar tarefa",
+		"key_clear_finished":   "limpar concluídas",
+		"key_scroll":           "rolar",
+		"key_oldest_newest":    "mais antigas/recentes",
+		"key_level":            "nível",
+		"key_refresh":          "atualizar",
+		"key_field":            "campo",
+		"key_change":           "alterar",
+		"key_save_apply":       "salvar e aplicar",
+		"key_create":           "criar",
+		"key_switch":           "trocar",
+		"key_new_profile":      "novo perfil",
+		"key_preview":          "pré-visualizar",
+		"key_use":              "usar",
+		"key_reload_files":     "recarregar arquivos",
+		"key_switch_field":     "trocar de campo",
+		"key_discard":          "descartar",
+		"key_next_field":       "próximo campo",
+		"key_import":           "importar",
+		"key_relink":           "religar",
+		"key_reimport":         "reimportar",
+		"key_remove_row":       "remover registro",
+		"key_quarantine":       "quarentena",
+		"key_check_again":      "verificar novamente",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"help_title":           "Atajos de teclado",
+		"key_help":             "ayuda",
+		"key_close_help":       "cerrar la ayuda",
+		"key_back":             "volver",
+		"key_commands":         "comandos",
+		"key_quit":             "salir",
+		"key_move":             "mover",
+		"key_select":           "seleccionar",
+		"key_column":           "columna",
+		"key_run":              "ejecutar",
+		"key_close":            "cerrar",
+		"key_cancel":           "cancelar",
+		"key_confirm":          "confirmar",
+		"key_choose":           "elegir",
+		"key_delete_archive":   "guardar una copia del keystore",
+		"key_keep_filter":      "mantener el filtro",
+		"key_clear_filter":     "limpiar el filtro",
+		"key_save":             "guardar",
+		"key_page":             "página",
+		"key_details":          "detalles",
+		"key_search":           "buscar",
+		"key_sort":             "ordenar",
+		"key_notes":            "notas",
+		"key_delete":           "eliminar",
+		"key_mark":             "marcar",
+		"key_mark_all":         "marcar todas/ninguna",
+		"key_export":           "exportar",
+		"key_report":           "informe",
+		"key_delete_marked":    "eliminar las marcadas",
+		"key_tag_marked":       "etiquetar las marcadas",
+		"key_balances_marked":  "saldos de las marcadas",
+		"key_clear_marks":      "desmarcar",
+		"key_export_wallet":    "exportación cifrada",
+		"key_sign":             "firmar transacción",
+		"key_send":             "enviar fondos",
+		"key_rpc":              "endpoint RPC propio",
+		"key_refresh_balances": "actualizar saldos",
+		"key_dust":             "mostrar/ocultar saldos mínimos",
+		"key_tokens":           "tokens ERC-20",
+		"key_children":         "carteras hijas",
+		"key_copy_address":     "copiar dirección",
+		"key_copy_private_key": "copiar clave privada",
+		"key_copy_mnemonic":    "copiar mnemónica",
+		"key_reveal_word":      "siguiente/anterior palabra",
+		"key_copy_report":      "copiar el informe",
+		"key_cancel_job":       "cancelar tarea",
+		"key_clear_finished":   "limpiar terminadas",
+		"key_scroll":           "desplazar",
+		"key_oldest_newest":    "más antiguas/recientes",
+		"key_level":            "nivel",
+		"key_refresh":          "actualizar",
+		"key_field":            "campo",
+		"key_change":           "cambiar",
+		"key_save_apply":       "guardar y aplicar",
+		"key_create":           "crear",
+		"key_switch":           "cambiar",
+		"key_new_profile":      "nuevo perfil",
+		"key_preview":          "previsualizar",
+		"key_use":              "usar",
+		"key_reload_files":     "recargar archivos",
+		"key_switch_field":     "cambiar de campo",
+		"key_discard":          "descartar",
+		"key_next_field":       "siguiente campo",
+		"key_import":           "importar",
+		"key_relink":           "volver a enlazar",
+		"key_reimport":         "reimportar",
+		"key_remove_row":       "eliminar registro",
+		"key_quarantine":       "cuarentena",
+		"key_check_again":      "verificar de nuevo",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,393 @@
+package localization
+
+// AddImportCompletionMessages adds the messages of the batch import screens:
+// the completion summary, its recovery suggestions and the password prompt
+func AddImportCompletionMessages() {
+	// English messages
+	english := map[string]string{
+		// Completion summary
+		"completion_title_success":  "Import Completed Successfully",
+		"completion_title_partial":  "Import Completed with Issues",
+		"completion_title_failed":   "Import Failed",
+		"completion_title_done":     "Import Complete",
+		"completion_stats":          "Total: %d | Success: %d | Failed: %d | Skipped: %d",
+		"completion_success_rate":   "Success Rate: %.1f%%",
+		"completion_elapsed":        "Completed in: %v",
+		"completion_elapsed_avg":    " (avg: %v per file)",
+		"completion_issues":         "Issues Encountered:",
+		"completion_errors":         "Errors:",
+		"completion_failed":         "Failed",
+		"completion_skipped":        "Skipped",
+		"completion_more":           "... and %d more",
+		"completion_actions":        "Available Actions:",
+		"completion_help_navigate":  "Use ↑/↓ or j/k to navigate actions",
+		"completion_help_execute":   "Press ENTER to execute selected action",
+		"completion_help_return":    "Press ESC or Q to return to main menu",
+		"completion_help_errors":    "Press E to view detailed error information",
+		"completion_help_done":      "Press ENTER to return to menu or R to retry failed imports",
+		"completion_cancelled":      "Import Cancelled",
+		"completion_processed":      "Processed: %d/%d files before cancellation",
+		"completion_imported":       "Successfully imported: %d wallets",
+		"completion_help_cancelled": "Press ENTER to return to menu",
+
+		// Password manifest rows left out of the import
+		"completion_manifest":             "Password manifest rows not used:",
+		"manifest_problem_missing_file":   "%s does not exist",
+		"manifest_problem_empty_password": "%s has an empty password",
+		"manifest_problem_duplicate":      "%s is listed more than once",
+		"manifest_problem_invalid_path":   "%s is outside the manifest directory",
+		"manifest_problem_malformed":      "the row needs a file name and a password",
+
+		// Completion actions
+		"completion_action_return":             "Return to Main Menu",
+		"completion_action_return_desc":        "Go back to the main wallet management menu",
+		"completion_action_select_files":       "Select Different Files",
+		"completion_action_select_files_desc":  "Choose different keystore files to import",
+		"completion_action_retry_failed":       "Retry Failed Imports (%d)",
+		"completion_action_retry_failed_desc":  "Retry importing files that failed due to errors",
+		"completion_action_retry_skipped":      "Retry Skipped Imports (%d)",
+		"completion_action_retry_skipped_desc": "Retry importing files that were skipped (password input cancelled)",
+		"completion_action_retry_all":          "Retry All Failed/Skipped (%d)",
+		"completion_action_retry_all_desc":     "Retry all files that failed or were skipped",
+		"completion_action_view_errors":        "View Error Details (%d)",
+		"completion_action_view_errors_desc":   "View detailed information about errors that occurred",
+
+		// Error details
+		"completion_no_errors":           "No errors to display",
+		"completion_error_details":       "Error Details",
+		"completion_error_position":      "Error %d of %d",
+		"completion_error_file":          "File: %s",
+		"completion_error_status":        "Status: %s",
+		"completion_error_type":          "Type: %s",
+		"completion_error_message":       "Error Details:",
+		"completion_suggestions":         "Suggested Actions:",
+		"completion_error_help_navigate": "Use ↑/↓ or j/k to navigate between errors",
+		"completion_error_help_retry":    "Press R to retry this specific file",
+		"completion_error_help_back":     "Press ESC or Q to return to summary",
+
+		// Recovery suggestions
+		"suggest_skipped":           "File was skipped due to user cancellation",
+		"suggest_manual_password":   "Retry with manual password input",
+		"suggest_verify_password":   "Verify the password is correct",
+		"suggest_check_pwd_file":    "Check if a .pwd file exists with the correct password",
+		"suggest_fix_pwd_file":      "Make sure the .pwd file is readable and holds only the password",
+		"suggest_valid_format":      "Verify the file is a valid KeyStore V3 format",
+		"suggest_corrupted":         "Check if the file is corrupted",
+		"suggest_permissions":       "Check file permissions",
+		"suggest_locked":            "Ensure the file is not locked by another process",
+		"suggest_reselect":          "Select the file again; it may have been moved or deleted",
+		"suggest_overwrite":         "A different keystore of this address is already managed",
+		"suggest_overwrite_review":  "Import this file on its own to review the differences and confirm",
+		"suggest_check_details":     "Check the error details above",
+		"suggest_verify_accessible": "Verify the file is accessible and valid",
+
+		// Password prompt
+		"password_popup_title":          "Password Required",
+		"password_popup_placeholder":    "Enter keystore password...",
+		"password_popup_file":           "File: %s",
+		"password_popup_attempts":       "Attempts remaining: %d",
+		"password_popup_max_attempts":   "Maximum attempts reached",
+		"password_popup_error":          "Error: %s",
+		"password_popup_help":           "Enter: Confirm • Esc: Cancel • Ctrl+S: Skip file",
+		"password_popup_apply_all":      "Use this password for all remaining locked files",
+		"password_popup_help_apply_all": "Enter: Confirm • Tab: Use for all • Esc: Cancel • Ctrl+S: Skip file",
+
+		// Interrupted batches
+		"import_resume_banner":  "Previous import interrupted: %d of %d files imported, %d left.\n   Press ctrl+r to resume it or ctrl+x to discard it.",
+		"import_resume_nothing": "Nothing left to resume: %d files were already imported or no longer exist",
+
+		// Duplicate check before a batch
+		"import_duplicates_title":     "Already imported",
+		"import_duplicates_summary":   "%d of %d files are already imported",
+		"import_duplicates_line":      "• %s  %s  (%s)",
+		"import_duplicates_identical": "same keystore",
+		"import_duplicates_different": "other keystore",
+		"import_duplicates_more":      "… and %d more",
+		"import_duplicates_help":      "s/enter: Skip them • o: Overwrite • i: Import anyway • esc: Back",
+
+		"completion_error_help_rename":       "Press N to retry it under another wallet name",
+		"completion_retry_name":              "Wallet name:",
+		"completion_retry_password":          "Password:",
+		"completion_retry_help":              "enter: Retry • tab: Next field • esc: Cancel",
+		"completion_retry_name_required":     "The wallet name cannot be empty",
+		"completion_retry_password_required": "The password cannot be empty",
+		"completion_retry_running":           "Retrying %s…",
+		"completion_retry_success":           "%s imported as %s",
+		"completion_retry_failed":            "%s failed again: %v",
+
+		"completion_action_export_report":      "Export Report",
+		"completion_action_export_report_desc": "Write the summary and the result of every file to a JSON or CSV file",
+		"completion_help_report":               "Press X to export a report of the import",
+		"completion_report_label":              "Report file:",
+		"completion_report_help":               "enter: Write • esc: Cancel • a name ending in .csv writes CSV, otherwise JSON",
+		"completion_report_written":            "Report of %d files written to %s",
+		"completion_report_failed":             "Could not write the report: %v",
+		"import_duplicates_nothing":            "Nothing to import: all %d files are already imported",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		// Resumo da importação
+		"completion_title_success":  "Importação Concluída com Sucesso",
+		"completion_title_partial":  "Importação Concluída com Problemas",
+		"completion_title_failed":   "Importação Falhou",
+		"completion_title_done":     "Importação Concluída",
+		"completion_stats":          "Total: %d | Sucesso: %d | Falhas: %d | Puladas: %d",
+		"completion_success_rate":   "Taxa de Sucesso: %.1f%%",
+		"completion_elapsed":        "Concluída em: %v",
+		"completion_elapsed_avg":    " (média: %v por arquivo)",
+		"completion_issues":         "Problemas Encontrados:",
+		"completion_errors":         "Erros:",
+		"completion_failed":         "Falhou",
+		"completion_skipped":        "Pulado",
+		"completion_more":           "... e mais %d",
+		"completion_actions":        "Ações Disponíveis:",
+		"completion_help_navigate":  "Use ↑/↓ ou j/k para navegar pelas ações",
+		"completion_help_execute":   "Pressione ENTER para executar a ação selecionada",
+		"completion_help_return":    "Pressione ESC ou Q para voltar ao menu principal",
+		"completion_help_errors":    "Pressione E para ver os detalhes dos erros",
+		"completion_help_done":      "Pressione ENTER para voltar ao menu ou R para repetir as importações com falha",
+		"completion_cancelled":      "Importação Cancelada",
+		"completion_processed":      "Processados: %d/%d arquivos antes do cancelamento",
+		"completion_imported":       "Importadas com sucesso: %d carteiras",
+		"completion_help_cancelled": "Pressione ENTER para voltar ao menu",
+
+		// Linhas do manifesto de senhas não usadas
+		"completion_manifest":             "Linhas do manifesto de senhas não usadas:",
+		"manifest_problem_missing_file":   "%s não existe",
+		"manifest_problem_empty_password": "%s tem a senha vazia",
+		"manifest_problem_duplicate":      "%s aparece mais de uma vez",
+		"manifest_problem_invalid_path":   "%s está fora do diretório do manifesto",
+		"manifest_problem_malformed":      "a linha precisa de um nome de arquivo e uma senha",
+
+		// Ações
+		"completion_action_return":             "Voltar ao Menu Principal",
+		"completion_action_return_desc":        "Voltar ao menu principal de gerenciamento de carteiras",
+		"completion_action_select_files":       "Selecionar Outros Arquivos",
+		"completion_action_select_files_desc":  "Escolher outros arquivos keystore para importar",
+		"completion_action_retry_failed":       "Repetir Importações com Falha (%d)",
+		"completion_action_retry_failed_desc":  "Importar novamente os arquivos que falharam por erros",
+		"completion_action_retry_skipped":      "Repetir Importações Puladas (%d)",
+		"completion_action_retry_skipped_desc": "Importar novamente os arquivos pulados (senha cancelada)",
+		"completion_action_retry_all":          "Repetir Todas com Falha/Puladas (%d)",
+		"completion_action_retry_all_desc":     "Importar novamente todos os arquivos que falharam ou foram pulados",
+		"completion_action_view_errors":        "Ver Detalhes dos Erros (%d)",
+		"completion_action_view_errors_desc":   "Ver informações detalhadas sobre os erros ocorridos",
+
+		// Detalhes dos erros
+		"completion_no_errors":           "Nenhum erro para exibir",
+		"completion_error_details":       "Detalhes do Erro",
+		"completion_error_position":      "Erro %d de %d",
+		"completion_error_file":          "Arquivo: %s",
+		"completion_error_status":        "Situação: %s",
+		"completion_error_type":          "Tipo: %s",
+		"completion_error_message":       "Detalhes do Erro:",
+		"completion_suggestions":         "Ações Sugeridas:",
+		"completion_error_help_navigate": "Use ↑/↓ ou j/k para navegar entre os erros",
+		"completion_error_help_retry":    "Pressione R para repetir este arquivo",
+		"completion_error_help_back":     "Pressione ESC ou Q para voltar ao resumo",
+
+		// Sugestões de recuperação
+		"suggest_skipped":           "O arquivo foi pulado por cancelamento do usuário",
+		"suggest_manual_password":   "Tente novamente digitando a senha",
+		"suggest_verify_password":   "Verifique se a senha está correta",
+		"suggest_check_pwd_file":    "Verifique se existe um arquivo .pwd com a senha correta",
+		"suggest_fix_pwd_file":      "Garanta que o arquivo .pwd possa ser lido e contenha apenas a senha",
+		"suggest_valid_format":      "Verifique se o arquivo está no formato KeyStore V3",
+		"suggest_corrupted":         "Verifique se o arquivo está corrompido",
+		"suggest_permissions":       "Verifique as permissões do arquivo",
+		"suggest_locked":            "Garanta que o arquivo não esteja bloqueado por outro processo",
+		"suggest_reselect":          "Selecione o arquivo novamente; ele pode ter sido movido ou apagado",
+		"suggest_overwrite":         "Outra keystore deste endereço já é gerenciada",
+		"suggest_overwrite_review":  "Importe este arquivo sozinho para revisar as diferenças e confirmar",
+		"suggest_check_details":     "Confira os detalhes do erro acima",
+		"suggest_verify_accessible": "Verifique se o arquivo está acessível e é válido",
+
+		// Solicitação de senha
+		"password_popup_title":          "Senha Necessária",
+		"password_popup_placeholder":    "Digite a senha da keystore...",
+		"password_popup_file":           "Arquivo: %s",
+		"password_popup_attempts":       "Tentativas restantes: %d",
+		"password_popup_max_attempts":   "Número máximo de tentativas atingido",
+		"password_popup_error":          "Erro: %s",
+		"password_popup_help":           "Enter: Confirmar • Esc: Cancelar • Ctrl+S: Pular arquivo",
+		"password_popup_apply_all":      "Usar esta senha em todos os arquivos bloqueados restantes",
+		"password_popup_help_apply_all": "Enter: Confirmar • Tab: Usar em todos • Esc: Cancelar • Ctrl+S: Pular arquivo",
+
+		// Lotes interrompidos
+		"import_resume_banner":  "Importação anterior interrompida: %d de %d arquivos importados, %d restantes.\n   Pressione ctrl+r para retomá-la ou ctrl+x para descartá-la.",
+		"import_resume_nothing": "Nada a retomar: %d arquivos já foram importados ou não existem mais",
+
+		// Verificação de duplicatas antes do lote
+		"import_duplicates_title":     "Já importados",
+		"import_duplicates_summary":   "%d de %d arquivos já foram importados",
+		"import_duplicates_line":      "• %s  %s  (%s)",
+		"import_duplicates_identical": "mesma keystore",
+		"import_duplicates_different": "outra keystore",
+		"import_duplicates_more":      "… e mais %d",
+		"import_duplicates_help":      "s/enter: Pular • o: Sobrescrever • i: Importar mesmo assim • esc: Voltar",
+
+		"completion_error_help_rename":       "Pressione N para repetir com outro nome de carteira",
+		"completion_retry_name":              "Nome da carteira:",
+		"completion_retry_password":          "Senha:",
+		"completion_retry_help":              "enter: Repetir • tab: Próximo campo • esc: Cancelar",
+		"completion_retry_name_required":     "O nome da carteira não pode ficar vazio",
+		"completion_retry_password_required": "A senha não pode ficar vazia",
+		"completion_retry_running":           "Repetindo %s…",
+		"completion_retry_success":           "%s importado como %s",
+		"completion_retry_failed":            "%s falhou novamente: %v",
+
+		"completion_action_export_report":      "Exportar Relatório",
+		"completion_action_export_report_desc": "Gravar o resumo e o resultado de cada arquivo em JSON ou CSV",
+		"completion_help_report":               "Pressione X para exportar um relatório da importação",
+		"completion_report_label":              "Arquivo do relatório:",
+		"completion_report_help":               "enter: Gravar • esc: Cancelar • um nome terminado em .csv grava CSV, senão JSON",
+		"completion_report_written":            "Relatório de %d arquivos gravado em %s",
+		"completion_report_failed":             "Não foi possível gravar o relatório: %v",
+		"import_duplicates_nothing":            "Nada a importar: todos os %d arquivos já foram importados",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		// Resumen de la importación
+		"completion_title_success":  "Importación Completada con Éxito",
+		"completion_title_partial":  "Importación Completada con Problemas",
+		"completion_title_failed":   "Importación Fallida",
+		"completion_title_done":     "Importación Completada",
+		"completion_stats":          "Total: %d | Éxito: %d | Fallidos: %d | Omitidos: %d",
+		"completion_success_rate":   "Tasa de Éxito: %.1f%%",
+		"completion_elapsed":        "Completada en: %v",
+		"completion_elapsed_avg":    " (promedio: %v por archivo)",
+		"completion_issues":         "Problemas Encontrados:",
+		"completion_errors":         "Errores:",
+		"completion_failed":         "Fallido",
+		"completion_skipped":        "Omitido",
+		"completion_more":           "... y %d más",
+		"completion_actions":        "Acciones Disponibles:",
+		"completion_help_navigate":  "Use ↑/↓ o j/k para navegar por las acciones",
+		"completion_help_execute":   "Presione ENTER para ejecutar la acción seleccionada",
+		"completion_help_return":    "Presione ESC o Q para volver al menú principal",
+		"completion_help_errors":    "Presione E para ver los detalles de los errores",
+		"completion_help_done":      "Presione ENTER para volver al menú o R para reintentar las importaciones fallidas",
+		"completion_cancelled":      "Importación Cancelada",
+		"completion_processed":      "Procesados: %d/%d archivos antes de la cancelación",
+		"completion_imported":       "Importadas con éxito: %d billeteras",
+		"completion_help_cancelled": "Presione ENTER para volver al menú",
+
+		// Filas del manifiesto de contraseñas no usadas
+		"completion_manifest":             "Filas del manifiesto de contraseñas no usadas:",
+		"manifest_problem_missing_file":   "%s no existe",
+		"manifest_problem_empty_password": "%s tiene la contraseña vacía",
+		"manifest_problem_duplicate":      "%s aparece más de una vez",
+		"manifest_problem_invalid_path":   "%s está fuera del directorio del manifiesto",
+		"manifest_problem_malformed":      "la fila necesita un nombre de archivo y una contraseña",
+
+		// Acciones
+		"completion_action_return":             "Volver al Menú Principal",
+		"completion_action_return_desc":        "Volver al menú principal de gestión de billeteras",
+		"completion_action_select_files":       "Seleccionar Otros Archivos",
+		"completion_action_select_files_desc":  "Elegir otros archivos keystore para importar",
+		"completion_action_retry_failed":       "Reintentar Importaciones Fallidas (%d)",
+		"completion_action_retry_failed_desc":  "Volver a importar los archivos que fallaron por errores",
+		"completion_action_retry_skipped":      "Reintentar Importaciones Omitidas (%d)",
+		"completion_action_retry_skipped_desc": "Volver a importar los archivos omitidos (contraseña cancelada)",
+		"completion_action_retry_all":          "Reintentar Todos los Fallidos/Omitidos (%d)",
+		"completion_action_retry_all_desc":     "Volver a importar todos los archivos fallidos u omitidos",
+		"completion_action_view_errors":        "Ver Detalles de los Errores (%d)",
+		"completion_action_view_errors_desc":   "Ver información detallada sobre los errores ocurridos",
+
+		// Detalles de los errores
+		"completion_no_errors":           "No hay errores para mostrar",
+		"completion_error_details":       "Detalles del Error",
+		"completion_error_position":      "Error %d de %d",
+		"completion_error_file":          "Archivo: %s",
+		"completion_error_status":        "Estado: %s",
+		"completion_error_type":          "Tipo: %s",
+		"completion_error_message":       "Detalles del Error:",
+		"completion_suggestions":         "Acciones Sugeridas:",
+		"completion_error_help_navigate": "Use ↑/↓ o j/k para navegar entre los errores",
+		"completion_error_help_retry":    "Presione R para reintentar este archivo",
+		"completion_error_help_back":     "Presione ESC o Q para volver al resumen",
+
+		// Sugerencias de recuperación
+		"suggest_skipped":           "El archivo se omitió por cancelación del usuario",
+		"suggest_manual_password":   "Reintente escribiendo la contraseña",
+		"suggest_verify_password":   "Verifique que la contraseña sea correcta",
+		"suggest_check_pwd_file":    "Verifique si existe un archivo .pwd con la contraseña correcta",
+		"suggest_fix_pwd_file":      "Asegúrese de que el archivo .pwd sea legible y contenga solo la contraseña",
+		"suggest_valid_format":      "Verifique que el archivo tenga el formato KeyStore V3",
+		"suggest_corrupted":         "Verifique si el archivo está dañado",
+		"suggest_permissions":       "Verifique los permisos del archivo",
+		"suggest_locked":            "Asegúrese de que el archivo no esté bloqueado por otro proceso",
+		"suggest_reselect":          "Seleccione el archivo otra vez; puede haber sido movido o eliminado",
+		"suggest_overwrite":         "Ya se gestiona otro keystore de esta dirección",
+		"suggest_overwrite_review":  "Importe este archivo por separado para revisar las diferencias y confirmar",
+		"suggest_check_details":     "Revise los detalles del error arriba",
+		"suggest_verify_accessible": "Verifique que el archivo sea accesible y válido",
+
+		// Solicitud de contraseña
+		"password_popup_title":          "Contraseña Requerida",
+		"password_popup_placeholder":    "Ingrese la contraseña del keystore...",
+		"password_popup_file":           "Archivo: %s",
+		"password_popup_attempts":       "Intentos restantes: %d",
+		"password_popup_max_attempts":   "Número máximo de intentos alcanzado",
+		"password_popup_error":          "Error: %s",
+		"password_popup_help":           "Enter: Confirmar • Esc: Cancelar • Ctrl+S: Omitir archivo",
+		"password_popup_apply_all":      "Usar esta contraseña en todos los archivos bloqueados restantes",
+		"password_popup_help_apply_all": "Enter: Confirmar • Tab: Usar en todos • Esc: Cancelar • Ctrl+S: Omitir archivo",
+
+		// Lotes interrumpidos
+		"import_resume_banner":  "Importación anterior interrumpida: %d de %d archivos importados, quedan %d.\n   Presione ctrl+r para reanudarla o ctrl+x para descartarla.",
+		"import_resume_nothing": "Nada que reanudar: %d archivos ya fueron importados o ya no existen",
+
+		// Verificación de duplicados antes del lote
+		"import_duplicates_title":     "Ya importados",
+		"import_duplicates_summary":   "%d de %d archivos ya fueron importados",
+		"import_duplicates_line":      "• %s  %s  (%s)",
+		"import_duplicates_identical": "misma keystore",
+		"import_duplicates_different": "otra keystore",
+		"import_duplicates_more":      "… y %d más",
+		"import_duplicates_help":      "s/enter: Omitir • o: Sobrescribir • i: Importar de todos modos • esc: Volver",
+
+		"completion_error_help_rename":       "Presione N para reintentar con otro nombre de cartera",
+		"completion_retry_name":              "Nombre de la cartera:",
+		"completion_retry_password":          "Contraseña:",
+		"completion_retry_help":              "enter: Reintentar • tab: Siguiente campo • esc: Cancelar",
+		"completion_retry_name_required":     "El nombre de la cartera no puede estar vacío",
+		"completion_retry_password_required": "La contraseña no puede estar vacía",
+		"completion_retry_running":           "Reintentando %s…",
+		"completion_retry_success":           "%s importado como %s",
+		"completion_retry_failed":            "%s falló de nuevo: %v",
+
+		"completion_action_export_report":      "Exportar Informe",
+		"completion_action_export_report_desc": "Guardar el resumen y el resultado de cada archivo en JSON o CSV",
+		"completion_help_report":               "Presione X para exportar un informe de la importación",
+		"completion_report_label":              "Archivo del informe:",
+		"completion_report_help":               "enter: Guardar • esc: Cancelar • un nombre terminado en .csv guarda CSV, si no JSON",
+		"completion_report_written":            "Informe de %d archivos guardado en %s",
+		"completion_report_failed":             "No se pudo guardar el informe: %v",
+		"import_duplicates_nothing":            "Nada que importar: los %d archivos ya fueron importados",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package localization
+
+// AddInheritanceMessages adds backup reminder and inheritance plan messages to the Labels map
+func AddInheritanceMessages() {
+	// English messages
+	english := map[string]string{
+		"inheritance":                          "Backup & Inheritance",
+		"inheritance_desc":                     "Backup reminders and inheritance package",
+		"inheritance_title":                    "Backup & Inheritance Plan",
+		"inheritance_progress":                 "Plan progress: %d of %d steps complete",
+		"inheritance_step_backups_verified":    "Backups verified",
+		"inheritance_step_shares_distributed":  "SLIP-39 shares distributed",
+		"inheritance_step_package_generated":   "Inheritance package generated",
+		"inheritance_step_instructions_stored": "Instructions stored with executor",
+		"inheritance_never_verified":           "never verified",
+		"inheritance_last_verified":            "last verified %s",
+		"inheritance_next_reminder":            "next reminder %s",
+		"inheritance_delivered_count":          "%d of %d delivered",
+		"inheritance_threshold":                "Share holders (recovery needs %d of %d shares):",
+		"inheritance_no_holders":               "  No share holders yet. Press 'a' to add one.",
+		"inheritance_add_holder":               "Add share holder",
+		"inheritance_holder_name":              "Name",
+		"inheritance_holder_contact":           "Contact or location (optional)",
+		"inheritance_generate":                 "Generate inheritance package",
+		"inheritance_output_placeholder":       "Output directory (empty for %s)",
+		"inheritance_verified":                 "Backups marked as verified.",
+		"inheritance_package_created":          "Inheritance package created in %s",
+		"inheritance_help":                     "v: backups verified • a: add holder • x: remove • space: delivered • +/-: threshold • g: generate package • i: instructions stored • esc: back",
+		"inheritance_form_help":                "tab: next field • enter: confirm • esc: cancel",
+		"backup_reminder_due":                  "It's time to verify your backups. Open Backup & Inheritance and press 'v' once checked.",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"inheritance":                          "Backup e Herança",
+		"inheritance_desc":                     "Lembretes de backup e pacote de herança",
+		"inheritance_title":                    "Plano de Backup e Herança",
+		"inheritance_progress":                 "Progresso do plano: %d de %d etapas concluídas",
+		"inheritance_step_backups_verified":    "Backups verificados",
+		"inheritance_step_shares_distributed":  "Partes SLIP-39 distribuídas",
+		"inheritance_step_package_generated":   "Pacote de herança gerado",
+		"inheritance_step_instructions_stored": "Instruções entregues ao executor",
+		"inheritance_never_verified":           "nunca verificado",
+		"inheritance_last_verified":            "última verificação em %s",
+		"inheritance_next_reminder":            "próximo lembrete em %s",
+		"inheritance_delivered_count":          "%d de %d entregues",
+		"inheritance_threshold":                "Guardiões das partes (a recuperação exige %d de %d partes):",
+		"inheritance_no_holders":               "  Nenhum guardião ainda. Pressione 'a' para adicionar.",
+		"inheritance_add_holder":               "Adicionar guardião",
+		"inheritance_holder_name":              "Nome",
+		"inheritance_holder_contact":           "Contato ou local (opcional)",
+		"inheritance_generate":                 "Gerar pacote de herança",
+		"inheritance_output_placeholder":       "Diretório de saída (vazio para %s)",
+		"inheritance_verified":                 "Backups marcados como verificados.",
+		"inheritance_package_created":          "Pacote de herança criado em %s",
+		"inheritance_help":                     "v: backups verificados • a: adicionar guardião • x: remover • espaço: entregue • +/-: limite • g: gerar pacote • i: instruções entregues • esc: voltar",
+		"inheritance_form_help":                "tab: próximo campo • enter: confirmar • esc: cancelar",
+		"backup_reminder_due":                  "É hora de verificar seus backups. Abra Backup e Herança e pressione 'v' após conferir.",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"inheritance":                          "Respaldo y Herencia",
+		"inheritance_desc":                     "Recordatorios de respaldo y paquete de herencia",
+		"inheritance_title":                    "Plan de Respaldo y Herencia",
+		"inheritance_progress":                 "Progreso del plan: %d de %d pasos completados",
+		"inheritance_step_backups_verified":    "Respaldos verificados",
+		"inheritance_step_shares_distributed":  "Partes SLIP-39 distribuidas",
+		"inheritance_step_package_generated":   "Paquete de herencia generado",
+		"inheritance_step_instructions_stored": "Instrucciones entregadas al albacea",
+		"inheritance_never_verified":           "nunca verificado",
+		"inheritance_last_verified":            "última verificación el %s",
+		"inheritance_next_reminder":            "próximo recordatorio el %s",
+		"inheritance_delivered_count":          "%d de %d entregadas",
+		"inheritance_threshold":                "Custodios de las partes (la recuperación requiere %d de %d partes):",
+		"inheritance_no_holders":               "  Aún no hay custodios. Presiona 'a' para agregar uno.",
+		"inheritance_add_holder":               "Agregar custodio",
+		"inheritance_holder_name":              "Nombre",
+		"inheritance_holder_contact":           "Contacto o ubicación (opcional)",
+		"inheritance_generate":                 "Generar paquete de herencia",
+		"inheritance_output_placeholder":       "Directorio de salida (vacío para %s)",
+		"inheritance_verified":                 "Respaldos marcados como verificados.",
+		"inheritance_package_created":          "Paquete de herencia creado en %s",
+		"inheritance_help":                     "v: respaldos verificados • a: agregar custodio • x: eliminar • espacio: entregada • +/-: umbral • g: generar paquete • i: instrucciones entregadas • esc: volver",
+		"inheritance_form_help":                "tab: siguiente campo • enter: confirmar • esc: cancelar",
+		"backup_reminder_due":                  "Es hora de verificar tus respaldos. Abre Respaldo y Herencia y presiona 'v' tras comprobarlos.",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package localization
+
+// AddIntegrityMessages adds wallet integrity check messages to the Labels map
+func AddIntegrityMessages() {
+	// English messages
+	english := map[string]string{
+		"integrity_title":             "Wallet Integrity Warning",
+		"integrity_desc":              "The key of wallet \"%s\" (%s) does not match what was stored for it:",
+		"integrity_advice":            "The keystore file or the wallet database may have been tampered with or corrupted. Do not use this wallet until you know why. Quarantine moves its files out of the wallet list without deleting anything.",
+		"integrity_quarantined":       "Wallet quarantined in %s",
+		"integrity_quarantine_failed": "Quarantine failed: %v",
+		"integrity_help":              "x: quarantine • esc: back to the list",
+		"integrity_help_back":         "esc: back to the list",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"integrity_title":             "Alerta de Integridade da Carteira",
+		"integrity_desc":              "A chave da carteira \"%s\" (%s) não confere com o que foi salvo para ela:",
+		"integrity_advice":            "O arquivo keystore ou o banco de carteiras pode ter sido adulterado ou corrompido. Não use esta carteira até entender o motivo. A quarentena retira seus arquivos da lista de carteiras sem apagar nada.",
+		"integrity_quarantined":       "Carteira colocada em quarentena em %s",
+		"integrity_quarantine_failed": "Falha na quarentena: %v",
+		"integrity_help":              "x: quarentena • esc: voltar à lista",
+		"integrity_help_back":         "esc: voltar à lista",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"integrity_title":             "Alerta de Integridad de la Cartera",
+		"integrity_desc":              "La clave de la cartera \"%s\" (%s) no coincide con lo que se guardó para ella:",
+		"integrity_advice":            "El archivo keystore o la base de carteras puede haber sido alterado o dañado. No use esta cartera hasta saber por qué. La cuarentena retira sus archivos de la lista de carteras sin borrar nada.",
+		"integrity_quarantined":       "Cartera puesta en cuarentena en %s",
+		"integrity_quarantine_failed": "Falló la cuarentena: %v",
+		"integrity_help":              "x: cuarentena • esc: volver a la lista",
+		"integrity_help_back":         "esc: volver a la lista",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package localization
+
+// AddJobMessages adds background job messages to the Labels map
+func AddJobMessages() {
+	// English messages
+	english := map[string]string{
+		"jobs":                      "Jobs",
+		"jobs_desc":                 "Track and cancel background tasks",
+		"jobs_title":                "Background Jobs",
+		"jobs_empty":                "No background jobs yet.",
+		"job_column_name":           "Job",
+		"job_column_status":         "Status",
+		"job_column_progress":       "Progress",
+		"job_column_message":        "Details",
+		"job_status_running":        "Running",
+		"job_status_succeeded":      "Done",
+		"job_status_failed":         "Failed",
+		"job_status_cancelled":      "Cancelled",
+		"job_kind_batch_import":     "Batch import",
+		"job_kind_balance_refresh":  "Balance refresh",
+		"job_kind_backup":           "Backup",
+		"job_kind_history_sync":     "History sync",
+		"job_kind_balance_snapshot": "Balance snapshot",
+		"job_kind_integrity_check":  "Integrity check",
+		"job_kind_batch_export":     "Batch export",
+		"job_conflict":              "Another job of this kind is already running. Check the Jobs screen.",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"jobs":                      "Tarefas",
+		"jobs_desc":                 "Acompanhar e cancelar tarefas em segundo plano",
+		"jobs_title":                "Tarefas em Segundo Plano",
+		"jobs_empty":                "Nenhuma tarefa em segundo plano ainda.",
+		"job_column_name":           "Tarefa",
+		"job_column_status":         "Status",
+		"job_column_progress":       "Progresso",
+		"job_column_message":        "Detalhes",
+		"job_status_running":        "Executando",
+		"job_status_succeeded":      "Concluída",
+		"job_status_failed":         "Falhou",
+		"job_status_cancelled":      "Cancelada",
+		"job_kind_batch_import":     "Importação em lote",
+		"job_kind_balance_refresh":  "Atualização de saldos",
+		"job_kind_backup":           "Backup",
+		"job_kind_history_sync":     "Sincronização de histórico",
+		"job_kind_balance_snapshot": "Registro de saldos",
+		"job_kind_integrity_check":  "Verificação de integridade",
+		"job_kind_batch_export":     "Exportação em lote",
+		"job_conflict":              "Outra tarefa deste tipo já está em execução. Verifique a tela de Tarefas.",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"jobs":                      "Tareas",
+		"jobs_desc":                 "Seguir y cancelar tareas en segundo plano",
+		"jobs_title":                "Tareas en Segundo Plano",
+		"jobs_empty":                "Aún no hay tareas en segundo plano.",
+		"job_column_name":           "Tarea",
+		"job_column_status":         "Estado",
+		"job_column_progress":       "Progreso",
+		"job_column_message":        "Detalles",
+		"job_status_running":        "En ejecución",
+		"job_status_succeeded":      "Terminada",
+		"job_status_failed":         "Fallida",
+		"job_status_cancelled":      "Cancelada",
+		"job_kind_batch_import":     "Importación por lotes",
+		"job_kind_balance_refresh":  "Actualización de saldos",
+		"job_kind_backup":           "Copia de seguridad",
+		"job_kind_history_sync":     "Sincronización de historial",
+		"job_kind_balance_snapshot": "Registro de saldos",
+		"job_kind_integrity_check":  "Verificación de integridad",
+		"job_kind_batch_export":     "Exportación por lotes",
+		"job_conflict":              "Ya hay otra tarea de este tipo en ejecución. Revise la pantalla de Tareas.",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package localization
+
+import "testing"
+
+func TestAddJobMessages(t *testing.T) {
+	testCases := []struct {
+		name     string
+		lang     string
+		key      string
+		expected string
+	}{
+		{"EN jobs", "en", "jobs", "Jobs"},
+		{"PT jobs", "pt", "jobs", "Tarefas"},
+		{"ES jobs", "es", "jobs", "Tareas"},
+		{"EN running", "en", "job_status_running", "Running"},
+		{"PT cancelled", "pt", "job_status_cancelled", "Cancelada"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			SetCurrentLanguage(tc.lang)
+			Labels = make(map[string]string)
+			AddJobMessages()
+			if Labels[tc.key] != tc.expected {
+				t.Fatalf("expected %q, got %q for key %q in lang %q", tc.expected, Labels[tc.key], tc.key, tc.lang)
+			}
+		})
+	}
+	SetCurrentLanguage("en")
+}
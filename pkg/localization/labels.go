@@ -18,6 +18,7 @@ func SetLanguage(lang string, appDir string) error {
 
 	// Create a minimal config for InitLocalization
 	cfg := &config.Config{
+		AppDir:    appDir,
 		Language:  lang,
 		LocaleDir: appDir + "/locale",
 	}
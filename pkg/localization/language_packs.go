@@ -0,0 +1,172 @@
+package localization
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// languageNameKey is the message of a language pack with the name of its
+// language, shown in the language menu instead of the code
+const languageNameKey = "language_name"
+
+// languagePack is a translation read from the locales directory
+type languagePack struct {
+	tag      language.Tag
+	path     string
+	messages map[string]*i18n.Message
+}
+
+var (
+	// packs holds the language packs loaded by InitLocalization, by language code
+	packs map[string]*languagePack
+	// packErrors holds the files of the locales directory that could not be loaded
+	packErrors []PackReport
+	// labelKeys are the keys of every built-in message, the ones a pack translates
+	labelKeys []string
+)
+
+// PackReport tells how complete a language pack is. Keys it does not
+// translate are shown in English.
+type PackReport struct {
+	Language   string   `json:"language"`
+	Name       string   `json:"name"`
+	Path       string   `json:"path"`
+	Translated int      `json:"translated"`
+	Total      int      `json:"total"`
+	Missing    []string `json:"missing,omitempty"`
+	Unknown    []string `json:"unknown,omitempty"` // Keys no message uses, misspelt or removed
+	Err        error    `json:"-"`                 // Why the file could not be loaded
+}
+
+// Percent is the share of the messages the pack translates
+func (r PackReport) Percent() int {
+	if r.Total == 0 {
+		return 0
+	}
+	return r.Translated * 100 / r.Total
+}
+
+// LanguagePacksDir is where language packs are read from: one TOML file per
+// language named after its code, such as de.toml or pt-BR.toml
+func LanguagePacksDir(appDir string) string {
+	return filepath.Join(appDir, "config", "locales")
+}
+
+// loadLanguagePacks reads the packs of dir into the bundle. A pack that
+// cannot be read is left out and reported, so the application still starts.
+func loadLanguagePacks(dir string) {
+	packs = make(map[string]*languagePack)
+	packErrors = nil
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.toml"))
+	if err != nil {
+		return
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		pack, err := loadLanguagePack(file)
+		if err != nil {
+			packErrors = append(packErrors, PackReport{Language: packLanguage(file), Path: file, Err: err})
+			continue
+		}
+		packs[pack.tag.String()] = pack
+	}
+}
+
+// loadLanguagePack parses a pack and adds its messages to the bundle
+func loadLanguagePack(path string) (*languagePack, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := i18n.ParseMessageFileBytes(data, path, map[string]i18n.UnmarshalFunc{"toml": toml.Unmarshal})
+	if err != nil {
+		return nil, err
+	}
+	if file.Tag == language.Und {
+		return nil, fmt.Errorf("the file name is not a language code")
+	}
+	if err := bundle.AddMessages(file.Tag, file.Messages...); err != nil {
+		return nil, err
+	}
+
+	pack := &languagePack{tag: file.Tag, path: path, messages: make(map[string]*i18n.Message, len(file.Messages))}
+	for _, msg := range file.Messages {
+		pack.messages[msg.ID] = msg
+	}
+	return pack, nil
+}
+
+// packLanguage is the language code in the name of a pack file
+func packLanguage(path string) string {
+	name := filepath.Base(path)
+	return name[:len(name)-len(filepath.Ext(name))]
+}
+
+// currentPack returns the pack of the current language, if one was loaded
+func currentPack() *languagePack {
+	tag, err := language.Parse(GetCurrentLanguage())
+	if err != nil {
+		return nil
+	}
+	return packs[tag.String()]
+}
+
+// applyLanguagePack replaces the labels translated by the pack of the current
+// language. It runs after the built-in messages, so the keys the pack leaves
+// out keep the English text.
+func applyLanguagePack() {
+	labelKeys = make([]string, 0, len(Labels)+len(pluralMessageIDs()))
+	for key := range Labels {
+		labelKeys = append(labelKeys, key)
+	}
+	labelKeys = append(labelKeys, pluralMessageIDs()...)
+	sort.Strings(labelKeys)
+
+	pack := currentPack()
+	if pack == nil {
+		return
+	}
+	for id, msg := range pack.messages {
+		if _, ok := Labels[id]; ok && msg.Other != "" {
+			Labels[id] = msg.Other
+		}
+	}
+}
+
+// LanguagePackReports tells, for every file of the locales directory, how
+// much of the application it translates or why it could not be loaded
+func LanguagePackReports() []PackReport {
+	known := make(map[string]bool, len(labelKeys))
+	for _, key := range labelKeys {
+		known[key] = true
+	}
+
+	reports := slices.Clone(packErrors)
+	for _, pack := range packs {
+		r := PackReport{Language: pack.tag.String(), Name: GetLanguageName(pack.tag.String()), Path: pack.path, Total: len(labelKeys)}
+		for _, key := range labelKeys {
+			if msg, ok := pack.messages[key]; ok && msg.Other != "" {
+				r.Translated++
+			} else {
+				r.Missing = append(r.Missing, key)
+			}
+		}
+		for id := range pack.messages {
+			if !known[id] && id != languageNameKey {
+				r.Unknown = append(r.Unknown, id)
+			}
+		}
+		sort.Strings(r.Unknown)
+		reports = append(reports, r)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Path < reports[j].Path })
+	return reports
+}
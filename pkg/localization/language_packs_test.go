@@ -0,0 +1,107 @@
+package localization
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blocowallet/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePack writes a language pack under the locales directory of appDir
+func writePack(t *testing.T, appDir, name, content string) {
+	t.Helper()
+	dir := LanguagePacksDir(appDir)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+}
+
+func initPacks(t *testing.T, appDir, lang string) {
+	t.Helper()
+	require.NoError(t, InitLocalization(&config.Config{AppDir: appDir, Language: lang, LocaleDir: filepath.Join(appDir, "locale")}))
+	t.Cleanup(func() { _ = SetLanguage("en", t.TempDir()) })
+}
+
+func TestLanguagePack_TranslatesAndFallsBackToEnglish(t *testing.T) {
+	appDir := t.TempDir()
+	writePack(t, appDir, "de.toml", `
+language_name = "Deutsch"
+jobs = "Aufgaben"
+not_a_key = "Tippfehler"
+
+[wallet_count]
+one = "{{.Count}} Wallet"
+other = "{{.Count}} Wallets"
+`)
+	initPacks(t, appDir, "de")
+
+	assert.Equal(t, "Aufgaben", Labels["jobs"])
+	assert.Equal(t, "Running", Labels["job_status_running"], "missing keys stay in English")
+	assert.NotContains(t, Labels, "not_a_key")
+
+	assert.Equal(t, "1 Wallet", Plural("wallet_count", 1))
+	assert.Equal(t, "3 Wallets", Plural("wallet_count", 3))
+	assert.Equal(t, "2 keystores", Plural("keystore_count", 2))
+
+	assert.Contains(t, GetAvailableLanguages(filepath.Join(appDir, "locale")), "de")
+	assert.Equal(t, "Deutsch", GetLanguageName("de"))
+
+	reports := LanguagePackReports()
+	require.Len(t, reports, 1)
+	r := reports[0]
+	assert.NoError(t, r.Err)
+	assert.Equal(t, "de", r.Language)
+	assert.Equal(t, 2, r.Translated)
+	assert.Equal(t, r.Total-2, len(r.Missing))
+	assert.Contains(t, r.Missing, "job_status_running")
+	assert.NotContains(t, r.Missing, "wallet_count")
+	assert.Equal(t, []string{"not_a_key"}, r.Unknown)
+}
+
+func TestLanguagePack_PluralRulesOfTheLanguage(t *testing.T) {
+	appDir := t.TempDir()
+	// Japanese has a single plural form
+	writePack(t, appDir, "ja.toml", `
+[wallet_count]
+other = "ウォレット{{.Count}}個"
+`)
+	initPacks(t, appDir, "ja")
+
+	assert.Equal(t, "ウォレット1個", Plural("wallet_count", 1))
+	assert.Equal(t, "ウォレット5個", Plural("wallet_count", 5))
+	assert.Equal(t, "Japanese", GetLanguageName("ja"))
+}
+
+func TestPlural_BuiltInLanguages(t *testing.T) {
+	require.NoError(t, SetLanguage("pt", t.TempDir()))
+	t.Cleanup(func() { _ = SetLanguage("en", t.TempDir()) })
+
+	assert.Equal(t, "1 carteira selecionada", Plural("wallets_selected", 1))
+	assert.Equal(t, "4 carteiras selecionadas", Plural("wallets_selected", 4))
+	assert.Equal(t, "no_such_key", Plural("no_such_key", 2))
+}
+
+func TestLanguagePack_BrokenPacksAreReported(t *testing.T) {
+	appDir := t.TempDir()
+	writePack(t, appDir, "fr.toml", `jobs = "Tâches`)
+	writePack(t, appDir, "not a language.toml", `jobs = "x"`)
+	writePack(t, appDir, "zh.toml", `jobs = "任务"`)
+	initPacks(t, appDir, "fr")
+
+	assert.Equal(t, "Jobs", Labels["jobs"], "an unreadable pack leaves English")
+
+	reports := LanguagePackReports()
+	require.Len(t, reports, 3)
+	assert.Error(t, reports[0].Err)
+	assert.Equal(t, "fr", reports[0].Language)
+	assert.Error(t, reports[1].Err)
+	assert.NoError(t, reports[2].Err)
+	assert.Equal(t, 1, reports[2].Translated)
+
+	languages := GetAvailableLanguages(filepath.Join(appDir, "locale"))
+	assert.Contains(t, languages, "zh")
+	assert.NotContains(t, languages, "fr")
+}
@@ -34,9 +34,22 @@ func InitLocalization(cfg *config.Config) error {
 	if err := loadMessageFiles(cfg.LocaleDir); err != nil {
 		return err
 	}
+	if err := addPluralMessages(); err != nil {
+		return err
+	}
+
+	// Language packs come last, so they also replace the messages above
+	packs = nil
+	packErrors = nil
+	if cfg.AppDir != "" {
+		loadLanguagePacks(LanguagePacksDir(cfg.AppDir))
+	}
 
 	// Create a localizer with the configured language
 	localizer = i18n.NewLocalizer(bundle, cfg.Language)
+	if cfg.Language != "" {
+		SetCurrentLanguage(cfg.Language)
+	}
 
 	// Populate the Labels map for backward compatibility
 	if err := populateLabelsMap(); err != nil {
@@ -203,7 +216,6 @@ func getEnglishMessages() map[string]string {
 		"public_key":                 "Public Key:",
 		"private_key":                "Private Key:",
 		"mnemonic_phrase_label":      "Mnemonic Phrase:",
-		"press_esc":                  "Press ESC to return to the wallet list.",
 		"main_menu_title":            "Main Menu",
 		"create_new_wallet":          "Create New",
 		"create_new_wallet_desc":     "Generate a new Ethereum wallet",
@@ -229,12 +241,13 @@ func getEnglishMessages() map[string]string {
 		"back_to_menu":               "Main Menu",
 		"back_to_menu_desc":          "Return to the main menu",
 		"private_key_title":          "Import Wallet via Private Key",
-		"enter_private_key":          "Enter the private key (with or without 0x prefix):",
+		"enter_private_key":          "Enter the private key (hex with or without 0x, base64 or WIF):",
 		"enter_mnemonic":             "Enter your 12-word mnemonic phrase:",
 		"invalid_mnemonic":           "Invalid mnemonic phrase. It must be 12 words separated by spaces.",
 		"wallet_created":             "Wallet created successfully!",
 		"wallet_imported":            "Wallet imported successfully!",
 		"wallet_deleted":             "Wallet deleted successfully.",
+		"wallet_changed_elsewhere":   "This wallet was changed in another session. The list was refreshed; review it and try again.",
 		"delete_wallet_confirmation": "Are you sure you want to delete this wallet?",
 		"yes":                        "Yes",
 		"no":                         "No",
@@ -281,7 +294,6 @@ func getPortugueseMessages() map[string]string {
 		"public_key":                 "Chave Pública:",
 		"private_key":                "Chave Privada:",
 		"mnemonic_phrase_label":      "Frase Mnemônica:",
-		"press_esc":                  "Pressione ESC para voltar à lista de carteiras.",
 		"main_menu_title":            "Menu Principal",
 		"create_new_wallet":          "Criar Nova",
 		"create_new_wallet_desc":     "Gerar uma nova carteira Ethereum",
@@ -307,12 +319,13 @@ func getPortugueseMessages() map[string]string {
 		"back_to_menu":               "Voltar",
 		"back_to_menu_desc":          "Retornar ao menu principal",
 		"private_key_title":          "Importar Carteira via Chave Privada",
-		"enter_private_key":          "Digite a chave privada (com ou sem prefixo 0x):",
+		"enter_private_key":          "Digite a chave privada (hex com ou sem 0x, base64 ou WIF):",
 		"enter_mnemonic":             "Digite sua frase mnemônica de 12 palavras:",
 		"invalid_mnemonic":           "Frase mnemônica inválida. Deve ter 12 palavras separadas por espaços.",
 		"wallet_created":             "Carteira criada com sucesso!",
 		"wallet_imported":            "Carteira importada com sucesso!",
 		"wallet_deleted":             "Carteira excluída com sucesso.",
+		"wallet_changed_elsewhere":   "Esta carteira foi alterada em outra sessão. A lista foi atualizada; revise e tente novamente.",
 		"delete_wallet_confirmation": "Tem certeza que deseja excluir esta carteira?",
 		"yes":                        "Sim",
 		"no":                         "Não",
@@ -359,7 +372,6 @@ func getSpanishMessages() map[string]string {
 		"public_key":                 "Clave Pública:",
 		"private_key":                "Clave Privada:",
 		"mnemonic_phrase_label":      "Frase Mnemónica:",
-		"press_esc":                  "Presione ESC para volver a la lista de carteras.",
 		"main_menu_title":            "Menú Principal",
 		"create_new_wallet":          "Crear Nueva",
 		"create_new_wallet_desc":     "Generar una nueva cartera Ethereum",
@@ -385,12 +397,13 @@ func getSpanishMessages() map[string]string {
 		"back_to_menu":               "Volver",
 		"back_to_menu_desc":          "Regresar al menú principal",
 		"private_key_title":          "Importar Cartera vía Clave Privada",
-		"enter_private_key":          "Ingrese la clave privada (con o sin prefijo 0x):",
+		"enter_private_key":          "Ingrese la clave privada (hex con o sin 0x, base64 o WIF):",
 		"enter_mnemonic":             "Ingrese su frase mnemónica de 12 palabras:",
 		"invalid_mnemonic":           "Frase mnemotécnica inválida. Debe tener 12 palabras separadas por espacios.",
 		"wallet_created":             "¡Cartera creada exitosamente!",
 		"wallet_imported":            "¡Cartera importada exitosamente!",
 		"wallet_deleted":             "Cartera eliminada exitosamente.",
+		"wallet_changed_elsewhere":   "Esta cartera fue modificada en otra sesión. La lista se actualizó; revísela e intente de nuevo.",
 		"delete_wallet_confirmation": "¿Está seguro de que desea eliminar esta cartera?",
 		"yes":                        "Sí",
 		"no":                         "No",
@@ -439,8 +452,94 @@ func populateLabelsMap() error {
 	AddKeystoreValidationMessages()
 	// Add wallet import messages
 	AddWalletImportMessages()
+	// Add batch import error categories and recovery hints
+	AddEnhancedImportMessages()
 	// Add password file messages
 	AddPasswordFileMessages()
+	// Add batch import completion and password prompt messages
+	AddImportCompletionMessages()
+	// Add keystore JSON paste messages
+	AddPasteImportMessages()
+	// Add background job messages
+	AddJobMessages()
+	// Add session summary messages
+	AddSessionMessages()
+	// Add clipboard copy messages
+	AddClipboardMessages()
+	// Add file permission warning messages
+	AddSecurityMessages()
+	// Add encrypted export messages
+	AddExportMessages()
+	// Add backup reminder and inheritance plan messages
+	AddInheritanceMessages()
+	// Add compliance mode messages
+	AddComplianceMessages()
+	// Add metadata sync messages
+	AddSyncMessages()
+	// Add encrypted backup messages
+	AddBackupMessages()
+	// Add master password messages
+	AddMasterPasswordMessages()
+	// Add wallet integrity check messages
+	AddIntegrityMessages()
+	// Add keystore overwrite confirmation messages
+	AddOverwriteMessages()
+	// Add wallet notes and search messages
+	AddNotesMessages()
+	// Add batch wallet creation messages
+	AddBatchCreateMessages()
+	// Add child wallet messages
+	AddChildWalletMessages()
+	// Add sign-only transaction messages
+	AddSignTxMessages()
+	// Add raw transaction broadcast messages
+	AddBroadcastMessages()
+	// Add transaction tracker messages
+	AddPendingTxMessages()
+	// Add per-wallet RPC endpoint messages
+	AddRPCOverrideMessages()
+	// Add Safe owners and watch-only wallet messages
+	AddSafeMessages()
+	// Add balance and allowance preview messages
+	AddTxPreviewMessages()
+	// Add decoded calldata messages
+	AddCalldataMessages()
+	// Add mnemonic grid and reveal messages
+	AddMnemonicMessages()
+	// Add send funds messages
+	AddSendMessages()
+	// Add per-network balance messages
+	AddBalanceMessages()
+	// Add About screen messages
+	AddAboutMessages()
+	// Add ERC-20 token messages
+	AddTokenMessages()
+	// Add key derivation heartbeat messages
+	AddOperationMessages()
+	// Add WalletConnect messages
+	AddWalletConnectMessages()
+	// Add "What's new" screen messages
+	AddWhatsNewMessages()
+	// Add local fork test messages
+	AddForkMessages()
+	// Add batch send messages
+	AddBatchSendMessages()
+	AddBatchExportMessages()
+	AddWalletBatchMessages()
+	AddNetworkImportMessages()
+	AddAddressMessages()
+	AddMetamaskMessages()
+	AddPrivateKeyMessages()
+	AddLogMessages()
+	AddStoreCheckMessages()
+	AddConfigEditorMessages()
+	AddProfileMessages()
+	AddThemeMessages()
+	AddPaletteMessages()
+	AddHelpMessages()
+
+	// Translations of a language pack replace the built-in ones
+	applyLanguagePack()
 
 	return nil
 }
@@ -492,7 +591,8 @@ func Get(key string) string {
 	return msg
 }
 
-// GetAvailableLanguages returns a list of available languages based on the locale files
+// GetAvailableLanguages returns a list of available languages based on the
+// locale files and the language packs that were loaded
 func GetAvailableLanguages(localeDir string) []string {
 	// Default languages that should always be available
 	defaultLanguages := []string{"en", "pt", "es"}
@@ -503,6 +603,10 @@ func GetAvailableLanguages(localeDir string) []string {
 		availableLanguages[lang] = true
 	}
 
+	for lang := range packs {
+		availableLanguages[lang] = true
+	}
+
 	// Get all .toml files in the locale directory
 	files, err := filepath.Glob(filepath.Join(localeDir, "*.toml"))
 	if err != nil {
@@ -530,8 +634,14 @@ func GetAvailableLanguages(localeDir string) []string {
 	return result
 }
 
-// GetLanguageName returns the full name of a language based on its code
+// GetLanguageName returns the full name of a language based on its code; a
+// language pack may name its language with language_name
 func GetLanguageName(code string) string {
+	if pack, ok := packs[code]; ok {
+		if msg, ok := pack.messages[languageNameKey]; ok && msg.Other != "" {
+			return msg.Other
+		}
+	}
 	switch code {
 	case "en":
 		return "English"
@@ -539,6 +649,14 @@ func GetLanguageName(code string) string {
 		return "Portuguese"
 	case "es":
 		return "Spanish"
+	case "de":
+		return "German"
+	case "fr":
+		return "French"
+	case "zh":
+		return "Chinese"
+	case "ja":
+		return "Japanese"
 	default:
 		return code
 	}
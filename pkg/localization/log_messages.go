@@ -0,0 +1,65 @@
+package localization
+
+// AddLogMessages adds the messages of the log viewer to the Labels map
+func AddLogMessages() {
+	// English messages
+	english := map[string]string{
+		"logs":             "Logs",
+		"logs_menu_desc":   "Follow the application log, filtered by level",
+		"logs_title":       "Logs",
+		"logs_location":    "Files: %s",
+		"logs_level":       "Minimum level: %s",
+		"logs_empty":       "Nothing logged at this level yet.",
+		"logs_position":    "Entries %d-%d of %d",
+		"logs_read_failed": "Cannot read the logs: %v",
+		"logs_no_config":   "the configuration could not be loaded",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"logs":             "Logs",
+		"logs_menu_desc":   "Acompanhar o log da aplicação, filtrado por nível",
+		"logs_title":       "Logs",
+		"logs_location":    "Arquivos: %s",
+		"logs_level":       "Nível mínimo: %s",
+		"logs_empty":       "Nada registrado neste nível ainda.",
+		"logs_position":    "Entradas %d-%d de %d",
+		"logs_read_failed": "Não foi possível ler os logs: %v",
+		"logs_no_config":   "não foi possível carregar a configuração",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"logs":             "Registros",
+		"logs_menu_desc":   "Seguir el registro de la aplicación, filtrado por nivel",
+		"logs_title":       "Registros",
+		"logs_location":    "Archivos: %s",
+		"logs_level":       "Nivel mínimo: %s",
+		"logs_empty":       "Aún no hay nada registrado en este nivel.",
+		"logs_position":    "Entradas %d-%d de %d",
+		"logs_read_failed": "No se pueden leer los registros: %v",
+		"logs_no_config":   "no se pudo cargar la configuración",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
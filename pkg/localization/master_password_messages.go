@@ -0,0 +1,105 @@
+package localization
+
+// AddMasterPasswordMessages adds the messages of the application master
+// password: the first-run setup, the unlock screen and the change flow
+func AddMasterPasswordMessages() {
+	// English messages
+	english := map[string]string{
+		"master_password":             "Master Password",
+		"master_password_menu_desc":   "Set or change the password that protects the wallet database",
+		"master_title_setup":          "Set a Master Password",
+		"master_title_unlock":         "Unlock Wallets",
+		"master_title_change":         "Change Master Password",
+		"master_setup_desc":           "A master password encrypts the mnemonics, notes and references stored in the wallet database and is asked every time the application starts. Each wallet still keeps its own password.",
+		"master_unlock_desc":          "The wallet database is protected by a master password. Enter it to continue.",
+		"master_unlock_restored_desc": "The restored database is protected by the master password it had when the backup was written. Enter it to continue.",
+		"master_change_desc":          "Everything protected by the current master password is encrypted again with the new one.",
+		"master_current":              "Current master password:",
+		"master_new":                  "New master password:",
+		"master_password_placeholder": "password",
+		"master_confirm_placeholder":  "repeat the password",
+		"master_required":             "Enter the master password",
+		"master_mismatch":             "The passwords do not match",
+		"master_wrong":                "Wrong master password",
+		"master_working":              "Deriving the master key...",
+		"master_set_success":          "Master password set; the wallet database is encrypted with it",
+		"master_change_success":       "Master password changed",
+		"master_help_setup":           "tab: next field • enter: set • esc: skip (it can be set later in Configuration)",
+		"master_help_unlock":          "enter: unlock • esc: quit",
+		"master_help_change":          "tab: next field • enter: save • esc: back",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"master_password":             "Senha Mestra",
+		"master_password_menu_desc":   "Definir ou trocar a senha que protege o banco de dados das carteiras",
+		"master_title_setup":          "Definir uma Senha Mestra",
+		"master_title_unlock":         "Desbloquear Carteiras",
+		"master_title_change":         "Trocar a Senha Mestra",
+		"master_setup_desc":           "A senha mestra criptografa as mnemônicas, notas e referências gravadas no banco de dados das carteiras e é pedida sempre que o aplicativo é aberto. Cada carteira continua com a sua própria senha.",
+		"master_unlock_desc":          "O banco de dados das carteiras é protegido por uma senha mestra. Informe-a para continuar.",
+		"master_unlock_restored_desc": "O banco restaurado é protegido pela senha mestra que tinha quando o backup foi gravado. Informe-a para continuar.",
+		"master_change_desc":          "Tudo o que é protegido pela senha mestra atual é criptografado de novo com a nova.",
+		"master_current":              "Senha mestra atual:",
+		"master_new":                  "Nova senha mestra:",
+		"master_password_placeholder": "senha",
+		"master_confirm_placeholder":  "repita a senha",
+		"master_required":             "Informe a senha mestra",
+		"master_mismatch":             "As senhas não conferem",
+		"master_wrong":                "Senha mestra incorreta",
+		"master_working":              "Derivando a chave mestra...",
+		"master_set_success":          "Senha mestra definida; o banco de dados das carteiras está criptografado com ela",
+		"master_change_success":       "Senha mestra trocada",
+		"master_help_setup":           "tab: próximo campo • enter: definir • esc: pular (pode ser definida depois em Configuração)",
+		"master_help_unlock":          "enter: desbloquear • esc: sair",
+		"master_help_change":          "tab: próximo campo • enter: salvar • esc: voltar",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"master_password":             "Contraseña Maestra",
+		"master_password_menu_desc":   "Definir o cambiar la contraseña que protege la base de datos de billeteras",
+		"master_title_setup":          "Definir una Contraseña Maestra",
+		"master_title_unlock":         "Desbloquear Billeteras",
+		"master_title_change":         "Cambiar la Contraseña Maestra",
+		"master_setup_desc":           "La contraseña maestra cifra las mnemónicas, notas y referencias guardadas en la base de datos de billeteras y se pide cada vez que se abre la aplicación. Cada billetera conserva su propia contraseña.",
+		"master_unlock_desc":          "La base de datos de billeteras está protegida por una contraseña maestra. Indíquela para continuar.",
+		"master_unlock_restored_desc": "La base restaurada está protegida por la contraseña maestra que tenía cuando se escribió la copia. Indíquela para continuar.",
+		"master_change_desc":          "Todo lo protegido por la contraseña maestra actual se cifra de nuevo con la nueva.",
+		"master_current":              "Contraseña maestra actual:",
+		"master_new":                  "Nueva contraseña maestra:",
+		"master_password_placeholder": "contraseña",
+		"master_confirm_placeholder":  "repita la contraseña",
+		"master_required":             "Indique la contraseña maestra",
+		"master_mismatch":             "Las contraseñas no coinciden",
+		"master_wrong":                "Contraseña maestra incorrecta",
+		"master_working":              "Derivando la clave maestra...",
+		"master_set_success":          "Contraseña maestra definida; la base de datos de billeteras está cifrada con ella",
+		"master_change_success":       "Contraseña maestra cambiada",
+		"master_help_setup":           "tab: siguiente campo • enter: definir • esc: omitir (se puede definir luego en Configuración)",
+		"master_help_unlock":          "enter: desbloquear • esc: salir",
+		"master_help_change":          "tab: siguiente campo • enter: guardar • esc: volver",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package localization
+
+// AddMetamaskMessages adds the messages of importing the accounts of a
+// MetaMask vault or Secret Recovery Phrase export
+func AddMetamaskMessages() {
+	// English messages
+	english := map[string]string{
+		"import_metamask":                "MetaMask",
+		"import_metamask_desc":           "Import every account of a MetaMask vault or Secret Recovery Phrase",
+		"metamask_title":                 "Import from MetaMask",
+		"metamask_desc":                  "Pick a vault backup, the extension's storage log (…/Local Extension Settings/nkbihfbeogaeaoehlefnkodbefgpgknn/*.log) or a text file with the Secret Recovery Phrase. The vault is opened with the extension password; the keys never leave this machine.",
+		"metamask_file":                  "File",
+		"metamask_file_placeholder":      "e.g. vault.json or 000003.log",
+		"metamask_vault_password":        "MetaMask password (not needed for a phrase file)",
+		"metamask_name":                  "Wallet name, numbered per account",
+		"metamask_wallet_password":       "Password of the new wallets (empty: the MetaMask password)",
+		"metamask_path_required":         "Enter the path of the file",
+		"metamask_read_failed":           "Could not read the file: %v",
+		"metamask_name_required":         "Enter a name for the wallets",
+		"metamask_reading":               "Opening the vault…",
+		"metamask_form_help":             "tab: next field • enter: open the file • esc: back",
+		"metamask_found":                 "%d accounts found:",
+		"metamask_source_phrase":         "Secret Recovery Phrase, account %d",
+		"metamask_source_key":            "imported key",
+		"metamask_unsupported":           "Not imported, they hold no key: %s",
+		"metamask_phrase_only":           "A phrase file only names the first account; derive the others as child wallets afterwards.",
+		"metamask_no_accounts":           "The vault only holds accounts without keys",
+		"metamask_review_help":           "enter: import • backspace: change the file • esc: back",
+		"metamask_running":               "Importing %d accounts…",
+		"metamask_running_help":          "esc: stop after the current account",
+		"metamask_cancelling":            "Stopping after the current account…",
+		"metamask_done":                  "Done",
+		"metamask_done_errors":           "Done with errors",
+		"metamask_summary":               "%d imported, %d failed, %d skipped",
+		"metamask_done_help":             "enter: wallet list • esc: back to the import menu",
+		"metamask_imported":              "imported as %s",
+		"metamask_exists":                "already in the wallet list",
+		"metamask_not_processed":         "not processed",
+		"metamask_vault_password_failed": "The MetaMask password is wrong",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"import_metamask":                "MetaMask",
+		"import_metamask_desc":           "Importar todas as contas de um cofre do MetaMask ou de uma Frase de Recuperação Secreta",
+		"metamask_title":                 "Importar do MetaMask",
+		"metamask_desc":                  "Escolha um backup do cofre, o log de armazenamento da extensão (…/Local Extension Settings/nkbihfbeogaeaoehlefnkodbefgpgknn/*.log) ou um arquivo de texto com a Frase de Recuperação Secreta. O cofre é aberto com a senha da extensão; as chaves nunca saem desta máquina.",
+		"metamask_file":                  "Arquivo",
+		"metamask_file_placeholder":      "ex.: vault.json ou 000003.log",
+		"metamask_vault_password":        "Senha do MetaMask (dispensável para um arquivo com a frase)",
+		"metamask_name":                  "Nome das carteiras, numerado por conta",
+		"metamask_wallet_password":       "Senha das novas carteiras (vazia: a senha do MetaMask)",
+		"metamask_path_required":         "Digite o caminho do arquivo",
+		"metamask_read_failed":           "Não foi possível ler o arquivo: %v",
+		"metamask_name_required":         "Digite um nome para as carteiras",
+		"metamask_reading":               "Abrindo o cofre…",
+		"metamask_form_help":             "tab: próximo campo • enter: abrir o arquivo • esc: voltar",
+		"metamask_found":                 "%d contas encontradas:",
+		"metamask_source_phrase":         "Frase de Recuperação Secreta, conta %d",
+		"metamask_source_key":            "chave importada",
+		"metamask_unsupported":           "Não importadas, não guardam chave: %s",
+		"metamask_phrase_only":           "Um arquivo com a frase só indica a primeira conta; derive as outras como carteiras filhas depois.",
+		"metamask_no_accounts":           "O cofre só tem contas sem chave",
+		"metamask_review_help":           "enter: importar • backspace: trocar o arquivo • esc: voltar",
+		"metamask_running":               "Importando %d contas…",
+		"metamask_running_help":          "esc: parar após a conta atual",
+		"metamask_cancelling":            "Parando após a conta atual…",
+		"metamask_done":                  "Concluído",
+		"metamask_done_errors":           "Concluído com erros",
+		"metamask_summary":               "%d importadas, %d com falha, %d ignoradas",
+		"metamask_done_help":             "enter: lista de carteiras • esc: voltar ao menu de importação",
+		"metamask_imported":              "importada como %s",
+		"metamask_exists":                "já está na lista de carteiras",
+		"metamask_not_processed":         "não processada",
+		"metamask_vault_password_failed": "A senha do MetaMask está errada",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"import_metamask":                "MetaMask",
+		"import_metamask_desc":           "Importar todas las cuentas de una bóveda de MetaMask o de una Frase de Recuperación Secreta",
+		"metamask_title":                 "Importar desde MetaMask",
+		"metamask_desc":                  "Elija una copia de la bóveda, el log de almacenamiento de la extensión (…/Local Extension Settings/nkbihfbeogaeaoehlefnkodbefgpgknn/*.log) o un archivo de texto con la Frase de Recuperación Secreta. La bóveda se abre con la contraseña de la extensión; las claves nunca salen de este equipo.",
+		"metamask_file":                  "Archivo",
+		"metamask_file_placeholder":      "p. ej. vault.json o 000003.log",
+		"metamask_vault_password":        "Contraseña de MetaMask (no hace falta para un archivo con la frase)",
+		"metamask_name":                  "Nombre de las carteras, numerado por cuenta",
+		"metamask_wallet_password":       "Contraseña de las nuevas carteras (vacía: la contraseña de MetaMask)",
+		"metamask_path_required":         "Ingrese la ruta del archivo",
+		"metamask_read_failed":           "No se pudo leer el archivo: %v",
+		"metamask_name_required":         "Ingrese un nombre para las carteras",
+		"metamask_reading":               "Abriendo la bóveda…",
+		"metamask_form_help":             "tab: siguiente campo • enter: abrir el archivo • esc: volver",
+		"metamask_found":                 "%d cuentas encontradas:",
+		"metamask_source_phrase":         "Frase de Recuperación Secreta, cuenta %d",
+		"metamask_source_key":            "clave importada",
+		"metamask_unsupported":           "No importadas, no guardan clave: %s",
+		"metamask_phrase_only":           "Un archivo con la frase solo indica la primera cuenta; derive las demás como carteras hijas después.",
+		"metamask_no_accounts":           "La bóveda solo tiene cuentas sin clave",
+		"metamask_review_help":           "enter: importar • backspace: cambiar el archivo • esc: volver",
+		"metamask_running":               "Importando %d cuentas…",
+		"metamask_running_help":          "esc: detener después de la cuenta actual",
+		"metamask_cancelling":            "Deteniendo después de la cuenta actual…",
+		"metamask_done":                  "Listo",
+		"metamask_done_errors":           "Listo con errores",
+		"metamask_summary":               "%d importadas, %d con error, %d omitidas",
+		"metamask_done_help":             "enter: lista de carteras • esc: volver al menú de importación",
+		"metamask_imported":              "importada como %s",
+		"metamask_exists":                "ya está en la lista de carteras",
+		"metamask_not_processed":         "no procesada",
+		"metamask_vault_password_failed": "La contraseña de MetaMask es incorrecta",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package localization
+
+// AddMnemonicMessages adds the messages of the mnemonic grid and word-by-word reveal to the Labels map
+func AddMnemonicMessages() {
+	// English messages
+	english := map[string]string{
+		"word":                 "Word",
+		"mnemonic_reveal_hint": "Word %d of %d • tab/shift+tab: next/previous word",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"word":                 "Palavra",
+		"mnemonic_reveal_hint": "Palavra %d de %d • tab/shift+tab: próxima/anterior",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"word":                 "Palabra",
+		"mnemonic_reveal_hint": "Palabra %d de %d • tab/shift+tab: siguiente/anterior",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package localization
+
+// AddNetworkImportMessages adds the messages of importing the networks of a
+// chainlist-style JSON file
+func AddNetworkImportMessages() {
+	// English messages
+	english := map[string]string{
+		"import_networks":              "Import Networks from File",
+		"import_networks_desc":         "Add every network of a chainlist JSON export",
+		"network_import_title":         "Import Networks from File",
+		"network_import_prompt":        "Path of the JSON file, an array of {name, chainId, rpc, nativeCurrency} as exported by chainlist.org:",
+		"network_import_placeholder":   "e.g. rpcs.json",
+		"network_import_path_required": "Enter the path of the file",
+		"network_import_read_failed":   "Could not read the file: %v",
+		"network_import_form_help":     "enter: import • esc: back to the network menu",
+		"network_import_running":       "Checking %d networks against their RPCs…",
+		"network_import_running_help":  "esc: stop after the current network",
+		"network_import_cancelling":    "Stopping after the current network…",
+		"network_import_done":          "Done",
+		"network_import_done_errors":   "Done with errors",
+		"network_import_summary":       "%d added, %d failed, %d skipped",
+		"network_import_done_help":     "enter: network list • esc: back to the network menu",
+		"network_import_added":         "added with %s",
+		"network_import_exists":        "already configured as %s",
+		"network_import_invalid":       "no name or chain ID",
+		"network_import_no_symbol":     "no native currency symbol",
+		"network_import_no_rpc":        "no HTTP RPC without an API key",
+		"network_import_rpc_failed":    "no RPC answered with chain ID %d: %v",
+		"network_import_not_processed": "not processed",
+		"job_kind_network_import":      "Network import",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"import_networks":              "Importar Redes de Arquivo",
+		"import_networks_desc":         "Adicionar todas as redes de uma exportação JSON do chainlist",
+		"network_import_title":         "Importar Redes de Arquivo",
+		"network_import_prompt":        "Caminho do arquivo JSON, uma lista de {name, chainId, rpc, nativeCurrency} como exportada pelo chainlist.org:",
+		"network_import_placeholder":   "ex.: rpcs.json",
+		"network_import_path_required": "Digite o caminho do arquivo",
+		"network_import_read_failed":   "Não foi possível ler o arquivo: %v",
+		"network_import_form_help":     "enter: importar • esc: voltar ao menu de redes",
+		"network_import_running":       "Verificando %d redes em seus RPCs…",
+		"network_import_running_help":  "esc: parar após a rede atual",
+		"network_import_cancelling":    "Parando após a rede atual…",
+		"network_import_done":          "Concluído",
+		"network_import_done_errors":   "Concluído com erros",
+		"network_import_summary":       "%d adicionadas, %d com falha, %d ignoradas",
+		"network_import_done_help":     "enter: lista de redes • esc: voltar ao menu de redes",
+		"network_import_added":         "adicionada com %s",
+		"network_import_exists":        "já configurada como %s",
+		"network_import_invalid":       "sem nome ou chain ID",
+		"network_import_no_symbol":     "sem símbolo da moeda nativa",
+		"network_import_no_rpc":        "nenhum RPC HTTP sem chave de API",
+		"network_import_rpc_failed":    "nenhum RPC respondeu com o chain ID %d: %v",
+		"network_import_not_processed": "não processada",
+		"job_kind_network_import":      "Importação de redes",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"import_networks":              "Importar Redes desde Archivo",
+		"import_networks_desc":         "Añadir todas las redes de una exportación JSON de chainlist",
+		"network_import_title":         "Importar Redes desde Archivo",
+		"network_import_prompt":        "Ruta del archivo JSON, una lista de {name, chainId, rpc, nativeCurrency} como la exporta chainlist.org:",
+		"network_import_placeholder":   "p. ej. rpcs.json",
+		"network_import_path_required": "Escriba la ruta del archivo",
+		"network_import_read_failed":   "No se pudo leer el archivo: %v",
+		"network_import_form_help":     "enter: importar • esc: volver al menú de redes",
+		"network_import_running":       "Verificando %d redes en sus RPC…",
+		"network_import_running_help":  "esc: detener tras la red actual",
+		"network_import_cancelling":    "Deteniendo tras la red actual…",
+		"network_import_done":          "Completado",
+		"network_import_done_errors":   "Completado con errores",
+		"network_import_summary":       "%d añadidas, %d con error, %d omitidas",
+		"network_import_done_help":     "enter: lista de redes • esc: volver al menú de redes",
+		"network_import_added":         "añadida con %s",
+		"network_import_exists":        "ya configurada como %s",
+		"network_import_invalid":       "sin nombre o chain ID",
+		"network_import_no_symbol":     "sin símbolo de la moneda nativa",
+		"network_import_no_rpc":        "ningún RPC HTTP sin clave de API",
+		"network_import_rpc_failed":    "ningún RPC respondió con el chain ID %d: %v",
+		"network_import_not_processed": "sin procesar",
+		"job_kind_network_import":      "Importación de redes",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
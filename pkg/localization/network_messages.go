@@ -29,7 +29,7 @@ func DefaultNetworkMessagesEnglish() map[string]string {
 		"invalid_rpc_endpoint":            "Invalid RPC endpoint. Must start with http:// or https://",
 		"failed_to_get_network_details":   "Failed to get network details",
 		"no_network_selected":             "No network selected",
-		"network_list_instructions":       "Use arrow keys to navigate, 'a' to add, 'e' to edit, 'd' to delete, 'esc' to go back.",
+		"network_list_instructions":       "Use arrow keys to navigate, 'a' to add, 'e' to edit, 'd' to delete, 'v' to verify, 't' to mark a testnet, 'esc' to go back.",
 		"add_network_footer":              "↑/↓: Navigate Suggestions • Tab: Next Field • Enter: Select/Submit • Esc: Back",
 		"search_networks_placeholder":     "Type to search networks (e.g., Ethereum, Polygon)",
 		"network_name_placeholder":        "Network name will be filled automatically",
@@ -51,6 +51,31 @@ func DefaultNetworkMessagesEnglish() map[string]string {
 		"rpc_validation_failed_guidance":  "Verify the RPC URL is correct and reachable.",
 		"network_selection_failed":        "Network selection failed",
 		"operation_failed_generic":        "Operation failed",
+		"quarantined":                     "Quarantined",
+		"verify_network":                  "Verify Endpoint",
+		"verifying_network":               "Verifying the endpoint of %s...",
+		"network_verified":                "%s passed verification",
+		"network_quarantined":             "%s is quarantined: %s",
+		"network_unreachable":             "%s could not be verified: %v",
+		"saving_network":                  "Saving network",
+		"edit_network_footer":             "Tab: Next Field • Enter: Save • Esc: Back to the list",
+		"network_updated":                 "%s updated",
+		"network_removed":                 "%s removed",
+		"network_delete_confirm":          "Remove %s (chain ID %d) from the configuration?",
+		"network_delete_help":             "y/enter: remove • n/esc: cancel",
+		"network_delete_in_use":           "Wallets still depend on %s: %s.",
+		"network_delete_balances":         "%d wallets with a balance",
+		"network_delete_pending":          "%d pending transactions",
+		"network_delete_force_help":       "f: remove anyway • n/esc: cancel",
+		"network_kind":                    "Net",
+		"mainnet":                         "Mainnet",
+		"testnet":                         "Testnet",
+		"toggle_testnet":                  "Testnet",
+		"network_testnet_note":            "Test network: its coins have no value",
+		"network_faucet":                  "Faucet: %s",
+		"network_testnets_hidden":         "%d test networks hidden · set hide_testnets = false under [balances] to list them",
+		"network_marked_testnet":          "%s marked as a test network",
+		"network_marked_mainnet":          "%s marked as a mainnet",
 	}
 }
 
@@ -83,7 +108,7 @@ func DefaultNetworkMessagesPortuguese() map[string]string {
 		"invalid_rpc_endpoint":            "Endpoint RPC inválido. Deve começar com http:// ou https://",
 		"failed_to_get_network_details":   "Falha ao obter detalhes da rede",
 		"no_network_selected":             "Nenhuma rede selecionada",
-		"network_list_instructions":       "Use as setas para navegar, 'a' para adicionar, 'e' para editar, 'd' para excluir, 'esc' para voltar.",
+		"network_list_instructions":       "Use as setas para navegar, 'a' para adicionar, 'e' para editar, 'd' para excluir, 'v' para verificar, 't' para marcar uma testnet, 'esc' para voltar.",
 		"add_network_footer":              "↑/↓: Navegar Sugestões • Tab: Próximo Campo • Enter: Selecionar/Enviar • Esc: Voltar",
 		"search_networks_placeholder":     "Digite para buscar redes (ex: Ethereum, Polygon)",
 		"network_name_placeholder":        "Nome da rede será preenchido automaticamente",
@@ -105,6 +130,31 @@ func DefaultNetworkMessagesPortuguese() map[string]string {
 		"rpc_validation_failed_guidance":  "Verifique se a URL do RPC está correta e acessível.",
 		"network_selection_failed":        "Falha ao selecionar a rede",
 		"operation_failed_generic":        "Falha na operação",
+		"quarantined":                     "Quarentena",
+		"verify_network":                  "Verificar Endpoint",
+		"verifying_network":               "Verificando o endpoint de %s...",
+		"network_verified":                "%s passou na verificação",
+		"network_quarantined":             "%s está em quarentena: %s",
+		"network_unreachable":             "%s não pôde ser verificada: %v",
+		"saving_network":                  "Salvando rede",
+		"edit_network_footer":             "Tab: Próximo Campo • Enter: Salvar • Esc: Voltar para a lista",
+		"network_updated":                 "%s atualizada",
+		"network_removed":                 "%s removida",
+		"network_delete_confirm":          "Remover %s (ID da cadeia %d) da configuração?",
+		"network_delete_help":             "y/enter: remover • n/esc: cancelar",
+		"network_delete_in_use":           "Carteiras ainda dependem de %s: %s.",
+		"network_delete_balances":         "%d carteiras com saldo",
+		"network_delete_pending":          "%d transações pendentes",
+		"network_delete_force_help":       "f: remover mesmo assim • n/esc: cancelar",
+		"network_kind":                    "Rede",
+		"mainnet":                         "Mainnet",
+		"testnet":                         "Testnet",
+		"toggle_testnet":                  "Testnet",
+		"network_testnet_note":            "Rede de teste: suas moedas não têm valor",
+		"network_faucet":                  "Faucet: %s",
+		"network_testnets_hidden":         "%d redes de teste ocultas · defina hide_testnets = false em [balances] para listá-las",
+		"network_marked_testnet":          "%s marcada como rede de teste",
+		"network_marked_mainnet":          "%s marcada como mainnet",
 	}
 }
 
@@ -137,7 +187,7 @@ func DefaultNetworkMessagesSpanish() map[string]string {
 		"invalid_rpc_endpoint":            "Endpoint RPC inválido. Debe comenzar con http:// o https://",
 		"failed_to_get_network_details":   "Error al obtener detalles de la red",
 		"no_network_selected":             "Ninguna red seleccionada",
-		"network_list_instructions":       "Use las flechas para navegar, 'a' para añadir, 'e' para editar, 'd' para eliminar, 'esc' para volver.",
+		"network_list_instructions":       "Use las flechas para navegar, 'a' para añadir, 'e' para editar, 'd' para eliminar, 'v' para verificar, 't' para marcar una testnet, 'esc' para volver.",
 		"add_network_footer":              "↑/↓: Navegar Sugerencias • Tab: Siguiente Campo • Enter: Seleccionar/Enviar • Esc: Volver",
 		"search_networks_placeholder":     "Escriba para buscar redes (ej: Ethereum, Polygon)",
 		"network_name_placeholder":        "El nombre de la red se completará automáticamente",
@@ -157,5 +207,30 @@ func DefaultNetworkMessagesSpanish() map[string]string {
 		"rpc_validation_failed_guidance":  "Verifique que la URL de RPC sea correcta y accesible.",
 		"network_selection_failed":        "Fallo al seleccionar la red",
 		"operation_failed_generic":        "Fallo en la operación",
+		"quarantined":                     "Cuarentena",
+		"verify_network":                  "Verificar Endpoint",
+		"verifying_network":               "Verificando el endpoint de %s...",
+		"network_verified":                "%s pasó la verificación",
+		"network_quarantined":             "%s está en cuarentena: %s",
+		"network_unreachable":             "%s no pudo verificarse: %v",
+		"saving_network":                  "Guardando red",
+		"edit_network_footer":             "Tab: Siguiente Campo • Enter: Guardar • Esc: Volver a la lista",
+		"network_updated":                 "%s actualizada",
+		"network_removed":                 "%s eliminada",
+		"network_delete_confirm":          "¿Eliminar %s (ID de cadena %d) de la configuración?",
+		"network_delete_help":             "y/enter: eliminar • n/esc: cancelar",
+		"network_delete_in_use":           "Hay carteras que aún dependen de %s: %s.",
+		"network_delete_balances":         "%d carteras con saldo",
+		"network_delete_pending":          "%d transacciones pendientes",
+		"network_delete_force_help":       "f: eliminar de todos modos • n/esc: cancelar",
+		"network_kind":                    "Red",
+		"mainnet":                         "Mainnet",
+		"testnet":                         "Testnet",
+		"toggle_testnet":                  "Testnet",
+		"network_testnet_note":            "Red de prueba: sus monedas no tienen valor",
+		"network_faucet":                  "Faucet: %s",
+		"network_testnets_hidden":         "%d redes de prueba ocultas · defina hide_testnets = false en [balances] para listarlas",
+		"network_marked_testnet":          "%s marcada como red de prueba",
+		"network_marked_mainnet":          "%s marcada como mainnet",
 	}
 }
@@ -0,0 +1,123 @@
+package localization
+
+// AddNotesMessages adds wallet notes and wallet list search, sorting and
+// paging messages to the Labels map
+func AddNotesMessages() {
+	// English messages
+	english := map[string]string{
+		"notes_title":                  "Wallet Notes",
+		"notes_wallet":                 "Wallet \"%s\" (%s)",
+		"notes_label":                  "Notes",
+		"notes_placeholder":            "What this wallet is for, who holds it, ...",
+		"notes_references_label":       "External references (comma separated)",
+		"notes_references_placeholder": "TICKET-123, https://...",
+		"notes_save_failed":            "Could not save the notes: %v",
+		"notes_details_label":          "Notes:",
+		"references_details_label":     "References:",
+		"wallet_search_label":          "Search:",
+		"wallet_search_placeholder":    "name, address, notes or reference",
+		"wallet_search_count":          "%d of %d",
+		"wallet_search_no_matches":     "No wallet matches \"%s\"",
+		"wallet_sort_created":          "created",
+		"wallet_sort_name":             "name",
+		"wallet_sort_type":             "type",
+		"wallet_list_position":         "%d of %d • page %d/%d • sorted by %s",
+		"delete_no_mnemonic":           "This wallet has no mnemonic: its key exists only in its keystore",
+		"delete_phrase_prompt":         "Type the wallet name (%s) or the last 4 characters of its address (%s) to delete it",
+		"delete_phrase_placeholder":    "name or last 4 characters",
+		"delete_archive_option":        "Copy the keystore to %s first",
+		"delete_phrase_help":           "tab: copy on/off • ←/→: choose • enter: confirm • esc: cancel",
+		"delete_archived":              "Deleted %s; its keystore was copied to %s",
+		"delete_archive_failed":        "The wallet was not deleted because its keystore could not be copied: %v",
+		"wallet_report_label":          "Report file:",
+		"wallet_report_help":           "enter: write (.md for Markdown, CSV otherwise) • esc: cancel",
+		"wallet_report_written":        "Wrote %d wallets to %s",
+		"wallet_report_failed":         "Could not write the report: %v",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"notes_title":                  "Notas da Carteira",
+		"notes_wallet":                 "Carteira \"%s\" (%s)",
+		"notes_label":                  "Notas",
+		"notes_placeholder":            "Para que serve esta carteira, quem a controla, ...",
+		"notes_references_label":       "Referências externas (separadas por vírgula)",
+		"notes_references_placeholder": "TICKET-123, https://...",
+		"notes_save_failed":            "Não foi possível salvar as notas: %v",
+		"notes_details_label":          "Notas:",
+		"references_details_label":     "Referências:",
+		"wallet_search_label":          "Buscar:",
+		"wallet_search_placeholder":    "nome, endereço, notas ou referência",
+		"wallet_search_count":          "%d de %d",
+		"wallet_search_no_matches":     "Nenhuma carteira corresponde a \"%s\"",
+		"wallet_sort_created":          "criação",
+		"wallet_sort_name":             "nome",
+		"wallet_sort_type":             "tipo",
+		"wallet_list_position":         "%d de %d • página %d/%d • ordenadas por %s",
+		"delete_no_mnemonic":           "Esta carteira não tem mnemônica: sua chave existe apenas na keystore",
+		"delete_phrase_prompt":         "Digite o nome da carteira (%s) ou os 4 últimos caracteres do endereço (%s) para excluí-la",
+		"delete_phrase_placeholder":    "nome ou 4 últimos caracteres",
+		"delete_archive_option":        "Copiar a keystore para %s antes",
+		"delete_phrase_help":           "tab: copiar sim/não • ←/→: escolher • enter: confirmar • esc: cancelar",
+		"delete_archived":              "%s excluída; sua keystore foi copiada para %s",
+		"delete_archive_failed":        "A carteira não foi excluída porque não foi possível copiar sua keystore: %v",
+		"wallet_report_label":          "Arquivo do relatório:",
+		"wallet_report_help":           "enter: gravar (.md para Markdown, CSV nos demais) • esc: cancelar",
+		"wallet_report_written":        "%d carteiras gravadas em %s",
+		"wallet_report_failed":         "Não foi possível gravar o relatório: %v",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"notes_title":                  "Notas de la Cartera",
+		"notes_wallet":                 "Cartera \"%s\" (%s)",
+		"notes_label":                  "Notas",
+		"notes_placeholder":            "Para qué sirve esta cartera, quién la controla, ...",
+		"notes_references_label":       "Referencias externas (separadas por coma)",
+		"notes_references_placeholder": "TICKET-123, https://...",
+		"notes_save_failed":            "No se pudieron guardar las notas: %v",
+		"notes_details_label":          "Notas:",
+		"references_details_label":     "Referencias:",
+		"wallet_search_label":          "Buscar:",
+		"wallet_search_placeholder":    "nombre, dirección, notas o referencia",
+		"wallet_search_count":          "%d de %d",
+		"wallet_search_no_matches":     "Ninguna cartera coincide con \"%s\"",
+		"wallet_sort_created":          "creación",
+		"wallet_sort_name":             "nombre",
+		"wallet_sort_type":             "tipo",
+		"wallet_list_position":         "%d de %d • página %d/%d • ordenadas por %s",
+		"delete_no_mnemonic":           "Esta cartera no tiene mnemónica: su clave existe solo en su keystore",
+		"delete_phrase_prompt":         "Escriba el nombre de la cartera (%s) o los 4 últimos caracteres de su dirección (%s) para eliminarla",
+		"delete_phrase_placeholder":    "nombre o 4 últimos caracteres",
+		"delete_archive_option":        "Copiar el keystore a %s antes",
+		"delete_phrase_help":           "tab: copiar sí/no • ←/→: elegir • enter: confirmar • esc: cancelar",
+		"delete_archived":              "%s eliminada; su keystore se copió a %s",
+		"delete_archive_failed":        "La cartera no se eliminó porque no se pudo copiar su keystore: %v",
+		"wallet_report_label":          "Archivo del informe:",
+		"wallet_report_help":           "enter: escribir (.md para Markdown, CSV en otro caso) • esc: cancelar",
+		"wallet_report_written":        "%d carteras escritas en %s",
+		"wallet_report_failed":         "No se pudo escribir el informe: %v",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
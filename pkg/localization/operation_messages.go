@@ -0,0 +1,65 @@
+package localization
+
+// AddOperationMessages adds the messages shown while a wallet key is derived
+func AddOperationMessages() {
+	// English messages
+	english := map[string]string{
+		"kdf_unlocking":      "Unlocking wallet…",
+		"kdf_importing":      "Importing wallet…",
+		"kdf_elapsed":        "Elapsed: %s",
+		"kdf_computing":      "Still deriving the key (scrypt is slow by design)",
+		"kdf_idle":           "No CPU activity for %s: the operation may be hung",
+		"kdf_stalled":        "This is taking longer than %s.",
+		"kdf_abort_help":     "esc: stop waiting",
+		"kdf_aborted":        "Stopped waiting after %s. The operation was abandoned.",
+		"kdf_aborted_import": "Stopped waiting after %s. If the import still finishes, the wallet is added to the list.",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"kdf_unlocking":      "Desbloqueando a carteira…",
+		"kdf_importing":      "Importando a carteira…",
+		"kdf_elapsed":        "Tempo decorrido: %s",
+		"kdf_computing":      "Ainda derivando a chave (o scrypt é lento de propósito)",
+		"kdf_idle":           "Sem atividade de CPU há %s: a operação pode estar travada",
+		"kdf_stalled":        "Isto está levando mais de %s.",
+		"kdf_abort_help":     "esc: parar de esperar",
+		"kdf_aborted":        "Espera interrompida após %s. A operação foi abandonada.",
+		"kdf_aborted_import": "Espera interrompida após %s. Se a importação ainda terminar, a carteira é adicionada à lista.",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"kdf_unlocking":      "Desbloqueando la cartera…",
+		"kdf_importing":      "Importando la cartera…",
+		"kdf_elapsed":        "Tiempo transcurrido: %s",
+		"kdf_computing":      "Todavía derivando la clave (scrypt es lento a propósito)",
+		"kdf_idle":           "Sin actividad de CPU desde hace %s: la operación puede estar colgada",
+		"kdf_stalled":        "Esto está tardando más de %s.",
+		"kdf_abort_help":     "esc: dejar de esperar",
+		"kdf_aborted":        "Espera detenida tras %s. La operación fue abandonada.",
+		"kdf_aborted_import": "Espera detenida tras %s. Si la importación aún termina, la cartera se añade a la lista.",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package localization
+
+// AddOverwriteMessages adds the messages shown before a managed keystore is replaced
+func AddOverwriteMessages() {
+	// English messages
+	english := map[string]string{
+		"overwrite_title":      "Replace Keystore?",
+		"overwrite_desc":       "A different keystore for %s is already managed at %s. Importing this file replaces it:",
+		"overwrite_field":      "field",
+		"overwrite_current":    "current",
+		"overwrite_new":        "new",
+		"overwrite_no_changes": "Same structure; only the encrypted content differs.",
+		"overwrite_weaker":     "The new file is encrypted with weaker parameters than the current one.",
+		"overwrite_help":       "y: replace the keystore • n/esc: keep the current one",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"overwrite_title":      "Substituir a Keystore?",
+		"overwrite_desc":       "Outra keystore de %s já é gerenciada em %s. Importar este arquivo a substitui:",
+		"overwrite_field":      "campo",
+		"overwrite_current":    "atual",
+		"overwrite_new":        "nova",
+		"overwrite_no_changes": "Mesma estrutura; só o conteúdo cifrado muda.",
+		"overwrite_weaker":     "O novo arquivo é cifrado com parâmetros mais fracos que o atual.",
+		"overwrite_help":       "y: substituir a keystore • n/esc: manter a atual",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"overwrite_title":      "¿Reemplazar el Keystore?",
+		"overwrite_desc":       "Ya se gestiona otro keystore de %s en %s. Importar este archivo lo reemplaza:",
+		"overwrite_field":      "campo",
+		"overwrite_current":    "actual",
+		"overwrite_new":        "nuevo",
+		"overwrite_no_changes": "Misma estructura; solo cambia el contenido cifrado.",
+		"overwrite_weaker":     "El nuevo archivo está cifrado con parámetros más débiles que el actual.",
+		"overwrite_help":       "y: reemplazar el keystore • n/esc: mantener el actual",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
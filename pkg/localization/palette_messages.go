@@ -0,0 +1,53 @@
+package localization
+
+// AddPaletteMessages adds the messages of the command palette to the Labels map
+func AddPaletteMessages() {
+	// English messages
+	english := map[string]string{
+		"palette_title":       "Command palette",
+		"palette_placeholder": "Type an action or a wallet name or address",
+		"palette_wallet":      "wallet",
+		"palette_no_match":    "Nothing matches.",
+		"palette_more":        "… %d more",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"palette_title":       "Paleta de comandos",
+		"palette_placeholder": "Digite uma ação ou o nome ou endereço de uma carteira",
+		"palette_wallet":      "carteira",
+		"palette_no_match":    "Nada encontrado.",
+		"palette_more":        "… mais %d",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"palette_title":       "Paleta de comandos",
+		"palette_placeholder": "Escriba una acción o el nombre o la dirección de una billetera",
+		"palette_wallet":      "billetera",
+		"palette_no_match":    "Nada coincide.",
+		"palette_more":        "… %d más",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package localization
+
+// AddPasteImportMessages adds the messages of the keystore JSON paste mode
+func AddPasteImportMessages() {
+	// English messages
+	english := map[string]string{
+		"paste_title":             "Paste Keystore JSON",
+		"paste_desc":              "Paste the content of a keystore v3 file, e.g. over SSH where copying the file is awkward.",
+		"paste_json":              "Keystore JSON",
+		"paste_name":              "Wallet name",
+		"paste_name_placeholder":  "Leave empty to name it after the address",
+		"paste_password":          "Password",
+		"paste_password_required": "Enter the keystore password",
+		"paste_default_name":      "Pasted keystore %s",
+		"paste_help":              "tab: next field • ctrl+s: import • esc: back to the files",
+		"paste_hint":              "ctrl+p: paste keystore JSON instead of choosing a file",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"paste_title":             "Colar JSON da Keystore",
+		"paste_desc":              "Cole o conteúdo de um arquivo keystore v3, por exemplo via SSH, onde copiar o arquivo é trabalhoso.",
+		"paste_json":              "JSON da keystore",
+		"paste_name":              "Nome da carteira",
+		"paste_name_placeholder":  "Deixe vazio para usar o endereço",
+		"paste_password":          "Senha",
+		"paste_password_required": "Digite a senha da keystore",
+		"paste_default_name":      "Keystore colada %s",
+		"paste_help":              "tab: próximo campo • ctrl+s: importar • esc: voltar aos arquivos",
+		"paste_hint":              "ctrl+p: colar o JSON da keystore em vez de escolher um arquivo",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"paste_title":             "Pegar JSON del Keystore",
+		"paste_desc":              "Pegue el contenido de un archivo keystore v3, por ejemplo por SSH, donde copiar el archivo es incómodo.",
+		"paste_json":              "JSON del keystore",
+		"paste_name":              "Nombre de la cartera",
+		"paste_name_placeholder":  "Déjelo vacío para usar la dirección",
+		"paste_password":          "Contraseña",
+		"paste_password_required": "Ingrese la contraseña del keystore",
+		"paste_default_name":      "Keystore pegado %s",
+		"paste_help":              "tab: siguiente campo • ctrl+s: importar • esc: volver a los archivos",
+		"paste_hint":              "ctrl+p: pegar el JSON del keystore en lugar de elegir un archivo",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package localization
+
+// AddPendingTxMessages adds the messages of the transaction tracker to the Labels map
+func AddPendingTxMessages() {
+	// English messages
+	english := map[string]string{
+		"pending_tx":                      "Transactions",
+		"pending_tx_desc":                 "Follow sent transactions, speed up or cancel",
+		"pending_tx_title":                "Transactions",
+		"pending_tx_empty":                "No transaction was broadcast from this wallet manager yet.",
+		"pending_tx_column_state":         "State",
+		"pending_tx_column_network":       "Network",
+		"pending_tx_column_hash":          "Hash",
+		"pending_tx_column_nonce":         "Nonce",
+		"pending_tx_column_confirmations": "Confirmations",
+		"pending_tx_column_kind":          "Kind",
+		"pending_tx_state_pending":        "Pending",
+		"pending_tx_state_included":       "Confirming",
+		"pending_tx_state_final":          "Final",
+		"pending_tx_state_reverted":       "Reverted",
+		"pending_tx_state_replaced":       "Replaced",
+		"pending_tx_state_cancelled":      "Cancelled",
+		"pending_tx_kind_transfer":        "Transaction",
+		"pending_tx_kind_speed_up":        "Speed-up",
+		"pending_tx_kind_cancel":          "Cancel",
+		"pending_tx_selected":             "%s from %s",
+		"pending_tx_not_replaceable":      "Only pending transactions can be sped up or cancelled",
+		"pending_tx_not_local":            "The sender is not a wallet of this manager, so it cannot sign a replacement",
+		"pending_tx_confirm_speed_up":     "Speed up: the same transaction is sent again with fees at least %[2]d%% higher. Password of \"%[1]s\":",
+		"pending_tx_confirm_cancel":       "Cancel: an empty transfer to the sender takes the nonce with fees at least %[2]d%% higher. Password of \"%[1]s\":",
+		"pending_tx_password_placeholder": "wallet password",
+		"pending_tx_replacing":            "Unlocking the wallet and sending the replacement…",
+		"pending_tx_replace_failed":       "The replacement was not sent: %v",
+		"pending_tx_sped_up":              "Speed-up sent: %s",
+		"pending_tx_cancel_sent":          "Cancel sent: %s. The original is cancelled once it is mined.",
+		"pending_tx_poll_failed":          "Some transactions could not be checked: %v",
+		"pending_tx_help":                 "↑/↓: select • s: speed up • x: cancel • r: check now • esc: back to menu",
+		"pending_tx_help_password":        "enter: sign and send • esc: back",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"pending_tx":                      "Transações",
+		"pending_tx_desc":                 "Acompanhar transações enviadas, acelerar ou cancelar",
+		"pending_tx_title":                "Transações",
+		"pending_tx_empty":                "Nenhuma transação foi transmitida por este gerenciador ainda.",
+		"pending_tx_column_state":         "Estado",
+		"pending_tx_column_network":       "Rede",
+		"pending_tx_column_hash":          "Hash",
+		"pending_tx_column_nonce":         "Nonce",
+		"pending_tx_column_confirmations": "Confirmações",
+		"pending_tx_column_kind":          "Tipo",
+		"pending_tx_state_pending":        "Pendente",
+		"pending_tx_state_included":       "Confirmando",
+		"pending_tx_state_final":          "Definitiva",
+		"pending_tx_state_reverted":       "Revertida",
+		"pending_tx_state_replaced":       "Substituída",
+		"pending_tx_state_cancelled":      "Cancelada",
+		"pending_tx_kind_transfer":        "Transação",
+		"pending_tx_kind_speed_up":        "Aceleração",
+		"pending_tx_kind_cancel":          "Cancelamento",
+		"pending_tx_selected":             "%s de %s",
+		"pending_tx_not_replaceable":      "Apenas transações pendentes podem ser aceleradas ou canceladas",
+		"pending_tx_not_local":            "O remetente não é uma carteira deste gerenciador e não pode assinar uma substituição",
+		"pending_tx_confirm_speed_up":     "Acelerar: a mesma transação é enviada de novo com taxas ao menos %[2]d%% maiores. Senha de \"%[1]s\":",
+		"pending_tx_confirm_cancel":       "Cancelar: uma transferência vazia para o remetente ocupa o nonce com taxas ao menos %[2]d%% maiores. Senha de \"%[1]s\":",
+		"pending_tx_password_placeholder": "senha da carteira",
+		"pending_tx_replacing":            "Desbloqueando a carteira e enviando a substituição…",
+		"pending_tx_replace_failed":       "A substituição não foi enviada: %v",
+		"pending_tx_sped_up":              "Aceleração enviada: %s",
+		"pending_tx_cancel_sent":          "Cancelamento enviado: %s. A original fica cancelada quando ele for minerado.",
+		"pending_tx_poll_failed":          "Algumas transações não puderam ser verificadas: %v",
+		"pending_tx_help":                 "↑/↓: selecionar • s: acelerar • x: cancelar • r: verificar agora • esc: voltar ao menu",
+		"pending_tx_help_password":        "enter: assinar e enviar • esc: voltar",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"pending_tx":                      "Transacciones",
+		"pending_tx_desc":                 "Seguir transacciones enviadas, acelerar o cancelar",
+		"pending_tx_title":                "Transacciones",
+		"pending_tx_empty":                "Todavía no se transmitió ninguna transacción desde este gestor.",
+		"pending_tx_column_state":         "Estado",
+		"pending_tx_column_network":       "Red",
+		"pending_tx_column_hash":          "Hash",
+		"pending_tx_column_nonce":         "Nonce",
+		"pending_tx_column_confirmations": "Confirmaciones",
+		"pending_tx_column_kind":          "Tipo",
+		"pending_tx_state_pending":        "Pendiente",
+		"pending_tx_state_included":       "Confirmando",
+		"pending_tx_state_final":          "Definitiva",
+		"pending_tx_state_reverted":       "Revertida",
+		"pending_tx_state_replaced":       "Reemplazada",
+		"pending_tx_state_cancelled":      "Cancelada",
+		"pending_tx_kind_transfer":        "Transacción",
+		"pending_tx_kind_speed_up":        "Aceleración",
+		"pending_tx_kind_cancel":          "Cancelación",
+		"pending_tx_selected":             "%s de %s",
+		"pending_tx_not_replaceable":      "Solo las transacciones pendientes pueden acelerarse o cancelarse",
+		"pending_tx_not_local":            "El remitente no es una cartera de este gestor y no puede firmar un reemplazo",
+		"pending_tx_confirm_speed_up":     "Acelerar: la misma transacción se envía de nuevo con tarifas al menos %[2]d%% más altas. Contraseña de \"%[1]s\":",
+		"pending_tx_confirm_cancel":       "Cancelar: una transferencia vacía al remitente ocupa el nonce con tarifas al menos %[2]d%% más altas. Contraseña de \"%[1]s\":",
+		"pending_tx_password_placeholder": "contraseña de la cartera",
+		"pending_tx_replacing":            "Desbloqueando la cartera y enviando el reemplazo…",
+		"pending_tx_replace_failed":       "El reemplazo no se envió: %v",
+		"pending_tx_sped_up":              "Aceleración enviada: %s",
+		"pending_tx_cancel_sent":          "Cancelación enviada: %s. La original queda cancelada cuando se mine.",
+		"pending_tx_poll_failed":          "Algunas transacciones no pudieron verificarse: %v",
+		"pending_tx_help":                 "↑/↓: seleccionar • s: acelerar • x: cancelar • r: verificar ahora • esc: volver al menú",
+		"pending_tx_help_password":        "enter: firmar y enviar • esc: volver",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
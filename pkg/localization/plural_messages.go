@@ -0,0 +1,63 @@
+package localization
+
+import (
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// addPluralMessages adds the messages that change with a count to the bundle.
+// They are not in the Labels map: Plural picks their form by the plural rules
+// of the language, which a single string cannot follow.
+func addPluralMessages() error {
+	// English messages
+	english := []*i18n.Message{
+		{ID: "wallet_count", One: "{{.Count}} wallet", Other: "{{.Count}} wallets"},
+		{ID: "keystore_count", One: "{{.Count}} keystore", Other: "{{.Count}} keystores"},
+		{ID: "wallets_selected", One: "{{.Count}} wallet selected", Other: "{{.Count}} wallets selected"},
+	}
+
+	// Portuguese messages
+	portuguese := []*i18n.Message{
+		{ID: "wallet_count", One: "{{.Count}} carteira", Other: "{{.Count}} carteiras"},
+		{ID: "keystore_count", One: "{{.Count}} keystore", Other: "{{.Count}} keystores"},
+		{ID: "wallets_selected", One: "{{.Count}} carteira selecionada", Other: "{{.Count}} carteiras selecionadas"},
+	}
+
+	// Spanish messages
+	spanish := []*i18n.Message{
+		{ID: "wallet_count", One: "{{.Count}} cartera", Other: "{{.Count}} carteras"},
+		{ID: "keystore_count", One: "{{.Count}} keystore", Other: "{{.Count}} keystores"},
+		{ID: "wallets_selected", One: "{{.Count}} cartera seleccionada", Other: "{{.Count}} carteras seleccionadas"},
+	}
+
+	if err := bundle.AddMessages(language.English, english...); err != nil {
+		return err
+	}
+	if err := bundle.AddMessages(language.Portuguese, portuguese...); err != nil {
+		return err
+	}
+	return bundle.AddMessages(language.Spanish, spanish...)
+}
+
+// pluralMessageIDs are the keys of the messages added by addPluralMessages
+func pluralMessageIDs() []string {
+	return []string{"wallet_count", "keystore_count", "wallets_selected"}
+}
+
+// Plural returns the message key in the form the current language uses for
+// count, with {{.Count}} replaced by it. Language packs may add the forms
+// their language has, such as few and many.
+func Plural(key string, count int) string {
+	if localizer == nil {
+		return key
+	}
+	msg, err := localizer.Localize(&i18n.LocalizeConfig{
+		MessageID:    key,
+		PluralCount:  count,
+		TemplateData: map[string]interface{}{"Count": count},
+	})
+	if msg == "" && err != nil {
+		return key
+	}
+	return msg
+}
@@ -0,0 +1,59 @@
+package localization
+
+// AddPrivateKeyMessages adds the messages of the private key format detection to the Labels map
+func AddPrivateKeyMessages() {
+	// English messages
+	english := map[string]string{
+		"private_key_detected":      "Detected format: %s",
+		"private_key_address":       "Address: %s",
+		"private_key_confirm":       "Check that this is the address you expect, then press enter to set the password or esc to edit the key.",
+		"private_key_format_hex":    "hex",
+		"private_key_format_base64": "base64",
+		"private_key_format_wif":    "WIF",
+		"private_key_unrecognized":  "Not a private key: enter 64 hex digits (with or without 0x), the key in base64 or a WIF key",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"private_key_detected":      "Formato detectado: %s",
+		"private_key_address":       "Endereço: %s",
+		"private_key_confirm":       "Confira se este é o endereço esperado e pressione enter para definir a senha ou esc para editar a chave.",
+		"private_key_format_hex":    "hex",
+		"private_key_format_base64": "base64",
+		"private_key_format_wif":    "WIF",
+		"private_key_unrecognized":  "Não é uma chave privada: digite 64 dígitos hex (com ou sem 0x), a chave em base64 ou uma chave WIF",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"private_key_detected":      "Formato detectado: %s",
+		"private_key_address":       "Dirección: %s",
+		"private_key_confirm":       "Verifique que esta sea la dirección esperada y presione enter para definir la contraseña o esc para editar la clave.",
+		"private_key_format_hex":    "hex",
+		"private_key_format_base64": "base64",
+		"private_key_format_wif":    "WIF",
+		"private_key_unrecognized":  "No es una clave privada: ingrese 64 dígitos hex (con o sin 0x), la clave en base64 o una clave WIF",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package localization
+
+// AddProfileMessages adds the messages of the profile switcher to the Labels map
+func AddProfileMessages() {
+	// English messages
+	english := map[string]string{
+		"profiles":                   "Profiles",
+		"profiles_menu_desc":         "Switch between environments with their own wallets and networks",
+		"profiles_title":             "Profiles",
+		"profiles_base_dir":          "Profiles in %s",
+		"profile_current":            "(in use)",
+		"profile_name":               "Profile name:",
+		"profile_name_placeholder":   "e.g. work or testnets",
+		"profile_created":            "Profile %s created; press enter to switch to it.",
+		"profile_create_failed":      "Cannot create the profile: %v",
+		"profile_list_failed":        "Cannot list the profiles: %v",
+		"profile_switching":          "Opening profile %s...",
+		"profile_switch_failed":      "Cannot switch profile: %v",
+		"profile_switch_unavailable": "Switching profiles needs a restart with --profile <name>.",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"profiles":                   "Perfis",
+		"profiles_menu_desc":         "Alternar entre ambientes com carteiras e redes próprias",
+		"profiles_title":             "Perfis",
+		"profiles_base_dir":          "Perfis em %s",
+		"profile_current":            "(em uso)",
+		"profile_name":               "Nome do perfil:",
+		"profile_name_placeholder":   "ex.: trabalho ou testnets",
+		"profile_created":            "Perfil %s criado; pressione enter para usá-lo.",
+		"profile_create_failed":      "Não foi possível criar o perfil: %v",
+		"profile_list_failed":        "Não foi possível listar os perfis: %v",
+		"profile_switching":          "Abrindo o perfil %s...",
+		"profile_switch_failed":      "Não foi possível trocar de perfil: %v",
+		"profile_switch_unavailable": "Para trocar de perfil, reinicie com --profile <nome>.",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"profiles":                   "Perfiles",
+		"profiles_menu_desc":         "Cambiar entre entornos con carteras y redes propias",
+		"profiles_title":             "Perfiles",
+		"profiles_base_dir":          "Perfiles en %s",
+		"profile_current":            "(en uso)",
+		"profile_name":               "Nombre del perfil:",
+		"profile_name_placeholder":   "p. ej. trabajo o testnets",
+		"profile_created":            "Perfil %s creado; pulse enter para usarlo.",
+		"profile_create_failed":      "No se puede crear el perfil: %v",
+		"profile_list_failed":        "No se pueden listar los perfiles: %v",
+		"profile_switching":          "Abriendo el perfil %s...",
+		"profile_switch_failed":      "No se puede cambiar de perfil: %v",
+		"profile_switch_unavailable": "Para cambiar de perfil, reinicie con --profile <nombre>.",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package localization
+
+// AddRPCOverrideMessages adds the messages of the per-wallet RPC endpoint screen to the Labels map
+func AddRPCOverrideMessages() {
+	// English messages
+	english := map[string]string{
+		"rpc_override_title":          "Wallet RPC Endpoints",
+		"rpc_override_wallet":         "Wallet \"%s\" (%s)",
+		"rpc_override_desc":           "Balances, transaction tracking and broadcasts of this wallet go through its own endpoint on a chain, e.g. a personal node or a private mempool, instead of the network's.",
+		"rpc_override_none":           "No networks are configured.",
+		"rpc_override_default":        "network default",
+		"rpc_override_endpoint":       "Endpoint for %s (chain %d); leave empty to use the network's",
+		"rpc_override_placeholder":    "https://my-node.example:8545",
+		"rpc_override_saved":          "%s now uses %s",
+		"rpc_override_removed":        "%s uses the network's endpoint again",
+		"rpc_override_failed":         "Could not save the endpoint: %v",
+		"rpc_override_help":           "↑/↓: select • enter: edit • d: use the network's endpoint • esc: back to the details",
+		"rpc_override_help_editing":   "enter: save • esc: cancel",
+		"wallet_details_rpc_override": "own endpoint",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"rpc_override_title":          "Endpoints RPC da Carteira",
+		"rpc_override_wallet":         "Carteira \"%s\" (%s)",
+		"rpc_override_desc":           "Saldos, acompanhamento de transações e transmissões desta carteira usam um endpoint próprio em uma rede, como um nó pessoal ou uma mempool privada, em vez do endpoint da rede.",
+		"rpc_override_none":           "Nenhuma rede configurada.",
+		"rpc_override_default":        "padrão da rede",
+		"rpc_override_endpoint":       "Endpoint para %s (chain %d); deixe vazio para usar o da rede",
+		"rpc_override_placeholder":    "https://meu-no.exemplo:8545",
+		"rpc_override_saved":          "%s agora usa %s",
+		"rpc_override_removed":        "%s voltou a usar o endpoint da rede",
+		"rpc_override_failed":         "Não foi possível salvar o endpoint: %v",
+		"rpc_override_help":           "↑/↓: selecionar • enter: editar • d: usar o endpoint da rede • esc: voltar aos detalhes",
+		"rpc_override_help_editing":   "enter: salvar • esc: cancelar",
+		"wallet_details_rpc_override": "endpoint próprio",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"rpc_override_title":          "Endpoints RPC de la Cartera",
+		"rpc_override_wallet":         "Cartera \"%s\" (%s)",
+		"rpc_override_desc":           "Los saldos, el seguimiento de transacciones y las transmisiones de esta cartera usan un endpoint propio en una red, como un nodo personal o una mempool privada, en lugar del endpoint de la red.",
+		"rpc_override_none":           "No hay redes configuradas.",
+		"rpc_override_default":        "predeterminado de la red",
+		"rpc_override_endpoint":       "Endpoint para %s (chain %d); déjelo vacío para usar el de la red",
+		"rpc_override_placeholder":    "https://mi-nodo.ejemplo:8545",
+		"rpc_override_saved":          "%s ahora usa %s",
+		"rpc_override_removed":        "%s vuelve a usar el endpoint de la red",
+		"rpc_override_failed":         "No se pudo guardar el endpoint: %v",
+		"rpc_override_help":           "↑/↓: seleccionar • enter: editar • d: usar el endpoint de la red • esc: volver a los detalles",
+		"rpc_override_help_editing":   "enter: guardar • esc: cancelar",
+		"wallet_details_rpc_override": "endpoint propio",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package localization
+
+// AddSafeMessages adds the messages of the Safe owners import and of watch-only wallets to the Labels map
+func AddSafeMessages() {
+	// English messages
+	english := map[string]string{
+		"import_safe_owners":      "Safe Owners",
+		"import_safe_owners_desc": "Add the signers of a Safe multisig as watch-only wallets",
+		"watch_only":              "Watch-only",
+		"safe_title":              "Import Safe Owners",
+		"safe_desc":               "The owners of the Safe are read from the network. Owners whose key is already a local wallet are tagged with the Safe; the others are added as watch-only wallets, which hold no key and cannot sign.",
+		"safe_address":            "Safe address",
+		"safe_label":              "Label (optional, stored as a reference of each owner)",
+		"safe_label_placeholder":  "e.g. treasury",
+		"safe_invalid_address":    "Enter the 0x address of the Safe",
+		"safe_fetching":           "Reading the owners on %s…",
+		"safe_fetch_failed":       "Could not read the owners: %v",
+		"safe_summary":            "Safe %s: %d of %d owners must confirm",
+		"safe_owner_new":          "new watch-only wallet",
+		"safe_owner_local":        "local key: %s",
+		"safe_owner_watched":      "watched: %s",
+		"safe_imported":           "%d watch-only wallets added, %d wallets tagged with the Safe",
+		"safe_import_failed":      "Import stopped: %v (%d wallets added)",
+		"safe_help":               "tab: next field • ←/→: network • enter: read the owners • esc: back",
+		"safe_help_review":        "enter: import • backspace: change the Safe • esc: back",
+		"safe_help_done":          "enter: import another Safe • esc: back",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"import_safe_owners":      "Donos de uma Safe",
+		"import_safe_owners_desc": "Adicionar os signatários de uma multisig Safe como carteiras somente leitura",
+		"watch_only":              "Somente leitura",
+		"safe_title":              "Importar Donos da Safe",
+		"safe_desc":               "Os donos da Safe são lidos da rede. Donos cuja chave já é uma carteira local recebem a referência da Safe; os demais são adicionados como carteiras somente leitura, que não guardam chave e não podem assinar.",
+		"safe_address":            "Endereço da Safe",
+		"safe_label":              "Rótulo (opcional, gravado como referência de cada dono)",
+		"safe_label_placeholder":  "ex.: tesouraria",
+		"safe_invalid_address":    "Digite o endereço 0x da Safe",
+		"safe_fetching":           "Lendo os donos em %s…",
+		"safe_fetch_failed":       "Não foi possível ler os donos: %v",
+		"safe_summary":            "Safe %s: %d de %d donos precisam confirmar",
+		"safe_owner_new":          "nova carteira somente leitura",
+		"safe_owner_local":        "chave local: %s",
+		"safe_owner_watched":      "acompanhada: %s",
+		"safe_imported":           "%d carteiras somente leitura adicionadas, %d carteiras marcadas com a Safe",
+		"safe_import_failed":      "Importação interrompida: %v (%d carteiras adicionadas)",
+		"safe_help":               "tab: próximo campo • ←/→: rede • enter: ler os donos • esc: voltar",
+		"safe_help_review":        "enter: importar • backspace: trocar a Safe • esc: voltar",
+		"safe_help_done":          "enter: importar outra Safe • esc: voltar",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"import_safe_owners":      "Dueños de una Safe",
+		"import_safe_owners_desc": "Agregar los firmantes de una multisig Safe como carteras de solo lectura",
+		"watch_only":              "Solo lectura",
+		"safe_title":              "Importar Dueños de la Safe",
+		"safe_desc":               "Los dueños de la Safe se leen de la red. Los dueños cuya clave ya es una cartera local reciben la referencia de la Safe; los demás se agregan como carteras de solo lectura, que no guardan clave y no pueden firmar.",
+		"safe_address":            "Dirección de la Safe",
+		"safe_label":              "Etiqueta (opcional, guardada como referencia de cada dueño)",
+		"safe_label_placeholder":  "p. ej. tesorería",
+		"safe_invalid_address":    "Ingrese la dirección 0x de la Safe",
+		"safe_fetching":           "Leyendo los dueños en %s…",
+		"safe_fetch_failed":       "No se pudieron leer los dueños: %v",
+		"safe_summary":            "Safe %s: %d de %d dueños deben confirmar",
+		"safe_owner_new":          "nueva cartera de solo lectura",
+		"safe_owner_local":        "clave local: %s",
+		"safe_owner_watched":      "seguida: %s",
+		"safe_imported":           "%d carteras de solo lectura agregadas, %d carteras marcadas con la Safe",
+		"safe_import_failed":      "Importación detenida: %v (%d carteras agregadas)",
+		"safe_help":               "tab: siguiente campo • ←/→: red • enter: leer los dueños • esc: volver",
+		"safe_help_review":        "enter: importar • backspace: cambiar la Safe • esc: volver",
+		"safe_help_done":          "enter: importar otra Safe • esc: volver",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
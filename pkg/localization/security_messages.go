@@ -0,0 +1,66 @@
+package localization
+
+// AddSecurityMessages adds file permission, network quarantine and integrity
+// warning messages to the Labels map
+func AddSecurityMessages() {
+	// English messages
+	english := map[string]string{
+		"security_warning_title":       "Security Warning",
+		"security_warning_desc":        "The following wallet files are accessible to other users and their permissions could not be fixed automatically:",
+		"security_warning_fix_unix":    "Restrict them manually with: chmod 600 <file> (chmod 700 for directories)",
+		"security_warning_fix_windows": "Restrict them manually with: icacls <file> /inheritance:r /grant:r %USERNAME%:F",
+		"security_warning_help":        "Press enter to continue",
+		"security_quarantine_desc":     "Strict RPC mode quarantined these networks because their endpoint failed verification; they are not used until they pass again:",
+		"security_quarantine_fix":      "Check the endpoint and its DNS, then press v on the network in Networks to verify it again.",
+		"security_integrity_desc":      "This copy of bloco-wallet was modified after it was built. Do not unlock wallets with it:",
+		"security_integrity_fix":       "Download the release again from a trusted source and check its published checksum.",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"security_warning_title":       "Aviso de Segurança",
+		"security_warning_desc":        "Os seguintes arquivos de carteira estão acessíveis a outros usuários e suas permissões não puderam ser corrigidas automaticamente:",
+		"security_warning_fix_unix":    "Restrinja-os manualmente com: chmod 600 <arquivo> (chmod 700 para diretórios)",
+		"security_warning_fix_windows": "Restrinja-os manualmente com: icacls <arquivo> /inheritance:r /grant:r %USERNAME%:F",
+		"security_warning_help":        "Pressione enter para continuar",
+		"security_quarantine_desc":     "O modo RPC estrito colocou estas redes em quarentena porque seu endpoint falhou na verificação; elas não são usadas até passarem novamente:",
+		"security_quarantine_fix":      "Confira o endpoint e seu DNS e pressione v sobre a rede em Redes para verificá-la novamente.",
+		"security_integrity_desc":      "Esta cópia do bloco-wallet foi alterada depois do build. Não desbloqueie carteiras com ela:",
+		"security_integrity_fix":       "Baixe a versão novamente de uma fonte confiável e confira o checksum publicado.",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"security_warning_title":       "Advertencia de Seguridad",
+		"security_warning_desc":        "Los siguientes archivos de billetera son accesibles para otros usuarios y sus permisos no pudieron corregirse automáticamente:",
+		"security_warning_fix_unix":    "Restríngelos manualmente con: chmod 600 <archivo> (chmod 700 para directorios)",
+		"security_warning_fix_windows": "Restríngelos manualmente con: icacls <archivo> /inheritance:r /grant:r %USERNAME%:F",
+		"security_warning_help":        "Presiona enter para continuar",
+		"security_quarantine_desc":     "El modo RPC estricto puso en cuarentena estas redes porque su endpoint falló la verificación; no se usan hasta que la pasen de nuevo:",
+		"security_quarantine_fix":      "Revisa el endpoint y su DNS, luego presiona v sobre la red en Redes para verificarla de nuevo.",
+		"security_integrity_desc":      "Esta copia de bloco-wallet fue modificada después de compilarse. No desbloquees billeteras con ella:",
+		"security_integrity_fix":       "Descarga la versión de nuevo desde una fuente de confianza y verifica su checksum publicado.",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package localization
+
+import "testing"
+
+func TestAddSecurityMessages(t *testing.T) {
+	testCases := []struct {
+		name     string
+		lang     string
+		key      string
+		expected string
+	}{
+		{"EN title", "en", "security_warning_title", "Security Warning"},
+		{"PT title", "pt", "security_warning_title", "Aviso de Segurança"},
+		{"ES title", "es", "security_warning_title", "Advertencia de Seguridad"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			SetCurrentLanguage(tc.lang)
+			Labels = make(map[string]string)
+			AddSecurityMessages()
+			if Labels[tc.key] != tc.expected {
+				t.Fatalf("expected %q, got %q for key %q in lang %q", tc.expected, Labels[tc.key], tc.key, tc.lang)
+			}
+		})
+	}
+	SetCurrentLanguage("en")
+}
@@ -0,0 +1,68 @@
+package localization
+
+// AddSendMessages adds the messages of the screen that sends funds to the Labels map
+func AddSendMessages() {
+	// English messages
+	english := map[string]string{
+		"send_title":          "Send",
+		"send_desc":           "Sends from this wallet through the network. The nonce, gas and fees are read from the network and shown for review before anything is sent.",
+		"send_network":        "Network (←/→)",
+		"send_no_networks":    "No active network with an RPC endpoint",
+		"send_preparing":      "Reading nonce, gas and fees from %s…",
+		"send_prepare_failed": "Could not prepare the transfer: %v",
+		"send_failed":         "Sending failed: %v",
+		"send_help":           "tab: next field • enter: review • esc: back",
+		"send_help_review":    "enter: sign and send • backspace: change the transfer • esc: back",
+		"send_help_sent":      "enter: send another • esc: back to the details",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"send_title":          "Enviar",
+		"send_desc":           "Envia desta carteira pela rede. O nonce, o gas e as taxas são lidos da rede e mostrados para revisão antes de qualquer envio.",
+		"send_network":        "Rede (←/→)",
+		"send_no_networks":    "Nenhuma rede ativa com endpoint RPC",
+		"send_preparing":      "Lendo nonce, gas e taxas de %s…",
+		"send_prepare_failed": "Não foi possível preparar a transferência: %v",
+		"send_failed":         "Falha no envio: %v",
+		"send_help":           "tab: próximo campo • enter: revisar • esc: voltar",
+		"send_help_review":    "enter: assinar e enviar • backspace: alterar a transferência • esc: voltar",
+		"send_help_sent":      "enter: enviar outra • esc: voltar aos detalhes",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"send_title":          "Enviar",
+		"send_desc":           "Envía desde esta cartera por la red. El nonce, el gas y las comisiones se leen de la red y se muestran para revisión antes de enviar nada.",
+		"send_network":        "Red (←/→)",
+		"send_no_networks":    "Ninguna red activa con endpoint RPC",
+		"send_preparing":      "Leyendo nonce, gas y comisiones de %s…",
+		"send_prepare_failed": "No se pudo preparar la transferencia: %v",
+		"send_failed":         "Falló el envío: %v",
+		"send_help":           "tab: siguiente campo • enter: revisar • esc: volver",
+		"send_help_review":    "enter: firmar y enviar • backspace: cambiar la transferencia • esc: volver",
+		"send_help_sent":      "enter: enviar otra • esc: volver a los detalles",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
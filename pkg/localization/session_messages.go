@@ -0,0 +1,62 @@
+package localization
+
+// AddSessionMessages adds the messages of the summary shown when quitting
+func AddSessionMessages() {
+	// English messages
+	english := map[string]string{
+		"session_title":     "Session Summary",
+		"session_created":   "Wallets created: %d",
+		"session_imported":  "Wallets imported: %d",
+		"session_errors":    "Errors: %d",
+		"session_elapsed":   "Time spent: %s",
+		"session_cancelled": "Jobs cancelled: %d",
+		"session_running":   "These background jobs are still running and will be cancelled:",
+		"session_help":      "enter/q: quit • esc: back",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"session_title":     "Resumo da Sessão",
+		"session_created":   "Carteiras criadas: %d",
+		"session_imported":  "Carteiras importadas: %d",
+		"session_errors":    "Erros: %d",
+		"session_elapsed":   "Tempo de uso: %s",
+		"session_cancelled": "Tarefas canceladas: %d",
+		"session_running":   "Estas tarefas em segundo plano ainda estão em execução e serão canceladas:",
+		"session_help":      "enter/q: sair • esc: voltar",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"session_title":     "Resumen de la Sesión",
+		"session_created":   "Carteras creadas: %d",
+		"session_imported":  "Carteras importadas: %d",
+		"session_errors":    "Errores: %d",
+		"session_elapsed":   "Tiempo de uso: %s",
+		"session_cancelled": "Tareas canceladas: %d",
+		"session_running":   "Estas tareas en segundo plano siguen en ejecución y se cancelarán:",
+		"session_help":      "enter/q: salir • esc: volver",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
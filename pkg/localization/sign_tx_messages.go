@@ -0,0 +1,140 @@
+package localization
+
+// AddSignTxMessages adds the messages of the sign-only transaction screen to the Labels map
+func AddSignTxMessages() {
+	// English messages
+	english := map[string]string{
+		"sign_tx_title":              "Sign Transaction",
+		"sign_tx_from":               "From \"%s\" (%s)",
+		"sign_tx_desc":               "Signs an EIP-1559 transaction without sending it. Broadcast the result later from an online machine or another tool.",
+		"sign_tx_chain_id":           "Chain ID (←/→)",
+		"sign_tx_to":                 "To",
+		"sign_tx_amount":             "Amount",
+		"sign_tx_data":               "Data (hex)",
+		"sign_tx_data_placeholder":   "optional, e.g. 0xa9059cbb…",
+		"sign_tx_nonce":              "Nonce",
+		"sign_tx_gas_limit":          "Gas limit",
+		"sign_tx_max_fee":            "Max fee (gwei)",
+		"sign_tx_tip":                "Priority fee (gwei)",
+		"sign_tx_output":             "Save to file",
+		"sign_tx_output_placeholder": "optional; .png saves a QR image, anything else the hex",
+		"sign_tx_invalid_chain":      "The chain ID must be a whole number",
+		"sign_tx_invalid_to":         "The recipient is not a valid address",
+		"sign_tx_invalid_data":       "The data is not valid hex",
+		"sign_tx_invalid_nonce":      "The nonce must be a whole number",
+		"sign_tx_invalid_gas":        "The gas limit must be a whole number",
+		"sign_tx_failed":             "Signing failed: %v",
+		"sign_tx_write_failed":       "Signed, but the file could not be written: %v",
+		"sign_tx_written":            "Signed and saved to %s",
+		"sign_tx_signed":             "Signed. Nothing was sent to the network.",
+		"sign_tx_qr_too_long":        "Signed. The transaction is too long for a QR code.",
+		"sign_tx_qr_too_wide":        "Widen the terminal to show the QR code.",
+		"sign_tx_no_network":         "No configured network with an RPC endpoint has this chain ID",
+		"sign_tx_filling":            "Reading nonce and fees from %s…",
+		"sign_tx_fill_failed":        "Could not read from the network: %v",
+		"sign_tx_filled":             "Nonce and fees filled in from the network",
+		"sign_tx_summary":            "To %s\nAmount %s %s • nonce %d • chain %d\nGas limit %d • max fee %s gwei • priority fee %s gwei\nMax cost %s %s",
+		"sign_tx_hash":               "Transaction hash %s",
+		"sign_tx_raw":                "Signed raw transaction:",
+		"sign_tx_help":               "tab: next field • ctrl+f: fill nonce and fees from the network • enter: sign • esc: back",
+		"sign_tx_help_done":          "enter: sign another • esc: back to the details",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"sign_tx_title":              "Assinar Transação",
+		"sign_tx_from":               "De \"%s\" (%s)",
+		"sign_tx_desc":               "Assina uma transação EIP-1559 sem enviá-la. Transmita o resultado depois, de uma máquina online ou outra ferramenta.",
+		"sign_tx_chain_id":           "Chain ID (←/→)",
+		"sign_tx_to":                 "Para",
+		"sign_tx_amount":             "Valor",
+		"sign_tx_data":               "Dados (hex)",
+		"sign_tx_data_placeholder":   "opcional, ex.: 0xa9059cbb…",
+		"sign_tx_nonce":              "Nonce",
+		"sign_tx_gas_limit":          "Limite de gas",
+		"sign_tx_max_fee":            "Taxa máxima (gwei)",
+		"sign_tx_tip":                "Taxa de prioridade (gwei)",
+		"sign_tx_output":             "Salvar em arquivo",
+		"sign_tx_output_placeholder": "opcional; .png salva um QR, qualquer outro o hex",
+		"sign_tx_invalid_chain":      "O chain ID deve ser um número inteiro",
+		"sign_tx_invalid_to":         "O destinatário não é um endereço válido",
+		"sign_tx_invalid_data":       "Os dados não são hex válido",
+		"sign_tx_invalid_nonce":      "O nonce deve ser um número inteiro",
+		"sign_tx_invalid_gas":        "O limite de gas deve ser um número inteiro",
+		"sign_tx_failed":             "Falha ao assinar: %v",
+		"sign_tx_write_failed":       "Assinada, mas o arquivo não pôde ser gravado: %v",
+		"sign_tx_written":            "Assinada e salva em %s",
+		"sign_tx_signed":             "Assinada. Nada foi enviado à rede.",
+		"sign_tx_qr_too_long":        "Assinada. A transação é longa demais para um QR code.",
+		"sign_tx_qr_too_wide":        "Aumente o terminal para exibir o QR code.",
+		"sign_tx_no_network":         "Nenhuma rede configurada com endpoint RPC tem este chain ID",
+		"sign_tx_filling":            "Lendo nonce e taxas de %s…",
+		"sign_tx_fill_failed":        "Não foi possível ler da rede: %v",
+		"sign_tx_filled":             "Nonce e taxas preenchidos a partir da rede",
+		"sign_tx_summary":            "Para %s\nValor %s %s • nonce %d • chain %d\nLimite de gas %d • taxa máxima %s gwei • taxa de prioridade %s gwei\nCusto máximo %s %s",
+		"sign_tx_hash":               "Hash da transação %s",
+		"sign_tx_raw":                "Transação assinada:",
+		"sign_tx_help":               "tab: próximo campo • ctrl+f: preencher nonce e taxas pela rede • enter: assinar • esc: voltar",
+		"sign_tx_help_done":          "enter: assinar outra • esc: voltar aos detalhes",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"sign_tx_title":              "Firmar Transacción",
+		"sign_tx_from":               "Desde \"%s\" (%s)",
+		"sign_tx_desc":               "Firma una transacción EIP-1559 sin enviarla. Transmita el resultado más tarde desde una máquina en línea u otra herramienta.",
+		"sign_tx_chain_id":           "Chain ID (←/→)",
+		"sign_tx_to":                 "Para",
+		"sign_tx_amount":             "Monto",
+		"sign_tx_data":               "Datos (hex)",
+		"sign_tx_data_placeholder":   "opcional, p. ej. 0xa9059cbb…",
+		"sign_tx_nonce":              "Nonce",
+		"sign_tx_gas_limit":          "Límite de gas",
+		"sign_tx_max_fee":            "Tarifa máxima (gwei)",
+		"sign_tx_tip":                "Tarifa de prioridad (gwei)",
+		"sign_tx_output":             "Guardar en archivo",
+		"sign_tx_output_placeholder": "opcional; .png guarda un QR, cualquier otro el hex",
+		"sign_tx_invalid_chain":      "El chain ID debe ser un número entero",
+		"sign_tx_invalid_to":         "El destinatario no es una dirección válida",
+		"sign_tx_invalid_data":       "Los datos no son hex válido",
+		"sign_tx_invalid_nonce":      "El nonce debe ser un número entero",
+		"sign_tx_invalid_gas":        "El límite de gas debe ser un número entero",
+		"sign_tx_failed":             "Error al firmar: %v",
+		"sign_tx_write_failed":       "Firmada, pero no se pudo escribir el archivo: %v",
+		"sign_tx_written":            "Firmada y guardada en %s",
+		"sign_tx_signed":             "Firmada. No se envió nada a la red.",
+		"sign_tx_qr_too_long":        "Firmada. La transacción es demasiado larga para un código QR.",
+		"sign_tx_qr_too_wide":        "Amplíe la terminal para mostrar el código QR.",
+		"sign_tx_no_network":         "Ninguna red configurada con endpoint RPC tiene este chain ID",
+		"sign_tx_filling":            "Leyendo nonce y tarifas de %s…",
+		"sign_tx_fill_failed":        "No se pudo leer de la red: %v",
+		"sign_tx_filled":             "Nonce y tarifas completados desde la red",
+		"sign_tx_summary":            "Para %s\nMonto %s %s • nonce %d • chain %d\nLímite de gas %d • tarifa máxima %s gwei • tarifa de prioridad %s gwei\nCosto máximo %s %s",
+		"sign_tx_hash":               "Hash de la transacción %s",
+		"sign_tx_raw":                "Transacción firmada:",
+		"sign_tx_help":               "tab: siguiente campo • ctrl+f: completar nonce y tarifas desde la red • enter: firmar • esc: volver",
+		"sign_tx_help_done":          "enter: firmar otra • esc: volver a los detalles",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package localization
+
+// AddStoreCheckMessages adds the messages of the wallet store check to the Labels map
+func AddStoreCheckMessages() {
+	// English messages
+	english := map[string]string{
+		"store_check":                           "Verify integrity",
+		"store_check_menu_desc":                 "Cross-check the wallet database with the keystore directory",
+		"store_check_title":                     "Verify Integrity",
+		"store_check_summary":                   "%s, %s in %s",
+		"store_check_ok":                        "The database and the keystore directory agree.",
+		"store_check_failed":                    "Cannot check the wallet store: %v",
+		"store_check_issue_missing_keystore":    "Missing keystore: %s (%s)",
+		"store_check_issue_orphan_keystore":     "Orphan keystore: %s (%s)",
+		"store_check_issue_address_mismatch":    "Address mismatch: %s (%s)",
+		"store_check_issue_unreadable_keystore": "Unreadable keystore: %s (%s)",
+		"store_check_candidate":                 "Keystore found with the same address: %s",
+		"store_check_no_repair":                 "Nothing to repair here; delete or move the file by hand.",
+		"store_check_reimport_desc":             "Import the orphan keystore as a new wallet:",
+		"store_check_name":                      "Wallet name:",
+		"store_check_name_placeholder":          "Leave empty to name it after the address",
+		"store_check_password":                  "Keystore password:",
+		"store_check_default_name":              "Recovered %s",
+		"store_check_repair_failed":             "Repair failed: %v",
+		"store_check_repaired_relink":           "The wallet points at the keystore found.",
+		"store_check_repaired_reimport":         "The keystore was imported as a new wallet.",
+		"store_check_repaired_remove":           "The wallet was removed from the database.",
+		"store_check_repaired_quarantine":       "The wallet and its keystore were moved to the quarantine.",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"store_check":                           "Verificar integridade",
+		"store_check_menu_desc":                 "Conferir o banco de dados das carteiras com o diretório de keystores",
+		"store_check_title":                     "Verificar Integridade",
+		"store_check_summary":                   "%s, %s em %s",
+		"store_check_ok":                        "O banco de dados e o diretório de keystores conferem.",
+		"store_check_failed":                    "Não foi possível verificar as carteiras: %v",
+		"store_check_issue_missing_keystore":    "Keystore ausente: %s (%s)",
+		"store_check_issue_orphan_keystore":     "Keystore órfã: %s (%s)",
+		"store_check_issue_address_mismatch":    "Endereço divergente: %s (%s)",
+		"store_check_issue_unreadable_keystore": "Keystore ilegível: %s (%s)",
+		"store_check_candidate":                 "Keystore encontrada com o mesmo endereço: %s",
+		"store_check_no_repair":                 "Nada a reparar aqui; apague ou mova o arquivo manualmente.",
+		"store_check_reimport_desc":             "Importar a keystore órfã como uma nova carteira:",
+		"store_check_name":                      "Nome da carteira:",
+		"store_check_name_placeholder":          "Deixe vazio para usar o endereço",
+		"store_check_password":                  "Senha da keystore:",
+		"store_check_default_name":              "Recuperada %s",
+		"store_check_repair_failed":             "Falha no reparo: %v",
+		"store_check_repaired_relink":           "A carteira aponta para a keystore encontrada.",
+		"store_check_repaired_reimport":         "A keystore foi importada como uma nova carteira.",
+		"store_check_repaired_remove":           "A carteira foi removida do banco de dados.",
+		"store_check_repaired_quarantine":       "A carteira e a sua keystore foram movidas para a quarentena.",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"store_check":                           "Verificar integridad",
+		"store_check_menu_desc":                 "Cotejar la base de datos de carteras con el directorio de keystores",
+		"store_check_title":                     "Verificar Integridad",
+		"store_check_summary":                   "%s, %s en %s",
+		"store_check_ok":                        "La base de datos y el directorio de keystores coinciden.",
+		"store_check_failed":                    "No se pueden verificar las carteras: %v",
+		"store_check_issue_missing_keystore":    "Keystore ausente: %s (%s)",
+		"store_check_issue_orphan_keystore":     "Keystore huérfana: %s (%s)",
+		"store_check_issue_address_mismatch":    "Dirección distinta: %s (%s)",
+		"store_check_issue_unreadable_keystore": "Keystore ilegible: %s (%s)",
+		"store_check_candidate":                 "Keystore encontrada con la misma dirección: %s",
+		"store_check_no_repair":                 "Nada que reparar aquí; borre o mueva el archivo manualmente.",
+		"store_check_reimport_desc":             "Importar la keystore huérfana como una nueva cartera:",
+		"store_check_name":                      "Nombre de la cartera:",
+		"store_check_name_placeholder":          "Déjelo vacío para usar la dirección",
+		"store_check_password":                  "Contraseña de la keystore:",
+		"store_check_default_name":              "Recuperada %s",
+		"store_check_repair_failed":             "Falló la reparación: %v",
+		"store_check_repaired_relink":           "La cartera apunta a la keystore encontrada.",
+		"store_check_repaired_reimport":         "La keystore se importó como una nueva cartera.",
+		"store_check_repaired_remove":           "La cartera se eliminó de la base de datos.",
+		"store_check_repaired_quarantine":       "La cartera y su keystore se movieron a la cuarentena.",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package localization
+
+// AddSyncMessages adds metadata sync messages to the Labels map
+func AddSyncMessages() {
+	// English messages
+	english := map[string]string{
+		"sync":                    "Sync",
+		"sync_menu_desc":          "Keep wallet names and networks consistent across installations",
+		"sync_title":              "Metadata Sync",
+		"sync_desc":               "Wallet names and networks are encrypted with your sync passphrase and shared with your other installations.",
+		"sync_not_configured":     "Sync is not configured. Set backend and url in the [sync] section of config.toml and the BLOCO_WALLET_SYNC_PASSPHRASE environment variable.",
+		"sync_target":             "Target: %s",
+		"sync_keystores_local":    "Keystores: kept on this machine only",
+		"sync_keystores_included": "Keystores: included (still protected by their passwords)",
+		"sync_last":               "Last sync: %s",
+		"sync_never":              "never",
+		"sync_running":            "Syncing...",
+		"sync_success":            "Synced %d wallets and %d networks (%d renamed, %d keystores imported, %d only on other installations)",
+		"sync_conflicts":          "Changed on both sides (kept the latest/local version): %s",
+		"sync_help":               "enter: sync now • esc: back",
+		"sync_help_back":          "esc: back",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"sync":                    "Sincronização",
+		"sync_menu_desc":          "Manter nomes de carteiras e redes iguais entre instalações",
+		"sync_title":              "Sincronização de Metadados",
+		"sync_desc":               "Nomes de carteiras e redes são criptografados com sua frase de sincronização e compartilhados com suas outras instalações.",
+		"sync_not_configured":     "A sincronização não está configurada. Defina backend e url na seção [sync] do config.toml e a variável de ambiente BLOCO_WALLET_SYNC_PASSPHRASE.",
+		"sync_target":             "Destino: %s",
+		"sync_keystores_local":    "Keystores: mantidos apenas nesta máquina",
+		"sync_keystores_included": "Keystores: incluídos (continuam protegidos por suas senhas)",
+		"sync_last":               "Última sincronização: %s",
+		"sync_never":              "nunca",
+		"sync_running":            "Sincronizando...",
+		"sync_success":            "%d carteiras e %d redes sincronizadas (%d renomeadas, %d keystores importados, %d apenas em outras instalações)",
+		"sync_conflicts":          "Alterados nos dois lados (mantida a versão mais recente/local): %s",
+		"sync_help":               "enter: sincronizar agora • esc: voltar",
+		"sync_help_back":          "esc: voltar",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"sync":                    "Sincronización",
+		"sync_menu_desc":          "Mantener nombres de billeteras y redes iguales entre instalaciones",
+		"sync_title":              "Sincronización de Metadatos",
+		"sync_desc":               "Los nombres de billeteras y las redes se cifran con su frase de sincronización y se comparten con sus otras instalaciones.",
+		"sync_not_configured":     "La sincronización no está configurada. Defina backend y url en la sección [sync] de config.toml y la variable de entorno BLOCO_WALLET_SYNC_PASSPHRASE.",
+		"sync_target":             "Destino: %s",
+		"sync_keystores_local":    "Keystores: solo en esta máquina",
+		"sync_keystores_included": "Keystores: incluidos (siguen protegidos por sus contraseñas)",
+		"sync_last":               "Última sincronización: %s",
+		"sync_never":              "nunca",
+		"sync_running":            "Sincronizando...",
+		"sync_success":            "%d billeteras y %d redes sincronizadas (%d renombradas, %d keystores importados, %d solo en otras instalaciones)",
+		"sync_conflicts":          "Cambiados en ambos lados (se mantuvo la versión más reciente/local): %s",
+		"sync_help":               "enter: sincronizar ahora • esc: volver",
+		"sync_help_back":          "esc: volver",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
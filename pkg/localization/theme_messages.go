@@ -0,0 +1,65 @@
+package localization
+
+// AddThemeMessages adds the messages of the theme picker to the Labels map
+func AddThemeMessages() {
+	// English messages
+	english := map[string]string{
+		"theme":             "Theme",
+		"theme_menu_desc":   "Choose the color scheme of the interface",
+		"theme_title":       "Theme",
+		"theme_user":        "(file)",
+		"theme_current":     "(in use)",
+		"theme_dir":         "Theme files: %s",
+		"theme_saved":       "Theme %s saved.",
+		"theme_save_failed": "Cannot save the theme: %v",
+		"theme_invalid":     "Cannot use %s: %v",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"theme":             "Tema",
+		"theme_menu_desc":   "Escolher o esquema de cores da interface",
+		"theme_title":       "Tema",
+		"theme_user":        "(arquivo)",
+		"theme_current":     "(em uso)",
+		"theme_dir":         "Arquivos de tema: %s",
+		"theme_saved":       "Tema %s salvo.",
+		"theme_save_failed": "Não foi possível salvar o tema: %v",
+		"theme_invalid":     "Não foi possível usar %s: %v",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"theme":             "Tema",
+		"theme_menu_desc":   "Elegir el esquema de colores de la interfaz",
+		"theme_title":       "Tema",
+		"theme_user":        "(archivo)",
+		"theme_current":     "(en uso)",
+		"theme_dir":         "Archivos de tema: %s",
+		"theme_saved":       "Tema %s guardado.",
+		"theme_save_failed": "No se puede guardar el tema: %v",
+		"theme_invalid":     "No se puede usar %s: %v",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package localization
+
+// AddTokenMessages adds the messages of the ERC-20 token screens to the Labels map
+func AddTokenMessages() {
+	// English messages
+	english := map[string]string{
+		"tokens_title":          "Tokens",
+		"tokens_desc":           "ERC-20 tokens followed on each network. Their balances are shown in the wallet details of every wallet, and they can be picked as the asset to send.",
+		"tokens_none":           "No tokens followed yet",
+		"tokens_contract":       "Token contract",
+		"tokens_reading":        "Reading the symbol and decimals from %s…",
+		"tokens_add_failed":     "Could not add the token: %v",
+		"tokens_added":          "Following %s (%d decimals)",
+		"tokens_removed":        "No longer following %s",
+		"tokens_unsupported":    "The wallet repository does not keep tokens",
+		"tokens_help":           "↑/↓: select • a: add a token • d: remove • b: block • r: review hidden • esc: back",
+		"tokens_help_add":       "←/→: network • enter: add • esc: cancel",
+		"tokens_hidden":         "%d tokens hidden as spam or blocked • r: review",
+		"tokens_review_title":   "Hidden tokens",
+		"tokens_review_desc":    "Tokens flagged as spam (known scam contracts, or symbols luring to a site) are hidden from the list, the balances and the assets to send. Unhide the ones you trust, or block a contract so it is never followed again.",
+		"tokens_review_none":    "No token was flagged or reviewed",
+		"tokens_help_review":    "↑/↓: select • u: unhide • b: block • c: clear the review • esc: back",
+		"tokens_allowed":        "%s is shown again",
+		"tokens_blocked":        "%s is blocked",
+		"tokens_reset":          "%s is left to the spam detection",
+		"tokens_state_allowed":  "unhidden",
+		"tokens_state_blocked":  "blocked",
+		"tokens_spam_listed":    "known spam contract",
+		"tokens_spam_url":       "symbol advertises a site",
+		"tokens_spam_lure":      "symbol invites to claim",
+		"tokens_spam_lookalike": "look-alike letters",
+		"send_asset":            "Asset (←/→)",
+		"send_token_transfer":   "Token transfer: %s %s to %s",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"tokens_title":          "Tokens",
+		"tokens_desc":           "Tokens ERC-20 acompanhados em cada rede. Seus saldos aparecem nos detalhes de todas as carteiras, e eles podem ser escolhidos como o ativo a enviar.",
+		"tokens_none":           "Nenhum token acompanhado ainda",
+		"tokens_contract":       "Contrato do token",
+		"tokens_reading":        "Lendo o símbolo e as casas decimais em %s…",
+		"tokens_add_failed":     "Não foi possível adicionar o token: %v",
+		"tokens_added":          "Acompanhando %s (%d casas decimais)",
+		"tokens_removed":        "%s não é mais acompanhado",
+		"tokens_unsupported":    "O repositório de carteiras não guarda tokens",
+		"tokens_help":           "↑/↓: selecionar • a: adicionar um token • d: remover • b: bloquear • r: revisar ocultos • esc: voltar",
+		"tokens_help_add":       "←/→: rede • enter: adicionar • esc: cancelar",
+		"tokens_hidden":         "%d tokens ocultos como spam ou bloqueados • r: revisar",
+		"tokens_review_title":   "Tokens ocultos",
+		"tokens_review_desc":    "Tokens marcados como spam (contratos de golpe conhecidos, ou símbolos que atraem para um site) ficam fora da lista, dos saldos e dos ativos a enviar. Mostre de novo os que você confia, ou bloqueie um contrato para que nunca mais seja acompanhado.",
+		"tokens_review_none":    "Nenhum token foi marcado ou revisado",
+		"tokens_help_review":    "↑/↓: selecionar • u: mostrar • b: bloquear • c: limpar a revisão • esc: voltar",
+		"tokens_allowed":        "%s voltou a ser mostrado",
+		"tokens_blocked":        "%s foi bloqueado",
+		"tokens_reset":          "%s fica a cargo da detecção de spam",
+		"tokens_state_allowed":  "mostrado",
+		"tokens_state_blocked":  "bloqueado",
+		"tokens_spam_listed":    "contrato de spam conhecido",
+		"tokens_spam_url":       "símbolo anuncia um site",
+		"tokens_spam_lure":      "símbolo convida a resgatar",
+		"tokens_spam_lookalike": "letras parecidas",
+		"send_asset":            "Ativo (←/→)",
+		"send_token_transfer":   "Transferência de token: %s %s para %s",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"tokens_title":          "Tokens",
+		"tokens_desc":           "Tokens ERC-20 seguidos en cada red. Sus saldos se muestran en los detalles de todas las carteras y pueden elegirse como el activo a enviar.",
+		"tokens_none":           "Ningún token seguido todavía",
+		"tokens_contract":       "Contrato del token",
+		"tokens_reading":        "Leyendo el símbolo y los decimales en %s…",
+		"tokens_add_failed":     "No se pudo añadir el token: %v",
+		"tokens_added":          "Siguiendo %s (%d decimales)",
+		"tokens_removed":        "%s ya no se sigue",
+		"tokens_unsupported":    "El repositorio de carteras no guarda tokens",
+		"tokens_help":           "↑/↓: seleccionar • a: añadir un token • d: quitar • b: bloquear • r: revisar ocultos • esc: volver",
+		"tokens_help_add":       "←/→: red • enter: añadir • esc: cancelar",
+		"tokens_hidden":         "%d tokens ocultos como spam o bloqueados • r: revisar",
+		"tokens_review_title":   "Tokens ocultos",
+		"tokens_review_desc":    "Los tokens marcados como spam (contratos de estafa conocidos, o símbolos que atraen a un sitio) quedan fuera de la lista, los saldos y los activos a enviar. Muestre de nuevo los que le merecen confianza, o bloquee un contrato para que nunca vuelva a seguirse.",
+		"tokens_review_none":    "Ningún token fue marcado ni revisado",
+		"tokens_help_review":    "↑/↓: seleccionar • u: mostrar • b: bloquear • c: borrar la revisión • esc: volver",
+		"tokens_allowed":        "%s vuelve a mostrarse",
+		"tokens_blocked":        "%s está bloqueado",
+		"tokens_reset":          "%s queda a cargo de la detección de spam",
+		"tokens_state_allowed":  "mostrado",
+		"tokens_state_blocked":  "bloqueado",
+		"tokens_spam_listed":    "contrato de spam conocido",
+		"tokens_spam_url":       "el símbolo anuncia un sitio",
+		"tokens_spam_lure":      "el símbolo invita a reclamar",
+		"tokens_spam_lookalike": "letras parecidas",
+		"send_asset":            "Activo (←/→)",
+		"send_token_transfer":   "Transferencia de token: %s %s a %s",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -61,6 +61,7 @@ func ChangeLanguage(lang string) {
 	}
 
 	localizer = i18n.NewLocalizer(bundle, lang)
+	SetCurrentLanguage(lang)
 
 	// Update the global Labels map to reflect the new language
 	err := populateLabelsMap()
@@ -0,0 +1,71 @@
+package localization
+
+// AddTxPreviewMessages adds the messages of the balance and allowance preview shown before sending to the Labels map
+func AddTxPreviewMessages() {
+	// English messages
+	english := map[string]string{
+		"tx_preview_title":             "Expected changes on %s:",
+		"tx_preview_running":           "Simulating on %s…",
+		"tx_preview_failed":            "Could not preview the changes: %v",
+		"tx_preview_you":               "You",
+		"tx_preview_balance":           "%s  %s %s  (%s → %s)",
+		"tx_preview_allowance":         "%s lets %s spend %s → %s %s",
+		"tx_preview_unlimited":         "unlimited",
+		"tx_preview_insufficient":      "— not enough",
+		"tx_preview_fee_note":          "The native debit counts the whole gas limit at the max fee.",
+		"tx_preview_unlimited_warning": "Unlimited approval: %s can move all of this token, now and later.",
+		"tx_preview_reverted":          "The simulation failed (%s). Sent as is, the transaction will likely revert and still cost gas.",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"tx_preview_title":             "Mudanças esperadas em %s:",
+		"tx_preview_running":           "Simulando em %s…",
+		"tx_preview_failed":            "Não foi possível prever as mudanças: %v",
+		"tx_preview_you":               "Você",
+		"tx_preview_balance":           "%s  %s %s  (%s → %s)",
+		"tx_preview_allowance":         "%s permite que %s gaste %s → %s %s",
+		"tx_preview_unlimited":         "ilimitado",
+		"tx_preview_insufficient":      "— insuficiente",
+		"tx_preview_fee_note":          "O débito nativo considera todo o limite de gas na taxa máxima.",
+		"tx_preview_unlimited_warning": "Aprovação ilimitada: %s pode mover todo este token, agora e depois.",
+		"tx_preview_reverted":          "A simulação falhou (%s). Enviada assim, a transação provavelmente será revertida e ainda custará gas.",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"tx_preview_title":             "Cambios esperados en %s:",
+		"tx_preview_running":           "Simulando en %s…",
+		"tx_preview_failed":            "No se pudieron prever los cambios: %v",
+		"tx_preview_you":               "Usted",
+		"tx_preview_balance":           "%s  %s %s  (%s → %s)",
+		"tx_preview_allowance":         "%s permite que %s gaste %s → %s %s",
+		"tx_preview_unlimited":         "ilimitado",
+		"tx_preview_insufficient":      "— insuficiente",
+		"tx_preview_fee_note":          "El débito nativo cuenta todo el límite de gas a la tarifa máxima.",
+		"tx_preview_unlimited_warning": "Aprobación ilimitada: %s puede mover todo este token, ahora y después.",
+		"tx_preview_reverted":          "La simulación falló (%s). Enviada así, la transacción probablemente se revertirá y aun costará gas.",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package localization
+
+// AddWalletBatchMessages adds the messages of the actions run on the wallets
+// marked in the list: deleting, tagging and refreshing their balances
+func AddWalletBatchMessages() {
+	// English messages
+	english := map[string]string{
+		"wallet_batch_delete_title":     "Delete Selected Wallets",
+		"wallet_batch_tag_title":        "Tag Selected Wallets",
+		"wallet_batch_balances_title":   "Refresh Balances of Selected Wallets",
+		"wallet_batch_wallets":          "%d wallets:",
+		"wallet_batch_more":             "… and %d more",
+		"wallet_batch_no_mnemonic":      "no mnemonic",
+		"wallet_batch_delete_warning":   "%d of these wallets have no mnemonic: their keys exist only in their keystores",
+		"wallet_batch_archive_option":   "Copy their keystores to %s first",
+		"wallet_batch_delete_prompt":    "Type %d to delete them",
+		"wallet_batch_delete_mismatch":  "Type the number of selected wallets to confirm",
+		"wallet_batch_tag_prompt":       "Reference added to each wallet, found by the list search:",
+		"wallet_batch_tag_placeholder":  "e.g. payroll-2026",
+		"wallet_batch_tag_required":     "Enter the reference to add",
+		"wallet_batch_tag_comma":        "A reference cannot contain commas",
+		"wallet_batch_balances_desc":    "The cached balances are dropped and read again on %d networks.",
+		"wallet_batch_form_help":        "enter: start • esc: back to the list",
+		"wallet_batch_delete_help":      "tab: copy on/off • enter: delete • esc: back to the list",
+		"wallet_batch_running_delete":   "Deleting %d wallets…",
+		"wallet_batch_running_tag":      "Tagging %d wallets…",
+		"wallet_batch_running_balances": "Refreshing the balances of %d wallets…",
+		"wallet_batch_running_help":     "esc: stop after the current wallet",
+		"wallet_batch_cancelling":       "Stopping after the current wallet…",
+		"wallet_batch_done":             "Done",
+		"wallet_batch_done_errors":      "Done with errors",
+		"wallet_batch_summary":          "%d succeeded, %d failed, %d not processed",
+		"wallet_batch_done_help":        "enter: back to the list",
+		"wallet_batch_deleted":          "deleted",
+		"wallet_batch_archived":         "deleted, keystore copied to %s",
+		"wallet_batch_tagged":           "tagged %s",
+		"wallet_batch_already_tagged":   "already tagged %s",
+		"wallet_batch_no_funds":         "no funds",
+		"wallet_batch_networks_failed":  "%d networks did not answer",
+		"wallet_batch_all_failed":       "no network answered",
+		"wallet_batch_not_processed":    "not processed",
+		"job_kind_wallet_batch":         "Wallet batch",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"wallet_batch_delete_title":     "Excluir Carteiras Selecionadas",
+		"wallet_batch_tag_title":        "Etiquetar Carteiras Selecionadas",
+		"wallet_batch_balances_title":   "Atualizar Saldos das Carteiras Selecionadas",
+		"wallet_batch_wallets":          "%d carteiras:",
+		"wallet_batch_more":             "… e mais %d",
+		"wallet_batch_no_mnemonic":      "sem mnemônica",
+		"wallet_batch_delete_warning":   "%d destas carteiras não têm mnemônica: suas chaves existem apenas nas keystores",
+		"wallet_batch_archive_option":   "Copiar suas keystores para %s antes",
+		"wallet_batch_delete_prompt":    "Digite %d para excluí-las",
+		"wallet_batch_delete_mismatch":  "Digite a quantidade de carteiras selecionadas para confirmar",
+		"wallet_batch_tag_prompt":       "Referência adicionada a cada carteira, encontrada pela busca da lista:",
+		"wallet_batch_tag_placeholder":  "ex.: folha-2026",
+		"wallet_batch_tag_required":     "Digite a referência a adicionar",
+		"wallet_batch_tag_comma":        "Uma referência não pode conter vírgulas",
+		"wallet_batch_balances_desc":    "Os saldos em cache são descartados e lidos novamente em %d redes.",
+		"wallet_batch_form_help":        "enter: iniciar • esc: voltar para a lista",
+		"wallet_batch_delete_help":      "tab: copiar sim/não • enter: excluir • esc: voltar para a lista",
+		"wallet_batch_running_delete":   "Excluindo %d carteiras…",
+		"wallet_batch_running_tag":      "Etiquetando %d carteiras…",
+		"wallet_batch_running_balances": "Atualizando os saldos de %d carteiras…",
+		"wallet_batch_running_help":     "esc: parar após a carteira atual",
+		"wallet_batch_cancelling":       "Parando após a carteira atual…",
+		"wallet_batch_done":             "Concluído",
+		"wallet_batch_done_errors":      "Concluído com erros",
+		"wallet_batch_summary":          "%d com sucesso, %d com falha, %d não processadas",
+		"wallet_batch_done_help":        "enter: voltar para a lista",
+		"wallet_batch_deleted":          "excluída",
+		"wallet_batch_archived":         "excluída, keystore copiada para %s",
+		"wallet_batch_tagged":           "etiquetada %s",
+		"wallet_batch_already_tagged":   "já etiquetada %s",
+		"wallet_batch_no_funds":         "sem saldo",
+		"wallet_batch_networks_failed":  "%d redes não responderam",
+		"wallet_batch_all_failed":       "nenhuma rede respondeu",
+		"wallet_batch_not_processed":    "não processada",
+		"job_kind_wallet_batch":         "Lote de carteiras",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"wallet_batch_delete_title":     "Eliminar Carteras Seleccionadas",
+		"wallet_batch_tag_title":        "Etiquetar Carteras Seleccionadas",
+		"wallet_batch_balances_title":   "Actualizar Saldos de las Carteras Seleccionadas",
+		"wallet_batch_wallets":          "%d carteras:",
+		"wallet_batch_more":             "… y %d más",
+		"wallet_batch_no_mnemonic":      "sin mnemónica",
+		"wallet_batch_delete_warning":   "%d de estas carteras no tienen mnemónica: sus claves existen solo en sus keystores",
+		"wallet_batch_archive_option":   "Copiar sus keystores a %s antes",
+		"wallet_batch_delete_prompt":    "Escriba %d para eliminarlas",
+		"wallet_batch_delete_mismatch":  "Escriba el número de carteras seleccionadas para confirmar",
+		"wallet_batch_tag_prompt":       "Referencia añadida a cada cartera, encontrada por la búsqueda de la lista:",
+		"wallet_batch_tag_placeholder":  "p. ej. nomina-2026",
+		"wallet_batch_tag_required":     "Escriba la referencia a añadir",
+		"wallet_batch_tag_comma":        "Una referencia no puede contener comas",
+		"wallet_batch_balances_desc":    "Los saldos en caché se descartan y se leen de nuevo en %d redes.",
+		"wallet_batch_form_help":        "enter: iniciar • esc: volver a la lista",
+		"wallet_batch_delete_help":      "tab: copiar sí/no • enter: eliminar • esc: volver a la lista",
+		"wallet_batch_running_delete":   "Eliminando %d carteras…",
+		"wallet_batch_running_tag":      "Etiquetando %d carteras…",
+		"wallet_batch_running_balances": "Actualizando los saldos de %d carteras…",
+		"wallet_batch_running_help":     "esc: detener tras la cartera actual",
+		"wallet_batch_cancelling":       "Deteniendo tras la cartera actual…",
+		"wallet_batch_done":             "Completado",
+		"wallet_batch_done_errors":      "Completado con errores",
+		"wallet_batch_summary":          "%d con éxito, %d con error, %d sin procesar",
+		"wallet_batch_done_help":        "enter: volver a la lista",
+		"wallet_batch_deleted":          "eliminada",
+		"wallet_batch_archived":         "eliminada, keystore copiado a %s",
+		"wallet_batch_tagged":           "etiquetada %s",
+		"wallet_batch_already_tagged":   "ya etiquetada %s",
+		"wallet_batch_no_funds":         "sin saldo",
+		"wallet_batch_networks_failed":  "%d redes no respondieron",
+		"wallet_batch_all_failed":       "ninguna red respondió",
+		"wallet_batch_not_processed":    "sin procesar",
+		"job_kind_wallet_batch":         "Lote de carteras",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
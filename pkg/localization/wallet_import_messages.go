@@ -21,6 +21,7 @@ func AddWalletImportMessages() {
 		"keystore_import_stage_parsing":    "Parsing keystore structure...",
 		"keystore_import_stage_decrypting": "Decrypting private key...",
 		"keystore_import_stage_saving":     "Saving wallet...",
+		"paste_multiline_rejected":         "Multi-line paste rejected: paste the password on a single line",
 	}
 
 	// Portuguese messages
@@ -40,6 +41,7 @@ func AddWalletImportMessages() {
 		"keystore_import_stage_parsing":    "Analisando estrutura do keystore...",
 		"keystore_import_stage_decrypting": "Descriptografando chave privada...",
 		"keystore_import_stage_saving":     "Salvando carteira...",
+		"paste_multiline_rejected":         "Colagem com várias linhas rejeitada: cole a senha em uma única linha",
 	}
 
 	// Spanish messages (optional for consistency)
@@ -59,6 +61,7 @@ func AddWalletImportMessages() {
 		"keystore_import_stage_parsing":    "Analizando estructura del keystore...",
 		"keystore_import_stage_decrypting": "Descifrando clave privada...",
 		"keystore_import_stage_saving":     "Guardando cartera...",
+		"paste_multiline_rejected":         "Pegado de varias líneas rechazado: pegue la contraseña en una sola línea",
 	}
 
 	// Ensure the Labels map is initialized
@@ -0,0 +1,179 @@
+package localization
+
+// AddWalletConnectMessages adds the messages of the WalletConnect screen
+func AddWalletConnectMessages() {
+	// English messages
+	english := map[string]string{
+		"walletconnect":           "WalletConnect",
+		"walletconnect_desc":      "Connect to dapps and sign their requests",
+		"wc_title":                "WalletConnect",
+		"wc_no_project_id":        "WalletConnect needs a project ID: set walletconnect.project_id in config.toml.",
+		"wc_uri_prompt":           "Paste the dapp's wc: URI",
+		"wc_no_sessions":          "No open sessions.",
+		"wc_sessions":             "Open sessions",
+		"wc_session_unconfirmed":  "(awaiting the dapp)",
+		"wc_pairing":              "Connecting to the dapp…",
+		"wc_paired":               "Connected, waiting for the dapp's session proposal…",
+		"wc_pair_failed":          "Pairing failed: %v",
+		"wc_proposal":             "%s asks to connect",
+		"wc_dapp_url":             "URL: %s",
+		"wc_chains":               "Chains: %s",
+		"wc_missing_chains":       "No active network serves chains %v",
+		"wc_no_chains":            "The dapp asks for no chain this wallet serves",
+		"wc_no_wallets":           "No wallet with a key to connect",
+		"wc_wallet":               "Wallet: %s (%s) [%d/%d]",
+		"wc_approving":            "Opening the session…",
+		"wc_approve_failed":       "Could not answer %s: %v",
+		"wc_proposal_rejected":    "Rejected %s",
+		"wc_session_opened":       "Session with %s opened for %s",
+		"wc_session_settled":      "%s confirmed the session",
+		"wc_session_deleted":      "%s closed the session",
+		"wc_disconnected":         "Disconnected from %s",
+		"wc_disconnect_failed":    "Disconnected from %s, but the dapp was not told: %v",
+		"wc_relay_error":          "WalletConnect relay: %v",
+		"wc_reconnected":          "Reconnected to the WalletConnect relay",
+		"wc_request":              "%s requests %s",
+		"wc_request_account":      "Account: %s on %s",
+		"wc_more_requests":        "%d more waiting",
+		"wc_request_invalid":      "This request cannot be served: %v",
+		"wc_password_prompt":      "Enter the wallet's password to approve:",
+		"wc_password_placeholder": "Wallet password",
+		"wc_unlock_failed":        "Could not unlock the wallet: %v",
+		"wc_wallet_missing":       "Wallet %s is no longer in the list",
+		"wc_responding":           "Signing and answering the dapp…",
+		"wc_request_signed":       "Signed and sent to the dapp",
+		"wc_request_rejected":     "Request rejected",
+		"wc_request_failed":       "Request failed: %v",
+		"wc_reply_failed":         "Done, but the dapp could not be told: %v",
+		"wc_tx_sent":              "Transaction %s sent on %s",
+		"wc_help":                 "enter: pair • ↑/↓: select session • ctrl+d: disconnect • esc: back",
+		"wc_help_back":            "esc: back",
+		"wc_help_proposal":        "←/→: wallet • enter: approve • x: reject • esc: back",
+		"wc_help_request":         "enter: approve • x: reject • esc: back",
+		"wc_help_password":        "enter: unlock and sign • esc: cancel",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"walletconnect":           "WalletConnect",
+		"walletconnect_desc":      "Conectar a dapps e assinar seus pedidos",
+		"wc_title":                "WalletConnect",
+		"wc_no_project_id":        "O WalletConnect precisa de um project ID: defina walletconnect.project_id no config.toml.",
+		"wc_uri_prompt":           "Cole a URI wc: do dapp",
+		"wc_no_sessions":          "Nenhuma sessão aberta.",
+		"wc_sessions":             "Sessões abertas",
+		"wc_session_unconfirmed":  "(aguardando o dapp)",
+		"wc_pairing":              "Conectando ao dapp…",
+		"wc_paired":               "Conectado, aguardando a proposta de sessão do dapp…",
+		"wc_pair_failed":          "Falha no pareamento: %v",
+		"wc_proposal":             "%s pede para conectar",
+		"wc_dapp_url":             "URL: %s",
+		"wc_chains":               "Redes: %s",
+		"wc_missing_chains":       "Nenhuma rede ativa atende as chains %v",
+		"wc_no_chains":            "O dapp não pede nenhuma rede atendida por esta carteira",
+		"wc_no_wallets":           "Nenhuma carteira com chave para conectar",
+		"wc_wallet":               "Carteira: %s (%s) [%d/%d]",
+		"wc_approving":            "Abrindo a sessão…",
+		"wc_approve_failed":       "Não foi possível responder a %s: %v",
+		"wc_proposal_rejected":    "%s rejeitado",
+		"wc_session_opened":       "Sessão com %s aberta para %s",
+		"wc_session_settled":      "%s confirmou a sessão",
+		"wc_session_deleted":      "%s encerrou a sessão",
+		"wc_disconnected":         "Desconectado de %s",
+		"wc_disconnect_failed":    "Desconectado de %s, mas o dapp não foi avisado: %v",
+		"wc_relay_error":          "Relay WalletConnect: %v",
+		"wc_reconnected":          "Reconectado ao relay WalletConnect",
+		"wc_request":              "%s pede %s",
+		"wc_request_account":      "Conta: %s em %s",
+		"wc_more_requests":        "Mais %d aguardando",
+		"wc_request_invalid":      "Este pedido não pode ser atendido: %v",
+		"wc_password_prompt":      "Digite a senha da carteira para aprovar:",
+		"wc_password_placeholder": "Senha da carteira",
+		"wc_unlock_failed":        "Não foi possível desbloquear a carteira: %v",
+		"wc_wallet_missing":       "A carteira %s não está mais na lista",
+		"wc_responding":           "Assinando e respondendo ao dapp…",
+		"wc_request_signed":       "Assinado e enviado ao dapp",
+		"wc_request_rejected":     "Pedido rejeitado",
+		"wc_request_failed":       "O pedido falhou: %v",
+		"wc_reply_failed":         "Concluído, mas o dapp não pôde ser avisado: %v",
+		"wc_tx_sent":              "Transação %s enviada em %s",
+		"wc_help":                 "enter: parear • ↑/↓: selecionar sessão • ctrl+d: desconectar • esc: voltar",
+		"wc_help_back":            "esc: voltar",
+		"wc_help_proposal":        "←/→: carteira • enter: aprovar • x: rejeitar • esc: voltar",
+		"wc_help_request":         "enter: aprovar • x: rejeitar • esc: voltar",
+		"wc_help_password":        "enter: desbloquear e assinar • esc: cancelar",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"walletconnect":           "WalletConnect",
+		"walletconnect_desc":      "Conectar con dapps y firmar sus solicitudes",
+		"wc_title":                "WalletConnect",
+		"wc_no_project_id":        "WalletConnect necesita un project ID: defina walletconnect.project_id en config.toml.",
+		"wc_uri_prompt":           "Pegue la URI wc: del dapp",
+		"wc_no_sessions":          "No hay sesiones abiertas.",
+		"wc_sessions":             "Sesiones abiertas",
+		"wc_session_unconfirmed":  "(esperando al dapp)",
+		"wc_pairing":              "Conectando con el dapp…",
+		"wc_paired":               "Conectado, esperando la propuesta de sesión del dapp…",
+		"wc_pair_failed":          "Falló el emparejamiento: %v",
+		"wc_proposal":             "%s pide conectarse",
+		"wc_dapp_url":             "URL: %s",
+		"wc_chains":               "Redes: %s",
+		"wc_missing_chains":       "Ninguna red activa sirve las chains %v",
+		"wc_no_chains":            "El dapp no pide ninguna red servida por esta cartera",
+		"wc_no_wallets":           "Ninguna cartera con clave para conectar",
+		"wc_wallet":               "Cartera: %s (%s) [%d/%d]",
+		"wc_approving":            "Abriendo la sesión…",
+		"wc_approve_failed":       "No se pudo responder a %s: %v",
+		"wc_proposal_rejected":    "%s rechazado",
+		"wc_session_opened":       "Sesión con %s abierta para %s",
+		"wc_session_settled":      "%s confirmó la sesión",
+		"wc_session_deleted":      "%s cerró la sesión",
+		"wc_disconnected":         "Desconectado de %s",
+		"wc_disconnect_failed":    "Desconectado de %s, pero el dapp no fue avisado: %v",
+		"wc_relay_error":          "Relay WalletConnect: %v",
+		"wc_reconnected":          "Reconectado al relay WalletConnect",
+		"wc_request":              "%s solicita %s",
+		"wc_request_account":      "Cuenta: %s en %s",
+		"wc_more_requests":        "%d más esperando",
+		"wc_request_invalid":      "Esta solicitud no se puede atender: %v",
+		"wc_password_prompt":      "Ingrese la contraseña de la cartera para aprobar:",
+		"wc_password_placeholder": "Contraseña de la cartera",
+		"wc_unlock_failed":        "No se pudo desbloquear la cartera: %v",
+		"wc_wallet_missing":       "La cartera %s ya no está en la lista",
+		"wc_responding":           "Firmando y respondiendo al dapp…",
+		"wc_request_signed":       "Firmado y enviado al dapp",
+		"wc_request_rejected":     "Solicitud rechazada",
+		"wc_request_failed":       "La solicitud falló: %v",
+		"wc_reply_failed":         "Hecho, pero no se pudo avisar al dapp: %v",
+		"wc_tx_sent":              "Transacción %s enviada en %s",
+		"wc_help":                 "enter: emparejar • ↑/↓: seleccionar sesión • ctrl+d: desconectar • esc: volver",
+		"wc_help_back":            "esc: volver",
+		"wc_help_proposal":        "←/→: cartera • enter: aprobar • x: rechazar • esc: volver",
+		"wc_help_request":         "enter: aprobar • x: rechazar • esc: volver",
+		"wc_help_password":        "enter: desbloquear y firmar • esc: cancelar",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
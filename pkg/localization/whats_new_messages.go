@@ -0,0 +1,54 @@
+package localization
+
+// AddWhatsNewMessages adds the messages of the "What's new" screen shown
+// after an upgrade to the Labels map
+func AddWhatsNewMessages() {
+	// English messages
+	english := map[string]string{
+		"whats_new_title":         "What's new",
+		"whats_new_version":       "Version %s",
+		"whats_new_breaking":      "Breaking changes:",
+		"whats_new_release_notes": "Full release notes: %s",
+		"whats_new_help":          "enter/esc: continue to the main menu",
+	}
+
+	// Portuguese messages
+	portuguese := map[string]string{
+		"whats_new_title":         "Novidades",
+		"whats_new_version":       "Versão %s",
+		"whats_new_breaking":      "Mudanças incompatíveis:",
+		"whats_new_release_notes": "Notas completas da versão: %s",
+		"whats_new_help":          "enter/esc: continuar para o menu principal",
+	}
+
+	// Spanish messages
+	spanish := map[string]string{
+		"whats_new_title":         "Novedades",
+		"whats_new_version":       "Versión %s",
+		"whats_new_breaking":      "Cambios incompatibles:",
+		"whats_new_release_notes": "Notas completas de la versión: %s",
+		"whats_new_help":          "enter/esc: continuar al menú principal",
+	}
+
+	// Ensure the Labels map is initialized
+	if Labels == nil {
+		Labels = make(map[string]string)
+	}
+
+	// Always add English defaults first
+	for k, v := range english {
+		Labels[k] = v
+	}
+
+	// Override with the current language when applicable
+	switch GetCurrentLanguage() {
+	case "pt":
+		for k, v := range portuguese {
+			Labels[k] = v
+		}
+	case "es":
+		for k, v := range spanish {
+			Labels[k] = v
+		}
+	}
+}
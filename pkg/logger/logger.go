@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"io"
 	"os"
 	"path/filepath"
 	"time"
@@ -17,6 +18,8 @@ type Logger interface {
 	Debug(msg string, fields ...zap.Field)
 	Warn(msg string, fields ...zap.Field)
 	Sync() error
+	// Close flushes the entries and releases the log files
+	Close() error
 }
 
 // LoggingConfig represents logging configuration
@@ -32,6 +35,7 @@ type LoggingConfig struct {
 // zapLogger implements the Logger interface using Uber Zap
 type zapLogger struct {
 	logger *zap.Logger
+	files  []io.Closer
 }
 
 // NewLogger initializes a new stdout/stderr logger based on the provided log level.
@@ -76,8 +80,8 @@ func NewFileLogger(c LoggingConfig) (Logger, error) {
 		return &zapLogger{logger: zap.NewNop()}, nil
 	}
 
-	appPath := filepath.Join(c.LogDir, "app.log")
-	errPath := filepath.Join(c.LogDir, "error.log")
+	appPath := filepath.Join(c.LogDir, AppLogFile)
+	errPath := filepath.Join(c.LogDir, ErrorLogFile)
 
 	// Ensure log files exist so tests and tools can rely on their presence even if empty
 	if f, err := os.OpenFile(appPath, os.O_CREATE|os.O_APPEND, 0600); err == nil {
@@ -87,20 +91,22 @@ func NewFileLogger(c LoggingConfig) (Logger, error) {
 		_ = f.Close()
 	}
 
-	appWriter := zapcore.AddSync(&lumberjack.Logger{
+	appFile := &lumberjack.Logger{
 		Filename:   appPath,
 		MaxSize:    maxInt(c.MaxFileSize, 25), // default 25 MB
 		MaxBackups: maxInt(c.MaxBackups, 3),
 		MaxAge:     maxInt(c.MaxAge, 14), // days
 		Compress:   false,
-	})
-	errWriter := zapcore.AddSync(&lumberjack.Logger{
+	}
+	errFile := &lumberjack.Logger{
 		Filename:   errPath,
 		MaxSize:    maxInt(c.MaxFileSize, 25),
 		MaxBackups: maxInt(c.MaxBackups, 3),
 		MaxAge:     maxInt(c.MaxAge, 14),
 		Compress:   false,
-	})
+	}
+	appWriter := zapcore.AddSync(appFile)
+	errWriter := zapcore.AddSync(errFile)
 
 	encCfg := zap.NewProductionEncoderConfig()
 	encCfg.EncodeTime = func(t time.Time, pae zapcore.PrimitiveArrayEncoder) {
@@ -128,7 +134,7 @@ func NewFileLogger(c LoggingConfig) (Logger, error) {
 
 	core := zapcore.NewTee(appCore, errCore)
 	z := zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
-	return &zapLogger{logger: z}, nil
+	return &zapLogger{logger: z, files: []io.Closer{appFile, errFile}}, nil
 }
 
 // levelRange enables a range of levels
@@ -171,6 +177,22 @@ func (z *zapLogger) Sync() error {
 	return nil
 }
 
+// Close flushes the entries and closes the log files, e.g. those of a profile
+// left for another one
+func (z *zapLogger) Close() error {
+	if z == nil || z.logger == nil {
+		return nil
+	}
+	_ = z.Sync()
+	var firstErr error
+	for _, f := range z.files {
+		if err := f.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
 // Helper functions for creating fields
 func Error(err error) zap.Field {
 	return zap.Error(err)
@@ -0,0 +1,139 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Names of the log files NewFileLogger writes in its directory
+const (
+	AppLogFile   = "app.log"
+	ErrorLogFile = "error.log"
+)
+
+// tailBytes is how much of the end of each log file ReadTail reads
+const tailBytes = 512 * 1024
+
+// Entry is a line of the log files
+type Entry struct {
+	Time    time.Time
+	Level   string // debug, info, warn, error, dpanic, panic or fatal; "" for lines that are not JSON
+	Message string
+	Caller  string
+	Fields  string // The other fields, as JSON; "" when there are none
+	Raw     string // The line as written
+}
+
+// LevelRank orders the levels of entries for filtering: debug 0, info 1,
+// warn 2 and error or worse 3. Lines without a level rank as info.
+func LevelRank(level string) int {
+	switch level {
+	case "debug":
+		return 0
+	case "warn":
+		return 2
+	case "error", "dpanic", "panic", "fatal":
+		return 3
+	default:
+		return 1
+	}
+}
+
+// ReadTail returns the last entries of app.log and error.log in dir merged in
+// time order, oldest first and at most limit of them. Missing files are
+// skipped, as the logger may not have written anything yet.
+func ReadTail(dir string, limit int) ([]Entry, error) {
+	var entries []Entry
+	for _, name := range []string{AppLogFile, ErrorLogFile} {
+		lines, err := tailLines(filepath.Join(dir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, line := range lines {
+			entries = append(entries, parseEntry(line))
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries, nil
+}
+
+// tailLines reads the complete lines of the last tailBytes of path
+func tailLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	offset := max(info.Size()-tailBytes, 0)
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		// The first line was cut by the offset
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data = data[i+1:]
+		}
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), tailBytes)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// parseEntry reads a JSON line written by the zap encoder of NewFileLogger
+func parseEntry(line string) Entry {
+	entry := Entry{Message: line, Raw: line}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		return entry
+	}
+
+	if level, ok := fields["level"].(string); ok {
+		entry.Level = level
+	}
+	if msg, ok := fields["msg"].(string); ok {
+		entry.Message = msg
+	}
+	if caller, ok := fields["caller"].(string); ok {
+		entry.Caller = caller
+	}
+	if ts, ok := fields["ts"].(string); ok {
+		entry.Time, _ = time.Parse(time.RFC3339, ts)
+	}
+	for _, key := range []string{"level", "msg", "caller", "ts", "stacktrace"} {
+		delete(fields, key)
+	}
+	if len(fields) > 0 {
+		if data, err := json.Marshal(fields); err == nil {
+			entry.Fields = string(data)
+		}
+	}
+	return entry
+}
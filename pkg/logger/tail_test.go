@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadTail_MergesFilesInTimeOrder(t *testing.T) {
+	tmp := t.TempDir()
+	l, err := NewFileLogger(LoggingConfig{LogDir: tmp, LogLevel: "debug"})
+	if err != nil {
+		t.Fatalf("NewFileLogger error: %v", err)
+	}
+	l.Debug("first", String("k", "v"))
+	l.Error("second")
+	l.Warn("third")
+	_ = l.Sync()
+
+	entries, err := ReadTail(tmp, 0)
+	if err != nil {
+		t.Fatalf("ReadTail error: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	levels := map[string]string{}
+	for _, e := range entries {
+		levels[e.Message] = e.Level
+		if e.Time.IsZero() || e.Caller == "" {
+			t.Fatalf("entry without time or caller: %+v", e)
+		}
+	}
+	if levels["first"] != "debug" || levels["second"] != "error" || levels["third"] != "warn" {
+		t.Fatalf("unexpected levels: %v", levels)
+	}
+	if entries[0].Message != "first" || entries[0].Fields != `{"k":"v"}` {
+		t.Fatalf("unexpected first entry: %+v", entries[0])
+	}
+
+	entries, err = ReadTail(tmp, 1)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected the last entry only, got %d (%v)", len(entries), err)
+	}
+}
+
+func TestReadTail_LinesThatAreNotJSON(t *testing.T) {
+	tmp := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmp, AppLogFile), []byte("panic: boom\n\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// error.log is missing
+	entries, err := ReadTail(tmp, 10)
+	if err != nil {
+		t.Fatalf("ReadTail error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Message != "panic: boom" || entries[0].Level != "" {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+	if LevelRank(entries[0].Level) != LevelRank("info") {
+		t.Fatal("lines without a level rank as info")
+	}
+}
+
+func TestReadTail_LargeFileKeepsWholeLines(t *testing.T) {
+	tmp := t.TempDir()
+	line := `{"level":"info","ts":"2025-01-01T00:00:00Z","msg":"` + strings.Repeat("x", 1000) + `"}` + "\n"
+	data := strings.Repeat(line, tailBytes/len(line)+10)
+	if err := os.WriteFile(filepath.Join(tmp, AppLogFile), []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ReadTail(tmp, 0)
+	if err != nil {
+		t.Fatalf("ReadTail error: %v", err)
+	}
+	for _, e := range entries {
+		if e.Level != "info" {
+			t.Fatalf("a cut line was read: %.40q", e.Raw)
+		}
+	}
+}